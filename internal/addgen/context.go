@@ -64,10 +64,11 @@ func modulePackageSegment(module string) string {
 //
 //	SQLDatastore/src/main/java/com/example/demo/sqldatastore/repository
 //
-// Module directory is PascalCase, package segment is lowercase. The
-// subpackage may be empty (returns just the module Java root).
+// Module directory is PascalCase (App in a modulith), package segment
+// is lowercase. The subpackage may be empty (returns just the module
+// Java root).
 func (c *Context) JavaSrcMain(module, subpackage string) string {
-	parts := []string{module, "src", "main", "java", c.PackagePath(), modulePackageSegment(module)}
+	parts := []string{c.ModuleDir(module), "src", "main", "java", c.PackagePath(), modulePackageSegment(module)}
 	if subpackage != "" {
 		parts = append(parts, subpackage)
 	}
@@ -78,7 +79,7 @@ func (c *Context) JavaSrcMain(module, subpackage string) string {
 // for a module + subpackage. Same shape as JavaSrcMain but under
 // src/test/java.
 func (c *Context) JavaSrcTest(module, subpackage string) string {
-	parts := []string{module, "src", "test", "java", c.PackagePath(), modulePackageSegment(module)}
+	parts := []string{c.ModuleDir(module), "src", "test", "java", c.PackagePath(), modulePackageSegment(module)}
 	if subpackage != "" {
 		parts = append(parts, subpackage)
 	}
@@ -88,7 +89,7 @@ func (c *Context) JavaSrcTest(module, subpackage string) string {
 // ResourcesMain returns the relative path under src/main/resources
 // for a module. Used for application.yml, db/migration/, etc.
 func (c *Context) ResourcesMain(module, subdir string) string {
-	parts := []string{module, "src", "main", "resources"}
+	parts := []string{c.ModuleDir(module), "src", "main", "resources"}
 	if subdir != "" {
 		parts = append(parts, subdir)
 	}
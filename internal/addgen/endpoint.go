@@ -14,6 +14,15 @@ type EndpointOpts struct {
 	Name string // PascalCase resource name; final controller is {Name}Controller
 	Type string // "plain" (default) or "crud" — crud emits 5 method stubs
 	Path string // override the auto-derived URL path (default: /api/{plural-lower-snake})
+
+	// Methods, set by `trabuco generate endpoint`, makes this a resource
+	// endpoint for those HTTP methods instead of a Type skeleton: request
+	// and response DTOs, a controller delegating to a Shared service, the
+	// service stubs and a MockMvc test.
+	Methods []string
+	// Fields is the ParseFields spec of a resource endpoint's DTOs;
+	// defaults to "name:string".
+	Fields string
 }
 
 const (
@@ -50,6 +59,9 @@ func GenerateEndpoint(ctx *Context, opts EndpointOpts) (*Result, error) {
 	if !strings.HasPrefix(path, "/") {
 		path = "/" + path
 	}
+	if len(opts.Methods) > 0 {
+		return generateResourceEndpoint(ctx, name, path, opts)
+	}
 
 	rel := filepath.Join(ctx.JavaSrcMain(config.ModuleAPI, "controller"), name+"Controller.java")
 	result := &Result{}
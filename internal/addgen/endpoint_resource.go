@@ -0,0 +1,667 @@
+package addgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/utils"
+)
+
+// Resource endpoint methods accepted in EndpointOpts.Methods. GET maps to a
+// list and a get-by-id operation, the others to one operation each.
+var resourceMethods = []string{"GET", "POST", "PUT", "DELETE"}
+
+// generateResourceEndpoint emits the files of a resource endpoint: the
+// request and response DTOs in Model, the controller and its MockMvc test
+// in API and, unless it already exists, the Shared service it delegates to.
+// An existing service is left alone; the caller adds the stubs from
+// ResourceServiceMethods to it.
+func generateResourceEndpoint(ctx *Context, name, path string, opts EndpointOpts) (*Result, error) {
+	for _, module := range []string{config.ModuleModel, config.ModuleShared} {
+		if !ctx.HasModule(module) {
+			return nil, fmt.Errorf("project does not have the %s module — the endpoint's DTOs and service live there", module)
+		}
+	}
+	methods, err := normalizeMethods(opts.Methods)
+	if err != nil {
+		return nil, err
+	}
+	spec := opts.Fields
+	if strings.TrimSpace(spec) == "" {
+		spec = "name:string"
+	}
+	fields, err := ParseFields(spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		if f.Name == "id" {
+			return nil, fmt.Errorf("field \"id\" is reserved: the response carries the resource ID")
+		}
+	}
+
+	result := &Result{}
+	emit := func(rel, content string) error {
+		return ctx.emitFile(rel, content, result)
+	}
+	dtoDir := ctx.JavaSrcMain(config.ModuleModel, "dto")
+	if hasWrite(methods) {
+		if err := emit(filepath.Join(dtoDir, name+"Request.java"), renderResourceRequest(ctx, name, fields)); err != nil {
+			return nil, err
+		}
+	}
+	if err := emit(filepath.Join(dtoDir, name+"Response.java"), renderResourceResponse(ctx, name, fields)); err != nil {
+		return nil, err
+	}
+	if err := emit(filepath.Join(ctx.JavaSrcMain(config.ModuleAPI, "controller"), name+"Controller.java"), renderResourceController(ctx, name, path, methods)); err != nil {
+		return nil, err
+	}
+	if err := emit(filepath.Join(ctx.JavaSrcTest(config.ModuleAPI, "controller"), name+"ControllerTest.java"), renderResourceControllerTest(ctx, name, path, methods, fields)); err != nil {
+		return nil, err
+	}
+	serviceRel := filepath.Join(ctx.JavaSrcMain(config.ModuleShared, "service"), name+"Service.java")
+	if _, err := os.Stat(filepath.Join(ctx.ProjectPath, serviceRel)); os.IsNotExist(err) {
+		if err := emit(serviceRel, renderResourceService(ctx, name, methods)); err != nil {
+			return nil, err
+		}
+	}
+	seenEnums := map[string]bool{}
+	for _, f := range fields {
+		if f.Type == FTEnum && !seenEnums[f.EnumName] {
+			seenEnums[f.EnumName] = true
+			if err := ctx.emitEnumIfMissing(f.EnumName, result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result.NextSteps = []string{
+		fmt.Sprintf("Implement the UnsupportedOperationException stubs in %s; until then the endpoint answers 500.", serviceRel),
+		fmt.Sprintf("Rename SCOPE_%s:* in the @PreAuthorize annotations if your IdP issues other scopes.", scopeNoun(name)),
+		fmt.Sprintf("Run ./mvnw test -pl API -am to compile the endpoint and run %sControllerTest.", name),
+	}
+	return result, nil
+}
+
+// normalizeMethods upper-cases and dedupes methods, keeping the order of
+// resourceMethods, and rejects the ones a resource endpoint does not map.
+func normalizeMethods(methods []string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, m := range methods {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if m == "" {
+			continue
+		}
+		if !slices.Contains(resourceMethods, m) {
+			return nil, fmt.Errorf("unsupported method %q (supported: %s)", m, strings.Join(resourceMethods, ", "))
+		}
+		seen[m] = true
+	}
+	var out []string
+	for _, m := range resourceMethods {
+		if seen[m] {
+			out = append(out, m)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("at least one method is required (supported: %s)", strings.Join(resourceMethods, ", "))
+	}
+	return out, nil
+}
+
+// pluralWords is the plural of name for prose, e.g. "order items".
+func pluralWords(name string) string {
+	return strings.ReplaceAll(utils.PluralLowerSnake(name), "_", " ")
+}
+
+// nounWords is name in words, e.g. "order item".
+func nounWords(name string) string {
+	return strings.ReplaceAll(utils.ToSnakeCase(name), "_", " ")
+}
+
+// withArticle is nounWords with its indefinite article, e.g.
+// "an order item".
+func withArticle(name string) string {
+	words := nounWords(name)
+	if strings.ContainsRune("aeiou", rune(words[0])) {
+		return "an " + words
+	}
+	return "a " + words
+}
+
+// hasWrite reports whether the methods take a request body.
+func hasWrite(methods []string) bool {
+	return slices.Contains(methods, "POST") || slices.Contains(methods, "PUT")
+}
+
+// scopeNoun is the resource noun of the endpoint's OAuth scopes, e.g.
+// "order-item" for SCOPE_order-item:read.
+func scopeNoun(name string) string {
+	return strings.ReplaceAll(utils.ToSnakeCase(name), "_", "-")
+}
+
+// ResourceServiceMethods renders the Shared service stubs a resource
+// endpoint calls for methods, and the imports they need. The stubs throw
+// UnsupportedOperationException until they are implemented.
+func ResourceServiceMethods(ctx *Context, name string, methods []string) (string, []string) {
+	dtoPkg := ctx.JavaPackage(config.ModuleModel, "dto")
+	response := "Immutable" + name + "Response"
+	request := "Immutable" + name + "Request"
+	imports := []string{dtoPkg + "." + response}
+	if hasWrite(methods) {
+		imports = append(imports, dtoPkg+"."+request)
+	}
+
+	var b strings.Builder
+	stub := func(doc, signature, method string) {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "  /** %s */\n", doc)
+		fmt.Fprintf(&b, "  public %s {\n", signature)
+		fmt.Fprintf(&b, "    throw new UnsupportedOperationException(\"%sService.%s not implemented\");\n", name, method)
+		b.WriteString("  }\n")
+	}
+	if slices.Contains(methods, "GET") {
+		imports = append(imports, "java.util.List", "java.util.Optional")
+		stub(fmt.Sprintf("List %s. Page the query before the table grows.", pluralWords(name)),
+			fmt.Sprintf("List<%s> list()", response), "list")
+		stub(fmt.Sprintf("Get %s by ID, or empty when there is none.", withArticle(name)),
+			fmt.Sprintf("Optional<%s> findById(String id)", response), "findById")
+	}
+	if slices.Contains(methods, "POST") {
+		stub(fmt.Sprintf("Create %s.", withArticle(name)),
+			fmt.Sprintf("%s create(%s request)", response, request), "create")
+	}
+	if slices.Contains(methods, "PUT") {
+		imports = append(imports, "java.util.Optional")
+		stub(fmt.Sprintf("Replace %s, or return empty when there is none.", withArticle(name)),
+			fmt.Sprintf("Optional<%s> update(String id, %s request)", response, request), "update")
+	}
+	if slices.Contains(methods, "DELETE") {
+		stub(fmt.Sprintf("Delete %s; false when there is none.", withArticle(name)),
+			"boolean delete(String id)", "delete")
+	}
+	return b.String(), uniqueImports(nil, imports...)
+}
+
+func renderResourceService(ctx *Context, name string, methods []string) string {
+	body, imports := ResourceServiceMethods(ctx, name, methods)
+	imports = uniqueImports(nil, append(imports, "org.springframework.stereotype.Service")...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s;\n\n", ctx.JavaPackage(config.ModuleShared, "service"))
+	for _, imp := range imports {
+		fmt.Fprintf(&b, "import %s;\n", imp)
+	}
+	b.WriteString("\n")
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * %s operations behind %sController. Generated by `trabuco generate endpoint`.\n", name, name)
+	b.WriteString(" *\n")
+	b.WriteString(" * <p>Inject repositories through the constructor and replace each stub.\n")
+	b.WriteString(" * Report a missing record with an empty result; the controller turns it\n")
+	b.WriteString(" * into a 404.\n")
+	b.WriteString(" */\n")
+	b.WriteString("@Service\n")
+	fmt.Fprintf(&b, "public class %sService {\n\n", name)
+	fmt.Fprintf(&b, "  public %sService() {\n", name)
+	b.WriteString("    // Inject dependencies via constructor when you add them.\n")
+	b.WriteString("  }\n\n")
+	b.WriteString(body)
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// renderResourceRequest renders the request DTO in the style of
+// PlaceholderRequest.
+func renderResourceRequest(ctx *Context, name string, fields []Field) string {
+	extras := append(enumImports(ctx, fields), dtoImports(ctx)...)
+	for _, f := range fields {
+		switch {
+		case f.Nullable:
+			extras = append(extras, "jakarta.annotation.Nullable")
+		case f.Type == FTString || f.Type == FTText:
+			extras = append(extras, "jakarta.validation.constraints.NotBlank")
+		default:
+			extras = append(extras, "jakarta.validation.constraints.NotNull")
+		}
+		if f.Type == FTString {
+			extras = append(extras, "jakarta.validation.constraints.Size")
+		}
+	}
+
+	var b strings.Builder
+	writeDTOHeader(&b, ctx, uniqueImports(fields, extras...))
+	fmt.Fprintf(&b, " * Request DTO for creating or replacing %s. Generated by `trabuco generate endpoint`.\n", withArticle(name))
+	writeDTOType(&b, name+"Request")
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch {
+		case f.Nullable:
+			b.WriteString("  @Nullable\n")
+		case f.Type == FTString || f.Type == FTText:
+			fmt.Fprintf(&b, "  @NotBlank(message = \"%s is required\")\n", f.Name)
+		default:
+			fmt.Fprintf(&b, "  @NotNull(message = \"%s is required\")\n", f.Name)
+		}
+		if f.Type == FTString {
+			fmt.Fprintf(&b, "  @Size(max = 255, message = \"%s must not exceed 255 characters\")\n", f.Name)
+		}
+		fmt.Fprintf(&b, "  %s %s();\n", f.JavaType(), f.Name)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// renderResourceResponse renders the response DTO: a string ID, as in
+// PlaceholderResponse, followed by the fields.
+func renderResourceResponse(ctx *Context, name string, fields []Field) string {
+	extras := append(enumImports(ctx, fields), dtoImports(ctx)...)
+	for _, f := range fields {
+		if f.Nullable {
+			extras = append(extras, "jakarta.annotation.Nullable")
+		}
+	}
+
+	var b strings.Builder
+	writeDTOHeader(&b, ctx, uniqueImports(fields, extras...))
+	fmt.Fprintf(&b, " * Response DTO for %s data. Generated by `trabuco generate endpoint`.\n", name)
+	writeDTOType(&b, name+"Response")
+	b.WriteString("  /** Identifier as a string, whatever the datastore's key type. */\n")
+	b.WriteString("  String id();\n")
+	for _, f := range fields {
+		b.WriteString("\n")
+		if f.Nullable {
+			b.WriteString("  @Nullable\n")
+		}
+		fmt.Fprintf(&b, "  %s %s();\n", f.JavaType(), f.Name)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dtoImports(ctx *Context) []string {
+	return []string{
+		"com.fasterxml.jackson.databind.annotation.JsonDeserialize",
+		"com.fasterxml.jackson.databind.annotation.JsonSerialize",
+		ctx.GroupID + ".model.ImmutableStyle",
+		"org.immutables.value.Value",
+	}
+}
+
+// writeDTOHeader writes the package, imports and the opening of the doc
+// comment; the caller writes the summary line.
+func writeDTOHeader(b *strings.Builder, ctx *Context, imports []string) {
+	fmt.Fprintf(b, "package %s;\n\n", ctx.JavaPackage(config.ModuleModel, "dto"))
+	for _, imp := range imports {
+		fmt.Fprintf(b, "import %s;\n", imp)
+	}
+	b.WriteString("\n/**\n")
+}
+
+// writeDTOType closes the doc comment and opens the Immutables interface.
+func writeDTOType(b *strings.Builder, typeName string) {
+	b.WriteString(" *\n")
+	fmt.Fprintf(b, " * <p>Always use Immutable%s.builder() to create instances.\n", typeName)
+	b.WriteString(" */\n")
+	b.WriteString("@Value.Immutable\n")
+	b.WriteString("@ImmutableStyle\n")
+	fmt.Fprintf(b, "@JsonSerialize(as = Immutable%s.class)\n", typeName)
+	fmt.Fprintf(b, "@JsonDeserialize(as = Immutable%s.class)\n", typeName)
+	fmt.Fprintf(b, "public interface %s {\n\n", typeName)
+}
+
+// renderResourceController renders a controller that delegates to the
+// Shared service and throws for errors, so GlobalExceptionHandler answers
+// every failure with a ProblemDetail.
+func renderResourceController(ctx *Context, name, path string, methods []string) string {
+	dtoPkg := ctx.JavaPackage(config.ModuleModel, "dto")
+	response := "Immutable" + name + "Response"
+	request := "Immutable" + name + "Request"
+	byID := slices.Contains(methods, "GET") || slices.Contains(methods, "PUT") || slices.Contains(methods, "DELETE")
+	imports := []string{
+		dtoPkg + "." + response,
+		ctx.JavaPackage(config.ModuleShared, "service") + "." + name + "Service",
+		"io.swagger.v3.oas.annotations.Operation",
+		"io.swagger.v3.oas.annotations.tags.Tag",
+		"org.springframework.security.access.prepost.PreAuthorize",
+		"org.springframework.web.bind.annotation.RequestMapping",
+		"org.springframework.web.bind.annotation.RestController",
+	}
+	if byID || hasWrite(methods) {
+		imports = append(imports,
+			"io.swagger.v3.oas.annotations.media.Content",
+			"io.swagger.v3.oas.annotations.media.Schema",
+			"io.swagger.v3.oas.annotations.responses.ApiResponse",
+			"org.springframework.http.ProblemDetail",
+		)
+	}
+	if byID {
+		imports = append(imports,
+			"org.springframework.http.HttpStatus",
+			"org.springframework.web.bind.annotation.PathVariable",
+			"org.springframework.web.server.ResponseStatusException",
+		)
+	}
+	if hasWrite(methods) {
+		imports = append(imports,
+			dtoPkg+"."+request,
+			"jakarta.validation.Valid",
+			"org.springframework.web.bind.annotation.RequestBody",
+		)
+	}
+	if slices.Contains(methods, "GET") {
+		imports = append(imports, "java.util.List", "org.springframework.web.bind.annotation.GetMapping")
+	}
+	if slices.Contains(methods, "POST") {
+		imports = append(imports,
+			"org.springframework.http.HttpStatus",
+			"org.springframework.web.bind.annotation.PostMapping",
+			"org.springframework.web.bind.annotation.ResponseStatus",
+		)
+	}
+	if slices.Contains(methods, "PUT") {
+		imports = append(imports, "org.springframework.web.bind.annotation.PutMapping")
+	}
+	if slices.Contains(methods, "DELETE") {
+		imports = append(imports,
+			"org.springframework.web.bind.annotation.DeleteMapping",
+			"org.springframework.web.bind.annotation.ResponseStatus",
+		)
+	}
+
+	noun := nounWords(name)
+	scope := scopeNoun(name)
+	problem := "\n      content = @Content(schema = @Schema(implementation = ProblemDetail.class))"
+	notFound := fmt.Sprintf("  @ApiResponse(responseCode = \"404\", description = \"No %s with this ID\",%s)\n", noun, problem)
+	invalid := fmt.Sprintf("  @ApiResponse(responseCode = \"400\", description = \"The request failed validation\",%s)\n", problem)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s;\n\n", ctx.JavaPackage(config.ModuleAPI, "controller"))
+	for _, imp := range uniqueImports(nil, imports...) {
+		fmt.Fprintf(&b, "import %s;\n", imp)
+	}
+	b.WriteString("\n")
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * REST controller for %s. Generated by `trabuco generate endpoint`.\n", pluralWords(name))
+	b.WriteString(" *\n")
+	fmt.Fprintf(&b, " * <p>Business logic lives in {@link %sService}. Failures are thrown, not\n", name)
+	b.WriteString(" * returned: GlobalExceptionHandler turns validation errors, unknown IDs and\n")
+	b.WriteString(" * datastore conflicts into RFC 7807 ProblemDetail responses.\n")
+	b.WriteString(" *\n")
+	fmt.Fprintf(&b, " * <p>The {@code SCOPE_%s:*} checks are enforced when\n", scope)
+	b.WriteString(" * {@code trabuco.auth.enabled=true}; PlaceholderController documents the\n")
+	b.WriteString(" * authorization model.\n")
+	b.WriteString(" */\n")
+	fmt.Fprintf(&b, "@Tag(name = %q)\n", name)
+	b.WriteString("@RestController\n")
+	fmt.Fprintf(&b, "@RequestMapping(%q)\n", path)
+	fmt.Fprintf(&b, "public class %sController {\n\n", name)
+	fmt.Fprintf(&b, "  private final %sService service;\n\n", name)
+	fmt.Fprintf(&b, "  public %sController(%sService service) {\n", name, name)
+	b.WriteString("    this.service = service;\n")
+	b.WriteString("  }\n")
+
+	if slices.Contains(methods, "GET") {
+		fmt.Fprintf(&b, "\n  @Operation(summary = \"List %s\")\n", pluralWords(name))
+		fmt.Fprintf(&b, "  @PreAuthorize(\"hasAuthority('SCOPE_%s:read')\")\n", scope)
+		b.WriteString("  @GetMapping\n")
+		fmt.Fprintf(&b, "  public List<%s> list() {\n", response)
+		b.WriteString("    return service.list();\n")
+		b.WriteString("  }\n")
+
+		fmt.Fprintf(&b, "\n  @Operation(summary = \"Get %s by ID\")\n", withArticle(name))
+		b.WriteString(notFound)
+		fmt.Fprintf(&b, "  @PreAuthorize(\"hasAuthority('SCOPE_%s:read')\")\n", scope)
+		b.WriteString("  @GetMapping(\"/{id}\")\n")
+		fmt.Fprintf(&b, "  public %s getById(@PathVariable String id) {\n", response)
+		b.WriteString("    return service.findById(id).orElseThrow(this::notFound);\n")
+		b.WriteString("  }\n")
+	}
+	if slices.Contains(methods, "POST") {
+		fmt.Fprintf(&b, "\n  @Operation(summary = \"Create %s\")\n", withArticle(name))
+		b.WriteString(invalid)
+		fmt.Fprintf(&b, "  @PreAuthorize(\"hasAuthority('SCOPE_%s:write')\")\n", scope)
+		b.WriteString("  @PostMapping\n")
+		b.WriteString("  @ResponseStatus(HttpStatus.CREATED)\n")
+		fmt.Fprintf(&b, "  public %s create(@Valid @RequestBody %s request) {\n", response, request)
+		b.WriteString("    return service.create(request);\n")
+		b.WriteString("  }\n")
+	}
+	if slices.Contains(methods, "PUT") {
+		fmt.Fprintf(&b, "\n  @Operation(summary = \"Replace %s\")\n", withArticle(name))
+		b.WriteString(invalid)
+		b.WriteString(notFound)
+		fmt.Fprintf(&b, "  @PreAuthorize(\"hasAuthority('SCOPE_%s:write')\")\n", scope)
+		b.WriteString("  @PutMapping(\"/{id}\")\n")
+		fmt.Fprintf(&b, "  public %s update(\n", response)
+		b.WriteString("      @PathVariable String id,\n")
+		fmt.Fprintf(&b, "      @Valid @RequestBody %s request) {\n", request)
+		b.WriteString("    return service.update(id, request).orElseThrow(this::notFound);\n")
+		b.WriteString("  }\n")
+	}
+	if slices.Contains(methods, "DELETE") {
+		fmt.Fprintf(&b, "\n  @Operation(summary = \"Delete %s\")\n", withArticle(name))
+		b.WriteString(notFound)
+		fmt.Fprintf(&b, "  @PreAuthorize(\"hasAuthority('SCOPE_%s:delete')\")\n", scope)
+		b.WriteString("  @DeleteMapping(\"/{id}\")\n")
+		b.WriteString("  @ResponseStatus(HttpStatus.NO_CONTENT)\n")
+		b.WriteString("  public void delete(@PathVariable String id) {\n")
+		b.WriteString("    if (!service.delete(id)) {\n")
+		b.WriteString("      throw notFound();\n")
+		b.WriteString("    }\n")
+		b.WriteString("  }\n")
+	}
+	if byID {
+		b.WriteString("\n")
+		b.WriteString("  // Rendered as a 404 ProblemDetail. The ID is not echoed back.\n")
+		b.WriteString("  private ResponseStatusException notFound() {\n")
+		fmt.Fprintf(&b, "    return new ResponseStatusException(HttpStatus.NOT_FOUND, \"%s not found\");\n", strings.ToUpper(noun[:1])+noun[1:])
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// renderResourceControllerTest renders a MockMvc test of the controller
+// with a mocked service. MockMvc is built standalone with
+// GlobalExceptionHandler as advice, so the error cases assert the real
+// ProblemDetail responses without starting the application context and
+// its datastores.
+func renderResourceControllerTest(ctx *Context, name, path string, methods []string, fields []Field) string {
+	dtoPkg := ctx.JavaPackage(config.ModuleModel, "dto")
+	response := "Immutable" + name + "Response"
+	request := "Immutable" + name + "Request"
+	imports := map[string]bool{
+		dtoPkg + "." + response: true,
+		ctx.JavaPackage(config.ModuleAPI, "config") + ".GlobalExceptionHandler":  true,
+		ctx.JavaPackage(config.ModuleShared, "service") + "." + name + "Service": true,
+		"org.junit.jupiter.api.BeforeEach":                                       true,
+		"org.junit.jupiter.api.Test":                                             true,
+		"org.junit.jupiter.api.extension.ExtendWith":                             true,
+		"org.mockito.Mock":                                           true,
+		"org.mockito.junit.jupiter.MockitoExtension":                 true,
+		"org.springframework.test.web.servlet.MockMvc":               true,
+		"org.springframework.test.web.servlet.setup.MockMvcBuilders": true,
+	}
+	statics := map[string]bool{
+		"org.mockito.Mockito.when": true,
+		"org.springframework.test.web.servlet.result.MockMvcResultMatchers.jsonPath": true,
+		"org.springframework.test.web.servlet.result.MockMvcResultMatchers.status":   true,
+	}
+	sample := func(builder string) string {
+		var s strings.Builder
+		fmt.Fprintf(&s, "%s.builder()", builder)
+		if builder == response {
+			s.WriteString("\n        .id(\"1\")")
+		}
+		for _, f := range fields {
+			value, imp := sampleValue(f)
+			if imp != "" {
+				imports[imp] = true
+			}
+			fmt.Fprintf(&s, "\n        .%s(%s)", f.Name, value)
+		}
+		s.WriteString("\n        .build()")
+		return s.String()
+	}
+	for _, imp := range enumImports(ctx, fields) {
+		imports[imp] = true
+	}
+	if hasWrite(methods) {
+		imports[dtoPkg+"."+request] = true
+		imports["com.fasterxml.jackson.databind.ObjectMapper"] = true
+		imports["org.springframework.http.MediaType"] = true
+		statics["org.mockito.ArgumentMatchers.any"] = true
+	}
+	if slices.Contains(methods, "GET") {
+		imports["java.util.List"] = true
+		imports["java.util.Optional"] = true
+		statics["org.springframework.test.web.servlet.request.MockMvcRequestBuilders.get"] = true
+	}
+	if slices.Contains(methods, "POST") {
+		statics["org.springframework.test.web.servlet.request.MockMvcRequestBuilders.post"] = true
+	}
+	if slices.Contains(methods, "PUT") {
+		imports["java.util.Optional"] = true
+		statics["org.mockito.ArgumentMatchers.eq"] = true
+		statics["org.springframework.test.web.servlet.request.MockMvcRequestBuilders.put"] = true
+	}
+	if slices.Contains(methods, "DELETE") {
+		statics["org.springframework.test.web.servlet.request.MockMvcRequestBuilders.delete"] = true
+	}
+	// Render the samples first: they add the field types' imports
+	responseSample := sample(response)
+	requestSample := ""
+	if hasWrite(methods) {
+		requestSample = sample(request)
+	}
+	required := false
+	for _, f := range fields {
+		if !f.Nullable {
+			required = true
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s;\n\n", ctx.JavaPackage(config.ModuleAPI, "controller"))
+	for _, imp := range sortedKeys(statics) {
+		fmt.Fprintf(&b, "import static %s;\n", imp)
+	}
+	b.WriteString("\n")
+	for _, imp := range sortedKeys(imports) {
+		fmt.Fprintf(&b, "import %s;\n", imp)
+	}
+	b.WriteString("\n")
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * MockMvc tests for {@link %sController}. Generated by `trabuco generate endpoint`.\n", name)
+	b.WriteString(" *\n")
+	b.WriteString(" * <p>MockMvc runs standalone with GlobalExceptionHandler as its advice, so\n")
+	b.WriteString(" * the error cases check the ProblemDetail clients receive without starting\n")
+	b.WriteString(" * the application context. Security filters are not part of this setup;\n")
+	b.WriteString(" * the auth tests in config/security cover them.\n")
+	b.WriteString(" */\n")
+	b.WriteString("@ExtendWith(MockitoExtension.class)\n")
+	fmt.Fprintf(&b, "class %sControllerTest {\n\n", name)
+	if hasWrite(methods) {
+		b.WriteString("  private final ObjectMapper objectMapper = new ObjectMapper().findAndRegisterModules();\n\n")
+	}
+	b.WriteString("  @Mock\n")
+	fmt.Fprintf(&b, "  private %sService service;\n\n", name)
+	b.WriteString("  private MockMvc mockMvc;\n\n")
+	b.WriteString("  @BeforeEach\n")
+	b.WriteString("  void setUp() {\n")
+	fmt.Fprintf(&b, "    mockMvc = MockMvcBuilders.standaloneSetup(new %sController(service))\n", name)
+	b.WriteString("        .setControllerAdvice(new GlobalExceptionHandler())\n")
+	b.WriteString("        .build();\n")
+	b.WriteString("  }\n")
+
+	test := func(method string, lines ...string) {
+		b.WriteString("\n  @Test\n")
+		fmt.Fprintf(&b, "  void %s() throws Exception {\n", method)
+		for _, l := range lines {
+			if l == "" {
+				b.WriteString("\n")
+				continue
+			}
+			fmt.Fprintf(&b, "    %s\n", l)
+		}
+		b.WriteString("  }\n")
+	}
+	if slices.Contains(methods, "GET") {
+		test("list_returnsAll",
+			"when(service.list()).thenReturn(List.of(sampleResponse()));",
+			"",
+			fmt.Sprintf("mockMvc.perform(get(%q))", path),
+			"    .andExpect(status().isOk())",
+			"    .andExpect(jsonPath(\"$[0].id\").value(\"1\"));")
+		test("getById_returns404ProblemDetailForUnknownId",
+			"when(service.findById(\"missing\")).thenReturn(Optional.empty());",
+			"",
+			fmt.Sprintf("mockMvc.perform(get(%q))", path+"/missing"),
+			"    .andExpect(status().isNotFound())",
+			"    .andExpect(jsonPath(\"$.status\").value(404));")
+	}
+	if slices.Contains(methods, "POST") {
+		test("create_returns201",
+			"when(service.create(any())).thenReturn(sampleResponse());",
+			"",
+			fmt.Sprintf("mockMvc.perform(post(%q)", path),
+			"        .contentType(MediaType.APPLICATION_JSON)",
+			"        .content(objectMapper.writeValueAsString(sampleRequest())))",
+			"    .andExpect(status().isCreated())",
+			"    .andExpect(jsonPath(\"$.id\").value(\"1\"));")
+		if required {
+			test("create_rejectsIncompleteBody",
+				fmt.Sprintf("mockMvc.perform(post(%q)", path),
+				"        .contentType(MediaType.APPLICATION_JSON)",
+				"        .content(\"{}\"))",
+				"    .andExpect(status().isBadRequest())",
+				"    .andExpect(jsonPath(\"$.status\").value(400));")
+		}
+	}
+	if slices.Contains(methods, "PUT") {
+		test("update_returns404ProblemDetailForUnknownId",
+			"when(service.update(eq(\"missing\"), any())).thenReturn(Optional.empty());",
+			"",
+			fmt.Sprintf("mockMvc.perform(put(%q)", path+"/missing"),
+			"        .contentType(MediaType.APPLICATION_JSON)",
+			"        .content(objectMapper.writeValueAsString(sampleRequest())))",
+			"    .andExpect(status().isNotFound())",
+			"    .andExpect(jsonPath(\"$.status\").value(404));")
+	}
+	if slices.Contains(methods, "DELETE") {
+		test("delete_returns204",
+			"when(service.delete(\"1\")).thenReturn(true);",
+			"",
+			fmt.Sprintf("mockMvc.perform(delete(%q))", path+"/1"),
+			"    .andExpect(status().isNoContent());")
+	}
+
+	fmt.Fprintf(&b, "\n  private static %s sampleResponse() {\n", response)
+	fmt.Fprintf(&b, "    return %s;\n", responseSample)
+	b.WriteString("  }\n")
+	if requestSample != "" {
+		fmt.Fprintf(&b, "\n  private static %s sampleRequest() {\n", request)
+		fmt.Fprintf(&b, "    return %s;\n", requestSample)
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func sortedKeys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
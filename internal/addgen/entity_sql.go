@@ -31,7 +31,7 @@ func generateSQLEntity(ctx *Context, opts EntityOpts, fields []Field) (*Result,
 	}
 
 	migrationDir := ctx.ResourcesMain(config.ModuleSQLDatastore, filepath.Join("db", "migration"))
-	version, err := nextMigrationVersion(filepath.Join(ctx.ProjectPath, migrationDir))
+	version, err := NextMigrationVersion(filepath.Join(ctx.ProjectPath, migrationDir))
 	if err != nil {
 		return nil, err
 	}
@@ -212,13 +212,13 @@ func renderSQLMigration(version int, tableName, database string, fields []Field)
 // autoIDColumn returns the database-flavored auto-PK column DDL.
 //
 //	postgresql → "BIGSERIAL PRIMARY KEY"
-//	mysql      → "BIGINT AUTO_INCREMENT PRIMARY KEY"
+//	mysql      → "BIGINT AUTO_INCREMENT PRIMARY KEY" (mariadb too)
 //	other      → "BIGINT PRIMARY KEY" (caller fills in default)
 func autoIDColumn(database string) string {
 	switch database {
 	case config.DatabasePostgreSQL:
 		return "BIGSERIAL PRIMARY KEY"
-	case config.DatabaseMySQL:
+	case config.DatabaseMySQL, config.DatabaseMariaDB:
 		return "BIGINT AUTO_INCREMENT PRIMARY KEY"
 	}
 	return "BIGINT PRIMARY KEY"
@@ -28,6 +28,12 @@ const (
 	FTBytes     FieldType = "bytes"
 )
 
+// FieldTypes lists every FieldType, in the order the help text shows them
+var FieldTypes = []FieldType{
+	FTString, FTText, FTInteger, FTLong, FTDecimal, FTBoolean,
+	FTInstant, FTLocalDate, FTUUID, FTJSON, FTBytes, FTEnum,
+}
+
 // Field is one parsed entry from --fields="name:type[?]". For enum
 // fields, EnumName holds the referenced enum class name (e.g.
 // "Status" from "status:enum:Status").
@@ -38,6 +44,18 @@ type Field struct {
 	EnumName string
 }
 
+// Spec returns the field in ParseFields syntax, e.g. "status:enum:Status?"
+func (f Field) Spec() string {
+	spec := f.Name + ":" + string(f.Type)
+	if f.Type == FTEnum {
+		spec += ":" + f.EnumName
+	}
+	if f.Nullable {
+		spec += "?"
+	}
+	return spec
+}
+
 // JavaType returns the boxed Java type for the field. Boxed
 // (Integer/Long/Boolean) regardless of nullability so the same
 // declaration works as a record component, builder argument, and
@@ -92,29 +110,32 @@ func (f Field) JavaImport() string {
 
 // SQLType returns the column DDL type for a database flavor.
 // PostgreSQL gets the richer types (NUMERIC, JSONB, TIMESTAMP WITH
-// TIME ZONE); MySQL falls back to the closest standard type.
+// TIME ZONE); MySQL and MariaDB fall back to the closest standard type,
+// except that MariaDB 10.7+ has a native UUID. MariaDB's JSON is an alias
+// for LONGTEXT with a JSON_VALID check, so JSON columns behave the same.
 func (f Field) SQLType(database string) string {
+	mysql := database == config.DatabaseMySQL || database == config.DatabaseMariaDB
 	switch f.Type {
 	case FTString:
 		return "VARCHAR(255)"
 	case FTText:
 		return "TEXT"
 	case FTInteger:
-		if database == config.DatabaseMySQL {
+		if mysql {
 			return "INT"
 		}
 		return "INTEGER"
 	case FTLong:
 		return "BIGINT"
 	case FTDecimal:
-		if database == config.DatabaseMySQL {
+		if mysql {
 			return "DECIMAL(19,4)"
 		}
 		return "NUMERIC(19,4)"
 	case FTBoolean:
 		return "BOOLEAN"
 	case FTInstant:
-		if database == config.DatabaseMySQL {
+		if mysql {
 			return "TIMESTAMP(6)"
 		}
 		return "TIMESTAMP WITH TIME ZONE"
@@ -126,12 +147,12 @@ func (f Field) SQLType(database string) string {
 		}
 		return "UUID"
 	case FTJSON:
-		if database == config.DatabaseMySQL {
+		if mysql {
 			return "JSON"
 		}
 		return "JSONB"
 	case FTBytes:
-		if database == config.DatabaseMySQL {
+		if mysql {
 			return "LONGBLOB"
 		}
 		return "BYTEA"
@@ -112,6 +112,21 @@ func TestParseFields(t *testing.T) {
 	}
 }
 
+func TestField_Spec(t *testing.T) {
+	spec := "notes:text?,total:decimal,status:enum:Status,kind:enum:Kind?"
+	fields, err := ParseFields(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs := make([]string, len(fields))
+	for i, f := range fields {
+		specs[i] = f.Spec()
+	}
+	if got := strings.Join(specs, ","); got != spec {
+		t.Errorf("Spec round trip = %q, want %q", got, spec)
+	}
+}
+
 func TestField_JavaType(t *testing.T) {
 	cases := []struct {
 		f    Field
@@ -188,6 +203,14 @@ func TestField_SQLType(t *testing.T) {
 		if got := f.SQLType("mysql"); got != tc.mysql {
 			t.Errorf("SQLType(%s, mysql) = %q, want %q", tc.ft, got, tc.mysql)
 		}
+		// MariaDB shares the MySQL types except for its native UUID
+		mariadb := tc.mysql
+		if tc.ft == FTUUID {
+			mariadb = "UUID"
+		}
+		if got := f.SQLType("mariadb"); got != mariadb {
+			t.Errorf("SQLType(%s, mariadb) = %q, want %q", tc.ft, got, mariadb)
+		}
 	}
 }
 
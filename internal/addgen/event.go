@@ -12,6 +12,12 @@ import (
 type EventOpts struct {
 	Name   string // PascalCase event class name
 	Fields string // ParseFields-format spec
+
+	// Topic, set by `trabuco generate event`, makes the record a broker
+	// message sent to this topic: it gains eventId, occurredAt and a create
+	// factory, Fields becomes optional, and EventConsumer (when present)
+	// gets a listener and its test.
+	Topic string
 }
 
 // GenerateEvent emits Model/.../events/{Name}.java — a Java record
@@ -33,15 +39,40 @@ func GenerateEvent(ctx *Context, opts EventOpts) (*Result, error) {
 		return nil, fmt.Errorf("project does not have the Model module — events live there")
 	}
 
-	fields, err := ParseFields(opts.Fields)
-	if err != nil {
-		return nil, err
+	var fields []Field
+	if opts.Topic == "" || strings.TrimSpace(opts.Fields) != "" {
+		parsed, err := ParseFields(opts.Fields)
+		if err != nil {
+			return nil, err
+		}
+		fields = parsed
 	}
 
 	rel := filepath.Join(ctx.JavaSrcMain(config.ModuleModel, "events"), name+".java")
 	result := &Result{}
-	if err := ctx.emitFile(rel, renderEventRecord(ctx, name, fields), result); err != nil {
-		return nil, err
+	if opts.Topic == "" {
+		if err := ctx.emitFile(rel, renderEventRecord(ctx, name, fields), result); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, f := range fields {
+			if f.Name == "eventId" || f.Name == "occurredAt" {
+				return nil, fmt.Errorf("field %q is reserved: every generated event carries eventId and occurredAt", f.Name)
+			}
+		}
+		if err := ctx.emitFile(rel, renderMessageEvent(ctx, name, opts.Topic, fields), result); err != nil {
+			return nil, err
+		}
+		if ctx.HasModule(config.ModuleEventConsumer) {
+			listenerRel := filepath.Join(ctx.JavaSrcMain(config.ModuleEventConsumer, "listener"), name+"Listener.java")
+			if err := ctx.emitFile(listenerRel, renderEventListener(ctx, name, opts.Topic), result); err != nil {
+				return nil, err
+			}
+			testRel := filepath.Join(ctx.JavaSrcTest(config.ModuleEventConsumer, "listener"), name+"ListenerTest.java")
+			if err := ctx.emitFile(testRel, renderEventListenerTest(ctx, name, fields), result); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	seenEnums := map[string]bool{}
@@ -55,6 +86,16 @@ func GenerateEvent(ctx *Context, opts EventOpts) (*Result, error) {
 		}
 	}
 
+	if opts.Topic != "" {
+		result.NextSteps = []string{
+			fmt.Sprintf("Publish with eventPublisher.publish(%s.create(...)) from the service that owns the change.", name),
+		}
+		if ctx.HasModule(config.ModuleEventConsumer) {
+			result.NextSteps = append(result.NextSteps,
+				fmt.Sprintf("Implement %sListener.handle in EventConsumer — replace the TODO body with your reaction to the event.", name))
+		}
+		return result, nil
+	}
 	result.NextSteps = []string{
 		fmt.Sprintf("If %s is part of a sealed event hierarchy, add it to the parent's `permits` clause.", name),
 		"Add @JsonProperty annotations on fields if you need wire-format stability across services.",
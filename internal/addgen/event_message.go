@@ -0,0 +1,408 @@
+package addgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// EventDestinations returns the property placeholders, with defaults, that a
+// broker event for topic is published to and consumed from: the same Kafka
+// topic, SQS queue, Redis stream or NATS subject on both sides; a RabbitMQ
+// exchange and its queue; a Pub/Sub topic and its subscription. The keys
+// mirror the placeholder event's under app.<broker>.
+func EventDestinations(cfg *config.ProjectConfig, topic string) (publish, consume string) {
+	switch {
+	case cfg.UsesKafka():
+		publish = fmt.Sprintf("${app.kafka.topics.%s:%s}", topic, topic)
+		return publish, publish
+	case cfg.UsesRabbitMQ():
+		return fmt.Sprintf("${app.rabbitmq.exchanges.%s:%s-exchange}", topic, topic),
+			fmt.Sprintf("${app.rabbitmq.queues.%s:%s}", topic, topic)
+	case cfg.UsesSQS():
+		publish = fmt.Sprintf("${app.sqs.queue.%s:%s}", topic, cfg.SQSQueueName(topic))
+		return publish, publish
+	case cfg.UsesPubSub():
+		return fmt.Sprintf("${app.pubsub.topic.%s:%s}", topic, topic),
+			fmt.Sprintf("${app.pubsub.subscription.%s:%s-sub}", topic, topic)
+	case cfg.UsesRedisStreams():
+		publish = fmt.Sprintf("${app.redis.streams.%s:%s}", topic, topic)
+		return publish, publish
+	case cfg.UsesNATS():
+		publish = fmt.Sprintf("${app.nats.subjects.%s:%s}", topic, topic)
+		return publish, publish
+	}
+	return "", ""
+}
+
+// brokerName is the display name of the project's message broker.
+func brokerName(cfg *config.ProjectConfig) string {
+	switch {
+	case cfg.UsesKafka():
+		return "Kafka"
+	case cfg.UsesRabbitMQ():
+		return "RabbitMQ"
+	case cfg.UsesSQS():
+		return "AWS SQS"
+	case cfg.UsesPubSub():
+		return "GCP Pub/Sub"
+	case cfg.UsesRedisStreams():
+		return "Redis Streams"
+	case cfg.UsesNATS():
+		return "NATS JetStream"
+	}
+	return "the message broker"
+}
+
+// renderMessageEvent renders a broker event record: eventId and occurredAt
+// for deduplication and ordering, the payload fields, a compact constructor
+// that rejects malformed payloads at deserialization, and a create factory.
+func renderMessageEvent(ctx *Context, name, topic string, fields []Field) string {
+	pkg := ctx.JavaPackage(config.ModuleModel, "events")
+	extras := append(enumImports(ctx, fields),
+		"jakarta.validation.constraints.NotBlank",
+		"jakarta.validation.constraints.NotNull",
+		"jakarta.validation.constraints.Size",
+		"java.time.Instant",
+		"java.util.UUID",
+	)
+	for _, f := range fields {
+		if f.Nullable {
+			extras = append(extras, "jakarta.annotation.Nullable")
+		}
+	}
+	imports := uniqueImports(fields, extras...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s;\n\n", pkg)
+	for _, imp := range imports {
+		fmt.Fprintf(&b, "import %s;\n", imp)
+	}
+	b.WriteString("\n")
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * %s event, published to %q. Generated by `trabuco generate event`.\n", name, topic)
+	b.WriteString(" *\n")
+	fmt.Fprintf(&b, " * <p>Publish with {@code eventPublisher.publish(%s.create(...))}. A\n", name)
+	b.WriteString(" * redelivered message keeps its {@code eventId}, which consumers use to\n")
+	b.WriteString(" * skip duplicates. The constructor rejects a malformed payload at the\n")
+	b.WriteString(" * deserialization boundary instead of passing it to the handler.\n")
+	b.WriteString(" *\n")
+	b.WriteString(" * @param eventId Unique identifier for this event instance (for idempotency)\n")
+	b.WriteString(" * @param occurredAt Timestamp when the event occurred\n")
+	b.WriteString(" */\n")
+	fmt.Fprintf(&b, "public record %s(\n", name)
+	b.WriteString("  @NotBlank @Size(max = 64) String eventId,\n")
+	b.WriteString("  @NotNull Instant occurredAt")
+	for _, f := range fields {
+		b.WriteString(",\n  ")
+		if f.Nullable {
+			b.WriteString("@Nullable ")
+		} else {
+			b.WriteString("@NotNull ")
+		}
+		fmt.Fprintf(&b, "%s %s", f.JavaType(), f.Name)
+	}
+	b.WriteString("\n) {\n\n")
+
+	fmt.Fprintf(&b, "  public %s {\n", name)
+	b.WriteString("    if (eventId == null || eventId.isBlank() || eventId.length() > 64) {\n")
+	fmt.Fprintf(&b, "      throw new IllegalArgumentException(\"%s.eventId must be 1-64 characters\");\n", name)
+	b.WriteString("    }\n")
+	writeNullCheck(&b, name, "occurredAt")
+	for _, f := range fields {
+		if !f.Nullable {
+			writeNullCheck(&b, name, f.Name)
+		}
+	}
+	b.WriteString("  }\n\n")
+
+	params := make([]string, len(fields))
+	args := []string{"UUID.randomUUID().toString()", "Instant.now()"}
+	for i, f := range fields {
+		params[i] = f.JavaType() + " " + f.Name
+		args = append(args, f.Name)
+	}
+	b.WriteString("  /**\n")
+	b.WriteString("   * Creates an event with a new ID, occurring now.\n")
+	b.WriteString("   */\n")
+	fmt.Fprintf(&b, "  public static %s create(%s) {\n", name, strings.Join(params, ", "))
+	fmt.Fprintf(&b, "    return new %s(%s);\n", name, strings.Join(args, ", "))
+	b.WriteString("  }\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func writeNullCheck(b *strings.Builder, name, field string) {
+	fmt.Fprintf(b, "    if (%s == null) {\n", field)
+	fmt.Fprintf(b, "      throw new IllegalArgumentException(\"%s.%s must not be null\");\n", name, field)
+	b.WriteString("    }\n")
+}
+
+// renderEventListener renders the EventConsumer listener for a broker event.
+// Kafka, RabbitMQ and SQS listeners subscribe through their annotations;
+// the Pub/Sub, Redis Streams and NATS ones expose a plain handle method that
+// the broker's config class calls, like PlaceholderEventListener.
+func renderEventListener(ctx *Context, name, topic string) string {
+	pkg := ctx.JavaPackage(config.ModuleEventConsumer, "listener")
+	eventFQN := ctx.JavaPackage(config.ModuleModel, "events") + "." + name
+	_, consume := EventDestinations(ctx.ProjectConfig, topic)
+
+	imports := []string{
+		eventFQN,
+		"org.slf4j.Logger",
+		"org.slf4j.LoggerFactory",
+		"org.springframework.stereotype.Component",
+	}
+	switch {
+	case ctx.UsesKafka():
+		imports = append(imports,
+			"org.springframework.kafka.annotation.DltHandler",
+			"org.springframework.kafka.annotation.KafkaListener",
+			"org.springframework.kafka.annotation.RetryableTopic",
+			"org.springframework.kafka.retrytopic.DltStrategy",
+			"org.springframework.kafka.support.KafkaHeaders",
+			"org.springframework.messaging.handler.annotation.Header",
+			"org.springframework.retry.annotation.Backoff",
+		)
+	case ctx.UsesRabbitMQ():
+		imports = append(imports,
+			"org.springframework.amqp.core.ExchangeTypes",
+			"org.springframework.amqp.rabbit.annotation.Argument",
+			"org.springframework.amqp.rabbit.annotation.Exchange",
+			"org.springframework.amqp.rabbit.annotation.Queue",
+			"org.springframework.amqp.rabbit.annotation.QueueBinding",
+			"org.springframework.amqp.rabbit.annotation.RabbitListener",
+		)
+	case ctx.UsesSQS():
+		imports = append(imports,
+			"io.awspring.cloud.sqs.annotation.SqsListener",
+			"io.awspring.cloud.sqs.listener.acknowledgement.Acknowledgement",
+		)
+	}
+	sort.Strings(imports)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s;\n\n", pkg)
+	for _, imp := range imports {
+		fmt.Fprintf(&b, "import %s;\n", imp)
+	}
+	b.WriteString("\n")
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * Consumes %s events from %s (%q). Generated by\n", name, brokerName(ctx.ProjectConfig), topic)
+	b.WriteString(" * `trabuco generate event`.\n")
+	b.WriteString(" *\n")
+	switch {
+	case ctx.UsesKafka():
+		b.WriteString(" * <p>Failed events are retried 3 times with exponential backoff (1s, 2s,\n")
+		fmt.Fprintf(&b, " * 4s) through {@code %s-retry-*} topics, then routed to {@code %s-dlt}.\n", topic, topic)
+		b.WriteString(" * The local broker auto-creates them; create them before the first deploy\n")
+		b.WriteString(" * (see PlaceholderEventListener for the topic names).\n")
+	case ctx.UsesRabbitMQ():
+		b.WriteString(" * <p>The listener declares its queue, a fanout exchange and a dead-letter\n")
+		b.WriteString(" * pair on startup. The container factory does not requeue rejected\n")
+		b.WriteString(" * messages, so a failed event goes straight to the {@code .dlq} queue.\n")
+	case ctx.UsesSQS():
+		b.WriteString(" * <p>A failed event is not acknowledged: SQS redelivers it after the\n")
+		if ctx.HasSQSDeadLetterQueue() {
+			b.WriteString(" * visibility timeout, and its redrive policy moves it to\n")
+			fmt.Fprintf(&b, " * {@code %s} after %d receives.\n", ctx.SQSDeadLetterQueueName(topic), ctx.SQSReceiveCount())
+		} else {
+			b.WriteString(" * visibility timeout. Set a redrive policy (maxReceiveCount) on the queue\n")
+			b.WriteString(" * to move poison messages to a dead-letter queue.\n")
+		}
+	case ctx.UsesPubSub():
+		b.WriteString(" * <p>Register an inbound channel adapter for the subscription in PubSubConfig\n")
+		fmt.Fprintf(&b, " * ({@code %s}) and call {@link #handle} from it, acking on\n", consume)
+		b.WriteString(" * success and nacking on failure, as PlaceholderEventListener does.\n")
+	case ctx.UsesRedisStreams():
+		fmt.Fprintf(&b, " * <p>Subscribe to the stream ({@code %s}) in RedisStreamConfig\n", consume)
+		b.WriteString(" * and call {@link #handle} for each record; a record whose handling\n")
+		b.WriteString(" * throws stays pending in the consumer group.\n")
+	case ctx.UsesNATS():
+		fmt.Fprintf(&b, " * <p>Add the subject ({@code %s}) to the stream's subjects\n", consume)
+		b.WriteString(" * and a durable consumer in NatsConfig that calls {@link #handle}; a\n")
+		b.WriteString(" * message whose handling throws is nak'ed and redelivered.\n")
+	}
+	b.WriteString(" */\n")
+	b.WriteString("@Component\n")
+	fmt.Fprintf(&b, "public class %sListener {\n\n", name)
+	fmt.Fprintf(&b, "  private static final Logger logger = LoggerFactory.getLogger(%sListener.class);\n\n", name)
+	b.WriteString("  private final IdempotencyTracker idempotencyTracker;\n\n")
+	fmt.Fprintf(&b, "  public %sListener(IdempotencyTracker idempotencyTracker) {\n", name)
+	b.WriteString("    this.idempotencyTracker = idempotencyTracker;\n")
+	b.WriteString("  }\n\n")
+
+	switch {
+	case ctx.UsesKafka():
+		b.WriteString("  @RetryableTopic(\n")
+		b.WriteString("    attempts = \"4\",\n")
+		b.WriteString("    backoff = @Backoff(delay = 1000, multiplier = 2.0),\n")
+		b.WriteString("    dltStrategy = DltStrategy.FAIL_ON_ERROR,\n")
+		b.WriteString("    autoCreateTopics = \"false\"\n")
+		b.WriteString("  )\n")
+		b.WriteString("  @KafkaListener(\n")
+		fmt.Fprintf(&b, "    topics = %q,\n", consume)
+		b.WriteString("    groupId = \"${spring.kafka.consumer.group-id}\",\n")
+		b.WriteString("    // The shared consumer factory deserializes to PlaceholderEvent by default\n")
+		fmt.Fprintf(&b, "    properties = \"spring.json.value.default.type=%s\"\n", eventFQN)
+		b.WriteString("  )\n")
+		writeHandleMethod(&b, name, "", "")
+		b.WriteString("\n  @DltHandler\n")
+		fmt.Fprintf(&b, "  public void handleDlt(%s event, @Header(KafkaHeaders.RECEIVED_TOPIC) String topic) {\n", name)
+		fmt.Fprintf(&b, "    logger.error(\"%s sent to DLT: topic={}, eventId={}\", topic, event.eventId());\n", name)
+		b.WriteString("    // TODO: Add alerting, store for manual review, etc.\n")
+		b.WriteString("  }\n")
+	case ctx.UsesRabbitMQ():
+		publish, _ := EventDestinations(ctx.ProjectConfig, topic)
+		b.WriteString("  @RabbitListener(bindings = @QueueBinding(\n")
+		b.WriteString("    value = @Queue(\n")
+		fmt.Fprintf(&b, "      value = %q,\n", consume)
+		fmt.Fprintf(&b, "      arguments = @Argument(name = \"x-dead-letter-exchange\", value = %q)),\n", publish+".dlx")
+		fmt.Fprintf(&b, "    exchange = @Exchange(value = %q, type = ExchangeTypes.FANOUT)))\n", publish)
+		writeHandleMethod(&b, name, "", "")
+		b.WriteString("\n  @RabbitListener(bindings = @QueueBinding(\n")
+		fmt.Fprintf(&b, "    value = @Queue(%q),\n", consume+".dlq")
+		fmt.Fprintf(&b, "    exchange = @Exchange(value = %q, type = ExchangeTypes.FANOUT)))\n", publish+".dlx")
+		fmt.Fprintf(&b, "  public void handleDlq(%s event) {\n", name)
+		fmt.Fprintf(&b, "    logger.error(\"%s sent to DLQ: eventId={}\", event.eventId());\n", name)
+		b.WriteString("    // TODO: Add alerting, store for manual review, etc.\n")
+		b.WriteString("  }\n")
+	case ctx.UsesSQS():
+		fmt.Fprintf(&b, "  @SqsListener(%q)\n", consume)
+		writeHandleMethod(&b, name, ", Acknowledgement acknowledgement", "acknowledgement.acknowledge();")
+	default:
+		writeHandleMethod(&b, name, "", "")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writeHandleMethod writes the listener's handle method: deduplicate on the
+// event ID, then the TODO body. ack, when set, runs after both.
+func writeHandleMethod(b *strings.Builder, name, extraParam, ack string) {
+	fmt.Fprintf(b, "  public void handle(%s event%s) {\n", name, extraParam)
+	fmt.Fprintf(b, "    logger.info(\"Received %s: eventId={}\", event.eventId());\n\n", name)
+	b.WriteString("    // Skip duplicate deliveries (broker replays).\n")
+	b.WriteString("    if (!idempotencyTracker.checkAndMark(event.eventId())) {\n")
+	if ack != "" {
+		fmt.Fprintf(b, "      %s\n", ack)
+	}
+	b.WriteString("      return;\n")
+	b.WriteString("    }\n\n")
+	fmt.Fprintf(b, "    // TODO: react to %s. Throw to have the broker redeliver it.\n", name)
+	if ack != "" {
+		fmt.Fprintf(b, "    %s\n", ack)
+	}
+	b.WriteString("  }\n")
+}
+
+// renderEventListenerTest renders the unit test of a generated listener. Like
+// PlaceholderEventListenerTest it uses a real IdempotencyTracker.
+func renderEventListenerTest(ctx *Context, name string, fields []Field) string {
+	pkg := ctx.JavaPackage(config.ModuleEventConsumer, "listener")
+	args := make([]string, len(fields))
+	imports := map[string]bool{
+		ctx.JavaPackage(config.ModuleModel, "events") + "." + name: true,
+		"org.junit.jupiter.api.BeforeEach":                         true,
+		"org.junit.jupiter.api.Test":                               true,
+	}
+	for i, f := range fields {
+		var imp string
+		args[i], imp = sampleValue(f)
+		if imp != "" {
+			imports[imp] = true
+		}
+	}
+	for _, imp := range enumImports(ctx, fields) {
+		imports[imp] = true
+	}
+	ack := ctx.UsesSQS()
+	if ack {
+		imports["io.awspring.cloud.sqs.listener.acknowledgement.Acknowledgement"] = true
+		imports["org.junit.jupiter.api.extension.ExtendWith"] = true
+		imports["org.mockito.Mock"] = true
+		imports["org.mockito.junit.jupiter.MockitoExtension"] = true
+	}
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+
+	call := func(event string) string {
+		if ack {
+			return fmt.Sprintf("listener.handle(%s, acknowledgement);", event)
+		}
+		return fmt.Sprintf("listener.handle(%s);", event)
+	}
+	create := fmt.Sprintf("%s.create(%s)", name, strings.Join(args, ", "))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s;\n\n", pkg)
+	if ack {
+		b.WriteString("import static org.mockito.Mockito.times;\n")
+		b.WriteString("import static org.mockito.Mockito.verify;\n")
+	} else {
+		b.WriteString("import static org.junit.jupiter.api.Assertions.assertDoesNotThrow;\n")
+	}
+	b.WriteString("\n")
+	for _, imp := range sorted {
+		fmt.Fprintf(&b, "import %s;\n", imp)
+	}
+	b.WriteString("\n")
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * Unit tests for {@link %sListener}. Generated by `trabuco generate event`.\n", name)
+	b.WriteString(" *\n")
+	b.WriteString(" * <p>Once handle() has real logic, assert its effects on the collaborators\n")
+	b.WriteString(" * it calls, including that a duplicate delivery does not repeat them.\n")
+	b.WriteString(" */\n")
+	if ack {
+		b.WriteString("@ExtendWith(MockitoExtension.class)\n")
+	}
+	fmt.Fprintf(&b, "class %sListenerTest {\n\n", name)
+	b.WriteString("  private final IdempotencyTracker idempotencyTracker = new IdempotencyTracker();\n\n")
+	fmt.Fprintf(&b, "  private %sListener listener;\n", name)
+	if ack {
+		b.WriteString("\n  @Mock\n")
+		b.WriteString("  private Acknowledgement acknowledgement;\n")
+	}
+	b.WriteString("\n  @BeforeEach\n")
+	b.WriteString("  void setUp() {\n")
+	b.WriteString("    idempotencyTracker.reset();\n")
+	fmt.Fprintf(&b, "    listener = new %sListener(idempotencyTracker);\n", name)
+	b.WriteString("  }\n\n")
+
+	b.WriteString("  @Test\n")
+	if ack {
+		b.WriteString("  void firstDelivery_isProcessed_andAcked() {\n")
+		fmt.Fprintf(&b, "    %s event = %s;\n\n", name, create)
+		fmt.Fprintf(&b, "    %s\n\n", call("event"))
+		b.WriteString("    verify(acknowledgement).acknowledge();\n")
+	} else {
+		b.WriteString("  void firstDelivery_isProcessed() {\n")
+		fmt.Fprintf(&b, "    %s event = %s;\n\n", name, create)
+		fmt.Fprintf(&b, "    assertDoesNotThrow(() -> %s);\n", strings.TrimSuffix(call("event"), ";"))
+	}
+	b.WriteString("  }\n\n")
+
+	b.WriteString("  @Test\n")
+	if ack {
+		b.WriteString("  void duplicateDelivery_isShortCircuited_butStillAcked() {\n")
+		fmt.Fprintf(&b, "    %s event = %s;\n\n", name, create)
+		fmt.Fprintf(&b, "    %s\n", call("event"))
+		fmt.Fprintf(&b, "    %s\n\n", call("event"))
+		b.WriteString("    verify(acknowledgement, times(2)).acknowledge();\n")
+	} else {
+		b.WriteString("  void duplicateDelivery_isShortCircuited() {\n")
+		fmt.Fprintf(&b, "    %s event = %s;\n\n", name, create)
+		b.WriteString("    assertDoesNotThrow(() -> {\n")
+		fmt.Fprintf(&b, "      %s\n", call("event"))
+		fmt.Fprintf(&b, "      %s\n", call("event"))
+		b.WriteString("    });\n")
+	}
+	b.WriteString("  }\n")
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -3,6 +3,7 @@ package addgen
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
@@ -12,6 +13,12 @@ import (
 type JobOpts struct {
 	Name    string // verb-noun PascalCase, e.g. "ProcessShipment". The CLI does not auto-prefix "Process"; the user controls naming.
 	Payload string // ParseFields-format payload spec (e.g. "orderId:string,amount:decimal")
+
+	// Runnable emits a Worker handler that completes (logging, with a TODO
+	// body) instead of throwing, plus a handler test, and allows an empty
+	// payload. `trabuco generate job` sets it: its jobs can be scheduled
+	// right away and the build runs the test.
+	Runnable bool
 }
 
 // GenerateJob emits the three-file JobRunr job bundle:
@@ -39,15 +46,23 @@ func GenerateJob(ctx *Context, opts JobOpts) (*Result, error) {
 		return nil, fmt.Errorf("project does not have the Worker module — JobRunr handlers live there")
 	}
 
-	fields, err := ParseFields(opts.Payload)
-	if err != nil {
-		return nil, fmt.Errorf("invalid --payload: %w", err)
+	var fields []Field
+	if !opts.Runnable || strings.TrimSpace(opts.Payload) != "" {
+		parsed, err := ParseFields(opts.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --payload: %w", err)
+		}
+		fields = parsed
+	}
+	command := "trabuco add job"
+	if opts.Runnable {
+		command = "trabuco generate job"
 	}
 
 	result := &Result{}
 
 	requestRel := filepath.Join(ctx.JavaSrcMain(config.ModuleModel, "jobs"), name+"JobRequest.java")
-	if err := ctx.emitFile(requestRel, renderJobRequest(ctx, name, fields), result); err != nil {
+	if err := ctx.emitFile(requestRel, renderJobRequest(ctx, name, fields, command), result); err != nil {
 		return nil, err
 	}
 
@@ -57,10 +72,17 @@ func GenerateJob(ctx *Context, opts JobOpts) (*Result, error) {
 	}
 
 	handlerRel := filepath.Join(ctx.JavaSrcMain(config.ModuleWorker, "handler"), name+"JobRequestHandler.java")
-	if err := ctx.emitFile(handlerRel, renderJobHandlerConcrete(ctx, name), result); err != nil {
+	if err := ctx.emitFile(handlerRel, renderJobHandlerConcrete(ctx, name, opts.Runnable), result); err != nil {
 		return nil, err
 	}
 
+	if opts.Runnable {
+		testRel := filepath.Join(ctx.JavaSrcTest(config.ModuleWorker, "handler"), name+"JobRequestHandlerTest.java")
+		if err := ctx.emitFile(testRel, renderJobHandlerTest(ctx, name, fields), result); err != nil {
+			return nil, err
+		}
+	}
+
 	seenEnums := map[string]bool{}
 	for _, f := range fields {
 		if f.Type != FTEnum || seenEnums[f.EnumName] {
@@ -74,16 +96,32 @@ func GenerateJob(ctx *Context, opts JobOpts) (*Result, error) {
 
 	result.NextSteps = []string{
 		fmt.Sprintf("Implement %s in the Worker module — replace the TODO body with your business logic.", handlerRel),
-		fmt.Sprintf("Enqueue jobs from any module that depends on Model: BackgroundJobRequest.enqueue(new %sJobRequest(...))", name),
-		"For recurring schedules, register the job in Worker/.../config/RecurringJobsConfig.java.",
+	}
+	if !opts.Runnable {
+		result.NextSteps = append(result.NextSteps,
+			fmt.Sprintf("Enqueue jobs from any module that depends on Model: BackgroundJobRequest.enqueue(new %sJobRequest(...))", name),
+			"For recurring schedules, register the job in Worker/.../config/RecurringJobsConfig.java.",
+		)
 	}
 	return result, nil
 }
 
-func renderJobRequest(ctx *Context, name string, fields []Field) string {
+// enumImports returns the imports of the entity enums the fields reference;
+// job and test classes live outside Model's entities package.
+func enumImports(ctx *Context, fields []Field) []string {
+	var imports []string
+	for _, f := range fields {
+		if f.Type == FTEnum {
+			imports = append(imports, ctx.JavaPackage(config.ModuleModel, "entities")+"."+f.EnumName)
+		}
+	}
+	return imports
+}
+
+func renderJobRequest(ctx *Context, name string, fields []Field, command string) string {
 	pkg := ctx.JavaPackage(config.ModuleModel, "jobs")
 	imports := uniqueImports(fields,
-		"org.jobrunr.jobs.lambdas.JobRequest",
+		append(enumImports(ctx, fields), "org.jobrunr.jobs.lambdas.JobRequest")...,
 	)
 
 	var b strings.Builder
@@ -93,20 +131,24 @@ func renderJobRequest(ctx *Context, name string, fields []Field) string {
 	}
 	b.WriteString("\n")
 	b.WriteString("/**\n")
-	fmt.Fprintf(&b, " * Job request for %s. Generated by `trabuco add job`.\n", name)
+	fmt.Fprintf(&b, " * Job request for %s. Generated by `%s`.\n", name, command)
 	b.WriteString(" *\n")
 	b.WriteString(" * <p>Enqueue: BackgroundJobRequest.enqueue(new ")
 	b.WriteString(name)
 	b.WriteString("JobRequest(...));\n")
 	b.WriteString(" */\n")
-	fmt.Fprintf(&b, "public record %sJobRequest(\n", name)
-	for i, f := range fields {
-		if i > 0 {
-			b.WriteString(",\n")
+	if len(fields) == 0 {
+		fmt.Fprintf(&b, "public record %sJobRequest() implements JobRequest {\n\n", name)
+	} else {
+		fmt.Fprintf(&b, "public record %sJobRequest(\n", name)
+		for i, f := range fields {
+			if i > 0 {
+				b.WriteString(",\n")
+			}
+			fmt.Fprintf(&b, "    %s %s", f.JavaType(), f.Name)
 		}
-		fmt.Fprintf(&b, "    %s %s", f.JavaType(), f.Name)
+		b.WriteString("\n) implements JobRequest {\n\n")
 	}
-	b.WriteString("\n) implements JobRequest {\n\n")
 	b.WriteString("  @Override\n")
 	fmt.Fprintf(&b, "  public Class<%sJobRequestHandler> getJobRequestHandler() {\n", name)
 	fmt.Fprintf(&b, "    return %sJobRequestHandler.class;\n", name)
@@ -137,18 +179,25 @@ func renderJobHandlerBase(ctx *Context, name string) string {
 	return b.String()
 }
 
-func renderJobHandlerConcrete(ctx *Context, name string) string {
+func renderJobHandlerConcrete(ctx *Context, name string, runnable bool) string {
 	pkg := ctx.JavaPackage(config.ModuleWorker, "handler")
 	jobsPkg := ctx.JavaPackage(config.ModuleModel, "jobs")
 	var b strings.Builder
 	fmt.Fprintf(&b, "package %s;\n\n", pkg)
 	fmt.Fprintf(&b, "import %s.%sJobRequest;\n", jobsPkg, name)
+	if runnable {
+		b.WriteString("import java.util.Objects;\n")
+	}
 	b.WriteString("import org.jobrunr.jobs.annotations.Job;\n")
 	b.WriteString("import org.slf4j.Logger;\n")
 	b.WriteString("import org.slf4j.LoggerFactory;\n")
 	b.WriteString("import org.springframework.stereotype.Component;\n\n")
 	b.WriteString("/**\n")
-	fmt.Fprintf(&b, " * Concrete handler for %sJobRequest. Generated by `trabuco add job`.\n", name)
+	if runnable {
+		fmt.Fprintf(&b, " * Concrete handler for %sJobRequest. Generated by `trabuco generate job`.\n", name)
+	} else {
+		fmt.Fprintf(&b, " * Concrete handler for %sJobRequest. Generated by `trabuco add job`.\n", name)
+	}
 	b.WriteString(" *\n")
 	b.WriteString(" * <p>Make handlers idempotent — JobRunr retries on failure.\n")
 	b.WriteString(" * Inject dependencies via constructor.\n")
@@ -160,10 +209,103 @@ func renderJobHandlerConcrete(ctx *Context, name string) string {
 	b.WriteString("  @Override\n")
 	fmt.Fprintf(&b, "  @Job(name = \"%s\")\n", name)
 	fmt.Fprintf(&b, "  public void run(%sJobRequest request) {\n", name)
+	if runnable {
+		// A null request is a corrupt payload; failing lets JobRunr retry
+		b.WriteString("    Objects.requireNonNull(request, \"request\");\n")
+	}
 	fmt.Fprintf(&b, "    log.info(\"Running %s with payload: {}\", request);\n", name)
 	b.WriteString("    // TODO: replace this with your business logic.\n")
-	b.WriteString("    throw new UnsupportedOperationException(\"not implemented\");\n")
+	if runnable {
+		fmt.Fprintf(&b, "    log.info(\"%s completed\");\n", name)
+	} else {
+		b.WriteString("    throw new UnsupportedOperationException(\"not implemented\");\n")
+	}
 	b.WriteString("  }\n")
 	b.WriteString("}\n")
 	return b.String()
 }
+
+func renderJobHandlerTest(ctx *Context, name string, fields []Field) string {
+	pkg := ctx.JavaPackage(config.ModuleWorker, "handler")
+	jobsPkg := ctx.JavaPackage(config.ModuleModel, "jobs")
+
+	args := make([]string, len(fields))
+	imports := map[string]bool{jobsPkg + "." + name + "JobRequest": true, "org.junit.jupiter.api.Test": true}
+	for i, f := range fields {
+		var imp string
+		args[i], imp = sampleValue(f)
+		if imp != "" {
+			imports[imp] = true
+		}
+	}
+	for _, imp := range enumImports(ctx, fields) {
+		imports[imp] = true
+	}
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s;\n\n", pkg)
+	b.WriteString("import static org.assertj.core.api.Assertions.assertThatNoException;\n")
+	b.WriteString("import static org.assertj.core.api.Assertions.assertThatThrownBy;\n\n")
+	for _, imp := range sorted {
+		fmt.Fprintf(&b, "import %s;\n", imp)
+	}
+	b.WriteString("\n")
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * Unit tests for {@link %sJobRequestHandler}. Generated by `trabuco generate job`.\n", name)
+	b.WriteString(" *\n")
+	b.WriteString(" * <p>Once run() calls real collaborators, construct the handler with mocks\n")
+	b.WriteString(" * and verify the calls it makes for the request below.\n")
+	b.WriteString(" */\n")
+	fmt.Fprintf(&b, "class %sJobRequestHandlerTest {\n\n", name)
+	fmt.Fprintf(&b, "  private final %sJobRequestHandler handler = new %sJobRequestHandler();\n\n", name, name)
+	b.WriteString("  @Test\n")
+	b.WriteString("  void run_completesForValidRequest() {\n")
+	fmt.Fprintf(&b, "    %sJobRequest request = new %sJobRequest(%s);\n\n", name, name, strings.Join(args, ", "))
+	b.WriteString("    // TODO: verify the collaborators run() calls once it has real logic.\n")
+	b.WriteString("    assertThatNoException().isThrownBy(() -> handler.run(request));\n")
+	b.WriteString("  }\n\n")
+	b.WriteString("  @Test\n")
+	b.WriteString("  void run_rejectsNullRequest() {\n")
+	b.WriteString("    // A null request means a corrupt payload; failing lets JobRunr retry\n")
+	b.WriteString("    // and surface it instead of silently skipping the run.\n")
+	b.WriteString("    assertThatThrownBy(() -> handler.run(null))\n")
+	b.WriteString("        .isInstanceOf(NullPointerException.class);\n")
+	b.WriteString("  }\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// sampleValue returns a Java expression of the field's type for generated
+// tests, and the import it needs ("" when none).
+func sampleValue(f Field) (string, string) {
+	switch f.Type {
+	case FTString, FTText:
+		return fmt.Sprintf("%q", "sample-"+f.Name), ""
+	case FTInteger:
+		return "1", ""
+	case FTLong:
+		return "1L", ""
+	case FTDecimal:
+		return `new BigDecimal("1.00")`, "java.math.BigDecimal"
+	case FTBoolean:
+		return "true", ""
+	case FTInstant:
+		return `Instant.parse("2024-01-01T00:00:00Z")`, "java.time.Instant"
+	case FTLocalDate:
+		return "LocalDate.of(2024, 1, 1)", "java.time.LocalDate"
+	case FTUUID:
+		return `UUID.fromString("00000000-0000-0000-0000-000000000001")`, "java.util.UUID"
+	case FTJSON:
+		return "JsonNodeFactory.instance.objectNode()", "com.fasterxml.jackson.databind.node.JsonNodeFactory"
+	case FTBytes:
+		return "new byte[0]", ""
+	case FTEnum:
+		return f.EnumName + ".values()[0]", ""
+	}
+	return "null", ""
+}
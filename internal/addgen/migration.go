@@ -54,7 +54,7 @@ func GenerateMigration(ctx *Context, opts MigrationOpts) (*Result, error) {
 	migrationDir := ctx.ResourcesMain(config.ModuleSQLDatastore, filepath.Join("db", "migration"))
 	absMigrationDir := filepath.Join(ctx.ProjectPath, migrationDir)
 
-	version, err := nextMigrationVersion(absMigrationDir)
+	version, err := NextMigrationVersion(absMigrationDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan %s: %w", migrationDir, err)
 	}
@@ -87,11 +87,11 @@ func buildMigrationContent(version int, snakeDesc string, originalDesc string) s
 
 var migrationFilenameRE = regexp.MustCompile(`^V(\d+)__.*\.sql$`)
 
-// nextMigrationVersion returns max(V{N}) + 1 across .sql files in the
+// NextMigrationVersion returns max(V{N}) + 1 across .sql files in the
 // directory. Returns 1 when the directory is missing or empty so an
 // agent can call this command on a project where SQLDatastore was
 // just added (and V1 hasn't been generated yet, in some edge cases).
-func nextMigrationVersion(dir string) (int, error) {
+func NextMigrationVersion(dir string) (int, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -58,12 +58,12 @@ func TestNextMigrationVersion(t *testing.T) {
 					}
 				}
 			}
-			n, err := nextMigrationVersion(migrationDir)
+			n, err := NextMigrationVersion(migrationDir)
 			if err != nil {
-				t.Fatalf("nextMigrationVersion: %v", err)
+				t.Fatalf("NextMigrationVersion: %v", err)
 			}
 			if n != tc.want {
-				t.Errorf("nextMigrationVersion = %d, want %d", n, tc.want)
+				t.Errorf("NextMigrationVersion = %d, want %d", n, tc.want)
 			}
 		})
 	}
@@ -145,7 +145,7 @@ func TestGenerateMigration(t *testing.T) {
 			wantErrContains: "does not have the SQLDatastore module",
 		},
 		// Note: refuse-clobber for `add migration` is unreachable through
-		// the public API because nextMigrationVersion always picks an
+		// the public API because NextMigrationVersion always picks an
 		// unoccupied V{N}. The protection still matters for concurrent
 		// invocations and for other add-commands; covered by
 		// TestEmitFile_RefusesClobber in emit_test.go.
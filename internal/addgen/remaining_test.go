@@ -36,6 +36,51 @@ func TestGenerateEvent(t *testing.T) {
 	}
 }
 
+func TestGenerateEvent_Topic(t *testing.T) {
+	project := setupProject(t, map[string]string{
+		".trabuco.json": `{
+  "version": "1.13.2", "projectName": "demo", "groupId": "com.example.demo",
+  "artifactId": "demo", "javaVersion": "21",
+  "modules": ["Model", "Events", "EventConsumer"], "messageBroker": "sqs"
+}`,
+	})
+	ctx := mustCtx(t, project)
+	result, err := GenerateEvent(ctx, EventOpts{Name: "OrderShipped", Topic: "orders", Fields: "orderId:uuid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listenerRel := "EventConsumer/src/main/java/com/example/demo/eventconsumer/listener/OrderShippedListener.java"
+	testRel := "EventConsumer/src/test/java/com/example/demo/eventconsumer/listener/OrderShippedListenerTest.java"
+	for _, p := range []string{listenerRel, testRel} {
+		if !contains(result.Created, p) {
+			t.Errorf("missing expected file %s in %v", p, result.Created)
+		}
+	}
+	event := readPath(t, project, "Model/src/main/java/com/example/demo/model/events/OrderShipped.java")
+	for _, w := range []string{
+		"@NotBlank @Size(max = 64) String eventId,",
+		"@NotNull UUID orderId",
+		"public static OrderShipped create(UUID orderId) {",
+	} {
+		if !strings.Contains(event, w) {
+			t.Errorf("event missing %q\ngot:\n%s", w, event)
+		}
+	}
+	if listener := readPath(t, project, listenerRel); !strings.Contains(listener, `@SqsListener("${app.sqs.queue.orders:orders}")`) {
+		t.Errorf("listener should subscribe to the queue:\n%s", listener)
+	}
+	if test := readPath(t, project, testRel); !strings.Contains(test, "verify(acknowledgement, times(2)).acknowledge();") {
+		t.Errorf("SQS listener test should verify acks:\n%s", test)
+	}
+
+	if _, err := GenerateEvent(ctx, EventOpts{Name: "Pinged", Topic: "pings"}); err != nil {
+		t.Errorf("a topic event should not need fields: %v", err)
+	}
+	if _, err := GenerateEvent(ctx, EventOpts{Name: "Clash", Topic: "pings", Fields: "eventId:string"}); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("expected reserved-field error, got %v", err)
+	}
+}
+
 func TestGenerateEvent_Errors(t *testing.T) {
 	project := setupProject(t, apiSqlPgFixture())
 	ctx := mustCtx(t, project)
@@ -189,6 +234,52 @@ func TestGenerateJob(t *testing.T) {
 	}
 }
 
+func TestGenerateJob_Runnable(t *testing.T) {
+	project := setupProject(t, map[string]string{
+		".trabuco.json": `{
+  "version": "1.13.2", "projectName": "demo", "groupId": "com.example.demo",
+  "artifactId": "demo", "javaVersion": "21",
+  "modules": ["Model", "SQLDatastore", "Shared", "API", "Worker"], "database": "postgresql"
+}`,
+	})
+	ctx := mustCtx(t, project)
+	result, err := GenerateJob(ctx, JobOpts{Name: "SendDigest", Runnable: true})
+	if err != nil {
+		t.Fatalf("a runnable job should not need a payload: %v", err)
+	}
+	testRel := "Worker/src/test/java/com/example/demo/worker/handler/SendDigestJobRequestHandlerTest.java"
+	if !contains(result.Created, testRel) {
+		t.Fatalf("expected handler test in %v", result.Created)
+	}
+	if req := readPath(t, project, "Model/src/main/java/com/example/demo/model/jobs/SendDigestJobRequest.java"); !strings.Contains(req, "public record SendDigestJobRequest() implements JobRequest {") {
+		t.Errorf("empty payload should render a no-field record:\n%s", req)
+	}
+	concrete := readPath(t, project, "Worker/src/main/java/com/example/demo/worker/handler/SendDigestJobRequestHandler.java")
+	if strings.Contains(concrete, "UnsupportedOperationException") || !strings.Contains(concrete, "Objects.requireNonNull(request") {
+		t.Errorf("runnable handler should complete instead of throwing:\n%s", concrete)
+	}
+	if test := readPath(t, project, testRel); !strings.Contains(test, "new SendDigestJobRequest()") {
+		t.Errorf("handler test should build the request:\n%s", test)
+	}
+
+	if _, err := GenerateJob(ctx, JobOpts{Name: "ShipOrder", Payload: "orderId:uuid,status:enum:OrderStatus", Runnable: true}); err != nil {
+		t.Fatal(err)
+	}
+	test := readPath(t, project, "Worker/src/test/java/com/example/demo/worker/handler/ShipOrderJobRequestHandlerTest.java")
+	for _, w := range []string{
+		"import com.example.demo.model.entities.OrderStatus;",
+		"import java.util.UUID;",
+		`new ShipOrderJobRequest(UUID.fromString("00000000-0000-0000-0000-000000000001"), OrderStatus.values()[0])`,
+	} {
+		if !strings.Contains(test, w) {
+			t.Errorf("handler test missing %q:\n%s", w, test)
+		}
+	}
+	if req := readPath(t, project, "Model/src/main/java/com/example/demo/model/jobs/ShipOrderJobRequest.java"); !strings.Contains(req, "import com.example.demo.model.entities.OrderStatus;") {
+		t.Errorf("job request should import its enum:\n%s", req)
+	}
+}
+
 func TestGenerateJob_RequiresWorker(t *testing.T) {
 	project := setupProject(t, apiSqlPgFixture())
 	ctx := mustCtx(t, project)
@@ -273,6 +364,76 @@ func TestGenerateEndpoint_Errors(t *testing.T) {
 	}
 }
 
+func TestGenerateEndpoint_Resource(t *testing.T) {
+	project := setupProject(t, apiSqlPgFixture())
+	ctx := mustCtx(t, project)
+	result, err := GenerateEndpoint(ctx, EndpointOpts{
+		Name:    "OrderItem",
+		Path:    "/api/order-items",
+		Methods: []string{"post", "GET"},
+		Fields:  "sku:string,note:string?",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 5 {
+		t.Fatalf("expected request, response, controller, test and service, got %v", result.Created)
+	}
+	files := map[string][]string{
+		"Model/src/main/java/com/example/demo/model/dto/OrderItemRequest.java": {
+			"@NotBlank(message = \"sku is required\")",
+			"  @Nullable\n  @Size(max = 255, message = \"note must not exceed 255 characters\")\n  String note();",
+		},
+		"Model/src/main/java/com/example/demo/model/dto/OrderItemResponse.java": {
+			"@JsonDeserialize(as = ImmutableOrderItemResponse.class)",
+			"  String id();",
+		},
+		"API/src/main/java/com/example/demo/api/controller/OrderItemController.java": {
+			"@RequestMapping(\"/api/order-items\")",
+			"@Operation(summary = \"Get an order item by ID\")",
+			"@PreAuthorize(\"hasAuthority('SCOPE_order-item:write')\")",
+			"return service.findById(id).orElseThrow(this::notFound);",
+		},
+		"API/src/test/java/com/example/demo/api/controller/OrderItemControllerTest.java": {
+			".setControllerAdvice(new GlobalExceptionHandler())",
+			"void getById_returns404ProblemDetailForUnknownId()",
+			"void create_rejectsIncompleteBody()",
+		},
+		"Shared/src/main/java/com/example/demo/shared/service/OrderItemService.java": {
+			"public List<ImmutableOrderItemResponse> list() {",
+			"public ImmutableOrderItemResponse create(ImmutableOrderItemRequest request) {",
+		},
+	}
+	for rel, wants := range files {
+		body := readPath(t, project, rel)
+		for _, w := range wants {
+			if !strings.Contains(body, w) {
+				t.Errorf("%s missing %q\ngot:\n%s", rel, w, body)
+			}
+		}
+	}
+	controller := readPath(t, project, "API/src/main/java/com/example/demo/api/controller/OrderItemController.java")
+	if strings.Contains(controller, "@PutMapping") || strings.Contains(controller, "@DeleteMapping") {
+		t.Errorf("only GET and POST were requested:\n%s", controller)
+	}
+}
+
+func TestGenerateEndpoint_ResourceErrors(t *testing.T) {
+	project := setupProject(t, apiSqlPgFixture())
+	ctx := mustCtx(t, project)
+	for _, tc := range []struct {
+		opts EndpointOpts
+		want string
+	}{
+		{EndpointOpts{Name: "Order", Methods: []string{"PATCH"}}, "unsupported method"},
+		{EndpointOpts{Name: "Order", Methods: []string{"GET"}, Fields: "id:long"}, "reserved"},
+	} {
+		if _, err := GenerateEndpoint(ctx, tc.opts); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: expected %q, got %v", tc.opts, tc.want, err)
+		}
+	}
+}
+
 // --- StreamingEndpoint ---
 
 func TestGenerateStreamingEndpoint(t *testing.T) {
@@ -153,7 +153,7 @@ func buildTestContent(ctx *Context, opts TestOpts, target, javaPkg string) (stri
 		return renderIntegrationTest(target, javaPkg), nil
 	case TestTypeRepository:
 		if opts.Module == config.ModuleSQLDatastore {
-			return renderSQLRepositoryTest(target, javaPkg, ctx.Database), nil
+			return renderSQLRepositoryTest(target, javaPkg, ctx.Database, ctx.DatabaseImage()), nil
 		}
 		return renderMongoRepositoryTest(target, javaPkg), nil
 	}
@@ -195,13 +195,16 @@ func renderIntegrationTest(target, pkg string) string {
 	return b.String()
 }
 
-func renderSQLRepositoryTest(target, pkg, database string) string {
+func renderSQLRepositoryTest(target, pkg, database, image string) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "package %s;\n\n", pkg)
+	useContainer := database == config.DatabasePostgreSQL || database == config.DatabaseMySQL || database == config.DatabaseMariaDB
+	if useContainer {
+		b.WriteString("import org.junit.jupiter.api.Tag;\n")
+	}
 	b.WriteString("import org.junit.jupiter.api.Test;\n")
 	b.WriteString("import org.springframework.boot.test.autoconfigure.data.jdbc.DataJdbcTest;\n")
 	b.WriteString("import org.springframework.boot.test.autoconfigure.jdbc.AutoConfigureTestDatabase;\n")
-	useContainer := database == config.DatabasePostgreSQL || database == config.DatabaseMySQL
 	if useContainer {
 		b.WriteString("import org.springframework.boot.testcontainers.service.connection.ServiceConnection;\n")
 		b.WriteString("import org.testcontainers.junit.jupiter.Container;\n")
@@ -211,6 +214,8 @@ func renderSQLRepositoryTest(target, pkg, database string) string {
 			b.WriteString("import org.testcontainers.containers.PostgreSQLContainer;\n")
 		case config.DatabaseMySQL:
 			b.WriteString("import org.testcontainers.containers.MySQLContainer;\n")
+		case config.DatabaseMariaDB:
+			b.WriteString("import org.testcontainers.containers.MariaDBContainer;\n")
 		}
 	}
 	b.WriteString("\n")
@@ -218,6 +223,7 @@ func renderSQLRepositoryTest(target, pkg, database string) string {
 	b.WriteString("@DataJdbcTest\n")
 	b.WriteString("@AutoConfigureTestDatabase(replace = AutoConfigureTestDatabase.Replace.NONE)\n")
 	if useContainer {
+		b.WriteString("@Tag(\"testcontainers\")\n")
 		b.WriteString("@Testcontainers(disabledWithoutDocker = true)\n")
 	}
 	fmt.Fprintf(&b, "class %sTest {\n\n", target)
@@ -225,10 +231,13 @@ func renderSQLRepositoryTest(target, pkg, database string) string {
 		switch database {
 		case config.DatabasePostgreSQL:
 			b.WriteString("    @Container @ServiceConnection\n")
-			b.WriteString("    static PostgreSQLContainer<?> postgres = new PostgreSQLContainer<>(\"postgres:15-alpine\");\n\n")
+			b.WriteString("    static PostgreSQLContainer<?> postgres = new PostgreSQLContainer<>(\"" + image + "\");\n\n")
 		case config.DatabaseMySQL:
 			b.WriteString("    @Container @ServiceConnection\n")
-			b.WriteString("    static MySQLContainer<?> mysql = new MySQLContainer<>(\"mysql:8.0\");\n\n")
+			b.WriteString("    static MySQLContainer<?> mysql = new MySQLContainer<>(\"" + image + "\");\n\n")
+		case config.DatabaseMariaDB:
+			b.WriteString("    @Container @ServiceConnection\n")
+			b.WriteString("    static MariaDBContainer<?> mariadb = new MariaDBContainer<>(\"" + image + "\");\n\n")
 		}
 	}
 	b.WriteString("    @Test\n")
@@ -243,6 +252,7 @@ func renderSQLRepositoryTest(target, pkg, database string) string {
 func renderMongoRepositoryTest(target, pkg string) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "package %s;\n\n", pkg)
+	b.WriteString("import org.junit.jupiter.api.Tag;\n")
 	b.WriteString("import org.junit.jupiter.api.Test;\n")
 	b.WriteString("import org.springframework.boot.test.autoconfigure.data.mongo.DataMongoTest;\n")
 	b.WriteString("import org.springframework.boot.testcontainers.service.connection.ServiceConnection;\n")
@@ -251,6 +261,7 @@ func renderMongoRepositoryTest(target, pkg string) string {
 	b.WriteString("import org.testcontainers.junit.jupiter.Testcontainers;\n\n")
 	b.WriteString("import static org.junit.jupiter.api.Assertions.*;\n\n")
 	b.WriteString("@DataMongoTest\n")
+	b.WriteString("@Tag(\"testcontainers\")\n")
 	b.WriteString("@Testcontainers(disabledWithoutDocker = true)\n")
 	fmt.Fprintf(&b, "class %sTest {\n\n", target)
 	b.WriteString("    @Container @ServiceConnection\n")
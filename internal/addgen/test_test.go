@@ -90,7 +90,7 @@ func TestGenerateTest(t *testing.T) {
 			wantContains: []string{
 				"@DataJdbcTest",
 				"@AutoConfigureTestDatabase(replace = AutoConfigureTestDatabase.Replace.NONE)",
-				"@Testcontainers(disabledWithoutDocker = true)",
+				"@Tag(\"testcontainers\")\n@Testcontainers(disabledWithoutDocker = true)",
 				"PostgreSQLContainer",
 				"postgres:15-alpine",
 			},
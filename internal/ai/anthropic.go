@@ -106,9 +106,11 @@ func (p *AnthropicProvider) Analyze(ctx context.Context, request *AnalysisReques
 	}
 
 	// Make the API call
-	message, err := p.client.Messages.New(ctx, params)
+	callCtx, cancel := withCallTimeout(ctx, p.config)
+	defer cancel()
+	message, err := p.client.Messages.New(callCtx, params)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrProviderError, err)
+		return nil, callError(ctx, callCtx, p.config, err)
 	}
 
 	// Extract text content
@@ -170,7 +172,9 @@ func (p *AnthropicProvider) Stream(ctx context.Context, request *AnalysisRequest
 		}
 
 		// Create streaming request
-		stream := p.client.Messages.NewStreaming(ctx, params)
+		callCtx, cancel := withCallTimeout(ctx, p.config)
+		defer cancel()
+		stream := p.client.Messages.NewStreaming(callCtx, params)
 
 		var totalInputTokens, totalOutputTokens int
 
@@ -191,7 +195,7 @@ func (p *AnthropicProvider) Stream(ctx context.Context, request *AnalysisRequest
 		}
 
 		if err := stream.Err(); err != nil {
-			ch <- StreamChunk{Error: fmt.Errorf("%w: %v", ErrProviderError, err)}
+			ch <- StreamChunk{Error: callError(ctx, callCtx, p.config, err)}
 			return
 		}
 
@@ -228,6 +228,9 @@ func (p *OpenRouterProvider) Analyze(ctx context.Context, request *AnalysisReque
 	// Make request
 	resp, err := p.client.Do(httpReq)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, fmt.Errorf("%w: %v", ErrProviderError, err)
 	}
 	defer resp.Body.Close()
@@ -3,6 +3,8 @@ package ai
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 )
 
 // Provider represents an AI provider that can analyze and transform code
@@ -232,6 +234,7 @@ var (
 	ErrProviderError    = errors.New("AI provider error")
 	ErrNoAPIKey         = errors.New("no API key provided")
 	ErrModelNotFound    = errors.New("model not found")
+	ErrTimeout          = errors.New("AI call timed out")
 )
 
 // ProviderConfig holds configuration for creating a provider
@@ -245,7 +248,8 @@ type ProviderConfig struct {
 	// BaseURL overrides the default API endpoint (for proxies)
 	BaseURL string
 
-	// Timeout in seconds for API calls
+	// Timeout in seconds for each API call, retries included; 0 leaves
+	// calls bounded only by the caller's context (OpenRouter: 120s)
 	Timeout int
 
 	// MaxRetries for failed requests
@@ -282,3 +286,24 @@ func EstimateTokens(content string) int {
 	}
 	return tokens
 }
+
+// withCallTimeout bounds one API call by the configured timeout
+func withCallTimeout(ctx context.Context, config *ProviderConfig) (context.Context, context.CancelFunc) {
+	if config == nil || config.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(config.Timeout)*time.Second)
+}
+
+// callError wraps an API call's error. A cancelled or expired caller
+// context is returned as is, so callers can tell an interrupt from a
+// provider failure; the call's own timeout becomes ErrTimeout.
+func callError(ctx, callCtx context.Context, config *ProviderConfig, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if callCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%w: no response within %ds", ErrTimeout, config.Timeout)
+	}
+	return fmt.Errorf("%w: %v", ErrProviderError, err)
+}
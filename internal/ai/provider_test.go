@@ -1,7 +1,12 @@
 package ai
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestModelPricing(t *testing.T) {
@@ -314,3 +319,37 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("MaxRetries = %v, want 3", config.MaxRetries)
 	}
 }
+
+func TestAnthropicAnalyze_TimeoutAndCancel(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	p, err := NewAnthropicProvider(&ProviderConfig{APIKey: "test", BaseURL: srv.URL, Timeout: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &AnalysisRequest{UserPrompt: "hi", MaxTokens: 10}
+
+	start := time.Now()
+	_, err = p.Analyze(context.Background(), req)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("err = %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("the call took %s despite a 1s timeout", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	_, err = p.Analyze(ctx, req)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
@@ -225,4 +225,5 @@ var (
 	ErrProviderNotFound  = errors.New("provider not found")
 	ErrInvalidCredential = errors.New("invalid credential")
 	ErrStorageError      = errors.New("credential storage error")
+	ErrNoProject         = errors.New("not inside a Trabuco project")
 )
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// helperServerURL is the key the credential store is saved under in a
+// credential helper
+const helperServerURL = "trabuco://credentials"
+
+// HelperStorage keeps the credential store in an external credential
+// helper speaking the docker-credential-helpers protocol, such as
+// docker-credential-pass, -osxkeychain, -wincred or -secretservice. The
+// store is saved as the secret of one entry.
+type HelperStorage struct {
+	program string
+}
+
+// NewHelperStorage creates a storage backend for helper: a name like
+// "pass" for docker-credential-pass on the PATH, or the path of a program
+// implementing the same get/store/erase protocol
+func NewHelperStorage(helper string) *HelperStorage {
+	program := helper
+	if !strings.ContainsAny(helper, `/\`) && !strings.HasPrefix(helper, "docker-credential-") {
+		program = "docker-credential-" + helper
+	}
+	return &HelperStorage{program: program}
+}
+
+// Name returns the storage backend name
+func (h *HelperStorage) Name() string {
+	return "credential helper (" + h.program + ")"
+}
+
+// Load retrieves credentials from the helper
+func (h *HelperStorage) Load() (*CredentialStore, error) {
+	out, err := h.run("get", helperServerURL)
+	if err != nil {
+		if strings.Contains(err.Error(), "credentials not found") {
+			return NewCredentialStore(), nil
+		}
+		return nil, fmt.Errorf("helper load: %w", err)
+	}
+
+	var entry struct {
+		Secret string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out, &entry); err != nil {
+		return nil, fmt.Errorf("helper unmarshal: %w", err)
+	}
+	var store CredentialStore
+	if err := json.Unmarshal([]byte(entry.Secret), &store); err != nil {
+		return nil, fmt.Errorf("helper unmarshal: %w", err)
+	}
+	return &store, nil
+}
+
+// Save persists credentials to the helper
+func (h *HelperStorage) Save(store *CredentialStore) error {
+	data, err := json.Marshal(store)
+	if err != nil {
+		return fmt.Errorf("helper marshal: %w", err)
+	}
+	entry, err := json.Marshal(map[string]string{
+		"ServerURL": helperServerURL,
+		"Username":  accountName,
+		"Secret":    string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("helper marshal: %w", err)
+	}
+	if _, err := h.run("store", string(entry)); err != nil {
+		return fmt.Errorf("helper save: %w", err)
+	}
+	return nil
+}
+
+// Clear removes credentials from the helper
+func (h *HelperStorage) Clear() error {
+	if _, err := h.run("erase", helperServerURL); err != nil {
+		if strings.Contains(err.Error(), "credentials not found") {
+			return nil
+		}
+		return fmt.Errorf("helper clear: %w", err)
+	}
+	return nil
+}
+
+// run calls the helper with a protocol action and its stdin. Helpers
+// report errors, "credentials not found in native keychain" among them,
+// on stdout.
+func (h *HelperStorage) run(action, input string) ([]byte, error) {
+	cmd := exec.Command(h.program, action)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if msg == "" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s %s: %s", h.program, action, msg)
+	}
+	return stdout.Bytes(), nil
+}
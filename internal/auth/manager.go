@@ -4,27 +4,40 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 )
 
+// ProjectCredentialsFile holds a project's own credentials, relative to
+// the project root. They override the global ones for commands run inside
+// the project.
+const ProjectCredentialsFile = ".trabuco/credentials"
+
 // Manager handles credential operations
 type Manager struct {
 	storage Storage
 	store   *CredentialStore
+
+	projectDir     string // Root of the enclosing Trabuco project; empty outside one
+	projectStorage Storage
+	projectStore   *CredentialStore
 }
 
-// NewManager creates a new credential manager
+// NewManager creates a new credential manager. Inside a Trabuco project
+// the project's credentials are layered over the global ones.
 func NewManager() (*Manager, error) {
-	storage := GetPreferredStorage()
-	store, err := storage.Load()
+	m, err := NewManagerWithStorage(GetPreferredStorage())
 	if err != nil {
-		return nil, fmt.Errorf("failed to load credentials: %w", err)
+		return nil, err
 	}
-
-	return &Manager{
-		storage: storage,
-		store:   store,
-	}, nil
+	if cwd, err := os.Getwd(); err == nil {
+		if root := findProjectRoot(cwd); root != "" {
+			if err := m.UseProject(root); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return m, nil
 }
 
 // NewManagerWithStorage creates a manager with a specific storage backend
@@ -40,6 +53,39 @@ func NewManagerWithStorage(storage Storage) (*Manager, error) {
 	}, nil
 }
 
+// UseProject layers the credentials of the project at projectDir over the
+// global ones. They are kept in an encrypted file, like the file backend.
+func (m *Manager) UseProject(projectDir string) error {
+	storage := NewFileStorage(filepath.Join(projectDir, ProjectCredentialsFile))
+	store, err := storage.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load project credentials: %w", err)
+	}
+	m.projectDir, m.projectStorage, m.projectStore = projectDir, storage, store
+	return nil
+}
+
+// ProjectDir returns the project whose credentials are in use, empty when
+// only global credentials are
+func (m *Manager) ProjectDir() string {
+	return m.projectDir
+}
+
+// findProjectRoot returns the closest directory from dir up that holds a
+// .trabuco.json, or "" when there is none
+func findProjectRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".trabuco.json")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
 // StorageBackend returns the name of the storage backend being used
 func (m *Manager) StorageBackend() string {
 	return m.storage.Name()
@@ -63,8 +109,29 @@ func (m *Manager) SetCredential(cred *Credential, validate bool) error {
 	return nil
 }
 
-// GetCredential retrieves a credential for a provider
+// SetProjectCredential stores a credential for the current project only
+func (m *Manager) SetProjectCredential(cred *Credential) error {
+	if m.projectStore == nil {
+		return ErrNoProject
+	}
+	if err := ValidateAPIKey(cred.Provider, cred.APIKey); err != nil {
+		return err
+	}
+	m.projectStore.SetCredential(cred)
+	if err := m.projectStorage.Save(m.projectStore); err != nil {
+		return fmt.Errorf("failed to save project credentials: %w", err)
+	}
+	return nil
+}
+
+// GetCredential retrieves a credential for a provider, the project's
+// before the global one
 func (m *Manager) GetCredential(provider Provider) (*Credential, error) {
+	if m.projectStore != nil {
+		if cred, ok := m.projectStore.GetCredential(provider); ok {
+			return cred, nil
+		}
+	}
 	cred, ok := m.store.GetCredential(provider)
 	if !ok {
 		return nil, ErrProviderNotFound
@@ -72,8 +139,14 @@ func (m *Manager) GetCredential(provider Provider) (*Credential, error) {
 	return cred, nil
 }
 
-// GetDefaultCredential returns the default provider's credential
+// GetDefaultCredential returns the default provider's credential. A
+// project's default wins over the global one.
 func (m *Manager) GetDefaultCredential() (*Credential, error) {
+	if m.projectStore != nil {
+		if cred, ok := m.projectStore.GetDefaultCredential(); ok {
+			return cred, nil
+		}
+	}
 	cred, ok := m.store.GetDefaultCredential()
 	if !ok {
 		return nil, ErrNoCredentials
@@ -81,10 +154,33 @@ func (m *Manager) GetDefaultCredential() (*Credential, error) {
 	return cred, nil
 }
 
+// ResolveCredential returns the credential commands use for provider, and
+// where it comes from: "env:VAR_NAME", "project" or "stored"
+func (m *Manager) ResolveCredential(provider Provider) (*Credential, string, error) {
+	info, ok := SupportedProviders[provider]
+	if !ok {
+		return nil, "", ErrProviderNotFound
+	}
+	if info.EnvVar != "" {
+		if key := os.Getenv(info.EnvVar); key != "" {
+			return &Credential{Provider: provider, APIKey: key}, "env:" + info.EnvVar, nil
+		}
+	}
+	if m.projectStore != nil {
+		if cred, ok := m.projectStore.GetCredential(provider); ok {
+			return cred, "project", nil
+		}
+	}
+	if cred, ok := m.store.GetCredential(provider); ok {
+		return cred, "stored", nil
+	}
+	return nil, "", ErrProviderNotFound
+}
+
 // GetCredentialWithFallback returns credentials in order of preference:
 // 1. Environment variable for the specified provider
-// 2. Stored credential for the specified provider
-// 3. Default stored credential
+// 2. Stored credential for the specified provider, the project's first
+// 3. Default stored credential, the project's first
 // 4. Any environment variable
 func (m *Manager) GetCredentialWithFallback(preferredProvider Provider) (*Credential, error) {
 	// 1. Check environment variable for preferred provider
@@ -132,6 +228,24 @@ func (m *Manager) RemoveCredential(provider Provider) error {
 	return m.storage.Save(m.store)
 }
 
+// RemoveProjectCredential removes a credential of the current project
+func (m *Manager) RemoveProjectCredential(provider Provider) error {
+	if m.projectStore == nil {
+		return ErrNoProject
+	}
+	m.projectStore.RemoveCredential(provider)
+	return m.projectStorage.Save(m.projectStore)
+}
+
+// ClearProject removes all credentials of the current project
+func (m *Manager) ClearProject() error {
+	if m.projectStore == nil {
+		return ErrNoProject
+	}
+	m.projectStore = NewCredentialStore()
+	return m.projectStorage.Clear()
+}
+
 // SetDefault sets the default provider
 func (m *Manager) SetDefault(provider Provider) error {
 	if err := m.store.SetDefault(provider); err != nil {
@@ -156,7 +270,7 @@ func (m *Manager) ListConfigured() []ProviderStatus {
 			IsDefault: provider == m.store.DefaultProvider,
 		}
 
-		// Check stored credential
+		// Check stored credential (a project's overrides the global one)
 		if cred, ok := m.store.GetCredential(provider); ok {
 			status.Configured = true
 			status.ValidatedAt = cred.ValidatedAt
@@ -165,6 +279,14 @@ func (m *Manager) ListConfigured() []ProviderStatus {
 				status.Model = cred.Model
 			}
 		}
+		if m.projectStore != nil {
+			if cred, ok := m.projectStore.GetCredential(provider); ok {
+				status.Configured = true
+				status.ValidatedAt = cred.ValidatedAt
+				status.Source = "project"
+				status.Model = cred.Model
+			}
+		}
 
 		// Check environment variable (overrides stored)
 		if info.EnvVar != "" {
@@ -189,8 +311,15 @@ func (m *Manager) Clear() error {
 	return m.storage.Clear()
 }
 
-// MarkValidated marks a credential as validated
+// MarkValidated marks a credential as validated, in the store it is
+// resolved from
 func (m *Manager) MarkValidated(provider Provider) error {
+	if m.projectStore != nil {
+		if cred, ok := m.projectStore.GetCredential(provider); ok {
+			cred.ValidatedAt = time.Now()
+			return m.projectStorage.Save(m.projectStore)
+		}
+	}
 	cred, ok := m.store.GetCredential(provider)
 	if !ok {
 		return ErrProviderNotFound
@@ -202,7 +331,7 @@ func (m *Manager) MarkValidated(provider Provider) error {
 // HasAnyCredentials returns true if any credentials are configured
 func (m *Manager) HasAnyCredentials() bool {
 	// Check stored credentials
-	if len(m.store.Credentials) > 0 {
+	if len(m.store.Credentials) > 0 || (m.projectStore != nil && len(m.projectStore.Credentials) > 0) {
 		return true
 	}
 
@@ -222,7 +351,7 @@ type ProviderStatus struct {
 	Info        ProviderInfo
 	Configured  bool
 	IsDefault   bool
-	Source      string    // "stored", "env:VAR_NAME"
+	Source      string    // "stored", "project", "env:VAR_NAME"
 	ValidatedAt time.Time
 	Model       string
 }
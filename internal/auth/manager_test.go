@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestManager_ProjectCredentialsOverrideGlobal(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	t.Setenv("OPENAI_API_KEY", "")
+	m, err := NewManagerWithStorage(NewFileStorage(filepath.Join(t.TempDir(), "credentials.enc")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetCredential(&Credential{Provider: ProviderAnthropic, APIKey: "sk-ant-global"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetCredential(&Credential{Provider: ProviderOpenAI, APIKey: "sk-openai-global"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetProjectCredential(&Credential{Provider: ProviderAnthropic, APIKey: "sk-ant-project"}); !errors.Is(err, ErrNoProject) {
+		t.Errorf("SetProjectCredential outside a project = %v, want ErrNoProject", err)
+	}
+
+	projectDir := t.TempDir()
+	if err := m.UseProject(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetProjectCredential(&Credential{Provider: ProviderAnthropic, APIKey: "sk-ant-project"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, ProjectCredentialsFile)); err != nil {
+		t.Fatalf("project credentials not written: %v", err)
+	}
+
+	// A fresh manager reads both layers back
+	m, err = NewManagerWithStorage(m.storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.UseProject(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	if cred, source, _ := m.ResolveCredential(ProviderAnthropic); cred == nil || cred.APIKey != "sk-ant-project" || source != "project" {
+		t.Errorf("anthropic = %+v from %q, want the project key", cred, source)
+	}
+	if cred, source, _ := m.ResolveCredential(ProviderOpenAI); cred == nil || cred.APIKey != "sk-openai-global" || source != "stored" {
+		t.Errorf("openai = %+v from %q, want the global key", cred, source)
+	}
+
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-env")
+	if cred, source, _ := m.ResolveCredential(ProviderAnthropic); cred.APIKey != "sk-ant-env" || source != "env:ANTHROPIC_API_KEY" {
+		t.Errorf("the environment should win, got %+v from %q", cred, source)
+	}
+
+	if err := m.RemoveProjectCredential(ProviderAnthropic); err != nil {
+		t.Fatal(err)
+	}
+	if cred, _ := m.GetCredential(ProviderAnthropic); cred == nil || cred.APIKey != "sk-ant-global" {
+		t.Errorf("removing the project key should fall back to the global one, got %+v", cred)
+	}
+}
+
+func TestFindProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".trabuco.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "API", "src")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := findProjectRoot(nested); got != root {
+		t.Errorf("findProjectRoot = %q, want %q", got, root)
+	}
+}
+
+func TestTestCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/models" && r.Header.Get("x-api-key") == "sk-ant-good":
+			w.Write([]byte(`{"data": []}`))
+		case r.URL.Path == "/v1/key" && r.Header.Get("Authorization") == "Bearer sk-or-good":
+			w.Write([]byte(`{"data": {}}`))
+		case r.URL.Path == "/api/tags":
+			w.Write([]byte(`{"models": []}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		cred    Credential
+		invalid bool
+	}{
+		{Credential{Provider: ProviderAnthropic, APIKey: "sk-ant-good"}, false},
+		{Credential{Provider: ProviderAnthropic, APIKey: "sk-ant-bad"}, true},
+		{Credential{Provider: ProviderOpenRouter, APIKey: "sk-or-good"}, false},
+		{Credential{Provider: ProviderOpenAI, APIKey: "sk-bad"}, true},
+		{Credential{Provider: ProviderOllama}, false},
+	} {
+		tc.cred.BaseURL = server.URL
+		err := TestCredential(context.Background(), &tc.cred)
+		if tc.invalid != errors.Is(err, ErrInvalidCredential) || (!tc.invalid && err != nil) {
+			t.Errorf("%s %s: err = %v", tc.cred.Provider, tc.cred.APIKey, err)
+		}
+	}
+}
+
+func TestHelperStorage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake helper is a shell script")
+	}
+	// A docker-credential helper keeping one secret in a file
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "docker-credential-fake")
+	script := `#!/bin/sh
+store="` + filepath.Join(dir, "entry") + `"
+case "$1" in
+  store) cat > "$store" ;;
+  get) if [ -f "$store" ]; then cat "$store"; else echo "credentials not found in native keychain"; exit 1; fi ;;
+  erase) rm -f "$store" ;;
+esac
+`
+	if err := os.WriteFile(helper, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	storage := NewHelperStorage(helper)
+	store, err := storage.Load()
+	if err != nil || len(store.Credentials) != 0 {
+		t.Fatalf("an empty helper should load an empty store, got %v, %v", store, err)
+	}
+	store.SetCredential(&Credential{Provider: ProviderAnthropic, APIKey: "sk-ant-helper"})
+	if err := storage.Save(store); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := storage.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred, ok := loaded.GetCredential(ProviderAnthropic); !ok || cred.APIKey != "sk-ant-helper" {
+		t.Errorf("loaded %+v", loaded.Credentials)
+	}
+	if err := storage.Clear(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := NewHelperStorage("pass").program; got != "docker-credential-pass" {
+		t.Errorf("program = %s", got)
+	}
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// probeEndpoints is the cheapest authenticated endpoint of each provider:
+// a listing that costs no tokens. Paths are relative to the base URL.
+var probeEndpoints = map[Provider]string{
+	ProviderAnthropic:  "/v1/models?limit=1",
+	ProviderOpenRouter: "/v1/key",
+	ProviderOpenAI:     "/v1/models",
+	ProviderOllama:     "/api/tags",
+}
+
+// probeClient is the HTTP client credential probes use
+var probeClient = &http.Client{Timeout: 15 * time.Second}
+
+// TestCredential checks a credential against its provider without spending
+// tokens. A rejected key wraps ErrInvalidCredential; an unreachable or
+// failing provider is reported as is.
+func TestCredential(ctx context.Context, cred *Credential) error {
+	info, ok := SupportedProviders[cred.Provider]
+	if !ok {
+		return ErrProviderNotFound
+	}
+	baseURL := info.BaseURL
+	if cred.BaseURL != "" {
+		baseURL = cred.BaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+probeEndpoints[cred.Provider], nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	switch cred.Provider {
+	case ProviderAnthropic:
+		req.Header.Set("x-api-key", cred.APIKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+	case ProviderOpenRouter, ProviderOpenAI:
+		req.Header.Set("Authorization", "Bearer "+cred.APIKey)
+	}
+
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s unreachable: %w", info.Name, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s rejected the key (HTTP %d)", ErrInvalidCredential, info.Name, resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("%s answered HTTP %d: %s", info.Name, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
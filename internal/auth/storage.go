@@ -352,7 +352,11 @@ func linuxSecretGet(service, account string) (string, error) {
 	cmd := exec.Command("secret-tool", "lookup", "service", service, "account", account)
 	out, err := cmd.Output()
 	if err != nil {
-		return "", errKeychainItemNotFound
+		// secret-tool exits 1 without output when nothing matches
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(out) == 0 {
+			return "", errKeychainItemNotFound
+		}
+		return "", err
 	}
 	return strings.TrimSpace(string(out)), nil
 }
@@ -368,37 +372,129 @@ func linuxSecretDelete(service, account string) error {
 	return cmd.Run()
 }
 
-// Windows Credential Manager using cmdkey
-func windowsCredentialGet(service, account string) (string, error) {
-	target := service + "/" + account
-	cmd := exec.Command("cmdkey", "/list:"+target)
+// Windows Credential Manager through PowerShell and the CredRead/CredWrite
+// API. cmdkey can store a generic credential but never reads its secret
+// back, and it takes the secret on the command line.
+const windowsCredentialScript = `
+$ErrorActionPreference = 'Stop'
+Add-Type -TypeDefinition @'
+using System;
+using System.Runtime.InteropServices;
+public static class TrabucoCred {
+  [StructLayout(LayoutKind.Sequential, CharSet = CharSet.Unicode)]
+  public struct CREDENTIAL {
+    public int Flags; public int Type; public string TargetName; public string Comment;
+    public long LastWritten; public int CredentialBlobSize; public IntPtr CredentialBlob;
+    public int Persist; public int AttributeCount; public IntPtr Attributes;
+    public string TargetAlias; public string UserName;
+  }
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredRead(string target, int type, int flags, out IntPtr cred);
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredWrite(ref CREDENTIAL cred, int flags);
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredDelete(string target, int type, int flags);
+  [DllImport("advapi32.dll")]
+  public static extern void CredFree(IntPtr cred);
+}
+'@
+$target = $env:TRABUCO_CRED_TARGET
+switch ($args[0]) {
+  'get' {
+    $ptr = [IntPtr]::Zero
+    if (-not [TrabucoCred]::CredRead($target, 1, 0, [ref]$ptr)) { exit 44 }
+    $cred = [Runtime.InteropServices.Marshal]::PtrToStructure($ptr, [type][TrabucoCred+CREDENTIAL])
+    $bytes = New-Object byte[] $cred.CredentialBlobSize
+    [Runtime.InteropServices.Marshal]::Copy($cred.CredentialBlob, $bytes, 0, $bytes.Length)
+    [TrabucoCred]::CredFree($ptr)
+    [Console]::Out.Write([Text.Encoding]::UTF8.GetString($bytes))
+  }
+  'set' {
+    $bytes = [Text.Encoding]::UTF8.GetBytes([Console]::In.ReadToEnd())
+    $cred = New-Object TrabucoCred+CREDENTIAL
+    $cred.Type = 1; $cred.Persist = 2; $cred.TargetName = $target; $cred.UserName = $env:TRABUCO_CRED_USER
+    $cred.CredentialBlobSize = $bytes.Length
+    $cred.CredentialBlob = [Runtime.InteropServices.Marshal]::AllocHGlobal($bytes.Length)
+    [Runtime.InteropServices.Marshal]::Copy($bytes, 0, $cred.CredentialBlob, $bytes.Length)
+    $ok = [TrabucoCred]::CredWrite([ref]$cred, 0)
+    [Runtime.InteropServices.Marshal]::FreeHGlobal($cred.CredentialBlob)
+    if (-not $ok) { exit 1 }
+  }
+  'delete' {
+    if (-not [TrabucoCred]::CredDelete($target, 1, 0)) { exit 44 }
+  }
+}
+`
+
+// windowsCredential runs the credential script for op ("get", "set" or
+// "delete"); exit code 44 means the credential does not exist
+func windowsCredential(op, service, account, input string) (string, error) {
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", "& {"+windowsCredentialScript+"}", op)
+	cmd.Env = append(os.Environ(), "TRABUCO_CRED_TARGET="+service+"/"+account, "TRABUCO_CRED_USER="+account)
+	cmd.Stdin = strings.NewReader(input)
 	out, err := cmd.Output()
-	if err != nil || !strings.Contains(string(out), target) {
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 44 {
 		return "", errKeychainItemNotFound
 	}
-	// Windows doesn't expose passwords directly via cmdkey
-	// We use a file-based fallback for actual storage
-	return "", errors.New("windows credential read not supported, using file fallback")
+	return string(out), err
+}
+
+func windowsCredentialGet(service, account string) (string, error) {
+	return windowsCredential("get", service, account, "")
 }
 
 func windowsCredentialSet(service, account, password string) error {
-	target := service + "/" + account
-	cmd := exec.Command("cmdkey", "/generic:"+target, "/user:"+account, "/pass:"+password)
-	return cmd.Run()
+	_, err := windowsCredential("set", service, account, password)
+	return err
 }
 
 func windowsCredentialDelete(service, account string) error {
-	target := service + "/" + account
-	cmd := exec.Command("cmdkey", "/delete:"+target)
-	return cmd.Run()
+	_, err := windowsCredential("delete", service, account, "")
+	return err
 }
 
-// GetPreferredStorage returns the best available storage backend
+// keychainAvailable returns true if the tool the keychain of this platform
+// is reached through is installed
+func keychainAvailable() bool {
+	tool := map[string]string{
+		"darwin":  "security",
+		"linux":   "secret-tool",
+		"windows": "powershell",
+	}[runtime.GOOS]
+	if tool == "" {
+		return false
+	}
+	_, err := exec.LookPath(tool)
+	return err == nil
+}
+
+// CredentialHelperEnv names a docker-credential helper to store
+// credentials with instead of the keychain
+const CredentialHelperEnv = "TRABUCO_CREDENTIAL_HELPER"
+
+// CredentialStoreEnv forces a storage backend: "keychain" or "file"
+const CredentialStoreEnv = "TRABUCO_CREDENTIAL_STORE"
+
+// GetPreferredStorage returns the best available storage backend: the
+// credential helper when one is configured, then the system keychain, then
+// the encrypted file
 func GetPreferredStorage() Storage {
+	if helper := os.Getenv(CredentialHelperEnv); helper != "" {
+		return NewHelperStorage(helper)
+	}
+	switch os.Getenv(CredentialStoreEnv) {
+	case "file":
+		return NewFileStorage("")
+	case "keychain":
+		return NewKeychainStorage()
+	}
+
 	// Try keychain first
-	keychain := NewKeychainStorage()
-	if _, err := keychain.Load(); err == nil {
-		return keychain
+	if keychainAvailable() {
+		keychain := NewKeychainStorage()
+		if _, err := keychain.Load(); err == nil {
+			return keychain
+		}
 	}
 
 	// Fall back to encrypted file
@@ -18,10 +18,13 @@ var (
 	addNoSQLDatabase string
 	addMessageBroker string
 	addDryRun        bool
+	addDiff          bool
 	addNoBackup      bool
 	addSkipDoctor    bool
 	addSkipBuild     bool
 	addRunTests      bool
+	addForceDocs     bool
+	addProfile       string
 )
 
 var addCmd = &cobra.Command{
@@ -38,32 +41,47 @@ Available modules:
   Shared          - Services, Circuit breaker, auth utilities
   API             - REST endpoints + dormant OIDC Resource Server
   Worker          - Background jobs (JobRunr)
+  Events          - Event publisher only, for events another service consumes
   EventConsumer   - Event listeners (Kafka, RabbitMQ, SQS, Pub/Sub)
   AIAgent         - Spring AI agent + dormant OIDC Resource Server
   MCP             - MCP server for AI tool integration
 
+Database, broker and CI defaults come from ~/.trabuco/config.yaml (its
+defaults block and --profile/default_profile) when the flags are omitted.
+
 Adding API or AIAgent auto-resolves Shared as a dependency (it holds
 the auth runtime utilities) and emits OIDC Resource Server scaffolding
 that stays dormant until 'trabuco.auth.enabled=true' is set at runtime.
 See docs/auth.md for per-provider recipes.
 
+README.md and the AI agent files are updated in place: only the regions
+between <!-- trabuco:begin/end --> markers are regenerated, so your own
+sections and edits outside them are kept. Use --force-docs to overwrite
+the files entirely.
+
 Examples:
   trabuco add SQLDatastore
   trabuco add SQLDatastore --database=postgresql
   trabuco add EventConsumer --message-broker=kafka
+  trabuco add Events --message-broker=kafka
   trabuco add Worker --dry-run
+  trabuco add Worker --diff      # Preview file contents as unified diffs
+  trabuco add Worker --force-docs # Overwrite README.md and agent files
   trabuco add                    # Interactive mode`,
 	Run: runAdd,
 }
 
 func init() {
-	addCmd.Flags().StringVar(&addDatabase, "database", "", "SQL database type: postgresql, mysql, generic")
-	addCmd.Flags().StringVar(&addNoSQLDatabase, "nosql-database", "", "NoSQL database type: mongodb, redis")
-	addCmd.Flags().StringVar(&addMessageBroker, "message-broker", "", "Message broker: kafka, rabbitmq, sqs, pubsub")
+	addCmd.Flags().StringVar(&addDatabase, "database", "", "SQL database type: postgresql, mysql, mariadb, generic")
+	addCmd.Flags().StringVar(&addNoSQLDatabase, "nosql-database", "", "NoSQL database type: mongodb, redis, dynamodb, cassandra")
+	addCmd.Flags().StringVar(&addMessageBroker, "message-broker", "", "Message broker: kafka, rabbitmq, sqs, pubsub, redis-streams, nats")
 	addCmd.Flags().BoolVar(&addDryRun, "dry-run", false, "Show what would change without making changes")
+	addCmd.Flags().BoolVar(&addDiff, "diff", false, "Show the full content of new files and unified diffs of changed files without making changes")
 	addCmd.Flags().BoolVar(&addNoBackup, "no-backup", false, "Skip creating backup (not recommended)")
 	addCmd.Flags().BoolVar(&addSkipDoctor, "skip-doctor", false, "Skip doctor validation (not recommended)")
 	addCmd.Flags().BoolVar(&addSkipBuild, "skip-build", false, "Skip running 'mvn clean install' after adding module")
+	addCmd.Flags().BoolVar(&addForceDocs, "force-docs", false, "Overwrite README.md and AI agent files instead of updating only their Trabuco-managed regions")
+	addCmd.Flags().StringVar(&addProfile, "profile", "", "Defaults profile from ~/.trabuco/config.yaml (default: its default_profile)")
 	addCmd.Flags().BoolVar(&addRunTests, "run-tests", false, "Run the full test suite during the post-add build (omits -DskipTests). Used by e2e CI jobs.")
 }
 
@@ -111,6 +129,11 @@ func runAdd(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if metadata.Architecture == config.ArchitectureModulith {
+		red.Fprintf(os.Stderr, "Error: modules cannot be added to a %s project; its modules are packages of %s\n", config.ArchitectureModulith, config.ModulithModule)
+		os.Exit(1)
+	}
+
 	// Show detected project info
 	fmt.Println()
 	cyan.Printf("Detected Trabuco project: ")
@@ -156,10 +179,26 @@ func runAdd(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Step 5: Get module-specific options
+	// Step 5: Get module-specific options; unset flags fall back to the
+	// user config before prompting
+	profile, err := config.LoadProfile(addProfile)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defaults := profile.Defaults(metadata.ProjectName)
 	database := addDatabase
+	if database == "" {
+		database = defaults["database"]
+	}
 	nosqlDatabase := addNoSQLDatabase
+	if nosqlDatabase == "" {
+		nosqlDatabase = defaults["nosql_database"]
+	}
 	messageBroker := addMessageBroker
+	if messageBroker == "" {
+		messageBroker = defaults["message_broker"]
+	}
 
 	if module == config.ModuleSQLDatastore && database == "" {
 		database, err = prompts.PromptDatabase()
@@ -184,7 +223,9 @@ func runAdd(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	if module == config.ModuleEventConsumer && messageBroker == "" {
+	// The broker is fixed once Events exists (e.g. adding EventConsumer
+	// after the publisher), so only prompt when there is none yet
+	if (module == config.ModuleEvents || module == config.ModuleEventConsumer) && messageBroker == "" && !metadata.HasModule(config.ModuleEvents) {
 		messageBroker, err = prompts.PromptMessageBroker()
 		if err != nil {
 			red.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -194,6 +235,7 @@ func runAdd(cmd *cobra.Command, args []string) {
 
 	// Step 6: Create module adder
 	adder := generator.NewModuleAdder(projectPath, metadata, Version, !addNoBackup)
+	adder.SetForceDocs(addForceDocs)
 
 	// Step 7: Dry run if requested
 	if addDryRun {
@@ -203,6 +245,17 @@ func runAdd(cmd *cobra.Command, args []string) {
 		yellow.Println("This is a dry run. No changes were made.")
 		os.Exit(0)
 	}
+	if addDiff {
+		result, err := adder.Diff(module, database, nosqlDatabase, messageBroker)
+		if err != nil {
+			red.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		result.Print()
+		fmt.Println()
+		yellow.Println("This is a preview. No changes were made.")
+		os.Exit(0)
+	}
 
 	// Step 8: Show what will be added
 	dependencies := adder.ResolveDependencies(module)
@@ -219,9 +272,15 @@ func runAdd(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Step 10: Offer CI if not configured
+	// Step 10: Offer CI if not configured (the user config's CI provider
+	// is used without asking)
 	if metadata.CIProvider == "" {
-		ciProvider, err := prompts.PromptCIProvider()
+		recordHistory := trackHistory(projectPath, "add ci")
+		ciProvider := defaults["ci"]
+		var err error
+		if ciProvider == "" {
+			ciProvider, err = prompts.PromptCIProvider()
+		}
 		if err == nil && ciProvider != "" {
 			// Update metadata and config with CI provider
 			metadata.CIProvider = ciProvider
@@ -235,6 +294,7 @@ func runAdd(cmd *cobra.Command, args []string) {
 					if genErr = gen.GenerateCIWorkflow(); genErr != nil {
 						yellow.Fprintf(os.Stderr, "Warning: failed to generate CI workflow: %v\n", genErr)
 					} else {
+						recordHistory()
 						green.Println("  \u2713 Generated .github/workflows/ci.yml")
 					}
 				}
@@ -243,6 +303,7 @@ func runAdd(cmd *cobra.Command, args []string) {
 	}
 
 	// Step 11: Success message
+	adder.Report().Print()
 	fmt.Println()
 	green.Println("✓ Module added successfully!")
 	fmt.Println()
@@ -250,12 +311,6 @@ func runAdd(cmd *cobra.Command, args []string) {
 	// Step 11: Run Maven build unless skipped
 	if addSkipBuild {
 		fmt.Println("Skipping Maven build (--skip-build flag).")
-		fmt.Println()
-		cyan.Println("Next steps:")
-		fmt.Println("  mvn clean install")
-		if needsDocker(module, database, nosqlDatabase, messageBroker) {
-			fmt.Println("  docker-compose up -d")
-		}
 	} else {
 		// Run Maven build
 		if err := runMavenBuild(projectPath, addRunTests); err != nil {
@@ -267,30 +322,10 @@ func runAdd(cmd *cobra.Command, args []string) {
 			green.Println("✓ Maven build completed successfully!")
 			fmt.Println()
 		}
-		if needsDocker(module, database, nosqlDatabase, messageBroker) {
-			cyan.Println("Next steps:")
-			fmt.Println("  docker-compose up -d")
-		}
 	}
 
 }
 
-// needsDocker returns true if the module requires docker services
-func needsDocker(module, database, nosqlDatabase, messageBroker string) bool {
-	switch module {
-	case config.ModuleSQLDatastore:
-		return database == config.DatabasePostgreSQL || database == config.DatabaseMySQL
-	case config.ModuleNoSQLDatastore:
-		return nosqlDatabase != ""
-	case config.ModuleEventConsumer:
-		return messageBroker != ""
-	case config.ModuleWorker:
-		return true // Always needs something for JobRunr
-	default:
-		return false
-	}
-}
-
 // GetAvailableModulesToAdd returns the list of modules that can be added
 // Used by shell completion
 func GetAvailableModulesToAdd() []string {
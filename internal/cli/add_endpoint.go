@@ -51,6 +51,7 @@ func runAddEndpoint(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addEndpointDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add endpoint")
 
 	result, err := addgen.GenerateEndpoint(ctx, addgen.EndpointOpts{
 		Name: args[0],
@@ -61,5 +62,6 @@ func runAddEndpoint(cmd *cobra.Command, args []string) {
 		printAddError(err, addEndpointJSON)
 		os.Exit(1)
 	}
+	recordHistory()
 	printAddResult(result, addEndpointDryRun, addEndpointJSON)
 }
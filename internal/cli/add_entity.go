@@ -71,6 +71,7 @@ func runAddEntity(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addEntityDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add entity")
 
 	result, err := addgen.GenerateEntity(ctx, addgen.EntityOpts{
 		Name:      args[0],
@@ -82,5 +83,6 @@ func runAddEntity(cmd *cobra.Command, args []string) {
 		printAddError(err, addEntityJSON)
 		os.Exit(1)
 	}
+	recordHistory()
 	printAddResult(result, addEntityDryRun, addEntityJSON)
 }
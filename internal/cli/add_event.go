@@ -49,6 +49,7 @@ func runAddEvent(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addEventDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add event")
 
 	result, err := addgen.GenerateEvent(ctx, addgen.EventOpts{
 		Name:   args[0],
@@ -58,5 +59,6 @@ func runAddEvent(cmd *cobra.Command, args []string) {
 		printAddError(err, addEventJSON)
 		os.Exit(1)
 	}
+	recordHistory()
 	printAddResult(result, addEventDryRun, addEventJSON)
 }
@@ -52,6 +52,7 @@ func runAddJob(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addJobDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add job")
 
 	result, err := addgen.GenerateJob(ctx, addgen.JobOpts{
 		Name:    args[0],
@@ -61,5 +62,6 @@ func runAddJob(cmd *cobra.Command, args []string) {
 		printAddError(err, addJobJSON)
 		os.Exit(1)
 	}
+	recordHistory()
 	printAddResult(result, addJobDryRun, addJobJSON)
 }
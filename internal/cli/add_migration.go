@@ -57,6 +57,7 @@ func runAddMigration(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addMigrationDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add migration")
 
 	result, err := addgen.GenerateMigration(ctx, addgen.MigrationOpts{
 		Description: addMigrationDescription,
@@ -67,5 +68,6 @@ func runAddMigration(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	recordHistory()
 	printAddResult(result, addMigrationDryRun, addMigrationJSON)
 }
@@ -50,6 +50,7 @@ func runAddService(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addServiceDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add service")
 
 	result, err := addgen.GenerateService(ctx, addgen.ServiceOpts{
 		Name:   args[0],
@@ -59,5 +60,6 @@ func runAddService(cmd *cobra.Command, args []string) {
 		printAddError(err, addServiceJSON)
 		os.Exit(1)
 	}
+	recordHistory()
 	printAddResult(result, addServiceDryRun, addServiceJSON)
 }
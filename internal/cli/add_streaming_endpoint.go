@@ -49,6 +49,7 @@ func runAddStreamingEndpoint(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addStreamingDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add streaming-endpoint")
 
 	result, err := addgen.GenerateStreamingEndpoint(ctx, addgen.StreamingEndpointOpts{
 		Name: args[0],
@@ -58,5 +59,6 @@ func runAddStreamingEndpoint(cmd *cobra.Command, args []string) {
 		printAddError(err, addStreamingJSON)
 		os.Exit(1)
 	}
+	recordHistory()
 	printAddResult(result, addStreamingDryRun, addStreamingJSON)
 }
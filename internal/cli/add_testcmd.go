@@ -64,6 +64,7 @@ func runAddTest(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	ctx.DryRun = addTestDryRun
+	recordHistory := trackHistory(ctx.ProjectPath, "add test")
 
 	result, err := addgen.GenerateTest(ctx, addgen.TestOpts{
 		Target:     args[0],
@@ -75,5 +76,6 @@ func runAddTest(cmd *cobra.Command, args []string) {
 		printAddError(err, addTestJSON)
 		os.Exit(1)
 	}
+	recordHistory()
 	printAddResult(result, addTestDryRun, addTestJSON)
 }
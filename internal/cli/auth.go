@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
-	"github.com/arianlopezc/Trabuco/internal/ai"
 	"github.com/arianlopezc/Trabuco/internal/auth"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -19,6 +20,7 @@ var (
 	authAPIKey   string
 	authModel    string
 	authForce    bool
+	authProject  bool
 )
 
 var authCmd = &cobra.Command{
@@ -29,11 +31,18 @@ features that call out to LLMs (e.g., the upcoming 1.10 migration feature).
 
 Trabuco securely stores your API keys in the system keychain (macOS Keychain,
 Linux Secret Service, or Windows Credential Manager) with fallback to an
-encrypted file.
+encrypted file. Set TRABUCO_CREDENTIAL_HELPER to keep them in a
+docker-credential helper instead (e.g. "pass" for docker-credential-pass),
+or TRABUCO_CREDENTIAL_STORE=file|keychain to force a backend.
+
+Inside a Trabuco project, keys saved with --project go to the project's
+.trabuco/credentials (encrypted, git-ignored) and override the global keys
+there. Environment variables override both.
 
 SUBCOMMANDS:
   login      Configure credentials for an LLM provider
   status     Show configured providers and their status
+  test       Check the configured keys against their providers
   logout     Remove stored credentials
   providers  List supported LLM providers with pricing info
 
@@ -47,9 +56,15 @@ Examples:
   # Login with API key directly
   trabuco auth login --provider anthropic --api-key sk-ant-...
 
+  # Use another key for the project in the current directory
+  trabuco auth login --provider anthropic --project
+
   # Check configured providers
   trabuco auth status
 
+  # Check that every configured key still works
+  trabuco auth test
+
   # Remove all credentials
   trabuco auth logout`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -87,6 +102,20 @@ If no provider is specified, all credentials will be removed.`,
 	Run:  runAuthLogout,
 }
 
+var authTestCmd = &cobra.Command{
+	Use:   "test [provider]",
+	Short: "Check the configured keys against their providers",
+	Long: `Check each configured key, or the one of the given provider, with the
+cheapest authenticated request the provider offers: listing models (or, on
+OpenRouter, the key's own info). No tokens are spent.
+
+The key checked is the one Trabuco would use: the environment variable,
+then the project key, then the global one. Stored keys that pass are
+marked validated. Exits with status 1 when any check fails.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runAuthTest,
+}
+
 var authProvidersCmd = &cobra.Command{
 	Use:   "providers",
 	Short: "List supported LLM providers with pricing info",
@@ -100,13 +129,16 @@ func init() {
 	authLoginCmd.Flags().StringVar(&authAPIKey, "api-key", "", "API key (alternative to interactive input)")
 	authLoginCmd.Flags().StringVar(&authModel, "model", "", "Default model to use")
 	authLoginCmd.Flags().BoolVarP(&authForce, "force", "f", false, "Overwrite existing credentials")
+	authLoginCmd.Flags().BoolVar(&authProject, "project", false, "Store the key for the current project only (.trabuco/credentials)")
 
 	// Logout flags
 	authLogoutCmd.Flags().BoolVarP(&authForce, "force", "f", false, "Skip confirmation")
+	authLogoutCmd.Flags().BoolVar(&authProject, "project", false, "Remove the current project's keys instead of the global ones")
 
 	// Add subcommands
 	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authTestCmd)
 	authCmd.AddCommand(authLogoutCmd)
 	authCmd.AddCommand(authProvidersCmd)
 }
@@ -128,7 +160,15 @@ func runAuthLogin(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Using storage: %s\n\n", manager.StorageBackend())
+	if authProject {
+		if manager.ProjectDir() == "" {
+			red.Fprintf(os.Stderr, "Error: --project needs to run inside a Trabuco project (no .trabuco.json found)\n")
+			os.Exit(1)
+		}
+		fmt.Printf("Using storage: %s\n\n", filepath.Join(manager.ProjectDir(), auth.ProjectCredentialsFile))
+	} else {
+		fmt.Printf("Using storage: %s\n\n", manager.StorageBackend())
+	}
 
 	// Select provider
 	var provider auth.Provider
@@ -169,7 +209,10 @@ func runAuthLogin(cmd *cobra.Command, args []string) {
 	info := auth.SupportedProviders[provider]
 
 	// Check if already configured
-	existing, _ := manager.GetCredential(provider)
+	existing, source, _ := manager.ResolveCredential(provider)
+	if scope := map[bool]string{false: "stored", true: "project"}[authProject]; source != scope {
+		existing = nil // From the environment or the other scope; not overwritten
+	}
 	if existing != nil && !authForce {
 		yellow.Printf("\n%s is already configured.\n", info.Name)
 		var overwrite bool
@@ -215,20 +258,16 @@ func runAuthLogin(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	valid, modelInfo := testProviderConnection(ctx, provider, apiKey)
-	if !valid {
+	if err := auth.TestCredential(ctx, &auth.Credential{Provider: provider, APIKey: apiKey}); err != nil {
 		fmt.Println()
 		red.Println("✗ Connection failed")
+		red.Printf("  %v\n", err)
 		red.Println("  Please check your API key and try again.")
 		os.Exit(1)
 	}
 
 	fmt.Print("\r")
-	green.Printf("✓ Connected successfully")
-	if modelInfo != "" {
-		fmt.Printf(" (%s available)", modelInfo)
-	}
-	fmt.Println()
+	green.Println("✓ Connected successfully")
 
 	// Select default model (optional)
 	model := authModel
@@ -259,7 +298,12 @@ func runAuthLogin(cmd *cobra.Command, args []string) {
 		ValidatedAt: time.Now(),
 	}
 
-	if err := manager.SetCredential(cred, false); err != nil {
+	if authProject {
+		err = manager.SetProjectCredential(cred)
+	} else {
+		err = manager.SetCredential(cred, false)
+	}
+	if err != nil {
 		red.Fprintf(os.Stderr, "Error storing credentials: %v\n", err)
 		os.Exit(1)
 	}
@@ -273,6 +317,12 @@ func runAuthLogin(cmd *cobra.Command, args []string) {
 	fmt.Println()
 
 	green.Println("✓ Credentials saved successfully!")
+	if authProject {
+		gitignore, _ := os.ReadFile(filepath.Join(manager.ProjectDir(), ".gitignore"))
+		if !strings.Contains(string(gitignore), auth.ProjectCredentialsFile) {
+			yellow.Printf("Note: .gitignore does not list %s; run 'trabuco sync --apply' before committing.\n", auth.ProjectCredentialsFile)
+		}
+	}
 	fmt.Println()
 	fmt.Println("These credentials will be used by Trabuco features that call out to LLMs")
 	fmt.Println("(the upcoming 1.10 migration feature is the primary consumer).")
@@ -291,7 +341,11 @@ func runAuthStatus(cmd *cobra.Command, args []string) {
 	}
 
 	cyan.Println("\nTrabuco Auth Status")
-	fmt.Printf("Storage: %s\n\n", manager.StorageBackend())
+	fmt.Printf("Storage: %s\n", manager.StorageBackend())
+	if manager.ProjectDir() != "" {
+		fmt.Printf("Project: %s\n", filepath.Join(manager.ProjectDir(), auth.ProjectCredentialsFile))
+	}
+	fmt.Println()
 
 	statuses := manager.ListConfigured()
 
@@ -373,7 +427,12 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 			}
 		}
 
-		if err := manager.RemoveCredential(provider); err != nil {
+		if authProject {
+			err = manager.RemoveProjectCredential(provider)
+		} else {
+			err = manager.RemoveCredential(provider)
+		}
+		if err != nil {
 			red.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -382,7 +441,11 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 	} else {
 		// Remove all credentials
 		if !authForce {
-			yellow.Println("\nThis will remove ALL stored credentials.")
+			if authProject {
+				yellow.Println("\nThis will remove all credentials of this project.")
+			} else {
+				yellow.Println("\nThis will remove ALL stored credentials.")
+			}
 			var confirm bool
 			prompt := &survey.Confirm{
 				Message: "Are you sure?",
@@ -394,7 +457,12 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 			}
 		}
 
-		if err := manager.Clear(); err != nil {
+		if authProject {
+			err = manager.ClearProject()
+		} else {
+			err = manager.Clear()
+		}
+		if err != nil {
 			red.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -440,36 +508,64 @@ func runAuthProviders(cmd *cobra.Command, args []string) {
 	fmt.Println()
 }
 
-// testProviderConnection validates credentials by making a test API call
-func testProviderConnection(ctx context.Context, provider auth.Provider, apiKey string) (bool, string) {
-	config := &ai.ProviderConfig{
-		APIKey:  apiKey,
-		Timeout: 30,
-	}
-
-	var aiProvider ai.Provider
-	var err error
-
-	switch provider {
-	case auth.ProviderAnthropic:
-		aiProvider, err = ai.NewAnthropicProvider(config)
-	case auth.ProviderOpenRouter:
-		aiProvider, err = ai.NewOpenRouterProvider(config)
-	case auth.ProviderOllama:
-		// For Ollama, just check if server is reachable
-		// TODO: Implement Ollama provider
-		return true, "llama3.3"
-	default:
-		return false, ""
-	}
+func runAuthTest(cmd *cobra.Command, args []string) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
 
+	manager, err := auth.NewManager()
 	if err != nil {
-		return false, ""
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	if err := aiProvider.ValidateAPIKey(ctx); err != nil {
-		return false, ""
+	var providers []auth.Provider
+	if len(args) > 0 {
+		provider := auth.Provider(strings.ToLower(args[0]))
+		if _, ok := auth.SupportedProviders[provider]; !ok {
+			red.Fprintf(os.Stderr, "Unknown provider: %s\n", args[0])
+			os.Exit(1)
+		}
+		providers = append(providers, provider)
+	} else {
+		for _, status := range manager.ListConfigured() {
+			if status.Configured {
+				providers = append(providers, status.Provider)
+			}
+		}
+		sort.Slice(providers, func(i, j int) bool { return providers[i] < providers[j] })
+	}
+	if len(providers) == 0 {
+		fmt.Println("No credentials configured. Run 'trabuco auth login' first.")
+		return
 	}
 
-	return true, "claude-sonnet-4-5"
+	failed := 0
+	for _, provider := range providers {
+		info := auth.SupportedProviders[provider]
+		cred, source, err := manager.ResolveCredential(provider)
+		if err != nil {
+			red.Printf("✗ %s: not configured\n", info.Name)
+			failed++
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		start := time.Now()
+		err = auth.TestCredential(ctx, cred)
+		cancel()
+		if err != nil {
+			red.Printf("✗ %s (%s): %v\n", info.Name, source, err)
+			failed++
+			continue
+		}
+		green.Printf("✓ %s (%s) in %s\n", info.Name, source, time.Since(start).Round(time.Millisecond))
+		if !strings.HasPrefix(source, "env:") {
+			if err := manager.MarkValidated(provider); err != nil {
+				red.Fprintf(os.Stderr, "  Warning: could not record the validation: %v\n", err)
+			}
+		}
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
 }
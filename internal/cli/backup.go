@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupJSON  bool
+	backupForce bool
+	backupKeep  int
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "List, restore and prune the backups taken before project changes",
+	Long: `List, restore and prune the backups in .trabuco-backup/.
+
+'trabuco add' backs up every file it is about to change. A failed add is
+rolled back automatically; if the rollback itself fails the backup is kept
+as a restore point. A successful add keeps its backup too, so restoring it
+undoes the add: the saved files are copied back and the files and module
+directories the add created are removed. The last 5 backups are kept.
+
+SUBCOMMANDS:
+  list      Show backups, newest first
+  restore   Restore the project to a backup
+  prune     Delete all but the most recent backups
+
+Examples:
+  trabuco backup list
+  trabuco backup restore 20260418-093012
+  trabuco backup prune --keep 2`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List backups, newest first",
+	Args:  cobra.NoArgs,
+	Run:   runBackupList,
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore the project to a backup",
+	Args:  cobra.ExactArgs(1),
+	Run:   runBackupRestore,
+}
+
+var backupPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete all but the most recent backups",
+	Args:  cobra.NoArgs,
+	Run:   runBackupPrune,
+}
+
+func init() {
+	backupListCmd.Flags().BoolVar(&backupJSON, "json", false, "Output the backups as JSON")
+	backupRestoreCmd.Flags().BoolVarP(&backupForce, "force", "f", false, "Skip confirmation")
+	backupPruneCmd.Flags().IntVar(&backupKeep, "keep", generator.DefaultBackupRetention, "Number of most recent backups to keep")
+
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	backupCmd.AddCommand(backupPruneCmd)
+}
+
+func runBackupList(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	cyan := color.New(color.FgCyan)
+	yellow := color.New(color.FgYellow)
+
+	projectPath := backupProjectPath()
+	backups, err := generator.ListBackups(projectPath)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if backupJSON {
+		if backups == nil {
+			backups = []generator.BackupInfo{}
+		}
+		data, _ := json.MarshalIndent(backups, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(backups) == 0 {
+		fmt.Printf("No backups in %s/\n", generator.BackupDirName)
+		return
+	}
+
+	for _, b := range backups {
+		cyan.Printf("%s", b.ID)
+		fmt.Printf("  %-22s  %3d files  ", b.Operation, len(b.Files))
+		if b.Status == generator.BackupCompleted {
+			fmt.Println(b.Status)
+		} else {
+			yellow.Println(b.Status)
+		}
+	}
+	fmt.Println()
+	fmt.Println("Restore one with: trabuco backup restore <id>")
+}
+
+func runBackupRestore(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	projectPath := backupProjectPath()
+	id := args[0]
+	backups, err := generator.ListBackups(projectPath)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var target *generator.BackupInfo
+	var newer []string
+	for i := range backups {
+		if backups[i].ID == id {
+			target = &backups[i]
+			break
+		}
+		newer = append(newer, backups[i].ID)
+	}
+	if target == nil {
+		red.Fprintf(os.Stderr, "Error: backup %s not found (see 'trabuco backup list')\n", id)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Restoring %s (%s):\n", target.ID, target.Operation)
+	for _, f := range target.Files {
+		fmt.Printf("  ~ %s\n", f)
+	}
+	for _, f := range target.Absent {
+		fmt.Printf("  - %s\n", f)
+	}
+	for _, d := range target.CreatedDirs {
+		fmt.Printf("  - %s/\n", d)
+	}
+	if len(newer) > 0 {
+		fmt.Println()
+		yellow.Printf("Newer backups exist (%s); restore those first to undo their operations too.\n", strings.Join(newer, ", "))
+	}
+
+	if !backupForce {
+		confirm := false
+		prompt := &survey.Confirm{
+			Message: "Restore this backup? Removed files and directories cannot be recovered.",
+			Default: false,
+		}
+		if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+			fmt.Println("Cancelled")
+			return
+		}
+	}
+
+	recordHistory := trackHistory(projectPath, "backup restore")
+	if _, err := generator.RestoreBackup(projectPath, id); err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordHistory()
+	green.Printf("✓ Restored backup %s\n", id)
+}
+
+func runBackupPrune(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	green := color.New(color.FgGreen)
+
+	projectPath := backupProjectPath()
+	removed, err := generator.PruneBackups(projectPath, backupKeep)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(removed) == 0 {
+		fmt.Println("Nothing to prune.")
+		return
+	}
+	for _, id := range removed {
+		fmt.Printf("  - %s\n", id)
+	}
+	green.Printf("✓ Pruned %d backup(s)\n", len(removed))
+}
+
+// backupProjectPath returns the project the backup commands operate on
+func backupProjectPath() string {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		color.New(color.FgRed).Fprintf(os.Stderr, "Error: could not get current directory: %v\n", err)
+		os.Exit(1)
+	}
+	return projectPath
+}
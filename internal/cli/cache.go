@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/cache"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheDir       string
+	cacheOlderThan string
+	cacheMaxSize   string
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the migration LLM response cache",
+	Long: `Manage the cache of LLM responses 'trabuco migrate' replays.
+
+Entries are content-addressed: the key hashes the model and the full
+prompt, which embeds the source files a specialist reads. An unchanged
+file migrated again, in another run, checkout or machine, is served from
+the cache at no cost. Point TRABUCO_CACHE_DIR or --cache-dir at a shared
+directory to share hits across machines.
+
+SUBCOMMANDS:
+  prune     Delete entries unused for a while, or down to a size
+
+Examples:
+  trabuco cache prune
+  trabuco cache prune --older-than 7d --max-size 500MB
+  trabuco cache prune --cache-dir /mnt/shared/trabuco-cache`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete entries unused for a while, or down to a size",
+	Args:  cobra.NoArgs,
+	Run:   runCachePrune,
+}
+
+func init() {
+	cacheCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Cache directory (default $"+cache.EnvDir+" or the user cache directory)")
+	cachePruneCmd.Flags().StringVar(&cacheOlderThan, "older-than", "30d", "Delete entries not used for this long (e.g. 7d, 12h; 0 keeps any age)")
+	cachePruneCmd.Flags().StringVar(&cacheMaxSize, "max-size", "", "Then delete the least recently used entries until the cache fits (e.g. 500MB, 2GB)")
+
+	cacheCmd.AddCommand(cachePruneCmd)
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	green := color.New(color.FgGreen)
+
+	maxAge, err := parseAge(cacheOlderThan)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: --older-than: %v\n", err)
+		os.Exit(1)
+	}
+	var maxBytes int64
+	if cacheMaxSize != "" {
+		if maxBytes, err = parseSize(cacheMaxSize); err != nil {
+			red.Fprintf(os.Stderr, "Error: --max-size: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	dir := cacheDir
+	if dir == "" {
+		dir = cache.DefaultDir()
+	}
+	c := cache.Open(dir)
+	removed, err := c.Prune(maxAge, maxBytes)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	left, _ := c.Stats()
+	if removed.Entries == 0 {
+		fmt.Printf("Nothing to prune in %s (%d entries, %s).\n", dir, left.Entries, formatSize(left.Bytes))
+		return
+	}
+	green.Printf("✓ Pruned %d entries (%s) from %s\n", removed.Entries, formatSize(removed.Bytes), dir)
+	fmt.Printf("  %d entries (%s) left\n", left.Entries, formatSize(left.Bytes))
+}
+
+// parseAge parses a Go duration, or a whole number of days as "30d".
+func parseAge(s string) (time.Duration, error) {
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	return d, nil
+}
+
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+}
+
+// parseSize parses a byte count with an optional KB/MB/GB suffix.
+func parseSize(s string) (int64, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	for _, u := range sizeUnits {
+		if num, ok := strings.CutSuffix(upper, u.suffix); ok {
+			n, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid size %q", s)
+			}
+			return int64(n * float64(u.bytes)), nil
+		}
+	}
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n, nil
+}
+
+// formatSize renders a byte count with the largest fitting unit.
+func formatSize(n int64) string {
+	for _, u := range sizeUnits[:3] {
+		if n >= u.bytes {
+			return fmt.Sprintf("%.1f %s", float64(n)/float64(u.bytes), u.suffix)
+		}
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAgeAndSize(t *testing.T) {
+	if d, err := parseAge("7d"); err != nil || d != 7*24*time.Hour {
+		t.Errorf("parseAge(7d) = %v, %v", d, err)
+	}
+	if d, err := parseAge("12h"); err != nil || d != 12*time.Hour {
+		t.Errorf("parseAge(12h) = %v, %v", d, err)
+	}
+	if _, err := parseAge("soon"); err == nil {
+		t.Error("parseAge should reject garbage")
+	}
+	if n, err := parseSize("1.5GB"); err != nil || n != 3<<29 {
+		t.Errorf("parseSize(1.5GB) = %d, %v", n, err)
+	}
+	if n, err := parseSize("500mb"); err != nil || n != 500<<20 {
+		t.Errorf("parseSize(500mb) = %d, %v", n, err)
+	}
+	if got := formatSize(3 << 20); got != "3.0 MB" {
+		t.Errorf("formatSize = %q", got)
+	}
+}
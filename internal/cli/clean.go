@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var cleanForce bool
+
+var cleanCmd = &cobra.Command{
+	Use:     "clean [path]",
+	Aliases: []string{"uninstall", "eject"},
+	Short:   "Remove Trabuco's artifacts from a project",
+	Long: `Eject a project from Trabuco: remove the files Trabuco uses to manage it,
+leaving a plain Maven project the team maintains by hand.
+
+Removed:
+  .trabuco.json          Project metadata
+  .trabuco/              History, review settings and template overrides
+  .trabuco-backup/       Backups taken by 'trabuco add'
+  .trabuco-migration/    Migration state
+  .ai/                   Shared AI agent prompts
+
+The <!-- trabuco:begin/end --> markers are stripped from README.md,
+AGENTS.md, docs/errors.md and the AI agent context files; their text stays.
+The "trabuco" MCP server is unregistered from .mcp.json, .cursor/mcp.json,
+.vscode/mcp.json, .gemini/settings.json and .codex/config.toml.
+
+pom.xml, the Maven wrapper, Docker files, CI workflows and source code are
+not touched. Afterwards 'trabuco add', 'doctor' and 'sync' no longer
+recognize the project. Commit first if you may want the files back.
+
+Examples:
+  trabuco clean            # list what would be removed, then confirm
+  trabuco clean ../orders
+  trabuco clean --force    # no confirmation`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runClean,
+}
+
+func init() {
+	cleanCmd.Flags().BoolVarP(&cleanForce, "force", "f", false, "Skip confirmation")
+}
+
+func runClean(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	green := color.New(color.FgGreen)
+
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+	plan, err := generator.PlanEject(projectPath)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if plan.IsEmpty() {
+		fmt.Println("No Trabuco artifacts found; nothing to clean.")
+		return
+	}
+
+	printEjectPlan(plan)
+	if !cleanForce {
+		confirm := false
+		prompt := &survey.Confirm{
+			Message: "Remove these Trabuco artifacts? The project can no longer be managed by Trabuco.",
+			Default: false,
+		}
+		if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+			fmt.Println("Cancelled")
+			return
+		}
+	}
+
+	if err := plan.Apply(); err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	green.Printf("✓ Removed %d path(s), unmarked %d doc(s), unregistered the MCP server from %d config(s)\n",
+		len(plan.Remove), len(plan.Unmark), len(plan.MCPConfigs))
+}
+
+// printEjectPlan lists what clean changes, in the - / ~ notation of
+// 'trabuco backup restore'
+func printEjectPlan(plan *generator.EjectPlan) {
+	if len(plan.Remove) > 0 {
+		fmt.Println("Remove:")
+		for _, rel := range plan.Remove {
+			fmt.Printf("  - %s\n", rel)
+		}
+	}
+	if len(plan.Unmark) > 0 {
+		fmt.Println("Strip managed-region markers (text is kept):")
+		for _, rel := range plan.Unmark {
+			fmt.Printf("  ~ %s\n", rel)
+		}
+	}
+	if len(plan.MCPConfigs) > 0 {
+		fmt.Println("Unregister the trabuco MCP server:")
+		for _, rel := range plan.MCPConfigs {
+			fmt.Printf("  ~ %s\n", rel)
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var configProfile string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show the organization defaults used by init and add",
+	Long: `Show the user/organization defaults file (~/.trabuco/config.yaml) and
+the values 'trabuco init' and 'trabuco add' take from it.
+
+The file has a defaults block that applies to every project and optional
+named profiles layered on top of it. Explicit command-line flags always
+win over both. Set TRABUCO_CONFIG to use a different file (e.g. one
+checked into an internal repository).
+
+SUBCOMMANDS:
+  show   Print the effective defaults (the default when no subcommand is given)
+  path   Print the location of the config file
+  init   Write a commented starter config file
+
+Examples:
+  trabuco config
+  trabuco config show --profile payments
+  trabuco config init`,
+	Run: runConfigShow,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective defaults",
+	Run:   runConfigShow,
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the location of the config file",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(config.UserConfigPath())
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented starter config file",
+	Run:   runConfigInit,
+}
+
+func init() {
+	configCmd.PersistentFlags().StringVar(&configProfile, "profile", "", "Profile to resolve (default: the file's default_profile)")
+
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configInitCmd)
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) {
+	cyan := color.New(color.FgCyan)
+	yellow := color.New(color.FgYellow)
+	red := color.New(color.FgRed)
+
+	path := config.UserConfigPath()
+	uc, err := config.LoadUserConfig()
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cyan.Printf("Config file: ")
+	fmt.Println(path)
+	if _, statErr := os.Stat(path); os.IsNotExist(statErr) {
+		yellow.Println("  (not found - run 'trabuco config init' to create one)")
+		return
+	}
+
+	if names := uc.ProfileNames(); len(names) > 0 {
+		cyan.Printf("Profiles:    ")
+		fmt.Println(strings.Join(names, ", "))
+	}
+	if uc.DefaultProfile != "" {
+		cyan.Printf("Default:     ")
+		fmt.Println(uc.DefaultProfile)
+	}
+
+	profile, err := uc.Profile(configProfile)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println()
+	if profile == nil {
+		fmt.Println("No defaults configured; Trabuco's built-in defaults apply.")
+		return
+	}
+	cyan.Printf("Effective defaults (%s):\n", profile.Name)
+	for _, row := range profileRows(profile) {
+		if row.value != "" {
+			fmt.Printf("  %-16s %s\n", row.label+":", row.value)
+		}
+	}
+	fmt.Println()
+	fmt.Println("Explicit flags on 'trabuco init' / 'trabuco add' override these values.")
+}
+
+// profileRows lists a profile's settings under their config file keys
+func profileRows(p *config.Profile) []struct{ label, value string } {
+	header := ""
+	if p.LicenseHeader != "" {
+		header = "(set)"
+	}
+	return []struct{ label, value string }{
+		{"group_id_prefix", p.GroupIDPrefix},
+		{"java_version", p.JavaVersion},
+		{"language", p.Language},
+		{"database", p.Database},
+		{"nosql_database", p.NoSQLDatabase},
+		{"message_broker", p.MessageBroker},
+		{"ai_agents", strings.Join(p.AIAgents, ", ")},
+		{"ci", p.CIProvider},
+		{"license", p.License},
+		{"license_header", header},
+		{"image_registry", p.ImageRegistry},
+		{"template_packs", strings.Join(p.TemplatePacks, ", ")},
+	}
+}
+
+// starterConfig is written by 'trabuco config init'
+const starterConfig = `# Trabuco organization defaults, used by 'trabuco init' and 'trabuco add'.
+# Explicit command-line flags always win over these values.
+
+defaults:
+  # group_id_prefix: com.acme          # --group-id becomes com.acme.<project>
+  # java_version: "21"
+  # language: kotlin                   # java (default) or kotlin
+  # ci: github
+  # ai_agents: [claude, cursor]
+  # license: apache2                   # apache2, mit or proprietary:<header-file>
+  # image_registry: ghcr.io/acme       # docker images become ghcr.io/acme/<project>-api
+  # template_packs: [AuditLog]         # installed packs ('trabuco plugin list') added to new projects
+
+# Named profiles are layered on top of defaults; pick one with --profile.
+# default_profile: payments
+# profiles:
+#   payments:
+#     database: postgresql
+#     message_broker: kafka
+`
+
+func runConfigInit(cmd *cobra.Command, args []string) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	path := config.UserConfigPath()
+	if _, err := os.Stat(path); err == nil {
+		red.Fprintf(os.Stderr, "Error: %s already exists\n", path)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(path, []byte(starterConfig), 0644); err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	green.Printf("✓ Wrote %s\n", path)
+	fmt.Println("  Uncomment the settings your organization wants to standardize.")
+}
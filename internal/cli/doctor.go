@@ -1,8 +1,14 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/arianlopezc/Trabuco/internal/doctor"
 	"github.com/fatih/color"
@@ -14,6 +20,8 @@ var (
 	doctorFix     bool
 	doctorJSON    bool
 	doctorCheck   string
+	doctorWatch   bool
+	doctorFormat  string
 )
 
 var doctorCmd = &cobra.Command{
@@ -29,21 +37,44 @@ It checks for:
   - Module directories and POMs
   - Configuration consistency
   - Docker Compose synchronization
+  - Template overrides (user-wide and project-local)
+  - Docker availability (local, remote DOCKER_HOST, or Testcontainers Cloud)
+  - Plaintext credentials (tracked .env files, literal passwords in config)
+  - Generated code: API component scan, ArchUnit rules, port clashes
+    between modules, and sequential Flyway migrations
+
+With --check=runtime it instead checks the local infrastructure the project
+runs against: the required docker-compose services are running and healthy,
+their published ports accept connections, and the configured Kafka topics
+exist. These checks only run when asked for.
+
+With --watch it runs the checks once, then keeps watching pom.xml, the
+module POMs, .trabuco.json, docker-compose.yml and the sources the code
+checks read: application.yml files, Flyway migrations, ArchitectureTest
+and Application classes. When one changes it
+re-runs the categories that read it and prints only the findings that are
+new or resolved. Stop it with Ctrl+C.
 
 Examples:
   trabuco doctor              Run all checks
   trabuco doctor --verbose    Show all checks (not just failures)
   trabuco doctor --fix        Auto-fix issues that can be fixed
   trabuco doctor --json       Output as JSON (for scripting)
-  trabuco doctor --check=metadata  Check specific category`,
+  trabuco doctor --format sarif > doctor.sarif   SARIF for GitHub code scanning
+  trabuco doctor --format junit > doctor.xml     JUnit XML for CI test reporters
+  trabuco doctor --check=metadata  Check specific category
+  trabuco doctor --check=runtime   Check that the local stack is up
+  trabuco doctor --watch      Re-check while editing the POMs or .trabuco.json`,
 	Run: runDoctor,
 }
 
 func init() {
 	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show all checks, not just failures")
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Auto-fix issues that can be fixed")
-	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output as JSON")
-	doctorCmd.Flags().StringVar(&doctorCheck, "check", "", "Run specific check category (structure, metadata, consistency)")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output as JSON (same as --format json)")
+	doctorCmd.Flags().StringVar(&doctorFormat, "format", doctor.FormatText, "Output format: "+strings.Join(doctor.Formats, ", "))
+	doctorCmd.Flags().StringVar(&doctorCheck, "check", "", "Run specific check category (structure, metadata, consistency, code, environment, security, runtime)")
+	doctorCmd.Flags().BoolVar(&doctorWatch, "watch", false, "Keep running and re-check when the POMs, .trabuco.json, docker-compose.yml or the checked sources change")
 }
 
 func runDoctor(cmd *cobra.Command, args []string) {
@@ -54,6 +85,23 @@ func runDoctor(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if doctorJSON {
+		doctorFormat = doctor.FormatJSON
+	}
+	if !slices.Contains(doctor.Formats, doctorFormat) {
+		fmt.Fprintf(os.Stderr, "Error: invalid --format %q (valid: %s)\n", doctorFormat, strings.Join(doctor.Formats, ", "))
+		os.Exit(1)
+	}
+
+	if doctorWatch {
+		if doctorFix || doctorFormat != doctor.FormatText {
+			fmt.Fprintln(os.Stderr, "Error: --watch cannot be combined with --fix, --json or --format")
+			os.Exit(1)
+		}
+		runDoctorWatch(projectPath)
+		return
+	}
+
 	// Create doctor
 	doc := doctor.New(projectPath, Version)
 
@@ -62,11 +110,13 @@ func runDoctor(cmd *cobra.Command, args []string) {
 
 	if doctorFix {
 		// Run with fix
+		recordHistory := trackHistory(projectPath, "doctor --fix")
 		result, fixResults, err = doc.RunAndFix()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error running doctor: %v\n", err)
 			os.Exit(1)
 		}
+		recordHistory()
 	} else if doctorCheck != "" {
 		// Run specific category
 		result, err = doc.RunCategory(doctorCheck)
@@ -84,20 +134,29 @@ func runDoctor(cmd *cobra.Command, args []string) {
 	}
 
 	// Output results
-	if doctorJSON {
-		jsonOutput, err := result.ToJSON()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println(string(jsonOutput))
-	} else {
+	switch doctorFormat {
+	case doctor.FormatText:
 		result.PrintSummary(doctorVerbose)
 
 		// Print fix results if we did fixes
 		if len(fixResults) > 0 {
 			doctor.PrintFixResults(fixResults)
 		}
+	default:
+		var output []byte
+		switch doctorFormat {
+		case doctor.FormatSARIF:
+			output, err = result.ToSARIF()
+		case doctor.FormatJUnit:
+			output, err = result.ToJUnit()
+		default:
+			output, err = result.ToJSON()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding %s: %v\n", doctorFormat, err)
+			os.Exit(1)
+		}
+		fmt.Println(string(output))
 	}
 
 	// Exit with appropriate code
@@ -106,9 +165,34 @@ func runDoctor(cmd *cobra.Command, args []string) {
 	}
 
 	// Show hint if there are warnings and we didn't fix
-	if result.HasWarnings() && !doctorFix && !doctorJSON {
+	if result.HasWarnings() && !doctorFix && doctorFormat == doctor.FormatText {
 		fmt.Println()
 		yellow := color.New(color.FgYellow)
 		yellow.Println("Tip: Run 'trabuco doctor --fix' to auto-fix warnings.")
 	}
 }
+
+// runDoctorWatch prints a full check, then the findings that each edit to a
+// watched file adds or resolves, until interrupted.
+func runDoctorWatch(projectPath string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := doctor.NewWatcher(projectPath, Version, doctorCheck)
+	result, err := watcher.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running doctor: %v\n", err)
+		os.Exit(1)
+	}
+	result.PrintSummary(doctorVerbose)
+
+	fmt.Println()
+	color.New(color.FgCyan).Println("Watching pom.xml, .trabuco.json and docker-compose.yml (Ctrl+C to stop)...")
+	watcher.Run(ctx, doctor.DefaultWatchInterval, func(update *doctor.WatchUpdate, err error) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running doctor: %v\n", err)
+			return
+		}
+		update.Print(time.Now())
+	})
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/envcontract"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envFile string
+	envJSON bool
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Work with the project's environment variable contract",
+	Long: `Work with the environment variable contract of a generated project.
+
+init and add write ENV.md and .env.schema at the project root. They list
+every environment variable the modules' application*.yml read, with its
+type, default, whether it is required and which modules read it.
+'trabuco doctor --fix' regenerates them after configuration changes.
+
+SUBCOMMANDS:
+  check     Validate a local .env against .env.schema
+
+Examples:
+  trabuco env check
+  trabuco env check --env-file .env.staging ./my-project`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var envCheckCmd = &cobra.Command{
+	Use:   "check [path]",
+	Short: "Validate a local .env against .env.schema",
+	Long: `Validate a local .env against the project's .env.schema before starting
+the modules.
+
+Required variables must be set and values must parse as their declared
+type (integer, boolean, duration, url). Variables no module reads are
+reported as warnings. Without .env.schema the modules' configuration is
+scanned instead. Exits with status 1 when the .env would not start.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runEnvCheck,
+}
+
+func init() {
+	envCheckCmd.Flags().StringVar(&envFile, "env-file", ".env", "The .env file to validate, relative to the project")
+	envCheckCmd.Flags().BoolVar(&envJSON, "json", false, "Output as JSON")
+
+	envCmd.AddCommand(envCheckCmd)
+}
+
+func runEnvCheck(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+	green := color.New(color.FgGreen)
+
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	vars, err := envcontract.LoadSchema(projectPath)
+	if os.IsNotExist(err) {
+		if !envJSON {
+			yellow.Printf("No %s; checking against the modules' configuration (run 'trabuco doctor --fix' to write it)\n", envcontract.SchemaFileName)
+		}
+		vars, err = envcontract.Scan(projectPath)
+	}
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	path := envFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectPath, path)
+	}
+	env, err := envcontract.ParseEnvFile(path)
+	if os.IsNotExist(err) {
+		// Every variable is unset: the required ones fail
+		env, err = map[string]string{}, nil
+		if !envJSON {
+			yellow.Printf("%s not found; checking the defaults alone\n", envFile)
+		}
+	}
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	result := envcontract.Validate(vars, env)
+	if envJSON {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		for _, e := range result.Errors {
+			red.Printf("  ✗ %s\n", e)
+		}
+		for _, w := range result.Warnings {
+			yellow.Printf("  ⚠ %s\n", w)
+		}
+		if result.OK() {
+			green.Printf("✓ %s satisfies the contract (%d variables)\n", envFile, len(vars))
+		} else {
+			red.Printf("%d problem(s) would stop the modules from starting\n", len(result.Errors))
+		}
+	}
+	if !result.OK() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var exportSpecOutput string
+
+var exportSpecCmd = &cobra.Command{
+	Use:   "export-spec [path]",
+	Short: "Write the project spec of an existing project",
+	Long: `Describe an existing Trabuco project as a YAML project spec, the input
+of 'trabuco init --spec'.
+
+Settings come from .trabuco.json. The parent pom.xml and docker-compose.yml
+take precedence where they were edited after generation: the group id, the
+module list, the Java version and the SQL database image. Anything the spec
+cannot carry is reported on stderr.
+
+Use it to regenerate a project with a newer Trabuco release, or to start a
+new service with the same shape (change name and group_id first). Code you
+wrote or scaffolded is not part of the spec.
+
+Examples:
+  trabuco export-spec                       # print the spec
+  trabuco export-spec -o orders.yaml
+  trabuco init --spec orders.yaml --name billing --group-id com.acme.billing`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runExportSpec,
+}
+
+func init() {
+	exportSpecCmd.Flags().StringVarP(&exportSpecOutput, "output", "o", "", "Write the spec to this file instead of stdout")
+}
+
+func runExportSpec(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+	spec, notes, err := config.ExportProjectSpec(projectPath)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	header := fmt.Sprintf("# Project spec of %s, exported by Trabuco %s.\n# Recreate it with: trabuco init --spec <this file>\n", spec.Name, Version)
+	out := append([]byte(header), data...)
+
+	for _, note := range notes {
+		yellow.Fprintf(os.Stderr, "Note: %s\n", note)
+	}
+	if exportSpecOutput == "" {
+		fmt.Print(string(out))
+		return
+	}
+	if err := os.WriteFile(exportSpecOutput, out, 0644); err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %s\n", exportSpecOutput)
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Retrofit optional scaffolding onto an existing Trabuco project",
+	Long: `Retrofit scaffolding that 'trabuco init' generates behind a flag onto an
+existing Trabuco project.
+
+Like 'trabuco add <type>', these commands only create new files (plus the
+POM entries they need) and refuse to overwrite existing ones. The project's
+.trabuco.json records the feature so later regenerations keep it. 'event',
+'endpoint' and 'job' are the exception: they record nothing and insert into
+generated classes and scripts.
+
+Available generators:
+  codeowners   CODEOWNERS and module boundary docs (--with-codeowners)
+  devcontainer Dev container for Codespaces and VS Code (--with-devcontainer)
+  endpoint     REST resource endpoint with DTOs, service stubs and MockMvc test
+  event        Broker event with publish method, listener, test and local topic
+  job          Runnable JobRunr job with enqueue method, test and optional cron
+  pagination   Paginated, sorted and filtered list endpoint (--with-pagination)
+  perf         k6 load test of the Placeholder endpoints (--with-perf)
+  rate-limit   Per-client Bucket4j rate limiting for the API (--with-rate-limit)`,
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateCodeOwnersDryRun bool
+	generateCodeOwnersJSON   bool
+)
+
+var generateCodeOwnersCmd = &cobra.Command{
+	Use:   "codeowners",
+	Short: "Add CODEOWNERS and module boundary docs",
+	Long: `Add the ownership files 'trabuco init --with-codeowners' generates:
+
+  CODEOWNERS               each module's directory and boundary doc mapped
+                           to a placeholder team (@your-org/<module>-owners),
+                           everything else to the project's maintainers
+  docs/modules/<Module>.md what the module owns, what it builds on, which
+                           modules use it and the ArchUnit rules guarding it
+
+'trabuco add' appends the entries of new modules to CODEOWNERS, keeping the
+owners already set, and regenerates the generated section of every
+boundary doc.
+
+Examples:
+  trabuco generate codeowners
+  trabuco generate codeowners --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  runGenerateCodeOwners,
+}
+
+func init() {
+	generateCodeOwnersCmd.Flags().BoolVar(&generateCodeOwnersDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generateCodeOwnersCmd.Flags().BoolVar(&generateCodeOwnersJSON, "json", false, "Emit machine-readable JSON output")
+	generateCmd.AddCommand(generateCodeOwnersCmd)
+}
+
+func runGenerateCodeOwners(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generateCodeOwnersJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generateCodeOwnersJSON)
+		os.Exit(1)
+	}
+	meta, err := config.LoadMetadata(ctx.ProjectPath)
+	if err != nil {
+		printAddError(err, generateCodeOwnersJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate codeowners")
+
+	created, err := generator.RetrofitCodeOwners(ctx.ProjectPath, meta, generateCodeOwnersDryRun)
+	if err != nil {
+		printAddError(err, generateCodeOwnersJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	printAddResult(&addgen.Result{
+		Created: created,
+		NextSteps: []string{
+			"Replace the @your-org placeholder teams in CODEOWNERS with the teams that own each module.",
+			"Turn on \"Require review from Code Owners\" in the repository's branch protection to enforce them.",
+		},
+	}, generateCodeOwnersDryRun, generateCodeOwnersJSON)
+}
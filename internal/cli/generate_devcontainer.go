@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateDevContainerDryRun bool
+	generateDevContainerJSON   bool
+)
+
+var generateDevContainerCmd = &cobra.Command{
+	Use:   "devcontainer",
+	Short: "Add a dev container for GitHub Codespaces and VS Code",
+	Long: `Add the dev container 'trabuco init --with-devcontainer' generates:
+
+  .devcontainer/devcontainer.json  Docker-in-Docker feature, a Maven cache
+                                   volume, forwarded ports for the project's
+                                   modules and a build on creation
+  .devcontainer/Dockerfile         the project's JDK and the Maven release
+                                   the wrapper pins
+
+'trabuco add' keeps the forwarded ports in step with the modules.
+
+Examples:
+  trabuco generate devcontainer
+  trabuco generate devcontainer --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  runGenerateDevContainer,
+}
+
+func init() {
+	generateDevContainerCmd.Flags().BoolVar(&generateDevContainerDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generateDevContainerCmd.Flags().BoolVar(&generateDevContainerJSON, "json", false, "Emit machine-readable JSON output")
+	generateCmd.AddCommand(generateDevContainerCmd)
+}
+
+func runGenerateDevContainer(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generateDevContainerJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generateDevContainerJSON)
+		os.Exit(1)
+	}
+	meta, err := config.LoadMetadata(ctx.ProjectPath)
+	if err != nil {
+		printAddError(err, generateDevContainerJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate devcontainer")
+
+	created, err := generator.RetrofitDevContainer(ctx.ProjectPath, meta, generateDevContainerDryRun)
+	if err != nil {
+		printAddError(err, generateDevContainerJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	printAddResult(&addgen.Result{
+		Created: created,
+		NextSteps: []string{
+			"Commit .devcontainer/, then open the repository in GitHub Codespaces or run 'Dev Containers: Reopen in Container' in VS Code.",
+		},
+	}, generateDevContainerDryRun, generateDevContainerJSON)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateEndpointMethods []string
+	generateEndpointName    string
+	generateEndpointFields  string
+	generateEndpointDryRun  bool
+	generateEndpointJSON    bool
+)
+
+var generateEndpointCmd = &cobra.Command{
+	Use:   "endpoint <path>",
+	Short: "Add a REST resource endpoint with DTOs, service stubs and MockMvc tests",
+	Long: `Add a REST resource endpoint, served under /api:
+
+  Model   dto/{Name}Request (POST, PUT) and dto/{Name}Response
+  API     controller/{Name}Controller with OpenAPI annotations and scope
+          checks (+ {Name}ControllerTest)
+  Shared  service/{Name}Service with a stub per operation
+
+The methods map to operations:
+
+  GET     GET /      list       GET /{id}  get by ID
+  POST    POST /     create
+  PUT     PUT /{id}  replace
+  DELETE  DELETE /{id}
+
+Errors are thrown: an unknown ID is a 404 and an invalid body a 400, both
+answered by GlobalExceptionHandler as ProblemDetail. The test builds MockMvc
+around the controller and GlobalExceptionHandler with a mocked service, so
+it runs without a database.
+
+{Name} is the singular of the path's last segment (/order-items gives
+OrderItem); --name overrides it. When {Name}Service already exists, the
+stubs are added to it instead.
+
+Requires the Model, Shared and API modules.
+
+Examples:
+  trabuco generate endpoint /orders --methods GET,POST
+  trabuco generate endpoint /orders --methods GET,POST,PUT,DELETE --fields "customerId:uuid,total:decimal,note:string?"`,
+	Args: cobra.ExactArgs(1),
+	Run:  runGenerateEndpoint,
+}
+
+func init() {
+	generateEndpointCmd.Flags().StringSliceVar(&generateEndpointMethods, "methods", nil, "HTTP methods to map (required): GET, POST, PUT, DELETE")
+	generateEndpointCmd.Flags().StringVar(&generateEndpointName, "name", "", "Resource name (default: singular of the path, e.g. Order)")
+	generateEndpointCmd.Flags().StringVar(&generateEndpointFields, "fields", "", `DTO fields (default "name:string"), e.g. "customerId:uuid,note:string?"`)
+	generateEndpointCmd.Flags().BoolVar(&generateEndpointDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generateEndpointCmd.Flags().BoolVar(&generateEndpointJSON, "json", false, "Emit machine-readable JSON output")
+	_ = generateEndpointCmd.MarkFlagRequired("methods")
+	generateCmd.AddCommand(generateEndpointCmd)
+}
+
+func runGenerateEndpoint(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generateEndpointJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generateEndpointJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate endpoint")
+
+	result, err := generator.GenerateEndpoint(ctx.ProjectPath, generator.EndpointOpts{
+		Path:    args[0],
+		Name:    generateEndpointName,
+		Methods: generateEndpointMethods,
+		Fields:  generateEndpointFields,
+	}, generateEndpointDryRun)
+	if err != nil {
+		printAddError(err, generateEndpointJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	printAddResult(result, generateEndpointDryRun, generateEndpointJSON)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateEventTopic  string
+	generateEventFields string
+	generateEventDryRun bool
+	generateEventJSON   bool
+)
+
+var generateEventCmd = &cobra.Command{
+	Use:   "event <Name>",
+	Short: "Add a broker event with its publish method and listener",
+	Long: `Add an event that is published and consumed through the project's broker:
+
+  Model          events/{Name} (eventId, occurredAt, payload, create factory)
+  Events         publish({Name}) on EventPublisher
+  EventConsumer  listener/{Name}Listener with retries and dead-lettering
+                 (+ {Name}ListenerTest)
+  Compose        the SQS queue or Pub/Sub topic and subscription in the
+                 local init scripts
+
+Kafka, RabbitMQ and SQS listeners subscribe on their own. For Pub/Sub,
+Redis Streams and NATS the listener exposes handle(), and the next steps
+say where to subscribe it. Destinations default to the topic name and can
+be overridden under app.<broker> in application.yml.
+
+Unlike 'trabuco add event', this edits EventPublisher and the init
+scripts. When one no longer has its generated shape, the edit is skipped
+and printed as a next step.
+
+Requires the Events module; the listener needs EventConsumer.
+
+Examples:
+  trabuco generate event OrderShipped --topic orders
+  trabuco generate event OrderShipped --topic orders --fields "orderId:uuid,carrier:string?"`,
+	Args: cobra.ExactArgs(1),
+	Run:  runGenerateEvent,
+}
+
+func init() {
+	generateEventCmd.Flags().StringVar(&generateEventTopic, "topic", "", "Broker topic or queue name (required), e.g. orders")
+	generateEventCmd.Flags().StringVar(&generateEventFields, "fields", "", `Event payload fields, e.g. "orderId:uuid,carrier:string?"`)
+	generateEventCmd.Flags().BoolVar(&generateEventDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generateEventCmd.Flags().BoolVar(&generateEventJSON, "json", false, "Emit machine-readable JSON output")
+	_ = generateEventCmd.MarkFlagRequired("topic")
+	generateCmd.AddCommand(generateEventCmd)
+}
+
+func runGenerateEvent(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generateEventJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generateEventJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate event")
+
+	result, err := generator.GenerateEvent(ctx.ProjectPath, generator.EventOpts{
+		Name:   args[0],
+		Topic:  generateEventTopic,
+		Fields: generateEventFields,
+	}, generateEventDryRun)
+	if err != nil {
+		printAddError(err, generateEventJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	printAddResult(result, generateEventDryRun, generateEventJSON)
+}
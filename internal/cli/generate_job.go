@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateJobSchedule string
+	generateJobPayload  string
+	generateJobDryRun   bool
+	generateJobJSON     bool
+)
+
+var generateJobCmd = &cobra.Command{
+	Use:   "job <Name>",
+	Short: "Add a runnable JobRunr job, optionally on a recurring schedule",
+	Long: `Add a JobRunr job that is wired up and runnable:
+
+  Model   jobs/{Name}JobRequest, jobs/{Name}JobRequestHandler (base)
+  Worker  handler/{Name}JobRequestHandler (completes with a TODO body)
+          (+ {Name}JobRequestHandlerTest)
+  Jobs    enqueue{Name}(...) on PlaceholderJobService
+  Worker  config/RecurringJobsConfig registers the job (with --schedule)
+
+--schedule takes a JobRunr cron expression: 5 fields, or 6 with a leading
+seconds field, which must be a single second. A scheduled job is registered
+with an empty request, so --schedule and --payload are exclusive.
+
+Unlike 'trabuco add job', this edits PlaceholderJobService and
+RecurringJobsConfig. When either no longer has its generated shape, the
+edit is skipped and printed as a next step.
+
+Requires the Worker module.
+
+Examples:
+  trabuco generate job SendDigest --schedule "0 0 8 * * *"
+  trabuco generate job ReindexOrder --payload "orderId:uuid"`,
+	Args: cobra.ExactArgs(1),
+	Run:  runGenerateJob,
+}
+
+func init() {
+	generateJobCmd.Flags().StringVar(&generateJobSchedule, "schedule", "", `Cron expression for a recurring job, e.g. "0 0 8 * * *"`)
+	generateJobCmd.Flags().StringVar(&generateJobPayload, "payload", "", `JobRequest payload fields, e.g. "orderId:string,amount:decimal"`)
+	generateJobCmd.Flags().BoolVar(&generateJobDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generateJobCmd.Flags().BoolVar(&generateJobJSON, "json", false, "Emit machine-readable JSON output")
+	generateCmd.AddCommand(generateJobCmd)
+}
+
+func runGenerateJob(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generateJobJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generateJobJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate job")
+
+	result, err := generator.GenerateJob(ctx.ProjectPath, generator.JobOpts{
+		Name:     args[0],
+		Payload:  generateJobPayload,
+		Schedule: generateJobSchedule,
+	}, generateJobDryRun)
+	if err != nil {
+		printAddError(err, generateJobJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	printAddResult(result, generateJobDryRun, generateJobJSON)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generatePaginationDryRun bool
+	generatePaginationJSON   bool
+)
+
+var generatePaginationCmd = &cobra.Command{
+	Use:   "pagination",
+	Short: "Add a paginated, sorted and filtered Placeholder list endpoint",
+	Long: `Add the scaffolding 'trabuco init --with-pagination' generates:
+
+  Model   PageRequest, PageResponse, PlaceholderFilter, SortWhitelist (+ test)
+  SQLDatastore
+          PlaceholderPagingRepository (Pageable + query by example)
+  Shared  PlaceholderQueryService (+ test)
+  API     PlaceholderPageController: GET /api/placeholders/page
+
+Requires the API and Shared modules and SQLDatastore or NoSQLDatastore with
+MongoDB.
+
+Examples:
+  trabuco generate pagination
+  trabuco generate pagination --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  runGeneratePagination,
+}
+
+func init() {
+	generatePaginationCmd.Flags().BoolVar(&generatePaginationDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generatePaginationCmd.Flags().BoolVar(&generatePaginationJSON, "json", false, "Emit machine-readable JSON output")
+	generateCmd.AddCommand(generatePaginationCmd)
+}
+
+func runGeneratePagination(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generatePaginationJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generatePaginationJSON)
+		os.Exit(1)
+	}
+	meta, err := config.LoadMetadata(ctx.ProjectPath)
+	if err != nil {
+		printAddError(err, generatePaginationJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate pagination")
+
+	created, err := generator.RetrofitPagination(ctx.ProjectPath, meta, generatePaginationDryRun)
+	if err != nil {
+		printAddError(err, generatePaginationJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	printAddResult(&addgen.Result{
+		Created: created,
+		NextSteps: []string{
+			"Run ./mvnw test -pl Model,Shared -am to compile the new DTOs and run the pagination tests.",
+			"Index every property in PlaceholderQueryService.SORTABLE, or remove the ones you don't need.",
+			"Copy the pattern to your own resources: a Filter DTO, a whitelist and a /page endpoint per list.",
+		},
+	}, generatePaginationDryRun, generatePaginationJSON)
+}
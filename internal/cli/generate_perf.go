@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generatePerfDryRun bool
+	generatePerfJSON   bool
+)
+
+var generatePerfCmd = &cobra.Command{
+	Use:   "perf",
+	Short: "Add a k6 load test of the Placeholder endpoints",
+	Long: `Add the load test 'trabuco init --with-perf' generates:
+
+  perf/k6/placeholders.js    k6 script: create, read and delete a placeholder
+                             per iteration, with p95 and error-rate thresholds
+  perf/run.sh                starts the docker-compose services and the API,
+                             runs k6 (or the grafana/k6 image) and writes the
+                             summary to perf/results/
+  .github/workflows/perf.yml manual workflow that runs perf/run.sh and
+                             uploads perf/results/ (GitHub Actions projects)
+
+Requires the API module and a datastore.
+
+Examples:
+  trabuco generate perf
+  trabuco generate perf --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  runGeneratePerf,
+}
+
+func init() {
+	generatePerfCmd.Flags().BoolVar(&generatePerfDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generatePerfCmd.Flags().BoolVar(&generatePerfJSON, "json", false, "Emit machine-readable JSON output")
+	generateCmd.AddCommand(generatePerfCmd)
+}
+
+func runGeneratePerf(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generatePerfJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generatePerfJSON)
+		os.Exit(1)
+	}
+	meta, err := config.LoadMetadata(ctx.ProjectPath)
+	if err != nil {
+		printAddError(err, generatePerfJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate perf")
+
+	created, err := generator.RetrofitPerf(ctx.ProjectPath, meta, generatePerfDryRun)
+	if err != nil {
+		printAddError(err, generatePerfJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	printAddResult(&addgen.Result{
+		Created: created,
+		NextSteps: []string{
+			"Run perf/run.sh for a 1 minute baseline with 10 virtual users (VUS and DURATION change it).",
+			"Tighten the thresholds in perf/k6/placeholders.js once you know the numbers on your hardware.",
+		},
+	}, generatePerfDryRun, generatePerfJSON)
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateRateLimitDryRun bool
+	generateRateLimitJSON   bool
+)
+
+var generateRateLimitCmd = &cobra.Command{
+	Use:   "rate-limit",
+	Short: "Add per-client Bucket4j rate limiting to the API",
+	Long: `Add the rate limiting 'trabuco init --with-rate-limit' generates:
+
+  API     RateLimitProperties (app.rate-limit), RateLimitInterceptor on
+          /api/**, RateLimitConfig, RateLimitExceededException (429 with
+          Retry-After) (+ test)
+  POMs    bucket4j-core.version in pom.xml; bucket4j_jdk17-core and
+          caffeine in API/pom.xml
+
+Clients are keyed by authenticated principal, or by client IP when
+anonymous. Without an app.rate-limit block every client gets 100 requests
+a minute.
+
+Requires the API module.
+
+Examples:
+  trabuco generate rate-limit
+  trabuco generate rate-limit --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  runGenerateRateLimit,
+}
+
+func init() {
+	generateRateLimitCmd.Flags().BoolVar(&generateRateLimitDryRun, "dry-run", false, "Print what would be created without writing to disk")
+	generateRateLimitCmd.Flags().BoolVar(&generateRateLimitJSON, "json", false, "Emit machine-readable JSON output")
+	generateCmd.AddCommand(generateRateLimitCmd)
+}
+
+func runGenerateRateLimit(cmd *cobra.Command, args []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		printAddError(err, generateRateLimitJSON)
+		os.Exit(1)
+	}
+	ctx, err := addgen.LoadContext(cwd)
+	if err != nil {
+		printAddError(err, generateRateLimitJSON)
+		os.Exit(1)
+	}
+	meta, err := config.LoadMetadata(ctx.ProjectPath)
+	if err != nil {
+		printAddError(err, generateRateLimitJSON)
+		os.Exit(1)
+	}
+	recordHistory := trackHistory(ctx.ProjectPath, "generate rate-limit")
+
+	created, err := generator.RetrofitRateLimit(ctx.ProjectPath, meta, generateRateLimitDryRun)
+	if err != nil {
+		printAddError(err, generateRateLimitJSON)
+		os.Exit(1)
+	}
+	recordHistory()
+	pomNote := "Updated pom.xml (bucket4j-core.version) and API/pom.xml (bucket4j_jdk17-core, caffeine)."
+	if generateRateLimitDryRun {
+		pomNote = "Would update pom.xml (bucket4j-core.version) and API/pom.xml (bucket4j_jdk17-core, caffeine)."
+	}
+	printAddResult(&addgen.Result{
+		Created: created,
+		Notes:   []string{pomNote},
+		NextSteps: []string{
+			"Run ./mvnw test -pl API -am to compile the interceptor and run RateLimitInterceptorTest.",
+			"Set limits in API/src/main/resources/application.yml under app.rate-limit (enabled, capacity, refill-period, and clients.<principal> overrides).",
+			"If bucket4j-spring-boot-starter is still in API/pom.xml with bucket4j.enabled=false, remove it and its bucket4j: block.",
+		},
+	}, generateRateLimitDryRun, generateRateLimitJSON)
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/graph"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [path]",
+	Short: "Draw the module and infrastructure dependencies of a project",
+	Long: `Draw the architecture of a Trabuco project as a diagram.
+
+Module edges come from the module POMs: a module points at every other
+module it depends on, with test-jar and other test-scoped dependencies
+dashed. Infrastructure edges point from the module that holds the client
+(SQLDatastore, Events, Worker, ...) to the docker-compose service it
+connects to.
+
+Examples:
+  trabuco graph                          # Mermaid flowchart
+  trabuco graph --format dot | dot -Tsvg -o graph.svg
+  trabuco graph --format json            # nodes and edges, for agents`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runGraph,
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", graph.FormatMermaid, "Output format: mermaid, dot or json")
+}
+
+func runGraph(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+
+	if msg := graph.ValidateFormat(graphFormat); msg != "" {
+		red.Fprintf(os.Stderr, "Error: %s\n", msg)
+		os.Exit(1)
+	}
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+	g, err := graph.Build(projectPath)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch graphFormat {
+	case graph.FormatJSON:
+		data, _ := json.MarshalIndent(g, "", "  ")
+		fmt.Println(string(data))
+	case graph.FormatDOT:
+		fmt.Print(g.DOT())
+	default:
+		fmt.Print(g.Mermaid())
+	}
+}
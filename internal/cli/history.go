@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyJSON  bool
+	historyFiles bool
+	historyLimit int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the log of Trabuco operations run against this project",
+	Long: `Show .trabuco/history.jsonl, the append-only log of every mutating
+Trabuco operation run against the project: init, add, add entity/endpoint/...,
+doctor --fix, sync --apply and review install/remove/enable/disable.
+
+Each entry records when it ran, the Trabuco version, the arguments, and
+the files it created, modified or deleted. Use it to undo an operation
+by hand (delete the created files, 'git checkout' the modified ones) and
+attach 'trabuco history --json' to support requests.
+
+Examples:
+  trabuco history                # newest last, one line per operation
+  trabuco history --files        # include the files each operation touched
+  trabuco history -n 1 --files   # what did the last operation change?
+  trabuco history --json         # raw entries for bug reports`,
+	Run: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output the entries as JSON")
+	historyCmd.Flags().BoolVar(&historyFiles, "files", false, "List the files each operation touched")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 0, "Show only the last N operations")
+}
+
+func runHistory(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	projectPath, err := os.Getwd()
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: could not get current directory: %v\n", err)
+		os.Exit(1)
+	}
+	entries, err := config.LoadHistory(projectPath)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if historyLimit > 0 && len(entries) > historyLimit {
+		entries = entries[len(entries)-historyLimit:]
+	}
+
+	if historyJSON {
+		if entries == nil {
+			entries = []config.HistoryEntry{}
+		}
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No history recorded yet (%s).\n", config.HistoryFileName)
+		return
+	}
+
+	for _, e := range entries {
+		cyan.Printf("%s", e.Timestamp)
+		fmt.Printf("  %-14s", e.Operation)
+		version := e.Version
+		if version == "" {
+			version = "dev"
+		}
+		fmt.Printf("  v%-8s  %3d files  %s\n", strings.TrimPrefix(version, "v"), e.FilesTouched(), e.Command())
+		if historyFiles {
+			for _, f := range e.FilesCreated {
+				green.Printf("    + %s\n", f)
+			}
+			for _, f := range e.FilesModified {
+				yellow.Printf("    ~ %s\n", f)
+			}
+			for _, f := range e.FilesDeleted {
+				red.Printf("    - %s\n", f)
+			}
+		}
+	}
+	if !historyFiles {
+		fmt.Println()
+		fmt.Println("Use --files to see what each operation changed.")
+	}
+}
+
+// trackHistory snapshots the project before a mutating command and returns
+// a function that appends its .trabuco/history.jsonl entry. Call it only
+// once the command succeeded. Directories that are not Trabuco projects
+// are left alone.
+func trackHistory(projectPath, operation string) func() {
+	recorder := generator.NewHistoryRecorder(projectPath, operation)
+	return func() {
+		if !config.MetadataExists(projectPath) {
+			return
+		}
+		if err := recorder.Record(Version, os.Args[1:]); err != nil {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "Warning: failed to record history: %v\n", err)
+		}
+	}
+}
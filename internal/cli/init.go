@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,11 +11,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/arianlopezc/Trabuco/internal/config"
 	"github.com/arianlopezc/Trabuco/internal/generator"
 	"github.com/arianlopezc/Trabuco/internal/java"
+	"github.com/arianlopezc/Trabuco/internal/mcp"
+	"github.com/arianlopezc/Trabuco/internal/plugin"
 	"github.com/arianlopezc/Trabuco/internal/prompts"
 	"github.com/arianlopezc/Trabuco/internal/utils"
 )
@@ -31,9 +35,11 @@ var (
 	flagGroupID       string
 	flagModules       string
 	flagDatabase      string
+	flagDBVersion     string // SQL database image version, e.g. "16" or "8.4"
 	flagNoSQLDatabase string
 	flagMessageBroker string
 	flagJavaVersion   string
+	flagLanguage      string // "java" or "kotlin"
 	flagAIAgents      string
 	flagCI            string
 	flagReview        string // "full" (default), "minimal", or "off"
@@ -42,6 +48,52 @@ var (
 	flagStrict        bool
 	flagSkipBuild     bool
 	flagRunTests      bool
+	flagKeepPartial   bool
+	flagProfile       string // Profile from ~/.trabuco/config.yaml ("" = default_profile, if any)
+	flagLicense       string // "apache2", "mit", "proprietary:<file>" or "" (profile header only)
+	flagObservability bool
+	flagNative        bool
+	flagPagination    bool
+	flagRateLimit     bool
+	flagIdempotency   bool
+	flagKafkaTx       bool
+	flagKafkaGroup    string // Consumer group naming convention, e.g. "{project}.orders"
+	flagKafkaConcurrency  int
+	flagKafkaPartitions   int
+	flagKafkaPollRecords  int
+	flagKafkaPollInterval string // max.poll.interval.ms as a duration, e.g. "5m"
+	flagKafkaAckMode      string // "batch", "record", "manual" or ""
+	flagSQSFifo           bool
+	flagSQSDLQ            bool
+	flagSQSReceiveCount   int
+	flagPubSubOrdering    bool
+	flagPubSubExactlyOnce bool
+	flagPerf          bool
+	flagDevContainer  bool
+	flagCodeOwners    bool
+	flagTaskRunner    string // "make", "task" or "none"
+	flagAuditing      bool
+	flagReadReplica   bool
+	flagCache         string // "caffeine", "redis", "none" or ""
+	flagNoCoverage    bool
+	flagAnalysis      string // "errorprone", "none" or ""
+	flagJPMS          bool
+	flagPreview       bool
+	flagSecrets       string // "vault", "aws", "gcp", "auto", "none" or ""
+	flagJobStorage    string // "postgres", "mysql", "existing-sql", "in-memory" or ""
+	flagJobRunrDashboard  bool
+	flagJobRunrBatches    bool
+	flagJobRunrCarbonArea string // ENTSO-E area code, e.g. "BE" or "US-CA"
+	flagRecommendID   string
+	flagImageRegistry string // Registry prefix for Docker image names, e.g. ghcr.io/acme
+	flagImageBuilder  string // "dockerfile" or "jib"
+	flagLogFormat     string // "json", "ecs" or "plain"
+	flagArchitecture  string // "multi-module" or "modulith"
+	flagPreset        string // Preset from 'trabuco presets' (modules + recommended backends)
+	flagSpec          string // YAML project spec (settings + scaffolds)
+	flagOnExists      string // "fail", "force" or "merge"
+	flagYes           bool   // Skip the --on-exists force confirmation
+	flagDryRun        bool   // List what init would generate without writing it
 )
 
 var initCmd = &cobra.Command{
@@ -64,27 +116,104 @@ activate JWT validation. Picking API or AIAgent also auto-resolves
 Shared as a hard dependency (the auth runtime utilities live there).
 See docs/auth.md for per-provider recipes.
 
+Defaults (group ID prefix, Java version, database, broker, AI agents,
+CI provider, license, image registry, template packs) can come from
+~/.trabuco/config.yaml: its defaults block plus a profile selected with
+--profile or default_profile ('trabuco config' shows what applies).
+Explicit flags always win over these values. With a group_id_prefix
+configured, --group-id may be omitted.
+
+Presets bundle a module set with its recommended database/broker
+('trabuco presets' lists them); with --preset, --modules may be omitted:
+  trabuco init --preset event-driven-postgres-kafka --name=orders --group-id=com.acme.orders
+
 For non-interactive mode, provide all required flags:
-  trabuco init --name=myproject --group-id=com.company.project --modules=Model,SQLDatastore --database=postgresql`,
+  trabuco init --name=myproject --group-id=com.company.project --modules=Model,SQLDatastore --database=postgresql
+
+Or declare the project in a YAML spec: name, group_id, modules, database,
+broker, agents, CI, any other init flag under options, and entities,
+endpoints and jobs to scaffold under scaffolds. Explicit flags override it:
+  trabuco init --spec project.yaml
+
+When the project directory already exists, init stops
+unless --on-exists says otherwise:
+  fail   stop without touching it (default)
+  force  generate afresh, after confirmation (--yes skips it); the old
+         directory is moved to <name>.trabuco-backup-<timestamp>
+  merge  write only the files that are missing and list the existing
+         files whose content differs from what Trabuco would generate
+
+To see what a configuration would generate without writing anything, add
+--dry-run: it lists the file tree, the docker-compose services, the parent
+POM properties and the file, class and line counts:
+  trabuco init --name=orders --group-id=com.acme.orders --modules=SQLDatastore,API --database=postgresql --dry-run`,
 	Run: runInit,
 }
 
 func init() {
 	initCmd.Flags().StringVar(&flagProjectName, "name", "", "Project name (non-interactive)")
 	initCmd.Flags().StringVar(&flagGroupID, "group-id", "", "Group ID, e.g., com.company.project (non-interactive)")
-	initCmd.Flags().StringVar(&flagModules, "modules", "", "Comma-separated modules: Model,SQLDatastore,NoSQLDatastore,Shared,API,EventConsumer (SQLDatastore and NoSQLDatastore are mutually exclusive)")
-	initCmd.Flags().StringVar(&flagDatabase, "database", "postgresql", "SQL database type: postgresql, mysql, none (non-interactive)")
-	initCmd.Flags().StringVar(&flagNoSQLDatabase, "nosql-database", "mongodb", "NoSQL database type: mongodb, redis (non-interactive)")
-	initCmd.Flags().StringVar(&flagMessageBroker, "message-broker", "kafka", "Message broker type: kafka, rabbitmq, sqs, pubsub (non-interactive, only used when EventConsumer is selected)")
+	initCmd.Flags().StringVar(&flagModules, "modules", "", "Comma-separated modules: "+strings.Join(config.GetSelectableModules(), ",")+" (SQLDatastore and NoSQLDatastore are mutually exclusive)")
+	initCmd.Flags().StringVar(&flagDatabase, "database", "postgresql", "SQL database type: postgresql, mysql, mariadb, none (non-interactive)")
+	initCmd.Flags().StringVar(&flagDBVersion, "db-version", "", "Pin the SQL database image version used by docker-compose, CI and Testcontainers, e.g. 16 (PostgreSQL), 8.4 (MySQL) or 11.4 (MariaDB); default: the version this Trabuco release ships with")
+	initCmd.Flags().StringVar(&flagNoSQLDatabase, "nosql-database", "mongodb", "NoSQL database type: mongodb, redis, dynamodb, cassandra (non-interactive)")
+	initCmd.Flags().StringVar(&flagMessageBroker, "message-broker", "kafka", "Message broker type: kafka, rabbitmq, sqs, pubsub, redis-streams, nats (non-interactive, only used when EventConsumer is selected)")
 	initCmd.Flags().StringVar(&flagJavaVersion, "java-version", "21", "Java version: 21 or 24 (non-interactive)")
-	initCmd.Flags().StringVar(&flagAIAgents, "ai-agents", "", "Comma-separated AI agents: claude,cursor,copilot,codex (non-interactive)")
+	initCmd.Flags().StringVar(&flagLanguage, "language", config.LanguageJava, "Application language: java or kotlin (Kotlin data classes instead of Immutables, kotlin-maven-plugin, Jackson Kotlin module)")
+	initCmd.Flags().StringVar(&flagAIAgents, "ai-agents", "", "Comma-separated AI agents: claude,cursor,copilot,codex,gemini,aider,zed (non-interactive)")
 	initCmd.Flags().StringVar(&flagCI, "ci", "", "CI provider to generate (github)")
 	initCmd.Flags().StringVar(&flagReview, "review", "full", "Review automation: full (subagents + hooks + skills), minimal (no Stop hook guard), off (no review artifacts). Only applies when Claude is among --ai-agents.")
 	initCmd.Flags().StringVar(&flagVectorStore, "vector-store", "", "Vector RAG backend for AIAgent: pgvector, qdrant, mongodb, or none (default: keyword retrieval only). Only meaningful when AIAgent is selected.")
 	initCmd.Flags().BoolVar(&flagIncludeClaude, "include-claude", false, "Deprecated: use --ai-agents=claude instead")
 	initCmd.Flags().BoolVar(&flagStrict, "strict", false, "Fail if specified Java version is not detected (non-interactive)")
 	initCmd.Flags().BoolVar(&flagSkipBuild, "skip-build", false, "Skip running 'mvn clean install' after generation")
+	initCmd.Flags().StringVar(&flagSpec, "spec", "", "YAML project spec with the init settings and the entities, endpoints and jobs to scaffold (see docs/manual.md). Explicit flags override it")
+	initCmd.Flags().StringVar(&flagPreset, "preset", "", "Project preset (see 'trabuco presets'), e.g. event-driven-postgres-kafka. Explicit flags override it")
+	initCmd.Flags().BoolVar(&flagNative, "native", false, "Add GraalVM native image support: a 'native' Maven profile, AOT hints, Dockerfile.native per runtime module and a CI job")
+	initCmd.Flags().BoolVar(&flagPagination, "with-pagination", false, "Add a paginated, sorted and filtered list endpoint (GET /api/placeholders/page) with page DTOs and a sort whitelist; needs API and SQLDatastore or MongoDB")
+	initCmd.Flags().BoolVar(&flagRateLimit, "with-rate-limit", false, "Add per-client rate limiting to the API: Bucket4j token buckets keyed by principal or client IP, limits under app.rate-limit in application.yml, 429 problem responses; needs API")
+	initCmd.Flags().BoolVar(&flagIdempotency, "with-idempotency", false, "Add Idempotency-Key handling to API POST endpoints: retries replay the stored response, keys kept in a SQLDatastore table (Flyway migration) or in Redis, settings under app.idempotency in application.yml; needs API and SQLDatastore or a Redis service")
+	initCmd.Flags().BoolVar(&flagKafkaTx, "kafka-transactions", false, "Add exactly-once Kafka processing to the EventConsumer: a transactional consume-process-produce listener, a transactional idempotent producer, read_committed consumers and transaction ids in application.yml; needs --message-broker kafka and EventConsumer")
+	initCmd.Flags().StringVar(&flagKafkaGroup, "kafka-consumer-group", "", "Consumer group naming convention of the EventConsumer's Kafka listener, {project} standing for the project name (default {project}-consumers)")
+	initCmd.Flags().IntVar(&flagKafkaConcurrency, "kafka-concurrency", 0, "Kafka consumer threads per EventConsumer instance (default 3)")
+	initCmd.Flags().IntVar(&flagKafkaPartitions, "kafka-partitions", 0, "Partitions of the topics the docker-compose Kafka creates (default: the consumer threads)")
+	initCmd.Flags().IntVar(&flagKafkaPollRecords, "kafka-max-poll-records", 0, "Records per Kafka poll, max.poll.records (default 500)")
+	initCmd.Flags().StringVar(&flagKafkaPollInterval, "kafka-max-poll-interval", "", "Longest time between Kafka polls before the consumer leaves the group, max.poll.interval.ms, as a duration such as 90s or 10m (default 5m)")
+	initCmd.Flags().StringVar(&flagKafkaAckMode, "kafka-ack-mode", "", "When the EventConsumer commits Kafka offsets: batch (after each poll), record (after each record) or manual (the listener calls Acknowledgment.acknowledge()); default batch")
+	initCmd.Flags().BoolVar(&flagSQSFifo, "sqs-fifo", false, "Create FIFO SQS queues with content-based deduplication; events of one placeholder share a message group and arrive in order (needs --message-broker sqs)")
+	initCmd.Flags().BoolVar(&flagSQSDLQ, "sqs-dlq", false, "Give each SQS queue a dead-letter queue and a redrive policy in the LocalStack init scripts (needs --message-broker sqs)")
+	initCmd.Flags().IntVar(&flagSQSReceiveCount, "sqs-max-receive-count", 0, "Receives before SQS moves a message to the dead-letter queue, 1 to 1000 (default 5, needs --sqs-dlq)")
+	initCmd.Flags().BoolVar(&flagPubSubOrdering, "pubsub-ordering", false, "Publish Pub/Sub events with an ordering key and create the subscriptions with message ordering (needs --message-broker pubsub)")
+	initCmd.Flags().BoolVar(&flagPubSubExactlyOnce, "pubsub-exactly-once", false, "Create the Pub/Sub subscriptions with exactly-once delivery; the listener waits for each ack to be confirmed (needs --message-broker pubsub)")
+	initCmd.Flags().BoolVar(&flagPerf, "with-perf", false, "Add a k6 load test of the Placeholder endpoints under perf/, a run script against the docker-compose stack and a manual GitHub Actions workflow that uploads the results; needs API and a datastore")
+	initCmd.Flags().BoolVar(&flagCodeOwners, "with-codeowners", false, "Add a CODEOWNERS file mapping each module to a placeholder team, and a boundary doc per module under docs/modules/; 'trabuco add' keeps both in step with the modules")
+	initCmd.Flags().BoolVar(&flagDevContainer, "with-devcontainer", false, "Add .devcontainer/ for GitHub Codespaces and VS Code dev containers: the project's JDK, Maven, Docker-in-Docker and forwarded ports for the selected modules")
+	initCmd.Flags().StringVar(&flagTaskRunner, "task-runner", config.TaskRunnerMake, "Root file of developer shortcuts (up, down, run-api, run-worker, test, fmt, verify...) kept in sync with the modules: make (Makefile), task (Taskfile.yml) or none")
+	initCmd.Flags().BoolVar(&flagReadReplica, "with-read-replica", false, "Add a read-only replica datasource to SQLDatastore with @Transactional(readOnly = true) routed to it, per-pool HikariCP settings and, on PostgreSQL, a streaming replica in docker-compose; needs SQLDatastore with postgresql, mysql or mariadb")
+	initCmd.Flags().BoolVar(&flagAuditing, "with-auditing", false, "Add created_by and deleted_at columns, Spring Data JDBC auditing (@EnableJdbcAuditing) and soft deletes to the Placeholder scaffolding; needs SQLDatastore")
+	initCmd.Flags().StringVar(&flagCache, "with-cache", "", "Cache PlaceholderService lookups with Spring Cache: caffeine (in-process) or redis (shared, reuses or adds the Redis service); needs Shared and a datastore")
+	initCmd.Flags().StringVar(&flagAnalysis, "static-analysis", "", "Compile-time static analysis: errorprone (Error Prone + NullAway on main sources, Java only) or none")
+	initCmd.Flags().BoolVar(&flagJPMS, "jpms", false, "Generate a module-info.java per Maven module from its imports: requires what it uses, exports only what other modules use (Java only)")
+	initCmd.Flags().BoolVar(&flagPreview, "enable-preview", false, "Compile, test and run with --enable-preview to use the preview features of the Java version (Java only, not with --native)")
+	initCmd.Flags().BoolVar(&flagNoCoverage, "no-coverage-gates", false, "Keep JaCoCo reports but drop the minimum-coverage check from the build and CI (for prototypes)")
+	initCmd.Flags().StringVar(&flagSecrets, "secrets", "", "Load credentials from a secrets manager: vault (Spring Cloud Vault + a dev-mode Vault in docker-compose), aws (Secrets Manager), gcp (Secret Manager), auto (follows the message broker's cloud) or none")
+	initCmd.Flags().StringVar(&flagJobStorage, "job-storage", "", "Where the Worker's JobRunr stores jobs: postgres or mysql (the SQLDatastore database, or a database container of its own), existing-sql (the SQLDatastore datasource) or in-memory (lost on restart, not shared between processes); default picks from the datastores")
+	initCmd.Flags().BoolVar(&flagJobRunrDashboard, "with-jobrunr-dashboard", false, "Turn the Worker's JobRunr dashboard on by default (basic auth from JOBRUNR_DASHBOARD_USERNAME/PASSWORD) and publish port 8000 from the docker-compose worker service; needs Worker")
+	initCmd.Flags().BoolVar(&flagJobRunrBatches, "with-jobrunr-batches", false, "Tune the Worker's JobRunr server for bulk workloads: worker count, poll interval, retries and job retention from environment variables; needs Worker")
+	initCmd.Flags().StringVar(&flagJobRunrCarbonArea, "jobrunr-carbon-area", "", "Enable JobRunr carbon-aware scheduling for an ENTSO-E area code, e.g. BE, DE or US-CA: jobs scheduled with CarbonAware margins run when the grid is greenest; needs Worker")
+	initCmd.Flags().StringVar(&flagRecommendID, "recommendation-id", "", "recommendation_id returned by the MCP suggest_architecture tool; records locally whether its modules were kept (needs pattern_feedback: true)")
+	initCmd.Flags().BoolVar(&flagObservability, "observability", false, "Enable OTLP tracing by default and add a Prometheus + Grafana + Tempo stack (docker-compose profile 'observability')")
+	initCmd.Flags().StringVar(&flagLicense, "license", "", "Project license: apache2, mit, or proprietary:<header-file>. Adds LICENSE, a header on every .java and .kt file, and a Spotless licenseHeader rule")
+	initCmd.Flags().StringVar(&flagImageRegistry, "image-registry", "", "Registry prefix for Docker image names in the generated docs, CI and Jib configuration, e.g. ghcr.io/acme")
+	initCmd.Flags().StringVar(&flagImageBuilder, "image-builder", config.ImageBuilderDockerfile, "How runtime modules become container images: dockerfile (a Dockerfile per module) or jib (jib-maven-plugin, no Dockerfile or Docker daemon)")
+	initCmd.Flags().StringVar(&flagLogFormat, "log-format", config.LogFormatJSON, "Log encoding of the runtime modules outside the local profile: json (Logstash JSON), ecs (Elastic Common Schema) or plain (text lines); every format carries the correlation and trace IDs")
+	initCmd.Flags().StringVar(&flagArchitecture, "architecture", config.ArchitectureMultiModule, "Build layout: multi-module (a Maven module per Trabuco module) or modulith (one Spring Boot application whose packages are the modules, boundaries verified by Spring Modulith; needs API)")
+	initCmd.Flags().StringVar(&flagProfile, "profile", "", "Defaults profile from ~/.trabuco/config.yaml (default: its default_profile)")
+	initCmd.Flags().BoolVar(&flagKeepPartial, "keep-partial", false, "Keep the partially generated project (in a hidden staging directory) when generation fails, for debugging")
 	initCmd.Flags().BoolVar(&flagRunTests, "run-tests", false, "Run the full test suite during the post-generation build (omits -DskipTests). Used by e2e CI jobs.")
+	initCmd.Flags().StringVar(&flagOnExists, "on-exists", generator.OnExistsFail, "What to do when the project directory already exists: fail, force (replace it, keeping the old one as a backup) or merge (write only missing files and report conflicts)")
+	initCmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Do not ask for confirmation before --on-exists force replaces the directory")
+	initCmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "List the files, docker-compose services and POM properties init would generate, without writing anything")
 }
 
 func runInit(cmd *cobra.Command, args []string) {
@@ -92,14 +221,20 @@ func runInit(cmd *cobra.Command, args []string) {
 	green := color.New(color.FgGreen)
 	yellow := color.New(color.FgYellow)
 
+	if err := generator.ValidateOnExists(flagOnExists); err != nil {
+		color.Red("Error: %v\n", err)
+		return
+	}
+
 	cyan.Println("\n╔════════════════════════════════════════╗")
 	cyan.Println("║   Trabuco - Java Project Generator     ║")
 	cyan.Println("╚════════════════════════════════════════╝")
 	fmt.Println()
 
-	// Validate Docker is running (required for Testcontainers and local development)
+	// Validate Docker is running (required for Testcontainers and local
+	// development); a dry run writes nothing, so it does not need it
 	dockerStatus := utils.CheckDocker()
-	if !dockerStatus.Running {
+	if !flagDryRun && !dockerStatus.CanRunTestcontainers() {
 		color.Red("Error: Docker is required but not available.\n")
 		if !dockerStatus.Installed {
 			color.Red("       Docker is not installed. Please install Docker Desktop.\n")
@@ -109,12 +244,67 @@ func runInit(cmd *cobra.Command, args []string) {
 		color.Yellow("\nDocker is required for:\n")
 		color.Yellow("  - Running integration tests (Testcontainers)\n")
 		color.Yellow("  - Local development with docker-compose\n")
+		color.Yellow("\nA remote daemon (DOCKER_HOST=tcp://... or ssh://...) or Testcontainers Cloud (TC_CLOUD_TOKEN) also works.\n")
 		fmt.Println()
 		return
 	}
+	if !flagDryRun && !dockerStatus.Running {
+		yellow.Println("Docker is not available locally; integration tests will run on Testcontainers Cloud.")
+		yellow.Println("docker-compose based local development still needs a Docker daemon.")
+		fmt.Println()
+	}
+
+	// A spec fills every flag the user didn't pass explicitly, before the
+	// preset and the profile get a say
+	var spec *config.ProjectSpec
+	if flagSpec != "" {
+		loaded, err := config.LoadProjectSpec(flagSpec)
+		if err != nil {
+			color.Red("Error: %v\n", err)
+			return
+		}
+		if err := applySpec(cmd, loaded); err != nil {
+			color.Red("Error: %v\n", err)
+			return
+		}
+		spec = loaded
+	}
+
+	// Template packs from the user config only join module lists the user
+	// did not spell out
+	modulesExplicit := cmd.Flags().Changed("modules")
+
+	// A preset fills modules and backends the user didn't pass explicitly
+	if flagPreset != "" {
+		preset, err := mcp.FindPreset(flagPreset)
+		if err != nil {
+			color.Red("Error: %v\n", err)
+			return
+		}
+		if flagProjectName == "" {
+			color.Red("Error: --preset requires --name (and --group-id unless your profile sets group_id_prefix)\n")
+			return
+		}
+		applyPreset(cmd, preset)
+	}
+
+	// Fill unset flags from the config profile; explicit flags win
+	profile, err := config.LoadProfile(flagProfile)
+	if err != nil {
+		color.Red("Error: %v\n", err)
+		return
+	}
+	applyProfileDefaults(cmd, profile)
+	if !modulesExplicit && flagModules != "" {
+		flagModules = withTemplatePacks(flagModules, profile)
+	}
+
+	if spec != nil && flagGroupID == "" {
+		color.Red("Error: %s sets no group_id and no profile provides a group_id_prefix\n", flagSpec)
+		return
+	}
 
 	var cfg *config.ProjectConfig
-	var err error
 
 	// Check if non-interactive mode (flags provided)
 	if flagProjectName != "" && flagGroupID != "" && flagModules != "" {
@@ -163,24 +353,32 @@ func runInit(cmd *cobra.Command, args []string) {
 			fmt.Fprintln(os.Stderr)
 		}
 
+		// Validate language
+		if langErr := config.ValidateLanguageFlag(flagLanguage); langErr != "" {
+			color.Red("\nError: %s\n", langErr)
+			return
+		}
+
 		// Validate database type
-		validDatabases := map[string]bool{"postgresql": true, "mysql": true, "none": true, "generic": true, "": true}
+		validDatabases := map[string]bool{"postgresql": true, "mysql": true, "mariadb": true, "none": true, "generic": true, "": true}
 		if !validDatabases[flagDatabase] {
-			color.Red("\nError: Invalid database type '%s'. Must be postgresql, mysql, or none.\n", flagDatabase)
+			color.Red("\nError: Invalid database type '%s'. Must be postgresql, mysql, mariadb, or none.\n", flagDatabase)
+			return
+		}
+		if vErr := config.ValidateDatabaseVersionFlag(flagDBVersion); vErr != "" {
+			color.Red("\nError: %s\n", vErr)
 			return
 		}
 
 		// Validate NoSQL database type
-		validNoSQLDatabases := map[string]bool{"mongodb": true, "redis": true, "": true}
-		if !validNoSQLDatabases[flagNoSQLDatabase] {
-			color.Red("\nError: Invalid NoSQL database type '%s'. Must be mongodb or redis.\n", flagNoSQLDatabase)
+		if flagNoSQLDatabase != "" && !config.IsValidNoSQLDatabase(flagNoSQLDatabase) {
+			color.Red("\nError: Invalid NoSQL database type '%s'. Must be %s.\n", flagNoSQLDatabase, strings.Join(config.NoSQLDatabases, ", "))
 			return
 		}
 
 		// Validate message broker type
-		validMessageBrokers := map[string]bool{"kafka": true, "rabbitmq": true, "sqs": true, "pubsub": true, "": true}
-		if !validMessageBrokers[flagMessageBroker] {
-			color.Red("\nError: Invalid message broker type '%s'. Must be kafka, rabbitmq, sqs, or pubsub.\n", flagMessageBroker)
+		if flagMessageBroker != "" && !config.IsValidMessageBroker(flagMessageBroker) {
+			color.Red("\nError: Invalid message broker type '%s'. Must be %s.\n", flagMessageBroker, strings.Join(config.MessageBrokers, ", "))
 			return
 		}
 
@@ -192,6 +390,57 @@ func runInit(cmd *cobra.Command, args []string) {
 			return
 		}
 
+		if sErr := config.ValidateSecretsFlag(flagSecrets); sErr != "" {
+			color.Red("\nError: %s\n", sErr)
+			return
+		}
+		if aErr := config.ValidateStaticAnalysisFlag(flagAnalysis); aErr != "" {
+			color.Red("\nError: %s\n", aErr)
+			return
+		}
+		if cErr := config.ValidateCacheFlag(flagCache); cErr != "" {
+			color.Red("\nError: %s\n", cErr)
+			return
+		}
+		if jErr := config.ValidateJobStorageFlag(flagJobStorage); jErr != "" {
+			color.Red("\nError: %s\n", jErr)
+			return
+		}
+		if jErr := config.ValidateJobRunrCarbonAreaFlag(flagJobRunrCarbonArea); jErr != "" {
+			color.Red("\nError: %s\n", jErr)
+			return
+		}
+		for _, kErr := range []string{
+			config.ValidateKafkaConsumerGroupFlag(flagKafkaGroup),
+			config.ValidateKafkaCountFlag("kafka-concurrency", flagKafkaConcurrency),
+			config.ValidateKafkaCountFlag("kafka-partitions", flagKafkaPartitions),
+			config.ValidateKafkaCountFlag("kafka-max-poll-records", flagKafkaPollRecords),
+			config.ValidateKafkaMaxPollIntervalFlag(flagKafkaPollInterval),
+			config.ValidateKafkaAckModeFlag(flagKafkaAckMode),
+			config.ValidateSQSMaxReceiveCountFlag(flagSQSReceiveCount),
+		} {
+			if kErr != "" {
+				color.Red("\nError: %s\n", kErr)
+				return
+			}
+		}
+		if tErr := config.ValidateTaskRunnerFlag(flagTaskRunner); tErr != "" {
+			color.Red("\nError: %s\n", tErr)
+			return
+		}
+		if iErr := config.ValidateImageBuilderFlag(flagImageBuilder); iErr != "" {
+			color.Red("\nError: %s\n", iErr)
+			return
+		}
+		if lErr := config.ValidateLogFormatFlag(flagLogFormat); lErr != "" {
+			color.Red("\nError: %s\n", lErr)
+			return
+		}
+		if aErr := config.ValidateArchitectureFlag(flagArchitecture); aErr != "" {
+			color.Red("\nError: %s\n", aErr)
+			return
+		}
+
 		// Parse and validate AI agents
 		var aiAgents []string
 		if flagAIAgents != "" {
@@ -267,13 +516,54 @@ func runInit(cmd *cobra.Command, args []string) {
 			ArtifactID:          flagProjectName,
 			JavaVersion:         flagJavaVersion,
 			JavaVersionDetected: javaVersionDetected,
+			Language:            flagLanguage,
 			Modules:             resolvedModules,
 			Database:            flagDatabase,
+			DatabaseVersion:     flagDBVersion,
 			NoSQLDatabase:       flagNoSQLDatabase,
 			MessageBroker:       flagMessageBroker,
 			AIAgents:            aiAgents,
 			CIProvider:          flagCI,
 			VectorStore:         flagVectorStore,
+			LicenseHeader:       profileLicenseHeader(profile),
+			ImageRegistry:       flagImageRegistry,
+			ImageBuilder:        flagImageBuilder,
+			LogFormat:           flagLogFormat,
+			Architecture:        flagArchitecture,
+			Observability:       flagObservability,
+			Native:              flagNative,
+			Pagination:          flagPagination,
+			RateLimit:           flagRateLimit,
+			Idempotency:         flagIdempotency,
+			KafkaTransactions:   flagKafkaTx,
+			KafkaConsumerGroup:  flagKafkaGroup,
+			KafkaConcurrency:    flagKafkaConcurrency,
+			KafkaPartitions:     flagKafkaPartitions,
+			KafkaMaxPollRecords: flagKafkaPollRecords,
+			KafkaMaxPollInterval: flagKafkaPollInterval,
+			KafkaAckMode:        flagKafkaAckMode,
+
+			SQSFifo:            flagSQSFifo,
+			SQSDeadLetter:      flagSQSDLQ,
+			SQSMaxReceiveCount: flagSQSReceiveCount,
+			PubSubOrdering:     flagPubSubOrdering,
+			PubSubExactlyOnce:  flagPubSubExactlyOnce,
+			Perf:                flagPerf,
+			DevContainer:        flagDevContainer,
+			CodeOwners:          flagCodeOwners,
+			TaskRunner:          flagTaskRunner,
+			Auditing:            flagAuditing,
+			ReadReplica:         flagReadReplica,
+			Cache:               flagCache,
+			NoCoverageGates:     flagNoCoverage,
+			StaticAnalysis:      flagAnalysis,
+			JPMS:                flagJPMS,
+			EnablePreview:       flagPreview,
+			Secrets:             flagSecrets,
+			JobStorage:          flagJobStorage,
+			JobRunrDashboard:    flagJobRunrDashboard,
+			JobRunrBatches:      flagJobRunrBatches,
+			JobRunrCarbonArea:   flagJobRunrCarbonArea,
 			Review: config.ReviewConfig{
 				Mode:        flagReview,
 				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
@@ -288,16 +578,66 @@ func runInit(cmd *cobra.Command, args []string) {
 			fmt.Fprintln(os.Stderr)
 		}
 
+		if cfg.Pagination && !cfg.SupportsPagination() {
+			yellow.Fprintf(os.Stderr, "\nWarning: --with-pagination needs the API module and SQLDatastore or NoSQLDatastore with MongoDB.\n")
+			fmt.Fprintln(os.Stderr, "  The pagination scaffolding will not be generated.")
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if cfg.RateLimit && !cfg.HasRateLimit() {
+			yellow.Fprintf(os.Stderr, "\nWarning: --with-rate-limit needs the API module.\n")
+			fmt.Fprintln(os.Stderr, "  The rate limiting interceptor will not be generated.")
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if cfg.Idempotency && !cfg.HasIdempotency() {
+			yellow.Fprintf(os.Stderr, "\nWarning: --with-idempotency needs the API module and SQLDatastore or a Redis service (NoSQLDatastore with Redis, Redis Streams or --cache redis).\n")
+			fmt.Fprintln(os.Stderr, "  The idempotency filter will not be generated.")
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if cfg.KafkaTransactions && !cfg.HasKafkaTransactions() {
+			yellow.Fprintf(os.Stderr, "\nWarning: --kafka-transactions needs --message-broker kafka and the EventConsumer module.\n")
+			fmt.Fprintln(os.Stderr, "  The transactional Kafka processor will not be generated.")
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if cfg.Perf && !cfg.HasPerf() {
+			yellow.Fprintf(os.Stderr, "\nWarning: --with-perf needs the API module and a datastore.\n")
+			fmt.Fprintln(os.Stderr, "  The perf/ load test will not be generated.")
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if cfg.Auditing && !cfg.HasAuditing() {
+			yellow.Fprintf(os.Stderr, "\nWarning: --with-auditing needs the SQLDatastore module.\n")
+			fmt.Fprintln(os.Stderr, "  The auditing columns and soft deletes will not be generated.")
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if cfg.ReadReplica && !cfg.HasReadReplica() {
+			yellow.Fprintf(os.Stderr, "\nWarning: --with-read-replica needs the SQLDatastore module with PostgreSQL, MySQL or MariaDB.\n")
+			fmt.Fprintln(os.Stderr, "  The read datasource and routing will not be generated.")
+			fmt.Fprintln(os.Stderr)
+		}
+
 		fmt.Println("Running in non-interactive mode...")
 	} else {
 		// Interactive mode - run prompts
-		cfg, err = prompts.RunPrompts()
+		cfg, err = prompts.RunPrompts(profile)
 		if err != nil {
 			color.Red("\nError: %v\n", err)
 			return
 		}
+		cfg.ImageRegistry = flagImageRegistry
+		cfg.ImageBuilder = flagImageBuilder
+		cfg.TaskRunner = flagTaskRunner
+		cfg.LogFormat = flagLogFormat
+		cfg.Architecture = flagArchitecture
 	}
 
+	// Adapt the generated Testcontainers setup to the runtime we found
+	cfg.ContainerRuntime = dockerStatus.Runtime
+
 	// Ensure review config is populated for both interactive and non-interactive
 	// paths. Interactive mode doesn't prompt for review mode — we default to full.
 	if cfg.Review.Mode == "" {
@@ -307,6 +647,15 @@ func runInit(cmd *cobra.Command, args []string) {
 		cfg.Review.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
 	}
 
+	// --license replaces any header coming from the profile
+	if flagLicense != "" {
+		cfg.License, cfg.LicenseHeader, err = config.ResolveLicense(flagLicense, cfg.ProjectName)
+		if err != nil {
+			color.Red("\nError: %v\n", err)
+			return
+		}
+	}
+
 	// Apply vector-store cross-flag rules (auto-add SQLDatastore for
 	// pgvector, coerce nosql-database for mongodb, surface conflicts
 	// like pgvector + mysql). Snapshot inputs first so we can tell the
@@ -330,17 +679,86 @@ func runInit(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// --secrets=auto picks the manager of the cloud the project already uses
+	if sErr := cfg.ResolveSecrets(); sErr != "" {
+		color.Red("\nError: %s\n", sErr)
+		return
+	}
+	if aErr := cfg.ResolveStaticAnalysis(); aErr != "" {
+		color.Red("\nError: %s\n", aErr)
+		return
+	}
+	if jErr := cfg.ResolveJPMS(); jErr != "" {
+		color.Red("\nError: %s\n", jErr)
+		return
+	}
+	if aErr := cfg.ResolveArchitecture(); aErr != "" {
+		color.Red("\nError: %s\n", aErr)
+		return
+	}
+	if pErr := cfg.ResolvePreview(); pErr != "" {
+		color.Red("\nError: %s\n", pErr)
+		return
+	}
+	if cErr := cfg.ResolveCache(); cErr != "" {
+		color.Red("\nError: %s\n", cErr)
+		return
+	}
+	if vErr := cfg.ResolveDatabaseVersion(); vErr != "" {
+		color.Red("\nError: %s\n", vErr)
+		return
+	}
+	if jErr := cfg.ResolveJobStorage(); jErr != "" {
+		color.Red("\nError: %s\n", jErr)
+		return
+	}
+	if jErr := cfg.ResolveJobRunrFeatures(); jErr != "" {
+		color.Red("\nError: %s\n", jErr)
+		return
+	}
+	for _, w := range cfg.JobStorageWarnings() {
+		yellow.Fprintf(os.Stderr, "\nWarning: %s\n", w)
+	}
+	if kErr := cfg.ResolveKafkaConsumer(); kErr != "" {
+		color.Red("\nError: %s\n", kErr)
+		return
+	}
+	for _, w := range cfg.KafkaConsumerWarnings() {
+		yellow.Fprintf(os.Stderr, "\nWarning: %s\n", w)
+	}
+	if bErr := cfg.ResolveBrokerDelivery(); bErr != "" {
+		color.Red("\nError: %s\n", bErr)
+		return
+	}
+	if nErr := cfg.ResolveNames(); nErr != "" {
+		color.Red("\nError: %s\n", nErr)
+		return
+	}
+
 	// Display summary
 	fmt.Println()
 	yellow.Println("─────────────────────────────────────────")
 	yellow.Println("  Project Summary")
 	yellow.Println("─────────────────────────────────────────")
 	fmt.Printf("  Project:    %s\n", cfg.ProjectName)
+	if flagPreset != "" {
+		fmt.Printf("  Preset:     %s\n", flagPreset)
+	}
+	if profile != nil {
+		fmt.Printf("  Profile:    %s\n", profile.Name)
+	}
 	fmt.Printf("  Group ID:   %s\n", cfg.GroupID)
 	fmt.Printf("  Java:       %s\n", cfg.JavaVersion)
+	if cfg.IsKotlin() {
+		fmt.Printf("  Language:   Kotlin\n")
+	}
 	fmt.Printf("  Modules:    %s\n", strings.Join(cfg.Modules, ", "))
 	if cfg.HasModule(config.ModuleSQLDatastore) {
-		fmt.Printf("  SQL DB:     %s\n", cfg.Database)
+		if cfg.DatabaseVersion != "" {
+			fmt.Printf("  SQL DB:     %s (%s)\n", cfg.Database, cfg.DatabaseImage())
+		} else {
+			fmt.Printf("  SQL DB:     %s\n", cfg.Database)
+		}
 	}
 	if cfg.HasModule(config.ModuleNoSQLDatastore) {
 		fmt.Printf("  NoSQL DB:   %s\n", cfg.NoSQLDatabase)
@@ -353,12 +771,28 @@ func runInit(cmd *cobra.Command, args []string) {
 		storageInfo := storageType
 		if cfg.WorkerUsesPostgresFallback() {
 			storageInfo = "postgresql (fallback)"
+		} else if cfg.JobRunrNeedsOwnDatabase() {
+			storageInfo = cfg.JobRunrSqlDatabase() + " (own container)"
+		} else if cfg.JobRunrUsesSql() {
+			storageInfo = cfg.JobRunrSqlDatabase()
 		}
 		fmt.Printf("  JobRunr:    %s\n", storageInfo)
 	}
 	if cfg.HasModule(config.ModuleEventConsumer) {
 		fmt.Printf("  Broker:     %s\n", cfg.MessageBroker)
 	}
+	if cfg.NeedsTestcontainersSocketOverride() {
+		fmt.Printf("  Runtime:    %s", cfg.ContainerRuntime)
+		if dockerStatus.SocketPath != "" {
+			fmt.Printf(" (%s)", dockerStatus.SocketPath)
+		}
+		fmt.Println()
+	} else if dockerStatus.Runtime == utils.RuntimeRemote {
+		fmt.Printf("  Runtime:    remote (%s)\n", dockerStatus.Host)
+	}
+	if dockerStatus.TestcontainersCloud {
+		fmt.Printf("  Tests:      Testcontainers Cloud\n")
+	}
 	if cfg.HasAnyAIAgent() {
 		selectedAgents := cfg.GetSelectedAIAgents()
 		agentNames := make([]string, len(selectedAgents))
@@ -367,6 +801,105 @@ func runInit(cmd *cobra.Command, args []string) {
 		}
 		fmt.Printf("  AI Agents:  %s\n", strings.Join(agentNames, ", "))
 	}
+	if cfg.HasNative() {
+		fmt.Printf("  Native:     GraalVM (-Pnative, Dockerfile.native)\n")
+	}
+	if cfg.HasPagination() {
+		fmt.Printf("  Paging:     GET /api/placeholders/page\n")
+	}
+	if cfg.HasRateLimit() {
+		fmt.Printf("  Limits:     Bucket4j per client (app.rate-limit)\n")
+	}
+	if cfg.HasIdempotency() {
+		store := "SQL table"
+		if cfg.IdempotencyUsesRedis() {
+			store = "Redis"
+		}
+		fmt.Printf("  Retries:    Idempotency-Key on POST /api/** (%s)\n", store)
+	}
+	if cfg.HasModule(config.ModuleEventConsumer) && cfg.UsesKafka() {
+		fmt.Printf("  Listener:   group %s, %d threads, ack mode %s\n", cfg.KafkaConsumerGroupID(), cfg.KafkaListenerConcurrency(), cfg.KafkaContainerAckMode())
+	}
+	if cfg.HasKafkaTransactions() {
+		fmt.Printf("  Kafka EOS:  PlaceholderEventProcessor (transactional, read_committed)\n")
+	}
+	if cfg.UsesSQSFifo() || cfg.HasSQSDeadLetterQueue() {
+		queue := cfg.SQSQueueName("placeholder-events")
+		if cfg.HasSQSDeadLetterQueue() {
+			queue += fmt.Sprintf(", dead letters to %s after %d receives", cfg.SQSDeadLetterQueueName("placeholder-events"), cfg.SQSReceiveCount())
+		}
+		fmt.Printf("  Queue:      %s\n", queue)
+	}
+	if cfg.UsesPubSubOrdering() || cfg.UsesPubSubExactlyOnce() {
+		var delivery []string
+		if cfg.UsesPubSubOrdering() {
+			delivery = append(delivery, "ordered by placeholder")
+		}
+		if cfg.UsesPubSubExactlyOnce() {
+			delivery = append(delivery, "exactly once")
+		}
+		fmt.Printf("  Delivery:   Pub/Sub %s\n", strings.Join(delivery, ", "))
+	}
+	if cfg.HasPerf() {
+		fmt.Printf("  Perf:       k6 load test (perf/run.sh)\n")
+	}
+	if cfg.HasDevContainer() {
+		fmt.Printf("  Dev env:    dev container (.devcontainer/)\n")
+	}
+	if cfg.HasCodeOwners() {
+		fmt.Printf("  Ownership:  CODEOWNERS, docs/modules/\n")
+	}
+	if cfg.HasMakefile() {
+		fmt.Printf("  Tasks:      Makefile (make help)\n")
+	} else if cfg.HasTaskfile() {
+		fmt.Printf("  Tasks:      Taskfile.yml (task --list)\n")
+	}
+	if cfg.HasAuditing() {
+		fmt.Printf("  Auditing:   created_by, soft deletes (deleted_at)\n")
+	}
+	if cfg.HasReplicaService() {
+		fmt.Printf("  Replica:    read-only transactions → postgres-replica (streaming)\n")
+	} else if cfg.HasReadReplica() {
+		fmt.Printf("  Replica:    read-only transactions → DB_READ_HOST\n")
+	}
+	if cfg.HasCache() {
+		fmt.Printf("  Cache:      %s (Spring Cache)\n", cfg.CacheProviderName())
+	}
+	if cfg.HasSecrets() {
+		fmt.Printf("  Secrets:    %s\n", cfg.SecretsProviderName())
+	}
+	if cfg.HasErrorProne() {
+		fmt.Printf("  Analysis:   Error Prone + NullAway\n")
+	}
+	if cfg.HasJPMS() {
+		fmt.Printf("  JPMS:       module-info.java per module\n")
+	}
+	if cfg.HasPreview() {
+		fmt.Printf("  Preview:    --enable-preview (%s)\n", cfg.PreviewFeatures())
+	}
+	if cfg.IsModulith() {
+		fmt.Printf("  Layout:     Spring Modulith application (App/)\n")
+	}
+	if !cfg.HasCoverageGates() {
+		fmt.Printf("  Coverage:   reports only (no gates)\n")
+	}
+	if cfg.HasObservability() {
+		fmt.Printf("  Observ.:    Prometheus + Grafana + Tempo\n")
+	}
+	if !cfg.UsesLogstashEncoder() && len(cfg.RuntimeTargets()) > 0 {
+		fmt.Printf("  Logs:       %s (logging/ parsing samples)\n", cfg.LogFormatName())
+	}
+	if cfg.License != "" {
+		fmt.Printf("  License:    %s\n", cfg.License)
+	} else if cfg.HasLicenseHeader() {
+		fmt.Printf("  License:    header from profile\n")
+	}
+	if cfg.ImageRegistry != "" {
+		fmt.Printf("  Registry:   %s\n", cfg.ImageRegistry)
+	}
+	if cfg.UsesJib() && len(cfg.RuntimeTargets()) > 0 {
+		fmt.Printf("  Images:     Jib (jib:build, jib:dockerBuild)\n")
+	}
 	if cfg.HasAnyCIProvider() {
 		for _, p := range config.GetAvailableCIProviders() {
 			if cfg.HasCIProvider(p.ID) {
@@ -377,19 +910,49 @@ func runInit(cmd *cobra.Command, args []string) {
 	yellow.Println("─────────────────────────────────────────")
 	fmt.Println()
 
+	if flagDryRun {
+		gen, err := generator.NewWithVersion(cfg, Version)
+		if err != nil {
+			color.Red("\nError: %v\n", err)
+			return
+		}
+		result, err := gen.DryRun()
+		if err != nil {
+			color.Red("\nError: %v\n", err)
+			return
+		}
+		result.Print()
+		return
+	}
+
+	if !confirmOnExists(cfg.ProjectName) {
+		return
+	}
+
 	// Generate project
 	gen, err := generator.NewWithVersion(cfg, Version)
 	if err != nil {
 		color.Red("\nError: %v\n", err)
 		return
 	}
+	gen.SetKeepPartial(flagKeepPartial)
+	gen.SetOnExists(flagOnExists)
 
 	if err := gen.Generate(); err != nil {
 		color.Red("\nError: %v\n", err)
+		var exists *generator.ExistsError
+		if errors.As(err, &exists) {
+			fmt.Println("Use --on-exists merge to add only the missing files, or --on-exists force to replace the directory.")
+		}
 		return
 	}
 
+	if err := mcp.RecordInitOutcome(flagRecommendID, cfg.Modules); err != nil {
+		yellow.Fprintf(os.Stderr, "Warning: recommendation feedback not recorded: %v\n", err)
+	}
+
 	// Success message
+	gen.Report().Print()
 	fmt.Println()
 	green.Println("✓ Project generated successfully!")
 	fmt.Println()
@@ -399,27 +962,36 @@ func runInit(cmd *cobra.Command, args []string) {
 		yellow.Printf("Reminder: Java %s was not detected. Install before building.\n\n", cfg.JavaVersion)
 	}
 
-	// Reminder about PostgreSQL fallback for Worker + Redis
+	// Reminder about PostgreSQL fallback for Worker + Redis, DynamoDB or Cassandra
 	if cfg.ShowRedisWorkerWarning() {
 		yellow.Println("Note: Worker module uses PostgreSQL for job storage (Redis is deprecated in JobRunr 8+).")
 		fmt.Println("      Start docker-compose to run the PostgreSQL instance for JobRunr.")
 		fmt.Println()
+	} else if cfg.WorkerUsesPostgresFallback() && cfg.HasModule(config.ModuleNoSQLDatastore) {
+		yellow.Printf("Note: Worker module uses PostgreSQL for job storage (JobRunr has no %s storage provider).\n", config.NoSQLDatabaseDisplayName(cfg.NoSQLDatabase))
+		fmt.Println("      Start docker-compose to run the PostgreSQL instance for JobRunr.")
+		fmt.Println()
 	}
 
-	// Run Maven build unless skipped or Java not detected
 	projectDir := filepath.Join(".", cfg.ProjectName)
+
+	// Scaffold the spec's entities, endpoints and jobs before the build, so
+	// it compiles them too
+	if spec != nil && !spec.Scaffolds.IsEmpty() {
+		result, err := generator.ApplySpecScaffolds(projectDir, spec.Scaffolds)
+		if err != nil {
+			color.Red("\nError: scaffolding from %s failed: %v\n", flagSpec, err)
+			return
+		}
+		printAddResult(result, false, false)
+		fmt.Println()
+	}
+
+	// Run Maven build unless skipped or Java not detected
 	if flagSkipBuild {
 		fmt.Println("Skipping Maven build (--skip-build flag).")
-		fmt.Println()
-		fmt.Println("Next steps:")
-		fmt.Printf("  cd %s\n", cfg.ProjectName)
-		fmt.Printf("  mvn clean install\n")
 	} else if !cfg.JavaVersionDetected {
 		fmt.Println("Skipping Maven build (Java not detected).")
-		fmt.Println()
-		fmt.Println("Next steps:")
-		fmt.Printf("  cd %s\n", cfg.ProjectName)
-		fmt.Printf("  mvn clean install\n")
 	} else {
 		// Run Maven build
 		if err := runMavenBuild(projectDir, flagRunTests); err != nil {
@@ -432,12 +1004,36 @@ func runInit(cmd *cobra.Command, args []string) {
 			fmt.Println()
 		}
 	}
+}
 
-	// Show how to run the application
-	if cfg.HasModule(config.ModuleAPI) {
-		fmt.Println("To run the API:")
-		fmt.Printf("  cd %s/%s && mvn spring-boot:run\n", cfg.ProjectName, config.ModuleAPI)
+// confirmOnExists checks the project directory against --on-exists before
+// generation starts, and asks before force replaces it
+func confirmOnExists(dir string) bool {
+	if !generator.OutputExists(dir) {
+		return true
 	}
+	switch flagOnExists {
+	case generator.OnExistsMerge:
+		color.Yellow("Directory '%s' exists: only missing files will be written.\n", dir)
+		return true
+	case generator.OnExistsForce:
+		if flagYes {
+			return true
+		}
+		confirm := false
+		prompt := &survey.Confirm{
+			Message: fmt.Sprintf("Replace '%s'? It is moved to %s.trabuco-backup-<timestamp> first.", dir, dir),
+			Default: false,
+		}
+		if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+			fmt.Println("Cancelled (pass --yes to replace it without asking)")
+			return false
+		}
+		return true
+	}
+	color.Red("Error: directory '%s' already exists\n", dir)
+	fmt.Println("Use --on-exists merge to add only the missing files, or --on-exists force to replace the directory.")
+	return false
 }
 
 // runSpotlessFormat runs 'mvn spotless:apply' to auto-format generated Java code
@@ -516,3 +1112,89 @@ func runMavenBuild(projectDir string, runTests bool) error {
 	fmt.Printf("\r                                                    \r") // Clear line
 	return nil
 }
+
+// applyPreset sets the modules and backends of a preset on every flag the
+// user did not pass explicitly. It runs before the profile, so a preset's
+// recommendations win over profile defaults.
+func applyPreset(cmd *cobra.Command, preset *mcp.Preset) {
+	values := map[string]string{
+		"modules":        strings.Join(preset.Modules, ","),
+		"database":       preset.Database,
+		"nosql-database": preset.NoSQLDatabase,
+		"message-broker": preset.MessageBroker,
+		"vector-store":   preset.VectorStore,
+	}
+	for name, value := range values {
+		if value != "" && !cmd.Flags().Changed(name) {
+			cmd.Flags().Set(name, value)
+		}
+	}
+}
+
+// applySpec sets every init flag the user did not pass explicitly to the
+// spec's value. An option that names no init flag is an error.
+func applySpec(cmd *cobra.Command, spec *config.ProjectSpec) error {
+	flags := spec.Flags()
+	for _, name := range spec.FlagNames() {
+		if name == "spec" || cmd.Flags().Lookup(name) == nil {
+			return fmt.Errorf("%s: unknown option '%s' (options take init flag names, e.g. with-read-replica)", flagSpec, name)
+		}
+		if cmd.Flags().Changed(name) {
+			continue
+		}
+		if err := cmd.Flags().Set(name, flags[name]); err != nil {
+			return fmt.Errorf("%s: invalid %s: %v", flagSpec, name, err)
+		}
+	}
+	return nil
+}
+
+// applyProfileDefaults sets every init flag the user did not pass explicitly
+// to the profile's value. The group ID is only derived once --name is known.
+func applyProfileDefaults(cmd *cobra.Command, profile *config.Profile) {
+	for key, value := range profile.Defaults(flagProjectName) {
+		name := strings.ReplaceAll(key, "_", "-")
+		if cmd.Flags().Changed(name) {
+			continue
+		}
+		cmd.Flags().Set(name, value)
+	}
+}
+
+// withTemplatePacks appends the profile's template packs to a comma-separated
+// module list. Packs that are not installed are skipped with a warning.
+func withTemplatePacks(modules string, profile *config.Profile) string {
+	if profile == nil {
+		return modules
+	}
+	list := strings.Split(modules, ",")
+	for _, pack := range profile.TemplatePacks {
+		if plugin.Get(pack) == nil {
+			color.Yellow("Warning: template pack '%s' from %s is not installed; skipping (see 'trabuco plugin install')\n", pack, config.UserConfigPath())
+			continue
+		}
+		if !containsModule(list, pack) {
+			list = append(list, pack)
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+// containsModule reports whether a module list (possibly padded with
+// spaces) contains name
+func containsModule(list []string, name string) bool {
+	for _, m := range list {
+		if strings.TrimSpace(m) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// profileLicenseHeader returns the profile's license header, if any
+func profileLicenseHeader(profile *config.Profile) string {
+	if profile == nil {
+		return ""
+	}
+	return profile.LicenseHeader
+}
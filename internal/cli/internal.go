@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/versions"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bumpWrite   bool
+	bumpFile    string
+	bumpRepoURL string
+	bumpJSON    bool
+)
+
+var internalCmd = &cobra.Command{
+	Use:    "internal",
+	Short:  "Maintainer commands for working on Trabuco itself",
+	Hidden: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var bumpVersionsCmd = &cobra.Command{
+	Use:   "bump-versions",
+	Short: "Check versions.yaml against Maven Central and propose updates",
+	Long: `Check every maven entry of versions.yaml against Maven Central and list
+the ones with a newer release, with a link to each changelog.
+
+Pre-releases are only proposed for entries already on one. Pinned entries are
+listed with their reason and never written.
+
+Run from the root of a Trabuco checkout to use --write.
+
+Examples:
+  trabuco internal bump-versions
+  trabuco internal bump-versions --write`,
+	Args: cobra.NoArgs,
+	Run:  runBumpVersions,
+}
+
+func init() {
+	bumpVersionsCmd.Flags().BoolVar(&bumpWrite, "write", false, "Write the proposed versions (except pinned ones) to versions.yaml")
+	bumpVersionsCmd.Flags().StringVar(&bumpFile, "file", versions.FileName, "versions.yaml to check and update")
+	bumpVersionsCmd.Flags().StringVar(&bumpRepoURL, "repo-url", versions.MavenCentralURL, "Maven repository to check")
+	bumpVersionsCmd.Flags().BoolVar(&bumpJSON, "json", false, "Output the proposed updates as JSON")
+
+	internalCmd.AddCommand(bumpVersionsCmd)
+	rootCmd.AddCommand(internalCmd)
+}
+
+func runBumpVersions(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	cyan := color.New(color.FgCyan)
+
+	content, err := os.ReadFile(bumpFile)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	catalog, err := versions.Parse(content)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	updates, checkErr := versions.NewMavenClient(bumpRepoURL).CheckUpdates(context.Background(), catalog)
+	if checkErr != nil {
+		yellow.Fprintf(os.Stderr, "Some entries could not be checked:\n%v\n\n", checkErr)
+	}
+
+	if bumpJSON {
+		if updates == nil {
+			updates = []versions.Update{}
+		}
+		data, _ := json.MarshalIndent(updates, "", "  ")
+		fmt.Println(string(data))
+	} else if len(updates) == 0 {
+		fmt.Println("All versions are up to date.")
+	} else {
+		for _, u := range updates {
+			cyan.Printf("%-28s", u.Name)
+			fmt.Printf(" %s → %s\n", u.Current, u.Latest)
+			if u.Changelog != "" {
+				fmt.Printf("  %s\n", u.Changelog)
+			}
+			if u.Pinned != "" {
+				yellow.Printf("  pinned: %s\n", u.Pinned)
+			}
+		}
+	}
+
+	if bumpWrite {
+		var apply []versions.Update
+		for _, u := range updates {
+			if u.Pinned == "" {
+				apply = append(apply, u)
+			}
+		}
+		if len(apply) > 0 {
+			out, err := versions.ApplyUpdates(content, apply)
+			if err != nil {
+				red.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := os.WriteFile(bumpFile, out, 0644); err != nil {
+				red.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println()
+			green.Printf("✓ Updated %d version(s) in %s\n", len(apply), bumpFile)
+		}
+	}
+
+	if checkErr != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renderConfig       string
+	renderAll          bool
+	renderTemplatesDir string
+	renderJSON         bool
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render [template-path]",
+	Short: "Render a template against a project config and print the output",
+	Long: `Render one template against a ProjectConfig and print the output, or
+render every template with --all and report the ones that fail.
+
+The config is a JSON ProjectConfig; a generated project's .trabuco.json
+works as is. Modules are expanded with their dependencies and the same
+cross-flag rules as 'trabuco init' are applied before rendering.
+
+Templates the generator renders with more than the ProjectConfig (entity,
+agent or rule data) are reported as skipped under --all, with the fields
+they need.
+
+Use --templates-dir to render from a checkout's templates/ directory, so
+template edits can be checked without rebuilding the binary.
+
+Examples:
+  trabuco internal render pom/parent.xml.tmpl --config .trabuco.json
+  trabuco internal render --all --config config.json
+  trabuco internal render --all java/ --config config.json --templates-dir templates`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runRender,
+}
+
+func init() {
+	renderCmd.Flags().StringVar(&renderConfig, "config", "", "JSON ProjectConfig (or .trabuco.json) to render against")
+	renderCmd.Flags().BoolVar(&renderAll, "all", false, "Render every template (under the given directory, if any) and report errors")
+	renderCmd.Flags().StringVar(&renderTemplatesDir, "templates-dir", "", "Read templates from this directory instead of the embedded ones")
+	renderCmd.Flags().BoolVar(&renderJSON, "json", false, "With --all, output the results as JSON")
+	renderCmd.MarkFlagRequired("config")
+
+	internalCmd.AddCommand(renderCmd)
+}
+
+func runRender(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	if !renderAll && len(args) == 0 {
+		red.Fprintln(os.Stderr, "Error: a template path is required unless --all is set")
+		os.Exit(1)
+	}
+
+	cfg, err := loadRenderConfig(renderConfig)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	engine := templates.NewEngine()
+	if renderTemplatesDir != "" {
+		if info, err := os.Stat(renderTemplatesDir); err != nil || !info.IsDir() {
+			red.Fprintf(os.Stderr, "Error: %s is not a directory\n", renderTemplatesDir)
+			os.Exit(1)
+		}
+		engine.WithFS(os.DirFS(renderTemplatesDir))
+	}
+
+	if !renderAll {
+		result := engine.Render(args[0], cfg)
+		switch {
+		case result.Failed():
+			red.Fprintf(os.Stderr, "Error: %s\n", result.Error)
+			os.Exit(1)
+		case result.Skipped():
+			red.Fprintf(os.Stderr, "Error: %s reads fields a ProjectConfig doesn't have: %s\n", result.Path, strings.Join(result.Missing, ", "))
+			yellow.Fprintln(os.Stderr, "The generator renders it with extra data around the config; it can only be checked through 'trabuco init' or 'trabuco add'.")
+			os.Exit(1)
+		}
+		fmt.Print(result.Output)
+		return
+	}
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	results, err := engine.RenderAll(dir, cfg)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	rendered, skipped, failed := 0, 0, 0
+	for _, r := range results {
+		switch {
+		case r.Failed():
+			failed++
+		case r.Skipped():
+			skipped++
+		default:
+			rendered++
+		}
+	}
+
+	if renderJSON {
+		data, _ := json.MarshalIndent(struct {
+			Rendered int                       `json:"rendered"`
+			Skipped  int                       `json:"skipped"`
+			Failed   int                       `json:"failed"`
+			Results  []*templates.RenderResult `json:"results"`
+		}{rendered, skipped, failed, results}, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		for _, r := range results {
+			if r.Failed() {
+				red.Printf("  ✗ %s\n", r.Path)
+				fmt.Printf("      %s\n", r.Error)
+			}
+		}
+		for _, r := range results {
+			if r.Skipped() {
+				yellow.Printf("  - %s (needs %s)\n", r.Path, strings.Join(r.Missing, ", "))
+			}
+		}
+		if failed+skipped > 0 {
+			fmt.Println()
+		}
+		summary := fmt.Sprintf("%d rendered, %d skipped, %d failed (%d templates)", rendered, skipped, failed, len(results))
+		if failed > 0 {
+			red.Println(summary)
+		} else {
+			green.Println(summary)
+		}
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadRenderConfig reads a ProjectConfig from path and resolves it the way
+// 'trabuco init' does
+func loadRenderConfig(path string) (*config.ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config.ProjectConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.ProjectName == "" {
+		return nil, fmt.Errorf("%s has no projectName", path)
+	}
+	if cfg.ArtifactID == "" {
+		cfg.ArtifactID = cfg.ProjectName
+	}
+	cfg.Modules = config.ResolveDependencies(cfg.Modules)
+
+	for _, resolve := range []func() string{
+		cfg.ResolveVectorStore,
+		cfg.ResolveSecrets,
+		cfg.ResolveStaticAnalysis,
+		cfg.ResolveJPMS,
+		cfg.ResolveArchitecture,
+		cfg.ResolvePreview,
+		cfg.ResolveCache,
+		cfg.ResolveDatabaseVersion,
+		cfg.ResolveJobStorage,
+		cfg.ResolveJobRunrFeatures,
+		cfg.ResolveKafkaConsumer,
+		cfg.ResolveBrokerDelivery,
+		cfg.ResolveNames,
+	} {
+		if msg := resolve(); msg != "" {
+			return nil, fmt.Errorf("%s: %s", path, msg)
+		}
+	}
+	return cfg, nil
+}
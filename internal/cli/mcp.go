@@ -1,13 +1,32 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	mcpserver "github.com/arianlopezc/Trabuco/internal/mcp"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	mcpWatch         bool
+	mcpWatchRoots    []string
+	mcpWatchInterval time.Duration
+	mcpAudit         bool
+
+	auditTailLines  int
+	auditTailFollow bool
+	auditTailTool   string
+	auditTailJSON   bool
+)
+
 var mcpCmd = &cobra.Command{
 	Use:   "mcp",
 	Short: "Start MCP (Model Context Protocol) server for AI agent integration",
@@ -28,18 +47,221 @@ AI agent to run "trabuco mcp" as an MCP server:
 
 Available tools:
   init_project    Generate a new Java project
+  validate_config Check an init_project configuration
   add_module      Add a module to an existing project
   run_doctor      Run health checks on a project
   get_project_info Read project metadata
+  suggest_next_steps Plan what to do next in a project
+  list_todos      List the TODOs left to implement
   list_modules    List available modules
   check_docker    Check Docker status
-  get_version     Get Trabuco version
+  get_version     Get Trabuco version and available updates
   auth_status     Check configured AI providers
-  list_providers  List supported providers with pricing`,
+  list_providers  List supported providers with pricing
+
+With --watch the server polls the projects it works on (plus any
+--watch-root) and sends notifications/resources/updated for
+trabuco://project/<path> when pom.xml, .trabuco.json or
+docker-compose.yml change, so agents notice out-of-band edits without
+polling get_project_info:
+
+  trabuco mcp --watch
+  trabuco mcp --watch --watch-root ./orders --watch-root ./billing
+
+With --audit (or mcp_audit: true in ~/.trabuco/config.yaml) every tool
+call is appended to ~/.trabuco/mcp-audit.jsonl: the tool, its arguments
+with secret-looking values redacted, the duration and whether it
+succeeded, failed or was cancelled. Read it with:
+
+  trabuco mcp audit tail
+  trabuco mcp audit tail -f --tool init_project`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := mcpserver.Start(Version); err != nil {
+		opts := mcpserver.Options{
+			Watch:         mcpWatch || len(mcpWatchRoots) > 0,
+			WatchRoots:    mcpWatchRoots,
+			WatchInterval: mcpWatchInterval,
+			Audit:         mcpAudit || mcpserver.AuditEnabled(),
+		}
+		if err := mcpserver.Start(Version, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
 			os.Exit(1)
 		}
 	},
 }
+
+func init() {
+	mcpCmd.Flags().BoolVar(&mcpWatch, "watch", false, "Watch project roots and send resource-updated notifications when pom.xml, .trabuco.json or docker-compose.yml change")
+	mcpCmd.Flags().StringSliceVar(&mcpWatchRoots, "watch-root", nil, "Project root to watch from startup (repeatable; implies --watch)")
+	mcpCmd.Flags().DurationVar(&mcpWatchInterval, "watch-interval", mcpserver.DefaultWatchInterval, "How often watched projects are polled")
+	mcpCmd.Flags().BoolVar(&mcpAudit, "audit", false, "Record every tool call in the audit log (also enabled by mcp_audit in ~/.trabuco/config.yaml)")
+
+	mcpAuditTailCmd.Flags().IntVarP(&auditTailLines, "lines", "n", 20, "Number of recent calls to show")
+	mcpAuditTailCmd.Flags().BoolVarP(&auditTailFollow, "follow", "f", false, "Keep printing calls as they are recorded")
+	mcpAuditTailCmd.Flags().StringVar(&auditTailTool, "tool", "", "Only show calls to this tool")
+	mcpAuditTailCmd.Flags().BoolVar(&auditTailJSON, "json", false, "Print the raw JSON lines")
+	mcpAuditCmd.AddCommand(mcpAuditTailCmd)
+	mcpCmd.AddCommand(mcpAuditCmd)
+}
+
+var mcpAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the MCP tool-call audit log",
+	Long: `Inspect the log of tool calls recorded by "trabuco mcp --audit".
+
+The log is ~/.trabuco/mcp-audit.jsonl (TRABUCO_MCP_AUDIT_LOG relocates
+it), one JSON object per call. Arguments whose names look like secrets
+(api_key, token, password, ...) and values that look like provider keys
+are stored as [REDACTED].`,
+}
+
+var mcpAuditTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Show the most recent MCP tool calls",
+	Long: `Show the most recent tool calls in the MCP audit log.
+
+Examples:
+  trabuco mcp audit tail                     # last 20 calls
+  trabuco mcp audit tail -n 100 --tool add_module
+  trabuco mcp audit tail -f                  # follow a running session
+  trabuco mcp audit tail --json | jq .`,
+	Args: cobra.NoArgs,
+	Run:  runAuditTail,
+}
+
+func runAuditTail(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	path := mcpserver.AuditLogPath()
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !auditTailFollow {
+			fmt.Printf("No audit log at %s\n", path)
+			fmt.Println("Start the server with 'trabuco mcp --audit' or set mcp_audit: true in ~/.trabuco/config.yaml.")
+			return
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			red.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var offset int64
+	if f != nil {
+		entries, n, err := mcpserver.ReadAuditFrom(f)
+		f.Close()
+		if err != nil {
+			red.Fprintf(os.Stderr, "Error: failed to read %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		offset = n
+		entries = filterAudit(entries, auditTailTool)
+		if auditTailLines >= 0 && len(entries) > auditTailLines {
+			entries = entries[len(entries)-auditTailLines:]
+		}
+		for _, e := range entries {
+			printAuditEntry(e)
+		}
+	}
+	if !auditTailFollow {
+		return
+	}
+
+	for {
+		time.Sleep(time.Second)
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		if info, err := f.Stat(); err == nil && info.Size() < offset {
+			// The log was truncated or replaced: start over
+			offset = 0
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			continue
+		}
+		entries, n, err := mcpserver.ReadAuditFrom(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		offset += n
+		for _, e := range filterAudit(entries, auditTailTool) {
+			printAuditEntry(e)
+		}
+	}
+}
+
+func filterAudit(entries []mcpserver.AuditEntry, tool string) []mcpserver.AuditEntry {
+	if tool == "" {
+		return entries
+	}
+	var out []mcpserver.AuditEntry
+	for _, e := range entries {
+		if e.Tool == tool {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func printAuditEntry(e mcpserver.AuditEntry) {
+	if auditTailJSON {
+		data, _ := json.Marshal(e)
+		fmt.Println(string(data))
+		return
+	}
+	gray := color.New(color.FgHiBlack)
+	status := color.New(color.FgGreen).Sprint(e.Status)
+	switch e.Status {
+	case mcpserver.AuditError:
+		status = color.New(color.FgRed).Sprint(e.Status)
+	case mcpserver.AuditCancelled:
+		status = color.New(color.FgYellow).Sprint(e.Status)
+	}
+	fmt.Printf("%s  %-20s %s  %s",
+		e.Time.Local().Format("2006-01-02 15:04:05"), e.Tool, status,
+		(time.Duration(e.DurationMS) * time.Millisecond).String())
+	if e.Client != "" {
+		gray.Printf("  %s", e.Client)
+	}
+	fmt.Println()
+	if summary := auditArgs(e.Arguments); summary != "" {
+		gray.Printf("    %s\n", summary)
+	}
+	if e.Error != "" {
+		fmt.Printf("    %s\n", firstLine(e.Error))
+	}
+}
+
+// auditArgs formats the arguments as key=value pairs on one line
+func auditArgs(args map[string]any) string {
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		var value string
+		switch v := args[k].(type) {
+		case string:
+			value = firstLine(v)
+		default:
+			data, _ := json.Marshal(v)
+			value = string(data)
+		}
+		if len(value) > 60 {
+			value = value[:57] + "..."
+		}
+		parts = append(parts, k+"="+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " ..."
+	}
+	return s
+}
@@ -4,15 +4,23 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/arianlopezc/Trabuco/internal/migration/cache"
 	"github.com/arianlopezc/Trabuco/internal/migration/orchestrator"
+	"github.com/arianlopezc/Trabuco/internal/migration/report"
 	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists/llm"
 	"github.com/arianlopezc/Trabuco/internal/migration/state"
 	"github.com/arianlopezc/Trabuco/internal/migration/types"
 	"github.com/arianlopezc/Trabuco/internal/migration/vcs"
@@ -62,6 +70,7 @@ func init() {
 	migrateCmd.AddCommand(migrateActivateCmd)
 	migrateCmd.AddCommand(migrateFinalizeCmd)
 	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateReportCmd)
 	migrateCmd.AddCommand(migrateRollbackCmd)
 	migrateCmd.AddCommand(migrateDecisionCmd)
 	migrateCmd.AddCommand(migrateResumeCmd)
@@ -69,7 +78,17 @@ func init() {
 
 	rootCmd.AddCommand(migrateCmd)
 
+	migrateCmd.PersistentFlags().String("cache-dir", "", "LLM response cache directory, e.g. one shared between machines (default $"+cache.EnvDir+" or the user cache directory)")
+	migrateCmd.PersistentFlags().Bool("no-cache", false, "Call the LLM for every request instead of replaying cached responses")
+	migrateCmd.PersistentFlags().Duration("timeout", 0, "Stop after this long (e.g. 45m), checkpointing the running phase for 'migrate resume'; 0 means no deadline")
+	migrateCmd.PersistentFlags().Duration("call-timeout", 10*time.Minute, "Fail an LLM call that gets no response within this long; 0 means no limit")
 	migrateModuleCmd.Flags().String("module", "", "Module to migrate (model|sqldatastore|nosqldatastore|shared|api|worker|eventconsumer|aiagent)")
+	for _, c := range []*cobra.Command{migrateModuleCmd, migrateTestsCmd, migrateRunCmd} {
+		addSelectionFlags(c)
+	}
+	for _, c := range []*cobra.Command{migrateModuleCmd, migrateConfigCmd, migrateDeploymentCmd, migrateTestsCmd, migrateResumeCmd, migrateRunCmd} {
+		addReviewFlags(c)
+	}
 	migrateRollbackCmd.Flags().Int("to-phase", -1, "Phase number to roll back to (0..13)")
 	migrateDecisionCmd.Flags().String("id", "", "Decision ID to record")
 	migrateDecisionCmd.Flags().String("choice", "", "Choice value")
@@ -157,6 +176,37 @@ var migrateStatusCmd = &cobra.Command{
 	},
 }
 
+var migrateReportCmd = &cobra.Command{
+	Use:   "report <repo-path>",
+	Short: "Regenerate MIGRATION_REPORT.md from the migration state",
+	Long: `Write MIGRATION_REPORT.md and .trabuco-migration/migration-report.json:
+converted files, skipped items with reasons, dependency changes since the
+migration baseline, TODOs left in migrated code, LLM token and cost totals,
+and a prioritized follow-up checklist.
+
+The finalizer writes the report at the end of a migration; run this to
+refresh it after fixing follow-ups, or to see the report of a migration
+still in progress.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoRoot, err := absRepoPath(args[0])
+		if err != nil {
+			return err
+		}
+		s, err := state.Load(repoRoot)
+		if err != nil {
+			return fmt.Errorf("no migration in progress at %s: %w", repoRoot, err)
+		}
+		r, err := report.Write(repoRoot, s, nil)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s (%d converted files, %d skipped, %d follow-ups, $%.2f LLM cost)\n",
+			state.MigrationReportPath(repoRoot), len(r.ConvertedFiles), len(r.Skipped), len(r.FollowUps), r.Usage.CostUSD)
+		return nil
+	},
+}
+
 var migrateRollbackCmd = &cobra.Command{
 	Use:   "rollback <repo-path> --to-phase=<N>",
 	Short: "Roll back to the pre-tag of phase N",
@@ -228,20 +278,48 @@ var migrateRunCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		applyCacheFlags(cmd)
 		o := newOrch(repoRoot)
-		ctx := context.Background()
+		if err := applyReview(cmd, o); err != nil {
+			return err
+		}
+		ctx, stop := migrateContext(cmd)
+		defer stop()
 		for _, p := range types.AllPhases() {
+			if p == types.PhaseModel {
+				// The selection lives in state.json, which exists from
+				// Phase 0 on.
+				if err := applySelection(cmd, o); err != nil {
+					return err
+				}
+			}
+			if p == types.PhaseActivation {
+				st, err := o.LoadState()
+				if err != nil {
+					return err
+				}
+				if st.Slicing() {
+					remaining, err := llm.RemainingSources(repoRoot, st)
+					if err != nil {
+						return err
+					}
+					if len(remaining) > 0 {
+						fmt.Printf("\nSlice migrated. %d source classes remain in legacy/; run again with another --only/--include, or --all, to migrate them. Activation and finalization run once nothing remains.\n", len(remaining))
+						return nil
+					}
+				}
+			}
 			fmt.Printf("\n=== Phase %d (%s) ===\n", int(p), p)
 			action, err := o.RunPhase(ctx, p, "")
 			if err != nil {
-				return err
+				return interrupted(cmd, err)
 			}
 			if action == types.GateReject {
 				fmt.Printf("Phase %s rejected; halting migration.\n", p)
 				return nil
 			}
 		}
-		fmt.Println("\nMigration complete. See .trabuco-migration/completion-report.md")
+		fmt.Println("\nMigration complete. See MIGRATION_REPORT.md for the follow-up checklist and .trabuco-migration/completion-report.md for phase results.")
 		return nil
 	},
 }
@@ -253,7 +331,11 @@ func runPhase(cmd *cobra.Command, repoArg string, phase types.Phase) error {
 	if err != nil {
 		return err
 	}
+	applyCacheFlags(cmd)
 	o := newOrch(repoRoot)
+	if err := applyReview(cmd, o); err != nil {
+		return err
+	}
 	if !state.Exists(repoRoot) {
 		// Auto-init at first phase only.
 		if phase != types.PhaseAssessment {
@@ -270,14 +352,96 @@ func runPhase(cmd *cobra.Command, repoArg string, phase types.Phase) error {
 			return err
 		}
 	}
-	action, err := o.RunPhase(cmd.Context(), phase, "")
-	if err != nil {
+	if err := applySelection(cmd, o); err != nil {
 		return err
 	}
+	ctx, stop := migrateContext(cmd)
+	defer stop()
+	action, err := o.RunPhase(ctx, phase, "")
+	if err != nil {
+		return interrupted(cmd, err)
+	}
 	fmt.Printf("Phase %s: %s\n", phase, action)
 	return nil
 }
 
+// migrateContext returns the context a migration command runs phases
+// under. Ctrl-C (or SIGTERM) and the --timeout deadline cancel it; the
+// orchestrator then checkpoints the running phase at its next stage
+// boundary. A second Ctrl-C kills the process as usual, for when a
+// prompt or a Maven build is in the way. It also applies --call-timeout.
+func migrateContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	if d, err := cmd.Flags().GetDuration("call-timeout"); err == nil {
+		llm.SetCallTimeout(d)
+	}
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stopSignals()
+	}()
+	cancel := stopSignals
+	if d, _ := cmd.Flags().GetDuration("timeout"); d > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, d)
+		cancel = func() {
+			cancelTimeout()
+			stopSignals()
+		}
+	}
+	return ctx, cancel
+}
+
+// interrupted explains a phase error caused by Ctrl-C or --timeout; other
+// errors are returned unchanged.
+func interrupted(cmd *cobra.Command, err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		d, _ := cmd.Flags().GetDuration("timeout")
+		return fmt.Errorf("--timeout %s reached: %w", d, err)
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("interrupted: %w", err)
+	}
+	return err
+}
+
+// addSelectionFlags registers the selective-migration flags on a command
+// that runs module phases.
+func addSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("only", "", "Migrate only these categories: "+strings.Join(state.SelectionCategories, ","))
+	cmd.Flags().String("include", "", "Migrate only classes matching these comma-separated patterns, * as wildcard (e.g. 'com.acme.billing.*')")
+	cmd.Flags().Bool("all", false, "Drop the stored --only/--include selection and migrate everything not migrated yet")
+}
+
+// applySelection stores the --only/--include/--all selection in
+// state.json. Without any of them the stored selection is kept, so
+// re-running a phase continues the same slice.
+func applySelection(cmd *cobra.Command, o *orchestrator.Orchestrator) error {
+	if cmd.Flags().Lookup("only") == nil {
+		return nil
+	}
+	only, _ := cmd.Flags().GetString("only")
+	include, _ := cmd.Flags().GetString("include")
+	all, _ := cmd.Flags().GetBool("all")
+	if only == "" && include == "" && !all {
+		return nil
+	}
+	if all && (only != "" || include != "") {
+		return fmt.Errorf("--all cannot be combined with --only or --include")
+	}
+	sel, err := state.ParseSelection(only, include)
+	if err != nil {
+		return err
+	}
+	changed, err := o.SetSelection(sel)
+	if err != nil {
+		return err
+	}
+	if changed {
+		fmt.Printf("Migrating %s; module phases reopened for this slice.\n", sel)
+	}
+	return nil
+}
+
 func absRepoPath(p string) (string, error) {
 	abs, err := filepath.Abs(p)
 	if err != nil {
@@ -386,6 +550,128 @@ func (terminalGate) Present(ctx context.Context, phase types.Phase, out *special
 	}
 }
 
+// applyCacheFlags points the LLM specialists at --cache-dir, or turns
+// the response cache off for --no-cache.
+func applyCacheFlags(cmd *cobra.Command) {
+	if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache {
+		llm.SetCacheDir("")
+		return
+	}
+	if dir, _ := cmd.Flags().GetString("cache-dir"); dir != "" {
+		llm.SetCacheDir(dir)
+	}
+}
+
+// addReviewFlags registers the per-file review flags on a command that
+// runs LLM phases.
+func addReviewFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("review", false, "Review each converted file as a diff (accept/edit/skip) before it is written")
+	cmd.Flags().Float64("auto-accept-threshold", 0, "With review, write files whose validation score (0-1: compiles, imports resolve) reaches this without asking; implies --review")
+}
+
+// applyReview turns on the per-file review stage when --review or
+// --auto-accept-threshold is set.
+func applyReview(cmd *cobra.Command, o *orchestrator.Orchestrator) error {
+	if cmd.Flags().Lookup("review") == nil {
+		return nil
+	}
+	review, _ := cmd.Flags().GetBool("review")
+	threshold, _ := cmd.Flags().GetFloat64("auto-accept-threshold")
+	if threshold < 0 || threshold > 1 {
+		return fmt.Errorf("--auto-accept-threshold must be between 0 and 1, got %g", threshold)
+	}
+	if review || threshold > 0 {
+		o.SetReviewer(terminalReviewer{}, threshold)
+	}
+	return nil
+}
+
+// terminalReviewer is the CLI-mode Reviewer: prints each file's checks
+// and diff and reads accept / edit / skip from stdin. Edit opens the
+// proposed content in $VISUAL or $EDITOR.
+type terminalReviewer struct{}
+
+func (terminalReviewer) Review(ctx context.Context, phase types.Phase, fr *orchestrator.FileReview) (orchestrator.ReviewDecision, string, error) {
+	fmt.Printf("\n--- Review %s (item %s) ---\n", fr.Path, fr.ItemID)
+	for _, c := range fr.Checks {
+		mark := "✓"
+		if !c.Passed {
+			mark = "✗"
+		}
+		fmt.Printf("  %s %s", mark, c.Name)
+		if c.Detail != "" {
+			fmt.Printf(" — %s", c.Detail)
+		}
+		fmt.Println()
+	}
+	if len(fr.Checks) > 0 {
+		fmt.Printf("  score: %.2f\n", fr.Score)
+	}
+	if fr.Original == "" {
+		fmt.Println("  (new file, no original)")
+	}
+	fmt.Println(fr.Diff)
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("[a]ccept / [e]dit / [s]kip? ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", "", fmt.Errorf("read stdin: %w", err)
+		}
+		choice := strings.ToLower(strings.TrimSpace(line))
+		switch choice {
+		case "a", "accept":
+			return orchestrator.ReviewAccept, "", nil
+		case "e", "edit":
+			edited, err := editInEditor(fr.Path, fr.Content)
+			if err != nil {
+				return "", "", err
+			}
+			return orchestrator.ReviewEdit, edited, nil
+		case "s", "skip":
+			return orchestrator.ReviewSkip, "", nil
+		default:
+			fmt.Printf("(unrecognized %q — please type a, e, or s)\n", choice)
+		}
+	}
+}
+
+// editInEditor opens content in the user's editor under a temp file
+// named like path (so the editor picks the right syntax) and returns
+// what was saved.
+func editInEditor(path, content string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	f, err := os.CreateTemp("", "trabuco-review-*-"+filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return "", err
+	}
+	f.Close()
+
+	args := strings.Fields(editor)
+	c := exec.Command(args[0], append(args[1:], f.Name())...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("run %s: %w", editor, err)
+	}
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 func truncForGate(s string, n int) string {
 	if len(s) <= n {
 		return s
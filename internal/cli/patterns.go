@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/mcp"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	patternsStatsJSON  bool
+	patternsTuneDryRun bool
+	patternsTuneReset  bool
+)
+
+var patternsCmd = &cobra.Command{
+	Use:   "patterns",
+	Short: "Inspect and tune suggest_architecture recommendations",
+	Long: `Inspect and tune the architecture recommendations of the MCP
+suggest_architecture tool.
+
+With pattern_feedback: true in ~/.trabuco/config.yaml, suggest_architecture
+returns a recommendation_id and init_project (or 'trabuco init
+--recommendation-id') records whether the recommended modules were kept.
+The feedback stays in ~/.trabuco/patterns and holds no requirement text,
+project names or paths.`,
+}
+
+var patternsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how often recommendations were accepted",
+	Long: `Show recorded recommendations, how often each pattern was accepted, and
+the acceptance rate per confidence level. A well-calibrated advisor has
+"high" accepted more often than "medium", and "medium" more than "low".`,
+	Args: cobra.NoArgs,
+	Run:  runPatternsStats,
+}
+
+var patternsTuneCmd = &cobra.Command{
+	Use:   "tune",
+	Short: "Tune keyword weights from recorded feedback",
+	Long: `Derive the keyword weights of pattern scoring from recorded feedback and
+save them to ~/.trabuco/patterns/weights.json, which suggest_architecture
+reads on every call. Keywords behind accepted recommendations gain weight;
+keywords behind rejected ones lose it. Weights stay between 0.5 and 2.
+
+Examples:
+  trabuco patterns tune --dry-run   # show the weight changes
+  trabuco patterns tune
+  trabuco patterns tune --reset     # back to the built-in weights`,
+	Args: cobra.NoArgs,
+	Run:  runPatternsTune,
+}
+
+func init() {
+	patternsStatsCmd.Flags().BoolVar(&patternsStatsJSON, "json", false, "Output as JSON")
+	patternsTuneCmd.Flags().BoolVar(&patternsTuneDryRun, "dry-run", false, "Show the weight changes without saving them")
+	patternsTuneCmd.Flags().BoolVar(&patternsTuneReset, "reset", false, "Delete the tuned weights; recorded feedback is kept")
+	patternsCmd.AddCommand(patternsStatsCmd)
+	patternsCmd.AddCommand(patternsTuneCmd)
+}
+
+func runPatternsStats(cmd *cobra.Command, args []string) {
+	stats, err := mcp.LoadPatternStats()
+	if err != nil {
+		color.Red("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if patternsStatsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(stats)
+		return
+	}
+
+	cyan := color.New(color.FgCyan)
+	yellow := color.New(color.FgYellow)
+	if !mcp.FeedbackEnabled() {
+		yellow.Printf("Pattern feedback is off. Set pattern_feedback: true in %s to record it.\n\n", config.UserConfigPath())
+	}
+	fmt.Printf("Recommendations: %d, with outcome: %d, accepted: %s\n", stats.Recommendations, stats.Outcomes, rate(stats.Accepted, stats.Outcomes))
+	if stats.Recommendations == 0 {
+		return
+	}
+
+	fmt.Println()
+	cyan.Println("By pattern")
+	for _, p := range stats.ByPattern {
+		fmt.Printf("  %-24s recommended %3d  outcomes %3d  accepted %s\n", p.Pattern, p.Recommended, p.Outcomes, rate(p.Accepted, p.Outcomes))
+	}
+	fmt.Println()
+	cyan.Println("By confidence")
+	for _, c := range stats.ByConfidence {
+		fmt.Printf("  %-8s outcomes %3d  accepted %s\n", c.Confidence, c.Outcomes, rate(c.Accepted, c.Outcomes))
+	}
+	if len(stats.Weights) > 0 {
+		fmt.Println()
+		fmt.Println("Tuned keyword weights are in effect; 'trabuco patterns tune --reset' restores the defaults.")
+	}
+}
+
+func runPatternsTune(cmd *cobra.Command, args []string) {
+	if patternsTuneReset {
+		if err := mcp.ResetPatternWeights(); err != nil {
+			color.Red("Error: %v\n", err)
+			os.Exit(1)
+		}
+		color.Green("✓ Keyword weights reset to the built-in defaults")
+		return
+	}
+
+	changes, err := mcp.TunePatternWeights(patternsTuneDryRun)
+	if err != nil {
+		color.Red("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(changes) == 0 {
+		fmt.Println("Keyword weights are already up to date with the recorded feedback.")
+		return
+	}
+	for _, c := range changes {
+		fmt.Printf("  %-24s %-20s %.2f → %.2f\n", c.Pattern, c.Keyword, c.Old, c.New)
+	}
+	fmt.Println()
+	if patternsTuneDryRun {
+		color.Yellow("DRY RUN — weights were not saved.")
+		return
+	}
+	color.Green("✓ Saved %d weight changes", len(changes))
+}
+
+// rate formats accepted/total as a percentage with the counts.
+func rate(accepted, total int) string {
+	if total == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%d%% (%d/%d)", accepted*100/total, accepted, total)
+}
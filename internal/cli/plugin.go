@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/plugin"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var pluginForce bool
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage custom module template packs",
+	Long: `Manage template packs that contribute custom modules (e.g. AuditLog,
+FeatureFlags) to 'trabuco init', 'trabuco add' and the MCP module catalog.
+
+A template pack is a directory (or .tar.gz archive) with a
+trabuco-plugin.yaml manifest at its root declaring the module name,
+dependencies, files to render, parent POM edits and docker-compose
+services. Installed packs live in ~/.trabuco/plugins.
+
+SUBCOMMANDS:
+  install   Install a pack from a directory or archive
+  list      Show installed packs
+  remove    Uninstall a pack
+
+Examples:
+  trabuco plugin install ./auditlog-pack
+  trabuco plugin install auditlog-pack-1.2.0.tar.gz --force
+  trabuco plugin list
+  trabuco plugin remove AuditLog`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <path>",
+	Short: "Install a template pack from a directory or .tar.gz archive",
+	Args:  cobra.ExactArgs(1),
+	Run:   runPluginInstall,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed template packs",
+	Run:   runPluginList,
+}
+
+var pluginRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Uninstall a template pack",
+	Args:  cobra.ExactArgs(1),
+	Run:   runPluginRemove,
+}
+
+func init() {
+	pluginInstallCmd.Flags().BoolVarP(&pluginForce, "force", "f", false, "Replace an already-installed pack with the same name")
+
+	pluginCmd.AddCommand(pluginInstallCmd)
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginRemoveCmd)
+}
+
+// loadPlugins registers installed template packs with the module registry.
+// Broken packs are reported but never block the command being run.
+func loadPlugins() {
+	for _, err := range plugin.Load() {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+func runPluginInstall(cmd *cobra.Command, args []string) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	p, err := plugin.Install(args[0], pluginForce)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	green.Printf("✓ Installed plugin %s", p.Manifest.Name)
+	if p.Manifest.Version != "" {
+		fmt.Printf(" (%s)", p.Manifest.Version)
+	}
+	fmt.Println()
+	fmt.Printf("  Location: %s\n", p.Dir)
+	fmt.Println()
+	fmt.Printf("Use it with: trabuco init --modules=...,%s  or  trabuco add %s\n", p.Manifest.Name, p.Manifest.Name)
+}
+
+func runPluginList(cmd *cobra.Command, args []string) {
+	cyan := color.New(color.FgCyan)
+	yellow := color.New(color.FgYellow)
+
+	plugins, errs := plugin.List()
+	for _, err := range errs {
+		yellow.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	if len(plugins) == 0 {
+		fmt.Printf("No plugins installed in %s\n", plugin.Dir())
+		return
+	}
+
+	for _, p := range plugins {
+		m := p.Manifest
+		cyan.Printf("%s", m.Name)
+		if m.Version != "" {
+			fmt.Printf(" %s", m.Version)
+		}
+		fmt.Printf(" - %s\n", m.Description)
+		if len(m.Dependencies) > 0 {
+			fmt.Printf("    Depends on: %s\n", strings.Join(m.Dependencies, ", "))
+		}
+		fmt.Printf("    Files: %d", len(m.Files))
+		if n := len(m.Docker.Services); n > 0 {
+			fmt.Printf(", docker services: %d", n)
+		}
+		fmt.Println()
+	}
+}
+
+func runPluginRemove(cmd *cobra.Command, args []string) {
+	if err := plugin.Remove(args[0]); err != nil {
+		color.New(color.FgRed).Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	color.New(color.FgGreen).Printf("✓ Removed plugin %s\n", args[0])
+}
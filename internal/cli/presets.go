@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/mcp"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var presetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List project presets for 'trabuco init --preset'",
+	Long: `List the project presets ("recipes") available to 'trabuco init --preset'.
+
+Each preset maps to one of the architecture patterns also used by the
+MCP suggest_architecture tool: a module set plus the recommended
+database, message broker and vector store. Presets can be referenced by
+their full name or by the pattern name.
+
+Examples:
+  trabuco presets
+  trabuco init --preset event-driven-postgres-kafka --name=orders --group-id=com.acme.orders`,
+	Run: runPresets,
+}
+
+func runPresets(cmd *cobra.Command, args []string) {
+	cyan := color.New(color.FgCyan)
+
+	for _, p := range mcp.Presets() {
+		cyan.Printf("%s", p.Name)
+		fmt.Printf(" - %s\n", p.Description)
+		fmt.Printf("    Modules: %s\n", strings.Join(p.Modules, ", "))
+		var backends []string
+		for _, b := range []struct{ label, value string }{
+			{"database", p.Database},
+			{"nosql-database", p.NoSQLDatabase},
+			{"message-broker", p.MessageBroker},
+			{"vector-store", p.VectorStore},
+		} {
+			if b.value != "" {
+				backends = append(backends, fmt.Sprintf("--%s=%s", b.label, b.value))
+			}
+		}
+		if len(backends) > 0 {
+			fmt.Printf("    Uses:    %s\n", strings.Join(backends, " "))
+		}
+	}
+	fmt.Println()
+	fmt.Println("Use with: trabuco init --preset <name> --name=<project> --group-id=<group>")
+	fmt.Println("Explicit flags (e.g. --message-broker=rabbitmq) override the preset.")
+}
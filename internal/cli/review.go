@@ -188,6 +188,7 @@ func runReviewEnable(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	recordHistory := trackHistory(projectDir, "review enable")
 	cfg, err := readReviewConfig(projectDir)
 	if err != nil {
 		return err
@@ -199,6 +200,7 @@ func runReviewEnable(cmd *cobra.Command, args []string) error {
 	if err := writeReviewConfig(projectDir, cfg); err != nil {
 		return err
 	}
+	recordHistory()
 	color.Green("✓ Review automation enabled.")
 	return nil
 }
@@ -208,6 +210,7 @@ func runReviewDisable(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	recordHistory := trackHistory(projectDir, "review disable")
 	cfg, err := readReviewConfig(projectDir)
 	if err != nil {
 		return err
@@ -220,6 +223,7 @@ func runReviewDisable(cmd *cobra.Command, args []string) error {
 	if err := writeReviewConfig(projectDir, cfg); err != nil {
 		return err
 	}
+	recordHistory()
 	color.Green("✓ Review automation disabled. Subagents and skills remain available for manual use; the Stop hook will no longer block turns.")
 	return nil
 }
@@ -229,6 +233,7 @@ func runReviewRemove(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	recordHistory := trackHistory(projectDir, "review remove")
 	// Artifacts to remove. Kept intentionally narrow — we do NOT touch
 	// format.sh (pure Spotless, useful standalone) or .claude/skills/review
 	// (the general /review skill, useful without the automation layer).
@@ -267,6 +272,7 @@ func runReviewRemove(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to remove %s: %w", rel, err)
 		}
 	}
+	recordHistory()
 	color.Green("✓ Removed %d review artifacts.", removed)
 	fmt.Println("Note: .claude/hooks/format.sh and the general /review skill were kept — they are independent of the review automation.")
 	fmt.Println("Note: You may need to manually remove the `Stop` hook entry from .claude/settings.json.")
@@ -286,6 +292,7 @@ func runReviewInstall(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	recordHistory := trackHistory(projectDir, "review install")
 	// Review artifacts primarily install for Claude (subagents + skills + hooks)
 	// but cross-tool Stop adapters (Codex, Cursor) are also emitted when those
 	// agents are selected. Only refuse when no supported tool is present.
@@ -316,6 +323,7 @@ func runReviewInstall(cmd *cobra.Command, args []string) error {
 	if err := gen.GenerateReviewArtifactsOnly(); err != nil {
 		return err
 	}
+	recordHistory()
 	color.Green("✓ Installed review artifacts (mode=%s).", reviewInstallMode)
 	if reviewInstallMode == config.ReviewModeFull {
 		fmt.Println("To activate the Stop hook, ensure .claude/settings.json includes:")
@@ -28,12 +28,29 @@ func Execute() {
 }
 
 func init() {
+	cobra.OnInitialize(loadPlugins)
+
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(selfUpdateCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(mcpCmd)
 	rootCmd.AddCommand(reviewCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(pluginCmd)
+	rootCmd.AddCommand(presetsCmd)
+	rootCmd.AddCommand(patternsCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(todosCmd)
+	rootCmd.AddCommand(envCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(exportSpecCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(cleanCmd)
 }
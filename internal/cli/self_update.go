@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/update"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	selfUpdateCheck   bool
+	selfUpdateVersion string
+	selfUpdateForce   bool
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update the Trabuco CLI to the latest release",
+	Long: `Update the Trabuco CLI to the latest GitHub release.
+
+The binary for this platform is downloaded, checked against the SHA-256
+in the release's checksums.txt, and swapped in place of the running one.
+A binary that fails the check is never installed.
+
+When Trabuco was installed with Homebrew, Scoop or npm, the update is
+left to that package manager (brew upgrade, scoop update, npm install -g)
+so it keeps track of the installed version.
+
+Examples:
+  trabuco self-update                   # install the latest release
+  trabuco self-update --check           # only report whether one is available
+  trabuco self-update --version v1.3.0  # install a specific release`,
+	Args: cobra.NoArgs,
+	Run:  runSelfUpdate,
+}
+
+func init() {
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateCheck, "check", false, "Only report whether a newer release is available")
+	selfUpdateCmd.Flags().StringVar(&selfUpdateVersion, "version", "", "Release to install instead of the latest (e.g. v1.3.0)")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, "Reinstall even when the release is not newer, or replace a package-managed binary")
+}
+
+func runSelfUpdate(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	exe, err := os.Executable()
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: could not locate the trabuco binary: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	updater := update.NewSelfUpdater(Version)
+	release, err := updater.Release(ctx, selfUpdateVersion)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	newer := updater.Newer(release)
+	if selfUpdateCheck {
+		if newer {
+			fmt.Printf("Trabuco %s is available (you have %s) — %s\n", release.Version, Version, release.URL)
+		} else {
+			fmt.Printf("Trabuco %s is up to date.\n", Version)
+		}
+		return
+	}
+	if !newer && !selfUpdateForce {
+		green.Printf("Trabuco %s is up to date.\n", Version)
+		return
+	}
+
+	if installer := update.DetectInstaller(exe); installer != nil && !selfUpdateForce {
+		cyan.Printf("Trabuco was installed with %s; running: %s\n", installer.Name, strings.Join(installer.Command, " "))
+		pm := exec.Command(installer.Command[0], installer.Command[1:]...)
+		pm.Stdin, pm.Stdout, pm.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := pm.Run(); err != nil {
+			red.Fprintf(os.Stderr, "Error: %s failed: %v\n", installer.Name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	asset := update.AssetName(runtime.GOOS, runtime.GOARCH)
+	cyan.Printf("Downloading %s %s...\n", asset, release.Version)
+	binary, err := updater.Download(ctx, release, asset)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := update.ReplaceExecutable(exe, binary); err != nil {
+		red.Fprintf(os.Stderr, "Error: could not replace %s: %v\n", exe, err)
+		if errors.Is(err, fs.ErrPermission) {
+			yellow.Fprintln(os.Stderr, "Run it again with the permissions the install directory needs (e.g. sudo trabuco self-update).")
+		}
+		os.Exit(1)
+	}
+	green.Printf("Updated Trabuco %s → %s (checksum verified).\n", Version, release.Version)
+}
@@ -31,8 +31,9 @@ user-owned and untouched.
 
 Scope: .ai/**, .claude/**, .cursor/**, .codex/**, .agents/**, .github/instructions/**,
 .github/scripts/review-checks.sh, .github/skills/**, .github/workflows/copilot-setup-steps.yml,
-.trabuco/review.config.json, CLAUDE.md, AGENTS.md, .github/copilot-instructions.md,
-and the Trabuco-managed block in .gitignore.
+.trabuco/review.config.json, CLAUDE.md, AGENTS.md, GEMINI.md, CONVENTIONS.md,
+.rules, .aider.conf.yml, .github/copilot-instructions.md, and the
+Trabuco-managed block in .gitignore.
 
 Out of scope: Java source, POMs, Flyway migrations, application.yml,
 docker-compose.yml, CI workflows (other than copilot-setup-steps.yml),
@@ -58,10 +59,14 @@ func runSync(cmd *cobra.Command, args []string) error {
 		projectPath = args[0]
 	}
 
+	recordHistory := trackHistory(projectPath, "sync --apply")
 	plan, err := syncpkg.Run(projectPath, Version, syncApply)
 	if err != nil {
 		return fmt.Errorf("sync failed: %w", err)
 	}
+	if syncApply {
+		recordHistory()
+	}
 
 	if syncJSON {
 		return plan.WriteJSON(os.Stdout)
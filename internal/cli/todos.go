@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/todos"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todosJSON      bool
+	todosModule    string
+	todosGenerated bool
+	todosContext   bool
+)
+
+var todosCmd = &cobra.Command{
+	Use:   "todos [path]",
+	Short: "List the TODOs left in a generated project",
+	Long: `List the TODO and FIXME comments of a Trabuco project, grouped by module.
+
+The generated skeleton has intentional TODOs where your business logic
+goes. init and add record them in .trabuco/todos.json; this command scans
+the project again and marks which outstanding TODOs came from Trabuco and
+how many of them you have already resolved. TODOs you added yourself are
+listed too.
+
+Java, Kotlin, XML, YAML, SQL, properties and shell files are searched;
+hidden directories and build output are skipped.
+
+Examples:
+  trabuco todos                    # outstanding TODOs by module
+  trabuco todos --module Worker    # one module ("." for root files)
+  trabuco todos --generated        # only the ones Trabuco generated
+  trabuco todos --context          # show the surrounding code
+  trabuco todos --json             # machine-readable, for agents and CI`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runTodos,
+}
+
+func init() {
+	todosCmd.Flags().BoolVar(&todosJSON, "json", false, "Output as JSON")
+	todosCmd.Flags().StringVar(&todosModule, "module", "", "Only list the TODOs of this module (\".\" for root files)")
+	todosCmd.Flags().BoolVar(&todosGenerated, "generated", false, "Only list the TODOs Trabuco generated")
+	todosCmd.Flags().BoolVar(&todosContext, "context", false, "Show the lines around each TODO")
+}
+
+func runTodos(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	gray := color.New(color.FgHiBlack)
+
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+	report, err := todos.Outstanding(projectPath, todosModule)
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if todosGenerated {
+		report.GeneratedOnly()
+	}
+
+	if todosJSON {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(report.Items) == 0 {
+		green.Println("✓ No outstanding TODOs")
+		if report.Resolved > 0 {
+			fmt.Printf("  %d generated TODOs resolved\n", report.Resolved)
+		}
+		return
+	}
+
+	modules := make([]string, 0, len(report.ByModule))
+	for m := range report.ByModule {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+	for _, m := range modules {
+		cyan.Printf("%s (%d)\n", m, report.ByModule[m])
+		for _, it := range report.Items {
+			if todos.ModuleLabel(it.Module) != m {
+				continue
+			}
+			origin := ""
+			if !it.Generated {
+				origin = gray.Sprint("  (yours)")
+			}
+			fmt.Printf("  %s:%d  %s: %s%s\n", it.File, it.Line, it.Kind, it.Text, origin)
+			if todosContext {
+				for _, l := range it.Context {
+					gray.Printf("      │ %s\n", strings.TrimRight(l, " \t"))
+				}
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("%d outstanding (%d generated", len(report.Items), report.Generated())
+	if report.Indexed {
+		fmt.Printf(", %d generated resolved", report.Resolved)
+	}
+	fmt.Println(")")
+	if !report.Indexed {
+		fmt.Printf("No %s found; generated TODOs cannot be told apart from yours.\n", todos.IndexFileName)
+	}
+}
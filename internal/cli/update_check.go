@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/update"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var noUpdateCheck bool
+
+// updateNotice receives the notice of the background update check started
+// for the running command; nil when no check was started.
+var updateNotice chan string
+
+// updateNoticeWait bounds how long a finished command waits for a check
+// still in flight. A check cut short runs again the next day.
+const updateNoticeWait = 1500 * time.Millisecond
+
+// updateCheckSkipped lists commands that never check for updates: the MCP
+// server talks JSON-RPC on stdio (agents ask get_version instead),
+// self-update checks on its own, and the maintainer commands are for
+// working on Trabuco itself.
+var updateCheckSkipped = map[string]bool{
+	"mcp":         true,
+	"self-update": true,
+	"internal":    true,
+	"completion":  true,
+	"help":        true,
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false, "Don't check for a newer Trabuco release or template pack (also: "+update.DisableEnv+"=1)")
+	rootCmd.PersistentPreRun = startUpdateCheck
+	rootCmd.PersistentPostRun = printUpdateNotice
+}
+
+// startUpdateCheck checks, in the background and at most once a day, for a
+// newer release or template pack relevant to the project in the working
+// directory. Only interactive runs of release builds check.
+func startUpdateCheck(cmd *cobra.Command, args []string) {
+	if noUpdateCheck || update.Disabled() || !update.IsRelease(Version) || !stderrIsTerminal() {
+		return
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if updateCheckSkipped[c.Name()] {
+			return
+		}
+	}
+
+	updateNotice = make(chan string, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		modules, packs := update.Scope(".")
+		status, err := update.NewChecker(Version).Check(ctx, modules, packs, false)
+		if err != nil {
+			updateNotice <- ""
+			return
+		}
+		notice := status.Notice()
+		switch {
+		case len(status.AffectedModules) > 0:
+			notice += "\nRun 'trabuco self-update' before adding or regenerating code: this project's modules changed since your version."
+		case status.UpdateAvailable:
+			notice += "\nRun 'trabuco self-update' to upgrade."
+		}
+		updateNotice <- notice
+	}()
+}
+
+func printUpdateNotice(cmd *cobra.Command, args []string) {
+	if updateNotice == nil {
+		return
+	}
+	select {
+	case notice := <-updateNotice:
+		if notice != "" {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "\n%s\n", notice)
+		}
+	case <-time.After(updateNoticeWait):
+	}
+}
+
+func stderrIsTerminal() bool {
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
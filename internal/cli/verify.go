@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/arianlopezc/Trabuco/internal/verify"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyWith      []string
+	verifyJar       bool
+	verifySkipBuild bool
+	verifyTimeout   = verify.DefaultTimeout
+	verifyJSON      bool
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [path]",
+	Short: "Boot the project and check that it answers",
+	Long: `Boot a generated project end to end and report whether it works.
+
+verify runs these steps and reports each as passed, failed or skipped:
+  1. Start the docker-compose services the modules need and wait for
+     their healthchecks. Services that are already running are reused.
+  2. Build the project (mvn install, or mvn package with --jar).
+  3. Launch API, plus Worker and EventConsumer with --with, through
+     mvn spring-boot:run or the built jars, and poll /actuator/health.
+  4. Create, read and delete a placeholder through PlaceholderController.
+  5. Stop the modules and the compose services verify started.
+
+Auth is turned off for the run (TRABUCO_AUTH_ENABLED=false). Module logs
+are written to target/trabuco-verify/. Ctrl-C still tears everything down.
+
+Examples:
+  trabuco verify                        # API and its dependencies
+  trabuco verify --with Worker,EventConsumer
+  trabuco verify --jar --skip-build     # reuse the jars of the last build
+  trabuco verify --json                 # structured report for CI`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().StringSliceVar(&verifyWith, "with", nil, "Also launch these modules: Worker, EventConsumer")
+	verifyCmd.Flags().BoolVar(&verifyJar, "jar", false, "Run the built jars instead of mvn spring-boot:run")
+	verifyCmd.Flags().BoolVar(&verifySkipBuild, "skip-build", false, "Skip the Maven build and use the existing one")
+	verifyCmd.Flags().DurationVar(&verifyTimeout, "timeout", verify.DefaultTimeout, "How long each module gets to become healthy")
+	verifyCmd.Flags().BoolVar(&verifyJSON, "json", false, "Output the report as JSON")
+}
+
+func runVerify(cmd *cobra.Command, args []string) {
+	red := color.New(color.FgRed)
+
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	// Cancel instead of exiting, so the modules and services get torn down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var progress io.Writer = os.Stdout
+	if verifyJSON {
+		progress = os.Stderr
+	}
+	report, err := verify.Run(ctx, projectPath, verify.Options{
+		With:      verifyWith,
+		UseJars:   verifyJar,
+		SkipBuild: verifySkipBuild,
+		Timeout:   verifyTimeout,
+		Progress:  progress,
+	})
+	if err != nil {
+		red.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if verifyJSON {
+		data, err := report.ToJSON()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	} else {
+		report.PrintSummary()
+	}
+	if !report.Passed {
+		os.Exit(1)
+	}
+}
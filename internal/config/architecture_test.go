@@ -0,0 +1,54 @@
+package config
+
+import "testing"
+
+func TestResolveArchitecture(t *testing.T) {
+	base := func() *ProjectConfig {
+		return &ProjectConfig{
+			Architecture: ArchitectureModulith,
+			Modules:      ResolveDependencies([]string{ModuleSQLDatastore, ModuleAPI, ModuleWorker}),
+		}
+	}
+	if msg := base().ResolveArchitecture(); msg != "" {
+		t.Errorf("a Java modulith with API should be accepted: %s", msg)
+	}
+
+	tests := map[string]func(c *ProjectConfig){
+		"without API":  func(c *ProjectConfig) { c.Modules = ResolveDependencies([]string{ModuleWorker}) },
+		"with AIAgent": func(c *ProjectConfig) { c.Modules = append(c.Modules, ModuleAIAgent) },
+		"Kotlin":       func(c *ProjectConfig) { c.Language = LanguageKotlin },
+		"JPMS":         func(c *ProjectConfig) { c.JPMS = true },
+	}
+	for name, change := range tests {
+		c := base()
+		change(c)
+		if c.ResolveArchitecture() == "" {
+			t.Errorf("%s: --architecture modulith should be rejected", name)
+		}
+	}
+
+	multi := &ProjectConfig{Modules: []string{ModuleModel, ModuleWorker}, JPMS: true}
+	if msg := multi.ResolveArchitecture(); msg != "" {
+		t.Errorf("the multi-module default should not be checked: %s", msg)
+	}
+}
+
+func TestModulithLayout(t *testing.T) {
+	c := &ProjectConfig{
+		Architecture: ArchitectureModulith,
+		Modules:      ResolveDependencies([]string{ModuleSQLDatastore, ModuleAPI, ModuleWorker}),
+	}
+	if got := c.MavenModules(); len(got) != 1 || got[0] != ModulithModule {
+		t.Errorf("MavenModules() = %v, want [%s]", got, ModulithModule)
+	}
+	if got := c.ModuleDir(ModuleWorker); got != ModulithModule {
+		t.Errorf("ModuleDir(Worker) = %s, want %s", got, ModulithModule)
+	}
+	targets := c.RuntimeTargets()
+	if len(targets) != 1 || targets[0].Module != ModulithModule || targets[0].Port != 8080 {
+		t.Errorf("RuntimeTargets() = %+v, want the App on 8080", targets)
+	}
+	if len(c.ModuleRuntimeTargets()) != 2 {
+		t.Errorf("ModuleRuntimeTargets() = %+v, want API and Worker", c.ModuleRuntimeTargets())
+	}
+}
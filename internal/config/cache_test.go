@@ -0,0 +1,23 @@
+package config
+
+import "testing"
+
+func TestResolveCache(t *testing.T) {
+	none := &ProjectConfig{Modules: []string{"Model", "Shared"}, Cache: CacheNone}
+	if msg := none.ResolveCache(); msg != "" || none.Cache != "" {
+		t.Errorf("none should normalize to empty, got %q (%s)", none.Cache, msg)
+	}
+
+	cached := &ProjectConfig{Modules: []string{"Model", "SQLDatastore", "Shared", "API"}, Database: DatabasePostgreSQL, Cache: CacheRedis}
+	if msg := cached.ResolveCache(); msg != "" {
+		t.Errorf("redis cache with Shared and SQLDatastore should resolve: %s", msg)
+	}
+	if !cached.NeedsRedisService() {
+		t.Error("redis cache should add the Redis service")
+	}
+
+	noDatastore := &ProjectConfig{Modules: []string{"Model", "Shared", "API"}, Cache: CacheCaffeine}
+	if noDatastore.ResolveCache() == "" {
+		t.Error("cache without a datastore should be rejected")
+	}
+}
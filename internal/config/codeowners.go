@@ -0,0 +1,106 @@
+package config
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// codeOwnersOrg is the placeholder organization of the generated teams,
+// replaced by the real one when the project is handed to its teams
+const codeOwnersOrg = "@your-org"
+
+// CodeOwnersFile is the CODEOWNERS file --with-codeowners generates. The
+// repository root is the one location GitHub, GitLab and Bitbucket all read.
+const CodeOwnersFile = "CODEOWNERS"
+
+// CodeOwner is one CODEOWNERS entry: a path pattern and the team owning it
+type CodeOwner struct {
+	Pattern string
+	Owner   string
+}
+
+// Line returns the entry as a CODEOWNERS line
+func (o CodeOwner) Line() string {
+	return o.Pattern + " " + o.Owner
+}
+
+// HasCodeOwners returns true if CODEOWNERS and the module boundary docs
+// under docs/modules/ are generated
+func (c *ProjectConfig) HasCodeOwners() bool {
+	return c.CodeOwners
+}
+
+// CodeOwnersDefaultTeam returns the placeholder team owning everything no
+// module entry matches: the build, CI and docker-compose files
+func (c *ProjectConfig) CodeOwnersDefaultTeam() string {
+	return codeOwnersOrg + "/" + c.ArtifactID + "-maintainers"
+}
+
+// CodeOwnersTeam returns the placeholder team owning a module
+func (c *ProjectConfig) CodeOwnersTeam(module string) string {
+	return codeOwnersOrg + "/" + strings.ToLower(module) + "-owners"
+}
+
+// ModuleSourcePattern returns the CODEOWNERS pattern matching a module's
+// sources: its directory, or its package inside App in a modulith
+func (c *ProjectConfig) ModuleSourcePattern(module string) string {
+	if c.IsModulith() && slices.Contains(modulithModules, module) {
+		return "/" + path.Join(ModulithModule, "src", "**", c.PackagePath(), strings.ToLower(module)) + "/"
+	}
+	return "/" + module + "/"
+}
+
+// ModuleBoundaryDoc returns the path of a module's boundary doc
+func ModuleBoundaryDoc(module string) string {
+	return "docs/modules/" + module + ".md"
+}
+
+// CodeOwnersModules returns the installed modules in registry order, so
+// CODEOWNERS and the boundary docs list them the same way whatever order
+// they were selected in
+func (c *ProjectConfig) CodeOwnersModules() []string {
+	var modules []string
+	for _, m := range ModuleRegistry {
+		if c.HasModule(m.Name) {
+			modules = append(modules, m.Name)
+		}
+	}
+	return modules
+}
+
+// ModuleCodeOwners returns the CODEOWNERS entries of a module: its sources
+// and its boundary doc, both owned by the module's team
+func (c *ProjectConfig) ModuleCodeOwners(module string) []CodeOwner {
+	team := c.CodeOwnersTeam(module)
+	return []CodeOwner{
+		{Pattern: c.ModuleSourcePattern(module), Owner: team},
+		{Pattern: "/" + ModuleBoundaryDoc(module), Owner: team},
+	}
+}
+
+// ModuleDependsOn returns the installed modules a module builds on
+func (c *ProjectConfig) ModuleDependsOn(module string) []string {
+	m := GetModule(module)
+	if m == nil {
+		return nil
+	}
+	var deps []string
+	for _, dep := range m.Dependencies {
+		if c.HasModule(dep) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// ModuleUsedBy returns the installed modules that build on a module
+func (c *ProjectConfig) ModuleUsedBy(module string) []string {
+	var users []string
+	for _, m := range c.CodeOwnersModules() {
+		if slices.Contains(GetModule(m).Dependencies, module) {
+			users = append(users, m)
+		}
+	}
+	return users
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModuleCodeOwners(t *testing.T) {
+	cfg := &ProjectConfig{GroupID: "com.test.shop", ArtifactID: "shop", Modules: []string{"API", "Model", "Shared", "SQLDatastore"}}
+	var lines []string
+	for _, o := range cfg.ModuleCodeOwners(ModuleAPI) {
+		lines = append(lines, o.Line())
+	}
+	if got := strings.Join(lines, "\n"); got != "/API/ @your-org/api-owners\n/docs/modules/API.md @your-org/api-owners" {
+		t.Errorf("API entries:\n%s", got)
+	}
+	if got := strings.Join(cfg.CodeOwnersModules(), ","); got != "Model,SQLDatastore,Shared,API" {
+		t.Errorf("modules should follow the registry order, got %s", got)
+	}
+	if got := strings.Join(cfg.ModuleUsedBy(ModuleShared), ","); got != "API" {
+		t.Errorf("Shared used by = %s", got)
+	}
+	if got := strings.Join(cfg.ModuleDependsOn(ModuleAPI), ","); got != "Model,Shared" {
+		t.Errorf("API depends on = %s", got)
+	}
+
+	// A modulith keeps the modules' packages in App; AIAgent stays apart
+	cfg.Architecture = ArchitectureModulith
+	if got := cfg.ModuleSourcePattern(ModuleAPI); got != "/App/src/**/com/test/shop/api/" {
+		t.Errorf("modulith API pattern = %s", got)
+	}
+	if got := cfg.ModuleSourcePattern(ModuleAIAgent); got != "/AIAgent/" {
+		t.Errorf("AIAgent pattern = %s", got)
+	}
+}
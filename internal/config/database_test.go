@@ -0,0 +1,44 @@
+package config
+
+import "testing"
+
+func TestDatabaseImage(t *testing.T) {
+	tests := []struct {
+		database, version, want string
+	}{
+		{DatabasePostgreSQL, "", "postgres:15-alpine"},
+		{DatabasePostgreSQL, "16", "postgres:16-alpine"},
+		{DatabasePostgreSQL, "16-bookworm", "postgres:16-bookworm"},
+		{DatabaseMySQL, "8.0", "mysql:8.0"},
+		{DatabaseMariaDB, "", "mariadb:11.4"},
+		{"generic", "", ""},
+	}
+	for _, tt := range tests {
+		cfg := &ProjectConfig{Database: tt.database, DatabaseVersion: tt.version}
+		if got := cfg.DatabaseImage(); got != tt.want {
+			t.Errorf("DatabaseImage(%s, %q) = %q, want %q", tt.database, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestValidateDatabaseVersionFlag(t *testing.T) {
+	for _, version := range []string{"", "16", "8.4", "11.4.2", "16-alpine"} {
+		if msg := ValidateDatabaseVersionFlag(version); msg != "" {
+			t.Errorf("%q should be valid: %s", version, msg)
+		}
+	}
+	for _, version := range []string{"latest", "16 ", "postgres:16", "-16"} {
+		if msg := ValidateDatabaseVersionFlag(version); msg == "" {
+			t.Errorf("%q should be rejected", version)
+		}
+	}
+
+	noSQL := &ProjectConfig{Modules: []string{"Model", "Shared"}, DatabaseVersion: "16"}
+	if noSQL.ResolveDatabaseVersion() == "" {
+		t.Error("--db-version without a SQL database should be rejected")
+	}
+	mariadb := &ProjectConfig{Modules: []string{"Model", "SQLDatastore"}, Database: DatabaseMariaDB, DatabaseVersion: "10.11"}
+	if msg := mariadb.ResolveDatabaseVersion(); msg != "" {
+		t.Errorf("MariaDB should accept a pinned version: %s", msg)
+	}
+}
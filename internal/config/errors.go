@@ -0,0 +1,91 @@
+package config
+
+import "strings"
+
+// ErrorCode is one entry of the API's error catalog: the errorCode
+// extension of an RFC 9457 Problem Detail, with the HTTP status and title
+// it always comes with. The generated ErrorCode enum in Model and
+// docs/errors.md are both rendered from this catalog.
+type ErrorCode struct {
+	Name        string // enum constant and errorCode value, e.g. "DUPLICATE_RESOURCE"
+	Status      int
+	Title       string
+	Description string // when the API answers with it, for docs/errors.md
+
+	// applies reports whether the generated API can answer with the code;
+	// nil means always
+	applies func(*ProjectConfig) bool
+}
+
+// Type returns the problem type URI of the code, e.g.
+// "urn:problem-type:duplicate-resource".
+func (e ErrorCode) Type() string {
+	return "urn:problem-type:" + strings.ReplaceAll(strings.ToLower(e.Name), "_", "-")
+}
+
+func hasDatastore(c *ProjectConfig) bool {
+	return c.HasModule(ModuleSQLDatastore) || c.HasModule(ModuleNoSQLDatastore)
+}
+
+// errorCatalog lists every code in the order of the enum. Published codes
+// are never renamed or reused: clients branch on them.
+var errorCatalog = []ErrorCode{
+	{Name: "VALIDATION_FAILED", Status: 400, Title: "Validation Failed",
+		Description: "A request body field failed Bean Validation. `fieldErrors` maps each field to its message; `globalErrors` lists object-level failures."},
+	{Name: "CONSTRAINT_VIOLATION", Status: 400, Title: "Validation Failed",
+		Description: "A path or query parameter failed a constraint. `violations` maps each parameter to its message."},
+	{Name: "MALFORMED_REQUEST", Status: 400, Title: "Malformed Request",
+		Description: "The request body is not valid JSON or has an unknown field, named in `unknownField`."},
+	{Name: "INVALID_ARGUMENT", Status: 400, Title: "Bad Request",
+		Description: "Application code rejected an argument (`IllegalArgumentException`). The reason is logged, not returned."},
+	{Name: "UNAUTHORIZED", Status: 401, Title: "Unauthorized",
+		Description: "With `trabuco.auth.enabled=true`, the request has no bearer token or its token was rejected."},
+	{Name: "FORBIDDEN", Status: 403, Title: "Forbidden",
+		Description: "With `trabuco.auth.enabled=true`, the token is valid but lacks the required scope."},
+	{Name: "RESOURCE_NOT_FOUND", Status: 404, Title: "Not Found",
+		Description: "The requested resource does not exist.",
+		applies:     hasDatastore},
+	{Name: "DUPLICATE_RESOURCE", Status: 409, Title: "Conflict",
+		Description: "A resource with a unique value of the request already exists.",
+		applies:     hasDatastore},
+	{Name: "DATA_INTEGRITY_VIOLATION", Status: 409, Title: "Conflict",
+		Description: "The request violates a data integrity constraint (foreign key, not-null, check).",
+		applies:     hasDatastore},
+	{Name: "OPTIMISTIC_LOCK_FAILED", Status: 409, Title: "Conflict",
+		Description: "The resource was modified concurrently. Re-fetch it and retry.",
+		applies:     hasDatastore},
+	{Name: "IDEMPOTENCY_KEY_IN_USE", Status: 409, Title: "Request In Progress",
+		Description: "A request with the same `Idempotency-Key` is still being processed. Retry once it completes to get its response.",
+		applies:     (*ProjectConfig).HasIdempotency},
+	{Name: "IDEMPOTENCY_KEY_REUSED", Status: 422, Title: "Idempotency Key Reused",
+		Description: "The `Idempotency-Key` was already used for a request with a different method, path or body. Use a new key for a new request.",
+		applies:     (*ProjectConfig).HasIdempotency},
+	{Name: "RATE_LIMIT_EXCEEDED", Status: 429, Title: "Too Many Requests",
+		Description: "The client used up its request quota. Retry after the `Retry-After` header.",
+		applies:     (*ProjectConfig).HasRateLimit},
+	{Name: "INTERNAL_ERROR", Status: 500, Title: "Internal Server Error",
+		Description: "An unexpected error. The stack trace is logged under the response's `correlationId`."},
+	{Name: "MESSAGING_UNAVAILABLE", Status: 503, Title: "Service Unavailable",
+		Description: "An event could not be published because the message broker is unavailable.",
+		applies: func(c *ProjectConfig) bool {
+			return c.HasModule(ModuleEvents) && (c.UsesKafka() || c.UsesRabbitMQ())
+		}},
+}
+
+// AllErrorCodes returns the whole error catalog, which the ErrorCode enum
+// declares whatever the modules so that adding one never misses a code.
+func (c *ProjectConfig) AllErrorCodes() []ErrorCode {
+	return errorCatalog
+}
+
+// ErrorCodes returns the codes the generated API can answer with, as
+// documented in docs/errors.md.
+func (c *ProjectConfig) ErrorCodes() []ErrorCode {
+	var codes []ErrorCode
+	for _, e := range errorCatalog {
+		if e.applies == nil || e.applies(c) {
+			codes = append(codes, e)
+		}
+	}
+	return codes
+}
@@ -0,0 +1,54 @@
+package config
+
+import "testing"
+
+func TestProjectConfig_ErrorCodes(t *testing.T) {
+	names := func(codes []ErrorCode) map[string]bool {
+		m := map[string]bool{}
+		for _, c := range codes {
+			m[c.Name] = true
+		}
+		return m
+	}
+
+	apiOnly := &ProjectConfig{Modules: []string{"Model", "Shared", "API"}}
+	got := names(apiOnly.ErrorCodes())
+	for _, want := range []string{"VALIDATION_FAILED", "UNAUTHORIZED", "INTERNAL_ERROR"} {
+		if !got[want] {
+			t.Errorf("API-only project should document %s", want)
+		}
+	}
+	for _, unwanted := range []string{"DUPLICATE_RESOURCE", "RATE_LIMIT_EXCEEDED", "MESSAGING_UNAVAILABLE"} {
+		if got[unwanted] {
+			t.Errorf("API-only project should not document %s", unwanted)
+		}
+	}
+	if len(apiOnly.AllErrorCodes()) != len(errorCatalog) {
+		t.Error("AllErrorCodes should return the whole catalog whatever the modules")
+	}
+
+	full := &ProjectConfig{
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "API", "Events"},
+		Database:      DatabasePostgreSQL,
+		MessageBroker: "kafka",
+		RateLimit:     true,
+		Idempotency:   true,
+	}
+	if n := len(full.ErrorCodes()); n != len(errorCatalog) {
+		t.Errorf("a project with every feature should document the whole catalog, got %d of %d", n, len(errorCatalog))
+	}
+
+	seen := map[string]bool{}
+	for _, c := range errorCatalog {
+		if c.Status < 400 || c.Status > 599 || c.Title == "" || c.Description == "" {
+			t.Errorf("%s is incomplete: %+v", c.Name, c)
+		}
+		if seen[c.Type()] {
+			t.Errorf("%s repeats the type %s", c.Name, c.Type())
+		}
+		seen[c.Type()] = true
+	}
+	if typ := (ErrorCode{Name: "DUPLICATE_RESOURCE"}).Type(); typ != "urn:problem-type:duplicate-resource" {
+		t.Errorf("unexpected type %s", typ)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HistoryFileName is the project's append-only log of mutating operations,
+// one JSON object per line.
+const HistoryFileName = ".trabuco/history.jsonl"
+
+// HistoryEntry records one mutating operation (init, add, doctor --fix,
+// sync --apply, ...) run against a project.
+type HistoryEntry struct {
+	Timestamp     string   `json:"timestamp"`
+	Version       string   `json:"version"`           // Trabuco version that ran the operation
+	Operation     string   `json:"operation"`         // e.g. "init", "add", "add entity", "doctor --fix"
+	Args          []string `json:"args,omitempty"`    // Command-line arguments (or MCP tool arguments)
+	Modules       []string `json:"modules,omitempty"` // Modules generated, for init/add
+	FilesCreated  []string `json:"files_created,omitempty"`
+	FilesModified []string `json:"files_modified,omitempty"`
+	FilesDeleted  []string `json:"files_deleted,omitempty"`
+}
+
+// FilesTouched returns the number of files the operation created, modified
+// or deleted
+func (e *HistoryEntry) FilesTouched() int {
+	return len(e.FilesCreated) + len(e.FilesModified) + len(e.FilesDeleted)
+}
+
+// Command returns the operation as the user would have typed it
+func (e *HistoryEntry) Command() string {
+	if len(e.Args) == 0 {
+		return "trabuco " + e.Operation
+	}
+	return "trabuco " + strings.Join(e.Args, " ")
+}
+
+// AppendHistory adds an entry to the project's history file
+func AppendHistory(projectPath string, entry HistoryEntry) error {
+	path := filepath.Join(projectPath, HistoryFileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", HistoryFileName, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s: %w", HistoryFileName, err)
+	}
+	return nil
+}
+
+// LoadHistory reads the project's history, oldest first. A missing file
+// yields no entries; malformed lines are skipped.
+func LoadHistory(projectPath string) ([]HistoryEntry, error) {
+	f, err := os.Open(filepath.Join(projectPath, HistoryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", HistoryFileName, err)
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var e HistoryEntry
+		if json.Unmarshal([]byte(line), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", HistoryFileName, err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveJobStorage(t *testing.T) {
+	worker := []string{"Model", "Jobs", "Worker"}
+	withSQL := []string{"Model", "SQLDatastore", "Jobs", "Worker"}
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+		want string // substring of the message; "" for ok
+	}{
+		{"auto", ProjectConfig{Modules: worker}, ""},
+		{"needs Worker", ProjectConfig{Modules: []string{"Model", "API"}, JobStorage: JobStorageInMemory}, "needs the Worker module"},
+		{"existing-sql needs SQLDatastore", ProjectConfig{Modules: worker, JobStorage: JobStorageExistingSQL}, "needs the SQLDatastore module"},
+		{"existing-sql", ProjectConfig{Modules: withSQL, Database: DatabaseMySQL, JobStorage: JobStorageExistingSQL}, ""},
+		{"postgres with postgres", ProjectConfig{Modules: withSQL, Database: DatabasePostgreSQL, JobStorage: JobStoragePostgres}, ""},
+		{"postgres with mysql", ProjectConfig{Modules: withSQL, Database: DatabaseMySQL, JobStorage: JobStoragePostgres}, "conflicts with --database mysql"},
+		{"mysql with mariadb", ProjectConfig{Modules: withSQL, Database: DatabaseMariaDB, JobStorage: JobStorageMySQL}, ""},
+		{"mysql with postgres", ProjectConfig{Modules: withSQL, Database: DatabasePostgreSQL, JobStorage: JobStorageMySQL}, "conflicts with --database postgresql"},
+		{"mysql of its own", ProjectConfig{Modules: worker, JobStorage: JobStorageMySQL}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.cfg.ResolveJobStorage()
+			if tt.want == "" && msg != "" || !strings.Contains(msg, tt.want) {
+				t.Errorf("ResolveJobStorage() = %q, want %q", msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobStorage_OwnDatabase(t *testing.T) {
+	mysql := &ProjectConfig{ProjectName: "job-shop", Modules: []string{"Model", "NoSQLDatastore", "Worker"}, NoSQLDatabase: DatabaseMongoDB, JobStorage: JobStorageMySQL}
+	if !mysql.WorkerNeedsOwnMySQL() || mysql.WorkerNeedsOwnPostgres() || mysql.JobRunrUsesMongoDB() {
+		t.Error("--job-storage mysql without SQLDatastore should get its own MySQL, not MongoDB")
+	}
+	if got := mysql.JobRunrServiceName(); got != "mysql-jobrunr" {
+		t.Errorf("JobRunrServiceName() = %q", got)
+	}
+	if got := mysql.JobRunrDatabaseName(); got != "job_shop_jobs" {
+		t.Errorf("JobRunrDatabaseName() = %q", got)
+	}
+	if !mysql.NeedsDockerCompose() {
+		t.Error("the JobRunr MySQL runs in docker-compose")
+	}
+
+	postgres := &ProjectConfig{ProjectName: "shop", Modules: []string{"Model", "NoSQLDatastore", "Worker"}, NoSQLDatabase: DatabaseRedis, JobStorage: JobStoragePostgres}
+	if !postgres.WorkerNeedsOwnPostgres() || postgres.WorkerUsesPostgresFallback() || postgres.ShowRedisWorkerWarning() {
+		t.Error("an explicit --job-storage postgres is not the fallback")
+	}
+
+	shared := &ProjectConfig{Modules: []string{"Model", "SQLDatastore", "Worker"}, Database: DatabaseMariaDB, JobStorage: JobStorageMySQL}
+	if shared.JobRunrNeedsOwnDatabase() || shared.JobRunrSqlDatabase() != DatabaseMariaDB {
+		t.Error("--job-storage mysql should reuse a MariaDB SQLDatastore")
+	}
+}
+
+func TestJobStorageWarnings(t *testing.T) {
+	memory := &ProjectConfig{Modules: []string{"Model", "API", "Jobs", "Worker"}, JobStorage: JobStorageInMemory}
+	if !memory.JobRunrUsesInMemory() || memory.JobRunrNeedsOwnDatabase() || memory.NeedsDockerCompose() {
+		t.Error("in-memory storage needs no database")
+	}
+	if got := memory.JobStorageWarnings(); len(got) != 2 {
+		t.Errorf("API with in-memory storage should warn twice, got %v", got)
+	}
+	memory.Architecture = ArchitectureModulith
+	if got := memory.JobStorageWarnings(); len(got) != 1 {
+		t.Errorf("a modulith enqueues in the Worker's process, got %v", got)
+	}
+	if got := (&ProjectConfig{Modules: []string{"Model", "Jobs", "Worker"}}).JobStorageWarnings(); got != nil {
+		t.Errorf("SQL storage has no warnings, got %v", got)
+	}
+}
+
+func TestResolveJobRunrFeatures(t *testing.T) {
+	noWorker := []string{"Model", "SQLDatastore", "API"}
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+		want string // substring of the message; "" for ok
+	}{
+		{"none", ProjectConfig{Modules: noWorker}, ""},
+		{"all with Worker", ProjectConfig{Modules: []string{"Model", "Jobs", "Worker"}, JobRunrDashboard: true, JobRunrBatches: true, JobRunrCarbonArea: "BE"}, ""},
+		{"dashboard", ProjectConfig{Modules: noWorker, JobRunrDashboard: true}, "--with-jobrunr-dashboard"},
+		{"batches", ProjectConfig{Modules: noWorker, JobRunrBatches: true}, "--with-jobrunr-batches"},
+		{"carbon area", ProjectConfig{Modules: noWorker, JobRunrCarbonArea: "DE"}, "--jobrunr-carbon-area=DE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.cfg.ResolveJobRunrFeatures()
+			if tt.want == "" && msg != "" || !strings.Contains(msg, tt.want) {
+				t.Errorf("ResolveJobRunrFeatures() = %q, want %q", msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateJobRunrCarbonAreaFlag(t *testing.T) {
+	for _, area := range []string{"", "BE", "DE", "US-CA", "IT-NO"} {
+		if msg := ValidateJobRunrCarbonAreaFlag(area); msg != "" {
+			t.Errorf("ValidateJobRunrCarbonAreaFlag(%q) = %q, want ok", area, msg)
+		}
+	}
+	for _, area := range []string{"be", "Belgium", "B", "US_CA", "US-"} {
+		if msg := ValidateJobRunrCarbonAreaFlag(area); msg == "" {
+			t.Errorf("ValidateJobRunrCarbonAreaFlag(%q) should fail", area)
+		}
+	}
+}
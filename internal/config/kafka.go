@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Kafka acknowledgement modes (--kafka-ack-mode)
+const (
+	KafkaAckBatch  = "batch"
+	KafkaAckRecord = "record"
+	KafkaAckManual = "manual"
+)
+
+// Defaults of the EventConsumer's Kafka listener when no flag overrides them
+const (
+	defaultKafkaConcurrency     = 3
+	defaultKafkaMaxPollRecords  = 500 // Kafka's own default
+	defaultKafkaMaxPollInterval = 5 * time.Minute
+	maxKafkaConcurrency         = 64
+)
+
+// kafkaGroupPattern is the character set Kafka allows in group ids and topic
+// names
+var kafkaGroupPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// KafkaConsumerGroupID returns the consumer group id of the EventConsumer's
+// Kafka listener: the --kafka-consumer-group convention with {project}
+// replaced by the project name, or "<project>-consumers"
+func (c *ProjectConfig) KafkaConsumerGroupID() string {
+	if c.KafkaConsumerGroup == "" {
+		return c.ProjectName + "-consumers"
+	}
+	return strings.ReplaceAll(c.KafkaConsumerGroup, "{project}", c.ProjectName)
+}
+
+// KafkaListenerConcurrency returns the number of consumer threads per
+// EventConsumer instance
+func (c *ProjectConfig) KafkaListenerConcurrency() int {
+	if c.KafkaConcurrency > 0 {
+		return c.KafkaConcurrency
+	}
+	return defaultKafkaConcurrency
+}
+
+// KafkaTopicPartitions returns the partition count of the topics the local
+// Kafka broker creates. It defaults to the listener concurrency, so that
+// every consumer thread gets a partition.
+func (c *ProjectConfig) KafkaTopicPartitions() int {
+	if c.KafkaPartitions > 0 {
+		return c.KafkaPartitions
+	}
+	return c.KafkaListenerConcurrency()
+}
+
+// KafkaPollRecords returns the consumer's max.poll.records
+func (c *ProjectConfig) KafkaPollRecords() int {
+	if c.KafkaMaxPollRecords > 0 {
+		return c.KafkaMaxPollRecords
+	}
+	return defaultKafkaMaxPollRecords
+}
+
+// KafkaPollIntervalMs returns the consumer's max.poll.interval.ms
+func (c *ProjectConfig) KafkaPollIntervalMs() int64 {
+	if d, err := time.ParseDuration(c.KafkaMaxPollInterval); err == nil && d > 0 {
+		return d.Milliseconds()
+	}
+	return defaultKafkaMaxPollInterval.Milliseconds()
+}
+
+// KafkaManualAck returns true if the Kafka listener acknowledges each record
+// itself (--kafka-ack-mode manual)
+func (c *ProjectConfig) KafkaManualAck() bool {
+	return c.KafkaAckMode == KafkaAckManual && c.HasModule(ModuleEventConsumer) && c.UsesKafka()
+}
+
+// KafkaContainerAckMode returns the spring.kafka.listener.ack-mode value for
+// the ack mode: manual acknowledgements commit immediately, on the consumer
+// thread, rather than with the next poll
+func (c *ProjectConfig) KafkaContainerAckMode() string {
+	switch c.KafkaAckMode {
+	case KafkaAckRecord:
+		return "record"
+	case KafkaAckManual:
+		return "manual_immediate"
+	}
+	return "batch"
+}
+
+// ValidateKafkaConsumerGroupFlag returns "" when the value is empty or a
+// consumer group naming convention Kafka accepts once {project} is
+// replaced, and an error message otherwise
+func ValidateKafkaConsumerGroupFlag(group string) string {
+	if group == "" {
+		return ""
+	}
+	if !kafkaGroupPattern.MatchString(strings.ReplaceAll(group, "{project}", "project")) {
+		return "Invalid --kafka-consumer-group value '" + group + "'. Use letters, digits, '.', '_' and '-', with {project} for the project name, e.g. '{project}.orders-consumers'"
+	}
+	return ""
+}
+
+// ValidateKafkaAckModeFlag returns "" when the value is a supported ack mode
+// (or empty) and an error message otherwise
+func ValidateKafkaAckModeFlag(mode string) string {
+	switch mode {
+	case "", KafkaAckBatch, KafkaAckRecord, KafkaAckManual:
+		return ""
+	}
+	return "Invalid --kafka-ack-mode value '" + mode + "'. Valid options: batch, record, manual"
+}
+
+// ValidateKafkaMaxPollIntervalFlag returns "" when the value is empty or a
+// duration of at least a second ("90s", "5m", "1h"), and an error message
+// otherwise
+func ValidateKafkaMaxPollIntervalFlag(interval string) string {
+	if interval == "" {
+		return ""
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d < time.Second {
+		return "Invalid --kafka-max-poll-interval value '" + interval + "'. Use a duration of at least 1s, e.g. 90s, 5m or 1h"
+	}
+	return ""
+}
+
+// ValidateKafkaCountFlag returns "" when n is unset (0) or a usable count for
+// the named flag, and an error message otherwise
+func ValidateKafkaCountFlag(flag string, n int) string {
+	if n < 0 {
+		return fmt.Sprintf("Invalid --%s value %d. Use a positive number", flag, n)
+	}
+	if flag == "kafka-concurrency" && n > maxKafkaConcurrency {
+		return fmt.Sprintf("Invalid --kafka-concurrency value %d. Use at most %d consumer threads per instance and scale out with more instances", n, maxKafkaConcurrency)
+	}
+	return ""
+}
+
+// ResolveKafkaConsumer enforces the cross-flag rules for the Kafka listener
+// settings: they configure the EventConsumer's Kafka consumer. Returns "" on
+// success or a human-readable error message.
+func (c *ProjectConfig) ResolveKafkaConsumer() string {
+	var set []string
+	for _, f := range []struct {
+		name string
+		on   bool
+	}{
+		{"--kafka-consumer-group", c.KafkaConsumerGroup != ""},
+		{"--kafka-concurrency", c.KafkaConcurrency != 0},
+		{"--kafka-partitions", c.KafkaPartitions != 0},
+		{"--kafka-max-poll-records", c.KafkaMaxPollRecords != 0},
+		{"--kafka-max-poll-interval", c.KafkaMaxPollInterval != ""},
+		{"--kafka-ack-mode", c.KafkaAckMode != ""},
+	} {
+		if f.on {
+			set = append(set, f.name)
+		}
+	}
+	if len(set) == 0 || c.HasModule(ModuleEventConsumer) && c.UsesKafka() {
+		return ""
+	}
+	return strings.Join(set, ", ") + " configure the EventConsumer's Kafka listener; they need the EventConsumer module with --message-broker kafka."
+}
+
+// KafkaConsumerWarnings returns advisory notes on the Kafka listener
+// settings that do not stop generation
+func (c *ProjectConfig) KafkaConsumerWarnings() []string {
+	if !c.HasModule(ModuleEventConsumer) || !c.UsesKafka() {
+		return nil
+	}
+	if partitions, concurrency := c.KafkaTopicPartitions(), c.KafkaListenerConcurrency(); partitions < concurrency {
+		return []string{fmt.Sprintf("--kafka-partitions=%d is below --kafka-concurrency=%d: Kafka assigns each partition to one consumer of the group, so %d consumer threads per instance stay idle.",
+			partitions, concurrency, concurrency-partitions)}
+	}
+	return nil
+}
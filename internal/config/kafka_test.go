@@ -0,0 +1,77 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKafkaListenerDefaults(t *testing.T) {
+	cfg := &ProjectConfig{ProjectName: "orders", Modules: []string{"Model", "Events", "EventConsumer"}, MessageBroker: BrokerKafka}
+	if got := cfg.KafkaConsumerGroupID(); got != "orders-consumers" {
+		t.Errorf("KafkaConsumerGroupID() = %q", got)
+	}
+	if cfg.KafkaListenerConcurrency() != 3 || cfg.KafkaTopicPartitions() != 3 || cfg.KafkaPollRecords() != 500 {
+		t.Errorf("defaults: concurrency %d, partitions %d, poll records %d", cfg.KafkaListenerConcurrency(), cfg.KafkaTopicPartitions(), cfg.KafkaPollRecords())
+	}
+	if got := cfg.KafkaPollIntervalMs(); got != 300000 {
+		t.Errorf("KafkaPollIntervalMs() = %d", got)
+	}
+	if cfg.KafkaContainerAckMode() != "batch" || cfg.KafkaManualAck() {
+		t.Error("the default ack mode is batch")
+	}
+
+	cfg.KafkaConsumerGroup = "{project}.billing"
+	cfg.KafkaConcurrency = 6
+	cfg.KafkaMaxPollInterval = "90s"
+	cfg.KafkaAckMode = KafkaAckManual
+	if got := cfg.KafkaConsumerGroupID(); got != "orders.billing" {
+		t.Errorf("KafkaConsumerGroupID() = %q", got)
+	}
+	if cfg.KafkaTopicPartitions() != 6 {
+		t.Error("partitions follow the concurrency")
+	}
+	if cfg.KafkaPollIntervalMs() != 90000 || cfg.KafkaContainerAckMode() != "manual_immediate" || !cfg.KafkaManualAck() {
+		t.Errorf("interval %d, ack mode %q", cfg.KafkaPollIntervalMs(), cfg.KafkaContainerAckMode())
+	}
+}
+
+func TestValidateKafkaListenerFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want string // substring of the message; "" for ok
+	}{
+		{"group convention", ValidateKafkaConsumerGroupFlag("{project}.orders-consumers"), ""},
+		{"group with space", ValidateKafkaConsumerGroupFlag("my group"), "--kafka-consumer-group"},
+		{"unknown token", ValidateKafkaConsumerGroupFlag("{env}-consumers"), "--kafka-consumer-group"},
+		{"ack mode", ValidateKafkaAckModeFlag(KafkaAckRecord), ""},
+		{"bad ack mode", ValidateKafkaAckModeFlag("auto"), "Valid options: batch, record, manual"},
+		{"interval", ValidateKafkaMaxPollIntervalFlag("10m"), ""},
+		{"interval without unit", ValidateKafkaMaxPollIntervalFlag("300000"), "--kafka-max-poll-interval"},
+		{"interval too short", ValidateKafkaMaxPollIntervalFlag("500ms"), "at least 1s"},
+		{"negative count", ValidateKafkaCountFlag("kafka-partitions", -1), "--kafka-partitions"},
+		{"too many threads", ValidateKafkaCountFlag("kafka-concurrency", 65), "at most 64"},
+		{"unset count", ValidateKafkaCountFlag("kafka-concurrency", 0), ""},
+	}
+	for _, tt := range tests {
+		if tt.want == "" && tt.msg != "" || !strings.Contains(tt.msg, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.msg, tt.want)
+		}
+	}
+}
+
+func TestResolveKafkaConsumer(t *testing.T) {
+	rabbit := &ProjectConfig{Modules: []string{"Model", "Events", "EventConsumer"}, MessageBroker: BrokerRabbitMQ, KafkaConcurrency: 4, KafkaAckMode: KafkaAckManual}
+	if msg := rabbit.ResolveKafkaConsumer(); !strings.Contains(msg, "--kafka-concurrency, --kafka-ack-mode configure") {
+		t.Errorf("ResolveKafkaConsumer() = %q", msg)
+	}
+	rabbit.MessageBroker = BrokerKafka
+	if msg := rabbit.ResolveKafkaConsumer(); msg != "" {
+		t.Errorf("ResolveKafkaConsumer() = %q, want ok", msg)
+	}
+
+	idle := &ProjectConfig{Modules: []string{"Model", "Events", "EventConsumer"}, MessageBroker: BrokerKafka, KafkaConcurrency: 8, KafkaPartitions: 2}
+	if got := idle.KafkaConsumerWarnings(); len(got) != 1 || !strings.Contains(got[0], "6 consumer threads") {
+		t.Errorf("KafkaConsumerWarnings() = %v", got)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// License identifiers accepted by --license
+const (
+	LicenseApache2     = "apache2"
+	LicenseMIT         = "mit"
+	LicenseProprietary = "proprietary" // --license proprietary:<file>, header read from the file
+)
+
+// LicenseYearToken is replaced with the current year in generated files.
+// Spotless understands the same token, so the header it enforces matches.
+const LicenseYearToken = "$YEAR"
+
+// ResolveLicense parses a --license value ("apache2", "mit" or
+// "proprietary:<file>") and returns the license ID and the header text.
+func ResolveLicense(spec, projectName string) (id, header string, err error) {
+	kind, file, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	switch strings.ToLower(kind) {
+	case LicenseApache2:
+		return LicenseApache2, fmt.Sprintf(`Copyright %s the %s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.`, LicenseYearToken, projectName), nil
+	case LicenseMIT:
+		return LicenseMIT, fmt.Sprintf(`Copyright %s the %s authors
+
+Use of this source code is governed by the MIT license that can be
+found in the LICENSE file.`, LicenseYearToken, projectName), nil
+	case LicenseProprietary:
+		if file == "" {
+			return "", "", fmt.Errorf("--license proprietary needs a header file: proprietary:<file>")
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read license header %s: %w", file, err)
+		}
+		header = strings.TrimSpace(string(data))
+		if header == "" {
+			return "", "", fmt.Errorf("license header file %s is empty", file)
+		}
+		return LicenseProprietary, header, nil
+	}
+	return "", "", fmt.Errorf("invalid license '%s'. Valid options: apache2, mit, proprietary:<file>", spec)
+}
+
+// HasLicenseHeader returns true if generated Java files get a license header
+func (c *ProjectConfig) HasLicenseHeader() bool {
+	return strings.TrimSpace(c.LicenseHeader) != ""
+}
+
+// LicenseHeaderComment returns LicenseHeader as a Java comment. Plain text is
+// wrapped in a block comment; text that already is a comment is kept as-is.
+// The $YEAR token is left in place for Spotless.
+func (c *ProjectConfig) LicenseHeaderComment() string {
+	header := strings.TrimSpace(c.LicenseHeader)
+	if header == "" || strings.HasPrefix(header, "/*") || strings.HasPrefix(header, "//") {
+		return header
+	}
+	var b strings.Builder
+	b.WriteString("/*\n")
+	for _, line := range strings.Split(header, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			b.WriteString(" *\n")
+		} else {
+			b.WriteString(" * " + line + "\n")
+		}
+	}
+	b.WriteString(" */")
+	return b.String()
+}
+
+// LicenseYear returns the year substituted for $YEAR in generated files
+func (c *ProjectConfig) LicenseYear() string {
+	return strconv.Itoa(time.Now().Year())
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveLicense(t *testing.T) {
+	id, header, err := ResolveLicense("apache2", "billing")
+	if err != nil || id != LicenseApache2 {
+		t.Fatalf("apache2: got %q, %v", id, err)
+	}
+	if !strings.HasPrefix(header, "Copyright $YEAR the billing authors") || !strings.Contains(header, "Apache License, Version 2.0") {
+		t.Errorf("unexpected apache2 header:\n%s", header)
+	}
+
+	id, header, err = ResolveLicense("MIT", "billing")
+	if err != nil || id != LicenseMIT || !strings.Contains(header, "MIT license") {
+		t.Errorf("mit: got %q, %q, %v", id, header, err)
+	}
+
+	file := filepath.Join(t.TempDir(), "header.txt")
+	os.WriteFile(file, []byte("\nAcme Corp. Confidential.\n"), 0644)
+	id, header, err = ResolveLicense("proprietary:"+file, "billing")
+	if err != nil || id != LicenseProprietary || header != "Acme Corp. Confidential." {
+		t.Errorf("proprietary: got %q, %q, %v", id, header, err)
+	}
+
+	for _, bad := range []string{"gpl", "proprietary", "proprietary:" + filepath.Join(t.TempDir(), "missing")} {
+		if _, _, err := ResolveLicense(bad, "billing"); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestProjectConfig_LicenseHeaderComment(t *testing.T) {
+	cfg := &ProjectConfig{LicenseHeader: "Copyright $YEAR Acme\n\nAll rights reserved.\n"}
+	want := "/*\n * Copyright $YEAR Acme\n *\n * All rights reserved.\n */"
+	if got := cfg.LicenseHeaderComment(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	cfg.LicenseHeader = "// SPDX-License-Identifier: MIT"
+	if got := cfg.LicenseHeaderComment(); got != cfg.LicenseHeader {
+		t.Errorf("comment header should be kept verbatim, got %q", got)
+	}
+
+	cfg.LicenseHeader = "  "
+	if cfg.HasLicenseHeader() || cfg.LicenseHeaderComment() != "" {
+		t.Error("blank header should count as no header")
+	}
+}
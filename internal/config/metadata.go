@@ -19,6 +19,7 @@ type ProjectMetadata struct {
 	GroupID       string   `json:"groupId"`
 	ArtifactID    string   `json:"artifactId"`
 	JavaVersion   string   `json:"javaVersion"`
+	Language      string   `json:"language,omitempty"`
 	Modules       []string `json:"modules"`
 	Database      string   `json:"database,omitempty"`
 	NoSQLDatabase string   `json:"noSqlDatabase,omitempty"`
@@ -30,7 +31,62 @@ type ProjectMetadata struct {
 	// because `trabuco sync` rebuilds the project from this metadata —
 	// without it, sync round-trips through an empty value and never
 	// re-emits vector-store templates.
-	VectorStore   string   `json:"vectorStore,omitempty"`
+	VectorStore       string `json:"vectorStore,omitempty"`
+	DatabaseVersion   string `json:"databaseVersion,omitempty"`
+	Observability     bool   `json:"observability,omitempty"`
+	Native            bool   `json:"native,omitempty"`
+	Pagination        bool   `json:"pagination,omitempty"`
+	RateLimit         bool   `json:"rateLimit,omitempty"`
+	Idempotency       bool   `json:"idempotency,omitempty"`
+	KafkaTransactions bool   `json:"kafkaTransactions,omitempty"`
+	Perf              bool   `json:"perf,omitempty"`
+	DevContainer      bool   `json:"devContainer,omitempty"`
+	CodeOwners        bool   `json:"codeOwners,omitempty"`
+	TaskRunner        string `json:"taskRunner,omitempty"`
+	Auditing          bool   `json:"auditing,omitempty"`
+	ReadReplica       bool   `json:"readReplica,omitempty"`
+	Cache             string `json:"cache,omitempty"`
+	Secrets           string `json:"secrets,omitempty"`
+	JobStorage        string `json:"jobStorage,omitempty"`
+	JobRunrDashboard  bool   `json:"jobRunrDashboard,omitempty"`
+	JobRunrBatches    bool   `json:"jobRunrBatches,omitempty"`
+	JobRunrCarbonArea string `json:"jobRunrCarbonArea,omitempty"`
+	License           string `json:"license,omitempty"`
+	// LicenseHeader is the comment prepended to generated .java files, so
+	// modules added later carry the same header.
+	LicenseHeader  string `json:"licenseHeader,omitempty"`
+	ImageRegistry  string `json:"imageRegistry,omitempty"`
+	ImageBuilder   string `json:"imageBuilder,omitempty"`
+	StaticAnalysis string `json:"staticAnalysis,omitempty"`
+	LogFormat      string `json:"logFormat,omitempty"`
+	Architecture   string `json:"architecture,omitempty"`
+	// JPMS records --jpms (module-info.java per module).
+	JPMS bool `json:"jpms,omitempty"`
+	// EnablePreview records --enable-preview.
+	EnablePreview bool `json:"enablePreview,omitempty"`
+	// NoCoverageGates records --no-coverage-gates; absent means gated.
+	NoCoverageGates bool `json:"noCoverageGates,omitempty"`
+
+	// Kafka listener settings of the EventConsumer (--kafka-consumer-group,
+	// --kafka-concurrency, ...); zero values are Trabuco's defaults.
+	KafkaConsumerGroup   string `json:"kafkaConsumerGroup,omitempty"`
+	KafkaConcurrency     int    `json:"kafkaConcurrency,omitempty"`
+	KafkaPartitions      int    `json:"kafkaPartitions,omitempty"`
+	KafkaMaxPollRecords  int    `json:"kafkaMaxPollRecords,omitempty"`
+	KafkaMaxPollInterval string `json:"kafkaMaxPollInterval,omitempty"`
+	KafkaAckMode         string `json:"kafkaAckMode,omitempty"`
+
+	// SQS and Pub/Sub delivery options (--sqs-fifo, --sqs-dlq,
+	// --sqs-max-receive-count, --pubsub-ordering, --pubsub-exactly-once).
+	SQSFifo            bool `json:"sqsFifo,omitempty"`
+	SQSDeadLetter      bool `json:"sqsDeadLetter,omitempty"`
+	SQSMaxReceiveCount int  `json:"sqsMaxReceiveCount,omitempty"`
+	PubSubOrdering     bool `json:"pubsubOrdering,omitempty"`
+	PubSubExactlyOnce  bool `json:"pubsubExactlyOnce,omitempty"`
+
+	// SpotlessRatchetFrom is the git ref Spotless formats and checks from;
+	// only files changed since it are touched (set by migrate activate).
+	SpotlessRatchetFrom string `json:"spotlessRatchetFrom,omitempty"`
 }
 
 // LoadMetadata loads project metadata from .trabuco.json in the specified directory
@@ -79,19 +135,64 @@ func MetadataExists(projectPath string) bool {
 // NewMetadataFromConfig creates a ProjectMetadata from a ProjectConfig
 func NewMetadataFromConfig(cfg *ProjectConfig, version string) *ProjectMetadata {
 	return &ProjectMetadata{
-		Version:       version,
-		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
-		ProjectName:   cfg.ProjectName,
-		GroupID:       cfg.GroupID,
-		ArtifactID:    cfg.ArtifactID,
-		JavaVersion:   cfg.JavaVersion,
-		Modules:       cfg.Modules,
-		Database:      cfg.Database,
-		NoSQLDatabase: cfg.NoSQLDatabase,
-		MessageBroker: cfg.MessageBroker,
-		AIAgents:      cfg.AIAgents,
-		CIProvider:    cfg.CIProvider,
-		VectorStore:   cfg.VectorStore,
+		Version:           version,
+		GeneratedAt:       time.Now().UTC().Format(time.RFC3339),
+		ProjectName:       cfg.ProjectName,
+		GroupID:           cfg.GroupID,
+		ArtifactID:        cfg.ArtifactID,
+		JavaVersion:       cfg.JavaVersion,
+		Language:          cfg.Language,
+		Modules:           cfg.Modules,
+		Database:          cfg.Database,
+		DatabaseVersion:   cfg.DatabaseVersion,
+		NoSQLDatabase:     cfg.NoSQLDatabase,
+		MessageBroker:     cfg.MessageBroker,
+		AIAgents:          cfg.AIAgents,
+		CIProvider:        cfg.CIProvider,
+		VectorStore:       cfg.VectorStore,
+		Observability:     cfg.Observability,
+		Native:            cfg.Native,
+		Pagination:        cfg.Pagination,
+		RateLimit:         cfg.RateLimit,
+		Idempotency:       cfg.Idempotency,
+		KafkaTransactions: cfg.KafkaTransactions,
+		Perf:              cfg.Perf,
+		DevContainer:      cfg.DevContainer,
+		CodeOwners:        cfg.CodeOwners,
+		TaskRunner:        cfg.TaskRunner,
+		Auditing:          cfg.Auditing,
+		ReadReplica:       cfg.ReadReplica,
+		Cache:             cfg.Cache,
+		Secrets:           cfg.Secrets,
+		JobStorage:        cfg.JobStorage,
+		JobRunrDashboard:  cfg.JobRunrDashboard,
+		JobRunrBatches:    cfg.JobRunrBatches,
+		JobRunrCarbonArea: cfg.JobRunrCarbonArea,
+		License:           cfg.License,
+		LicenseHeader:     cfg.LicenseHeader,
+		ImageRegistry:     cfg.ImageRegistry,
+		ImageBuilder:      cfg.ImageBuilder,
+
+		StaticAnalysis:      cfg.StaticAnalysis,
+		LogFormat:           cfg.LogFormat,
+		Architecture:        cfg.Architecture,
+		JPMS:                cfg.JPMS,
+		EnablePreview:       cfg.EnablePreview,
+		NoCoverageGates:     cfg.NoCoverageGates,
+		SpotlessRatchetFrom: cfg.SpotlessRatchetFrom,
+
+		KafkaConsumerGroup:   cfg.KafkaConsumerGroup,
+		KafkaConcurrency:     cfg.KafkaConcurrency,
+		KafkaPartitions:      cfg.KafkaPartitions,
+		KafkaMaxPollRecords:  cfg.KafkaMaxPollRecords,
+		KafkaMaxPollInterval: cfg.KafkaMaxPollInterval,
+		KafkaAckMode:         cfg.KafkaAckMode,
+
+		SQSFifo:            cfg.SQSFifo,
+		SQSDeadLetter:      cfg.SQSDeadLetter,
+		SQSMaxReceiveCount: cfg.SQSMaxReceiveCount,
+		PubSubOrdering:     cfg.PubSubOrdering,
+		PubSubExactlyOnce:  cfg.PubSubExactlyOnce,
 	}
 }
 
@@ -99,17 +200,62 @@ func NewMetadataFromConfig(cfg *ProjectConfig, version string) *ProjectMetadata
 // This is useful when inferring configuration from an existing project
 func (m *ProjectMetadata) ToProjectConfig() *ProjectConfig {
 	return &ProjectConfig{
-		ProjectName:   m.ProjectName,
-		GroupID:       m.GroupID,
-		ArtifactID:    m.ArtifactID,
-		JavaVersion:   m.JavaVersion,
-		Modules:       m.Modules,
-		Database:      m.Database,
-		NoSQLDatabase: m.NoSQLDatabase,
-		MessageBroker: m.MessageBroker,
-		AIAgents:      m.AIAgents,
-		CIProvider:    m.CIProvider,
-		VectorStore:   m.VectorStore,
+		ProjectName:       m.ProjectName,
+		GroupID:           m.GroupID,
+		ArtifactID:        m.ArtifactID,
+		JavaVersion:       m.JavaVersion,
+		Language:          m.Language,
+		Modules:           m.Modules,
+		Database:          m.Database,
+		DatabaseVersion:   m.DatabaseVersion,
+		NoSQLDatabase:     m.NoSQLDatabase,
+		MessageBroker:     m.MessageBroker,
+		AIAgents:          m.AIAgents,
+		CIProvider:        m.CIProvider,
+		VectorStore:       m.VectorStore,
+		Observability:     m.Observability,
+		Native:            m.Native,
+		Pagination:        m.Pagination,
+		RateLimit:         m.RateLimit,
+		Idempotency:       m.Idempotency,
+		KafkaTransactions: m.KafkaTransactions,
+		Perf:              m.Perf,
+		DevContainer:      m.DevContainer,
+		CodeOwners:        m.CodeOwners,
+		TaskRunner:        m.TaskRunner,
+		Auditing:          m.Auditing,
+		ReadReplica:       m.ReadReplica,
+		Cache:             m.Cache,
+		Secrets:           m.Secrets,
+		JobStorage:        m.JobStorage,
+		JobRunrDashboard:  m.JobRunrDashboard,
+		JobRunrBatches:    m.JobRunrBatches,
+		JobRunrCarbonArea: m.JobRunrCarbonArea,
+		License:           m.License,
+		LicenseHeader:     m.LicenseHeader,
+		ImageRegistry:     m.ImageRegistry,
+		ImageBuilder:      m.ImageBuilder,
+
+		StaticAnalysis:      m.StaticAnalysis,
+		LogFormat:           m.LogFormat,
+		Architecture:        m.Architecture,
+		JPMS:                m.JPMS,
+		EnablePreview:       m.EnablePreview,
+		NoCoverageGates:     m.NoCoverageGates,
+		SpotlessRatchetFrom: m.SpotlessRatchetFrom,
+
+		KafkaConsumerGroup:   m.KafkaConsumerGroup,
+		KafkaConcurrency:     m.KafkaConcurrency,
+		KafkaPartitions:      m.KafkaPartitions,
+		KafkaMaxPollRecords:  m.KafkaMaxPollRecords,
+		KafkaMaxPollInterval: m.KafkaMaxPollInterval,
+		KafkaAckMode:         m.KafkaAckMode,
+
+		SQSFifo:            m.SQSFifo,
+		SQSDeadLetter:      m.SQSDeadLetter,
+		SQSMaxReceiveCount: m.SQSMaxReceiveCount,
+		PubSubOrdering:     m.PubSubOrdering,
+		PubSubExactlyOnce:  m.PubSubExactlyOnce,
 	}
 }
 
@@ -1,35 +1,124 @@
 package config
 
+import "fmt"
+
 // Module name constants - use these instead of string literals
 const (
 	ModuleModel          = "Model"
 	ModuleJobs           = "Jobs"
 	ModuleSQLDatastore   = "SQLDatastore"
 	ModuleNoSQLDatastore = "NoSQLDatastore"
+	ModuleSearch         = "Search"
 	ModuleShared         = "Shared"
 	ModuleAPI            = "API"
 	ModuleWorker         = "Worker"
 	ModuleEvents         = "Events"
 	ModuleEventConsumer = "EventConsumer"
 	ModuleAIAgent       = "AIAgent"
+	ModuleClientSDK     = "ClientSDK"
 )
 
 // Database type constants
 const (
 	DatabasePostgreSQL = "postgresql"
 	DatabaseMySQL      = "mysql"
+	DatabaseMariaDB    = "mariadb"
 	DatabaseMongoDB    = "mongodb"
 	DatabaseRedis      = "redis"
+	DatabaseDynamoDB   = "dynamodb"
+	DatabaseCassandra  = "cassandra"
 )
 
+// SQLDatabases lists the SQL databases that run in a container, in display order
+var SQLDatabases = []string{DatabasePostgreSQL, DatabaseMySQL, DatabaseMariaDB}
+
+// SQLDatabaseDisplayName returns the human-readable name of a SQL database
+func SQLDatabaseDisplayName(db string) string {
+	switch db {
+	case DatabasePostgreSQL:
+		return "PostgreSQL"
+	case DatabaseMySQL:
+		return "MySQL"
+	case DatabaseMariaDB:
+		return "MariaDB"
+	default:
+		return db
+	}
+}
+
+// NoSQLDatabases lists the supported NoSQL databases in display order
+var NoSQLDatabases = []string{DatabaseMongoDB, DatabaseRedis, DatabaseDynamoDB, DatabaseCassandra}
+
+// NoSQLDatabaseDisplayName returns the human-readable name of a NoSQL database
+func NoSQLDatabaseDisplayName(db string) string {
+	switch db {
+	case DatabaseMongoDB:
+		return "MongoDB"
+	case DatabaseRedis:
+		return "Redis"
+	case DatabaseDynamoDB:
+		return "DynamoDB"
+	case DatabaseCassandra:
+		return "Cassandra"
+	default:
+		return db
+	}
+}
+
+// IsValidNoSQLDatabase reports whether db is a supported NoSQL database
+func IsValidNoSQLDatabase(db string) bool {
+	for _, d := range NoSQLDatabases {
+		if d == db {
+			return true
+		}
+	}
+	return false
+}
+
 // Message broker constants
 const (
 	BrokerKafka    = "kafka"
 	BrokerRabbitMQ = "rabbitmq"
 	BrokerSQS      = "sqs"
 	BrokerPubSub   = "pubsub"
+	// BrokerRedisStreams and BrokerNATS are lightweight brokers for systems
+	// that don't want to run Kafka or RabbitMQ.
+	BrokerRedisStreams = "redis-streams"
+	BrokerNATS         = "nats"
 )
 
+// MessageBrokers lists the supported message brokers in display order
+var MessageBrokers = []string{BrokerKafka, BrokerRabbitMQ, BrokerSQS, BrokerPubSub, BrokerRedisStreams, BrokerNATS}
+
+// IsValidMessageBroker reports whether broker is a supported message broker
+func IsValidMessageBroker(broker string) bool {
+	for _, b := range MessageBrokers {
+		if b == broker {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageBrokerDisplayName returns the human-readable name of a broker
+func MessageBrokerDisplayName(broker string) string {
+	switch broker {
+	case BrokerKafka:
+		return "Kafka"
+	case BrokerRabbitMQ:
+		return "RabbitMQ"
+	case BrokerSQS:
+		return "AWS SQS"
+	case BrokerPubSub:
+		return "GCP Pub/Sub"
+	case BrokerRedisStreams:
+		return "Redis Streams"
+	case BrokerNATS:
+		return "NATS JetStream"
+	}
+	return broker
+}
+
 // Module represents a project module with its metadata
 type Module struct {
 	Name           string   // Technical identifier (no spaces): "AIAgent"
@@ -40,8 +129,10 @@ type Module struct {
 	DoesNotInclude string   // Explicit boundaries for this module
 	Required       bool     // If true, cannot be deselected
 	Internal       bool     // If true, not shown in CLI prompts (auto-included when needed)
+	Addable        bool     // If true, an Internal module that 'trabuco add <name>' still accepts (not offered in prompts)
 	Dependencies   []string // Names of modules this depends on (only Model is a real dependency)
 	ConflictsWith  []string // Explicit mutual exclusions
+	Plugin         string   // Name of the template pack that contributed this module ("" for built-ins)
 }
 
 // ModuleRegistry contains all available modules
@@ -85,15 +176,27 @@ var ModuleRegistry = []Module{
 	},
 	{
 		Name:           ModuleNoSQLDatastore,
-		Description:    "NoSQL repositories (MongoDB, Redis)",
-		UseCase:        "Adds NoSQL database persistence. Choose MongoDB for flexible document storage or Redis for key-value/caching.",
-		WhenToUse:      "User mentions: MongoDB, Redis, NoSQL, document store, cache, key-value",
+		Description:    "NoSQL repositories (MongoDB, Redis, DynamoDB, Cassandra)",
+		UseCase:        "Adds NoSQL database persistence. Choose MongoDB for flexible document storage, Redis for key-value/caching, DynamoDB for serverless key-value on AWS, or Cassandra for write-heavy wide-column data.",
+		WhenToUse:      "User mentions: MongoDB, Redis, DynamoDB, Cassandra, NoSQL, document store, cache, key-value, wide-column",
 		DoesNotInclude: "Does not include data modeling, indexing strategies, or cache eviction policies",
 		Required:       false,
 		Internal:       false,
 		Dependencies:   []string{ModuleModel},
 		ConflictsWith:  []string{ModuleSQLDatastore},
 	},
+	{
+		Name:           ModuleSearch,
+		Description:    "Full-text search (OpenSearch, Spring Data Elasticsearch)",
+		UseCase:        "Adds full-text search backed by OpenSearch through Spring Data Elasticsearch: an index mapping for the placeholder, a search repository and a SearchService in Shared that keeps the index in step with the datastore.",
+		WhenToUse:      "User mentions: search, full-text, Elasticsearch, OpenSearch, autocomplete, relevance, fuzzy matching, faceted search",
+		DoesNotInclude: "Does not include reindexing jobs, change-data-capture pipelines, vector/semantic search, or managed-cluster provisioning",
+		Required:       false,
+		Internal:       false,
+		// SearchService (the indexing hook) lives in Shared
+		Dependencies:  []string{ModuleModel, ModuleShared},
+		ConflictsWith: []string{},
+	},
 	{
 		Name:           ModuleShared,
 		Description:    "Services, Circuit breaker, Utilities",
@@ -115,7 +218,7 @@ var ModuleRegistry = []Module{
 		// trabuco.auth.enabled property flips it on. The runtime utilities
 		// (RequestContextHolder, JwtClaimsExtractor, AuthContextPropagator)
 		// live in Shared, so Shared is a hard dependency.
-		DoesNotInclude: "Does not include rate limiting, API versioning, pagination helpers, or GraphQL. Auth scaffolding is generated dormant by default and activated via trabuco.auth.enabled.",
+		DoesNotInclude: "Does not include rate limiting (opt in with --with-rate-limit), API versioning, pagination helpers, or GraphQL. Auth scaffolding is generated dormant by default and activated via trabuco.auth.enabled.",
 		Required:       false,
 		Internal:       false,
 		Dependencies:   []string{ModuleModel, ModuleShared},
@@ -136,10 +239,11 @@ var ModuleRegistry = []Module{
 		Name:           ModuleEvents,
 		Description:    "Event contracts for event-driven processing",
 		UseCase:        "Defines event contracts using sealed interfaces and provides EventPublisher. Auto-included when EventConsumer is selected.",
-		WhenToUse:      "Automatically included when EventConsumer module is selected. Not selected directly.",
+		WhenToUse:      "Automatically included when EventConsumer module is selected. Add it on its own ('trabuco add Events') to publish events that another service consumes.",
 		DoesNotInclude: "Does not include event processing logic — only contracts and publisher",
 		Required:       false,
 		Internal:       true,
+		Addable:        true,
 		Dependencies:   []string{ModuleModel},
 		ConflictsWith:  []string{},
 	},
@@ -169,6 +273,22 @@ var ModuleRegistry = []Module{
 		Dependencies:  []string{ModuleModel, ModuleShared},
 		ConflictsWith: []string{},
 	},
+	{
+		Name:           ModuleClientSDK,
+		DisplayName:    "Client SDK",
+		Description:    "Typed Java client generated from the API's OpenAPI spec",
+		UseCase:        "Adds a standalone Maven module that generates a typed Java client (Spring RestClient) from the API's OpenAPI spec with openapi-generator. Consumers of the API depend on the ClientSDK artifact instead of hand-writing HTTP calls; a Maven exec step refreshes the spec from the running API so the client stays in sync.",
+		WhenToUse:      "User mentions: client SDK, API client, typed client, OpenAPI generator, consumers of the API, other services calling this API",
+		DoesNotInclude: "Does not include a TypeScript or other non-Java client (switch the generatorName in ClientSDK/pom.xml to produce one), client-side auth token acquisition, or retries.",
+		Required:       false,
+		Internal:       false,
+		// The client is generated from the API's OpenAPI output, so the
+		// module is meaningless without API (listed with API's own Shared
+		// dependency, since resolution is one level deep). It has no Maven
+		// dependency on any other module: consumers get only the client.
+		Dependencies:  []string{ModuleModel, ModuleShared, ModuleAPI},
+		ConflictsWith: []string{},
+	},
 }
 
 // GetModule returns a module by name, or nil if not found
@@ -181,6 +301,28 @@ func GetModule(name string) *Module {
 	return nil
 }
 
+// RegisterModule appends an externally-defined module (e.g. one contributed
+// by an installed template pack) to the registry. Returns an error when the
+// name collides with an existing module so a plugin can never shadow a
+// built-in.
+func RegisterModule(m Module) error {
+	if m.Name == "" {
+		return fmt.Errorf("module name is required")
+	}
+	if GetModule(m.Name) != nil {
+		return fmt.Errorf("module %s is already registered", m.Name)
+	}
+	ModuleRegistry = append(ModuleRegistry, m)
+	return nil
+}
+
+// IsPluginModule reports whether the named module was contributed by a
+// template pack rather than shipped with Trabuco.
+func IsPluginModule(name string) bool {
+	m := GetModule(name)
+	return m != nil && m.Plugin != ""
+}
+
 // GetModuleNames returns all module names
 func GetModuleNames() []string {
 	names := make([]string, len(ModuleRegistry))
@@ -2,6 +2,7 @@ package config
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -450,6 +451,22 @@ func TestProjectConfig_WorkerUsesPostgresFallback(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "Worker with DynamoDB - fallback",
+			cfg: &ProjectConfig{
+				Modules:       []string{"Model", "NoSQLDatastore", "Worker"},
+				NoSQLDatabase: "dynamodb",
+			},
+			expected: true,
+		},
+		{
+			name: "Worker with Cassandra - fallback",
+			cfg: &ProjectConfig{
+				Modules:       []string{"Model", "NoSQLDatastore", "Worker"},
+				NoSQLDatabase: "cassandra",
+			},
+			expected: true,
+		},
 		{
 			name: "No Worker - no fallback",
 			cfg: &ProjectConfig{
@@ -771,3 +788,86 @@ func TestProjectConfig_ShowRedisWorkerWarning(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectConfig_ObservabilityTargets(t *testing.T) {
+	cfg := &ProjectConfig{Modules: []string{ModuleModel, ModuleShared}, Observability: true}
+	if cfg.HasObservability() || cfg.NeedsDockerCompose() {
+		t.Error("observability needs a runtime module")
+	}
+
+	cfg.Modules = []string{ModuleModel, ModuleAPI, ModuleWorker, ModuleAIAgent}
+	if !cfg.HasObservability() || !cfg.NeedsDockerCompose() {
+		t.Error("expected observability stack and docker-compose")
+	}
+	got := map[string]int{}
+	for _, target := range cfg.ObservabilityTargets() {
+		got[target.Job] = target.Port
+	}
+	want := map[string]int{"api": 8080, "worker": 8081, "aiagent": 8086}
+	if len(got) != len(want) {
+		t.Errorf("targets = %v, want %v", got, want)
+	}
+	for job, port := range want {
+		if got[job] != port {
+			t.Errorf("%s port = %d, want %d", job, got[job], port)
+		}
+	}
+
+	cfg.Observability = false
+	if cfg.HasObservability() {
+		t.Error("observability should be opt-in")
+	}
+}
+
+func TestProjectConfig_ComposeAppServices(t *testing.T) {
+	env := func(svc ComposeAppService) map[string]string {
+		m := map[string]string{}
+		for _, e := range svc.Env {
+			m[e.Name] = e.Value
+		}
+		return m
+	}
+
+	cfg := &ProjectConfig{
+		ProjectName:   "shop",
+		Modules:       []string{ModuleModel, ModuleSQLDatastore, ModuleShared, ModuleAPI, ModuleWorker, ModuleEvents, ModuleEventConsumer},
+		Database:      DatabasePostgreSQL,
+		MessageBroker: BrokerKafka,
+	}
+	services := cfg.ComposeAppServices()
+	if len(services) != 3 {
+		t.Fatalf("expected a service per runtime module, got %+v", services)
+	}
+	api, worker := env(services[0]), env(services[1])
+	if services[0].Name != "api" || api["SERVER_PORT"] != "8080" || api["DB_HOST"] != "postgres" || api["KAFKA_BOOTSTRAP_SERVERS"] != "kafka:29092" {
+		t.Errorf("unexpected api service: %+v", services[0])
+	}
+	if _, ok := api["SPRING_DATASOURCE_URL"]; ok {
+		t.Error("the API datasource follows DB_HOST")
+	}
+	if worker["SPRING_DATASOURCE_URL"] != "jdbc:postgresql://postgres:5432/shop" {
+		t.Errorf("the Worker's JobRunr datasource should point at postgres, got %q", worker["SPRING_DATASOURCE_URL"])
+	}
+	if got := strings.Join(services[2].DependsOn, ","); got != "postgres,kafka" {
+		t.Errorf("eventconsumer depends on %s", got)
+	}
+
+	cfg = &ProjectConfig{
+		ProjectName:   "shop",
+		Modules:       []string{ModuleModel, ModuleNoSQLDatastore, ModuleShared, ModuleAPI, ModuleWorker},
+		NoSQLDatabase: DatabaseRedis,
+	}
+	for _, svc := range cfg.ComposeAppServices() {
+		if env(svc)["SPRING_DATASOURCE_URL"] != "jdbc:postgresql://postgres-jobrunr:5432/shop_jobs" {
+			t.Errorf("%s should use the JobRunr PostgreSQL", svc.Name)
+		}
+		if got := strings.Join(svc.DependsOn, ","); got != "redis,postgres-jobrunr" {
+			t.Errorf("%s depends on %s", svc.Name, got)
+		}
+	}
+
+	cfg.Modules = []string{ModuleModel, ModuleShared}
+	if len(cfg.ComposeAppServices()) != 0 {
+		t.Error("no runtime module, no app service")
+	}
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// javaReservedWords are the keywords and literals that cannot be an
+// identifier, and so not a package segment either (JLS 3.9)
+var javaReservedWords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extends": true, "final": true, "finally": true, "float": true,
+	"for": true, "goto": true, "if": true, "implements": true, "import": true,
+	"instanceof": true, "int": true, "interface": true, "long": true, "native": true,
+	"new": true, "package": true, "private": true, "protected": true, "public": true,
+	"return": true, "short": true, "static": true, "strictfp": true, "super": true,
+	"switch": true, "synchronized": true, "this": true, "throw": true, "throws": true,
+	"transient": true, "try": true, "void": true, "volatile": true, "while": true,
+	"true": true, "false": true, "null": true, "_": true,
+}
+
+// isWindowsReservedName reports whether Windows refuses name as a file or
+// directory name, whatever the extension
+func isWindowsReservedName(name string) bool {
+	switch name {
+	case "con", "prn", "aux", "nul":
+		return true
+	}
+	if len(name) == 4 && (strings.HasPrefix(name, "com") || strings.HasPrefix(name, "lpt")) {
+		return name[3] >= '1' && name[3] <= '9'
+	}
+	return false
+}
+
+// Name length limits of the engines the project name ends up in
+const (
+	postgresMaxIdentifier = 63  // NAMEDATALEN - 1, in bytes
+	mysqlMaxDatabase      = 64  // MySQL and MariaDB schema names
+	mongoMaxDatabase      = 63  // Database names must be shorter than 64 bytes
+	cassandraMaxKeyspace  = 48  // Keyspace names
+	dynamoDBMaxTable      = 255 // Table names
+	kafkaMaxName          = 249 // Topic names; consumer group ids are held to it too
+)
+
+// ValidateProjectName returns "" when a project name that already matches
+// the name pattern is also usable as a directory on every platform, and
+// an error message otherwise
+func ValidateProjectName(name string) string {
+	if isWindowsReservedName(name) {
+		return fmt.Sprintf("project name '%s' is a reserved device name on Windows, so the project directory could not be created or cloned there. Use a longer name, e.g. '%s-service'", name, name)
+	}
+	return ""
+}
+
+// ValidateGroupID returns "" when every segment of a group ID that already
+// matches the package pattern is a legal Java package name and a legal
+// directory name on Windows, and an error message otherwise
+func ValidateGroupID(groupID string) string {
+	segments := strings.Split(groupID, ".")
+	for i, segment := range segments {
+		renamed := append([]string{}, segments...)
+		renamed[i] = segment + "app"
+		fixed := strings.Join(renamed, ".")
+		if javaReservedWords[segment] {
+			return fmt.Sprintf("group ID segment '%s' is a Java reserved word and cannot be part of a package name. Rename it, e.g. '%s'", segment, fixed)
+		}
+		if isWindowsReservedName(segment) {
+			return fmt.Sprintf("group ID segment '%s' is a reserved device name on Windows, where its package directory could not be created. Rename it, e.g. '%s'", segment, fixed)
+		}
+	}
+	return ""
+}
+
+// ResolveNames checks the names derived from the project name and group ID
+// against the selected modules and backends: the base package must not end
+// in a module's package, and databases, keyspaces, tables and Kafka ids
+// must fit the engines' limits. It returns "" or an error message.
+func (c *ProjectConfig) ResolveNames() string {
+	if msg := ValidateProjectName(c.ProjectName); msg != "" {
+		return msg
+	}
+	if msg := ValidateGroupID(c.GroupID); msg != "" {
+		return msg
+	}
+
+	last := c.GroupID[strings.LastIndex(c.GroupID, ".")+1:]
+	for _, module := range c.Modules {
+		if GetModule(module) == nil || strings.ToLower(module) != last {
+			continue
+		}
+		fix := "e.g. '" + c.GroupID[:len(c.GroupID)-len(last)] + strings.ReplaceAll(c.ProjectName, "-", "") + "'"
+		if strings.ReplaceAll(c.ProjectName, "-", "") == last {
+			fix = "e.g. '" + c.GroupID + "s'"
+		}
+		return fmt.Sprintf("group ID '%s' ends in '%s', the package segment of the %s module: its code would live in %s.%s, and every other module's package would look nested in it. Use another last segment, %s",
+			c.GroupID, last, module, c.GroupID, last, fix)
+	}
+
+	type limit struct {
+		what  string
+		name  string
+		max   int
+		field string
+	}
+	var limits []limit
+	if c.HasModule(ModuleSQLDatastore) {
+		switch {
+		case c.Database == DatabasePostgreSQL:
+			limits = append(limits, limit{"PostgreSQL database", c.ProjectName, postgresMaxIdentifier, "bytes"})
+		case c.IsMySQLCompatible():
+			limits = append(limits, limit{SQLDatabaseDisplayName(c.Database) + " database", c.ProjectNameSnake(), mysqlMaxDatabase, "characters"})
+		}
+	}
+	if c.WorkerNeedsOwnPostgres() {
+		limits = append(limits, limit{"JobRunr PostgreSQL database", c.JobRunrDatabaseName(), postgresMaxIdentifier, "bytes"})
+	}
+	if c.WorkerNeedsOwnMySQL() {
+		limits = append(limits, limit{"JobRunr MySQL database", c.JobRunrDatabaseName(), mysqlMaxDatabase, "characters"})
+	}
+	if c.HasModule(ModuleNoSQLDatastore) {
+		switch c.NoSQLDatabase {
+		case DatabaseMongoDB:
+			limits = append(limits, limit{"MongoDB database", c.ProjectName, mongoMaxDatabase, "bytes"})
+		case DatabaseCassandra:
+			limits = append(limits, limit{"Cassandra keyspace", c.ProjectNameSnake(), cassandraMaxKeyspace, "characters"})
+		case DatabaseDynamoDB:
+			limits = append(limits, limit{"DynamoDB table", c.ProjectName + "-placeholders", dynamoDBMaxTable, "characters"})
+		}
+	}
+	if c.HasModule(ModuleEventConsumer) && c.UsesKafka() {
+		limits = append(limits, limit{"Kafka consumer group", c.KafkaConsumerGroupID(), kafkaMaxName, "characters"})
+		if c.HasKafkaTransactions() {
+			limits = append(limits, limit{"Kafka processor group", c.ProjectName + "-processor", kafkaMaxName, "characters"})
+		}
+	}
+	for _, l := range limits {
+		if len(l.name) > l.max {
+			return fmt.Sprintf("project name '%s' is too long: the %s '%s' it names has %d %s, over the limit of %d. Shorten the project name by at least %d characters",
+				c.ProjectName, l.what, l.name, len(l.name), l.field, l.max, len(l.name)-l.max)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateProjectName(t *testing.T) {
+	for _, name := range []string{"con", "aux", "nul", "prn", "com1", "lpt9"} {
+		if msg := ValidateProjectName(name); !strings.Contains(msg, "Windows") {
+			t.Errorf("ValidateProjectName(%q) = %q, want a Windows reserved name error", name, msg)
+		}
+	}
+	for _, name := range []string{"orders", "console", "com", "com10", "aux-service"} {
+		if msg := ValidateProjectName(name); msg != "" {
+			t.Errorf("ValidateProjectName(%q) = %q, want ok", name, msg)
+		}
+	}
+}
+
+func TestValidateGroupID(t *testing.T) {
+	tests := []struct {
+		groupID string
+		want    string // substring of the message; "" for ok
+	}{
+		{"com.acme.orders", ""},
+		{"com.acme.classes", ""},
+		{"com.acme.new", "'com.acme.newapp'"},
+		{"com.class.orders", "Java reserved word"},
+		{"com.acme.true", "Java reserved word"},
+		{"com.aux.orders", "'com.auxapp.orders'"},
+		{"com.acme.lpt1", "Windows"},
+	}
+	for _, tt := range tests {
+		msg := ValidateGroupID(tt.groupID)
+		if tt.want == "" && msg != "" || !strings.Contains(msg, tt.want) {
+			t.Errorf("ValidateGroupID(%q) = %q, want %q", tt.groupID, msg, tt.want)
+		}
+	}
+}
+
+func TestResolveNames(t *testing.T) {
+	long := func(n int) string { return strings.Repeat("a", n) }
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+		want string // substring of the message; "" for ok
+	}{
+		{"plain", ProjectConfig{ProjectName: "orders", GroupID: "com.acme.orders", Modules: []string{"Model", "Shared", "API"}}, ""},
+		{"group ends in module package", ProjectConfig{ProjectName: "orders", GroupID: "com.acme.api", Modules: []string{"Model", "Shared", "API"}},
+			"the package segment of the API module: its code would live in com.acme.api.api"},
+		{"suggests the project name", ProjectConfig{ProjectName: "order-service", GroupID: "com.acme.shared", Modules: []string{"Model", "Shared"}},
+			"'com.acme.orderservice'"},
+		{"module not selected", ProjectConfig{ProjectName: "orders", GroupID: "com.acme.worker", Modules: []string{"Model", "Shared", "API"}}, ""},
+		{"reserved group segment", ProjectConfig{ProjectName: "orders", GroupID: "com.acme.switch", Modules: []string{"Model"}}, "Java reserved word"},
+
+		{"postgres fits", ProjectConfig{ProjectName: long(63), GroupID: "com.acme.orders", Modules: []string{"Model", "SQLDatastore"}, Database: DatabasePostgreSQL}, ""},
+		{"postgres too long", ProjectConfig{ProjectName: long(64), GroupID: "com.acme.orders", Modules: []string{"Model", "SQLDatastore"}, Database: DatabasePostgreSQL},
+			"PostgreSQL database"},
+		{"jobrunr database", ProjectConfig{ProjectName: long(60), GroupID: "com.acme.orders", Modules: []string{"Model", "Jobs", "Worker"}},
+			"JobRunr PostgreSQL database"},
+		{"mysql uses snake case", ProjectConfig{ProjectName: long(65), GroupID: "com.acme.orders", Modules: []string{"Model", "SQLDatastore"}, Database: DatabaseMySQL},
+			"MySQL database"},
+		{"cassandra keyspace", ProjectConfig{ProjectName: long(30) + "-" + long(20), GroupID: "com.acme.orders", Modules: []string{"Model", "NoSQLDatastore"}, NoSQLDatabase: DatabaseCassandra},
+			"Cassandra keyspace '" + long(30) + "_" + long(20) + "' it names has 51 characters, over the limit of 48. Shorten the project name by at least 3"},
+		{"mongodb", ProjectConfig{ProjectName: long(64), GroupID: "com.acme.orders", Modules: []string{"Model", "NoSQLDatastore"}, NoSQLDatabase: DatabaseMongoDB},
+			"MongoDB database"},
+		{"kafka consumer group", ProjectConfig{ProjectName: long(240), GroupID: "com.acme.orders", Modules: []string{"Model", "Events", "EventConsumer"}, MessageBroker: BrokerKafka},
+			"Kafka consumer group"},
+		{"kafka limit ignores other brokers", ProjectConfig{ProjectName: long(240), GroupID: "com.acme.orders", Modules: []string{"Model", "Events", "EventConsumer"}, MessageBroker: BrokerRabbitMQ}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.cfg.ResolveNames()
+			if tt.want == "" && msg != "" || !strings.Contains(msg, tt.want) {
+				t.Errorf("ResolveNames() = %q, want %q", msg, tt.want)
+			}
+		})
+	}
+}
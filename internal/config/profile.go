@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile carries organizational defaults for new projects. Every field is
+// optional; empty values leave Trabuco's built-in defaults in place.
+type Profile struct {
+	Name          string   `yaml:"-"`                         // Key under profiles:, filled in on lookup
+	GroupIDPrefix string   `yaml:"group_id_prefix,omitempty"` // e.g. "com.acme" → com.acme.<project>
+	JavaVersion   string   `yaml:"java_version,omitempty"`
+	Language      string   `yaml:"language,omitempty"` // "java" or "kotlin"
+	Database      string   `yaml:"database,omitempty"`
+	NoSQLDatabase string   `yaml:"nosql_database,omitempty"`
+	MessageBroker string   `yaml:"message_broker,omitempty"`
+	AIAgents      []string `yaml:"ai_agents,omitempty"`
+	CIProvider    string   `yaml:"ci,omitempty"`
+	LicenseHeader string   `yaml:"license_header,omitempty"` // Prepended to every generated .java file
+	License       string   `yaml:"license,omitempty"`        // A --license value: apache2, mit, proprietary:<file>
+	ImageRegistry string   `yaml:"image_registry,omitempty"` // e.g. "ghcr.io/acme" → ghcr.io/acme/<project>-api
+	TemplatePacks []string `yaml:"template_packs,omitempty"` // Installed template pack modules included by default
+}
+
+// UserConfig is the content of ~/.trabuco/config.yaml. The defaults block
+// applies to every project; a selected profile is layered on top of it:
+//
+//	defaults:
+//	  group_id_prefix: com.acme
+//	  license: apache2
+//	  image_registry: ghcr.io/acme
+//	default_profile: acme
+//	profiles:
+//	  acme:
+//	    group_id_prefix: com.acme
+//	    java_version: "21"
+//	    database: postgresql
+//	    ai_agents: [claude, cursor]
+//	    ci: github
+//	pattern_feedback: true
+//	semantic_matching: auto
+//	mcp_audit: true
+type UserConfig struct {
+	Defaults       Profile            `yaml:"defaults,omitempty"`
+	DefaultProfile string             `yaml:"default_profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+	// PatternFeedback records, locally, whether suggest_architecture's
+	// recommendations were accepted — see `trabuco patterns stats`.
+	PatternFeedback bool `yaml:"pattern_feedback,omitempty"`
+	// SemanticMatching blends embedding similarity into suggest_architecture's
+	// pattern scores: "local" embeds offline, "auto" uses provider embeddings
+	// when credentials exist. Empty or "off" scores by keywords only.
+	SemanticMatching string `yaml:"semantic_matching,omitempty"`
+	// MCPAudit makes `trabuco mcp` append every tool call to
+	// ~/.trabuco/mcp-audit.jsonl — see `trabuco mcp audit tail`.
+	MCPAudit bool `yaml:"mcp_audit,omitempty"`
+}
+
+// UserConfigPath returns the location of the user config file. It can be
+// relocated with TRABUCO_CONFIG.
+func UserConfigPath() string {
+	if p := os.Getenv("TRABUCO_CONFIG"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".trabuco", "config.yaml")
+}
+
+// LoadUserConfig reads the user config file. A missing file yields an empty
+// config, not an error.
+func LoadUserConfig() (*UserConfig, error) {
+	path := UserConfigPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &UserConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var uc UserConfig
+	if err := yaml.Unmarshal(data, &uc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &uc, nil
+}
+
+// ProfileNames returns the configured profile names, sorted
+func (uc *UserConfig) ProfileNames() []string {
+	names := make([]string, 0, len(uc.Profiles))
+	for name := range uc.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the named profile, or the default profile when name is
+// empty, layered over the defaults block. Without a profile the defaults
+// block alone is returned (named "defaults"); nil without error means the
+// file configures nothing. An unknown name is an error.
+func (uc *UserConfig) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = uc.DefaultProfile
+		if name == "" {
+			if uc.Defaults.IsEmpty() {
+				return nil, nil
+			}
+			d := uc.Defaults
+			d.Name = "defaults"
+			return &d, nil
+		}
+	}
+	p, ok := uc.Profiles[name]
+	if !ok {
+		available := "none configured"
+		if len(uc.Profiles) > 0 {
+			available = strings.Join(uc.ProfileNames(), ", ")
+		}
+		return nil, fmt.Errorf("profile '%s' not found in %s (available: %s)", name, UserConfigPath(), available)
+	}
+	p = p.over(uc.Defaults)
+	p.Name = name
+	return &p, nil
+}
+
+// IsEmpty returns true if the profile sets nothing
+func (p Profile) IsEmpty() bool {
+	return p.GroupIDPrefix == "" && p.JavaVersion == "" && p.Language == "" && p.Database == "" &&
+		p.NoSQLDatabase == "" && p.MessageBroker == "" && len(p.AIAgents) == 0 &&
+		p.CIProvider == "" && p.LicenseHeader == "" && p.License == "" &&
+		p.ImageRegistry == "" && len(p.TemplatePacks) == 0
+}
+
+// over returns p with every unset field taken from base
+func (p Profile) over(base Profile) Profile {
+	pick := func(v, fallback string) string {
+		if v != "" {
+			return v
+		}
+		return fallback
+	}
+	p.GroupIDPrefix = pick(p.GroupIDPrefix, base.GroupIDPrefix)
+	p.JavaVersion = pick(p.JavaVersion, base.JavaVersion)
+	p.Language = pick(p.Language, base.Language)
+	p.Database = pick(p.Database, base.Database)
+	p.NoSQLDatabase = pick(p.NoSQLDatabase, base.NoSQLDatabase)
+	p.MessageBroker = pick(p.MessageBroker, base.MessageBroker)
+	p.CIProvider = pick(p.CIProvider, base.CIProvider)
+	// A profile's own license (or header) replaces the inherited one as a whole
+	if p.License == "" && p.LicenseHeader == "" {
+		p.License = base.License
+		p.LicenseHeader = base.LicenseHeader
+	}
+	p.ImageRegistry = pick(p.ImageRegistry, base.ImageRegistry)
+	if len(p.AIAgents) == 0 {
+		p.AIAgents = base.AIAgents
+	}
+	if len(p.TemplatePacks) == 0 {
+		p.TemplatePacks = base.TemplatePacks
+	}
+	return p
+}
+
+// LoadProfile loads the user config and resolves a profile from it
+func LoadProfile(name string) (*Profile, error) {
+	uc, err := LoadUserConfig()
+	if err != nil {
+		return nil, err
+	}
+	return uc.Profile(name)
+}
+
+// GroupIDFor derives a group ID for a project from the profile prefix,
+// e.g. "com.acme" + "order-service" → "com.acme.orderservice".
+// Returns "" when the profile has no prefix.
+func (p *Profile) GroupIDFor(projectName string) string {
+	if p == nil || p.GroupIDPrefix == "" {
+		return ""
+	}
+	prefix := strings.TrimSuffix(p.GroupIDPrefix, ".")
+	return prefix + "." + strings.ReplaceAll(projectName, "-", "")
+}
+
+// Defaults returns the profile's init settings keyed by MCP parameter name
+// (group_id, java_version, language, database, nosql_database, message_broker,
+// ai_agents, ci, license, image_registry). CLI flags use the same names with hyphens. Unset values
+// are omitted so callers only fill in what the profile actually provides.
+func (p *Profile) Defaults(projectName string) map[string]string {
+	d := map[string]string{}
+	if p == nil {
+		return d
+	}
+	set := func(key, value string) {
+		if value != "" {
+			d[key] = value
+		}
+	}
+	set("group_id", p.GroupIDFor(projectName))
+	set("java_version", p.JavaVersion)
+	set("language", p.Language)
+	set("database", p.Database)
+	set("nosql_database", p.NoSQLDatabase)
+	set("message_broker", p.MessageBroker)
+	set("ai_agents", strings.Join(p.AIAgents, ","))
+	set("ci", p.CIProvider)
+	set("license", p.License)
+	set("image_registry", p.ImageRegistry)
+	return d
+}
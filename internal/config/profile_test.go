@@ -0,0 +1,149 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeUserConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TRABUCO_CONFIG", path)
+}
+
+func TestLoadProfile_MissingFile(t *testing.T) {
+	t.Setenv("TRABUCO_CONFIG", filepath.Join(t.TempDir(), "absent.yaml"))
+
+	p, err := LoadProfile("")
+	if err != nil || p != nil {
+		t.Fatalf("expected no profile and no error, got %+v, %v", p, err)
+	}
+	if _, err := LoadProfile("acme"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
+
+func TestLoadProfile_DefaultAndNamed(t *testing.T) {
+	writeUserConfig(t, `
+default_profile: acme
+profiles:
+  acme:
+    group_id_prefix: com.acme
+    java_version: "25"
+    database: mysql
+    ai_agents: [claude, cursor]
+    ci: github
+    license_header: Copyright Acme
+  side:
+    message_broker: rabbitmq
+`)
+
+	p, err := LoadProfile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "acme" || p.JavaVersion != "25" || p.LicenseHeader != "Copyright Acme" {
+		t.Errorf("unexpected default profile: %+v", p)
+	}
+
+	p, err = LoadProfile("side")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "side" || p.MessageBroker != "rabbitmq" {
+		t.Errorf("unexpected named profile: %+v", p)
+	}
+
+	_, err = LoadProfile("nope")
+	if err == nil || !strings.Contains(err.Error(), "acme, side") {
+		t.Errorf("expected error listing available profiles, got %v", err)
+	}
+}
+
+func TestProfile_Defaults(t *testing.T) {
+	p := &Profile{
+		GroupIDPrefix: "com.acme.",
+		JavaVersion:   "21",
+		AIAgents:      []string{"claude", "codex"},
+		CIProvider:    "github",
+	}
+	d := p.Defaults("order-service")
+
+	want := map[string]string{
+		"group_id":     "com.acme.orderservice",
+		"java_version": "21",
+		"ai_agents":    "claude,codex",
+		"ci":           "github",
+	}
+	if len(d) != len(want) {
+		t.Errorf("expected %d defaults, got %v", len(want), d)
+	}
+	for k, v := range want {
+		if d[k] != v {
+			t.Errorf("%s = %q, want %q", k, d[k], v)
+		}
+	}
+
+	var none *Profile
+	if len(none.Defaults("x")) != 0 {
+		t.Error("nil profile should have no defaults")
+	}
+}
+
+func TestLoadProfile_DefaultsBlock(t *testing.T) {
+	writeUserConfig(t, `
+defaults:
+  group_id_prefix: com.acme
+  license: apache2
+  image_registry: ghcr.io/acme
+  template_packs: [AuditLog]
+profiles:
+  payments:
+    database: mysql
+    license_header: Copyright Payments
+`)
+
+	p, err := LoadProfile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p == nil || p.Name != "defaults" || p.ImageRegistry != "ghcr.io/acme" || p.TemplatePacks[0] != "AuditLog" {
+		t.Fatalf("expected the defaults block, got %+v", p)
+	}
+
+	p, err = LoadProfile("payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.GroupIDPrefix != "com.acme" || p.Database != "mysql" || p.ImageRegistry != "ghcr.io/acme" {
+		t.Errorf("expected profile layered over defaults, got %+v", p)
+	}
+	// The profile's own header replaces the inherited license
+	if p.License != "" || p.LicenseHeader != "Copyright Payments" {
+		t.Errorf("expected profile license header to win, got license=%q header=%q", p.License, p.LicenseHeader)
+	}
+
+	d := p.Defaults("orders")
+	if d["image_registry"] != "ghcr.io/acme" || d["group_id"] != "com.acme.orders" {
+		t.Errorf("unexpected defaults: %v", d)
+	}
+}
+
+func TestProjectConfig_ImageName(t *testing.T) {
+	cfg := &ProjectConfig{ProjectName: "orders"}
+	if got := cfg.ImageName("API"); got != "orders-api" {
+		t.Errorf("ImageName = %q, want orders-api", got)
+	}
+	cfg.ImageRegistry = "ghcr.io/acme/"
+	if got := cfg.ImageName("EventConsumer"); got != "ghcr.io/acme/orders-eventconsumer" {
+		t.Errorf("ImageName = %q, want ghcr.io/acme/orders-eventconsumer", got)
+	}
+	if got := cfg.ImagePrefix(); got != "ghcr.io/acme/" {
+		t.Errorf("ImagePrefix = %q, want ghcr.io/acme/", got)
+	}
+}
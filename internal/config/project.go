@@ -1,6 +1,13 @@
 package config
 
-import "github.com/arianlopezc/Trabuco/internal/utils"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/utils"
+	"github.com/arianlopezc/Trabuco/internal/versions"
+)
 
 // ProjectConfig holds all configuration for a generated project
 type ProjectConfig struct {
@@ -13,20 +20,31 @@ type ProjectConfig struct {
 	JavaVersion         string // "21" or "24" (25/26 deferred until Spring Boot 3.5.x bump)
 	JavaVersionDetected bool   // Whether the selected Java version was detected on the system
 
+	// Language of the application code: "java" (default, also when empty)
+	// or "kotlin". Kotlin projects get Kotlin equivalents of the domain,
+	// service, controller and test templates; infrastructure configuration
+	// stays Java in the same mixed-source build.
+	Language string
+
 	// Modules
 	Modules []string // e.g., ["Model", "SQLDatastore", "NoSQLDatastore", "Shared", "API"]
 
 	// SQL Database (only if SQLDatastore selected)
-	Database string // "postgresql", "mysql", or "generic"
+	Database string // "postgresql", "mysql", "mariadb", or "generic"
+
+	// DatabaseVersion pins the version of the SQL database image used by
+	// docker-compose, CI and Testcontainers ("16", "8.4", "11.4"); empty
+	// keeps the default tag from versions.yaml.
+	DatabaseVersion string
 
 	// NoSQL Database (only if NoSQLDatastore selected)
-	NoSQLDatabase string // "mongodb" or "redis"
+	NoSQLDatabase string // "mongodb", "redis", "dynamodb", or "cassandra"
 
 	// Message Broker (only if EventConsumer selected)
 	MessageBroker string // "kafka" or "rabbitmq"
 
 	// AI Coding Agents
-	AIAgents []string // Selected agents: "claude", "cursor", "copilot", "codex"
+	AIAgents []string // Selected agents: "claude", "cursor", "copilot", "codex", "gemini", "aider", "zed"
 
 	// CI/CD Provider
 	CIProvider string // "github" or "" (empty = none)
@@ -48,6 +66,189 @@ type ProjectConfig struct {
 	// Review: on-turn code review automation (subagents + hooks + skills)
 	Review ReviewConfig
 
+	// ContainerRuntime is the Docker-compatible runtime detected on the
+	// generating machine ("docker-desktop", "docker", "podman", "colima";
+	// empty when unknown). Podman and Colima get a Testcontainers socket
+	// override in the generated build so integration tests work out of the box.
+	ContainerRuntime string
+
+	// Observability adds OpenTelemetry tracing (OTLP export) to the runtime
+	// modules and a Prometheus + Grafana + Tempo stack under the
+	// "observability" docker-compose profile.
+	Observability bool
+
+	// Native adds GraalVM native image support to the runtime modules: a
+	// "native" Maven profile, AOT runtime hints, Dockerfile.native and a
+	// CI job.
+	Native bool
+
+	// Pagination adds a paged, sorted and filtered list endpoint for the
+	// Placeholder resource: page request DTOs, a sort whitelist and
+	// query-by-example filtering.
+	Pagination bool
+
+	// RateLimit adds per-client request rate limiting to the API module:
+	// Bucket4j token buckets keyed by the authenticated principal (or the
+	// client IP), configured under app.rate-limit, with 429 responses.
+	RateLimit bool
+
+	// Idempotency adds Idempotency-Key handling to the API's POST
+	// endpoints: a retried request replays the stored response instead of
+	// running again. Keys live in a SQL table (SQLDatastore) or in Redis,
+	// configured under app.idempotency.
+	Idempotency bool
+
+	// KafkaTransactions makes the EventConsumer's Kafka processing exactly
+	// once: a transactional consume-process-produce listener commits its
+	// output records and consumed offsets atomically, and consumers read
+	// committed records only.
+	KafkaTransactions bool
+
+	// KafkaConsumerGroup is the naming convention of the EventConsumer's
+	// Kafka consumer group, where "{project}" stands for the project name;
+	// empty means "{project}-consumers".
+	KafkaConsumerGroup string
+
+	// KafkaConcurrency is the number of consumer threads per EventConsumer
+	// instance, KafkaPartitions the partition count of the topics the
+	// local broker creates, KafkaMaxPollRecords and KafkaMaxPollInterval
+	// the consumer's max.poll.records and max.poll.interval.ms (as a
+	// duration such as "5m"). Zero or empty keeps Trabuco's defaults.
+	KafkaConcurrency     int
+	KafkaPartitions      int
+	KafkaMaxPollRecords  int
+	KafkaMaxPollInterval string
+
+	// KafkaAckMode is when the EventConsumer commits offsets: "batch"
+	// (after each poll, the default), "record" (after each record) or
+	// "manual" (when the listener calls Acknowledgment.acknowledge()).
+	KafkaAckMode string
+
+	// SQSFifo makes the SQS queues FIFO queues with content-based
+	// deduplication; SQSDeadLetter gives each queue a dead-letter queue
+	// that receives a message after SQSMaxReceiveCount failed receives
+	// (0 keeps the default of 5).
+	SQSFifo            bool
+	SQSDeadLetter      bool
+	SQSMaxReceiveCount int
+
+	// PubSubOrdering publishes with an ordering key and creates the
+	// subscriptions with message ordering; PubSubExactlyOnce creates them
+	// with exactly-once delivery and makes the listener wait for its acks.
+	PubSubOrdering    bool
+	PubSubExactlyOnce bool
+
+	// Perf adds a k6 load test of the Placeholder endpoints under perf/, a
+	// script that runs it against the docker-compose stack and a manually
+	// triggered CI workflow that uploads the results.
+	Perf bool
+
+	// DevContainer adds .devcontainer/ (devcontainer.json and a Dockerfile
+	// with the project's JDK, Maven and Docker-in-Docker) so the project
+	// opens ready to build in GitHub Codespaces or a local dev container.
+	DevContainer bool
+
+	// CodeOwners adds a CODEOWNERS file mapping each module to a
+	// placeholder team, and a boundary doc per module under docs/modules/.
+	CodeOwners bool
+
+	// TaskRunner picks the root file of developer shortcuts: "make"
+	// (Makefile), "task" (Taskfile.yml) or "none"; empty generates nothing.
+	TaskRunner string
+
+	// Auditing adds created_by and deleted_at columns to the baseline
+	// schema, Spring Data JDBC auditing and soft deletes for the
+	// Placeholder resource.
+	Auditing bool
+
+	// ReadReplica adds a second, read-only datasource to SQLDatastore with
+	// read-only transactions routed to it, and a streaming replica of the
+	// PostgreSQL compose service.
+	ReadReplica bool
+
+	// Cache adds Spring Cache to PlaceholderService lookups: "caffeine"
+	// (in-process) or "redis" (shared across instances); empty or "none"
+	// adds nothing.
+	Cache string
+
+	// Secrets loads credentials from a secrets manager at startup: "vault"
+	// (Spring Cloud Vault), "aws" (AWS Secrets Manager) or "gcp" (GCP Secret
+	// Manager); empty or "none" keeps them in environment variables.
+	Secrets string
+
+	// JobStorage picks where the Worker's JobRunr keeps its jobs:
+	// "postgres", "mysql", "existing-sql" (the SQLDatastore datasource) or
+	// "in-memory"; empty picks from the selected datastores.
+	JobStorage string
+
+	// JobRunrDashboard turns the Worker's JobRunr dashboard on by default
+	// and publishes it from the docker-compose worker service, with basic
+	// auth credentials taken from the environment.
+	JobRunrDashboard bool
+
+	// JobRunrBatches tunes the Worker's background job server for bulk
+	// workloads: worker count, poll interval and how long finished jobs
+	// are kept, all overridable from the environment.
+	JobRunrBatches bool
+
+	// JobRunrCarbonArea enables JobRunr's carbon-aware job processing for
+	// an ENTSO-E or grid area code such as "BE", "DE" or "US-CA"; empty
+	// leaves it off.
+	JobRunrCarbonArea string
+
+	// StaticAnalysis adds compile-time analysis to the javac build:
+	// "errorprone" (Error Prone with NullAway); empty or "none" adds nothing.
+	StaticAnalysis string
+
+	// JPMS writes a module-info.java into each Maven module, derived from
+	// the module's imports: requires for what it uses, exports only for
+	// the packages other project modules use.
+	JPMS bool
+
+	// EnablePreview compiles, tests and runs the project with
+	// --enable-preview, unlocking the preview language and API features of
+	// its Java version.
+	EnablePreview bool
+
+	// NoCoverageGates drops the JaCoCo minimum-coverage check, keeping only
+	// the reports; meant for prototypes. Gates are on by default.
+	NoCoverageGates bool
+
+	// SpotlessRatchetFrom limits Spotless to files changed since this git
+	// ref, so legacy code brought in by a migration is not reformatted
+	// wholesale. Empty formats the whole project.
+	SpotlessRatchetFrom string
+
+	// License is the project license chosen with --license ("apache2",
+	// "mit", "proprietary"); empty means no LICENSE file.
+	License string
+
+	// LicenseHeader is prepended to every generated .java file and enforced
+	// by Spotless. Comes from --license or the user's config profile; empty
+	// means no header.
+	LicenseHeader string
+
+	// ImageRegistry prefixes the Docker image names used in the generated
+	// docs and Jib configuration (e.g. "ghcr.io/acme" →
+	// ghcr.io/acme/<project>-api); empty means local image names.
+	ImageRegistry string
+
+	// ImageBuilder is how the runtime modules become container images:
+	// "dockerfile" (a Dockerfile per module) or "jib" (jib-maven-plugin,
+	// no Dockerfile or Docker daemon needed); empty means dockerfile.
+	ImageBuilder string
+
+	// LogFormat is how the runtime modules log outside the local profile:
+	// "json" (Logstash JSON), "ecs" (Elastic Common Schema) or "plain"
+	// (one text line per event); empty means json.
+	LogFormat string
+
+	// Architecture is the build layout: "multi-module" (a Maven module per
+	// Trabuco module) or "modulith" (one Spring Boot application whose
+	// packages are the Trabuco modules, with boundaries verified by Spring
+	// Modulith); empty means multi-module.
+	Architecture string
+
 	// Deprecated: Use AIAgents instead
 	IncludeCLAUDEMD bool // Legacy field for backwards compatibility
 }
@@ -108,6 +309,19 @@ func (c *ProjectConfig) ProjectNameSnake() string {
 	return result
 }
 
+// NeedsTestcontainersSocketOverride returns true when the generated build must
+// set TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE. Podman machine and Colima run the
+// daemon inside a VM, so the socket Ryuk mounts is the in-VM
+// /var/run/docker.sock rather than the host socket in DOCKER_HOST.
+func (c *ProjectConfig) NeedsTestcontainersSocketOverride() bool {
+	return c.ContainerRuntime == "podman" || c.ContainerRuntime == "colima"
+}
+
+// UsesPodman returns true when the detected container runtime is Podman
+func (c *ProjectConfig) UsesPodman() bool {
+	return c.ContainerRuntime == "podman"
+}
+
 // HasModule checks if a specific module is included
 func (c *ProjectConfig) HasModule(name string) bool {
 	for _, m := range c.Modules {
@@ -133,6 +347,69 @@ func (c *ProjectConfig) HasAnyDatastore() bool {
 	return c.HasModule(ModuleSQLDatastore) || c.HasModule(ModuleNoSQLDatastore)
 }
 
+// HasSharedTestDatabase returns true when SQLDatastore's test-jar carries
+// the singleton Testcontainers database that the integration tests of the
+// other modules extend.
+func (c *ProjectConfig) HasSharedTestDatabase() bool {
+	return c.HasModule(ModuleSQLDatastore) && (c.Database == DatabasePostgreSQL || c.IsMySQLCompatible())
+}
+
+// IsMySQLCompatible returns true for MySQL and MariaDB, which share the SQL
+// dialect, port and Flyway module but not the JDBC driver or image.
+func (c *ProjectConfig) IsMySQLCompatible() bool {
+	return c.Database == DatabaseMySQL || c.Database == DatabaseMariaDB
+}
+
+// SQLDatabaseName returns the display name of the SQL database
+func (c *ProjectConfig) SQLDatabaseName() string {
+	return SQLDatabaseDisplayName(c.Database)
+}
+
+// DatabaseServiceName returns the docker-compose service (and CI service
+// container) of the SQL database: "postgres", "mysql" or "mariadb".
+func (c *ProjectConfig) DatabaseServiceName() string {
+	if c.Database == DatabasePostgreSQL {
+		return "postgres"
+	}
+	return c.Database
+}
+
+// DatabaseServerCommand returns the server options of the MySQL or MariaDB
+// container. Both are pinned to utf8mb4, whatever the image's own default,
+// so 4-byte characters such as emoji survive a round trip.
+func (c *ProjectConfig) DatabaseServerCommand() string {
+	return SQLDatabaseServerCommand(c.Database)
+}
+
+// SQLDatabaseServerCommand returns the container command options of a SQL
+// database, empty when it needs none
+func SQLDatabaseServerCommand(db string) string {
+	switch db {
+	case DatabaseMySQL:
+		return "--character-set-server=utf8mb4 --collation-server=utf8mb4_0900_ai_ci"
+	case DatabaseMariaDB:
+		return "--character-set-server=utf8mb4 --collation-server=utf8mb4_unicode_ci"
+	}
+	return ""
+}
+
+// DatabaseImage returns the image of the SQL database. A pinned
+// DatabaseVersion replaces the version part of the default tag and keeps
+// its variant, so "16" gives postgres:16-alpine; a version that names a
+// variant itself ("16-bookworm") is used as the whole tag. Empty for the
+// generic database, which has no image.
+func (c *ProjectConfig) DatabaseImage() string {
+	image, err := versions.LookupImage(c.DatabaseServiceName())
+	if err != nil || c.DatabaseVersion == "" {
+		return image
+	}
+	repository, tag, _ := strings.Cut(image, ":")
+	if _, variant, ok := strings.Cut(tag, "-"); ok && !strings.Contains(c.DatabaseVersion, "-") {
+		return repository + ":" + c.DatabaseVersion + "-" + variant
+	}
+	return repository + ":" + c.DatabaseVersion
+}
+
 // HasBothDatastores checks if both datastore modules are included
 func (c *ProjectConfig) HasBothDatastores() bool {
 	return c.HasModule(ModuleSQLDatastore) && c.HasModule(ModuleNoSQLDatastore)
@@ -148,10 +425,21 @@ func (c *ProjectConfig) HasAIAgentModule() bool {
 // The storage is separate from the main application datastore to allow for
 // independent scaling and configuration in production.
 
+// Job storage constants (--job-storage)
+const (
+	JobStoragePostgres    = "postgres"
+	JobStorageMySQL       = "mysql"
+	JobStorageExistingSQL = "existing-sql"
+	JobStorageInMemory    = "in-memory"
+)
+
 // JobRunrStorageType returns the storage type for JobRunr:
+// - "memory" if --job-storage in-memory was chosen
+// - "sql" if --job-storage postgres, mysql or existing-sql was chosen
 // - "sql" if SQLDatastore is selected (PostgreSQL or MySQL)
 // - "mongodb" if NoSQLDatastore with MongoDB is selected
 // - "sql" (PostgreSQL fallback) if NoSQLDatastore with Redis is selected (Redis deprecated in JobRunr 8)
+// - "sql" (PostgreSQL fallback) if NoSQLDatastore with DynamoDB or Cassandra is selected (no storage provider)
 // - "sql" (PostgreSQL fallback) if no datastore is selected but Worker is
 // - "" if Worker is not selected (no storage needed)
 func (c *ProjectConfig) JobRunrStorageType() string {
@@ -160,6 +448,12 @@ func (c *ProjectConfig) JobRunrStorageType() string {
 		return ""
 	}
 
+	switch c.JobStorage {
+	case JobStorageInMemory:
+		return "memory"
+	case JobStoragePostgres, JobStorageMySQL, JobStorageExistingSQL:
+		return "sql"
+	}
 	if c.HasModule(ModuleSQLDatastore) {
 		return "sql"
 	}
@@ -167,7 +461,8 @@ func (c *ProjectConfig) JobRunrStorageType() string {
 		if c.NoSQLDatabase == DatabaseMongoDB {
 			return DatabaseMongoDB
 		}
-		// Redis is deprecated in JobRunr 8, fallback to PostgreSQL
+		// Redis is deprecated in JobRunr 8 and DynamoDB/Cassandra have no
+		// storage provider, fallback to PostgreSQL
 		return "sql"
 	}
 	// No datastore selected but Worker module is, fallback to PostgreSQL
@@ -184,25 +479,43 @@ func (c *ProjectConfig) JobRunrUsesMongoDB() bool {
 	return c.JobRunrStorageType() == DatabaseMongoDB
 }
 
+// JobRunrUsesInMemory returns true if JobRunr keeps its jobs in the memory
+// of each process (--job-storage in-memory)
+func (c *ProjectConfig) JobRunrUsesInMemory() bool {
+	return c.JobRunrStorageType() == "memory"
+}
+
 // JobRunrSqlDatabase returns the SQL database type for JobRunr storage:
 // - If SQLDatastore is selected, uses the same database type
+// - With --job-storage postgres or mysql and no SQLDatastore, that engine
 // - Otherwise, defaults to "postgresql" (for Redis fallback or no datastore)
 func (c *ProjectConfig) JobRunrSqlDatabase() string {
 	if c.HasModule(ModuleSQLDatastore) {
 		return c.Database
 	}
+	if c.JobStorage == JobStorageMySQL {
+		return DatabaseMySQL
+	}
 	return DatabasePostgreSQL
 }
 
+// JobRunrNeedsOwnDatabase returns true if JobRunr stores its jobs in a
+// database container of its own, because there is no SQLDatastore
+// datasource to share
+func (c *ProjectConfig) JobRunrNeedsOwnDatabase() bool {
+	return c.JobRunrUsesSql() && !c.HasModule(ModuleSQLDatastore)
+}
+
 // WorkerUsesPostgresFallback returns true if Worker is using PostgreSQL
-// as a fallback because the user selected Redis (which is deprecated in JobRunr 8)
-// or no datastore at all
+// as a fallback because the user selected a NoSQL database JobRunr can't
+// store jobs in (Redis is deprecated in JobRunr 8, DynamoDB and Cassandra
+// are unsupported) or no datastore at all, and picked no --job-storage
 func (c *ProjectConfig) WorkerUsesPostgresFallback() bool {
-	if !c.HasModule(ModuleWorker) {
+	if !c.HasModule(ModuleWorker) || c.JobStorage != "" {
 		return false
 	}
-	// Redis fallback - Redis is deprecated in JobRunr 8+
-	if c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseRedis {
+	// NoSQL fallback - only MongoDB is a JobRunr storage provider
+	if c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase != DatabaseMongoDB {
 		return true
 	}
 	// No datastore selected - Worker uses PostgreSQL fallback for JobRunr storage
@@ -215,38 +528,955 @@ func (c *ProjectConfig) WorkerUsesPostgresFallback() bool {
 // WorkerNeedsOwnPostgres returns true if Worker needs its own PostgreSQL
 // instance because no SQL datastore is available for JobRunr storage.
 // This happens when:
-// - NoSQLDatastore with Redis is selected (Redis deprecated in JobRunr 8+)
+// - NoSQLDatastore with Redis (deprecated in JobRunr 8+) is selected
+// - NoSQLDatastore with DynamoDB or Cassandra (no storage provider) is selected
 // - No datastore is selected at all
+// - --job-storage postgres is chosen without SQLDatastore
 func (c *ProjectConfig) WorkerNeedsOwnPostgres() bool {
+	return c.JobRunrNeedsOwnDatabase() && c.JobRunrSqlDatabase() == DatabasePostgreSQL
+}
+
+// WorkerNeedsOwnMySQL returns true if Worker needs its own MySQL instance:
+// --job-storage mysql without SQLDatastore
+func (c *ProjectConfig) WorkerNeedsOwnMySQL() bool {
+	return c.JobRunrNeedsOwnDatabase() && c.JobRunrSqlDatabase() == DatabaseMySQL
+}
+
+// JobRunrDatabaseName returns the name of the database JobRunr stores its
+// jobs in when it has its own container (MySQL names are snake_case)
+func (c *ProjectConfig) JobRunrDatabaseName() string {
+	if c.WorkerNeedsOwnMySQL() {
+		return c.ProjectNameSnake() + "_jobs"
+	}
+	return c.ProjectName + "_jobs"
+}
+
+// JobRunrServiceName returns the docker-compose service of JobRunr's own
+// database container, or "" when it has none
+func (c *ProjectConfig) JobRunrServiceName() string {
+	switch {
+	case c.WorkerNeedsOwnPostgres():
+		return "postgres-jobrunr"
+	case c.WorkerNeedsOwnMySQL():
+		return "mysql-jobrunr"
+	}
+	return ""
+}
+
+// JobRunrJdbcURL returns the local JDBC URL of JobRunr's own database
+// container, on the host port docker-compose.yml publishes it on
+func (c *ProjectConfig) JobRunrJdbcURL() string {
+	if c.WorkerNeedsOwnMySQL() {
+		return "jdbc:mysql://localhost:3308/" + c.JobRunrDatabaseName() + "?useSSL=false&allowPublicKeyRetrieval=true"
+	}
+	return "jdbc:postgresql://localhost:5434/" + c.JobRunrDatabaseName()
+}
+
+// ValidateJobStorageFlag returns "" when the value is a supported job
+// storage (or empty) and an error message otherwise. Use ResolveJobStorage
+// for the cross-flag rules.
+func ValidateJobStorageFlag(storage string) string {
+	switch storage {
+	case "", JobStoragePostgres, JobStorageMySQL, JobStorageExistingSQL, JobStorageInMemory:
+		return ""
+	}
+	return "Invalid --job-storage value '" + storage + "'. Valid options: postgres, mysql, existing-sql, in-memory"
+}
+
+// ResolveJobStorage enforces the cross-flag rules for --job-storage.
+// JobRunr uses the application's single datasource, so with SQLDatastore
+// the storage has to be that database. Returns "" on success or a
+// human-readable error message.
+func (c *ProjectConfig) ResolveJobStorage() string {
+	if c.JobStorage == "" {
+		return ""
+	}
 	if !c.HasModule(ModuleWorker) {
-		return false
+		return "--job-storage=" + c.JobStorage + " picks where JobRunr keeps its jobs; it needs the Worker module."
 	}
-	// If NoSQLDatastore with Redis is selected, Worker needs PostgreSQL for JobRunr
-	if c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseRedis {
-		return true
+	sql := c.HasModule(ModuleSQLDatastore)
+	switch c.JobStorage {
+	case JobStorageExistingSQL:
+		if !sql {
+			return "--job-storage=existing-sql stores jobs in the SQLDatastore database; it needs the SQLDatastore module. Use postgres or mysql for a database of its own."
+		}
+	case JobStoragePostgres:
+		if sql && c.Database != DatabasePostgreSQL {
+			return "--job-storage=postgres conflicts with --database " + c.Database + ": JobRunr shares the Worker's datasource with SQLDatastore. Use --job-storage existing-sql."
+		}
+	case JobStorageMySQL:
+		if sql && !c.IsMySQLCompatible() {
+			return "--job-storage=mysql conflicts with --database " + c.Database + ": JobRunr shares the Worker's datasource with SQLDatastore. Use --job-storage existing-sql."
+		}
 	}
-	// If no datastore is selected, Worker needs PostgreSQL for JobRunr
-	if !c.HasModule(ModuleSQLDatastore) && !c.HasModule(ModuleNoSQLDatastore) {
-		return true
+	return ""
+}
+
+// JobStorageWarnings returns advisory notes on the chosen job storage that
+// do not stop generation
+func (c *ProjectConfig) JobStorageWarnings() []string {
+	if !c.JobRunrUsesInMemory() {
+		return nil
 	}
-	return false
+	warnings := []string{"--job-storage=in-memory keeps jobs in the Worker's memory: they are lost on restart and several Worker instances do not share them. Use it for local development and demos."}
+	if c.HasModule(ModuleAPI) && !c.IsModulith() {
+		warnings = append(warnings, "With --job-storage=in-memory the API and the Worker each have their own job store: jobs the API enqueues are never run by the Worker. Enqueue from the Worker, or use a SQL storage.")
+	}
+	return warnings
+}
+
+// HasJobRunrDashboard returns true if the Worker's JobRunr dashboard is on
+// by default and published from docker-compose
+func (c *ProjectConfig) HasJobRunrDashboard() bool {
+	return c.JobRunrDashboard && c.HasModule(ModuleWorker)
+}
+
+// HasJobRunrBatches returns true if the Worker is tuned for bulk workloads
+func (c *ProjectConfig) HasJobRunrBatches() bool {
+	return c.JobRunrBatches && c.HasModule(ModuleWorker)
+}
+
+// HasJobRunrCarbonAware returns true if JobRunr moves jobs scheduled with
+// a margin to the hours with the least carbon-intensive energy
+func (c *ProjectConfig) HasJobRunrCarbonAware() bool {
+	return c.JobRunrCarbonArea != "" && c.HasModule(ModuleWorker)
+}
+
+var carbonAreaPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,6})?$`)
+
+// ValidateJobRunrCarbonAreaFlag returns "" when the value is empty or
+// looks like an area code ("BE", "DE", "US-CA"), and an error message
+// otherwise
+func ValidateJobRunrCarbonAreaFlag(area string) string {
+	if area == "" || carbonAreaPattern.MatchString(area) {
+		return ""
+	}
+	return "Invalid --jobrunr-carbon-area value '" + area + "'. Use an upper-case area code, e.g. BE, DE or US-CA"
+}
+
+// ResolveJobRunrFeatures enforces the cross-flag rules for the JobRunr
+// dashboard, batch tuning and carbon-aware processing: all of them
+// configure the Worker. Returns "" on success or a human-readable error
+// message.
+func (c *ProjectConfig) ResolveJobRunrFeatures() string {
+	if c.HasModule(ModuleWorker) {
+		return ""
+	}
+	switch {
+	case c.JobRunrDashboard:
+		return "--with-jobrunr-dashboard exposes the Worker's JobRunr dashboard; it needs the Worker module."
+	case c.JobRunrBatches:
+		return "--with-jobrunr-batches tunes the Worker's background job server; it needs the Worker module."
+	case c.JobRunrCarbonArea != "":
+		return "--jobrunr-carbon-area=" + c.JobRunrCarbonArea + " configures the Worker's JobRunr; it needs the Worker module."
+	}
+	return ""
 }
 
 // NeedsDockerCompose returns true if docker-compose.yml should be generated.
 // This is the case when a runtime module (API or Worker) needs a datastore,
-// when Worker needs its own PostgreSQL for JobRunr storage,
-// or when EventConsumer needs a message broker.
+// when Worker needs its own PostgreSQL or MySQL for JobRunr storage,
+// when EventConsumer needs a message broker, or when the observability
+// stack is enabled.
 func (c *ProjectConfig) NeedsDockerCompose() bool {
 	hasDatastore := (c.HasModule(ModuleSQLDatastore) && c.Database != "") ||
 		(c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase != "")
 	hasRuntime := c.HasModule(ModuleAPI) || c.HasModule(ModuleWorker)
-	return (hasRuntime && hasDatastore) || c.WorkerNeedsOwnPostgres() || c.EventConsumerNeedsDockerCompose()
+	return (hasRuntime && (hasDatastore || c.HasModule(ModuleSearch))) || c.JobRunrNeedsOwnDatabase() || c.MessageBrokerNeedsDockerCompose() || c.HasObservability() || c.SecretsUsesVault()
+}
+
+// ImageName returns the Docker image name for a runtime module, e.g.
+// "orders-api", or "ghcr.io/acme/orders-api" with an image registry.
+func (c *ProjectConfig) ImageName(module string) string {
+	return c.ImagePrefix() + c.ProjectName + "-" + strings.ToLower(module)
+}
+
+// ImagePrefix returns the image registry with a trailing slash, e.g.
+// "ghcr.io/acme/", or "" for local image names.
+func (c *ProjectConfig) ImagePrefix() string {
+	if registry := strings.TrimSuffix(c.ImageRegistry, "/"); registry != "" {
+		return registry + "/"
+	}
+	return ""
+}
+
+// Image builder constants
+const (
+	ImageBuilderDockerfile = "dockerfile"
+	ImageBuilderJib        = "jib"
+)
+
+// UsesJib returns true if the runtime modules are containerized with
+// jib-maven-plugin instead of Dockerfiles
+func (c *ProjectConfig) UsesJib() bool {
+	return c.ImageBuilder == ImageBuilderJib
+}
+
+// ValidateImageBuilderFlag validates the --image-builder value. Returns ""
+// if valid or a human-readable error message.
+func ValidateImageBuilderFlag(builder string) string {
+	switch builder {
+	case "", ImageBuilderDockerfile, ImageBuilderJib:
+		return ""
+	}
+	return "Invalid --image-builder value '" + builder + "'. Valid options: dockerfile, jib"
+}
+
+// Log format constants
+const (
+	LogFormatJSON  = "json"
+	LogFormatECS   = "ecs"
+	LogFormatPlain = "plain"
+)
+
+// UsesLogstashEncoder returns true if the runtime modules log with
+// logstash-logback-encoder, the default
+func (c *ProjectConfig) UsesLogstashEncoder() bool {
+	return c.LogFormat == "" || c.LogFormat == LogFormatJSON
+}
+
+// UsesECSLogs returns true if the runtime modules log Elastic Common Schema
+// JSON through Spring Boot's structured logging
+func (c *ProjectConfig) UsesECSLogs() bool {
+	return c.LogFormat == LogFormatECS
+}
+
+// UsesPlainLogs returns true if the runtime modules log plain text lines
+func (c *ProjectConfig) UsesPlainLogs() bool {
+	return c.LogFormat == LogFormatPlain
+}
+
+// LogFormatOrDefault returns the log format, json when unset
+func (c *ProjectConfig) LogFormatOrDefault() string {
+	if c.LogFormat == "" {
+		return LogFormatJSON
+	}
+	return c.LogFormat
+}
+
+// LogFormatName returns a display name for the log format
+func (c *ProjectConfig) LogFormatName() string {
+	switch {
+	case c.UsesECSLogs():
+		return "ECS JSON"
+	case c.UsesPlainLogs():
+		return "plain text"
+	}
+	return "Logstash JSON"
+}
+
+// ValidateLogFormatFlag validates the --log-format value. Returns "" if
+// valid or a human-readable error message.
+func ValidateLogFormatFlag(format string) string {
+	switch format {
+	case "", LogFormatJSON, LogFormatECS, LogFormatPlain:
+		return ""
+	}
+	return "Invalid --log-format value '" + format + "'. Valid options: json, ecs, plain"
+}
+
+// Architecture constants
+const (
+	ArchitectureMultiModule = "multi-module"
+	ArchitectureModulith    = "modulith"
+)
+
+// ModulithModule is the single Maven module of a modulith project
+const ModulithModule = "App"
+
+// IsModulith returns true if the project is one Spring Modulith application
+// instead of a Maven module per Trabuco module
+func (c *ProjectConfig) IsModulith() bool {
+	return c.Architecture == ArchitectureModulith
+}
+
+// MavenModules returns the Maven modules of the parent POM
+func (c *ProjectConfig) MavenModules() []string {
+	if c.IsModulith() {
+		return []string{ModulithModule}
+	}
+	return c.Modules
+}
+
+// ModuleDir returns the directory holding a Trabuco module's sources: the
+// module's own, or App in a modulith
+func (c *ProjectConfig) ModuleDir(module string) string {
+	if c.IsModulith() {
+		return ModulithModule
+	}
+	return module
+}
+
+// modulithModules are the Trabuco modules a modulith can fold into App.
+// AIAgent and ClientSDK are deployed or published on their own.
+var modulithModules = []string{
+	ModuleModel, ModuleJobs, ModuleSQLDatastore, ModuleNoSQLDatastore, ModuleSearch,
+	ModuleShared, ModuleAPI, ModuleWorker, ModuleEvents, ModuleEventConsumer,
+}
+
+// ResolveArchitecture enforces the cross-flag rules for --architecture
+// modulith: API hosts the application, and the Java sources of every module
+// must fit in one Maven module. Returns "" on success or a human-readable
+// error message.
+func (c *ProjectConfig) ResolveArchitecture() string {
+	if !c.IsModulith() {
+		return ""
+	}
+	for _, module := range c.Modules {
+		supported := false
+		for _, m := range modulithModules {
+			supported = supported || m == module
+		}
+		if !supported {
+			return "--architecture modulith does not support the " + module + " module. Valid modules: " + strings.Join(modulithModules, ", ")
+		}
+	}
+	switch {
+	case !c.HasModule(ModuleAPI):
+		return "--architecture modulith needs the API module, which hosts the application."
+	case c.IsKotlin():
+		return "--architecture modulith is only available for Java projects."
+	case c.HasJPMS():
+		return "--architecture modulith cannot be combined with --jpms: the application is a single Maven module."
+	case c.HasNative():
+		return "--architecture modulith cannot be combined with --native."
+	}
+	return ""
+}
+
+// ValidateArchitectureFlag validates the --architecture value. Returns ""
+// if valid or a human-readable error message.
+func ValidateArchitectureFlag(architecture string) string {
+	switch architecture {
+	case "", ArchitectureMultiModule, ArchitectureModulith:
+		return ""
+	}
+	return "Invalid --architecture value '" + architecture + "'. Valid options: multi-module, modulith"
+}
+
+// HasObservability returns true if the observability stack is generated.
+// It only applies when there is a runtime module to instrument.
+func (c *ProjectConfig) HasObservability() bool {
+	return c.Observability && len(c.ObservabilityTargets()) > 0
+}
+
+// Supported application languages
+const (
+	LanguageJava   = "java"
+	LanguageKotlin = "kotlin"
+)
+
+// IsKotlin returns true if the application code is generated in Kotlin
+func (c *ProjectConfig) IsKotlin() bool {
+	return c.Language == LanguageKotlin
+}
+
+// ValidateLanguageFlag returns "" when the value is a supported language
+// (or empty, meaning Java) and an error message otherwise.
+func ValidateLanguageFlag(language string) string {
+	switch language {
+	case "", LanguageJava, LanguageKotlin:
+		return ""
+	}
+	return "Invalid --language value '" + language + "'. Valid options: java, kotlin"
+}
+
+// HasNative returns true if the runtime modules get GraalVM native image
+// support. It only applies when there is a runtime module to compile.
+func (c *ProjectConfig) HasNative() bool {
+	return c.Native && len(c.RuntimeTargets()) > 0
+}
+
+// HasPagination returns true if the paginated list scaffolding is
+// generated. It needs the API and Shared modules and a datastore with
+// query-by-example support: SQLDatastore or MongoDB.
+func (c *ProjectConfig) HasPagination() bool {
+	return c.Pagination && c.SupportsPagination()
+}
+
+// HasRateLimit returns true if the rate limiting interceptor is generated.
+// It needs the API module.
+func (c *ProjectConfig) HasRateLimit() bool {
+	return c.RateLimit && c.HasModule(ModuleAPI)
+}
+
+// HasIdempotency returns true if the Idempotency-Key filter is generated. It
+// needs the API module and a store for the keys: SQLDatastore or the
+// project's Redis server.
+func (c *ProjectConfig) HasIdempotency() bool {
+	return c.Idempotency && c.HasModule(ModuleAPI) &&
+		(c.HasModule(ModuleSQLDatastore) || c.NeedsRedisService())
+}
+
+// IdempotencyUsesRedis returns true if the idempotency keys are Redis keys
+// rather than rows of the SQL datastore, which is preferred when both exist.
+func (c *ProjectConfig) IdempotencyUsesRedis() bool {
+	return c.HasIdempotency() && !c.HasModule(ModuleSQLDatastore)
+}
+
+// HasKafkaTransactions returns true if the EventConsumer gets the
+// transactional (exactly-once) Kafka processor. It needs Kafka as the broker
+// and the EventConsumer module.
+func (c *ProjectConfig) HasKafkaTransactions() bool {
+	return c.KafkaTransactions && c.UsesKafka() && c.HasModule(ModuleEventConsumer)
+}
+
+// HasPerf returns true if the perf/ load test is generated. The Placeholder
+// endpoints it drives need the API module and a datastore to answer.
+func (c *ProjectConfig) HasPerf() bool {
+	return c.Perf && c.HasModule(ModuleAPI) && c.HasAnyDatastore()
+}
+
+// HasDevContainer returns true if .devcontainer/ is generated
+func (c *ProjectConfig) HasDevContainer() bool {
+	return c.DevContainer
+}
+
+// ForwardedPort is a port a dev container forwards to the host
+type ForwardedPort struct {
+	Port  int
+	Label string
+}
+
+// DevContainerPorts returns the ports the dev container forwards: every
+// runtime module, the JobRunr dashboard with Worker and Grafana with the
+// observability stack.
+func (c *ProjectConfig) DevContainerPorts() []ForwardedPort {
+	var ports []ForwardedPort
+	for _, t := range c.RuntimeTargets() {
+		ports = append(ports, ForwardedPort{Port: t.Port, Label: t.Module})
+	}
+	if c.HasModule(ModuleWorker) {
+		ports = append(ports, ForwardedPort{Port: 8000, Label: "JobRunr dashboard"})
+	}
+	if c.HasObservability() {
+		ports = append(ports, ForwardedPort{Port: 3000, Label: "Grafana"})
+	}
+	return ports
+}
+
+// EnvVar is one environment variable of a generated container.
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// ComposeAppService is a runtime module run as a container by the "app"
+// profile of docker-compose.yml.
+type ComposeAppService struct {
+	Name   string // service name, e.g. "api"
+	Module string
+	Port   int
+	// ExtraPorts are published next to Port, e.g. the JobRunr dashboard
+	ExtraPorts []int
+	// Env points the module at the infrastructure services by their
+	// in-network names instead of the host ports of application.yml
+	Env []EnvVar
+	// DependsOn lists the infrastructure services started before the module
+	DependsOn []string
+}
+
+// ComposeAppServices returns a docker-compose service for every runtime
+// module. Each one listens on the port it uses on the host and reports
+// healthy through its readiness probe.
+func (c *ProjectConfig) ComposeAppServices() []ComposeAppService {
+	var deps []string
+	var env []EnvVar
+	set := func(name, value string) { env = append(env, EnvVar{name, value}) }
+
+	if c.HasModule(ModuleSQLDatastore) {
+		switch c.Database {
+		case DatabasePostgreSQL:
+			deps = append(deps, "postgres")
+			set("DB_HOST", "postgres")
+			set("DB_PORT", "5432")
+			if c.HasReplicaService() {
+				deps = append(deps, "postgres-replica")
+				set("DB_READ_HOST", "postgres-replica")
+				set("DB_READ_PORT", "5432")
+			}
+		case DatabaseMySQL, DatabaseMariaDB:
+			deps = append(deps, c.DatabaseServiceName())
+			set("DB_HOST", c.DatabaseServiceName())
+			set("DB_PORT", "3306")
+		}
+	}
+	if c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseMongoDB {
+		deps = append(deps, "mongodb")
+		set("MONGODB_URI", "mongodb://mongodb:27017/"+c.ProjectName)
+		set("SPRING_DATA_MONGODB_URI", "mongodb://mongodb:27017/"+c.ProjectName)
+	}
+	if c.NeedsRedisService() {
+		deps = append(deps, "redis")
+		set("REDIS_HOST", "redis")
+		set("REDIS_PORT", "6379")
+	}
+	if c.UsesDynamoDB() {
+		deps = append(deps, "dynamodb")
+		set("DYNAMODB_ENDPOINT", "http://dynamodb:8000")
+	}
+	if c.UsesCassandra() {
+		deps = append(deps, "cassandra")
+		set("CASSANDRA_CONTACT_POINTS", "cassandra:9042")
+	}
+	if c.HasModule(ModuleSearch) {
+		deps = append(deps, "opensearch")
+		set("OPENSEARCH_URIS", "http://opensearch:9200")
+	}
+	if c.HasModule(ModuleEvents) {
+		switch {
+		case c.UsesKafka():
+			deps = append(deps, "kafka")
+			set("KAFKA_BOOTSTRAP_SERVERS", "kafka:29092")
+		case c.UsesRabbitMQ():
+			deps = append(deps, "rabbitmq")
+			set("RABBITMQ_HOST", "rabbitmq")
+			set("RABBITMQ_PORT", "5672")
+		case c.UsesSQS():
+			deps = append(deps, "localstack")
+			set("SQS_ENDPOINT", "http://localstack:4566")
+			if c.SecretsUsesAWS() {
+				set("SECRETSMANAGER_ENDPOINT", "http://localstack:4566")
+			}
+		case c.UsesPubSub():
+			deps = append(deps, "pubsub-emulator")
+			set("PUBSUB_EMULATOR_HOST", "pubsub-emulator:8085")
+		case c.UsesNATS():
+			deps = append(deps, "nats")
+			set("NATS_URL", "nats://nats:4222")
+		}
+	}
+	if c.SecretsUsesVault() {
+		deps = append(deps, "vault")
+		set("VAULT_URI", "http://vault:8200")
+	}
+	if c.HasObservability() {
+		set("OTEL_EXPORTER_OTLP_ENDPOINT", "http://tempo:4318")
+	}
+
+	// The JobRunr datasource of the Worker (and of the API, which enqueues)
+	// is not built from DB_HOST/DB_PORT
+	var jobsURL string
+	jobsService := c.JobRunrServiceName()
+	switch {
+	case c.WorkerNeedsOwnPostgres():
+		jobsURL = "jdbc:postgresql://postgres-jobrunr:5432/" + c.JobRunrDatabaseName()
+	case c.WorkerNeedsOwnMySQL():
+		jobsURL = "jdbc:mysql://mysql-jobrunr:3306/" + c.JobRunrDatabaseName() + "?useSSL=false&allowPublicKeyRetrieval=true"
+	case c.HasModule(ModuleWorker) && c.HasModule(ModuleSQLDatastore) && c.Database == DatabasePostgreSQL:
+		jobsURL = "jdbc:postgresql://postgres:5432/" + c.ProjectName
+	}
+
+	var services []ComposeAppService
+	for _, t := range c.RuntimeTargets() {
+		svc := ComposeAppService{
+			Name:      strings.ToLower(t.Module),
+			Module:    t.Module,
+			Port:      t.Port,
+			Env:       append([]EnvVar{{"SERVER_PORT", strconv.Itoa(t.Port)}}, env...),
+			DependsOn: deps,
+		}
+		ownsJobs := t.Module == ModuleAPI || t.Module == ModulithModule
+		if jobsURL != "" && (t.Module == ModuleWorker || (ownsJobs && jobsService != "")) {
+			svc.Env = append(svc.Env, EnvVar{"SPRING_DATASOURCE_URL", jobsURL})
+			if jobsService != "" {
+				svc.DependsOn = append(append([]string{}, deps...), jobsService)
+			}
+		}
+		if c.HasJobRunrDashboard() && t.Module == c.ModuleDir(ModuleWorker) {
+			svc.ExtraPorts = append(svc.ExtraPorts, 8000)
+			svc.Env = append(svc.Env,
+				EnvVar{"JOBRUNR_DASHBOARD_USERNAME", "${JOBRUNR_DASHBOARD_USERNAME:-admin}"},
+				// Compose interpolates every profile, so an unset password
+				// must not fail `up`; the Worker refuses to start without one
+				EnvVar{"JOBRUNR_DASHBOARD_PASSWORD", "${JOBRUNR_DASHBOARD_PASSWORD:-}"})
+		}
+		services = append(services, svc)
+	}
+	return services
+}
+
+// SupportsPagination returns true if the module selection can host the
+// paginated list scaffolding, whether or not it was requested.
+func (c *ProjectConfig) SupportsPagination() bool {
+	if !c.HasModule(ModuleAPI) || !c.HasModule(ModuleShared) {
+		return false
+	}
+	return c.HasModule(ModuleSQLDatastore) ||
+		(c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseMongoDB)
+}
+
+// HasAuditing returns true if the auditing columns and soft deletes are
+// generated. Spring Data JDBC auditing needs the SQLDatastore module.
+func (c *ProjectConfig) HasAuditing() bool {
+	return c.Auditing && c.HasModule(ModuleSQLDatastore)
+}
+
+// HasReadReplica returns true if SQLDatastore routes read-only
+// transactions to a replica datasource. The generic database is in-memory
+// and has no replica to route to.
+func (c *ProjectConfig) HasReadReplica() bool {
+	return c.ReadReplica && c.HasSharedTestDatabase()
+}
+
+// HasReplicaService returns true if docker-compose starts the replica. Only
+// PostgreSQL gets one, streaming from the primary; the MySQL and MariaDB
+// read datasource points at the primary until DB_READ_HOST names a replica.
+func (c *ProjectConfig) HasReplicaService() bool {
+	return c.HasReadReplica() && c.Database == DatabasePostgreSQL
+}
+
+// ReadReplicaJdbcURL returns the local default JDBC URL of the read
+// datasource, overridable per part with the DB_READ_* variables.
+func (c *ProjectConfig) ReadReplicaJdbcURL() string {
+	host := "${DB_READ_HOST:${DB_HOST:localhost}}"
+	switch c.Database {
+	case DatabaseMySQL:
+		return "jdbc:mysql://" + host + ":${DB_READ_PORT:${DB_PORT:3307}}/${DB_NAME:" + c.ProjectNameSnake() + "}?useSSL=${DB_USE_SSL:false}&requireSSL=${DB_REQUIRE_SSL:false}&verifyServerCertificate=${DB_VERIFY_CERT:false}&allowPublicKeyRetrieval=${DB_ALLOW_PUBLIC_KEY_RETRIEVAL:true}"
+	case DatabaseMariaDB:
+		return "jdbc:mariadb://" + host + ":${DB_READ_PORT:${DB_PORT:3307}}/${DB_NAME:" + c.ProjectNameSnake() + "}?sslMode=${DB_SSL_MODE:disable}"
+	}
+	return "jdbc:postgresql://" + host + ":${DB_READ_PORT:5435}/${DB_NAME:" + c.ProjectName + "}?sslmode=${DB_SSL_MODE:disable}"
+}
+
+// Task runner constants
+const (
+	TaskRunnerMake = "make"
+	TaskRunnerTask = "task"
+	TaskRunnerNone = "none"
+)
+
+// HasMakefile returns true if a root Makefile is generated
+func (c *ProjectConfig) HasMakefile() bool {
+	return c.TaskRunner == TaskRunnerMake
+}
+
+// HasTaskfile returns true if a root Taskfile.yml is generated
+func (c *ProjectConfig) HasTaskfile() bool {
+	return c.TaskRunner == TaskRunnerTask
+}
+
+// ValidateTaskRunnerFlag validates the --task-runner value. Returns "" if
+// valid or a human-readable error message.
+func ValidateTaskRunnerFlag(runner string) string {
+	switch runner {
+	case "", TaskRunnerMake, TaskRunnerTask, TaskRunnerNone:
+		return ""
+	}
+	return "Invalid --task-runner value '" + runner + "'. Valid options: make, task, none"
+}
+
+// Cache provider constants
+const (
+	CacheNone     = "none"
+	CacheCaffeine = "caffeine"
+	CacheRedis    = "redis"
+)
+
+// HasCache returns true if PlaceholderService caches its lookups. The
+// cached service lives in Shared and needs a datastore to read from.
+func (c *ProjectConfig) HasCache() bool {
+	return (c.Cache == CacheCaffeine || c.Cache == CacheRedis) &&
+		c.HasModule(ModuleShared) && c.HasAnyDatastore()
+}
+
+// CacheUsesCaffeine returns true if the cache is an in-process Caffeine cache
+func (c *ProjectConfig) CacheUsesCaffeine() bool {
+	return c.HasCache() && c.Cache == CacheCaffeine
+}
+
+// CacheUsesRedis returns true if the cache lives in Redis
+func (c *ProjectConfig) CacheUsesRedis() bool {
+	return c.HasCache() && c.Cache == CacheRedis
+}
+
+// CacheProviderName returns the display name of the cache provider
+func (c *ProjectConfig) CacheProviderName() string {
+	switch c.Cache {
+	case CacheCaffeine:
+		return "Caffeine"
+	case CacheRedis:
+		return "Redis"
+	}
+	return ""
+}
+
+// ValidateCacheFlag returns "" when the value is a supported cache provider
+// (or empty) and an error message otherwise. Use ResolveCache for the
+// cross-flag rules.
+func ValidateCacheFlag(cache string) string {
+	switch cache {
+	case "", CacheNone, CacheCaffeine, CacheRedis:
+		return ""
+	}
+	return "Invalid --with-cache value '" + cache + "'. Valid options: caffeine, redis, none"
+}
+
+// ValidateDatabaseVersionFlag returns "" when the value is empty or looks
+// like an image tag version ("16", "8.4", "11.4.2", "16-bookworm"), and an
+// error message otherwise.
+func ValidateDatabaseVersionFlag(version string) string {
+	if version == "" || databaseVersionPattern.MatchString(version) {
+		return ""
+	}
+	return "Invalid --db-version value '" + version + "'. Use the image version, e.g. 16 for PostgreSQL, 8.4 for MySQL or 11.4 for MariaDB"
+}
+
+var databaseVersionPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*(-[a-z0-9.]+)?$`)
+
+// ResolveDatabaseVersion enforces the cross-flag rules for --db-version: it
+// pins the image of a containerized SQL database, so it needs SQLDatastore
+// with PostgreSQL, MySQL or MariaDB. Returns "" on success or a
+// human-readable error message.
+func (c *ProjectConfig) ResolveDatabaseVersion() string {
+	if c.DatabaseVersion != "" && !c.HasSharedTestDatabase() {
+		return "--db-version=" + c.DatabaseVersion + " pins the database image; it needs the SQLDatastore module with --database postgresql, mysql or mariadb."
+	}
+	return ""
+}
+
+// ResolveCache enforces the cross-flag rules for the cache and normalizes
+// "none" to empty. The cache wraps PlaceholderService, so it needs Shared
+// and a datastore. Returns "" on success or a human-readable error message.
+func (c *ProjectConfig) ResolveCache() string {
+	if c.Cache == CacheNone {
+		c.Cache = ""
+	}
+	if c.Cache != "" && !c.HasCache() {
+		return "--with-cache=" + c.Cache + " caches PlaceholderService lookups; it needs the Shared module and SQLDatastore or NoSQLDatastore."
+	}
+	return ""
+}
+
+// Secrets manager constants
+const (
+	SecretsNone  = "none"
+	SecretsAuto  = "auto"
+	SecretsVault = "vault"
+	SecretsAWS   = "aws"
+	SecretsGCP   = "gcp"
+)
+
+// Supported static analysis options
+const (
+	StaticAnalysisNone       = "none"
+	StaticAnalysisErrorProne = "errorprone"
+)
+
+// HasErrorProne returns true if javac runs Error Prone and NullAway
+func (c *ProjectConfig) HasErrorProne() bool {
+	return c.StaticAnalysis == StaticAnalysisErrorProne
+}
+
+// ValidateStaticAnalysisFlag returns "" when the value is a supported
+// static analysis option (or empty) and an error message otherwise. Use
+// ResolveStaticAnalysis for the cross-flag rules.
+func ValidateStaticAnalysisFlag(analysis string) string {
+	switch analysis {
+	case "", StaticAnalysisNone, StaticAnalysisErrorProne:
+		return ""
+	}
+	return "Invalid --static-analysis value '" + analysis + "'. Valid options: errorprone, none"
+}
+
+// ResolveStaticAnalysis enforces the cross-flag rules for static analysis
+// and normalizes "none" to empty. Error Prone is a javac plugin, so it
+// cannot check Kotlin sources. Returns "" on success or a human-readable
+// error message.
+func (c *ProjectConfig) ResolveStaticAnalysis() string {
+	if c.StaticAnalysis == StaticAnalysisNone {
+		c.StaticAnalysis = ""
+	}
+	if c.HasErrorProne() && c.IsKotlin() {
+		return "--static-analysis=errorprone checks Java sources only; Kotlin projects rely on the compiler's built-in null safety."
+	}
+	return ""
+}
+
+// HasJPMS returns true if the Maven modules get module-info.java descriptors
+func (c *ProjectConfig) HasJPMS() bool {
+	return c.JPMS
+}
+
+// ResolveJPMS enforces the cross-flag rules for --jpms. The descriptors are
+// derived from Java imports, so Kotlin projects are rejected. Returns "" on
+// success or a human-readable error message.
+func (c *ProjectConfig) ResolveJPMS() string {
+	if c.JPMS && c.IsKotlin() {
+		return "--jpms generates module-info.java from Java sources; it is not available for Kotlin projects."
+	}
+	return ""
+}
+
+// javaPreviewFeatures lists the preview features --enable-preview unlocks
+// per Java version. A version without an entry has none worth enabling.
+var javaPreviewFeatures = map[string]string{
+	"21": "string templates, unnamed patterns and variables, unnamed classes and instance main methods, scoped values, structured concurrency",
+	"24": "scoped values, structured concurrency, primitive types in patterns, flexible constructor bodies, module import declarations, simple source files",
+}
+
+// HasPreview returns true if javac, the tests and the applications run
+// with --enable-preview
+func (c *ProjectConfig) HasPreview() bool {
+	return c.EnablePreview
+}
+
+// PreviewFeatures returns the preview features of the project's Java
+// version, empty when it has none
+func (c *ProjectConfig) PreviewFeatures() string {
+	return javaPreviewFeatures[c.JavaVersion]
+}
+
+// ResolvePreview enforces the cross-flag rules for --enable-preview.
+// Preview class files only run on the exact Java version they were
+// compiled for, so the version must have preview features; Kotlin and
+// GraalVM native images are rejected. Returns "" on success or a
+// human-readable error message.
+func (c *ProjectConfig) ResolvePreview() string {
+	if !c.EnablePreview {
+		return ""
+	}
+	switch {
+	case c.PreviewFeatures() == "":
+		return "--enable-preview is not available for Java " + c.JavaVersion + ": it has no preview features Trabuco supports."
+	case c.IsKotlin():
+		return "--enable-preview unlocks Java language features; it is not available for Kotlin projects."
+	case c.Native:
+		return "--enable-preview cannot be combined with --native."
+	}
+	return ""
+}
+
+// HasVirtualThreads returns true if the runtime modules run request
+// handling and message listeners on virtual threads, which needs Java 21
+func (c *ProjectConfig) HasVirtualThreads() bool {
+	version, err := strconv.Atoi(c.JavaVersion)
+	return err == nil && version >= 21
+}
+
+// HasCoverageGates returns true if the build fails when a module's test
+// coverage drops below its JaCoCo minimums
+func (c *ProjectConfig) HasCoverageGates() bool {
+	return !c.NoCoverageGates
+}
+
+// HasSecrets returns true if the runtime modules load their credentials
+// from a secrets manager. It only applies when there is a runtime module.
+func (c *ProjectConfig) HasSecrets() bool {
+	return c.Secrets != "" && c.Secrets != SecretsNone && len(c.RuntimeTargets()) > 0
+}
+
+// SecretsUsesVault returns true if the secrets come from HashiCorp Vault
+func (c *ProjectConfig) SecretsUsesVault() bool {
+	return c.HasSecrets() && c.Secrets == SecretsVault
+}
+
+// SecretsUsesAWS returns true if the secrets come from AWS Secrets Manager
+func (c *ProjectConfig) SecretsUsesAWS() bool {
+	return c.HasSecrets() && c.Secrets == SecretsAWS
+}
+
+// SecretsUsesGCP returns true if the secrets come from GCP Secret Manager
+func (c *ProjectConfig) SecretsUsesGCP() bool {
+	return c.HasSecrets() && c.Secrets == SecretsGCP
+}
+
+// SecretsProviderName returns the display name of the secrets manager
+func (c *ProjectConfig) SecretsProviderName() string {
+	switch c.Secrets {
+	case SecretsVault:
+		return "HashiCorp Vault"
+	case SecretsAWS:
+		return "AWS Secrets Manager"
+	case SecretsGCP:
+		return "GCP Secret Manager"
+	}
+	return ""
+}
+
+// ValidateSecretsFlag returns "" when the value is a supported secrets
+// manager (or empty) and an error message otherwise. Use ResolveSecrets
+// for the cross-flag rules.
+func ValidateSecretsFlag(secrets string) string {
+	switch secrets {
+	case "", SecretsNone, SecretsAuto, SecretsVault, SecretsAWS, SecretsGCP:
+		return ""
+	}
+	return "Invalid --secrets value '" + secrets + "'. Valid options: vault, aws, gcp, auto, none"
+}
+
+// ResolveSecrets enforces the cross-flag rules for the secrets manager and
+// adjusts the config in-place. "auto" follows the cloud the project already
+// uses: AWS Secrets Manager with SQS, GCP Secret Manager with Pub/Sub and
+// Vault otherwise. Returns "" on success or a human-readable error message.
+func (c *ProjectConfig) ResolveSecrets() string {
+	if c.Secrets == "" || c.Secrets == SecretsNone {
+		return ""
+	}
+	if len(c.RuntimeTargets()) == 0 {
+		return "--secrets=" + c.Secrets + " requires a runtime module (API, Worker, EventConsumer or AIAgent) to load the secrets."
+	}
+	if c.Secrets == SecretsAuto {
+		switch {
+		case c.UsesSQS():
+			c.Secrets = SecretsAWS
+		case c.UsesPubSub():
+			c.Secrets = SecretsGCP
+		default:
+			c.Secrets = SecretsVault
+		}
+	}
+	return ""
+}
+
+// RuntimeTarget is a runnable Spring Boot module and its default HTTP port
+type RuntimeTarget struct {
+	Module string
+	Job    string // Lowercase module name: Prometheus job, image and package suffix
+	Port   int
+}
+
+// ObservabilityTargets returns the runtime modules Prometheus scrapes during
+// local development (the app runs on the host, Prometheus in docker-compose)
+func (c *ProjectConfig) ObservabilityTargets() []RuntimeTarget {
+	return c.RuntimeTargets()
+}
+
+// RuntimeTargets returns what runs as a Spring Boot application with its
+// default HTTP port: the runtime modules, or the App of a modulith
+func (c *ProjectConfig) RuntimeTargets() []RuntimeTarget {
+	if c.IsModulith() {
+		return []RuntimeTarget{{Module: ModulithModule, Job: strings.ToLower(ModulithModule), Port: 8080}}
+	}
+	return c.ModuleRuntimeTargets()
+}
+
+// ModuleRuntimeTargets returns the runtime modules with their default HTTP
+// ports, before a modulith folds them into App. AIAgent defaults to 8080
+// like API; when both are present it is expected on 8086 (run it with
+// SERVER_PORT=8086).
+func (c *ProjectConfig) ModuleRuntimeTargets() []RuntimeTarget {
+	var targets []RuntimeTarget
+	add := func(module string, port int) {
+		if c.HasModule(module) {
+			targets = append(targets, RuntimeTarget{Module: module, Job: strings.ToLower(module), Port: port})
+		}
+	}
+	add(ModuleAPI, 8080)
+	add(ModuleWorker, 8081)
+	add(ModuleEventConsumer, 8083)
+	if c.HasModule(ModuleAPI) {
+		add(ModuleAIAgent, 8086)
+	} else {
+		add(ModuleAIAgent, 8080)
+	}
+	return targets
 }
 
 // ShowRedisWorkerWarning returns true if a warning should be shown about
 // Redis + Worker combination (Redis is deprecated in JobRunr 8+)
 func (c *ProjectConfig) ShowRedisWorkerWarning() bool {
-	return c.HasModule(ModuleWorker) && c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseRedis
+	return c.JobStorage == "" && c.HasModule(ModuleWorker) && c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseRedis
 }
 
 // Event Consumer Configuration Helpers
@@ -271,9 +1501,55 @@ func (c *ProjectConfig) UsesPubSub() bool {
 	return c.MessageBroker == BrokerPubSub
 }
 
-// EventConsumerNeedsDockerCompose returns true if EventConsumer needs docker-compose services
-func (c *ProjectConfig) EventConsumerNeedsDockerCompose() bool {
-	return c.HasModule(ModuleEventConsumer) && c.MessageBroker != ""
+// UsesRedisStreams returns true if Redis Streams is the selected message broker
+func (c *ProjectConfig) UsesRedisStreams() bool {
+	return c.MessageBroker == BrokerRedisStreams
+}
+
+// UsesNATS returns true if NATS JetStream is the selected message broker
+func (c *ProjectConfig) UsesNATS() bool {
+	return c.MessageBroker == BrokerNATS
+}
+
+// NeedsRedisService returns true if docker-compose runs Redis, as the
+// NoSQL datastore, the Redis Streams broker, the Redis cache or any mix of
+// them (they share one server)
+func (c *ProjectConfig) NeedsRedisService() bool {
+	return (c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseRedis) ||
+		(c.HasModule(ModuleEvents) && c.UsesRedisStreams()) ||
+		c.CacheUsesRedis()
+}
+
+// UsesDynamoDB returns true if NoSQLDatastore stores documents in DynamoDB
+func (c *ProjectConfig) UsesDynamoDB() bool {
+	return c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseDynamoDB
+}
+
+// UsesCassandra returns true if NoSQLDatastore stores documents in Cassandra
+func (c *ProjectConfig) UsesCassandra() bool {
+	return c.HasModule(ModuleNoSQLDatastore) && c.NoSQLDatabase == DatabaseCassandra
+}
+
+// NoSQLDatabaseName returns the display name of the selected NoSQL database
+func (c *ProjectConfig) NoSQLDatabaseName() string {
+	return NoSQLDatabaseDisplayName(c.NoSQLDatabase)
+}
+
+// UsesSpringCloudAWS returns true if a module uses a Spring Cloud AWS
+// starter (SQS, DynamoDB or Secrets Manager) and the parent POM imports its BOM
+func (c *ProjectConfig) UsesSpringCloudAWS() bool {
+	return c.UsesSQS() || c.UsesDynamoDB() || c.SecretsUsesAWS()
+}
+
+// MessageBrokerName returns the display name of the selected message broker
+func (c *ProjectConfig) MessageBrokerName() string {
+	return MessageBrokerDisplayName(c.MessageBroker)
+}
+
+// MessageBrokerNeedsDockerCompose returns true if the Events module (publisher
+// alone or with EventConsumer) needs a docker-compose broker service
+func (c *ProjectConfig) MessageBrokerNeedsDockerCompose() bool {
+	return c.HasModule(ModuleEvents) && c.MessageBroker != ""
 }
 
 // AI Agent Configuration Helpers
@@ -293,6 +1569,9 @@ func GetAvailableAIAgents() []AIAgentInfo {
 		{ID: "cursor", Name: "Cursor", FilePath: ".cursor/rules/project.mdc", Description: "AI-first code editor"},
 		{ID: "copilot", Name: "GitHub Copilot", FilePath: ".github/copilot-instructions.md", Description: "GitHub's AI pair programmer"},
 		{ID: "codex", Name: "Codex", FilePath: "AGENTS.md", Description: "OpenAI's software engineering agent"},
+		{ID: "gemini", Name: "Gemini CLI", FilePath: "GEMINI.md", Description: "Google's terminal AI agent"},
+		{ID: "aider", Name: "Aider", FilePath: "CONVENTIONS.md", Description: "AI pair programming in the terminal"},
+		{ID: "zed", Name: "Zed", FilePath: ".rules", Description: "Editor with a built-in AI agent"},
 	}
 }
 
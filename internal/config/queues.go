@@ -0,0 +1,154 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Defaults and limits of the SQS dead-letter redrive (--sqs-max-receive-count)
+const (
+	defaultSQSMaxReceiveCount = 5
+	maxSQSMaxReceiveCount     = 1000 // SQS rejects a larger maxReceiveCount
+)
+
+// localStackSQSArn is the ARN prefix of the queues LocalStack creates with
+// its default region and account
+const localStackSQSArn = "arn:aws:sqs:us-east-1:000000000000:"
+
+// UsesSQSFifo returns true if the SQS queues are FIFO queues with
+// content-based deduplication
+func (c *ProjectConfig) UsesSQSFifo() bool {
+	return c.SQSFifo && c.UsesSQS()
+}
+
+// HasSQSDeadLetterQueue returns true if every SQS queue gets a dead-letter
+// queue and a redrive policy
+func (c *ProjectConfig) HasSQSDeadLetterQueue() bool {
+	return c.SQSDeadLetter && c.UsesSQS()
+}
+
+// SQSReceiveCount returns the number of receives after which SQS moves a
+// message to the dead-letter queue
+func (c *ProjectConfig) SQSReceiveCount() int {
+	if c.SQSMaxReceiveCount > 0 {
+		return c.SQSMaxReceiveCount
+	}
+	return defaultSQSMaxReceiveCount
+}
+
+// SQSQueueName returns the SQS queue name for a base name such as
+// "placeholder-events": FIFO queue names must end in ".fifo"
+func (c *ProjectConfig) SQSQueueName(base string) string {
+	if c.UsesSQSFifo() {
+		return base + ".fifo"
+	}
+	return base
+}
+
+// SQSDeadLetterQueueName returns the name of the dead-letter queue of a
+// queue. A FIFO queue needs a FIFO dead-letter queue.
+func (c *ProjectConfig) SQSDeadLetterQueueName(base string) string {
+	return c.SQSQueueName(base + "-dlq")
+}
+
+// SQSCreateQueueCommands returns the AWS CLI commands that create the queue
+// named base, and its dead-letter queue first when there is one, in the
+// local LocalStack. cli is the command prefix, e.g. "awslocal" or
+// "aws --endpoint-url=http://localstack:4566". The queue's own command is
+// always the last one.
+func (c *ProjectConfig) SQSCreateQueueCommands(cli, base string) []string {
+	var cmds []string
+	attributes := map[string]string{}
+	if c.UsesSQSFifo() {
+		attributes["FifoQueue"] = "true"
+		attributes["ContentBasedDeduplication"] = "true"
+	}
+	if c.HasSQSDeadLetterQueue() {
+		dlq := c.SQSDeadLetterQueueName(base)
+		cmd := cli + " sqs create-queue --queue-name " + dlq
+		if c.UsesSQSFifo() {
+			cmd += ` --attributes '{"FifoQueue":"true"}'`
+		}
+		cmds = append(cmds, cmd)
+		redrive, _ := json.Marshal(map[string]string{
+			"deadLetterTargetArn": localStackSQSArn + dlq,
+			"maxReceiveCount":     strconv.Itoa(c.SQSReceiveCount()),
+		})
+		attributes["RedrivePolicy"] = string(redrive)
+	}
+	cmd := cli + " sqs create-queue --queue-name " + c.SQSQueueName(base)
+	if len(attributes) > 0 {
+		encoded, _ := json.Marshal(attributes)
+		cmd += " --attributes '" + string(encoded) + "'"
+	}
+	return append(cmds, cmd)
+}
+
+// UsesPubSubOrdering returns true if events are published with an ordering
+// key and the subscriptions deliver them in order
+func (c *ProjectConfig) UsesPubSubOrdering() bool {
+	return c.PubSubOrdering && c.UsesPubSub()
+}
+
+// UsesPubSubExactlyOnce returns true if the subscriptions have exactly-once
+// delivery enabled
+func (c *ProjectConfig) UsesPubSubExactlyOnce() bool {
+	return c.PubSubExactlyOnce && c.UsesPubSub()
+}
+
+// PubSubSubscriptionBody returns the JSON body of the emulator request that
+// creates the subscription of a topic
+func (c *ProjectConfig) PubSubSubscriptionBody(topic string) string {
+	body := `{"topic": "projects/local-project/topics/` + topic + `"`
+	if c.UsesPubSubOrdering() {
+		body += `, "enableMessageOrdering": true`
+	}
+	if c.UsesPubSubExactlyOnce() {
+		body += `, "enableExactlyOnceDelivery": true`
+	}
+	return body + "}"
+}
+
+// ValidateSQSMaxReceiveCountFlag returns "" when n is unset (0) or a
+// maxReceiveCount SQS accepts, and an error message otherwise
+func ValidateSQSMaxReceiveCountFlag(n int) string {
+	if n < 0 || n > maxSQSMaxReceiveCount {
+		return fmt.Sprintf("Invalid --sqs-max-receive-count value %d. Use a number from 1 to %d", n, maxSQSMaxReceiveCount)
+	}
+	return ""
+}
+
+// ResolveBrokerDelivery enforces the cross-flag rules for the SQS and
+// Pub/Sub delivery options: each one needs its broker, and the receive
+// count needs a dead-letter queue. Returns "" on success or a
+// human-readable error message.
+func (c *ProjectConfig) ResolveBrokerDelivery() string {
+	var sqs, pubsub []string
+	if c.SQSFifo {
+		sqs = append(sqs, "--sqs-fifo")
+	}
+	if c.SQSDeadLetter {
+		sqs = append(sqs, "--sqs-dlq")
+	}
+	if c.SQSMaxReceiveCount != 0 {
+		if !c.SQSDeadLetter {
+			return "--sqs-max-receive-count sets when SQS moves a message to the dead-letter queue; it needs --sqs-dlq."
+		}
+		sqs = append(sqs, "--sqs-max-receive-count")
+	}
+	if c.PubSubOrdering {
+		pubsub = append(pubsub, "--pubsub-ordering")
+	}
+	if c.PubSubExactlyOnce {
+		pubsub = append(pubsub, "--pubsub-exactly-once")
+	}
+	if len(sqs) > 0 && !c.UsesSQS() {
+		return strings.Join(sqs, ", ") + " configure the SQS queues; they need --message-broker sqs."
+	}
+	if len(pubsub) > 0 && !c.UsesPubSub() {
+		return strings.Join(pubsub, ", ") + " configure the Pub/Sub topics and subscriptions; they need --message-broker pubsub."
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSQSCreateQueueCommands(t *testing.T) {
+	plain := &ProjectConfig{MessageBroker: BrokerSQS}
+	if got := plain.SQSCreateQueueCommands("awslocal", "orders"); len(got) != 1 || got[0] != "awslocal sqs create-queue --queue-name orders" {
+		t.Errorf("standard queue commands = %q", got)
+	}
+
+	cfg := &ProjectConfig{MessageBroker: BrokerSQS, SQSFifo: true, SQSDeadLetter: true, SQSMaxReceiveCount: 3}
+	want := []string{
+		`awslocal sqs create-queue --queue-name orders-dlq.fifo --attributes '{"FifoQueue":"true"}'`,
+		`awslocal sqs create-queue --queue-name orders.fifo --attributes '{"ContentBasedDeduplication":"true","FifoQueue":"true","RedrivePolicy":"{\"deadLetterTargetArn\":\"arn:aws:sqs:us-east-1:000000000000:orders-dlq.fifo\",\"maxReceiveCount\":\"3\"}"}'`,
+	}
+	got := cfg.SQSCreateQueueCommands("awslocal", "orders")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("FIFO queue with DLQ commands:\ngot  %q\nwant %q", got, want)
+	}
+
+	// The options only apply to SQS
+	other := &ProjectConfig{MessageBroker: BrokerKafka, SQSFifo: true, SQSDeadLetter: true}
+	if other.UsesSQSFifo() || other.HasSQSDeadLetterQueue() || other.SQSQueueName("orders") != "orders" {
+		t.Error("SQS options should be off without the SQS broker")
+	}
+	if plain.SQSReceiveCount() != 5 {
+		t.Errorf("default receive count = %d, want 5", plain.SQSReceiveCount())
+	}
+}
+
+func TestPubSubSubscriptionBody(t *testing.T) {
+	cfg := &ProjectConfig{MessageBroker: BrokerPubSub}
+	if got := cfg.PubSubSubscriptionBody("orders"); got != `{"topic": "projects/local-project/topics/orders"}` {
+		t.Errorf("default body = %s", got)
+	}
+	cfg.PubSubOrdering, cfg.PubSubExactlyOnce = true, true
+	if got := cfg.PubSubSubscriptionBody("orders"); got != `{"topic": "projects/local-project/topics/orders", "enableMessageOrdering": true, "enableExactlyOnceDelivery": true}` {
+		t.Errorf("ordered exactly-once body = %s", got)
+	}
+}
+
+func TestResolveBrokerDelivery(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+		want string // substring of the error, "" for success
+	}{
+		{"none", ProjectConfig{MessageBroker: BrokerKafka}, ""},
+		{"sqs options", ProjectConfig{MessageBroker: BrokerSQS, SQSFifo: true, SQSDeadLetter: true, SQSMaxReceiveCount: 10}, ""},
+		{"pubsub options", ProjectConfig{MessageBroker: BrokerPubSub, PubSubOrdering: true, PubSubExactlyOnce: true}, ""},
+		{"fifo on kafka", ProjectConfig{MessageBroker: BrokerKafka, SQSFifo: true}, "--sqs-fifo configure the SQS queues"},
+		{"ordering on sqs", ProjectConfig{MessageBroker: BrokerSQS, PubSubOrdering: true}, "--message-broker pubsub"},
+		{"receive count without dlq", ProjectConfig{MessageBroker: BrokerSQS, SQSMaxReceiveCount: 3}, "needs --sqs-dlq"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cfg.ResolveBrokerDelivery()
+			if tt.want == "" && got != "" || tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("ResolveBrokerDelivery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for n, valid := range map[int]bool{0: true, 1: true, 1000: true, -1: false, 1001: false} {
+		if got := ValidateSQSMaxReceiveCountFlag(n); (got == "") != valid {
+			t.Errorf("ValidateSQSMaxReceiveCountFlag(%d) = %q", n, got)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "testing"
+
+func TestResolveSecrets(t *testing.T) {
+	tests := []struct {
+		broker string
+		want   string
+	}{
+		{BrokerSQS, SecretsAWS},
+		{BrokerPubSub, SecretsGCP},
+		{BrokerKafka, SecretsVault},
+	}
+	for _, tt := range tests {
+		cfg := &ProjectConfig{
+			Modules:       []string{"Model", "Events", "EventConsumer"},
+			MessageBroker: tt.broker,
+			Secrets:       SecretsAuto,
+		}
+		if msg := cfg.ResolveSecrets(); msg != "" || cfg.Secrets != tt.want {
+			t.Errorf("%s: expected %s, got %q (%s)", tt.broker, tt.want, cfg.Secrets, msg)
+		}
+	}
+
+	library := &ProjectConfig{Modules: []string{"Model", "Shared"}, Secrets: SecretsVault}
+	if library.ResolveSecrets() == "" {
+		t.Error("secrets without a runtime module should be rejected")
+	}
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectSpec is a declarative project definition read by `trabuco init
+// --spec`. The top-level keys are the common init settings; options takes
+// any other init flag by name, and scaffolds lists the code generated into
+// the project once it exists:
+//
+//	name: orders
+//	group_id: com.acme.orders
+//	modules: [SQLDatastore, API, Worker]
+//	database: postgresql
+//	ai_agents: [claude]
+//	ci: github
+//	options:
+//	  with-pagination: true
+//	  secrets: vault
+//	scaffolds:
+//	  entities:
+//	    - name: Order
+//	      fields:
+//	        - {name: customerId, type: uuid}
+//	        - {name: note, type: text, nullable: true}
+//	  endpoints:
+//	    - path: /orders
+//	      methods: [GET, POST]
+//	  jobs:
+//	    - name: SendDigest
+//	      schedule: "0 0 8 * * *"
+type ProjectSpec struct {
+	Name          string         `yaml:"name"`
+	GroupID       string         `yaml:"group_id,omitempty"` // May come from a profile's group_id_prefix
+	Modules       []string       `yaml:"modules"`
+	JavaVersion   string         `yaml:"java_version,omitempty"`
+	Language      string         `yaml:"language,omitempty"`
+	Database      string         `yaml:"database,omitempty"`
+	DBVersion     string         `yaml:"db_version,omitempty"`
+	NoSQLDatabase string         `yaml:"nosql_database,omitempty"`
+	MessageBroker string         `yaml:"message_broker,omitempty"`
+	VectorStore   string         `yaml:"vector_store,omitempty"`
+	AIAgents      []string       `yaml:"ai_agents,omitempty"`
+	CI            string         `yaml:"ci,omitempty"`
+	Review        string         `yaml:"review,omitempty"`
+	Options       map[string]any `yaml:"options,omitempty"` // Other init flags, e.g. with-read-replica: true
+	Scaffolds     SpecScaffolds  `yaml:"scaffolds,omitempty"`
+}
+
+// SpecScaffolds is the code a spec generates into the new project, with
+// the same generators as `trabuco add entity` and `trabuco generate
+// endpoint|job`
+type SpecScaffolds struct {
+	Entities  []SpecEntity   `yaml:"entities,omitempty"`
+	Endpoints []SpecEndpoint `yaml:"endpoints,omitempty"`
+	Jobs      []SpecJob      `yaml:"jobs,omitempty"`
+}
+
+// IsEmpty returns true if the spec scaffolds nothing
+func (s SpecScaffolds) IsEmpty() bool {
+	return len(s.Entities) == 0 && len(s.Endpoints) == 0 && len(s.Jobs) == 0
+}
+
+// SpecEntity is an entity with its repository (and migration, on SQL)
+type SpecEntity struct {
+	Name      string     `yaml:"name"`
+	Fields    SpecFields `yaml:"fields"`
+	Module    string     `yaml:"module,omitempty"`     // SQLDatastore or NoSQLDatastore; auto-detected when empty
+	TableName string     `yaml:"table_name,omitempty"` // Overrides the plural snake_case name
+}
+
+// SpecEndpoint is a REST resource endpoint
+type SpecEndpoint struct {
+	Path    string     `yaml:"path"`
+	Methods []string   `yaml:"methods"`
+	Name    string     `yaml:"name,omitempty"`
+	Fields  SpecFields `yaml:"fields,omitempty"`
+}
+
+// SpecJob is a runnable JobRunr job, recurring when it has a schedule
+type SpecJob struct {
+	Name     string     `yaml:"name"`
+	Payload  SpecFields `yaml:"payload,omitempty"`
+	Schedule string     `yaml:"schedule,omitempty"`
+}
+
+// SpecFields is a field list in the "name:type,..." syntax of --fields. In
+// YAML it is either that string or a list of {name, type, enum, nullable}.
+type SpecFields string
+
+// UnmarshalYAML accepts both forms of a field list
+func (f *SpecFields) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*f = SpecFields(node.Value)
+		return nil
+	}
+	var list []struct {
+		Name     string `yaml:"name"`
+		Type     string `yaml:"type"`
+		Enum     string `yaml:"enum"`
+		Nullable bool   `yaml:"nullable"`
+	}
+	if err := node.Decode(&list); err != nil {
+		return fmt.Errorf("line %d: fields must be a \"name:type,...\" string or a list of {name, type}", node.Line)
+	}
+	entries := make([]string, len(list))
+	for i, field := range list {
+		if field.Name == "" || field.Type == "" {
+			return fmt.Errorf("line %d: field %d needs both name and type", node.Line, i+1)
+		}
+		entry := field.Name + ":" + field.Type
+		if field.Enum != "" {
+			entry += ":" + field.Enum
+		}
+		if field.Nullable {
+			entry += "?"
+		}
+		entries[i] = entry
+	}
+	*f = SpecFields(strings.Join(entries, ","))
+	return nil
+}
+
+// LoadProjectSpec reads and checks a project spec. Unknown keys are errors,
+// so a misspelled setting is not silently dropped.
+func LoadProjectSpec(path string) (*ProjectSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec %s: %w", path, err)
+	}
+	var spec ProjectSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec %s: %w", path, err)
+	}
+	if spec.Name == "" {
+		return nil, fmt.Errorf("spec %s: name is required", path)
+	}
+	if len(spec.Modules) == 0 {
+		return nil, fmt.Errorf("spec %s: modules is required", path)
+	}
+	for i, e := range spec.Scaffolds.Entities {
+		if e.Name == "" || e.Fields == "" {
+			return nil, fmt.Errorf("spec %s: scaffolds.entities[%d] needs a name and fields", path, i)
+		}
+	}
+	for i, e := range spec.Scaffolds.Endpoints {
+		if e.Path == "" || len(e.Methods) == 0 {
+			return nil, fmt.Errorf("spec %s: scaffolds.endpoints[%d] needs a path and methods", path, i)
+		}
+	}
+	for i, j := range spec.Scaffolds.Jobs {
+		if j.Name == "" {
+			return nil, fmt.Errorf("spec %s: scaffolds.jobs[%d] needs a name", path, i)
+		}
+	}
+	return &spec, nil
+}
+
+// Flags returns the spec's init settings keyed by CLI flag name. Option
+// keys may use underscores for dashes, like the profile settings. Unset
+// values are omitted.
+func (s *ProjectSpec) Flags() map[string]string {
+	flags := map[string]string{}
+	set := func(name, value string) {
+		if value != "" {
+			flags[name] = value
+		}
+	}
+	for name, value := range s.Options {
+		if value != nil {
+			set(strings.ReplaceAll(name, "_", "-"), fmt.Sprint(value))
+		}
+	}
+	set("name", s.Name)
+	set("group-id", s.GroupID)
+	set("modules", strings.Join(s.Modules, ","))
+	set("java-version", s.JavaVersion)
+	set("language", s.Language)
+	set("database", s.Database)
+	set("db-version", s.DBVersion)
+	set("nosql-database", s.NoSQLDatabase)
+	set("message-broker", s.MessageBroker)
+	set("vector-store", s.VectorStore)
+	set("ai-agents", strings.Join(s.AIAgents, ","))
+	set("ci", s.CI)
+	set("review", s.Review)
+	return flags
+}
+
+// FlagNames returns the keys of Flags, sorted
+func (s *ProjectSpec) FlagNames() []string {
+	flags := s.Flags()
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/versions"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportProjectSpec describes the project at projectPath as a spec that
+// `trabuco init --spec` turns back into the same project shape. Settings
+// come from .trabuco.json; the parent POM and docker-compose.yml win where
+// they were edited after generation (group id, modules, Java version, the
+// database image). The notes explain every such correction and whatever a
+// spec cannot carry, such as a proprietary license header file.
+// Scaffolds are never exported: the entities and endpoints of a project
+// are its own code, not part of its shape.
+func ExportProjectSpec(projectPath string) (*ProjectSpec, []string, error) {
+	meta, err := LoadMetadata(projectPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	var notes []string
+
+	groupID, modules, javaVersion := meta.GroupID, meta.Modules, meta.JavaVersion
+	root, err := readSpecPOM(filepath.Join(projectPath, "pom.xml"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if root.GroupID != "" && root.GroupID != groupID {
+		notes = append(notes, fmt.Sprintf("group_id %s is taken from pom.xml (.trabuco.json has %s)", root.GroupID, groupID))
+		groupID = root.GroupID
+	}
+	if v := root.Properties.CompilerSource; v != "" && !strings.Contains(v, "${") && v != javaVersion {
+		notes = append(notes, fmt.Sprintf("java_version %s is taken from pom.xml (.trabuco.json has %s)", v, javaVersion))
+		javaVersion = v
+	}
+	// A modulith's POM lists only App; its modules are packages
+	if len(root.Modules) > 0 && meta.Architecture != ArchitectureModulith {
+		var pomModules []string
+		for _, name := range root.Modules {
+			if GetModule(name) == nil {
+				notes = append(notes, fmt.Sprintf("module %s is not a Trabuco module and is left out", name))
+				continue
+			}
+			pomModules = append(pomModules, name)
+		}
+		if !sameModules(pomModules, modules) {
+			notes = append(notes, "modules are taken from pom.xml, which differs from .trabuco.json")
+			modules = pomModules
+		}
+	}
+
+	spec := &ProjectSpec{
+		Name:          meta.ProjectName,
+		GroupID:       groupID,
+		Modules:       selectedModules(modules),
+		JavaVersion:   javaVersion,
+		Language:      meta.Language,
+		Database:      meta.Database,
+		DBVersion:     meta.DatabaseVersion,
+		NoSQLDatabase: meta.NoSQLDatabase,
+		MessageBroker: meta.MessageBroker,
+		VectorStore:   meta.VectorStore,
+		AIAgents:      meta.AIAgents,
+		CI:            meta.CIProvider,
+		Options:       map[string]any{},
+	}
+	if spec.Language == LanguageJava {
+		spec.Language = ""
+	}
+
+	cfg := meta.ToProjectConfig()
+	cfg.Modules = ResolveDependencies(spec.Modules)
+	if image, err := composeImage(projectPath, cfg.DatabaseServiceName()); err != nil {
+		return nil, nil, err
+	} else if image != "" && cfg.HasModule(ModuleSQLDatastore) && image != cfg.DatabaseImage() {
+		if version := databaseVersionOf(cfg.DatabaseServiceName(), image); version != "" {
+			notes = append(notes, fmt.Sprintf("db_version %s is taken from the %s image in docker-compose.yml", version, image))
+			spec.DBVersion = version
+		} else {
+			notes = append(notes, fmt.Sprintf("the %s image in docker-compose.yml is not a %s image and is left out", image, cfg.DatabaseServiceName()))
+		}
+	}
+
+	flags := map[string]bool{
+		"observability":          meta.Observability,
+		"native":                 meta.Native,
+		"with-pagination":        meta.Pagination,
+		"with-rate-limit":        meta.RateLimit,
+		"with-idempotency":       meta.Idempotency,
+		"kafka-transactions":     meta.KafkaTransactions,
+		"sqs-fifo":               meta.SQSFifo,
+		"sqs-dlq":                meta.SQSDeadLetter,
+		"pubsub-ordering":        meta.PubSubOrdering,
+		"pubsub-exactly-once":    meta.PubSubExactlyOnce,
+		"with-perf":              meta.Perf,
+		"with-devcontainer":      meta.DevContainer,
+		"with-codeowners":        meta.CodeOwners,
+		"with-auditing":          meta.Auditing,
+		"with-read-replica":      meta.ReadReplica,
+		"with-jobrunr-dashboard": meta.JobRunrDashboard,
+		"with-jobrunr-batches":   meta.JobRunrBatches,
+		"jpms":                   meta.JPMS,
+		"enable-preview":         meta.EnablePreview,
+		"no-coverage-gates":      meta.NoCoverageGates,
+	}
+	for name, on := range flags {
+		if on {
+			spec.Options[name] = true
+		}
+	}
+	values := map[string]string{
+		"with-cache":              meta.Cache,
+		"secrets":                 meta.Secrets,
+		"job-storage":             meta.JobStorage,
+		"jobrunr-carbon-area":     meta.JobRunrCarbonArea,
+		"kafka-consumer-group":    meta.KafkaConsumerGroup,
+		"kafka-max-poll-interval": meta.KafkaMaxPollInterval,
+		"kafka-ack-mode":          meta.KafkaAckMode,
+		"image-registry":          meta.ImageRegistry,
+		"static-analysis":         meta.StaticAnalysis,
+	}
+	if meta.LogFormat != LogFormatJSON {
+		values["log-format"] = meta.LogFormat
+	}
+	if meta.Architecture != ArchitectureMultiModule {
+		values["architecture"] = meta.Architecture
+	}
+	if meta.TaskRunner != TaskRunnerMake {
+		values["task-runner"] = meta.TaskRunner
+	}
+	if meta.ImageBuilder != ImageBuilderDockerfile {
+		values["image-builder"] = meta.ImageBuilder
+	}
+	switch meta.License {
+	case "":
+	case LicenseProprietary:
+		notes = append(notes, "the proprietary license needs its header file: add license: proprietary:<file> to options")
+	default:
+		values["license"] = meta.License
+	}
+	for name, value := range values {
+		if value != "" {
+			spec.Options[name] = value
+		}
+	}
+	counts := map[string]int{
+		"kafka-concurrency":      meta.KafkaConcurrency,
+		"kafka-partitions":       meta.KafkaPartitions,
+		"kafka-max-poll-records": meta.KafkaMaxPollRecords,
+		"sqs-max-receive-count":  meta.SQSMaxReceiveCount,
+	}
+	for name, n := range counts {
+		if n != 0 {
+			spec.Options[name] = n
+		}
+	}
+	if len(spec.Options) == 0 {
+		spec.Options = nil
+	}
+
+	for _, agent := range meta.AIAgents {
+		if agent == "claude" {
+			notes = append(notes, "the review mode is not recorded in .trabuco.json; set review if the project does not use the default (full)")
+		}
+	}
+	return spec, notes, nil
+}
+
+// MarshalYAML renders a field list as its "name:type,..." string
+func (f SpecFields) MarshalYAML() (any, error) {
+	return string(f), nil
+}
+
+// specPOM is the part of the parent POM an exported spec reads
+type specPOM struct {
+	GroupID    string   `xml:"groupId"`
+	Modules    []string `xml:"modules>module"`
+	Properties struct {
+		CompilerSource string `xml:"maven.compiler.source"`
+	} `xml:"properties"`
+}
+
+func readSpecPOM(path string) (*specPOM, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &specPOM{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var p specPOM
+	if err := xml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// composeImage returns the image of a docker-compose.yml service, empty
+// when the project has no compose file or no such service
+func composeImage(projectPath, service string) (string, error) {
+	path := filepath.Join(projectPath, "docker-compose.yml")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var compose struct {
+		Services map[string]struct {
+			Image string `yaml:"image"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &compose); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return compose.Services[service].Image, nil
+}
+
+// databaseVersionOf returns the --db-version that gives image: the version
+// alone when the image keeps the default tag's variant, the whole tag
+// otherwise. Empty when image is from another repository.
+func databaseVersionOf(service, image string) string {
+	defaultImage, err := versions.LookupImage(service)
+	if err != nil {
+		return ""
+	}
+	defaultRepository, defaultTag, _ := strings.Cut(defaultImage, ":")
+	repository, tag, ok := strings.Cut(image, ":")
+	if !ok || repository != defaultRepository {
+		return ""
+	}
+	if _, variant, ok := strings.Cut(defaultTag, "-"); ok {
+		if version, found := strings.CutSuffix(tag, "-"+variant); found && !strings.Contains(version, "-") {
+			return version
+		}
+	}
+	return tag
+}
+
+// selectedModules drops the modules init adds by itself (Model, and those
+// another module depends on), leaving the list a user would have passed to
+// --modules
+func selectedModules(modules []string) []string {
+	implied := map[string]bool{}
+	for _, name := range modules {
+		if m := GetModule(name); m != nil {
+			if m.Required {
+				implied[name] = true
+			}
+			for _, dep := range m.Dependencies {
+				implied[dep] = true
+			}
+		}
+	}
+	var selected []string
+	for _, name := range modules {
+		if !implied[name] {
+			selected = append(selected, name)
+		}
+	}
+	if len(selected) == 0 || !sameModules(ResolveDependencies(selected), modules) {
+		return modules
+	}
+	return selected
+}
+
+func sameModules(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, name := range a {
+		set[name] = true
+	}
+	for _, name := range b {
+		if !set[name] {
+			return false
+		}
+	}
+	return true
+}
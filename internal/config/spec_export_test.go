@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportProjectSpec(t *testing.T) {
+	dir := t.TempDir()
+	meta := &ProjectMetadata{
+		ProjectName:  "orders",
+		GroupID:      "com.acme.orders",
+		ArtifactID:   "orders",
+		JavaVersion:  "21",
+		Language:     LanguageJava,
+		Modules:      ResolveDependencies([]string{"SQLDatastore", "API", "Worker"}),
+		Database:     DatabasePostgreSQL,
+		AIAgents:     []string{"claude"},
+		CIProvider:   "github",
+		Pagination:   true,
+		Secrets:      "vault",
+		TaskRunner:   TaskRunnerMake,
+		ImageBuilder: ImageBuilderDockerfile,
+		License:      LicenseProprietary,
+	}
+	if err := SaveMetadata(dir, meta); err != nil {
+		t.Fatal(err)
+	}
+	pom := `<project><groupId>com.acme.sales</groupId>
+  <properties><maven.compiler.source>21</maven.compiler.source></properties>
+  <modules><module>Model</module><module>Jobs</module><module>SQLDatastore</module>
+    <module>Shared</module><module>API</module><module>Worker</module><module>Reports</module></modules>
+</project>`
+	compose := "services:\n  postgres:\n    image: postgres:16-alpine\n"
+	for name, content := range map[string]string{"pom.xml": pom, "docker-compose.yml": compose} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spec, notes, err := ExportProjectSpec(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.GroupID != "com.acme.sales" || spec.DBVersion != "16" || spec.Language != "" {
+		t.Errorf("spec = %+v", spec)
+	}
+	if got := strings.Join(spec.Modules, ","); got != "SQLDatastore,API,Worker" {
+		t.Errorf("Modules = %s, want the selection without implied modules", got)
+	}
+	if len(spec.Options) != 2 || spec.Options["with-pagination"] != true || spec.Options["secrets"] != "vault" {
+		t.Errorf("Options = %v", spec.Options)
+	}
+	joined := strings.Join(notes, "\n")
+	for _, want := range []string{"group_id com.acme.sales", "module Reports", "db_version 16", "proprietary", "review"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("notes should mention %q:\n%s", want, joined)
+		}
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "orders.yaml")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadProjectSpec(path)
+	if err != nil {
+		t.Fatalf("the exported spec should load:\n%s\n%v", data, err)
+	}
+	if loaded.Flags()["with-pagination"] != "true" || loaded.Flags()["db-version"] != "16" {
+		t.Errorf("round-tripped Flags() = %v", loaded.Flags())
+	}
+}
+
+func TestExportProjectSpec_NoMetadata(t *testing.T) {
+	if _, _, err := ExportProjectSpec(t.TempDir()); err == nil {
+		t.Error("a directory without .trabuco.json should be an error")
+	}
+}
+
+func TestDatabaseVersionOf(t *testing.T) {
+	cases := map[string]string{
+		"postgres:16-alpine":    "16",
+		"postgres:16-bookworm":  "16-bookworm",
+		"postgres:16":           "16",
+		"bitnami/postgresql:16": "",
+	}
+	for image, want := range cases {
+		if got := databaseVersionOf("postgres", image); got != want {
+			t.Errorf("databaseVersionOf(%s) = %q, want %q", image, got, want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadProjectSpec(t *testing.T) {
+	spec, err := LoadProjectSpec(writeSpec(t, `
+name: orders
+group_id: com.acme.orders
+modules: [SQLDatastore, API, Worker]
+database: mysql
+ai_agents: [claude, cursor]
+ci: github
+options:
+  with_read_replica: true
+  secrets: vault
+scaffolds:
+  entities:
+    - name: Order
+      fields:
+        - {name: customerId, type: uuid}
+        - {name: status, type: enum, enum: OrderStatus, nullable: true}
+  endpoints:
+    - path: /orders
+      methods: [GET, POST]
+      fields: "customerId:uuid"
+  jobs:
+    - name: SendDigest
+      schedule: "0 0 8 * * *"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flags := spec.Flags()
+	want := map[string]string{
+		"name":              "orders",
+		"group-id":          "com.acme.orders",
+		"modules":           "SQLDatastore,API,Worker",
+		"database":          "mysql",
+		"ai-agents":         "claude,cursor",
+		"ci":                "github",
+		"with-read-replica": "true",
+		"secrets":           "vault",
+	}
+	if len(flags) != len(want) {
+		t.Errorf("Flags() = %v, want %v", flags, want)
+	}
+	for name, value := range want {
+		if flags[name] != value {
+			t.Errorf("Flags()[%s] = %q, want %q", name, flags[name], value)
+		}
+	}
+
+	if got := spec.Scaffolds.Entities[0].Fields; got != "customerId:uuid,status:enum:OrderStatus?" {
+		t.Errorf("structured fields = %q", got)
+	}
+	if got := spec.Scaffolds.Endpoints[0].Fields; got != "customerId:uuid" {
+		t.Errorf("string fields = %q", got)
+	}
+	if spec.Scaffolds.IsEmpty() || spec.Scaffolds.Jobs[0].Schedule != "0 0 8 * * *" {
+		t.Errorf("scaffolds = %+v", spec.Scaffolds)
+	}
+}
+
+func TestLoadProjectSpec_Errors(t *testing.T) {
+	cases := []struct {
+		name, content, wantErr string
+	}{
+		{"missing name", "modules: [API]\n", "name is required"},
+		{"missing modules", "name: orders\n", "modules is required"},
+		{"misspelled key", "name: orders\nmodules: [API]\ndatabse: mysql\n", "databse"},
+		{"entity without fields", "name: orders\nmodules: [API]\nscaffolds:\n  entities:\n    - name: Order\n", "entities[0]"},
+		{"field without type", "name: orders\nmodules: [API]\nscaffolds:\n  entities:\n    - name: Order\n      fields: [{name: x}]\n", "needs both name and type"},
+		{"endpoint without methods", "name: orders\nmodules: [API]\nscaffolds:\n  endpoints:\n    - path: /orders\n", "endpoints[0]"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadProjectSpec(writeSpec(t, tc.content))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
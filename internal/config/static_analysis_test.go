@@ -0,0 +1,56 @@
+package config
+
+import "testing"
+
+func TestResolveStaticAnalysis(t *testing.T) {
+	cfg := &ProjectConfig{StaticAnalysis: StaticAnalysisNone}
+	if msg := cfg.ResolveStaticAnalysis(); msg != "" || cfg.StaticAnalysis != "" {
+		t.Errorf("none should normalize to empty, got %q (%s)", cfg.StaticAnalysis, msg)
+	}
+
+	java := &ProjectConfig{StaticAnalysis: StaticAnalysisErrorProne}
+	if msg := java.ResolveStaticAnalysis(); msg != "" || !java.HasErrorProne() {
+		t.Errorf("errorprone should be accepted for Java projects: %s", msg)
+	}
+
+	kotlin := &ProjectConfig{StaticAnalysis: StaticAnalysisErrorProne, Language: LanguageKotlin}
+	if kotlin.ResolveStaticAnalysis() == "" {
+		t.Error("errorprone should be rejected for Kotlin projects")
+	}
+
+	if ValidateStaticAnalysisFlag("spotbugs") == "" {
+		t.Error("unknown static analysis tools should be rejected")
+	}
+}
+
+func TestResolveJPMS(t *testing.T) {
+	java := &ProjectConfig{JPMS: true}
+	if msg := java.ResolveJPMS(); msg != "" || !java.HasJPMS() {
+		t.Errorf("--jpms should be accepted for Java projects: %s", msg)
+	}
+
+	kotlin := &ProjectConfig{JPMS: true, Language: LanguageKotlin}
+	if kotlin.ResolveJPMS() == "" {
+		t.Error("--jpms should be rejected for Kotlin projects")
+	}
+}
+
+func TestResolvePreview(t *testing.T) {
+	for _, version := range []string{"21", "24"} {
+		c := &ProjectConfig{JavaVersion: version, EnablePreview: true}
+		if msg := c.ResolvePreview(); msg != "" || !c.HasPreview() {
+			t.Errorf("--enable-preview should be accepted for Java %s: %s", version, msg)
+		}
+	}
+
+	rejected := map[string]*ProjectConfig{
+		"Java 17": {JavaVersion: "17", EnablePreview: true},
+		"Kotlin":  {JavaVersion: "21", EnablePreview: true, Language: LanguageKotlin},
+		"native":  {JavaVersion: "21", EnablePreview: true, Native: true},
+	}
+	for name, c := range rejected {
+		if c.ResolvePreview() == "" {
+			t.Errorf("--enable-preview should be rejected for %s", name)
+		}
+	}
+}
@@ -1,11 +1,20 @@
 package doctor
 
 import (
+	"bufio"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/envcontract"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+	"github.com/arianlopezc/Trabuco/internal/utils"
 )
 
 // Checker is the interface for individual health checks
@@ -24,6 +33,10 @@ const (
 	CategoryStructure   CheckCategory = "structure"
 	CategoryMetadata    CheckCategory = "metadata"
 	CategoryConsistency CheckCategory = "consistency"
+	CategoryEnvironment CheckCategory = "environment"
+	CategorySecurity    CheckCategory = "security"
+	CategoryRuntime     CheckCategory = "runtime"
+	CategoryCode        CheckCategory = "code"
 )
 
 // BaseCheck provides common fields for checks
@@ -318,9 +331,9 @@ func (c *MetadataSyncCheck) Check(projectPath string, meta *config.ProjectMetada
 		}
 	}
 
-	// Compare metadata modules with POM modules
+	// Compare metadata modules with POM modules; a modulith lists only App
 	metaSet := make(map[string]bool)
-	for _, m := range meta.Modules {
+	for _, m := range meta.ToProjectConfig().MavenModules() {
 		metaSet[m] = true
 	}
 
@@ -357,7 +370,7 @@ func (c *MetadataSyncCheck) Check(projectPath string, meta *config.ProjectMetada
 			Message:    "Metadata modules don't match POM",
 			Details:    details,
 			FixAction:  "sync metadata with POM",
-			CanAutoFix: true,
+			CanAutoFix: meta.Architecture != config.ArchitectureModulith,
 		}
 	}
 
@@ -803,6 +816,616 @@ func (c *CrossModuleDepsCheck) Check(projectPath string, meta *config.ProjectMet
 	}
 }
 
+// --- TEMPLATE_OVERRIDES Check ---
+
+// TemplateOverridesCheck reports user-wide and project-local template
+// overrides, since they make 'trabuco add' output diverge from the defaults
+type TemplateOverridesCheck struct {
+	BaseCheck
+}
+
+func NewTemplateOverridesCheck() *TemplateOverridesCheck {
+	return &TemplateOverridesCheck{
+		BaseCheck: BaseCheck{
+			id:       "TEMPLATE_OVERRIDES",
+			name:     "Template overrides valid",
+			category: CategoryConsistency,
+		},
+	}
+}
+
+func (c *TemplateOverridesCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	engine := templates.NewEngine().WithProjectOverrides(projectPath)
+	overrides := engine.Overrides()
+	if len(overrides) == 0 {
+		return CheckResult{
+			ID:     c.id,
+			Name:   c.name,
+			Status: SeverityPass,
+		}
+	}
+
+	var details, invalid []string
+	for _, o := range overrides {
+		if err := engine.ValidateOverride(o); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s (%s): %v", o.Path, o.File, err))
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s (%s)", o.Path, o.Scope))
+	}
+
+	if len(invalid) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityError,
+			Message: fmt.Sprintf("%d of %d template override(s) are invalid", len(invalid), len(overrides)),
+			Details: invalid,
+		}
+	}
+
+	return CheckResult{
+		ID:      c.id,
+		Name:    c.name,
+		Status:  SeverityWarn,
+		Message: fmt.Sprintf("%d template override(s) active; generated files will differ from the built-in templates", len(overrides)),
+		Details: details,
+	}
+}
+
+// --- SPOTLESS_CONFIG Check ---
+
+// SpotlessConfigCheck verifies the parent POM still configures the Spotless
+// plugin the CI formatting step and format hooks run, and keeps the ratchet
+// recorded in .trabuco.json. A migration-mode POM has no Spotless until
+// 'trabuco migrate activate', so it passes.
+type SpotlessConfigCheck struct {
+	BaseCheck
+}
+
+func NewSpotlessConfigCheck() *SpotlessConfigCheck {
+	return &SpotlessConfigCheck{
+		BaseCheck: BaseCheck{
+			id:       "SPOTLESS_CONFIG",
+			name:     "Spotless formatting configured",
+			category: CategoryConsistency,
+		},
+	}
+}
+
+func (c *SpotlessConfigCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	data, err := os.ReadFile(filepath.Join(projectPath, "pom.xml"))
+	if err != nil || strings.Contains(string(data), "Trabuco migration mode") {
+		// A missing POM is reported by PARENT_POM_VALID
+		return CheckResult{
+			ID:     c.id,
+			Name:   c.name,
+			Status: SeverityPass,
+		}
+	}
+	pom := string(data)
+
+	if !strings.Contains(pom, "<artifactId>spotless-maven-plugin</artifactId>") {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "Spotless plugin is not configured in the parent POM",
+			Details: []string{
+				"CI runs 'mvn spotless:check' and the format hooks run 'mvn spotless:apply'; both fail without the plugin",
+				"Restore the spotless-maven-plugin block in the parent pom.xml",
+			},
+		}
+	}
+
+	if meta != nil && meta.SpotlessRatchetFrom != "" && !strings.Contains(pom, "<ratchetFrom>") {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "Spotless ratchet was removed from the parent POM",
+			Details: []string{
+				fmt.Sprintf(".trabuco.json ratchets formatting from %s, but Spotless now formats every file", meta.SpotlessRatchetFrom),
+				"Add <ratchetFrom>${spotless.ratchetFrom}</ratchetFrom> to the Spotless configuration, or clear spotlessRatchetFrom to format the whole project",
+			},
+		}
+	}
+
+	return CheckResult{
+		ID:     c.id,
+		Name:   c.name,
+		Status: SeverityPass,
+	}
+}
+
+// --- RUN_CONFIGS Check ---
+
+// RunConfigsCheck verifies the IntelliJ run configurations in .run/ point at
+// modules that exist and contain a main class, and that every module with a
+// generated run configuration still has one. Fix removes the generated
+// configurations of removed modules and regenerates missing or broken ones
+// from the templates; hand-written configurations are only reported.
+type RunConfigsCheck struct {
+	BaseCheck
+}
+
+func NewRunConfigsCheck() *RunConfigsCheck {
+	return &RunConfigsCheck{
+		BaseCheck: BaseCheck{
+			id:       "RUN_CONFIGS",
+			name:     "IntelliJ run configurations valid",
+			category: CategoryConsistency,
+		},
+	}
+}
+
+// runConfigIssues lists what is wrong with .run/, split into what Fix can
+// regenerate or remove and what it cannot
+type runConfigIssues struct {
+	details    []string
+	regenerate []string // Modules whose generated configuration is missing or broken
+	remove     []string // Generated configurations of modules no longer in the project
+	manual     int      // Hand-written configurations with problems
+}
+
+func (c *RunConfigsCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	issues := inspectRunConfigs(projectPath, meta)
+	if len(issues.details) == 0 {
+		return CheckResult{
+			ID:     c.id,
+			Name:   c.name,
+			Status: SeverityPass,
+		}
+	}
+
+	result := CheckResult{
+		ID:      c.id,
+		Name:    c.name,
+		Status:  SeverityWarn,
+		Message: fmt.Sprintf("%d run configuration problem(s) in .run/", len(issues.details)),
+		Details: issues.details,
+	}
+	if len(issues.regenerate) > 0 || len(issues.remove) > 0 {
+		result.FixAction = "regenerate run configurations"
+		result.CanAutoFix = true
+	}
+	return result
+}
+
+func (c *RunConfigsCheck) Fix(projectPath string, meta *config.ProjectMetadata) error {
+	issues := inspectRunConfigs(projectPath, meta)
+	for _, module := range issues.remove {
+		if err := os.Remove(filepath.Join(projectPath, ".run", module+".run.xml")); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s run configuration: %w", module, err)
+		}
+	}
+
+	if len(issues.regenerate) > 0 {
+		if meta == nil {
+			return fmt.Errorf("cannot regenerate run configurations without .trabuco.json")
+		}
+		cfg := meta.ToProjectConfig()
+		engine := templates.NewEngine().WithProjectOverrides(projectPath)
+		if err := os.MkdirAll(filepath.Join(projectPath, ".run"), 0755); err != nil {
+			return err
+		}
+		for _, module := range issues.regenerate {
+			content, err := engine.Execute(runConfigTemplate(module), cfg)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(projectPath, ".run", module+".run.xml"), []byte(content), 0644); err != nil {
+				return fmt.Errorf("failed to write %s run configuration: %w", module, err)
+			}
+		}
+	}
+
+	if issues.manual > 0 {
+		return fmt.Errorf("%d hand-written run configuration(s) need fixing by hand", issues.manual)
+	}
+	return nil
+}
+
+// runConfigTemplate is the template a module's run configuration is
+// generated from
+func runConfigTemplate(module string) string {
+	return "idea/run/" + module + "__Maven_.run.xml.tmpl"
+}
+
+// runConfig is the part of a .run/*.run.xml file the check looks at
+type runConfig struct {
+	Type       string // e.g. MavenRunConfiguration, Application, SpringBootApplicationConfigurationType
+	WorkingDir string // Maven: $PROJECT_DIR$/<module>
+	MainClass  string // Application / Spring Boot
+	Module     string // Application / Spring Boot
+}
+
+// parseRunConfig reads a run configuration file
+func parseRunConfig(path string) (*runConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rc := &runConfig{}
+	found := false
+	decoder := xml.NewDecoder(f)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		attrs := map[string]string{}
+		for _, a := range start.Attr {
+			attrs[a.Name.Local] = a.Value
+		}
+		switch start.Name.Local {
+		case "configuration":
+			if !found {
+				rc.Type = attrs["type"]
+				found = true
+			}
+		case "option":
+			switch attrs["name"] {
+			case "workingDirPath":
+				rc.WorkingDir = attrs["value"]
+			case "MAIN_CLASS_NAME", "SPRING_BOOT_MAIN_CLASS":
+				rc.MainClass = attrs["value"]
+			}
+		case "module":
+			rc.Module = attrs["name"]
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no <configuration> element")
+	}
+	return rc, nil
+}
+
+// inspectRunConfigs compares .run/ with the project's modules
+func inspectRunConfigs(projectPath string, meta *config.ProjectMetadata) runConfigIssues {
+	var issues runConfigIssues
+
+	var modules []string
+	if meta != nil {
+		modules = meta.ToProjectConfig().MavenModules()
+	} else if pomModules, err := GetModulesFromPOM(projectPath); err == nil {
+		modules = pomModules
+	} else {
+		return issues // Nothing to compare against
+	}
+	inProject := map[string]bool{}
+	for _, m := range modules {
+		inProject[m] = true
+	}
+
+	engine := templates.NewEngine()
+	generated := func(name string) bool {
+		return config.GetModule(name) != nil && engine.TemplateExists(runConfigTemplate(name))
+	}
+
+	runDir := filepath.Join(projectPath, ".run")
+	entries, _ := os.ReadDir(runDir)
+	present := map[string]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".run.xml") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".run.xml")
+		present[name] = true
+		isGenerated := generated(name)
+
+		if isGenerated && !inProject[name] {
+			issues.details = append(issues.details, fmt.Sprintf(".run/%s: module %s is not in the project", entry.Name(), name))
+			issues.remove = append(issues.remove, name)
+			continue
+		}
+
+		problem := runConfigProblem(projectPath, filepath.Join(runDir, entry.Name()), inProject)
+		if problem == "" {
+			continue
+		}
+		issues.details = append(issues.details, fmt.Sprintf(".run/%s: %s", entry.Name(), problem))
+		if isGenerated {
+			issues.regenerate = append(issues.regenerate, name)
+		} else {
+			issues.manual++
+		}
+	}
+
+	for _, m := range modules {
+		if generated(m) && !present[m] {
+			issues.details = append(issues.details, fmt.Sprintf(".run/%s.run.xml is missing for module %s", m, m))
+			issues.regenerate = append(issues.regenerate, m)
+		}
+	}
+
+	return issues
+}
+
+// runConfigProblem returns why a run configuration is stale, or "" when it
+// points at an existing module and main class. Configuration types other
+// than Maven, Application and Spring Boot are not checked.
+func runConfigProblem(projectPath, path string, inProject map[string]bool) string {
+	rc, err := parseRunConfig(path)
+	if err != nil {
+		return fmt.Sprintf("cannot be parsed (%v)", err)
+	}
+
+	switch rc.Type {
+	case "MavenRunConfiguration":
+		dir := strings.TrimPrefix(rc.WorkingDir, "$PROJECT_DIR$")
+		module := strings.Trim(filepath.ToSlash(dir), "/")
+		if module == "" || strings.Contains(module, "/") {
+			return "" // Runs from the root or a nested directory; nothing to check
+		}
+		if !inProject[module] {
+			return fmt.Sprintf("working directory %s is not a module of the project", module)
+		}
+		if !hasSpringBootMain(filepath.Join(projectPath, module)) {
+			return fmt.Sprintf("module %s has no @SpringBootApplication main class", module)
+		}
+	case "Application", "SpringBootApplicationConfigurationType":
+		if rc.Module != "" && !inProject[rc.Module] {
+			return fmt.Sprintf("module %s is not in the project", rc.Module)
+		}
+		if rc.MainClass != "" && !mainClassExists(projectPath, rc.Module, rc.MainClass) {
+			return fmt.Sprintf("main class %s not found", rc.MainClass)
+		}
+	}
+	return ""
+}
+
+// hasSpringBootMain reports whether a module's main sources declare a
+// @SpringBootApplication class
+func hasSpringBootMain(moduleDir string) bool {
+	found := false
+	for _, lang := range []string{"java", "kotlin"} {
+		filepath.WalkDir(filepath.Join(moduleDir, "src", "main", lang), func(path string, d os.DirEntry, err error) error {
+			if err != nil || found || d.IsDir() {
+				return nil
+			}
+			if !strings.HasSuffix(path, ".java") && !strings.HasSuffix(path, ".kt") {
+				return nil
+			}
+			if data, err := os.ReadFile(path); err == nil && strings.Contains(string(data), "@SpringBootApplication") {
+				found = true
+				return filepath.SkipAll
+			}
+			return nil
+		})
+	}
+	return found
+}
+
+// mainClassExists looks for a class's source file in the given module, or
+// in every module when none is named
+func mainClassExists(projectPath, module, className string) bool {
+	rel := strings.ReplaceAll(className, ".", string(filepath.Separator))
+	var moduleDirs []string
+	if module != "" {
+		moduleDirs = []string{filepath.Join(projectPath, module)}
+	} else if modules, err := GetModulesFromPOM(projectPath); err == nil {
+		for _, m := range modules {
+			moduleDirs = append(moduleDirs, filepath.Join(projectPath, m))
+		}
+	}
+	for _, dir := range moduleDirs {
+		for _, candidate := range []string{
+			filepath.Join(dir, "src", "main", "java", rel+".java"),
+			filepath.Join(dir, "src", "main", "kotlin", rel+".kt"),
+		} {
+			if _, err := os.Stat(candidate); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// --- DOCKER_AVAILABLE Check ---
+
+// DockerAvailableCheck verifies a Docker-compatible daemon (local or remote)
+// or Testcontainers Cloud is available for the project's integration tests
+type DockerAvailableCheck struct {
+	BaseCheck
+	check func() utils.DockerStatus // Overridable in tests
+}
+
+func NewDockerAvailableCheck() *DockerAvailableCheck {
+	return &DockerAvailableCheck{
+		BaseCheck: BaseCheck{
+			id:       "DOCKER_AVAILABLE",
+			name:     "Docker available for Testcontainers",
+			category: CategoryEnvironment,
+		},
+		check: utils.CheckDocker,
+	}
+}
+
+func (c *DockerAvailableCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	status := c.check()
+
+	if !status.CanRunTestcontainers() {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: status.Error,
+			Details: []string{
+				"Integration tests and docker-compose need a Docker-compatible daemon",
+				"Start Docker locally, set DOCKER_HOST to a remote daemon, or configure Testcontainers Cloud (TC_CLOUD_TOKEN)",
+			},
+		}
+	}
+
+	var details []string
+	switch {
+	case status.Runtime == utils.RuntimeRemote:
+		details = append(details, fmt.Sprintf("Remote daemon: %s", status.Host))
+	case status.Running:
+		runtime := status.Runtime
+		if status.SocketPath != "" {
+			runtime += " (" + status.SocketPath + ")"
+		}
+		details = append(details, fmt.Sprintf("Runtime: %s", runtime))
+	}
+	if status.TestcontainersCloud {
+		details = append(details, "Testcontainers Cloud is configured; integration tests run on cloud workers")
+		if !status.Running {
+			details = append(details, "No local daemon: docker-compose based local development is unavailable")
+		}
+	}
+
+	return CheckResult{
+		ID:      c.id,
+		Name:    c.name,
+		Status:  SeverityPass,
+		Details: details,
+	}
+}
+
+// --- PLAINTEXT_SECRETS Check ---
+
+var (
+	// secretKeyPattern matches configuration keys that hold credentials
+	secretKeyPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|(api|access|secret|private)[-_.]?key|credentials)$`)
+	// yamlEntryPattern captures the key and value of a YAML mapping line
+	yamlEntryPattern = regexp.MustCompile(`^\s*([\w.\-]+)\s*:\s*(.*)$`)
+	// propertiesEntryPattern captures the key and value of a properties line
+	propertiesEntryPattern = regexp.MustCompile(`^\s*([\w.\-]+)\s*[=:]\s*(.*)$`)
+	// placeholderDefaultPattern matches ${VAR:default} with a non-empty default
+	placeholderDefaultPattern = regexp.MustCompile(`^\$\{[^:}]+:[^}]+\}$`)
+)
+
+// PlaintextSecretsCheck warns about credentials committed with the project:
+// .env files tracked by git, literal values for password, secret, token
+// and key properties in the modules' Spring configuration, and credential
+// defaults in the staging and prod profiles. docker-compose.yml and the
+// .env examples are left alone; their local-only defaults are intended.
+type PlaintextSecretsCheck struct {
+	BaseCheck
+}
+
+func NewPlaintextSecretsCheck() *PlaintextSecretsCheck {
+	return &PlaintextSecretsCheck{
+		BaseCheck: BaseCheck{
+			id:       "PLAINTEXT_SECRETS",
+			name:     "No plaintext credentials committed",
+			category: CategorySecurity,
+		},
+	}
+}
+
+func (c *PlaintextSecretsCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	details := trackedEnvFiles(projectPath)
+
+	configs, _ := filepath.Glob(filepath.Join(projectPath, "*", "src", "main", "resources", "*"))
+	for _, path := range configs {
+		name := filepath.Base(path)
+		ext := filepath.Ext(name)
+		if ext != ".yml" && ext != ".yaml" && ext != ".properties" {
+			continue
+		}
+		if !strings.HasPrefix(name, "application") && !strings.HasPrefix(name, "secrets") {
+			continue
+		}
+		rel, _ := filepath.Rel(projectPath, path)
+		deployed := strings.Contains(name, "-staging.") || strings.Contains(name, "-prod.")
+		details = append(details, plaintextCredentials(path, filepath.ToSlash(rel), ext == ".properties", deployed)...)
+	}
+
+	if len(details) == 0 {
+		return CheckResult{
+			ID:     c.id,
+			Name:   c.name,
+			Status: SeverityPass,
+		}
+	}
+
+	hint := "Reference credentials as ${ENV_VAR} and keep the values in the environment"
+	if meta != nil && meta.Secrets != "" && meta.Secrets != config.SecretsNone {
+		hint = "Reference credentials as ${ENV_VAR} and store the values in the secrets manager (see secrets.yml)"
+	}
+	return CheckResult{
+		ID:      c.id,
+		Name:    c.name,
+		Status:  SeverityWarn,
+		Message: fmt.Sprintf("%d possible plaintext credential(s) found", len(details)),
+		Details: append(details, hint),
+	}
+}
+
+// trackedEnvFiles lists the .env files git tracks, other than the
+// committed *.example templates and the .env.schema contract. Outside a
+// git repository nothing is tracked.
+func trackedEnvFiles(projectPath string) []string {
+	out, err := exec.Command("git", "-C", projectPath, "ls-files").Output()
+	if err != nil {
+		return nil
+	}
+	var details []string
+	for _, file := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		name := filepath.Base(file)
+		if (name == ".env" || strings.HasPrefix(name, ".env.")) && !strings.HasSuffix(name, ".example") && name != envcontract.SchemaFileName {
+			details = append(details, fmt.Sprintf("%s is tracked by git; remove it with 'git rm --cached %s'", file, file))
+		}
+	}
+	return details
+}
+
+// plaintextCredentials reports the credential properties of a Spring
+// configuration file that have a literal value. In deployed profiles
+// (staging, prod) a ${VAR:default} fallback counts as literal too.
+func plaintextCredentials(path, rel string, properties, deployed bool) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	entry := yamlEntryPattern
+	if properties {
+		entry = propertiesEntryPattern
+	}
+	var details []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "!") {
+			continue
+		}
+		m := entry.FindStringSubmatch(scanner.Text())
+		if m == nil || !secretKeyPattern.MatchString(m[1]) {
+			continue
+		}
+		value := m[2]
+		if i := strings.Index(value, " #"); i >= 0 && !properties {
+			value = value[:i]
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		switch {
+		case value == "" || value == "true" || value == "false" || strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			continue
+		case strings.HasPrefix(value, "${"):
+			if deployed && placeholderDefaultPattern.MatchString(value) {
+				details = append(details, fmt.Sprintf("%s:%d: %s falls back to a default credential", rel, line, m[1]))
+			}
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s:%d: %s has a literal value", rel, line, m[1]))
+	}
+	return details
+}
+
 // GetAllChecks returns all available checks
 func GetAllChecks() []Checker {
 	return []Checker{
@@ -818,13 +1441,24 @@ func GetAllChecks() []Checker {
 		NewGroupIDConsistentCheck(),
 		NewDockerComposeSyncCheck(),
 		NewCrossModuleDepsCheck(),
+		NewTemplateOverridesCheck(),
+		NewSpotlessConfigCheck(),
+		NewRunConfigsCheck(),
+		NewDockerAvailableCheck(),
+		NewEnvContractCheck(),
+		NewPlaintextSecretsCheck(),
+		NewComponentScanCheck(),
+		NewArchitectureRulesCheck(),
+		NewServerPortsCheck(),
+		NewFlywayMigrationsCheck(),
 	}
 }
 
-// GetChecksByCategory returns checks filtered by category
+// GetChecksByCategory returns checks filtered by category, including the
+// runtime checks GetAllChecks leaves out
 func GetChecksByCategory(category string) []Checker {
 	var filtered []Checker
-	for _, check := range GetAllChecks() {
+	for _, check := range append(GetAllChecks(), GetRuntimeChecks()...) {
 		if check.Category() == category {
 			filtered = append(filtered, check)
 		}
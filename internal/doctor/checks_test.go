@@ -1,11 +1,14 @@
 package doctor
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/utils"
 )
 
 func TestProjectStructureCheck(t *testing.T) {
@@ -214,6 +217,30 @@ func TestMetadataSyncCheck(t *testing.T) {
 			t.Error("Expected CanAutoFix to be true")
 		}
 	})
+
+	t.Run("passes for a modulith listing only App", func(t *testing.T) {
+		tempDir := createTestTrabucoProject(t)
+		defer os.RemoveAll(tempDir)
+
+		pomContent := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <groupId>com.example.test</groupId>
+    <artifactId>test-project-parent</artifactId>
+    <modules>
+        <module>App</module>
+    </modules>
+</project>`
+		if err := os.WriteFile(filepath.Join(tempDir, "pom.xml"), []byte(pomContent), 0644); err != nil {
+			t.Fatalf("Failed to write pom.xml: %v", err)
+		}
+
+		meta, _ := config.LoadMetadata(tempDir)
+		meta.Architecture = config.ArchitectureModulith
+		result := check.Check(tempDir, meta)
+		if result.Status != SeverityPass {
+			t.Errorf("Expected PASS, got %s: %s %v", result.Status, result.Message, result.Details)
+		}
+	})
 }
 
 func TestParentPOMValidCheck(t *testing.T) {
@@ -441,10 +468,248 @@ func TestCrossModuleDepsCheck(t *testing.T) {
 	})
 }
 
+func TestTemplateOverridesCheck(t *testing.T) {
+	check := NewTemplateOverridesCheck()
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", t.TempDir())
+
+	tempDir := createTestTrabucoProject(t)
+	defer os.RemoveAll(tempDir)
+
+	if result := check.Check(tempDir, nil); result.Status != SeverityPass {
+		t.Errorf("Expected PASS without overrides, got %s: %s", result.Status, result.Message)
+	}
+
+	overrideDir := filepath.Join(tempDir, ".trabuco", "templates", "docs")
+	os.MkdirAll(overrideDir, 0755)
+	overridePath := filepath.Join(overrideDir, "gitignore.tmpl")
+	os.WriteFile(overridePath, []byte("target/\n"), 0644)
+
+	if result := check.Check(tempDir, nil); result.Status != SeverityWarn {
+		t.Errorf("Expected WARN with an active override, got %s: %s", result.Status, result.Message)
+	}
+
+	os.WriteFile(overridePath, []byte("{{.NoSuchField}}\n"), 0644)
+	if result := check.Check(tempDir, nil); result.Status != SeverityError {
+		t.Errorf("Expected ERROR for an invalid override, got %s: %s", result.Status, result.Message)
+	}
+}
+
+func TestDockerAvailableCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     utils.DockerStatus
+		wantStatus Severity
+		wantDetail string
+	}{
+		{"local daemon", utils.DockerStatus{Installed: true, Running: true, Runtime: utils.RuntimeColima, SocketPath: "/home/dev/.colima/default/docker.sock"}, SeverityPass, "colima"},
+		{"remote daemon", utils.DockerStatus{Installed: true, Running: true, Runtime: utils.RuntimeRemote, Host: "tcp://ci-docker:2376"}, SeverityPass, "tcp://ci-docker:2376"},
+		{"testcontainers cloud only", utils.DockerStatus{TestcontainersCloud: true}, SeverityPass, "docker-compose"},
+		{"nothing available", utils.DockerStatus{Error: "Docker is not installed or not in PATH"}, SeverityWarn, "TC_CLOUD_TOKEN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewDockerAvailableCheck()
+			check.check = func() utils.DockerStatus { return tt.status }
+
+			result := check.Check(t.TempDir(), nil)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Expected %s, got %s: %s", tt.wantStatus, result.Status, result.Message)
+			}
+			if !strings.Contains(strings.Join(result.Details, "\n"), tt.wantDetail) {
+				t.Errorf("Expected details to mention %q, got %v", tt.wantDetail, result.Details)
+			}
+		})
+	}
+}
+
+func TestPlaintextSecretsCheck(t *testing.T) {
+	check := NewPlaintextSecretsCheck()
+	tempDir := createTestTrabucoProject(t)
+	defer os.RemoveAll(tempDir)
+
+	resources := filepath.Join(tempDir, "API", "src", "main", "resources")
+	os.MkdirAll(resources, 0755)
+	os.WriteFile(filepath.Join(resources, "application.yml"), []byte(`spring:
+  datasource:
+    password: ${DB_PASSWORD:postgres}
+  ai:
+    openai:
+      api-key: ${OPENAI_API_KEY}
+      max-tokens: 1024
+`), 0644)
+
+	if result := check.Check(tempDir, nil); result.Status != SeverityPass {
+		t.Errorf("Expected PASS for placeholders, got %s: %v", result.Status, result.Details)
+	}
+
+	os.WriteFile(filepath.Join(resources, "application-prod.yml"), []byte(`spring:
+  datasource:
+    # password: not-this-one
+    password: ${DB_PASSWORD:postgres}
+  rabbitmq:
+    password: "guest"
+`), 0644)
+	os.WriteFile(filepath.Join(resources, "application-local.properties"), []byte("app.client-secret=s3cr3t\n"), 0644)
+
+	result := check.Check(tempDir, nil)
+	if result.Status != SeverityWarn {
+		t.Fatalf("Expected WARN, got %s", result.Status)
+	}
+	details := strings.Join(result.Details, "\n")
+	for _, want := range []string{
+		"API/src/main/resources/application-prod.yml:4: password falls back to a default credential",
+		"API/src/main/resources/application-prod.yml:6: password has a literal value",
+		"API/src/main/resources/application-local.properties:1: app.client-secret has a literal value",
+	} {
+		if !strings.Contains(details, want) {
+			t.Errorf("Expected details to contain %q, got:\n%s", want, details)
+		}
+	}
+	if strings.Contains(details, "s3cr3t") || strings.Contains(details, "guest") {
+		t.Error("Details must not echo the credential values")
+	}
+}
+
+func TestSpotlessConfigCheck(t *testing.T) {
+	check := NewSpotlessConfigCheck()
+	tempDir := createTestTrabucoProject(t)
+	defer os.RemoveAll(tempDir)
+	pomPath := filepath.Join(tempDir, "pom.xml")
+	meta := &config.ProjectMetadata{ProjectName: "test-project", SpotlessRatchetFrom: "abc123"}
+
+	if result := check.Check(tempDir, nil); result.Status != SeverityWarn {
+		t.Errorf("Expected WARN without the plugin, got %s", result.Status)
+	}
+
+	os.WriteFile(pomPath, []byte(`<project>
+    <!-- Trabuco migration mode: enforcement OFF. -->
+</project>`), 0644)
+	if result := check.Check(tempDir, nil); result.Status != SeverityPass {
+		t.Errorf("Expected PASS for a migration-mode POM, got %s: %s", result.Status, result.Message)
+	}
+
+	plugin := `<plugin>
+                <artifactId>spotless-maven-plugin</artifactId>
+                <configuration>%s<java><googleJavaFormat/></java></configuration>
+            </plugin>`
+	os.WriteFile(pomPath, []byte(fmt.Sprintf(plugin, "")), 0644)
+	if result := check.Check(tempDir, nil); result.Status != SeverityPass {
+		t.Errorf("Expected PASS with the plugin, got %s: %s", result.Status, result.Message)
+	}
+	result := check.Check(tempDir, meta)
+	if result.Status != SeverityWarn || !strings.Contains(result.Message, "ratchet") {
+		t.Errorf("Expected ratchet WARN, got %s: %s", result.Status, result.Message)
+	}
+
+	os.WriteFile(pomPath, []byte(fmt.Sprintf(plugin, "<ratchetFrom>${spotless.ratchetFrom}</ratchetFrom>")), 0644)
+	if result := check.Check(tempDir, meta); result.Status != SeverityPass {
+		t.Errorf("Expected PASS with the ratchet, got %s: %s", result.Status, result.Message)
+	}
+}
+
+func TestRunConfigsCheck(t *testing.T) {
+	check := NewRunConfigsCheck()
+
+	setup := func(t *testing.T) (string, *config.ProjectMetadata) {
+		t.Helper()
+		tempDir := createTestTrabucoProject(t)
+		t.Cleanup(func() { os.RemoveAll(tempDir) })
+		appDir := filepath.Join(tempDir, "API", "src", "main", "java", "com", "example", "test", "api")
+		if err := os.MkdirAll(appDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		app := "package com.example.test.api;\n\n@SpringBootApplication\npublic class TestApiApplication {}\n"
+		if err := os.WriteFile(filepath.Join(appDir, "TestApiApplication.java"), []byte(app), 0644); err != nil {
+			t.Fatal(err)
+		}
+		meta, err := config.LoadMetadata(tempDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tempDir, meta
+	}
+
+	t.Run("missing configuration is regenerated", func(t *testing.T) {
+		tempDir, meta := setup(t)
+
+		result := check.Check(tempDir, meta)
+		if result.Status != SeverityWarn || !result.CanAutoFix {
+			t.Fatalf("Expected fixable WARN, got %s: %v", result.Status, result.Details)
+		}
+		if err := check.Fix(tempDir, meta); err != nil {
+			t.Fatalf("Fix failed: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(tempDir, ".run", "API.run.xml"))
+		if err != nil || !strings.Contains(string(data), "$PROJECT_DIR$/API") {
+			t.Fatalf("Expected API.run.xml regenerated, got %q (%v)", data, err)
+		}
+		if result := check.Check(tempDir, meta); result.Status != SeverityPass {
+			t.Errorf("Expected PASS after fix, got %s: %v", result.Status, result.Details)
+		}
+	})
+
+	t.Run("configuration of a removed module is deleted", func(t *testing.T) {
+		tempDir, meta := setup(t)
+		if err := check.Fix(tempDir, meta); err != nil {
+			t.Fatal(err)
+		}
+		worker := strings.ReplaceAll(mustReadFile(t, filepath.Join(tempDir, ".run", "API.run.xml")), "/API", "/Worker")
+		os.WriteFile(filepath.Join(tempDir, ".run", "Worker.run.xml"), []byte(worker), 0644)
+
+		result := check.Check(tempDir, meta)
+		if result.Status != SeverityWarn || !strings.Contains(strings.Join(result.Details, "\n"), "Worker is not in the project") {
+			t.Fatalf("Expected WARN for the Worker configuration, got %s: %v", result.Status, result.Details)
+		}
+		if err := check.Fix(tempDir, meta); err != nil {
+			t.Fatalf("Fix failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, ".run", "Worker.run.xml")); !os.IsNotExist(err) {
+			t.Error("Expected Worker.run.xml removed")
+		}
+	})
+
+	t.Run("hand-written configuration with a missing main class", func(t *testing.T) {
+		tempDir, meta := setup(t)
+		if err := check.Fix(tempDir, meta); err != nil {
+			t.Fatal(err)
+		}
+		custom := `<component name="ProjectRunConfigurationManager">
+  <configuration default="false" name="Debug API" type="Application" factoryName="Application">
+    <option name="MAIN_CLASS_NAME" value="com.example.test.api.RenamedApplication" />
+    <module name="API" />
+  </configuration>
+</component>`
+		os.WriteFile(filepath.Join(tempDir, ".run", "Debug API.run.xml"), []byte(custom), 0644)
+
+		result := check.Check(tempDir, meta)
+		if result.Status != SeverityWarn || result.CanAutoFix {
+			t.Fatalf("Expected non-fixable WARN, got %s (fixable=%v): %v", result.Status, result.CanAutoFix, result.Details)
+		}
+		if !strings.Contains(result.Details[0], "main class com.example.test.api.RenamedApplication not found") {
+			t.Errorf("Unexpected details: %v", result.Details)
+		}
+
+		os.WriteFile(filepath.Join(tempDir, ".run", "Debug API.run.xml"), []byte(strings.ReplaceAll(custom, "RenamedApplication", "TestApiApplication")), 0644)
+		if result := check.Check(tempDir, meta); result.Status != SeverityPass {
+			t.Errorf("Expected PASS, got %s: %v", result.Status, result.Details)
+		}
+	})
+}
+
+func mustReadFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
 func TestGetAllChecks(t *testing.T) {
 	checks := GetAllChecks()
 
-	expectedCount := 12
+	expectedCount := 22
 	if len(checks) != expectedCount {
 		t.Errorf("Expected %d checks, got %d", expectedCount, len(checks))
 	}
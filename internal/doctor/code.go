@@ -0,0 +1,521 @@
+package doctor
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Code checks look inside the generated sources for drift a hand edit or a
+// half-finished 'trabuco add' leaves behind: a module the API no longer
+// scans, ArchUnit rules for modules that are gone, runtime modules
+// competing for a port, and Flyway migrations that skip or reuse a version.
+
+// apiScannedModules are the modules whose packages the API Application's
+// @ComponentScan lists, in template order.
+var apiScannedModules = []string{
+	config.ModuleShared,
+	config.ModuleSQLDatastore,
+	config.ModuleNoSQLDatastore,
+	config.ModuleSearch,
+	config.ModuleEvents,
+	config.ModuleJobs,
+}
+
+// componentScanRE matches @ComponentScan(basePackages = {...}) in Java and
+// its Kotlin form with [...].
+var componentScanRE = regexp.MustCompile(`(?s)@ComponentScan\s*\(\s*basePackages\s*=\s*[{\[](.*?)[}\]]`)
+
+var quotedRE = regexp.MustCompile(`"([^"]+)"`)
+
+// findSource returns the first .java or .kt file under dir whose base name
+// satisfies match, or "" when there is none. Build output is skipped.
+func findSource(dir string, match func(base string) bool) string {
+	var found string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || found != "" {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == "target" || d.Name() == "build" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if (ext == ".java" || ext == ".kt") && match(strings.TrimSuffix(d.Name(), ext)) {
+			found = path
+		}
+		return nil
+	})
+	return found
+}
+
+// modulePackage returns the base package of a module: the group ID plus
+// the lowercase module name.
+func modulePackage(groupID, module string) string {
+	return groupID + "." + strings.ToLower(module)
+}
+
+// --- COMPONENT_SCAN Check ---
+
+// ComponentScanCheck verifies the API Application's @ComponentScan covers
+// the package of every installed module it should, and no module that is
+// gone.
+type ComponentScanCheck struct {
+	BaseCheck
+}
+
+func NewComponentScanCheck() *ComponentScanCheck {
+	return &ComponentScanCheck{
+		BaseCheck: BaseCheck{
+			id:       "COMPONENT_SCAN",
+			name:     "API component scan covers modules",
+			category: CategoryCode,
+		},
+	}
+}
+
+func (c *ComponentScanCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	if meta == nil || meta.GroupID == "" || !slices.Contains(meta.Modules, config.ModuleAPI) {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "No API module"}
+	}
+	if meta.Architecture == config.ArchitectureModulith {
+		// The modulith Application sits in the root package and scans every module
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "Modulith application"}
+	}
+
+	appFile := findSource(filepath.Join(projectPath, config.ModuleAPI, "src", "main"), func(base string) bool {
+		return strings.HasSuffix(base, "ApiApplication")
+	})
+	if appFile == "" {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "API Application class not found",
+			Details: []string{"Expected a *ApiApplication class under API/src/main"},
+		}
+	}
+	rel, _ := filepath.Rel(projectPath, appFile)
+	rel = filepath.ToSlash(rel)
+
+	data, err := os.ReadFile(appFile)
+	if err != nil {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityWarn, Message: fmt.Sprintf("Could not read %s", rel), Details: []string{err.Error()}}
+	}
+	match := componentScanRE.FindSubmatch(data)
+	if match == nil {
+		// Without an explicit scan Spring Boot scans the Application's
+		// package only; that is a choice, not drift
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "No explicit @ComponentScan"}
+	}
+	scanned := map[string]bool{}
+	for _, m := range quotedRE.FindAllSubmatch(match[1], -1) {
+		scanned[string(m[1])] = true
+	}
+
+	var details []string
+	for _, module := range apiScannedModules {
+		pkg := modulePackage(meta.GroupID, module)
+		installed := slices.Contains(meta.Modules, module)
+		switch {
+		case installed && !scanned[pkg]:
+			details = append(details, fmt.Sprintf("Missing %q (%s module is installed)", pkg, module))
+		case !installed && scanned[pkg]:
+			details = append(details, fmt.Sprintf("Scans %q but the %s module is not installed", pkg, module))
+		}
+	}
+	if len(details) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: fmt.Sprintf("@ComponentScan in %s does not match the modules", rel),
+			Details: details,
+		}
+	}
+	return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass}
+}
+
+// --- ARCHITECTURE_RULES Check ---
+
+// architectureRules maps each module-specific rule of the Shared module's
+// ArchitectureTest to the module it guards.
+var architectureRules = []struct {
+	method string
+	module string
+}{
+	{"noForeignKeysInMigrations", config.ModuleSQLDatastore},
+}
+
+// moduleArchitectureTests are the ArchUnit tests generated into the module
+// whose classes they check, with the rules each one must keep.
+var moduleArchitectureTests = []struct {
+	module string
+	test   string
+	rules  []string
+}{
+	{config.ModuleModel, "ModelArchitectureTest", []string{"modelShouldNotDependOnSpringWeb"}},
+	{config.ModuleAPI, "ApiArchitectureTest", []string{"controllersShouldNotAccessRepositoriesDirectly", "controllerHandlersMustDeclareAuthorization"}},
+	{config.ModuleEventConsumer, "EventConsumerArchitectureTest", []string{"listenersShouldStayThin"}},
+}
+
+// ArchitectureRulesCheck verifies the Shared module's ArchitectureTest
+// imports the project's packages and has the rules of the installed
+// modules, and only those, and that every installed module with rules of
+// its own still has its architecture test.
+type ArchitectureRulesCheck struct {
+	BaseCheck
+}
+
+func NewArchitectureRulesCheck() *ArchitectureRulesCheck {
+	return &ArchitectureRulesCheck{
+		BaseCheck: BaseCheck{
+			id:       "ARCHITECTURE_RULES",
+			name:     "Architecture rules match modules",
+			category: CategoryCode,
+		},
+	}
+}
+
+func (c *ArchitectureRulesCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	if meta == nil {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass}
+	}
+
+	details, err := c.moduleTestDetails(projectPath, meta)
+	if err != nil {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityWarn, Message: "Could not read an architecture test", Details: []string{err.Error()}}
+	}
+	if slices.Contains(meta.Modules, config.ModuleShared) {
+		sharedDir := meta.ToProjectConfig().ModuleDir(config.ModuleShared)
+		testFile := findSource(filepath.Join(projectPath, sharedDir, "src", "test"), func(base string) bool {
+			return base == "ArchitectureTest"
+		})
+		if testFile == "" {
+			return CheckResult{
+				ID:      c.id,
+				Name:    c.name,
+				Status:  SeverityWarn,
+				Message: fmt.Sprintf("ArchitectureTest not found in %s/src/test", sharedDir),
+				Details: append([]string{"The ArchUnit rules no longer run as part of the build"}, details...),
+			}
+		}
+		data, err := os.ReadFile(testFile)
+		if err != nil {
+			return CheckResult{ID: c.id, Name: c.name, Status: SeverityWarn, Message: "Could not read ArchitectureTest", Details: []string{err.Error()}}
+		}
+		content := string(data)
+
+		if meta.GroupID != "" && !strings.Contains(content, fmt.Sprintf("importPackages(%q)", meta.GroupID)) {
+			details = append(details, fmt.Sprintf("Does not import the project's packages (%s)", meta.GroupID))
+		}
+		for _, rule := range architectureRules {
+			installed := slices.Contains(meta.Modules, rule.module)
+			present := strings.Contains(content, rule.method+"(")
+			switch {
+			case installed && !present:
+				details = append(details, fmt.Sprintf("Missing rule %s (%s module is installed)", rule.method, rule.module))
+			case !installed && present:
+				details = append(details, fmt.Sprintf("Rule %s is for the %s module, which is not installed", rule.method, rule.module))
+			}
+		}
+	}
+	if len(details) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "Architecture tests do not match the modules",
+			Details: details,
+		}
+	}
+	return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass}
+}
+
+// moduleTestDetails reports the module architecture tests that are missing
+// or lost a rule for an installed module, and those left behind for a
+// module that is gone.
+func (c *ArchitectureRulesCheck) moduleTestDetails(projectPath string, meta *config.ProjectMetadata) ([]string, error) {
+	cfg := meta.ToProjectConfig()
+	var details []string
+	for _, mt := range moduleArchitectureTests {
+		dir := cfg.ModuleDir(mt.module)
+		testFile := findSource(filepath.Join(projectPath, dir, "src", "test"), func(base string) bool {
+			return base == mt.test
+		})
+		installed := slices.Contains(meta.Modules, mt.module)
+		switch {
+		case !installed:
+			if testFile != "" {
+				details = append(details, fmt.Sprintf("%s is for the %s module, which is not installed", mt.test, mt.module))
+			}
+			continue
+		case testFile == "":
+			details = append(details, fmt.Sprintf("Missing %s in %s/src/test (%s module is installed)", mt.test, dir, mt.module))
+			continue
+		}
+		data, err := os.ReadFile(testFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range mt.rules {
+			if !strings.Contains(string(data), rule+"(") {
+				details = append(details, fmt.Sprintf("Missing rule %s in %s", rule, mt.test))
+			}
+		}
+	}
+	return details, nil
+}
+
+// --- SERVER_PORTS Check ---
+
+// portModules are the runtime modules whose default ports must not clash
+// when they run side by side.
+var portModules = []string{config.ModuleAPI, config.ModuleWorker, config.ModuleEventConsumer}
+
+// ServerPortsCheck verifies API, Worker and EventConsumer do not default to
+// the same port in their application.yml: the server port, the management
+// port and any other listener they open, such as the JobRunr dashboard.
+type ServerPortsCheck struct {
+	BaseCheck
+}
+
+func NewServerPortsCheck() *ServerPortsCheck {
+	return &ServerPortsCheck{
+		BaseCheck: BaseCheck{
+			id:       "SERVER_PORTS",
+			name:     "No port clashes between modules",
+			category: CategoryCode,
+		},
+	}
+}
+
+func (c *ServerPortsCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	if meta == nil || meta.Architecture == config.ArchitectureModulith {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass}
+	}
+
+	owners := map[int][]string{}
+	var unreadable []string
+	for _, module := range portModules {
+		if !slices.Contains(meta.Modules, module) {
+			continue
+		}
+		path := filepath.Join(projectPath, module, "src", "main", "resources", "application.yml")
+		ports, err := listenPorts(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				unreadable = append(unreadable, fmt.Sprintf("%s/src/main/resources/application.yml: %v", module, err))
+			}
+			continue
+		}
+		for key, port := range ports {
+			owners[port] = append(owners[port], module+" "+key)
+		}
+	}
+
+	var details []string
+	for port, users := range owners {
+		modules := map[string]bool{}
+		for _, u := range users {
+			modules[strings.Fields(u)[0]] = true
+		}
+		if len(modules) > 1 {
+			sort.Strings(users)
+			details = append(details, fmt.Sprintf("Port %d: %s", port, strings.Join(users, ", ")))
+		}
+	}
+	sort.Strings(details)
+
+	if len(details) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "Modules default to the same port and cannot run side by side",
+			Details: append(details, "Change the default in one module's application.yml, or start it with SERVER_PORT set"),
+		}
+	}
+	if len(unreadable) > 0 {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityWarn, Message: "Could not read application.yml", Details: unreadable}
+	}
+	return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass}
+}
+
+// listenPorts returns the ports an application.yml listens on by default,
+// keyed by property path. It reads every "port" key outside the spring
+// tree, whose ports belong to the servers the module connects to. Only the
+// first document counts: the others are profile overrides.
+func listenPorts(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	ports := map[string]int{}
+	var walk func(prefix string, node map[string]any)
+	walk = func(prefix string, node map[string]any) {
+		for key, value := range node {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			switch v := value.(type) {
+			case map[string]any:
+				walk(path, v)
+			default:
+				if key == "port" {
+					if port, ok := portValue(v); ok {
+						ports[path] = port
+					}
+				}
+			}
+		}
+	}
+	for key, value := range doc {
+		if node, ok := value.(map[string]any); ok && key != "spring" {
+			walk(key, node)
+		}
+	}
+	return ports, nil
+}
+
+// portValue resolves a port property to its default: a number, or the
+// default of a ${VAR:default} placeholder. Empty and 0 (random) defaults
+// cannot clash.
+func portValue(v any) (int, bool) {
+	var s string
+	switch v := v.(type) {
+	case int:
+		s = strconv.Itoa(v)
+	case string:
+		s = v
+		if m := placeholderPattern.FindStringSubmatch(v); m != nil {
+			s = m[2]
+		}
+	default:
+		return 0, false
+	}
+	port, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || port <= 0 {
+		return 0, false
+	}
+	return port, true
+}
+
+// --- FLYWAY_MIGRATIONS Check ---
+
+// flywayVersionedRE matches a versioned migration, V<n>__<description>.sql.
+var flywayVersionedRE = regexp.MustCompile(`^V(\d+)__.+\.sql$`)
+
+// FlywayMigrationsCheck verifies the SQLDatastore migrations are numbered
+// V1, V2, ... without gaps or duplicates, and that every .sql file in the
+// migration directory has a name Flyway picks up.
+type FlywayMigrationsCheck struct {
+	BaseCheck
+}
+
+func NewFlywayMigrationsCheck() *FlywayMigrationsCheck {
+	return &FlywayMigrationsCheck{
+		BaseCheck: BaseCheck{
+			id:       "FLYWAY_MIGRATIONS",
+			name:     "Flyway migrations sequential",
+			category: CategoryCode,
+		},
+	}
+}
+
+func (c *FlywayMigrationsCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	if meta == nil || !slices.Contains(meta.Modules, config.ModuleSQLDatastore) {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "No SQLDatastore module"}
+	}
+
+	migrations := filepath.Join(meta.ToProjectConfig().ModuleDir(config.ModuleSQLDatastore), "src", "main", "resources", "db", "migration")
+	dir := filepath.Join(projectPath, migrations)
+	files := map[int][]string{}
+	var ignored []string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".sql" {
+			return nil
+		}
+		name := d.Name()
+		if m := flywayVersionedRE.FindStringSubmatch(name); m != nil {
+			version, _ := strconv.Atoi(m[1])
+			files[version] = append(files[version], name)
+		} else if !strings.HasPrefix(name, "R__") {
+			ignored = append(ignored, name)
+		}
+		return nil
+	})
+	if len(files) == 0 && len(ignored) == 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "No migrations in " + filepath.ToSlash(migrations),
+		}
+	}
+
+	versions := make([]int, 0, len(files))
+	for v := range files {
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+
+	var duplicates, gaps []string
+	expected := 1
+	for _, v := range versions {
+		if len(files[v]) > 1 {
+			sort.Strings(files[v])
+			duplicates = append(duplicates, fmt.Sprintf("V%d is used by %s", v, strings.Join(files[v], ", ")))
+		}
+		if v > expected {
+			if v == expected+1 {
+				gaps = append(gaps, fmt.Sprintf("V%d is missing", expected))
+			} else {
+				gaps = append(gaps, fmt.Sprintf("V%d to V%d are missing", expected, v-1))
+			}
+		}
+		expected = v + 1
+	}
+	sort.Strings(ignored)
+
+	if len(duplicates) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityError,
+			Message: "Two migrations share a version; Flyway refuses to start",
+			Details: append(duplicates, "Renumber one of them with the next free version (trabuco add migration picks it for you)"),
+		}
+	}
+	if len(gaps) > 0 || len(ignored) > 0 {
+		var details []string
+		details = append(details, gaps...)
+		for _, name := range ignored {
+			details = append(details, fmt.Sprintf("%s is not named V<n>__<description>.sql; Flyway skips it", name))
+		}
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "Migrations are not sequential",
+			Details: details,
+		}
+	}
+	return CheckResult{ID: c.id, Name: fmt.Sprintf("Flyway migrations sequential (V1 to V%d)", versions[len(versions)-1]), Status: SeverityPass}
+}
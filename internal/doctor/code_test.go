@@ -0,0 +1,189 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func writeProjectFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestComponentScanCheck(t *testing.T) {
+	check := NewComponentScanCheck()
+	meta := &config.ProjectMetadata{GroupID: "com.example", Modules: []string{"Model", "Shared", "API", "SQLDatastore", "Jobs"}}
+	appPath := "API/src/main/java/com/example/api/ShopApiApplication.java"
+
+	t.Run("covers every module", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFile(t, dir, appPath, `@SpringBootApplication
+@ComponentScan(basePackages = {
+  "com.example.api",
+  "com.example.shared",
+  "com.example.sqldatastore",
+  "com.example.jobs"
+})
+public class ShopApiApplication {}
+`)
+		if result := check.Check(dir, meta); result.Status != SeverityPass {
+			t.Errorf("expected pass, got %v: %v", result.Status, result.Details)
+		}
+	})
+
+	t.Run("missing and stale packages", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFile(t, dir, appPath, `@ComponentScan(basePackages = {"com.example.api", "com.example.shared", "com.example.search"})
+public class ShopApiApplication {}
+`)
+		result := check.Check(dir, meta)
+		if result.Status != SeverityWarn {
+			t.Fatalf("expected warning, got %v", result.Status)
+		}
+		details := strings.Join(result.Details, "\n")
+		for _, want := range []string{`Missing "com.example.sqldatastore"`, `Missing "com.example.jobs"`, `Scans "com.example.search"`} {
+			if !strings.Contains(details, want) {
+				t.Errorf("details should contain %s, got:\n%s", want, details)
+			}
+		}
+	})
+
+	t.Run("no API module", func(t *testing.T) {
+		result := check.Check(t.TempDir(), &config.ProjectMetadata{GroupID: "com.example", Modules: []string{"Model", "Worker"}})
+		if result.Status != SeverityPass {
+			t.Errorf("expected pass without API, got %v", result.Status)
+		}
+	})
+}
+
+func TestArchitectureRulesCheck(t *testing.T) {
+	check := NewArchitectureRulesCheck()
+	testPath := "Shared/src/test/java/com/example/shared/ArchitectureTest.java"
+
+	dir := t.TempDir()
+	writeProjectFile(t, dir, testPath, `class ArchitectureTest {
+  static void importClasses() { new ClassFileImporter().importPackages("com.example"); }
+  @Test void noForeignKeysInMigrations() throws Exception {}
+}
+`)
+	writeProjectFile(t, dir, "Model/src/test/java/com/example/model/ModelArchitectureTest.java", `class ModelArchitectureTest {
+  @Test void modelShouldNotDependOnSpringWeb() {}
+}
+`)
+	writeProjectFile(t, dir, "API/src/test/java/com/example/api/ApiArchitectureTest.java", `class ApiArchitectureTest {
+  @Test void controllersShouldNotAccessRepositoriesDirectly() {}
+  @Test void controllerHandlersMustDeclareAuthorization() {}
+}
+`)
+
+	withSQL := &config.ProjectMetadata{GroupID: "com.example", Modules: []string{"Model", "Shared", "SQLDatastore", "API"}}
+	if result := check.Check(dir, withSQL); result.Status != SeverityPass {
+		t.Errorf("expected pass, got %v: %v", result.Status, result.Details)
+	}
+
+	withConsumer := &config.ProjectMetadata{GroupID: "com.example", Modules: []string{"Model", "Shared", "Events", "EventConsumer"}}
+	result := check.Check(dir, withConsumer)
+	if result.Status != SeverityWarn {
+		t.Fatalf("expected warning, got %v", result.Status)
+	}
+	details := strings.Join(result.Details, "\n")
+	for _, want := range []string{
+		"noForeignKeysInMigrations is for the SQLDatastore module",
+		"ApiArchitectureTest is for the API module",
+		"Missing EventConsumerArchitectureTest in EventConsumer/src/test",
+	} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details should contain %q, got:\n%s", want, details)
+		}
+	}
+
+	// A module test that lost its rule is drift too
+	writeProjectFile(t, dir, "API/src/test/java/com/example/api/ApiArchitectureTest.java", `class ApiArchitectureTest {
+  @Test void controllerHandlersMustDeclareAuthorization() {}
+}
+`)
+	if result := check.Check(dir, withSQL); result.Status != SeverityWarn || !strings.Contains(strings.Join(result.Details, "\n"), "Missing rule controllersShouldNotAccessRepositoriesDirectly in ApiArchitectureTest") {
+		t.Errorf("a removed API rule should be reported, got %v: %v", result.Status, result.Details)
+	}
+
+	renamed := &config.ProjectMetadata{GroupID: "org.other", Modules: []string{"Model", "Shared", "SQLDatastore"}}
+	if result := check.Check(dir, renamed); result.Status != SeverityWarn || !strings.Contains(strings.Join(result.Details, "\n"), "org.other") {
+		t.Errorf("a different group ID should be reported, got %v: %v", result.Status, result.Details)
+	}
+
+	if result := check.Check(t.TempDir(), withSQL); result.Status != SeverityWarn {
+		t.Errorf("a missing ArchitectureTest should warn, got %v", result.Status)
+	}
+}
+
+func TestServerPortsCheck(t *testing.T) {
+	check := NewServerPortsCheck()
+	meta := &config.ProjectMetadata{Modules: []string{"Model", "API", "Worker", "EventConsumer"}}
+
+	dir := t.TempDir()
+	writeProjectFile(t, dir, "API/src/main/resources/application.yml", "server:\n  port: ${SERVER_PORT:8080}\nspring:\n  data:\n    redis:\n      port: 6379\n")
+	writeProjectFile(t, dir, "Worker/src/main/resources/application.yml", "server:\n  port: ${SERVER_PORT:8081}\nmanagement:\n  server:\n    port: ${MANAGEMENT_SERVER_PORT:}\norg:\n  jobrunr:\n    dashboard:\n      port: ${JOBRUNR_DASHBOARD_PORT:8000}\n")
+	writeProjectFile(t, dir, "EventConsumer/src/main/resources/application.yml", "server:\n  port: 8083\nspring:\n  data:\n    redis:\n      port: 6379\n")
+	if result := check.Check(dir, meta); result.Status != SeverityPass {
+		t.Errorf("expected pass, got %v: %v", result.Status, result.Details)
+	}
+
+	writeProjectFile(t, dir, "EventConsumer/src/main/resources/application.yml", "server:\n  port: ${SERVER_PORT:8081}\n")
+	result := check.Check(dir, meta)
+	if result.Status != SeverityWarn {
+		t.Fatalf("expected warning, got %v", result.Status)
+	}
+	if result.Details[0] != "Port 8081: EventConsumer server.port, Worker server.port" {
+		t.Errorf("unexpected clash detail: %q", result.Details[0])
+	}
+}
+
+func TestFlywayMigrationsCheck(t *testing.T) {
+	check := NewFlywayMigrationsCheck()
+	meta := &config.ProjectMetadata{Modules: []string{"Model", "SQLDatastore"}}
+	migrations := "SQLDatastore/src/main/resources/db/migration/"
+
+	tests := []struct {
+		name   string
+		files  []string
+		status Severity
+		detail string
+	}{
+		{"sequential", []string{"V1__baseline.sql", "V2__add_orders.sql", "R__views.sql"}, SeverityPass, ""},
+		{"gap", []string{"V1__baseline.sql", "V2__add_orders.sql", "V5__add_users.sql"}, SeverityWarn, "V3 to V4 are missing"},
+		{"not starting at V1", []string{"V2__baseline.sql"}, SeverityWarn, "V1 is missing"},
+		{"misnamed", []string{"V1__baseline.sql", "V2_add_orders.sql"}, SeverityWarn, "V2_add_orders.sql is not named"},
+		{"duplicate", []string{"V1__baseline.sql", "V2__add_orders.sql", "V2__add_users.sql"}, SeverityError, "V2 is used by V2__add_orders.sql, V2__add_users.sql"},
+		{"empty", nil, SeverityWarn, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			os.MkdirAll(filepath.Join(dir, filepath.FromSlash(migrations)), 0755)
+			for _, name := range tt.files {
+				writeProjectFile(t, dir, migrations+name, "SELECT 1;\n")
+			}
+			result := check.Check(dir, meta)
+			if result.Status != tt.status {
+				t.Fatalf("expected %v, got %v: %v", tt.status, result.Status, result.Details)
+			}
+			if tt.detail != "" && !strings.Contains(strings.Join(result.Details, "\n"), tt.detail) {
+				t.Errorf("details should contain %q, got %v", tt.detail, result.Details)
+			}
+		})
+	}
+
+	if result := check.Check(t.TempDir(), &config.ProjectMetadata{Modules: []string{"Model", "API"}}); result.Status != SeverityPass {
+		t.Errorf("expected pass without SQLDatastore, got %v", result.Status)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
@@ -36,6 +37,8 @@ type AppConfig struct {
 type SpringConfig struct {
 	Datasource  DatasourceConfig  `yaml:"datasource"`
 	Data        DataConfig        `yaml:"data"`
+	Cassandra   CassandraConfig   `yaml:"cassandra"`
+	Cloud       CloudConfig       `yaml:"cloud"`
 	Kafka       KafkaConfig       `yaml:"kafka"`
 	RabbitMQ    RabbitMQConfig    `yaml:"rabbitmq"`
 }
@@ -61,6 +64,20 @@ type RedisConfig struct {
 	Host string `yaml:"host"`
 }
 
+// CassandraConfig holds Cassandra configuration
+type CassandraConfig struct {
+	ContactPoints string `yaml:"contact-points"`
+}
+
+// CloudConfig holds Spring Cloud configuration
+type CloudConfig struct {
+	AWS struct {
+		DynamoDB struct {
+			Endpoint string `yaml:"endpoint"`
+		} `yaml:"dynamodb"`
+	} `yaml:"aws"`
+}
+
 // KafkaConfig holds Kafka configuration
 type KafkaConfig struct {
 	BootstrapServers string `yaml:"bootstrap-servers"`
@@ -210,6 +227,10 @@ func inferDatabaseConfig(projectPath string, modules []string) (database, nosqlD
 					nosqlDatabase = "mongodb"
 				} else if appConfig.Spring.Data.Redis.Host != "" {
 					nosqlDatabase = "redis"
+				} else if appConfig.Spring.Cloud.AWS.DynamoDB.Endpoint != "" {
+					nosqlDatabase = "dynamodb"
+				} else if appConfig.Spring.Cassandra.ContactPoints != "" {
+					nosqlDatabase = "cassandra"
 				}
 			}
 			if nosqlDatabase == "" {
@@ -226,6 +247,9 @@ func detectDatabaseFromURL(url string) string {
 	if strings.Contains(url, "postgresql") || strings.Contains(url, "postgres") {
 		return "postgresql"
 	}
+	if strings.Contains(url, "mariadb") {
+		return "mariadb"
+	}
 	if strings.Contains(url, "mysql") {
 		return "mysql"
 	}
@@ -262,6 +286,12 @@ func inferMessageBrokerConfig(projectPath string, modules []string) string {
 			if containsFile(configPath, "PubSubConfig.java") {
 				return "pubsub"
 			}
+			if containsFile(configPath, "RedisStreamConfig.java") {
+				return "redis-streams"
+			}
+			if containsFile(configPath, "NatsConfig.java") {
+				return "nats"
+			}
 
 			return "kafka" // Default
 		}
@@ -387,7 +417,8 @@ func ParseModulePOM(pomPath string) (*ModulePOMInfo, error) {
 
 // DockerComposeService represents a service in docker-compose.yml
 type DockerComposeService struct {
-	Image string `yaml:"image"`
+	Image string   `yaml:"image"`
+	Ports []string `yaml:"ports"`
 }
 
 // DockerCompose represents a docker-compose.yml file
@@ -421,6 +452,8 @@ func GetRequiredDockerServices(meta *config.ProjectMetadata) []string {
 			required = append(required, "postgres")
 		case config.DatabaseMySQL:
 			required = append(required, "mysql")
+		case config.DatabaseMariaDB:
+			required = append(required, "mariadb")
 		}
 	}
 
@@ -431,9 +464,18 @@ func GetRequiredDockerServices(meta *config.ProjectMetadata) []string {
 			required = append(required, "mongodb")
 		case config.DatabaseRedis:
 			required = append(required, "redis")
+		case config.DatabaseDynamoDB:
+			required = append(required, "dynamodb")
+		case config.DatabaseCassandra:
+			required = append(required, "cassandra")
 		}
 	}
 
+	// Search engine service
+	if meta.HasModule(config.ModuleSearch) {
+		required = append(required, "opensearch")
+	}
+
 	// Message broker service
 	if meta.HasModule(config.ModuleEventConsumer) {
 		switch meta.MessageBroker {
@@ -445,14 +487,20 @@ func GetRequiredDockerServices(meta *config.ProjectMetadata) []string {
 			required = append(required, "localstack")
 		case config.BrokerPubSub:
 			required = append(required, "pubsub-emulator")
+		case config.BrokerRedisStreams:
+			if !slices.Contains(required, "redis") {
+				required = append(required, "redis")
+			}
+		case config.BrokerNATS:
+			required = append(required, "nats")
 		}
 	}
 
-	// Worker with PostgreSQL fallback
+	// Worker with a JobRunr database of its own (PostgreSQL fallback or --job-storage)
 	if meta.HasModule(config.ModuleWorker) {
 		cfg := meta.ToProjectConfig()
-		if cfg.WorkerNeedsOwnPostgres() {
-			required = append(required, "postgres-jobrunr")
+		if service := cfg.JobRunrServiceName(); service != "" {
+			required = append(required, service)
 		}
 	}
 
@@ -358,6 +358,23 @@ func TestGetRequiredDockerServices(t *testing.T) {
 			},
 			expected: []string{"pubsub-emulator"},
 		},
+		{
+			name: "NATS broker",
+			metadata: &config.ProjectMetadata{
+				Modules:       []string{"Model", "EventConsumer"},
+				MessageBroker: "nats",
+			},
+			expected: []string{"nats"},
+		},
+		{
+			name: "Redis Streams shares the Redis datastore",
+			metadata: &config.ProjectMetadata{
+				Modules:       []string{"Model", "NoSQLDatastore", "EventConsumer"},
+				NoSQLDatabase: "redis",
+				MessageBroker: "redis-streams",
+			},
+			expected: []string{"redis"},
+		},
 		{
 			name: "Worker with no datastore needs postgres-jobrunr",
 			metadata: &config.ProjectMetadata{
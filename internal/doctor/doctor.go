@@ -67,6 +67,7 @@ func (d *Doctor) Run() (*DoctorResult, error) {
 	// Run all checks
 	for _, check := range d.checks {
 		checkResult := check.Check(d.projectPath, metadata)
+		checkResult.Category = check.Category()
 		result.Checks = append(result.Checks, checkResult)
 	}
 
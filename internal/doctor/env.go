@@ -0,0 +1,84 @@
+package doctor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/envcontract"
+)
+
+// --- ENV_CONTRACT Check ---
+
+// EnvContractCheck verifies .env.schema lists the environment variables
+// the modules' configuration reads today, and validates a local .env
+// against it: required variables set, values of the declared type. Fix
+// regenerates ENV.md and .env.schema; .env itself is only reported.
+type EnvContractCheck struct {
+	BaseCheck
+}
+
+func NewEnvContractCheck() *EnvContractCheck {
+	return &EnvContractCheck{
+		BaseCheck: BaseCheck{
+			id:       "ENV_CONTRACT",
+			name:     "Environment variables match the contract",
+			category: CategoryEnvironment,
+		},
+	}
+}
+
+func (c *EnvContractCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	vars, err := envcontract.Scan(projectPath)
+	if err != nil {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityWarn, Message: "Could not read the modules' configuration", Details: []string{err.Error()}}
+	}
+	if len(vars) == 0 {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass}
+	}
+
+	result := CheckResult{ID: c.id, Name: c.name, Status: SeverityPass}
+	current, err := os.ReadFile(filepath.Join(projectPath, envcontract.SchemaFileName))
+	switch {
+	case os.IsNotExist(err):
+		result.Status = SeverityWarn
+		result.Message = fmt.Sprintf("%s not found", envcontract.SchemaFileName)
+		result.Details = []string{fmt.Sprintf("The configuration reads %d environment variables; %s and %s document them", len(vars), envcontract.DocFileName, envcontract.SchemaFileName)}
+	case err == nil && !bytes.Equal(current, envcontract.RenderSchema(vars)):
+		result.Status = SeverityWarn
+		result.Message = fmt.Sprintf("%s is out of date with the modules' configuration", envcontract.SchemaFileName)
+	}
+	if result.Status != SeverityPass {
+		result.FixAction = fmt.Sprintf("regenerate %s and %s", envcontract.DocFileName, envcontract.SchemaFileName)
+		result.CanAutoFix = true
+	}
+
+	env, err := envcontract.ParseEnvFile(filepath.Join(projectPath, ".env"))
+	if os.IsNotExist(err) {
+		return result
+	}
+	if err != nil {
+		result.Status = SeverityWarn
+		result.Details = append(result.Details, "Could not read .env: "+err.Error())
+		return result
+	}
+	validation := envcontract.Validate(vars, env)
+	if len(validation.Errors)+len(validation.Warnings) > 0 {
+		if result.Status == SeverityPass {
+			result.Message = fmt.Sprintf("%d problem(s) in .env", len(validation.Errors)+len(validation.Warnings))
+		}
+		result.Status = SeverityWarn
+		result.Details = append(append(result.Details, validation.Errors...), validation.Warnings...)
+	}
+	return result
+}
+
+func (c *EnvContractCheck) Fix(projectPath string, meta *config.ProjectMetadata) error {
+	name := filepath.Base(projectPath)
+	if meta != nil && meta.ProjectName != "" {
+		name = meta.ProjectName
+	}
+	return envcontract.Write(projectPath, name)
+}
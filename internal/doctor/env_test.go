@@ -0,0 +1,45 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/envcontract"
+)
+
+func TestEnvContractCheck(t *testing.T) {
+	dir := t.TempDir()
+	resources := filepath.Join(dir, "API", "src", "main", "resources")
+	os.MkdirAll(resources, 0755)
+	os.WriteFile(filepath.Join(resources, "application.yml"), []byte("server:\n  port: ${SERVER_PORT:8080}\n"), 0644)
+
+	check := NewEnvContractCheck()
+	result := check.Check(dir, nil)
+	if result.Status != SeverityWarn || !result.CanAutoFix {
+		t.Fatalf("missing schema: %+v", result)
+	}
+	if err := check.Fix(dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if result := check.Check(dir, nil); result.Status != SeverityPass {
+		t.Fatalf("after fix: %+v", result)
+	}
+
+	// The configuration moves on; the schema is stale
+	os.WriteFile(filepath.Join(resources, "application.yml"), []byte("server:\n  port: ${SERVER_PORT:8080}\napi-key: ${API_KEY}\n"), 0644)
+	if result := check.Check(dir, nil); result.Status != SeverityWarn || !result.CanAutoFix {
+		t.Errorf("stale schema: %+v", result)
+	}
+	check.Fix(dir, nil)
+
+	// A local .env missing a required variable is reported, not fixed
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("SERVER_PORT=80a\n"), 0644)
+	result = check.Check(dir, nil)
+	if result.Status != SeverityWarn || result.CanAutoFix || len(result.Details) != 2 {
+		t.Errorf("bad .env: %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(dir, envcontract.DocFileName)); err != nil {
+		t.Errorf("Fix should write %s: %v", envcontract.DocFileName, err)
+	}
+}
@@ -0,0 +1,265 @@
+package doctor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// Output formats accepted by 'trabuco doctor --format'.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+)
+
+// Formats lists the output formats, text first as the default.
+var Formats = []string{FormatText, FormatJSON, FormatSARIF, FormatJUnit}
+
+// sarifSchema and sarifVersion identify the SARIF revision GitHub code
+// scanning accepts.
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string          `json:"id"`
+	Name                 string          `json:"name"`
+	ShortDescription     sarifText       `json:"shortDescription"`
+	Help                 *sarifText      `json:"help,omitempty"`
+	DefaultConfiguration sarifRuleConfig `json:"defaultConfiguration"`
+	Properties           map[string]any  `json:"properties,omitempty"`
+}
+
+type sarifRuleConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	RuleIndex  int             `json:"ruleIndex"`
+	Level      string          `json:"level"`
+	Message    sarifText       `json:"message"`
+	Locations  []sarifLocation `json:"locations,omitempty"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// checkArtifact returns the project file a finding of check id is reported
+// against, or "" for a check that reads several files or none, such as the
+// per-module and Docker checks. Checks only know their project, not a line,
+// so a finding is pinned to the top of the file.
+func checkArtifact(id string) string {
+	switch id {
+	case "TRABUCO_PROJECT", "PROJECT_STRUCTURE", "PARENT_POM_VALID", "JAVA_VERSION_CONSISTENT", "GROUP_ID_CONSISTENT", "SPOTLESS_CONFIG":
+		return "pom.xml"
+	case "METADATA_EXISTS", "METADATA_VALID", "METADATA_SYNC":
+		return config.MetadataFileName
+	case "DOCKER_COMPOSE_SYNC", "COMPOSE_SERVICES_RUNNING", "INFRA_PORTS_REACHABLE":
+		return "docker-compose.yml"
+	default:
+		return ""
+	}
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarn:
+		return "warning"
+	default:
+		return "none"
+	}
+}
+
+// findingText joins a finding's message and details into one message.
+func findingText(check CheckResult) string {
+	lines := []string{check.Name}
+	if check.Message != "" {
+		lines[0] = check.Name + ": " + check.Message
+	}
+	lines = append(lines, check.Details...)
+	return strings.Join(lines, "\n")
+}
+
+// ToSARIF serializes the result as a SARIF 2.1.0 log for GitHub code
+// scanning. Every check run becomes a rule; every warning or error becomes
+// a result. Doctor fixes are actions rather than text edits, so a finding
+// 'doctor --fix' can repair names the command in its message and in its
+// fixCommand and fixAction properties instead of carrying a SARIF fix.
+func (r *DoctorResult) ToSARIF() ([]byte, error) {
+	driver := sarifDriver{
+		Name:           "trabuco-doctor",
+		Version:        r.TrabucoVersion,
+		InformationURI: "https://github.com/arianlopezc/Trabuco",
+		Rules:          []sarifRule{},
+	}
+	results := []sarifResult{}
+	for i, check := range r.Checks {
+		rule := sarifRule{
+			ID:                   check.ID,
+			Name:                 check.ID,
+			ShortDescription:     sarifText{Text: check.Name},
+			DefaultConfiguration: sarifRuleConfig{Level: "warning"},
+		}
+		if check.Category != "" {
+			rule.Properties = map[string]any{"tags": []string{check.Category}}
+		}
+		if check.FixAction != "" {
+			rule.Help = &sarifText{Text: fmt.Sprintf("Run 'trabuco doctor --fix' to %s.", check.FixAction)}
+		}
+		driver.Rules = append(driver.Rules, rule)
+
+		if check.Status == SeverityPass {
+			continue
+		}
+		result := sarifResult{
+			RuleID:    check.ID,
+			RuleIndex: i,
+			Level:     sarifLevel(check.Status),
+			Message:   sarifText{Text: findingText(check)},
+		}
+		if uri := checkArtifact(check.ID); uri != "" {
+			result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: uri, URIBaseID: "%SRCROOT%"},
+				Region:           &sarifRegion{StartLine: 1},
+			}}}
+		}
+		if check.CanAutoFix && check.FixAction != "" {
+			result.Message.Text += fmt.Sprintf("\nRun 'trabuco doctor --fix' to %s.", check.FixAction)
+			result.Properties = map[string]any{
+				"fixCommand": "trabuco doctor --fix",
+				"fixAction":  check.FixAction,
+			}
+		}
+		results = append(results, result)
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}, "", "  ")
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnit serializes the result as a JUnit XML report for CI test
+// reporters: one test suite per category and one test case per check.
+// Errors are failures. Warnings pass, as they do for the exit code, and
+// carry the finding in system-err so reporters still show it.
+func (r *DoctorResult) ToJUnit() ([]byte, error) {
+	report := junitTestSuites{Name: "trabuco doctor"}
+	suiteIndex := map[string]int{}
+	for _, check := range r.Checks {
+		category := check.Category
+		if category == "" {
+			category = "doctor"
+		}
+		i, ok := suiteIndex[category]
+		if !ok {
+			i = len(report.Suites)
+			suiteIndex[category] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: category})
+		}
+		suite := &report.Suites[i]
+
+		testCase := junitTestCase{Name: check.Name, ClassName: "doctor." + category + "." + check.ID}
+		switch check.Status {
+		case SeverityError:
+			testCase.Failure = &junitFailure{Message: check.Message, Type: SeverityError.String(), Text: findingText(check)}
+			suite.Failures++
+			report.Failures++
+		case SeverityWarn:
+			testCase.SystemErr = SeverityWarn.String() + ": " + findingText(check)
+		}
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
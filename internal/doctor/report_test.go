@@ -0,0 +1,147 @@
+package doctor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func reportTestResult() *DoctorResult {
+	result := &DoctorResult{
+		Project:        "test-project",
+		TrabucoVersion: "1.2.0",
+		Checks: []CheckResult{
+			{ID: "PROJECT_STRUCTURE", Name: "Project structure valid", Category: "structure", Status: SeverityPass},
+			{ID: "METADATA_SYNC", Name: "Metadata in sync", Category: "metadata", Status: SeverityWarn,
+				Message: "Metadata modules don't match POM", Details: []string{"In POM but not in metadata: [Worker]"},
+				FixAction: "sync metadata with POM", CanAutoFix: true},
+			{ID: "PARENT_POM_VALID", Name: "Parent POM valid", Category: "structure", Status: SeverityError,
+				Message: "Missing <modules> section"},
+		},
+	}
+	result.ComputeSummary()
+	return result
+}
+
+func TestToSARIF(t *testing.T) {
+	result := reportTestResult()
+	result.Checks = append(result.Checks, CheckResult{ID: "SERVER_PORTS", Name: "Server ports unique", Category: "code",
+		Status: SeverityWarn, Message: "API and Worker both listen on 8080"})
+	data, err := result.ToSARIF()
+	if err != nil {
+		t.Fatalf("ToSARIF failed: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID   string `json:"id"`
+						Help *struct {
+							Text string `json:"text"`
+						} `json:"help"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Message   struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Fixes      []json.RawMessage `json:"fixes"`
+				Properties struct {
+					FixCommand string `json:"fixCommand"`
+					FixAction  string `json:"fixAction"`
+				} `json:"properties"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("SARIF should be valid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("expected one SARIF 2.1.0 run, got version %q with %d runs", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 4 {
+		t.Errorf("every check should be a rule, got %d", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("passing checks should not be results, got %d results", len(run.Results))
+	}
+
+	warn, failure, unlocated := run.Results[0], run.Results[1], run.Results[2]
+	if warn.RuleID != "METADATA_SYNC" || warn.Level != "warning" || run.Tool.Driver.Rules[warn.RuleIndex].ID != "METADATA_SYNC" {
+		t.Errorf("unexpected warning result: %+v", warn)
+	}
+	if uri := warn.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != ".trabuco.json" {
+		t.Errorf("METADATA_SYNC should point at .trabuco.json, got %s", uri)
+	}
+	if !strings.Contains(warn.Message.Text, "[Worker]") {
+		t.Errorf("message should include the details, got %q", warn.Message.Text)
+	}
+	if len(warn.Fixes) != 0 {
+		t.Errorf("doctor fixes are not text edits and should not be SARIF fixes, got %+v", warn.Fixes)
+	}
+	if !strings.Contains(warn.Message.Text, "Run 'trabuco doctor --fix' to sync metadata with POM") ||
+		warn.Properties.FixCommand != "trabuco doctor --fix" || warn.Properties.FixAction != "sync metadata with POM" {
+		t.Errorf("an auto-fixable finding should name the fix, got %q %+v", warn.Message.Text, warn.Properties)
+	}
+
+	if failure.Level != "error" || failure.Properties.FixCommand != "" {
+		t.Errorf("unexpected error result: %+v", failure)
+	}
+	if uri := failure.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "pom.xml" {
+		t.Errorf("PARENT_POM_VALID should point at pom.xml, got %s", uri)
+	}
+	if unlocated.RuleID != "SERVER_PORTS" || len(unlocated.Locations) != 0 {
+		t.Errorf("a check reading several files should have no location, got %+v", unlocated)
+	}
+}
+
+func TestToJUnit(t *testing.T) {
+	data, err := reportTestResult().ToJUnit()
+	if err != nil {
+		t.Fatalf("ToJUnit failed: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Error("JUnit report should start with an XML header")
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("JUnit report should be valid XML: %v", err)
+	}
+	if report.Tests != 3 || report.Failures != 1 {
+		t.Errorf("expected 3 tests and 1 failure, got %d and %d", report.Tests, report.Failures)
+	}
+	if len(report.Suites) != 2 || report.Suites[0].Name != "structure" || report.Suites[1].Name != "metadata" {
+		t.Fatalf("expected structure and metadata suites, got %+v", report.Suites)
+	}
+
+	structure := report.Suites[0]
+	if structure.Tests != 2 || structure.Failures != 1 {
+		t.Errorf("structure suite should have 2 tests and 1 failure, got %d and %d", structure.Tests, structure.Failures)
+	}
+	if f := structure.Cases[1].Failure; f == nil || f.Type != "ERROR" || f.Message != "Missing <modules> section" {
+		t.Errorf("the error should be a failure, got %+v", f)
+	}
+
+	warn := report.Suites[1].Cases[0]
+	if warn.Failure != nil || !strings.HasPrefix(warn.SystemErr, "WARN: ") {
+		t.Errorf("a warning should pass with the finding in system-err, got %+v", warn)
+	}
+}
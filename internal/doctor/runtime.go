@@ -0,0 +1,462 @@
+package doctor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Runtime checks look at the local infrastructure the project runs against
+// rather than at its files: are the docker-compose services up, do their
+// ports answer, do the Kafka topics exist. They are slow and fail whenever
+// the stack is simply stopped, so they only run with --check=runtime.
+
+// composeService is one entry of `docker compose ps --format json`.
+type composeService struct {
+	Service string `json:"Service"`
+	State   string `json:"State"`
+	Health  string `json:"Health"`
+}
+
+// composeCommands are the compose front ends tried, in order.
+var composeCommands = [][]string{
+	{"docker", "compose"},
+	{"docker-compose"},
+	{"podman", "compose"},
+}
+
+// RunCompose runs a compose subcommand in projectPath with the first
+// compose front end that is installed. It returns the command's stdout.
+func RunCompose(projectPath string, args ...string) ([]byte, error) {
+	var lastErr error
+	for _, c := range composeCommands {
+		if _, err := exec.LookPath(c[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(c[0], append(c[1:], args...)...)
+		cmd.Dir = projectPath
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err == nil {
+			return out, nil
+		}
+		lastErr = fmt.Errorf("%s: %s", strings.Join(c, " "), firstLine(stderr.String(), err.Error()))
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("neither docker compose, docker-compose nor podman compose is installed")
+	}
+	return nil, lastErr
+}
+
+func firstLine(s, fallback string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return fallback
+	}
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// composePS lists the project's compose containers, stopped ones included.
+func composePS(projectPath string) ([]composeService, error) {
+	out, err := RunCompose(projectPath, "ps", "--all", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+	return parseComposePS(out)
+}
+
+// parseComposePS reads both output shapes of `compose ps --format json`:
+// a JSON array (Compose < 2.21) and one object per line (later versions).
+func parseComposePS(out []byte) ([]composeService, error) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil, nil
+	}
+	var services []composeService
+	if out[0] == '[' {
+		if err := json.Unmarshal(out, &services); err != nil {
+			return nil, fmt.Errorf("unexpected compose ps output: %w", err)
+		}
+		return services, nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var s composeService
+		if err := json.Unmarshal(line, &s); err != nil {
+			return nil, fmt.Errorf("unexpected compose ps output: %w", err)
+		}
+		services = append(services, s)
+	}
+	return services, scanner.Err()
+}
+
+// RunningComposeServices returns the names of the project's compose
+// services whose containers are running.
+func RunningComposeServices(projectPath string) ([]string, error) {
+	services, err := composePS(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	var running []string
+	for _, s := range services {
+		if s.State == "running" {
+			running = append(running, s.Service)
+		}
+	}
+	return running, nil
+}
+
+// --- COMPOSE_SERVICES_RUNNING Check ---
+
+// ComposeServicesRunningCheck verifies the docker-compose services the
+// project's modules need are running and, when they have a healthcheck,
+// healthy.
+type ComposeServicesRunningCheck struct {
+	BaseCheck
+	ps func(projectPath string) ([]composeService, error) // Overridable in tests
+}
+
+func NewComposeServicesRunningCheck() *ComposeServicesRunningCheck {
+	return &ComposeServicesRunningCheck{
+		BaseCheck: BaseCheck{
+			id:       "COMPOSE_SERVICES_RUNNING",
+			name:     "Docker Compose services running",
+			category: CategoryRuntime,
+		},
+		ps: composePS,
+	}
+}
+
+func (c *ComposeServicesRunningCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	required := RequiredRuntimeServices(projectPath, meta)
+	if len(required) == 0 {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "No docker-compose services required"}
+	}
+
+	running, err := c.ps(projectPath)
+	if err != nil {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityError,
+			Message: "Could not list docker-compose services",
+			Details: []string{err.Error(), "Is Docker running? See 'trabuco doctor --check=environment'"},
+		}
+	}
+	byName := make(map[string]composeService, len(running))
+	for _, s := range running {
+		byName[s.Service] = s
+	}
+
+	var down, unhealthy []string
+	for _, name := range required {
+		s, ok := byName[name]
+		switch {
+		case !ok:
+			down = append(down, name+": not created")
+		case s.State != "running":
+			down = append(down, fmt.Sprintf("%s: %s", name, s.State))
+		case s.Health != "" && s.Health != "healthy":
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", name, s.Health))
+		}
+	}
+
+	if len(down) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityError,
+			Message: "Required services are not running — start them with 'docker compose up -d'",
+			Details: append(down, unhealthy...),
+		}
+	}
+	if len(unhealthy) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "Services are running but not healthy yet — check 'docker compose logs <service>'",
+			Details: unhealthy,
+		}
+	}
+	return CheckResult{
+		ID:      c.id,
+		Name:    c.name,
+		Status:  SeverityPass,
+		Details: []string{"Running: " + strings.Join(required, ", ")},
+	}
+}
+
+// RequiredRuntimeServices returns the services the modules need that the
+// project's docker-compose.yml defines. Services missing from the file are
+// DOCKER_COMPOSE_SYNC's concern.
+func RequiredRuntimeServices(projectPath string, meta *config.ProjectMetadata) []string {
+	if meta == nil {
+		return nil
+	}
+	dc, err := ParseDockerCompose(filepath.Join(projectPath, "docker-compose.yml"))
+	if err != nil {
+		return nil
+	}
+	var services []string
+	for _, name := range GetRequiredDockerServices(meta) {
+		if _, ok := dc.Services[name]; ok {
+			services = append(services, name)
+		}
+	}
+	return services
+}
+
+// --- INFRA_PORTS_REACHABLE Check ---
+
+// InfraPortsReachableCheck verifies that the host ports docker-compose.yml
+// publishes for the required services accept connections — the ports the
+// application's local configuration connects to.
+type InfraPortsReachableCheck struct {
+	BaseCheck
+	dial func(address string) error // Overridable in tests
+}
+
+func NewInfraPortsReachableCheck() *InfraPortsReachableCheck {
+	return &InfraPortsReachableCheck{
+		BaseCheck: BaseCheck{
+			id:       "INFRA_PORTS_REACHABLE",
+			name:     "Local infrastructure reachable",
+			category: CategoryRuntime,
+		},
+		dial: func(address string) error {
+			conn, err := net.DialTimeout("tcp", address, 2*time.Second)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
+
+func (c *InfraPortsReachableCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	required := RequiredRuntimeServices(projectPath, meta)
+	if len(required) == 0 {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "No docker-compose services required"}
+	}
+	dc, err := ParseDockerCompose(filepath.Join(projectPath, "docker-compose.yml"))
+	if err != nil {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityWarn, Message: "Could not parse docker-compose.yml", Details: []string{err.Error()}}
+	}
+
+	var reachable, unreachable []string
+	for _, name := range required {
+		for _, address := range publishedAddresses(dc.Services[name].Ports) {
+			if err := c.dial(address); err != nil {
+				unreachable = append(unreachable, fmt.Sprintf("%s at %s: %v", name, address, err))
+			} else {
+				reachable = append(reachable, fmt.Sprintf("%s at %s", name, address))
+			}
+		}
+	}
+
+	if len(unreachable) > 0 {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityError,
+			Message: "Services do not accept connections on their published ports",
+			Details: append(unreachable, "Start the stack with 'docker compose up -d', or wait for the containers to finish starting"),
+		}
+	}
+	return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Details: reachable}
+}
+
+// publishedAddresses returns host:port for every published port mapping
+// ("127.0.0.1:5433:5432", "5433:5432/tcp"). Ports without a host side get
+// a random host port and are skipped.
+func publishedAddresses(ports []string) []string {
+	var addresses []string
+	for _, p := range ports {
+		p = strings.SplitN(p, "/", 2)[0]
+		parts := strings.Split(p, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		host := "localhost"
+		if len(parts) == 3 && parts[0] != "" && parts[0] != "0.0.0.0" {
+			host = parts[0]
+		}
+		addresses = append(addresses, net.JoinHostPort(host, parts[len(parts)-2]))
+	}
+	return addresses
+}
+
+// --- KAFKA_TOPICS_EXIST Check ---
+
+// KafkaTopicsExistCheck verifies the topics the modules configure under
+// app.kafka.topics exist on the local Kafka broker.
+type KafkaTopicsExistCheck struct {
+	BaseCheck
+	listTopics func(projectPath string) ([]string, error) // Overridable in tests
+}
+
+func NewKafkaTopicsExistCheck() *KafkaTopicsExistCheck {
+	return &KafkaTopicsExistCheck{
+		BaseCheck: BaseCheck{
+			id:       "KAFKA_TOPICS_EXIST",
+			name:     "Kafka topics exist",
+			category: CategoryRuntime,
+		},
+		listTopics: listKafkaTopics,
+	}
+}
+
+// listKafkaTopics asks the compose kafka service for its topics over the
+// broker's internal listener.
+func listKafkaTopics(projectPath string) ([]string, error) {
+	out, err := RunCompose(projectPath, "exec", "-T", "kafka", "kafka-topics", "--bootstrap-server", "kafka:29092", "--list")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(out)), nil
+}
+
+func (c *KafkaTopicsExistCheck) Check(projectPath string, meta *config.ProjectMetadata) CheckResult {
+	if meta == nil || meta.MessageBroker != config.BrokerKafka || !slices.Contains(RequiredRuntimeServices(projectPath, meta), "kafka") {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "Project does not use a local Kafka broker"}
+	}
+	configured := configuredKafkaTopics(projectPath, meta)
+	if len(configured) == 0 {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Message: "No topics configured under app.kafka.topics"}
+	}
+
+	existing, err := c.listTopics(projectPath)
+	if err != nil {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityError,
+			Message: "Could not list Kafka topics",
+			Details: []string{err.Error(), "Is the kafka service running? See COMPOSE_SERVICES_RUNNING"},
+		}
+	}
+	have := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		have[t] = true
+	}
+	var missing []string
+	for _, t := range configured {
+		if !have[t] {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) == 0 {
+		return CheckResult{ID: c.id, Name: c.name, Status: SeverityPass, Details: []string{"Topics: " + strings.Join(configured, ", ")}}
+	}
+
+	create := fmt.Sprintf("Create them with: docker compose exec kafka kafka-topics --bootstrap-server kafka:29092 --create --topic %s", missing[0])
+	if kafkaAutoCreatesTopics(projectPath) {
+		return CheckResult{
+			ID:      c.id,
+			Name:    c.name,
+			Status:  SeverityWarn,
+			Message: "Topics do not exist yet; the broker creates them on first use, and consumers log UNKNOWN_TOPIC_OR_PARTITION until then",
+			Details: append(missing, create),
+		}
+	}
+	return CheckResult{
+		ID:      c.id,
+		Name:    c.name,
+		Status:  SeverityError,
+		Message: "Configured topics do not exist and the broker does not auto-create them",
+		Details: append(missing, create),
+	}
+}
+
+// placeholderPattern matches a Spring ${VAR:default} placeholder.
+var placeholderPattern = regexp.MustCompile(`^\$\{([^:}]+)(?::([^}]*))?\}$`)
+
+// ResolvePlaceholder resolves a Spring ${VAR:default} value like Spring
+// does: VAR from the environment when set, the default otherwise. Other
+// values are returned unchanged.
+func ResolvePlaceholder(value string) string {
+	m := placeholderPattern.FindStringSubmatch(value)
+	if m == nil {
+		return value
+	}
+	if env := os.Getenv(m[1]); env != "" {
+		return env
+	}
+	return m[2]
+}
+
+// configuredKafkaTopics returns the topic names the modules configure under
+// app.kafka.topics, resolving ${VAR:default} from the environment like
+// Spring does.
+func configuredKafkaTopics(projectPath string, meta *config.ProjectMetadata) []string {
+	seen := map[string]bool{}
+	for _, module := range meta.Modules {
+		data, err := os.ReadFile(filepath.Join(projectPath, module, "src", "main", "resources", "application.yml"))
+		if err != nil {
+			continue
+		}
+		var doc struct {
+			App struct {
+				Kafka struct {
+					Topics map[string]string `yaml:"topics"`
+				} `yaml:"kafka"`
+			} `yaml:"app"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			continue
+		}
+		for _, value := range doc.App.Kafka.Topics {
+			if value = ResolvePlaceholder(value); value != "" {
+				seen[value] = true
+			}
+		}
+	}
+	topics := make([]string, 0, len(seen))
+	for t := range seen {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// kafkaAutoCreatesTopics reports whether the compose kafka service enables
+// KAFKA_AUTO_CREATE_TOPICS_ENABLE.
+func kafkaAutoCreatesTopics(projectPath string) bool {
+	data, err := os.ReadFile(filepath.Join(projectPath, "docker-compose.yml"))
+	if err != nil {
+		return false
+	}
+	return regexp.MustCompile(`KAFKA_AUTO_CREATE_TOPICS_ENABLE:\s*"?true"?`).Match(data)
+}
+
+// GetRuntimeChecks returns the checks of the runtime category. They are not
+// part of GetAllChecks: they need the local stack running.
+func GetRuntimeChecks() []Checker {
+	return []Checker{
+		NewComposeServicesRunningCheck(),
+		NewInfraPortsReachableCheck(),
+		NewKafkaTopicsExistCheck(),
+	}
+}
@@ -0,0 +1,179 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+const runtimeCompose = `services:
+  postgres:
+    image: postgres:15-alpine
+    ports:
+      - "127.0.0.1:5433:5432"
+  kafka:
+    image: confluentinc/cp-kafka:7.6.0
+    ports:
+      - "127.0.0.1:9093:9092"
+    environment:
+      KAFKA_AUTO_CREATE_TOPICS_ENABLE: "true"
+`
+
+const runtimeConsumerConfig = `app:
+  kafka:
+    topics:
+      orders: ${KAFKA_TOPIC_ORDERS:orders-events}
+      audit: audit-log
+`
+
+// createRuntimeProject writes a compose file and an EventConsumer config
+// for a project with PostgreSQL and Kafka.
+func createRuntimeProject(t *testing.T, compose string) (string, *config.ProjectMetadata) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "docker-compose.yml"), []byte(compose), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resources := filepath.Join(dir, "EventConsumer", "src", "main", "resources")
+	if err := os.MkdirAll(resources, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(resources, "application.yml"), []byte(runtimeConsumerConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir, &config.ProjectMetadata{
+		ProjectName:   "orders",
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "EventConsumer"},
+		Database:      config.DatabasePostgreSQL,
+		MessageBroker: config.BrokerKafka,
+	}
+}
+
+func TestParseComposePS(t *testing.T) {
+	lines := `{"Service":"postgres","State":"running","Health":"healthy"}
+{"Service":"kafka","State":"exited","Health":""}`
+	array := `[{"Service":"postgres","State":"running","Health":"healthy"},{"Service":"kafka","State":"exited","Health":""}]`
+	for name, out := range map[string]string{"ndjson": lines, "array": array} {
+		services, err := parseComposePS([]byte(out))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(services) != 2 || services[0].Service != "postgres" || services[1].State != "exited" {
+			t.Errorf("%s: unexpected services %+v", name, services)
+		}
+	}
+}
+
+func TestComposeServicesRunningCheck(t *testing.T) {
+	dir, meta := createRuntimeProject(t, runtimeCompose)
+	check := NewComposeServicesRunningCheck()
+
+	check.ps = func(string) ([]composeService, error) {
+		return []composeService{{Service: "postgres", State: "running", Health: "healthy"}, {Service: "kafka", State: "running"}}, nil
+	}
+	if r := check.Check(dir, meta); r.Status != SeverityPass {
+		t.Errorf("Expected PASS, got %s: %v", r.Status, r.Details)
+	}
+
+	check.ps = func(string) ([]composeService, error) {
+		return []composeService{{Service: "postgres", State: "running", Health: "starting"}}, nil
+	}
+	r := check.Check(dir, meta)
+	if r.Status != SeverityError || !strings.Contains(strings.Join(r.Details, "\n"), "kafka: not created") {
+		t.Errorf("Expected ERROR for the missing kafka container, got %s: %v", r.Status, r.Details)
+	}
+
+	check.ps = func(string) ([]composeService, error) {
+		return []composeService{{Service: "postgres", State: "running", Health: "unhealthy"}, {Service: "kafka", State: "running"}}, nil
+	}
+	if r := check.Check(dir, meta); r.Status != SeverityWarn {
+		t.Errorf("Expected WARN for an unhealthy service, got %s", r.Status)
+	}
+
+	check.ps = func(string) ([]composeService, error) { return nil, errors.New("Cannot connect to the Docker daemon") }
+	if r := check.Check(dir, meta); r.Status != SeverityError {
+		t.Errorf("Expected ERROR without Docker, got %s", r.Status)
+	}
+
+	if r := check.Check(dir, nil); r.Status != SeverityPass {
+		t.Errorf("Expected PASS without metadata, got %s", r.Status)
+	}
+}
+
+func TestInfraPortsReachableCheck(t *testing.T) {
+	dir, meta := createRuntimeProject(t, runtimeCompose)
+	check := NewInfraPortsReachableCheck()
+
+	var dialed []string
+	check.dial = func(address string) error {
+		dialed = append(dialed, address)
+		if address == "127.0.0.1:9093" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	r := check.Check(dir, meta)
+	if r.Status != SeverityError || !strings.Contains(r.Details[0], "kafka at 127.0.0.1:9093") {
+		t.Errorf("Expected ERROR for kafka, got %s: %v", r.Status, r.Details)
+	}
+	if strings.Join(dialed, ",") != "127.0.0.1:5433,127.0.0.1:9093" {
+		t.Errorf("Unexpected addresses dialed: %v", dialed)
+	}
+}
+
+func TestPublishedAddresses(t *testing.T) {
+	got := publishedAddresses([]string{"127.0.0.1:5433:5432", "8080:8080/tcp", "0.0.0.0:15672:15672", "9000"})
+	want := "127.0.0.1:5433,localhost:8080,localhost:15672"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Expected %s, got %v", want, got)
+	}
+}
+
+func TestKafkaTopicsExistCheck(t *testing.T) {
+	dir, meta := createRuntimeProject(t, runtimeCompose)
+	check := NewKafkaTopicsExistCheck()
+
+	check.listTopics = func(string) ([]string, error) {
+		return []string{"__consumer_offsets", "orders-events", "audit-log"}, nil
+	}
+	if r := check.Check(dir, meta); r.Status != SeverityPass {
+		t.Errorf("Expected PASS, got %s: %v", r.Status, r.Details)
+	}
+
+	check.listTopics = func(string) ([]string, error) { return []string{"orders-events"}, nil }
+	r := check.Check(dir, meta)
+	if r.Status != SeverityWarn || r.Details[0] != "audit-log" {
+		t.Errorf("Expected WARN for audit-log with auto-create on, got %s: %v", r.Status, r.Details)
+	}
+
+	noAutoCreate, meta := createRuntimeProject(t, strings.Replace(runtimeCompose, `"true"`, `"false"`, 1))
+	if r := check.Check(noAutoCreate, meta); r.Status != SeverityError {
+		t.Errorf("Expected ERROR without auto-create, got %s", r.Status)
+	}
+
+	// ${VAR:default} resolves from the environment first
+	t.Setenv("KAFKA_TOPIC_ORDERS", "orders-v2")
+	if topics := configuredKafkaTopics(dir, meta); strings.Join(topics, ",") != "audit-log,orders-v2" {
+		t.Errorf("Unexpected topics: %v", topics)
+	}
+
+	meta.MessageBroker = config.BrokerRabbitMQ
+	if r := check.Check(dir, meta); r.Status != SeverityPass {
+		t.Errorf("Expected PASS without Kafka, got %s", r.Status)
+	}
+}
+
+func TestRuntimeChecksAreOptIn(t *testing.T) {
+	for _, check := range GetAllChecks() {
+		if check.Category() == string(CategoryRuntime) {
+			t.Errorf("%s must not run by default", check.ID())
+		}
+	}
+	if got := len(GetChecksByCategory("runtime")); got != 3 {
+		t.Errorf("Expected 3 runtime checks, got %d", got)
+	}
+}
@@ -44,6 +44,7 @@ func (s Severity) MarshalJSON() ([]byte, error) {
 type CheckResult struct {
 	ID         string   `json:"id"`
 	Name       string   `json:"name"`
+	Category   string   `json:"category,omitempty"`
 	Status     Severity `json:"status"`
 	Message    string   `json:"message,omitempty"`
 	Details    []string `json:"details,omitempty"`
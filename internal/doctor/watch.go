@@ -0,0 +1,279 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/fatih/color"
+)
+
+// DefaultWatchInterval is how often `doctor --watch` polls the project.
+const DefaultWatchInterval = time.Second
+
+// watchStamp identifies a version of a watched file. A zero stamp means the
+// file does not exist.
+type watchStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher re-runs the doctor categories affected by edits to pom.xml files,
+// .trabuco.json, docker-compose.yml and the sources the code checks read,
+// and reports only the findings that changed since the previous run. Like the MCP server's project watcher it
+// polls, which keeps it free of platform-specific notification APIs.
+type Watcher struct {
+	projectPath string
+	version     string
+	categories  []string // restricts the checks; empty means every category but runtime
+	stamps      map[string]watchStamp
+	findings    map[string]CheckResult // non-passing results by check ID
+}
+
+// WatchUpdate is what one poll found: the files that changed, the categories
+// that were re-run, the findings that appeared or changed, and the findings
+// that went away.
+type WatchUpdate struct {
+	Changed    []string
+	Categories []string
+	New        []CheckResult
+	Resolved   []CheckResult
+}
+
+// NewWatcher creates a Watcher for projectPath. A non-empty category limits
+// it to that category's checks, as --check does for a single run.
+func NewWatcher(projectPath, version, category string) *Watcher {
+	w := &Watcher{
+		projectPath: projectPath,
+		version:     version,
+		findings:    make(map[string]CheckResult),
+	}
+	if category != "" {
+		w.categories = []string{category}
+	}
+	return w
+}
+
+// Start runs the watched checks once, records their findings and the state
+// of the watched files, and returns the full result to print as a baseline.
+func (w *Watcher) Start() (*DoctorResult, error) {
+	w.stamps = w.snapshot()
+	result, err := NewWithChecks(w.projectPath, w.version, w.checks(w.categories)).Run()
+	if err != nil {
+		return nil, err
+	}
+	for _, check := range result.Checks {
+		if check.Status != SeverityPass {
+			w.findings[check.ID] = check
+		}
+	}
+	return result, nil
+}
+
+// Poll compares the watched files with the last snapshot. When some changed
+// it re-runs the categories they feed and returns the difference in
+// findings; otherwise it returns nil.
+func (w *Watcher) Poll() (*WatchUpdate, error) {
+	current := w.snapshot()
+	var changed []string
+	for name, stamp := range current {
+		if w.stamps[name] != stamp {
+			changed = append(changed, name)
+		}
+	}
+	for name := range w.stamps {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(changed)
+	w.stamps = current
+
+	var categories []string
+	for _, name := range changed {
+		for _, category := range watchCategories(name) {
+			if !slices.Contains(categories, category) && (len(w.categories) == 0 || slices.Contains(w.categories, category)) {
+				categories = append(categories, category)
+			}
+		}
+	}
+	update := &WatchUpdate{Changed: changed, Categories: categories}
+	if len(categories) == 0 {
+		return update, nil
+	}
+
+	result, err := NewWithChecks(w.projectPath, w.version, w.checks(categories)).Run()
+	if err != nil {
+		return nil, err
+	}
+	for _, check := range result.Checks {
+		previous, had := w.findings[check.ID]
+		if check.Status == SeverityPass {
+			if had {
+				update.Resolved = append(update.Resolved, previous)
+				delete(w.findings, check.ID)
+			}
+			continue
+		}
+		if !had || !sameFinding(previous, check) {
+			update.New = append(update.New, check)
+		}
+		w.findings[check.ID] = check
+	}
+	return update, nil
+}
+
+// Run polls every interval until ctx is done, calling report for each poll
+// that found changed files.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration, report func(*WatchUpdate, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if update, err := w.Poll(); update != nil || err != nil {
+				report(update, err)
+			}
+		}
+	}
+}
+
+// checks returns the checks of the given categories, in GetAllChecks order.
+// No categories means every check GetAllChecks runs.
+func (w *Watcher) checks(categories []string) []Checker {
+	if len(categories) == 0 {
+		return GetAllChecks()
+	}
+	var checks []Checker
+	for _, check := range append(GetAllChecks(), GetRuntimeChecks()...) {
+		if slices.Contains(categories, check.Category()) {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// watchGlobs are the module files the doctor checks read, relative to the
+// project root.
+var watchGlobs = []string{
+	filepath.Join("*", "pom.xml"),
+	filepath.Join("*", "src", "main", "resources", "application*.yml"),
+	filepath.Join("*", "src", "main", "resources", "db", "migration", "*.sql"),
+}
+
+// snapshot stamps the watched files: the parent and module POMs,
+// .trabuco.json, docker-compose.yml, and the application.yml files, Flyway
+// migrations, ArchitectureTest classes and Application entry points of the
+// modules. They are found again on every poll so a module or file added by
+// hand is picked up.
+func (w *Watcher) snapshot() map[string]watchStamp {
+	names := []string{"pom.xml", config.MetadataFileName, "docker-compose.yml"}
+	var paths []string
+	for _, glob := range watchGlobs {
+		if matches, err := filepath.Glob(filepath.Join(w.projectPath, glob)); err == nil {
+			paths = append(paths, matches...)
+		}
+	}
+	for _, root := range []string{filepath.Join("src", "main", "java"), filepath.Join("src", "test", "java")} {
+		dirs, _ := filepath.Glob(filepath.Join(w.projectPath, "*", root))
+		for _, dir := range dirs {
+			filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+				if err == nil && !d.IsDir() && isWatchedSource(d.Name()) {
+					paths = append(paths, path)
+				}
+				return nil
+			})
+		}
+	}
+	for _, path := range paths {
+		if rel, err := filepath.Rel(w.projectPath, path); err == nil {
+			names = append(names, filepath.ToSlash(rel))
+		}
+	}
+	stamps := make(map[string]watchStamp, len(names))
+	for _, name := range names {
+		if info, err := os.Stat(filepath.Join(w.projectPath, name)); err == nil {
+			stamps[name] = watchStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+// watchCategories returns the doctor categories whose checks read the
+// watched file name.
+func watchCategories(name string) []string {
+	switch {
+	case name == "docker-compose.yml":
+		return []string{string(CategoryConsistency)}
+	case name == config.MetadataFileName, strings.HasSuffix(name, "pom.xml"):
+		return []string{string(CategoryStructure), string(CategoryMetadata), string(CategoryConsistency)}
+	case strings.HasSuffix(name, ".yml"), strings.HasSuffix(name, ".sql"), isWatchedSource(filepath.Base(name)):
+		return []string{string(CategoryCode)}
+	default:
+		return nil
+	}
+}
+
+// isWatchedSource reports whether a Java file is one the code checks read:
+// an ArchitectureTest or an Application entry point.
+func isWatchedSource(name string) bool {
+	return strings.HasSuffix(name, "ArchitectureTest.java") || strings.HasSuffix(name, "Application.java")
+}
+
+// sameFinding reports whether two results of the same check say the same
+// thing, so an unchanged warning is not printed again.
+func sameFinding(a, b CheckResult) bool {
+	return a.Status == b.Status && a.Message == b.Message && slices.Equal(a.Details, b.Details)
+}
+
+// Print writes the update to stdout: a timestamped line naming the changed
+// files, then each new finding and each resolved one.
+func (u *WatchUpdate) Print(now time.Time) {
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	red := color.New(color.FgRed)
+	cyan := color.New(color.FgCyan)
+
+	fmt.Println()
+	cyan.Printf("[%s] ", now.Format("15:04:05"))
+	fmt.Printf("Changed: %s", strings.Join(u.Changed, ", "))
+	if len(u.Categories) > 0 {
+		fmt.Printf(" (re-ran %s)", strings.Join(u.Categories, ", "))
+	}
+	fmt.Println()
+
+	if len(u.New) == 0 && len(u.Resolved) == 0 {
+		fmt.Println("  No new findings")
+		return
+	}
+	for _, check := range u.New {
+		if check.Status == SeverityError {
+			red.Printf("  \u2717 ")
+		} else {
+			yellow.Printf("  \u26a0 ")
+		}
+		fmt.Println(check.Name)
+		if check.Message != "" {
+			fmt.Printf("      %s\n", check.Message)
+		}
+		for _, detail := range check.Details {
+			fmt.Printf("      %s\n", detail)
+		}
+	}
+	for _, check := range u.Resolved {
+		green.Printf("  \u2713 ")
+		fmt.Printf("%s (resolved)\n", check.Name)
+	}
+}
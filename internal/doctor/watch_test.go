@@ -0,0 +1,149 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	projectDir := createTestTrabucoProject(t)
+	defer os.RemoveAll(projectDir)
+
+	w := NewWatcher(projectDir, "1.0.0", "")
+	if _, err := w.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if update, err := w.Poll(); err != nil || update != nil {
+		t.Fatalf("expected no update without changes, got %+v, %v", update, err)
+	}
+
+	setModules := func(modules ...string) {
+		t.Helper()
+		meta, err := config.LoadMetadata(projectDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		meta.Modules = modules
+		if err := config.SaveMetadata(projectDir, meta); err != nil {
+			t.Fatal(err)
+		}
+		// Make the change visible even on filesystems with coarse timestamps
+		later := time.Now().Add(time.Duration(len(modules)) * time.Minute)
+		os.Chtimes(filepath.Join(projectDir, config.MetadataFileName), later, later)
+	}
+
+	setModules("Model", "API", "Worker")
+	update, err := w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if update == nil || len(update.Changed) != 1 || update.Changed[0] != config.MetadataFileName {
+		t.Fatalf("expected a change to %s, got %+v", config.MetadataFileName, update)
+	}
+	if len(update.New) == 0 {
+		t.Fatal("modules missing from the POM should be a new finding")
+	}
+	if found := update.New[0].ID; found != "METADATA_SYNC" {
+		t.Errorf("expected METADATA_SYNC, got %s", found)
+	}
+
+	// Touching the file again without changing the finding prints nothing new
+	later := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(projectDir, config.MetadataFileName), later, later)
+	update, err = w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if update == nil || len(update.New) != 0 || len(update.Resolved) != 0 {
+		t.Errorf("an unchanged finding should not be reported again, got %+v", update)
+	}
+
+	setModules("Model", "API")
+	update, err = w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if update == nil || len(update.Resolved) == 0 || update.Resolved[0].ID != "METADATA_SYNC" {
+		t.Errorf("expected METADATA_SYNC to be resolved, got %+v", update)
+	}
+}
+
+func TestWatcherPollCategory(t *testing.T) {
+	projectDir := createTestTrabucoProject(t)
+	defer os.RemoveAll(projectDir)
+
+	w := NewWatcher(projectDir, "1.0.0", "metadata")
+	if _, err := w.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// docker-compose.yml only feeds the consistency checks
+	if err := os.WriteFile(filepath.Join(projectDir, "docker-compose.yml"), []byte("services: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	update, err := w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if update == nil || len(update.Categories) != 0 {
+		t.Errorf("expected the change to re-run no metadata checks, got %+v", update)
+	}
+
+	// A new module POM is picked up and re-runs the metadata checks
+	if err := os.MkdirAll(filepath.Join(projectDir, "Worker"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "Worker", "pom.xml"), []byte("<project/>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	update, err = w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if update == nil || len(update.Changed) != 1 || update.Changed[0] != "Worker/pom.xml" {
+		t.Fatalf("expected Worker/pom.xml to change, got %+v", update)
+	}
+	if len(update.Categories) != 1 || update.Categories[0] != "metadata" {
+		t.Errorf("expected only the metadata category, got %v", update.Categories)
+	}
+}
+
+func TestWatcherPollCode(t *testing.T) {
+	projectDir := createTestTrabucoProject(t)
+	defer os.RemoveAll(projectDir)
+
+	resources := filepath.Join(projectDir, "API", "src", "main", "resources")
+	if err := os.MkdirAll(resources, 0755); err != nil {
+		t.Fatal(err)
+	}
+	appYAML := filepath.Join(resources, "application.yml")
+	if err := os.WriteFile(appYAML, []byte("server:\n  port: 8080\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWatcher(projectDir, "1.0.0", "")
+	if _, err := w.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if err := os.WriteFile(appYAML, []byte("server:\n  port: 9090\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(appYAML, later, later)
+
+	update, err := w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if update == nil || len(update.Changed) != 1 || update.Changed[0] != "API/src/main/resources/application.yml" {
+		t.Fatalf("expected API/src/main/resources/application.yml to change, got %+v", update)
+	}
+	if len(update.Categories) != 1 || update.Categories[0] != string(CategoryCode) {
+		t.Errorf("expected only the code category to re-run, got %v", update.Categories)
+	}
+}
@@ -0,0 +1,224 @@
+// Package envcontract derives the environment variable contract of a
+// Trabuco project from the ${VAR:default} placeholders of its modules'
+// Spring configuration, writes it as ENV.md and .env.schema, and checks a
+// local .env against it.
+package envcontract
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DocFileName is the human-readable contract at the project root
+	DocFileName = "ENV.md"
+	// SchemaFileName is the typed contract `trabuco env check` reads
+	SchemaFileName = ".env.schema"
+	// DefaultProfile names the base application.yml among the profiles
+	DefaultProfile = "default"
+)
+
+// Variable types, inferred from the default or, without one, the name
+const (
+	TypeString   = "string"
+	TypeInteger  = "integer"
+	TypeBoolean  = "boolean"
+	TypeDuration = "duration"
+	TypeURL      = "url"
+)
+
+// Variable is one environment variable the configuration references
+type Variable struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Default string `json:"default,omitempty"`
+	// Required is set when a module's base configuration references the
+	// variable without a default, so the application cannot start without it
+	Required bool `json:"required"`
+	// RequiredIn lists the other profiles (dev, staging, prod) that
+	// reference it without a default
+	RequiredIn []string `json:"requiredIn,omitempty"`
+	Modules    []string `json:"modules"`
+	Profiles   []string `json:"profiles"`
+}
+
+var (
+	// envNamePattern tells environment variables from Spring property
+	// references such as ${spring.application.name}
+	envNamePattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+	// configFilePattern matches application.yml and its profiles
+	configFilePattern = regexp.MustCompile(`^application(?:-([\w-]+))?\.(?:ya?ml|properties)$`)
+	durationPattern   = regexp.MustCompile(`^(?:\d+(?:ns|us|ms|s|m|h|d)?|P(?:T?[\d.]+[DHMS])+)$`)
+)
+
+// reference is one ${NAME[:default]} occurrence
+type reference struct {
+	name       string
+	def        string
+	hasDefault bool
+}
+
+// placeholders returns the placeholders of s, including the ones nested
+// in defaults (${A:${B:x}} references A and B)
+func placeholders(s string) []reference {
+	var refs []reference
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] != '$' || s[i+1] != '{' {
+			continue
+		}
+		depth, end := 0, -1
+		for j := i + 2; j < len(s) && end < 0; j++ {
+			switch {
+			case s[j] == '{':
+				depth++
+			case s[j] == '}' && depth == 0:
+				end = j
+			case s[j] == '}':
+				depth--
+			}
+		}
+		if end < 0 {
+			break
+		}
+		body := s[i+2 : end]
+		ref := reference{name: body}
+		if k := strings.Index(body, ":"); k >= 0 {
+			ref = reference{name: body[:k], def: body[k+1:], hasDefault: true}
+			refs = append(refs, placeholders(ref.def)...)
+		}
+		if envNamePattern.MatchString(ref.name) {
+			refs = append(refs, ref)
+		}
+		i = end
+	}
+	return refs
+}
+
+// Scan collects the variables referenced by the application*.yml (and
+// .properties) files under <module>/src/main/resources, sorted by name
+func Scan(projectPath string) ([]Variable, error) {
+	files, err := filepath.Glob(filepath.Join(projectPath, "*", "src", "main", "resources", "application*"))
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]*Variable{}
+	defaults := map[string]map[string]string{} // name -> profile -> default
+	for _, path := range files {
+		m := configFilePattern.FindStringSubmatch(filepath.Base(path))
+		if m == nil {
+			continue
+		}
+		profile := m[1]
+		if profile == "" {
+			profile = DefaultProfile
+		}
+		rel, _ := filepath.Rel(projectPath, path)
+		module := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+				continue
+			}
+			for _, ref := range placeholders(line) {
+				v := byName[ref.name]
+				if v == nil {
+					v = &Variable{Name: ref.name}
+					byName[ref.name] = v
+					defaults[ref.name] = map[string]string{}
+				}
+				v.Modules = addSorted(v.Modules, module)
+				v.Profiles = addSorted(v.Profiles, profile)
+				switch {
+				case !ref.hasDefault && profile == DefaultProfile:
+					v.Required = true
+				case !ref.hasDefault:
+					v.RequiredIn = addSorted(v.RequiredIn, profile)
+				default:
+					if _, seen := defaults[ref.name][profile]; !seen {
+						defaults[ref.name][profile] = ref.def
+					}
+				}
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+	}
+
+	vars := make([]Variable, 0, len(byName))
+	for name, v := range byName {
+		// The base configuration's default is the one a local run sees
+		if def, ok := defaults[name][DefaultProfile]; ok {
+			v.Default = def
+		} else {
+			for _, p := range v.Profiles {
+				if def, ok := defaults[name][p]; ok {
+					v.Default = def
+					break
+				}
+			}
+		}
+		if v.Required {
+			v.Default = ""
+		}
+		v.Type = inferType(name, v.Default)
+		vars = append(vars, *v)
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars, nil
+}
+
+func addSorted(list []string, s string) []string {
+	i := sort.SearchStrings(list, s)
+	if i < len(list) && list[i] == s {
+		return list
+	}
+	return append(list[:i], append([]string{s}, list[i:]...)...)
+}
+
+// inferType guesses a variable's type from its default, or from its name
+// when the default says nothing (empty or another placeholder)
+func inferType(name, def string) string {
+	if def != "" && !strings.Contains(def, "${") {
+		switch {
+		case def == "true" || def == "false":
+			return TypeBoolean
+		case isInteger(def):
+			return TypeInteger
+		case durationPattern.MatchString(def):
+			return TypeDuration
+		case strings.Contains(def, "://"):
+			return TypeURL
+		}
+		return TypeString
+	}
+	switch {
+	case strings.HasSuffix(name, "_PORT") || strings.HasSuffix(name, "_SIZE"):
+		return TypeInteger
+	case strings.HasSuffix(name, "_ENABLED"):
+		return TypeBoolean
+	case strings.HasSuffix(name, "_URL") || strings.HasSuffix(name, "_URI") || strings.HasSuffix(name, "_ENDPOINT"):
+		return TypeURL
+	case strings.HasSuffix(name, "_TIMEOUT"):
+		return TypeDuration
+	}
+	return TypeString
+}
+
+func isInteger(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
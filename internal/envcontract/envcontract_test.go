@@ -0,0 +1,126 @@
+package envcontract
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	refs := placeholders("url: jdbc:postgresql://${DB_HOST:localhost}:${DB_PORT:5433}/${spring.application.name} ${DB_READ_USERNAME:${DB_USERNAME:postgres}} ${API_KEY}")
+	var got []string
+	for _, r := range refs {
+		got = append(got, r.name+"="+r.def)
+	}
+	want := []string{"DB_HOST=localhost", "DB_PORT=5433", "DB_USERNAME=postgres", "DB_READ_USERNAME=${DB_USERNAME:postgres}", "API_KEY="}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("placeholders = %v, want %v", got, want)
+	}
+	if refs[4].hasDefault {
+		t.Error("${API_KEY} has no default")
+	}
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "API", "src", "main", "resources", "application.yml"), `server:
+  port: ${SERVER_PORT:8080}
+  # port: ${COMMENTED_OUT:1}
+spring:
+  datasource:
+    password: ${DB_PASSWORD:postgres}
+    url: ${DB_URL}
+  lifecycle:
+    timeout-per-shutdown-phase: ${SHUTDOWN_TIMEOUT:30s}
+`)
+	writeFile(t, filepath.Join(dir, "API", "src", "main", "resources", "application-prod.yml"), "spring:\n  datasource:\n    password: ${DB_PASSWORD}\n")
+	writeFile(t, filepath.Join(dir, "Worker", "src", "main", "resources", "application.yml"), "server:\n  port: ${SERVER_PORT:8081}\nflags: ${FEATURE_ENABLED:true}\n")
+	writeFile(t, filepath.Join(dir, "Worker", "src", "main", "resources", "logback.xml"), "${IGNORED:1}")
+
+	vars, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]Variable{}
+	var names []string
+	for _, v := range vars {
+		byName[v.Name] = v
+		names = append(names, v.Name)
+	}
+	if want := []string{"DB_PASSWORD", "DB_URL", "FEATURE_ENABLED", "SERVER_PORT", "SHUTDOWN_TIMEOUT"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("variables = %v, want %v", names, want)
+	}
+
+	if v := byName["DB_PASSWORD"]; v.Required || !reflect.DeepEqual(v.RequiredIn, []string{"prod"}) || v.Default != "postgres" {
+		t.Errorf("DB_PASSWORD = %+v; required in prod only, default from the base profile", v)
+	}
+	if v := byName["DB_URL"]; !v.Required || v.Type != TypeURL {
+		t.Errorf("DB_URL = %+v; required url", v)
+	}
+	if v := byName["SERVER_PORT"]; v.Type != TypeInteger || !reflect.DeepEqual(v.Modules, []string{"API", "Worker"}) {
+		t.Errorf("SERVER_PORT = %+v", v)
+	}
+	if byName["SHUTDOWN_TIMEOUT"].Type != TypeDuration || byName["FEATURE_ENABLED"].Type != TypeBoolean {
+		t.Errorf("types = %s, %s", byName["SHUTDOWN_TIMEOUT"].Type, byName["FEATURE_ENABLED"].Type)
+	}
+
+	// The schema reads back as written
+	if err := Write(dir, "demo"); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSchema(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, vars) {
+		t.Errorf("LoadSchema = %+v\nwant %+v", loaded, vars)
+	}
+	doc, _ := os.ReadFile(filepath.Join(dir, DocFileName))
+	if !strings.Contains(string(doc), "| `DB_PASSWORD` | string | `postgres` | in prod | API |") {
+		t.Errorf("ENV.md:\n%s", doc)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	vars := []Variable{
+		{Name: "DB_URL", Type: TypeURL, Required: true, Modules: []string{"API"}},
+		{Name: "DB_PORT", Type: TypeInteger, Default: "5433"},
+		{Name: "TIMEOUT", Type: TypeDuration, Default: "30s"},
+		{Name: "ENABLED", Type: TypeBoolean, Default: "true"},
+	}
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".env"), `# local overrides
+export DB_URL="jdbc:postgresql://localhost:5433/demo"
+DB_PORT=${OTHER_PORT}
+TIMEOUT=PT1M
+ENABLED=TRUE # inline comment
+`)
+	env, err := ParseEnvFile(filepath.Join(dir, ".env"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := Validate(vars, env); !r.OK() || len(r.Warnings) != 0 {
+		t.Errorf("valid .env reported %+v", r)
+	}
+
+	r := Validate(vars, map[string]string{"DB_PORT": "54x", "TIMEOUT": "soon", "ENABLED": "yes", "DB_PROT": "1"})
+	if len(r.Errors) != 4 {
+		t.Errorf("errors = %v, want missing DB_URL and three type errors", r.Errors)
+	}
+	if len(r.Warnings) != 1 || !strings.HasPrefix(r.Warnings[0], "DB_PROT") {
+		t.Errorf("warnings = %v, want the unread DB_PROT", r.Warnings)
+	}
+}
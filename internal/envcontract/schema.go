@@ -0,0 +1,244 @@
+package envcontract
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RenderSchema writes the variables in .env.schema form: a .env file whose
+// entries carry their default, preceded by comment lines with the modules
+// and @-decorators for the type and requirement
+func RenderSchema(vars []Variable) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Environment contract, generated by Trabuco from the modules' application*.yml.\n")
+	b.WriteString("# Do not edit: 'trabuco doctor --fix' regenerates it after configuration changes.\n")
+	b.WriteString("# 'trabuco env check' validates .env against it.\n")
+	for _, v := range vars {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "# Modules: %s\n", strings.Join(v.Modules, ", "))
+		decorators := []string{"@type=" + v.Type}
+		if v.Required {
+			decorators = append([]string{"@required"}, decorators...)
+		}
+		if len(v.RequiredIn) > 0 {
+			decorators = append(decorators, "@requiredIn="+strings.Join(v.RequiredIn, ","))
+		}
+		if len(v.Profiles) > 0 {
+			decorators = append(decorators, "@profiles="+strings.Join(v.Profiles, ","))
+		}
+		fmt.Fprintf(&b, "# %s\n", strings.Join(decorators, " "))
+		fmt.Fprintf(&b, "%s=%s\n", v.Name, v.Default)
+	}
+	return b.Bytes()
+}
+
+// RenderDoc writes the variables as the ENV.md table
+func RenderDoc(projectName string, vars []Variable) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s environment variables\n\n", projectName)
+	b.WriteString("Every environment variable the modules' `application*.yml` read, generated by Trabuco.\n")
+	b.WriteString("Set them in `.env` for local runs (see `.env.example`) and in the deployment for the other profiles.\n")
+	b.WriteString("`trabuco env check` validates `.env` against `" + SchemaFileName + "`; `trabuco doctor --fix` regenerates both files.\n\n")
+	if len(vars) == 0 {
+		b.WriteString("The configuration reads no environment variables.\n")
+		return b.Bytes()
+	}
+	b.WriteString("| Variable | Type | Default | Required | Modules |\n")
+	b.WriteString("|----------|------|---------|----------|---------|\n")
+	for _, v := range vars {
+		def := "—"
+		if !v.Required && v.Default != "" {
+			def = "`" + strings.ReplaceAll(v.Default, "|", `\|`) + "`"
+		} else if !v.Required && len(v.RequiredIn) == 0 {
+			def = "(empty)"
+		}
+		required := ""
+		switch {
+		case v.Required:
+			required = "yes"
+		case len(v.RequiredIn) > 0:
+			required = "in " + strings.Join(v.RequiredIn, ", ")
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", v.Name, v.Type, def, required, strings.Join(v.Modules, ", "))
+	}
+	return b.Bytes()
+}
+
+// Write scans the project and writes ENV.md and .env.schema. A project
+// whose configuration reads no variables gets neither.
+func Write(projectPath, projectName string) error {
+	vars, err := Scan(projectPath)
+	if err != nil {
+		return err
+	}
+	if len(vars) == 0 {
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(projectPath, SchemaFileName), RenderSchema(vars), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(projectPath, DocFileName), RenderDoc(projectName, vars), 0644)
+}
+
+// LoadSchema reads the project's .env.schema
+func LoadSchema(projectPath string) ([]Variable, error) {
+	f, err := os.Open(filepath.Join(projectPath, SchemaFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var vars []Variable
+	var pending Variable
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "":
+			pending = Variable{}
+		case strings.HasPrefix(text, "# Modules:"):
+			for _, m := range strings.Split(strings.TrimPrefix(text, "# Modules:"), ",") {
+				if m = strings.TrimSpace(m); m != "" {
+					pending.Modules = append(pending.Modules, m)
+				}
+			}
+		case strings.HasPrefix(text, "# @"):
+			for _, d := range strings.Fields(strings.TrimPrefix(text, "#")) {
+				key, value, _ := strings.Cut(d, "=")
+				switch key {
+				case "@required":
+					pending.Required = true
+				case "@type":
+					pending.Type = value
+				case "@requiredIn":
+					pending.RequiredIn = strings.Split(value, ",")
+				case "@profiles":
+					pending.Profiles = strings.Split(value, ",")
+				}
+			}
+		case strings.HasPrefix(text, "#"):
+		default:
+			name, value, ok := strings.Cut(text, "=")
+			if !ok || !envNamePattern.MatchString(name) {
+				return nil, fmt.Errorf("%s:%d: expected NAME=default", SchemaFileName, line)
+			}
+			pending.Name, pending.Default = name, value
+			if pending.Type == "" {
+				pending.Type = TypeString
+			}
+			vars = append(vars, pending)
+			pending = Variable{}
+		}
+	}
+	return vars, scanner.Err()
+}
+
+// ParseEnvFile reads a .env file: NAME=value lines, optionally prefixed
+// with export and quoted, with # comments
+func ParseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	env := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		if !ok {
+			continue
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		env[name] = value
+	}
+	return env, scanner.Err()
+}
+
+// Result is the outcome of validating a .env against the contract
+type Result struct {
+	// Errors would stop a local run: missing required variables and
+	// values of the wrong type
+	Errors []string `json:"errors,omitempty"`
+	// Warnings are variables the configuration does not read, usually a
+	// typo or a leftover
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// OK reports whether the .env satisfies the contract
+func (r Result) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// Validate checks env, the content of a local .env, against the contract.
+// Only the base profile's requirements apply: a local run uses it.
+func Validate(vars []Variable, env map[string]string) Result {
+	var r Result
+	known := map[string]bool{}
+	for _, v := range vars {
+		known[v.Name] = true
+		value, set := env[v.Name]
+		if !set || value == "" {
+			if v.Required {
+				r.Errors = append(r.Errors, fmt.Sprintf("%s is required by %s and not set", v.Name, strings.Join(v.Modules, ", ")))
+			}
+			continue
+		}
+		if err := checkType(v.Type, value); err != nil {
+			r.Errors = append(r.Errors, fmt.Sprintf("%s=%s: %v", v.Name, value, err))
+		}
+	}
+	names := make([]string, 0, len(env))
+	for name := range env {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s is not read by any module's configuration", name))
+	}
+	return r
+}
+
+// checkType validates a value against a variable type. Values that
+// reference other variables are left to Spring.
+func checkType(typ, value string) error {
+	if strings.Contains(value, "${") {
+		return nil
+	}
+	switch typ {
+	case TypeInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("not an integer")
+		}
+	case TypeBoolean:
+		if v := strings.ToLower(value); v != "true" && v != "false" {
+			return fmt.Errorf("not a boolean (true or false)")
+		}
+	case TypeDuration:
+		if !durationPattern.MatchString(value) {
+			return fmt.Errorf("not a duration (such as 500ms, 30s, 5m or PT30S)")
+		}
+	case TypeURL:
+		if !strings.Contains(value, "://") {
+			return fmt.Errorf("not a URL")
+		}
+	}
+	return nil
+}
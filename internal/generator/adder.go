@@ -4,29 +4,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/envcontract"
+	"github.com/arianlopezc/Trabuco/internal/plugin"
 	"github.com/arianlopezc/Trabuco/internal/templates"
+	"github.com/arianlopezc/Trabuco/internal/todos"
+	"github.com/arianlopezc/Trabuco/internal/versions"
 	"github.com/fatih/color"
 )
 
-// Version constants for dependencies added during module addition
-const (
-	JobRunrVersion           = "8.4.0"
-	LogstashEncoderVersion   = "8.0"
-	SpringDocVersion         = "2.7.0"
-	JaCoCoVersion            = "0.8.12"
-	SpringCloudAWSVersion    = "3.2.0"
-	SpringCloudGCPVersion    = "5.8.0"
-	LocalStackImageVersion   = "3.0"
-	ConfluentKafkaVersion    = "7.5.0"
-
-	EnforcerVersion          = "3.5.0"
-	SpotlessVersion          = "2.44.4"
-	ArchUnitVersion          = "1.4.0"
-)
-
 // Module, database, and broker constants are defined in config package
 // Use config.ModuleModel, config.DatabasePostgreSQL, config.BrokerKafka, etc.
 
@@ -38,6 +27,10 @@ type ModuleAdder struct {
 	engine      *templates.Engine
 	backup      *BackupManager
 	version     string
+	report      *OperationReport // Set by a successful Add
+	args        []string         // Invocation recorded in .trabuco/history.jsonl
+	forceDocs   bool             // Overwrite docs instead of merging managed regions
+	docsKept    []KeptDoc        // Docs regenerateDocs could not merge into
 }
 
 // NewModuleAdder creates a new ModuleAdder
@@ -48,7 +41,7 @@ func NewModuleAdder(projectPath string, metadata *config.ProjectMetadata, versio
 		projectPath: projectPath,
 		metadata:    metadata,
 		config:      cfg,
-		engine:      templates.NewEngine(),
+		engine:      templates.NewEngine().WithProjectOverrides(projectPath),
 		backup:      NewBackupManager(projectPath, enableBackup),
 		version:     version,
 	}
@@ -69,8 +62,6 @@ func (a *ModuleAdder) GetConfig() *config.ProjectConfig {
 // docs out of sync. The defer ensures every error path rolls back to
 // the pre-add snapshot.
 func (a *ModuleAdder) Add(module string, database, nosqlDatabase, messageBroker string) (err error) {
-	green := color.New(color.FgGreen)
-
 	// Validate module can be added
 	if err = a.ValidateCanAdd(module); err != nil {
 		return err
@@ -81,6 +72,12 @@ func (a *ModuleAdder) Add(module string, database, nosqlDatabase, messageBroker
 		return err
 	}
 
+	// Without a flag, fall back to the recorded broker (the one an existing
+	// Events publisher already uses)
+	if isBrokerModule(module) && messageBroker == "" {
+		messageBroker = a.metadata.MessageBroker
+	}
+
 	// Resolve dependencies
 	dependencies := a.ResolveDependencies(module)
 	// Create a new slice to avoid modifying the original dependencies slice
@@ -88,21 +85,33 @@ func (a *ModuleAdder) Add(module string, database, nosqlDatabase, messageBroker
 	allModules = append(allModules, dependencies...)
 	allModules = append(allModules, module)
 
+	tracker := newOperationTracker(a.projectPath)
+
 	// Backup existing files
+	a.backup.SetOperation("add "+module, a.version)
 	filesToBackup := GetFilesToBackup(module)
 	if err = a.backup.BackupAll(filesToBackup); err != nil {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
+	if err = a.backup.WriteManifest(BackupInProgress); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
 
 	// Restore on any error past this point. Covers the addModule loop
 	// AND every sequential mutation that follows (parent POM, docker-
-	// compose, Model/Shared/API modules, metadata, docs). Cleanup of
-	// the backup itself runs only on success at the bottom.
+	// compose, Model/Shared/API modules, metadata, docs). A clean
+	// rollback leaves nothing to restore, so its backup is dropped; a
+	// failed one keeps the backup as a restore point.
 	defer func() {
 		if err != nil {
 			if restoreErr := a.backup.Restore(); restoreErr != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to restore backup: %v\n", restoreErr)
+				if manifestErr := a.backup.WriteManifest(BackupRestoreFailed); manifestErr != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to update backup manifest: %v\n", manifestErr)
+				}
 				a.backup.PrintRestoreInstructions()
+			} else if cleanupErr := a.backup.Cleanup(); cleanupErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to cleanup backup: %v\n", cleanupErr)
 			}
 		}
 	}()
@@ -115,32 +124,50 @@ func (a *ModuleAdder) Add(module string, database, nosqlDatabase, messageBroker
 		if err = a.addModule(mod); err != nil {
 			return fmt.Errorf("failed to add %s: %w", mod, err)
 		}
-		green.Printf("  \u2713 Created %s module\n", mod)
 	}
 
 	// Update parent POM (modules and properties)
 	if err = a.updateParentPOM(allModules, messageBroker); err != nil {
 		return fmt.Errorf("failed to update parent POM: %w", err)
 	}
-	green.Println("  \u2713 Updated pom.xml")
 
 	// Update docker-compose if needed
 	if err = a.updateDockerCompose(module, database, nosqlDatabase, messageBroker); err != nil {
 		return fmt.Errorf("failed to update docker-compose: %w", err)
 	}
+	if err = a.syncComposeAppServices(module, allModules); err != nil {
+		return fmt.Errorf("failed to update docker-compose: %w", err)
+	}
 
 	// Update Model module if needed
 	if err = a.updateModelModule(module); err != nil {
 		return fmt.Errorf("failed to update Model module: %w", err)
 	}
 
+	// Add the error catalog the API classes answer with, when Model
+	// predates it
+	if err = a.ensureErrorCatalog(); err != nil {
+		return fmt.Errorf("failed to update Model module: %w", err)
+	}
+
+	// Publish the test-jars the Shared, API and Worker POMs depend on,
+	// when the project predates them
+	if err = a.ensureTestFixtures(); err != nil {
+		return fmt.Errorf("failed to add test fixtures: %w", err)
+	}
+
 	// Update Shared module if needed (when adding datastore)
 	if err = a.updateSharedModule(module); err != nil {
 		return fmt.Errorf("failed to update Shared module: %w", err)
 	}
 
+	// Update the Shared ArchitectureTest if needed (its rules depend on modules)
+	if err = a.updateArchitectureTest(allModules); err != nil {
+		return fmt.Errorf("failed to update ArchitectureTest: %w", err)
+	}
+
 	// Update API module if needed (to include new packages in ComponentScan)
-	if err = a.updateAPIModule(module); err != nil {
+	if err = a.updateAPIModule(allModules); err != nil {
 		return fmt.Errorf("failed to update API module: %w", err)
 	}
 
@@ -149,30 +176,80 @@ func (a *ModuleAdder) Add(module string, database, nosqlDatabase, messageBroker
 	if err = config.SaveMetadata(a.projectPath, a.metadata); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
-	green.Println("  ✓ Updated .trabuco.json")
+
+	// Regenerate module-info.java: the new module changes who requires and
+	// exports what
+	if err = a.regenerateModuleInfos(); err != nil {
+		return fmt.Errorf("failed to regenerate module-info.java: %w", err)
+	}
 
 	// Regenerate documentation files (README.md and AI agent files)
 	if err = a.regenerateDocs(); err != nil {
 		return fmt.Errorf("failed to regenerate documentation: %w", err)
 	}
-	green.Println("  ✓ Updated documentation files")
 
-	// Cleanup old backups first, then current backup after successful
-	// operation. Cleanup-warning errors are intentionally NOT assigned
-	// to `err` — a backup-cleanup failure must not trigger restore
+	// Record what changed (LAST_OPERATION.md is best-effort)
+	a.report = tracker.report("add", a.config, allModules)
+	a.report.DocsKept = a.docsKept
+	if saveErr := a.report.Save(a.projectPath); saveErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write %s: %v\n", LastOperationFile, saveErr)
+	}
+	if histErr := config.AppendHistory(a.projectPath, a.report.HistoryEntry(a.version, a.invocation())); histErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write %s: %v\n", config.HistoryFileName, histErr)
+	}
+	if todoErr := todos.RecordGenerated(a.projectPath, a.version, a.report.CreatedFiles()); todoErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write %s: %v\n", todos.IndexFileName, todoErr)
+	}
+
+	// Keep the backup as a restore point that undoes this add, and prune
+	// beyond the retention limit. Errors are intentionally NOT assigned
+	// to `err` — a backup bookkeeping failure must not trigger restore
 	// (which would undo the successful Add).
-	if cleanupErr := a.backup.CleanupOldBackups(); cleanupErr != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to cleanup old backups: %v\n", cleanupErr)
+	for _, f := range a.report.CreatedFiles() {
+		a.backup.TrackCreatedFile(f)
 	}
-	if cleanupErr := a.backup.Cleanup(); cleanupErr != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to cleanup backup: %v\n", cleanupErr)
+	if manifestErr := a.backup.WriteManifest(BackupCompleted); manifestErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update backup manifest: %v\n", manifestErr)
+	}
+	if _, pruneErr := PruneBackups(a.projectPath, DefaultBackupRetention); pruneErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to prune old backups: %v\n", pruneErr)
 	}
 
 	return nil
 }
 
+// Report returns the summary of the last successful Add, or nil
+func (a *ModuleAdder) Report() *OperationReport {
+	return a.report
+}
+
+// SetInvocation sets the arguments recorded in the project history
+// (default: the process command line)
+func (a *ModuleAdder) SetInvocation(args []string) {
+	a.args = args
+}
+
+// SetForceDocs makes Add overwrite README.md and the AI agent context
+// files instead of merging into their managed regions, discarding any
+// user edits to them
+func (a *ModuleAdder) SetForceDocs(force bool) {
+	a.forceDocs = force
+}
+
+func (a *ModuleAdder) invocation() []string {
+	if a.args != nil {
+		return a.args
+	}
+	return defaultInvocation()
+}
+
 // ValidateCanAdd checks if a module can be added
 func (a *ModuleAdder) ValidateCanAdd(module string) error {
+	// A modulith has no module directories to add to
+	if a.metadata.Architecture == config.ArchitectureModulith {
+		return fmt.Errorf("cannot add %s: modules cannot be added to a %s project, whose modules are packages of %s", module, config.ArchitectureModulith, config.ModulithModule)
+	}
+
 	// Check if module already exists
 	if a.metadata.HasModule(module) {
 		return fmt.Errorf("module %s already exists in this project", module)
@@ -193,7 +270,7 @@ func (a *ModuleAdder) ValidateCanAdd(module string) error {
 	}
 
 	// Check if internal module
-	if m.Internal {
+	if m.Internal && !m.Addable {
 		return fmt.Errorf("cannot add %s directly: it's automatically included", module)
 	}
 
@@ -237,6 +314,9 @@ func (a *ModuleAdder) DryRun(module string) *DryRunResult {
 	result.FilesModified = append(result.FilesModified, "pom.xml", ".trabuco.json", "README.md")
 	if needsDockerComposeUpdate(module) {
 		result.FilesModified = append(result.FilesModified, "docker-compose.yml")
+	} else if _, err := os.Stat(filepath.Join(a.projectPath, "docker-compose.yml")); err == nil && hasRuntimeModule(allModules) {
+		// The "app" profile services follow the runtime modules
+		result.FilesModified = append(result.FilesModified, "docker-compose.yml")
 	}
 	// Add AI agent files that are present
 	for _, agent := range a.config.GetSelectedAIAgents() {
@@ -246,6 +326,15 @@ func (a *ModuleAdder) DryRun(module string) *DryRunResult {
 	if a.config.HasCIProvider("github") {
 		result.FilesModified = append(result.FilesModified, ".github/workflows/ci.yml")
 	}
+	// CODEOWNERS gets the new modules' entries, and each gets a boundary doc
+	if a.config.HasCodeOwners() {
+		result.FilesModified = append(result.FilesModified, config.CodeOwnersFile)
+		for _, mod := range allModules {
+			if !a.metadata.HasModule(mod) {
+				result.FilesCreated = append(result.FilesCreated, config.ModuleBoundaryDoc(mod))
+			}
+		}
+	}
 
 	return result
 }
@@ -289,21 +378,33 @@ func (d *DryRunResult) Print() {
 func (a *ModuleAdder) validateOptions(module, database, nosqlDatabase, messageBroker string) error {
 	switch module {
 	case config.ModuleSQLDatastore:
-		if database != "" && database != config.DatabasePostgreSQL && database != config.DatabaseMySQL {
-			return fmt.Errorf("invalid database type: %s (must be '%s' or '%s')", database, config.DatabasePostgreSQL, config.DatabaseMySQL)
+		if database != "" && database != config.DatabasePostgreSQL && database != config.DatabaseMySQL && database != config.DatabaseMariaDB {
+			return fmt.Errorf("invalid database type: %s (must be one of %s)", database, strings.Join(config.SQLDatabases, ", "))
 		}
 	case config.ModuleNoSQLDatastore:
-		if nosqlDatabase != "" && nosqlDatabase != config.DatabaseMongoDB && nosqlDatabase != config.DatabaseRedis {
-			return fmt.Errorf("invalid NoSQL database type: %s (must be '%s' or '%s')", nosqlDatabase, config.DatabaseMongoDB, config.DatabaseRedis)
+		if nosqlDatabase != "" && !config.IsValidNoSQLDatabase(nosqlDatabase) {
+			return fmt.Errorf("invalid NoSQL database type: %s (must be one of %s)", nosqlDatabase, strings.Join(config.NoSQLDatabases, ", "))
 		}
-	case config.ModuleEventConsumer:
-		if messageBroker != "" && messageBroker != config.BrokerKafka && messageBroker != config.BrokerRabbitMQ && messageBroker != config.BrokerSQS && messageBroker != config.BrokerPubSub {
-			return fmt.Errorf("invalid message broker: %s (must be '%s', '%s', '%s', or '%s')", messageBroker, config.BrokerKafka, config.BrokerRabbitMQ, config.BrokerSQS, config.BrokerPubSub)
+	case config.ModuleEvents, config.ModuleEventConsumer:
+		if messageBroker != "" && !config.IsValidMessageBroker(messageBroker) {
+			return fmt.Errorf("invalid message broker: %s (must be one of: %s)", messageBroker, strings.Join(config.MessageBrokers, ", "))
+		}
+		if existing := a.metadata.MessageBroker; a.metadata.HasModule(config.ModuleEvents) && messageBroker != "" && messageBroker != existing {
+			return fmt.Errorf("cannot use message broker %s: this project already publishes events with %s", messageBroker, existing)
+		}
+		// A publisher without a broker has nowhere to send events
+		if module == config.ModuleEvents && messageBroker == "" && a.metadata.MessageBroker == "" {
+			return fmt.Errorf("adding %s requires a message broker (one of: %s)", module, strings.Join(config.MessageBrokers, ", "))
 		}
 	}
 	return nil
 }
 
+// isBrokerModule reports whether a module needs the project's message broker
+func isBrokerModule(module string) bool {
+	return module == config.ModuleEvents || module == config.ModuleEventConsumer
+}
+
 // updateConfig updates the config with new options
 func (a *ModuleAdder) updateConfig(module, database, nosqlDatabase, messageBroker string) {
 	if module == config.ModuleSQLDatastore && database != "" {
@@ -312,7 +413,7 @@ func (a *ModuleAdder) updateConfig(module, database, nosqlDatabase, messageBroke
 	if module == config.ModuleNoSQLDatastore && nosqlDatabase != "" {
 		a.config.NoSQLDatabase = nosqlDatabase
 	}
-	if module == config.ModuleEventConsumer && messageBroker != "" {
+	if isBrokerModule(module) && messageBroker != "" {
 		a.config.MessageBroker = messageBroker
 	}
 
@@ -359,7 +460,31 @@ func (a *ModuleAdder) addModule(module string) error {
 		outDir: a.projectPath,
 	}
 
-	return gen.generateModule(module)
+	if err := gen.generateModule(module); err != nil {
+		return err
+	}
+	if a.config.HasNative() {
+		if err := gen.generateNativeModule(module); err != nil {
+			return err
+		}
+	}
+	if err := gen.generateSecretsModule(module); err != nil {
+		return err
+	}
+	if module == config.ModuleAPI {
+		if err := gen.generateRateLimit(); err != nil {
+			return err
+		}
+		if err := gen.generateIdempotency(); err != nil {
+			return err
+		}
+	}
+	// The load test needs API and a datastore; either may be the module
+	// that completes the pair
+	if err := gen.generatePerf(); err != nil {
+		return err
+	}
+	return gen.generateEnvProfilesModule(module)
 }
 
 // createModuleDirectories creates the directory structure for a module
@@ -407,6 +532,16 @@ func (a *ModuleAdder) createModuleDirectories(module string) error {
 			filepath.Join(a.projectPath, config.ModuleNoSQLDatastore, "src", "main", "resources"),
 			filepath.Join(nosqlTestBase, "repository"),
 		}
+	case config.ModuleSearch:
+		searchBase := filepath.Join(a.projectPath, config.ModuleSearch, "src", "main", "java", packagePath, "search")
+		searchTestBase := filepath.Join(a.projectPath, config.ModuleSearch, "src", "test", "java", packagePath, "search")
+		dirs = []string{
+			filepath.Join(searchBase, "config"),
+			filepath.Join(searchBase, "document"),
+			filepath.Join(searchBase, "repository"),
+			filepath.Join(a.projectPath, config.ModuleSearch, "src", "main", "resources"),
+			filepath.Join(searchTestBase, "repository"),
+		}
 	case config.ModuleShared:
 		sharedBase := filepath.Join(a.projectPath, config.ModuleShared, "src", "main", "java", packagePath, "shared")
 		sharedTestBase := filepath.Join(a.projectPath, config.ModuleShared, "src", "test", "java", packagePath, "shared")
@@ -455,6 +590,10 @@ func (a *ModuleAdder) createModuleDirectories(module string) error {
 			filepath.Join(a.projectPath, config.ModuleEventConsumer, "src", "main", "resources"),
 			filepath.Join(ecTestBase, "listener"),
 		}
+	case config.ModuleClientSDK:
+		dirs = []string{
+			filepath.Join(a.projectPath, config.ModuleClientSDK, "src", "main", "openapi"),
+		}
 	}
 
 	for _, dir := range dirs {
@@ -478,6 +617,11 @@ func (a *ModuleAdder) updateDockerCompose(module, database, nosqlDatabase, messa
 		return err
 	}
 
+	if p := plugin.Get(module); p != nil {
+		applyPluginDockerServices(updater, p)
+		return updater.Save()
+	}
+
 	switch module {
 	case config.ModuleSQLDatastore:
 		if database == config.DatabasePostgreSQL && !updater.HasService("postgres") {
@@ -485,20 +629,35 @@ func (a *ModuleAdder) updateDockerCompose(module, database, nosqlDatabase, messa
 			dbName := a.config.ProjectName
 			// Use postgres/postgres credentials to match application.yml template defaults
 			// Port 5433 to avoid conflicts with local PostgreSQL
-			updater.AddService("postgres", GetPostgresService(
+			postgres := GetPostgresService(
 				"postgres",
 				dbName,
 				"postgres",
 				"postgres",
 				5433,
-			))
+			)
+			if a.config.HasReplicaService() {
+				if err := a.createReplicationInitScript(); err != nil {
+					return fmt.Errorf("failed to create replication init script: %w", err)
+				}
+				postgres["volumes"] = append(postgres["volumes"].([]string), "./postgres-init:/docker-entrypoint-initdb.d:ro")
+			}
+			updater.AddService("postgres", postgres)
 			updater.AddVolume("postgres-data")
+			if a.config.HasReplicaService() {
+				updater.AddService("postgres-replica", GetPostgresReplicaService("postgres", 5435))
+				updater.AddVolume("postgres-replica-data")
+			}
 		} else if database == config.DatabaseMySQL && !updater.HasService("mysql") {
 			// MySQL doesn't support hyphens in database names, use snake_case
 			dbName := a.config.ProjectNameSnake()
 			// Use root/root credentials to match application.yml template defaults
 			updater.AddService("mysql", GetMySQLService("mysql", dbName, "root"))
 			updater.AddVolume("mysql-data")
+		} else if database == config.DatabaseMariaDB && !updater.HasService("mariadb") {
+			// Same snake_case name and root/root credentials as MySQL
+			updater.AddService("mariadb", GetMariaDBService("mariadb", a.config.ProjectNameSnake(), "root"))
+			updater.AddVolume("mariadb-data")
 		}
 
 	case config.ModuleNoSQLDatastore:
@@ -511,13 +670,25 @@ func (a *ModuleAdder) updateDockerCompose(module, database, nosqlDatabase, messa
 		} else if nosqlDatabase == config.DatabaseRedis && !updater.HasService("redis") {
 			updater.AddService("redis", GetRedisService("redis"))
 			updater.AddVolume("redis-data")
+		} else if nosqlDatabase == config.DatabaseDynamoDB && !updater.HasService("dynamodb") {
+			updater.AddService("dynamodb", GetDynamoDBService())
+			updater.AddVolume("dynamodb-data")
+		} else if nosqlDatabase == config.DatabaseCassandra && !updater.HasService("cassandra") {
+			updater.AddService("cassandra", GetCassandraService())
+			updater.AddVolume("cassandra-data")
+		}
+
+	case config.ModuleSearch:
+		if !updater.HasService("opensearch") {
+			updater.AddService("opensearch", GetOpenSearchService())
+			updater.AddVolume("opensearch-data")
 		}
 
 	case config.ModuleWorker:
-		// Check if Worker needs its own PostgreSQL for JobRunr
+		// Check if Worker needs its own PostgreSQL or MySQL for JobRunr
 		if a.config.WorkerNeedsOwnPostgres() && !updater.HasService("postgres-jobrunr") {
 			// Use original project name to match application.yml template defaults
-			dbName := a.config.ProjectName + "_jobs"
+			dbName := a.config.JobRunrDatabaseName()
 			// Use postgres/postgres credentials to match application.yml template defaults
 			// Port 5434 to differentiate from main database
 			updater.AddService("postgres-jobrunr", GetPostgresService(
@@ -529,8 +700,15 @@ func (a *ModuleAdder) updateDockerCompose(module, database, nosqlDatabase, messa
 			))
 			updater.AddVolume("postgres-jobrunr-data")
 		}
+		if a.config.WorkerNeedsOwnMySQL() && !updater.HasService("mysql-jobrunr") {
+			// Port 3308 to differentiate from a main MySQL database on 3307
+			service := GetMySQLService("mysql-jobrunr", a.config.JobRunrDatabaseName(), "root")
+			service["ports"] = []string{"3308:3306"}
+			updater.AddService("mysql-jobrunr", service)
+			updater.AddVolume("mysql-jobrunr-data")
+		}
 
-	case config.ModuleEventConsumer:
+	case config.ModuleEvents, config.ModuleEventConsumer:
 		switch messageBroker {
 		case config.BrokerKafka:
 			if !updater.HasService("kafka") {
@@ -556,12 +734,62 @@ func (a *ModuleAdder) updateDockerCompose(module, database, nosqlDatabase, messa
 			if !updater.HasService("pubsub-emulator") {
 				updater.AddService("pubsub-emulator", GetPubSubEmulatorService())
 			}
+		case config.BrokerRedisStreams:
+			// Shares the server of a Redis NoSQLDatastore when there is one
+			if !updater.HasService("redis") {
+				updater.AddService("redis", GetRedisService("redis"))
+				updater.AddVolume("redis-data")
+			}
+		case config.BrokerNATS:
+			if !updater.HasService("nats") {
+				updater.AddService("nats", GetNATSService())
+				updater.AddVolume("nats-data")
+			}
 		}
 	}
 
 	return updater.Save()
 }
 
+// syncComposeAppServices rewrites the "app" profile services of an
+// existing docker-compose.yml when the add brings a runtime module or new
+// infrastructure, so the containerized modules include the one just added
+// and reach its services.
+func (a *ModuleAdder) syncComposeAppServices(module string, modules []string) error {
+	if !needsDockerComposeUpdate(module) && !hasRuntimeModule(modules) {
+		return nil
+	}
+	composePath := filepath.Join(a.projectPath, "docker-compose.yml")
+	if _, err := os.Stat(composePath); err != nil {
+		return nil
+	}
+	services := a.config.ComposeAppServices()
+	if len(services) == 0 {
+		return nil
+	}
+
+	updater, err := NewDockerComposeUpdater(composePath)
+	if err != nil {
+		return err
+	}
+	for _, svc := range services {
+		updater.AddService(svc.Name, GetAppService(a.config, svc, updater.HasHealthcheck))
+	}
+	return updater.Save()
+}
+
+// hasRuntimeModule reports whether modules include one that runs as a
+// service.
+func hasRuntimeModule(modules []string) bool {
+	for _, mod := range modules {
+		switch mod {
+		case config.ModuleAPI, config.ModuleWorker, config.ModuleEventConsumer, config.ModuleAIAgent:
+			return true
+		}
+	}
+	return false
+}
+
 // updateParentPOM updates the parent pom.xml with modules and required properties/BOMs
 func (a *ModuleAdder) updateParentPOM(modules []string, messageBroker string) error {
 	pomPath := filepath.Join(a.projectPath, "pom.xml")
@@ -579,68 +807,120 @@ func (a *ModuleAdder) updateParentPOM(modules []string, messageBroker string) er
 
 	// Add required properties based on modules being added
 	for _, mod := range modules {
+		if p := plugin.Get(mod); p != nil {
+			if err := applyPluginPOMEdits(updater, p); err != nil {
+				return err
+			}
+			continue
+		}
 		switch mod {
 		case config.ModuleJobs, config.ModuleWorker:
 			// JobRunr version property needed for Jobs and Worker modules
-			if err := updater.AddProperty("jobrunr.version", JobRunrVersion); err != nil {
+			if err := updater.AddProperty("jobrunr.version", versions.Get("jobrunr")); err != nil {
 				return fmt.Errorf("failed to add jobrunr.version property: %w", err)
 			}
 			// logstash-logback-encoder for structured logging in Worker
-			if mod == config.ModuleWorker {
-				if err := updater.AddProperty("logstash-logback-encoder.version", LogstashEncoderVersion); err != nil {
+			if mod == config.ModuleWorker && a.config.UsesLogstashEncoder() {
+				if err := updater.AddProperty("logstash-logback-encoder.version", versions.Get("logstash-logback-encoder")); err != nil {
 					return fmt.Errorf("failed to add logstash-logback-encoder.version property: %w", err)
 				}
 			}
 		case config.ModuleAPI:
 			// logstash-logback-encoder for structured logging
-			if err := updater.AddProperty("logstash-logback-encoder.version", LogstashEncoderVersion); err != nil {
-				return fmt.Errorf("failed to add logstash-logback-encoder.version property: %w", err)
+			if a.config.UsesLogstashEncoder() {
+				if err := updater.AddProperty("logstash-logback-encoder.version", versions.Get("logstash-logback-encoder")); err != nil {
+					return fmt.Errorf("failed to add logstash-logback-encoder.version property: %w", err)
+				}
 			}
 			// springdoc for OpenAPI/Swagger
-			if err := updater.AddProperty("springdoc.version", SpringDocVersion); err != nil {
+			if err := updater.AddProperty("springdoc.version", versions.Get("springdoc")); err != nil {
 				return fmt.Errorf("failed to add springdoc.version property: %w", err)
 			}
+			// Bucket4j: the core for --with-rate-limit, else the dormant starter
+			if a.config.HasRateLimit() {
+				if err := updater.AddProperty("bucket4j-core.version", versions.Get("bucket4j-core")); err != nil {
+					return fmt.Errorf("failed to add bucket4j-core.version property: %w", err)
+				}
+			} else if err := updater.AddProperty("bucket4j.version", versions.Get("bucket4j")); err != nil {
+				return fmt.Errorf("failed to add bucket4j.version property: %w", err)
+			}
 			// jacoco for test coverage
-			if err := updater.AddProperty("jacoco.version", JaCoCoVersion); err != nil {
+			if err := updater.AddProperty("jacoco.version", versions.Get("jacoco")); err != nil {
 				return fmt.Errorf("failed to add jacoco.version property: %w", err)
 			}
 		case config.ModuleEventConsumer:
 			// logstash-logback-encoder for structured logging
-			if err := updater.AddProperty("logstash-logback-encoder.version", LogstashEncoderVersion); err != nil {
-				return fmt.Errorf("failed to add logstash-logback-encoder.version property: %w", err)
+			if a.config.UsesLogstashEncoder() {
+				if err := updater.AddProperty("logstash-logback-encoder.version", versions.Get("logstash-logback-encoder")); err != nil {
+					return fmt.Errorf("failed to add logstash-logback-encoder.version property: %w", err)
+				}
+			}
+		case config.ModuleClientSDK:
+			// openapi-generator generates the client; exec-maven-plugin refreshes the spec
+			if err := updater.AddProperty("openapi-generator.version", versions.Get("openapi-generator")); err != nil {
+				return fmt.Errorf("failed to add openapi-generator.version property: %w", err)
+			}
+			if err := updater.AddProperty("exec-maven-plugin.version", versions.Get("exec-maven-plugin")); err != nil {
+				return fmt.Errorf("failed to add exec-maven-plugin.version property: %w", err)
+			}
+		case config.ModuleSearch:
+			// spring-data-opensearch is not managed by Spring Boot
+			if err := updater.AddProperty("spring-data-opensearch.version", versions.Get("spring-data-opensearch")); err != nil {
+				return fmt.Errorf("failed to add spring-data-opensearch.version property: %w", err)
+			}
+			if err := updater.AddDependencyManagement("org.opensearch.client", "spring-data-opensearch-starter", "${spring-data-opensearch.version}", "", ""); err != nil {
+				return fmt.Errorf("failed to add spring-data-opensearch dependency management: %w", err)
 			}
 		case config.ModuleShared:
 			// Quality plugin versions (Enforcer, Spotless, ArchUnit)
-			if err := updater.AddProperty("maven-enforcer.version", EnforcerVersion); err != nil {
+			if err := updater.AddProperty("maven-enforcer.version", versions.Get("maven-enforcer")); err != nil {
 				return fmt.Errorf("failed to add maven-enforcer.version property: %w", err)
 			}
-			if err := updater.AddProperty("spotless.version", SpotlessVersion); err != nil {
+			if err := updater.AddProperty("spotless.version", versions.Get("spotless")); err != nil {
 				return fmt.Errorf("failed to add spotless.version property: %w", err)
 			}
-			if err := updater.AddProperty("archunit.version", ArchUnitVersion); err != nil {
+			if err := updater.AddProperty("archunit.version", versions.Get("archunit")); err != nil {
 				return fmt.Errorf("failed to add archunit.version property: %w", err)
 			}
 		}
 	}
 
-	// Add required BOMs for message brokers
-	if messageBroker == config.BrokerSQS {
-		// Spring Cloud AWS BOM for SQS
+	// Native Build Tools version for the runtime modules' `native` profile
+	if a.config.HasNative() {
+		if err := updater.AddProperty("native-maven-plugin.version", versions.Get("native-maven-plugin")); err != nil {
+			return fmt.Errorf("failed to add native-maven-plugin.version property: %w", err)
+		}
+	}
+
+	// Spring Cloud AWS BOM for SQS and DynamoDB
+	addsDynamoDB := slices.Contains(modules, config.ModuleNoSQLDatastore) && a.config.NoSQLDatabase == config.DatabaseDynamoDB
+	if messageBroker == config.BrokerSQS || addsDynamoDB {
 		if err := updater.AddDependencyManagement(
 			"io.awspring.cloud",
 			"spring-cloud-aws-dependencies",
-			SpringCloudAWSVersion,
+			versions.Get("spring-cloud-aws"),
 			"pom",
 			"import",
 		); err != nil {
 			return fmt.Errorf("failed to add Spring Cloud AWS BOM: %w", err)
 		}
+	}
+
+	// Add required BOMs and properties for message brokers
+	if messageBroker == config.BrokerNATS {
+		// jnats is not managed by Spring Boot
+		if err := updater.AddProperty("jnats.version", versions.Get("jnats")); err != nil {
+			return fmt.Errorf("failed to add jnats.version property: %w", err)
+		}
+		if err := updater.AddDependencyManagement("io.nats", "jnats", "${jnats.version}", "", ""); err != nil {
+			return fmt.Errorf("failed to add jnats dependency management: %w", err)
+		}
 	} else if messageBroker == config.BrokerPubSub {
 		// Spring Cloud GCP BOM for Pub/Sub
 		if err := updater.AddDependencyManagement(
 			"com.google.cloud",
 			"spring-cloud-gcp-dependencies",
-			SpringCloudGCPVersion,
+			versions.Get("spring-cloud-gcp"),
 			"pom",
 			"import",
 		); err != nil {
@@ -665,15 +945,89 @@ func (a *ModuleAdder) createSQSInitScript() error {
 		return err
 	}
 
-	scriptPath := filepath.Join(scriptDir, "init-sqs.sh")
-	// Queue name matches the application.yml template default: placeholder-events
-	content := `#!/bin/bash
-# Create SQS queues for local development
-awslocal sqs create-queue --queue-name placeholder-events
-echo "SQS queues created successfully"
-`
+	// The same script init writes, with the project's queue options
+	gen := &Generator{
+		config: a.config,
+		engine: a.engine,
+		outDir: a.projectPath,
+	}
+	return gen.writeTemplateExecutable("docker/localstack-init/ready.d/init-sqs.sh.tmpl", filepath.Join("localstack-init", "ready.d", "init-sqs.sh"))
+}
+
+// createReplicationInitScript writes the init script that prepares the
+// postgres service as the primary of postgres-replica
+func (a *ModuleAdder) createReplicationInitScript() error {
+	initDir := filepath.Join(a.projectPath, "postgres-init")
+	if _, err := os.Stat(initDir); os.IsNotExist(err) {
+		a.backup.TrackCreatedDir(initDir)
+	}
+	gen := &Generator{
+		config: a.config,
+		engine: a.engine,
+		outDir: a.projectPath,
+	}
+	return gen.writeTemplateExecutable("docker/postgres-init/replication.sh.tmpl", filepath.Join("postgres-init", "replication.sh"))
+}
+
+// ensureErrorCatalog writes ErrorCode and ApplicationException to Model
+// when the project has the API and does not have them yet, so that the API
+// classes and docs/errors.md can refer to them.
+func (a *ModuleAdder) ensureErrorCatalog() error {
+	if !a.config.HasModule(config.ModuleAPI) {
+		return nil
+	}
+	gen := &Generator{
+		config: a.config,
+		engine: a.engine,
+		outDir: a.projectPath,
+	}
+	created, err := gen.ensureErrorCatalog()
+	for _, f := range created {
+		a.backup.TrackCreatedFile(f)
+	}
+	return err
+}
+
+// ensureTestFixtures writes the Model and SQLDatastore test fixtures and
+// declares their test-jars when the project has a module whose POM depends
+// on them but was generated before they existed.
+func (a *ModuleAdder) ensureTestFixtures() error {
+	if !a.config.HasModule(config.ModuleShared) && !a.config.HasModule(config.ModuleWorker) {
+		return nil
+	}
+	for _, module := range []string{config.ModuleModel, config.ModuleSQLDatastore} {
+		if !a.config.HasModule(module) {
+			continue
+		}
+		if err := a.backup.Backup(filepath.Join(module, "pom.xml")); err != nil {
+			return fmt.Errorf("failed to backup %s pom.xml: %w", module, err)
+		}
+	}
+	gen := &Generator{
+		config: a.config,
+		engine: a.engine,
+		outDir: a.projectPath,
+	}
+	created, err := gen.ensureTestFixtures()
+	for _, f := range created {
+		a.backup.TrackCreatedFile(f)
+	}
+	return err
+}
 
-	return os.WriteFile(scriptPath, []byte(content), 0755)
+// regeneratePlaceholderMother rewrites Model's PlaceholderMother so it
+// builds the entity of a newly added datastore. A project without the
+// fixtures gets them from ensureTestFixtures instead.
+func (a *ModuleAdder) regeneratePlaceholderMother(gen *Generator) error {
+	f := gen.placeholderMotherFile()
+	path := gen.sourcePath(f[0], f[1])
+	if _, err := os.Stat(filepath.Join(a.projectPath, path)); os.IsNotExist(err) {
+		return nil
+	}
+	if err := a.backup.Backup(path); err != nil {
+		return fmt.Errorf("failed to backup PlaceholderMother: %w", err)
+	}
+	return gen.writeTemplate(f[0], f[1])
 }
 
 // updateModelModule adds new files to Model module when needed
@@ -698,11 +1052,10 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		if err := modelPom.Save(); err != nil {
 			return fmt.Errorf("failed to save Model pom.xml: %w", err)
 		}
-		color.New(color.FgGreen).Println("  ✓ Added spring-data-relational dependency to Model")
 
 		// Backup and regenerate Placeholder.java with SQL id field
 		placeholderPath := gen.javaPath(config.ModuleModel, filepath.Join("entities", "Placeholder.java"))
-		if err := a.backup.Backup(placeholderPath); err != nil {
+		if err := a.backup.Backup(gen.sourcePath("java/model/entities/Placeholder.java.tmpl", placeholderPath)); err != nil {
 			return fmt.Errorf("failed to backup Placeholder.java: %w", err)
 		}
 		if err := gen.writeTemplate(
@@ -711,11 +1064,10 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		); err != nil {
 			return err
 		}
-		color.New(color.FgGreen).Println("  ✓ Updated Placeholder.java with SQL id field")
 
 		// Backup and regenerate PlaceholderResponse.java with SQL id field
 		responsePath := gen.javaPath(config.ModuleModel, filepath.Join("dto", "PlaceholderResponse.java"))
-		if err := a.backup.Backup(responsePath); err != nil {
+		if err := a.backup.Backup(gen.sourcePath("java/model/dto/PlaceholderResponse.java.tmpl", responsePath)); err != nil {
 			return fmt.Errorf("failed to backup PlaceholderResponse.java: %w", err)
 		}
 		if err := gen.writeTemplate(
@@ -724,10 +1076,14 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		); err != nil {
 			return err
 		}
-		color.New(color.FgGreen).Println("  ✓ Updated PlaceholderResponse.java with SQL id field")
+
+		// Regenerate PlaceholderMother with the record builders
+		if err := a.regeneratePlaceholderMother(gen); err != nil {
+			return err
+		}
 
 		// Add PlaceholderRecord.java if not exists
-		recordPath := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, filepath.Join("entities", "PlaceholderRecord.java")))
+		recordPath := filepath.Join(a.projectPath, gen.sourcePath("java/model/entities/PlaceholderRecord.java.tmpl", gen.javaPath(config.ModuleModel, filepath.Join("entities", "PlaceholderRecord.java"))))
 		if _, err := os.Stat(recordPath); os.IsNotExist(err) {
 			if err := gen.writeTemplate(
 				"java/model/entities/PlaceholderRecord.java.tmpl",
@@ -735,7 +1091,6 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			); err != nil {
 				return err
 			}
-			color.New(color.FgGreen).Println("  ✓ Added PlaceholderRecord.java to Model")
 		}
 
 	case config.ModuleNoSQLDatastore:
@@ -752,12 +1107,14 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			if err := modelPom.AddDependency("org.springframework.data", "spring-data-mongodb", ""); err != nil {
 				return fmt.Errorf("failed to add spring-data-mongodb dependency to Model: %w", err)
 			}
-			color.New(color.FgGreen).Println("  ✓ Added spring-data-mongodb dependency to Model")
 		case config.DatabaseRedis:
 			if err := modelPom.AddDependency("org.springframework.data", "spring-data-redis", ""); err != nil {
 				return fmt.Errorf("failed to add spring-data-redis dependency to Model: %w", err)
 			}
-			color.New(color.FgGreen).Println("  ✓ Added spring-data-redis dependency to Model")
+		case config.DatabaseCassandra:
+			if err := modelPom.AddDependency("org.springframework.data", "spring-data-cassandra", ""); err != nil {
+				return fmt.Errorf("failed to add spring-data-cassandra dependency to Model: %w", err)
+			}
 		}
 
 		if err := modelPom.Save(); err != nil {
@@ -766,7 +1123,7 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 
 		// Backup and regenerate Placeholder.java with NoSQL documentId field
 		placeholderPath := gen.javaPath(config.ModuleModel, filepath.Join("entities", "Placeholder.java"))
-		if err := a.backup.Backup(placeholderPath); err != nil {
+		if err := a.backup.Backup(gen.sourcePath("java/model/entities/Placeholder.java.tmpl", placeholderPath)); err != nil {
 			return fmt.Errorf("failed to backup Placeholder.java: %w", err)
 		}
 		if err := gen.writeTemplate(
@@ -775,11 +1132,10 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		); err != nil {
 			return err
 		}
-		color.New(color.FgGreen).Println("  ✓ Updated Placeholder.java with NoSQL documentId field")
 
 		// Backup and regenerate PlaceholderResponse.java with NoSQL documentId field
 		responsePath := gen.javaPath(config.ModuleModel, filepath.Join("dto", "PlaceholderResponse.java"))
-		if err := a.backup.Backup(responsePath); err != nil {
+		if err := a.backup.Backup(gen.sourcePath("java/model/dto/PlaceholderResponse.java.tmpl", responsePath)); err != nil {
 			return fmt.Errorf("failed to backup PlaceholderResponse.java: %w", err)
 		}
 		if err := gen.writeTemplate(
@@ -788,10 +1144,14 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		); err != nil {
 			return err
 		}
-		color.New(color.FgGreen).Println("  ✓ Updated PlaceholderResponse.java with NoSQL documentId field")
+
+		// Regenerate PlaceholderMother with the document builder
+		if err := a.regeneratePlaceholderMother(gen); err != nil {
+			return err
+		}
 
 		// Add PlaceholderDocument.java if not exists
-		docPath := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, filepath.Join("entities", "PlaceholderDocument.java")))
+		docPath := filepath.Join(a.projectPath, gen.sourcePath("java/model/entities/PlaceholderDocument.java.tmpl", gen.javaPath(config.ModuleModel, filepath.Join("entities", "PlaceholderDocument.java"))))
 		if _, err := os.Stat(docPath); os.IsNotExist(err) {
 			if err := gen.writeTemplate(
 				"java/model/entities/PlaceholderDocument.java.tmpl",
@@ -799,7 +1159,6 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			); err != nil {
 				return err
 			}
-			color.New(color.FgGreen).Println("  ✓ Added PlaceholderDocument.java to Model")
 		}
 
 	case config.ModuleWorker:
@@ -815,7 +1174,6 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		if err := modelPom.Save(); err != nil {
 			return fmt.Errorf("failed to save Model pom.xml: %w", err)
 		}
-		color.New(color.FgGreen).Println("  ✓ Added JobRunr dependency to Model")
 
 		// Add job request files
 		jobsDir := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, "jobs"))
@@ -824,7 +1182,7 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		}
 
 		// PlaceholderJobRequest.java
-		jobReqPath := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, filepath.Join("jobs", "PlaceholderJobRequest.java")))
+		jobReqPath := filepath.Join(a.projectPath, gen.sourcePath("java/model/jobs/PlaceholderJobRequest.java.tmpl", gen.javaPath(config.ModuleModel, filepath.Join("jobs", "PlaceholderJobRequest.java"))))
 		if _, err := os.Stat(jobReqPath); os.IsNotExist(err) {
 			if err := gen.writeTemplate(
 				"java/model/jobs/PlaceholderJobRequest.java.tmpl",
@@ -832,11 +1190,10 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			); err != nil {
 				return err
 			}
-			color.New(color.FgGreen).Println("  ✓ Added PlaceholderJobRequest.java to Model")
 		}
 
 		// ProcessPlaceholderJobRequest.java
-		processPath := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, filepath.Join("jobs", "ProcessPlaceholderJobRequest.java")))
+		processPath := filepath.Join(a.projectPath, gen.sourcePath("java/model/jobs/ProcessPlaceholderJobRequest.java.tmpl", gen.javaPath(config.ModuleModel, filepath.Join("jobs", "ProcessPlaceholderJobRequest.java"))))
 		if _, err := os.Stat(processPath); os.IsNotExist(err) {
 			if err := gen.writeTemplate(
 				"java/model/jobs/ProcessPlaceholderJobRequest.java.tmpl",
@@ -844,11 +1201,10 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			); err != nil {
 				return err
 			}
-			color.New(color.FgGreen).Println("  ✓ Added ProcessPlaceholderJobRequest.java to Model")
 		}
 
 		// ProcessPlaceholderJobRequestHandler.java (base class)
-		handlerPath := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, filepath.Join("jobs", "ProcessPlaceholderJobRequestHandler.java")))
+		handlerPath := filepath.Join(a.projectPath, gen.sourcePath("java/model/jobs/ProcessPlaceholderJobRequestHandler.java.tmpl", gen.javaPath(config.ModuleModel, filepath.Join("jobs", "ProcessPlaceholderJobRequestHandler.java"))))
 		if _, err := os.Stat(handlerPath); os.IsNotExist(err) {
 			if err := gen.writeTemplate(
 				"java/model/jobs/ProcessPlaceholderJobRequestHandler.java.tmpl",
@@ -856,10 +1212,9 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			); err != nil {
 				return err
 			}
-			color.New(color.FgGreen).Println("  ✓ Added ProcessPlaceholderJobRequestHandler.java to Model")
 		}
 
-	case config.ModuleEventConsumer:
+	case config.ModuleEvents, config.ModuleEventConsumer:
 		// Add event files
 		eventsDir := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, "events"))
 		if err := os.MkdirAll(eventsDir, 0755); err != nil {
@@ -867,7 +1222,7 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 		}
 
 		// PlaceholderEvent.java
-		eventPath := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, filepath.Join("events", "PlaceholderEvent.java")))
+		eventPath := filepath.Join(a.projectPath, gen.sourcePath("java/model/events/PlaceholderEvent.java.tmpl", gen.javaPath(config.ModuleModel, filepath.Join("events", "PlaceholderEvent.java"))))
 		if _, err := os.Stat(eventPath); os.IsNotExist(err) {
 			if err := gen.writeTemplate(
 				"java/model/events/PlaceholderEvent.java.tmpl",
@@ -875,11 +1230,10 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			); err != nil {
 				return err
 			}
-			color.New(color.FgGreen).Println("  ✓ Added PlaceholderEvent.java to Model")
 		}
 
 		// PlaceholderCreatedEvent.java
-		createdPath := filepath.Join(a.projectPath, gen.javaPath(config.ModuleModel, filepath.Join("events", "PlaceholderCreatedEvent.java")))
+		createdPath := filepath.Join(a.projectPath, gen.sourcePath("java/model/events/PlaceholderCreatedEvent.java.tmpl", gen.javaPath(config.ModuleModel, filepath.Join("events", "PlaceholderCreatedEvent.java"))))
 		if _, err := os.Stat(createdPath); os.IsNotExist(err) {
 			if err := gen.writeTemplate(
 				"java/model/events/PlaceholderCreatedEvent.java.tmpl",
@@ -887,18 +1241,17 @@ func (a *ModuleAdder) updateModelModule(module string) error {
 			); err != nil {
 				return err
 			}
-			color.New(color.FgGreen).Println("  ✓ Added PlaceholderCreatedEvent.java to Model")
 		}
 	}
 
 	return nil
 }
 
-// updateSharedModule updates the Shared module when adding a datastore
-// This adds the datastore as a dependency and regenerates PlaceholderService
+// updateSharedModule updates the Shared module when adding a datastore or Search
+// This adds the module as a dependency and regenerates PlaceholderService
 func (a *ModuleAdder) updateSharedModule(module string) error {
-	// Only update Shared for datastore modules, and only if Shared exists
-	if module != config.ModuleSQLDatastore && module != config.ModuleNoSQLDatastore {
+	// Only update Shared for modules PlaceholderService calls into, and only if Shared exists
+	if module != config.ModuleSQLDatastore && module != config.ModuleNoSQLDatastore && module != config.ModuleSearch {
 		return nil
 	}
 
@@ -925,11 +1278,10 @@ func (a *ModuleAdder) updateSharedModule(module string) error {
 	if err := sharedPom.Save(); err != nil {
 		return fmt.Errorf("failed to save Shared pom.xml: %w", err)
 	}
-	color.New(color.FgGreen).Printf("  ✓ Added %s dependency to Shared\n", module)
 
 	// Backup and regenerate PlaceholderService.java
 	servicePath := gen.javaPath(config.ModuleShared, filepath.Join("service", "PlaceholderService.java"))
-	if err := a.backup.Backup(servicePath); err != nil {
+	if err := a.backup.Backup(gen.sourcePath("java/shared/service/PlaceholderService.java.tmpl", servicePath)); err != nil {
 		return fmt.Errorf("failed to backup PlaceholderService.java: %w", err)
 	}
 	if err := gen.writeTemplate(
@@ -938,13 +1290,12 @@ func (a *ModuleAdder) updateSharedModule(module string) error {
 	); err != nil {
 		return err
 	}
-	color.New(color.FgGreen).Println("  ✓ Updated PlaceholderService.java to use repository")
 
 	// Backup and regenerate PlaceholderServiceTest.java
 	testPath := gen.javaPath(config.ModuleShared, filepath.Join("service", "PlaceholderServiceTest.java"))
 	// Put test in test directory
 	testPath = strings.Replace(testPath, "/main/", "/test/", 1)
-	if err := a.backup.Backup(testPath); err != nil {
+	if err := a.backup.Backup(gen.sourcePath("java/shared/test/PlaceholderServiceTest.java.tmpl", testPath)); err != nil {
 		// Test file might not exist, that's OK
 		if !os.IsNotExist(err) {
 			return fmt.Errorf("failed to backup PlaceholderServiceTest.java: %w", err)
@@ -956,27 +1307,69 @@ func (a *ModuleAdder) updateSharedModule(module string) error {
 	); err != nil {
 		return err
 	}
-	color.New(color.FgGreen).Println("  ✓ Updated PlaceholderServiceTest.java")
 
-	return nil
+	if module != config.ModuleSearch {
+		return nil
+	}
+
+	// SearchService.java (the indexing hook PlaceholderService now calls)
+	if err := gen.writeTemplate(
+		"java/shared/service/SearchService.java.tmpl",
+		gen.javaPath(config.ModuleShared, filepath.Join("service", "SearchService.java")),
+	); err != nil {
+		return err
+	}
+	return gen.writeTemplate(
+		"java/shared/test/SearchServiceTest.java.tmpl",
+		gen.testJavaPath(config.ModuleShared, filepath.Join("service", "SearchServiceTest.java")),
+	)
+}
+
+// updateArchitectureTest regenerates the Shared module's ArchitectureTest
+// when an added module brings a rule to it (SQLDatastore) and Shared was
+// already there; a Shared module added now is generated with it. The rules
+// over a module's own classes (ModelArchitectureTest, ApiArchitectureTest,
+// EventConsumerArchitectureTest) are generated with that module.
+func (a *ModuleAdder) updateArchitectureTest(modules []string) error {
+	if !a.metadata.HasModule(config.ModuleShared) || !slices.Contains(modules, config.ModuleSQLDatastore) {
+		return nil
+	}
+
+	gen := &Generator{
+		config: a.config,
+		engine: a.engine,
+		outDir: a.projectPath,
+	}
+	testPath := gen.testJavaPath(config.ModuleShared, "ArchitectureTest.java")
+	if err := a.backup.Backup(testPath); err != nil {
+		return fmt.Errorf("failed to backup ArchitectureTest.java: %w", err)
+	}
+	return gen.writeTemplate("java/shared/test/ArchitectureTest.java.tmpl", testPath)
 }
 
 // updateAPIModule updates the API module when adding modules that need ComponentScan
-// This regenerates Application.java to include new packages in the scan
-func (a *ModuleAdder) updateAPIModule(module string) error {
+// This regenerates Application.java to include new packages in the scan, and
+// wires the Events publisher (dependency and EventController) when it is new
+func (a *ModuleAdder) updateAPIModule(modules []string) error {
 	// Only update API for modules that need to be scanned
 	scannableModules := []string{
 		config.ModuleSQLDatastore,
 		config.ModuleNoSQLDatastore,
+		config.ModuleSearch,
 		config.ModuleEvents,
 		config.ModuleJobs,
 	}
 
 	needsUpdate := false
-	for _, m := range scannableModules {
-		if module == m {
-			needsUpdate = true
-			break
+	addsEvents := false
+	for _, mod := range modules {
+		for _, m := range scannableModules {
+			if mod == m {
+				needsUpdate = true
+			}
+		}
+		if mod == config.ModuleEvents {
+			addsEvents = true
 		}
 	}
 
@@ -1005,7 +1398,6 @@ func (a *ModuleAdder) updateAPIModule(module string) error {
 	); err != nil {
 		return err
 	}
-	color.New(color.FgGreen).Println("  ✓ Updated API Application.java with ComponentScan")
 
 	// Backup and regenerate application.yml (includes datasource config conditionally)
 	ymlPath := filepath.Join(config.ModuleAPI, "src", "main", "resources", "application.yml")
@@ -1018,13 +1410,45 @@ func (a *ModuleAdder) updateAPIModule(module string) error {
 	); err != nil {
 		return err
 	}
-	color.New(color.FgGreen).Println("  ✓ Updated API application.yml with database config")
+
+	if !addsEvents {
+		return nil
+	}
+
+	// Add Events dependency to API pom.xml so controllers can publish
+	apiPomPath := filepath.Join(config.ModuleAPI, "pom.xml")
+	if err := a.backup.Backup(apiPomPath); err != nil {
+		return fmt.Errorf("failed to backup API pom.xml: %w", err)
+	}
+	apiPom, err := NewPOMUpdater(filepath.Join(a.projectPath, apiPomPath))
+	if err != nil {
+		return fmt.Errorf("failed to read API pom.xml: %w", err)
+	}
+	if err := apiPom.AddDependency("${project.groupId}", config.ModuleEvents, "${project.version}"); err != nil {
+		return fmt.Errorf("failed to add %s dependency to API: %w", config.ModuleEvents, err)
+	}
+	if err := apiPom.Save(); err != nil {
+		return fmt.Errorf("failed to save API pom.xml: %w", err)
+	}
+
+	// EventController.java (publishes the placeholder event over HTTP)
+	controllerPath := gen.javaPath(config.ModuleAPI, filepath.Join("controller", "EventController.java"))
+	if _, err := os.Stat(filepath.Join(a.projectPath, gen.sourcePath("java/api/controller/EventController.java.tmpl", controllerPath))); os.IsNotExist(err) {
+		if err := gen.writeTemplate(
+			"java/api/controller/EventController.java.tmpl",
+			controllerPath,
+		); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 // regenerateDocs regenerates README.md and AI agent context files
-// This is called after adding a module to update documentation with new module info
+// This is called after adding a module to update documentation with new module info.
+// The docs are merged through writeDoc so user edits outside their managed
+// regions are kept.
 func (a *ModuleAdder) regenerateDocs() error {
 	gen := &Generator{
 		config: a.config,
@@ -1033,13 +1457,26 @@ func (a *ModuleAdder) regenerateDocs() error {
 	}
 
 	// Regenerate README.md
-	if err := gen.writeTemplate("docs/README.md.tmpl", "README.md"); err != nil {
+	if err := a.writeDoc(gen, "docs/README.md.tmpl", "README.md", a.config); err != nil {
 		return fmt.Errorf("failed to regenerate README.md: %w", err)
 	}
 
+	// ENV.md and .env.schema follow the configuration the module brought
+	if err := envcontract.Write(a.projectPath, a.config.ProjectName); err != nil {
+		return fmt.Errorf("failed to regenerate %s: %w", envcontract.DocFileName, err)
+	}
+
+	// docs/errors.md lists the error codes the API can answer with, which
+	// follow the modules
+	if a.config.HasModule(config.ModuleAPI) {
+		if err := a.writeDoc(gen, "docs/errors.md.tmpl", "docs/errors.md", a.config); err != nil {
+			return fmt.Errorf("failed to regenerate docs/errors.md: %w", err)
+		}
+	}
+
 	// Regenerate AGENTS.md cross-tool baseline first (Codex uses this as-is)
 	if a.config.HasAnyAIAgent() {
-		if err := gen.writeTemplate("docs/AGENTS.md.tmpl", "AGENTS.md"); err != nil {
+		if err := a.writeDoc(gen, "docs/AGENTS.md.tmpl", "AGENTS.md", a.config); err != nil {
 			return fmt.Errorf("failed to regenerate AGENTS.md: %w", err)
 		}
 	}
@@ -1065,8 +1502,9 @@ func (a *ModuleAdder) regenerateDocs() error {
 			PromptsDir:    promptsDir,
 			TaskGuidesDir: ".ai/prompts",
 			Frontmatter:   frontmatter,
+			Agent:         agent.ID,
 		}
-		if err := gen.writeTemplateWithData("docs/CLAUDE.md.tmpl", agent.FilePath, data); err != nil {
+		if err := a.writeDoc(gen, "docs/CLAUDE.md.tmpl", agent.FilePath, data); err != nil {
 			return fmt.Errorf("failed to regenerate %s: %w", agent.FilePath, err)
 		}
 	}
@@ -1085,6 +1523,23 @@ func (a *ModuleAdder) regenerateDocs() error {
 		}
 	}
 
+	// Regenerate the dev container so it forwards the new module's ports
+	if a.config.HasDevContainer() {
+		if err := gen.writeTemplate("devcontainer/devcontainer.json.tmpl", devContainerJSON); err != nil {
+			return fmt.Errorf("failed to regenerate %s: %w", devContainerJSON, err)
+		}
+	}
+
+	// Regenerate the Makefile or Taskfile.yml so it runs the new module
+	if err := gen.generateTaskRunner(); err != nil {
+		return err
+	}
+
+	// Give the new module its CODEOWNERS entries and boundary doc
+	if err := a.updateCodeOwners(); err != nil {
+		return err
+	}
+
 	// Regenerate agent-specific files
 	if a.config.HasAIAgent("claude") {
 		if err := gen.generateClaudeCodeFiles(); err != nil {
@@ -1106,6 +1561,46 @@ func (a *ModuleAdder) regenerateDocs() error {
 			return fmt.Errorf("failed to regenerate Codex files: %w", err)
 		}
 	}
+	if a.config.HasAIAgent("aider") {
+		if err := gen.generateAiderFiles(); err != nil {
+			return fmt.Errorf("failed to regenerate Aider files: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeDoc regenerates a doc with data. Only its managed regions are
+// rewritten, so user edits outside them survive; a doc without intact
+// region markers is left alone and reported, unless forceDocs is set.
+func (a *ModuleAdder) writeDoc(gen *Generator, templatePath, outputPath string, data interface{}) error {
+	if a.forceDocs {
+		return gen.writeTemplateWithData(templatePath, outputPath, data)
+	}
+	merged, reason, err := gen.writeManagedTemplate(templatePath, outputPath, data)
+	if err != nil {
+		return err
+	}
+	if !merged {
+		a.docsKept = append(a.docsKept, KeptDoc{File: outputPath, Reason: reason})
+	}
+	return nil
+}
+
+// regenerateModuleInfos rewrites every module's descriptor when the
+// project uses --jpms
+func (a *ModuleAdder) regenerateModuleInfos() error {
+	gen := &Generator{
+		config: a.config,
+		engine: a.engine,
+		outDir: a.projectPath,
+	}
+	notes, err := gen.generateModuleInfos()
+	if err != nil {
+		return err
+	}
+	for _, note := range notes {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+	}
 	return nil
 }
 
@@ -1114,6 +1609,17 @@ func (a *ModuleAdder) getModuleFiles(module string) []string {
 	var files []string
 	packagePath := a.config.PackagePath()
 
+	if p := plugin.Get(module); p != nil {
+		for _, f := range p.Manifest.Files {
+			// A path that fails to render or escapes the project is never
+			// written; generatePluginModule reports it
+			if out, err := pluginOutputPath(a.engine, a.config, p, f); err == nil {
+				files = append(files, filepath.FromSlash(out))
+			}
+		}
+		return files
+	}
+
 	switch module {
 	case config.ModuleModel:
 		base := filepath.Join(config.ModuleModel, "src", "main", "java", packagePath, "model")
@@ -1134,6 +1640,15 @@ func (a *ModuleAdder) getModuleFiles(module string) []string {
 			filepath.Join(config.ModuleSQLDatastore, "src", "main", "resources", "db", "migration", "V1__baseline.sql"),
 			filepath.Join(config.ModuleSQLDatastore, "src", "main", "resources", "application.yml"),
 		)
+		if a.config.HasAuditing() {
+			files = append(files, filepath.Join(base, "config", "AuditingConfig.java"))
+		}
+		if a.config.HasReadReplica() {
+			files = append(files,
+				filepath.Join(base, "config", "ReadReplicaConfig.java"),
+				filepath.Join(base, "config", "ReadWriteRoutingDataSource.java"),
+			)
+		}
 
 	case config.ModuleNoSQLDatastore:
 		base := filepath.Join(config.ModuleNoSQLDatastore, "src", "main", "java", packagePath, "nosqldatastore")
@@ -1144,6 +1659,16 @@ func (a *ModuleAdder) getModuleFiles(module string) []string {
 			filepath.Join(config.ModuleNoSQLDatastore, "src", "main", "resources", "application.yml"),
 		)
 
+	case config.ModuleSearch:
+		base := filepath.Join(config.ModuleSearch, "src", "main", "java", packagePath, "search")
+		files = append(files,
+			filepath.Join(config.ModuleSearch, "pom.xml"),
+			filepath.Join(base, "config", "SearchConfig.java"),
+			filepath.Join(base, "document", "PlaceholderSearchDocument.java"),
+			filepath.Join(base, "repository", "PlaceholderSearchRepository.java"),
+			filepath.Join(config.ModuleSearch, "src", "main", "resources", "application.yml"),
+		)
+
 	case config.ModuleShared:
 		base := filepath.Join(config.ModuleShared, "src", "main", "java", packagePath, "shared")
 		testBase := filepath.Join(config.ModuleShared, "src", "test", "java", packagePath, "shared")
@@ -1155,6 +1680,9 @@ func (a *ModuleAdder) getModuleFiles(module string) []string {
 			filepath.Join(config.ModuleShared, "src", "main", "resources", "application.yml"),
 			filepath.Join(testBase, "ArchitectureTest.java"),
 		)
+		if a.config.HasCache() {
+			files = append(files, filepath.Join(base, "config", "CacheConfig.java"))
+		}
 
 	case config.ModuleAPI:
 		base := filepath.Join(config.ModuleAPI, "src", "main", "java", packagePath, "api")
@@ -1168,7 +1696,29 @@ func (a *ModuleAdder) getModuleFiles(module string) []string {
 			filepath.Join(base, "config", "SecurityHeadersFilter.java"),
 			filepath.Join(config.ModuleAPI, "src", "main", "resources", "application.yml"),
 			filepath.Join(config.ModuleAPI, "Dockerfile"),
+			filepath.Join(config.ModuleAPI, "src", "test", "java", packagePath, "api", "ApiArchitectureTest.java"),
 		)
+		if a.config.HasRateLimit() {
+			files = append(files,
+				filepath.Join(base, "config", "RateLimitProperties.java"),
+				filepath.Join(base, "config", "RateLimitInterceptor.java"),
+				filepath.Join(base, "config", "RateLimitConfig.java"),
+				filepath.Join(base, "config", "RateLimitExceededException.java"),
+			)
+		}
+		if a.config.HasIdempotency() {
+			store := "JdbcIdempotencyStore.java"
+			if a.config.IdempotencyUsesRedis() {
+				store = "RedisIdempotencyStore.java"
+			}
+			files = append(files,
+				filepath.Join(base, "config", "IdempotencyProperties.java"),
+				filepath.Join(base, "config", "IdempotencyStore.java"),
+				filepath.Join(base, "config", store),
+				filepath.Join(base, "config", "IdempotencyFilter.java"),
+				filepath.Join(base, "config", "IdempotencyConfig.java"),
+			)
+		}
 
 	case config.ModuleJobs:
 		base := filepath.Join(config.ModuleJobs, "src", "main", "java", packagePath, "jobs")
@@ -1195,6 +1745,12 @@ func (a *ModuleAdder) getModuleFiles(module string) []string {
 			filepath.Join(config.ModuleEvents, "pom.xml"),
 			filepath.Join(base, "EventPublisher.java"),
 		)
+		if a.config.UsesKafka() {
+			files = append(files,
+				filepath.Join(base, "config", "KafkaPublisherConfig.java"),
+				filepath.Join(base, "config", "CorrelationIdProducerInterceptor.java"),
+			)
+		}
 
 	case config.ModuleEventConsumer:
 		base := filepath.Join(config.ModuleEventConsumer, "src", "main", "java", packagePath, "eventconsumer")
@@ -1204,9 +1760,32 @@ func (a *ModuleAdder) getModuleFiles(module string) []string {
 			filepath.Join(base, "listener", "PlaceholderEventListener.java"),
 			filepath.Join(config.ModuleEventConsumer, "src", "main", "resources", "application.yml"),
 			filepath.Join(config.ModuleEventConsumer, "Dockerfile"),
+			filepath.Join(config.ModuleEventConsumer, "src", "test", "java", packagePath, "eventconsumer", "EventConsumerArchitectureTest.java"),
+		)
+		if a.config.UsesKafka() || a.config.UsesRabbitMQ() {
+			files = append(files, filepath.Join(base, "config", "CorrelationIdInterceptor.java"))
+		}
+		if a.config.HasKafkaTransactions() {
+			files = append(files, filepath.Join(base, "listener", "PlaceholderEventProcessor.java"))
+		}
+
+	case config.ModuleClientSDK:
+		files = append(files,
+			filepath.Join(config.ModuleClientSDK, "pom.xml"),
+			filepath.Join(config.ModuleClientSDK, "src", "main", "openapi", "openapi.json"),
 		)
 
 	}
 
+	switch module {
+	case config.ModuleAPI, config.ModuleWorker, config.ModuleEventConsumer:
+		for _, env := range envProfiles {
+			files = append(files, filepath.Join(module, "src", "main", "resources", "application-"+env+".yml"))
+		}
+		if a.config.HasSecrets() {
+			files = append(files, filepath.Join(module, "src", "main", "resources", "secrets.yml"))
+		}
+	}
+
 	return files
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/doctor"
 )
 
 func TestModuleAdderValidateCanAdd(t *testing.T) {
@@ -28,6 +29,7 @@ func TestModuleAdderValidateCanAdd(t *testing.T) {
 		{"cannot add existing module", "API", true},
 		{"cannot add unknown module", "UnknownModule", true},
 		{"cannot add internal module", "Jobs", true},
+		{"can add Events publisher on its own", "Events", false},
 	}
 
 	for _, tt := range tests {
@@ -43,6 +45,42 @@ func TestModuleAdderValidateCanAdd(t *testing.T) {
 	}
 }
 
+func TestModuleAdderEventsBroker(t *testing.T) {
+	t.Run("Events requires a broker", func(t *testing.T) {
+		metadata := &config.ProjectMetadata{
+			ProjectName: "test-project",
+			GroupID:     "com.example.test",
+			Modules:     []string{"Model", "API"},
+		}
+
+		adder := NewModuleAdder("/tmp/test", metadata, "1.0.0", false)
+		if err := adder.validateOptions("Events", "", "", ""); err == nil {
+			t.Error("Expected error when adding Events without a broker")
+		}
+		if err := adder.validateOptions("Events", "", "", "kafka"); err != nil {
+			t.Errorf("Expected no error but got: %v", err)
+		}
+	})
+
+	t.Run("EventConsumer cannot switch the publisher's broker", func(t *testing.T) {
+		metadata := &config.ProjectMetadata{
+			ProjectName:   "test-project",
+			GroupID:       "com.example.test",
+			Modules:       []string{"Model", "API", "Events"},
+			MessageBroker: "rabbitmq",
+		}
+
+		adder := NewModuleAdder("/tmp/test", metadata, "1.0.0", false)
+		err := adder.validateOptions("EventConsumer", "", "", "kafka")
+		if err == nil || !strings.Contains(err.Error(), "already publishes events with rabbitmq") {
+			t.Errorf("Expected broker conflict error, got: %v", err)
+		}
+		if err := adder.validateOptions("EventConsumer", "", "", ""); err != nil {
+			t.Errorf("Expected no error but got: %v", err)
+		}
+	})
+}
+
 func TestModuleAdderMutualExclusion(t *testing.T) {
 	t.Run("cannot add NoSQLDatastore when SQLDatastore exists", func(t *testing.T) {
 		metadata := &config.ProjectMetadata{
@@ -444,3 +482,195 @@ func TestGetFilesToBackup(t *testing.T) {
 		})
 	}
 }
+
+func TestListRestorePruneBackups(t *testing.T) {
+	projectPath := t.TempDir()
+
+	for i, id := range []string{"20260101-000000", "20260102-000000", "20260103-000000"} {
+		// Each backup is taken before the same add
+		os.WriteFile(filepath.Join(projectPath, "pom.xml"), []byte("original"), 0644)
+		os.Remove(filepath.Join(projectPath, "README.md"))
+		os.RemoveAll(filepath.Join(projectPath, "Worker"))
+
+		backup := NewBackupManager(projectPath, true)
+		backup.timestamp = id
+		backup.backupDir = filepath.Join(projectPath, BackupDirName, id)
+		backup.SetOperation("add Worker", "1.0.0")
+		if err := backup.BackupAll([]string{"pom.xml", "README.md"}); err != nil {
+			t.Fatal(err)
+		}
+		backup.TrackCreatedDir(filepath.Join(projectPath, "Worker"))
+
+		// Simulate the add the backup was taken before
+		os.WriteFile(filepath.Join(projectPath, "pom.xml"), []byte("modified"), 0644)
+		os.WriteFile(filepath.Join(projectPath, "README.md"), []byte("created"), 0644)
+		os.MkdirAll(filepath.Join(projectPath, "Worker", "src"), 0755)
+
+		if err := backup.WriteManifest(BackupCompleted); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			// The oldest backup predates manifests
+			os.Remove(filepath.Join(backup.backupDir, BackupManifestFile))
+		}
+	}
+
+	backups, err := ListBackups(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 3 || backups[0].ID != "20260103-000000" {
+		t.Fatalf("expected 3 backups newest first, got %+v", backups)
+	}
+	if backups[0].Operation != "add Worker" || backups[0].Status != BackupCompleted {
+		t.Errorf("expected manifest metadata, got %+v", backups[0])
+	}
+	if backups[2].Operation != "unknown" || len(backups[2].Files) != 1 || backups[2].Files[0] != "pom.xml" {
+		t.Errorf("expected a legacy backup listed from its files, got %+v", backups[2])
+	}
+
+	os.WriteFile(filepath.Join(projectPath, "pom.xml"), []byte("modified"), 0644)
+	os.WriteFile(filepath.Join(projectPath, "README.md"), []byte("created"), 0644)
+	os.MkdirAll(filepath.Join(projectPath, "Worker", "src"), 0755)
+
+	if _, err := RestoreBackup(projectPath, "20260103-000000"); err != nil {
+		t.Fatalf("RestoreBackup failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(projectPath, "pom.xml")); string(data) != "original" {
+		t.Errorf("expected pom.xml restored, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "README.md")); !os.IsNotExist(err) {
+		t.Error("expected README.md, created after the backup, to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "Worker")); !os.IsNotExist(err) {
+		t.Error("expected the created Worker directory to be removed")
+	}
+
+	if _, err := RestoreBackup(projectPath, "../outside"); err == nil {
+		t.Error("expected an invalid backup id to be rejected")
+	}
+	if _, err := RestoreBackup(projectPath, "20250101-000000"); err == nil {
+		t.Error("expected an unknown backup id to fail")
+	}
+
+	removed, err := PruneBackups(projectPath, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 2 || removed[0] != "20260102-000000" {
+		t.Errorf("expected the 2 oldest backups pruned, got %v", removed)
+	}
+	if backups, _ := ListBackups(projectPath); len(backups) != 1 || backups[0].ID != "20260103-000000" {
+		t.Errorf("expected only the newest backup left, got %+v", backups)
+	}
+}
+
+func TestModuleAdder_Add_KeepsRestorePoint(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "restorable")
+	cfg := &config.ProjectConfig{
+		ProjectName: "restorable",
+		GroupID:     "com.test.restorable",
+		ArtifactID:  "restorable",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	pomBefore, _ := os.ReadFile(filepath.Join(projectPath, "pom.xml"))
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewModuleAdder(projectPath, metadata, "test", true).Add(config.ModuleWorker, "", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	backups, err := ListBackups(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 || backups[0].Operation != "add Worker" || backups[0].Status != BackupCompleted {
+		t.Fatalf("expected a completed 'add Worker' restore point, got %+v", backups)
+	}
+	if !strings.Contains(strings.Join(backups[0].CreatedDirs, ","), "Worker") {
+		t.Errorf("expected Worker among created directories, got %v", backups[0].CreatedDirs)
+	}
+
+	if _, err := RestoreBackup(projectPath, backups[0].ID); err != nil {
+		t.Fatalf("RestoreBackup failed: %v", err)
+	}
+	if pomAfter, _ := os.ReadFile(filepath.Join(projectPath, "pom.xml")); string(pomAfter) != string(pomBefore) {
+		t.Error("expected the parent POM back to its pre-add content")
+	}
+	for _, path := range []string{"Worker", "Jobs", ".run/Worker.run.xml"} {
+		if _, err := os.Stat(filepath.Join(projectPath, path)); !os.IsNotExist(err) {
+			t.Errorf("expected %s removed by the restore", path)
+		}
+	}
+	restored, _ := config.LoadMetadata(projectPath)
+	if restored.HasModule(config.ModuleWorker) {
+		t.Error("expected .trabuco.json back without Worker")
+	}
+}
+
+func TestModuleAdder_Add_ArchitectureTests(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	gen, err := NewWithVersionAt(&config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.test.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Each rule lives in the module whose classes it checks
+	if model := readFile(t, projectPath, "Model/src/test/java/com/test/shop/model/ModelArchitectureTest.java"); !strings.Contains(model, `"org.springframework.web.."`) {
+		t.Errorf("ModelArchitectureTest should forbid Spring web:\n%s", model)
+	}
+	if api := readFile(t, projectPath, "API/src/test/java/com/test/shop/api/ApiArchitectureTest.java"); !strings.Contains(api, "void controllersShouldNotAccessRepositoriesDirectly()") {
+		t.Errorf("ApiArchitectureTest should keep controllers away from repositories:\n%s", api)
+	}
+	shared := readFile(t, projectPath, "Shared/src/test/java/com/test/shop/shared/ArchitectureTest.java")
+	if strings.Contains(shared, "controllersShouldNotAccessRepositoriesDirectly") || strings.Contains(shared, "noForeignKeysInMigrations") {
+		t.Errorf("Shared ArchitectureTest should have no API or SQLDatastore rule:\n%s", shared)
+	}
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewModuleAdder(projectPath, metadata, "test", false).Add(config.ModuleSQLDatastore, config.DatabasePostgreSQL, "", ""); err != nil {
+		t.Fatalf("Add SQLDatastore failed: %v", err)
+	}
+	metadata, _ = config.LoadMetadata(projectPath)
+	if err := NewModuleAdder(projectPath, metadata, "test", false).Add(config.ModuleEventConsumer, "", "", config.BrokerKafka); err != nil {
+		t.Fatalf("Add EventConsumer failed: %v", err)
+	}
+
+	if shared := readFile(t, projectPath, "Shared/src/test/java/com/test/shop/shared/ArchitectureTest.java"); !strings.Contains(shared, "void noForeignKeysInMigrations()") {
+		t.Errorf("adding SQLDatastore should regenerate the Shared ArchitectureTest:\n%s", shared)
+	}
+	if consumer := readFile(t, projectPath, "EventConsumer/src/test/java/com/test/shop/eventconsumer/EventConsumerArchitectureTest.java"); !strings.Contains(consumer, "void listenersShouldStayThin()") {
+		t.Errorf("EventConsumerArchitectureTest should keep listeners thin:\n%s", consumer)
+	}
+	if pom := readFile(t, projectPath, "EventConsumer/pom.xml"); !strings.Contains(pom, "<artifactId>archunit-junit5</artifactId>") {
+		t.Error("EventConsumer POM should depend on ArchUnit")
+	}
+
+	metadata, _ = config.LoadMetadata(projectPath)
+	if result := doctor.NewArchitectureRulesCheck().Check(projectPath, metadata); result.Status != doctor.SeverityPass {
+		t.Errorf("architecture tests should match the modules after add, got %v: %v", result.Status, result.Details)
+	}
+}
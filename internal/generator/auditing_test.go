@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_Auditing(t *testing.T) {
+	tests := []struct {
+		name       string
+		language   string
+		database   string
+		record     string
+		column     string
+		repo       string
+		softDelete string
+	}{
+		{"java-postgresql", "java", "postgresql", "Model/src/main/java/com/test/audited/model/entities/PlaceholderRecord.java", "deleted_at TIMESTAMP WITH TIME ZONE",
+			"SQLDatastore/src/main/java/com/test/audited/sqldatastore/repository/PlaceholderRepository.java", "int softDeleteById"},
+		{"kotlin-mysql", "kotlin", "mysql", "Model/src/main/kotlin/com/test/audited/model/entities/PlaceholderRecord.kt", "deleted_at TIMESTAMP NULL",
+			"SQLDatastore/src/main/kotlin/com/test/audited/sqldatastore/repository/PlaceholderRepository.kt", "fun softDeleteById"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName: "audited",
+				GroupID:     "com.test.audited",
+				ArtifactID:  "audited",
+				JavaVersion: "21",
+				Language:    tt.language,
+				Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+				Database:    tt.database,
+				Auditing:    true,
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			read := func(path string) string {
+				t.Helper()
+				data, err := os.ReadFile(filepath.Join("audited", path))
+				if err != nil {
+					t.Fatalf("expected %s: %v", path, err)
+				}
+				return string(data)
+			}
+
+			migration := read("SQLDatastore/src/main/resources/db/migration/V1__baseline.sql")
+			if !strings.Contains(migration, "created_by VARCHAR(255)") || !strings.Contains(migration, tt.column) {
+				t.Errorf("baseline should add created_by and %q:\n%s", tt.column, migration)
+			}
+			if !strings.Contains(read("SQLDatastore/src/main/java/com/test/audited/sqldatastore/config/AuditingConfig.java"), "@EnableJdbcAuditing") {
+				t.Error("AuditingConfig should enable JDBC auditing")
+			}
+			if !strings.Contains(read("SQLDatastore/pom.xml"), "spring-security-core") {
+				t.Error("SQLDatastore should depend on spring-security-core for the auditor")
+			}
+			if record := read(tt.record); !strings.Contains(record, "@CreatedBy") || !strings.Contains(record, "deletedAt") {
+				t.Errorf("%s should carry the auditing fields", tt.record)
+			}
+			repo := read(tt.repo)
+			if !strings.Contains(repo, tt.softDelete) || !strings.Contains(repo, "WHERE id IN (:ids) AND deleted_at IS NULL") {
+				t.Error("PlaceholderRepository should soft-delete and filter deleted rows")
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_AuditingNeedsSQL(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "mongo-app",
+		GroupID:       "com.test.mongoapp",
+		ArtifactID:    "mongo-app",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "NoSQLDatastore", "Shared", "API"},
+		NoSQLDatabase: "mongodb",
+		Auditing:      true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join("mongo-app", "Model/src/main/java/com/test/mongoapp/model/entities/Placeholder.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "createdBy") {
+		t.Error("auditing fields need SQLDatastore")
+	}
+}
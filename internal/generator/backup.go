@@ -1,29 +1,62 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/plugin"
 )
 
 // BackupManager handles backup and restore of files before modification
 type BackupManager struct {
-	projectPath     string
-	backupDir       string
-	timestamp       string
-	files           []string
-	createdDirs     []string // Track directories created during add operation
-	enabled         bool
+	projectPath string
+	backupDir   string
+	timestamp   string
+	files       []string
+	absent      []string // Files that did not exist; restore removes them
+	createdDirs []string // Track directories created during add operation
+	enabled     bool
+	operation   string
+	version     string
 }
 
 // BackupDirName is the name of the backup directory
 const BackupDirName = ".trabuco-backup"
 
+// BackupManifestFile describes a backup; it lives inside its directory
+const BackupManifestFile = "backup.json"
+
+// DefaultBackupRetention is how many backups a successful operation keeps
+const DefaultBackupRetention = 5
+
+// Backup statuses recorded in the manifest
+const (
+	BackupInProgress    = "in_progress"    // Operation still running (or the process died)
+	BackupCompleted     = "completed"      // Operation succeeded; restoring undoes it
+	BackupRestoreFailed = "restore_failed" // Operation failed and the automatic rollback did too
+)
+
+// BackupInfo is the manifest of one backup: what produced it and what a
+// restore puts back
+type BackupInfo struct {
+	ID          string   `json:"id"`
+	Operation   string   `json:"operation"`
+	Version     string   `json:"trabuco_version,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	Status      string   `json:"status"`
+	Files       []string `json:"files"`                  // Saved copies, project-relative
+	Absent      []string `json:"absent,omitempty"`       // Did not exist before; removed on restore
+	CreatedDirs []string `json:"created_dirs,omitempty"` // Created by the operation; removed on restore
+}
+
 // NewBackupManager creates a new BackupManager
 func NewBackupManager(projectPath string, enabled bool) *BackupManager {
 	timestamp := time.Now().Format("20060102-150405")
@@ -39,6 +72,18 @@ func NewBackupManager(projectPath string, enabled bool) *BackupManager {
 	}
 }
 
+// SetOperation records what produced the backup (e.g. "add Worker") and the
+// Trabuco version, both written to its manifest
+func (b *BackupManager) SetOperation(operation, version string) {
+	b.operation = operation
+	b.version = version
+}
+
+// ID returns the backup identifier used by 'trabuco backup restore'
+func (b *BackupManager) ID() string {
+	return b.timestamp
+}
+
 // TrackCreatedDir records a directory that was created during the add operation
 // so it can be removed during rollback
 func (b *BackupManager) TrackCreatedDir(dir string) {
@@ -49,6 +94,15 @@ func (b *BackupManager) TrackCreatedDir(dir string) {
 	b.createdDirs = append(b.createdDirs, dir)
 }
 
+// TrackCreatedFile records a file created by the operation so a restore
+// removes it
+func (b *BackupManager) TrackCreatedFile(relativePath string) {
+	if !b.enabled || slices.Contains(b.files, relativePath) || slices.Contains(b.absent, relativePath) {
+		return
+	}
+	b.absent = append(b.absent, relativePath)
+}
+
 // Backup creates a backup of a single file
 func (b *BackupManager) Backup(relativePath string) error {
 	if !b.enabled {
@@ -59,7 +113,14 @@ func (b *BackupManager) Backup(relativePath string) error {
 
 	// Check if source file exists
 	if _, err := os.Stat(srcPath); os.IsNotExist(err) {
-		return nil // Nothing to backup
+		// Nothing to back up, but a restore must remove it again
+		if !slices.Contains(b.absent, relativePath) && !slices.Contains(b.files, relativePath) {
+			b.absent = append(b.absent, relativePath)
+		}
+		return nil
+	}
+	if slices.Contains(b.files, relativePath) || slices.Contains(b.absent, relativePath) {
+		return nil // Keep the oldest copy
 	}
 
 	// Create backup directory if needed
@@ -112,6 +173,13 @@ func (b *BackupManager) Restore() error {
 		}
 	}
 
+	// Remove files that did not exist when the backup was taken
+	for _, relativePath := range b.absent {
+		if err := os.Remove(filepath.Join(b.projectPath, relativePath)); err != nil && !os.IsNotExist(err) {
+			restoreErrors = append(restoreErrors, fmt.Errorf("failed to remove %s: %w", relativePath, err))
+		}
+	}
+
 	// Then, remove created directories in reverse order (deepest first)
 	// This ensures parent directories are removed after their children
 	for i := len(b.createdDirs) - 1; i >= 0; i-- {
@@ -207,8 +275,178 @@ func (b *BackupManager) PrintRestoreInstructions() {
 		return
 	}
 
-	fmt.Println("\nTo restore from backup:")
-	fmt.Printf("  cp -r %s/* %s/\n", b.backupDir, b.projectPath)
+	fmt.Fprintf(os.Stderr, "\nRestore point %s kept. To restore it:\n", b.timestamp)
+	fmt.Fprintf(os.Stderr, "  trabuco backup restore %s\n", b.timestamp)
+}
+
+// WriteManifest records the backup's manifest with the given status. A
+// backup that saved nothing and tracked nothing is not written.
+func (b *BackupManager) WriteManifest(status string) error {
+	if !b.enabled || (len(b.files) == 0 && len(b.absent) == 0 && len(b.createdDirs) == 0) {
+		return nil
+	}
+	info := BackupInfo{
+		ID:        b.timestamp,
+		Operation: b.operation,
+		Version:   b.version,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Status:    status,
+		Files:     b.files,
+		Absent:    b.absent,
+	}
+	for _, dir := range b.createdDirs {
+		if rel, err := filepath.Rel(b.projectPath, dir); err == nil {
+			info.CreatedDirs = append(info.CreatedDirs, filepath.ToSlash(rel))
+		}
+	}
+	if status == BackupCompleted {
+		// Only the files the operation actually created need removing, and
+		// those inside a created directory go with it
+		info.Absent = nil
+		for _, f := range b.absent {
+			if _, err := os.Stat(filepath.Join(b.projectPath, f)); err != nil {
+				continue
+			}
+			if !slices.ContainsFunc(info.CreatedDirs, func(dir string) bool { return strings.HasPrefix(f, dir+"/") }) {
+				info.Absent = append(info.Absent, f)
+			}
+		}
+	}
+	if err := os.MkdirAll(b.backupDir, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.backupDir, BackupManifestFile), append(data, '\n'), 0644)
+}
+
+// ListBackups returns the project's backups, newest first. Backups written
+// before manifests existed are listed from their directory contents.
+func ListBackups(projectPath string) ([]BackupInfo, error) {
+	backupRoot := filepath.Join(projectPath, BackupDirName)
+	entries, err := os.ReadDir(backupRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var backups []BackupInfo
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := loadBackup(projectPath, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, *info)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].ID > backups[j].ID })
+	return backups, nil
+}
+
+// loadBackup reads one backup's manifest
+func loadBackup(projectPath, id string) (*BackupInfo, error) {
+	dir := filepath.Join(projectPath, BackupDirName, id)
+	data, err := os.ReadFile(filepath.Join(dir, BackupManifestFile))
+	if err == nil {
+		info := &BackupInfo{}
+		if err := json.Unmarshal(data, info); err != nil {
+			return nil, fmt.Errorf("invalid %s in backup %s: %w", BackupManifestFile, id, err)
+		}
+		info.ID = id
+		return info, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	info := &BackupInfo{ID: id, Operation: "unknown", Status: "unknown"}
+	if t, err := time.ParseInLocation("20060102-150405", id, time.Local); err == nil {
+		info.CreatedAt = t.UTC().Format(time.RFC3339)
+	}
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info.Files = append(info.Files, filepath.ToSlash(rel))
+		return nil
+	})
+	return info, err
+}
+
+// RestoreBackup puts a project back to the state saved in a backup: saved
+// files are copied back, and files and directories the operation created
+// are removed. The backup itself is kept.
+func RestoreBackup(projectPath, id string) (*BackupInfo, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return nil, fmt.Errorf("invalid backup id %q", id)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, BackupDirName, id)); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("backup %s not found (see 'trabuco backup list')", id)
+		}
+		return nil, err
+	}
+	info, err := loadBackup(projectPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &BackupManager{
+		projectPath: projectPath,
+		backupDir:   filepath.Join(projectPath, BackupDirName, id),
+		timestamp:   id,
+		absent:      info.Absent,
+		enabled:     true,
+	}
+	for _, f := range info.Files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(projectPath, f)), 0755); err != nil {
+			return nil, err
+		}
+		b.files = append(b.files, f)
+	}
+	for _, dir := range info.CreatedDirs {
+		b.createdDirs = append(b.createdDirs, filepath.Join(projectPath, filepath.FromSlash(dir)))
+	}
+	if err := b.Restore(); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// PruneBackups deletes all but the keep most recent backups and returns the
+// IDs it removed
+func PruneBackups(projectPath string, keep int) ([]string, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("keep must not be negative")
+	}
+	backups, err := ListBackups(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for i := keep; i < len(backups); i++ {
+		if err := os.RemoveAll(filepath.Join(projectPath, BackupDirName, backups[i].ID)); err != nil {
+			return removed, fmt.Errorf("failed to remove backup %s: %w", backups[i].ID, err)
+		}
+		removed = append(removed, backups[i].ID)
+	}
+
+	// Drop the backup root once it is empty
+	backupRoot := filepath.Join(projectPath, BackupDirName)
+	if entries, err := os.ReadDir(backupRoot); err == nil && len(entries) == 0 {
+		os.Remove(backupRoot)
+	}
+	return removed, nil
 }
 
 // copyFile copies a file from src to dst
@@ -246,10 +484,14 @@ func GetFilesToBackup(module string) []string {
 		// Documentation files that will be regenerated
 		"README.md",
 		"AGENTS.md",
+		"docs/errors.md",
 		// AI agent context files (backup handles nonexistent files gracefully)
 		"CLAUDE.md",
 		".cursor/rules/project.mdc",
 		".github/copilot-instructions.md",
+		"GEMINI.md",
+		"CONVENTIONS.md",
+		".rules",
 		// Agent-specific config files
 		".claude/settings.json",
 		".cursor/rules/java.mdc",
@@ -258,8 +500,17 @@ func GetFilesToBackup(module string) []string {
 		".github/instructions/java.instructions.md",
 		".codex/hooks.json",
 		".codex/config.toml",
+		".aider.conf.yml",
 		// CI workflow
 		".github/workflows/ci.yml",
+		// Dev container (forwarded ports follow the modules)
+		".devcontainer/devcontainer.json",
+		// Task runner (targets follow the modules)
+		"Makefile",
+		"Taskfile.yml",
+		// Environment contract (variables follow the modules' configuration)
+		"ENV.md",
+		".env.schema",
 	}
 
 	// Docker-related files
@@ -267,8 +518,23 @@ func GetFilesToBackup(module string) []string {
 		files = append(files, "docker-compose.yml", ".env.example")
 	}
 
+	// module-info.java descriptors regenerated for --jpms projects
+	for _, m := range config.GetModuleNames() {
+		files = append(files, m+"/src/main/java/module-info.java")
+	}
+
+	// CODEOWNERS and the module boundary docs, all regenerated for
+	// --with-codeowners projects so a restore keeps them in step
+	files = append(files, config.CodeOwnersFile)
+	for _, m := range config.GetModuleNames() {
+		files = append(files, config.ModuleBoundaryDoc(m))
+	}
+	if !slices.Contains(config.GetModuleNames(), module) {
+		files = append(files, config.ModuleBoundaryDoc(module))
+	}
+
 	// Model module files that might be updated
-	if module == config.ModuleSQLDatastore || module == config.ModuleNoSQLDatastore || module == config.ModuleWorker || module == config.ModuleEventConsumer {
+	if module == config.ModuleSQLDatastore || module == config.ModuleNoSQLDatastore || module == config.ModuleWorker || module == config.ModuleEvents || module == config.ModuleEventConsumer {
 		files = append(files, config.ModuleModel+"/pom.xml")
 	}
 
@@ -278,9 +544,12 @@ func GetFilesToBackup(module string) []string {
 // needsDockerComposeUpdate returns true if adding this module might need docker-compose updates
 func needsDockerComposeUpdate(module string) bool {
 	switch module {
-	case config.ModuleSQLDatastore, config.ModuleNoSQLDatastore, config.ModuleWorker, config.ModuleEventConsumer:
+	case config.ModuleSQLDatastore, config.ModuleNoSQLDatastore, config.ModuleSearch, config.ModuleWorker, config.ModuleEvents, config.ModuleEventConsumer:
 		return true
 	default:
+		if p := plugin.Get(module); p != nil {
+			return len(p.Manifest.Docker.Services) > 0
+		}
 		return false
 	}
 }
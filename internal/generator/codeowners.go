@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+)
+
+// boundaryRule is an ArchUnit rule a module's boundary doc lists. Test is
+// the class holding it and Host the module that test lives in.
+type boundaryRule struct {
+	Host   string
+	Test   string
+	Rule   string
+	Checks string
+}
+
+// moduleBoundaryRules are the generated ArchUnit rules guarding each
+// module's boundary
+var moduleBoundaryRules = map[string][]boundaryRule{
+	config.ModuleModel: {
+		{config.ModuleModel, "ModelArchitectureTest", "modelShouldNotDependOnSpringWeb", "No Spring web, HTTP or Servlet types"},
+	},
+	config.ModuleShared: {
+		{config.ModuleShared, "ArchitectureTest", "noFieldInjection", "Constructor injection only"},
+		{config.ModuleShared, "ArchitectureTest", "noCyclicDependenciesBetweenPackages", "No cycles between the modules' packages"},
+	},
+	config.ModuleSQLDatastore: {
+		{config.ModuleShared, "ArchitectureTest", "noForeignKeysInMigrations", "Flyway migrations declare no foreign keys"},
+	},
+	config.ModuleAPI: {
+		{config.ModuleAPI, "ApiArchitectureTest", "controllersShouldNotAccessRepositoriesDirectly", "Controllers call services, never repositories"},
+		{config.ModuleAPI, "ApiArchitectureTest", "controllerHandlersMustDeclareAuthorization", "Every endpoint declares an authorization decision"},
+	},
+	config.ModuleEventConsumer: {
+		{config.ModuleEventConsumer, "EventConsumerArchitectureTest", "listenersShouldStayThin", "Listeners delegate; no repositories, JDBC or REST clients"},
+	},
+	config.ModuleAIAgent: {
+		{config.ModuleAIAgent, "AgentArchitectureTest", "controllerHandlersMustDeclareAuthorization", "Every endpoint declares an authorization decision"},
+	},
+}
+
+// moduleDocData is the data of a module's boundary doc
+type moduleDocData struct {
+	*config.ProjectConfig
+	Module    config.Module
+	Source    string
+	Owner     string
+	DependsOn []string
+	UsedBy    []string
+	Rules     []boundaryRule
+}
+
+// newModuleDocData returns the boundary doc data of an installed module
+func newModuleDocData(cfg *config.ProjectConfig, module string) *moduleDocData {
+	data := &moduleDocData{
+		ProjectConfig: cfg,
+		Module:        config.Module{Name: module},
+		Source:        strings.TrimPrefix(cfg.ModuleSourcePattern(module), "/"),
+		Owner:         cfg.CodeOwnersTeam(module),
+		DependsOn:     cfg.ModuleDependsOn(module),
+		UsedBy:        cfg.ModuleUsedBy(module),
+	}
+	if m := config.GetModule(module); m != nil {
+		data.Module = *m
+	}
+	for _, rule := range moduleBoundaryRules[module] {
+		if cfg.HasModule(rule.Host) {
+			data.Rules = append(data.Rules, rule)
+		}
+	}
+	return data
+}
+
+// codeOwnersFiles returns the files --with-codeowners generates
+func codeOwnersFiles(cfg *config.ProjectConfig) []string {
+	files := []string{config.CodeOwnersFile}
+	for _, module := range cfg.CodeOwnersModules() {
+		files = append(files, config.ModuleBoundaryDoc(module))
+	}
+	return files
+}
+
+// generateCodeOwners writes CODEOWNERS and the module boundary docs when
+// the project asked for them with --with-codeowners
+func (g *Generator) generateCodeOwners() error {
+	if !g.config.HasCodeOwners() {
+		return nil
+	}
+	if err := g.writeTemplate("docs/CODEOWNERS.tmpl", config.CodeOwnersFile); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", config.CodeOwnersFile, err)
+	}
+	for _, module := range g.config.CodeOwnersModules() {
+		if err := g.writeTemplateWithData("docs/module.md.tmpl", config.ModuleBoundaryDoc(module), newModuleDocData(g.config, module)); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", config.ModuleBoundaryDoc(module), err)
+		}
+	}
+	return nil
+}
+
+// appendCodeOwners adds the entries of installed modules CODEOWNERS does
+// not mention yet, leaving the owners already set alone. It returns
+// whether the file changed.
+func appendCodeOwners(projectPath string, cfg *config.ProjectConfig) (bool, error) {
+	path := filepath.Join(projectPath, config.CodeOwnersFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	content := string(data)
+
+	patterns := map[string]bool{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && !strings.HasPrefix(fields[0], "#") {
+			patterns[fields[0]] = true
+		}
+	}
+	var added []string
+	for _, module := range cfg.CodeOwnersModules() {
+		for _, entry := range cfg.ModuleCodeOwners(module) {
+			if !patterns[entry.Pattern] {
+				added = append(added, entry.Line())
+			}
+		}
+	}
+	if len(added) == 0 {
+		return false, nil
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += strings.Join(added, "\n") + "\n"
+	return true, os.WriteFile(path, []byte(content), 0644)
+}
+
+// updateCodeOwners brings CODEOWNERS and the boundary docs in step with
+// the modules after 'trabuco add': the new module's entries are appended,
+// and every module's doc is regenerated, since the "Used by" of the
+// modules the new one builds on changes.
+func (a *ModuleAdder) updateCodeOwners() error {
+	if !a.config.HasCodeOwners() {
+		return nil
+	}
+	gen := &Generator{
+		config: a.config,
+		engine: a.engine,
+		outDir: a.projectPath,
+	}
+
+	if _, err := os.Stat(filepath.Join(a.projectPath, config.CodeOwnersFile)); os.IsNotExist(err) {
+		if err := gen.writeTemplate("docs/CODEOWNERS.tmpl", config.CodeOwnersFile); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", config.CodeOwnersFile, err)
+		}
+	} else if _, err := appendCodeOwners(a.projectPath, a.config); err != nil {
+		return fmt.Errorf("failed to update %s: %w", config.CodeOwnersFile, err)
+	}
+	for _, module := range a.config.CodeOwnersModules() {
+		if err := a.writeDoc(gen, "docs/module.md.tmpl", config.ModuleBoundaryDoc(module), newModuleDocData(a.config, module)); err != nil {
+			return fmt.Errorf("failed to regenerate %s: %w", config.ModuleBoundaryDoc(module), err)
+		}
+	}
+	return nil
+}
+
+// RetrofitCodeOwners adds CODEOWNERS and the module boundary docs to the
+// existing project at projectPath (`trabuco generate codeowners`) and
+// records the flag in .trabuco.json. It refuses to overwrite a file that
+// already exists. It returns the files created, relative to projectPath;
+// in dry-run mode nothing is written and the files that would be are
+// returned.
+func RetrofitCodeOwners(projectPath string, metadata *config.ProjectMetadata, dryRun bool) ([]string, error) {
+	if metadata.CodeOwners {
+		return nil, fmt.Errorf("CODEOWNERS is already part of this project")
+	}
+	cfg := metadata.ToProjectConfig()
+	cfg.CodeOwners = true
+
+	gen := &Generator{
+		config: cfg,
+		engine: templates.NewEngine().WithProjectOverrides(projectPath),
+		outDir: projectPath,
+	}
+	created := codeOwnersFiles(cfg)
+	for _, f := range created {
+		if _, err := os.Stat(filepath.Join(projectPath, f)); err == nil {
+			return nil, fmt.Errorf("refusing to overwrite existing file: %s (delete it first if you want to regenerate)", f)
+		}
+	}
+	if dryRun {
+		return created, nil
+	}
+
+	if err := gen.generateCodeOwners(); err != nil {
+		return nil, err
+	}
+	metadata.CodeOwners = true
+	metadata.UpdateGeneratedAt()
+	if err := config.SaveMetadata(projectPath, metadata); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", config.MetadataFileName, err)
+	}
+	return created, nil
+}
@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_CodeOwners(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "teams")
+	gen, err := NewWithVersionAt(&config.ProjectConfig{
+		ProjectName: "teams",
+		GroupID:     "com.test.teams",
+		ArtifactID:  "teams",
+		JavaVersion: "21",
+		Modules:     []string{"API", "Shared", "Model"},
+		CodeOwners:  true,
+	}, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	owners := readFile(t, projectPath, "CODEOWNERS")
+	want := "* @your-org/teams-maintainers\n/CODEOWNERS @your-org/teams-maintainers\n\n# Modules\n" +
+		"/Model/ @your-org/model-owners\n/docs/modules/Model.md @your-org/model-owners\n" +
+		"/Shared/ @your-org/shared-owners\n/docs/modules/Shared.md @your-org/shared-owners\n" +
+		"/API/ @your-org/api-owners\n/docs/modules/API.md @your-org/api-owners\n"
+	if !strings.HasSuffix(owners, want) {
+		t.Errorf("CODEOWNERS should list the modules in registry order:\n%s", owners)
+	}
+
+	shared := readFile(t, projectPath, "docs/modules/Shared.md")
+	for _, s := range []string{
+		"# Shared module",
+		"| Owner | `@your-org/shared-owners`",
+		"| Builds on | [Model](Model.md) |",
+		"| Used by | [API](API.md) |",
+		"| `ArchitectureTest` | `noFieldInjection` |",
+	} {
+		if !strings.Contains(shared, s) {
+			t.Errorf("Shared.md missing %q:\n%s", s, shared)
+		}
+	}
+	if api := readFile(t, projectPath, "docs/modules/API.md"); !strings.Contains(api, "`controllersShouldNotAccessRepositoriesDirectly`") {
+		t.Errorf("API.md should list the controller rule:\n%s", api)
+	}
+
+	// A team set by hand survives 'trabuco add', which appends the new
+	// modules and refreshes the docs they change
+	edited := strings.Replace(owners, "/API/ @your-org/api-owners", "/API/ @acme/web", 1)
+	if err := os.WriteFile(filepath.Join(projectPath, "CODEOWNERS"), []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	notes := filepath.Join(projectPath, "docs/modules/Model.md")
+	model, _ := os.ReadFile(notes)
+	if err := os.WriteFile(notes, append(model, "On call: #model-team\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.CodeOwners {
+		t.Fatal("expected codeOwners recorded in .trabuco.json")
+	}
+	ownersBefore := readFile(t, projectPath, "CODEOWNERS")
+	modelBefore := readFile(t, projectPath, "docs/modules/Model.md")
+	if err := NewModuleAdder(projectPath, metadata, "test", true).Add(config.ModuleWorker, "", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	owners = readFile(t, projectPath, "CODEOWNERS")
+	if !strings.Contains(owners, "/API/ @acme/web\n") || strings.Contains(owners, "/API/ @your-org") {
+		t.Errorf("the API owner set by hand should be kept:\n%s", owners)
+	}
+	if !strings.HasSuffix(owners, "/Jobs/ @your-org/jobs-owners\n/docs/modules/Jobs.md @your-org/jobs-owners\n/Worker/ @your-org/worker-owners\n/docs/modules/Worker.md @your-org/worker-owners\n") {
+		t.Errorf("the Jobs and Worker entries should be appended:\n%s", owners)
+	}
+	model, _ = os.ReadFile(notes)
+	if !strings.Contains(string(model), "| Used by | [Jobs](Jobs.md), [Shared](Shared.md), [API](API.md), [Worker](Worker.md) |") || !strings.Contains(string(model), "On call: #model-team") {
+		t.Errorf("Model.md should list Jobs and Worker and keep the team notes:\n%s", model)
+	}
+	if worker := readFile(t, projectPath, "docs/modules/Worker.md"); !strings.Contains(worker, "| Builds on | [Model](Model.md), [Jobs](Jobs.md) |") {
+		t.Errorf("Worker.md should be generated:\n%s", worker)
+	}
+
+	// Restoring the add's backup brings the boundary docs back with CODEOWNERS
+	backups, err := ListBackups(projectPath)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected one backup, got %v, %v", backups, err)
+	}
+	if _, err := RestoreBackup(projectPath, backups[0].ID); err != nil {
+		t.Fatalf("RestoreBackup failed: %v", err)
+	}
+	if readFile(t, projectPath, "CODEOWNERS") != ownersBefore {
+		t.Error("CODEOWNERS should be restored")
+	}
+	if readFile(t, projectPath, "docs/modules/Model.md") != modelBefore {
+		t.Error("Model.md should be restored with CODEOWNERS")
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "docs/modules/Worker.md")); !os.IsNotExist(err) {
+		t.Error("Worker.md did not exist before the add and should be removed by the restore")
+	}
+}
+
+func TestRetrofitCodeOwners(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "later")
+	gen, err := NewWithVersionAt(&config.ProjectConfig{
+		ProjectName: "later",
+		GroupID:     "com.test.later",
+		ArtifactID:  "later",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared"},
+	}, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "CODEOWNERS")); !os.IsNotExist(err) {
+		t.Fatal("CODEOWNERS should only be generated with --with-codeowners")
+	}
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := RetrofitCodeOwners(projectPath, metadata, true)
+	if err != nil || strings.Join(created, ",") != "CODEOWNERS,docs/modules/Model.md,docs/modules/Shared.md" {
+		t.Fatalf("unexpected dry run: %v, %v", created, err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "CODEOWNERS")); !os.IsNotExist(err) {
+		t.Fatal("dry run should not write files")
+	}
+
+	if _, err := RetrofitCodeOwners(projectPath, metadata, false); err != nil {
+		t.Fatalf("RetrofitCodeOwners failed: %v", err)
+	}
+	if owners := readFile(t, projectPath, "CODEOWNERS"); !strings.Contains(owners, "/Shared/ @your-org/shared-owners") {
+		t.Errorf("unexpected CODEOWNERS:\n%s", owners)
+	}
+	if saved, _ := config.LoadMetadata(projectPath); !saved.CodeOwners {
+		t.Error("expected codeOwners recorded in .trabuco.json")
+	}
+	if _, err := RetrofitCodeOwners(projectPath, metadata, false); err == nil {
+		t.Error("expected a second retrofit to be refused")
+	}
+}
@@ -427,6 +427,56 @@ func TestCompilation_WorkerWithNoSQLDatastore_Redis(t *testing.T) {
 	t.Log("Worker + NoSQLDatastore (Redis) project compiled successfully")
 }
 
+func TestCompilation_NoSQLWithDynamoDB(t *testing.T) {
+	checkMavenInstalled(t)
+
+	tempDir, err := os.MkdirTemp("", "trabuco-compile-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "nosql-dynamodb",
+		GroupID:       "com.test.nosqldynamodb",
+		ArtifactID:    "nosql-dynamodb",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "Jobs", "NoSQLDatastore", "Shared", "API", "Worker"},
+		NoSQLDatabase: "dynamodb",
+	}
+
+	projectDir := generateProject(t, tempDir, cfg)
+	t.Logf("Generated project at: %s", projectDir)
+
+	runMavenCompile(t, projectDir)
+	t.Log("NoSQLDatastore (DynamoDB) project compiled successfully")
+}
+
+func TestCompilation_NoSQLWithCassandra(t *testing.T) {
+	checkMavenInstalled(t)
+
+	tempDir, err := os.MkdirTemp("", "trabuco-compile-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "nosql-cassandra",
+		GroupID:       "com.test.nosqlcassandra",
+		ArtifactID:    "nosql-cassandra",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "Jobs", "NoSQLDatastore", "Shared", "API", "Worker"},
+		NoSQLDatabase: "cassandra",
+	}
+
+	projectDir := generateProject(t, tempDir, cfg)
+	t.Logf("Generated project at: %s", projectDir)
+
+	runMavenCompile(t, projectDir)
+	t.Log("NoSQLDatastore (Cassandra) project compiled successfully")
+}
+
 func TestCompilation_AllModulesWithWorker(t *testing.T) {
 	checkMavenInstalled(t)
 
@@ -655,6 +705,62 @@ func TestCompilation_EventConsumerWithPubSub(t *testing.T) {
 	t.Log("EventConsumer with GCP Pub/Sub compiled successfully")
 }
 
+func TestCompilation_EventConsumerWithRedisStreams(t *testing.T) {
+	checkMavenInstalled(t)
+
+	tempDir, err := os.MkdirTemp("", "trabuco-compile-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Resolve modules to auto-include Events
+	modules := config.ResolveDependencies([]string{"Model", "API", "EventConsumer"})
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "redisstreams-events",
+		GroupID:       "com.test.redisstreamsevents",
+		ArtifactID:    "redisstreams-events",
+		JavaVersion:   "21",
+		Modules:       modules,
+		MessageBroker: "redis-streams",
+	}
+
+	projectDir := generateProject(t, tempDir, cfg)
+	t.Logf("Generated project at: %s", projectDir)
+
+	runMavenCompile(t, projectDir)
+	t.Log("EventConsumer with Redis Streams compiled successfully")
+}
+
+func TestCompilation_EventConsumerWithNATS(t *testing.T) {
+	checkMavenInstalled(t)
+
+	tempDir, err := os.MkdirTemp("", "trabuco-compile-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Resolve modules to auto-include Events
+	modules := config.ResolveDependencies([]string{"Model", "API", "EventConsumer"})
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "nats-events",
+		GroupID:       "com.test.natsevents",
+		ArtifactID:    "nats-events",
+		JavaVersion:   "21",
+		Modules:       modules,
+		MessageBroker: "nats",
+	}
+
+	projectDir := generateProject(t, tempDir, cfg)
+	t.Logf("Generated project at: %s", projectDir)
+
+	runMavenCompile(t, projectDir)
+	t.Log("EventConsumer with NATS JetStream compiled successfully")
+}
+
 func TestCompilation_AIAgentMinimal(t *testing.T) {
 	checkMavenInstalled(t)
 
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_MariaDB(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.test.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    config.DatabaseMariaDB,
+		CIProvider:  "github",
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	read := func(rel string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(projectPath, rel))
+		if err != nil {
+			t.Fatalf("expected %s: %v", rel, err)
+		}
+		return string(data)
+	}
+
+	compose := read("docker-compose.yml")
+	for _, want := range []string{"image: mariadb:11.4", "--collation-server=utf8mb4_unicode_ci", "healthcheck.sh"} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml should contain %s:\n%s", want, compose)
+		}
+	}
+	if strings.Contains(compose, "image: mysql") {
+		t.Error("a MariaDB project should not start MySQL")
+	}
+	pom := read("SQLDatastore/pom.xml")
+	if !strings.Contains(pom, "<artifactId>mariadb-java-client</artifactId>") || strings.Contains(pom, "mysql-connector-j") {
+		t.Error("SQLDatastore should use the MariaDB driver only")
+	}
+	if !strings.Contains(read("SQLDatastore/src/main/resources/application.yml"), "jdbc:mariadb://") {
+		t.Error("application.yml should use a jdbc:mariadb URL")
+	}
+	if !strings.Contains(read("SQLDatastore/src/test/java/com/test/shop/sqldatastore/repository/PlaceholderRepositoryTest.java"), `new MariaDBContainer("mariadb:11.4")`) {
+		t.Error("the repository test should start a MariaDB container")
+	}
+	if !strings.Contains(read("SQLDatastore/src/main/resources/db/migration/V1__baseline.sql"), "DEFAULT CHARSET=utf8mb4") {
+		t.Error("the baseline table should declare utf8mb4")
+	}
+	if !strings.Contains(read(".github/workflows/ci.yml"), "jdbc:mariadb://localhost:3307/shop") {
+		t.Error("CI should run against a MariaDB service")
+	}
+}
+
+func TestGenerator_Generate_PinnedDatabaseVersion(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "pinned")
+	cfg := &config.ProjectConfig{
+		ProjectName:     "pinned",
+		GroupID:         "com.test.pinned",
+		ArtifactID:      "pinned",
+		JavaVersion:     "21",
+		Modules:         []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:        config.DatabasePostgreSQL,
+		DatabaseVersion: "16",
+		CIProvider:      "github",
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, rel := range []string{
+		"docker-compose.yml",
+		".github/workflows/ci.yml",
+		"SQLDatastore/src/test/java/com/test/pinned/sqldatastore/repository/PlaceholderRepositoryTest.java",
+		"SQLDatastore/src/test/java/com/test/pinned/sqldatastore/fixtures/AbstractDatabaseTest.java",
+	} {
+		data, err := os.ReadFile(filepath.Join(projectPath, rel))
+		if err != nil {
+			t.Fatalf("expected %s: %v", rel, err)
+		}
+		if !strings.Contains(string(data), "postgres:16-alpine") || strings.Contains(string(data), "postgres:15-alpine") {
+			t.Errorf("%s should use the pinned postgres:16-alpine image", rel)
+		}
+	}
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.DatabaseVersion != "16" {
+		t.Errorf("metadata should record the pinned version, got %q", metadata.DatabaseVersion)
+	}
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+)
+
+// devContainerJSON is the dev container definition. Its forwarded ports
+// follow the modules, so 'trabuco add' regenerates it.
+const devContainerJSON = ".devcontainer/devcontainer.json"
+
+// devContainerFiles returns the template and output path of every file of
+// the dev container.
+func devContainerFiles() [][2]string {
+	return [][2]string{
+		{"devcontainer/devcontainer.json.tmpl", devContainerJSON},
+		{"devcontainer/Dockerfile.tmpl", ".devcontainer/Dockerfile"},
+	}
+}
+
+// generateDevContainer writes .devcontainer/ when the project asked for it
+// with --with-devcontainer.
+func (g *Generator) generateDevContainer() error {
+	if !g.config.HasDevContainer() {
+		return nil
+	}
+	for _, f := range devContainerFiles() {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to generate the dev container: %w", err)
+		}
+	}
+	return nil
+}
+
+// RetrofitDevContainer adds .devcontainer/ to the existing project at
+// projectPath (`trabuco generate devcontainer`) and records the flag in
+// .trabuco.json. It refuses to overwrite a file that already exists. It
+// returns the files created, relative to projectPath; in dry-run mode
+// nothing is written and the files that would be are returned.
+func RetrofitDevContainer(projectPath string, metadata *config.ProjectMetadata, dryRun bool) ([]string, error) {
+	if metadata.DevContainer {
+		return nil, fmt.Errorf("the dev container is already part of this project")
+	}
+	cfg := metadata.ToProjectConfig()
+	cfg.DevContainer = true
+
+	gen := &Generator{
+		config: cfg,
+		engine: templates.NewEngine().WithProjectOverrides(projectPath),
+		outDir: projectPath,
+	}
+	var created []string
+	for _, f := range devContainerFiles() {
+		if _, err := os.Stat(filepath.Join(projectPath, f[1])); err == nil {
+			return nil, fmt.Errorf("refusing to overwrite existing file: %s (delete it first if you want to regenerate)", f[1])
+		}
+		created = append(created, f[1])
+	}
+	if dryRun {
+		return created, nil
+	}
+
+	if err := gen.generateDevContainer(); err != nil {
+		return nil, err
+	}
+	metadata.DevContainer = true
+	metadata.UpdateGeneratedAt()
+	if err := config.SaveMetadata(projectPath, metadata); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", config.MetadataFileName, err)
+	}
+	return created, nil
+}
@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/versions"
+)
+
+type devContainerDef struct {
+	Build struct {
+		Dockerfile string            `json:"dockerfile"`
+		Args       map[string]string `json:"args"`
+	} `json:"build"`
+	Features        map[string]any            `json:"features"`
+	ForwardPorts    []int                     `json:"forwardPorts"`
+	PortsAttributes map[string]map[string]any `json:"portsAttributes"`
+}
+
+func readDevContainer(t *testing.T, projectPath string) devContainerDef {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(projectPath, devContainerJSON))
+	if err != nil {
+		t.Fatalf("expected %s: %v", devContainerJSON, err)
+	}
+	var def devContainerDef
+	if err := json.Unmarshal(data, &def); err != nil {
+		t.Fatalf("%s should be valid JSON: %v\n%s", devContainerJSON, err, data)
+	}
+	return def
+}
+
+func TestGenerator_Generate_DevContainer(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "devbox")
+	cfg := &config.ProjectConfig{
+		ProjectName:  "devbox",
+		GroupID:      "com.test.devbox",
+		ArtifactID:   "devbox",
+		JavaVersion:  "25",
+		Modules:      []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker"},
+		Database:     "postgresql",
+		DevContainer: true,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	def := readDevContainer(t, projectPath)
+	if def.Build.Dockerfile != "Dockerfile" || def.Build.Args["JAVA_VERSION"] != "25" {
+		t.Errorf("expected a Dockerfile build for Java 25, got %+v", def.Build)
+	}
+	if _, ok := def.Features["ghcr.io/devcontainers/features/docker-in-docker:2"]; !ok {
+		t.Error("expected the docker-in-docker feature")
+	}
+	if got := def.ForwardPorts; len(got) != 3 || got[0] != 8080 || got[1] != 8081 || got[2] != 8000 {
+		t.Errorf("expected API, Worker and JobRunr dashboard ports, got %v", got)
+	}
+	if def.PortsAttributes["8000"]["label"] != "JobRunr dashboard" {
+		t.Errorf("expected port labels, got %v", def.PortsAttributes)
+	}
+
+	dockerfile, err := os.ReadFile(filepath.Join(projectPath, ".devcontainer", "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	maven := versions.Get("apache-maven")
+	if !strings.Contains(string(dockerfile), "ARG JAVA_VERSION=25") || !strings.Contains(string(dockerfile), "ARG MAVEN_VERSION="+maven) {
+		t.Errorf("Dockerfile should pin the JDK and Maven, got:\n%s", dockerfile)
+	}
+	wrapper, _ := os.ReadFile(filepath.Join(projectPath, ".mvn", "wrapper", "maven-wrapper.properties"))
+	if !strings.Contains(string(wrapper), "apache-maven-"+maven+"-bin.zip") {
+		t.Error("the Maven wrapper and the dev container should use the same Maven")
+	}
+
+	// Adding a module forwards its port too
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.DevContainer {
+		t.Fatal("expected devContainer recorded in .trabuco.json")
+	}
+	if err := NewModuleAdder(projectPath, metadata, "test", false).Add(config.ModuleEventConsumer, "", "", "kafka"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got := readDevContainer(t, projectPath).ForwardPorts; len(got) != 4 || got[2] != 8083 {
+		t.Errorf("expected the EventConsumer port forwarded after add, got %v", got)
+	}
+}
+
+func TestGenerator_Generate_NoDevContainer(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "plain")
+	cfg := &config.ProjectConfig{
+		ProjectName: "plain",
+		GroupID:     "com.test.plain",
+		ArtifactID:  "plain",
+		JavaVersion: "21",
+		Modules:     []string{"Model"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, ".devcontainer")); !os.IsNotExist(err) {
+		t.Error(".devcontainer/ should only be generated with --with-devcontainer")
+	}
+}
+
+func TestRetrofitDevContainer(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "later")
+	cfg := &config.ProjectConfig{
+		ProjectName: "later",
+		GroupID:     "com.test.later",
+		ArtifactID:  "later",
+		JavaVersion: "21",
+		Modules:     []string{"Model"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := RetrofitDevContainer(projectPath, metadata, true)
+	if err != nil || len(created) != 2 {
+		t.Fatalf("expected 2 files in dry run, got %v, %v", created, err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, devContainerJSON)); !os.IsNotExist(err) {
+		t.Fatal("dry run should not write files")
+	}
+
+	if _, err := RetrofitDevContainer(projectPath, metadata, false); err != nil {
+		t.Fatalf("RetrofitDevContainer failed: %v", err)
+	}
+	// A project without runtime modules has nothing to forward
+	if def := readDevContainer(t, projectPath); len(def.ForwardPorts) != 0 {
+		t.Errorf("expected no forwarded ports, got %v", def.ForwardPorts)
+	}
+	if saved, _ := config.LoadMetadata(projectPath); !saved.DevContainer {
+		t.Error("expected devContainer recorded in .trabuco.json")
+	}
+	if _, err := RetrofitDevContainer(projectPath, metadata, false); err == nil {
+		t.Error("expected a second retrofit to be refused")
+	}
+}
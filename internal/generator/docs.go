@@ -2,6 +2,8 @@ package generator
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
 )
@@ -18,12 +20,24 @@ func (g *Generator) generateDocs() error {
 		return err
 	}
 
+	// Generate LICENSE for the license chosen with --license
+	if err := g.generateLicense(); err != nil {
+		return err
+	}
+
 	if g.config.HasModule(config.ModuleAPI) || g.config.HasModule(config.ModuleAIAgent) {
 		if err := g.writeTemplate("docs/auth.md.tmpl", "docs/auth.md"); err != nil {
 			return err
 		}
 	}
 
+	// The error catalog of the API's Problem Details
+	if g.config.HasModule(config.ModuleAPI) {
+		if err := g.writeTemplate("docs/errors.md.tmpl", "docs/errors.md"); err != nil {
+			return err
+		}
+	}
+
 	// F-INFRA-01: Maven Wrapper. Ships {@code only-script} distribution
 	// (no embedded jar) so the bootstrap script downloads the pinned
 	// Maven distribution at first invocation. Pinning the Maven version
@@ -41,6 +55,13 @@ func (g *Generator) generateDocs() error {
 	); err != nil {
 		return fmt.Errorf("failed to generate maven-wrapper.properties: %w", err)
 	}
+	// Error Prone runs inside Maven's JVM and needs javac's internals
+	// exported to it
+	if g.config.HasErrorProne() {
+		if err := g.writeTemplate("maven-wrapper/.mvn/jvm.config.tmpl", ".mvn/jvm.config"); err != nil {
+			return err
+		}
+	}
 
 	// Generate AGENTS.md cross-tool baseline first (when any AI agent is selected).
 	// This is written before per-agent files so that Codex (which uses AGENTS.md as its
@@ -99,6 +120,72 @@ func (g *Generator) generateDocs() error {
 		if err := g.writeTemplate("docker/env.example.tmpl", ".env.example"); err != nil {
 			return err
 		}
+		// Replication role and slot the postgres-replica service streams from
+		if g.config.HasReplicaService() {
+			if err := g.writeTemplateExecutable("docker/postgres-init/replication.sh.tmpl", "postgres-init/replication.sh"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Generate Prometheus/Tempo/Grafana config for the observability compose profile
+	if err := g.generateObservability(); err != nil {
+		return err
+	}
+
+	// Generate the logging/ Fluent Bit samples for the runtime modules' log format
+	if err := g.generateLogParsing(); err != nil {
+		return err
+	}
+
+	// Generate native image hints and Dockerfile.native per runtime module
+	if err := g.generateNative(); err != nil {
+		return err
+	}
+
+	// Generate the paginated list endpoint, page DTOs and sort whitelist
+	if err := g.generatePagination(); err != nil {
+		return err
+	}
+
+	// Generate the API rate limiting interceptor and its properties
+	if err := g.generateRateLimit(); err != nil {
+		return err
+	}
+
+	// Generate the Idempotency-Key filter, its store and migration
+	if err := g.generateIdempotency(); err != nil {
+		return err
+	}
+
+	// Generate the perf/ k6 load test, its run script and CI workflow
+	if err := g.generatePerf(); err != nil {
+		return err
+	}
+
+	// Generate .devcontainer/ for Codespaces and local dev containers
+	if err := g.generateDevContainer(); err != nil {
+		return err
+	}
+
+	// Generate CODEOWNERS and the module boundary docs
+	if err := g.generateCodeOwners(); err != nil {
+		return err
+	}
+
+	// Generate the root Makefile or Taskfile.yml of developer shortcuts
+	if err := g.generateTaskRunner(); err != nil {
+		return err
+	}
+
+	// Generate dev/staging/prod Spring profiles per runtime module
+	if err := g.generateEnvProfiles(); err != nil {
+		return err
+	}
+
+	// Generate the secrets manager config imported by each runtime module
+	if err := g.generateSecrets(); err != nil {
+		return err
 	}
 
 	// Generate LocalStack init script for SQS
@@ -163,6 +250,13 @@ func (g *Generator) generateDocs() error {
 		}
 	}
 
+	// Generate Aider specific files when Aider is selected
+	if g.config.HasAIAgent("aider") {
+		if err := g.generateAiderFiles(); err != nil {
+			return err
+		}
+	}
+
 	// Review subagents, hooks, and the skill catalog. Runs exactly once
 	// regardless of which AI agents are selected — generateReviewArtifacts
 	// and generateSkills each gate per-tool internally (HasAIAgent checks).
@@ -411,8 +505,29 @@ func (g *Generator) generateCodexFiles() error {
 	return nil
 }
 
+// generateAiderFiles generates Aider specific configuration files. Gemini
+// CLI and Zed need nothing beyond their context file (GEMINI.md, .rules).
+func (g *Generator) generateAiderFiles() error {
+	// Generate .aider.conf.yml, which loads CONVENTIONS.md read-only into
+	// every chat and wires the lint and test commands
+	return g.writeTemplate("aider/aider.conf.yml.tmpl", ".aider.conf.yml")
+}
+
 // generateMetadata generates the .trabuco.json metadata file
 func (g *Generator) generateMetadata(version string) error {
 	metadata := config.NewMetadataFromConfig(g.config, version)
 	return config.SaveMetadata(g.outDir, metadata)
 }
+
+// generateLicense writes LICENSE. Proprietary licenses reuse the header text
+// the user supplied, with the year filled in.
+func (g *Generator) generateLicense() error {
+	switch g.config.License {
+	case config.LicenseApache2, config.LicenseMIT:
+		return g.writeTemplate("docs/license/"+g.config.License+".tmpl", "LICENSE")
+	case config.LicenseProprietary:
+		text := strings.ReplaceAll(strings.TrimSpace(g.config.LicenseHeader), config.LicenseYearToken, g.config.LicenseYear())
+		return g.writeFile(filepath.Join(g.outDir, "LICENSE"), text+"\n")
+	}
+	return nil
+}
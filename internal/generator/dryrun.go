@@ -0,0 +1,229 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// InitDryRunResult is the preview of 'trabuco init --dry-run': everything
+// Generate would write, without touching the output directory
+type InitDryRunResult struct {
+	Project        string        `json:"project"`
+	Directory      string        `json:"directory"`
+	Exists         bool          `json:"exists"` // The output directory is already there
+	Modules        []string      `json:"modules"`
+	Files          []string      `json:"files"`
+	DockerServices []string      `json:"docker_services"`
+	PomProperties  []PomProperty `json:"pom_properties"`
+	Counts         InitCounts    `json:"counts"`
+}
+
+// PomProperty is one property of the parent POM, in POM order
+type PomProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// InitCounts sizes the project a dry run would generate
+type InitCounts struct {
+	Files       int            `json:"files"`
+	Directories int            `json:"directories"`
+	Sources     int            `json:"sources"` // Java and Kotlin main sources
+	Tests       int            `json:"tests"`   // Java and Kotlin test sources
+	Lines       int            `json:"lines"`
+	Bytes       int64          `json:"bytes"`
+	PerModule   map[string]int `json:"per_module"` // Files per Maven module; "" is the project root
+}
+
+// DryRun renders the project into a scratch directory and reports what
+// Generate would create there, then removes it. Git is not initialized
+// and no history, TODO index or LAST_OPERATION.md is written.
+func (g *Generator) DryRun() (*InitDryRunResult, error) {
+	scratch, err := os.MkdirTemp("", "trabuco-dryrun-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	dir, err := filepath.Abs(g.outDir)
+	if err != nil {
+		return nil, err
+	}
+	result := &InitDryRunResult{
+		Project:        g.config.ProjectName,
+		Directory:      dir,
+		Exists:         OutputExists(g.outDir),
+		Modules:        g.config.Modules,
+		Files:          []string{},
+		DockerServices: []string{},
+		PomProperties:  []PomProperty{},
+		Counts:         InitCounts{PerModule: map[string]int{}},
+	}
+
+	finalDir := g.outDir
+	g.outDir = filepath.Join(scratch, filepath.Base(finalDir))
+	err = g.render()
+	rendered := g.outDir
+	g.outDir = finalDir
+	if err != nil {
+		return nil, err
+	}
+
+	for path := range snapshotTree(rendered) {
+		result.Files = append(result.Files, path)
+	}
+	sort.Strings(result.Files)
+	dirs := map[string]bool{}
+	for _, path := range result.Files {
+		info, err := os.Stat(filepath.Join(rendered, path))
+		if err != nil {
+			return nil, err
+		}
+		result.Counts.Files++
+		result.Counts.Bytes += info.Size()
+		if data, err := os.ReadFile(filepath.Join(rendered, path)); err == nil {
+			result.Counts.Lines += strings.Count(string(data), "\n")
+		}
+		for dir := filepath.Dir(path); dir != "."; dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+		if ext := filepath.Ext(path); ext == ".java" || ext == ".kt" {
+			if strings.Contains(path, "/src/test/") {
+				result.Counts.Tests++
+			} else {
+				result.Counts.Sources++
+			}
+		}
+		module, _, found := strings.Cut(path, "/")
+		if !found || !isMavenModule(rendered, module) {
+			module = ""
+		}
+		result.Counts.PerModule[module]++
+	}
+	result.Counts.Directories = len(dirs)
+
+	if services := composeServices(filepath.Join(rendered, "docker-compose.yml")); services != nil {
+		result.DockerServices = services
+	}
+	properties, err := pomProperties(filepath.Join(rendered, "pom.xml"))
+	if err != nil {
+		return nil, err
+	}
+	result.PomProperties = properties
+	return result, nil
+}
+
+// isMavenModule reports whether dir under root holds a module POM
+func isMavenModule(root, dir string) bool {
+	_, err := os.Stat(filepath.Join(root, dir, "pom.xml"))
+	return err == nil
+}
+
+// pomProperties returns the <properties> of the POM at path
+func pomProperties(path string) ([]PomProperty, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	e, err := newXMLEditor(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pom.xml: %w", err)
+	}
+	properties := []PomProperty{}
+	if node := e.find("project", "properties"); node != nil {
+		for _, c := range node.children {
+			properties = append(properties, PomProperty{Name: c.name, Value: e.text(c)})
+		}
+	}
+	return properties, nil
+}
+
+// Print prints the dry run: the file tree, the Docker services, the POM
+// properties and the counts
+func (d *InitDryRunResult) Print() {
+	cyan := color.New(color.FgCyan)
+	yellow := color.New(color.FgYellow)
+
+	fmt.Println()
+	cyan.Println("Dry Run Results:")
+	fmt.Println()
+
+	fmt.Printf("Project: %s (%s)\n", d.Project, d.Directory)
+	fmt.Printf("Modules: %s\n", strings.Join(d.Modules, ", "))
+	if d.Exists {
+		yellow.Printf("Note: %s already exists; init would stop unless told what to do with it\n", d.Directory)
+	}
+
+	fmt.Println()
+	yellow.Println("Files that would be created:")
+	for _, line := range fileTree(d.Files) {
+		fmt.Printf("  %s\n", line)
+	}
+
+	if len(d.DockerServices) > 0 {
+		fmt.Println()
+		yellow.Println("Docker Compose services:")
+		for _, s := range d.DockerServices {
+			fmt.Printf("  - %s\n", s)
+		}
+	}
+
+	if len(d.PomProperties) > 0 {
+		fmt.Println()
+		yellow.Println("POM properties:")
+		width := 0
+		for _, p := range d.PomProperties {
+			width = max(width, len(p.Name))
+		}
+		for _, p := range d.PomProperties {
+			fmt.Printf("  %-*s  %s\n", width, p.Name, p.Value)
+		}
+	}
+
+	fmt.Println()
+	yellow.Println("Totals:")
+	fmt.Printf("  %d files in %d directories (%d lines, %.1f KB)\n", d.Counts.Files, d.Counts.Directories, d.Counts.Lines, float64(d.Counts.Bytes)/1024)
+	fmt.Printf("  %d source and %d test classes\n", d.Counts.Sources, d.Counts.Tests)
+	modules := make([]string, 0, len(d.Counts.PerModule))
+	for m := range d.Counts.PerModule {
+		if m != "" {
+			modules = append(modules, m)
+		}
+	}
+	sort.Strings(modules)
+	for _, m := range modules {
+		fmt.Printf("  %-16s %d files\n", m, d.Counts.PerModule[m])
+	}
+	if n := d.Counts.PerModule[""]; n > 0 {
+		fmt.Printf("  %-16s %d files\n", "(project root)", n)
+	}
+	fmt.Println()
+	fmt.Println("No files were written. Run again without --dry-run to generate the project.")
+}
+
+// fileTree renders sorted slash-separated paths as an indented tree
+func fileTree(paths []string) []string {
+	var lines []string
+	var previous []string
+	for _, path := range paths {
+		parts := strings.Split(path, "/")
+		common := 0
+		for common < len(parts)-1 && common < len(previous)-1 && parts[common] == previous[common] {
+			common++
+		}
+		for i := common; i < len(parts); i++ {
+			name := parts[i]
+			if i < len(parts)-1 {
+				name += "/"
+			}
+			lines = append(lines, strings.Repeat("  ", i)+name)
+		}
+		previous = parts
+	}
+	return lines
+}
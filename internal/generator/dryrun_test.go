@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_DryRun(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{
+		ProjectName:   "orders",
+		GroupID:       "com.test.orders",
+		ArtifactID:    "orders",
+		JavaVersion:   "21",
+		Modules:       config.ResolveDependencies([]string{"SQLDatastore", "API", "EventConsumer"}),
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := gen.DryRun()
+	if err != nil {
+		t.Fatalf("DryRun failed: %v", err)
+	}
+	if _, err := os.Stat(projectPath); !os.IsNotExist(err) {
+		t.Fatalf("dry run must not create %s", projectPath)
+	}
+	if result.Exists {
+		t.Error("expected the output directory not to exist")
+	}
+
+	for _, f := range []string{"pom.xml", "docker-compose.yml", config.MetadataFileName, "API/pom.xml", "SQLDatastore/src/main/resources/db/migration/V1__baseline.sql"} {
+		if !slices.Contains(result.Files, f) {
+			t.Errorf("expected %s in the file list", f)
+		}
+	}
+	if !slices.IsSorted(result.Files) {
+		t.Error("expected the file list to be sorted")
+	}
+	for _, f := range []string{LastOperationFile, config.HistoryFileName} {
+		if slices.Contains(result.Files, f) {
+			t.Errorf("dry run should not list the bookkeeping file %s", f)
+		}
+	}
+
+	for _, s := range []string{"postgres", "kafka"} {
+		if !slices.Contains(result.DockerServices, s) {
+			t.Errorf("expected docker service %s, got %v", s, result.DockerServices)
+		}
+	}
+	javaVersion := ""
+	for _, p := range result.PomProperties {
+		if p.Name == "maven.compiler.source" {
+			javaVersion = p.Value
+		}
+	}
+	if javaVersion != "21" {
+		t.Errorf("expected maven.compiler.source 21 in %v", result.PomProperties)
+	}
+
+	if result.Counts.Files != len(result.Files) {
+		t.Errorf("expected %d files counted, got %d", len(result.Files), result.Counts.Files)
+	}
+	if result.Counts.Sources == 0 || result.Counts.Tests == 0 || result.Counts.Lines == 0 {
+		t.Errorf("expected sources, tests and lines to be counted: %+v", result.Counts)
+	}
+	if result.Counts.PerModule[config.ModuleAPI] == 0 || result.Counts.PerModule[""] == 0 {
+		t.Errorf("expected per-module counts for API and the project root: %v", result.Counts.PerModule)
+	}
+
+	// The dry run lists what Generate writes
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, f := range result.Files {
+		if _, err := os.Stat(filepath.Join(projectPath, f)); err != nil {
+			t.Errorf("dry run listed %s, which Generate did not write", f)
+		}
+	}
+}
+
+func TestFileTree(t *testing.T) {
+	got := fileTree([]string{"API/pom.xml", "API/src/App.java", "pom.xml"})
+	want := []string{"API/", "  pom.xml", "  src/", "    App.java", "pom.xml"}
+	if !slices.Equal(got, want) {
+		t.Errorf("fileTree = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,291 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// ejectPaths are the Trabuco state files and directories 'trabuco clean'
+// deletes. Directories end in a slash.
+var ejectPaths = []string{
+	config.MetadataFileName,
+	".trabuco/", // History, review settings, template overrides
+	BackupDirName + "/",
+	".trabuco-migration/",
+	".ai/",
+}
+
+// ejectMCPConfigs are the agent MCP config files a trabuco server entry is
+// registered in, with the JSON key holding the servers
+var ejectMCPConfigs = []struct{ path, key string }{
+	{".mcp.json", "mcpServers"},
+	{".cursor/mcp.json", "mcpServers"},
+	{".gemini/settings.json", "mcpServers"},
+	{".vscode/mcp.json", "servers"},
+}
+
+// tomlTableHeader matches the [table] header lines of a TOML file
+var tomlTableHeader = regexp.MustCompile(`(?m)^\s*\[.*$`)
+
+// codexConfigPath is the Codex config, whose MCP servers are TOML tables
+const codexConfigPath = ".codex/config.toml"
+
+// EjectPlan is what 'trabuco clean' changes in a project. Build files and
+// source code are never part of it.
+type EjectPlan struct {
+	Remove     []string // Deleted, project-relative; directories end in a slash
+	Unmark     []string // Docs whose trabuco:begin/end markers are stripped, their text kept
+	MCPConfigs []string // Config files the trabuco MCP server entry is removed from
+
+	projectPath string
+}
+
+// PlanEject lists the Trabuco artifacts present in the project at
+// projectPath
+func PlanEject(projectPath string) (*EjectPlan, error) {
+	plan := &EjectPlan{projectPath: projectPath}
+	for _, rel := range ejectPaths {
+		if _, err := os.Stat(filepath.Join(projectPath, rel)); err == nil {
+			plan.Remove = append(plan.Remove, rel)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to check %s: %w", rel, err)
+		}
+	}
+
+	for _, rel := range managedDocPaths() {
+		content, err := os.ReadFile(filepath.Join(projectPath, rel))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		if managedMarkerPattern.Match(content) {
+			plan.Unmark = append(plan.Unmark, rel)
+		}
+	}
+
+	for _, c := range ejectMCPConfigs {
+		content, err := os.ReadFile(filepath.Join(projectPath, c.path))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", c.path, err)
+		}
+		if _, changed, err := removeJSONMCPServer(content, c.key); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.path, err)
+		} else if changed {
+			plan.MCPConfigs = append(plan.MCPConfigs, c.path)
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(projectPath, codexConfigPath)); err == nil {
+		if _, changed := removeTOMLMCPServer(content); changed {
+			plan.MCPConfigs = append(plan.MCPConfigs, codexConfigPath)
+		}
+	}
+	return plan, nil
+}
+
+// IsEmpty returns true if the project has no Trabuco artifacts left
+func (p *EjectPlan) IsEmpty() bool {
+	return len(p.Remove) == 0 && len(p.Unmark) == 0 && len(p.MCPConfigs) == 0
+}
+
+// Apply makes the changes of the plan. An MCP config left without any
+// server or other setting is deleted.
+func (p *EjectPlan) Apply() error {
+	for _, rel := range p.Unmark {
+		path := filepath.Join(p.projectPath, rel)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		if err := os.WriteFile(path, stripManagedMarkers(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+	}
+
+	for _, rel := range p.MCPConfigs {
+		path := filepath.Join(p.projectPath, rel)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		var updated []byte
+		if rel == codexConfigPath {
+			updated, _ = removeTOMLMCPServer(content)
+		} else {
+			for _, c := range ejectMCPConfigs {
+				if c.path == rel {
+					updated, _, err = removeJSONMCPServer(content, c.key)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", rel, err)
+			}
+		}
+		if len(bytes.TrimSpace(updated)) == 0 {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", rel, err)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, updated, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+	}
+
+	// The state goes last, so a failure above leaves a project Trabuco
+	// still recognizes
+	for _, rel := range p.Remove {
+		if err := os.RemoveAll(filepath.Join(p.projectPath, strings.TrimSuffix(rel, "/"))); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+// managedDocPaths returns every doc Trabuco generates with managed regions,
+// for any AI agent
+func managedDocPaths() []string {
+	paths := []string{"README.md", "AGENTS.md", "docs/errors.md"}
+	for _, agent := range config.GetAvailableAIAgents() {
+		if agent.FilePath != "AGENTS.md" {
+			paths = append(paths, agent.FilePath)
+		}
+	}
+	return paths
+}
+
+// stripManagedMarkers removes the trabuco:begin/end marker lines of a doc,
+// leaving the text of its regions in place
+func stripManagedMarkers(content []byte) []byte {
+	var out []byte
+	pos := 0
+	for _, m := range managedMarkerPattern.FindAllIndex(content, -1) {
+		out = append(out, content[pos:m[0]]...)
+		pos = m[1]
+		if pos < len(content) && content[pos] == '\n' {
+			pos++
+		}
+	}
+	return append(out, content[pos:]...)
+}
+
+// removeTOMLMCPServer drops the [mcp_servers.trabuco] table, and its
+// subtables, from a Codex config
+func removeTOMLMCPServer(content []byte) ([]byte, bool) {
+	var out []byte
+	changed := false
+	pos, skipping := 0, false
+	for _, m := range tomlTableHeader.FindAllIndex(content, -1) {
+		if !skipping {
+			out = append(out, content[pos:m[0]]...)
+		}
+		header := strings.TrimSpace(string(content[m[0]:m[1]]))
+		skipping = header == "[mcp_servers.trabuco]" || strings.HasPrefix(header, "[mcp_servers.trabuco.")
+		changed = changed || skipping
+		pos = m[0]
+	}
+	if !skipping {
+		out = append(out, content[pos:]...)
+	}
+	if !changed {
+		return content, false
+	}
+	return out, true
+}
+
+// removeJSONMCPServer drops the "trabuco" entry from the servers object
+// under key. The result is empty when nothing else is left in the file.
+// The entry is cut out of the original bytes, so the other keys keep their
+// order and formatting.
+func removeJSONMCPServer(content []byte, key string) ([]byte, bool, error) {
+	doc, err := jsonObjectMembers(content)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse: %w", err)
+	}
+	i := slices.IndexFunc(doc, func(m jsonMember) bool { return m.key == key })
+	if i < 0 {
+		return content, false, nil
+	}
+	serversRaw := content[doc[i].valueStart:doc[i].end]
+	servers, err := jsonObjectMembers(serversRaw)
+	if err != nil {
+		return content, false, nil
+	}
+	j := slices.IndexFunc(servers, func(m jsonMember) bool { return m.key == "trabuco" })
+	if j < 0 {
+		return content, false, nil
+	}
+	if len(servers) > 1 {
+		out := slices.Concat(content[:doc[i].valueStart], cutJSONMember(serversRaw, servers, j), content[doc[i].end:])
+		return out, true, nil
+	}
+	if len(doc) == 1 {
+		return nil, true, nil
+	}
+	return cutJSONMember(content, doc, i), true, nil
+}
+
+// jsonMember locates one member of a JSON object in its source bytes.
+type jsonMember struct {
+	key        string
+	start      int // First byte of the key
+	valueStart int // First byte of the value
+	end        int // Just past the value
+}
+
+// jsonObjectMembers returns the members of the JSON object in data, in
+// source order.
+func jsonObjectMembers(data []byte) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("not a JSON object")
+	}
+	var members []jsonMember
+	for dec.More() {
+		before := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		end := int(dec.InputOffset())
+		members = append(members, jsonMember{
+			key:        key,
+			start:      before + bytes.IndexByte(data[before:], '"'),
+			valueStart: end - len(value),
+			end:        end,
+		})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// cutJSONMember removes member i, and the comma separating it from its
+// neighbour, from the object in data. The object must keep at least one
+// member.
+func cutJSONMember(data []byte, members []jsonMember, i int) []byte {
+	if i > 0 {
+		return slices.Concat(data[:members[i-1].end], data[members[i].end:])
+	}
+	return slices.Concat(data[:members[0].start], data[members[1].start:])
+}
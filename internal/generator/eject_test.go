@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestEjectPlan_Apply(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{
+		ProjectName: "orders",
+		GroupID:     "com.test.orders",
+		ArtifactID:  "orders",
+		JavaVersion: "21",
+		Modules:     config.ResolveDependencies([]string{"SQLDatastore", "API"}),
+		Database:    config.DatabasePostgreSQL,
+		AIAgents:    []string{"claude"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	write := func(rel, content string) {
+		path := filepath.Join(projectPath, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".mcp.json", `{"mcpServers": {"trabuco": {"command": "trabuco", "args": ["mcp"]}, "github": {"command": "gh-mcp"}}}`)
+	write(".cursor/mcp.json", `{"mcpServers": {"trabuco": {"command": "trabuco", "args": ["mcp"]}}}`)
+	write(".codex/config.toml", "model = \"o3\"\n\n[mcp_servers.trabuco]\ncommand = \"trabuco\"\nargs = [\"mcp\"]\n\n[mcp_servers.github]\ncommand = \"gh-mcp\"\n")
+
+	plan, err := PlanEject(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{".trabuco.json", ".ai/"} {
+		if !slices.Contains(plan.Remove, want) {
+			t.Errorf("Remove = %v, want %s", plan.Remove, want)
+		}
+	}
+	for _, want := range []string{"README.md", "CLAUDE.md"} {
+		if !slices.Contains(plan.Unmark, want) {
+			t.Errorf("Unmark = %v, want %s", plan.Unmark, want)
+		}
+	}
+	if len(plan.MCPConfigs) != 3 {
+		t.Errorf("MCPConfigs = %v", plan.MCPConfigs)
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	for _, gone := range []string{".trabuco.json", ".ai", ".cursor/mcp.json"} {
+		if _, err := os.Stat(filepath.Join(projectPath, gone)); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed", gone)
+		}
+	}
+	for _, kept := range []string{"pom.xml", "mvnw", "API/pom.xml"} {
+		if _, err := os.Stat(filepath.Join(projectPath, kept)); err != nil {
+			t.Errorf("%s should be kept: %v", kept, err)
+		}
+	}
+	readme := readFile(t, projectPath, "README.md")
+	if strings.Contains(readme, "trabuco:begin") || !strings.Contains(readme, "# orders") {
+		t.Errorf("README.md should keep its text without markers:\n%s", readme)
+	}
+	if mcp := readFile(t, projectPath, ".mcp.json"); strings.Contains(mcp, "trabuco") || !strings.Contains(mcp, "gh-mcp") {
+		t.Errorf(".mcp.json should keep only the other server:\n%s", mcp)
+	}
+	if codex := readFile(t, projectPath, ".codex/config.toml"); codex != "model = \"o3\"\n\n[mcp_servers.github]\ncommand = \"gh-mcp\"\n" {
+		t.Errorf(".codex/config.toml =\n%s", codex)
+	}
+
+	plan, err = PlanEject(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.IsEmpty() {
+		t.Errorf("an ejected project should have nothing left to clean: %+v", plan)
+	}
+}
+
+func TestRemoveJSONMCPServer(t *testing.T) {
+	settings := `{
+  "permissions": {"allow": ["Bash(mvn:*)"]},
+  "mcpServers": {
+    "zeta": {"command": "zeta-mcp"},
+    "trabuco": {"command": "trabuco", "args": ["mcp"]},
+    "alpha": {"command": "alpha-mcp"}
+  },
+  "env": {"B": "1", "A": "2"}
+}
+`
+	out, changed, err := removeJSONMCPServer([]byte(settings), "mcpServers")
+	if err != nil || !changed {
+		t.Fatalf("expected the trabuco server removed, got %v, %v", changed, err)
+	}
+	want := `{
+  "permissions": {"allow": ["Bash(mvn:*)"]},
+  "mcpServers": {
+    "zeta": {"command": "zeta-mcp"},
+    "alpha": {"command": "alpha-mcp"}
+  },
+  "env": {"B": "1", "A": "2"}
+}
+`
+	if string(out) != want {
+		t.Errorf("only the trabuco entry should go, keeping order and formatting:\n%s", out)
+	}
+
+	first := `{"mcpServers": {"trabuco": {"command": "trabuco"}, "github": {"command": "gh-mcp"}}, "z": 1, "a": 2}`
+	out, _, _ = removeJSONMCPServer([]byte(first), "mcpServers")
+	if string(out) != `{"mcpServers": {"github": {"command": "gh-mcp"}}, "z": 1, "a": 2}` {
+		t.Errorf("unexpected result removing the first server: %s", out)
+	}
+
+	only := `{"other": true, "mcpServers": {"trabuco": {"command": "trabuco"}}}`
+	out, _, _ = removeJSONMCPServer([]byte(only), "mcpServers")
+	if string(out) != `{"other": true}` {
+		t.Errorf("an emptied servers object should be dropped: %s", out)
+	}
+
+	out, changed, _ = removeJSONMCPServer([]byte(`{"mcpServers": {"trabuco": {}}}`), "mcpServers")
+	if !changed || out != nil {
+		t.Errorf("a file left empty should be reported as such, got %q", out)
+	}
+}
+
+func TestStripManagedMarkers(t *testing.T) {
+	doc := "# App\n<!-- trabuco:begin intro -->\r\nGenerated\r\n<!-- trabuco:end intro -->\r\nMine\n<!-- trabuco:end stray -->\n<!-- trabuco:begin tail -->\nLast\n<!-- trabuco:end tail -->"
+	want := "# App\nGenerated\r\nMine\nLast\n"
+	if got := string(stripManagedMarkers([]byte(doc))); got != want {
+		t.Errorf("stripManagedMarkers() = %q, want %q", got, want)
+	}
+}
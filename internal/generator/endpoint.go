@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+	"github.com/arianlopezc/Trabuco/internal/utils"
+)
+
+// EndpointOpts configures GenerateEndpoint.
+type EndpointOpts struct {
+	Path    string   // resource path, e.g. "/orders"; mounted under /api
+	Name    string   // optional PascalCase resource name; derived from Path
+	Methods []string // HTTP methods: GET, POST, PUT, DELETE
+	Fields  string   // optional addgen.ParseFields spec for the DTOs
+}
+
+var resourcePathPattern = regexp.MustCompile(`^(/[a-z][a-z0-9-]*)+$`)
+
+// resourcePath validates a resource path and mounts it under /api, where
+// the generated API serves its resources and WebConfig applies CORS.
+func resourcePath(path string) (string, error) {
+	p := strings.TrimSuffix(strings.TrimSpace(path), "/")
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if !resourcePathPattern.MatchString(p) {
+		return "", fmt.Errorf("invalid path %q: use lowercase segments of letters, digits and hyphens, e.g. /orders or /order-items", path)
+	}
+	if p == "/api" {
+		return "", fmt.Errorf("invalid path %q: name the resource, e.g. /orders", path)
+	}
+	if !strings.HasPrefix(p, "/api/") {
+		p = "/api" + p
+	}
+	return p, nil
+}
+
+// GenerateEndpoint adds a resource endpoint to the project at projectPath
+// (`trabuco generate endpoint`). Via addgen it creates the request and
+// response DTOs in Model, the controller and its MockMvc test in API and
+// the service in Shared. When the service already exists, the stubs the
+// controller calls are added to it instead, or handed over as a next step
+// when it no longer has its generated shape. JPMS projects get their
+// module descriptors regenerated. In dry-run mode nothing is written.
+func GenerateEndpoint(projectPath string, opts EndpointOpts, dryRun bool) (*addgen.Result, error) {
+	path, err := resourcePath(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		name = utils.SingularPascal(path[strings.LastIndex(path, "/")+1:])
+	}
+	if len(opts.Methods) == 0 {
+		return nil, fmt.Errorf("--methods is required, e.g. GET,POST")
+	}
+
+	ctx, err := addgen.LoadContext(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range []string{config.ModuleModel, config.ModuleShared, config.ModuleAPI} {
+		if !ctx.HasModule(module) {
+			return nil, fmt.Errorf("generate endpoint requires the %s module", module)
+		}
+	}
+	service := filepath.Join(ctx.JavaSrcMain(config.ModuleShared, "service"), name+"Service.java")
+	_, statErr := os.Stat(filepath.Join(projectPath, service))
+	serviceExists := statErr == nil
+
+	ctx.DryRun = dryRun
+	result, err := addgen.GenerateEndpoint(ctx, addgen.EndpointOpts{
+		Name:    name,
+		Path:    path,
+		Methods: opts.Methods,
+		Fields:  opts.Fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if serviceExists {
+		methods := make([]string, len(opts.Methods))
+		for i, m := range opts.Methods {
+			methods[i] = strings.ToUpper(strings.TrimSpace(m))
+		}
+		stubs, imports := addgen.ResourceServiceMethods(ctx, name, methods)
+		edited, err := editJavaFile(projectPath, service, dryRun, imports, func(src string) (string, bool) {
+			for _, method := range []string{" list(", " findById(", " create(", " update(", " delete("} {
+				if strings.Contains(stubs, method) && strings.Contains(src, method) {
+					// Same-named methods with other signatures; let the user merge
+					return src, false
+				}
+			}
+			end := strings.LastIndex(src, "}")
+			if end < 0 {
+				return src, false
+			}
+			return strings.TrimRight(src[:end], "\n") + "\n\n" + stubs + src[end:], true
+		})
+		if err != nil {
+			return nil, err
+		}
+		recordEdit(result, edited, service, dryRun,
+			fmt.Sprintf("Add the methods %sController calls, without clashing with the ones it has:\n%s", name, stubs))
+	}
+
+	if ctx.HasJPMS() {
+		if dryRun {
+			result.Notes = append(result.Notes, "Would regenerate the module-info.java descriptors")
+		} else {
+			gen := &Generator{
+				config: ctx.ProjectConfig,
+				engine: templates.NewEngine().WithProjectOverrides(projectPath),
+				outDir: projectPath,
+			}
+			notes, err := gen.generateModuleInfos()
+			if err != nil {
+				return nil, err
+			}
+			result.Notes = append(result.Notes, "Regenerated the module-info.java descriptors")
+			result.Notes = append(result.Notes, notes...)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func apiProjectConfig() *config.ProjectConfig {
+	return &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.test.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+	}
+}
+
+func TestGenerateEndpoint_DerivesNameAndPath(t *testing.T) {
+	projectPath := generateTestProject(t, apiProjectConfig())
+	opts := EndpointOpts{Path: "/order-items", Methods: []string{"GET", "POST"}}
+	planned, err := GenerateEndpoint(projectPath, opts, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	controllerRel := "API/src/main/java/com/test/shop/api/controller/OrderItemController.java"
+	if _, err := os.Stat(filepath.Join(projectPath, controllerRel)); !os.IsNotExist(err) {
+		t.Errorf("dry run should not write the controller: %v", err)
+	}
+
+	result, err := GenerateEndpoint(projectPath, opts, false)
+	if err != nil {
+		t.Fatalf("GenerateEndpoint failed: %v", err)
+	}
+	if strings.Join(result.Created, "\n") != strings.Join(planned.Created, "\n") {
+		t.Errorf("dry run planned %v, generated %v", planned.Created, result.Created)
+	}
+	if !strings.Contains(readFile(t, projectPath, controllerRel), `@RequestMapping("/api/order-items")`) {
+		t.Error("the controller should be mounted under /api")
+	}
+}
+
+func TestGenerateEndpoint_ExistingService(t *testing.T) {
+	projectPath := generateTestProject(t, apiProjectConfig())
+	serviceRel := "Shared/src/main/java/com/test/shop/shared/service/InvoiceService.java"
+	existing := "package com.test.shop.shared.service;\n\nimport org.springframework.stereotype.Service;\n\n@Service\npublic class InvoiceService {\n}\n"
+	if err := os.WriteFile(filepath.Join(projectPath, serviceRel), []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := GenerateEndpoint(projectPath, EndpointOpts{Path: "invoices", Methods: []string{"GET", "DELETE"}}, false)
+	if err != nil {
+		t.Fatalf("GenerateEndpoint failed: %v", err)
+	}
+	for _, created := range result.Created {
+		if created == serviceRel {
+			t.Error("the existing service should be edited, not recreated")
+		}
+	}
+	service := readFile(t, projectPath, serviceRel)
+	for _, want := range []string{
+		"import com.test.shop.model.dto.ImmutableInvoiceResponse;\nimport java.util.List;\nimport java.util.Optional;\nimport org.springframework.stereotype.Service;",
+		"  public boolean delete(String id) {\n",
+	} {
+		if !strings.Contains(service, want) {
+			t.Errorf("InvoiceService missing %q:\n%s", want, service)
+		}
+	}
+}
+
+func TestGenerateEndpoint_ServiceMethodClash(t *testing.T) {
+	projectPath := generateTestProject(t, apiProjectConfig())
+	serviceRel := "Shared/src/main/java/com/test/shop/shared/service/InvoiceService.java"
+	existing := "package com.test.shop.shared.service;\n\nimport org.springframework.stereotype.Service;\n\n@Service\npublic class InvoiceService {\n  public void delete(long id) {\n  }\n}\n"
+	if err := os.WriteFile(filepath.Join(projectPath, serviceRel), []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := GenerateEndpoint(projectPath, EndpointOpts{Path: "invoices", Methods: []string{"DELETE"}}, false)
+	if err != nil {
+		t.Fatalf("GenerateEndpoint failed: %v", err)
+	}
+	if readFile(t, projectPath, serviceRel) != existing {
+		t.Error("a service with a clashing method should be left alone")
+	}
+	if !strings.Contains(strings.Join(result.NextSteps, "\n"), "public boolean delete(String id) {") {
+		t.Errorf("the stubs should be handed over as a next step, got %v", result.NextSteps)
+	}
+}
+
+func TestGenerateEndpoint_Validation(t *testing.T) {
+	projectPath := generateTestProject(t, apiProjectConfig())
+	for _, tc := range []struct {
+		opts EndpointOpts
+		want string
+	}{
+		{EndpointOpts{Path: "/Orders", Methods: []string{"GET"}}, "invalid path"},
+		{EndpointOpts{Path: "/orders/{id}", Methods: []string{"GET"}}, "invalid path"},
+		{EndpointOpts{Path: "/api", Methods: []string{"GET"}}, "name the resource"},
+		{EndpointOpts{Path: "/orders"}, "--methods is required"},
+		{EndpointOpts{Path: "/orders", Methods: []string{"PATCH"}}, "unsupported method"},
+	} {
+		if _, err := GenerateEndpoint(projectPath, tc.opts, true); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: expected error containing %q, got %v", tc.opts, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// errorCatalogFiles returns the template and output path of every file of
+// the API's error catalog, which lives in Model so services can throw its
+// codes.
+func (g *Generator) errorCatalogFiles() [][2]string {
+	return [][2]string{
+		{"java/model/error/ErrorCode.java.tmpl", g.javaPath(config.ModuleModel, filepath.Join("error", "ErrorCode.java"))},
+		{"java/model/error/ApplicationException.java.tmpl", g.javaPath(config.ModuleModel, filepath.Join("error", "ApplicationException.java"))},
+		{"java/model/test/error/ErrorCodeTest.java.tmpl", g.testJavaPath(config.ModuleModel, filepath.Join("error", "ErrorCodeTest.java"))},
+	}
+}
+
+// generateErrorCatalog writes the error catalog classes.
+func (g *Generator) generateErrorCatalog() error {
+	for _, f := range g.errorCatalogFiles() {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to generate the error catalog: %w", err)
+		}
+	}
+	return nil
+}
+
+// missingErrorCatalogFiles returns the files of the error catalog that a
+// project generated before it existed lacks, as template and output path.
+func (g *Generator) missingErrorCatalogFiles() [][2]string {
+	var missing [][2]string
+	for _, f := range g.errorCatalogFiles() {
+		if _, err := os.Stat(filepath.Join(g.outDir, g.sourcePath(f[0], f[1]))); os.IsNotExist(err) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// ensureErrorCatalog writes the missing files of the error catalog, which
+// the API classes generated into an existing project refer to. It returns
+// the files created.
+func (g *Generator) ensureErrorCatalog() ([]string, error) {
+	var created []string
+	for _, f := range g.missingErrorCatalogFiles() {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return nil, fmt.Errorf("failed to generate the error catalog: %w", err)
+		}
+		created = append(created, g.sourcePath(f[0], f[1]))
+	}
+	return created, nil
+}
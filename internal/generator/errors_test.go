@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_ErrorCatalog(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.test.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	modelDir := filepath.Join(projectPath, "Model", "src", "main", "java", "com", "test", "shop", "model", "error")
+	enum, err := os.ReadFile(filepath.Join(modelDir, "ErrorCode.java"))
+	if err != nil {
+		t.Fatalf("expected ErrorCode.java in Model: %v", err)
+	}
+	// The enum declares the whole catalog, even codes no module produces yet
+	for _, want := range []string{"VALIDATION_FAILED(400, \"Validation Failed\"),", "DUPLICATE_RESOURCE(409, \"Conflict\"),", "MESSAGING_UNAVAILABLE(503, \"Service Unavailable\");"} {
+		if !strings.Contains(string(enum), want) {
+			t.Errorf("ErrorCode.java should declare %s", want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(modelDir, "ApplicationException.java")); err != nil {
+		t.Errorf("expected ApplicationException.java in Model: %v", err)
+	}
+
+	apiDir := filepath.Join(projectPath, "API", "src")
+	handler, _ := os.ReadFile(filepath.Join(apiDir, "main", "java", "com", "test", "shop", "api", "config", "GlobalExceptionHandler.java"))
+	for _, want := range []string{"@ExceptionHandler(ApplicationException.class)", "ErrorCode.INTERNAL_ERROR", "CorrelationIdFilter.CORRELATION_ID_MDC_KEY", "createResponseEntity"} {
+		if !strings.Contains(string(handler), want) {
+			t.Errorf("GlobalExceptionHandler.java should contain %s", want)
+		}
+	}
+	if strings.Contains(string(handler), "urn:problem-type:") {
+		t.Error("GlobalExceptionHandler.java should take every problem type from ErrorCode")
+	}
+	if _, err := os.Stat(filepath.Join(apiDir, "test", "java", "com", "test", "shop", "api", "config", "GlobalExceptionHandlerTest.java")); err != nil {
+		t.Errorf("expected the error contract test: %v", err)
+	}
+
+	doc, err := os.ReadFile(filepath.Join(projectPath, "docs", "errors.md"))
+	if err != nil {
+		t.Fatalf("expected docs/errors.md: %v", err)
+	}
+	if !strings.Contains(string(doc), "| `VALIDATION_FAILED` | 400 |") {
+		t.Error("docs/errors.md should list VALIDATION_FAILED")
+	}
+	if strings.Contains(string(doc), "DUPLICATE_RESOURCE") {
+		t.Error("docs/errors.md should not list datastore codes without a datastore")
+	}
+}
+
+func TestGenerator_Generate_ErrorCatalogWithoutAPI(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.test.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "Jobs", "Worker"},
+		Database:    "postgresql",
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "Model", "src", "main", "java", "com", "test", "shop", "model", "error")); !os.IsNotExist(err) {
+		t.Error("without the API, Model should have no error catalog")
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "docs", "errors.md")); !os.IsNotExist(err) {
+		t.Error("without the API, there should be no docs/errors.md")
+	}
+}
+
+func TestModuleAdder_Add_ErrorCatalog(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.test.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// A project generated before the catalog existed
+	modelDir := filepath.Join(projectPath, "Model", "src", "main", "java", "com", "test", "shop", "model", "error")
+	os.RemoveAll(modelDir)
+	os.Remove(filepath.Join(projectPath, "docs", "errors.md"))
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adder := NewModuleAdder(projectPath, metadata, "test", false)
+	if err := adder.Add(config.ModuleSQLDatastore, "postgresql", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	for _, f := range []string{"ErrorCode.java", "ApplicationException.java"} {
+		if _, err := os.Stat(filepath.Join(modelDir, f)); err != nil {
+			t.Errorf("the add should restore %s: %v", f, err)
+		}
+	}
+	doc, err := os.ReadFile(filepath.Join(projectPath, "docs", "errors.md"))
+	if err != nil {
+		t.Fatalf("the add should write docs/errors.md: %v", err)
+	}
+	if !strings.Contains(string(doc), "| `DUPLICATE_RESOURCE` | 409 |") {
+		t.Error("docs/errors.md should list the datastore codes once SQLDatastore is added")
+	}
+}
@@ -0,0 +1,293 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/utils"
+)
+
+// EventOpts configures GenerateEvent.
+type EventOpts struct {
+	Name   string // PascalCase event record name, e.g. "OrderShipped"
+	Topic  string // broker topic / queue name, e.g. "orders"
+	Fields string // optional addgen.ParseFields payload spec
+}
+
+var (
+	topicPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	// The placeholder destination field EventPublisher starts with, for every broker
+	publisherFieldPattern = regexp.MustCompile(`(?m)^  @Value\("\$\{app\.[^"]+\}"\)\n  private String placeholder\w+;\n`)
+)
+
+// GenerateEvent adds a broker event to the project at projectPath (`trabuco
+// generate event`). It creates the event record in Model and, with
+// EventConsumer, a listener and its test (via addgen). It also adds a publish
+// method to the Events module's EventPublisher and creates the SQS queue or
+// Pub/Sub topic in the local docker-compose init scripts; the other brokers
+// create their topics on first use or from the listener.
+//
+// Like GenerateJob, an edit whose anchor is gone is skipped and handed back as
+// a next step. In dry-run mode nothing is written.
+func GenerateEvent(projectPath string, opts EventOpts, dryRun bool) (*addgen.Result, error) {
+	topic := strings.TrimSpace(opts.Topic)
+	if !topicPattern.MatchString(topic) {
+		return nil, fmt.Errorf("invalid --topic %q: use lowercase letters, digits and hyphens, starting with a letter (valid for every broker)", opts.Topic)
+	}
+	ctx, err := addgen.LoadContext(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range []string{config.ModuleModel, config.ModuleEvents} {
+		if !ctx.HasModule(module) {
+			return nil, fmt.Errorf("generate event requires the %s module", module)
+		}
+	}
+
+	publisher := filepath.Join(ctx.JavaSrcMain(config.ModuleEvents, ""), "EventPublisher.java")
+	field := utils.ToCamelCase(topic) + destinationSuffix(ctx.ProjectConfig)
+	if src, err := os.ReadFile(filepath.Join(projectPath, publisher)); err == nil && strings.Contains(string(src), "private String "+field+";") {
+		// The generated listeners deserialize every message on their
+		// destination to one event type
+		return nil, fmt.Errorf("topic %q already carries another event (EventPublisher.%s); give each event type its own topic", topic, field)
+	}
+
+	ctx.DryRun = dryRun
+	result, err := addgen.GenerateEvent(ctx, addgen.EventOpts{Name: opts.Name, Fields: opts.Fields, Topic: topic})
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(opts.Name)
+
+	publish, _ := addgen.EventDestinations(ctx.ProjectConfig, topic)
+	method := publishMethod(ctx.ProjectConfig, name, field)
+	fieldDecl := fmt.Sprintf("\n  @Value(%q)\n  private String %s;\n", publish, field)
+	imports := []string{ctx.JavaPackage(config.ModuleModel, "events") + "." + name}
+	if ctx.UsesPubSubOrdering() {
+		imports = append(imports, "com.google.cloud.spring.pubsub.support.GcpPubSubHeaders", "java.util.Map")
+	}
+	edited, err := editJavaFile(projectPath, publisher, dryRun, imports, func(src string) (string, bool) {
+		if strings.Contains(src, "public void publish("+name+" event)") {
+			return src, true
+		}
+		loc := publisherFieldPattern.FindStringIndex(src)
+		end := strings.LastIndex(src, "}")
+		if loc == nil || end < loc[1] {
+			return src, false
+		}
+		return src[:loc[1]] + fieldDecl + strings.TrimRight(src[loc[1]:end], "\n") + "\n\n" + method + src[end:], true
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordEdit(result, edited, publisher, dryRun,
+		fmt.Sprintf("Add the destination and publish method to EventPublisher:\n%s\n%s", strings.TrimPrefix(fieldDecl, "\n"), method))
+
+	switch {
+	case ctx.UsesSQS():
+		// Anchored on the placeholder queue's own command, the last one, so
+		// the new queue's dead-letter queue is created before it
+		for _, init := range []struct{ path, cli string }{
+			{"docker-compose.yml", "aws --endpoint-url=http://localstack:4566"},
+			{filepath.Join("localstack-init", "ready.d", "init-sqs.sh"), "awslocal"},
+		} {
+			placeholder := ctx.SQSCreateQueueCommands(init.cli, "placeholder-events")
+			lines := ctx.SQSCreateQueueCommands(init.cli, topic)
+			if init.cli != "awslocal" {
+				lines = append([]string{fmt.Sprintf("echo \"Creating SQS queue: %s\"", ctx.SQSQueueName(topic))}, lines...)
+			}
+			if err := addInitLines(projectPath, init.path, dryRun, result,
+				placeholder[len(placeholder)-1], false, lines[len(lines)-1]+"\n", lines); err != nil {
+				return nil, err
+			}
+		}
+	case ctx.UsesPubSub():
+		lines := []string{
+			fmt.Sprintf("echo \"Creating Pub/Sub topic: %s\"", topic),
+			fmt.Sprintf("curl -s -X PUT \"http://pubsub-emulator:8085/v1/projects/local-project/topics/%s\"", topic),
+			`echo ""`,
+			fmt.Sprintf("echo \"Creating Pub/Sub subscription: %s-sub\"", topic),
+			fmt.Sprintf("curl -s -X PUT \"http://pubsub-emulator:8085/v1/projects/local-project/subscriptions/%s-sub\" \\", topic),
+			`  -H "Content-Type: application/json" \`,
+			fmt.Sprintf(`  -d '%s'`, ctx.PubSubSubscriptionBody(topic)),
+			`echo ""`,
+		}
+		if err := addInitLines(projectPath, "docker-compose.yml", dryRun, result,
+			`echo "Pub/Sub initialization complete"`, true, "/topics/"+topic+"\"", lines); err != nil {
+			return nil, err
+		}
+	case ctx.UsesKafka():
+		result.Notes = append(result.Notes, fmt.Sprintf("The local Kafka broker auto-creates %q and its retry topics; create them before deploying.", topic))
+	case ctx.UsesRabbitMQ():
+		if ctx.HasModule(config.ModuleEventConsumer) {
+			result.Notes = append(result.Notes, "The listener declares the exchange, queue and dead-letter pair when EventConsumer starts.")
+		} else {
+			result.NextSteps = append(result.NextSteps, fmt.Sprintf("Declare the %s-exchange fanout exchange where the events are consumed; RabbitMQ drops messages sent to a missing exchange.", topic))
+		}
+	case ctx.UsesNATS():
+		result.NextSteps = append(result.NextSteps, fmt.Sprintf(
+			"Add the %q subject to the JetStream stream's subjects: NatsPublisherConfig and NatsConfig create it with placeholder.events only, and JetStream rejects publishes to other subjects.", topic))
+	}
+
+	if ctx.HasModule(config.ModuleEventConsumer) {
+		switch {
+		case ctx.UsesPubSub():
+			result.NextSteps = append(result.NextSteps, fmt.Sprintf("Register an inbound channel adapter for the %s-sub subscription in PubSubConfig that calls %sListener.handle.", topic, name))
+		case ctx.UsesRedisStreams():
+			result.NextSteps = append(result.NextSteps, fmt.Sprintf("Subscribe to the %s stream in RedisStreamConfig and call %sListener.handle for each record.", topic, name))
+		case ctx.UsesNATS():
+			result.NextSteps = append(result.NextSteps, fmt.Sprintf("Add a durable consumer for the %s subject in NatsConfig that calls %sListener.handle.", topic, name))
+		}
+		result.NextSteps = append(result.NextSteps,
+			fmt.Sprintf("Run ./mvnw test -pl EventConsumer -am to compile the event and run %sListenerTest.", name))
+	} else {
+		result.Notes = append(result.Notes, "No EventConsumer module: only the publishing side was generated.")
+	}
+	return result, nil
+}
+
+// destinationSuffix names EventPublisher's destination fields after what the
+// broker publishes to, as the placeholder fields are.
+func destinationSuffix(cfg *config.ProjectConfig) string {
+	switch {
+	case cfg.UsesRabbitMQ():
+		return "Exchange"
+	case cfg.UsesSQS():
+		return "Queue"
+	case cfg.UsesRedisStreams():
+		return "Stream"
+	case cfg.UsesNATS():
+		return "Subject"
+	}
+	return "Topic"
+}
+
+// publishMethod renders EventPublisher's publish overload for an event,
+// mirroring the placeholder method of the project's broker.
+func publishMethod(cfg *config.ProjectConfig, name, field string) string {
+	var b strings.Builder
+	b.WriteString("  /**\n")
+	switch {
+	case cfg.UsesKafka():
+		fmt.Fprintf(&b, "   * Publishes {@link %s} events to Kafka, keyed by event ID.\n", name)
+	case cfg.UsesRabbitMQ():
+		fmt.Fprintf(&b, "   * Publishes {@link %s} events to RabbitMQ. The consumer's listener\n", name)
+		b.WriteString("   * declares the exchange; until it has started, messages are dropped.\n")
+	case cfg.UsesSQS():
+		fmt.Fprintf(&b, "   * Publishes {@link %s} events to AWS SQS.\n", name)
+		if cfg.UsesSQSFifo() {
+			b.WriteString("   * The message group is the event ID, so the events are not ordered: group\n")
+			b.WriteString("   * them by the ID of the entity they are about to consume its events in order.\n")
+		}
+	case cfg.UsesPubSub():
+		fmt.Fprintf(&b, "   * Publishes {@link %s} events to GCP Pub/Sub.\n", name)
+		if cfg.UsesPubSubOrdering() {
+			b.WriteString("   * The ordering key is the event ID, so the events are not ordered: key\n")
+			b.WriteString("   * them by the ID of the entity they are about to deliver its events in order.\n")
+		}
+	case cfg.UsesRedisStreams():
+		fmt.Fprintf(&b, "   * Appends {@link %s} events to a Redis Stream.\n", name)
+	case cfg.UsesNATS():
+		fmt.Fprintf(&b, "   * Publishes {@link %s} events to NATS JetStream, waiting for the stream's ack.\n", name)
+	}
+	b.WriteString("   *\n")
+	b.WriteString("   * @param event The event to publish\n")
+	b.WriteString("   */\n")
+	fmt.Fprintf(&b, "  public void publish(%s event) {\n", name)
+	logLine := func(broker, label string) {
+		fmt.Fprintf(&b, "    logger.info(\"Publishing event to %s: %s={}, eventId={}, type={}\",\n", broker, label)
+		fmt.Fprintf(&b, "      %s, event.eventId(), event.getClass().getSimpleName());\n", field)
+	}
+	switch {
+	case cfg.UsesKafka():
+		logLine("Kafka", "topic")
+		fmt.Fprintf(&b, "    kafkaTemplate.send(%s, event.eventId(), event);\n", field)
+	case cfg.UsesRabbitMQ():
+		logLine("RabbitMQ", "exchange")
+		fmt.Fprintf(&b, "    rabbitTemplate.convertAndSend(%s, \"\", event);\n", field)
+	case cfg.UsesSQS():
+		logLine("SQS", "queue")
+		if cfg.UsesSQSFifo() {
+			fmt.Fprintf(&b, "    sqsTemplate.send(to -> to.queue(%s).payload(event).messageGroupId(event.eventId()));\n", field)
+		} else {
+			fmt.Fprintf(&b, "    sqsTemplate.send(%s, event);\n", field)
+		}
+	case cfg.UsesPubSub():
+		logLine("Pub/Sub", "topic")
+		if cfg.UsesPubSubOrdering() {
+			fmt.Fprintf(&b, "    pubSubTemplate.publish(%s, event, Map.of(GcpPubSubHeaders.ORDERING_KEY, event.eventId()));\n", field)
+		} else {
+			fmt.Fprintf(&b, "    pubSubTemplate.publish(%s, event);\n", field)
+		}
+	case cfg.UsesRedisStreams():
+		logLine("Redis Stream", "stream")
+		b.WriteString("    String payload;\n")
+		b.WriteString("    try {\n")
+		b.WriteString("      payload = objectMapper.writeValueAsString(event);\n")
+		b.WriteString("    } catch (JsonProcessingException e) {\n")
+		b.WriteString("      throw new IllegalArgumentException(\"Cannot serialize event \" + event.eventId(), e);\n")
+		b.WriteString("    }\n")
+		b.WriteString("    redisTemplate.opsForStream().add(\n")
+		b.WriteString("      StreamRecords.string(Map.of(\"eventId\", event.eventId(), \"payload\", payload))\n")
+		fmt.Fprintf(&b, "        .withStreamKey(%s));\n", field)
+	case cfg.UsesNATS():
+		logLine("NATS", "subject")
+		b.WriteString("    try {\n")
+		b.WriteString("      NatsMessage message = NatsMessage.builder()\n")
+		fmt.Fprintf(&b, "        .subject(%s)\n", field)
+		b.WriteString("        .headers(new Headers().add(\"Nats-Msg-Id\", event.eventId()))\n")
+		b.WriteString("        .data(objectMapper.writeValueAsBytes(event))\n")
+		b.WriteString("        .build();\n")
+		b.WriteString("      jetStream.publish(message);\n")
+		b.WriteString("    } catch (JsonProcessingException e) {\n")
+		b.WriteString("      throw new IllegalArgumentException(\"Cannot serialize event \" + event.eventId(), e);\n")
+		b.WriteString("    } catch (IOException e) {\n")
+		b.WriteString("      throw new UncheckedIOException(\"Failed to publish event \" + event.eventId(), e);\n")
+		b.WriteString("    } catch (JetStreamApiException e) {\n")
+		b.WriteString("      throw new IllegalStateException(\"JetStream rejected event \" + event.eventId(), e);\n")
+		b.WriteString("    }\n")
+	}
+	b.WriteString("  }\n")
+	return b.String()
+}
+
+// addInitLines inserts lines into a local init script next to the first line
+// containing anchor, after it or (before) ahead of it, with that line's
+// indentation. done is a marker whose presence means the lines are already
+// there. The outcome lands on result like any other edit.
+func addInitLines(projectPath, relPath string, dryRun bool, result *addgen.Result, anchor string, before bool, done string, lines []string) error {
+	edited, err := editFile(projectPath, relPath, dryRun, func(src string) (string, bool) {
+		if strings.Contains(src, done) {
+			return src, true
+		}
+		at := strings.Index(src, anchor)
+		if at < 0 {
+			return src, false
+		}
+		start := strings.LastIndex(src[:at], "\n") + 1
+		indent := src[start : start+len(src[start:])-len(strings.TrimLeft(src[start:], " "))]
+		var block strings.Builder
+		for _, line := range lines {
+			block.WriteString(indent + line + "\n")
+		}
+		if before {
+			return src[:start] + block.String() + src[start:], true
+		}
+		end := strings.Index(src[at:], "\n")
+		if end < 0 {
+			return src + "\n" + strings.TrimSuffix(block.String(), "\n"), true
+		}
+		end += at + 1
+		return src[:end] + block.String() + src[end:], true
+	})
+	if err != nil {
+		return err
+	}
+	recordEdit(result, edited, relPath, dryRun, "Add to it:\n"+strings.Join(lines, "\n"))
+	return nil
+}
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func eventsProjectConfig(broker string) *config.ProjectConfig {
+	return &config.ProjectConfig{
+		ProjectName:   "events",
+		GroupID:       "com.test.events",
+		ArtifactID:    "events",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "Events", "EventConsumer"},
+		MessageBroker: broker,
+	}
+}
+
+func TestGenerateEvent_Kafka(t *testing.T) {
+	projectPath := generateTestProject(t, eventsProjectConfig("kafka"))
+	publisherRel := "Events/src/main/java/com/test/events/events/EventPublisher.java"
+	before := readFile(t, projectPath, publisherRel)
+
+	opts := EventOpts{Name: "OrderShipped", Topic: "orders", Fields: "orderId:uuid"}
+	if _, err := GenerateEvent(projectPath, opts, true); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if readFile(t, projectPath, publisherRel) != before {
+		t.Error("dry run should not edit EventPublisher")
+	}
+
+	result, err := GenerateEvent(projectPath, opts, false)
+	if err != nil {
+		t.Fatalf("GenerateEvent failed: %v", err)
+	}
+	if len(result.Created) != 3 {
+		t.Errorf("expected event, listener and test, got %v", result.Created)
+	}
+	publisher := readFile(t, projectPath, publisherRel)
+	for _, want := range []string{
+		"import com.test.events.model.events.OrderShipped;\nimport com.test.events.model.events.PlaceholderEvent;",
+		"  private String placeholderTopic;\n\n  @Value(\"${app.kafka.topics.orders:orders}\")\n  private String ordersTopic;\n",
+		"    kafkaTemplate.send(ordersTopic, event.eventId(), event);\n  }\n}",
+	} {
+		if !strings.Contains(publisher, want) {
+			t.Errorf("EventPublisher missing %q:\n%s", want, publisher)
+		}
+	}
+	listener := readFile(t, projectPath, "EventConsumer/src/main/java/com/test/events/eventconsumer/listener/OrderShippedListener.java")
+	for _, want := range []string{"@RetryableTopic(", "spring.json.value.default.type=com.test.events.model.events.OrderShipped", "@DltHandler"} {
+		if !strings.Contains(listener, want) {
+			t.Errorf("listener missing %q", want)
+		}
+	}
+
+	// Listeners deserialize one event type per topic
+	if _, err := GenerateEvent(projectPath, EventOpts{Name: "OrderCancelled", Topic: "orders"}, false); err == nil || !strings.Contains(err.Error(), "already carries") {
+		t.Errorf("expected a second event on the topic to be rejected, got %v", err)
+	}
+}
+
+func TestGenerateEvent_SQSInitScripts(t *testing.T) {
+	projectPath := generateTestProject(t, eventsProjectConfig("sqs"))
+	if _, err := GenerateEvent(projectPath, EventOpts{Name: "OrderShipped", Topic: "orders"}, false); err != nil {
+		t.Fatalf("GenerateEvent failed: %v", err)
+	}
+	compose := readFile(t, projectPath, "docker-compose.yml")
+	if !strings.Contains(compose, "sqs create-queue --queue-name placeholder-events\n        echo \"Creating SQS queue: orders\"\n        aws --endpoint-url=http://localstack:4566 sqs create-queue --queue-name orders\n") {
+		t.Errorf("docker-compose.yml should create the orders queue:\n%s", compose)
+	}
+	script := readFile(t, projectPath, "localstack-init/ready.d/init-sqs.sh")
+	if !strings.Contains(script, "awslocal sqs create-queue --queue-name orders\n") {
+		t.Errorf("init-sqs.sh should create the orders queue:\n%s", script)
+	}
+	info, err := os.Stat(filepath.Join(projectPath, "localstack-init/ready.d/init-sqs.sh"))
+	if err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("init-sqs.sh should stay executable: %v", err)
+	}
+}
+
+func TestGenerateEvent_MissingPublisher(t *testing.T) {
+	projectPath := generateTestProject(t, eventsProjectConfig("rabbitmq"))
+	if err := os.Remove(filepath.Join(projectPath, "Events/src/main/java/com/test/events/events/EventPublisher.java")); err != nil {
+		t.Fatal(err)
+	}
+	result, err := GenerateEvent(projectPath, EventOpts{Name: "OrderShipped", Topic: "orders"}, false)
+	if err != nil {
+		t.Fatalf("a missing publisher should not fail the event: %v", err)
+	}
+	manual := strings.Join(result.NextSteps, "\n")
+	if !strings.Contains(manual, `rabbitTemplate.convertAndSend(ordersExchange, "", event);`) {
+		t.Errorf("the publish method should be handed over as a next step, got:\n%s", manual)
+	}
+}
+
+func TestGenerateEvent_Validation(t *testing.T) {
+	projectPath := generateTestProject(t, eventsProjectConfig("kafka"))
+	for _, topic := range []string{"", "Orders", "orders.shipped", "1orders"} {
+		if _, err := GenerateEvent(projectPath, EventOpts{Name: "OrderShipped", Topic: topic}, true); err == nil || !strings.Contains(err.Error(), "invalid --topic") {
+			t.Errorf("topic %q: expected invalid --topic error, got %v", topic, err)
+		}
+	}
+}
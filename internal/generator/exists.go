@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// What Generate does when the output directory already exists
+const (
+	OnExistsFail  = "fail"  // Refuse to generate (default)
+	OnExistsForce = "force" // Move the directory aside as a backup and generate afresh
+	OnExistsMerge = "merge" // Write only the missing files; report the ones that differ
+)
+
+// OnExistsModes lists the accepted --on-exists values
+var OnExistsModes = []string{OnExistsFail, OnExistsForce, OnExistsMerge}
+
+// ValidateOnExists checks an --on-exists / on_exists value; empty means fail
+func ValidateOnExists(mode string) error {
+	switch mode {
+	case "", OnExistsFail, OnExistsForce, OnExistsMerge:
+		return nil
+	}
+	return fmt.Errorf("invalid on-exists mode %q: use fail, force or merge", mode)
+}
+
+// ExistsError is returned by Generate when the output directory already
+// exists and the on-exists mode is fail
+type ExistsError struct {
+	Dir string
+}
+
+func (e *ExistsError) Error() string {
+	return fmt.Sprintf("directory '%s' already exists", e.Dir)
+}
+
+// SetOnExists sets what Generate does when the output directory already
+// exists (OnExistsFail, OnExistsForce or OnExistsMerge)
+func (g *Generator) SetOnExists(mode string) {
+	g.onExists = mode
+}
+
+// OutputExists reports whether something is already at dir, the case the
+// on-exists mode decides
+func OutputExists(dir string) bool {
+	_, err := os.Lstat(dir)
+	return !os.IsNotExist(err)
+}
+
+// replacedBackupPath returns the sibling directory force moves dir to
+func replacedBackupPath(dir string) string {
+	base := fmt.Sprintf("%s.trabuco-backup-%s", filepath.Clean(dir), time.Now().Format("20060102-150405"))
+	path := base
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// replaceDir moves dir aside and staging into its place, putting dir back
+// if the second move fails. It returns where dir went.
+func replaceDir(staging, dir string) (string, error) {
+	backup := replacedBackupPath(dir)
+	if err := os.Rename(dir, backup); err != nil {
+		return "", fmt.Errorf("failed to move %s aside: %w", dir, err)
+	}
+	if err := os.Rename(staging, dir); err != nil {
+		if restoreErr := os.Rename(backup, dir); restoreErr != nil {
+			return "", fmt.Errorf("failed to move generated project into %s: %w (the previous directory is in %s)", dir, err, backup)
+		}
+		return "", fmt.Errorf("failed to move generated project into %s: %w", dir, err)
+	}
+	return backup, nil
+}
+
+// mergeInto moves the files of staging that dir lacks into dir. Files
+// present in both are left alone; the ones whose content differs are
+// returned, project-relative and sorted. staging is removed afterwards.
+func mergeInto(staging, dir string) ([]string, error) {
+	var conflicts []string
+	err := filepath.WalkDir(staging, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(staging, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		target := filepath.Join(dir, rel)
+		existing, statErr := os.Lstat(target)
+		if os.IsNotExist(statErr) {
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Rename(path, target); err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if statErr != nil {
+			return statErr
+		}
+		switch {
+		case d.IsDir() && existing.IsDir():
+			return nil
+		case d.IsDir() || existing.IsDir():
+			conflicts = append(conflicts, filepath.ToSlash(rel))
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		same, err := sameContent(path, target)
+		if err != nil {
+			return err
+		}
+		if !same {
+			conflicts = append(conflicts, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge generated project into %s: %w", dir, err)
+	}
+	sort.Strings(conflicts)
+	return conflicts, os.RemoveAll(staging)
+}
+
+func sameContent(a, b string) (bool, error) {
+	x, err := os.ReadFile(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := os.ReadFile(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(x, y), nil
+}
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func existsTestGenerator(t *testing.T, outDir, mode string) *Generator {
+	t.Helper()
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.acme.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen.SetOnExists(mode)
+	return gen
+}
+
+// existingProject creates outDir with a hand-written README.md and an
+// unrelated notes.txt
+func existingProject(t *testing.T) string {
+	t.Helper()
+	outDir := filepath.Join(t.TempDir(), "shop")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"README.md": "# mine\n", "notes.txt": "keep me\n"} {
+		if err := os.WriteFile(filepath.Join(outDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return outDir
+}
+
+func TestGenerate_OnExistsFail(t *testing.T) {
+	outDir := existingProject(t)
+	for _, mode := range []string{"", OnExistsFail} {
+		err := existsTestGenerator(t, outDir, mode).Generate()
+		var exists *ExistsError
+		if !errors.As(err, &exists) || exists.Dir != outDir {
+			t.Fatalf("mode %q: err = %v, want ExistsError for %s", mode, err, outDir)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(outDir, "README.md")); string(data) != "# mine\n" {
+		t.Errorf("README.md changed: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "pom.xml")); !os.IsNotExist(err) {
+		t.Error("fail must not write into the existing directory")
+	}
+}
+
+func TestGenerate_OnExistsForce(t *testing.T) {
+	outDir := existingProject(t)
+	gen := existsTestGenerator(t, outDir, OnExistsForce)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(outDir, "notes.txt")); !os.IsNotExist(err) {
+		t.Error("force must replace the directory, notes.txt is still there")
+	}
+	if data, _ := os.ReadFile(filepath.Join(outDir, "README.md")); string(data) == "# mine\n" {
+		t.Error("README.md was not regenerated")
+	}
+
+	report := gen.Report()
+	backup := report.ReplacedBackup
+	if !strings.HasPrefix(backup, outDir+".trabuco-backup-") {
+		t.Fatalf("ReplacedBackup = %q", backup)
+	}
+	if data, _ := os.ReadFile(filepath.Join(backup, "notes.txt")); string(data) != "keep me\n" {
+		t.Errorf("backup lost notes.txt: %q", data)
+	}
+	if len(report.FilesModified) != 0 || !slices.Contains(report.CreatedFiles(), "README.md") {
+		t.Errorf("a forced init creates every file: modified %v", report.FilesModified)
+	}
+	if !strings.Contains(report.Markdown(), backup) {
+		t.Error("LAST_OPERATION.md should name the backup")
+	}
+}
+
+func TestGenerate_OnExistsMerge(t *testing.T) {
+	outDir := existingProject(t)
+
+	// A file identical to the generated one is not a conflict
+	reference := filepath.Join(t.TempDir(), "shop")
+	if err := existsTestGenerator(t, reference, OnExistsFail).Generate(); err != nil {
+		t.Fatal(err)
+	}
+	same, err := os.ReadFile(filepath.Join(reference, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, ".gitignore"), same, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := existsTestGenerator(t, outDir, OnExistsMerge)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(outDir, "README.md")); string(data) != "# mine\n" {
+		t.Errorf("merge overwrote README.md: %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(outDir, "notes.txt")); string(data) != "keep me\n" {
+		t.Errorf("merge touched notes.txt: %q", data)
+	}
+	for _, rel := range []string{"pom.xml", "Model/pom.xml", "API/pom.xml"} {
+		if _, err := os.Stat(filepath.Join(outDir, rel)); err != nil {
+			t.Errorf("missing file %s not written: %v", rel, err)
+		}
+	}
+
+	report := gen.Report()
+	if !slices.Contains(report.Conflicts, "README.md") {
+		t.Errorf("Conflicts = %v, want README.md", report.Conflicts)
+	}
+	if slices.Contains(report.Conflicts, ".gitignore") || slices.Contains(report.Conflicts, "notes.txt") {
+		t.Errorf("Conflicts = %v, identical and unrelated files are not conflicts", report.Conflicts)
+	}
+	created := report.CreatedFiles()
+	if !slices.Contains(created, "pom.xml") || slices.Contains(created, "README.md") {
+		t.Errorf("created = %v", created)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(outDir))
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".staging-") {
+			t.Errorf("staging directory %s left behind", e.Name())
+		}
+	}
+}
+
+func TestValidateOnExists(t *testing.T) {
+	for _, mode := range append([]string{""}, OnExistsModes...) {
+		if err := ValidateOnExists(mode); err != nil {
+			t.Errorf("ValidateOnExists(%q) = %v", mode, err)
+		}
+	}
+	if err := ValidateOnExists("overwrite"); err == nil {
+		t.Error("ValidateOnExists(overwrite) should fail")
+	}
+}
@@ -1,16 +1,22 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/fatih/color"
+	"golang.org/x/sync/errgroup"
 	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/envcontract"
+	"github.com/arianlopezc/Trabuco/internal/plugin"
 	"github.com/arianlopezc/Trabuco/internal/templates"
+	"github.com/arianlopezc/Trabuco/internal/todos"
 )
 
 // Generator handles project generation
@@ -19,6 +25,14 @@ type Generator struct {
 	engine  *templates.Engine
 	outDir  string
 	version string
+	report  *OperationReport // Set by a successful Generate
+	args    []string         // Invocation recorded in .trabuco/history.jsonl
+
+	keepPartial bool            // Keep the staging directory when Generate fails
+	onExists    string          // What to do when outDir exists (OnExists*)
+	jpmsNotes   []string        // Modules --jpms left on the classpath, and why
+	progress    ProgressFunc    // Called as Generate enters each stage
+	ctx         context.Context // Stops Generate between stages when done
 }
 
 // New creates a new Generator
@@ -38,9 +52,10 @@ func NewWithVersion(cfg *config.ProjectConfig, version string) (*Generator, erro
 	}, nil
 }
 
-// NewWithVersionAt creates a new Generator with a specified version and output directory
+// NewWithVersionAt creates a new Generator with a specified version and output directory.
+// Templates under <outDir>/.trabuco/templates shadow the built-in ones.
 func NewWithVersionAt(cfg *config.ProjectConfig, version string, outDir string) (*Generator, error) {
-	engine := templates.NewEngine()
+	engine := templates.NewEngine().WithProjectOverrides(outDir)
 
 	return &Generator{
 		config:  cfg,
@@ -50,6 +65,12 @@ func NewWithVersionAt(cfg *config.ProjectConfig, version string, outDir string)
 	}, nil
 }
 
+// UseProjectOverrides makes the generator honour the template overrides of
+// the project at projectRoot, for renders whose output goes elsewhere.
+func (g *Generator) UseProjectOverrides(projectRoot string) {
+	g.engine.WithProjectOverrides(projectRoot)
+}
+
 // GenerateCIWorkflow generates only the CI workflow file
 func (g *Generator) GenerateCIWorkflow() error {
 	if g.config.HasCIProvider("github") {
@@ -58,65 +79,199 @@ func (g *Generator) GenerateCIWorkflow() error {
 	return nil
 }
 
-// Generate creates the complete project structure
+// Generate creates the complete project structure. Files are rendered into
+// a hidden staging directory next to the output directory and renamed into
+// place once every step succeeded, so a failed run leaves nothing behind
+// (see SetKeepPartial). An existing output directory is handled as
+// SetOnExists says.
 func (g *Generator) Generate() error {
-	green := color.New(color.FgGreen)
 	yellow := color.New(color.FgYellow)
 
 	yellow.Println("\nGenerating project...")
 
-	// Check if directory already exists
-	if _, err := os.Stat(g.outDir); !os.IsNotExist(err) {
-		return fmt.Errorf("directory '%s' already exists", g.outDir)
+	exists := OutputExists(g.outDir)
+	if info, err := os.Stat(g.outDir); err == nil && !info.IsDir() {
+		return fmt.Errorf("'%s' exists and is not a directory", g.outDir)
+	}
+	if exists && (g.onExists == "" || g.onExists == OnExistsFail) {
+		return &ExistsError{Dir: g.outDir}
+	}
+	if err := ValidateOnExists(g.onExists); err != nil {
+		return err
+	}
+	tracker := newOperationTracker(g.outDir)
+	if exists && g.onExists == OnExistsForce {
+		// The project is generated afresh: everything in it is new
+		tracker.before, tracker.services = treeSnapshot{}, map[string]bool{}
+	}
+
+	staging, err := newStagingDir(g.outDir)
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	finalDir := g.outDir
+	g.outDir = staging
+	err = g.render()
+	g.outDir = finalDir
+	var conflicts []string
+	var replaced string
+	if err == nil {
+		switch {
+		case exists && g.onExists == OnExistsMerge:
+			conflicts, err = mergeInto(staging, finalDir)
+		case exists && g.onExists == OnExistsForce:
+			replaced, err = replaceDir(staging, finalDir)
+		default:
+			if renameErr := os.Rename(staging, finalDir); renameErr != nil {
+				err = fmt.Errorf("failed to move generated project into %s: %w", finalDir, renameErr)
+			}
+		}
+	}
+	if err != nil {
+		if g.keepPartial {
+			return fmt.Errorf("%w (partial project kept in %s)", err, staging)
+		}
+		if rmErr := os.RemoveAll(staging); rmErr != nil {
+			log.Printf("Warning: failed to cleanup directory %s: %v", staging, rmErr)
+		}
+		return err
+	}
+
+	for _, note := range g.jpmsNotes {
+		yellow.Printf("  ⚠ %s\n", note)
+	}
+
+	// Initialize git repository
+	g.enter("git")
+	if err := g.initGit(); err != nil {
+		yellow.Printf("  ⚠ Could not initialize git repository: %v\n", err)
+	}
+
+	// Record what was generated (LAST_OPERATION.md is best-effort)
+	g.report = tracker.report("init", g.config, g.config.Modules)
+	g.report.Conflicts = conflicts
+	g.report.ReplacedBackup = replaced
+	if err := g.report.Save(g.outDir); err != nil {
+		yellow.Printf("  ⚠ Could not write %s: %v\n", LastOperationFile, err)
+	}
+	if err := config.AppendHistory(g.outDir, g.report.HistoryEntry(g.version, g.invocation())); err != nil {
+		yellow.Printf("  ⚠ Could not write %s: %v\n", config.HistoryFileName, err)
+	}
+	if err := todos.RecordGenerated(g.outDir, g.version, g.report.CreatedFiles()); err != nil {
+		yellow.Printf("  ⚠ Could not write %s: %v\n", todos.IndexFileName, err)
 	}
 
+	return nil
+}
+
+// render writes every project file into g.outDir
+func (g *Generator) render() error {
 	// Create directory structure
+	if err := g.enter("directories"); err != nil {
+		return err
+	}
 	if err := g.createDirectories(); err != nil {
-		g.cleanup()
 		return fmt.Errorf("failed to create directories: %w", err)
 	}
-	green.Println("  ✓ Created directory structure")
 
-	// Generate parent POM
-	if err := g.generateParentPOM(); err != nil {
-		g.cleanup()
-		return fmt.Errorf("failed to generate parent pom.xml: %w", err)
+	// Generate parent POM, modules and documentation concurrently
+	if err := g.enter("files"); err != nil {
+		return err
+	}
+	if err := g.generateFiles(); err != nil {
+		return err
 	}
-	green.Println("  ✓ Created parent pom.xml")
 
-	// Generate modules
-	for _, module := range g.config.Modules {
-		if err := g.generateModule(module); err != nil {
-			g.cleanup()
-			return fmt.Errorf("failed to generate %s module: %w", module, err)
+	// Fold the modules of a modulith into its single App module
+	if err := g.enter("modulith"); err != nil {
+		return err
+	}
+	if g.config.IsModulith() {
+		if err := g.foldIntoModulith(); err != nil {
+			return fmt.Errorf("failed to fold the modules into %s: %w", config.ModulithModule, err)
 		}
-		green.Printf("  ✓ Created %s module\n", module)
 	}
 
-	// Generate documentation files
-	if err := g.generateDocs(); err != nil {
-		g.cleanup()
-		return fmt.Errorf("failed to generate documentation: %w", err)
+	// Derive module-info.java descriptors from the rendered sources
+	if err := g.enter("module descriptors"); err != nil {
+		return err
+	}
+	notes, err := g.generateModuleInfos()
+	if err != nil {
+		return fmt.Errorf("failed to generate module-info.java: %w", err)
+	}
+	g.jpmsNotes = notes
+
+	// Apply parent POM and docker-compose edits declared by template packs
+	if err := g.enter("template packs"); err != nil {
+		return err
+	}
+	if len(pluginModules(g.config.Modules)) > 0 {
+		if err := g.applyPluginProjectEdits(); err != nil {
+			return fmt.Errorf("failed to apply plugin edits: %w", err)
+		}
 	}
-	green.Println("  ✓ Created documentation files")
 
 	// Generate metadata file (.trabuco.json)
+	if err := g.enter("metadata"); err != nil {
+		return err
+	}
 	if err := g.generateMetadata(g.version); err != nil {
-		g.cleanup()
 		return fmt.Errorf("failed to generate metadata: %w", err)
 	}
-	green.Println("  ✓ Created .trabuco.json")
 
-	// Initialize git repository
-	if err := g.initGit(); err != nil {
-		yellow.Printf("  ⚠ Could not initialize git repository: %v\n", err)
-	} else {
-		green.Println("  ✓ Initialized git repository")
+	// Document the environment variables the rendered configuration reads
+	if err := envcontract.Write(g.outDir, g.config.ProjectName); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", envcontract.DocFileName, err)
 	}
 
 	return nil
 }
 
+// newStagingDir creates an empty hidden directory next to outDir. Being a
+// sibling keeps it on the same filesystem, so the final os.Rename is atomic.
+// MkdirTemp creates it 0700; it becomes the project root, so it gets the
+// 0755 of every other generated directory.
+func newStagingDir(outDir string) (string, error) {
+	parent := filepath.Dir(outDir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", err
+	}
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(outDir)+".staging-")
+	if err != nil {
+		return "", err
+	}
+	if err := os.Chmod(staging, 0755); err != nil {
+		os.Remove(staging)
+		return "", err
+	}
+	return staging, nil
+}
+
+// Report returns the summary of the last successful Generate, or nil
+func (g *Generator) Report() *OperationReport {
+	return g.report
+}
+
+// SetInvocation sets the arguments recorded in the project history
+// (default: the process command line)
+func (g *Generator) SetInvocation(args []string) {
+	g.args = args
+}
+
+// SetKeepPartial leaves the staging directory on disk when Generate fails,
+// for debugging; its path is part of the returned error
+func (g *Generator) SetKeepPartial(keep bool) {
+	g.keepPartial = keep
+}
+
+func (g *Generator) invocation() []string {
+	if g.args != nil {
+		return g.args
+	}
+	return defaultInvocation()
+}
+
 // createDirectories creates all necessary directories for the project
 func (g *Generator) createDirectories() error {
 	packagePath := g.config.PackagePath()
@@ -166,6 +321,19 @@ func (g *Generator) createDirectories() error {
 		)
 	}
 
+	// Search module directories
+	if g.config.HasModule(config.ModuleSearch) {
+		searchBase := filepath.Join(g.outDir, config.ModuleSearch, "src", "main", "java", packagePath, "search")
+		searchTestBase := filepath.Join(g.outDir, config.ModuleSearch, "src", "test", "java", packagePath, "search")
+		dirs = append(dirs,
+			filepath.Join(searchBase, "config"),
+			filepath.Join(searchBase, "document"),
+			filepath.Join(searchBase, "repository"),
+			filepath.Join(g.outDir, config.ModuleSearch, "src", "main", "resources"),
+			filepath.Join(searchTestBase, "repository"),
+		)
+	}
+
 	// Shared module directories
 	if g.config.HasModule(config.ModuleShared) {
 		sharedBase := filepath.Join(g.outDir, config.ModuleShared, "src", "main", "java", packagePath, "shared")
@@ -260,6 +428,13 @@ func (g *Generator) createDirectories() error {
 		)
 	}
 
+	// ClientSDK module directories (sources are generated under target/)
+	if g.config.HasModule(config.ModuleClientSDK) {
+		dirs = append(dirs,
+			filepath.Join(g.outDir, config.ModuleClientSDK, "src", "main", "openapi"),
+		)
+	}
+
 	// Create all directories
 	for _, dir := range dirs {
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -270,6 +445,47 @@ func (g *Generator) createDirectories() error {
 	return nil
 }
 
+// generationStep is one independent unit of Generate's file output.
+type generationStep struct {
+	what string // Used in the error, e.g. "API module"
+	run  func() error
+}
+
+// generateFiles renders the parent POM, every module and the documentation
+// in parallel, bounded by GOMAXPROCS. The steps write disjoint files, and
+// os.MkdirAll tolerates concurrent creation of shared parent directories.
+// Every step runs to completion and the error of the earliest failing step
+// (in module order) is returned, so failures are reported the same way
+// regardless of scheduling.
+func (g *Generator) generateFiles() error {
+	steps := []generationStep{{what: "parent pom.xml", run: g.generateParentPOM}}
+	for _, module := range g.config.Modules {
+		steps = append(steps, generationStep{
+			what: module + " module",
+			run:  func() error { return g.generateModule(module) },
+		})
+	}
+	steps = append(steps, generationStep{what: "documentation", run: g.generateDocs})
+
+	errs := make([]error, len(steps))
+	var group errgroup.Group
+	group.SetLimit(runtime.GOMAXPROCS(0))
+	for i, step := range steps {
+		group.Go(func() error {
+			errs[i] = step.run()
+			return nil
+		})
+	}
+	group.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", steps[i].what, err)
+		}
+	}
+	return nil
+}
+
 // generateModule generates all files for a specific module
 func (g *Generator) generateModule(module string) error {
 	switch module {
@@ -281,6 +497,8 @@ func (g *Generator) generateModule(module string) error {
 		return g.generateSQLDatastoreModule()
 	case config.ModuleNoSQLDatastore:
 		return g.generateNoSQLDatastoreModule()
+	case config.ModuleSearch:
+		return g.generateSearchModule()
 	case config.ModuleShared:
 		return g.generateSharedModule()
 	case config.ModuleAPI:
@@ -293,17 +511,13 @@ func (g *Generator) generateModule(module string) error {
 		return g.generateEventConsumerModule()
 	case config.ModuleAIAgent:
 		return g.generateAIAgentModule()
+	case config.ModuleClientSDK:
+		return g.generateClientSDKModule()
 	default:
-		return fmt.Errorf("unknown module: %s", module)
-	}
-}
-
-// cleanup removes the generated directory on error
-func (g *Generator) cleanup() {
-	if g.outDir != "" {
-		if err := os.RemoveAll(g.outDir); err != nil {
-			log.Printf("Warning: failed to cleanup directory %s: %v", g.outDir, err)
+		if p := plugin.Get(module); p != nil {
+			return g.generatePluginModule(p)
 		}
+		return fmt.Errorf("unknown module: %s", module)
 	}
 }
 
@@ -315,6 +529,11 @@ func (g *Generator) writeFile(path string, content string) error {
 		return fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
 
+	if strings.HasSuffix(path, ".java") || strings.HasSuffix(path, ".kt") {
+		comment := strings.ReplaceAll(g.config.LicenseHeaderComment(), config.LicenseYearToken, g.config.LicenseYear())
+		content = withLicenseHeader(comment, content)
+	}
+
 	// Write file
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 		return fmt.Errorf("failed to write file %s: %w", path, err)
@@ -323,6 +542,17 @@ func (g *Generator) writeFile(path string, content string) error {
 	return nil
 }
 
+// withLicenseHeader prepends a license comment to Java source, separated by
+// a newline the way Spotless' licenseHeader step writes it. Files that
+// already begin with the header are left alone so regeneration doesn't
+// stack copies.
+func withLicenseHeader(comment, content string) string {
+	if comment == "" || strings.HasPrefix(content, comment) {
+		return content
+	}
+	return comment + "\n" + content
+}
+
 // templateData wraps ProjectConfig with additional per-render context.
 // Embedding ProjectConfig ensures all existing template calls (e.g. {{.HasModule "API"}}) still work.
 type templateData struct {
@@ -341,6 +571,7 @@ func (g *Generator) renderTemplate(templatePath string) (string, error) {
 
 // writeTemplate renders a template and writes it to a file
 func (g *Generator) writeTemplate(templatePath, outputPath string) error {
+	templatePath, outputPath = g.sourceTemplate(templatePath, outputPath)
 	content, err := g.renderTemplate(templatePath)
 	if err != nil {
 		return fmt.Errorf("failed to render template %s: %w", templatePath, err)
@@ -352,6 +583,7 @@ func (g *Generator) writeTemplate(templatePath, outputPath string) error {
 
 // writeTemplateWithData renders a template with custom data and writes it to a file
 func (g *Generator) writeTemplateWithData(templatePath, outputPath string, data interface{}) error {
+	templatePath, outputPath = g.sourceTemplate(templatePath, outputPath)
 	content, err := g.engine.Execute(templatePath, data)
 	if err != nil {
 		return fmt.Errorf("failed to render template %s: %w", templatePath, err)
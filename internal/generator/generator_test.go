@@ -1,11 +1,18 @@
 package generator
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/todos"
+	"gopkg.in/yaml.v3"
 )
 
 func TestGenerator_Generate_ModelOnly(t *testing.T) {
@@ -556,7 +563,7 @@ func TestGenerator_Generate_GlobalExceptionHandler_DataIntegrity(t *testing.T) {
 			markers := []string{
 				"DataIntegrityViolationException",
 				"DuplicateKeyException",
-				"HttpStatus.CONFLICT",
+				"ErrorCode.DUPLICATE_RESOURCE",
 			}
 			for _, m := range markers {
 				present := contains(src, m)
@@ -1060,3 +1067,1326 @@ func TestGenerator_Generate_ReviewScriptEmittedWithoutCI(t *testing.T) {
 		t.Errorf("ci.yml should NOT be emitted without CIProvider='github'")
 	}
 }
+
+func TestGenerator_Generate_ContainerRuntimeSocketOverride(t *testing.T) {
+	tests := []struct {
+		runtime      string
+		wantOverride bool
+		wantRyukOff  bool
+	}{
+		{"podman", true, true},
+		{"colima", true, false},
+		{"docker-desktop", false, false},
+		{"", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("runtime="+tt.runtime, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName:      "runtime-project",
+				GroupID:          "com.test.runtime",
+				ArtifactID:       "runtime-project",
+				JavaVersion:      "21",
+				Modules:          []string{"Model", "SQLDatastore", "Shared"},
+				Database:         "postgresql",
+				ContainerRuntime: tt.runtime,
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create generator: %v", err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Failed to generate project: %v", err)
+			}
+
+			pom, err := os.ReadFile(filepath.Join("runtime-project", "pom.xml"))
+			if err != nil {
+				t.Fatalf("Failed to read parent POM: %v", err)
+			}
+			content := string(pom)
+			if got := strings.Contains(content, "<TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE>"); got != tt.wantOverride {
+				t.Errorf("socket override present = %v, want %v", got, tt.wantOverride)
+			}
+			if got := strings.Contains(content, "<TESTCONTAINERS_RYUK_DISABLED>"); got != tt.wantRyukOff {
+				t.Errorf("Ryuk disabled present = %v, want %v", got, tt.wantRyukOff)
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_CIRemoteDockerPlumbing(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "remote-docker",
+		GroupID:     "com.test.remote",
+		ArtifactID:  "remote-docker",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+		CIProvider:  "github",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	ci, err := os.ReadFile(filepath.Join("remote-docker", ".github", "workflows", "ci.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read ci.yml: %v", err)
+	}
+	for _, want := range []string{
+		"TC_CLOUD_TOKEN: ${{ secrets.TC_CLOUD_TOKEN }}",
+		"if: ${{ env.TC_CLOUD_TOKEN != '' }}",
+		"atomicjar/testcontainers-cloud-setup-action",
+		"REMOTE_DOCKER_HOST: ${{ vars.DOCKER_HOST }}",
+		"SPRING_DATASOURCE_URL",
+	} {
+		if !strings.Contains(string(ci), want) {
+			t.Errorf("ci.yml should contain %q", want)
+		}
+	}
+
+	env, err := os.ReadFile(filepath.Join("remote-docker", ".env.example"))
+	if err != nil {
+		t.Fatalf("Failed to read .env.example: %v", err)
+	}
+	for _, want := range []string{"# DOCKER_HOST=tcp://", "# TC_CLOUD_TOKEN="} {
+		if !strings.Contains(string(env), want) {
+			t.Errorf(".env.example should contain %q", want)
+		}
+	}
+}
+
+func TestWithLicenseHeader(t *testing.T) {
+	src := "package com.acme;\n"
+	comment := "/*\n * Copyright Acme\n */"
+
+	if got := withLicenseHeader("", src); got != src {
+		t.Errorf("empty header should leave content unchanged, got %q", got)
+	}
+
+	got := withLicenseHeader(comment, src)
+	if want := comment + "\npackage com.acme;\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if again := withLicenseHeader(comment, got); again != got {
+		t.Error("header should not be prepended twice")
+	}
+}
+
+func TestGenerator_Generate_LicenseHeader(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "licensed-project",
+		GroupID:       "com.test.licensed",
+		ArtifactID:    "licensed-project",
+		JavaVersion:   "21",
+		Modules:       []string{"Model"},
+		License:       config.LicenseProprietary,
+		LicenseHeader: "Copyright $YEAR Acme",
+	}
+	year := cfg.LicenseYear()
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	javaFiles := 0
+	filepath.Walk("licensed-project", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		data, readErr := os.ReadFile(path)
+		if readErr != nil || info.IsDir() {
+			return nil
+		}
+		hasHeader := strings.HasPrefix(string(data), "/*\n * Copyright "+year+" Acme\n */\npackage")
+		if strings.HasSuffix(path, ".java") {
+			javaFiles++
+			if !hasHeader {
+				t.Errorf("%s is missing the license header", path)
+			}
+		} else if hasHeader {
+			t.Errorf("%s is not Java but got the license header", path)
+		}
+		return nil
+	})
+	if javaFiles == 0 {
+		t.Fatal("expected generated Java files")
+	}
+
+	meta, err := config.LoadMetadata("licensed-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.License != config.LicenseProprietary || meta.LicenseHeader != "Copyright $YEAR Acme" {
+		t.Errorf("license not persisted in metadata: %q, %q", meta.License, meta.LicenseHeader)
+	}
+
+	license, err := os.ReadFile(filepath.Join("licensed-project", "LICENSE"))
+	if err != nil {
+		t.Fatalf("LICENSE not generated: %v", err)
+	}
+	if string(license) != "Copyright "+year+" Acme\n" {
+		t.Errorf("unexpected LICENSE content: %q", license)
+	}
+
+	pom, _ := os.ReadFile(filepath.Join("licensed-project", "pom.xml"))
+	if !strings.Contains(string(pom), "<licenseHeader>") || !strings.Contains(string(pom), " * Copyright $YEAR Acme") {
+		t.Error("parent POM should configure the Spotless licenseHeader with the $YEAR token")
+	}
+}
+
+func TestGenerator_Generate_LicenseFile(t *testing.T) {
+	for _, license := range []string{config.LicenseApache2, config.LicenseMIT} {
+		t.Run(license, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			id, header, err := config.ResolveLicense(license, "oss-project")
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config.ProjectConfig{
+				ProjectName:   "oss-project",
+				GroupID:       "com.test.oss",
+				ArtifactID:    "oss-project",
+				JavaVersion:   "21",
+				Modules:       []string{"Model"},
+				License:       id,
+				LicenseHeader: header,
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create generator: %v", err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Failed to generate project: %v", err)
+			}
+
+			data, err := os.ReadFile(filepath.Join("oss-project", "LICENSE"))
+			if err != nil {
+				t.Fatalf("LICENSE not generated: %v", err)
+			}
+			want := map[string]string{
+				config.LicenseApache2: "Apache License",
+				config.LicenseMIT:     "MIT License",
+			}[license]
+			if !strings.Contains(string(data), want) {
+				t.Errorf("LICENSE should contain %q", want)
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_ImageRegistry(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "orders",
+		GroupID:       "com.test.orders",
+		ArtifactID:    "orders",
+		JavaVersion:   "21",
+		Modules:       config.ResolveDependencies([]string{"Model", "API"}),
+		ImageRegistry: "ghcr.io/acme",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join("orders", "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"docker build -f API/Dockerfile -t ghcr.io/acme/orders-api .",
+		"docker push ghcr.io/acme/orders-api",
+	} {
+		if !strings.Contains(string(readme), want) {
+			t.Errorf("README.md should contain %q", want)
+		}
+	}
+}
+
+func TestGenerator_Generate_Observability(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "observed-project",
+		GroupID:       "com.test.observed",
+		ArtifactID:    "observed-project",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "Shared", "API", "Jobs", "Worker"},
+		Observability: true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("observed-project", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	compose := read("docker-compose.yml")
+	for _, s := range []string{"prometheus:", "grafana:", "tempo:", `profiles: ["observability"]`} {
+		if !strings.Contains(compose, s) {
+			t.Errorf("docker-compose.yml missing %q", s)
+		}
+	}
+
+	prom := read("observability/prometheus/prometheus.yml")
+	for _, s := range []string{"job_name: api", "host.docker.internal:8080", "job_name: worker", "host.docker.internal:8081"} {
+		if !strings.Contains(prom, s) {
+			t.Errorf("prometheus.yml missing %q", s)
+		}
+	}
+
+	var dashboard map[string]interface{}
+	raw := read("observability/grafana/dashboards/overview.json")
+	if err := json.Unmarshal([]byte(raw), &dashboard); err != nil {
+		t.Fatalf("dashboard is not valid JSON: %v", err)
+	}
+	if dashboard["title"] != "observed-project overview" || !strings.Contains(raw, `"legendFormat": "{{module}}"`) {
+		t.Error("dashboard should be titled after the project and keep Grafana legend placeholders")
+	}
+
+	read("observability/tempo/tempo.yaml")
+	read("observability/grafana/provisioning/datasources/datasources.yml")
+	read("observability/grafana/provisioning/dashboards/dashboards.yml")
+
+	apiYml := read("API/src/main/resources/application.yml")
+	if !strings.Contains(apiYml, "${MANAGEMENT_ENDPOINTS:health,info,prometheus}") || !strings.Contains(apiYml, "${OTEL_TRACES_EXPORTER:otlp}") {
+		t.Error("API application.yml should expose prometheus and export traces over OTLP")
+	}
+}
+
+func TestGenerator_Generate_ObservabilityOff(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "plain-project",
+		GroupID:     "com.test.plain",
+		ArtifactID:  "plain-project",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join("plain-project", "observability")); !os.IsNotExist(err) {
+		t.Error("observability/ should not be generated by default")
+	}
+	apiYml, _ := os.ReadFile(filepath.Join("plain-project", "API", "src", "main", "resources", "application.yml"))
+	if !strings.Contains(string(apiYml), "${MANAGEMENT_ENDPOINTS:health,info}") || !strings.Contains(string(apiYml), "${OTEL_TRACES_EXPORTER:none}") {
+		t.Error("defaults should be unchanged without observability")
+	}
+}
+
+func TestGenerator_Generate_ClientSDK(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []string
+		idType  string
+	}{
+		{"sql", []string{"SQLDatastore", "ClientSDK"}, "integer"},
+		{"mongodb", []string{"NoSQLDatastore", "ClientSDK"}, "string"},
+		{"no datastore", []string{"ClientSDK"}, "string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName:   "orders",
+				GroupID:       "com.test.orders",
+				ArtifactID:    "orders",
+				JavaVersion:   "21",
+				Modules:       config.ResolveDependencies(tt.modules),
+				Database:      "postgresql",
+				NoSQLDatabase: "mongodb",
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create generator: %v", err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Failed to generate project: %v", err)
+			}
+
+			for _, m := range []string{"API", "Shared"} {
+				if !cfg.HasModule(m) {
+					t.Errorf("ClientSDK should pull in %s, got %v", m, cfg.Modules)
+				}
+			}
+
+			parent, err := os.ReadFile(filepath.Join("orders", "pom.xml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"<module>ClientSDK</module>", "<openapi-generator.version>"} {
+				if !strings.Contains(string(parent), want) {
+					t.Errorf("parent pom.xml should contain %q", want)
+				}
+			}
+
+			pom, err := os.ReadFile(filepath.Join("orders", "ClientSDK", "pom.xml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{
+				"<artifactId>openapi-generator-maven-plugin</artifactId>",
+				"<apiPackage>com.test.orders.clientsdk.api</apiPackage>",
+				"<id>refresh-openapi</id>",
+			} {
+				if !strings.Contains(string(pom), want) {
+					t.Errorf("ClientSDK/pom.xml should contain %q", want)
+				}
+			}
+
+			data, err := os.ReadFile(filepath.Join("orders", "ClientSDK", "src", "main", "openapi", "openapi.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var spec struct {
+				Paths map[string]map[string]struct {
+					Parameters []struct {
+						Schema struct {
+							Type string `json:"type"`
+						} `json:"schema"`
+					} `json:"parameters"`
+				} `json:"paths"`
+			}
+			if err := json.Unmarshal(data, &spec); err != nil {
+				t.Fatalf("openapi.json is not valid JSON: %v\n%s", err, data)
+			}
+			get := spec.Paths["/api/placeholders/{id}"]["get"]
+			if len(get.Parameters) != 1 || get.Parameters[0].Schema.Type != tt.idType {
+				t.Errorf("id parameter should be %s, got %+v", tt.idType, get.Parameters)
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_TodoIndex(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "todo-app",
+		GroupID:     "com.test.todoapp",
+		ArtifactID:  "todo-app",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	idx, err := todos.LoadIndex("todo-app")
+	if err != nil || idx == nil {
+		t.Fatalf("expected %s: %v", todos.IndexFileName, err)
+	}
+	found := false
+	for _, it := range idx.Items {
+		if it.Module == "Shared" && strings.HasPrefix(it.Text, "No datastore module included") {
+			found = true
+		}
+		if !it.Generated {
+			t.Errorf("indexed TODOs are generated: %+v", it)
+		}
+	}
+	if !found {
+		t.Errorf("the index should list the no-datastore TODO of the Shared service: %+v", idx.Items)
+	}
+}
+
+func TestGenerator_Generate_SpotlessRatchet(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:         "ratcheted",
+		GroupID:             "com.test.ratcheted",
+		ArtifactID:          "ratcheted",
+		JavaVersion:         "21",
+		Modules:             []string{"Model", "Shared", "API"},
+		CIProvider:          "github",
+		SpotlessRatchetFrom: "abc123",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	pom, _ := os.ReadFile(filepath.Join("ratcheted", "pom.xml"))
+	for _, want := range []string{"<spotless.ratchetFrom>abc123</spotless.ratchetFrom>", "<ratchetFrom>${spotless.ratchetFrom}</ratchetFrom>"} {
+		if !strings.Contains(string(pom), want) {
+			t.Errorf("parent pom.xml should contain %q", want)
+		}
+	}
+	ci, _ := os.ReadFile(filepath.Join("ratcheted", ".github", "workflows", "ci.yml"))
+	for _, want := range []string{"fetch-depth: 0", "-Dspotless.ratchetFrom=$RATCHET_BASE"} {
+		if !strings.Contains(string(ci), want) {
+			t.Errorf("ci.yml should contain %q", want)
+		}
+	}
+}
+
+func TestGenerator_Generate_CoverageGates(t *testing.T) {
+	for _, gated := range []bool{true, false} {
+		outDir := filepath.Join(t.TempDir(), "covered")
+		cfg := &config.ProjectConfig{
+			ProjectName:     "covered",
+			GroupID:         "com.test.covered",
+			ArtifactID:      "covered",
+			JavaVersion:     "21",
+			Modules:         []string{"Model", "Shared", "API"},
+			CIProvider:      "github",
+			NoCoverageGates: !gated,
+		}
+		gen, err := NewWithVersionAt(cfg, "test", outDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		pom, _ := os.ReadFile(filepath.Join(outDir, "pom.xml"))
+		if !strings.Contains(string(pom), "<exclude>**/Immutable*.class</exclude>") {
+			t.Error("parent pom.xml should exclude Immutables classes from coverage")
+		}
+		model, _ := os.ReadFile(filepath.Join(outDir, "Model", "pom.xml"))
+		if !strings.Contains(string(pom), "<exclude>**/*Config.class</exclude>") {
+			t.Error("parent pom.xml should exclude Spring configuration classes from coverage")
+		}
+		api, _ := os.ReadFile(filepath.Join(outDir, "API", "pom.xml"))
+		if strings.Contains(string(api), "<exclude>**/config/**</exclude>") {
+			t.Error("API pom.xml should not exclude its whole config package from coverage")
+		}
+		ci, _ := os.ReadFile(filepath.Join(outDir, ".github", "workflows", "ci.yml"))
+		if !strings.Contains(string(ci), "GITHUB_STEP_SUMMARY") {
+			t.Error("ci.yml should write a coverage summary")
+		}
+
+		checks := map[string]bool{
+			"parent check execution": strings.Contains(string(pom), "<id>coverage-check</id>"),
+			"parent minimums":        strings.Contains(string(pom), "<jacoco.minimum.line>0.60</jacoco.minimum.line>"),
+			"Model override":         strings.Contains(string(model), "<jacoco.minimum.line>0.30</jacoco.minimum.line>"),
+			"CI check step":          strings.Contains(string(ci), "mvn jacoco:check@coverage-check"),
+		}
+		for name, present := range checks {
+			if present != gated {
+				t.Errorf("gated=%v: %s present=%v", gated, name, present)
+			}
+		}
+	}
+}
+
+func TestGenerator_Generate_ErrorProne(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "analyzed")
+	cfg := &config.ProjectConfig{
+		ProjectName:    "analyzed",
+		GroupID:        "com.test.analyzed",
+		ArtifactID:     "analyzed",
+		JavaVersion:    "21",
+		Modules:        []string{"Model", "Shared", "API"},
+		StaticAnalysis: config.StaticAnalysisErrorProne,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	pom, _ := os.ReadFile(filepath.Join(outDir, "pom.xml"))
+	for _, want := range []string{
+		"<artifactId>error_prone_core</artifactId>",
+		"<artifactId>nullaway</artifactId>",
+		"-XepOpt:NullAway:AnnotatedPackages=com.test.analyzed",
+		"-Xplugin:ErrorProne ${errorprone.args} ${nullaway.args}",
+		"-Xep:NullAway:OFF",
+	} {
+		if !strings.Contains(string(pom), want) {
+			t.Errorf("parent pom.xml should contain %q", want)
+		}
+	}
+	model, _ := os.ReadFile(filepath.Join(outDir, "Model", "pom.xml"))
+	if !strings.Contains(string(model), `<annotationProcessorPaths combine.children="append">`) {
+		t.Error("Model pom.xml should append Immutables to the inherited processors")
+	}
+	jvmConfig, err := os.ReadFile(filepath.Join(outDir, ".mvn", "jvm.config"))
+	if err != nil || !strings.Contains(string(jvmConfig), "jdk.compiler/com.sun.tools.javac.api") {
+		t.Errorf(".mvn/jvm.config should export javac internals: %v", err)
+	}
+}
+
+func TestGenerator_Generate_NoStaticAnalysis(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "plain")
+	cfg := &config.ProjectConfig{
+		ProjectName: "plain",
+		GroupID:     "com.test.plain",
+		ArtifactID:  "plain",
+		JavaVersion: "21",
+		Modules:     []string{"Model"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	pom, _ := os.ReadFile(filepath.Join(outDir, "pom.xml"))
+	if strings.Contains(string(pom), "errorprone") {
+		t.Error("parent pom.xml should not configure Error Prone by default")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, ".mvn", "jvm.config")); !os.IsNotExist(err) {
+		t.Error(".mvn/jvm.config should only be generated with Error Prone")
+	}
+}
+
+func TestGenerator_Generate_ReportsEarliestFailure(t *testing.T) {
+	// Break two modules' POM templates; whichever goroutine fails first,
+	// the error must name the module that comes first in the selection.
+	overrides := t.TempDir()
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", overrides)
+	for _, name := range []string{"model.xml.tmpl", "worker.xml.tmpl"} {
+		path := filepath.Join(overrides, "pom", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{{.Broken"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		parent := t.TempDir()
+		outDir := filepath.Join(parent, "shop")
+		cfg := &config.ProjectConfig{
+			ProjectName: "shop",
+			GroupID:     "com.acme.shop",
+			ArtifactID:  "shop",
+			JavaVersion: "21",
+			Modules:     []string{"Model", "Jobs", "Shared", "API", "Worker"},
+			Database:    "postgresql",
+		}
+
+		gen, err := NewWithVersionAt(cfg, "test", outDir)
+		if err != nil {
+			t.Fatalf("Failed to create generator: %v", err)
+		}
+
+		err = gen.Generate()
+		if err == nil || !strings.HasPrefix(err.Error(), "failed to generate Model module:") {
+			t.Fatalf("run %d: expected the Model module failure, got %v", i, err)
+		}
+		if entries, _ := os.ReadDir(parent); len(entries) != 0 {
+			t.Fatalf("run %d: nothing should be left after a failure, found %s", i, entries[0].Name())
+		}
+	}
+}
+
+func TestGenerator_Generate_RootMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permissions")
+	}
+	projectPath := generateTestProject(t, &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.acme.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model"},
+	})
+	info, err := os.Stat(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0755 {
+		t.Errorf("project root should be 0755 like its subdirectories, got %o", mode)
+	}
+}
+
+func TestGenerator_Generate_KeepPartial(t *testing.T) {
+	overrides := t.TempDir()
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", overrides)
+	path := filepath.Join(overrides, "pom", "api.xml.tmpl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{{.Broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	parent := t.TempDir()
+	outDir := filepath.Join(parent, "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.acme.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen.SetKeepPartial(true)
+
+	err = gen.Generate()
+	if err == nil {
+		t.Fatal("expected the API module to fail")
+	}
+	if _, statErr := os.Stat(outDir); !os.IsNotExist(statErr) {
+		t.Error("a failed run must not create the output directory")
+	}
+	entries, _ := os.ReadDir(parent)
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Name(), ".shop.staging-") {
+		t.Fatalf("expected one staging directory, got %v", entries)
+	}
+	staging := filepath.Join(parent, entries[0].Name())
+	if !strings.Contains(err.Error(), staging) {
+		t.Errorf("error should name the kept staging directory: %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(staging, "Model", "pom.xml")); statErr != nil {
+		t.Error("files rendered before the failure should be kept")
+	}
+}
+
+func TestGenerator_Generate_ProgressAndCancel(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.acme.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+
+	parent := t.TempDir()
+	gen, err := NewWithVersionAt(cfg, "test", filepath.Join(parent, "shop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stages []string
+	gen.SetProgress(func(stage string, done, total int) {
+		if done != len(stages) || total != len(generateStages) {
+			t.Errorf("stage %s reported %d of %d", stage, done, total)
+		}
+		stages = append(stages, stage)
+	})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Join(stages, ",") != strings.Join(generateStages, ",") {
+		t.Errorf("stages = %v, want %v", stages, generateStages)
+	}
+
+	// Cancelled after the files stage: the run fails and leaves nothing.
+	parent = t.TempDir()
+	gen, err = NewWithVersionAt(cfg, "test", filepath.Join(parent, "shop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gen.SetContext(ctx)
+	gen.SetProgress(func(stage string, done, total int) {
+		if stage == "files" {
+			cancel()
+		}
+	})
+	if err := gen.Generate(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if entries, _ := os.ReadDir(parent); len(entries) != 0 {
+		t.Errorf("a cancelled run should leave nothing, found %s", entries[0].Name())
+	}
+}
+
+func TestGenerator_Generate_LightweightBrokers(t *testing.T) {
+	tests := []struct {
+		broker        string
+		nosql         string
+		configFile    string
+		publisherFile string
+		services      []string
+		pomDeps       []string
+	}{
+		{
+			broker:     config.BrokerRedisStreams,
+			nosql:      config.DatabaseRedis,
+			configFile: "RedisStreamConfig.java",
+			services:   []string{"redis"},
+			pomDeps:    []string{"spring-boot-starter-data-redis"},
+		},
+		{
+			broker:        config.BrokerNATS,
+			configFile:    "NatsConfig.java",
+			publisherFile: "NatsPublisherConfig.java",
+			services:      []string{"nats"},
+			pomDeps:       []string{"<artifactId>jnats</artifactId>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.broker, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			selected := []string{"Model", "API", "EventConsumer"}
+			if tt.nosql != "" {
+				selected = append(selected, "NoSQLDatastore")
+			}
+			cfg := &config.ProjectConfig{
+				ProjectName:   "light-events",
+				GroupID:       "com.test.lightevents",
+				ArtifactID:    "light-events",
+				JavaVersion:   "21",
+				Modules:       config.ResolveDependencies(selected),
+				NoSQLDatabase: tt.nosql,
+				MessageBroker: tt.broker,
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create generator: %v", err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Failed to generate project: %v", err)
+			}
+
+			read := func(path string) string {
+				t.Helper()
+				data, err := os.ReadFile(filepath.Join("light-events", path))
+				if err != nil {
+					t.Fatalf("expected %s: %v", path, err)
+				}
+				return string(data)
+			}
+			pkg := "src/main/java/com/test/lightevents"
+
+			read(filepath.Join("EventConsumer", pkg, "eventconsumer/config", tt.configFile))
+			if tt.publisherFile != "" {
+				read(filepath.Join("Events", pkg, "events/config", tt.publisherFile))
+			}
+			listener := read(filepath.Join("EventConsumer", pkg, "eventconsumer/listener/PlaceholderEventListener.java"))
+			if !strings.Contains(listener, "public void handlePlaceholderEvent(PlaceholderEvent event)") {
+				t.Error("listener should expose a broker-free handlePlaceholderEvent")
+			}
+
+			var compose struct {
+				Services map[string]interface{} `yaml:"services"`
+			}
+			if err := yaml.Unmarshal([]byte(read("docker-compose.yml")), &compose); err != nil {
+				t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+			}
+			for _, svc := range tt.services {
+				if _, ok := compose.Services[svc]; !ok {
+					t.Errorf("docker-compose.yml missing service %q", svc)
+				}
+			}
+
+			for _, module := range []string{"API", "EventConsumer"} {
+				var doc map[string]interface{}
+				if err := yaml.Unmarshal([]byte(read(filepath.Join(module, "src/main/resources/application.yml"))), &doc); err != nil {
+					t.Errorf("%s application.yml is not valid YAML: %v", module, err)
+				}
+			}
+
+			for _, pom := range []string{"Events/pom.xml", "EventConsumer/pom.xml"} {
+				content := read(pom)
+				for _, dep := range tt.pomDeps {
+					if !strings.Contains(content, dep) {
+						t.Errorf("%s missing %s", pom, dep)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_DynamoDBAndCassandra(t *testing.T) {
+	tests := []struct {
+		nosql    string
+		service  string
+		pomDep   string
+		repoType string
+	}{
+		{
+			nosql:    config.DatabaseDynamoDB,
+			service:  "dynamodb",
+			pomDep:   "spring-cloud-aws-starter-dynamodb",
+			repoType: "public class PlaceholderDocumentRepository implements CrudRepository<PlaceholderDocument, String>",
+		},
+		{
+			nosql:    config.DatabaseCassandra,
+			service:  "cassandra",
+			pomDep:   "spring-boot-starter-data-cassandra",
+			repoType: "extends CassandraRepository<PlaceholderDocument, String>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nosql, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName:   "wide-store",
+				GroupID:       "com.test.widestore",
+				ArtifactID:    "wide-store",
+				JavaVersion:   "21",
+				Modules:       config.ResolveDependencies([]string{"Model", "NoSQLDatastore", "Shared", "API", "Worker", "EventConsumer"}),
+				NoSQLDatabase: tt.nosql,
+				MessageBroker: config.BrokerSQS,
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create generator: %v", err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Failed to generate project: %v", err)
+			}
+
+			read := func(path string) string {
+				t.Helper()
+				data, err := os.ReadFile(filepath.Join("wide-store", path))
+				if err != nil {
+					t.Fatalf("expected %s: %v", path, err)
+				}
+				return string(data)
+			}
+			pkg := "src/main/java/com/test/widestore"
+
+			repo := read(filepath.Join("NoSQLDatastore", pkg, "nosqldatastore/repository/PlaceholderDocumentRepository.java"))
+			if !strings.Contains(repo, tt.repoType) {
+				t.Errorf("repository should contain %q", tt.repoType)
+			}
+			if !strings.Contains(read("NoSQLDatastore/pom.xml"), tt.pomDep) {
+				t.Errorf("NoSQLDatastore/pom.xml missing %s", tt.pomDep)
+			}
+
+			var compose struct {
+				Services map[string]interface{} `yaml:"services"`
+			}
+			if err := yaml.Unmarshal([]byte(read("docker-compose.yml")), &compose); err != nil {
+				t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+			}
+			// JobRunr has no storage provider for either database
+			for _, svc := range []string{tt.service, "postgres-jobrunr", "localstack"} {
+				if _, ok := compose.Services[svc]; !ok {
+					t.Errorf("docker-compose.yml missing service %q", svc)
+				}
+			}
+
+			// yaml.v3 rejects duplicate keys, which catches app: blocks
+			// from the broker and the datastore colliding.
+			for _, module := range []string{"API", "Worker", "EventConsumer", "NoSQLDatastore"} {
+				var doc map[string]interface{}
+				if err := yaml.Unmarshal([]byte(read(filepath.Join(module, "src/main/resources/application.yml"))), &doc); err != nil {
+					t.Errorf("%s application.yml is not valid YAML: %v", module, err)
+				}
+			}
+			for _, env := range []string{"dev", "staging", "prod"} {
+				var doc map[string]interface{}
+				if err := yaml.Unmarshal([]byte(read(filepath.Join("API", "src/main/resources", "application-"+env+".yml"))), &doc); err != nil {
+					t.Errorf("API application-%s.yml is not valid YAML: %v", env, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_Search(t *testing.T) {
+	tests := []struct {
+		name     string
+		modules  []string
+		database string
+		nosql    string
+		broker   string
+		deleteID string
+	}{
+		{
+			name:     "sql",
+			modules:  []string{"Model", "SQLDatastore", "Search", "API", "Worker", "Events"},
+			database: config.DatabasePostgreSQL,
+			broker:   config.BrokerNATS,
+			deleteID: "searchService.delete(String.valueOf(id))",
+		},
+		{
+			name:     "nosql",
+			modules:  []string{"Model", "NoSQLDatastore", "Search", "API", "Worker"},
+			nosql:    config.DatabaseDynamoDB,
+			deleteID: "searchService.delete(documentId)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName:   "find-it",
+				GroupID:       "com.test.findit",
+				ArtifactID:    "find-it",
+				JavaVersion:   "21",
+				Modules:       config.ResolveDependencies(tt.modules),
+				Database:      tt.database,
+				NoSQLDatabase: tt.nosql,
+				MessageBroker: tt.broker,
+			}
+			if !cfg.HasModule(config.ModuleShared) {
+				t.Fatal("Search should pull in Shared")
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create generator: %v", err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Failed to generate project: %v", err)
+			}
+
+			read := func(path string) string {
+				t.Helper()
+				data, err := os.ReadFile(filepath.Join("find-it", path))
+				if err != nil {
+					t.Fatalf("expected %s: %v", path, err)
+				}
+				return string(data)
+			}
+			pkg := "src/main/java/com/test/findit"
+
+			for _, f := range []string{
+				"search/config/SearchConfig.java",
+				"search/document/PlaceholderSearchDocument.java",
+				"search/repository/PlaceholderSearchRepository.java",
+			} {
+				read(filepath.Join("Search", pkg, f))
+			}
+			read("Search/src/test/java/com/test/findit/search/repository/PlaceholderSearchRepositoryTest.java")
+			read("Shared/src/test/java/com/test/findit/shared/service/SearchServiceTest.java")
+
+			if !strings.Contains(read("Search/pom.xml"), "spring-data-opensearch-starter") {
+				t.Error("Search/pom.xml missing spring-data-opensearch-starter")
+			}
+			if !strings.Contains(read("Shared/pom.xml"), "<artifactId>Search</artifactId>") {
+				t.Error("Shared/pom.xml missing the Search dependency")
+			}
+			if !strings.Contains(read("pom.xml"), "<spring-data-opensearch.version>") {
+				t.Error("parent pom.xml missing spring-data-opensearch.version")
+			}
+
+			service := read(filepath.Join("Shared", pkg, "shared/service/PlaceholderService.java"))
+			for _, want := range []string{"private final SearchService searchService;", "searchService.index(", tt.deleteID} {
+				if !strings.Contains(service, want) {
+					t.Errorf("PlaceholderService.java should contain %q", want)
+				}
+			}
+			if !strings.Contains(read(filepath.Join("API", pkg, "api/FindItApiApplication.java")), `"com.test.findit.search"`) {
+				t.Error("API application should scan the search package")
+			}
+
+			var compose struct {
+				Services map[string]interface{} `yaml:"services"`
+			}
+			if err := yaml.Unmarshal([]byte(read("docker-compose.yml")), &compose); err != nil {
+				t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+			}
+			if _, ok := compose.Services["opensearch"]; !ok {
+				t.Error("docker-compose.yml missing service \"opensearch\"")
+			}
+
+			// yaml.v3 rejects duplicate keys, which catches the app: and
+			// spring: blocks of Search colliding with the other modules'.
+			for _, module := range []string{"API", "Worker", "Search"} {
+				var doc map[string]interface{}
+				if err := yaml.Unmarshal([]byte(read(filepath.Join(module, "src/main/resources/application.yml"))), &doc); err != nil {
+					t.Errorf("%s application.yml is not valid YAML: %v", module, err)
+				}
+			}
+			for _, module := range []string{"API", "Worker"} {
+				for _, env := range []string{"dev", "staging", "prod"} {
+					var doc map[string]interface{}
+					if err := yaml.Unmarshal([]byte(read(filepath.Join(module, "src/main/resources", "application-"+env+".yml"))), &doc); err != nil {
+						t.Errorf("%s application-%s.yml is not valid YAML: %v", module, env, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_Cache(t *testing.T) {
+	tests := []struct {
+		name       string
+		cache      string
+		modules    []string
+		database   string
+		nosql      string
+		sharedDeps []string
+		cacheable  string
+		redis      bool
+	}{
+		{
+			name:       "caffeine",
+			cache:      config.CacheCaffeine,
+			modules:    []string{"Model", "SQLDatastore", "Shared", "API", "Worker"},
+			database:   config.DatabasePostgreSQL,
+			sharedDeps: []string{"spring-boot-starter-cache", "<artifactId>caffeine</artifactId>"},
+			cacheable:  "public Optional<ImmutablePlaceholder> findById(Long id)",
+		},
+		{
+			name:       "redis",
+			cache:      config.CacheRedis,
+			modules:    []string{"Model", "NoSQLDatastore", "Shared", "API", "Worker", "AIAgent"},
+			nosql:      config.DatabaseMongoDB,
+			sharedDeps: []string{"spring-boot-starter-cache", "spring-boot-starter-data-redis", "jackson-datatype-jsr310"},
+			cacheable:  "public Optional<ImmutablePlaceholder> findByDocumentId(String documentId)",
+			redis:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName:   "cached",
+				GroupID:       "com.test.cached",
+				ArtifactID:    "cached",
+				JavaVersion:   "21",
+				Modules:       config.ResolveDependencies(tt.modules),
+				Database:      tt.database,
+				NoSQLDatabase: tt.nosql,
+				Cache:         tt.cache,
+			}
+			if msg := cfg.ResolveCache(); msg != "" {
+				t.Fatalf("ResolveCache: %s", msg)
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create generator: %v", err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Failed to generate project: %v", err)
+			}
+
+			read := func(path string) string {
+				t.Helper()
+				data, err := os.ReadFile(filepath.Join("cached", path))
+				if err != nil {
+					t.Fatalf("expected %s: %v", path, err)
+				}
+				return string(data)
+			}
+			pkg := "src/main/java/com/test/cached"
+
+			cacheConfig := read(filepath.Join("Shared", pkg, "shared/config/CacheConfig.java"))
+			manager := "CaffeineCacheManager"
+			if tt.redis {
+				manager = "RedisCacheManager"
+			}
+			if !strings.Contains(cacheConfig, "@EnableCaching") || !strings.Contains(cacheConfig, manager) {
+				t.Errorf("CacheConfig.java should enable caching with %s", manager)
+			}
+
+			sharedPom := read("Shared/pom.xml")
+			for _, dep := range tt.sharedDeps {
+				if !strings.Contains(sharedPom, dep) {
+					t.Errorf("Shared/pom.xml missing %s", dep)
+				}
+			}
+
+			service := read(filepath.Join("Shared", pkg, "shared/service/PlaceholderService.java"))
+			for _, want := range []string{
+				"@Cacheable(cacheNames = CacheConfig.PLACEHOLDERS, unless = \"#result == null\")\n  " + tt.cacheable,
+				"@CachePut(cacheNames = CacheConfig.PLACEHOLDERS",
+				"@CacheEvict(cacheNames = CacheConfig.PLACEHOLDERS",
+			} {
+				if !strings.Contains(service, want) {
+					t.Errorf("PlaceholderService.java should contain %q", want)
+				}
+			}
+
+			var compose struct {
+				Services map[string]interface{} `yaml:"services"`
+			}
+			if err := yaml.Unmarshal([]byte(read("docker-compose.yml")), &compose); err != nil {
+				t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+			}
+			if _, ok := compose.Services["redis"]; ok != tt.redis {
+				t.Errorf("docker-compose.yml redis service present = %v, want %v", ok, tt.redis)
+			}
+
+			// Every runtime module scans Shared's CacheConfig, so each one
+			// carries the spring.cache settings (and the Redis connection
+			// for redis) without duplicating its spring: or data: keys.
+			for _, module := range cfg.Modules {
+				if module != config.ModuleAPI && module != config.ModuleWorker && module != config.ModuleAIAgent {
+					continue
+				}
+				var doc struct {
+					Spring struct {
+						Cache map[string]interface{} `yaml:"cache"`
+						Data  map[string]interface{} `yaml:"data"`
+					} `yaml:"spring"`
+				}
+				if err := yaml.Unmarshal([]byte(read(filepath.Join(module, "src/main/resources/application.yml"))), &doc); err != nil {
+					t.Fatalf("%s application.yml is not valid YAML: %v", module, err)
+				}
+				if _, ok := doc.Spring.Cache[tt.cache]; !ok {
+					t.Errorf("%s application.yml missing spring.cache.%s", module, tt.cache)
+				}
+				if _, ok := doc.Spring.Data["redis"]; tt.redis && !ok {
+					t.Errorf("%s application.yml missing spring.data.redis", module)
+				}
+				for _, env := range []string{"dev", "staging", "prod"} {
+					var profile map[string]interface{}
+					if err := yaml.Unmarshal([]byte(read(filepath.Join(module, "src/main/resources", "application-"+env+".yml"))), &profile); err != nil {
+						t.Errorf("%s application-%s.yml is not valid YAML: %v", module, env, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_GeminiAiderZed(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "agents")
+	cfg := &config.ProjectConfig{
+		ProjectName: "agents",
+		GroupID:     "com.test.agents",
+		ArtifactID:  "agents",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+		AIAgents:    []string{"gemini", "aider", "zed"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, file := range []string{"GEMINI.md", "CONVENTIONS.md", ".rules"} {
+		content, err := os.ReadFile(filepath.Join(projectPath, file))
+		if err != nil {
+			t.Fatalf("%s should be generated: %v", file, err)
+		}
+		if !strings.HasPrefix(string(content), "<!-- trabuco:begin overview -->\n# agents\n") || !strings.Contains(string(content), ".ai/prompts/JAVA_CODE_QUALITY.md") {
+			t.Errorf("%s should hold the full project context", file)
+		}
+	}
+	for _, file := range []string{"AGENTS.md", "CLAUDE.md"} {
+		_, err := os.Stat(filepath.Join(projectPath, file))
+		if want := file == "AGENTS.md"; (err == nil) != want {
+			t.Errorf("%s generated = %v, want %v", file, err == nil, want)
+		}
+	}
+
+	aiderConf, err := os.ReadFile(filepath.Join(projectPath, ".aider.conf.yml"))
+	if err != nil {
+		t.Fatalf(".aider.conf.yml should be generated: %v", err)
+	}
+	var conf map[string]any
+	if err := yaml.Unmarshal(aiderConf, &conf); err != nil {
+		t.Fatalf(".aider.conf.yml should be valid YAML: %v", err)
+	}
+	if read, _ := conf["read"].([]any); len(read) != 1 || read[0] != "CONVENTIONS.md" {
+		t.Errorf(".aider.conf.yml should read CONVENTIONS.md, got %v", conf["read"])
+	}
+	gitignore, _ := os.ReadFile(filepath.Join(projectPath, ".gitignore"))
+	if !strings.Contains(string(gitignore), ".aider.chat.history.md") {
+		t.Error(".gitignore should list the Aider chat history")
+	}
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewModuleAdder(projectPath, metadata, "test", false).Add(config.ModuleWorker, "", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	for _, file := range []string{"GEMINI.md", "CONVENTIONS.md", ".rules"} {
+		content, _ := os.ReadFile(filepath.Join(projectPath, file))
+		if !strings.Contains(string(content), "cd Worker && mvn spring-boot:run") {
+			t.Errorf("%s should be regenerated with the Worker module", file)
+		}
+	}
+}
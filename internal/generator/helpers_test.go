@@ -0,0 +1,34 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// generateTestProject generates cfg into a temporary directory and returns
+// the project path.
+func generateTestProject(t *testing.T, cfg *config.ProjectConfig) string {
+	t.Helper()
+	projectPath := filepath.Join(t.TempDir(), cfg.ProjectName)
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	return projectPath
+}
+
+// readFile returns the content of rel, a slash-separated path under root.
+func readFile(t *testing.T, root, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// HistoryRecorder snapshots a project before a mutating command so the files
+// it touched can be appended to .trabuco/history.jsonl afterwards. init and
+// add record their own entries; other commands (doctor --fix, sync --apply,
+// add entity, ...) wrap their work in a recorder.
+type HistoryRecorder struct {
+	root      string
+	operation string
+	before    treeSnapshot
+}
+
+// NewHistoryRecorder snapshots the project at root before operation runs
+func NewHistoryRecorder(root, operation string) *HistoryRecorder {
+	return &HistoryRecorder{root: root, operation: operation, before: snapshotTree(root)}
+}
+
+// Record appends a history entry for the files changed since the snapshot.
+// Operations that changed nothing are not recorded.
+func (h *HistoryRecorder) Record(version string, args []string) error {
+	after := snapshotTree(h.root)
+	entry := config.HistoryEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Version:   version,
+		Operation: h.operation,
+		Args:      args,
+	}
+	for path, hash := range after {
+		old, existed := h.before[path]
+		switch {
+		case !existed:
+			entry.FilesCreated = append(entry.FilesCreated, path)
+		case old != hash:
+			entry.FilesModified = append(entry.FilesModified, path)
+		}
+	}
+	for path := range h.before {
+		if _, ok := after[path]; !ok {
+			entry.FilesDeleted = append(entry.FilesDeleted, path)
+		}
+	}
+	if entry.FilesTouched() == 0 {
+		return nil
+	}
+	sort.Strings(entry.FilesCreated)
+	sort.Strings(entry.FilesModified)
+	sort.Strings(entry.FilesDeleted)
+	return config.AppendHistory(h.root, entry)
+}
+
+// HistoryEntry converts the report of an init or add into a history entry
+func (r *OperationReport) HistoryEntry(version string, args []string) config.HistoryEntry {
+	entry := config.HistoryEntry{
+		Timestamp:     r.Timestamp,
+		Version:       version,
+		Operation:     r.Operation,
+		Args:          args,
+		Modules:       r.Modules,
+		FilesModified: r.FilesModified,
+	}
+	for _, m := range r.FilesCreated {
+		entry.FilesCreated = append(entry.FilesCreated, m.Files...)
+	}
+	sort.Strings(entry.FilesCreated)
+	return entry
+}
+
+// defaultInvocation is the command line recorded in the history when the
+// caller did not supply one (MCP tools pass their own arguments)
+func defaultInvocation() []string {
+	if len(os.Args) < 2 {
+		return nil
+	}
+	return append([]string(nil), os.Args[1:]...)
+}
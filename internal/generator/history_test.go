@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestHistory_InitAddAndRecorder(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "history-app",
+		GroupID:     "com.test.history",
+		ArtifactID:  "history-app",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := NewWithVersion(cfg, "1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen.SetInvocation([]string{"init", "--name=history-app"})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "history-app")
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adder := NewModuleAdder(projectPath, metadata, "1.2.3", false)
+	adder.SetInvocation([]string{"add", "SQLDatastore"})
+	if err := adder.Add(config.ModuleSQLDatastore, config.DatabasePostgreSQL, "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	// A wrapped command: one file changed, one created, one deleted
+	rec := NewHistoryRecorder(projectPath, "doctor --fix")
+	os.WriteFile(filepath.Join(projectPath, "README.md"), []byte("changed"), 0644)
+	os.WriteFile(filepath.Join(projectPath, "NOTES.md"), []byte("new"), 0644)
+	os.Remove(filepath.Join(projectPath, LastOperationFile)) // not tracked
+	os.Remove(filepath.Join(projectPath, ".gitignore"))
+	if err := rec.Record("1.2.3", []string{"doctor", "--fix"}); err != nil {
+		t.Fatal(err)
+	}
+	// A no-op is not recorded
+	if err := NewHistoryRecorder(projectPath, "sync --apply").Record("1.2.3", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := config.LoadHistory(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 history entries, got %d: %+v", len(entries), entries)
+	}
+
+	initEntry := entries[0]
+	if initEntry.Operation != "init" || initEntry.Version != "1.2.3" || initEntry.Command() != "trabuco init --name=history-app" {
+		t.Errorf("unexpected init entry: %+v", initEntry)
+	}
+	if !strings.Contains(strings.Join(initEntry.FilesCreated, ","), "API/pom.xml") {
+		t.Errorf("init entry should list created files")
+	}
+	if strings.Contains(strings.Join(initEntry.FilesCreated, ","), config.HistoryFileName) {
+		t.Errorf("history file must not list itself")
+	}
+
+	addEntry := entries[1]
+	if addEntry.Operation != "add" || !strings.Contains(strings.Join(addEntry.FilesModified, ","), "pom.xml") {
+		t.Errorf("unexpected add entry: %+v", addEntry)
+	}
+
+	fixEntry := entries[2]
+	if fixEntry.Operation != "doctor --fix" ||
+		strings.Join(fixEntry.FilesCreated, ",") != "NOTES.md" ||
+		strings.Join(fixEntry.FilesModified, ",") != "README.md" ||
+		strings.Join(fixEntry.FilesDeleted, ",") != ".gitignore" {
+		t.Errorf("unexpected recorder entry: %+v", fixEntry)
+	}
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// idempotencyFiles returns the template and output path of every class of
+// the Idempotency-Key filter. The store is a table of SQLDatastore when the
+// project has one, else Redis keys.
+func (g *Generator) idempotencyFiles() [][2]string {
+	store := "JdbcIdempotencyStore"
+	if g.config.IdempotencyUsesRedis() {
+		store = "RedisIdempotencyStore"
+	}
+	return [][2]string{
+		{"java/api/config/IdempotencyProperties.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "IdempotencyProperties.java"))},
+		{"java/api/config/IdempotencyStore.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "IdempotencyStore.java"))},
+		{"java/api/config/" + store + ".java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", store+".java"))},
+		{"java/api/config/IdempotencyFilter.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "IdempotencyFilter.java"))},
+		{"java/api/config/IdempotencyConfig.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "IdempotencyConfig.java"))},
+		{"java/api/test/config/IdempotencyFilterTest.java.tmpl", g.testJavaPath(config.ModuleAPI, filepath.Join("config", "IdempotencyFilterTest.java"))},
+	}
+}
+
+// generateIdempotency writes the Idempotency-Key filter when the project
+// asked for it with --with-idempotency, and the Flyway migration of its
+// table when the keys live in SQLDatastore.
+func (g *Generator) generateIdempotency() error {
+	if !g.config.HasIdempotency() {
+		return nil
+	}
+	for _, f := range g.idempotencyFiles() {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to generate idempotency support: %w", err)
+		}
+	}
+	if g.config.IdempotencyUsesRedis() {
+		return nil
+	}
+	migration, err := g.idempotencyMigrationPath()
+	if err != nil || migration == "" {
+		return err
+	}
+	if err := g.writeTemplate("java/sqldatastore/migration/idempotency_keys.sql.tmpl", migration); err != nil {
+		return fmt.Errorf("failed to generate idempotency migration: %w", err)
+	}
+	return nil
+}
+
+// idempotencyMigrationPath returns the path of the next Flyway migration for
+// the idempotency_keys table, or "" when the project already has one.
+func (g *Generator) idempotencyMigrationPath() (string, error) {
+	rel := g.resourcePath(config.ModuleSQLDatastore, filepath.Join("db", "migration"))
+	dir := filepath.Join(g.outDir, rel)
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), "__idempotency_keys.sql") {
+			return "", nil
+		}
+	}
+	version, err := addgen.NextMigrationVersion(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to number the idempotency migration: %w", err)
+	}
+	return filepath.Join(rel, fmt.Sprintf("V%d__idempotency_keys.sql", version)), nil
+}
@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerator_Generate_Idempotency(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "orders",
+		GroupID:     "com.test.orders",
+		ArtifactID:  "orders",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+		RateLimit:   true,
+		Idempotency: true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("orders", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	base := "API/src/main/java/com/test/orders/api/config/"
+	read(base + "IdempotencyProperties.java")
+	read(base + "IdempotencyStore.java")
+	read(base + "JdbcIdempotencyStore.java")
+	read("API/src/test/java/com/test/orders/api/config/IdempotencyFilterTest.java")
+	if _, err := os.Stat(filepath.Join("orders", base, "RedisIdempotencyStore.java")); !os.IsNotExist(err) {
+		t.Error("a project with SQLDatastore should not get the Redis store")
+	}
+	if filter := read(base + "IdempotencyFilter.java"); !strings.Contains(filter, "ErrorCode.IDEMPOTENCY_KEY_REUSED") {
+		t.Error("IdempotencyFilter should answer a reused key with IDEMPOTENCY_KEY_REUSED")
+	}
+	if registration := read(base + "IdempotencyConfig.java"); !strings.Contains(registration, "new JdbcIdempotencyStore(jdbcTemplate)") {
+		t.Error("IdempotencyConfig should wire the JDBC store")
+	}
+	if codes := read("Model/src/main/java/com/test/orders/model/error/ErrorCode.java"); !strings.Contains(codes, "IDEMPOTENCY_KEY_IN_USE") {
+		t.Error("ErrorCode should declare the idempotency codes")
+	}
+	if docs := read("docs/errors.md"); !strings.Contains(docs, "`IDEMPOTENCY_KEY_REUSED` | 422") {
+		t.Error("docs/errors.md should list the idempotency codes")
+	}
+
+	// V1 is the baseline, so the table comes next
+	migration := read("SQLDatastore/src/main/resources/db/migration/V2__idempotency_keys.sql")
+	for _, want := range []string{"CREATE TABLE IF NOT EXISTS idempotency_keys", "idempotency_key CHAR(64) PRIMARY KEY", "BYTEA"} {
+		if !strings.Contains(migration, want) {
+			t.Errorf("migration should contain %q", want)
+		}
+	}
+	if strings.Contains(read("API/pom.xml"), "spring-boot-starter-data-redis") {
+		t.Error("the JDBC store should not add the Redis starter")
+	}
+
+	// The idempotency block joins the rate-limit app: key instead of adding a second one
+	var yml struct {
+		App struct {
+			RateLimit   map[string]any `yaml:"rate-limit"`
+			Idempotency map[string]any `yaml:"idempotency"`
+		} `yaml:"app"`
+	}
+	content := read("API/src/main/resources/application.yml")
+	if err := yaml.Unmarshal([]byte(content), &yml); err != nil {
+		t.Fatalf("application.yml should parse: %v", err)
+	}
+	if strings.Count(content, "\napp:") != 1 {
+		t.Errorf("application.yml should have one app: key, got %d", strings.Count(content, "\napp:"))
+	}
+	if yml.App.RateLimit == nil || yml.App.Idempotency["ttl"] != "${IDEMPOTENCY_TTL:24h}" {
+		t.Errorf("app should hold rate-limit and idempotency, got %+v", yml.App)
+	}
+
+	metadata, err := config.LoadMetadata(filepath.Join(tempDir, "orders"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.Idempotency {
+		t.Error("metadata should persist idempotency")
+	}
+}
+
+func TestGenerator_Generate_IdempotencyRedis(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "keys",
+		GroupID:       "com.test.keys",
+		ArtifactID:    "keys",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "NoSQLDatastore", "Shared", "API"},
+		NoSQLDatabase: "redis",
+		Idempotency:   true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	base := filepath.Join("keys", "API/src/main/java/com/test/keys/api/config")
+	if _, err := os.Stat(filepath.Join(base, "RedisIdempotencyStore.java")); err != nil {
+		t.Errorf("expected RedisIdempotencyStore.java: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(base, "JdbcIdempotencyStore.java")); !os.IsNotExist(err) {
+		t.Error("a project without SQLDatastore should not get the JDBC store")
+	}
+	registration, err := os.ReadFile(filepath.Join(base, "IdempotencyConfig.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(registration), "new RedisIdempotencyStore(redisTemplate, objectMapper)") {
+		t.Error("IdempotencyConfig should wire the Redis store")
+	}
+	apiPom, err := os.ReadFile(filepath.Join("keys", "API", "pom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(apiPom), "<artifactId>spring-boot-starter-data-redis</artifactId>") {
+		t.Error("API pom should depend on the Redis starter")
+	}
+}
+
+func TestGenerator_Generate_IdempotencyWithoutStore(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "mongo",
+		GroupID:       "com.test.mongo",
+		ArtifactID:    "mongo",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "NoSQLDatastore", "Shared", "API"},
+		NoSQLDatabase: "mongodb",
+		Idempotency:   true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("mongo", "API/src/main/java/com/test/mongo/api/config/IdempotencyFilter.java")); !os.IsNotExist(err) {
+		t.Error("idempotency without SQLDatastore or Redis should not be generated")
+	}
+	yml, err := os.ReadFile(filepath.Join("mongo", "API/src/main/resources/application.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(yml), "idempotency:") {
+		t.Error("application.yml should not configure idempotency without a store")
+	}
+}
+
+func TestModuleAdder_Add_Idempotency(t *testing.T) {
+	tempDir := t.TempDir()
+	projectPath := filepath.Join(tempDir, "later")
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "later",
+		GroupID:     "com.test.later",
+		ArtifactID:  "later",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared"},
+		Database:    "postgresql",
+		Idempotency: true,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	migrations := filepath.Join(projectPath, "SQLDatastore/src/main/resources/db/migration")
+	if err := os.WriteFile(filepath.Join(migrations, "V2__orders.sql"), []byte("SELECT 1;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewModuleAdder(projectPath, metadata, "test", false).Add(config.ModuleAPI, "", "", ""); err != nil {
+		t.Fatalf("Add API failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(projectPath, "API/src/main/java/com/test/later/api/config/IdempotencyFilter.java")); err != nil {
+		t.Errorf("adding API should generate the idempotency filter: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(migrations, "V3__idempotency_keys.sql")); err != nil {
+		t.Errorf("the idempotency migration should follow the existing ones: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// generateDockerfile writes the Dockerfile of a runtime module. Projects
+// built with --image-builder jib get none: jib-maven-plugin, configured in
+// the module's pom.xml, builds the image instead.
+func (g *Generator) generateDockerfile(module string) error {
+	if g.config.UsesJib() {
+		return nil
+	}
+	if err := g.writeTemplate(
+		"docker/"+strings.ToLower(module)+".Dockerfile.tmpl",
+		filepath.Join(module, "Dockerfile"),
+	); err != nil {
+		return fmt.Errorf("failed to generate %s Dockerfile: %w", module, err)
+	}
+	return nil
+}
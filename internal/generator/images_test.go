@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_Jib(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{
+		ProjectName:   "orders",
+		GroupID:       "com.test.orders",
+		ArtifactID:    "orders",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker"},
+		Database:      "postgresql",
+		CIProvider:    "github",
+		ImageRegistry: "ghcr.io/acme",
+		ImageBuilder:  config.ImageBuilderJib,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	read := func(rel string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(projectPath, rel))
+		if err != nil {
+			t.Fatalf("expected %s: %v", rel, err)
+		}
+		return string(data)
+	}
+
+	for _, module := range []string{"API", "Worker"} {
+		if _, err := os.Stat(filepath.Join(projectPath, module, "Dockerfile")); err == nil {
+			t.Errorf("%s/Dockerfile should not be generated with Jib", module)
+		}
+	}
+	api := read("API/pom.xml")
+	for _, want := range []string{"<artifactId>jib-maven-plugin</artifactId>", "<skip>false</skip>", "<image>${image.prefix}orders-api:${image.tag}</image>", "<mainClass>com.test.orders.api.OrdersApiApplication</mainClass>"} {
+		if !strings.Contains(api, want) {
+			t.Errorf("API/pom.xml should contain %s", want)
+		}
+	}
+	if !strings.Contains(read("Worker/pom.xml"), "<port>8081</port>") {
+		t.Error("Worker/pom.xml should expose the Worker port")
+	}
+	if strings.Contains(read("Model/pom.xml"), "jib-maven-plugin") {
+		t.Error("library modules should not configure Jib")
+	}
+
+	parent := read("pom.xml")
+	for _, want := range []string{"<jib-maven-plugin.version>", "<image.prefix>ghcr.io/acme/</image.prefix>", "<jib.skip>true</jib.skip>", "<name>env.IMAGE_REGISTRY</name>", "<image.tag>${env.IMAGE_TAG}</image.tag>"} {
+		if !strings.Contains(parent, want) {
+			t.Errorf("parent pom.xml should contain %s", want)
+		}
+	}
+
+	ci := read(".github/workflows/ci.yml")
+	if !strings.Contains(ci, "mvn -B -pl API,Worker -am -DskipTests package \"$goal\"") || strings.Contains(ci, "docker build") {
+		t.Error("the images job should build with Jib")
+	}
+	if !strings.Contains(read("README.md"), "jib:dockerBuild") {
+		t.Error("README.md should document the Jib build")
+	}
+}
+
+func TestGenerator_Generate_DockerfileImages(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{
+		ProjectName: "orders",
+		GroupID:     "com.test.orders",
+		ArtifactID:  "orders",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+		CIProvider:  "github",
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(projectPath, "API", "Dockerfile")); err != nil {
+		t.Error("API/Dockerfile should be generated by default")
+	}
+	pom, _ := os.ReadFile(filepath.Join(projectPath, "pom.xml"))
+	if strings.Contains(string(pom), "jib") {
+		t.Error("the parent pom.xml should not configure Jib by default")
+	}
+	ci, _ := os.ReadFile(filepath.Join(projectPath, ".github", "workflows", "ci.yml"))
+	if !strings.Contains(string(ci), `docker build -f "$module/Dockerfile"`) {
+		t.Error("the images job should build the Dockerfiles")
+	}
+}
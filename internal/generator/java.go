@@ -66,8 +66,8 @@ func (g *Generator) generateModelModule() error {
 		return fmt.Errorf("failed to generate PlaceholderResponse.java: %w", err)
 	}
 
-	// Event classes (only if EventConsumer is selected)
-	if g.config.HasModule(config.ModuleEventConsumer) {
+	// Event classes (whenever the Events publisher is present)
+	if g.config.HasModule(config.ModuleEvents) {
 		// PlaceholderEvent.java (sealed interface)
 		if err := g.writeTemplate(
 			"java/model/events/PlaceholderEvent.java.tmpl",
@@ -118,6 +118,13 @@ func (g *Generator) generateModelModule() error {
 		}
 	}
 
+	// The API's error catalog: ErrorCode and ApplicationException
+	if g.config.HasModule(config.ModuleAPI) {
+		if err := g.generateErrorCatalog(); err != nil {
+			return err
+		}
+	}
+
 	// Job request classes (only if Worker is selected)
 	if g.config.HasModule(config.ModuleWorker) {
 		// PlaceholderJobRequest.java (sealed interface for placeholder domain)
@@ -145,7 +152,16 @@ func (g *Generator) generateModelModule() error {
 		}
 	}
 
-	return nil
+	// ModelArchitectureTest.java (ArchUnit rules for Model)
+	if err := g.writeTemplate(
+		"java/model/test/ModelArchitectureTest.java.tmpl",
+		g.testJavaPath("Model", "ModelArchitectureTest.java"),
+	); err != nil {
+		return fmt.Errorf("failed to generate ModelArchitectureTest.java: %w", err)
+	}
+
+	// Object mothers and RandomData, published as Model's test-jar
+	return g.generateModelFixtures()
 }
 
 // generateSQLDatastoreModule generates all SQLDatastore module files
@@ -163,6 +179,28 @@ func (g *Generator) generateSQLDatastoreModule() error {
 		return fmt.Errorf("failed to generate DatabaseConfig.java: %w", err)
 	}
 
+	// AuditingConfig.java (--with-auditing)
+	if g.config.HasAuditing() {
+		if err := g.writeTemplate(
+			"java/sqldatastore/config/AuditingConfig.java.tmpl",
+			g.javaPath("SQLDatastore", filepath.Join("config", "AuditingConfig.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate AuditingConfig.java: %w", err)
+		}
+	}
+
+	// ReadReplicaConfig.java and its routing data source (--with-read-replica)
+	if g.config.HasReadReplica() {
+		for _, name := range []string{"ReadReplicaConfig", "ReadWriteRoutingDataSource"} {
+			if err := g.writeTemplate(
+				"java/sqldatastore/config/"+name+".java.tmpl",
+				g.javaPath("SQLDatastore", filepath.Join("config", name+".java")),
+			); err != nil {
+				return fmt.Errorf("failed to generate %s.java: %w", name, err)
+			}
+		}
+	}
+
 	// PlaceholderRepository.java
 	if err := g.writeTemplate(
 		"java/sqldatastore/repository/PlaceholderRepository.java.tmpl",
@@ -203,7 +241,25 @@ func (g *Generator) generateSQLDatastoreModule() error {
 		return fmt.Errorf("failed to generate PlaceholderRepositoryTest.java: %w", err)
 	}
 
-	return nil
+	// H2 variant of the repository test and its schema, run by -Plight-tests.
+	// The generic database already runs on H2 without Docker.
+	if g.config.Database == config.DatabasePostgreSQL || g.config.IsMySQLCompatible() {
+		if err := g.writeTemplate(
+			"java/sqldatastore/test/PlaceholderRepositoryLightTest.java.tmpl",
+			g.testJavaPath("SQLDatastore", filepath.Join("repository", "PlaceholderRepositoryLightTest.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate PlaceholderRepositoryLightTest.java: %w", err)
+		}
+		if err := g.writeTemplate(
+			"java/sqldatastore/test/light-tests-schema.sql.tmpl",
+			filepath.Join("SQLDatastore", "src", "test", "resources", "light-tests", "schema.sql"),
+		); err != nil {
+			return fmt.Errorf("failed to generate light-tests schema.sql: %w", err)
+		}
+	}
+
+	// Singleton database container, published as SQLDatastore's test-jar
+	return g.generateDatabaseFixture()
 }
 
 // generateNoSQLDatastoreModule generates all NoSQLDatastore module files
@@ -253,6 +309,74 @@ func (g *Generator) generateNoSQLDatastoreModule() error {
 		return fmt.Errorf("failed to generate PlaceholderDocumentRepositoryTest.java: %w", err)
 	}
 
+	// Embedded-mongod variant of the repository test, run by -Plight-tests
+	if g.config.NoSQLDatabase == config.DatabaseMongoDB {
+		if err := g.writeTemplate(
+			"java/nosqldatastore/test/PlaceholderDocumentRepositoryLightTest.java.tmpl",
+			g.testJavaPath("NoSQLDatastore", filepath.Join("repository", "PlaceholderDocumentRepositoryLightTest.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate PlaceholderDocumentRepositoryLightTest.java: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// generateSearchModule generates all Search module files
+func (g *Generator) generateSearchModule() error {
+	// Generate module POM
+	if err := g.generateModulePOM(config.ModuleSearch); err != nil {
+		return err
+	}
+
+	// SearchConfig.java
+	if err := g.writeTemplate(
+		"java/search/config/SearchConfig.java.tmpl",
+		g.javaPath(config.ModuleSearch, filepath.Join("config", "SearchConfig.java")),
+	); err != nil {
+		return fmt.Errorf("failed to generate SearchConfig.java: %w", err)
+	}
+
+	// PlaceholderSearchDocument.java (index mapping)
+	if err := g.writeTemplate(
+		"java/search/document/PlaceholderSearchDocument.java.tmpl",
+		g.javaPath(config.ModuleSearch, filepath.Join("document", "PlaceholderSearchDocument.java")),
+	); err != nil {
+		return fmt.Errorf("failed to generate PlaceholderSearchDocument.java: %w", err)
+	}
+
+	// PlaceholderSearchRepository.java
+	if err := g.writeTemplate(
+		"java/search/repository/PlaceholderSearchRepository.java.tmpl",
+		g.javaPath(config.ModuleSearch, filepath.Join("repository", "PlaceholderSearchRepository.java")),
+	); err != nil {
+		return fmt.Errorf("failed to generate PlaceholderSearchRepository.java: %w", err)
+	}
+
+	// application.yml (OpenSearch connection for the module's own tests)
+	if err := g.writeTemplate(
+		"java/search/resources/application.yml.tmpl",
+		g.resourcePath(config.ModuleSearch, "application.yml"),
+	); err != nil {
+		return fmt.Errorf("failed to generate Search application.yml: %w", err)
+	}
+
+	// TestConfig.java (test configuration for library module)
+	if err := g.writeTemplate(
+		"java/search/test/TestConfig.java.tmpl",
+		g.testJavaPath(config.ModuleSearch, "TestConfig.java"),
+	); err != nil {
+		return fmt.Errorf("failed to generate Search TestConfig.java: %w", err)
+	}
+
+	// PlaceholderSearchRepositoryTest.java (Testcontainers OpenSearch)
+	if err := g.writeTemplate(
+		"java/search/test/PlaceholderSearchRepositoryTest.java.tmpl",
+		g.testJavaPath(config.ModuleSearch, filepath.Join("repository", "PlaceholderSearchRepositoryTest.java")),
+	); err != nil {
+		return fmt.Errorf("failed to generate PlaceholderSearchRepositoryTest.java: %w", err)
+	}
+
 	return nil
 }
 
@@ -279,6 +403,16 @@ func (g *Generator) generateSharedModule() error {
 		return fmt.Errorf("failed to generate CircuitBreakerConfiguration.java: %w", err)
 	}
 
+	// CacheConfig.java (Spring Cache manager for --with-cache)
+	if g.config.HasCache() {
+		if err := g.writeTemplate(
+			"java/shared/config/CacheConfig.java.tmpl",
+			g.javaPath("Shared", filepath.Join("config", "CacheConfig.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate CacheConfig.java: %w", err)
+		}
+	}
+
 	// application.yml (circuit breaker configuration)
 	if err := g.writeTemplate(
 		"java/shared/resources/application.yml.tmpl",
@@ -303,6 +437,22 @@ func (g *Generator) generateSharedModule() error {
 		return fmt.Errorf("failed to generate PlaceholderServiceTest.java: %w", err)
 	}
 
+	// SearchService.java and its test (indexing hook for the Search module)
+	if g.config.HasModule(config.ModuleSearch) {
+		if err := g.writeTemplate(
+			"java/shared/service/SearchService.java.tmpl",
+			g.javaPath("Shared", filepath.Join("service", "SearchService.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate SearchService.java: %w", err)
+		}
+		if err := g.writeTemplate(
+			"java/shared/test/SearchServiceTest.java.tmpl",
+			g.testJavaPath("Shared", filepath.Join("service", "SearchServiceTest.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate SearchServiceTest.java: %w", err)
+		}
+	}
+
 	// ArchitectureTest.java (ArchUnit rules)
 	if err := g.writeTemplate(
 		"java/shared/test/ArchitectureTest.java.tmpl",
@@ -404,8 +554,8 @@ func (g *Generator) generateAPIModule() error {
 		}
 	}
 
-	// EventController.java (only when EventConsumer module is selected)
-	if g.config.HasModule(config.ModuleEventConsumer) {
+	// EventController.java (whenever the Events publisher is present)
+	if g.config.HasModule(config.ModuleEvents) {
 		if err := g.writeTemplate(
 			"java/api/controller/EventController.java.tmpl",
 			g.javaPath("API", filepath.Join("controller", "EventController.java")),
@@ -522,9 +672,10 @@ func (g *Generator) generateAPIModule() error {
 		}
 	}
 
-	// F-WEB-01 ArchUnit guard — every controller endpoint must declare
-	// an explicit authorization decision. Lives module-local because
-	// the test classpath has to see API's own controller classes.
+	// F-WEB-01 ArchUnit guards — every controller endpoint must declare
+	// an explicit authorization decision, and controllers must not use
+	// repositories. Lives module-local because the test classpath has to
+	// see API's own controller classes.
 	if err := g.writeTemplate(
 		"java/api/test/ApiArchitectureTest.java.tmpl",
 		g.testJavaPath("API", "ApiArchitectureTest.java"),
@@ -532,6 +683,15 @@ func (g *Generator) generateAPIModule() error {
 		return fmt.Errorf("failed to generate ApiArchitectureTest.java: %w", err)
 	}
 
+	// Error contract test: ErrorCode-driven Problem Details with the
+	// errorCode and correlationId extensions
+	if err := g.writeTemplate(
+		"java/api/test/config/GlobalExceptionHandlerTest.java.tmpl",
+		g.testJavaPath("API", filepath.Join("config", "GlobalExceptionHandlerTest.java")),
+	); err != nil {
+		return fmt.Errorf("failed to generate GlobalExceptionHandlerTest.java: %w", err)
+	}
+
 	// GlobalExceptionHandler integration test — emitted only when both
 	// the SQLDatastore module and Postgres database are selected, since
 	// the test relies on a Postgres Testcontainer to surface real
@@ -564,12 +724,9 @@ func (g *Generator) generateAPIModule() error {
 		return fmt.Errorf("failed to generate API logback-spring.xml: %w", err)
 	}
 
-	// Dockerfile
-	if err := g.writeTemplate(
-		"docker/api.Dockerfile.tmpl",
-		filepath.Join("API", "Dockerfile"),
-	); err != nil {
-		return fmt.Errorf("failed to generate API Dockerfile: %w", err)
+	// Dockerfile (none with --image-builder jib)
+	if err := g.generateDockerfile("API"); err != nil {
+		return err
 	}
 
 	// IntelliJ IDEA Run Configuration (Maven)
@@ -667,12 +824,9 @@ func (g *Generator) generateWorkerModule() error {
 		return fmt.Errorf("failed to generate Worker logback-spring.xml: %w", err)
 	}
 
-	// Dockerfile
-	if err := g.writeTemplate(
-		"docker/worker.Dockerfile.tmpl",
-		filepath.Join("Worker", "Dockerfile"),
-	); err != nil {
-		return fmt.Errorf("failed to generate Worker Dockerfile: %w", err)
+	// Dockerfile (none with --image-builder jib)
+	if err := g.generateDockerfile("Worker"); err != nil {
+		return err
 	}
 
 	// IntelliJ IDEA Run Configuration (Maven)
@@ -704,6 +858,18 @@ func (g *Generator) generateEventsModule() error {
 		return fmt.Errorf("failed to generate EventPublisher.java: %w", err)
 	}
 
+	// Kafka producer interceptor that adds the correlation ID header - only for Kafka
+	if g.config.UsesKafka() {
+		for _, name := range []string{"KafkaPublisherConfig", "CorrelationIdProducerInterceptor"} {
+			if err := g.writeTemplate(
+				"java/events/config/"+name+".java.tmpl",
+				g.javaPath("Events", filepath.Join("config", name+".java")),
+			); err != nil {
+				return fmt.Errorf("failed to generate Events %s.java: %w", name, err)
+			}
+		}
+	}
+
 	// RabbitConfig.java (RabbitMQ JSON configuration) - only for RabbitMQ
 	if g.config.UsesRabbitMQ() {
 		if err := g.writeTemplate(
@@ -724,6 +890,16 @@ func (g *Generator) generateEventsModule() error {
 		}
 	}
 
+	// NatsPublisherConfig.java (connection and stream setup) - only for NATS
+	if g.config.UsesNATS() {
+		if err := g.writeTemplate(
+			"java/events/config/NatsPublisherConfig.java.tmpl",
+			g.javaPath("Events", filepath.Join("config", "NatsPublisherConfig.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate Events NatsPublisherConfig.java: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -745,7 +921,7 @@ func (g *Generator) generateEventConsumerModule() error {
 		return fmt.Errorf("failed to generate EventConsumerApplication.java: %w", err)
 	}
 
-	// Config (Kafka, RabbitMQ, SQS, or Pub/Sub)
+	// Config (Kafka, RabbitMQ, SQS, Pub/Sub, Redis Streams, or NATS)
 	if g.config.UsesKafka() {
 		if err := g.writeTemplate(
 			"java/eventconsumer/config/KafkaConfig.java.tmpl",
@@ -774,6 +950,31 @@ func (g *Generator) generateEventConsumerModule() error {
 		); err != nil {
 			return fmt.Errorf("failed to generate PubSubConfig.java: %w", err)
 		}
+	} else if g.config.UsesRedisStreams() {
+		if err := g.writeTemplate(
+			"java/eventconsumer/config/RedisStreamConfig.java.tmpl",
+			g.javaPath("EventConsumer", filepath.Join("config", "RedisStreamConfig.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate RedisStreamConfig.java: %w", err)
+		}
+	} else if g.config.UsesNATS() {
+		if err := g.writeTemplate(
+			"java/eventconsumer/config/NatsConfig.java.tmpl",
+			g.javaPath("EventConsumer", filepath.Join("config", "NatsConfig.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate NatsConfig.java: %w", err)
+		}
+	}
+
+	// CorrelationIdInterceptor.java: logs each message under the
+	// correlation ID header it was published with (Kafka and RabbitMQ)
+	if g.config.UsesKafka() || g.config.UsesRabbitMQ() {
+		if err := g.writeTemplate(
+			"java/eventconsumer/config/CorrelationIdInterceptor.java.tmpl",
+			g.javaPath("EventConsumer", filepath.Join("config", "CorrelationIdInterceptor.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate CorrelationIdInterceptor.java: %w", err)
+		}
 	}
 
 	// PlaceholderEventListener.java
@@ -784,6 +985,16 @@ func (g *Generator) generateEventConsumerModule() error {
 		return fmt.Errorf("failed to generate PlaceholderEventListener.java: %w", err)
 	}
 
+	// PlaceholderEventProcessor.java: the exactly-once Kafka template
+	if g.config.HasKafkaTransactions() {
+		if err := g.writeTemplate(
+			"java/eventconsumer/listener/PlaceholderEventProcessor.java.tmpl",
+			g.javaPath("EventConsumer", filepath.Join("listener", "PlaceholderEventProcessor.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate PlaceholderEventProcessor.java: %w", err)
+		}
+	}
+
 	// F-EVENTS-05: in-memory idempotency tracker — bounded LRU; doc
 	// recommends DB/Redis-backed replacement for multi-instance
 	// deployments.
@@ -821,12 +1032,9 @@ func (g *Generator) generateEventConsumerModule() error {
 		return fmt.Errorf("failed to generate EventConsumer logback-spring.xml: %w", err)
 	}
 
-	// Dockerfile
-	if err := g.writeTemplate(
-		"docker/eventconsumer.Dockerfile.tmpl",
-		filepath.Join("EventConsumer", "Dockerfile"),
-	); err != nil {
-		return fmt.Errorf("failed to generate EventConsumer Dockerfile: %w", err)
+	// Dockerfile (none with --image-builder jib)
+	if err := g.generateDockerfile("EventConsumer"); err != nil {
+		return err
 	}
 
 	// Test
@@ -836,6 +1044,32 @@ func (g *Generator) generateEventConsumerModule() error {
 	); err != nil {
 		return fmt.Errorf("failed to generate PlaceholderEventListenerTest.java: %w", err)
 	}
+	// PlaceholderEventListenerLoadTest.java: drives the listener with the
+	// generated Kafka concurrency and poll settings
+	if g.config.UsesKafka() {
+		if err := g.writeTemplate(
+			"java/eventconsumer/test/PlaceholderEventListenerLoadTest.java.tmpl",
+			g.testJavaPath("EventConsumer", filepath.Join("listener", "PlaceholderEventListenerLoadTest.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate PlaceholderEventListenerLoadTest.java: %w", err)
+		}
+	}
+	if g.config.HasKafkaTransactions() {
+		if err := g.writeTemplate(
+			"java/eventconsumer/test/PlaceholderEventProcessorTest.java.tmpl",
+			g.testJavaPath("EventConsumer", filepath.Join("listener", "PlaceholderEventProcessorTest.java")),
+		); err != nil {
+			return fmt.Errorf("failed to generate PlaceholderEventProcessorTest.java: %w", err)
+		}
+	}
+	// EventConsumerArchitectureTest.java: listeners delegate instead of
+	// reaching into data stores
+	if err := g.writeTemplate(
+		"java/eventconsumer/test/EventConsumerArchitectureTest.java.tmpl",
+		g.testJavaPath("EventConsumer", "EventConsumerArchitectureTest.java"),
+	); err != nil {
+		return fmt.Errorf("failed to generate EventConsumerArchitectureTest.java: %w", err)
+	}
 
 	// IntelliJ run configuration
 	if err := g.writeTemplate(
@@ -1147,11 +1381,8 @@ func (g *Generator) generateAIAgentModule() error {
 	}
 
 	// ─── Docker ─────────────────────────────────────────────────────────
-	if err := g.writeTemplate(
-		"docker/aiagent.Dockerfile.tmpl",
-		filepath.Join("AIAgent", "Dockerfile"),
-	); err != nil {
-		return fmt.Errorf("failed to generate AIAgent Dockerfile: %w", err)
+	if err := g.generateDockerfile("AIAgent"); err != nil {
+		return err
 	}
 
 	// ─── Tests ──────────────────────────────────────────────────────────
@@ -1233,3 +1464,22 @@ func (g *Generator) generateAIAgentModule() error {
 
 	return nil
 }
+
+// generateClientSDKModule generates the ClientSDK module: a POM that runs
+// openapi-generator at build time and the initial OpenAPI spec describing
+// the generated API endpoints. The spec is a source file — the
+// refresh-openapi profile replaces it with the running API's /api-docs.
+func (g *Generator) generateClientSDKModule() error {
+	if err := g.generateModulePOM(config.ModuleClientSDK); err != nil {
+		return err
+	}
+
+	if err := g.writeTemplate(
+		"java/clientsdk/openapi.json.tmpl",
+		filepath.Join(config.ModuleClientSDK, "src", "main", "openapi", "openapi.json"),
+	); err != nil {
+		return fmt.Errorf("failed to generate openapi.json: %w", err)
+	}
+
+	return nil
+}
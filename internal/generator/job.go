@@ -0,0 +1,282 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/utils"
+)
+
+// JobOpts configures GenerateJob.
+type JobOpts struct {
+	Name     string // PascalCase job name, e.g. "SendDigest"
+	Payload  string // optional addgen.ParseFields payload spec
+	Schedule string // optional cron expression; registers a recurring job
+}
+
+var (
+	cronFieldPattern = regexp.MustCompile(`^[0-9A-Za-z*?/,#-]+$`)
+	cronSecondsField = regexp.MustCompile(`^[0-9]{1,2}$`)
+)
+
+// validateCron checks a JobRunr cron expression: 5 fields, or 6 with a
+// leading seconds field. The seconds field must be a single second so the
+// job fires at most once a minute, the floor RecurringJobsConfig documents.
+// JobRunr parses the expression itself when the Worker starts.
+func validateCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 && len(fields) != 6 {
+		return fmt.Errorf("invalid --schedule %q: expected 5 or 6 cron fields, got %d", expr, len(fields))
+	}
+	for _, f := range fields {
+		if !cronFieldPattern.MatchString(f) {
+			return fmt.Errorf("invalid --schedule %q: unexpected characters in field %q", expr, f)
+		}
+	}
+	if len(fields) == 6 && !cronSecondsField.MatchString(fields[0]) {
+		return fmt.Errorf("invalid --schedule %q: the seconds field must be a single second (e.g. 0); recurring jobs may fire at most once a minute", expr)
+	}
+	return nil
+}
+
+// GenerateJob adds a runnable job to the project at projectPath (`trabuco
+// generate job`). It creates the JobRequest and handler pair in Model and
+// Worker with a handler test (via addgen), adds an enqueue method to the
+// Jobs module's PlaceholderJobService and, with a schedule, registers the
+// job in Worker's RecurringJobsConfig.
+//
+// The edits are insertions at anchors the generated files start with. When
+// a file was renamed or reshaped the edit is skipped and the snippet is
+// returned as a next step instead. In dry-run mode nothing is written.
+func GenerateJob(projectPath string, opts JobOpts, dryRun bool) (*addgen.Result, error) {
+	schedule := strings.Join(strings.Fields(opts.Schedule), " ")
+	if schedule != "" {
+		if err := validateCron(schedule); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(opts.Payload) != "" {
+			return nil, fmt.Errorf("--schedule cannot be combined with --payload: a recurring job is registered with a fixed request, so it takes no payload")
+		}
+	}
+
+	ctx, err := addgen.LoadContext(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range []string{config.ModuleModel, config.ModuleJobs, config.ModuleWorker} {
+		if !ctx.HasModule(module) {
+			return nil, fmt.Errorf("generate job requires the %s module", module)
+		}
+	}
+	var fields []addgen.Field
+	if strings.TrimSpace(opts.Payload) != "" {
+		if fields, err = addgen.ParseFields(opts.Payload); err != nil {
+			return nil, fmt.Errorf("invalid --payload: %w", err)
+		}
+	}
+
+	ctx.DryRun = dryRun
+	result, err := addgen.GenerateJob(ctx, addgen.JobOpts{Name: opts.Name, Payload: opts.Payload, Runnable: true})
+	if err != nil {
+		return nil, err
+	}
+	name := utils.ToPascalCase(opts.Name)
+	jobsPkg := ctx.JavaPackage(config.ModuleModel, "jobs")
+
+	service := filepath.Join(ctx.JavaSrcMain(config.ModuleJobs, ""), "PlaceholderJobService.java")
+	method := enqueueMethod(name, fields)
+	imports := []string{jobsPkg + "." + name + "JobRequest"}
+	for _, f := range fields {
+		if imp := f.JavaImport(); imp != "" {
+			imports = append(imports, imp)
+		} else if f.Type == addgen.FTEnum {
+			imports = append(imports, ctx.JavaPackage(config.ModuleModel, "entities")+"."+f.EnumName)
+		}
+	}
+	edited, err := editJavaFile(projectPath, service, dryRun, imports, func(src string) (string, bool) {
+		if strings.Contains(src, " enqueue"+name+"(") {
+			return src, true
+		}
+		end := strings.LastIndex(src, "}")
+		if end < 0 {
+			return src, false
+		}
+		return strings.TrimRight(src[:end], "\n") + "\n\n" + method + src[end:], true
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordEdit(result, edited, service, dryRun,
+		fmt.Sprintf("Add enqueue%s to the Jobs module's job service:\n%s", name, method))
+
+	if schedule != "" {
+		recurring := filepath.Join(ctx.JavaSrcMain(config.ModuleWorker, "config"), "RecurringJobsConfig.java")
+		id := strings.ReplaceAll(utils.ToSnakeCase(name), "_", "-")
+		edited, err := editJavaFile(projectPath, recurring, dryRun, []string{jobsPkg + "." + name + "JobRequest"}, func(src string) (string, bool) {
+			if strings.Contains(src, `"`+id+`"`) {
+				return src, true
+			}
+			at := strings.Index(src, `log.info("Recurring jobs registered`)
+			if at < 0 {
+				at = strings.Index(src, "} catch (RuntimeException e) {")
+			}
+			if at < 0 {
+				return src, false
+			}
+			lineStart := strings.LastIndex(src[:at], "\n") + 1
+			indent := src[lineStart:at]
+			if strings.TrimSpace(indent) != "" {
+				return src, false
+			}
+			if strings.HasPrefix(src[at:], "}") {
+				indent += "  "
+			}
+			return src[:lineStart] + scheduleCall(indent, id, schedule, name) + "\n" + src[lineStart:], true
+		})
+		if err != nil {
+			return nil, err
+		}
+		recordEdit(result, edited, recurring, dryRun,
+			fmt.Sprintf("Register the recurring job in RecurringJobsConfig.registerRecurringJobs():\n%s", scheduleCall("", id, schedule, name)))
+	}
+
+	result.NextSteps = append(result.NextSteps,
+		fmt.Sprintf("Run ./mvnw test -pl Worker -am to compile the job and run %sJobRequestHandlerTest.", name),
+		fmt.Sprintf("Enqueue on demand through PlaceholderJobService.enqueue%s, or rename that service to fit your domain.", name),
+	)
+	return result, nil
+}
+
+// enqueueMethod renders the Jobs service method that enqueues name's request.
+func enqueueMethod(name string, fields []addgen.Field) string {
+	params := make([]string, len(fields))
+	args := make([]string, len(fields))
+	for i, f := range fields {
+		params[i] = f.JavaType() + " " + f.Name
+		args[i] = f.Name
+	}
+	var b strings.Builder
+	b.WriteString("  /**\n")
+	fmt.Fprintf(&b, "   * Enqueue a %s job for immediate background execution.\n", name)
+	if len(fields) > 0 {
+		b.WriteString("   *\n")
+		for _, f := range fields {
+			fmt.Fprintf(&b, "   * @param %s the job's %s\n", f.Name, f.Name)
+		}
+	}
+	b.WriteString("   */\n")
+	fmt.Fprintf(&b, "  public void enqueue%s(%s) {\n", name, strings.Join(params, ", "))
+	fmt.Fprintf(&b, "    BackgroundJobRequest.enqueue(new %sJobRequest(%s));\n", name, strings.Join(args, ", "))
+	b.WriteString("  }\n")
+	return b.String()
+}
+
+// scheduleCall renders the scheduleRecurrently call for RecurringJobsConfig.
+func scheduleCall(indent, id, schedule, name string) string {
+	return fmt.Sprintf("%sBackgroundJobRequest.scheduleRecurrently(\n%s    %q,\n%s    %q,\n%s    new %sJobRequest());\n",
+		indent, indent, id, indent, schedule, indent, name)
+}
+
+// recordEdit reports the outcome of an editJavaFile call on result: a note
+// for an applied edit, or a next step with the snippet for a skipped one.
+func recordEdit(result *addgen.Result, edited bool, relPath string, dryRun bool, manual string) {
+	switch {
+	case !edited:
+		result.NextSteps = append(result.NextSteps, fmt.Sprintf("%s was not found in its generated shape. %s", relPath, manual))
+	case dryRun:
+		result.Notes = append(result.Notes, "Would update "+relPath)
+	default:
+		result.Notes = append(result.Notes, "Updated "+relPath)
+	}
+}
+
+// editJavaFile applies insert to the Java source at relPath and adds the
+// imports it is missing, as editFile does.
+func editJavaFile(projectPath, relPath string, dryRun bool, imports []string, insert func(string) (string, bool)) (bool, error) {
+	return editFile(projectPath, relPath, dryRun, func(src string) (string, bool) {
+		src, ok := insert(src)
+		if !ok {
+			return src, false
+		}
+		return addJavaImports(src, imports)
+	})
+}
+
+// editFile applies insert to the file at relPath. insert reports false when
+// it cannot find its anchor; editFile then returns false and leaves the file
+// alone, as it does when the file does not exist. An insert that finds its
+// change already applied returns the source unchanged, making reruns no-ops.
+func editFile(projectPath, relPath string, dryRun bool, insert func(string) (string, bool)) (bool, error) {
+	abs := filepath.Join(projectPath, relPath)
+	data, err := os.ReadFile(abs)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+	src, ok := insert(string(data))
+	if !ok {
+		return false, nil
+	}
+	if dryRun || src == string(data) {
+		return true, nil
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", relPath, err)
+	}
+	if err := os.WriteFile(abs, []byte(src), info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", relPath, err)
+	}
+	return true, nil
+}
+
+// addJavaImports adds the missing imports to the non-static import block of
+// src, each before the first existing import that sorts after it, so the
+// block's own order is kept. It reports false when src has no single,
+// ungrouped import block.
+func addJavaImports(src string, imports []string) (string, bool) {
+	lines := strings.Split(src, "\n")
+	first, last := -1, -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "import ") && !strings.HasPrefix(line, "import static ") {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return src, false
+	}
+	block := append([]string{}, lines[first:last+1]...)
+	for _, line := range block {
+		// A grouped block has its own order; leave it to the user
+		if !strings.HasPrefix(line, "import ") {
+			return src, false
+		}
+	}
+	for _, imp := range imports {
+		stmt := "import " + imp + ";"
+		if slices.Contains(block, stmt) {
+			continue
+		}
+		at := len(block)
+		for i, line := range block {
+			if line > stmt {
+				at = i
+				break
+			}
+		}
+		block = slices.Insert(block, at, stmt)
+	}
+	out := append(append(append([]string{}, lines[:first]...), block...), lines[last+1:]...)
+	return strings.Join(out, "\n"), true
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func workerProjectConfig() *config.ProjectConfig {
+	return &config.ProjectConfig{
+		ProjectName: "jobs",
+		GroupID:     "com.test.jobs",
+		ArtifactID:  "jobs",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Jobs", "Worker"},
+		Database:    "postgresql",
+	}
+}
+
+func TestGenerateJob_Scheduled(t *testing.T) {
+	projectPath := generateTestProject(t, workerProjectConfig())
+	recurringRel := "Worker/src/main/java/com/test/jobs/worker/config/RecurringJobsConfig.java"
+	serviceRel := "Jobs/src/main/java/com/test/jobs/jobs/PlaceholderJobService.java"
+	recurringBefore := readFile(t, projectPath, recurringRel)
+
+	opts := JobOpts{Name: "SendDigest", Schedule: "0 0 8 * * *"}
+	planned, err := GenerateJob(projectPath, opts, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if readFile(t, projectPath, recurringRel) != recurringBefore {
+		t.Error("dry run should not edit RecurringJobsConfig")
+	}
+	if len(planned.Notes) != 2 || !strings.HasPrefix(planned.Notes[0], "Would update") {
+		t.Errorf("dry run should report both planned edits, got %v", planned.Notes)
+	}
+
+	result, err := GenerateJob(projectPath, opts, false)
+	if err != nil {
+		t.Fatalf("GenerateJob failed: %v", err)
+	}
+	if len(result.Created) != 4 {
+		t.Errorf("expected request, base handler, handler and test, got %v", result.Created)
+	}
+
+	recurring := readFile(t, projectPath, recurringRel)
+	for _, want := range []string{
+		"import com.test.jobs.model.jobs.SendDigestJobRequest;\nimport org.jobrunr.scheduling.BackgroundJobRequest;",
+		"      BackgroundJobRequest.scheduleRecurrently(\n          \"send-digest\",\n          \"0 0 8 * * *\",\n          new SendDigestJobRequest());\n\n      log.info(\"Recurring jobs registered",
+	} {
+		if !strings.Contains(recurring, want) {
+			t.Errorf("RecurringJobsConfig missing %q:\n%s", want, recurring)
+		}
+	}
+	service := readFile(t, projectPath, serviceRel)
+	if !strings.Contains(service, "  public void enqueueSendDigest() {\n    BackgroundJobRequest.enqueue(new SendDigestJobRequest());\n  }\n}\n") {
+		t.Errorf("PlaceholderJobService should end with enqueueSendDigest:\n%s", service)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "Worker/src/test/java/com/test/jobs/worker/handler/SendDigestJobRequestHandlerTest.java")); err != nil {
+		t.Errorf("expected handler test: %v", err)
+	}
+}
+
+func TestGenerateJob_PayloadWithMissingAnchors(t *testing.T) {
+	projectPath := generateTestProject(t, workerProjectConfig())
+	servicePath := filepath.Join(projectPath, "Jobs/src/main/java/com/test/jobs/jobs/PlaceholderJobService.java")
+	if err := os.Remove(servicePath); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := GenerateJob(projectPath, JobOpts{Name: "ReindexOrder", Payload: "orderId:uuid"}, false)
+	if err != nil {
+		t.Fatalf("a missing service should not fail the job: %v", err)
+	}
+	if len(result.Notes) != 0 {
+		t.Errorf("nothing should be reported as updated, got %v", result.Notes)
+	}
+	manual := strings.Join(result.NextSteps, "\n")
+	if !strings.Contains(manual, "public void enqueueReindexOrder(UUID orderId) {") {
+		t.Errorf("the enqueue method should be handed over as a next step, got:\n%s", manual)
+	}
+}
+
+func TestGenerateJob_Validation(t *testing.T) {
+	projectPath := generateTestProject(t, workerProjectConfig())
+	for _, tc := range []struct {
+		opts JobOpts
+		want string
+	}{
+		{JobOpts{Name: "Tick", Schedule: "* * * * * *"}, "once a minute"},
+		{JobOpts{Name: "Tick", Schedule: "0 8 * *"}, "5 or 6 cron fields"},
+		{JobOpts{Name: "Tick", Schedule: "0 8 * * ; rm"}, "unexpected characters"},
+		{JobOpts{Name: "Tick", Schedule: "0 8 * * *", Payload: "x:string"}, "cannot be combined"},
+	} {
+		if _, err := GenerateJob(projectPath, tc.opts, true); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: expected error containing %q, got %v", tc.opts, tc.want, err)
+		}
+	}
+	if _, err := GenerateJob(projectPath, JobOpts{Name: "Tick", Schedule: "*/15 * * * *"}, true); err != nil {
+		t.Errorf("a 5-field cron should be accepted: %v", err)
+	}
+}
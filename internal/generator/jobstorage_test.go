@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_JobStorageMySQL(t *testing.T) {
+	projectPath := generateTestProject(t, &config.ProjectConfig{
+		ProjectName:   "job-shop",
+		GroupID:       "com.test.jobshop",
+		ArtifactID:    "job-shop",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "NoSQLDatastore", "Shared", "API", "Jobs", "Worker"},
+		NoSQLDatabase: config.DatabaseRedis,
+		JobStorage:    config.JobStorageMySQL,
+	})
+
+	var compose struct {
+		Services map[string]struct {
+			Ports       []string          `yaml:"ports"`
+			Environment map[string]string `yaml:"environment"`
+		} `yaml:"services"`
+		Volumes map[string]interface{} `yaml:"volumes"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, projectPath, "docker-compose.yml")), &compose); err != nil {
+		t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+	}
+	if _, ok := compose.Services["postgres-jobrunr"]; ok {
+		t.Error("--job-storage mysql should not add postgres-jobrunr")
+	}
+	mysql, ok := compose.Services["mysql-jobrunr"]
+	if !ok || mysql.Environment["MYSQL_DATABASE"] != "job_shop_jobs" {
+		t.Fatalf("docker-compose.yml should run mysql-jobrunr with the job_shop_jobs database, got %+v", mysql)
+	}
+	if _, ok := compose.Volumes["mysql_jobrunr_data"]; !ok {
+		t.Error("mysql-jobrunr needs its own volume")
+	}
+
+	url := "jdbc:mysql://localhost:3308/job_shop_jobs"
+	for _, rel := range []string{"Worker/src/main/resources/application.yml", "API/src/main/resources/application.yml"} {
+		if !strings.Contains(readFile(t, projectPath, rel), url) {
+			t.Errorf("%s should point JobRunr at %s", rel, url)
+		}
+	}
+	pom := readFile(t, projectPath, "Worker/pom.xml")
+	if !strings.Contains(pom, "mysql-connector-j") || strings.Contains(pom, "<artifactId>postgresql</artifactId>") {
+		t.Error("Worker/pom.xml should carry the MySQL driver instead of PostgreSQL")
+	}
+}
+
+func TestGenerator_Generate_JobStorageInMemory(t *testing.T) {
+	projectPath := generateTestProject(t, &config.ProjectConfig{
+		ProjectName: "memjobs",
+		GroupID:     "com.test.memjobs",
+		ArtifactID:  "memjobs",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "Jobs", "Worker"},
+		Database:    config.DatabasePostgreSQL,
+		JobStorage:  config.JobStorageInMemory,
+	})
+
+	worker := readFile(t, projectPath, "Worker/src/main/resources/application.yml")
+	if !strings.Contains(worker, "type: mem") || strings.Contains(worker, "type: sql") {
+		t.Error("JobRunr should use in-memory storage")
+	}
+	if !strings.Contains(worker, "url: jdbc:postgresql://localhost:5433/memjobs") {
+		t.Error("the Worker keeps the SQLDatastore datasource for application data")
+	}
+	if compose := readFile(t, projectPath, "docker-compose.yml"); strings.Contains(compose, "jobrunr:") {
+		t.Error("in-memory storage needs no JobRunr database container")
+	}
+	if !strings.Contains(readFile(t, projectPath, "Worker/src/main/java/com/test/memjobs/worker/config/JobRunrConfig.java"), "In-memory storage") {
+		t.Error("JobRunrConfig should document the in-memory storage")
+	}
+}
+
+func TestGenerator_Generate_JobRunrFeatures(t *testing.T) {
+	projectPath := generateTestProject(t, &config.ProjectConfig{
+		ProjectName:       "greenjobs",
+		GroupID:           "com.test.greenjobs",
+		ArtifactID:        "greenjobs",
+		JavaVersion:       "21",
+		Modules:           []string{"Model", "SQLDatastore", "Shared", "Jobs", "Worker"},
+		Database:          config.DatabasePostgreSQL,
+		JobRunrDashboard:  true,
+		JobRunrBatches:    true,
+		JobRunrCarbonArea: "BE",
+	})
+
+	var compose struct {
+		Services map[string]struct {
+			Ports       []string          `yaml:"ports"`
+			Environment map[string]string `yaml:"environment"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, projectPath, "docker-compose.yml")), &compose); err != nil {
+		t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+	}
+	worker, ok := compose.Services["worker"]
+	if !ok {
+		t.Fatal("docker-compose.yml should have a worker service")
+	}
+	if !strings.Contains(strings.Join(worker.Ports, " "), "127.0.0.1:8000:8000") {
+		t.Errorf("the worker service should publish the dashboard, got ports %v", worker.Ports)
+	}
+	if _, ok := worker.Environment["JOBRUNR_DASHBOARD_PASSWORD"]; !ok {
+		t.Error("the worker service should pass the dashboard password")
+	}
+
+	yml := readFile(t, projectPath, "Worker/src/main/resources/application.yml")
+	for _, want := range []string{
+		"enabled: ${JOBRUNR_DASHBOARD_ENABLED:true}",
+		"worker-count: ${JOBRUNR_WORKER_COUNT:16}",
+		"area-code: ${JOBRUNR_CARBON_AREA_CODE:BE}",
+	} {
+		if !strings.Contains(yml, want) {
+			t.Errorf("Worker application.yml should contain %q", want)
+		}
+	}
+	if !strings.Contains(readFile(t, projectPath, ".env.example"), "JOBRUNR_CARBON_AREA_CODE=BE") {
+		t.Error(".env.example should carry the carbon area")
+	}
+}
@@ -0,0 +1,420 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// jpmsModuleNames maps package prefixes to the module that provides them:
+// the JDK's own modules, module-info names of libraries that ship one and
+// Automatic-Module-Name entries of those that don't. The longest matching
+// prefix wins. java.base needs no requires and maps to "". Libraries
+// missing from this table keep their users on the classpath, because a
+// wrong guess fails the build with "module not found".
+var jpmsModuleNames = map[string]string{
+	"java":                        "",
+	"javax.crypto":                "",
+	"javax.net":                   "",
+	"javax.security.auth":         "",
+	"java.sql":                    "java.sql",
+	"javax.sql":                   "java.sql",
+	"java.net.http":               "java.net.http",
+	"java.util.logging":           "java.logging",
+	"java.lang.management":        "java.management",
+	"javax.annotation.processing": "java.compiler",
+
+	"org.springframework.aop":         "spring.aop",
+	"org.aopalliance":                 "spring.aop",
+	"org.springframework.aot":         "spring.core",
+	"org.springframework.core":        "spring.core",
+	"org.springframework.lang":        "spring.core",
+	"org.springframework.util":        "spring.core",
+	"org.springframework.beans":       "spring.beans",
+	"org.springframework.cache":       "spring.context",
+	"org.springframework.context":     "spring.context",
+	"org.springframework.format":      "spring.context",
+	"org.springframework.scheduling":  "spring.context",
+	"org.springframework.stereotype":  "spring.context",
+	"org.springframework.validation":  "spring.context",
+	"org.springframework.expression":  "spring.expression",
+	"org.springframework.dao":         "spring.tx",
+	"org.springframework.transaction": "spring.tx",
+	"org.springframework.jdbc":        "spring.jdbc",
+	"org.springframework.http":        "spring.web",
+	"org.springframework.web":         "spring.web",
+	"org.springframework.web.servlet": "spring.webmvc",
+	"org.springframework.messaging":   "spring.messaging",
+	"org.springframework.retry":       "spring.retry",
+	"org.springframework.integration": "spring.integration.core",
+	"org.springframework.kafka":       "spring.kafka",
+	"org.springframework.amqp":        "spring.amqp",
+	"org.springframework.amqp.rabbit": "spring.rabbit",
+
+	"org.springframework.boot":                       "spring.boot",
+	"org.springframework.boot.autoconfigure":         "spring.boot.autoconfigure",
+	"org.springframework.boot.actuate":               "spring.boot.actuator",
+	"org.springframework.boot.actuate.autoconfigure": "spring.boot.actuator.autoconfigure",
+
+	"org.springframework.data":            "spring.data.commons",
+	"org.springframework.data.jdbc":       "spring.data.jdbc",
+	"org.springframework.data.relational": "spring.data.relational",
+	"org.springframework.data.mongodb":    "spring.data.mongodb",
+	"org.springframework.data.redis":      "spring.data.redis",
+	"org.springframework.data.cassandra":  "spring.data.cassandra",
+
+	"org.springframework.data.elasticsearch": "spring.data.elasticsearch",
+
+	// CaffeineCacheManager lives in spring-context-support, unlike the
+	// rest of org.springframework.cache
+	"org.springframework.cache.caffeine": "spring.context.support",
+
+	"org.springframework.security":                        "spring.security.core",
+	"org.springframework.security.crypto":                 "spring.security.crypto",
+	"org.springframework.security.config":                 "spring.security.config",
+	"org.springframework.security.web":                    "spring.security.web",
+	"org.springframework.security.oauth2.core":            "spring.security.oauth2.core",
+	"org.springframework.security.oauth2.jwt":             "spring.security.oauth2.jose",
+	"org.springframework.security.oauth2.jose":            "spring.security.oauth2.jose",
+	"org.springframework.security.oauth2.server.resource": "spring.security.oauth2.resource.server",
+
+	"com.fasterxml.jackson.annotation":      "com.fasterxml.jackson.annotation",
+	"com.fasterxml.jackson.core":            "com.fasterxml.jackson.core",
+	"com.fasterxml.jackson.databind":        "com.fasterxml.jackson.databind",
+	"com.fasterxml.jackson.datatype.jsr310": "com.fasterxml.jackson.datatype.jsr310",
+
+	// Spring Boot's web starter gets the Servlet API from embedded Tomcat
+	"jakarta.annotation": "jakarta.annotation",
+	"jakarta.servlet":    "org.apache.tomcat.embed.core",
+	"jakarta.validation": "jakarta.validation",
+
+	"io.github.resilience4j.circuitbreaker":            "io.github.resilience4j.circuitbreaker",
+	"io.github.resilience4j.circuitbreaker.annotation": "io.github.resilience4j.annotations",
+	"io.github.resilience4j.retry":                     "io.github.resilience4j.retry",
+	"io.github.resilience4j.retry.annotation":          "io.github.resilience4j.annotations",
+	"io.swagger.v3.oas.annotations":                    "io.swagger.v3.oas.annotations",
+	"io.swagger.v3.oas.models":                         "io.swagger.v3.oas.models",
+	"org.springdoc.core":                               "org.springdoc.openapi.common",
+	"org.apache.kafka":                                 "kafka.clients",
+	"org.flywaydb.core":                                "org.flywaydb.core",
+	"org.immutables.value":                             "org.immutables.value",
+	"org.jobrunr":                                      "org.jobrunr",
+	"org.slf4j":                                        "org.slf4j",
+	"software.amazon.awssdk.services.sqs":              "software.amazon.awssdk.services.sqs",
+	"software.amazon.awssdk.services.dynamodb":         "software.amazon.awssdk.services.dynamodb",
+	"software.amazon.awssdk.enhanced.dynamodb":         "software.amazon.awssdk.enhanced.dynamodb",
+	"com.datastax.oss.driver.api.core":                 "com.datastax.oss.driver.core",
+	"com.datastax.oss.driver.api.querybuilder":         "com.datastax.oss.driver.querybuilder",
+}
+
+// jpmsStaticModules are only needed at compile time (annotation
+// processors and the annotations they read)
+var jpmsStaticModules = map[string]bool{
+	"org.immutables.value": true,
+	"java.compiler":        true,
+}
+
+var (
+	javaCommentRe = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	javaStringRe  = regexp.MustCompile(`(?s)""".*?"""|"(?:\\.|[^"\\\n])*"|'(?:\\.|[^'\\\n])*'`)
+	javaImportRe  = regexp.MustCompile(`(?m)^\s*import\s+(static\s+)?([\w.]+?)(\.\*)?\s*;`)
+	// Fully qualified names used inline, e.g. @org.immutables.value.Value.Immutable
+	javaQualifiedRe = regexp.MustCompile(`\b((?:java|javax|jakarta|org|com|io|software)(?:\.[a-z_][a-z0-9_]*)+)\.[A-Z]`)
+)
+
+// jpmsModule is what a Maven module's main sources declare and use
+type jpmsModule struct {
+	name     string          // Maven module, e.g. "Shared"
+	packages []string        // packages with at least one source file
+	uses     map[string]bool // referenced packages outside java.lang
+	requires map[string]bool // resolved module names, project modules included
+	skip     string          // why the module stays on the classpath
+}
+
+// generateModuleInfos writes src/main/java/module-info.java into every
+// Java module whose dependencies all have known module names. It returns
+// one note per module left on the classpath, with the reason.
+func (g *Generator) generateModuleInfos() ([]string, error) {
+	if !g.config.HasJPMS() {
+		return nil, nil
+	}
+
+	byPrefix := map[string]string{} // "com.acme.shop.shared" -> "Shared"
+	var modules []*jpmsModule
+	for _, name := range g.config.Modules {
+		// A template pack's package layout is up to the pack
+		if config.IsPluginModule(name) {
+			continue
+		}
+		prefix := g.config.GroupID + "." + strings.ToLower(name)
+		byPrefix[prefix] = name
+		m, err := g.scanJPMSModule(name)
+		if err != nil {
+			return nil, err
+		}
+		if name == config.ModuleClientSDK {
+			m.skip = "its client sources are generated from the OpenAPI spec at build time"
+		}
+		modules = append(modules, m)
+	}
+
+	byName := map[string]*jpmsModule{}
+	for _, m := range modules {
+		byName[m.name] = m
+		if m.skip == "" {
+			m.resolveRequires(g.config.GroupID, byPrefix)
+		}
+	}
+
+	// A module can only require named modules, so skipping one pushes
+	// every module that depends on it onto the classpath too
+	for changed := true; changed; {
+		changed = false
+		for _, m := range modules {
+			if m.skip != "" {
+				continue
+			}
+			for req := range m.requires {
+				if dep, ok := byName[req]; ok && dep.skip != "" {
+					m.skip = "it depends on " + dep.name + ", which stays on the classpath"
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var notes []string
+	for _, m := range modules {
+		if m.skip != "" {
+			notes = append(notes, fmt.Sprintf("JPMS: %s stays on the classpath: %s", m.name, m.skip))
+			continue
+		}
+		content := g.moduleInfo(m, modules)
+		if err := g.writeFile(filepath.Join(g.outDir, m.name, "src", "main", "java", "module-info.java"), content); err != nil {
+			return nil, err
+		}
+	}
+	return notes, nil
+}
+
+// scanJPMSModule collects the packages a module declares and the packages
+// its main sources reference through imports and qualified names
+func (g *Generator) scanJPMSModule(name string) (*jpmsModule, error) {
+	m := &jpmsModule{name: name, uses: map[string]bool{}, requires: map[string]bool{}}
+	root := filepath.Join(g.outDir, name, "src", "main", "java")
+	seen := map[string]bool{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".java") || d.Name() == "module-info.java" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if pkg := strings.ReplaceAll(filepath.ToSlash(rel), "/", "."); pkg != "." && !seen[pkg] {
+			seen[pkg] = true
+			m.packages = append(m.packages, pkg)
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, pkg := range javaReferencedPackages(string(src)) {
+			m.uses[pkg] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s sources: %w", name, err)
+	}
+	sort.Strings(m.packages)
+	return m, nil
+}
+
+// javaReferencedPackages returns the packages a Java source file refers to
+func javaReferencedPackages(src string) []string {
+	src = javaCommentRe.ReplaceAllString(src, "")
+	src = javaStringRe.ReplaceAllString(src, `""`)
+
+	var pkgs []string
+	for _, match := range javaImportRe.FindAllStringSubmatch(src, -1) {
+		isStatic, name, wildcard := match[1] != "", match[2], match[3] != ""
+		segments := strings.Split(name, ".")
+		if isStatic && !wildcard {
+			segments = segments[:len(segments)-1] // the imported member
+		}
+		pkgs = append(pkgs, javaPackageOf(segments))
+	}
+	body := javaImportRe.ReplaceAllString(src, "")
+	for _, match := range javaQualifiedRe.FindAllStringSubmatch(body, -1) {
+		pkgs = append(pkgs, match[1])
+	}
+	return pkgs
+}
+
+// javaPackageOf drops class names (the first capitalized segment onwards)
+// from a qualified name
+func javaPackageOf(segments []string) string {
+	for i, s := range segments {
+		if s != "" && s[0] >= 'A' && s[0] <= 'Z' {
+			return strings.Join(segments[:i], ".")
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+// resolveRequires turns the referenced packages into module names, or sets
+// skip when one of them has no known module
+func (m *jpmsModule) resolveRequires(groupID string, byPrefix map[string]string) {
+	var unknown []string
+	for pkg := range m.uses {
+		if pkg == "" || pkg == "java.lang" {
+			continue
+		}
+		if strings.HasPrefix(pkg+".", groupID+".") {
+			owner, ok := projectModuleOf(pkg, byPrefix)
+			if !ok {
+				unknown = append(unknown, pkg)
+			} else if owner != m.name {
+				m.requires[owner] = true
+			}
+			continue
+		}
+		module, ok := lookupJPMSModule(pkg)
+		if !ok {
+			unknown = append(unknown, pkg)
+		} else if module != "" {
+			m.requires[module] = true
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		m.skip = "no module name known for " + unknown[0]
+		if len(unknown) > 1 {
+			m.skip += fmt.Sprintf(" (and %d more packages)", len(unknown)-1)
+		}
+		return
+	}
+	// Immutables-generated classes are annotated with
+	// @javax.annotation.processing.Generated
+	if m.requires["org.immutables.value"] {
+		m.requires["java.compiler"] = true
+	}
+}
+
+// projectModuleOf returns the Maven module whose root package contains pkg
+func projectModuleOf(pkg string, byPrefix map[string]string) (string, bool) {
+	for prefix, name := range byPrefix {
+		if pkg == prefix || strings.HasPrefix(pkg, prefix+".") {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// lookupJPMSModule finds the module providing pkg by longest prefix
+func lookupJPMSModule(pkg string) (string, bool) {
+	for p := pkg; p != ""; {
+		if module, ok := jpmsModuleNames[p]; ok {
+			return module, true
+		}
+		i := strings.LastIndex(p, ".")
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return "", false
+}
+
+// jpmsName is the module name of a Maven module: its root package
+func (g *Generator) jpmsName(module string) string {
+	return g.config.GroupID + "." + strings.ToLower(module)
+}
+
+// moduleInfo renders a module's descriptor. The module is open because
+// Spring, Jackson and the data mappers reflect on its classes. Model, the
+// shared vocabulary, exports every package; the other modules export a
+// package only to the modules that use it.
+func (g *Generator) moduleInfo(m *jpmsModule, modules []*jpmsModule) string {
+	var b strings.Builder
+	b.WriteString("/**\n")
+	b.WriteString(" * Generated by Trabuco from the imports of this module's main sources.\n")
+	b.WriteString(" * 'trabuco add' regenerates it, so keep hand edits in the sources.\n")
+	b.WriteString(" */\n")
+	fmt.Fprintf(&b, "open module %s {\n", g.jpmsName(m.name))
+
+	var project, libraries []string
+	for req := range m.requires {
+		if _, isProject := findJPMSModule(modules, req); isProject {
+			project = append(project, g.jpmsName(req))
+		} else {
+			libraries = append(libraries, req)
+		}
+	}
+	sort.Strings(project)
+	sort.Strings(libraries)
+	for _, req := range project {
+		fmt.Fprintf(&b, "  requires %s;\n", req)
+	}
+	if len(project) > 0 && len(libraries) > 0 {
+		b.WriteString("\n")
+	}
+	for _, req := range libraries {
+		if jpmsStaticModules[req] {
+			fmt.Fprintf(&b, "  requires static %s;\n", req)
+		} else {
+			fmt.Fprintf(&b, "  requires %s;\n", req)
+		}
+	}
+
+	var exports []string
+	for _, pkg := range m.packages {
+		if m.name == config.ModuleModel {
+			exports = append(exports, fmt.Sprintf("  exports %s;\n", pkg))
+			continue
+		}
+		var to []string
+		for _, other := range modules {
+			if other == m || other.skip != "" || !other.uses[pkg] {
+				continue
+			}
+			to = append(to, g.jpmsName(other.name))
+		}
+		if len(to) > 0 {
+			sort.Strings(to)
+			exports = append(exports, fmt.Sprintf("  exports %s to\n      %s;\n", pkg, strings.Join(to, ",\n      ")))
+		}
+	}
+	if len(exports) > 0 && len(m.requires) > 0 {
+		b.WriteString("\n")
+	}
+	for _, e := range exports {
+		b.WriteString(e)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// findJPMSModule looks a project module up by its Maven name
+func findJPMSModule(modules []*jpmsModule, name string) (*jpmsModule, bool) {
+	for _, m := range modules {
+		if m.name == name {
+			return m, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestJavaReferencedPackages(t *testing.T) {
+	src := `package com.test.app.shared.service;
+
+import com.test.app.model.entities.Placeholder;
+import static org.springframework.util.Assert.notNull;
+import org.springframework.data.domain.*;
+// import com.example.commented.Out;
+
+@org.immutables.value.Value.Immutable
+class Service {
+    String s = "see org.example.text.Message";
+}
+`
+	got := javaReferencedPackages(src)
+	want := []string{
+		"com.test.app.model.entities",
+		"org.springframework.util",
+		"org.springframework.data.domain",
+		"org.immutables.value",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("javaReferencedPackages() = %v, want %v", got, want)
+	}
+}
+
+func TestLookupJPMSModule(t *testing.T) {
+	tests := []struct {
+		pkg    string
+		module string
+		known  bool
+	}{
+		{"java.util", "", true},
+		{"java.sql", "java.sql", true},
+		{"org.springframework.web.bind.annotation", "spring.web", true},
+		{"org.springframework.web.servlet.config.annotation", "spring.webmvc", true},
+		{"org.springframework.data.jdbc.repository.query", "spring.data.jdbc", true},
+		{"org.springframework.data.domain", "spring.data.commons", true},
+		{"org.springframework.cache.annotation", "spring.context", true},
+		{"org.springframework.cache.caffeine", "spring.context.support", true},
+		{"io.awspring.cloud.sqs.annotation", "", false},
+	}
+	for _, tt := range tests {
+		module, known := lookupJPMSModule(tt.pkg)
+		if module != tt.module || known != tt.known {
+			t.Errorf("lookupJPMSModule(%q) = %q, %v; want %q, %v", tt.pkg, module, known, tt.module, tt.known)
+		}
+	}
+}
+
+func TestGenerator_Generate_JPMS(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "modular")
+	cfg := &config.ProjectConfig{
+		ProjectName: "modular",
+		GroupID:     "com.test.modular",
+		ArtifactID:  "modular",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+		JPMS:        true,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(module string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(outDir, module, "src", "main", "java", "module-info.java"))
+		if err != nil {
+			t.Fatalf("%s should have a module-info.java: %v", module, err)
+		}
+		return string(content)
+	}
+
+	model := read("Model")
+	for _, want := range []string{
+		"open module com.test.modular.model {",
+		"requires static org.immutables.value;",
+		"exports com.test.modular.model.entities;",
+	} {
+		if !strings.Contains(model, want) {
+			t.Errorf("Model module-info.java should contain %q", want)
+		}
+	}
+
+	datastore := read("SQLDatastore")
+	if !strings.Contains(datastore, "exports com.test.modular.sqldatastore.repository to\n      com.test.modular.shared;") {
+		t.Errorf("SQLDatastore should export its repositories to Shared only:\n%s", datastore)
+	}
+
+	shared := read("Shared")
+	for _, want := range []string{
+		"requires com.test.modular.model;",
+		"requires com.test.modular.sqldatastore;",
+		"exports com.test.modular.shared.service to\n      com.test.modular.api;",
+	} {
+		if !strings.Contains(shared, want) {
+			t.Errorf("Shared module-info.java should contain %q:\n%s", want, shared)
+		}
+	}
+
+	if api := read("API"); strings.Contains(api, "exports") || strings.Contains(api, "requires com.test.modular.sqldatastore;") {
+		t.Errorf("API should export nothing and not read the datastore:\n%s", api)
+	}
+
+	pom, _ := os.ReadFile(filepath.Join(outDir, "pom.xml"))
+	if !strings.Contains(string(pom), "<useModulePath>false</useModulePath>") {
+		t.Error("parent pom.xml should run tests on the classpath")
+	}
+}
+
+func TestGenerator_Generate_JPMSOff(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "classpath")
+	cfg := &config.ProjectConfig{
+		ProjectName: "classpath",
+		GroupID:     "com.test.classpath",
+		ArtifactID:  "classpath",
+		JavaVersion: "21",
+		Modules:     []string{"Model"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "Model", "src", "main", "java", "module-info.java")); !os.IsNotExist(err) {
+		t.Error("module-info.java should only be generated with --jpms")
+	}
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_KafkaListenerSettings(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "ledger")
+	gen, err := NewWithVersionAt(&config.ProjectConfig{
+		ProjectName:          "ledger",
+		GroupID:              "com.test.ledger",
+		ArtifactID:           "ledger",
+		JavaVersion:          "21",
+		Modules:              []string{"Model", "Events", "EventConsumer"},
+		MessageBroker:        config.BrokerKafka,
+		KafkaConsumerGroup:   "{project}.postings",
+		KafkaConcurrency:     6,
+		KafkaMaxPollRecords:  100,
+		KafkaMaxPollInterval: "10m",
+		KafkaAckMode:         config.KafkaAckManual,
+	}, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var yml struct {
+		Spring struct {
+			Kafka struct {
+				Consumer struct {
+					GroupID        string            `yaml:"group-id"`
+					MaxPollRecords string            `yaml:"max-poll-records"`
+					Properties     map[string]string `yaml:"properties"`
+				} `yaml:"consumer"`
+				Listener struct {
+					Concurrency string `yaml:"concurrency"`
+					AckMode     string `yaml:"ack-mode"`
+				} `yaml:"listener"`
+			} `yaml:"kafka"`
+		} `yaml:"spring"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, projectPath, "EventConsumer/src/main/resources/application.yml")), &yml); err != nil {
+		t.Fatalf("application.yml is not valid YAML: %v", err)
+	}
+	kafka := yml.Spring.Kafka
+	for got, want := range map[string]string{
+		kafka.Consumer.GroupID:                            "${KAFKA_CONSUMER_GROUP:ledger.postings}",
+		kafka.Consumer.MaxPollRecords:                     "${KAFKA_MAX_POLL_RECORDS:100}",
+		kafka.Consumer.Properties["max.poll.interval.ms"]: "${KAFKA_MAX_POLL_INTERVAL_MS:600000}",
+		kafka.Listener.Concurrency:                        "${KAFKA_LISTENER_CONCURRENCY:6}",
+		kafka.Listener.AckMode:                            "manual_immediate",
+	} {
+		if got != want {
+			t.Errorf("application.yml has %q, want %q", got, want)
+		}
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			Environment map[string]interface{} `yaml:"environment"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, projectPath, "docker-compose.yml")), &compose); err != nil {
+		t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+	}
+	if got := compose.Services["kafka"].Environment["KAFKA_NUM_PARTITIONS"]; got != 6 {
+		t.Errorf("the local broker should create topics with 6 partitions, got %v", got)
+	}
+
+	base := "EventConsumer/src/main/java/com/test/ledger/eventconsumer/"
+	if !strings.Contains(readFile(t, projectPath, base+"config/KafkaConfig.java"), "setAckMode(kafkaProperties.getListener().getAckMode())") {
+		t.Error("KafkaConfig should apply spring.kafka.listener.ack-mode")
+	}
+	listener := readFile(t, projectPath, base+"listener/PlaceholderEventListener.java")
+	if strings.Count(listener, "acknowledgment.acknowledge();") != 3 {
+		t.Error("the listener should acknowledge handled, duplicate and dead-lettered records")
+	}
+	testBase := "EventConsumer/src/test/java/com/test/ledger/eventconsumer/listener/"
+	if !strings.Contains(readFile(t, projectPath, testBase+"PlaceholderEventListenerTest.java"), "verify(acknowledgment).acknowledge()") {
+		t.Error("PlaceholderEventListenerTest should verify the acknowledgement")
+	}
+	load := readFile(t, projectPath, testBase+"PlaceholderEventListenerLoadTest.java")
+	for _, want := range []string{"CONSUMERS = 6;", "POLL_RECORDS = 100;", "MAX_POLL_INTERVAL_MS = 600000L;", "acks::incrementAndGet"} {
+		if !strings.Contains(load, want) {
+			t.Errorf("PlaceholderEventListenerLoadTest should contain %q", want)
+		}
+	}
+}
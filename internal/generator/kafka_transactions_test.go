@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerator_Generate_KafkaTransactions(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:       "streams",
+		GroupID:           "com.test.streams",
+		ArtifactID:        "streams",
+		JavaVersion:       "21",
+		Modules:           []string{"Model", "API", "Events", "EventConsumer"},
+		MessageBroker:     "kafka",
+		KafkaTransactions: true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("streams", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	base := "EventConsumer/src/main/java/com/test/streams/eventconsumer/"
+	processor := read(base + "listener/PlaceholderEventProcessor.java")
+	if !strings.Contains(processor, `containerFactory = "transactionalKafkaListenerContainerFactory"`) {
+		t.Error("PlaceholderEventProcessor should listen through the transactional container factory")
+	}
+	read("EventConsumer/src/test/java/com/test/streams/eventconsumer/listener/PlaceholderEventProcessorTest.java")
+
+	kafkaConfig := read(base + "config/KafkaConfig.java")
+	for _, want := range []string{
+		"factory.setTransactionIdPrefix(transactionIdPrefix)",
+		"setKafkaAwareTransactionManager(new KafkaTransactionManager<>(producerFactory))",
+		"template.setAllowNonTransactional(true)",
+		"DefaultAfterRollbackProcessor",
+	} {
+		if !strings.Contains(kafkaConfig, want) {
+			t.Errorf("KafkaConfig should contain %q", want)
+		}
+	}
+	// A TransactionManager bean would switch off the JDBC one
+	if strings.Contains(kafkaConfig, "public KafkaTransactionManager") {
+		t.Error("the Kafka transaction manager should not be a bean")
+	}
+
+	var yml struct {
+		Spring struct {
+			Kafka struct {
+				Consumer map[string]any `yaml:"consumer"`
+			} `yaml:"kafka"`
+		} `yaml:"spring"`
+		App struct {
+			Kafka struct {
+				Topics       map[string]any `yaml:"topics"`
+				Transactions map[string]any `yaml:"transactions"`
+			} `yaml:"kafka"`
+		} `yaml:"app"`
+	}
+	if err := yaml.Unmarshal([]byte(read("EventConsumer/src/main/resources/application.yml")), &yml); err != nil {
+		t.Fatalf("application.yml should parse: %v", err)
+	}
+	if yml.Spring.Kafka.Consumer["isolation-level"] != "read_committed" {
+		t.Errorf("consumers should read committed records only, got %+v", yml.Spring.Kafka.Consumer)
+	}
+	if yml.App.Kafka.Transactions["id-prefix"] != "${KAFKA_TRANSACTION_ID_PREFIX:streams-tx-${random.uuid}-}" {
+		t.Errorf("app.kafka.transactions should set the id prefix, got %+v", yml.App.Kafka.Transactions)
+	}
+	if yml.App.Kafka.Topics["placeholder-processed"] == nil {
+		t.Error("app.kafka.topics should name the processed topic")
+	}
+
+	if !strings.Contains(read("docker-compose.yml"), "KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR: 1") {
+		t.Error("the single compose broker should replicate the transaction log once")
+	}
+	if !strings.Contains(read("README.md"), "## Exactly-Once Processing") {
+		t.Error("README should document the exactly-once guarantees")
+	}
+
+	metadata, err := config.LoadMetadata(filepath.Join(tempDir, "streams"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.KafkaTransactions {
+		t.Error("metadata should persist kafkaTransactions")
+	}
+}
+
+func TestGenerator_Generate_KafkaTransactionsWithoutKafka(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:       "rabbit",
+		GroupID:           "com.test.rabbit",
+		ArtifactID:        "rabbit",
+		JavaVersion:       "21",
+		Modules:           []string{"Model", "API", "Events", "EventConsumer"},
+		MessageBroker:     "rabbitmq",
+		KafkaTransactions: true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	processor := filepath.Join("rabbit", "EventConsumer/src/main/java/com/test/rabbit/eventconsumer/listener/PlaceholderEventProcessor.java")
+	if _, err := os.Stat(processor); !os.IsNotExist(err) {
+		t.Error("the transactional processor should only be generated for Kafka")
+	}
+}
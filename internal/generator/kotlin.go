@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// sourceTemplate returns the template to render and the file to write for a
+// Java source template. Kotlin projects use the Kotlin equivalent under
+// kotlin/ (same relative path, .kt.tmpl) when there is one and write it to
+// src/main/kotlin or src/test/kotlin. Templates without a Kotlin equivalent
+// stay Java; the mixed-source build compiles both.
+func (g *Generator) sourceTemplate(templatePath, outputPath string) (string, string) {
+	if !g.config.IsKotlin() || !strings.HasPrefix(templatePath, "java/") || !strings.HasSuffix(templatePath, ".java.tmpl") {
+		return templatePath, outputPath
+	}
+	kotlinTemplate := "kotlin/" + strings.TrimSuffix(strings.TrimPrefix(templatePath, "java/"), ".java.tmpl") + ".kt.tmpl"
+	if !g.engine.TemplateExists(kotlinTemplate) {
+		return templatePath, outputPath
+	}
+	return kotlinTemplate, kotlinSourcePath(outputPath)
+}
+
+// sourcePath returns the file writeTemplate will actually write for
+// templatePath, so callers that back up or stat the file before
+// regenerating it see the Kotlin file in Kotlin projects.
+func (g *Generator) sourcePath(templatePath, outputPath string) string {
+	_, outputPath = g.sourceTemplate(templatePath, outputPath)
+	return outputPath
+}
+
+// kotlinSourcePath maps a Java source file path to its Kotlin counterpart,
+// e.g. Model/src/main/java/.../Placeholder.java → Model/src/main/kotlin/.../Placeholder.kt
+func kotlinSourcePath(javaPath string) string {
+	p := filepath.ToSlash(javaPath)
+	p = strings.Replace(p, "/src/main/java/", "/src/main/kotlin/", 1)
+	p = strings.Replace(p, "/src/test/java/", "/src/test/kotlin/", 1)
+	if strings.HasSuffix(p, ".java") {
+		p = strings.TrimSuffix(p, ".java") + ".kt"
+	}
+	return filepath.FromSlash(p)
+}
@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_Kotlin(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "kt-app",
+		GroupID:       "com.test.ktapp",
+		ArtifactID:    "kt-app",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker", "Events", "EventConsumer"},
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+		Language:      config.LanguageKotlin,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("kt-app", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join("kt-app", path))
+		return err == nil
+	}
+
+	for _, path := range []string{
+		"Model/src/main/kotlin/com/test/ktapp/model/entities/Placeholder.kt",
+		"Model/src/main/kotlin/com/test/ktapp/model/entities/PlaceholderRecord.kt",
+		"Model/src/main/kotlin/com/test/ktapp/model/events/PlaceholderEvent.kt",
+		"Model/src/main/kotlin/com/test/ktapp/model/jobs/ProcessPlaceholderJobRequest.kt",
+		"Shared/src/main/kotlin/com/test/ktapp/shared/service/PlaceholderService.kt",
+		"Shared/src/test/kotlin/com/test/ktapp/shared/service/PlaceholderServiceTest.kt",
+		"API/src/main/kotlin/com/test/ktapp/api/controller/PlaceholderController.kt",
+		"API/src/main/kotlin/com/test/ktapp/api/controller/EventController.kt",
+		"Events/src/main/kotlin/com/test/ktapp/events/EventPublisher.kt",
+		"EventConsumer/src/main/kotlin/com/test/ktapp/eventconsumer/listener/PlaceholderEventListener.kt",
+		"EventConsumer/src/test/kotlin/com/test/ktapp/eventconsumer/listener/PlaceholderEventListenerTest.kt",
+		"SQLDatastore/src/main/kotlin/com/test/ktapp/sqldatastore/repository/PlaceholderRepository.kt",
+		"Jobs/src/main/kotlin/com/test/ktapp/jobs/PlaceholderJobService.kt",
+		"API/src/main/kotlin/com/test/ktapp/api/controller/PlaceholderJobController.kt",
+		"API/src/main/kotlin/com/test/ktapp/api/controller/HealthController.kt",
+		"Worker/src/main/kotlin/com/test/ktapp/worker/handler/ProcessPlaceholderJobRequestHandler.kt",
+		"Worker/src/test/kotlin/com/test/ktapp/worker/handler/ProcessPlaceholderJobRequestHandlerTest.kt",
+	} {
+		if !exists(path) {
+			t.Errorf("expected Kotlin source %s", path)
+		}
+		if javaPath := strings.Replace(strings.TrimSuffix(path, ".kt")+".java", "/kotlin/", "/java/", 1); exists(javaPath) {
+			t.Errorf("%s should not be generated alongside its Kotlin equivalent", javaPath)
+		}
+	}
+
+	// Templates without a Kotlin equivalent stay Java.
+	for _, path := range []string{
+		"Model/src/main/java/com/test/ktapp/model/ImmutableStyle.java",
+		"Worker/src/main/java/com/test/ktapp/worker/config/JobRunrConfig.java",
+	} {
+		if !exists(path) {
+			t.Errorf("expected Java source %s", path)
+		}
+	}
+
+	if placeholder := read("Model/src/main/kotlin/com/test/ktapp/model/entities/Placeholder.kt"); !strings.Contains(placeholder, "data class Placeholder(") {
+		t.Error("Placeholder.kt should declare a data class")
+	}
+	if repository := read("SQLDatastore/src/main/kotlin/com/test/ktapp/sqldatastore/repository/PlaceholderRepository.kt"); !strings.Contains(repository, "fun findByName(name: String): PlaceholderRecord?") {
+		t.Error("PlaceholderRepository.kt should return nullable types instead of Optional")
+	}
+
+	pom := read("pom.xml")
+	if err := xml.Unmarshal([]byte(pom), new(struct{})); err != nil {
+		t.Fatalf("pom.xml is not well-formed: %v", err)
+	}
+	for _, want := range []string{"<kotlin.version>", "kotlin-maven-plugin", "<plugin>spring</plugin>", "jackson-module-kotlin", "<ktfmt>"} {
+		if !strings.Contains(pom, want) {
+			t.Errorf("pom.xml missing %q", want)
+		}
+	}
+
+	var metadata config.ProjectMetadata
+	if err := json.Unmarshal([]byte(read(".trabuco.json")), &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Language != config.LanguageKotlin {
+		t.Errorf("metadata language = %q, want kotlin", metadata.Language)
+	}
+}
+
+func TestGenerator_Generate_KotlinVariants(t *testing.T) {
+	variants := []struct {
+		name string
+		cfg  config.ProjectConfig
+	}{
+		{"mongodb", config.ProjectConfig{Modules: []string{"Model", "NoSQLDatastore", "Shared", "API"}, NoSQLDatabase: "mongodb"}},
+		{"redis-no-shared", config.ProjectConfig{Modules: []string{"Model", "NoSQLDatastore", "API"}, NoSQLDatabase: "redis"}},
+		{"sql-no-shared", config.ProjectConfig{Modules: []string{"Model", "SQLDatastore", "API"}, Database: "mysql"}},
+		{"no-datastore", config.ProjectConfig{Modules: []string{"Model", "Shared", "API", "Events", "EventConsumer"}, MessageBroker: "sqs"}},
+	}
+	for _, v := range variants {
+		t.Run(v.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := v.cfg
+			cfg.ProjectName = "kt-app"
+			cfg.GroupID = "com.test.ktapp"
+			cfg.ArtifactID = "kt-app"
+			cfg.JavaVersion = "21"
+			cfg.Language = config.LanguageKotlin
+			gen, err := New(&cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			controller, err := os.ReadFile(filepath.Join("kt-app", "API/src/main/kotlin/com/test/ktapp/api/controller/PlaceholderController.kt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(controller), "class PlaceholderController") {
+				t.Error("PlaceholderController.kt should declare the controller class")
+			}
+		})
+	}
+}
+
+func TestKotlinSourcePath(t *testing.T) {
+	tests := map[string]string{
+		"Model/src/main/java/com/x/model/Placeholder.java":   "Model/src/main/kotlin/com/x/model/Placeholder.kt",
+		"Shared/src/test/java/com/x/shared/ServiceTest.java": "Shared/src/test/kotlin/com/x/shared/ServiceTest.kt",
+		"Model/src/main/kotlin/com/x/model/Placeholder.kt":   "Model/src/main/kotlin/com/x/model/Placeholder.kt",
+	}
+	for in, want := range tests {
+		if got := filepath.ToSlash(kotlinSourcePath(filepath.FromSlash(in))); got != want {
+			t.Errorf("kotlinSourcePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
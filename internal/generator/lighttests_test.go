@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_LightTests(t *testing.T) {
+	tests := []struct {
+		name      string
+		language  string
+		database  string
+		nosql     string
+		modules   []string
+		lightTest string
+		noLight   string
+	}{
+		{"java-mysql", "java", "mysql", "", []string{"Model", "SQLDatastore", "Shared", "API"},
+			"SQLDatastore/src/test/java/com/test/light/sqldatastore/repository/PlaceholderRepositoryLightTest.java", ""},
+		{"kotlin-mongodb", "kotlin", "", "mongodb", []string{"Model", "NoSQLDatastore", "Shared", "API"},
+			"NoSQLDatastore/src/test/kotlin/com/test/light/nosqldatastore/repository/PlaceholderDocumentRepositoryLightTest.kt", ""},
+		{"java-redis", "java", "", "redis", []string{"Model", "NoSQLDatastore", "Shared", "API"}, "",
+			"NoSQLDatastore/src/test/java/com/test/light/nosqldatastore/repository/PlaceholderDocumentRepositoryLightTest.java"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName:   "light",
+				GroupID:       "com.test.light",
+				ArtifactID:    "light",
+				JavaVersion:   "21",
+				Language:      tt.language,
+				Modules:       tt.modules,
+				Database:      tt.database,
+				NoSQLDatabase: tt.nosql,
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			read := func(path string) string {
+				t.Helper()
+				data, err := os.ReadFile(filepath.Join("light", path))
+				if err != nil {
+					t.Fatalf("expected %s: %v", path, err)
+				}
+				return string(data)
+			}
+
+			parent := read("pom.xml")
+			if !strings.Contains(parent, "<id>light-tests</id>") || !strings.Contains(parent, "<excludedGroups>testcontainers</excludedGroups>") {
+				t.Error("parent POM should have a light-tests profile excluding the testcontainers tag")
+			}
+			if tt.lightTest != "" && !strings.Contains(read(tt.lightTest), `@EnabledIfSystemProperty(named = "trabuco.light-tests", matches = "true")`) {
+				t.Errorf("%s should only run under the light-tests profile", tt.lightTest)
+			}
+			if tt.noLight != "" {
+				if _, err := os.Stat(filepath.Join("light", tt.noLight)); !os.IsNotExist(err) {
+					t.Errorf("%s should not be generated, there is no embedded alternative", tt.noLight)
+				}
+			}
+			if !strings.Contains(read("README.md"), "mvn -Plight-tests test") {
+				t.Error("README should document the light-tests profile")
+			}
+
+			if tt.database != "" {
+				schema := read("SQLDatastore/src/test/resources/light-tests/schema.sql")
+				if strings.Contains(schema, "id BIGSERIAL") || !strings.Contains(schema, "CREATE TABLE IF NOT EXISTS placeholders") {
+					t.Errorf("light-tests schema should be H2-compatible:\n%s", schema)
+				}
+				if !strings.Contains(read("SQLDatastore/pom.xml"), "<artifactId>h2</artifactId>") {
+					t.Error("SQLDatastore should have H2 on the test classpath")
+				}
+			}
+			if tt.nosql == config.DatabaseMongoDB && !strings.Contains(read("NoSQLDatastore/pom.xml"), "de.flapdoodle.embed.mongo") {
+				t.Error("NoSQLDatastore should have embedded mongod on the test classpath")
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package generator
+
+// logParsingFiles maps templates under templates/logging to their place in
+// the generated project
+var logParsingFiles = [][2]string{
+	{"logging/README.md.tmpl", "logging/README.md"},
+	{"logging/fluent-bit.conf.tmpl", "logging/fluent-bit.conf"},
+	{"logging/parsers.conf.tmpl", "logging/parsers.conf"},
+}
+
+// generateLogParsing writes sample Fluent Bit configs that parse the log
+// format of the runtime modules, with a field reference
+func (g *Generator) generateLogParsing() error {
+	if len(g.config.RuntimeTargets()) == 0 {
+		return nil
+	}
+	for _, f := range logParsingFiles {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
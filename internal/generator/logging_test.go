@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_LogFormat(t *testing.T) {
+	tests := []struct {
+		format, broker string
+		logback        []string // in every runtime module's logback-spring.xml
+		logstash       bool     // logstash-logback-encoder in the POMs
+	}{
+		{"", config.BrokerKafka, []string{"LogstashEncoder", "<includeMdcKeyName>trace_id</includeMdcKeyName>"}, true},
+		{config.LogFormatECS, config.BrokerKafka, []string{"StructuredLogEncoder", "<format>ecs</format>"}, false},
+		{config.LogFormatPlain, config.BrokerRabbitMQ, []string{"correlationId=%X{correlationId:--} traceId=%X{trace_id:--}"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+"-"+tt.broker, func(t *testing.T) {
+			projectPath := filepath.Join(t.TempDir(), "logs")
+			cfg := &config.ProjectConfig{
+				ProjectName:   "logs",
+				GroupID:       "com.test.logs",
+				ArtifactID:    "logs",
+				JavaVersion:   "21",
+				Modules:       config.ResolveDependencies([]string{"SQLDatastore", "API", "Worker", "EventConsumer"}),
+				Database:      config.DatabasePostgreSQL,
+				MessageBroker: tt.broker,
+				LogFormat:     tt.format,
+			}
+			gen, err := NewWithVersionAt(cfg, "test", projectPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			for _, module := range []string{"API", "Worker", "EventConsumer"} {
+				logback := readFile(t, projectPath, module+"/src/main/resources/logback-spring.xml")
+				if err := xml.Unmarshal([]byte(logback), new(struct{})); err != nil {
+					t.Errorf("%s logback-spring.xml is not valid XML: %v", module, err)
+				}
+				for _, want := range tt.logback {
+					if !strings.Contains(logback, want) {
+						t.Errorf("%s logback-spring.xml should contain %s", module, want)
+					}
+				}
+				if got := strings.Contains(readFile(t, projectPath, module+"/pom.xml"), "logstash-logback-encoder"); got != tt.logstash {
+					t.Errorf("%s pom.xml has logstash-logback-encoder = %v, want %v", module, got, tt.logstash)
+				}
+			}
+			if got := strings.Contains(readFile(t, projectPath, "pom.xml"), "logstash-logback-encoder.version"); got != tt.logstash {
+				t.Errorf("parent pom.xml has logstash-logback-encoder.version = %v, want %v", got, tt.logstash)
+			}
+
+			parsers := readFile(t, projectPath, "logging/parsers.conf")
+			if !strings.Contains(parsers, "Name        logs-"+cfg.LogFormatOrDefault()) {
+				t.Errorf("logging/parsers.conf should define the %s parser:\n%s", cfg.LogFormatOrDefault(), parsers)
+			}
+			if !strings.Contains(readFile(t, projectPath, "logging/fluent-bit.conf"), "Parser       logs-"+cfg.LogFormatOrDefault()) {
+				t.Error("logging/fluent-bit.conf should use the parser of the log format")
+			}
+
+			pkg := "src/main/java/com/test/logs/"
+			interceptor := readFile(t, projectPath, "EventConsumer/"+pkg+"eventconsumer/config/CorrelationIdInterceptor.java")
+			if tt.broker == config.BrokerKafka {
+				if !strings.Contains(interceptor, "implements RecordInterceptor<String, PlaceholderEvent>") {
+					t.Error("the Kafka consumer should read the correlation ID with a RecordInterceptor")
+				}
+				if !strings.Contains(readFile(t, projectPath, "EventConsumer/"+pkg+"eventconsumer/config/KafkaConfig.java"), "setRecordInterceptor(new CorrelationIdInterceptor())") {
+					t.Error("KafkaConfig should register the interceptor")
+				}
+				if !strings.Contains(readFile(t, projectPath, "Events/"+pkg+"events/config/KafkaPublisherConfig.java"), "INTERCEPTOR_CLASSES_CONFIG") {
+					t.Error("the Events producer should add the correlation ID header")
+				}
+			} else {
+				if !strings.Contains(interceptor, "implements MethodInterceptor") {
+					t.Error("the RabbitMQ consumer should read the correlation ID in container advice")
+				}
+				if !strings.Contains(readFile(t, projectPath, "EventConsumer/"+pkg+"eventconsumer/config/RabbitConfig.java"), "setAdviceChain(new CorrelationIdInterceptor())") {
+					t.Error("RabbitConfig should register the advice")
+				}
+				if !strings.Contains(readFile(t, projectPath, "Events/"+pkg+"events/config/RabbitConfig.java"), "addBeforePublishPostProcessors") {
+					t.Error("the Events RabbitTemplate should add the correlation ID header")
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_LogParsingNeedsRuntimeModule(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "lib")
+	cfg := &config.ProjectConfig{
+		ProjectName: "lib",
+		GroupID:     "com.test.lib",
+		ArtifactID:  "lib",
+		JavaVersion: "21",
+		Modules:     config.ResolveDependencies([]string{"SQLDatastore"}),
+		Database:    config.DatabasePostgreSQL,
+		LogFormat:   config.LogFormatECS,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "logging")); !os.IsNotExist(err) {
+		t.Error("a project without runtime modules has no logs to parse")
+	}
+}
@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Generated docs (README.md, AGENTS.md and the agent context files) are
+// split into named managed regions:
+//
+//	<!-- trabuco:begin quick-start -->
+//	## Quick Start
+//	...
+//	<!-- trabuco:end quick-start -->
+//
+// Regenerating a doc after 'trabuco add' rewrites only the regions; text
+// outside them is user content and is kept as-is, so a project can add its
+// own sections between the generated ones.
+var managedMarkerPattern = regexp.MustCompile(`(?m)^<!-- trabuco:(begin|end) ([A-Za-z0-9_.-]+) -->\r?$`)
+
+// managedRegion is one region of a doc, as byte offsets into its content.
+// start is the start of the begin marker line and end the position after
+// the end marker line (its line ending included).
+type managedRegion struct {
+	name       string
+	start, end int
+}
+
+// parseManagedRegions returns the regions of content in order. Nested,
+// unclosed, stray or repeated markers are an error: a file the user has
+// edited that far is not merged into.
+func parseManagedRegions(content string) ([]managedRegion, error) {
+	var regions []managedRegion
+	seen := map[string]bool{}
+	open := -1
+	for _, m := range managedMarkerPattern.FindAllStringSubmatchIndex(content, -1) {
+		kind, name := content[m[2]:m[3]], content[m[4]:m[5]]
+		switch {
+		case kind == "begin" && open >= 0:
+			return nil, fmt.Errorf("region %q begins inside region %q", name, regions[open].name)
+		case kind == "begin" && seen[name]:
+			return nil, fmt.Errorf("region %q appears more than once", name)
+		case kind == "begin":
+			seen[name] = true
+			regions = append(regions, managedRegion{name: name, start: m[0]})
+			open = len(regions) - 1
+		case open < 0 || regions[open].name != name:
+			return nil, fmt.Errorf("end of region %q without a matching begin", name)
+		default:
+			end := m[1]
+			if end < len(content) && content[end] == '\n' {
+				end++
+			}
+			regions[open].end = end
+			open = -1
+		}
+	}
+	if open >= 0 {
+		return nil, fmt.Errorf("region %q is never closed", regions[open].name)
+	}
+	return regions, nil
+}
+
+// mergeManagedRegions returns existing with its managed regions replaced by
+// the regions of the same name in generated. A region generated for the
+// first time (a section the new module brings) is inserted after the
+// generated region it follows; a region no longer generated is removed.
+// Everything outside the regions is kept, and the regions take the line
+// endings of existing.
+func mergeManagedRegions(existing, generated string) (string, error) {
+	want, err := parseManagedRegions(generated)
+	if err != nil {
+		return "", fmt.Errorf("generated content: %w", err)
+	}
+	have, err := parseManagedRegions(existing)
+	if err != nil {
+		return "", err
+	}
+	if len(have) == 0 {
+		return "", fmt.Errorf("no trabuco:begin/end markers")
+	}
+
+	nl := detectLineEnding(existing)
+	block := func(r managedRegion) string {
+		body := strings.ReplaceAll(generated[r.start:r.end], "\r\n", "\n")
+		if !strings.HasSuffix(body, "\n") {
+			body += "\n"
+		}
+		return strings.ReplaceAll(body, "\n", nl)
+	}
+
+	// Attach each new region to the closest preceding region the file
+	// already has ("" when none precedes it)
+	present := map[string]bool{}
+	for _, r := range have {
+		present[r.name] = true
+	}
+	generatedByName := map[string]managedRegion{}
+	inserts := map[string][]managedRegion{}
+	anchor := ""
+	for _, r := range want {
+		generatedByName[r.name] = r
+		if present[r.name] {
+			anchor = r.name
+			continue
+		}
+		inserts[anchor] = append(inserts[anchor], r)
+	}
+
+	var b strings.Builder
+	pos := 0
+	for i, r := range have {
+		b.WriteString(existing[pos:r.start])
+		if i == 0 {
+			for _, n := range inserts[""] {
+				b.WriteString(block(n))
+			}
+		}
+		if g, ok := generatedByName[r.name]; ok {
+			b.WriteString(block(g))
+			for _, n := range inserts[r.name] {
+				b.WriteString(block(n))
+			}
+		}
+		pos = r.end
+	}
+	b.WriteString(existing[pos:])
+	return b.String(), nil
+}
+
+// detectLineEnding returns "\r\n" for content edited with CRLF line
+// endings, else "\n".
+func detectLineEnding(content string) string {
+	if strings.Contains(content, "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// writeManagedTemplate renders a doc template with data and merges it into
+// outputPath, which is written in full when it does not exist yet. A file
+// without intact managed-region markers is left untouched and reported as
+// not merged, along with the reason.
+func (g *Generator) writeManagedTemplate(templatePath, outputPath string, data interface{}) (merged bool, reason string, err error) {
+	content, err := g.engine.Execute(templatePath, data)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to render template %s: %w", templatePath, err)
+	}
+
+	fullPath := filepath.Join(g.outDir, outputPath)
+	existing, err := os.ReadFile(fullPath)
+	if os.IsNotExist(err) {
+		return true, "", g.writeFile(fullPath, content)
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read %s: %w", outputPath, err)
+	}
+
+	updated, mergeErr := mergeManagedRegions(string(existing), content)
+	if mergeErr != nil {
+		return false, mergeErr.Error(), nil
+	}
+	if updated == string(existing) {
+		return true, "", nil
+	}
+	return true, "", g.writeFile(fullPath, updated)
+}
@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func region(name, body string) string {
+	return "<!-- trabuco:begin " + name + " -->\n" + body + "<!-- trabuco:end " + name + " -->\n"
+}
+
+func TestMergeManagedRegions(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		want      string
+	}{
+		{
+			name:      "rewrites regions and keeps user text",
+			existing:  "Intro\n\n" + region("a", "old a\n") + "## Notes\n\n" + region("b", "old b\n") + "Footer\n",
+			generated: region("a", "new a\n") + region("b", "new b\n"),
+			want:      "Intro\n\n" + region("a", "new a\n") + "## Notes\n\n" + region("b", "new b\n") + "Footer\n",
+		},
+		{
+			name:      "inserts a new region after the one it follows",
+			existing:  region("a", "a\n") + "## Notes\n" + region("c", "c\n"),
+			generated: region("a", "a\n") + region("b", "b\n") + region("c", "c\n"),
+			want:      region("a", "a\n") + region("b", "b\n") + "## Notes\n" + region("c", "c\n"),
+		},
+		{
+			name:      "inserts a new first region before the others",
+			existing:  "Intro\n" + region("b", "b\n"),
+			generated: region("a", "a\n") + region("b", "b\n"),
+			want:      "Intro\n" + region("a", "a\n") + region("b", "b\n"),
+		},
+		{
+			name:      "drops a region no longer generated",
+			existing:  region("a", "a\n") + region("b", "b\n") + "Mine\n",
+			generated: region("b", "b2\n"),
+			want:      region("b", "b2\n") + "Mine\n",
+		},
+		{
+			name:      "keeps CRLF line endings",
+			existing:  strings.ReplaceAll("Intro\n"+region("a", "old\n"), "\n", "\r\n"),
+			generated: region("a", "new\nline\n"),
+			want:      strings.ReplaceAll("Intro\n"+region("a", "new\nline\n"), "\n", "\r\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeManagedRegions(tt.existing, tt.generated)
+			if err != nil {
+				t.Fatalf("mergeManagedRegions failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeManagedRegions_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no markers": "# My README\n",
+		"unclosed":   "<!-- trabuco:begin a -->\ntext\n",
+		"nested":     "<!-- trabuco:begin a -->\n" + region("b", "") + "<!-- trabuco:end a -->\n",
+		"stray end":  "text\n<!-- trabuco:end a -->\n",
+		"mismatched": "<!-- trabuco:begin a -->\n<!-- trabuco:end b -->\n",
+		"repeated":   region("a", "") + region("a", ""),
+	}
+	for name, existing := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := mergeManagedRegions(existing, region("a", "a\n")); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+// TestDocTemplates_ManagedRegions checks every doc template renders
+// balanced regions, whatever the modules
+func TestDocTemplates_ManagedRegions(t *testing.T) {
+	for _, modules := range [][]string{
+		{"Model", "Shared", "API"},
+		{"Model", "SQLDatastore", "Shared", "API", "Worker", "EventConsumer", "AIAgent"},
+	} {
+		cfg := &config.ProjectConfig{
+			ProjectName:   "docs",
+			GroupID:       "com.test.docs",
+			ArtifactID:    "docs",
+			JavaVersion:   "21",
+			Modules:       modules,
+			Database:      "postgresql",
+			MessageBroker: "kafka",
+			AIAgents:      []string{"claude", "cursor"},
+		}
+		gen, err := NewWithVersionAt(cfg, "test", t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tmpl := range []string{"docs/README.md.tmpl", "docs/AGENTS.md.tmpl", "docs/CLAUDE.md.tmpl"} {
+			content, err := gen.engine.Execute(tmpl, &templateData{ProjectConfig: cfg, PromptsDir: ".ai/prompts", Frontmatter: "alwaysApply: true\n"})
+			if err != nil {
+				t.Fatalf("%s: %v", tmpl, err)
+			}
+			regions, err := parseManagedRegions(content)
+			if err != nil || len(regions) < 2 {
+				t.Errorf("%s with %v: expected balanced regions, got %d, %v", tmpl, modules, len(regions), err)
+			}
+		}
+	}
+}
+
+func TestModuleAdder_Add_MergesDocs(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "docs")
+	cfg := &config.ProjectConfig{
+		ProjectName: "docs",
+		GroupID:     "com.test.docs",
+		ArtifactID:  "docs",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+		AIAgents:    []string{"claude"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	readmePath := filepath.Join(projectPath, "README.md")
+	readme, _ := os.ReadFile(readmePath)
+	custom := strings.Replace(string(readme), "<!-- trabuco:begin configuration -->", "## Team notes\n\nOn-call rota lives in the wiki.\n\n<!-- trabuco:begin configuration -->", 1)
+	if custom == string(readme) {
+		t.Fatal("README.md should have a configuration region")
+	}
+	os.WriteFile(readmePath, []byte(custom), 0644)
+	os.WriteFile(filepath.Join(projectPath, "CLAUDE.md"), []byte("# Hand-written context\n"), 0644)
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adder := NewModuleAdder(projectPath, metadata, "test", false)
+	if err := adder.Add(config.ModuleWorker, "", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	merged, _ := os.ReadFile(readmePath)
+	if !strings.Contains(string(merged), "On-call rota lives in the wiki.") {
+		t.Error("user text outside the managed regions should survive the add")
+	}
+	if !strings.Contains(string(merged), "cd Worker") {
+		t.Error("the managed regions should describe the new Worker module")
+	}
+	if claude, _ := os.ReadFile(filepath.Join(projectPath, "CLAUDE.md")); string(claude) != "# Hand-written context\n" {
+		t.Error("a doc without markers should be left untouched")
+	}
+	kept := adder.Report().DocsKept
+	if len(kept) != 1 || kept[0].File != "CLAUDE.md" {
+		t.Errorf("expected CLAUDE.md reported as not regenerated, got %+v", kept)
+	}
+
+	metadata, _ = config.LoadMetadata(projectPath)
+	adder = NewModuleAdder(projectPath, metadata, "test", false)
+	adder.SetForceDocs(true)
+	if err := adder.Add(config.ModuleEventConsumer, "", "", "kafka"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	forced, _ := os.ReadFile(readmePath)
+	if strings.Contains(string(forced), "On-call rota") {
+		t.Error("--force-docs should overwrite user edits")
+	}
+	if claude, _ := os.ReadFile(filepath.Join(projectPath, "CLAUDE.md")); !strings.Contains(string(claude), "<!-- trabuco:begin overview -->") {
+		t.Error("--force-docs should regenerate a doc without markers")
+	}
+	if kept := adder.Report().DocsKept; len(kept) != 0 {
+		t.Errorf("nothing should be kept with --force-docs, got %+v", kept)
+	}
+}
@@ -0,0 +1,387 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// modulithOwnedKeys are the top-level sections of application*.yml that a
+// module other than API configures for the whole modulith: Worker runs the
+// JobRunr background server the API only enqueues into.
+var modulithOwnedKeys = map[string]string{"jobrunr": config.ModuleWorker}
+
+// modulithMainClasses are the main classes of the runtime modules, replaced
+// by the one application class of App
+var modulithMainClasses = map[string]string{
+	config.ModuleAPI:           "ApiApplication.java",
+	config.ModuleWorker:        "WorkerApplication.java",
+	config.ModuleEventConsumer: "EventConsumerApplication.java",
+}
+
+// modulithData is the template data of the App POM
+type modulithData struct {
+	*config.ProjectConfig
+	Properties   []pomProperty
+	Dependencies string // <dependency> elements of the modules, merged
+}
+
+// modulithModuleData is the template data of a module's package-info.java
+type modulithModuleData struct {
+	*config.ProjectConfig
+	Module      string
+	Description string
+	Allowed     []string // Package names of the modules it may use
+}
+
+// foldIntoModulith turns the rendered Maven modules into the single App
+// module of a modulith. Sources and resources move to App/src; resources
+// the modules share by name are merged when they are YAML and must be
+// identical otherwise. The module POMs become App/pom.xml, and the main
+// classes of the runtime modules give way to one application class in the
+// root package, next to which every module is a package.
+func (g *Generator) foldIntoModulith() error {
+	app := filepath.Join(g.outDir, config.ModulithModule)
+	poms := map[string]*modulePOM{}
+	var properties []pomProperty
+	seenProperty := map[string]bool{}
+	deps := &dependencySet{index: map[string]int{}}
+
+	for _, module := range modulithOrder(g.config) {
+		dir := filepath.Join(g.outDir, module)
+		pom, err := readModulePOM(filepath.Join(dir, "pom.xml"), g.config.GroupID)
+		if err != nil {
+			return err
+		}
+		poms[module] = pom
+		for _, p := range pom.properties {
+			if !seenProperty[p.Name] && !strings.HasPrefix(p.Name, "jacoco.") {
+				seenProperty[p.Name] = true
+				properties = append(properties, p)
+			}
+		}
+		for _, d := range pom.dependencies {
+			deps.add(d)
+		}
+
+		skip := map[string]bool{"pom.xml": true, "Dockerfile": true}
+		if main, ok := modulithMainClasses[module]; ok {
+			rel, _ := filepath.Rel(module, g.javaPath(module, g.config.ProjectNamePascal()+main))
+			skip[rel] = true
+		}
+		err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil || skip[rel] {
+				return err
+			}
+			return foldFile(module, path, filepath.Join(app, rel))
+		})
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", module, err)
+		}
+		if err := os.Remove(filepath.Join(g.outDir, ".run", module+".run.xml")); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	// API names the application; the merged configuration is all of it
+	appYML := filepath.Join(app, "src", "main", "resources", "application.yml")
+	if data, err := os.ReadFile(appYML); err == nil {
+		data = bytes.Replace(data, []byte("name: "+g.config.ProjectName+"-api"), []byte("name: "+g.config.ProjectName), 1)
+		if err := os.WriteFile(appYML, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", appYML, err)
+		}
+	}
+
+	pomData := &modulithData{ProjectConfig: g.config, Properties: properties, Dependencies: deps.String()}
+	if err := g.writeTemplateWithData("pom/app.xml.tmpl", filepath.Join(config.ModulithModule, "pom.xml"), pomData); err != nil {
+		return fmt.Errorf("failed to generate App pom.xml: %w", err)
+	}
+	root := filepath.Join(config.ModulithModule, "src", "main", "java", g.config.PackagePath())
+	testRoot := filepath.Join(config.ModulithModule, "src", "test", "java", g.config.PackagePath())
+	files := [][2]string{
+		{"java/app/Application.java.tmpl", filepath.Join(root, g.config.ProjectNamePascal()+"Application.java")},
+		{"java/app/test/ModularityTests.java.tmpl", filepath.Join(testRoot, "ModularityTests.java")},
+		{"idea/run/App__Maven_.run.xml.tmpl", filepath.Join(".run", "App.run.xml")},
+	}
+	if !g.config.UsesJib() {
+		files = append(files, [2]string{"docker/app.Dockerfile.tmpl", filepath.Join(config.ModulithModule, "Dockerfile")})
+	}
+	for _, f := range files {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	for _, module := range g.config.Modules {
+		data := &modulithModuleData{
+			ProjectConfig: g.config,
+			Module:        module,
+			Description:   config.GetModule(module).Description,
+			Allowed:       allowedModules(module, poms, g.config.Modules),
+		}
+		out := filepath.Join(root, strings.ToLower(module), "package-info.java")
+		if err := g.writeTemplateWithData("java/app/package-info.java.tmpl", out, data); err != nil {
+			return fmt.Errorf("failed to generate %s package-info.java: %w", module, err)
+		}
+	}
+	return nil
+}
+
+// modulithOrder returns the modules in the order their files are folded:
+// the runtime modules first, API leading, so their settings win a merge
+func modulithOrder(cfg *config.ProjectConfig) []string {
+	var order []string
+	seen := map[string]bool{}
+	for _, t := range cfg.ModuleRuntimeTargets() {
+		order = append(order, t.Module)
+		seen[t.Module] = true
+	}
+	for _, module := range cfg.Modules {
+		if !seen[module] {
+			order = append(order, module)
+		}
+	}
+	return order
+}
+
+// foldFile moves a module file to dest in App. A file already there from
+// another module is merged when it is YAML and must match otherwise.
+func foldFile(module, src, dest string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	existing, err := os.ReadFile(dest)
+	switch {
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(dest), err)
+		}
+		return os.Rename(src, dest)
+	case err != nil:
+		return fmt.Errorf("failed to read %s: %w", dest, err)
+	case bytes.Equal(existing, data):
+		return nil
+	}
+	if ext := filepath.Ext(dest); ext != ".yml" && ext != ".yaml" {
+		return fmt.Errorf("%s has a different %s than the modules before it", module, filepath.Base(dest))
+	}
+	owned := map[string]bool{}
+	for key, owner := range modulithOwnedKeys {
+		owned[key] = owner == module
+	}
+	merged, err := mergeYAML(existing, data, owned)
+	if err != nil {
+		return fmt.Errorf("failed to merge the %s %s: %w", module, filepath.Base(dest), err)
+	}
+	return os.WriteFile(dest, merged, 0644)
+}
+
+// mergeYAML adds the settings of overlay that base lacks, recursing into
+// mappings both have. base wins every other conflict, except the top-level
+// keys marked in owned, which overlay replaces. Comments are kept.
+func mergeYAML(base, overlay []byte, owned map[string]bool) ([]byte, error) {
+	var dst, src yaml.Node
+	if err := yaml.Unmarshal(base, &dst); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(overlay, &src); err != nil {
+		return nil, err
+	}
+	if len(src.Content) == 0 || src.Content[0].Kind != yaml.MappingNode {
+		return base, nil
+	}
+	if len(dst.Content) == 0 || dst.Content[0].Kind != yaml.MappingNode {
+		return overlay, nil
+	}
+	mergeMapping(dst.Content[0], src.Content[0], owned)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&dst); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func mergeMapping(dst, src *yaml.Node, replace map[string]bool) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		j := mappingIndex(dst, key.Value)
+		switch {
+		case j < 0:
+			dst.Content = append(dst.Content, key, value)
+		case replace[key.Value]:
+			dst.Content[j+1] = value
+		case dst.Content[j+1].Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			mergeMapping(dst.Content[j+1], value, nil)
+		}
+	}
+}
+
+func mappingIndex(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// allowedModules returns the package names of the project modules module
+// may use: those its POM depended on, directly or through one another
+func allowedModules(module string, poms map[string]*modulePOM, order []string) []string {
+	reach := map[string]bool{}
+	var visit func(string)
+	visit = func(m string) {
+		if pom := poms[m]; pom != nil {
+			for _, dep := range pom.modules {
+				if !reach[dep] {
+					reach[dep] = true
+					visit(dep)
+				}
+			}
+		}
+	}
+	visit(module)
+	var allowed []string
+	for _, m := range order {
+		if reach[m] && m != module {
+			allowed = append(allowed, strings.ToLower(m))
+		}
+	}
+	return allowed
+}
+
+// pomProperty is a <properties> entry of a module POM
+type pomProperty struct {
+	Name, Value string
+}
+
+// pomDependency is a <dependency> element of a module POM, with the
+// comment lines before it
+type pomDependency struct {
+	key  string // groupId:artifactId:type:classifier
+	test bool
+	text string
+}
+
+// modulePOM is what folding needs from a rendered module POM
+type modulePOM struct {
+	properties   []pomProperty
+	dependencies []pomDependency // Third-party dependencies
+	modules      []string        // Project modules used outside tests
+}
+
+var (
+	pomPropertiesBlock   = regexp.MustCompile(`(?s)\n    <properties>(.*?)\n    </properties>`)
+	pomDependenciesBlock = regexp.MustCompile(`(?s)\n    <dependencies>(.*?)\n    </dependencies>`)
+	pomPropertyElement   = regexp.MustCompile(`<([A-Za-z0-9_.-]+)>([^<]*)</([A-Za-z0-9_.-]+)>`)
+	pomDependencyChunk   = regexp.MustCompile(`(?s).*?</dependency>`)
+)
+
+// readModulePOM reads the properties and dependencies of a module POM.
+// Dependencies on the project's own modules (groupId) are returned by
+// module name instead, test-jars included in neither.
+func readModulePOM(path, groupID string) (*modulePOM, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	pom := &modulePOM{}
+	if m := pomPropertiesBlock.FindSubmatch(data); m != nil {
+		for _, p := range pomPropertyElement.FindAllStringSubmatch(string(m[1]), -1) {
+			if p[1] == p[3] {
+				pom.properties = append(pom.properties, pomProperty{Name: p[1], Value: p[2]})
+			}
+		}
+	}
+	m := pomDependenciesBlock.FindSubmatch(data)
+	if m == nil {
+		return pom, nil
+	}
+	for _, chunk := range pomDependencyChunk.FindAllString(string(m[1]), -1) {
+		start := strings.Index(chunk, "<dependency>")
+		if start < 0 {
+			return nil, fmt.Errorf("failed to parse the dependencies of %s", path)
+		}
+		var dep struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Type       string `xml:"type"`
+			Classifier string `xml:"classifier"`
+			Scope      string `xml:"scope"`
+		}
+		if err := xml.Unmarshal([]byte(chunk[start:]), &dep); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if (dep.GroupID == groupID || dep.GroupID == "${project.groupId}") && config.GetModule(dep.ArtifactID) != nil {
+			if dep.Scope != "test" && dep.Type == "" {
+				pom.modules = append(pom.modules, dep.ArtifactID)
+			}
+			continue
+		}
+		pom.dependencies = append(pom.dependencies, pomDependency{
+			key:  strings.Join([]string{dep.GroupID, dep.ArtifactID, dep.Type, dep.Classifier}, ":"),
+			test: dep.Scope == "test",
+			text: trimBlankLines(chunk),
+		})
+	}
+	return pom, nil
+}
+
+// trimBlankLines drops the empty lines a rendered template leaves around
+// an element
+func trimBlankLines(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dependencySet collects the dependencies of every module once. A test
+// dependency that another module uses in main code becomes a main one.
+type dependencySet struct {
+	deps  []pomDependency
+	index map[string]int
+}
+
+func (s *dependencySet) add(d pomDependency) {
+	i, ok := s.index[d.key]
+	if !ok {
+		s.index[d.key] = len(s.deps)
+		s.deps = append(s.deps, d)
+		return
+	}
+	if s.deps[i].test && !d.test {
+		s.deps[i] = d
+	}
+}
+
+func (s *dependencySet) String() string {
+	texts := make([]string, len(s.deps))
+	for i, d := range s.deps {
+		texts[i] = d.text
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateModulith(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName:   "shop",
+		GroupID:       "com.test.shop",
+		ArtifactID:    "shop",
+		JavaVersion:   "21",
+		Modules:       config.ResolveDependencies([]string{"SQLDatastore", "API", "Worker", "EventConsumer"}),
+		Database:      config.DatabasePostgreSQL,
+		MessageBroker: config.BrokerKafka,
+		Architecture:  config.ArchitectureModulith,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, module := range cfg.Modules {
+		if _, err := os.Stat(filepath.Join(projectPath, module)); !os.IsNotExist(err) {
+			t.Errorf("module directory %s should be folded into App", module)
+		}
+	}
+	for _, want := range []string{
+		"App/Dockerfile",
+		"App/src/main/java/com/test/shop/ShopApplication.java",
+		"App/src/main/java/com/test/shop/model/package-info.java",
+		"App/src/main/java/com/test/shop/worker/package-info.java",
+		"App/src/test/java/com/test/shop/ModularityTests.java",
+		".run/App.run.xml",
+	} {
+		if _, err := os.Stat(filepath.Join(projectPath, want)); err != nil {
+			t.Errorf("expected %s: %v", want, err)
+		}
+	}
+	for _, gone := range []string{
+		"App/src/main/java/com/test/shop/api/ApiApplication.java",
+		"App/src/main/java/com/test/shop/worker/WorkerApplication.java",
+	} {
+		if _, err := os.Stat(filepath.Join(projectPath, gone)); !os.IsNotExist(err) {
+			t.Errorf("%s should be replaced by the modulith Application", gone)
+		}
+	}
+
+	parent := readFile(t, projectPath, "pom.xml")
+	if !strings.Contains(parent, "<module>App</module>") || strings.Contains(parent, "<module>API</module>") {
+		t.Error("the parent POM should list App as its only module")
+	}
+	pom := readFile(t, projectPath, "App/pom.xml")
+	var project struct {
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+		} `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal([]byte(pom), &project); err != nil {
+		t.Fatalf("App/pom.xml does not parse: %v", err)
+	}
+	artifacts := map[string]bool{}
+	for _, d := range project.Dependencies {
+		if d.GroupID == cfg.GroupID || d.GroupID == "${project.groupId}" {
+			t.Errorf("App/pom.xml still depends on module %s", d.ArtifactID)
+		}
+		artifacts[d.ArtifactID] = true
+	}
+	for _, want := range []string{"spring-modulith-api", "spring-modulith-starter-test", "spring-boot-starter-web", "spring-kafka", "flyway-core"} {
+		if !artifacts[want] {
+			t.Errorf("App/pom.xml should depend on %s", want)
+		}
+	}
+
+	var app struct {
+		Server struct {
+			Port string `yaml:"port"`
+		} `yaml:"server"`
+		Spring struct {
+			Application struct {
+				Name string `yaml:"name"`
+			} `yaml:"application"`
+		} `yaml:"spring"`
+		JobRunr struct {
+			BackgroundJobServer struct {
+				Enabled bool `yaml:"enabled"`
+			} `yaml:"background-job-server"`
+		} `yaml:"jobrunr"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, projectPath, "App/src/main/resources/application.yml")), &app); err != nil {
+		t.Fatalf("application.yml does not parse: %v", err)
+	}
+	if !strings.Contains(app.Server.Port, "8080") || app.Spring.Application.Name != "shop" {
+		t.Errorf("application.yml should run shop on 8080, got %+v", app)
+	}
+	if !app.JobRunr.BackgroundJobServer.Enabled {
+		t.Error("the Worker's JobRunr settings should win, running the background job server")
+	}
+
+	apiInfo := readFile(t, projectPath, "App/src/main/java/com/test/shop/api/package-info.java")
+	for _, want := range []string{`"model"`, `"shared"`, `"sqldatastore"`} {
+		if !strings.Contains(apiInfo, want) {
+			t.Errorf("the api module should be allowed to use %s", want)
+		}
+	}
+	if strings.Contains(apiInfo, `"worker"`) {
+		t.Error("the api module should not be allowed to use worker")
+	}
+
+	meta, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Architecture != config.ArchitectureModulith {
+		t.Errorf("metadata architecture = %q", meta.Architecture)
+	}
+	spec, notes, err := config.ExportProjectSpec(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 0 || spec.Options["architecture"] != config.ArchitectureModulith {
+		t.Errorf("spec options = %v, notes = %v", spec.Options, notes)
+	}
+}
+
+func TestMergeYAML(t *testing.T) {
+	base := "server:\n  port: 8080\n# base comment\nlogging:\n  level:\n    root: INFO\njobrunr:\n  background-job-server:\n    enabled: false\n"
+	overlay := "server:\n  port: 8081\n  shutdown: graceful\nlogging:\n  level:\n    org.jobrunr: INFO\njobrunr:\n  dashboard:\n    enabled: true\n"
+
+	merged, err := mergeYAML([]byte(base), []byte(overlay), map[string]bool{"jobrunr": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(merged)
+	for _, want := range []string{"port: 8080", "shutdown: graceful", "root: INFO", "org.jobrunr: INFO", "# base comment", "dashboard:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("merged YAML should contain %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"port: 8081", "background-job-server"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("merged YAML should not contain %q:\n%s", unwanted, got)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// nativeData renders the per-module native image templates
+type nativeData struct {
+	*config.ProjectConfig
+	Target config.RuntimeTarget
+}
+
+// generateNative writes the GraalVM native image support of every runtime
+// module. The `native` Maven profile itself lives in the module POM
+// templates.
+func (g *Generator) generateNative() error {
+	if !g.config.HasNative() {
+		return nil
+	}
+	for _, target := range g.config.RuntimeTargets() {
+		if err := g.generateNativeModule(target.Module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateNativeModule writes the AOT runtime hints and Dockerfile.native of
+// one runtime module. Other modules are skipped.
+func (g *Generator) generateNativeModule(module string) error {
+	for _, target := range g.config.RuntimeTargets() {
+		if target.Module != module {
+			continue
+		}
+		data := &nativeData{ProjectConfig: g.config, Target: target}
+		files := [][2]string{
+			{"java/native/NativeHints.java.tmpl", g.javaPath(module, filepath.Join("config", "NativeHints.java"))},
+			{"java/native/aot.factories.tmpl", g.resourcePath(module, filepath.Join("META-INF", "spring", "aot.factories"))},
+			{"docker/native.Dockerfile.tmpl", filepath.Join(module, "Dockerfile.native")},
+		}
+		for _, f := range files {
+			if err := g.writeTemplateWithData(f[0], f[1], data); err != nil {
+				return fmt.Errorf("failed to generate %s native support: %w", module, err)
+			}
+		}
+	}
+	return nil
+}
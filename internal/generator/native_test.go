@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_Native(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "native-app",
+		GroupID:     "com.test.nativeapp",
+		ArtifactID:  "native-app",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker"},
+		Database:    "postgresql",
+		CIProvider:  "github",
+		Native:      true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("native-app", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	for _, m := range []struct{ module, pkg string }{{"API", "api"}, {"Worker", "worker"}} {
+		pom := read(m.module + "/pom.xml")
+		if err := xml.Unmarshal([]byte(pom), new(struct{})); err != nil {
+			t.Errorf("%s/pom.xml is not well-formed: %v", m.module, err)
+		}
+		for _, want := range []string{"<id>native</id>", "native-maven-plugin", "<goal>process-aot</goal>", "<imageName>native-app-" + m.pkg + "</imageName>"} {
+			if !strings.Contains(pom, want) {
+				t.Errorf("%s/pom.xml missing %q", m.module, want)
+			}
+		}
+
+		hints := read(m.module + "/src/main/java/com/test/nativeapp/" + m.pkg + "/config/NativeHints.java")
+		if !strings.Contains(hints, "package com.test.nativeapp."+m.pkg+".config;") || !strings.Contains(hints, `"com.test.nativeapp.model"`) {
+			t.Errorf("%s NativeHints.java should scan the model package", m.module)
+		}
+		if !strings.Contains(hints, `registerPattern("db/migration/*")`) {
+			t.Errorf("%s NativeHints.java should register Flyway migrations", m.module)
+		}
+		factories := read(m.module + "/src/main/resources/META-INF/spring/aot.factories")
+		if !strings.Contains(factories, "com.test.nativeapp."+m.pkg+".config.NativeHints") {
+			t.Errorf("%s aot.factories should register NativeHints", m.module)
+		}
+		dockerfile := read(m.module + "/Dockerfile.native")
+		if !strings.Contains(dockerfile, "-Pnative package -pl "+m.module) || !strings.Contains(dockerfile, "target/native-app-"+m.pkg+" app") {
+			t.Errorf("%s/Dockerfile.native should build and copy the native executable", m.module)
+		}
+	}
+
+	if !strings.Contains(read("pom.xml"), "<native-maven-plugin.version>") {
+		t.Error("parent pom.xml should declare native-maven-plugin.version")
+	}
+	if ci := read(".github/workflows/ci.yml"); !strings.Contains(ci, "graalvm/setup-graalvm") || !strings.Contains(ci, "mvn -Pnative package") {
+		t.Error("ci.yml should have a native build job")
+	}
+	if !strings.Contains(read("README.md"), "docker build -f API/Dockerfile.native") {
+		t.Error("README.md should document the native image build")
+	}
+}
+
+func TestGenerator_Generate_NativeOff(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "jvm-app",
+		GroupID:     "com.test.jvmapp",
+		ArtifactID:  "jvm-app",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+		CIProvider:  "github",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join("jvm-app", "API", "Dockerfile.native")); !os.IsNotExist(err) {
+		t.Error("Dockerfile.native should not be generated by default")
+	}
+	pom, _ := os.ReadFile(filepath.Join("jvm-app", "API", "pom.xml"))
+	if strings.Contains(string(pom), "native-maven-plugin") {
+		t.Error("API pom.xml should not have a native profile by default")
+	}
+	ci, _ := os.ReadFile(filepath.Join("jvm-app", ".github", "workflows", "ci.yml"))
+	if strings.Contains(string(ci), "setup-graalvm") {
+		t.Error("ci.yml should not have a native job by default")
+	}
+}
+
+func TestModuleAdder_Add_Native(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "native-add",
+		GroupID:     "com.test.nativeadd",
+		ArtifactID:  "native-add",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+		Native:      true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "native-add")
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.Native {
+		t.Fatal("metadata should persist native")
+	}
+	adder := NewModuleAdder(projectPath, metadata, "test", false)
+	if err := adder.Add(config.ModuleWorker, "", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "Worker", "Dockerfile.native")); err != nil {
+		t.Errorf("added Worker should get Dockerfile.native: %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package generator
+
+// observabilityFiles maps templates under templates/observability to their
+// place in the generated project. docker-compose.yml mounts these paths.
+var observabilityFiles = [][2]string{
+	{"observability/prometheus/prometheus.yml.tmpl", "observability/prometheus/prometheus.yml"},
+	{"observability/tempo/tempo.yaml.tmpl", "observability/tempo/tempo.yaml"},
+	{"observability/grafana/provisioning/datasources/datasources.yml.tmpl", "observability/grafana/provisioning/datasources/datasources.yml"},
+	{"observability/grafana/provisioning/dashboards/dashboards.yml.tmpl", "observability/grafana/provisioning/dashboards/dashboards.yml"},
+	{"observability/grafana/dashboards/overview.json.tmpl", "observability/grafana/dashboards/overview.json"},
+}
+
+// generateObservability writes the config for the Prometheus + Grafana +
+// Tempo stack behind the "observability" docker-compose profile
+func (g *Generator) generateObservability() error {
+	if !g.config.HasObservability() {
+		return nil
+	}
+	for _, f := range observabilityFiles {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
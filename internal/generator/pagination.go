@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+)
+
+// paginationFiles returns the template and output path of every file of the
+// paginated list scaffolding. The scaffolding only adds files, so it can be
+// retrofitted onto a project without touching code the user may have edited.
+func (g *Generator) paginationFiles() [][2]string {
+	files := [][2]string{
+		{"java/model/dto/PageRequest.java.tmpl", g.javaPath(config.ModuleModel, filepath.Join("dto", "PageRequest.java"))},
+		{"java/model/dto/PageResponse.java.tmpl", g.javaPath(config.ModuleModel, filepath.Join("dto", "PageResponse.java"))},
+		{"java/model/dto/PlaceholderFilter.java.tmpl", g.javaPath(config.ModuleModel, filepath.Join("dto", "PlaceholderFilter.java"))},
+		{"java/model/dto/SortWhitelist.java.tmpl", g.javaPath(config.ModuleModel, filepath.Join("dto", "SortWhitelist.java"))},
+		{"java/model/test/dto/SortWhitelistTest.java.tmpl", g.testJavaPath(config.ModuleModel, filepath.Join("dto", "SortWhitelistTest.java"))},
+	}
+	// The query service follows PlaceholderService: SQL wins when both
+	// datastores are present.
+	if g.config.HasModule(config.ModuleSQLDatastore) {
+		files = append(files, [2]string{
+			"java/sqldatastore/repository/PlaceholderPagingRepository.java.tmpl",
+			g.javaPath(config.ModuleSQLDatastore, filepath.Join("repository", "PlaceholderPagingRepository.java")),
+		})
+	}
+	return append(files,
+		[2]string{"java/shared/service/PlaceholderQueryService.java.tmpl", g.javaPath(config.ModuleShared, filepath.Join("service", "PlaceholderQueryService.java"))},
+		[2]string{"java/shared/test/PlaceholderQueryServiceTest.java.tmpl", g.testJavaPath(config.ModuleShared, filepath.Join("service", "PlaceholderQueryServiceTest.java"))},
+		[2]string{"java/api/controller/PlaceholderPageController.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("controller", "PlaceholderPageController.java"))},
+	)
+}
+
+// generatePagination writes the paginated list scaffolding when the project
+// asked for it with --with-pagination.
+func (g *Generator) generatePagination() error {
+	if !g.config.HasPagination() {
+		return nil
+	}
+	for _, f := range g.paginationFiles() {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to generate pagination scaffolding: %w", err)
+		}
+	}
+	return nil
+}
+
+// RetrofitPagination adds the paginated list scaffolding to the existing
+// project at projectPath (`trabuco generate pagination`) and records it in
+// .trabuco.json. Like the add-commands it refuses to overwrite a file that
+// already exists. It returns the files written, relative to projectPath; in
+// dry-run mode nothing is written and the files that would be are returned.
+func RetrofitPagination(projectPath string, metadata *config.ProjectMetadata, dryRun bool) ([]string, error) {
+	cfg := metadata.ToProjectConfig()
+	if !cfg.SupportsPagination() {
+		return nil, fmt.Errorf("pagination needs the API and Shared modules and SQLDatastore or NoSQLDatastore with MongoDB")
+	}
+	if metadata.Pagination {
+		return nil, fmt.Errorf("pagination scaffolding is already part of this project")
+	}
+	cfg.Pagination = true
+
+	gen := &Generator{
+		config: cfg,
+		engine: templates.NewEngine().WithProjectOverrides(projectPath),
+		outDir: projectPath,
+	}
+	files := gen.paginationFiles()
+	var created []string
+	for _, f := range files {
+		out := gen.sourcePath(f[0], f[1])
+		if _, err := os.Stat(filepath.Join(projectPath, out)); err == nil {
+			return nil, fmt.Errorf("refusing to overwrite existing file: %s (delete it first if you want to regenerate)", out)
+		}
+		created = append(created, out)
+	}
+	if dryRun {
+		return created, nil
+	}
+
+	for _, f := range files {
+		if err := gen.writeTemplate(f[0], f[1]); err != nil {
+			return nil, fmt.Errorf("failed to generate pagination scaffolding: %w", err)
+		}
+	}
+	metadata.Pagination = true
+	metadata.UpdateGeneratedAt()
+	if err := config.SaveMetadata(projectPath, metadata); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", config.MetadataFileName, err)
+	}
+	return created, nil
+}
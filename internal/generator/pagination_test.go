@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_Pagination(t *testing.T) {
+	tests := []struct {
+		name      string
+		modules   []string
+		nosql     string
+		probe     string
+		idMapping string
+	}{
+		{"sql", []string{"Model", "SQLDatastore", "Shared", "API"}, "", "PlaceholderPagingRepository repository", "String.valueOf(p.id())"},
+		{"mongodb", []string{"Model", "NoSQLDatastore", "Shared", "API"}, "mongodb", "PlaceholderDocumentRepository repository", ".id(p.documentId())"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			oldWd, _ := os.Getwd()
+			os.Chdir(tempDir)
+			defer os.Chdir(oldWd)
+
+			cfg := &config.ProjectConfig{
+				ProjectName:   "paged",
+				GroupID:       "com.test.paged",
+				ArtifactID:    "paged",
+				JavaVersion:   "21",
+				Modules:       tt.modules,
+				Database:      "postgresql",
+				NoSQLDatabase: tt.nosql,
+				Pagination:    true,
+			}
+			gen, err := New(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			read := func(path string) string {
+				t.Helper()
+				data, err := os.ReadFile(filepath.Join("paged", path))
+				if err != nil {
+					t.Fatalf("expected %s: %v", path, err)
+				}
+				return string(data)
+			}
+
+			read("Model/src/main/java/com/test/paged/model/dto/PageRequest.java")
+			read("Model/src/test/java/com/test/paged/model/dto/SortWhitelistTest.java")
+			service := read("Shared/src/main/java/com/test/paged/shared/service/PlaceholderQueryService.java")
+			if !strings.Contains(service, tt.probe) || !strings.Contains(service, "SortWhitelist.of(") {
+				t.Errorf("PlaceholderQueryService should page through %q behind a sort whitelist", tt.probe)
+			}
+			read("Shared/src/test/java/com/test/paged/shared/service/PlaceholderQueryServiceTest.java")
+			controller := read("API/src/main/java/com/test/paged/api/controller/PlaceholderPageController.java")
+			if !strings.Contains(controller, `@GetMapping("/page")`) || !strings.Contains(controller, tt.idMapping) {
+				t.Errorf("PlaceholderPageController should map GET /page with %q", tt.idMapping)
+			}
+
+			_, err = os.Stat(filepath.Join("paged", "SQLDatastore/src/main/java/com/test/paged/sqldatastore/repository/PlaceholderPagingRepository.java"))
+			if (err == nil) != (tt.nosql == "") {
+				t.Errorf("PlaceholderPagingRepository should only exist with SQLDatastore, stat err: %v", err)
+			}
+
+			metadata, err := config.LoadMetadata(filepath.Join(tempDir, "paged"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !metadata.Pagination {
+				t.Error("metadata should persist pagination")
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_PaginationUnsupported(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "redis-app",
+		GroupID:       "com.test.redisapp",
+		ArtifactID:    "redis-app",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "NoSQLDatastore", "Shared", "API"},
+		NoSQLDatabase: "redis",
+		Pagination:    true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("redis-app", "API/src/main/java/com/test/redisapp/api/controller/PlaceholderPageController.java")); !os.IsNotExist(err) {
+		t.Error("Redis has no query by example; pagination should be skipped")
+	}
+}
+
+func TestRetrofitPagination(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "retro",
+		GroupID:     "com.test.retro",
+		ArtifactID:  "retro",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "retro")
+	controller := filepath.Join(projectPath, "API/src/main/java/com/test/retro/api/controller/PlaceholderPageController.java")
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	planned, err := RetrofitPagination(projectPath, metadata, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if _, err := os.Stat(controller); !os.IsNotExist(err) {
+		t.Error("dry run should not write files")
+	}
+
+	created, err := RetrofitPagination(projectPath, metadata, false)
+	if err != nil {
+		t.Fatalf("RetrofitPagination failed: %v", err)
+	}
+	if len(created) != len(planned) || len(created) != 9 {
+		t.Errorf("expected the 9 planned files, planned %v, created %v", planned, created)
+	}
+	if _, err := os.Stat(controller); err != nil {
+		t.Errorf("expected PlaceholderPageController.java: %v", err)
+	}
+
+	reloaded, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.Pagination {
+		t.Error("retrofit should record pagination in .trabuco.json")
+	}
+	if _, err := RetrofitPagination(projectPath, reloaded, false); err == nil {
+		t.Error("a second retrofit should be rejected")
+	}
+
+	// A user file in the way stops the retrofit before anything is written.
+	reloaded.Pagination = false
+	os.Remove(controller)
+	if _, err := RetrofitPagination(projectPath, reloaded, false); err == nil || !strings.Contains(err.Error(), "refusing to overwrite") {
+		t.Errorf("expected refusal to overwrite, got %v", err)
+	}
+	if _, err := os.Stat(controller); !os.IsNotExist(err) {
+		t.Error("a refused retrofit should not write files")
+	}
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+)
+
+// perfRunScript is the script that runs the load test; it is written
+// executable.
+const perfRunScript = "perf/run.sh"
+
+// perfFiles returns the template and output path of every file of the
+// perf/ load test. The CI workflow is only part of it with GitHub Actions.
+func (g *Generator) perfFiles() [][2]string {
+	files := [][2]string{
+		{"perf/k6/placeholders.js.tmpl", "perf/k6/placeholders.js"},
+		{"perf/run.sh.tmpl", perfRunScript},
+	}
+	if g.config.HasCIProvider("github") {
+		files = append(files, [2]string{"github/workflows/perf.yml.tmpl", ".github/workflows/perf.yml"})
+	}
+	return files
+}
+
+// generatePerf writes the perf/ load test when the project asked for it
+// with --with-perf. Files that already exist are kept, so a module added
+// later does not reset tuned thresholds.
+func (g *Generator) generatePerf() error {
+	if !g.config.HasPerf() {
+		return nil
+	}
+	for _, f := range g.perfFiles() {
+		if _, err := os.Stat(filepath.Join(g.outDir, f[1])); err == nil {
+			continue
+		}
+		if err := g.writePerfFile(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Generator) writePerfFile(templatePath, outputPath string) error {
+	var err error
+	if outputPath == perfRunScript {
+		err = g.writeTemplateExecutable(templatePath, outputPath)
+	} else {
+		err = g.writeTemplate(templatePath, outputPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate the perf load test: %w", err)
+	}
+	return nil
+}
+
+// RetrofitPerf adds the perf/ load test to the existing project at
+// projectPath (`trabuco generate perf`) and records the flag in
+// .trabuco.json. It refuses to overwrite a file that already exists. It
+// returns the files created, relative to projectPath; in dry-run mode
+// nothing is written and the files that would be are returned.
+func RetrofitPerf(projectPath string, metadata *config.ProjectMetadata, dryRun bool) ([]string, error) {
+	cfg := metadata.ToProjectConfig()
+	if !cfg.HasModule(config.ModuleAPI) || !cfg.HasAnyDatastore() {
+		return nil, fmt.Errorf("the load test needs the API module and a datastore")
+	}
+	if metadata.Perf {
+		return nil, fmt.Errorf("the load test is already part of this project")
+	}
+	cfg.Perf = true
+
+	gen := &Generator{
+		config: cfg,
+		engine: templates.NewEngine().WithProjectOverrides(projectPath),
+		outDir: projectPath,
+	}
+	files := gen.perfFiles()
+	var created []string
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(projectPath, f[1])); err == nil {
+			return nil, fmt.Errorf("refusing to overwrite existing file: %s (delete it first if you want to regenerate)", f[1])
+		}
+		created = append(created, f[1])
+	}
+	if dryRun {
+		return created, nil
+	}
+
+	for _, f := range files {
+		if err := gen.writePerfFile(f[0], f[1]); err != nil {
+			return nil, err
+		}
+	}
+	metadata.Perf = true
+	metadata.UpdateGeneratedAt()
+	if err := config.SaveMetadata(projectPath, metadata); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", config.MetadataFileName, err)
+	}
+	return created, nil
+}
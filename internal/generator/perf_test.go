@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_Perf(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "loaded",
+		GroupID:     "com.test.loaded",
+		ArtifactID:  "loaded",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+		CIProvider:  "github",
+		Pagination:  true,
+		RateLimit:   true,
+		Perf:        true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("loaded", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	script := read("perf/k6/placeholders.js")
+	for _, want := range []string{"/api/placeholders/page?size=20", "'http_req_duration{endpoint:page}'", "http_req_failed"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("k6 script should contain %s", want)
+		}
+	}
+	run := read("perf/run.sh")
+	if !strings.Contains(run, "docker compose up -d --wait") || !strings.Contains(run, "RATE_LIMIT_ENABLED=false") {
+		t.Error("run.sh should start compose and disable rate limiting")
+	}
+	if !strings.Contains(run, "grafana/k6:") {
+		t.Error("run.sh should fall back to the grafana/k6 image")
+	}
+	info, err := os.Stat(filepath.Join("loaded", "perf", "run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("run.sh should be executable, mode %v", info.Mode())
+	}
+	if workflow := read(".github/workflows/perf.yml"); !strings.Contains(workflow, "workflow_dispatch") || !strings.Contains(workflow, "run: perf/run.sh") {
+		t.Error("perf workflow should run perf/run.sh on manual dispatch")
+	}
+	if !strings.Contains(read("README.md"), "## Performance Testing") {
+		t.Error("README should document the load test")
+	}
+
+	metadata, err := config.LoadMetadata(filepath.Join(tempDir, "loaded"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.Perf {
+		t.Error("metadata should persist perf")
+	}
+}
+
+func TestGenerator_Generate_PerfWithoutDatastore(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "stateless",
+		GroupID:     "com.test.stateless",
+		ArtifactID:  "stateless",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "API"},
+		Perf:        true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("stateless", "perf")); !os.IsNotExist(err) {
+		t.Error("the load test without a datastore should not be generated")
+	}
+}
+
+func TestRetrofitPerf(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "retro",
+		GroupID:       "com.test.retro",
+		ArtifactID:    "retro",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "NoSQLDatastore", "Shared", "API"},
+		NoSQLDatabase: "mongodb",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "retro")
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	planned, err := RetrofitPerf(projectPath, metadata, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "perf")); !os.IsNotExist(err) {
+		t.Error("dry run should not write files")
+	}
+
+	created, err := RetrofitPerf(projectPath, metadata, false)
+	if err != nil {
+		t.Fatalf("RetrofitPerf failed: %v", err)
+	}
+	if len(created) != len(planned) || len(created) != 2 {
+		t.Errorf("expected the 2 planned files without CI, planned %v, created %v", planned, created)
+	}
+	if script, err := os.ReadFile(filepath.Join(projectPath, "perf", "k6", "placeholders.js")); err != nil {
+		t.Errorf("expected placeholders.js: %v", err)
+	} else if strings.Contains(string(script), "endpoint:page") {
+		t.Error("the k6 script should skip the page endpoint without pagination")
+	}
+
+	reloaded, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.Perf {
+		t.Error("metadata should record perf after the retrofit")
+	}
+	if _, err := RetrofitPerf(projectPath, reloaded, false); err == nil {
+		t.Error("a second retrofit should fail")
+	}
+}
@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/plugin"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+)
+
+// generatePluginModule renders every file declared by a template pack. Both
+// the template body and the output path are executed against the project
+// config, so packs can place sources under {{packagePath .GroupID}}.
+func (g *Generator) generatePluginModule(p *plugin.Plugin) error {
+	for _, f := range p.Manifest.Files {
+		content, err := p.ReadTemplate(f.Template)
+		if err != nil {
+			return err
+		}
+		rendered, err := g.engine.ExecuteString(p.Manifest.Name+":"+f.Template, content, g.config)
+		if err != nil {
+			return fmt.Errorf("plugin %s: failed to render %s: %w", p.Manifest.Name, f.Template, err)
+		}
+		outPath, err := pluginOutputPath(g.engine, g.config, p, f)
+		if err != nil {
+			return err
+		}
+
+		fullPath := filepath.Join(g.outDir, filepath.FromSlash(outPath))
+		if f.Executable {
+			err = g.writeFileExecutable(fullPath, rendered)
+		} else {
+			err = g.writeFile(fullPath, rendered)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pluginOutputPath renders the output path of a pack file and checks the
+// result stays inside the project. The manifest is validated before
+// rendering, so a path like {{"../x"}}/y is only caught here.
+func pluginOutputPath(engine *templates.Engine, cfg *config.ProjectConfig, p *plugin.Plugin, f plugin.FileSpec) (string, error) {
+	outPath, err := engine.ExecuteString(p.Manifest.Name+":output", f.Output, cfg)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s: failed to render output path %s: %w", p.Manifest.Name, f.Output, err)
+	}
+	if !filepath.IsLocal(filepath.FromSlash(outPath)) {
+		return "", fmt.Errorf("plugin %s: output %q renders to %q, which is outside the project", p.Manifest.Name, f.Output, outPath)
+	}
+	return outPath, nil
+}
+
+// applyPluginProjectEdits applies the parent POM and docker-compose edits of
+// every plugin module in the config. Runs after the built-in files are
+// written so the edits land on top of the rendered parent POM and compose
+// file rather than being overwritten by them.
+func (g *Generator) applyPluginProjectEdits() error {
+	for _, module := range g.config.Modules {
+		p := plugin.Get(module)
+		if p == nil {
+			continue
+		}
+
+		pom, err := NewPOMUpdater(filepath.Join(g.outDir, "pom.xml"))
+		if err != nil {
+			return err
+		}
+		if err := applyPluginPOMEdits(pom, p); err != nil {
+			return err
+		}
+		if err := pom.Save(); err != nil {
+			return err
+		}
+
+		if len(p.Manifest.Docker.Services) > 0 {
+			compose, err := NewDockerComposeUpdater(filepath.Join(g.outDir, "docker-compose.yml"))
+			if err != nil {
+				return err
+			}
+			applyPluginDockerServices(compose, p)
+			if err := compose.Save(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyPluginPOMEdits adds the pack's properties and managed dependencies.
+func applyPluginPOMEdits(pom *POMUpdater, p *plugin.Plugin) error {
+	names := make([]string, 0, len(p.Manifest.POM.Properties))
+	for name := range p.Manifest.POM.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := pom.AddProperty(name, p.Manifest.POM.Properties[name]); err != nil {
+			return fmt.Errorf("plugin %s: failed to add property %s: %w", p.Manifest.Name, name, err)
+		}
+	}
+	for _, d := range p.Manifest.POM.DependencyManagement {
+		if err := pom.AddDependencyManagement(d.GroupID, d.ArtifactID, d.Version, d.Type, d.Scope); err != nil {
+			return fmt.Errorf("plugin %s: failed to add managed dependency %s:%s: %w", p.Manifest.Name, d.GroupID, d.ArtifactID, err)
+		}
+	}
+	return nil
+}
+
+// applyPluginDockerServices adds the pack's services and volumes, leaving any
+// service the user already defined untouched.
+func applyPluginDockerServices(compose *DockerComposeUpdater, p *plugin.Plugin) {
+	for name, svc := range p.Manifest.Docker.Services {
+		if !compose.HasService(name) {
+			compose.AddService(name, svc)
+		}
+	}
+	for _, v := range p.Manifest.Docker.Volumes {
+		compose.AddVolume(v)
+	}
+}
+
+// pluginModules returns the plugin-contributed modules in a module list.
+func pluginModules(modules []string) []string {
+	var out []string
+	for _, m := range modules {
+		if config.IsPluginModule(m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/plugin"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+)
+
+// installTestPlugin installs a FeatureFlags pack into an isolated plugins
+// dir and registers it. The module registry is restored on cleanup.
+func installTestPlugin(t *testing.T) {
+	t.Helper()
+	t.Setenv("TRABUCO_PLUGINS_DIR", t.TempDir())
+	saved := append([]config.Module(nil), config.ModuleRegistry...)
+	t.Cleanup(func() { config.ModuleRegistry = saved })
+
+	src := t.TempDir()
+	manifest := `name: FeatureFlags
+description: Feature flag evaluation
+dependencies: [Model]
+files:
+  - template: pom.xml.tmpl
+    output: FeatureFlags/pom.xml
+  - template: Flags.java.tmpl
+    output: 'FeatureFlags/src/main/java/{{packagePath .GroupID}}/featureflags/Flags.java'
+pom:
+  properties:
+    unleash.version: "9.2.0"
+docker:
+  services:
+    unleash:
+      image: unleashorg/unleash-server:6
+  volumes: [unleash-data]
+`
+	files := map[string]string{
+		plugin.ManifestFileName: manifest,
+		"pom.xml.tmpl":          "<artifactId>FeatureFlags</artifactId>\n",
+		"Flags.java.tmpl":       "package {{.GroupID}}.featureflags;\n\npublic final class Flags {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := plugin.Install(src, true); err != nil {
+		t.Fatalf("install failed: %v", err)
+	}
+	if errs := plugin.Load(); len(errs) > 0 {
+		t.Fatalf("load failed: %v", errs)
+	}
+}
+
+func TestGenerator_Generate_PluginModule(t *testing.T) {
+	installTestPlugin(t)
+
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "flags-app",
+		GroupID:     "com.test.flags",
+		ArtifactID:  "flags-app",
+		JavaVersion: "21",
+		Modules:     config.ResolveDependencies([]string{"FeatureFlags"}),
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	javaFile := filepath.Join("flags-app", "FeatureFlags", "src", "main", "java", "com", "test", "flags", "featureflags", "Flags.java")
+	content, err := os.ReadFile(javaFile)
+	if err != nil {
+		t.Fatalf("expected plugin source to be rendered: %v", err)
+	}
+	if !strings.Contains(string(content), "package com.test.flags.featureflags;") {
+		t.Errorf("template was not rendered with project config:\n%s", content)
+	}
+
+	pom, _ := os.ReadFile(filepath.Join("flags-app", "pom.xml"))
+	if !strings.Contains(string(pom), "<module>FeatureFlags</module>") {
+		t.Error("parent POM should list the plugin module")
+	}
+	if !strings.Contains(string(pom), "<unleash.version>9.2.0</unleash.version>") {
+		t.Error("parent POM should carry the plugin property")
+	}
+
+	compose, err := os.ReadFile(filepath.Join("flags-app", "docker-compose.yml"))
+	if err != nil {
+		t.Fatalf("expected docker-compose.yml with plugin service: %v", err)
+	}
+	if !strings.Contains(string(compose), "unleash:") {
+		t.Error("docker-compose.yml should include the plugin service")
+	}
+}
+
+func TestPluginOutputPath(t *testing.T) {
+	p := &plugin.Plugin{Manifest: &plugin.Manifest{Name: "FeatureFlags"}}
+	cfg := &config.ProjectConfig{ProjectName: "flags-app", GroupID: "com.test.flags"}
+	engine := templates.NewEngine()
+
+	out, err := pluginOutputPath(engine, cfg, p, plugin.FileSpec{Output: "FeatureFlags/{{packagePath .GroupID}}/Flags.java"})
+	if err != nil || out != "FeatureFlags/com/test/flags/Flags.java" {
+		t.Errorf("unexpected output path %q, %v", out, err)
+	}
+	for _, output := range []string{`{{"../../x"}}/y`, `{{"/etc"}}/passwd`, `FeatureFlags/{{".."}}/../y`} {
+		if _, err := pluginOutputPath(engine, cfg, p, plugin.FileSpec{Output: output}); err == nil || !strings.Contains(err.Error(), "outside the project") {
+			t.Errorf("%s: expected the rendered path to be refused, got %v", output, err)
+		}
+	}
+}
@@ -21,6 +21,8 @@ func (g *Generator) generateModulePOM(module string) error {
 		templateName = "pom/sqldatastore.xml.tmpl"
 	case config.ModuleNoSQLDatastore:
 		templateName = "pom/nosqldatastore.xml.tmpl"
+	case config.ModuleSearch:
+		templateName = "pom/search.xml.tmpl"
 	case config.ModuleShared:
 		templateName = "pom/shared.xml.tmpl"
 	case config.ModuleAPI:
@@ -33,6 +35,8 @@ func (g *Generator) generateModulePOM(module string) error {
 		templateName = "pom/eventconsumer.xml.tmpl"
 	case config.ModuleAIAgent:
 		templateName = "pom/aiagent.xml.tmpl"
+	case config.ModuleClientSDK:
+		templateName = "pom/clientsdk.xml.tmpl"
 	default:
 		return nil
 	}
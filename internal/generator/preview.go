@@ -0,0 +1,223 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/todos"
+	"github.com/arianlopezc/Trabuco/internal/utils"
+	"github.com/fatih/color"
+)
+
+// DiffResult is the content-level preview of an add: full content for every
+// file that would be created and a unified diff for every file that would
+// change. Unlike DryRun it comes from running the real add, so it matches
+// what Add would write.
+type DiffResult struct {
+	Module        string     `json:"module"`
+	Dependencies  []string   `json:"dependencies"`
+	FilesCreated  []FileDiff `json:"files_created"`
+	FilesModified []FileDiff `json:"files_modified"`
+}
+
+// FileDiff is the preview of one file
+type FileDiff struct {
+	Path    string `json:"path"`
+	Diff    string `json:"diff,omitempty"`    // Unified diff (modified files)
+	Content string `json:"content,omitempty"` // Full content (created files)
+}
+
+// Diff previews adding a module without touching the project. The project is
+// copied to a scratch directory, the add runs there, and the result is
+// compared against the original. Bookkeeping files (LAST_OPERATION.md,
+// history, the TODO index) are left out.
+func (a *ModuleAdder) Diff(module, database, nosqlDatabase, messageBroker string) (*DiffResult, error) {
+	scratch, err := os.MkdirTemp("", "trabuco-diff-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	if err := copyProjectTree(a.projectPath, scratch); err != nil {
+		return nil, fmt.Errorf("failed to copy project: %w", err)
+	}
+
+	// Add mutates the metadata it is given; preview against a copy
+	data, err := json.Marshal(a.metadata)
+	if err != nil {
+		return nil, err
+	}
+	meta := &config.ProjectMetadata{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, err
+	}
+
+	preview := NewModuleAdder(scratch, meta, a.version, false)
+	preview.forceDocs = a.forceDocs
+	if err := preview.Add(module, database, nosqlDatabase, messageBroker); err != nil {
+		return nil, err
+	}
+
+	result := &DiffResult{
+		Module:       module,
+		Dependencies: a.ResolveDependencies(module),
+	}
+	before := snapshotTree(a.projectPath)
+	after := snapshotTree(scratch)
+
+	paths := make([]string, 0, len(after))
+	for path := range after {
+		paths = append(paths, path)
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if path == todos.IndexFileName {
+			continue
+		}
+		oldHash, existed := before[path]
+		newHash, exists := after[path]
+		if existed && exists && oldHash == newHash {
+			continue
+		}
+
+		var oldText, newText string
+		if existed {
+			oldText, err = readPreviewFile(filepath.Join(a.projectPath, path))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if exists {
+			newText, err = readPreviewFile(filepath.Join(scratch, path))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case !existed:
+			result.FilesCreated = append(result.FilesCreated, FileDiff{Path: path, Content: newText})
+		case !exists:
+			result.FilesModified = append(result.FilesModified, FileDiff{
+				Path: path,
+				Diff: utils.UnifiedDiff("a/"+path, "/dev/null", oldText, ""),
+			})
+		default:
+			result.FilesModified = append(result.FilesModified, FileDiff{
+				Path: path,
+				Diff: utils.UnifiedDiff("a/"+path, "b/"+path, oldText, newText),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// readPreviewFile reads a file for display, replacing binary content with a
+// placeholder
+func readPreviewFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "(binary file)\n", nil
+	}
+	return string(data), nil
+}
+
+// copyProjectTree copies every file snapshotTree would look at from src into
+// dst, preserving modes
+func copyProjectTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "target", "node_modules", BackupDirName:
+				if rel != "." {
+					return filepath.SkipDir
+				}
+			}
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target)
+	})
+}
+
+// Print prints the diff preview: new files in full, changed files as
+// unified diffs
+func (d *DiffResult) Print() {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	fmt.Println()
+	cyan.Println("Diff Preview:")
+	fmt.Println()
+
+	fmt.Printf("Module to add: %s\n", d.Module)
+	if len(d.Dependencies) > 0 {
+		fmt.Printf("Dependencies: %s\n", strings.Join(d.Dependencies, ", "))
+	}
+
+	for _, f := range d.FilesModified {
+		fmt.Println()
+		for i, line := range previewLines(f.Diff) {
+			switch {
+			case i < 2: // ---/+++ file header
+				fmt.Println(line)
+			case strings.HasPrefix(line, "@@"):
+				cyan.Println(line)
+			case strings.HasPrefix(line, "+"):
+				green.Println(line)
+			case strings.HasPrefix(line, "-"):
+				red.Println(line)
+			default:
+				fmt.Println(line)
+			}
+		}
+	}
+
+	for _, f := range d.FilesCreated {
+		fmt.Println()
+		yellow.Printf("+++ %s (new file)\n", f.Path)
+		for _, line := range previewLines(f.Content) {
+			green.Printf("+%s\n", line)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d file(s) would be created, %d modified\n", len(d.FilesCreated), len(d.FilesModified))
+}
+
+// previewLines splits text into lines for printing
+func previewLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestModuleAdder_Diff(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "preview")
+	cfg := &config.ProjectConfig{
+		ProjectName: "preview",
+		GroupID:     "com.test.preview",
+		ArtifactID:  "preview",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pomBefore, err := os.ReadFile(filepath.Join(projectPath, "pom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adder := NewModuleAdder(projectPath, metadata, "test", false)
+	result, err := adder.Diff(config.ModuleSQLDatastore, config.DatabasePostgreSQL, "", "")
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	// The project itself is untouched
+	if _, err := os.Stat(filepath.Join(projectPath, "SQLDatastore")); !os.IsNotExist(err) {
+		t.Error("Diff should not create module directories in the project")
+	}
+	if pomAfter, _ := os.ReadFile(filepath.Join(projectPath, "pom.xml")); string(pomAfter) != string(pomBefore) {
+		t.Error("Diff should not modify the parent POM")
+	}
+	if metadata.HasModule(config.ModuleSQLDatastore) {
+		t.Error("Diff should not mutate the adder's metadata")
+	}
+
+	created := map[string]string{}
+	for _, f := range result.FilesCreated {
+		created[f.Path] = f.Content
+	}
+	if !strings.Contains(created["SQLDatastore/pom.xml"], "<artifactId>SQLDatastore</artifactId>") {
+		t.Errorf("expected full content of SQLDatastore/pom.xml among created files")
+	}
+
+	modified := map[string]string{}
+	for _, f := range result.FilesModified {
+		modified[f.Path] = f.Diff
+	}
+	pomDiff := modified["pom.xml"]
+	if !strings.HasPrefix(pomDiff, "--- a/pom.xml\n+++ b/pom.xml\n@@ ") {
+		t.Errorf("expected a unified diff for pom.xml, got:\n%s", pomDiff)
+	}
+	if !strings.Contains(pomDiff, "+        <module>SQLDatastore</module>") {
+		t.Errorf("pom.xml diff should add the SQLDatastore module, got:\n%s", pomDiff)
+	}
+	for path := range modified {
+		if path == LastOperationFile || path == config.HistoryFileName {
+			t.Errorf("bookkeeping file %s should not be previewed", path)
+		}
+	}
+}
+
+func TestModuleAdder_Diff_InvalidModule(t *testing.T) {
+	metadata := &config.ProjectMetadata{
+		ProjectName: "preview",
+		GroupID:     "com.test.preview",
+		Modules:     []string{"Model", "SQLDatastore"},
+	}
+	adder := NewModuleAdder(t.TempDir(), metadata, "test", false)
+	if _, err := adder.Diff(config.ModuleSQLDatastore, config.DatabasePostgreSQL, "", ""); err == nil {
+		t.Error("expected an error previewing a module that already exists")
+	}
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// readComposeServices parses the services of the project's docker-compose.yml
+func readComposeServices(t *testing.T, projectPath string) map[string]map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(projectPath, "docker-compose.yml"))
+	if err != nil {
+		t.Fatalf("expected docker-compose.yml: %v", err)
+	}
+	var compose struct {
+		Services map[string]map[string]interface{} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &compose); err != nil {
+		t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+	}
+	return compose.Services
+}
+
+func TestGenerator_Generate_HealthProbes(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName:   "shop",
+		GroupID:       "com.test.shop",
+		ArtifactID:    "shop",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker", "Events", "EventConsumer"},
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for module, port := range map[string]string{"API": "8080", "Worker": "8081", "EventConsumer": "8083"} {
+		yml, _ := os.ReadFile(filepath.Join(projectPath, module, "src", "main", "resources", "application.yml"))
+		for _, want := range []string{"add-additional-paths: true", "include: livenessState", "exclude: livenessState", "shutdown: graceful"} {
+			if !strings.Contains(string(yml), want) {
+				t.Errorf("%s application.yml should contain %s", module, want)
+			}
+		}
+		dockerfile, _ := os.ReadFile(filepath.Join(projectPath, module, "Dockerfile"))
+		if !strings.Contains(string(dockerfile), "http://localhost:"+port+"/actuator/health/liveness") {
+			t.Errorf("%s/Dockerfile should check liveness on port %s", module, port)
+		}
+	}
+
+	services := readComposeServices(t, projectPath)
+	for _, name := range []string{"api", "worker", "eventconsumer"} {
+		svc, ok := services[name]
+		if !ok {
+			t.Fatalf("docker-compose.yml should define the %s service", name)
+		}
+		if profiles, _ := svc["profiles"].([]interface{}); len(profiles) != 1 || profiles[0] != "app" {
+			t.Errorf("%s should only start with the app profile, got %v", name, svc["profiles"])
+		}
+		healthcheck, _ := svc["healthcheck"].(map[string]interface{})
+		if test, _ := healthcheck["test"].([]interface{}); len(test) == 0 || !strings.HasSuffix(test[len(test)-1].(string), "/actuator/health/readiness") {
+			t.Errorf("%s healthcheck should call the readiness probe, got %v", name, healthcheck["test"])
+		}
+		dependsOn, _ := svc["depends_on"].(map[string]interface{})
+		if kafka, _ := dependsOn["kafka"].(map[string]interface{}); kafka["condition"] != "service_healthy" {
+			t.Errorf("%s should wait for a healthy kafka, got %v", name, svc["depends_on"])
+		}
+	}
+	if env, _ := services["worker"]["environment"].(map[string]interface{}); env["SPRING_DATASOURCE_URL"] != "jdbc:postgresql://postgres:5432/shop" {
+		t.Errorf("worker should reach postgres in the network, got %v", env)
+	}
+	if build, _ := services["api"]["build"].(map[string]interface{}); build["dockerfile"] != "API/Dockerfile" {
+		t.Errorf("api should build from API/Dockerfile, got %v", services["api"]["build"])
+	}
+}
+
+func TestModuleAdder_Add_SyncsComposeAppServices(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName:  "shop",
+		GroupID:      "com.test.shop",
+		ArtifactID:   "shop",
+		JavaVersion:  "21",
+		Modules:      []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:     "postgresql",
+		ImageBuilder: config.ImageBuilderJib,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if image := readComposeServices(t, projectPath)["api"]["image"]; image != "shop-api" {
+		t.Errorf("with Jib the api service should run the shop-api image, got %v", image)
+	}
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adder := NewModuleAdder(projectPath, metadata, "test", false)
+	if err := adder.Add(config.ModuleEventConsumer, "", "", "rabbitmq"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	services := readComposeServices(t, projectPath)
+	consumer, ok := services["eventconsumer"]
+	if !ok {
+		t.Fatal("adding EventConsumer should add its app service")
+	}
+	if env, _ := consumer["environment"].(map[string]interface{}); env["RABBITMQ_HOST"] != "rabbitmq" || env["SERVER_PORT"] != "8083" {
+		t.Errorf("unexpected eventconsumer environment: %v", env)
+	}
+	// The adder's RabbitMQ service has no healthcheck to wait for
+	dependsOn, _ := services["api"]["depends_on"].(map[string]interface{})
+	if rabbit, _ := dependsOn["rabbitmq"].(map[string]interface{}); rabbit["condition"] != "service_started" {
+		t.Errorf("api should now wait for rabbitmq to start, got %v", services["api"]["depends_on"])
+	}
+	if postgres, _ := dependsOn["postgres"].(map[string]interface{}); postgres["condition"] != "service_healthy" {
+		t.Errorf("api should still wait for a healthy postgres, got %v", services["api"]["depends_on"])
+	}
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// envProfiles are the Spring profiles generated for every runtime module
+var envProfiles = []string{"dev", "staging", "prod"}
+
+// profileData renders the per-module, per-environment profile templates
+type profileData struct {
+	*config.ProjectConfig
+	Target config.RuntimeTarget
+	Env    string
+}
+
+// envData renders the per-environment .env example
+type envData struct {
+	*config.ProjectConfig
+	Env string
+}
+
+// generateEnvProfiles writes application-{dev,staging,prod}.yml for every
+// runtime module and the matching .env.<env>.example files at the root.
+func (g *Generator) generateEnvProfiles() error {
+	targets := g.config.ModuleRuntimeTargets()
+	if len(targets) == 0 {
+		return nil
+	}
+	for _, target := range targets {
+		if err := g.generateEnvProfilesModule(target.Module); err != nil {
+			return err
+		}
+	}
+	for _, env := range envProfiles {
+		data := &envData{ProjectConfig: g.config, Env: env}
+		if err := g.writeTemplateWithData("docker/env.profile.example.tmpl", ".env."+env+".example", data); err != nil {
+			return fmt.Errorf("failed to generate .env.%s.example: %w", env, err)
+		}
+	}
+	return nil
+}
+
+// generateEnvProfilesModule writes the environment profiles of one runtime
+// module. Other modules are skipped.
+func (g *Generator) generateEnvProfilesModule(module string) error {
+	for _, target := range g.config.ModuleRuntimeTargets() {
+		if target.Module != module {
+			continue
+		}
+		for _, env := range envProfiles {
+			data := &profileData{ProjectConfig: g.config, Target: target, Env: env}
+			out := g.resourcePath(module, "application-"+env+".yml")
+			if err := g.writeTemplateWithData("java/profiles/application-env.yml.tmpl", out, data); err != nil {
+				return fmt.Errorf("failed to generate %s %s profile: %w", module, env, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_EnvProfiles(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "profiled",
+		GroupID:       "com.test.profiled",
+		ArtifactID:    "profiled",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker", "Events", "EventConsumer"},
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("profiled", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	for _, module := range []string{"API", "Worker", "EventConsumer"} {
+		for _, env := range envProfiles {
+			read(filepath.Join(module, "src", "main", "resources", "application-"+env+".yml"))
+		}
+	}
+	if _, err := os.Stat(filepath.Join("profiled", "Model", "src", "main", "resources", "application-dev.yml")); !os.IsNotExist(err) {
+		t.Error("library modules should not get environment profiles")
+	}
+
+	dev := read("API/src/main/resources/application-dev.yml")
+	if strings.Contains(dev, "url:") || !strings.Contains(dev, "${DB_POOL_SIZE:5}") || !strings.Contains(dev, "${LOG_LEVEL:DEBUG}") {
+		t.Errorf("dev should keep the localhost endpoints, use a small pool and log at DEBUG:\n%s", dev)
+	}
+	prod := read("API/src/main/resources/application-prod.yml")
+	for _, want := range []string{
+		"jdbc:postgresql://${DB_HOST}:",
+		"sslmode=${DB_SSL_MODE:require}",
+		"${DB_POOL_SIZE:20}",
+		"bootstrap-servers: ${KAFKA_BOOTSTRAP_SERVERS}",
+		"${SPRINGDOC_ENABLED:false}",
+		"allowed-origins: ${CORS_ALLOWED_ORIGINS}",
+	} {
+		if !strings.Contains(prod, want) {
+			t.Errorf("API prod profile should contain %q", want)
+		}
+	}
+	if staging := read("EventConsumer/src/main/resources/application-staging.yml"); !strings.Contains(staging, "${KAFKA_SECURITY_PROTOCOL:SASL_SSL}") || strings.Contains(staging, "datasource") {
+		t.Errorf("EventConsumer staging should override only the broker:\n%s", staging)
+	}
+
+	env := read(".env.prod.example")
+	for _, want := range []string{"SPRING_PROFILES_ACTIVE=prod", "DB_HOST=\n", "KAFKA_BOOTSTRAP_SERVERS=\n"} {
+		if !strings.Contains(env, want) {
+			t.Errorf(".env.prod.example should contain %q:\n%s", want, env)
+		}
+	}
+	if !strings.Contains(read(".gitignore"), "!.env.*.example") {
+		t.Error(".gitignore should ignore .env.<env> copies but keep the examples")
+	}
+}
+
+func TestGenerator_Generate_EnvProfilesNeedRuntimeModule(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "library",
+		GroupID:     "com.test.library",
+		ArtifactID:  "library",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared"},
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("library", ".env.dev.example")); !os.IsNotExist(err) {
+		t.Error("projects without a runtime module should not get .env profile examples")
+	}
+}
@@ -0,0 +1,56 @@
+package generator
+
+import "context"
+
+// generateStages are the steps of Generate, in order, as reported to the
+// progress callback. Steps that do not apply to a project (the modulith
+// fold, template pack edits) are reported all the same so the count
+// stays fixed.
+var generateStages = []string{
+	"directories",
+	"files",
+	"modulith",
+	"module descriptors",
+	"template packs",
+	"metadata",
+	"git",
+}
+
+// GenerateStages returns the stages Generate reports, in order
+func GenerateStages() []string {
+	return append([]string(nil), generateStages...)
+}
+
+// ProgressFunc receives the stage Generate is entering and how many
+// stages precede it out of the total.
+type ProgressFunc func(stage string, done, total int)
+
+// SetProgress registers fn to be called as Generate enters each stage
+func (g *Generator) SetProgress(fn ProgressFunc) {
+	g.progress = fn
+}
+
+// SetContext makes Generate stop before its next stage once ctx is
+// done. A cancelled run fails like any other: nothing is left in the
+// output directory unless SetKeepPartial is on.
+func (g *Generator) SetContext(ctx context.Context) {
+	g.ctx = ctx
+}
+
+// enter reports the stage and returns the context's error when the run
+// was cancelled. The git stage runs after the project is in place and
+// is never cancelled.
+func (g *Generator) enter(stage string) error {
+	if g.progress != nil {
+		for i, s := range generateStages {
+			if s == stage {
+				g.progress(stage, i, len(generateStages))
+				break
+			}
+		}
+	}
+	if g.ctx != nil && stage != "git" {
+		return g.ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_SQSFifoDeadLetter(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	gen, err := NewWithVersionAt(&config.ProjectConfig{
+		ProjectName:        "orders",
+		GroupID:            "com.test.orders",
+		ArtifactID:         "orders",
+		JavaVersion:        "21",
+		Modules:            []string{"Model", "Events", "EventConsumer"},
+		MessageBroker:      config.BrokerSQS,
+		SQSFifo:            true,
+		SQSDeadLetter:      true,
+		SQSMaxReceiveCount: 3,
+	}, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	script := readFile(t, projectPath, "localstack-init/ready.d/init-sqs.sh")
+	for _, want := range []string{
+		"awslocal sqs create-queue --queue-name placeholder-events-dlq.fifo --attributes '{\"FifoQueue\":\"true\"}'\n",
+		"awslocal sqs create-queue --queue-name placeholder-events.fifo --attributes '{\"ContentBasedDeduplication\":\"true\",\"FifoQueue\":\"true\",\"RedrivePolicy\":",
+		`\"maxReceiveCount\":\"3\"`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("init-sqs.sh missing %q:\n%s", want, script)
+		}
+	}
+	if bash, err := exec.LookPath("bash"); err == nil {
+		if out, err := exec.Command(bash, "-n", filepath.Join(projectPath, "localstack-init/ready.d/init-sqs.sh")).CombinedOutput(); err != nil {
+			t.Errorf("init-sqs.sh is not valid bash: %v\n%s", err, out)
+		}
+	}
+	compose := readFile(t, projectPath, "docker-compose.yml")
+	if !strings.Contains(compose, "        aws --endpoint-url=http://localstack:4566 sqs create-queue --queue-name placeholder-events-dlq.fifo") {
+		t.Errorf("docker-compose.yml should create the dead-letter queue:\n%s", compose)
+	}
+	if yml := readFile(t, projectPath, "EventConsumer/src/main/resources/application.yml"); !strings.Contains(yml, "${SQS_QUEUE_PLACEHOLDER:placeholder-events.fifo}") {
+		t.Errorf("application.yml should default to the FIFO queue:\n%s", yml)
+	}
+	publisher := readFile(t, projectPath, "Events/src/main/java/com/test/orders/events/EventPublisher.java")
+	for _, want := range []string{
+		"sqsTemplate.send(to -> to.queue(placeholderQueue).payload(event).messageGroupId(orderingKey(event)));",
+		"case PlaceholderCreatedEvent created -> created.placeholderId();",
+		"import com.test.orders.model.events.PlaceholderCreatedEvent;",
+	} {
+		if !strings.Contains(publisher, want) {
+			t.Errorf("EventPublisher missing %q:\n%s", want, publisher)
+		}
+	}
+	listener := readFile(t, projectPath, "EventConsumer/src/main/java/com/test/orders/eventconsumer/listener/PlaceholderEventListener.java")
+	if !strings.Contains(listener, "{@code placeholder-events-dlq.fifo}") || strings.Contains(listener, "in AWS Console") {
+		t.Errorf("listener should point to the generated dead-letter queue:\n%s", listener)
+	}
+
+	// A generated event gets its own FIFO queue and dead-letter queue
+	if _, err := GenerateEvent(projectPath, EventOpts{Name: "OrderShipped", Topic: "shipments"}, false); err != nil {
+		t.Fatalf("GenerateEvent failed: %v", err)
+	}
+	script = readFile(t, projectPath, "localstack-init/ready.d/init-sqs.sh")
+	dlq := strings.Index(script, "--queue-name shipments-dlq.fifo")
+	queue := strings.Index(script, "--queue-name shipments.fifo --attributes")
+	if dlq < 0 || queue < dlq {
+		t.Errorf("init-sqs.sh should create shipments-dlq.fifo, then shipments.fifo:\n%s", script)
+	}
+	compose = readFile(t, projectPath, "docker-compose.yml")
+	if !strings.Contains(compose, "echo \"Creating SQS queue: shipments.fifo\"") {
+		t.Errorf("docker-compose.yml should create the shipments queue:\n%s", compose)
+	}
+	publisher = readFile(t, projectPath, "Events/src/main/java/com/test/orders/events/EventPublisher.java")
+	for _, want := range []string{
+		"@Value(\"${app.sqs.queue.shipments:shipments.fifo}\")",
+		"sqsTemplate.send(to -> to.queue(shipmentsQueue).payload(event).messageGroupId(event.eventId()));",
+	} {
+		if !strings.Contains(publisher, want) {
+			t.Errorf("EventPublisher missing %q:\n%s", want, publisher)
+		}
+	}
+}
+
+func TestGenerator_Generate_PubSubOrderingExactlyOnce(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	gen, err := NewWithVersionAt(&config.ProjectConfig{
+		ProjectName:       "orders",
+		GroupID:           "com.test.orders",
+		ArtifactID:        "orders",
+		JavaVersion:       "21",
+		Modules:           []string{"Model", "API", "Events", "EventConsumer"},
+		MessageBroker:     config.BrokerPubSub,
+		PubSubOrdering:    true,
+		PubSubExactlyOnce: true,
+	}, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	compose := readFile(t, projectPath, "docker-compose.yml")
+	if !strings.Contains(compose, `-d '{"topic": "projects/local-project/topics/placeholder-events", "enableMessageOrdering": true, "enableExactlyOnceDelivery": true}'`) {
+		t.Errorf("docker-compose.yml should create an ordered exactly-once subscription:\n%s", compose)
+	}
+	if yml := readFile(t, projectPath, "API/src/main/resources/application.yml"); !strings.Contains(yml, "enable-message-ordering: true") {
+		t.Errorf("the API publisher should enable message ordering:\n%s", yml)
+	}
+	publisher := readFile(t, projectPath, "Events/src/main/java/com/test/orders/events/EventPublisher.java")
+	if !strings.Contains(publisher, "pubSubTemplate.publish(placeholderTopic, event, Map.of(GcpPubSubHeaders.ORDERING_KEY, orderingKey(event)));") {
+		t.Errorf("EventPublisher should publish with an ordering key:\n%s", publisher)
+	}
+	listener := readFile(t, projectPath, "EventConsumer/src/main/java/com/test/orders/eventconsumer/listener/PlaceholderEventListener.java")
+	if strings.Count(listener, "message.ack().join();") != 2 {
+		t.Errorf("listener should wait for both acks to be confirmed:\n%s", listener)
+	}
+	test := readFile(t, projectPath, "EventConsumer/src/test/java/com/test/orders/eventconsumer/listener/PlaceholderEventListenerTest.java")
+	if !strings.Contains(test, "when(message.ack()).thenReturn(CompletableFuture.completedFuture(null));") {
+		t.Errorf("listener test should complete the ack future:\n%s", test)
+	}
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/templates"
+	"github.com/arianlopezc/Trabuco/internal/versions"
+)
+
+// rateLimitFiles returns the template and output path of every file of the
+// API rate limiting. GlobalExceptionHandler gets a dedicated 429 handler only
+// at init; a retrofitted project renders RateLimitExceededException through
+// the handler it inherits from ResponseEntityExceptionHandler.
+func (g *Generator) rateLimitFiles() [][2]string {
+	return [][2]string{
+		{"java/api/config/RateLimitProperties.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "RateLimitProperties.java"))},
+		{"java/api/config/RateLimitExceededException.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "RateLimitExceededException.java"))},
+		{"java/api/config/RateLimitInterceptor.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "RateLimitInterceptor.java"))},
+		{"java/api/config/RateLimitConfig.java.tmpl", g.javaPath(config.ModuleAPI, filepath.Join("config", "RateLimitConfig.java"))},
+		{"java/api/test/config/RateLimitInterceptorTest.java.tmpl", g.testJavaPath(config.ModuleAPI, filepath.Join("config", "RateLimitInterceptorTest.java"))},
+	}
+}
+
+// generateRateLimit writes the rate limiting classes when the project asked
+// for them with --with-rate-limit.
+func (g *Generator) generateRateLimit() error {
+	if !g.config.HasRateLimit() {
+		return nil
+	}
+	for _, f := range g.rateLimitFiles() {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to generate rate limiting: %w", err)
+		}
+	}
+	return nil
+}
+
+// RetrofitRateLimit adds the API rate limiting to the existing project at
+// projectPath (`trabuco generate rate-limit`): the new classes (and the
+// error catalog in Model, when the project predates it), the bucket4j-core
+// version property in the parent POM, the Bucket4j and Caffeine
+// dependencies in API/pom.xml, and the flag in .trabuco.json. It refuses to
+// overwrite a file that already exists. It returns the files created,
+// relative to projectPath; in dry-run mode nothing is written and the files
+// that would be are returned.
+//
+// application.yml is left alone: the limits default to 100 requests a minute
+// per client until an app.rate-limit block overrides them.
+func RetrofitRateLimit(projectPath string, metadata *config.ProjectMetadata, dryRun bool) ([]string, error) {
+	cfg := metadata.ToProjectConfig()
+	if !cfg.HasModule(config.ModuleAPI) {
+		return nil, fmt.Errorf("rate limiting needs the API module")
+	}
+	if metadata.RateLimit {
+		return nil, fmt.Errorf("rate limiting is already part of this project")
+	}
+	cfg.RateLimit = true
+
+	gen := &Generator{
+		config: cfg,
+		engine: templates.NewEngine().WithProjectOverrides(projectPath),
+		outDir: projectPath,
+	}
+	files := gen.rateLimitFiles()
+	var created []string
+	for _, f := range files {
+		out := gen.sourcePath(f[0], f[1])
+		if _, err := os.Stat(filepath.Join(projectPath, out)); err == nil {
+			return nil, fmt.Errorf("refusing to overwrite existing file: %s (delete it first if you want to regenerate)", out)
+		}
+		created = append(created, out)
+	}
+	// RateLimitExceededException answers with a code of the error catalog
+	for _, f := range gen.missingErrorCatalogFiles() {
+		created = append(created, gen.sourcePath(f[0], f[1]))
+	}
+	if dryRun {
+		return created, nil
+	}
+
+	parentPom, err := NewPOMUpdater(filepath.Join(projectPath, "pom.xml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pom.xml: %w", err)
+	}
+	if err := parentPom.AddProperty("bucket4j-core.version", versions.Get("bucket4j-core")); err != nil {
+		return nil, fmt.Errorf("failed to add bucket4j-core.version to pom.xml: %w", err)
+	}
+	apiPom, err := NewPOMUpdater(filepath.Join(projectPath, config.ModuleAPI, "pom.xml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API pom.xml: %w", err)
+	}
+	if err := apiPom.AddDependency("com.bucket4j", "bucket4j_jdk17-core", "${bucket4j-core.version}"); err != nil {
+		return nil, fmt.Errorf("failed to add bucket4j to API pom.xml: %w", err)
+	}
+	// Version managed by the Spring Boot parent
+	if err := apiPom.AddDependency("com.github.ben-manes.caffeine", "caffeine", ""); err != nil {
+		return nil, fmt.Errorf("failed to add caffeine to API pom.xml: %w", err)
+	}
+	if err := parentPom.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save pom.xml: %w", err)
+	}
+	if err := apiPom.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save API pom.xml: %w", err)
+	}
+
+	for _, f := range files {
+		if err := gen.writeTemplate(f[0], f[1]); err != nil {
+			return nil, fmt.Errorf("failed to generate rate limiting: %w", err)
+		}
+	}
+	if _, err := gen.ensureErrorCatalog(); err != nil {
+		return nil, err
+	}
+	metadata.RateLimit = true
+	metadata.UpdateGeneratedAt()
+	if err := config.SaveMetadata(projectPath, metadata); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", config.MetadataFileName, err)
+	}
+	return created, nil
+}
@@ -0,0 +1,216 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerator_Generate_RateLimit(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "limited",
+		GroupID:       "com.test.limited",
+		ArtifactID:    "limited",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "API", "Events"},
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+		RateLimit:     true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("limited", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	read("API/src/main/java/com/test/limited/api/config/RateLimitProperties.java")
+	read("API/src/main/java/com/test/limited/api/config/RateLimitExceededException.java")
+	read("API/src/test/java/com/test/limited/api/config/RateLimitInterceptorTest.java")
+	if registration := read("API/src/main/java/com/test/limited/api/config/RateLimitConfig.java"); !strings.Contains(registration, `addPathPatterns("/api/**")`) {
+		t.Error("RateLimitConfig should register the interceptor for /api/**")
+	}
+	if interceptor := read("API/src/main/java/com/test/limited/api/config/RateLimitInterceptor.java"); !strings.Contains(interceptor, "tryConsumeAndReturnRemaining") {
+		t.Error("RateLimitInterceptor should consume from a Bucket4j bucket")
+	}
+	if handler := read("API/src/main/java/com/test/limited/api/config/GlobalExceptionHandler.java"); !strings.Contains(handler, "@ExceptionHandler(RateLimitExceededException.class)") {
+		t.Error("GlobalExceptionHandler should map RateLimitExceededException to 429")
+	}
+
+	apiPom := read("API/pom.xml")
+	if !strings.Contains(apiPom, "<artifactId>bucket4j_jdk17-core</artifactId>") || strings.Contains(apiPom, "bucket4j-spring-boot-starter") {
+		t.Error("API pom should use bucket4j core instead of the starter")
+	}
+	if !strings.Contains(read("pom.xml"), "<bucket4j-core.version>") {
+		t.Error("parent pom should define bucket4j-core.version")
+	}
+
+	// The rate-limit block joins the Kafka app: key instead of adding a second one
+	var yml struct {
+		Bucket4j any `yaml:"bucket4j"`
+		App      struct {
+			Kafka     map[string]any `yaml:"kafka"`
+			RateLimit map[string]any `yaml:"rate-limit"`
+		} `yaml:"app"`
+	}
+	content := read("API/src/main/resources/application.yml")
+	if err := yaml.Unmarshal([]byte(content), &yml); err != nil {
+		t.Fatalf("application.yml should parse: %v", err)
+	}
+	if strings.Count(content, "\napp:") != 1 {
+		t.Errorf("application.yml should have one app: key, got %d", strings.Count(content, "\napp:"))
+	}
+	if yml.App.Kafka == nil || yml.App.RateLimit["capacity"] != "${RATE_LIMIT_CAPACITY:100}" {
+		t.Errorf("app should hold kafka and rate-limit, got %+v", yml.App)
+	}
+	if yml.Bucket4j != nil {
+		t.Error("the starter's bucket4j block should be dropped")
+	}
+
+	metadata, err := config.LoadMetadata(filepath.Join(tempDir, "limited"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.RateLimit {
+		t.Error("metadata should persist rateLimit")
+	}
+}
+
+func TestGenerator_Generate_RateLimitWithoutAPI(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "worker-only",
+		GroupID:     "com.test.workeronly",
+		ArtifactID:  "worker-only",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Jobs", "Worker"},
+		Database:    "postgresql",
+		RateLimit:   true,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("worker-only", "API")); !os.IsNotExist(err) {
+		t.Error("rate limiting without the API module should not create it")
+	}
+	pom, err := os.ReadFile(filepath.Join("worker-only", "pom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(pom), "bucket4j") {
+		t.Error("parent pom should not carry bucket4j without the API module")
+	}
+}
+
+func TestRetrofitRateLimit(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "retro",
+		GroupID:     "com.test.retro",
+		ArtifactID:  "retro",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "retro")
+	interceptor := filepath.Join(projectPath, "API/src/main/java/com/test/retro/api/config/RateLimitInterceptor.java")
+	apiPomPath := filepath.Join(projectPath, "API", "pom.xml")
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	planned, err := RetrofitRateLimit(projectPath, metadata, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if _, err := os.Stat(interceptor); !os.IsNotExist(err) {
+		t.Error("dry run should not write files")
+	}
+	if pom, _ := os.ReadFile(apiPomPath); strings.Contains(string(pom), "bucket4j_jdk17-core") {
+		t.Error("dry run should not edit the API pom")
+	}
+
+	created, err := RetrofitRateLimit(projectPath, metadata, false)
+	if err != nil {
+		t.Fatalf("RetrofitRateLimit failed: %v", err)
+	}
+	if len(created) != len(planned) || len(created) != 5 {
+		t.Errorf("expected the 5 planned files, planned %v, created %v", planned, created)
+	}
+	if _, err := os.Stat(interceptor); err != nil {
+		t.Errorf("expected RateLimitInterceptor.java: %v", err)
+	}
+	apiPom, err := os.ReadFile(apiPomPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<artifactId>bucket4j_jdk17-core</artifactId>", "<version>${bucket4j-core.version}</version>", "<artifactId>caffeine</artifactId>"} {
+		if !strings.Contains(string(apiPom), want) {
+			t.Errorf("API pom should contain %s", want)
+		}
+	}
+	parentPom, err := os.ReadFile(filepath.Join(projectPath, "pom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(parentPom), "<bucket4j-core.version>") {
+		t.Error("parent pom should define bucket4j-core.version")
+	}
+
+	reloaded, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.RateLimit {
+		t.Error("retrofit should record rateLimit in .trabuco.json")
+	}
+	if _, err := RetrofitRateLimit(projectPath, reloaded, false); err == nil {
+		t.Error("a second retrofit should be rejected")
+	}
+
+	// A user file in the way stops the retrofit before anything is written.
+	reloaded.RateLimit = false
+	if _, err := RetrofitRateLimit(projectPath, reloaded, false); err == nil || !strings.Contains(err.Error(), "refusing to overwrite") {
+		t.Errorf("expected refusal to overwrite, got %v", err)
+	}
+}
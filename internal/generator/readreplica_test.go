@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_ReadReplica(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "replica")
+	cfg := &config.ProjectConfig{
+		ProjectName: "replica",
+		GroupID:     "com.test.replica",
+		ArtifactID:  "replica",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker"},
+		Database:    config.DatabasePostgreSQL,
+		ReadReplica: true,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	routing := readFile(t, projectPath, "SQLDatastore/src/main/java/com/test/replica/sqldatastore/config/ReadWriteRoutingDataSource.java")
+	if !strings.Contains(routing, "isCurrentTransactionReadOnly()") {
+		t.Error("the routing data source should route on the read-only flag")
+	}
+	replicaConfig := readFile(t, projectPath, "SQLDatastore/src/main/java/com/test/replica/sqldatastore/config/ReadReplicaConfig.java")
+	for _, want := range []string{"new LazyConnectionDataSourceProxy(routing)", `@ConfigurationProperties("spring.datasource.read")`, "JdbcConnectionDetails"} {
+		if !strings.Contains(replicaConfig, want) {
+			t.Errorf("ReadReplicaConfig should contain %s", want)
+		}
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			Volumes   []string               `yaml:"volumes"`
+			DependsOn map[string]interface{} `yaml:"depends_on"`
+		} `yaml:"services"`
+		Volumes map[string]interface{} `yaml:"volumes"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, projectPath, "docker-compose.yml")), &compose); err != nil {
+		t.Fatalf("docker-compose.yml is not valid YAML: %v", err)
+	}
+	replica, ok := compose.Services["postgres-replica"]
+	if !ok || replica.DependsOn["postgres"] == nil {
+		t.Fatal("docker-compose.yml should start postgres-replica after postgres")
+	}
+	if _, ok := compose.Volumes["postgres_replica_data"]; !ok {
+		t.Error("the replica needs its own volume")
+	}
+	if !strings.Contains(strings.Join(compose.Services["postgres"].Volumes, " "), "./postgres-init:/docker-entrypoint-initdb.d") {
+		t.Error("the primary should run postgres-init/replication.sh")
+	}
+	if api, ok := compose.Services["api"]; ok && api.DependsOn["postgres-replica"] == nil {
+		t.Error("the containerized API should wait for the replica")
+	}
+	info, err := os.Stat(filepath.Join(projectPath, "postgres-init", "replication.sh"))
+	if err != nil || info.Mode()&0100 == 0 {
+		t.Errorf("postgres-init/replication.sh should be executable: %v", err)
+	}
+
+	for _, module := range []string{"API", "Worker"} {
+		var doc struct {
+			Spring struct {
+				Datasource struct {
+					Read map[string]interface{} `yaml:"read"`
+				} `yaml:"datasource"`
+			} `yaml:"spring"`
+		}
+		if err := yaml.Unmarshal([]byte(readFile(t, projectPath, module+"/src/main/resources/application.yml")), &doc); err != nil {
+			t.Fatalf("%s application.yml is not valid YAML: %v", module, err)
+		}
+		url, _ := doc.Spring.Datasource.Read["jdbc-url"].(string)
+		if !strings.Contains(url, "${DB_READ_PORT:5435}") {
+			t.Errorf("%s should read from the replica, got %q", module, url)
+		}
+	}
+	if !strings.Contains(readFile(t, projectPath, "API/src/main/resources/application-prod.yml"), "${DB_READ_HOST}") {
+		t.Error("the prod profile should require DB_READ_HOST")
+	}
+}
+
+func TestGenerator_Generate_ReadReplicaMySQL(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "replica")
+	cfg := &config.ProjectConfig{
+		ProjectName: "replica",
+		GroupID:     "com.test.replica",
+		ArtifactID:  "replica",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    config.DatabaseMySQL,
+		ReadReplica: true,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if strings.Contains(readFile(t, projectPath, "docker-compose.yml"), "postgres-replica") {
+		t.Error("MySQL gets no compose replica")
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "postgres-init")); !os.IsNotExist(err) {
+		t.Error("postgres-init is for the PostgreSQL replica only")
+	}
+	if !strings.Contains(readFile(t, projectPath, "API/src/main/resources/application.yml"), "jdbc-url: jdbc:mysql://${DB_READ_HOST:${DB_HOST:localhost}}:${DB_READ_PORT:${DB_PORT:3307}}") {
+		t.Error("the MySQL read datasource should default to the primary")
+	}
+}
@@ -0,0 +1,380 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+)
+
+// LastOperationFile is written to the project root after every init/add so
+// the most recent change set can be reviewed after the terminal is gone.
+const LastOperationFile = "LAST_OPERATION.md"
+
+// projectFilesGroup is the group for files that don't live in a module
+const projectFilesGroup = "(project)"
+
+// OperationReport describes everything an init or add run changed. The CLI
+// prints it as a summary table, it is persisted as LAST_OPERATION.md, and
+// MCP tools return it as-is.
+type OperationReport struct {
+	Operation      string        `json:"operation"` // "init" or "add"
+	Project        string        `json:"project"`
+	Modules        []string      `json:"modules"` // Modules generated by this operation
+	Timestamp      string        `json:"timestamp"`
+	FilesCreated   []ModuleFiles `json:"files_created"`
+	FilesModified  []string      `json:"files_modified"`
+	DockerServices []string      `json:"docker_services_added"`
+	NextSteps      []string      `json:"next_steps"`
+	DocsKept       []KeptDoc     `json:"docs_kept,omitempty"` // Docs left untouched instead of regenerated
+	// Conflicts are files a merging init found with other content and left
+	// as they were
+	Conflicts []string `json:"conflicts,omitempty"`
+	// ReplacedBackup is where a forced init moved the previous directory
+	ReplacedBackup string `json:"replaced_backup,omitempty"`
+	// Saved records whether Save wrote LAST_OPERATION.md
+	Saved bool `json:"-"`
+}
+
+// KeptDoc is a doc 'trabuco add' left untouched because it has no intact
+// managed-region markers to merge into
+type KeptDoc struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+// ModuleFiles lists files created under one module directory
+type ModuleFiles struct {
+	Module string   `json:"module"`
+	Files  []string `json:"files"`
+}
+
+// CreatedCount returns the total number of files created
+func (r *OperationReport) CreatedCount() int {
+	n := 0
+	for _, m := range r.FilesCreated {
+		n += len(m.Files)
+	}
+	return n
+}
+
+// CreatedFiles returns every file created, project-relative
+func (r *OperationReport) CreatedFiles() []string {
+	var files []string
+	for _, m := range r.FilesCreated {
+		files = append(files, m.Files...)
+	}
+	return files
+}
+
+// treeSnapshot maps project-relative paths to content hashes
+type treeSnapshot map[string]string
+
+// snapshotTree hashes every file under root, skipping VCS metadata, build
+// output, backups and the previous LAST_OPERATION.md. A missing root yields
+// an empty snapshot.
+func snapshotTree(root string) treeSnapshot {
+	snap := treeSnapshot{}
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "target", "node_modules", BackupDirName:
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel == LastOperationFile || rel == config.HistoryFileName {
+			return nil
+		}
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil
+		}
+		sum := sha256.Sum256(data)
+		snap[rel] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	return snap
+}
+
+// composeServices returns the service names declared in a compose file
+func composeServices(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var doc struct {
+		Services map[string]interface{} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(doc.Services))
+	for name := range doc.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// operationTracker captures the project state before an operation so the
+// report can be derived from what actually changed on disk.
+type operationTracker struct {
+	root     string
+	before   treeSnapshot
+	services map[string]bool
+}
+
+func newOperationTracker(root string) *operationTracker {
+	t := &operationTracker{
+		root:     root,
+		before:   snapshotTree(root),
+		services: map[string]bool{},
+	}
+	for _, s := range composeServices(filepath.Join(root, "docker-compose.yml")) {
+		t.services[s] = true
+	}
+	return t
+}
+
+// report diffs the current state against the snapshot. Created files are
+// grouped by the top-level module directory they live in.
+func (t *operationTracker) report(operation string, cfg *config.ProjectConfig, modules []string) *OperationReport {
+	r := &OperationReport{
+		Operation: operation,
+		Project:   cfg.ProjectName,
+		Modules:   modules,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	after := snapshotTree(t.root)
+	byModule := map[string][]string{}
+	for path, hash := range after {
+		old, existed := t.before[path]
+		switch {
+		case !existed:
+			group := projectFilesGroup
+			if top, _, found := strings.Cut(path, "/"); found && config.GetModule(top) != nil {
+				group = top
+			}
+			byModule[group] = append(byModule[group], path)
+		case old != hash:
+			r.FilesModified = append(r.FilesModified, path)
+		}
+	}
+	sort.Strings(r.FilesModified)
+
+	// Module groups in registry order, project-level files last
+	for _, m := range config.ModuleRegistry {
+		if files, ok := byModule[m.Name]; ok {
+			sort.Strings(files)
+			r.FilesCreated = append(r.FilesCreated, ModuleFiles{Module: m.Name, Files: files})
+		}
+	}
+	if files, ok := byModule[projectFilesGroup]; ok {
+		sort.Strings(files)
+		r.FilesCreated = append(r.FilesCreated, ModuleFiles{Module: projectFilesGroup, Files: files})
+	}
+
+	for _, s := range composeServices(filepath.Join(t.root, "docker-compose.yml")) {
+		if !t.services[s] {
+			r.DockerServices = append(r.DockerServices, s)
+		}
+	}
+
+	r.NextSteps = nextSteps(operation, cfg, r)
+	return r
+}
+
+// nextSteps suggests what to run after the operation
+func nextSteps(operation string, cfg *config.ProjectConfig, r *OperationReport) []string {
+	var steps []string
+	if operation == "init" {
+		steps = append(steps, fmt.Sprintf("cd %s", cfg.ProjectName))
+	}
+	steps = append(steps, "mvn clean install")
+	if len(r.DockerServices) > 0 {
+		steps = append(steps, "docker compose up -d")
+	}
+	if operation == "init" && cfg.HasObservability() {
+		steps = append(steps, "docker compose --profile observability up -d")
+	}
+	if operation == "init" && cfg.HasModule(config.ModuleAPI) {
+		steps = append(steps, fmt.Sprintf("cd %s && mvn spring-boot:run", config.ModuleAPI))
+	}
+	return steps
+}
+
+// Markdown renders the report as the LAST_OPERATION.md document
+func (r *OperationReport) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Last Trabuco operation: %s\n\n", r.Operation)
+	fmt.Fprintf(&b, "- **Project:** %s\n", r.Project)
+	if len(r.Modules) > 0 {
+		fmt.Fprintf(&b, "- **Modules:** %s\n", strings.Join(r.Modules, ", "))
+	}
+	fmt.Fprintf(&b, "- **When:** %s\n\n", r.Timestamp)
+
+	fmt.Fprintf(&b, "## Files created (%d)\n\n", r.CreatedCount())
+	if len(r.FilesCreated) == 0 {
+		b.WriteString("None.\n\n")
+	} else {
+		b.WriteString("| Module | Files |\n|--------|------:|\n")
+		for _, m := range r.FilesCreated {
+			fmt.Fprintf(&b, "| %s | %d |\n", m.Module, len(m.Files))
+		}
+		b.WriteString("\n")
+		for _, m := range r.FilesCreated {
+			fmt.Fprintf(&b, "<details><summary>%s</summary>\n\n", m.Module)
+			for _, f := range m.Files {
+				fmt.Fprintf(&b, "- `%s`\n", f)
+			}
+			b.WriteString("\n</details>\n\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "## Files modified (%d)\n\n", len(r.FilesModified))
+	writeMarkdownList(&b, r.FilesModified, true)
+
+	fmt.Fprintf(&b, "## Docker services added (%d)\n\n", len(r.DockerServices))
+	writeMarkdownList(&b, r.DockerServices, false)
+
+	if len(r.DocsKept) > 0 {
+		fmt.Fprintf(&b, "## Docs not regenerated (%d)\n\n", len(r.DocsKept))
+		for _, d := range r.DocsKept {
+			fmt.Fprintf(&b, "- `%s`: %s\n", d.File, d.Reason)
+		}
+		b.WriteString("\nRe-run with `--force-docs` to overwrite them.\n\n")
+	}
+
+	if len(r.Conflicts) > 0 {
+		fmt.Fprintf(&b, "## Conflicts (%d)\n\n", len(r.Conflicts))
+		b.WriteString("These files already existed with other content and were left as they were.\n\n")
+		writeMarkdownList(&b, r.Conflicts, true)
+	}
+
+	if r.ReplacedBackup != "" {
+		fmt.Fprintf(&b, "The previous directory was moved to `%s`.\n\n", r.ReplacedBackup)
+	}
+
+	b.WriteString("## Next steps\n\n")
+	b.WriteString("```bash\n")
+	for _, s := range r.NextSteps {
+		b.WriteString(s + "\n")
+	}
+	b.WriteString("```\n")
+	return b.String()
+}
+
+func writeMarkdownList(b *strings.Builder, items []string, code bool) {
+	if len(items) == 0 {
+		b.WriteString("None.\n\n")
+		return
+	}
+	for _, item := range items {
+		if code {
+			fmt.Fprintf(b, "- `%s`\n", item)
+		} else {
+			fmt.Fprintf(b, "- %s\n", item)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// Save writes LAST_OPERATION.md into the project root
+func (r *OperationReport) Save(projectRoot string) error {
+	if err := os.WriteFile(filepath.Join(projectRoot, LastOperationFile), []byte(r.Markdown()), 0644); err != nil {
+		return err
+	}
+	r.Saved = true
+	return nil
+}
+
+// Print renders the report as a colored summary table
+func (r *OperationReport) Print() {
+	cyan := color.New(color.FgCyan, color.Bold)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	bold := color.New(color.Bold)
+
+	line := strings.Repeat("─", 41)
+	fmt.Println()
+	cyan.Println(line)
+	cyan.Printf("  Summary: trabuco %s %s\n", r.Operation, r.Project)
+	cyan.Println(line)
+
+	bold.Printf("  %-28s %10s\n", "Files created", "Count")
+	for _, m := range r.FilesCreated {
+		fmt.Printf("  %-28s ", m.Module)
+		green.Printf("%10d\n", len(m.Files))
+	}
+	fmt.Printf("  %-28s ", "Total")
+	green.Printf("%10d\n", r.CreatedCount())
+
+	if len(r.FilesModified) > 0 {
+		fmt.Println()
+		bold.Println("  Files modified")
+		for _, f := range r.FilesModified {
+			yellow.Printf("  ~ %s\n", f)
+		}
+	}
+
+	if len(r.DockerServices) > 0 {
+		fmt.Println()
+		bold.Println("  Docker services added")
+		for _, s := range r.DockerServices {
+			green.Printf("  + %s\n", s)
+		}
+	}
+
+	if len(r.DocsKept) > 0 {
+		fmt.Println()
+		bold.Println("  Docs not regenerated (use --force-docs to overwrite)")
+		for _, d := range r.DocsKept {
+			yellow.Printf("  ! %s: %s\n", d.File, d.Reason)
+		}
+	}
+
+	if len(r.Conflicts) > 0 {
+		fmt.Println()
+		bold.Println("  Conflicts (existing content kept)")
+		for _, f := range r.Conflicts {
+			yellow.Printf("  ! %s\n", f)
+		}
+	}
+
+	if r.ReplacedBackup != "" {
+		fmt.Println()
+		bold.Println("  Previous directory moved to")
+		fmt.Printf("    %s\n", r.ReplacedBackup)
+	}
+
+	if len(r.NextSteps) > 0 {
+		fmt.Println()
+		bold.Println("  Next steps")
+		for _, s := range r.NextSteps {
+			fmt.Printf("    %s\n", s)
+		}
+	}
+	cyan.Println(line)
+	if r.Saved {
+		fmt.Printf("  Saved to %s\n", LastOperationFile)
+	}
+}
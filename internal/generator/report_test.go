@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_OperationReport(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "report-app",
+		GroupID:     "com.test.report",
+		ArtifactID:  "report-app",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	report := gen.Report()
+	if report == nil || report.Operation != "init" {
+		t.Fatalf("expected an init report, got %+v", report)
+	}
+	groups := map[string]int{}
+	for _, m := range report.FilesCreated {
+		groups[m.Module] = len(m.Files)
+	}
+	for _, want := range []string{"Model", "SQLDatastore", "Shared", "API", projectFilesGroup} {
+		if groups[want] == 0 {
+			t.Errorf("expected created files grouped under %s, got %v", want, groups)
+		}
+	}
+	if len(report.FilesModified) != 0 {
+		t.Errorf("init should not report modified files, got %v", report.FilesModified)
+	}
+	if !strings.Contains(strings.Join(report.DockerServices, ","), "postgres") {
+		t.Errorf("expected postgres docker service, got %v", report.DockerServices)
+	}
+
+	md, err := os.ReadFile(filepath.Join("report-app", LastOperationFile))
+	if err != nil {
+		t.Fatalf("expected %s: %v", LastOperationFile, err)
+	}
+	if !report.Saved {
+		t.Errorf("expected the report to record that %s was saved", LastOperationFile)
+	}
+	for _, want := range []string{"# Last Trabuco operation: init", "| API |", "## Docker services added", "mvn clean install"} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("%s should contain %q", LastOperationFile, want)
+		}
+	}
+}
+
+func TestModuleAdder_Add_OperationReport(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "report-add",
+		GroupID:     "com.test.reportadd",
+		ArtifactID:  "report-add",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Shared", "API"},
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "report-add")
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adder := NewModuleAdder(projectPath, metadata, "test", false)
+	if err := adder.Add(config.ModuleSQLDatastore, config.DatabasePostgreSQL, "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	report := adder.Report()
+	if report == nil || report.Operation != "add" {
+		t.Fatalf("expected an add report, got %+v", report)
+	}
+	created := map[string][]string{}
+	for _, m := range report.FilesCreated {
+		created[m.Module] = m.Files
+	}
+	if !strings.Contains(strings.Join(created[config.ModuleSQLDatastore], ","), "SQLDatastore/pom.xml") {
+		t.Errorf("expected SQLDatastore/pom.xml under SQLDatastore, got %+v", report.FilesCreated)
+	}
+	modified := strings.Join(report.FilesModified, ",")
+	for _, want := range []string{"pom.xml", ".trabuco.json"} {
+		if !strings.Contains(modified, want) {
+			t.Errorf("expected %s among modified files, got %v", want, report.FilesModified)
+		}
+	}
+	if !strings.Contains(strings.Join(report.DockerServices, ","), "postgres") {
+		t.Errorf("expected postgres service to be reported as added, got %v", report.DockerServices)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, LastOperationFile)); err != nil {
+		t.Errorf("expected %s after add: %v", LastOperationFile, err)
+	}
+}
+
+func TestOperationReport_Save(t *testing.T) {
+	report := &OperationReport{Operation: "add", Project: "demo"}
+	if err := report.Save(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected Save to fail without the project directory")
+	}
+	if report.Saved {
+		t.Error("a failed Save should not mark the report as saved")
+	}
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// secretsData renders the per-module secrets manager configuration
+type secretsData struct {
+	*config.ProjectConfig
+	Target config.RuntimeTarget
+}
+
+// generateSecrets writes the secrets.yml imported by the application.yml of
+// every runtime module. The starters themselves live in the module POM
+// templates and the dev-mode Vault in docker-compose.yml.
+func (g *Generator) generateSecrets() error {
+	if !g.config.HasSecrets() {
+		return nil
+	}
+	for _, target := range g.config.ModuleRuntimeTargets() {
+		if err := g.generateSecretsModule(target.Module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateSecretsModule writes the secrets.yml of one runtime module. Other
+// modules are skipped.
+func (g *Generator) generateSecretsModule(module string) error {
+	if !g.config.HasSecrets() {
+		return nil
+	}
+	for _, target := range g.config.ModuleRuntimeTargets() {
+		if target.Module != module {
+			continue
+		}
+		data := &secretsData{ProjectConfig: g.config, Target: target}
+		if err := g.writeTemplateWithData("java/secrets/secrets.yml.tmpl", g.resourcePath(module, "secrets.yml"), data); err != nil {
+			return fmt.Errorf("failed to generate %s secrets config: %w", module, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_VaultSecrets(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "vaulted",
+		GroupID:     "com.test.vaulted",
+		ArtifactID:  "vaulted",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "Jobs", "Worker"},
+		Secrets:     config.SecretsVault,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("vaulted", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	if secrets := read("Worker/src/main/resources/secrets.yml"); !strings.Contains(secrets, "import: optional:vault://") || !strings.Contains(secrets, "default-context: vaulted") {
+		t.Errorf("Worker secrets.yml should import Vault with the project's KV context:\n%s", secrets)
+	}
+	if yml := read("Worker/src/main/resources/application.yml"); !strings.Contains(yml, "import: classpath:secrets.yml") || strings.Contains(yml, "password: postgres") {
+		t.Errorf("Worker application.yml should import secrets.yml and keep no literal credentials:\n%s", yml)
+	}
+	if !strings.Contains(read("Worker/pom.xml"), "spring-cloud-starter-vault-config") {
+		t.Error("Worker pom.xml should depend on the Vault config starter")
+	}
+	if !strings.Contains(read("pom.xml"), "spring-cloud-dependencies") {
+		t.Error("parent pom.xml should import the Spring Cloud BOM")
+	}
+	compose := read("docker-compose.yml")
+	for _, want := range []string{"hashicorp/vault", "VAULT_DEV_ROOT_TOKEN_ID", "vault kv put secret/vaulted", "DB_PASSWORD=postgres"} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml should contain %q", want)
+		}
+	}
+	if env := read(".env.prod.example"); !strings.Contains(env, "VAULT_FAIL_FAST=true") {
+		t.Errorf(".env.prod.example should fail fast without Vault:\n%s", env)
+	}
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// ApplySpecScaffolds generates the scaffolds of a project spec into the
+// project at projectPath: entities first, so endpoints and jobs declared
+// after them can refer to their enums, then endpoints, then jobs. The
+// results are merged into one; the first failure stops the run and names
+// the scaffold.
+func ApplySpecScaffolds(projectPath string, scaffolds config.SpecScaffolds) (*addgen.Result, error) {
+	merged := &addgen.Result{}
+	merge := func(r *addgen.Result) {
+		merged.Created = append(merged.Created, r.Created...)
+		merged.NextSteps = append(merged.NextSteps, r.NextSteps...)
+		merged.Notes = append(merged.Notes, r.Notes...)
+	}
+
+	for _, e := range scaffolds.Entities {
+		ctx, err := addgen.LoadContext(projectPath)
+		if err != nil {
+			return merged, err
+		}
+		r, err := addgen.GenerateEntity(ctx, addgen.EntityOpts{
+			Name:      e.Name,
+			Fields:    string(e.Fields),
+			Module:    e.Module,
+			TableName: e.TableName,
+		})
+		if err != nil {
+			return merged, fmt.Errorf("entity %s: %w", e.Name, err)
+		}
+		merge(r)
+	}
+	for _, e := range scaffolds.Endpoints {
+		r, err := GenerateEndpoint(projectPath, EndpointOpts{
+			Path:    e.Path,
+			Name:    e.Name,
+			Methods: e.Methods,
+			Fields:  string(e.Fields),
+		}, false)
+		if err != nil {
+			return merged, fmt.Errorf("endpoint %s: %w", e.Path, err)
+		}
+		merge(r)
+	}
+	for _, j := range scaffolds.Jobs {
+		r, err := GenerateJob(projectPath, JobOpts{
+			Name:     j.Name,
+			Payload:  string(j.Payload),
+			Schedule: j.Schedule,
+		}, false)
+		if err != nil {
+			return merged, fmt.Errorf("job %s: %w", j.Name, err)
+		}
+		merge(r)
+	}
+	return merged, nil
+}
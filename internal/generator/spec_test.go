@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestApplySpecScaffolds(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName: "shop",
+		GroupID:     "com.test.shop",
+		ArtifactID:  "shop",
+		JavaVersion: "21",
+		Modules:     config.ResolveDependencies([]string{"SQLDatastore", "API", "Worker"}),
+		Database:    config.DatabasePostgreSQL,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	result, err := ApplySpecScaffolds(projectPath, config.SpecScaffolds{
+		Entities:  []config.SpecEntity{{Name: "Order", Fields: "customerId:uuid,total:decimal"}},
+		Endpoints: []config.SpecEndpoint{{Path: "/orders", Methods: []string{"GET", "POST"}}},
+		Jobs:      []config.SpecJob{{Name: "SendDigest", Schedule: "0 0 8 * * *"}},
+	})
+	if err != nil {
+		t.Fatalf("ApplySpecScaffolds failed: %v", err)
+	}
+	for _, want := range []string{
+		"Model/src/main/java/com/test/shop/model/entities/Order.java",
+		"SQLDatastore/src/main/resources/db/migration/V2__create_orders.sql",
+		"API/src/main/java/com/test/shop/api/controller/OrderController.java",
+		"Worker/src/main/java/com/test/shop/worker/handler/SendDigestJobRequestHandler.java",
+	} {
+		if _, err := os.Stat(filepath.Join(projectPath, want)); err != nil {
+			t.Errorf("expected %s: %v", want, err)
+		}
+	}
+	if len(result.Created) < 4 {
+		t.Errorf("Created = %v", result.Created)
+	}
+
+	_, err = ApplySpecScaffolds(projectPath, config.SpecScaffolds{
+		Jobs: []config.SpecJob{{Name: "SendDigest", Schedule: "0 0 8 * * *"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "job SendDigest") {
+		t.Errorf("a scaffold that already exists should fail naming it, got %v", err)
+	}
+}
+
+func TestExportProjectSpec_GeneratedProject(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "billing")
+	cfg := &config.ProjectConfig{
+		ProjectName:     "billing",
+		GroupID:         "com.test.billing",
+		ArtifactID:      "billing",
+		JavaVersion:     "21",
+		Modules:         config.ResolveDependencies([]string{"SQLDatastore", "API", "Worker"}),
+		Database:        config.DatabaseMySQL,
+		DatabaseVersion: "8.4",
+		ReadReplica:     true,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	spec, notes, err := config.ExportProjectSpec(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 0 {
+		t.Errorf("a freshly generated project should export without notes: %v", notes)
+	}
+	if got := strings.Join(spec.Modules, ","); got != "SQLDatastore,API,Worker" {
+		t.Errorf("Modules = %s", got)
+	}
+	if spec.GroupID != "com.test.billing" || spec.Database != config.DatabaseMySQL || spec.DBVersion != "8.4" {
+		t.Errorf("spec = %+v", spec)
+	}
+	if spec.Options["with-read-replica"] != true {
+		t.Errorf("Options = %v", spec.Options)
+	}
+}
@@ -0,0 +1,29 @@
+package generator
+
+import "fmt"
+
+// taskRunnerFile returns the template and output path of the root file of
+// developer shortcuts the project asked for with --task-runner, or false
+// when it has none. The targets follow the modules (run-api, run-worker,
+// up/down when there is a docker-compose), so 'trabuco add' regenerates it.
+func (g *Generator) taskRunnerFile() (tmpl, out string, ok bool) {
+	switch {
+	case g.config.HasMakefile():
+		return "taskrunner/Makefile.tmpl", "Makefile", true
+	case g.config.HasTaskfile():
+		return "taskrunner/Taskfile.yml.tmpl", "Taskfile.yml", true
+	}
+	return "", "", false
+}
+
+// generateTaskRunner writes the root Makefile or Taskfile.yml.
+func (g *Generator) generateTaskRunner() error {
+	tmpl, out, ok := g.taskRunnerFile()
+	if !ok {
+		return nil
+	}
+	if err := g.writeTemplate(tmpl, out); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", out, err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_Makefile(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shortcuts")
+	cfg := &config.ProjectConfig{
+		ProjectName: "shortcuts",
+		GroupID:     "com.test.shortcuts",
+		ArtifactID:  "shortcuts",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    "postgresql",
+		TaskRunner:  config.TaskRunnerMake,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectPath, "Makefile"))
+	if err != nil {
+		t.Fatalf("expected a Makefile: %v", err)
+	}
+	makefile := string(data)
+	for _, target := range []string{"help:", "up:", "down:", "run-api:", "test:", "fmt:", "verify:"} {
+		if !strings.Contains(makefile, "\n"+target) {
+			t.Errorf("Makefile should have the %s target", target)
+		}
+	}
+	if strings.Contains(makefile, "run-worker:") {
+		t.Error("Makefile should not run a Worker the project does not have")
+	}
+	if !strings.Contains(makefile, "\n\t$(MVN) -pl API spring-boot:run\n") {
+		t.Error("recipes should be indented with a tab")
+	}
+	if !strings.Contains(makefile, "jacoco:check@coverage-check") {
+		t.Error("verify should run the coverage gates like CI")
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "Taskfile.yml")); err == nil {
+		t.Error("Taskfile.yml should not be generated with --task-runner make")
+	}
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.TaskRunner != config.TaskRunnerMake {
+		t.Errorf("expected taskRunner make in metadata, got %q", metadata.TaskRunner)
+	}
+	adder := NewModuleAdder(projectPath, metadata, "test", false)
+	if err := adder.Add(config.ModuleWorker, "", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(projectPath, "Makefile"))
+	if !strings.Contains(string(data), "\nrun-worker:") {
+		t.Error("adding the Worker should add the run-worker target")
+	}
+}
+
+func TestGenerator_Generate_Taskfile(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shortcuts")
+	cfg := &config.ProjectConfig{
+		ProjectName:     "shortcuts",
+		GroupID:         "com.test.shortcuts",
+		ArtifactID:      "shortcuts",
+		JavaVersion:     "21",
+		Modules:         []string{"Model", "Shared", "API", "Jobs", "Worker", "AIAgent"},
+		TaskRunner:      config.TaskRunnerTask,
+		NoCoverageGates: true,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectPath, "Taskfile.yml"))
+	if err != nil {
+		t.Fatalf("expected a Taskfile.yml: %v", err)
+	}
+	var taskfile struct {
+		Version string `yaml:"version"`
+		Tasks   map[string]struct {
+			Env  map[string]string `yaml:"env"`
+			Cmds []string          `yaml:"cmds"`
+		} `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal(data, &taskfile); err != nil {
+		t.Fatalf("Taskfile.yml should be valid YAML: %v\n%s", err, data)
+	}
+	for _, task := range []string{"run-api", "run-worker", "run-aiagent", "test", "fmt", "verify"} {
+		if _, ok := taskfile.Tasks[task]; !ok {
+			t.Errorf("Taskfile.yml should have the %s task", task)
+		}
+	}
+	if port := taskfile.Tasks["run-aiagent"].Env["SERVER_PORT"]; port != "8086" {
+		t.Errorf("the AIAgent should run next to the API on 8086, got %q", port)
+	}
+	if strings.Contains(strings.Join(taskfile.Tasks["verify"].Cmds, "\n"), "jacoco:check") {
+		t.Error("verify should not check coverage with --no-coverage-gates")
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "Makefile")); err == nil {
+		t.Error("Makefile should not be generated with --task-runner task")
+	}
+}
+
+func TestGenerator_Generate_NoTaskRunner(t *testing.T) {
+	for _, runner := range []string{config.TaskRunnerNone, ""} {
+		projectPath := filepath.Join(t.TempDir(), "plain")
+		cfg := &config.ProjectConfig{
+			ProjectName: "plain",
+			GroupID:     "com.test.plain",
+			ArtifactID:  "plain",
+			JavaVersion: "21",
+			Modules:     []string{"Model", "Shared", "API"},
+			TaskRunner:  runner,
+		}
+		gen, err := NewWithVersionAt(cfg, "test", projectPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		for _, file := range []string{"Makefile", "Taskfile.yml"} {
+			if _, err := os.Stat(filepath.Join(projectPath, file)); err == nil {
+				t.Errorf("%s should not be generated with task runner %q", file, runner)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// modelFixtureFiles returns the template and output path of Model's test
+// fixtures, which Shared, API and Worker reuse through Model's test-jar.
+func (g *Generator) modelFixtureFiles() [][2]string {
+	return [][2]string{
+		{"java/model/test/fixtures/RandomData.java.tmpl", g.testJavaPath(config.ModuleModel, filepath.Join("fixtures", "RandomData.java"))},
+		g.placeholderMotherFile(),
+	}
+}
+
+// placeholderMotherFile returns the template and output path of
+// PlaceholderMother, whose methods follow the datastore modules.
+func (g *Generator) placeholderMotherFile() [2]string {
+	return [2]string{"java/model/test/fixtures/PlaceholderMother.java.tmpl", g.testJavaPath(config.ModuleModel, filepath.Join("fixtures", "PlaceholderMother.java"))}
+}
+
+// generateModelFixtures writes the object mothers and random data helpers
+// of Model's test-jar.
+func (g *Generator) generateModelFixtures() error {
+	for _, f := range g.modelFixtureFiles() {
+		if err := g.writeTemplate(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to generate test fixtures: %w", err)
+		}
+	}
+	return nil
+}
+
+// databaseFixtureFile returns the template and output path of
+// AbstractDatabaseTest in SQLDatastore's test-jar.
+func (g *Generator) databaseFixtureFile() [2]string {
+	return [2]string{
+		"java/sqldatastore/test/fixtures/AbstractDatabaseTest.java.tmpl",
+		g.testJavaPath(config.ModuleSQLDatastore, filepath.Join("fixtures", "AbstractDatabaseTest.java")),
+	}
+}
+
+// generateDatabaseFixture writes AbstractDatabaseTest, the singleton
+// Testcontainers database of SQLDatastore's test-jar, when the database
+// runs in a container.
+func (g *Generator) generateDatabaseFixture() error {
+	if !g.config.HasSharedTestDatabase() {
+		return nil
+	}
+	f := g.databaseFixtureFile()
+	if err := g.writeTemplate(f[0], f[1]); err != nil {
+		return fmt.Errorf("failed to generate AbstractDatabaseTest.java: %w", err)
+	}
+	return nil
+}
+
+// ensureTestFixtures writes the fixtures, and declares the test-jars, that
+// a project generated before them lacks: the Shared, API and Worker POMs
+// generated into it depend on those test-jars. It returns the files created.
+func (g *Generator) ensureTestFixtures() ([]string, error) {
+	var created []string
+	publish := func(module string, files [][2]string) error {
+		missing := false
+		for _, f := range files {
+			if _, err := os.Stat(filepath.Join(g.outDir, g.sourcePath(f[0], f[1]))); os.IsNotExist(err) {
+				if err := g.writeTemplate(f[0], f[1]); err != nil {
+					return fmt.Errorf("failed to generate test fixtures: %w", err)
+				}
+				created = append(created, g.sourcePath(f[0], f[1]))
+				missing = true
+			}
+		}
+		if !missing {
+			return nil
+		}
+		pom, err := NewPOMUpdater(filepath.Join(g.outDir, module, "pom.xml"))
+		if err != nil {
+			return fmt.Errorf("failed to read %s pom.xml: %w", module, err)
+		}
+		if err := pom.AddTestJar(); err != nil {
+			return fmt.Errorf("failed to publish the %s test-jar: %w", module, err)
+		}
+		return pom.Save()
+	}
+
+	if err := publish(config.ModuleModel, g.modelFixtureFiles()); err != nil {
+		return created, err
+	}
+	if g.config.HasSharedTestDatabase() {
+		if err := publish(config.ModuleSQLDatastore, [][2]string{g.databaseFixtureFile()}); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_TestFixtures(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName: "fixtures",
+		GroupID:     "com.test.fixtures",
+		ArtifactID:  "fixtures",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API", "Worker"},
+		Database:    config.DatabasePostgreSQL,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("fixtures", path))
+		if err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	mother := read("Model/src/test/java/com/test/fixtures/model/fixtures/PlaceholderMother.java")
+	if !strings.Contains(mother, "public static PlaceholderRecord newRecord()") || strings.Contains(mother, "PlaceholderDocument") {
+		t.Errorf("PlaceholderMother should follow the SQL datastore:\n%s", mother)
+	}
+	read("Model/src/test/java/com/test/fixtures/model/fixtures/RandomData.java")
+	base := read("SQLDatastore/src/test/java/com/test/fixtures/sqldatastore/fixtures/AbstractDatabaseTest.java")
+	if !strings.Contains(base, "PostgreSQLContainer DATABASE") || !strings.Contains(base, "DATABASE.start();") {
+		t.Errorf("AbstractDatabaseTest should start a singleton PostgreSQL container:\n%s", base)
+	}
+
+	for _, module := range []string{"Model", "SQLDatastore"} {
+		if !strings.Contains(read(module+"/pom.xml"), "<goal>test-jar</goal>") {
+			t.Errorf("%s should publish a test-jar", module)
+		}
+	}
+	for _, module := range []string{"Shared", "API", "Worker"} {
+		pom := read(module + "/pom.xml")
+		if strings.Count(pom, "<type>test-jar</type>") != 2 {
+			t.Errorf("%s should depend on the Model and SQLDatastore test-jars", module)
+		}
+	}
+	if !strings.Contains(read("API/src/test/java/com/test/fixtures/api/config/security/AuthDormantTest.java"), "extends AbstractDatabaseTest") {
+		t.Error("API security tests should share the AbstractDatabaseTest container")
+	}
+}
+
+func TestGenerator_Generate_TestFixturesKotlinNoSQL(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	cfg := &config.ProjectConfig{
+		ProjectName:   "ktfixtures",
+		GroupID:       "com.test.kt",
+		ArtifactID:    "ktfixtures",
+		JavaVersion:   "21",
+		Language:      config.LanguageKotlin,
+		Modules:       []string{"Model", "NoSQLDatastore", "Shared", "API"},
+		NoSQLDatabase: config.DatabaseMongoDB,
+	}
+	gen, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	mother, err := os.ReadFile(filepath.Join("ktfixtures", "Model/src/test/kotlin/com/test/kt/model/fixtures/PlaceholderMother.kt"))
+	if err != nil {
+		t.Fatalf("expected a Kotlin PlaceholderMother: %v", err)
+	}
+	if !strings.Contains(string(mother), "fun document(") {
+		t.Errorf("PlaceholderMother should build documents for MongoDB:\n%s", mother)
+	}
+	if _, err := os.Stat(filepath.Join("ktfixtures", "SQLDatastore")); !os.IsNotExist(err) {
+		t.Error("no SQLDatastore test-jar without SQLDatastore")
+	}
+	api, _ := os.ReadFile(filepath.Join("ktfixtures", "API", "pom.xml"))
+	if strings.Count(string(api), "<type>test-jar</type>") != 1 {
+		t.Error("API should depend on the Model test-jar only")
+	}
+}
+
+func TestModuleAdder_Add_PublishesTestFixtures(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "legacy")
+	cfg := &config.ProjectConfig{
+		ProjectName: "legacy",
+		GroupID:     "com.test.legacy",
+		ArtifactID:  "legacy",
+		JavaVersion: "21",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    config.DatabaseMySQL,
+	}
+	gen, err := NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Rewind to a project generated before the fixtures existed
+	jarPlugin := regexp.MustCompile(`(?s)\s*<plugin>\s*(<!--.*?-->\s*)?<groupId>org.apache.maven.plugins</groupId>\s*<artifactId>maven-jar-plugin</artifactId>.*?</plugin>`)
+	for _, module := range []string{"Model", "SQLDatastore"} {
+		pomPath := filepath.Join(projectPath, module, "pom.xml")
+		pom, _ := os.ReadFile(pomPath)
+		stripped := jarPlugin.ReplaceAllString(string(pom), "")
+		if strings.Contains(stripped, "test-jar") {
+			t.Fatalf("failed to strip the test-jar plugin from %s", module)
+		}
+		os.WriteFile(pomPath, []byte(stripped), 0644)
+	}
+	os.RemoveAll(filepath.Join(projectPath, "Model/src/test/java/com/test/legacy/model/fixtures"))
+	os.RemoveAll(filepath.Join(projectPath, "SQLDatastore/src/test/java/com/test/legacy/sqldatastore/fixtures"))
+
+	metadata, err := config.LoadMetadata(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewModuleAdder(projectPath, metadata, "test", true).Add(config.ModuleWorker, "", "", ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	for _, path := range []string{
+		"Model/src/test/java/com/test/legacy/model/fixtures/PlaceholderMother.java",
+		"Model/src/test/java/com/test/legacy/model/fixtures/RandomData.java",
+		"SQLDatastore/src/test/java/com/test/legacy/sqldatastore/fixtures/AbstractDatabaseTest.java",
+	} {
+		if _, err := os.Stat(filepath.Join(projectPath, path)); err != nil {
+			t.Errorf("expected %s restored for the Worker tests: %v", path, err)
+		}
+	}
+	for _, module := range []string{"Model", "SQLDatastore"} {
+		pom, _ := os.ReadFile(filepath.Join(projectPath, module, "pom.xml"))
+		if strings.Count(string(pom), "<goal>test-jar</goal>") != 1 {
+			t.Errorf("%s should publish its test-jar again", module)
+		}
+	}
+}
+
+func TestPOMUpdater_AddTestJar(t *testing.T) {
+	pomPath := filepath.Join(t.TempDir(), "pom.xml")
+	os.WriteFile(pomPath, []byte(`<project>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+            </plugin>
+        </plugins>
+    </build>
+</project>`), 0644)
+
+	pom, err := NewPOMUpdater(pomPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := pom.AddTestJar(); err != nil {
+			t.Fatalf("AddTestJar failed: %v", err)
+		}
+	}
+	if err := pom.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(pomPath)
+	content := string(data)
+	if strings.Count(content, "<goal>test-jar</goal>") != 1 {
+		t.Errorf("expected exactly one test-jar execution:\n%s", content)
+	}
+	if strings.Index(content, "maven-jar-plugin") > strings.Index(content, "</plugins>") {
+		t.Errorf("expected the plugin inside <plugins>:\n%s", content)
+	}
+}
@@ -4,16 +4,52 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/versions"
 	"gopkg.in/yaml.v3"
 )
 
-// POMUpdater handles modifications to pom.xml files
+// POMUpdater handles modifications to pom.xml files. Edits go through an
+// XML editor that splices new elements in at the document's own
+// indentation, so comments, formatting and the order of everything it
+// does not touch are preserved byte for byte.
 type POMUpdater struct {
-	path    string
-	content string
+	path string
+	doc  *xmlEditor
+}
+
+// pomOrder lists the child elements of POM elements in the order the
+// Maven POM reference gives them; new elements are inserted before the
+// first existing sibling that comes after them.
+var pomOrder = map[string][]string{
+	"project": {"modelVersion", "parent", "groupId", "artifactId", "version", "packaging", "name", "description",
+		"url", "inceptionYear", "organization", "licenses", "developers", "contributors", "mailingLists",
+		"prerequisites", "modules", "scm", "issueManagement", "ciManagement", "distributionManagement",
+		"properties", "dependencyManagement", "dependencies", "repositories", "pluginRepositories", "build",
+		"reporting", "profiles"},
+	"profile":    {"id", "activation", "build", "modules", "distributionManagement", "properties", "dependencyManagement", "dependencies", "repositories", "pluginRepositories", "reporting"},
+	"build":      {"defaultGoal", "directory", "finalName", "sourceDirectory", "testSourceDirectory", "outputDirectory", "testOutputDirectory", "extensions", "resources", "testResources", "pluginManagement", "plugins"},
+	"dependency": {"groupId", "artifactId", "version", "type", "classifier", "scope", "systemPath", "optional", "exclusions"},
+	"plugin":     {"groupId", "artifactId", "version", "extensions", "executions", "dependencies", "goals", "inherited", "configuration"},
+}
+
+// POMPlugin is a build plugin declaration. Configuration is the inner
+// XML of its <configuration>, re-indented where it is inserted.
+type POMPlugin struct {
+	GroupID       string
+	ArtifactID    string
+	Version       string
+	Executions    []POMExecution
+	Configuration string
+}
+
+// POMExecution is one <execution> of a plugin
+type POMExecution struct {
+	ID    string
+	Phase string
+	Goals []string
 }
 
 // NewPOMUpdater creates a new POMUpdater
@@ -22,279 +58,517 @@ func NewPOMUpdater(pomPath string) (*POMUpdater, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read POM file: %w", err)
 	}
+	doc, err := newXMLEditor(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse POM file %s: %w", pomPath, err)
+	}
+	if doc.find("project") == nil {
+		return nil, fmt.Errorf("%s has no <project> element", pomPath)
+	}
 
 	return &POMUpdater{
-		path:    pomPath,
-		content: string(data),
+		path: pomPath,
+		doc:  doc,
 	}, nil
 }
 
 // Save writes the modified POM back to disk
 func (p *POMUpdater) Save() error {
-	return os.WriteFile(p.path, []byte(p.content), 0644)
+	return os.WriteFile(p.path, p.doc.src, 0644)
+}
+
+// section returns the element at path under <project>, creating the
+// missing elements along the way
+func (p *POMUpdater) section(path ...string) (*xmlNode, error) {
+	n := p.doc.find("project")
+	for _, name := range path {
+		var err error
+		if n, err = p.doc.ensureChild(n, name, pomOrder[n.name]); err != nil {
+			return nil, fmt.Errorf("failed to add <%s>: %w", name, err)
+		}
+	}
+	return n, nil
 }
 
 // AddModule adds a module to the <modules> section
 func (p *POMUpdater) AddModule(moduleName string) error {
-	// Find the modules section
-	modulesRegex := regexp.MustCompile(`(<modules>)([\s\S]*?)(</modules>)`)
-	matches := modulesRegex.FindStringSubmatch(p.content)
-
-	if len(matches) < 4 {
-		return fmt.Errorf("could not find <modules> section in POM")
+	modules, err := p.section("modules")
+	if err != nil {
+		return err
 	}
-
-	modulesContent := matches[2]
-
-	// Check if module already exists
-	if strings.Contains(modulesContent, fmt.Sprintf("<module>%s</module>", moduleName)) {
-		return nil // Already exists
+	for _, m := range modules.elements("module") {
+		if p.doc.text(m) == moduleName {
+			return nil // Already exists
+		}
 	}
+	_, err = p.doc.insertChild(modules, xmlElem{name: "module", text: moduleName}, nil)
+	return err
+}
 
-	// Find the last module entry to determine indentation
-	moduleEntryRegex := regexp.MustCompile(`(\s*)<module>[^<]+</module>`)
-	moduleMatches := moduleEntryRegex.FindAllStringSubmatch(modulesContent, -1)
-
-	indent := "        " // Default indent
-	if len(moduleMatches) > 0 {
-		lastMatch := moduleMatches[len(moduleMatches)-1]
-		indent = lastMatch[1]
+// AddProperty adds a property to the <properties> section. An existing
+// property keeps its value.
+func (p *POMUpdater) AddProperty(name, value string) error {
+	properties, err := p.section("properties")
+	if err != nil {
+		return err
 	}
-
-	// Add new module entry before </modules>
-	newModuleEntry := fmt.Sprintf("%s<module>%s</module>\n", indent, moduleName)
-	newModulesSection := matches[1] + modulesContent + newModuleEntry + "    " + matches[3]
-
-	p.content = modulesRegex.ReplaceAllString(p.content, newModulesSection)
-	return nil
+	return p.addProperty(properties, name, value)
 }
 
-// AddProperty adds a property to the <properties> section
-func (p *POMUpdater) AddProperty(name, value string) error {
-	// Check if property already exists
-	propRegex := regexp.MustCompile(fmt.Sprintf(`<%s>[^<]+</%s>`, regexp.QuoteMeta(name), regexp.QuoteMeta(name)))
-	if propRegex.MatchString(p.content) {
+func (p *POMUpdater) addProperty(properties *xmlNode, name, value string) error {
+	if properties.child(name) != nil {
 		return nil // Already exists
 	}
+	_, err := p.doc.insertChild(properties, xmlElem{name: name, text: value}, nil)
+	return err
+}
 
-	// Find the properties section
-	propertiesRegex := regexp.MustCompile(`(<properties>)([\s\S]*?)(</properties>)`)
-	matches := propertiesRegex.FindStringSubmatch(p.content)
-
-	if len(matches) < 4 {
-		return fmt.Errorf("could not find <properties> section in POM")
+// AddDependency adds a dependency to the project's <dependencies> (not
+// the ones of <dependencyManagement> or a profile)
+func (p *POMUpdater) AddDependency(groupID, artifactID, version string) error {
+	deps, err := p.section("dependencies")
+	if err != nil {
+		return err
 	}
+	return p.addDependency(deps, dependencyElem(groupID, artifactID, version, "", ""))
+}
 
-	propertiesContent := matches[2]
-
-	// Find indentation from existing properties
-	propEntryRegex := regexp.MustCompile(`(\s*)<[a-z]`)
-	propMatches := propEntryRegex.FindStringSubmatch(propertiesContent)
+// AddDependencyManagement adds a dependency to the dependencyManagement section
+func (p *POMUpdater) AddDependencyManagement(groupID, artifactID, version, depType, scope string) error {
+	deps, err := p.section("dependencyManagement", "dependencies")
+	if err != nil {
+		return err
+	}
+	return p.addDependency(deps, dependencyElem(groupID, artifactID, version, depType, scope))
+}
 
-	indent := "        " // Default indent
-	if len(propMatches) > 0 {
-		indent = propMatches[1]
+func dependencyElem(groupID, artifactID, version, depType, scope string) xmlElem {
+	dep := xmlElem{name: "dependency", children: []xmlElem{
+		{name: "groupId", text: groupID},
+		{name: "artifactId", text: artifactID},
+	}}
+	for _, f := range [][2]string{{"version", version}, {"type", depType}, {"scope", scope}} {
+		if f[1] != "" {
+			dep.children = append(dep.children, xmlElem{name: f[0], text: f[1]})
+		}
 	}
+	return dep
+}
 
-	// Add new property before </properties>
-	newPropEntry := fmt.Sprintf("%s<%s>%s</%s>\n", indent, name, value, name)
-	newPropertiesSection := matches[1] + propertiesContent + newPropEntry + "    " + matches[3]
+// addDependency appends dep to a <dependencies> list unless the list
+// already declares the artifact
+func (p *POMUpdater) addDependency(deps *xmlNode, dep xmlElem) error {
+	if p.findDependency(deps, dep.children[0].text, dep.children[1].text) != nil {
+		return nil // Already exists
+	}
+	_, err := p.doc.insertChild(deps, dep, nil)
+	return err
+}
 
-	p.content = propertiesRegex.ReplaceAllString(p.content, newPropertiesSection)
+// findDependency returns the <dependency> of a list that declares
+// groupID:artifactID
+func (p *POMUpdater) findDependency(deps *xmlNode, groupID, artifactID string) *xmlNode {
+	for _, d := range deps.elements("dependency") {
+		if p.doc.childText(d, "artifactId") == artifactID && p.doc.childText(d, "groupId") == groupID {
+			return d
+		}
+	}
 	return nil
 }
 
-// AddDependency adds a dependency to the <dependencies> section
-func (p *POMUpdater) AddDependency(groupID, artifactID, version string) error {
-	// Find the dependencies section that is NOT inside dependencyManagement
-	// We need to check for the dependency only within the main dependencies section
-	depsRegex := regexp.MustCompile(`(?s)<dependencies>(.*?)</dependencies>`)
-
-	// Find all dependencies sections
-	allMatches := depsRegex.FindAllStringSubmatchIndex(p.content, -1)
-	if len(allMatches) == 0 {
-		return fmt.Errorf("could not find <dependencies> section in POM")
-	}
-
-	// Find the main dependencies section (not inside dependencyManagement)
-	// The main dependencies section is typically after </dependencyManagement>
-	depMgmtEnd := strings.Index(p.content, "</dependencyManagement>")
-	var mainDepsStart, mainDepsEnd int
-	found := false
-
-	for _, match := range allMatches {
-		start := match[0]
-		end := match[1]
-		// If dependencyManagement exists, main deps section should be after it
-		// If not, take the first dependencies section
-		if depMgmtEnd == -1 || start > depMgmtEnd {
-			mainDepsStart = start
-			mainDepsEnd = end
-			found = true
-			break
+// AddExclusion excludes exclGroupID:exclArtifactID from the transitive
+// dependencies of groupID:artifactID, declared in <dependencies> or, if
+// not there, in <dependencyManagement>
+func (p *POMUpdater) AddExclusion(groupID, artifactID, exclGroupID, exclArtifactID string) error {
+	var dep *xmlNode
+	for _, path := range [][]string{{"project", "dependencies"}, {"project", "dependencyManagement", "dependencies"}} {
+		if deps := p.doc.find(path...); deps != nil {
+			if dep = p.findDependency(deps, groupID, artifactID); dep != nil {
+				break
+			}
 		}
 	}
-
-	if !found {
-		return fmt.Errorf("could not find main <dependencies> section in POM")
+	if dep == nil {
+		return fmt.Errorf("dependency %s:%s is not declared in the POM", groupID, artifactID)
 	}
-
-	// Extract the main dependencies section content
-	mainDepsContent := p.content[mainDepsStart:mainDepsEnd]
-
-	// Check if dependency already exists in the main dependencies section
-	if strings.Contains(mainDepsContent, fmt.Sprintf("<artifactId>%s</artifactId>", artifactID)) {
-		return nil // Already exists in main dependencies
+	exclusions, err := p.doc.ensureChild(dep, "exclusions", pomOrder["dependency"])
+	if err != nil {
+		return err
 	}
-
-	// Build the dependency entry
-	var depBuilder strings.Builder
-	depBuilder.WriteString("        <dependency>\n")
-	depBuilder.WriteString(fmt.Sprintf("            <groupId>%s</groupId>\n", groupID))
-	depBuilder.WriteString(fmt.Sprintf("            <artifactId>%s</artifactId>\n", artifactID))
-	if version != "" {
-		depBuilder.WriteString(fmt.Sprintf("            <version>%s</version>\n", version))
+	for _, e := range exclusions.elements("exclusion") {
+		if p.doc.childText(e, "groupId") == exclGroupID && p.doc.childText(e, "artifactId") == exclArtifactID {
+			return nil // Already excluded
+		}
 	}
-	depBuilder.WriteString("        </dependency>\n")
+	_, err = p.doc.insertChild(exclusions, xmlElem{name: "exclusion", children: []xmlElem{
+		{name: "groupId", text: exclGroupID},
+		{name: "artifactId", text: exclArtifactID},
+	}}, nil)
+	return err
+}
 
-	depEntry := depBuilder.String()
+// AddPlugin declares a plugin in <build><plugins>. A plugin already
+// declared there is left as it is.
+func (p *POMUpdater) AddPlugin(plugin POMPlugin) error {
+	plugins, err := p.section("build", "plugins")
+	if err != nil {
+		return err
+	}
+	return p.addPlugin(plugins, plugin)
+}
 
-	// Find where to insert (before </dependencies> in the main section)
-	insertPos := mainDepsEnd - len("</dependencies>")
+// AddPluginManagement declares a plugin in <build><pluginManagement>,
+// fixing its version and configuration for the modules that use it
+func (p *POMUpdater) AddPluginManagement(plugin POMPlugin) error {
+	plugins, err := p.section("build", "pluginManagement", "plugins")
+	if err != nil {
+		return err
+	}
+	return p.addPlugin(plugins, plugin)
+}
 
-	// Insert the new dependency
-	p.content = p.content[:insertPos] + depEntry + "    " + p.content[insertPos:]
+func (p *POMUpdater) addPlugin(plugins *xmlNode, plugin POMPlugin) error {
+	if p.findPlugin(plugins, plugin.GroupID, plugin.ArtifactID) != nil {
+		return nil // Already declared
+	}
+	el := xmlElem{name: "plugin"}
+	if plugin.GroupID != "" {
+		el.children = append(el.children, xmlElem{name: "groupId", text: plugin.GroupID})
+	}
+	el.children = append(el.children, xmlElem{name: "artifactId", text: plugin.ArtifactID})
+	if plugin.Version != "" {
+		el.children = append(el.children, xmlElem{name: "version", text: plugin.Version})
+	}
+	if len(plugin.Executions) > 0 {
+		executions := xmlElem{name: "executions"}
+		for _, e := range plugin.Executions {
+			executions.children = append(executions.children, executionElem(e))
+		}
+		el.children = append(el.children, executions)
+	}
+	if plugin.Configuration != "" {
+		el.children = append(el.children, xmlElem{name: "configuration", raw: plugin.Configuration})
+	}
+	_, err := p.doc.insertChild(plugins, el, nil)
+	return err
+}
 
-	return nil
+func executionElem(e POMExecution) xmlElem {
+	el := xmlElem{name: "execution"}
+	if e.ID != "" {
+		el.children = append(el.children, xmlElem{name: "id", text: e.ID})
+	}
+	if e.Phase != "" {
+		el.children = append(el.children, xmlElem{name: "phase", text: e.Phase})
+	}
+	goals := xmlElem{name: "goals"}
+	for _, g := range e.Goals {
+		goals.children = append(goals.children, xmlElem{name: "goal", text: g})
+	}
+	return xmlElem{name: el.name, children: append(el.children, goals)}
 }
 
-// AddDependencyManagement adds a dependency to the dependencyManagement section
-func (p *POMUpdater) AddDependencyManagement(groupID, artifactID, version, depType, scope string) error {
-	// Build the dependency entry
-	var depBuilder strings.Builder
-	depBuilder.WriteString("            <dependency>\n")
-	depBuilder.WriteString(fmt.Sprintf("                <groupId>%s</groupId>\n", groupID))
-	depBuilder.WriteString(fmt.Sprintf("                <artifactId>%s</artifactId>\n", artifactID))
-	depBuilder.WriteString(fmt.Sprintf("                <version>%s</version>\n", version))
-	if depType != "" {
-		depBuilder.WriteString(fmt.Sprintf("                <type>%s</type>\n", depType))
+// findPlugin returns the <plugin> of a list that declares the plugin. An
+// empty groupId is Maven's default, org.apache.maven.plugins.
+func (p *POMUpdater) findPlugin(plugins *xmlNode, groupID, artifactID string) *xmlNode {
+	normalize := func(g string) string {
+		if g == "" {
+			return "org.apache.maven.plugins"
+		}
+		return g
 	}
-	if scope != "" {
-		depBuilder.WriteString(fmt.Sprintf("                <scope>%s</scope>\n", scope))
+	for _, pl := range plugins.elements("plugin") {
+		if p.doc.childText(pl, "artifactId") == artifactID && normalize(p.doc.childText(pl, "groupId")) == normalize(groupID) {
+			return pl
+		}
 	}
-	depBuilder.WriteString("            </dependency>\n")
+	return nil
+}
 
-	depEntry := depBuilder.String()
+// AddProfile declares a profile with the given id in <profiles>
+func (p *POMUpdater) AddProfile(id string) error {
+	_, err := p.profile(id)
+	return err
+}
 
-	// Check if dependency already exists
-	if strings.Contains(p.content, fmt.Sprintf("<artifactId>%s</artifactId>", artifactID)) {
-		return nil // Already exists
+// AddProfileProperty adds a property to a profile, declaring the profile
+// if needed
+func (p *POMUpdater) AddProfileProperty(profileID, name, value string) error {
+	profile, err := p.profile(profileID)
+	if err != nil {
+		return err
+	}
+	properties, err := p.doc.ensureChild(profile, "properties", pomOrder["profile"])
+	if err != nil {
+		return err
 	}
+	return p.addProperty(properties, name, value)
+}
 
-	// Find the dependencyManagement/dependencies section
-	depMgmtRegex := regexp.MustCompile(`(<dependencyManagement>\s*<dependencies>)([\s\S]*?)(</dependencies>\s*</dependencyManagement>)`)
-	matches := depMgmtRegex.FindStringSubmatch(p.content)
+// AddProfileDependency adds a dependency to a profile, declaring the
+// profile if needed
+func (p *POMUpdater) AddProfileDependency(profileID, groupID, artifactID, version string) error {
+	profile, err := p.profile(profileID)
+	if err != nil {
+		return err
+	}
+	deps, err := p.doc.ensureChild(profile, "dependencies", pomOrder["profile"])
+	if err != nil {
+		return err
+	}
+	return p.addDependency(deps, dependencyElem(groupID, artifactID, version, "", ""))
+}
 
-	if len(matches) < 4 {
-		return fmt.Errorf("could not find <dependencyManagement> section in POM")
+// profile returns the <profile> with the given id, declaring it if needed
+func (p *POMUpdater) profile(id string) (*xmlNode, error) {
+	profiles, err := p.section("profiles")
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range profiles.elements("profile") {
+		if p.doc.childText(pr, "id") == id {
+			return pr, nil
+		}
 	}
+	return p.doc.insertChild(profiles, xmlElem{name: "profile", children: []xmlElem{{name: "id", text: id}}}, nil)
+}
 
-	// Add new dependency
-	newContent := matches[1] + matches[2] + depEntry + "        " + matches[3]
-	p.content = depMgmtRegex.ReplaceAllString(p.content, newContent)
-	return nil
+// AddTestJar declares a maven-jar-plugin test-jar execution in the <build>
+// plugins, so the module publishes its test classes for other modules
+func (p *POMUpdater) AddTestJar() error {
+	execution := POMExecution{ID: "test-fixtures", Goals: []string{"test-jar"}}
+	plugins, err := p.section("build", "plugins")
+	if err != nil {
+		return err
+	}
+	jar := p.findPlugin(plugins, "org.apache.maven.plugins", "maven-jar-plugin")
+	if jar == nil {
+		return p.addPlugin(plugins, POMPlugin{
+			GroupID:    "org.apache.maven.plugins",
+			ArtifactID: "maven-jar-plugin",
+			Executions: []POMExecution{execution},
+		})
+	}
+	executions, err := p.doc.ensureChild(jar, "executions", pomOrder["plugin"])
+	if err != nil {
+		return err
+	}
+	for _, e := range executions.elements("execution") {
+		if goals := e.child("goals"); goals != nil {
+			for _, g := range goals.elements("goal") {
+				if p.doc.text(g) == "test-jar" {
+					return nil // Already published
+				}
+			}
+		}
+	}
+	_, err = p.doc.insertChild(executions, executionElem(execution), nil)
+	return err
 }
 
-// DockerComposeUpdater handles modifications to docker-compose.yml
+// DockerComposeUpdater handles modifications to docker-compose.yml. Edits
+// are spliced into the file's own lines, so comments, anchors, blank
+// lines and the order of the services a user wrote or rearranged survive.
+// Methods that cannot report an error record the first one; Save returns
+// it instead of writing.
 type DockerComposeUpdater struct {
-	path     string
-	content  map[string]interface{}
-	services map[string]interface{}
-	volumes  map[string]interface{}
+	path string
+	doc  *yamlEditor
+	err  error
 }
 
 // NewDockerComposeUpdater creates a new DockerComposeUpdater
 func NewDockerComposeUpdater(composePath string) (*DockerComposeUpdater, error) {
 	data, err := os.ReadFile(composePath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			// Create new docker-compose structure
-			return &DockerComposeUpdater{
-				path: composePath,
-				content: map[string]interface{}{
-					"services": map[string]interface{}{},
-				},
-				services: map[string]interface{}{},
-				volumes:  map[string]interface{}{},
-			}, nil
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read docker-compose file: %w", err)
 		}
-		return nil, fmt.Errorf("failed to read docker-compose file: %w", err)
+		// Create new docker-compose structure
+		data = []byte("services: {}\n")
 	}
 
-	var content map[string]interface{}
-	if err := yaml.Unmarshal(data, &content); err != nil {
+	doc, err := newYAMLEditor(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse docker-compose file: %w", err)
 	}
 
-	services := map[string]interface{}{}
-	if s, ok := content["services"].(map[string]interface{}); ok {
-		services = s
-	}
-
-	volumes := map[string]interface{}{}
-	if v, ok := content["volumes"].(map[string]interface{}); ok {
-		volumes = v
-	}
-
 	return &DockerComposeUpdater{
-		path:     composePath,
-		content:  content,
-		services: services,
-		volumes:  volumes,
+		path: composePath,
+		doc:  doc,
 	}, nil
 }
 
 // Save writes the modified docker-compose.yml back to disk
 func (d *DockerComposeUpdater) Save() error {
-	d.content["services"] = d.services
-	if len(d.volumes) > 0 {
-		d.content["volumes"] = d.volumes
+	if d.err != nil {
+		return fmt.Errorf("failed to update %s: %w", d.path, d.err)
 	}
+	return os.WriteFile(d.path, d.doc.bytes(), 0644)
+}
 
-	data, err := yaml.Marshal(d.content)
-	if err != nil {
-		return fmt.Errorf("failed to marshal docker-compose: %w", err)
+func (d *DockerComposeUpdater) fail(err error) {
+	if err != nil && d.err == nil {
+		d.err = err
 	}
+}
 
-	return os.WriteFile(d.path, data, 0644)
+// section returns the key of a top-level section, adding an empty one
+// when the file has none
+func (d *DockerComposeUpdater) section(name string) (*yaml.Node, error) {
+	if k, _ := entry(d.doc.root, name); k != nil {
+		return k, nil
+	}
+	if err := d.doc.set(nil, name, &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}); err != nil {
+		return nil, err
+	}
+	k, _ := entry(d.doc.root, name)
+	return k, nil
+}
+
+// service returns the definition of a service as compose reads it
+func (d *DockerComposeUpdater) service(name string) *yaml.Node {
+	_, services := entry(d.doc.root, "services")
+	_, svc := entry(resolve(services), name)
+	return resolve(svc)
 }
 
 // HasService checks if a service exists
 func (d *DockerComposeUpdater) HasService(name string) bool {
-	_, ok := d.services[name]
-	return ok
+	return d.service(name) != nil
 }
 
-// AddService adds a new service
+// AddService adds a new service, replacing a service of that name in place
 func (d *DockerComposeUpdater) AddService(name string, config map[string]interface{}) {
-	d.services[name] = config
+	var value yaml.Node
+	if err := value.Encode(config); err != nil {
+		d.fail(fmt.Errorf("service %s: %w", name, err))
+		return
+	}
+	// Profiles read best on one line, as in the generated compose file
+	if _, profiles := entry(&value, "profiles"); profiles != nil {
+		profiles.Style = yaml.FlowStyle
+		for _, p := range profiles.Content {
+			p.Style = yaml.DoubleQuotedStyle
+		}
+	}
+	services, err := d.section("services")
+	if err == nil {
+		err = d.doc.set(services, name, &value)
+	}
+	d.fail(err)
+}
+
+// AddProfiledService adds a service that only starts when profile is
+// enabled (docker compose --profile <profile> up), such as the
+// "observability" stack next to the always-on infrastructure
+func (d *DockerComposeUpdater) AddProfiledService(profile, name string, config map[string]interface{}) {
+	withProfile := make(map[string]interface{}, len(config)+1)
+	for k, v := range config {
+		withProfile[k] = v
+	}
+	withProfile["profiles"] = []string{profile}
+	d.AddService(name, withProfile)
+}
+
+// ServiceProfiles returns the profiles a service belongs to; none means
+// it always starts
+func (d *DockerComposeUpdater) ServiceProfiles(name string) []string {
+	var profiles []string
+	if p := lookup(d.service(name), "profiles"); p != nil {
+		_ = p.Decode(&profiles)
+	}
+	return profiles
+}
+
+// SetServiceProfiles moves an existing service to the given profiles, or
+// out of all of them when none are given
+func (d *DockerComposeUpdater) SetServiceProfiles(name string, profiles ...string) error {
+	key := d.serviceKey(name)
+	if key == nil {
+		return fmt.Errorf("service %s not found", name)
+	}
+	if len(profiles) == 0 {
+		return d.doc.remove(key, "profiles")
+	}
+	value := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, p := range profiles {
+		value.Content = append(value.Content, &yaml.Node{Kind: yaml.ScalarNode, Style: yaml.DoubleQuotedStyle, Value: p})
+	}
+	return d.doc.set(key, "profiles", value)
+}
+
+// serviceKey returns the key node of a service defined in the file itself
+func (d *DockerComposeUpdater) serviceKey(name string) *yaml.Node {
+	_, services := entry(d.doc.root, "services")
+	k, _ := entry(services, name)
+	return k
+}
+
+// ServiceImage returns the image a service runs, or "" for one that is
+// built
+func (d *DockerComposeUpdater) ServiceImage(name string) string {
+	if image := lookup(d.service(name), "image"); image != nil {
+		return image.Value
+	}
+	return ""
+}
+
+// SetImageVersion changes the tag (or digest) of the image an existing
+// service runs, keeping its quoting and comment. An image shared through
+// an anchor is changed at the anchor, for every service using it.
+func (d *DockerComposeUpdater) SetImageVersion(name, version string) error {
+	image := lookup(d.service(name), "image")
+	if image == nil {
+		return fmt.Errorf("service %s has no image", name)
+	}
+	if strings.Contains(image.Value, "${") {
+		return fmt.Errorf("image of service %s is set by a variable: %s", name, image.Value)
+	}
+	return d.doc.setScalar(image, imageRepository(image.Value)+":"+version)
+}
+
+// imageRepository strips the tag and digest from an image reference,
+// leaving a registry port alone
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
 }
 
 // AddVolume adds a named volume (with empty config, which is standard for named volumes)
 func (d *DockerComposeUpdater) AddVolume(name string) {
-	d.volumes[name] = nil
+	volumes, err := d.section("volumes")
+	if err == nil {
+		if k, _ := entry(d.doc.valueOf(volumes), name); k != nil {
+			return
+		}
+		err = d.doc.set(volumes, name, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"})
+	}
+	d.fail(err)
 }
 
 // RemoveService removes a service
 func (d *DockerComposeUpdater) RemoveService(name string) {
-	delete(d.services, name)
+	if services, _ := entry(d.doc.root, "services"); services != nil {
+		d.fail(d.doc.remove(services, name))
+	}
+}
+
+// HasHealthcheck checks if a service exists and defines a healthcheck
+func (d *DockerComposeUpdater) HasHealthcheck(name string) bool {
+	return lookup(d.service(name), "healthcheck") != nil
 }
 
 // GetPostgresService returns a PostgreSQL service configuration
 // hostPort allows customization to avoid conflicts (use 5433 for main db, 5434 for jobrunr)
 func GetPostgresService(serviceName, database, user, password string, hostPort int) map[string]interface{} {
 	return map[string]interface{}{
-		"image": "postgres:16-alpine",
+		"image": versions.GetImage("postgres"),
 		"ports": []string{fmt.Sprintf("%d:5432", hostPort)},
 		"environment": map[string]string{
 			"POSTGRES_DB":       database,
@@ -311,12 +585,41 @@ func GetPostgresService(serviceName, database, user, password string, hostPort i
 	}
 }
 
+// GetPostgresReplicaService returns a streaming replica of the primary
+// service. On an empty volume it clones the primary with pg_basebackup
+// through the replica_1 slot, then runs as a hot standby.
+func GetPostgresReplicaService(primary string, hostPort int) map[string]interface{} {
+	const pgdata = "/var/lib/postgresql/data"
+	return map[string]interface{}{
+		"image": versions.GetImage("postgres"),
+		"user":  "postgres",
+		"ports": []string{fmt.Sprintf("%d:5432", hostPort)},
+		"environment": map[string]string{
+			"PGPASSWORD": "replicator",
+		},
+		"volumes": []string{primary + "-replica-data:" + pgdata},
+		"command": []string{"bash", "-c",
+			"if [ ! -s " + pgdata + "/PG_VERSION ]; then " +
+				"pg_basebackup --host=" + primary + " --username=replicator --pgdata=" + pgdata + " --slot=replica_1 --write-recovery-conf --wal-method=stream && " +
+				"chmod 0700 " + pgdata + "; fi && exec postgres"},
+		"depends_on": map[string]interface{}{
+			primary: map[string]string{"condition": "service_healthy"},
+		},
+		"healthcheck": map[string]interface{}{
+			"test":     []string{"CMD-SHELL", "pg_isready -U postgres"},
+			"interval": "5s",
+			"timeout":  "5s",
+			"retries":  10,
+		},
+	}
+}
+
 // GetMySQLService returns a MySQL service configuration
 // Uses port 3307 on host to avoid conflicts with local MySQL installations
 // Only root user is created to match application.yml template defaults (username: root, password: root)
 func GetMySQLService(serviceName, database, rootPassword string) map[string]interface{} {
 	return map[string]interface{}{
-		"image": "mysql:8.0",
+		"image": versions.GetImage("mysql"),
 		"ports": []string{"3307:3306"},
 		"environment": map[string]string{
 			"MYSQL_ROOT_PASSWORD": rootPassword,
@@ -329,8 +632,28 @@ func GetMySQLService(serviceName, database, rootPassword string) map[string]inte
 			"timeout":  "5s",
 			"retries":  5,
 		},
-		// Use mysql_native_password for Java driver compatibility
-		"command": "--default-authentication-plugin=mysql_native_password",
+		"command": config.SQLDatabaseServerCommand(config.DatabaseMySQL),
+	}
+}
+
+// GetMariaDBService returns a MariaDB service configuration, on the same
+// host port and root credentials as GetMySQLService
+func GetMariaDBService(serviceName, database, rootPassword string) map[string]interface{} {
+	return map[string]interface{}{
+		"image": versions.GetImage("mariadb"),
+		"ports": []string{"3307:3306"},
+		"environment": map[string]string{
+			"MARIADB_ROOT_PASSWORD": rootPassword,
+			"MARIADB_DATABASE":      database,
+		},
+		"volumes": []string{serviceName + "-data:/var/lib/mysql"},
+		"healthcheck": map[string]interface{}{
+			"test":     []string{"CMD", "healthcheck.sh", "--connect", "--innodb_initialized"},
+			"interval": "5s",
+			"timeout":  "5s",
+			"retries":  5,
+		},
+		"command": config.SQLDatabaseServerCommand(config.DatabaseMariaDB),
 	}
 }
 
@@ -338,7 +661,7 @@ func GetMySQLService(serviceName, database, rootPassword string) map[string]inte
 // No authentication for local development (matches docker-compose template)
 func GetMongoDBService(serviceName, database string) map[string]interface{} {
 	return map[string]interface{}{
-		"image": "mongo:7",
+		"image": versions.GetImage("mongo"),
 		"ports": []string{"27017:27017"},
 		"environment": map[string]string{
 			"MONGO_INITDB_DATABASE": database,
@@ -350,16 +673,58 @@ func GetMongoDBService(serviceName, database string) map[string]interface{} {
 // GetRedisService returns a Redis service configuration
 func GetRedisService(serviceName string) map[string]interface{} {
 	return map[string]interface{}{
-		"image":   "redis:7-alpine",
+		"image":   versions.GetImage("redis"),
 		"ports":   []string{"6379:6379"},
 		"volumes": []string{serviceName + "-data:/data"},
 	}
 }
 
+// GetDynamoDBService returns a DynamoDB Local service configuration
+func GetDynamoDBService() map[string]interface{} {
+	return map[string]interface{}{
+		"image":       versions.GetImage("dynamodb-local"),
+		"user":        "root",
+		"working_dir": "/home/dynamodblocal",
+		"command":     "-jar DynamoDBLocal.jar -sharedDb -dbPath ./data",
+		"ports":       []string{"127.0.0.1:8001:8000"},
+		"volumes":     []string{"dynamodb-data:/home/dynamodblocal/data"},
+	}
+}
+
+// GetCassandraService returns a single-node Cassandra service configuration
+func GetCassandraService() map[string]interface{} {
+	return map[string]interface{}{
+		"image": versions.GetImage("cassandra"),
+		"ports": []string{"127.0.0.1:9043:9042"},
+		"environment": map[string]string{
+			"CASSANDRA_CLUSTER_NAME": "local",
+			"CASSANDRA_DC":           "datacenter1",
+			"MAX_HEAP_SIZE":          "512M",
+			"HEAP_NEWSIZE":           "128M",
+		},
+		"volumes": []string{"cassandra-data:/var/lib/cassandra"},
+	}
+}
+
+// GetOpenSearchService returns a single-node OpenSearch service configuration
+func GetOpenSearchService() map[string]interface{} {
+	return map[string]interface{}{
+		"image": versions.GetImage("opensearch"),
+		"ports": []string{"127.0.0.1:9201:9200"},
+		"environment": map[string]string{
+			"discovery.type":              "single-node",
+			"DISABLE_SECURITY_PLUGIN":     "true",
+			"DISABLE_INSTALL_DEMO_CONFIG": "true",
+			"OPENSEARCH_JAVA_OPTS":        "-Xms512m -Xmx512m",
+		},
+		"volumes": []string{"opensearch-data:/usr/share/opensearch/data"},
+	}
+}
+
 // GetKafkaService returns Kafka service configurations (Kafka + Zookeeper)
 func GetKafkaService() (kafka, zookeeper map[string]interface{}) {
 	zookeeper = map[string]interface{}{
-		"image": "confluentinc/cp-zookeeper:" + ConfluentKafkaVersion,
+		"image": versions.GetImage("cp-zookeeper"),
 		"environment": map[string]string{
 			"ZOOKEEPER_CLIENT_PORT": "2181",
 			"ZOOKEEPER_TICK_TIME":   "2000",
@@ -367,24 +732,79 @@ func GetKafkaService() (kafka, zookeeper map[string]interface{}) {
 	}
 
 	kafka = map[string]interface{}{
-		"image":      "confluentinc/cp-kafka:" + ConfluentKafkaVersion,
+		"image":      versions.GetImage("cp-kafka"),
 		"depends_on": []string{"zookeeper"},
-		"ports":      []string{"9092:9092"},
+		"ports":      []string{"127.0.0.1:9093:9092"},
+		// INTERNAL for the containers of the "app" profile, EXTERNAL for
+		// clients on the host (see the docker-compose template)
 		"environment": map[string]string{
 			"KAFKA_BROKER_ID":                        "1",
 			"KAFKA_ZOOKEEPER_CONNECT":                "zookeeper:2181",
-			"KAFKA_ADVERTISED_LISTENERS":             "PLAINTEXT://localhost:9092",
+			"KAFKA_LISTENERS":                        "INTERNAL://0.0.0.0:29092,EXTERNAL://0.0.0.0:9092",
+			"KAFKA_ADVERTISED_LISTENERS":             "INTERNAL://kafka:29092,EXTERNAL://localhost:9093",
+			"KAFKA_LISTENER_SECURITY_PROTOCOL_MAP":   "INTERNAL:PLAINTEXT,EXTERNAL:PLAINTEXT",
+			"KAFKA_INTER_BROKER_LISTENER_NAME":       "INTERNAL",
 			"KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR": "1",
 		},
+		"healthcheck": map[string]interface{}{
+			"test":     []string{"CMD-SHELL", "kafka-topics --bootstrap-server kafka:29092 --list"},
+			"interval": "10s",
+			"timeout":  "10s",
+			"retries":  5,
+		},
 	}
 
 	return kafka, zookeeper
 }
 
+// GetAppService returns the "app" profile service of a runtime module:
+// built from its Dockerfile (or the image Jib loads into Docker), healthy
+// once its readiness probe answers. healthchecked tells whether a
+// dependency has a healthcheck to wait for; the others only have to start.
+func GetAppService(cfg *config.ProjectConfig, svc config.ComposeAppService, healthchecked func(string) bool) map[string]interface{} {
+	environment := map[string]string{}
+	for _, e := range svc.Env {
+		environment[e.Name] = e.Value
+	}
+	service := map[string]interface{}{
+		"container_name": cfg.ProjectName + "-" + svc.Name,
+		"profiles":       []string{"app"},
+		"environment":    environment,
+		"ports":          []string{fmt.Sprintf("127.0.0.1:%d:%d", svc.Port, svc.Port)},
+		"healthcheck": map[string]interface{}{
+			"test":         []string{"CMD", "wget", "-qO-", fmt.Sprintf("http://localhost:%d/actuator/health/readiness", svc.Port)},
+			"interval":     "10s",
+			"timeout":      "5s",
+			"retries":      10,
+			"start_period": "60s",
+		},
+	}
+	if cfg.UsesJib() {
+		service["image"] = cfg.ImageName(svc.Module)
+	} else {
+		service["build"] = map[string]string{
+			"context":    ".",
+			"dockerfile": svc.Module + "/Dockerfile",
+		}
+	}
+	if len(svc.DependsOn) > 0 {
+		dependsOn := map[string]interface{}{}
+		for _, dep := range svc.DependsOn {
+			condition := "service_started"
+			if healthchecked(dep) {
+				condition = "service_healthy"
+			}
+			dependsOn[dep] = map[string]string{"condition": condition}
+		}
+		service["depends_on"] = dependsOn
+	}
+	return service
+}
+
 // GetRabbitMQService returns a RabbitMQ service configuration
 func GetRabbitMQService(user, password string) map[string]interface{} {
 	return map[string]interface{}{
-		"image": "rabbitmq:3-management-alpine",
+		"image": versions.GetImage("rabbitmq"),
 		"ports": []string{"5672:5672", "15672:15672"},
 		"environment": map[string]string{
 			"RABBITMQ_DEFAULT_USER": user,
@@ -397,7 +817,7 @@ func GetRabbitMQService(user, password string) map[string]interface{} {
 // GetLocalStackService returns a LocalStack service configuration for SQS
 func GetLocalStackService() map[string]interface{} {
 	return map[string]interface{}{
-		"image": "localstack/localstack:" + LocalStackImageVersion,
+		"image": versions.GetImage("localstack"),
 		"ports": []string{"4566:4566"},
 		"environment": map[string]string{
 			"SERVICES":       "sqs",
@@ -419,6 +839,16 @@ func GetPubSubEmulatorService() map[string]interface{} {
 	}
 }
 
+// GetNATSService returns a NATS server configuration with JetStream enabled
+func GetNATSService() map[string]interface{} {
+	return map[string]interface{}{
+		"image":   versions.GetImage("nats"),
+		"ports":   []string{"127.0.0.1:4223:4222", "127.0.0.1:8223:8222"},
+		"command": []string{"-js", "-sd", "/data", "-m", "8222"},
+		"volumes": []string{"nats-data:/data"},
+	}
+}
+
 // EnvUpdater handles modifications to .env.example files
 type EnvUpdater struct {
 	path    string
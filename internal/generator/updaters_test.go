@@ -0,0 +1,316 @@
+package generator
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files under testdata")
+
+// pomGoldenCases edit a POM under testdata/pom; the result must match
+// testdata/pom/<name>.golden.xml byte for byte
+var pomGoldenCases = []struct {
+	name  string
+	input string
+	edit  func(p *POMUpdater) error
+}{
+	{"modules-properties", "input.xml", func(p *POMUpdater) error {
+		return firstErr(
+			p.AddModule("SQLDatastore"),
+			p.AddProperty("testcontainers.version", "1.20.4"),
+		)
+	}},
+	{"dependencies", "input.xml", func(p *POMUpdater) error {
+		return firstErr(
+			p.AddDependency("org.projectlombok", "lombok", ""),
+			p.AddDependencyManagement("org.testcontainers", "testcontainers-bom", "${testcontainers.version}", "pom", "import"),
+			p.AddExclusion("org.springframework.boot", "spring-boot-starter-web", "org.springframework.boot", "spring-boot-starter-tomcat"),
+			p.AddExclusion("com.example", "Model", "commons-logging", "commons-logging"),
+		)
+	}},
+	{"plugins", "input.xml", func(p *POMUpdater) error {
+		return firstErr(
+			p.AddPluginManagement(POMPlugin{
+				GroupID:       "org.apache.maven.plugins",
+				ArtifactID:    "maven-surefire-plugin",
+				Version:       "3.5.2",
+				Configuration: "<includes>\n    <include>**/*Test.java</include>\n</includes>",
+			}),
+			p.AddPlugin(POMPlugin{
+				GroupID:    "org.jacoco",
+				ArtifactID: "jacoco-maven-plugin",
+				Version:    "0.8.12",
+				Executions: []POMExecution{{ID: "report", Phase: "verify", Goals: []string{"report"}}},
+			}),
+			p.AddTestJar(),
+		)
+	}},
+	{"profiles", "input.xml", func(p *POMUpdater) error {
+		return firstErr(
+			p.AddProfile("native"),
+			p.AddProfileProperty("native", "spring.aot.enabled", "true"),
+			p.AddProfileDependency("native", "org.graalvm.sdk", "graal-sdk", "24.1.1"),
+			p.AddProfileProperty("ci", "skipITs", "false"),
+		)
+	}},
+	{"minimal", "minimal.xml", func(p *POMUpdater) error {
+		return firstErr(
+			p.AddTestJar(),
+			p.AddDependency("com.example", "Model", ""),
+			p.AddModule("Model"),
+			p.AddProperty("java.version", "21"),
+		)
+	}},
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// editPOM applies edit to a copy of src and returns the saved bytes
+func editPOM(t *testing.T, src []byte, edit func(p *POMUpdater) error) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPOMUpdater(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := edit(p); err != nil {
+		t.Fatalf("edit failed: %v", err)
+	}
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestPOMUpdater_Golden(t *testing.T) {
+	for _, tc := range pomGoldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join("testdata", "pom", tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := editPOM(t, src, tc.edit)
+
+			golden := filepath.Join("testdata", "pom", tc.name+".golden.xml")
+			if *updateGolden {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run go test -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s differs from %s:\n%s", tc.input, golden, got)
+			}
+
+			// Every edit is idempotent
+			if again := editPOM(t, got, tc.edit); !bytes.Equal(again, got) {
+				t.Errorf("repeating the edit changed the POM:\n%s", again)
+			}
+
+			// A CRLF document gets CRLF edits and is otherwise the same
+			crlf := editPOM(t, bytes.ReplaceAll(src, []byte("\n"), []byte("\r\n")), tc.edit)
+			if !bytes.Equal(crlf, bytes.ReplaceAll(want, []byte("\n"), []byte("\r\n"))) {
+				t.Errorf("CRLF round trip differs:\n%q", crlf)
+			}
+		})
+	}
+}
+
+func TestPOMUpdater_UntouchedSectionsAreByteStable(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "pom", "input.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing to do: the file is written back unchanged
+	got := editPOM(t, src, func(p *POMUpdater) error {
+		return firstErr(
+			p.AddModule("API"),
+			p.AddProperty("java.version", "17"),
+			p.AddDependency("org.springframework.boot", "spring-boot-starter-web", ""),
+			p.AddDependencyManagement("com.example", "Model", "${project.version}", "", ""),
+			p.AddPlugin(POMPlugin{ArtifactID: "maven-compiler-plugin"}),
+		)
+	})
+	if !bytes.Equal(got, src) {
+		t.Errorf("no-op edits rewrote the POM:\n%s", got)
+	}
+
+	// An edit deep in <build> leaves everything before it alone
+	got = editPOM(t, src, func(p *POMUpdater) error { return p.AddTestJar() })
+	cut := bytes.Index(src, []byte("    </plugins>"))
+	if !bytes.HasPrefix(got, src[:cut]) {
+		t.Errorf("content before <plugins> changed:\n%s", got)
+	}
+	if !bytes.HasSuffix(got, src[cut:]) {
+		t.Errorf("content after the new plugin changed:\n%s", got)
+	}
+}
+
+func TestPOMUpdater_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	os.WriteFile(path, []byte("<project><modules></project>"), 0644)
+	if _, err := NewPOMUpdater(path); err == nil {
+		t.Error("a malformed POM must be rejected")
+	}
+
+	os.WriteFile(path, []byte("<settings/>"), 0644)
+	if _, err := NewPOMUpdater(path); err == nil || !strings.Contains(err.Error(), "<project>") {
+		t.Errorf("a document without <project> must be rejected, got %v", err)
+	}
+
+	os.WriteFile(path, []byte("<project>\n</project>\n"), 0644)
+	p, err := NewPOMUpdater(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddExclusion("g", "missing", "x", "y"); err == nil {
+		t.Error("excluding from an undeclared dependency must fail")
+	}
+}
+
+func TestDockerComposeUpdater_UserModifiedFile(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "compose", "user-modified.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewDockerComposeUpdater(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.HasHealthcheck("postgres") || d.HasHealthcheck("redis") {
+		t.Error("HasHealthcheck should see postgres' healthcheck only")
+	}
+	if got := d.ServiceImage("redis"); got != "redis:7.2-alpine" {
+		t.Errorf("ServiceImage(redis) = %q", got)
+	}
+	if got := d.ServiceProfiles("grafana"); len(got) != 1 || got[0] != "observability" {
+		t.Errorf("ServiceProfiles(grafana) = %v", got)
+	}
+
+	for _, step := range []error{
+		d.SetImageVersion("redis", "7.4-alpine"),
+		d.SetImageVersion("postgres", "17-alpine"),
+		d.SetImageVersion("grafana", "11.4.0"),
+		d.SetServiceProfiles("redis", "infra"),
+	} {
+		if step != nil {
+			t.Fatal(step)
+		}
+	}
+	d.AddService("rabbitmq", GetRabbitMQService("guest", "guest"))
+	d.AddProfiledService("observability", "tempo", map[string]interface{}{"image": "grafana/tempo:2.6.1"})
+	d.AddVolume("postgres_data")
+	d.AddVolume("rabbitmq-data")
+	if err := d.Save(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "compose", "user-modified.golden.yml")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("edited compose file differs from %s:\n%s", golden, got)
+	}
+
+	// Reopening the result reads back what was written
+	d, err = NewDockerComposeUpdater(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.ServiceProfiles("tempo"); len(got) != 1 || got[0] != "observability" {
+		t.Errorf("ServiceProfiles(tempo) = %v", got)
+	}
+	if !d.HasService("rabbitmq") || d.ServiceImage("rabbitmq") == "" {
+		t.Error("rabbitmq should be added with its image")
+	}
+}
+
+func TestDockerComposeUpdater_NoopKeepsFile(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "compose", "user-modified.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src = bytes.ReplaceAll(src, []byte("\n"), []byte("\r\n"))
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	os.WriteFile(path, src, 0644)
+
+	d, err := NewDockerComposeUpdater(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.AddVolume("postgres_data")
+	if err := d.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, src) {
+		t.Errorf("a no-op edit rewrote the file:\n%q", got)
+	}
+}
+
+func TestDockerComposeUpdater_NewFileAndErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	d, err := NewDockerComposeUpdater(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.AddService("redis", GetRedisService("redis"))
+	d.AddVolume("redis-data")
+	d.RemoveService("missing")
+	if err := d.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "services:\n  redis:\n") || !strings.Contains(string(data), "\nvolumes:\n  redis-data:\n") {
+		t.Errorf("unexpected new compose file:\n%s", data)
+	}
+
+	d, _ = NewDockerComposeUpdater(path)
+	d.RemoveService("redis")
+	if d.HasService("redis") {
+		t.Error("RemoveService should drop the service")
+	}
+	if err := d.SetImageVersion("redis", "8"); err == nil {
+		t.Error("SetImageVersion on a missing service must fail")
+	}
+	if err := d.SetServiceProfiles("redis", "infra"); err == nil {
+		t.Error("SetServiceProfiles on a missing service must fail")
+	}
+}
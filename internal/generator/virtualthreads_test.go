@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestGenerator_Generate_EnablePreview(t *testing.T) {
+	for _, preview := range []bool{false, true} {
+		projectPath := filepath.Join(t.TempDir(), "loom")
+		cfg := &config.ProjectConfig{
+			ProjectName:    "loom",
+			GroupID:        "com.test.loom",
+			ArtifactID:     "loom",
+			JavaVersion:    "24",
+			Modules:        config.ResolveDependencies([]string{"SQLDatastore", "API", "EventConsumer"}),
+			Database:       config.DatabasePostgreSQL,
+			MessageBroker:  config.BrokerKafka,
+			StaticAnalysis: config.StaticAnalysisErrorProne,
+			EnablePreview:  preview,
+		}
+		gen, err := NewWithVersionAt(cfg, "test", projectPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		pom := readFile(t, projectPath, "pom.xml")
+		if err := xml.Unmarshal([]byte(pom), new(struct{})); err != nil {
+			t.Fatalf("pom.xml is not valid XML: %v", err)
+		}
+		// javac (main and test sources), surefire and spring-boot:run
+		if got, want := strings.Count(pom, "--enable-preview"), map[bool]int{false: 0, true: 4}[preview]; got != want {
+			t.Errorf("preview=%v: pom.xml has %d --enable-preview, want %d", preview, got, want)
+		}
+		dockerfile := readFile(t, projectPath, "API/Dockerfile")
+		if got := strings.Contains(dockerfile, `ENTRYPOINT ["java", "--enable-preview", "-jar", "app.jar"]`); got != preview {
+			t.Errorf("preview=%v: API/Dockerfile ENTRYPOINT with --enable-preview = %v", preview, got)
+		}
+		if !strings.Contains(dockerfile, `"-jar", "app.jar"]`) {
+			t.Error("API/Dockerfile should still run app.jar")
+		}
+	}
+}
+
+func TestGenerator_Generate_VirtualThreadListeners(t *testing.T) {
+	for _, tt := range []struct {
+		broker, config, executor string
+	}{
+		{config.BrokerKafka, "KafkaConfig.java", "setListenerTaskExecutor(listenerTaskExecutor(environment))"},
+		{config.BrokerRabbitMQ, "RabbitConfig.java", `new VirtualThreadTaskExecutor("rabbit-listener-")`},
+		{config.BrokerRedisStreams, "RedisStreamConfig.java", `new VirtualThreadTaskExecutor("redis-stream-")`},
+	} {
+		t.Run(tt.broker, func(t *testing.T) {
+			projectPath := filepath.Join(t.TempDir(), "loom")
+			cfg := &config.ProjectConfig{
+				ProjectName:   "loom",
+				GroupID:       "com.test.loom",
+				ArtifactID:    "loom",
+				JavaVersion:   "21",
+				Modules:       config.ResolveDependencies([]string{"EventConsumer"}),
+				MessageBroker: tt.broker,
+			}
+			gen, err := NewWithVersionAt(cfg, "test", projectPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			source := readFile(t, projectPath, "EventConsumer/src/main/java/com/test/loom/eventconsumer/config/"+tt.config)
+			for _, want := range []string{"Threading.VIRTUAL.isActive(environment)", tt.executor} {
+				if !strings.Contains(source, want) {
+					t.Errorf("%s should contain %s", tt.config, want)
+				}
+			}
+			yml := readFile(t, projectPath, "EventConsumer/src/main/resources/application.yml")
+			if !strings.Contains(yml, "enabled: ${SPRING_THREADS_VIRTUAL_ENABLED:true}") {
+				t.Error("application.yml should enable virtual threads")
+			}
+		})
+	}
+}
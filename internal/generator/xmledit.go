@@ -0,0 +1,399 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xmlEditor edits an XML document in place. Elements are located by their
+// byte spans in the source text and every edit splices new text into it,
+// so whatever an edit does not touch — comments, attribute order and
+// quoting, blank lines, indentation, the XML declaration — is written back
+// byte for byte.
+type xmlEditor struct {
+	src     []byte
+	doc     *xmlNode // synthetic node whose children are the top-level elements
+	newline string
+	unit    string // one level of indentation
+}
+
+// xmlNode is an element's position in the source. For a self-closing
+// element openEnd, closeStart and end are equal.
+type xmlNode struct {
+	name       string
+	start      int // offset of '<'
+	openEnd    int // offset just past the start tag
+	closeStart int // offset of the end tag's '<'
+	end        int // offset just past the end tag
+	children   []*xmlNode
+}
+
+func (n *xmlNode) selfClosing() bool { return n.openEnd == n.end }
+
+// child returns the first child element named name, or nil
+func (n *xmlNode) child(name string) *xmlNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// elements returns the child elements named name
+func (n *xmlNode) elements(name string) []*xmlNode {
+	var out []*xmlNode
+	for _, c := range n.children {
+		if c.name == name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func newXMLEditor(src []byte) (*xmlEditor, error) {
+	e := &xmlEditor{src: src, newline: "\n"}
+	if bytes.Contains(src, []byte("\r\n")) {
+		e.newline = "\r\n"
+	}
+	if err := e.parse(); err != nil {
+		return nil, err
+	}
+	e.unit = e.detectUnit()
+	return e, nil
+}
+
+// parse rebuilds the element tree from e.src
+func (e *xmlEditor) parse() error {
+	d := xml.NewDecoder(bytes.NewReader(e.src))
+	d.Strict = false
+	doc := &xmlNode{end: len(e.src), closeStart: len(e.src)}
+	stack := []*xmlNode{doc}
+	for {
+		before := int(d.InputOffset())
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		after := int(d.InputOffset())
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local, start: before, openEnd: after}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			if len(stack) == 1 || n.name != t.Name.Local {
+				return fmt.Errorf("unexpected </%s> at offset %d", t.Name.Local, before)
+			}
+			// A self-closing tag yields an end element of zero length
+			n.closeStart, n.end = before, after
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) != 1 {
+		return fmt.Errorf("<%s> is never closed", stack[len(stack)-1].name)
+	}
+	e.doc = doc
+	return nil
+}
+
+// detectUnit returns the indentation step of the document: the extra
+// indentation of the first element nested on its own line
+func (e *xmlEditor) detectUnit() string {
+	var walk func(n *xmlNode) string
+	walk = func(n *xmlNode) string {
+		for _, c := range n.children {
+			outer, ok1 := e.lineIndent(n)
+			inner, ok2 := e.lineIndent(c)
+			if n != e.doc && ok1 && ok2 && len(inner) > len(outer) && strings.HasPrefix(inner, outer) {
+				return inner[len(outer):]
+			}
+			if u := walk(c); u != "" {
+				return u
+			}
+		}
+		return ""
+	}
+	if u := walk(e.doc); u != "" {
+		return u
+	}
+	return "    "
+}
+
+// find returns the element at path from the document, or nil
+func (e *xmlEditor) find(path ...string) *xmlNode {
+	n := e.doc
+	for _, name := range path {
+		if n = n.child(name); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// nodeAt returns the element starting at offset. Edits only ever splice
+// inside or after the element being edited, so a parent found before an
+// edit is found again by its start offset.
+func (e *xmlEditor) nodeAt(offset int) *xmlNode {
+	var walk func(n *xmlNode) *xmlNode
+	walk = func(n *xmlNode) *xmlNode {
+		for _, c := range n.children {
+			if c.start == offset {
+				return c
+			}
+			if c.start < offset && offset < c.end {
+				return walk(c)
+			}
+		}
+		return nil
+	}
+	return walk(e.doc)
+}
+
+// text returns the element's content without surrounding whitespace
+func (e *xmlEditor) text(n *xmlNode) string {
+	if n == nil || n.selfClosing() {
+		return ""
+	}
+	return strings.TrimSpace(string(e.src[n.openEnd:n.closeStart]))
+}
+
+// childText returns the text of n's first child named name
+func (e *xmlEditor) childText(n *xmlNode, name string) string {
+	return e.text(n.child(name))
+}
+
+// lineIndent returns the whitespace before n on its line, and whether n
+// is the first thing on that line
+func (e *xmlEditor) lineIndent(n *xmlNode) (string, bool) {
+	i := n.start
+	for i > 0 && (e.src[i-1] == ' ' || e.src[i-1] == '\t') {
+		i--
+	}
+	if i > 0 && e.src[i-1] != '\n' {
+		return "", false
+	}
+	return string(e.src[i:n.start]), true
+}
+
+func (e *xmlEditor) indentOf(n *xmlNode) string {
+	indent, _ := e.lineIndent(n)
+	return indent
+}
+
+// childIndent returns the indentation of n's children: that of an
+// existing child on its own line, else one level deeper than n
+func (e *xmlEditor) childIndent(n *xmlNode) string {
+	for _, c := range n.children {
+		if indent, ok := e.lineIndent(c); ok {
+			return indent
+		}
+	}
+	if n == e.doc {
+		return ""
+	}
+	return e.indentOf(n) + e.unit
+}
+
+// splice replaces src[start:end] with text and reparses
+func (e *xmlEditor) splice(start, end int, text string) error {
+	src := make([]byte, 0, len(e.src)-(end-start)+len(text))
+	src = append(src, e.src[:start]...)
+	src = append(src, text...)
+	src = append(src, e.src[end:]...)
+	old := e.src
+	e.src = src
+	if err := e.parse(); err != nil {
+		e.src = old
+		_ = e.parse()
+		return fmt.Errorf("edit produced invalid XML: %w", err)
+	}
+	return nil
+}
+
+// xmlElem is an element to insert. An element has text, raw inner XML
+// or children; raw content is re-indented to its new position. A block
+// element without content puts its end tag on a line of its own.
+type xmlElem struct {
+	name     string
+	text     string
+	raw      string
+	children []xmlElem
+	block    bool
+}
+
+// render writes the element as it appears at indent, its first line
+// unindented
+func (e *xmlEditor) render(el xmlElem, indent string) string {
+	var b strings.Builder
+	b.WriteString("<" + el.name + ">")
+	switch {
+	case len(el.children) > 0:
+		for _, c := range el.children {
+			b.WriteString(e.newline + indent + e.unit + e.render(c, indent+e.unit))
+		}
+		b.WriteString(e.newline + indent)
+	case el.raw != "":
+		for _, line := range dedentLines(el.raw) {
+			if line == "" {
+				b.WriteString(e.newline)
+				continue
+			}
+			b.WriteString(e.newline + indent + e.unit + line)
+		}
+		b.WriteString(e.newline + indent)
+	case el.block:
+		b.WriteString(e.newline + indent)
+	default:
+		b.WriteString(escapeXMLText(el.text))
+	}
+	b.WriteString("</" + el.name + ">")
+	return b.String()
+}
+
+// dedentLines splits raw into lines without blank leading and trailing
+// lines and without their common indentation
+func dedentLines(raw string) []string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	common := -1
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		n := len(l) - len(strings.TrimLeft(l, " \t"))
+		if common == -1 || n < common {
+			common = n
+		}
+	}
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = strings.TrimRight(l[common:], " \t")
+		}
+	}
+	return lines
+}
+
+// insertChild inserts el into parent at the position the canonical
+// element order gives it (order lists the child names of parent's kind;
+// names not in it go last), or as the last child. It returns the
+// inserted element.
+func (e *xmlEditor) insertChild(parent *xmlNode, el xmlElem, order []string) (*xmlNode, error) {
+	indent := e.childIndent(parent)
+	rendered := e.render(el, indent)
+
+	// The splice is prefix + rendered + suffix at [start, end)
+	var start, end int
+	var prefix, suffix string
+	if before := nextInOrder(parent, el.name, order); before != nil {
+		start = before.start
+		if lineIndent, ok := e.lineIndent(before); ok {
+			start -= len(lineIndent)
+			prefix, suffix = indent, e.newline
+		}
+		end = start
+	} else if parent.selfClosing() {
+		tag := strings.TrimRight(strings.TrimSuffix(string(e.src[parent.start:parent.end]), "/>"), " \t\r\n")
+		start, end = parent.start, parent.end
+		prefix = tag + ">" + e.newline + indent
+		suffix = e.newline + e.indentOf(parent) + "</" + parent.name + ">"
+	} else {
+		// After the last non-blank content, keeping the whitespace that
+		// precedes the end tag
+		start = parent.closeStart
+		for start > parent.openEnd && strings.ContainsRune(" \t\r\n", rune(e.src[start-1])) {
+			start--
+		}
+		end = start
+		prefix = e.newline + indent
+		if !bytes.Contains(e.src[start:parent.closeStart], []byte("\n")) {
+			suffix = e.newline + e.indentOf(parent)
+		}
+	}
+	if err := e.splice(start, end, prefix+rendered+suffix); err != nil {
+		return nil, err
+	}
+	if n := e.nodeAt(start + len(prefix)); n != nil {
+		return n, nil
+	}
+	return nil, fmt.Errorf("inserted <%s> not found", el.name)
+}
+
+// nextInOrder returns the first child of parent that order places after
+// name, or nil when name goes last
+func nextInOrder(parent *xmlNode, name string, order []string) *xmlNode {
+	rank := func(n string) int {
+		for i, o := range order {
+			if o == n {
+				return i
+			}
+		}
+		return len(order)
+	}
+	r := rank(name)
+	if r == len(order) {
+		return nil
+	}
+	for _, c := range parent.children {
+		if rank(c.name) > r {
+			return c
+		}
+	}
+	return nil
+}
+
+// ensureChild returns parent's child named name, inserting an empty one
+// where order places it if there is none
+func (e *xmlEditor) ensureChild(parent *xmlNode, name string, order []string) (*xmlNode, error) {
+	if c := parent.child(name); c != nil {
+		return c, nil
+	}
+	return e.insertChild(parent, xmlElem{name: name, block: true}, order)
+}
+
+// remove deletes n, with its line when it is alone on it
+func (e *xmlEditor) remove(n *xmlNode) error {
+	start, end := n.start, n.end
+	if indent, ok := e.lineIndent(n); ok {
+		rest := end
+		for rest < len(e.src) && (e.src[rest] == ' ' || e.src[rest] == '\t' || e.src[rest] == '\r') {
+			rest++
+		}
+		if rest == len(e.src) || e.src[rest] == '\n' {
+			start -= len(indent)
+			end = rest
+			if end < len(e.src) {
+				end++
+			}
+		}
+	}
+	return e.splice(start, end, "")
+}
+
+// setText replaces the content of n with text
+func (e *xmlEditor) setText(n *xmlNode, text string) error {
+	if n.selfClosing() {
+		tag := strings.TrimRight(strings.TrimSuffix(string(e.src[n.start:n.end]), "/>"), " \t\r\n")
+		return e.splice(n.start, n.end, tag+">"+escapeXMLText(text)+"</"+n.name+">")
+	}
+	return e.splice(n.openEnd, n.closeStart, escapeXMLText(text))
+}
+
+// escapeXMLText escapes the characters that cannot appear literally in
+// element content. Quotes are left alone, as hand-written POMs do.
+var escapeXMLText = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
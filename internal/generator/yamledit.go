@@ -0,0 +1,366 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlEditor edits a block-style YAML document line by line. yaml.v3
+// locates the entries; edits then splice rendered lines into the source,
+// so comments, blank lines, anchors, quoting and key order outside the
+// edited entries are written back unchanged — which a decode/encode
+// round trip through yaml.v3 does not guarantee.
+type yamlEditor struct {
+	lines   []string // without line endings
+	newline string
+	unit    int // spaces per indentation level
+	root    *yaml.Node
+}
+
+func newYAMLEditor(src []byte) (*yamlEditor, error) {
+	e := &yamlEditor{newline: "\n"}
+	if bytes.Contains(src, []byte("\r\n")) {
+		e.newline = "\r\n"
+	}
+	e.lines = strings.Split(strings.ReplaceAll(string(src), "\r\n", "\n"), "\n")
+	if err := e.parse(); err != nil {
+		return nil, err
+	}
+	e.unit = e.detectUnit()
+	return e, nil
+}
+
+// bytes returns the edited document
+func (e *yamlEditor) bytes() []byte {
+	return []byte(strings.Join(e.lines, e.newline))
+}
+
+// parse rebuilds the node tree from e.lines
+func (e *yamlEditor) parse() error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(e.lines, "\n")), &doc); err != nil {
+		return err
+	}
+	switch {
+	case len(doc.Content) == 0:
+		e.root = nil
+	case doc.Content[0].Kind == yaml.MappingNode:
+		e.root = doc.Content[0]
+	default:
+		return fmt.Errorf("top level is not a mapping")
+	}
+	return nil
+}
+
+// detectUnit returns the indentation step of the first nested block
+// mapping, or 2
+func (e *yamlEditor) detectUnit() int {
+	if e.root != nil {
+		for i := 0; i+1 < len(e.root.Content); i += 2 {
+			k, v := e.root.Content[i], e.root.Content[i+1]
+			if v.Kind == yaml.MappingNode && v.Style&yaml.FlowStyle == 0 && len(v.Content) > 0 && v.Line > k.Line {
+				return v.Content[0].Column - k.Column
+			}
+		}
+	}
+	return 2
+}
+
+// entry returns the key and value nodes of key in mapping m
+func entry(m *yaml.Node, key string) (k, v *yaml.Node) {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i], m.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// lookup returns the value of key in m, following aliases and merge keys
+// (<<) the way a YAML reader sees the mapping
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	m = resolve(m)
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	if _, v := entry(m, key); v != nil {
+		return resolve(v)
+	}
+	if _, merge := entry(m, "<<"); merge != nil {
+		merge = resolve(merge)
+		sources := []*yaml.Node{merge}
+		if merge.Kind == yaml.SequenceNode {
+			sources = merge.Content
+		}
+		for _, s := range sources {
+			if v := lookup(s, key); v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func resolve(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
+
+func indentWidth(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// entryEnd returns the index of the last line of the entry whose key is
+// k: the lines after it that are indented deeper, including comments
+// indented deeper and a sequence written at the key's own indentation.
+// Blank lines and comments that follow the entry belong to what comes
+// next.
+func (e *yamlEditor) entryEnd(k *yaml.Node, v *yaml.Node) int {
+	col := k.Column - 1
+	last := k.Line - 1
+	if v != nil && v.Style&yaml.FlowStyle != 0 {
+		// A flow collection may continue over several lines
+		last = max(last, lastLine(v)-1)
+	}
+	for i := last + 1; i < len(e.lines); i++ {
+		line := e.lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case indentWidth(line) > col:
+			last = i
+		case indentWidth(line) == col && v != nil && v.Kind == yaml.SequenceNode && strings.HasPrefix(trimmed, "-"):
+			last = i
+		default:
+			return last
+		}
+	}
+	return last
+}
+
+// lastLine returns the last line any node under n starts on
+func lastLine(n *yaml.Node) int {
+	line := n.Line
+	for _, c := range n.Content {
+		line = max(line, lastLine(c))
+	}
+	return line
+}
+
+// render formats key: value as block YAML indented by indent spaces
+func (e *yamlEditor) render(key string, value *yaml.Node, indent int) ([]string, error) {
+	m := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: key}, value}}
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(e.unit)
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	pad := strings.Repeat(" ", indent)
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = pad + l
+		}
+	}
+	return lines, nil
+}
+
+// splice replaces lines[start:end] with repl and reparses, reverting the
+// edit if it does not parse
+func (e *yamlEditor) splice(start, end int, repl []string) error {
+	old := e.lines
+	lines := make([]string, 0, len(old)-(end-start)+len(repl))
+	lines = append(lines, old[:start]...)
+	lines = append(lines, repl...)
+	lines = append(lines, old[end:]...)
+	e.lines = lines
+	if err := e.parse(); err != nil {
+		e.lines = old
+		_ = e.parse()
+		return fmt.Errorf("edit produced invalid YAML: %w", err)
+	}
+	return nil
+}
+
+// set makes key: value an entry of the mapping under parentKey, or of the
+// top level when parentKey is nil. An existing entry is replaced where it
+// stands, keeping the comments above it; a new one goes after the
+// mapping's last entry.
+func (e *yamlEditor) set(parentKey *yaml.Node, key string, value *yaml.Node) error {
+	if parentKey == nil && e.root == nil {
+		lines, err := e.render(key, value, 0)
+		if err != nil {
+			return err
+		}
+		// Keep leading comments; drop the empty document's blank tail
+		end := len(e.lines)
+		for end > 0 && strings.TrimSpace(e.lines[end-1]) == "" {
+			end--
+		}
+		return e.splice(end, len(e.lines), append(lines, ""))
+	}
+
+	m, parentCol := e.root, -1
+	if parentKey != nil {
+		m = e.valueOf(parentKey)
+		parentCol = parentKey.Column - 1
+	}
+
+	if k, v := entry(m, key); k != nil {
+		lines, err := e.render(key, value, k.Column-1)
+		if err != nil {
+			return err
+		}
+		return e.splice(k.Line-1, e.entryEnd(k, v)+1, lines)
+	}
+
+	if m.Kind == yaml.MappingNode && m.Style&yaml.FlowStyle == 0 && len(m.Content) > 0 {
+		first := m.Content[0]
+		lines, err := e.render(key, value, first.Column-1)
+		if err != nil {
+			return err
+		}
+		lastKey := m.Content[len(m.Content)-2]
+		at := e.entryEnd(lastKey, m.Content[len(m.Content)-1]) + 1
+		if len(m.Content) > 2 && lastKey.Line > 1 && strings.TrimSpace(e.lines[lastKey.Line-2]) == "" {
+			// Entries are set apart by blank lines: follow suit
+			lines = append([]string{""}, lines...)
+		}
+		return e.splice(at, at, lines)
+	}
+
+	// The parent is empty (null, {}): turn it into a block mapping holding
+	// just this entry
+	empty := m.Kind == yaml.ScalarNode && m.Tag == "!!null" || m.Kind == yaml.MappingNode && len(m.Content) == 0
+	if parentKey == nil || !empty {
+		return fmt.Errorf("cannot add %s: not a block mapping", key)
+	}
+	lines, err := e.render(key, value, parentCol+e.unit)
+	if err != nil {
+		return err
+	}
+	head := e.lines[parentKey.Line-1]
+	if m.Line == parentKey.Line && !(m.Kind == yaml.ScalarNode && m.Value == "") {
+		head = strings.TrimRight(head[:m.Column-1], " ")
+	}
+	end := e.entryEnd(parentKey, m)
+	return e.splice(parentKey.Line-1, end+1, append([]string{head}, lines...))
+}
+
+// valueOf returns the value node paired with key node k
+func (e *yamlEditor) valueOf(k *yaml.Node) *yaml.Node {
+	var find func(n *yaml.Node) *yaml.Node
+	find = func(n *yaml.Node) *yaml.Node {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i] == k {
+					return n.Content[i+1]
+				}
+			}
+		}
+		for _, c := range n.Content {
+			if v := find(c); v != nil {
+				return v
+			}
+		}
+		return nil
+	}
+	return find(e.root)
+}
+
+// remove deletes key's entry from the mapping under parentKey
+func (e *yamlEditor) remove(parentKey *yaml.Node, key string) error {
+	m := e.root
+	if parentKey != nil {
+		m = e.valueOf(parentKey)
+	}
+	k, v := entry(m, key)
+	if k == nil {
+		return nil
+	}
+	if m.Style&yaml.FlowStyle != 0 {
+		return fmt.Errorf("cannot remove %s from a flow mapping", key)
+	}
+	return e.splice(k.Line-1, e.entryEnd(k, v)+1, nil)
+}
+
+// setScalar replaces the text of n, a scalar on a single line of block
+// YAML, keeping its quoting, anchor and trailing comment
+func (e *yamlEditor) setScalar(n *yaml.Node, value string) error {
+	if n.Kind != yaml.ScalarNode || n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return fmt.Errorf("value at line %d is not a plain or quoted scalar", n.Line)
+	}
+	line := e.lines[n.Line-1]
+	start := n.Column - 1
+	if n.Anchor != "" {
+		// The node starts at its anchor
+		start += len("&" + n.Anchor)
+		for start < len(line) && line[start] == ' ' {
+			start++
+		}
+	}
+	end, ok := scalarEnd(line, start, n.Style)
+	if !ok {
+		return fmt.Errorf("value at line %d spans several lines", n.Line)
+	}
+	var text string
+	switch {
+	case n.Style&yaml.DoubleQuotedStyle != 0:
+		text = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	case n.Style&yaml.SingleQuotedStyle != 0:
+		text = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	default:
+		rendered, err := e.render("k", &yaml.Node{Kind: yaml.ScalarNode, Value: value}, 0)
+		if err != nil {
+			return err
+		}
+		text = strings.TrimPrefix(rendered[0], "k: ")
+	}
+	return e.splice(n.Line-1, n.Line, []string{line[:start] + text + line[end:]})
+}
+
+// scalarEnd returns the offset just past the scalar starting at start,
+// and false when it does not end on this line
+func scalarEnd(line string, start int, style yaml.Style) (int, bool) {
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			switch line[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1, true
+			}
+		}
+		return 0, false
+	}
+	end := len(line)
+	if i := strings.Index(line[start:], " #"); i >= 0 {
+		end = start + i
+	}
+	return start + len(strings.TrimRight(line[start:end], " ")), true
+}
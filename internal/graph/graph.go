@@ -0,0 +1,344 @@
+// Package graph maps the architecture of a Trabuco project: the Maven
+// dependencies between its modules, read from the module POMs, and the
+// docker-compose services each module talks to.
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Node kinds
+const (
+	KindModule  = "module"
+	KindService = "service"
+)
+
+// Edge kinds
+const (
+	EdgeCompile = "compile" // Maven dependency on another module
+	EdgeTest    = "test"    // Test-scoped dependency, such as a test-jar
+	EdgeUses    = "uses"    // Module to the docker-compose service it connects to
+)
+
+// Node is a module or a docker-compose service
+type Node struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+}
+
+// Edge points from a module to what it depends on
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// Graph is the architecture of one project. Modules come first, in the
+// order of the parent POM, then the services in alphabetical order.
+type Graph struct {
+	Project string `json:"project"` // Project name, or the parent POM's artifactId without metadata
+	Nodes   []Node `json:"nodes"`
+	Edges   []Edge `json:"edges"`
+}
+
+// pom is the part of a pom.xml the graph reads
+type pom struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+	} `xml:"parent"`
+	Modules      []string        `xml:"modules>module"`
+	Dependencies []pomDependency `xml:"dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Scope      string `xml:"scope"`
+}
+
+// Build reads the graph of the project at projectPath. The module edges come
+// from the POMs. The service edges come from .trabuco.json and are limited
+// to the services docker-compose.yml still defines; without metadata they
+// fall back to the depends_on of the modules run by the "app" profile.
+func Build(projectPath string) (*Graph, error) {
+	root, err := readPOM(filepath.Join(projectPath, "pom.xml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(root.Modules) == 0 {
+		return nil, fmt.Errorf("%s lists no modules; not a Trabuco project", filepath.Join(projectPath, "pom.xml"))
+	}
+	groupID := root.GroupID
+	if groupID == "" {
+		groupID = root.Parent.GroupID
+	}
+
+	g := &Graph{Project: root.ArtifactID}
+	isModule := make(map[string]bool, len(root.Modules))
+	for _, m := range root.Modules {
+		isModule[m] = true
+		g.Nodes = append(g.Nodes, Node{ID: m, Kind: KindModule})
+	}
+
+	for _, m := range root.Modules {
+		p, err := readPOM(filepath.Join(projectPath, m, "pom.xml"))
+		if err != nil {
+			return nil, err
+		}
+		kinds := map[string]string{}
+		var order []string
+		for _, d := range p.Dependencies {
+			if !isModule[d.ArtifactID] || d.ArtifactID == m {
+				continue
+			}
+			if d.GroupID != groupID && d.GroupID != "${project.groupId}" {
+				continue
+			}
+			kind := EdgeCompile
+			if d.Scope == "test" {
+				kind = EdgeTest
+			}
+			prev, seen := kinds[d.ArtifactID]
+			if !seen {
+				order = append(order, d.ArtifactID)
+			}
+			// A module used both ways, like Model and its test-jar, is drawn
+			// once as a compile dependency
+			if !seen || prev == EdgeTest {
+				kinds[d.ArtifactID] = kind
+			}
+		}
+		for _, dep := range order {
+			g.Edges = append(g.Edges, Edge{From: m, To: dep, Kind: kinds[dep]})
+		}
+	}
+
+	services, err := composeServices(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	var uses map[string][]string
+	if meta, err := config.LoadMetadata(projectPath); err == nil {
+		g.Project = meta.ProjectName
+		uses = ModuleServices(meta.ToProjectConfig())
+	} else {
+		uses = appDependsOn(services)
+	}
+
+	used := map[string]bool{}
+	for _, m := range root.Modules {
+		for _, svc := range uses[m] {
+			if services != nil && !services.has(svc) {
+				continue
+			}
+			used[svc] = true
+			g.Edges = append(g.Edges, Edge{From: m, To: svc, Kind: EdgeUses})
+		}
+	}
+	names := make([]string, 0, len(used))
+	for svc := range used {
+		names = append(names, svc)
+	}
+	sort.Strings(names)
+	for _, svc := range names {
+		g.Nodes = append(g.Nodes, Node{ID: svc, Kind: KindService})
+	}
+	return g, nil
+}
+
+// ModuleServices returns, for each module of cfg, the docker-compose
+// services it connects to. The service belongs to the module that holds the
+// client: SQLDatastore talks to the database, not the API that calls it.
+func ModuleServices(cfg *config.ProjectConfig) map[string][]string {
+	uses := map[string][]string{}
+	add := func(module string, services ...string) {
+		if cfg.HasModule(module) {
+			uses[module] = append(uses[module], services...)
+		}
+	}
+
+	if cfg.HasSharedTestDatabase() {
+		add(config.ModuleSQLDatastore, cfg.DatabaseServiceName())
+		if cfg.HasReplicaService() {
+			add(config.ModuleSQLDatastore, "postgres-replica")
+		}
+	}
+	switch cfg.NoSQLDatabase {
+	case config.DatabaseMongoDB, config.DatabaseRedis, config.DatabaseDynamoDB, config.DatabaseCassandra:
+		add(config.ModuleNoSQLDatastore, cfg.NoSQLDatabase)
+	}
+	add(config.ModuleSearch, "opensearch")
+	if cfg.CacheUsesRedis() {
+		add(config.ModuleShared, "redis")
+	}
+	if broker := brokerService(cfg); broker != "" && cfg.HasModule(config.ModuleEvents) {
+		add(config.ModuleEvents, broker)
+		add(config.ModuleEventConsumer, broker)
+	}
+
+	// JobRunr keeps its jobs in the project's database when it can, and in
+	// a database of its own otherwise; in memory it needs no service. The
+	// API enqueues into the same store.
+	switch {
+	case cfg.JobRunrServiceName() != "":
+		add(config.ModuleWorker, cfg.JobRunrServiceName())
+		add(config.ModuleAPI, cfg.JobRunrServiceName())
+	case cfg.HasModule(config.ModuleWorker) && cfg.HasSharedTestDatabase():
+		add(config.ModuleWorker, cfg.DatabaseServiceName())
+	case cfg.JobRunrUsesMongoDB():
+		add(config.ModuleWorker, "mongodb")
+	}
+
+	for _, t := range cfg.ModuleRuntimeTargets() {
+		switch {
+		case cfg.SecretsUsesVault():
+			add(t.Module, "vault")
+		case cfg.SecretsUsesAWS():
+			add(t.Module, "localstack")
+		}
+		if cfg.HasObservability() {
+			add(t.Module, "tempo")
+		}
+	}
+
+	for m, services := range uses {
+		uses[m] = dedupe(services)
+	}
+	return uses
+}
+
+// brokerService returns the docker-compose service of the message broker
+func brokerService(cfg *config.ProjectConfig) string {
+	switch {
+	case cfg.UsesKafka():
+		return "kafka"
+	case cfg.UsesRabbitMQ():
+		return "rabbitmq"
+	case cfg.UsesSQS():
+		return "localstack"
+	case cfg.UsesPubSub():
+		return "pubsub-emulator"
+	case cfg.UsesRedisStreams():
+		return "redis"
+	case cfg.UsesNATS():
+		return "nats"
+	}
+	return ""
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func readPOM(path string) (*pom, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var p pom
+	if err := xml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// compose is the part of docker-compose.yml the graph reads
+type compose struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Profiles  []string  `yaml:"profiles"`
+	DependsOn yaml.Node `yaml:"depends_on"`
+}
+
+func (c *compose) has(service string) bool {
+	_, ok := c.Services[service]
+	return ok
+}
+
+// composeServices reads docker-compose.yml, or returns nil when the project
+// has none
+func composeServices(projectPath string) (*compose, error) {
+	path := filepath.Join(projectPath, "docker-compose.yml")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var c compose
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// appDependsOn maps the modules run by the "app" profile, named after the
+// lowercased module, to the services they wait for. depends_on is either a
+// list of names or a map keyed by name.
+func appDependsOn(c *compose) map[string][]string {
+	uses := map[string][]string{}
+	if c == nil {
+		return uses
+	}
+	for name, svc := range c.Services {
+		if !containsString(svc.Profiles, "app") {
+			continue
+		}
+		module := moduleForService(name)
+		if module == "" {
+			continue
+		}
+		var deps []string
+		switch svc.DependsOn.Kind {
+		case yaml.SequenceNode:
+			for _, n := range svc.DependsOn.Content {
+				deps = append(deps, n.Value)
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(svc.DependsOn.Content); i += 2 {
+				deps = append(deps, svc.DependsOn.Content[i].Value)
+			}
+		}
+		uses[module] = deps
+	}
+	return uses
+}
+
+func moduleForService(name string) string {
+	for _, m := range config.GetModuleNames() {
+		if strings.ToLower(m) == name {
+			return m
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+)
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func hasEdge(g *Graph, from, to, kind string) bool {
+	for _, e := range g.Edges {
+		if e.From == from && e.To == to && e.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBuild_GeneratedProject(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName:   "shop",
+		GroupID:       "com.test.shop",
+		ArtifactID:    "shop",
+		JavaVersion:   "21",
+		Modules:       []string{"Model", "SQLDatastore", "Shared", "API", "Jobs", "Worker", "Events", "EventConsumer"},
+		Database:      config.DatabasePostgreSQL,
+		MessageBroker: config.BrokerKafka,
+		ReadReplica:   true,
+	}
+	gen, err := generator.NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	g, err := Build(projectPath)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if g.Project != "shop" {
+		t.Errorf("Project = %q, want shop", g.Project)
+	}
+	for _, want := range []Edge{
+		{"SQLDatastore", "Model", EdgeCompile},
+		{"API", "Shared", EdgeCompile},
+		// Model is both a compile dependency and a test-jar: one edge
+		{"API", "Model", EdgeCompile},
+		{"SQLDatastore", "postgres", EdgeUses},
+		{"SQLDatastore", "postgres-replica", EdgeUses},
+		{"Events", "kafka", EdgeUses},
+		{"EventConsumer", "kafka", EdgeUses},
+		{"Worker", "postgres", EdgeUses},
+	} {
+		if !hasEdge(g, want.From, want.To, want.Kind) {
+			t.Errorf("missing edge %s -> %s (%s)", want.From, want.To, want.Kind)
+		}
+	}
+	if hasEdge(g, "API", "Model", EdgeTest) {
+		t.Error("API -> Model should not be drawn twice")
+	}
+	if hasEdge(g, "API", "postgres", EdgeUses) {
+		t.Error("the API reaches the database through SQLDatastore, not directly")
+	}
+
+	mermaid := g.Mermaid()
+	for _, want := range []string{"flowchart LR", `postgres_replica[("postgres-replica")]`, "SQLDatastore --> postgres_replica", "API --> Shared"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid output should contain %q:\n%s", want, mermaid)
+		}
+	}
+	dot := g.DOT()
+	for _, want := range []string{`digraph "shop" {`, `"kafka" [shape=cylinder];`, `"Events" -> "kafka" [color="gray40"];`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output should contain %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestBuild_WithoutMetadata(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "pom.xml", `<project>
+  <groupId>com.acme</groupId>
+  <artifactId>acme</artifactId>
+  <modules>
+    <module>Model</module>
+    <module>API</module>
+    <module>Tools</module>
+  </modules>
+  <dependencyManagement>
+    <dependencies>
+      <dependency><groupId>com.acme</groupId><artifactId>API</artifactId></dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`)
+	writeFile(t, root, "Model/pom.xml", "<project><parent><groupId>com.acme</groupId></parent></project>")
+	writeFile(t, root, "Tools/pom.xml", "<project/>")
+	writeFile(t, root, "API/pom.xml", `<project>
+  <dependencies>
+    <dependency><groupId>com.acme</groupId><artifactId>Model</artifactId></dependency>
+    <dependency><groupId>org.other</groupId><artifactId>Model</artifactId></dependency>
+    <dependency><groupId>${project.groupId}</groupId><artifactId>Tools</artifactId><scope>test</scope></dependency>
+  </dependencies>
+</project>`)
+	writeFile(t, root, "docker-compose.yml", `services:
+  redis:
+    image: redis:7-alpine
+  api:
+    profiles: ["app"]
+    depends_on:
+      redis:
+        condition: service_healthy
+`)
+
+	g, err := Build(root)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if g.Project != "acme" {
+		t.Errorf("Project = %q, want the parent artifactId", g.Project)
+	}
+	want := []Edge{{"API", "Model", EdgeCompile}, {"API", "Tools", EdgeTest}, {"API", "redis", EdgeUses}}
+	if len(g.Edges) != len(want) {
+		t.Fatalf("Edges = %+v, want %+v", g.Edges, want)
+	}
+	for i := range want {
+		if g.Edges[i] != want[i] {
+			t.Errorf("Edges[%d] = %+v, want %+v", i, g.Edges[i], want[i])
+		}
+	}
+	if len(g.Nodes) != 4 || g.Nodes[3] != (Node{"redis", KindService}) {
+		t.Errorf("Nodes = %+v", g.Nodes)
+	}
+	if !strings.Contains(g.Mermaid(), "API -. test .-> Tools") {
+		t.Errorf("test dependencies should be dashed:\n%s", g.Mermaid())
+	}
+}
+
+func TestBuild_NotAProject(t *testing.T) {
+	if _, err := Build(t.TempDir()); err == nil {
+		t.Error("Build should fail without a pom.xml")
+	}
+}
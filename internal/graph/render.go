@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Output formats of the graph command
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// nonIdentifier matches the characters Mermaid does not accept in a node ID
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// DOT renders the graph for Graphviz. Modules are boxes and services are
+// cylinders; test dependencies are dashed.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", g.Project)
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+	for _, n := range g.Nodes {
+		shape := "box"
+		if n.Kind == KindService {
+			shape = "cylinder"
+		}
+		fmt.Fprintf(&b, "  %q [shape=%s];\n", n.ID, shape)
+	}
+	for _, e := range g.Edges {
+		attrs := ""
+		switch e.Kind {
+		case EdgeTest:
+			attrs = " [style=dashed, label=\"test\"]"
+		case EdgeUses:
+			attrs = " [color=\"gray40\"]"
+		}
+		fmt.Fprintf(&b, "  %q -> %q%s;\n", e.From, e.To, attrs)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart, which GitHub renders
+// inside a ```mermaid block
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		if n.Kind == KindService {
+			fmt.Fprintf(&b, "  %s[(\"%s\")]\n", mermaidID(n.ID), n.ID)
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", mermaidID(n.ID), n.ID)
+		}
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Kind == EdgeTest {
+			arrow = "-. test .->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", mermaidID(e.From), arrow, mermaidID(e.To))
+	}
+	return b.String()
+}
+
+// mermaidID keeps service names such as postgres-replica from being read
+// as an edge
+func mermaidID(id string) string {
+	return nonIdentifier.ReplaceAllString(id, "_")
+}
+
+// ValidateFormat returns "" when format is a supported output format, or a
+// human-readable error message
+func ValidateFormat(format string) string {
+	switch format {
+	case FormatDOT, FormatMermaid, FormatJSON:
+		return ""
+	}
+	return "Invalid --format value '" + format + "'. Valid options: mermaid, dot, json"
+}
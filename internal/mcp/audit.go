@@ -0,0 +1,246 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Audit statuses
+const (
+	AuditOK        = "ok"
+	AuditError     = "error"
+	AuditCancelled = "cancelled"
+)
+
+// auditRedacted replaces the values of secret-looking arguments
+const auditRedacted = "[REDACTED]"
+
+// auditMaxString caps recorded string arguments and error messages, so a
+// long requirements text does not bloat every line
+const auditMaxString = 1000
+
+var (
+	// auditSecretKey matches argument names whose values are never recorded
+	auditSecretKey = regexp.MustCompile(`(?i)(api[-_]?key|token|secret|passw(or)?d|credential|private[-_]?key|authorization)`)
+	// auditSecretValue matches provider API keys passed under other names
+	auditSecretValue = regexp.MustCompile(`^(sk-|sk_|ghp_|github_pat_|xox[bp]-|AKIA)[A-Za-z0-9_\-]{8,}`)
+)
+
+// AuditEntry is one tool call in the MCP audit log
+type AuditEntry struct {
+	Time       time.Time      `json:"time"`
+	Tool       string         `json:"tool"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	DurationMS int64          `json:"durationMs"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Client     string         `json:"client,omitempty"` // MCP client name and version
+	PID        int            `json:"pid"`              // Server process, to tell sessions apart
+}
+
+// AuditLogPath returns where the audit log is written. It can be
+// relocated with TRABUCO_MCP_AUDIT_LOG.
+func AuditLogPath() string {
+	if p := os.Getenv("TRABUCO_MCP_AUDIT_LOG"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".trabuco", "mcp-audit.jsonl")
+}
+
+// AuditEnabled reports whether mcp_audit is on in the user config
+func AuditEnabled() bool {
+	uc, err := config.LoadUserConfig()
+	return err == nil && uc.MCPAudit
+}
+
+// auditing is set once the audit middleware is installed; cancelled
+// calls are only remembered for it
+var auditing bool
+
+// auditLog appends entries to the audit log. Calls may run concurrently;
+// each entry is written with a single append.
+type auditLog struct {
+	mu     sync.Mutex
+	path   string
+	warned bool
+}
+
+func (a *auditLog) write(e AuditEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	line, err := json.Marshal(e)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(a.path), 0700)
+	}
+	var f *os.File
+	if err == nil {
+		f, err = os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	}
+	if err == nil {
+		_, err = f.Write(append(line, '\n'))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil && !a.warned {
+		// Auditing never fails a tool call; say so once
+		fmt.Fprintf(os.Stderr, "Warning: could not write the MCP audit log %s: %v\n", a.path, err)
+		a.warned = true
+	}
+}
+
+// auditMiddleware records every tool call, its redacted arguments,
+// duration and outcome in the log at path
+func auditMiddleware(path string) server.ToolHandlerMiddleware {
+	auditing = true
+	auditFile := &auditLog{path: path}
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, req)
+
+			entry := AuditEntry{
+				Time:       start.UTC(),
+				Tool:       req.Params.Name,
+				Arguments:  redactArguments(req.GetArguments()),
+				DurationMS: time.Since(start).Milliseconds(),
+				Status:     AuditOK,
+				Client:     auditClient(ctx),
+				PID:        os.Getpid(),
+			}
+			switch {
+			case takeCancelled(req) || ctx.Err() != nil:
+				entry.Status = AuditCancelled
+			case err != nil:
+				entry.Status, entry.Error = AuditError, truncate(err.Error())
+			case result != nil && result.IsError:
+				entry.Status, entry.Error = AuditError, truncate(resultText(result))
+			}
+			auditFile.write(entry)
+			return result, err
+		}
+	}
+}
+
+// auditClient names the client of the session the call came from
+func auditClient(ctx context.Context) string {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		return ""
+	}
+	info := session.GetClientInfo()
+	return strings.TrimSpace(info.Name + " " + info.Version)
+}
+
+// resultText returns the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range result.Content {
+		if t, ok := c.(mcp.TextContent); ok {
+			parts = append(parts, t.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// redactArguments copies args with secret-looking values replaced and
+// long strings cut
+func redactArguments(args map[string]any) map[string]any {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		if auditSecretKey.MatchString(k) {
+			out[k] = auditRedacted
+			continue
+		}
+		out[k] = redactValue(v)
+	}
+	return out
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return redactArguments(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactValue(item)
+		}
+		return out
+	case string:
+		if auditSecretValue.MatchString(v) {
+			return auditRedacted
+		}
+		return truncate(v)
+	}
+	return v
+}
+
+func truncate(s string) string {
+	if len(s) <= auditMaxString {
+		return s
+	}
+	// Cut on a rune boundary so the log stays valid UTF-8
+	cut := auditMaxString
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s… (%d more bytes)", s[:cut], len(s)-cut)
+}
+
+// ReadAudit returns the entries of the audit log at path, oldest first.
+// Lines that do not parse (a write cut short) are skipped.
+func ReadAudit(path string) ([]AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entries, _, err := ReadAuditFrom(f)
+	return entries, err
+}
+
+// ReadAuditFrom reads the entries from r up to its last complete line and
+// returns how many bytes that was, so a follower can resume from there
+func ReadAuditFrom(r io.Reader) ([]AuditEntry, int64, error) {
+	var entries []AuditEntry
+	var read int64
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// An incomplete last line is still being written
+			return entries, read, nil
+		}
+		if err != nil {
+			return entries, read, err
+		}
+		read += int64(len(line))
+		var e AuditEntry
+		if json.Unmarshal(line, &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+}
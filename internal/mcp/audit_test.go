@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestAuditMiddleware_RecordsCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "mcp-audit.jsonl")
+	s := server.NewMCPServer("test", "0",
+		server.WithToolCapabilities(false),
+		server.WithHooks(cancellationHooks()),
+		server.WithToolHandlerMiddleware(auditMiddleware(path)))
+	registerCancellation(s)
+
+	s.AddTool(mcp.NewTool("echo"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	s.AddTool(mcp.NewTool("broken"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("module Worker requires SQLDatastore"), nil
+	})
+	s.AddTool(mcp.NewTool("failing"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("boom")
+	})
+	started := make(chan struct{})
+	s.AddTool(mcp.NewTool("slow"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, cancel := cancellable(ctx, req)
+		defer cancel()
+		close(started)
+		select {
+		case <-ctx.Done():
+			return mcp.NewToolResultText("cancelled"), nil
+		case <-time.After(5 * time.Second):
+			return mcp.NewToolResultText("finished"), nil
+		}
+	})
+
+	call := func(id int, name, args string) {
+		msg := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":%q,"arguments":%s}}`, id, name, args)
+		s.HandleMessage(context.Background(), json.RawMessage(msg))
+	}
+	call(1, "echo", `{"project_name":"orders","api_key":"hunter2","provider":{"key":"sk-ant-0123456789abcdef"},"requirements":"`+strings.Repeat("x", auditMaxString+10)+`"}`)
+	call(2, "broken", `{}`)
+	call(3, "failing", `{}`)
+
+	done := make(chan struct{})
+	go func() {
+		call(4, "slow", `{}`)
+		close(done)
+	}()
+	<-started
+	s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":4}}`))
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("cancelled call did not return")
+	}
+
+	entries, err := ReadAudit(path)
+	if err != nil {
+		t.Fatalf("ReadAudit: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4: %+v", len(entries), entries)
+	}
+
+	echo := entries[0]
+	if echo.Tool != "echo" || echo.Status != AuditOK || echo.PID != os.Getpid() || echo.Time.IsZero() {
+		t.Errorf("echo entry = %+v", echo)
+	}
+	if echo.Arguments["project_name"] != "orders" {
+		t.Errorf("project_name = %v, want orders", echo.Arguments["project_name"])
+	}
+	if echo.Arguments["api_key"] != auditRedacted {
+		t.Errorf("api_key = %v, want it redacted", echo.Arguments["api_key"])
+	}
+	if provider, _ := echo.Arguments["provider"].(map[string]any); provider["key"] != auditRedacted {
+		t.Errorf("nested provider key = %v, want it redacted", echo.Arguments["provider"])
+	}
+	if req, _ := echo.Arguments["requirements"].(string); !strings.HasSuffix(req, "(10 more bytes)") {
+		t.Errorf("long argument not truncated: %d bytes", len(req))
+	}
+
+	if e := entries[1]; e.Status != AuditError || e.Error != "module Worker requires SQLDatastore" {
+		t.Errorf("error result entry = %+v", e)
+	}
+	if e := entries[2]; e.Status != AuditError || e.Error != "boom" {
+		t.Errorf("handler error entry = %+v", e)
+	}
+	if e := entries[3]; e.Tool != "slow" || e.Status != AuditCancelled {
+		t.Errorf("cancelled entry = %+v", e)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("audit log mode = %v, want 0600", perm)
+	}
+}
+
+func TestReadAuditFrom_StopsAtPartialLine(t *testing.T) {
+	complete := `{"time":"2026-01-02T03:04:05Z","tool":"list_modules","durationMs":3,"status":"ok","pid":1}` + "\n" +
+		"not json\n"
+	partial := `{"time":"2026-01-02T03:04:06Z","tool":"init_pro`
+
+	entries, n, err := ReadAuditFrom(strings.NewReader(complete + partial))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Tool != "list_modules" || entries[0].DurationMS != 3 {
+		t.Errorf("entries = %+v", entries)
+	}
+	if n != int64(len(complete)) {
+		t.Errorf("read %d bytes, want %d (up to the partial line)", n, len(complete))
+	}
+}
+
+func TestTruncate_RuneBoundary(t *testing.T) {
+	// "é" is two bytes; the limit falls in the middle of one
+	s := strings.Repeat("a", auditMaxString-1) + strings.Repeat("é", 10)
+	got := truncate(s)
+	if !utf8.ValidString(got) {
+		t.Fatalf("truncated string is not valid UTF-8: %q", got[auditMaxString-8:])
+	}
+	if !strings.HasPrefix(got, strings.Repeat("a", auditMaxString-1)+"…") || !strings.HasSuffix(got, "(20 more bytes)") {
+		t.Errorf("expected the cut before the split rune, got %q", got[auditMaxString-8:])
+	}
+	if short := "déjà vu"; truncate(short) != short {
+		t.Errorf("a short string should be kept, got %q", truncate(short))
+	}
+}
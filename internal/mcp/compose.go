@@ -0,0 +1,260 @@
+package mcp
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// composedRecommendation merges the modules of several matching patterns
+// into one init_project configuration, for requirements that need more than
+// any single pattern covers (e.g. an API that also runs jobs and consumes
+// events).
+type composedRecommendation struct {
+	Modules       string `json:"modules"`
+	Database      string `json:"database,omitempty"`
+	NoSQLDatabase string `json:"nosql_database,omitempty"`
+	MessageBroker string `json:"message_broker,omitempty"`
+	VectorStore   string `json:"vector_store,omitempty"`
+	// Patterns are the patterns the modules were merged from, best first.
+	Patterns []string       `json:"patterns"`
+	Drivers  []moduleDriver `json:"drivers"`
+	// Excluded lists modules a pattern suggested but the composition left
+	// out because they conflict with a better-supported module.
+	Excluded  []string `json:"excluded,omitempty"`
+	Reasoning string   `json:"reasoning"`
+}
+
+// moduleDriver explains why a module is part of a composed recommendation.
+type moduleDriver struct {
+	Module  string `json:"module"`
+	Pattern string `json:"pattern,omitempty"`
+	// Requirements are the requirement terms that call for the module.
+	Requirements []string `json:"requirements,omitempty"`
+	Reason       string   `json:"reason"`
+}
+
+// moduleSignals are the requirement terms that call for a module directly,
+// independent of the pattern that suggested it. They decide between
+// conflicting modules and explain each module of a composition.
+var moduleSignals = map[string][]string{
+	config.ModuleAPI:            {"rest", "api", "endpoint", "endpoints", "http", "crud", "web service"},
+	config.ModuleWorker:         {"background", "job", "jobs", "worker", "workers", "scheduled", "cron", "batch", "delayed", "fire-and-forget", "etl", "pipeline", "ingestion"},
+	config.ModuleEventConsumer:  {"event", "events", "kafka", "rabbitmq", "sqs", "pubsub", "pub/sub", "nats", "redis streams", "message", "messages", "streaming", "cqrs", "consumer", "consumers", "consume"},
+	config.ModuleSQLDatastore:   {"sql", "postgresql", "postgres", "mysql", "mariadb", "relational", "database", "transactional"},
+	config.ModuleNoSQLDatastore: {"nosql", "mongodb", "mongo", "redis", "dynamodb", "cassandra", "document store", "flexible schema", "wide-column"},
+	config.ModuleSearch:         {"full-text", "full text", "opensearch", "elasticsearch", "fuzzy", "search engine"},
+	config.ModuleAIAgent:        {"ai", "agent", "llm", "chatbot", "rag", "claude", "tool calling", "knowledge base"},
+}
+
+// Datastores and brokers named in the requirements win over pattern defaults.
+var (
+	databaseMentions = []struct{ term, value string }{
+		{"mariadb", config.DatabaseMariaDB},
+		{"mysql", config.DatabaseMySQL},
+		{"postgres", config.DatabasePostgreSQL},
+	}
+	nosqlMentions = []struct{ term, value string }{
+		{"mongo", config.DatabaseMongoDB},
+		{"redis", config.DatabaseRedis},
+		{"dynamodb", config.DatabaseDynamoDB},
+		{"cassandra", config.DatabaseCassandra},
+	}
+	brokerMentions = []struct{ term, value string }{
+		{"kafka", config.BrokerKafka},
+		{"rabbitmq", config.BrokerRabbitMQ},
+		{"sqs", config.BrokerSQS},
+		{"pubsub", config.BrokerPubSub},
+		{"pub/sub", config.BrokerPubSub},
+		{"redis streams", config.BrokerRedisStreams},
+		{"nats", config.BrokerNATS},
+	}
+)
+
+// moduleSignalsIn returns the signal terms of module found as whole words
+// in lower.
+func moduleSignalsIn(module, lower string) []string {
+	var found []string
+	for _, term := range moduleSignals[module] {
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(term) + `\b`).MatchString(lower) {
+			found = append(found, term)
+		}
+	}
+	return found
+}
+
+// buildComposedRecommendation merges the modules of the patterns that
+// scored at least MinRecommendScore. Of two conflicting modules
+// (SQLDatastore vs NoSQLDatastore) the composition keeps the one the
+// requirements name, then the one the merged patterns back with more score.
+// Returns nil unless two or more patterns contribute and the merge
+// differs from the top pattern alone — recommended_config covers that case.
+func buildComposedRecommendation(requirements string, patterns []scoredPattern, table scoringTable) *composedRecommendation {
+	var merged []scoredPattern
+	for _, p := range patterns {
+		if p.Score >= table.MinRecommendScore {
+			merged = append(merged, p)
+		}
+	}
+	if len(merged) < 2 {
+		return nil
+	}
+	lower := strings.ToLower(requirements)
+
+	// The module's first (best-scoring) pattern and the score backing it
+	origin := map[string]string{}
+	backing := map[string]int{}
+	var candidates []string
+	for _, p := range merged {
+		for _, m := range p.Modules {
+			if _, seen := origin[m]; !seen {
+				origin[m] = p.Name
+				candidates = append(candidates, m)
+			}
+			backing[m] += p.Score
+		}
+	}
+
+	rank := map[string]int{}
+	for i, m := range candidates {
+		rank[m] = i
+	}
+	chosen := map[string]bool{}
+	var excluded []string
+	for _, m := range candidates {
+		chosen[m] = true
+		if mod := config.GetModule(m); mod != nil {
+			for _, c := range mod.ConflictsWith {
+				if _, suggested := origin[c]; suggested && !winsConflict(m, c, lower, backing, rank) {
+					delete(chosen, m)
+					excluded = append(excluded, fmt.Sprintf("%s (from '%s') conflicts with %s", m, origin[m], c))
+					break
+				}
+			}
+		}
+	}
+
+	// Credit each module to the best pattern none of whose modules were
+	// left out, so a pattern that lost a conflict only explains what else
+	// nobody suggested.
+	for _, m := range candidates {
+		for _, p := range merged {
+			if slices.Contains(p.Modules, m) && !slices.ContainsFunc(p.Modules, func(o string) bool { _, ok := origin[o]; return ok && !chosen[o] }) {
+				origin[m] = p.Name
+				break
+			}
+		}
+	}
+
+	var modules []string
+	for _, m := range config.ResolveDependencies(mapKeys(chosen)) {
+		if mod := config.GetModule(m); mod != nil && !mod.Internal {
+			modules = append(modules, m)
+		}
+	}
+	if strings.Join(modules, ",") == strings.Join(config.ResolveDependencies(merged[0].Modules), ",") {
+		return nil
+	}
+
+	rec := &composedRecommendation{
+		Modules:  strings.Join(modules, ","),
+		Excluded: excluded,
+	}
+	contributed := map[string]bool{}
+	for _, m := range modules {
+		driver := moduleDriver{Module: m, Pattern: origin[m]}
+		mod := config.GetModule(m)
+		switch {
+		case mod != nil && mod.Required:
+			driver.Pattern = ""
+			driver.Reason = "Always required"
+		case len(moduleSignalsIn(m, lower)) > 0:
+			driver.Requirements = moduleSignalsIn(m, lower)
+			driver.Reason = "Requirements mention " + strings.Join(driver.Requirements, ", ")
+		case m == config.ModuleShared:
+			driver.Reason = "Shared services and configuration for the API, Worker and EventConsumer modules"
+		default:
+			driver.Reason = fmt.Sprintf("Part of the '%s' pattern", origin[m])
+		}
+		if driver.Pattern != "" {
+			contributed[driver.Pattern] = true
+		}
+		rec.Drivers = append(rec.Drivers, driver)
+	}
+	for _, p := range merged {
+		if contributed[p.Name] {
+			rec.Patterns = append(rec.Patterns, p.Name)
+		}
+	}
+
+	var choices []string
+	if chosen[config.ModuleSQLDatastore] {
+		rec.Database, choices = pickOption(lower, databaseMentions, merged, func(p scoredPattern) string { return p.RecommendedDB }, config.DatabasePostgreSQL, "database", choices)
+	}
+	if chosen[config.ModuleNoSQLDatastore] {
+		rec.NoSQLDatabase, choices = pickOption(lower, nosqlMentions, merged, func(p scoredPattern) string { return p.RecommendedNoDB }, config.DatabaseMongoDB, "NoSQL database", choices)
+	}
+	if chosen[config.ModuleEventConsumer] {
+		rec.MessageBroker, choices = pickOption(lower, brokerMentions, merged, func(p scoredPattern) string { return p.RecommendedBrkr }, config.BrokerKafka, "message broker", choices)
+	}
+	if chosen[config.ModuleAIAgent] && chosen[config.ModuleSQLDatastore] {
+		for _, p := range merged {
+			if p.RecommendedVector != "" {
+				rec.VectorStore = p.RecommendedVector
+				choices = append(choices, fmt.Sprintf("vector store %s from '%s'", p.RecommendedVector, p.Name))
+				break
+			}
+		}
+	}
+
+	rec.Reasoning = "Merges the '" + strings.Join(rec.Patterns, "', '") + "' patterns"
+	if len(choices) > 0 {
+		rec.Reasoning += "; " + strings.Join(choices, "; ")
+	}
+	if len(excluded) > 0 {
+		rec.Reasoning += "; left out " + strings.Join(excluded, ", ")
+	}
+	return rec
+}
+
+// winsConflict reports whether module a wins over its conflicting module
+// b: the one the requirements name wins, then the one backed by more
+// pattern score, then the one the better pattern suggested first.
+func winsConflict(a, b, lower string, backing, rank map[string]int) bool {
+	aNamed, bNamed := len(moduleSignalsIn(a, lower)) > 0, len(moduleSignalsIn(b, lower)) > 0
+	if aNamed != bNamed {
+		return aNamed
+	}
+	if backing[a] != backing[b] {
+		return backing[a] > backing[b]
+	}
+	return rank[a] < rank[b]
+}
+
+// pickOption chooses a datastore or broker value: one named in the
+// requirements, else the best pattern's recommendation, else fallback. The
+// explanation of the choice is appended to choices.
+func pickOption(lower string, mentions []struct{ term, value string }, patterns []scoredPattern, recommended func(scoredPattern) string, fallback, label string, choices []string) (string, []string) {
+	for _, m := range mentions {
+		if strings.Contains(lower, m.term) {
+			return m.value, append(choices, fmt.Sprintf("%s %s named in the requirements", label, m.value))
+		}
+	}
+	for _, p := range patterns {
+		if v := recommended(p); v != "" {
+			return v, append(choices, fmt.Sprintf("%s %s recommended by '%s'", label, v, p.Name))
+		}
+	}
+	return fallback, append(choices, fmt.Sprintf("%s %s by default", label, fallback))
+}
+
+func mapKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func composedFor(t *testing.T, requirements string) *composedRecommendation {
+	t.Helper()
+	return buildComposedRecommendation(requirements, scorePatternsWith(requirements, defaultScoring()), defaultScoring())
+}
+
+func driverOf(rec *composedRecommendation, module string) *moduleDriver {
+	for i := range rec.Drivers {
+		if rec.Drivers[i].Module == module {
+			return &rec.Drivers[i]
+		}
+	}
+	return nil
+}
+
+func TestComposed_APIWorkerAndEventConsumer(t *testing.T) {
+	// rest-api-nosql scores highest on "rest api" alone, but two patterns
+	// back SQLDatastore and nothing asks for NoSQL
+	rec := composedFor(t, "REST API with background jobs and Kafka event consumers")
+	if rec == nil {
+		t.Fatal("Expected a composed recommendation")
+	}
+	if rec.Modules != "Model,SQLDatastore,Shared,API,Worker,EventConsumer" {
+		t.Errorf("Unexpected modules %s", rec.Modules)
+	}
+	if rec.Database != "postgresql" || rec.MessageBroker != "kafka" || rec.NoSQLDatabase != "" {
+		t.Errorf("Unexpected datastore/broker: %+v", rec)
+	}
+	if len(rec.Excluded) != 1 || !strings.HasPrefix(rec.Excluded[0], "NoSQLDatastore") {
+		t.Errorf("Expected NoSQLDatastore to be left out, got %v", rec.Excluded)
+	}
+	if strings.Join(rec.Patterns, ",") != "event-driven,background-processing" {
+		t.Errorf("Expected the patterns that contributed, got %v", rec.Patterns)
+	}
+	if d := driverOf(rec, "Worker"); d == nil || d.Pattern != "background-processing" || strings.Join(d.Requirements, ",") != "background,jobs" {
+		t.Errorf("Unexpected Worker driver %+v", d)
+	}
+	if d := driverOf(rec, "EventConsumer"); d == nil || !strings.Contains(d.Reason, "kafka") {
+		t.Errorf("Unexpected EventConsumer driver %+v", d)
+	}
+}
+
+func TestComposed_NamedDatastoreWinsConflict(t *testing.T) {
+	rec := composedFor(t, "REST API with MongoDB and scheduled cron jobs")
+	if rec == nil {
+		t.Fatal("Expected a composed recommendation")
+	}
+	if rec.Modules != "Model,NoSQLDatastore,Shared,API,Worker" || rec.NoSQLDatabase != "mongodb" || rec.Database != "" {
+		t.Errorf("Expected MongoDB with Worker, got %+v", rec)
+	}
+	if len(rec.Excluded) != 1 || !strings.HasPrefix(rec.Excluded[0], "SQLDatastore") {
+		t.Errorf("Expected SQLDatastore to be left out, got %v", rec.Excluded)
+	}
+}
+
+func TestComposed_NamedBrokerAndDatabase(t *testing.T) {
+	rec := composedFor(t, "Order service: REST endpoints, nightly batch jobs, and consume events from RabbitMQ on MySQL")
+	if rec == nil {
+		t.Fatal("Expected a composed recommendation")
+	}
+	if rec.MessageBroker != "rabbitmq" || rec.Database != "mysql" {
+		t.Errorf("Expected rabbitmq and mysql from the requirements, got %s/%s", rec.MessageBroker, rec.Database)
+	}
+	if !strings.Contains(rec.Reasoning, "message broker rabbitmq named in the requirements") {
+		t.Errorf("Reasoning should explain the broker choice: %s", rec.Reasoning)
+	}
+}
+
+func TestComposed_NilForSinglePattern(t *testing.T) {
+	for _, req := range []string{"I need a REST API with PostgreSQL", "Kafka event streaming with async message processing", "asdf qwerty"} {
+		if rec := composedFor(t, req); rec != nil {
+			t.Errorf("%q: expected no composition, got %s", req, rec.Modules)
+		}
+	}
+}
+
+func TestWinsConflict_IsAntisymmetric(t *testing.T) {
+	backing := map[string]int{"SQLDatastore": 30, "NoSQLDatastore": 30}
+	rank := map[string]int{"NoSQLDatastore": 1, "SQLDatastore": 2}
+	a := winsConflict("SQLDatastore", "NoSQLDatastore", "rest api", backing, rank)
+	b := winsConflict("NoSQLDatastore", "SQLDatastore", "rest api", backing, rank)
+	if a == b {
+		t.Errorf("Exactly one side must win a tie, got %v/%v", a, b)
+	}
+}
+
+func TestBuildAdvisory_IncludesComposedRecommendation(t *testing.T) {
+	advisory := buildAdvisory("REST API with background workers and Kafka event consumers")
+	if advisory.ComposedRecommendation == nil {
+		t.Fatal("Expected composed_recommendation for a combined request")
+	}
+	if advisory := buildAdvisory("I need a REST API with PostgreSQL"); advisory.ComposedRecommendation != nil {
+		t.Error("Expected no composed_recommendation when one pattern covers the requirements")
+	}
+}
@@ -33,11 +33,11 @@ func registerTrabucoExpert(s *server.MCPServer) {
 TRABUCO MODULE DECISION TREE:
 1. Does the user need HTTP endpoints? → Add API
 2. Does the user need SQL persistence? → Add SQLDatastore (pick postgresql or mysql)
-3. Does the user need NoSQL persistence? → Add NoSQLDatastore (pick mongodb or redis)
+3. Does the user need NoSQL persistence? → Add NoSQLDatastore (pick mongodb, redis, dynamodb or cassandra)
    - SQLDatastore and NoSQLDatastore are MUTUALLY EXCLUSIVE
 4. Does the user need business logic orchestration? → Add Shared
 5. Does the user need background jobs? → Add Worker (uses SQL database for job storage)
-6. Does the user need message broker consumers? → Add EventConsumer (pick kafka, rabbitmq, sqs, or pubsub)
+6. Does the user need message broker consumers? → Add EventConsumer (pick kafka, rabbitmq, sqs, pubsub, redis-streams, or nats)
 7. Does the user need AI/LLM capabilities? → Add AIAgent (tool calling, guardrails, multi-agent, MCP server, A2A)
 8. Does the user need vector search / RAG? → Add AIAgent + pass --vector-store=pgvector|qdrant|mongodb
    - pgvector: same Postgres datastore (auto-adds SQLDatastore + forces postgresql)
@@ -50,7 +50,7 @@ COMMON PITFALLS TO AVOID:
 - EventConsumer requires specifying a message_broker parameter
 - "events" in user requirements may mean webhooks (use API) not message broker consumers (EventConsumer)
 - "queue" may mean internal job queue (Worker) not external message queue (EventConsumer)
-- "cache" may mean application-level caching (not generated) not Redis data store (NoSQLDatastore)
+- "cache" usually means application-level caching (init_project cache=caffeine or cache=redis) not a Redis data store (NoSQLDatastore)
 
 WHAT TRABUCO GENERATES:
 - Multi-module Maven project with Spring Boot 3.4
@@ -228,9 +228,9 @@ STEP-BY-STEP EXTENSION GUIDE:
 
 2. DETERMINE WHICH MODULE TO ADD
    - Background jobs → Worker module (adds JobRunr)
-   - Message processing → EventConsumer module (needs a broker: kafka, rabbitmq, sqs, pubsub)
+   - Message processing → EventConsumer module (needs a broker: kafka, rabbitmq, sqs, pubsub, redis-streams, nats)
    - SQL persistence → SQLDatastore module (needs database: postgresql or mysql)
-   - NoSQL persistence → NoSQLDatastore module (needs nosql_database: mongodb or redis)
+   - NoSQL persistence → NoSQLDatastore module (needs nosql_database: mongodb, redis, dynamodb or cassandra)
    - REST endpoints → API module
    - Business logic → Shared module
 
@@ -13,6 +13,7 @@ func registerAllResources(s *server.MCPServer) {
 	registerModulesResource(s)
 	registerPatternsResource(s)
 	registerLimitationsResource(s)
+	registerProjectResource(s)
 }
 
 func registerModulesResource(s *server.MCPServer) {
@@ -0,0 +1,460 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/doctor"
+	"github.com/arianlopezc/Trabuco/internal/envcontract"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/arianlopezc/Trabuco/internal/todos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ProjectExplanation is the explain_project result
+type ProjectExplanation struct {
+	Project      string              `json:"project"`
+	Path         string              `json:"path"`
+	Architecture string              `json:"architecture"` // multi-module or modulith
+	Language     string              `json:"language"`
+	Summary      string              `json:"summary"`
+	Modules      []ModuleExplanation `json:"modules"`
+	DataFlows    []DataFlow          `json:"data_flows"`
+	StartHere    []ProjectFile       `json:"start_here"` // The classes to modify first
+	Files        FileOwnership       `json:"files"`
+}
+
+// ModuleExplanation describes one installed module
+type ModuleExplanation struct {
+	Name        string        `json:"name"`
+	Role        string        `json:"role"`
+	Directory   string        `json:"directory"`
+	BuildsOn    []string      `json:"builds_on,omitempty"`
+	UsedBy      []string      `json:"used_by,omitempty"`
+	DoesNotHold string        `json:"does_not_hold,omitempty"`
+	KeyClasses  []ProjectFile `json:"key_classes,omitempty"`
+}
+
+// ProjectFile is a project-relative path and why it matters
+type ProjectFile struct {
+	Path string `json:"path"`
+	Note string `json:"note"`
+}
+
+// DataFlow is how one kind of work travels through the modules
+type DataFlow struct {
+	ID        string     `json:"id"` // http_request, background_job or event
+	Title     string     `json:"title"`
+	Narrative string     `json:"narrative"`
+	Steps     []FlowStep `json:"steps"`
+}
+
+// FlowStep is one hop of a data flow
+type FlowStep struct {
+	Module    string `json:"module"`
+	Component string `json:"component"`
+	Action    string `json:"action"`
+}
+
+// FileOwnership splits the project's files by who owns them
+type FileOwnership struct {
+	Managed        []ProjectFile `json:"managed"`         // Rewritten by Trabuco; edits are lost
+	ManagedRegions []ProjectFile `json:"managed_regions"` // Edit outside the trabuco:begin/end markers
+	UpdatedByAdd   []ProjectFile `json:"updated_by_add"`  // Yours, but 'trabuco add' patches them after a backup
+	SafeToEdit     []ProjectFile `json:"safe_to_edit"`
+}
+
+// keyClass is a class worth knowing in a module, found by its file name
+type keyClass struct {
+	name, note string
+}
+
+// moduleKeyClasses are the generated classes each module is built around.
+// The Placeholder ones are the skeleton meant to be replaced first.
+var moduleKeyClasses = map[string][]keyClass{
+	config.ModuleModel: {
+		{"Placeholder", "The placeholder domain entity; rename it to your first entity"},
+		{"PlaceholderRecord", "The persisted form of the entity in SQLDatastore"},
+		{"PlaceholderDocument", "The persisted form of the entity in NoSQLDatastore"},
+		{"PlaceholderRequest", "The request body the API validates"},
+		{"PlaceholderResponse", "The response body the API returns"},
+		{"PlaceholderEvent", "The sealed event contract published and consumed"},
+		{"PlaceholderJobRequest", "The job contract enqueued by Jobs and run by Worker"},
+		{"ErrorCode", "The error codes exceptions carry to the API"},
+	},
+	config.ModuleSQLDatastore: {
+		{"PlaceholderRepository", "The Spring Data JDBC repository of the placeholder entity"},
+	},
+	config.ModuleNoSQLDatastore: {
+		{"PlaceholderDocumentRepository", "The repository of the placeholder document"},
+	},
+	config.ModuleSearch: {
+		{"PlaceholderSearchDocument", "The index mapping of the placeholder entity"},
+		{"PlaceholderSearchRepository", "The search repository queried by SearchService"},
+	},
+	config.ModuleShared: {
+		{"PlaceholderService", "The business logic; controllers, jobs and listeners call services like it"},
+		{"SearchService", "Keeps the search index in step with the datastore"},
+	},
+	config.ModuleAPI: {
+		{"PlaceholderController", "The placeholder REST endpoints"},
+		{"PlaceholderJobController", "The endpoints that enqueue jobs"},
+		{"EventController", "The endpoint that publishes events"},
+		{"GlobalExceptionHandler", "Turns exceptions into RFC 7807 problem details"},
+		{"SecurityConfig", "The authentication and authorization rules"},
+	},
+	config.ModuleJobs: {
+		{"PlaceholderJobService", "Enqueues and schedules the placeholder job"},
+	},
+	config.ModuleWorker: {
+		{"ProcessPlaceholderJobRequestHandler", "Runs the placeholder job"},
+		{"RecurringJobsConfig", "Registers the recurring jobs"},
+	},
+	config.ModuleEvents: {
+		{"EventPublisher", "Publishes the events to the broker"},
+	},
+	config.ModuleEventConsumer: {
+		{"PlaceholderEventListener", "Receives the placeholder events from the broker"},
+		{"PlaceholderEventProcessor", "Handles the placeholder events; the listener delegates to it"},
+	},
+	config.ModuleAIAgent: {
+		{"PrimaryAgent", "The agent answering requests"},
+		{"PlaceholderTools", "The placeholder tools the agent can call"},
+		{"KnowledgeBase", "The knowledge the agent retrieves from"},
+	},
+}
+
+func registerExplainProject(s *server.MCPServer) {
+	tool := mcp.NewTool("explain_project",
+		mcp.WithDescription(
+			"Explain an existing Trabuco project, assembled from its .trabuco.json and the module registry (no AI involved): "+
+				"a narrative summary, what each installed module does and which modules it builds on, the key classes of each module, "+
+				"how data flows from controller to service to repository and on to events and jobs, the classes to modify first, "+
+				"and which files Trabuco manages (rewritten, or merged through managed regions) versus the ones that are safe to edit. "+
+				"Use it before changing a project you did not generate, or to orient a new contributor.",
+		),
+		mcp.WithString("path",
+			mcp.Description("Path to the Trabuco project root"),
+			mcp.Required(),
+		),
+	)
+
+	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		absPath, err := resolvePath(req.GetString("path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+		}
+		explanation, err := explainProject(absPath)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		watchProject(absPath)
+		return toolJSON(explanation)
+	})
+}
+
+// explainProject builds the explanation of the project at root
+func explainProject(root string) (*ProjectExplanation, error) {
+	meta, err := config.LoadMetadata(root)
+	if err != nil {
+		meta, err = doctor.GetProjectMetadata(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read project at '%s': %v. Verify the path points to a Trabuco project root (should contain .trabuco.json or pom.xml)", root, err)
+		}
+	}
+	cfg := meta.ToProjectConfig()
+
+	e := &ProjectExplanation{
+		Project:      meta.ProjectName,
+		Path:         root,
+		Architecture: config.ArchitectureMultiModule,
+		Language:     "Java",
+		Modules:      []ModuleExplanation{},
+		DataFlows:    []DataFlow{},
+		StartHere:    []ProjectFile{},
+	}
+	if cfg.IsModulith() {
+		e.Architecture = config.ArchitectureModulith
+	}
+	if cfg.IsKotlin() {
+		e.Language = "Kotlin"
+	}
+
+	for _, name := range cfg.CodeOwnersModules() {
+		m := config.GetModule(name)
+		classes, err := findKeyClasses(root, cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		e.Modules = append(e.Modules, ModuleExplanation{
+			Name:        name,
+			Role:        m.UseCase,
+			Directory:   cfg.ModuleDir(name),
+			BuildsOn:    cfg.ModuleDependsOn(name),
+			UsedBy:      cfg.ModuleUsedBy(name),
+			DoesNotHold: m.DoesNotInclude,
+			KeyClasses:  classes,
+		})
+		for _, c := range classes {
+			if strings.Contains(filepath.Base(c.Path), "Placeholder") {
+				e.StartHere = append(e.StartHere, c)
+			}
+		}
+	}
+	if cfg.HasModule(config.ModuleSQLDatastore) {
+		migrations, err := listMigrations(root)
+		if err != nil {
+			return nil, err
+		}
+		if len(migrations) > 0 {
+			e.StartHere = append(e.StartHere, ProjectFile{
+				Path: migrations[len(migrations)-1],
+				Note: "The latest Flyway migration; the schema changes with the entity",
+			})
+		}
+	}
+
+	e.DataFlows = explainDataFlows(cfg)
+	e.Files = explainFileOwnership(root, cfg)
+	e.Summary = explainSummary(e, cfg)
+	return e, nil
+}
+
+// findKeyClasses returns the key classes of a module that exist in the
+// project. In a modulith they are the ones under the module's package.
+func findKeyClasses(root string, cfg *config.ProjectConfig, module string) ([]ProjectFile, error) {
+	wanted := moduleKeyClasses[module]
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+	src := filepath.Join(root, cfg.ModuleDir(module), "src", "main")
+	if !fileExists(src) {
+		return nil, nil
+	}
+	pkg := "/" + strings.ToLower(module) + "/"
+
+	found := map[string]string{}
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(d.Name())
+		if d.IsDir() || (ext != ".java" && ext != ".kt") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if cfg.IsModulith() && !strings.Contains(rel, pkg) {
+			return nil
+		}
+		name := strings.TrimSuffix(d.Name(), ext)
+		if _, ok := found[name]; !ok {
+			found[name] = rel
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %v", module, err)
+	}
+
+	var classes []ProjectFile
+	for _, c := range wanted {
+		if rel, ok := found[c.name]; ok {
+			classes = append(classes, ProjectFile{Path: rel, Note: c.note})
+		}
+	}
+	return classes, nil
+}
+
+// explainDataFlows traces the flows the installed modules take part in
+func explainDataFlows(cfg *config.ProjectConfig) []DataFlow {
+	flows := []DataFlow{}
+	has := cfg.HasModule
+
+	if has(config.ModuleAPI) {
+		steps := []FlowStep{
+			{config.ModuleAPI, "PlaceholderController", "receives the HTTP request and validates the PlaceholderRequest"},
+		}
+		if has(config.ModuleShared) {
+			steps = append(steps, FlowStep{config.ModuleShared, "PlaceholderService", "applies the business logic"})
+		}
+		switch {
+		case has(config.ModuleSQLDatastore):
+			steps = append(steps, FlowStep{config.ModuleSQLDatastore, "PlaceholderRepository", "reads and writes the PlaceholderRecord in " + cfg.Database})
+		case has(config.ModuleNoSQLDatastore):
+			steps = append(steps, FlowStep{config.ModuleNoSQLDatastore, "PlaceholderDocumentRepository", "reads and writes the PlaceholderDocument in " + cfg.NoSQLDatabase})
+		}
+		if has(config.ModuleSearch) {
+			steps = append(steps, FlowStep{config.ModuleShared, "SearchService", "keeps the OpenSearch index in step with the datastore"})
+		}
+		steps = append(steps, FlowStep{config.ModuleAPI, "PlaceholderController", "maps the result to a PlaceholderResponse"})
+		flows = append(flows, newDataFlow("http_request", "HTTP request", steps))
+	}
+
+	if has(config.ModuleJobs) {
+		var steps []FlowStep
+		if has(config.ModuleAPI) {
+			steps = append(steps, FlowStep{config.ModuleAPI, "PlaceholderJobController", "accepts the request to run the job"})
+		}
+		steps = append(steps, FlowStep{config.ModuleJobs, "PlaceholderJobService", "enqueues a PlaceholderJobRequest in JobRunr's job storage"})
+		if has(config.ModuleWorker) {
+			steps = append(steps, FlowStep{config.ModuleWorker, "ProcessPlaceholderJobRequestHandler", "picks the job up and runs it, retrying on failure"})
+			if has(config.ModuleShared) {
+				steps = append(steps, FlowStep{config.ModuleShared, "PlaceholderService", "does the work the handler delegates"})
+			}
+		}
+		flows = append(flows, newDataFlow("background_job", "Background job", steps))
+	}
+
+	if has(config.ModuleEvents) {
+		broker := config.MessageBrokerDisplayName(cfg.MessageBroker)
+		var steps []FlowStep
+		if has(config.ModuleAPI) {
+			steps = append(steps, FlowStep{config.ModuleAPI, "EventController", "accepts the request to publish the event"})
+		}
+		steps = append(steps, FlowStep{config.ModuleEvents, "EventPublisher", "publishes a PlaceholderEvent to " + broker})
+		if has(config.ModuleEventConsumer) {
+			steps = append(steps,
+				FlowStep{config.ModuleEventConsumer, "PlaceholderEventListener", "receives the event and skips duplicates"},
+				FlowStep{config.ModuleEventConsumer, "PlaceholderEventProcessor", "handles the event"},
+			)
+		}
+		flows = append(flows, newDataFlow("event", "Event", steps))
+	}
+	return flows
+}
+
+// newDataFlow returns a flow with its steps told as one sentence
+func newDataFlow(id, title string, steps []FlowStep) DataFlow {
+	parts := make([]string, len(steps))
+	for i, s := range steps {
+		parts[i] = fmt.Sprintf("%s (%s) %s", s.Component, s.Module, s.Action)
+	}
+	return DataFlow{
+		ID:        id,
+		Title:     title,
+		Narrative: strings.Join(parts, ", then ") + ".",
+		Steps:     steps,
+	}
+}
+
+// explainFileOwnership lists the files Trabuco manages that the project
+// has, and where the user's own code goes
+func explainFileOwnership(root string, cfg *config.ProjectConfig) FileOwnership {
+	var own FileOwnership
+	add := func(list *[]ProjectFile, path, note string) {
+		if fileExists(filepath.Join(root, filepath.FromSlash(path))) {
+			*list = append(*list, ProjectFile{Path: path, Note: note})
+		}
+	}
+
+	add(&own.Managed, config.MetadataFileName, "The project's configuration; change it through trabuco commands")
+	add(&own.Managed, config.HistoryFileName, "The log of the trabuco commands run on the project")
+	add(&own.Managed, todos.IndexFileName, "The index of the TODOs Trabuco generated")
+	add(&own.Managed, generator.LastOperationFile, "The summary of the last trabuco operation")
+	add(&own.Managed, envcontract.DocFileName, "Regenerated from the modules' configuration")
+	add(&own.Managed, envcontract.SchemaFileName, "Regenerated from the modules' configuration")
+	add(&own.Managed, ".github/workflows/ci.yml", "Regenerated by 'trabuco add'")
+	add(&own.Managed, ".devcontainer/devcontainer.json", "Regenerated by 'trabuco add'")
+	add(&own.Managed, "Makefile", "Regenerated by 'trabuco add'; put your own targets in Makefile.local")
+	add(&own.Managed, "Taskfile.yml", "Regenerated by 'trabuco add'")
+	add(&own.Managed, ".ai/prompts", "The AI agents' task guides, refreshed by 'trabuco add'")
+
+	add(&own.ManagedRegions, "README.md", "")
+	add(&own.ManagedRegions, "docs/errors.md", "")
+	if cfg.HasAnyAIAgent() {
+		add(&own.ManagedRegions, "AGENTS.md", "")
+	}
+	for _, agent := range cfg.GetSelectedAIAgents() {
+		if agent.FilePath != "AGENTS.md" {
+			add(&own.ManagedRegions, agent.FilePath, "")
+		}
+	}
+	if cfg.HasCodeOwners() {
+		for _, module := range cfg.CodeOwnersModules() {
+			add(&own.ManagedRegions, config.ModuleBoundaryDoc(module), "The boundary region is regenerated; write in Team notes")
+		}
+	}
+	for i := range own.ManagedRegions {
+		if own.ManagedRegions[i].Note == "" {
+			own.ManagedRegions[i].Note = "Regenerated by 'trabuco add' inside its managed regions only"
+		}
+	}
+
+	add(&own.UpdatedByAdd, "pom.xml", "Gets the new module and its dependencies")
+	add(&own.UpdatedByAdd, "docker-compose.yml", "Gets the services the new module needs")
+	add(&own.UpdatedByAdd, config.CodeOwnersFile, "Gets the new module's entries; the owners you set are kept")
+	if cfg.HasModule(config.ModuleModel) {
+		for _, c := range []string{"Placeholder", "PlaceholderResponse"} {
+			if f := findClass(root, cfg, config.ModuleModel, c); f != "" {
+				add(&own.UpdatedByAdd, f, "Regenerated when a datastore is added")
+			}
+		}
+	}
+	if f := findClass(root, cfg, config.ModuleShared, "PlaceholderService"); f != "" {
+		add(&own.UpdatedByAdd, f, "Regenerated when a datastore is added")
+	}
+
+	for _, module := range cfg.MavenModules() {
+		add(&own.SafeToEdit, module+"/src", "Your code; the generated classes are a starting point")
+	}
+	add(&own.SafeToEdit, "Makefile.local", "Your own make targets")
+	add(&own.SafeToEdit, ".trabuco/templates", "Overrides of the templates Trabuco generates from")
+	return own
+}
+
+// findClass returns the path of a module's source file with the given
+// class name, or "" when the project has none
+func findClass(root string, cfg *config.ProjectConfig, module, name string) string {
+	classes, err := findKeyClasses(root, cfg, module)
+	if err != nil {
+		return ""
+	}
+	for _, c := range classes {
+		base := filepath.Base(c.Path)
+		if strings.TrimSuffix(base, filepath.Ext(base)) == name {
+			return c.Path
+		}
+	}
+	return ""
+}
+
+// explainSummary tells the project in a few sentences
+func explainSummary(e *ProjectExplanation, cfg *config.ProjectConfig) string {
+	names := make([]string, len(e.Modules))
+	for i, m := range e.Modules {
+		names[i] = m.Name
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s is a %s Spring Boot project", e.Project, e.Language)
+	if cfg.IsModulith() {
+		b.WriteString(" built as one Spring Modulith application in App")
+	} else {
+		b.WriteString(" with a Maven module per Trabuco module")
+	}
+	fmt.Fprintf(&b, ". Its modules are %s.", strings.Join(names, ", "))
+	if cfg.Database != "" && cfg.HasModule(config.ModuleSQLDatastore) {
+		fmt.Fprintf(&b, " Data is stored in %s.", cfg.Database)
+	}
+	if cfg.NoSQLDatabase != "" && cfg.HasModule(config.ModuleNoSQLDatastore) {
+		fmt.Fprintf(&b, " Documents are stored in %s.", cfg.NoSQLDatabase)
+	}
+	if cfg.MessageBroker != "" && cfg.HasModule(config.ModuleEvents) {
+		fmt.Fprintf(&b, " Events travel over %s.", config.MessageBrokerDisplayName(cfg.MessageBroker))
+	}
+	for _, f := range e.DataFlows {
+		fmt.Fprintf(&b, " %s: %s", f.Title, f.Narrative)
+	}
+	if len(e.StartHere) > 0 {
+		b.WriteString(" Start with the Placeholder classes: they are the skeleton meant to be renamed or replaced with your domain.")
+	}
+	return b.String()
+}
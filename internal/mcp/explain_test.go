@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func hasFile(files []ProjectFile, path string) bool {
+	for _, f := range files {
+		if f.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExplainProject(t *testing.T) {
+	projectPath := generateTestProject(t, &config.ProjectConfig{
+		ProjectName:   "orders",
+		GroupID:       "com.test.orders",
+		ArtifactID:    "orders",
+		JavaVersion:   "21",
+		Modules:       config.ResolveDependencies([]string{"SQLDatastore", "Shared", "API", "Worker", "EventConsumer"}),
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+		AIAgents:      []string{"claude"},
+	})
+
+	e, err := explainProject(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Architecture != config.ArchitectureMultiModule || e.Language != "Java" {
+		t.Errorf("unexpected architecture/language: %s/%s", e.Architecture, e.Language)
+	}
+	var api *ModuleExplanation
+	for i := range e.Modules {
+		if e.Modules[i].Name == config.ModuleAPI {
+			api = &e.Modules[i]
+		}
+	}
+	if api == nil {
+		t.Fatalf("API missing from modules: %+v", e.Modules)
+	}
+	if api.Role == "" || api.Directory != "API" {
+		t.Errorf("unexpected API explanation: %+v", api)
+	}
+	controller := "API/src/main/java/com/test/orders/api/controller/PlaceholderController.java"
+	if !hasFile(api.KeyClasses, controller) {
+		t.Errorf("expected %s in API key classes, got %+v", controller, api.KeyClasses)
+	}
+	if !hasFile(e.StartHere, controller) {
+		t.Errorf("expected %s to start with, got %+v", controller, e.StartHere)
+	}
+
+	flows := map[string]DataFlow{}
+	for _, f := range e.DataFlows {
+		flows[f.ID] = f
+	}
+	for _, id := range []string{"http_request", "background_job", "event"} {
+		if _, ok := flows[id]; !ok {
+			t.Errorf("expected the %s flow, got %+v", id, e.DataFlows)
+		}
+	}
+	var components []string
+	for _, s := range flows["http_request"].Steps {
+		components = append(components, s.Component)
+	}
+	if got := strings.Join(components, " > "); got != "PlaceholderController > PlaceholderService > PlaceholderRepository > PlaceholderController" {
+		t.Errorf("unexpected HTTP flow: %s", got)
+	}
+	if !strings.Contains(flows["event"].Narrative, "Kafka") {
+		t.Errorf("expected the event flow to name the broker: %s", flows["event"].Narrative)
+	}
+
+	for _, f := range []string{config.MetadataFileName, "ENV.md"} {
+		if !hasFile(e.Files.Managed, f) {
+			t.Errorf("expected %s to be managed, got %+v", f, e.Files.Managed)
+		}
+	}
+	for _, f := range []string{"README.md", "AGENTS.md", "CLAUDE.md"} {
+		if !hasFile(e.Files.ManagedRegions, f) {
+			t.Errorf("expected %s to have managed regions, got %+v", f, e.Files.ManagedRegions)
+		}
+	}
+	if !hasFile(e.Files.SafeToEdit, "Shared/src") {
+		t.Errorf("expected Shared/src to be safe to edit, got %+v", e.Files.SafeToEdit)
+	}
+	if !strings.Contains(e.Summary, "orders is a Java Spring Boot project") {
+		t.Errorf("unexpected summary: %s", e.Summary)
+	}
+}
+
+func TestExplainProject_Modulith(t *testing.T) {
+	projectPath := generateTestProject(t, &config.ProjectConfig{
+		ProjectName:  "shop",
+		GroupID:      "com.test.shop",
+		ArtifactID:   "shop",
+		JavaVersion:  "21",
+		Modules:      config.ResolveDependencies([]string{"SQLDatastore", "API"}),
+		Database:     "postgresql",
+		Architecture: config.ArchitectureModulith,
+	})
+
+	e, err := explainProject(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Architecture != config.ArchitectureModulith {
+		t.Errorf("expected a modulith, got %s", e.Architecture)
+	}
+	for _, m := range e.Modules {
+		if m.Directory != config.ModulithModule {
+			t.Errorf("expected %s to live in App, got %s", m.Name, m.Directory)
+		}
+		for _, c := range m.KeyClasses {
+			if !strings.Contains(c.Path, "/"+strings.ToLower(m.Name)+"/") {
+				t.Errorf("%s key class outside its package: %s", m.Name, c.Path)
+			}
+		}
+	}
+	if !hasFile(e.Files.SafeToEdit, "App/src") {
+		t.Errorf("expected App/src to be safe to edit, got %+v", e.Files.SafeToEdit)
+	}
+}
+
+func TestExplainProject_NotAProject(t *testing.T) {
+	if _, err := explainProject(t.TempDir()); err == nil {
+		t.Fatal("expected an error for a directory that is not a Trabuco project")
+	}
+}
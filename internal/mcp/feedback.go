@@ -0,0 +1,400 @@
+package mcp
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// Recommendation feedback is opt-in (pattern_feedback: true in
+// ~/.trabuco/config.yaml) and never leaves the machine. suggest_architecture
+// records which pattern it recommended and which catalog keywords matched;
+// init records whether the generated modules were the recommended ones. No
+// requirement text, project name or path is stored, and timestamps are
+// truncated to the day.
+
+// recommendationRecord is one line of recommendations.jsonl.
+type recommendationRecord struct {
+	ID         string              `json:"id"`
+	Date       string              `json:"date"`
+	Pattern    string              `json:"pattern"`
+	Score      int                 `json:"score"`
+	Confidence string              `json:"confidence"`
+	Modules    []string            `json:"modules"`
+	Matched    map[string][]string `json:"matched"` // pattern → matched catalog keywords
+}
+
+// outcomeRecord is one line of outcomes.jsonl.
+type outcomeRecord struct {
+	ID            string   `json:"id"`
+	Date          string   `json:"date"`
+	Accepted      bool     `json:"accepted"`
+	ChosenPattern string   `json:"chosen_pattern,omitempty"` // catalog pattern with exactly the generated modules
+	Added         []string `json:"added,omitempty"`
+	Removed       []string `json:"removed,omitempty"`
+}
+
+// Tuning moves a keyword weight by tuneStep per net vote and needs at least
+// minTuneOutcomes recorded outcomes before it changes anything.
+const (
+	tuneStep        = 0.1
+	minTuneOutcomes = 5
+)
+
+// patternsDir returns the directory feedback and tuned weights are stored
+// in. It can be relocated with TRABUCO_PATTERNS_DIR.
+func patternsDir() string {
+	if dir := os.Getenv("TRABUCO_PATTERNS_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".trabuco", "patterns")
+}
+
+// FeedbackEnabled reports whether recommendation feedback is recorded.
+func FeedbackEnabled() bool {
+	uc, err := config.LoadUserConfig()
+	return err == nil && uc.PatternFeedback
+}
+
+// recordRecommendation stores the advisory's recommendation and returns
+// the id init passes back with its outcome. It returns "" when feedback is
+// off, nothing was recommended or the record could not be written —
+// feedback never fails the tool call.
+func recordRecommendation(advisory *architectureAdvisory, requirements string) string {
+	rec := advisory.RecommendedConfig
+	if rec == nil || len(advisory.Patterns) == 0 || !FeedbackEnabled() {
+		return ""
+	}
+	id, err := newRecommendationID()
+	if err != nil {
+		return ""
+	}
+	matched := map[string][]string{}
+	for _, p := range patternCatalog {
+		if kws := p.matchedKeywords(requirements); len(kws) > 0 {
+			matched[p.Name] = kws
+		}
+	}
+	top := advisory.Patterns[0]
+	record := recommendationRecord{
+		ID:         id,
+		Date:       time.Now().UTC().Format(time.DateOnly),
+		Pattern:    top.Name,
+		Score:      top.Score,
+		Confidence: rec.Confidence,
+		Modules:    config.ResolveDependencies(top.Modules),
+		Matched:    matched,
+	}
+	if err := appendJSONLine("recommendations.jsonl", record); err != nil {
+		return ""
+	}
+	return id
+}
+
+// RecordInitOutcome records whether a project generated after the
+// recommendation with the given id kept its modules. It does nothing when
+// feedback is off.
+func RecordInitOutcome(id string, modules []string) error {
+	if id == "" || !FeedbackEnabled() {
+		return nil
+	}
+	var rec *recommendationRecord
+	if err := readJSONLines("recommendations.jsonl", func(r recommendationRecord) {
+		if r.ID == id {
+			rec = &r
+		}
+	}); err != nil {
+		return err
+	}
+	if rec == nil {
+		return fmt.Errorf("unknown recommendation id %q", id)
+	}
+
+	chosen := config.ResolveDependencies(modules)
+	outcome := outcomeRecord{
+		ID:      id,
+		Date:    time.Now().UTC().Format(time.DateOnly),
+		Added:   moduleDiff(chosen, rec.Modules),
+		Removed: moduleDiff(rec.Modules, chosen),
+	}
+	outcome.Accepted = len(outcome.Added) == 0 && len(outcome.Removed) == 0
+	for _, p := range patternCatalog {
+		if sameModules(config.ResolveDependencies(p.Modules), chosen) {
+			outcome.ChosenPattern = p.Name
+			break
+		}
+	}
+	return appendJSONLine("outcomes.jsonl", outcome)
+}
+
+// PatternStats summarizes recorded recommendation feedback.
+type PatternStats struct {
+	Recommendations int                           `json:"recommendations"`
+	Outcomes        int                           `json:"outcomes"`
+	Accepted        int                           `json:"accepted"`
+	ByPattern       []PatternStat                 `json:"by_pattern"`
+	ByConfidence    []ConfidenceStat              `json:"by_confidence"`
+	Weights         map[string]map[string]float64 `json:"tuned_weights,omitempty"`
+}
+
+// PatternStat is the feedback of one recommended pattern.
+type PatternStat struct {
+	Pattern     string `json:"pattern"`
+	Recommended int    `json:"recommended"`
+	Outcomes    int    `json:"outcomes"`
+	Accepted    int    `json:"accepted"`
+}
+
+// ConfidenceStat is the acceptance of one confidence level — the
+// calibration check: "high" should be accepted more often than "low".
+type ConfidenceStat struct {
+	Confidence string `json:"confidence"`
+	Outcomes   int    `json:"outcomes"`
+	Accepted   int    `json:"accepted"`
+}
+
+// feedback is the joined content of the feedback files.
+type feedback struct {
+	recommendations map[string]recommendationRecord
+	outcomes        []outcomeRecord
+}
+
+func loadFeedback() (*feedback, error) {
+	fb := &feedback{recommendations: map[string]recommendationRecord{}}
+	if err := readJSONLines("recommendations.jsonl", func(r recommendationRecord) {
+		fb.recommendations[r.ID] = r
+	}); err != nil {
+		return nil, err
+	}
+	// A recommendation used for several inits counts once, with its
+	// latest outcome.
+	latest := map[string]int{}
+	if err := readJSONLines("outcomes.jsonl", func(o outcomeRecord) {
+		if _, ok := fb.recommendations[o.ID]; !ok {
+			return
+		}
+		if i, seen := latest[o.ID]; seen {
+			fb.outcomes[i] = o
+			return
+		}
+		latest[o.ID] = len(fb.outcomes)
+		fb.outcomes = append(fb.outcomes, o)
+	}); err != nil {
+		return nil, err
+	}
+	return fb, nil
+}
+
+// LoadPatternStats reads the recorded feedback and the tuned weights.
+func LoadPatternStats() (*PatternStats, error) {
+	fb, err := loadFeedback()
+	if err != nil {
+		return nil, err
+	}
+	stats := &PatternStats{
+		Recommendations: len(fb.recommendations),
+		Outcomes:        len(fb.outcomes),
+		Weights:         loadScoring().KeywordWeights,
+	}
+
+	byPattern := map[string]*PatternStat{}
+	patternStat := func(name string) *PatternStat {
+		if byPattern[name] == nil {
+			byPattern[name] = &PatternStat{Pattern: name}
+		}
+		return byPattern[name]
+	}
+	for _, r := range fb.recommendations {
+		patternStat(r.Pattern).Recommended++
+	}
+	byConfidence := map[string]*ConfidenceStat{}
+	for _, level := range []string{"high", "medium", "low"} {
+		byConfidence[level] = &ConfidenceStat{Confidence: level}
+	}
+	for _, o := range fb.outcomes {
+		r := fb.recommendations[o.ID]
+		ps := patternStat(r.Pattern)
+		ps.Outcomes++
+		cs, ok := byConfidence[r.Confidence]
+		if ok {
+			cs.Outcomes++
+		}
+		if o.Accepted {
+			stats.Accepted++
+			ps.Accepted++
+			if ok {
+				cs.Accepted++
+			}
+		}
+	}
+
+	for _, ps := range byPattern {
+		stats.ByPattern = append(stats.ByPattern, *ps)
+	}
+	sort.Slice(stats.ByPattern, func(i, j int) bool {
+		if stats.ByPattern[i].Recommended != stats.ByPattern[j].Recommended {
+			return stats.ByPattern[i].Recommended > stats.ByPattern[j].Recommended
+		}
+		return stats.ByPattern[i].Pattern < stats.ByPattern[j].Pattern
+	})
+	for _, level := range []string{"high", "medium", "low"} {
+		stats.ByConfidence = append(stats.ByConfidence, *byConfidence[level])
+	}
+	return stats, nil
+}
+
+// WeightChange is one keyword weight moved by tuning.
+type WeightChange struct {
+	Pattern string  `json:"pattern"`
+	Keyword string  `json:"keyword"`
+	Old     float64 `json:"old"`
+	New     float64 `json:"new"`
+}
+
+// TunePatternWeights derives keyword weights from the recorded outcomes
+// and, unless dryRun, saves them to weights.json. Keywords that matched an
+// accepted recommendation gain weight; keywords that matched a rejected
+// one lose it, and those of the pattern chosen instead gain it. Weights
+// are recomputed from all feedback on every run, so tuning is repeatable.
+func TunePatternWeights(dryRun bool) ([]WeightChange, error) {
+	fb, err := loadFeedback()
+	if err != nil {
+		return nil, err
+	}
+	if len(fb.outcomes) < minTuneOutcomes {
+		return nil, fmt.Errorf("need at least %d recorded outcomes to tune, have %d", minTuneOutcomes, len(fb.outcomes))
+	}
+
+	votes := map[string]map[string]int{}
+	vote := func(pattern string, keywords []string, delta int) {
+		if votes[pattern] == nil {
+			votes[pattern] = map[string]int{}
+		}
+		for _, kw := range keywords {
+			votes[pattern][kw] += delta
+		}
+	}
+	for _, o := range fb.outcomes {
+		r := fb.recommendations[o.ID]
+		if o.Accepted {
+			vote(r.Pattern, r.Matched[r.Pattern], 1)
+			continue
+		}
+		vote(r.Pattern, r.Matched[r.Pattern], -1)
+		if o.ChosenPattern != "" && o.ChosenPattern != r.Pattern {
+			vote(o.ChosenPattern, r.Matched[o.ChosenPattern], 1)
+		}
+	}
+
+	table := loadScoring()
+	tuned := map[string]map[string]float64{}
+	var changes []WeightChange
+	for _, p := range patternCatalog {
+		for _, kw := range p.keywords {
+			w := 1 + tuneStep*float64(votes[p.Name][kw])
+			w = math.Round(math.Max(minKeywordWeight, math.Min(maxKeywordWeight, w))*100) / 100
+			if w != 1 {
+				if tuned[p.Name] == nil {
+					tuned[p.Name] = map[string]float64{}
+				}
+				tuned[p.Name][kw] = w
+			}
+			if old := table.weight(p.Name, kw); old != w {
+				changes = append(changes, WeightChange{Pattern: p.Name, Keyword: kw, Old: old, New: w})
+			}
+		}
+	}
+	if dryRun {
+		return changes, nil
+	}
+	table.KeywordWeights = tuned
+	if err := saveScoring(table); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// ResetPatternWeights deletes the tuned scoring table, restoring the
+// defaults. Recorded feedback is kept.
+func ResetPatternWeights() error {
+	if err := os.Remove(weightsPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func newRecommendationID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// moduleDiff returns the modules in a that are not in b.
+func moduleDiff(a, b []string) []string {
+	var diff []string
+	for _, m := range a {
+		if !slices.Contains(b, m) {
+			diff = append(diff, m)
+		}
+	}
+	return diff
+}
+
+func sameModules(a, b []string) bool {
+	return len(moduleDiff(a, b)) == 0 && len(moduleDiff(b, a)) == 0
+}
+
+func appendJSONLine(name string, v any) error {
+	dir := patternsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// readJSONLines decodes every line of the named feedback file into T and
+// passes it to fn. A missing file has no lines; malformed lines are skipped.
+func readJSONLines[T any](name string, fn func(T)) error {
+	f, err := os.Open(filepath.Join(patternsDir(), name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var v T
+		if json.Unmarshal(scanner.Bytes(), &v) == nil {
+			fn(v)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// enableFeedback points the patterns directory and the user config at temp
+// locations, with pattern_feedback switched on when enabled.
+func enableFeedback(t *testing.T, enabled bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := filepath.Join(dir, "config.yaml")
+	content := "pattern_feedback: false\n"
+	if enabled {
+		content = "pattern_feedback: true\n"
+	}
+	if err := os.WriteFile(cfg, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TRABUCO_CONFIG", cfg)
+	t.Setenv("TRABUCO_PATTERNS_DIR", filepath.Join(dir, "patterns"))
+	return filepath.Join(dir, "patterns")
+}
+
+func TestWeightedScore_DefaultsMatchOriginalScoring(t *testing.T) {
+	enableFeedback(t, false)
+	for _, req := range []string{
+		"I need a REST API with PostgreSQL",
+		"Kafka event streaming with async message processing",
+		"nothing relevant here",
+	} {
+		for _, p := range patternCatalog {
+			if got, want := p.weightedScore(req, defaultScoring()), p.matchScore(req); got != want {
+				t.Errorf("%s on %q: weighted %d, original %d", p.Name, req, got, want)
+			}
+		}
+	}
+}
+
+func TestWeightedScore_TunedWeightsChangeRanking(t *testing.T) {
+	p := findPattern("rest-api")
+	table := defaultScoring()
+	base := p.weightedScore("REST API with MongoDB", table)
+	table.KeywordWeights = map[string]map[string]float64{"rest-api": {"rest": 2, "api": 2}}
+	if tuned := p.weightedScore("REST API with MongoDB", table); tuned <= base {
+		t.Errorf("heavier matched keywords should raise the score: %d → %d", base, tuned)
+	}
+}
+
+func TestRecordRecommendation_DisabledByDefault(t *testing.T) {
+	dir := enableFeedback(t, false)
+	advisory := buildAdvisory("REST API with PostgreSQL database")
+	if id := recordRecommendation(advisory, "REST API with PostgreSQL database"); id != "" {
+		t.Errorf("feedback off should record nothing, got id %q", id)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("feedback off should not create the patterns directory")
+	}
+}
+
+func TestRecordInitOutcome_Stats(t *testing.T) {
+	enableFeedback(t, true)
+	req := "REST API with PostgreSQL database"
+	advisory := buildAdvisory(req)
+	id := recordRecommendation(advisory, req)
+	if id == "" {
+		t.Fatal("expected a recommendation id")
+	}
+	if err := RecordInitOutcome(id, advisory.Patterns[0].Modules); err != nil {
+		t.Fatal(err)
+	}
+	rejected := recordRecommendation(advisory, req)
+	if err := RecordInitOutcome(rejected, []string{"Model", "Jobs", "Worker"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordInitOutcome("nope", []string{"Model"}); err == nil {
+		t.Error("an unknown id should be an error")
+	}
+
+	stats, err := LoadPatternStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Recommendations != 2 || stats.Outcomes != 2 || stats.Accepted != 1 {
+		t.Errorf("expected 2 recommendations, 2 outcomes, 1 accepted; got %+v", stats)
+	}
+	if len(stats.ByPattern) != 1 || stats.ByPattern[0].Pattern != "rest-api" {
+		t.Errorf("expected rest-api stats, got %+v", stats.ByPattern)
+	}
+
+	// Re-initialising from the same recommendation replaces its outcome.
+	if err := RecordInitOutcome(rejected, advisory.Patterns[0].Modules); err != nil {
+		t.Fatal(err)
+	}
+	stats, _ = LoadPatternStats()
+	if stats.Outcomes != 2 || stats.Accepted != 2 {
+		t.Errorf("latest outcome should win, got %d outcomes, %d accepted", stats.Outcomes, stats.Accepted)
+	}
+}
+
+func TestTunePatternWeights(t *testing.T) {
+	dir := enableFeedback(t, true)
+	req := "REST API with PostgreSQL database"
+	advisory := buildAdvisory(req)
+
+	id := recordRecommendation(advisory, req)
+	RecordInitOutcome(id, advisory.Patterns[0].Modules)
+	if _, err := TunePatternWeights(false); err == nil {
+		t.Fatal("tuning should need more outcomes")
+	}
+	for i := 1; i < minTuneOutcomes; i++ {
+		id := recordRecommendation(advisory, req)
+		RecordInitOutcome(id, advisory.Patterns[0].Modules)
+	}
+
+	changes, err := TunePatternWeights(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) == 0 {
+		t.Fatal("accepted recommendations should raise keyword weights")
+	}
+	for _, c := range changes {
+		if c.Pattern != "rest-api" || c.New <= c.Old || c.New > maxKeywordWeight {
+			t.Errorf("unexpected change %+v", c)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "weights.json")); !os.IsNotExist(err) {
+		t.Error("dry run should not save weights")
+	}
+
+	if _, err := TunePatternWeights(false); err != nil {
+		t.Fatal(err)
+	}
+	if w := loadScoring().weight("rest-api", "rest"); w <= 1 {
+		t.Errorf("expected a tuned weight above 1 for rest, got %v", w)
+	}
+	again, _ := TunePatternWeights(false)
+	if len(again) != 0 {
+		t.Errorf("tuning twice on the same feedback should change nothing, got %+v", again)
+	}
+
+	if err := ResetPatternWeights(); err != nil {
+		t.Fatal(err)
+	}
+	if w := loadScoring().weight("rest-api", "rest"); w != 1 {
+		t.Errorf("reset should restore weight 1, got %v", w)
+	}
+}
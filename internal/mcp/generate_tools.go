@@ -0,0 +1,250 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/addgen"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerGenerateCommandTools registers the MCP side of `trabuco generate`
+// for code: the same generators as the CLI, with the fields as structured
+// objects instead of a "name:type" string an agent has to get right.
+func registerGenerateCommandTools(s *server.MCPServer) {
+	registerGenerateEntity(s)
+	registerGenerateEndpoint(s)
+	registerGenerateJob(s)
+	registerGenerateEvent(s)
+}
+
+// withFields declares an array of field objects. Each one becomes a
+// ParseFields entry, so the tools validate names and types like the CLI.
+func withFields(name, description string, opts ...mcp.PropertyOption) mcp.ToolOption {
+	types := make([]string, len(addgen.FieldTypes))
+	for i, t := range addgen.FieldTypes {
+		types[i] = string(t)
+	}
+	opts = append([]mcp.PropertyOption{
+		mcp.Description(description),
+		mcp.Items(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{
+					"type":        "string",
+					"description": "camelCase Java field name, e.g. customerId",
+				},
+				"type": map[string]any{
+					"type": "string",
+					"enum": types,
+				},
+				"enum": map[string]any{
+					"type":        "string",
+					"description": "PascalCase enum class name; required when type is enum",
+				},
+				"nullable": map[string]any{
+					"type":        "boolean",
+					"description": "Whether the field may be null (default false)",
+				},
+			},
+			"required": []string{"name", "type"},
+		}),
+	}, opts...)
+	return mcp.WithArray(name, opts...)
+}
+
+// fieldsSpec turns the field objects of argument key into a ParseFields
+// spec. A plain "name:type,..." string is passed through unchanged.
+func fieldsSpec(req mcp.CallToolRequest, key string) (string, error) {
+	raw, ok := req.GetArguments()[key]
+	if !ok || raw == nil {
+		return "", nil
+	}
+	if spec, ok := raw.(string); ok {
+		return spec, nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return "", fmt.Errorf("%s must be an array of {name, type, enum, nullable} objects", key)
+	}
+	specs := make([]string, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%s[%d] must be an object with name and type", key, i)
+		}
+		name, _ := obj["name"].(string)
+		typ, _ := obj["type"].(string)
+		if name == "" || typ == "" {
+			return "", fmt.Errorf("%s[%d] needs both name and type", key, i)
+		}
+		f := addgen.Field{Name: name, Type: addgen.FieldType(strings.ToLower(typ))}
+		f.EnumName, _ = obj["enum"].(string)
+		f.Nullable, _ = obj["nullable"].(bool)
+		specs = append(specs, f.Spec())
+	}
+	return strings.Join(specs, ","), nil
+}
+
+// --- entity ---
+
+func registerGenerateEntity(s *server.MCPServer) {
+	tool := mcp.NewTool("generate_entity",
+		mcp.WithDescription(
+			"Generate a persisted entity from structured fields: the Immutables interface in Model plus, in SQL "+
+				"projects, the JDBC record, repository and Flyway migration, or in NoSQL projects the Mongo "+
+				"document and repository. Enum fields get an enum stub. Same output as add_entity, which takes "+
+				"the fields as a string. Use dry_run=true first to see the files. Refuses to overwrite existing files.",
+		),
+		mcp.WithString("path", mcp.Description("Project root path"), mcp.Required()),
+		mcp.WithString("name", mcp.Description("PascalCase entity name, e.g. Order"), mcp.Required()),
+		withFields("fields", "Entity fields, e.g. [{name: customerId, type: uuid}, {name: notes, type: text, nullable: true}]", mcp.Required(), mcp.MinItems(1)),
+		mcp.WithString("module", mcp.Description("Force SQLDatastore or NoSQLDatastore (default: auto-detect)")),
+		mcp.WithString("table_name", mcp.Description("Override the auto-derived plural snake_case table/collection name")),
+		mcp.WithBoolean("dry_run", mcp.Description("Preview without writing to disk")),
+	)
+	s.AddTool(tool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fields, err := fieldsSpec(req, "fields")
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		ctx, errRes := loadAddCtx(req.GetString("path", ""), req.GetBool("dry_run", false))
+		if errRes != nil {
+			return errRes, nil
+		}
+		result, err := addgen.GenerateEntity(ctx, addgen.EntityOpts{
+			Name:      req.GetString("name", ""),
+			Fields:    fields,
+			Module:    req.GetString("module", ""),
+			TableName: req.GetString("table_name", ""),
+		})
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		return addResultJSON(result, ctx.DryRun)
+	})
+}
+
+// --- endpoint ---
+
+func registerGenerateEndpoint(s *server.MCPServer) {
+	tool := mcp.NewTool("generate_endpoint",
+		mcp.WithDescription(
+			"Generate a REST resource endpoint served under /api: request and response DTOs in Model, a controller "+
+				"with OpenAPI annotations and a MockMvc test in API, and a Shared service with a stub per operation "+
+				"(added to the service when it already exists). GET maps list and get-by-id, POST create, PUT replace, "+
+				"DELETE delete. Requires Model, Shared and API. Mirrors `trabuco generate endpoint`.",
+		),
+		mcp.WithString("path", mcp.Description("Project root path"), mcp.Required()),
+		mcp.WithString("resource_path", mcp.Description("Resource path, e.g. /orders or /order-items; mounted under /api"), mcp.Required()),
+		mcp.WithArray("methods",
+			mcp.Description("HTTP methods to map"),
+			mcp.WithStringEnumItems([]string{"GET", "POST", "PUT", "DELETE"}),
+			mcp.Required(),
+			mcp.MinItems(1),
+		),
+		mcp.WithString("name", mcp.Description("PascalCase resource name (default: singular of the path's last segment)")),
+		withFields("fields", "DTO fields (default a single name string field)"),
+		mcp.WithBoolean("dry_run", mcp.Description("Preview without writing to disk")),
+	)
+	s.AddTool(tool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fields, err := fieldsSpec(req, "fields")
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		absPath, err := resolvePath(req.GetString("path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+		}
+		dryRun := req.GetBool("dry_run", false)
+		result, err := generator.GenerateEndpoint(absPath, generator.EndpointOpts{
+			Path:    req.GetString("resource_path", ""),
+			Name:    req.GetString("name", ""),
+			Methods: req.GetStringSlice("methods", nil),
+			Fields:  fields,
+		}, dryRun)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		return addResultJSON(result, dryRun)
+	})
+}
+
+// --- job ---
+
+func registerGenerateJob(s *server.MCPServer) {
+	tool := mcp.NewTool("generate_job",
+		mcp.WithDescription(
+			"Generate a runnable JobRunr job: the JobRequest and base handler in Model, a Worker handler that "+
+				"completes with a TODO body plus its test, and an enqueue method on PlaceholderJobService. With a "+
+				"schedule, RecurringJobsConfig registers it as a recurring job; a scheduled job takes no payload. "+
+				"Requires Worker. Mirrors `trabuco generate job`.",
+		),
+		mcp.WithString("path", mcp.Description("Project root path"), mcp.Required()),
+		mcp.WithString("name", mcp.Description("PascalCase job name (verb-noun), e.g. SendDigest"), mcp.Required()),
+		withFields("payload", "JobRequest payload fields; leave out for a scheduled job"),
+		mcp.WithString("schedule", mcp.Description(`JobRunr cron expression, 5 fields or 6 with a single leading second, e.g. "0 0 8 * * *"`)),
+		mcp.WithBoolean("dry_run", mcp.Description("Preview without writing to disk")),
+	)
+	s.AddTool(tool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		payload, err := fieldsSpec(req, "payload")
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		absPath, err := resolvePath(req.GetString("path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+		}
+		dryRun := req.GetBool("dry_run", false)
+		result, err := generator.GenerateJob(absPath, generator.JobOpts{
+			Name:     req.GetString("name", ""),
+			Payload:  payload,
+			Schedule: req.GetString("schedule", ""),
+		}, dryRun)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		return addResultJSON(result, dryRun)
+	})
+}
+
+// --- event ---
+
+func registerGenerateEvent(s *server.MCPServer) {
+	tool := mcp.NewTool("generate_event",
+		mcp.WithDescription(
+			"Generate a broker event: the event record in Model (eventId, occurredAt, payload, create factory), "+
+				"a publish method on EventPublisher, and with EventConsumer a listener with retries and "+
+				"dead-lettering plus its test. SQS queues and Pub/Sub topics are added to the local init scripts. "+
+				"Requires Events. Mirrors `trabuco generate event`.",
+		),
+		mcp.WithString("path", mcp.Description("Project root path"), mcp.Required()),
+		mcp.WithString("name", mcp.Description("PascalCase event name, e.g. OrderShipped"), mcp.Required()),
+		mcp.WithString("topic", mcp.Description("Broker topic or queue name: lowercase letters, digits and hyphens, e.g. orders"), mcp.Required()),
+		withFields("fields", "Event payload fields"),
+		mcp.WithBoolean("dry_run", mcp.Description("Preview without writing to disk")),
+	)
+	s.AddTool(tool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fields, err := fieldsSpec(req, "fields")
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		absPath, err := resolvePath(req.GetString("path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+		}
+		dryRun := req.GetBool("dry_run", false)
+		result, err := generator.GenerateEvent(absPath, generator.EventOpts{
+			Name:   req.GetString("name", ""),
+			Topic:  req.GetString("topic", ""),
+			Fields: fields,
+		}, dryRun)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		return addResultJSON(result, dryRun)
+	})
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestGenerateTools(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{
+		ProjectName:   "orders",
+		GroupID:       "com.test.orders",
+		ArtifactID:    "orders",
+		JavaVersion:   "21",
+		Modules:       config.ResolveDependencies([]string{"SQLDatastore", "API", "Worker", "EventConsumer"}),
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+	}
+	gen, err := generator.NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	s := server.NewMCPServer("test", "0.0.0")
+	registerGenerateCommandTools(s)
+	call := func(tool string, args map[string]any) (string, bool) {
+		t.Helper()
+		args["path"] = projectPath
+		data, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+			"params": map[string]any{"name": tool, "arguments": args},
+		})
+		result := s.HandleMessage(context.Background(), data).(mcp.JSONRPCResponse).Result.(*mcp.CallToolResult)
+		return result.Content[0].(mcp.TextContent).Text, result.IsError
+	}
+	javaFile := func(module, rel string) string {
+		return filepath.Join(projectPath, module, "src/main/java/com/test/orders", strings.ToLower(module), rel)
+	}
+
+	fields := []any{
+		map[string]any{"name": "customerId", "type": "uuid"},
+		map[string]any{"name": "status", "type": "enum", "enum": "OrderStatus"},
+		map[string]any{"name": "notes", "type": "text", "nullable": true},
+	}
+	text, isErr := call("generate_entity", map[string]any{"name": "Invoice", "fields": fields, "dry_run": true})
+	if isErr {
+		t.Fatalf("generate_entity dry run failed: %s", text)
+	}
+	if !strings.Contains(text, `"dry_run":true`) || !strings.Contains(text, "InvoiceRecord.java") {
+		t.Errorf("the dry run should list the entity files:\n%s", text)
+	}
+	if _, err := os.Stat(javaFile("Model", "entities/Invoice.java")); !os.IsNotExist(err) {
+		t.Error("a dry run should not write the entity")
+	}
+
+	if text, isErr := call("generate_entity", map[string]any{"name": "Invoice", "fields": fields}); isErr {
+		t.Fatalf("generate_entity failed: %s", text)
+	}
+	entity, err := os.ReadFile(javaFile("Model", "entities/Invoice.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"UUID customerId()", "OrderStatus status()", "@Nullable"} {
+		if !strings.Contains(string(entity), want) {
+			t.Errorf("Invoice should contain %q", want)
+		}
+	}
+
+	if text, isErr := call("generate_job", map[string]any{"name": "SendDigest", "schedule": "0 0 8 * * *"}); isErr {
+		t.Fatalf("generate_job failed: %s", text)
+	}
+	if _, err := os.Stat(javaFile("Worker", "handler/SendDigestJobRequestHandler.java")); err != nil {
+		t.Errorf("generate_job should write the Worker handler: %v", err)
+	}
+
+	if text, isErr := call("generate_event", map[string]any{
+		"name": "InvoicePaid", "topic": "invoices",
+		"fields": []any{map[string]any{"name": "invoiceId", "type": "uuid"}},
+	}); isErr {
+		t.Fatalf("generate_event failed: %s", text)
+	}
+	if _, err := os.Stat(javaFile("Model", "events/InvoicePaid.java")); err != nil {
+		t.Errorf("generate_event should write the event record: %v", err)
+	}
+
+	if text, isErr := call("generate_endpoint", map[string]any{"resource_path": "/invoices", "methods": []any{"GET", "POST"}}); isErr {
+		t.Fatalf("generate_endpoint failed: %s", text)
+	}
+	if _, err := os.Stat(javaFile("API", "controller/InvoiceController.java")); err != nil {
+		t.Errorf("generate_endpoint should write the controller: %v", err)
+	}
+
+	text, isErr = call("generate_entity", map[string]any{"name": "Bad", "fields": []any{map[string]any{"name": "x", "type": "weird"}}})
+	if !isErr || !strings.Contains(text, "unknown type") {
+		t.Errorf("an unknown field type should be a tool error, got %s", text)
+	}
+	text, isErr = call("generate_entity", map[string]any{"name": "Bad", "fields": []any{map[string]any{"name": "x"}}})
+	if !isErr || !strings.Contains(text, "needs both name and type") {
+		t.Errorf("a field without a type should be a tool error, got %s", text)
+	}
+}
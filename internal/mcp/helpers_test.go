@@ -0,0 +1,24 @@
+package mcp
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+)
+
+// generateTestProject generates cfg into a temporary directory and returns
+// the project path.
+func generateTestProject(t *testing.T, cfg *config.ProjectConfig) string {
+	t.Helper()
+	projectPath := filepath.Join(t.TempDir(), cfg.ProjectName)
+	gen, err := generator.NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	return projectPath
+}
@@ -0,0 +1,309 @@
+package mcp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Languages suggest_architecture recognizes. Requirements in other languages
+// are scored as written.
+const (
+	languageEnglish    = "en"
+	languageSpanish    = "es"
+	languagePortuguese = "pt"
+)
+
+// requirementsLanguage reports the language detected in the requirements
+// and, when they were not English, the English text used for scoring.
+type requirementsLanguage struct {
+	Detected               string `json:"detected"`
+	Translated             bool   `json:"translated"`
+	NormalizedRequirements string `json:"normalized_requirements,omitempty"`
+}
+
+// stopwords are frequent function words that tell the languages apart.
+// Words shared by Spanish and Portuguese ("de", "que", "para") are left out.
+var stopwords = map[string][]string{
+	languageEnglish:    {"the", "and", "of", "to", "with", "for", "that", "is", "we", "need", "want", "should", "without", "an", "it"},
+	languageSpanish:    {"el", "los", "las", "y", "del", "en", "un", "una", "con", "sin", "necesito", "necesitamos", "quiero", "queremos", "hay", "debe", "tambien"},
+	languagePortuguese: {"os", "do", "da", "dos", "das", "e", "em", "um", "uma", "com", "sem", "preciso", "precisamos", "quero", "queremos", "deve", "tambem", "nao"},
+}
+
+// glossary maps Spanish and Portuguese requirement terms, accent-folded, to
+// the English keywords pattern scoring and the disambiguation rules look for.
+// Longer phrases are applied first, so "sin base de datos" wins over
+// "base de datos".
+var glossary = map[string]map[string]string{
+	languageSpanish: {
+		"base de datos":           "database",
+		"bases de datos":          "database",
+		"sin base de datos":       "no database",
+		"sin api":                 "no api",
+		"cola de mensajes":        "message queue",
+		"colas de mensajes":       "message queue",
+		"cola":                    "queue",
+		"colas":                   "queue",
+		"segundo plano":           "background",
+		"trabajo":                 "job",
+		"trabajos":                "jobs",
+		"tarea":                   "job",
+		"tareas":                  "jobs",
+		"trabajador":              "worker",
+		"trabajadores":            "worker",
+		"programada":              "scheduled",
+		"programadas":             "scheduled",
+		"programado":              "scheduled",
+		"programados":             "scheduled",
+		"diferido":                "delayed",
+		"diferidos":               "delayed",
+		"lote":                    "batch",
+		"lotes":                   "batch",
+		"evento":                  "event",
+		"eventos":                 "events",
+		"dirigido por eventos":    "event-driven",
+		"orientado a eventos":     "event-driven",
+		"mensaje":                 "message",
+		"mensajes":                "messages",
+		"asincrono":               "async",
+		"asincrona":               "async",
+		"asincronos":              "async",
+		"asincronas":              "async",
+		"consumidor":              "consumer",
+		"consumidores":            "consumer",
+		"flujo de eventos":        "event streaming",
+		"tiempo real":             "real-time",
+		"procesamiento de datos":  "data processing",
+		"importacion de datos":    "data import",
+		"ingesta":                 "ingestion",
+		"procesador":              "processor",
+		"microservicio":           "microservice",
+		"microservicios":          "microservice",
+		"puerta de enlace":        "gateway",
+		"ligero":                  "lightweight",
+		"agregacion":              "aggregation",
+		"enrutamiento":            "routing",
+		"documento":               "document",
+		"documentos":              "document",
+		"esquema flexible":        "flexible schema",
+		"servicio web":            "web service",
+		"servicio":                "service",
+		"servicios":               "services",
+		"backend completo":        "complete backend",
+		"todos los modulos":       "all modules",
+		"empresarial":             "enterprise",
+		"agente de ia":            "ai agent",
+		"agentes de ia":           "ai agent",
+		"agente":                  "agent",
+		"agentes":                 "agent",
+		"inteligencia artificial": "ai",
+		"ia":                      "ai",
+		"inteligente":             "intelligent",
+		"asistente":               "assistant",
+		"lenguaje natural":        "natural language",
+		"llamada a herramientas":  "tool calling",
+		"base de conocimiento":    "knowledge base",
+		"busqueda semantica":      "semantic search",
+		"busqueda vectorial":      "vector search",
+		"base de datos vectorial": "vector database",
+		"notificacion":            "notification",
+		"notificaciones":          "notification",
+		"notificar":               "notify",
+		"inicio de sesion":        "login",
+		"autenticacion":           "authentication",
+		"interfaz de usuario":     "ui",
+		"multiinquilino":          "multi-tenant",
+		"inquilino":               "tenant",
+		"inquilinos":              "tenant",
+		"limite de peticiones":    "rate limit",
+		"limitacion de velocidad": "rate limit",
+	},
+	languagePortuguese: {
+		"banco de dados":          "database",
+		"bancos de dados":         "database",
+		"base de dados":           "database",
+		"sem banco de dados":      "no database",
+		"sem api":                 "no api",
+		"fila de mensagens":       "message queue",
+		"filas de mensagens":      "message queue",
+		"fila de trabalhos":       "job queue",
+		"segundo plano":           "background",
+		"trabalho":                "job",
+		"trabalhos":               "jobs",
+		"tarefa":                  "job",
+		"tarefas":                 "jobs",
+		"trabalhador":             "worker",
+		"trabalhadores":           "worker",
+		"agendada":                "scheduled",
+		"agendadas":               "scheduled",
+		"agendado":                "scheduled",
+		"agendados":               "scheduled",
+		"programada":              "scheduled",
+		"programadas":             "scheduled",
+		"atrasado":                "delayed",
+		"lote":                    "batch",
+		"lotes":                   "batch",
+		"evento":                  "event",
+		"eventos":                 "events",
+		"orientado a eventos":     "event-driven",
+		"mensagem":                "message",
+		"mensagens":               "messages",
+		"assincrono":              "async",
+		"assincrona":              "async",
+		"assincronos":             "async",
+		"assincronas":             "async",
+		"consumidor":              "consumer",
+		"consumidores":            "consumer",
+		"fluxo de eventos":        "event streaming",
+		"tempo real":              "real-time",
+		"processamento de dados":  "data processing",
+		"importacao de dados":     "data import",
+		"ingestao":                "ingestion",
+		"processador":             "processor",
+		"microsservico":           "microservice",
+		"microsservicos":          "microservice",
+		"microservico":            "microservice",
+		"microservicos":           "microservice",
+		"leve":                    "lightweight",
+		"agregacao":               "aggregation",
+		"roteamento":              "routing",
+		"documento":               "document",
+		"documentos":              "document",
+		"esquema flexivel":        "flexible schema",
+		"servico web":             "web service",
+		"servico":                 "service",
+		"servicos":                "services",
+		"backend completo":        "complete backend",
+		"todos os modulos":        "all modules",
+		"empresarial":             "enterprise",
+		"agente de ia":            "ai agent",
+		"agentes de ia":           "ai agent",
+		"agente":                  "agent",
+		"agentes":                 "agent",
+		"inteligencia artificial": "ai",
+		"ia":                      "ai",
+		"inteligente":             "intelligent",
+		"assistente":              "assistant",
+		"linguagem natural":       "natural language",
+		"chamada de ferramentas":  "tool calling",
+		"base de conhecimento":    "knowledge base",
+		"busca semantica":         "semantic search",
+		"busca vetorial":          "vector search",
+		"banco de dados vetorial": "vector database",
+		"notificacao":             "notification",
+		"notificacoes":            "notification",
+		"notificar":               "notify",
+		"autenticacao":            "authentication",
+		"interface de usuario":    "ui",
+		"multilocatario":          "multi-tenant",
+		"locatario":               "tenant",
+		"locatarios":              "tenant",
+		"limite de requisicoes":   "rate limit",
+	},
+}
+
+// glossaryRule is one compiled glossary entry.
+type glossaryRule struct {
+	pattern *regexp.Regexp
+	english string
+}
+
+// glossaryRules holds each language's entries compiled longest-first, built
+// once at startup.
+var glossaryRules = compileGlossary()
+
+func compileGlossary() map[string][]glossaryRule {
+	rules := make(map[string][]glossaryRule, len(glossary))
+	for lang, terms := range glossary {
+		phrases := make([]string, 0, len(terms))
+		for phrase := range terms {
+			phrases = append(phrases, phrase)
+		}
+		sort.Slice(phrases, func(i, j int) bool {
+			if len(phrases[i]) != len(phrases[j]) {
+				return len(phrases[i]) > len(phrases[j])
+			}
+			return phrases[i] < phrases[j]
+		})
+		for _, phrase := range phrases {
+			rules[lang] = append(rules[lang], glossaryRule{
+				pattern: regexp.MustCompile(`\b` + regexp.QuoteMeta(phrase) + `\b`),
+				english: terms[phrase],
+			})
+		}
+	}
+	return rules
+}
+
+// accentFolder strips the diacritics Spanish and Portuguese use so the
+// wordlists match regardless of how the requirement was typed.
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "ê", "e",
+	"í", "i",
+	"ó", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+var wordPattern = regexp.MustCompile(`[a-z]+`)
+
+// detectLanguage returns the language whose stopwords occur most often in
+// text. Ties and texts without stopwords count as English, so English
+// requirements are never rewritten.
+func detectLanguage(text string) string {
+	raw := strings.ToLower(text)
+	words := wordPattern.FindAllString(accentFolder.Replace(raw), -1)
+
+	scores := map[string]int{}
+	for _, w := range words {
+		for lang, list := range stopwords {
+			for _, s := range list {
+				if w == s {
+					scores[lang]++
+				}
+			}
+		}
+	}
+	// Spelling that only one of the two languages uses.
+	scores[languageSpanish] += strings.Count(raw, "ñ") + strings.Count(raw, "ción")
+	scores[languagePortuguese] += strings.Count(raw, "ção") + strings.Count(raw, "ões") + strings.Count(raw, "ã")
+
+	best := languageEnglish
+	for _, lang := range []string{languageSpanish, languagePortuguese} {
+		if scores[lang] > scores[best] {
+			best = lang
+		}
+	}
+	return best
+}
+
+// translateRequirements rewrites requirements in lang into the English
+// keywords scoring understands. Words the glossary does not know — product
+// names such as Kafka or PostgreSQL included — are kept as written.
+func translateRequirements(requirements, lang string) string {
+	rules, ok := glossaryRules[lang]
+	if !ok {
+		return requirements
+	}
+	text := accentFolder.Replace(strings.ToLower(requirements))
+	for _, r := range rules {
+		text = r.pattern.ReplaceAllString(text, r.english)
+	}
+	return text
+}
+
+// normalizeRequirements detects the language of requirements and returns
+// the text to score with the language report for the advisory.
+func normalizeRequirements(requirements string) (string, *requirementsLanguage) {
+	lang := detectLanguage(requirements)
+	if lang == languageEnglish {
+		return requirements, &requirementsLanguage{Detected: lang}
+	}
+	normalized := translateRequirements(requirements, lang)
+	return normalized, &requirementsLanguage{
+		Detected:               lang,
+		Translated:             true,
+		NormalizedRequirements: normalized,
+	}
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"I need a REST API with a PostgreSQL database for the orders", languageEnglish},
+		{"Necesito una API REST con base de datos PostgreSQL y trabajos en segundo plano", languageSpanish},
+		{"Preciso de uma API REST com banco de dados e processamento de eventos do Kafka", languagePortuguese},
+		{"Sistema de notificação com filas", languagePortuguese},
+		{"Kafka PostgreSQL", languageEnglish},
+	}
+	for _, tt := range tests {
+		if got := detectLanguage(tt.text); got != tt.want {
+			t.Errorf("detectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTranslateRequirements(t *testing.T) {
+	got := translateRequirements("Un servicio sin base de datos que procesa tareas programadas en segundo plano", languageSpanish)
+	for _, want := range []string{"no database", "jobs", "scheduled", "background"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("translation should contain %q, got %q", want, got)
+		}
+	}
+	if strings.Contains(got, "no database") && strings.Contains(got, "sin database") {
+		t.Errorf("longer phrases should win, got %q", got)
+	}
+
+	got = translateRequirements("Agente de IA com base de conhecimento e busca semântica", languagePortuguese)
+	for _, want := range []string{"ai agent", "knowledge base", "semantic search"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("translation should contain %q, got %q", want, got)
+		}
+	}
+}
+
+func TestBuildAdvisory_Spanish(t *testing.T) {
+	advisory := buildAdvisory("Necesito procesar trabajos en segundo plano, tareas programadas y por lotes, con una cola")
+	if advisory.Language == nil || advisory.Language.Detected != languageSpanish || !advisory.Language.Translated {
+		t.Fatalf("expected Spanish to be detected and translated, got %+v", advisory.Language)
+	}
+	if len(advisory.Patterns) == 0 || advisory.Patterns[0].Name != "background-processing" {
+		t.Errorf("expected background-processing as the top pattern, got %+v", advisory.Patterns)
+	}
+}
+
+func TestBuildAdvisory_Portuguese(t *testing.T) {
+	advisory := buildAdvisory("Queremos um serviço orientado a eventos que consome mensagens do Kafka de forma assíncrona")
+	if advisory.Language.Detected != languagePortuguese {
+		t.Fatalf("expected Portuguese, got %+v", advisory.Language)
+	}
+	if len(advisory.Patterns) == 0 || advisory.Patterns[0].Name != "event-driven" {
+		t.Errorf("expected event-driven as the top pattern, got %+v", advisory.Patterns)
+	}
+}
+
+func TestBuildAdvisory_EnglishIsNotRewritten(t *testing.T) {
+	advisory := buildAdvisory("REST API with PostgreSQL database")
+	if advisory.Language.Detected != languageEnglish || advisory.Language.Translated || advisory.Language.NormalizedRequirements != "" {
+		t.Errorf("English requirements should be scored as written, got %+v", advisory.Language)
+	}
+}
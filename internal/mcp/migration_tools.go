@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	"github.com/arianlopezc/Trabuco/internal/migration/orchestrator"
+	"github.com/arianlopezc/Trabuco/internal/migration/report"
 	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists/llm"
 	"github.com/arianlopezc/Trabuco/internal/migration/state"
 	"github.com/arianlopezc/Trabuco/internal/migration/types"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -35,13 +37,17 @@ func registerMigrationTools(s *server.MCPServer, version string) {
 	registerMigrateActivate(s, version)
 	registerMigrateFinalize(s, version)
 	registerMigrateStatus(s)
+	registerMigrateReport(s)
 	registerMigrateRollback(s, version)
 	registerMigrateDecision(s, version)
 	registerMigrateResume(s, version)
 }
 
 // runPhaseTool is the shared handler that backs each phase-running tool.
-func runPhaseTool(repoRoot, version string, phase types.Phase) (*mcp.CallToolResult, error) {
+// The phase reports its stages as progress notifications when the call
+// carries a progress token, and a notifications/cancelled for the call
+// stops it at the next stage boundary, checkpointed for migrate_resume.
+func runPhaseTool(ctx context.Context, req mcp.CallToolRequest, repoRoot, version string, phase types.Phase) (*mcp.CallToolResult, error) {
 	abs, err := resolvePath(repoRoot)
 	if err != nil {
 		return toolError(fmt.Sprintf("resolve path: %v", err)), nil
@@ -60,7 +66,22 @@ func runPhaseTool(repoRoot, version string, phase types.Phase) (*mcp.CallToolRes
 		}
 	}
 
-	action, err := o.RunPhase(context.Background(), phase, "")
+	if phase == types.PhaseActivation {
+		if st, err := o.LoadState(); err == nil && st.Slicing() {
+			remaining, err := llm.RemainingSources(abs, st)
+			if err != nil {
+				return toolError(fmt.Sprintf("list remaining sources: %v", err)), nil
+			}
+			if len(remaining) > 0 {
+				return toolError(fmt.Sprintf("%d source classes are not migrated yet; run the module phases again with another only/include slice, or all=true, before activation", len(remaining))), nil
+			}
+		}
+	}
+
+	ctx, cancel := cancellable(ctx, req)
+	defer cancel()
+	o.SetProgress(newProgressReporter(ctx, req).phase)
+	action, err := o.RunPhase(ctx, phase, "")
 	if err != nil {
 		return toolError(fmt.Sprintf("run phase %s: %v", phase, err)), nil
 	}
@@ -73,6 +94,47 @@ func runPhaseTool(repoRoot, version string, phase types.Phase) (*mcp.CallToolRes
 	})
 }
 
+// selectionParams are the selective-migration parameters of the
+// module-running tools, mirroring the CLI's --only/--include/--all.
+func selectionParams() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("only", mcp.Description("Comma-separated categories to migrate: entities, repositories, services, controllers, jobs, listeners, publishers, tests")),
+		mcp.WithString("include", mcp.Description("Comma-separated fully-qualified class name patterns to migrate, * as wildcard (e.g. com.acme.billing.*)")),
+		mcp.WithBoolean("all", mcp.Description("Drop the stored selection and migrate everything not migrated yet")),
+	}
+}
+
+// applySelectionTool stores the request's selection in state.json before
+// the phase runs. Without only/include/all the stored selection is kept.
+// It returns a tool error result, or nil to proceed.
+func applySelectionTool(req mcp.CallToolRequest, version string) *mcp.CallToolResult {
+	only := req.GetString("only", "")
+	include := req.GetString("include", "")
+	all := req.GetBool("all", false)
+	if only == "" && include == "" && !all {
+		return nil
+	}
+	if all && (only != "" || include != "") {
+		return toolError("all cannot be combined with only or include")
+	}
+	abs, err := resolvePath(req.GetString("repo_path", ""))
+	if err != nil {
+		return toolError(fmt.Sprintf("resolve path: %v", err))
+	}
+	if !state.Exists(abs) {
+		return toolError(fmt.Sprintf("no migration initialized at %s; call migrate_assess first", abs))
+	}
+	sel, err := state.ParseSelection(only, include)
+	if err != nil {
+		return toolError(err.Error())
+	}
+	o := orchestrator.New(abs, version, specialists.Default(), pluginGate{})
+	if _, err := o.SetSelection(sel); err != nil {
+		return toolError(fmt.Sprintf("set selection: %v", err))
+	}
+	return nil
+}
+
 // pluginGate is the no-op Gate for plugin mode. The orchestrator subagent
 // owns the user dialogue; this gate auto-approves so the orchestrator can
 // surface the diff to the user via natural language and call migrate_rollback
@@ -99,7 +161,7 @@ func registerMigrateAssess(s *server.MCPServer, version string) {
 		mcp.WithString("repo_path", mcp.Description("Absolute path to the user's repository"), mcp.Required()),
 	)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return runPhaseTool(req.GetString("repo_path", ""), version, types.PhaseAssessment)
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, types.PhaseAssessment)
 	})
 }
 
@@ -109,17 +171,21 @@ func registerMigrateSkeleton(s *server.MCPServer, version string) {
 		mcp.WithString("repo_path", mcp.Required()),
 	)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return runPhaseTool(req.GetString("repo_path", ""), version, types.PhaseSkeleton)
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, types.PhaseSkeleton)
 	})
 }
 
 func registerMigrateModule(s *server.MCPServer, version string) {
-	tool := mcp.NewTool("migrate_module",
-		mcp.WithDescription("Phases 2-8 — Run a single module specialist. Module: model | sqldatastore | nosqldatastore | shared | api | worker | eventconsumer | aiagent."),
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("Phases 2-8 — Run a single module specialist. Module: model | sqldatastore | nosqldatastore | shared | api | worker | eventconsumer | aiagent. only/include migrate a slice of the source; the rest stays in legacy/ for a later call."),
 		mcp.WithString("repo_path", mcp.Required()),
 		mcp.WithString("module", mcp.Description("Module to migrate"), mcp.Required()),
-	)
+	}
+	tool := mcp.NewTool("migrate_module", append(opts, selectionParams()...)...)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if res := applySelectionTool(req, version); res != nil {
+			return res, nil
+		}
 		mod := req.GetString("module", "")
 		var phase types.Phase
 		switch mod {
@@ -140,7 +206,7 @@ func registerMigrateModule(s *server.MCPServer, version string) {
 		default:
 			return toolError(fmt.Sprintf("unknown module: %q", mod)), nil
 		}
-		return runPhaseTool(req.GetString("repo_path", ""), version, phase)
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, phase)
 	})
 }
 
@@ -150,7 +216,7 @@ func registerMigrateConfig(s *server.MCPServer, version string) {
 		mcp.WithString("repo_path", mcp.Required()),
 	)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return runPhaseTool(req.GetString("repo_path", ""), version, types.PhaseConfiguration)
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, types.PhaseConfiguration)
 	})
 }
 
@@ -160,17 +226,21 @@ func registerMigrateDeployment(s *server.MCPServer, version string) {
 		mcp.WithString("repo_path", mcp.Required()),
 	)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return runPhaseTool(req.GetString("repo_path", ""), version, types.PhaseDeployment)
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, types.PhaseDeployment)
 	})
 }
 
 func registerMigrateTests(s *server.MCPServer, version string) {
-	tool := mcp.NewTool("migrate_tests",
+	opts := []mcp.ToolOption{
 		mcp.WithDescription("Phase 11 — Per-test analysis: KEEP / ADAPT / DISCARD / CHARACTERIZE-FIRST decisions on every test in the source."),
 		mcp.WithString("repo_path", mcp.Required()),
-	)
+	}
+	tool := mcp.NewTool("migrate_tests", append(opts, selectionParams()...)...)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return runPhaseTool(req.GetString("repo_path", ""), version, types.PhaseTests)
+		if res := applySelectionTool(req, version); res != nil {
+			return res, nil
+		}
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, types.PhaseTests)
 	})
 }
 
@@ -180,7 +250,7 @@ func registerMigrateActivate(s *server.MCPServer, version string) {
 		mcp.WithString("repo_path", mcp.Required()),
 	)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return runPhaseTool(req.GetString("repo_path", ""), version, types.PhaseActivation)
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, types.PhaseActivation)
 	})
 }
 
@@ -190,7 +260,7 @@ func registerMigrateFinalize(s *server.MCPServer, version string) {
 		mcp.WithString("repo_path", mcp.Required()),
 	)
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return runPhaseTool(req.GetString("repo_path", ""), version, types.PhaseFinalization)
+		return runPhaseTool(ctx, req, req.GetString("repo_path", ""), version, types.PhaseFinalization)
 	})
 }
 
@@ -213,6 +283,28 @@ func registerMigrateStatus(s *server.MCPServer) {
 	})
 }
 
+func registerMigrateReport(s *server.MCPServer) {
+	tool := mcp.NewTool("migrate_report",
+		mcp.WithDescription("Regenerate MIGRATION_REPORT.md and .trabuco-migration/migration-report.json, and return the report: converted files, skipped items with reasons, dependency changes, TODOs in migrated code, token/cost totals and a prioritized follow-up checklist. migrate_finalize writes it automatically."),
+		mcp.WithString("repo_path", mcp.Required()),
+	)
+	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		abs, err := resolvePath(req.GetString("repo_path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("resolve path: %v", err)), nil
+		}
+		st, err := state.Load(abs)
+		if err != nil {
+			return toolError(fmt.Sprintf("no migration in progress at %s: %v", abs, err)), nil
+		}
+		r, err := report.Write(abs, st, nil)
+		if err != nil {
+			return toolError(fmt.Sprintf("write report: %v", err)), nil
+		}
+		return toolJSON(r)
+	})
+}
+
 func registerMigrateRollback(s *server.MCPServer, version string) {
 	tool := mcp.NewTool("migrate_rollback",
 		mcp.WithDescription("Roll back the migration to the pre-tag of phase N (0..13). Destructive: git resets working tree to the tag and clears phases >= N from state.json."),
@@ -277,7 +369,7 @@ func registerMigrateResume(s *server.MCPServer, version string) {
 		for _, p := range types.AllPhases() {
 			rec := st.Phases[p]
 			if rec.State == types.PhaseInProgress || rec.State == types.PhaseFailed {
-				return runPhaseTool(abs, version, p)
+				return runPhaseTool(ctx, req, abs, version, p)
 			}
 		}
 		return toolJSON(map[string]string{"status": "nothing_to_resume"})
@@ -0,0 +1,361 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/doctor"
+	"github.com/arianlopezc/Trabuco/internal/todos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// NextStep is one item of a suggest_next_steps plan
+type NextStep struct {
+	ID       string   `json:"id"`       // Stable identifier, e.g. "replace_placeholder_entity"
+	Priority int      `json:"priority"` // 1 is the most urgent
+	Category string   `json:"category"` // health, domain, persistence, logic, api, jobs, events, todos, ci, vcs
+	Title    string   `json:"title"`
+	Reason   string   `json:"reason"` // The project state that triggered the step
+	Files    []string `json:"files,omitempty"`
+	Tool     string   `json:"tool,omitempty"`    // MCP tool that does or helps with it
+	Command  string   `json:"command,omitempty"` // CLI equivalent
+}
+
+// NextStepsPlan is the suggest_next_steps result
+type NextStepsPlan struct {
+	Project string       `json:"project"`
+	Path    string       `json:"path"`
+	Modules []string     `json:"modules"`
+	Signals StepsSignals `json:"signals"`
+	Steps   []NextStep   `json:"steps"`
+}
+
+// StepsSignals summarizes the project state the plan was built from
+type StepsSignals struct {
+	DoctorErrors     int  `json:"doctor_errors"`
+	DoctorWarnings   int  `json:"doctor_warnings"`
+	GeneratedTodos   int  `json:"generated_todos"`
+	OtherTodos       int  `json:"other_todos"`
+	ResolvedTodos    int  `json:"resolved_todos"`
+	PlaceholderFiles int  `json:"placeholder_files"`
+	Migrations       int  `json:"migrations"`
+	HasCI            bool `json:"has_ci"`
+	HasGitRepository bool `json:"has_git_repository"`
+}
+
+// placeholderStep describes the step for the placeholder files of a group
+// of modules
+type placeholderStep struct {
+	id, category, title, tool, command string
+	priority                           int
+	modules                            []string
+}
+
+// placeholderSteps are in priority order: the domain model first, since
+// everything else is written against it
+var placeholderSteps = []placeholderStep{
+	{"replace_placeholder_entity", "domain", "Replace the Placeholder entity with your first domain entity",
+		"add_entity", "trabuco add entity <Name>", 2, []string{config.ModuleModel, config.ModuleSQLDatastore, config.ModuleNoSQLDatastore}},
+	{"implement_first_service", "logic", "Replace PlaceholderService with your business logic",
+		"add_service", "trabuco add service <Name>", 3, []string{config.ModuleShared}},
+	{"replace_placeholder_endpoints", "api", "Replace the placeholder REST endpoints",
+		"add_endpoint", "trabuco add endpoint <Name>", 3, []string{config.ModuleAPI}},
+	{"implement_first_job", "jobs", "Replace the placeholder job with your first background job",
+		"add_job", "trabuco add job <Name>", 3, []string{config.ModuleJobs, config.ModuleWorker}},
+	{"implement_first_event", "events", "Replace the placeholder event and listener with your first event",
+		"add_event", "trabuco add event <Name>", 3, []string{config.ModuleEvents, config.ModuleEventConsumer}},
+}
+
+// migrationDir holds the Flyway migrations of SQLDatastore
+const migrationDir = "SQLDatastore/src/main/resources/db/migration"
+
+func registerSuggestNextSteps(s *server.MCPServer, version string) {
+	tool := mcp.NewTool("suggest_next_steps",
+		mcp.WithDescription(
+			"Inspect a Trabuco project and return a prioritized, machine-readable plan of what to do next, built from its current state: "+
+				"doctor errors and warnings, placeholder files (Placeholder entity, service, endpoints, job, event) still in the code, "+
+				"a SQLDatastore with only the baseline migration, the generated TODOs left in .trabuco/todos.json, and missing CI or git. "+
+				"Each step has a stable id, a priority (1 is most urgent), the files involved, and the MCP tool and CLI command that do it. "+
+				"Use it to drive onboarding after init_project and to pick up where a project was left, instead of init_project's static next_steps.",
+		),
+		mcp.WithString("path",
+			mcp.Description("Path to the Trabuco project root"),
+			mcp.Required(),
+		),
+	)
+
+	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		absPath, err := resolvePath(req.GetString("path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+		}
+		plan, err := suggestNextSteps(absPath, version)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		watchProject(absPath)
+		return toolJSON(plan)
+	})
+}
+
+// suggestNextSteps builds the next-steps plan of the project at root
+func suggestNextSteps(root, version string) (*NextStepsPlan, error) {
+	meta, err := config.LoadMetadata(root)
+	if err != nil {
+		meta, err = doctor.GetProjectMetadata(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read project at '%s': %v. Verify the path points to a Trabuco project root (should contain .trabuco.json or pom.xml)", root, err)
+		}
+	}
+
+	plan := &NextStepsPlan{
+		Project: meta.ProjectName,
+		Path:    root,
+		Modules: meta.Modules,
+		Steps:   []NextStep{},
+	}
+	add := func(step NextStep) {
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	// Health: errors block add_module, so they come first
+	result, err := doctor.New(root, version).Run()
+	if err != nil {
+		return nil, fmt.Errorf("doctor failed: %v", err)
+	}
+	var errorChecks, warnChecks []string
+	autoFix := false
+	for _, c := range result.Checks {
+		switch c.Status {
+		case doctor.SeverityError:
+			errorChecks = append(errorChecks, c.ID)
+			autoFix = autoFix || c.CanAutoFix
+		case doctor.SeverityWarn:
+			warnChecks = append(warnChecks, c.ID)
+		}
+	}
+	plan.Signals.DoctorErrors = len(errorChecks)
+	plan.Signals.DoctorWarnings = len(warnChecks)
+	if len(errorChecks) > 0 {
+		step := NextStep{
+			ID: "fix_doctor_errors", Priority: 1, Category: "health",
+			Title:   "Fix the project health errors",
+			Reason:  fmt.Sprintf("trabuco doctor reports %d error(s): %s", len(errorChecks), strings.Join(errorChecks, ", ")),
+			Tool:    "run_doctor",
+			Command: "trabuco doctor",
+		}
+		if autoFix {
+			step.Command = "trabuco doctor --fix"
+		}
+		add(step)
+	}
+
+	// Placeholders the project still carries
+	placeholders, err := findPlaceholders(root, meta.Modules)
+	if err != nil {
+		return nil, err
+	}
+	byStep := map[string][]string{}
+	for _, f := range placeholders {
+		if id := placeholderStepFor(f); id != "" {
+			byStep[id] = append(byStep[id], f)
+		}
+	}
+	for _, ps := range placeholderSteps {
+		files := byStep[ps.id]
+		if len(files) == 0 {
+			continue
+		}
+		var modules []string
+		for _, f := range files {
+			if m, _, _ := strings.Cut(f, "/"); !slices.Contains(modules, m) {
+				modules = append(modules, m)
+			}
+		}
+		plan.Signals.PlaceholderFiles += len(files)
+		add(NextStep{
+			ID: ps.id, Priority: ps.priority, Category: ps.category,
+			Title:   ps.title,
+			Reason:  fmt.Sprintf("%d placeholder file(s) from the generated skeleton are still in %s", len(files), strings.Join(modules, ", ")),
+			Files:   files,
+			Tool:    ps.tool,
+			Command: ps.command,
+		})
+	}
+
+	// Persistence: a schema that is still only the baseline
+	if meta.HasModule(config.ModuleSQLDatastore) {
+		migrations, err := listMigrations(root)
+		if err != nil {
+			return nil, err
+		}
+		plan.Signals.Migrations = len(migrations)
+		if len(migrations) <= 1 {
+			add(NextStep{
+				ID: "write_first_migration", Priority: 2, Category: "persistence",
+				Title:   "Write the first Flyway migration for your schema",
+				Reason:  "SQLDatastore only has the baseline migration",
+				Files:   migrations,
+				Tool:    "add_migration",
+				Command: "trabuco add migration",
+			})
+		}
+	}
+
+	// Generated TODOs still open
+	report, err := todos.Outstanding(root, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TODOs: %v", err)
+	}
+	plan.Signals.GeneratedTodos = report.Generated()
+	plan.Signals.OtherTodos = len(report.Items) - report.Generated()
+	plan.Signals.ResolvedTodos = report.Resolved
+	if n := report.Generated(); n > 0 {
+		perModule := map[string]int{}
+		for _, it := range report.Items {
+			if it.Generated {
+				perModule[todos.ModuleLabel(it.Module)]++
+			}
+		}
+		add(NextStep{
+			ID: "resolve_generated_todos", Priority: 4, Category: "todos",
+			Title:   "Work through the TODOs the skeleton left for your business logic",
+			Reason:  fmt.Sprintf("%d generated TODO(s) outstanding (%s), %d resolved", n, formatCounts(perModule), report.Resolved),
+			Tool:    "list_todos",
+			Command: "trabuco todos --generated",
+		})
+	}
+
+	if len(warnChecks) > 0 {
+		add(NextStep{
+			ID: "review_doctor_warnings", Priority: 5, Category: "health",
+			Title:   "Review the project health warnings",
+			Reason:  fmt.Sprintf("trabuco doctor reports %d warning(s): %s", len(warnChecks), strings.Join(warnChecks, ", ")),
+			Tool:    "run_doctor",
+			Command: "trabuco doctor",
+		})
+	}
+
+	// Delivery: CI and version control
+	plan.Signals.HasCI = meta.CIProvider != "" || fileExists(filepath.Join(root, ".github", "workflows"))
+	if !plan.Signals.HasCI {
+		add(NextStep{
+			ID: "enable_ci", Priority: 5, Category: "ci",
+			Title:  "Enable CI",
+			Reason: "The project has no CI workflow. 'trabuco add' offers to generate .github/workflows/ci.yml when it adds a module; ci: github in the config profile generates it without asking",
+		})
+	}
+	plan.Signals.HasGitRepository = fileExists(filepath.Join(root, ".git"))
+	if !plan.Signals.HasGitRepository {
+		add(NextStep{
+			ID: "init_git", Priority: 5, Category: "vcs",
+			Title:   "Put the project under version control",
+			Reason:  "The project is not a git repository; the Spotless ratchet and CI need one",
+			Command: "git init && git add -A && git commit -m \"Initial project\"",
+		})
+	}
+
+	sort.SliceStable(plan.Steps, func(i, j int) bool {
+		return plan.Steps[i].Priority < plan.Steps[j].Priority
+	})
+	return plan, nil
+}
+
+// findPlaceholders returns the placeholder source files of the modules:
+// files under src/ whose name contains "Placeholder", as generated by init
+func findPlaceholders(root string, modules []string) ([]string, error) {
+	var found []string
+	for _, module := range modules {
+		src := filepath.Join(root, module, "src")
+		if !fileExists(src) {
+			continue
+		}
+		err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.Contains(d.Name(), "Placeholder") {
+				return nil
+			}
+			if ext := filepath.Ext(d.Name()); ext != ".java" && ext != ".kt" {
+				return nil
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			found = append(found, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %v", module, err)
+		}
+	}
+	return found, nil
+}
+
+// placeholderStepFor returns the step that replaces a placeholder file.
+// Model holds the event and job contracts too, which go with their steps.
+func placeholderStepFor(file string) string {
+	module, _, _ := strings.Cut(file, "/")
+	if module == config.ModuleModel {
+		switch {
+		case strings.Contains(file, "/events/"):
+			return "implement_first_event"
+		case strings.Contains(file, "/jobs/"):
+			return "implement_first_job"
+		}
+	}
+	for _, ps := range placeholderSteps {
+		if slices.Contains(ps.modules, module) {
+			return ps.id
+		}
+	}
+	return ""
+}
+
+// listMigrations returns the project-relative paths of the SQL migrations
+func listMigrations(root string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, migrationDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %v", err)
+	}
+	var migrations []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".sql") {
+			migrations = append(migrations, migrationDir+"/"+e.Name())
+		}
+	}
+	return migrations, nil
+}
+
+// formatCounts renders per-module counts as "API: 2, Worker: 1"
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s: %d", k, counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
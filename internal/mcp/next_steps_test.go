@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/generator"
+)
+
+func stepByID(plan *NextStepsPlan, id string) *NextStep {
+	for i := range plan.Steps {
+		if plan.Steps[i].ID == id {
+			return &plan.Steps[i]
+		}
+	}
+	return nil
+}
+
+func TestSuggestNextSteps(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{
+		ProjectName: "orders",
+		GroupID:     "com.test.orders",
+		ArtifactID:  "orders",
+		JavaVersion: "21",
+		Modules:     config.ResolveDependencies([]string{"SQLDatastore", "API", "Worker"}),
+		Database:    "postgresql",
+	}
+	gen, err := generator.NewWithVersionAt(cfg, "test", projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	plan, err := suggestNextSteps(projectPath, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"replace_placeholder_entity", "write_first_migration", "replace_placeholder_endpoints", "implement_first_job", "resolve_generated_todos", "enable_ci"} {
+		if stepByID(plan, id) == nil {
+			t.Errorf("expected step %s, got %+v", id, plan.Steps)
+		}
+	}
+	if stepByID(plan, "implement_first_event") != nil {
+		t.Error("no event step expected without Events")
+	}
+	for i := 1; i < len(plan.Steps); i++ {
+		if plan.Steps[i].Priority < plan.Steps[i-1].Priority {
+			t.Fatalf("steps are not ordered by priority: %+v", plan.Steps)
+		}
+	}
+
+	// Model's job contracts belong to the job step, not the entity step
+	for _, f := range stepByID(plan, "replace_placeholder_entity").Files {
+		if filepath.Base(filepath.Dir(f)) == "jobs" {
+			t.Errorf("job contract %s listed under the entity step", f)
+		}
+	}
+	if plan.Signals.PlaceholderFiles == 0 || plan.Signals.GeneratedTodos == 0 {
+		t.Errorf("unexpected signals: %+v", plan.Signals)
+	}
+
+	// Writing a migration and replacing the endpoints clears their steps
+	migration := filepath.Join(projectPath, migrationDir, "V2__orders.sql")
+	if err := os.WriteFile(migration, []byte("CREATE TABLE orders (id BIGINT PRIMARY KEY);\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range stepByID(plan, "replace_placeholder_endpoints").Files {
+		if err := os.Remove(filepath.Join(projectPath, f)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plan, err = suggestNextSteps(projectPath, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stepByID(plan, "write_first_migration") != nil || stepByID(plan, "replace_placeholder_endpoints") != nil {
+		t.Errorf("completed steps still suggested: %+v", plan.Steps)
+	}
+	if plan.Signals.Migrations != 2 {
+		t.Errorf("expected 2 migrations, got %d", plan.Signals.Migrations)
+	}
+}
+
+func TestSuggestNextSteps_NotAProject(t *testing.T) {
+	if _, err := suggestNextSteps(t.TempDir(), "test"); err == nil {
+		t.Error("expected an error for a directory without a project")
+	}
+}
@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cursorPrefix marks continuation tokens so a token from another source
+// (or a hand-typed number) is rejected instead of silently misread.
+const cursorPrefix = "offset:"
+
+// pageRequest holds the response-size controls of tools whose results grow
+// with the project or workspace.
+type pageRequest struct {
+	Summary  bool // drop per-item detail, keep counts
+	MaxItems int  // items per page; 0 means no limit
+	Offset   int  // decoded from the continuation token
+}
+
+// pageInfo is returned next to a paginated list. NextCursor is empty on the
+// last page.
+type pageInfo struct {
+	Total      int    `json:"total"`
+	Returned   int    `json:"returned"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// withPaging adds the summary, max_items and cursor parameters to a tool.
+// items names what is paged, for the parameter descriptions.
+func withPaging(items string) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithBoolean("summary",
+			mcp.Description(fmt.Sprintf("Return counts and a compact form of the %s instead of full detail. Use on large projects to keep the response small", items)),
+		)(t)
+		mcp.WithNumber("max_items",
+			mcp.Description(fmt.Sprintf("Maximum number of %s to return. When more remain, the response has page.next_cursor. Default: no limit", items)),
+		)(t)
+		mcp.WithString("cursor",
+			mcp.Description("Continuation token from a previous response's page.next_cursor. Repeat the other arguments unchanged"),
+		)(t)
+	}
+}
+
+// readPageRequest reads the paging parameters of a tool call.
+func readPageRequest(req mcp.CallToolRequest) (pageRequest, error) {
+	p := pageRequest{
+		Summary:  req.GetBool("summary", false),
+		MaxItems: int(req.GetFloat("max_items", 0)),
+	}
+	if p.MaxItems < 0 {
+		return p, fmt.Errorf("max_items must not be negative")
+	}
+	if cursor := req.GetString("cursor", ""); cursor != "" {
+		offset, err := decodeCursor(cursor)
+		if err != nil {
+			return p, err
+		}
+		p.Offset = offset
+	}
+	return p, nil
+}
+
+// paginate returns the page of items p selects and the page info to return
+// with it.
+func paginate[T any](items []T, p pageRequest) ([]T, pageInfo) {
+	start := min(p.Offset, len(items))
+	end := len(items)
+	if p.MaxItems > 0 && start+p.MaxItems < end {
+		end = start + p.MaxItems
+	}
+	info := pageInfo{Total: len(items), Returned: end - start}
+	if end < len(items) {
+		info.NextCursor = encodeCursor(end)
+	}
+	return items[start:end], info
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, fmt.Errorf("invalid cursor %q: pass page.next_cursor from a previous response", cursor)
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor %q: pass page.next_cursor from a previous response", cursor)
+	}
+	return offset, nil
+}
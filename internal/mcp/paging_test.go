@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/doctor"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	page, info := paginate(items, pageRequest{})
+	if len(page) != 5 || info.Total != 5 || info.Returned != 5 || info.NextCursor != "" {
+		t.Errorf("no limit should return everything, got %v %+v", page, info)
+	}
+
+	var all []int
+	p := pageRequest{MaxItems: 2}
+	for i := 0; ; i++ {
+		if i > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		page, info := paginate(items, p)
+		all = append(all, page...)
+		if info.NextCursor == "" {
+			break
+		}
+		offset, err := decodeCursor(info.NextCursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Offset = offset
+	}
+	if len(all) != 5 || all[4] != 5 {
+		t.Errorf("pages should cover every item once, got %v", all)
+	}
+
+	page, info = paginate(items, pageRequest{Offset: 10})
+	if len(page) != 0 || info.Returned != 0 || info.NextCursor != "" {
+		t.Errorf("offset past the end should return an empty last page, got %v %+v", page, info)
+	}
+}
+
+func TestDecodeCursor_RejectsForeignTokens(t *testing.T) {
+	for _, cursor := range []string{"3", "!!!", "cGFnZToy"} {
+		if _, err := decodeCursor(cursor); err == nil {
+			t.Errorf("decodeCursor(%q) should fail", cursor)
+		}
+	}
+}
+
+func TestDoctorResponse_Summary(t *testing.T) {
+	result := &doctor.DoctorResult{
+		Project: "demo",
+		Summary: doctor.DoctorSummary{Passed: 2, Warnings: 1, Errors: 1},
+		Checks: []doctor.CheckResult{
+			{ID: "A", Name: "a", Status: doctor.SeverityPass},
+			{ID: "B", Name: "b", Status: doctor.SeverityWarn, Details: []string{"long detail"}},
+			{ID: "C", Name: "c", Status: doctor.SeverityPass},
+			{ID: "D", Name: "d", Status: doctor.SeverityError, Details: []string{"long detail"}},
+		},
+	}
+
+	data, err := json.Marshal(doctorResponse(result, pageRequest{Summary: true, MaxItems: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Summary doctor.DoctorSummary `json:"summary"`
+		Checks  []map[string]any     `json:"checks"`
+		Page    pageInfo             `json:"page"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Summary.Errors != 1 || got.Summary.Passed != 2 {
+		t.Errorf("summary counts should cover all checks, got %+v", got.Summary)
+	}
+	if len(got.Checks) != 1 || got.Checks[0]["id"] != "B" || got.Checks[0]["details"] != nil {
+		t.Errorf("summary should list failing checks without details, got %v", got.Checks)
+	}
+	if got.Page.Total != 2 || got.Page.NextCursor == "" {
+		t.Errorf("page should count the failing checks and continue, got %+v", got.Page)
+	}
+}
+
+func TestDesignSystem_Paging(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0")
+	registerDesignSystem(s)
+
+	call := func(args string) map[string]any {
+		t.Helper()
+		resp := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"design_system","arguments":`+args+`}}`))
+		rpc, ok := resp.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("expected a JSON-RPC response, got %#v", resp)
+		}
+		result := rpc.Result.(*mcp.CallToolResult)
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("tool error: %s", text)
+		}
+		var out map[string]any
+		if err := json.Unmarshal([]byte(text), &out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	requirements := `"user service, order service, payment service and notification service"`
+	first := call(`{"requirements":` + requirements + `,"summary":true,"max_items":2}`)
+	services := first["services"].([]any)
+	if len(services) != 2 {
+		t.Fatalf("expected 2 services, got %v", services)
+	}
+	if _, ok := services[0].(map[string]any)["boundaries"]; ok {
+		t.Error("summary services should not carry boundaries")
+	}
+	cursor, _ := first["page"].(map[string]any)["next_cursor"].(string)
+	if cursor == "" {
+		t.Fatalf("expected a continuation token, got %v", first["page"])
+	}
+
+	second := call(`{"requirements":` + requirements + `,"summary":true,"max_items":2,"cursor":"` + cursor + `"}`)
+	next := second["services"].([]any)
+	if len(next) == 0 || next[0].(map[string]any)["name"] == services[0].(map[string]any)["name"] {
+		t.Errorf("second page should continue after the first, got %v", next)
+	}
+
+	resp := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"design_system","arguments":{"requirements":"x","cursor":"bogus"}}}`))
+	result := resp.(mcp.JSONRPCResponse).Result.(*mcp.CallToolResult)
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "invalid cursor") {
+		t.Errorf("a bad cursor should be a tool error, got %#v", result)
+	}
+}
@@ -20,28 +20,38 @@ type ArchitecturePattern struct {
 	keywords []string
 }
 
-// matchScore scores how well this pattern matches natural language requirements.
-// Returns a value from 0 to 100.
+// matchScore scores how well this pattern matches natural language requirements
+// with the default scoring table. Returns a value from 0 to 100.
 func (p *ArchitecturePattern) matchScore(requirements string) int {
+	return p.weightedScore(requirements, defaultScoring())
+}
+
+// weightedScore scores the pattern against requirements with the keyword
+// weights and match bonus of table. Returns a value from 0 to 100.
+func (p *ArchitecturePattern) weightedScore(requirements string, table scoringTable) int {
 	lower := strings.ToLower(requirements)
 	if len(p.keywords) == 0 {
 		return 0
 	}
 
 	matched := 0
+	var matchedWeight, totalWeight float64
 	for _, kw := range p.keywords {
+		w := table.weight(p.Name, kw)
+		totalWeight += w
 		if strings.Contains(lower, kw) {
 			matched++
+			matchedWeight += w
 		}
 	}
 
-	// Base score: percentage of keywords matched
-	score := (matched * 100) / len(p.keywords)
+	// Base score: weighted percentage of keywords matched
+	score := int(matchedWeight * 100 / totalWeight)
 
 	// Bonus: each additional keyword match beyond the first adds extra weight
 	// to reward patterns with more overlap
 	if matched > 1 {
-		score += matched * 5
+		score += matched * table.MatchBonus
 	}
 
 	if score > 100 {
@@ -50,6 +60,18 @@ func (p *ArchitecturePattern) matchScore(requirements string) int {
 	return score
 }
 
+// matchedKeywords returns the pattern keywords found in requirements.
+func (p *ArchitecturePattern) matchedKeywords(requirements string) []string {
+	lower := strings.ToLower(requirements)
+	var matched []string
+	for _, kw := range p.keywords {
+		if strings.Contains(lower, kw) {
+			matched = append(matched, kw)
+		}
+	}
+	return matched
+}
+
 // patternCatalog contains all pre-defined architectural patterns.
 var patternCatalog = []ArchitecturePattern{
 	{
@@ -77,7 +99,7 @@ var patternCatalog = []ArchitecturePattern{
 		Modules:         []string{"Model", "SQLDatastore", "Shared", "API", "EventConsumer"},
 		RecommendedDB:   "postgresql",
 		RecommendedBrkr: "kafka",
-		Constraints:     []string{"Requires an external message broker (Kafka, RabbitMQ, SQS, or Pub/Sub)"},
+		Constraints:     []string{"Requires an external message broker (Kafka, RabbitMQ, SQS, Pub/Sub, Redis Streams, or NATS)"},
 		keywords:        []string{"event", "kafka", "rabbitmq", "sqs", "pubsub", "message", "async", "streaming", "event-driven", "cqrs"},
 	},
 	{
@@ -136,12 +158,18 @@ var patternCatalog = []ArchitecturePattern{
 	},
 }
 
-// scorePatterns scores all patterns against requirements and returns them sorted by score (descending).
+// scorePatterns scores all patterns against requirements with the scoring
+// table in effect and returns them sorted by score (descending).
 // Only patterns with score > 0 are returned.
 func scorePatterns(requirements string) []scoredPattern {
+	return scorePatternsWith(requirements, loadScoring())
+}
+
+// scorePatternsWith is scorePatterns with an explicit scoring table.
+func scorePatternsWith(requirements string, table scoringTable) []scoredPattern {
 	var results []scoredPattern
 	for _, p := range patternCatalog {
-		score := p.matchScore(requirements)
+		score := p.weightedScore(requirements, table)
 		if score > 0 {
 			results = append(results, scoredPattern{
 				ArchitecturePattern: p,
@@ -151,6 +179,12 @@ func scorePatterns(requirements string) []scoredPattern {
 		}
 	}
 
+	return topPatterns(results)
+}
+
+// topPatterns sorts scored patterns by score (descending) and keeps the
+// top 3.
+func topPatterns(results []scoredPattern) []scoredPattern {
 	// Sort by score descending (simple insertion sort — small slice)
 	for i := 1; i < len(results); i++ {
 		for j := i; j > 0 && results[j].Score > results[j-1].Score; j-- {
@@ -167,13 +201,7 @@ func scorePatterns(requirements string) []scoredPattern {
 
 // buildPatternReasoning explains why a pattern matches the requirements.
 func buildPatternReasoning(p ArchitecturePattern, requirements string) string {
-	lower := strings.ToLower(requirements)
-	var matched []string
-	for _, kw := range p.keywords {
-		if strings.Contains(lower, kw) {
-			matched = append(matched, kw)
-		}
-	}
+	matched := p.matchedKeywords(requirements)
 	if len(matched) == 0 {
 		return "Low relevance to the stated requirements"
 	}
@@ -183,27 +211,33 @@ func buildPatternReasoning(p ArchitecturePattern, requirements string) string {
 // buildRecommendedConfig generates a concrete init_project configuration from the top-scoring pattern.
 // Returns nil if no pattern scored high enough.
 func buildRecommendedConfig(patterns []scoredPattern) *recommendedConfig {
+	return buildRecommendedConfigWith(patterns, loadScoring())
+}
+
+// buildRecommendedConfigWith is buildRecommendedConfig with an explicit
+// scoring table for the confidence thresholds.
+func buildRecommendedConfigWith(patterns []scoredPattern, table scoringTable) *recommendedConfig {
 	if len(patterns) == 0 {
 		return nil
 	}
 	top := patterns[0]
-	if top.Score < 20 {
+	if top.Score < table.MinRecommendScore {
 		return nil
 	}
 
 	confidence := "high"
 	reasoning := "Strong match with the '" + top.Name + "' pattern"
 
-	if top.Score < 50 {
+	if top.Score < table.MediumConfidence {
 		confidence = "low"
 		reasoning = "Weak match — review the pattern details and adjust modules as needed"
-	} else if top.Score < 70 {
+	} else if top.Score < table.HighConfidence {
 		confidence = "medium"
 		reasoning = "Moderate match with '" + top.Name + "' — verify the module selection fits your needs"
 	}
 
 	// If top two patterns are close in score, lower confidence
-	if len(patterns) > 1 && (top.Score-patterns[1].Score) <= 10 {
+	if len(patterns) > 1 && (top.Score-patterns[1].Score) <= table.CloseMargin {
 		if confidence == "high" {
 			confidence = "medium"
 		} else if confidence == "medium" {
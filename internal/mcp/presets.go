@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Preset is a ready-to-generate project recipe derived from a catalog
+// pattern: its modules plus the recommended database, broker and vector
+// store. `trabuco init --preset` and `trabuco presets` use these.
+type Preset struct {
+	Name          string   `json:"name"`    // e.g. "event-driven-postgres-kafka"
+	Pattern       string   `json:"pattern"` // Catalog pattern it comes from, e.g. "event-driven"
+	Description   string   `json:"description"`
+	Modules       []string `json:"modules"`
+	Database      string   `json:"database,omitempty"`
+	NoSQLDatabase string   `json:"nosql_database,omitempty"`
+	MessageBroker string   `json:"message_broker,omitempty"`
+	VectorStore   string   `json:"vector_store,omitempty"`
+}
+
+// presetNameParts shortens recommendation values for preset names
+var presetNameParts = map[string]string{
+	"postgresql": "postgres",
+}
+
+// presetFromPattern names a preset after the pattern and its recommended
+// backends: event-driven + postgresql + kafka → event-driven-postgres-kafka.
+func presetFromPattern(p ArchitecturePattern) Preset {
+	parts := []string{p.Name}
+	for _, v := range []string{p.RecommendedDB, p.RecommendedNoDB, p.RecommendedBrkr, p.RecommendedVector} {
+		if v == "" || strings.HasSuffix(p.Name, "-"+v) {
+			continue
+		}
+		if short, ok := presetNameParts[v]; ok {
+			v = short
+		}
+		parts = append(parts, v)
+	}
+	return Preset{
+		Name:          strings.Join(parts, "-"),
+		Pattern:       p.Name,
+		Description:   p.Description,
+		Modules:       append([]string(nil), p.Modules...),
+		Database:      p.RecommendedDB,
+		NoSQLDatabase: p.RecommendedNoDB,
+		MessageBroker: p.RecommendedBrkr,
+		VectorStore:   p.RecommendedVector,
+	}
+}
+
+// Presets returns one preset per catalog pattern, in catalog order
+func Presets() []Preset {
+	presets := make([]Preset, len(patternCatalog))
+	for i, p := range patternCatalog {
+		presets[i] = presetFromPattern(p)
+	}
+	return presets
+}
+
+// FindPreset looks a preset up by its full name or by its pattern name
+// (so "event-driven" works as well as "event-driven-postgres-kafka").
+func FindPreset(name string) (*Preset, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	presets := Presets()
+	for i := range presets {
+		if presets[i].Name == name || presets[i].Pattern == name {
+			return &presets[i], nil
+		}
+	}
+	names := make([]string, len(presets))
+	for i, p := range presets {
+		names[i] = p.Name
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown preset '%s'. Available: %s (run 'trabuco presets' for details)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestPresets_CoverCatalog(t *testing.T) {
+	presets := Presets()
+	if len(presets) != len(patternCatalog) {
+		t.Fatalf("expected one preset per pattern, got %d for %d patterns", len(presets), len(patternCatalog))
+	}
+
+	seen := map[string]bool{}
+	for _, p := range presets {
+		if seen[p.Name] {
+			t.Errorf("duplicate preset name %s", p.Name)
+		}
+		seen[p.Name] = true
+		if msg := config.ValidateModuleSelection(p.Modules); msg != "" {
+			t.Errorf("preset %s has an invalid module set: %s", p.Name, msg)
+		}
+	}
+
+	for _, name := range []string{"event-driven-postgres-kafka", "rest-api-postgres", "rest-api-nosql-mongodb", "ai-agent-rag-postgres-pgvector", "microservice-light"} {
+		if !seen[name] {
+			t.Errorf("expected preset %s", name)
+		}
+	}
+}
+
+func TestFindPreset(t *testing.T) {
+	p, err := FindPreset("event-driven-postgres-kafka")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Pattern != "event-driven" || p.Database != "postgresql" || p.MessageBroker != "kafka" {
+		t.Errorf("unexpected preset: %+v", p)
+	}
+
+	byPattern, err := FindPreset("Event-Driven")
+	if err != nil || byPattern.Name != p.Name {
+		t.Errorf("pattern name should resolve to the same preset, got %+v, %v", byPattern, err)
+	}
+
+	if _, err := FindPreset("serverless"); err == nil || !strings.Contains(err.Error(), "event-driven-postgres-kafka") {
+		t.Errorf("expected error listing presets, got %v", err)
+	}
+}
+
+func TestPresets_DoNotAliasCatalog(t *testing.T) {
+	p := Presets()[0]
+	p.Modules[0] = "Changed"
+	if patternCatalog[0].Modules[0] == "Changed" {
+		t.Error("presets must not share module slices with the catalog")
+	}
+}
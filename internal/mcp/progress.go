@@ -0,0 +1,155 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/orchestrator"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requestIDMeta is the _meta field the call-tool hook stores the
+// JSON-RPC request ID under, since tool handlers are not given it and
+// notifications/cancelled names the request by it.
+const requestIDMeta = "trabuco/requestId"
+
+// inflight holds the cancel functions of the running cancellable tool
+// calls by request ID, and the IDs of the ones a client cancelled until
+// the audit log takes them.
+var inflight = struct {
+	sync.Mutex
+	cancel    map[string]context.CancelFunc
+	cancelled map[string]bool
+}{cancel: map[string]context.CancelFunc{}, cancelled: map[string]bool{}}
+
+// cancellationHooks returns the hooks that let notifications/cancelled
+// reach tool handlers. Register the notification handler with
+// registerCancellation once the server exists.
+func cancellationHooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddBeforeCallTool(func(ctx context.Context, id any, req *mcp.CallToolRequest) {
+		if id == nil {
+			return
+		}
+		if req.Params.Meta == nil {
+			req.Params.Meta = &mcp.Meta{}
+		}
+		if req.Params.Meta.AdditionalFields == nil {
+			req.Params.Meta.AdditionalFields = map[string]any{}
+		}
+		req.Params.Meta.AdditionalFields[requestIDMeta] = fmt.Sprint(id)
+	})
+	return hooks
+}
+
+// registerCancellation cancels the context of the tool call a
+// notifications/cancelled names. Calls that are not cancellable, or
+// already finished, ignore it.
+func registerCancellation(s *server.MCPServer) {
+	s.AddNotificationHandler("notifications/cancelled", func(ctx context.Context, n mcp.JSONRPCNotification) {
+		id, ok := n.Params.AdditionalFields["requestId"]
+		if !ok {
+			return
+		}
+		inflight.Lock()
+		cancel := inflight.cancel[fmt.Sprint(id)]
+		if cancel != nil && auditing {
+			inflight.cancelled[fmt.Sprint(id)] = true
+		}
+		inflight.Unlock()
+		if cancel != nil {
+			cancel()
+		}
+	})
+}
+
+// cancellable derives a context that notifications/cancelled for req
+// cancels. The returned function releases it and must be called when
+// the handler returns.
+func cancellable(ctx context.Context, req mcp.CallToolRequest) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	id := requestID(req)
+	if id == "" {
+		return ctx, cancel
+	}
+	inflight.Lock()
+	inflight.cancel[id] = cancel
+	inflight.Unlock()
+	return ctx, func() {
+		inflight.Lock()
+		delete(inflight.cancel, id)
+		inflight.Unlock()
+		cancel()
+	}
+}
+
+// requestID returns the JSON-RPC ID the call-tool hook stored in req
+func requestID(req mcp.CallToolRequest) string {
+	if req.Params.Meta == nil {
+		return ""
+	}
+	id, _ := req.Params.Meta.AdditionalFields[requestIDMeta].(string)
+	return id
+}
+
+// takeCancelled reports whether the client cancelled req, forgetting it
+func takeCancelled(req mcp.CallToolRequest) bool {
+	id := requestID(req)
+	inflight.Lock()
+	defer inflight.Unlock()
+	cancelled := inflight.cancelled[id]
+	delete(inflight.cancelled, id)
+	return cancelled
+}
+
+// progressReporter sends notifications/progress for a tool call whose
+// request carried a progress token. Without a token, or outside a
+// client session, it does nothing.
+type progressReporter struct {
+	ctx   context.Context
+	srv   *server.MCPServer
+	token mcp.ProgressToken
+}
+
+func newProgressReporter(ctx context.Context, req mcp.CallToolRequest) *progressReporter {
+	p := &progressReporter{ctx: ctx, srv: server.ServerFromContext(ctx)}
+	if req.Params.Meta != nil {
+		p.token = req.Params.Meta.ProgressToken
+	}
+	return p
+}
+
+// send reports progress out of total with a human-readable message.
+// partial, when not nil, is attached under _meta as the tool's partial
+// result so far.
+func (p *progressReporter) send(progress, total float64, message string, partial any) {
+	if p.token == nil || p.srv == nil {
+		return
+	}
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      progress,
+		"total":         total,
+		"message":       message,
+	}
+	if partial != nil {
+		params["_meta"] = map[string]any{"trabuco/partial": partial}
+	}
+	_ = p.srv.SendNotificationToClient(p.ctx, "notifications/progress", params)
+}
+
+// phase forwards an orchestrator's phase progress, with the phase's
+// LLM cost so far, as percentage progress.
+func (p *progressReporter) phase(ev orchestrator.Progress) {
+	message := fmt.Sprintf("%s: %s", ev.Phase, ev.Stage)
+	if ev.Usage != nil {
+		message += fmt.Sprintf(" ($%.2f so far)", ev.Usage.CostUSD)
+	}
+	p.send(ev.Percent, 100, message, map[string]any{
+		"phase": ev.Phase.String(),
+		"stage": ev.Stage,
+		"usage": ev.Usage,
+	})
+}
@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestCancellation_ReachesToolHandler(t *testing.T) {
+	s := server.NewMCPServer("test", "0", server.WithToolCapabilities(false), server.WithHooks(cancellationHooks()))
+	registerCancellation(s)
+
+	started := make(chan struct{})
+	s.AddTool(mcp.NewTool("slow"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, cancel := cancellable(ctx, req)
+		defer cancel()
+		close(started)
+		select {
+		case <-ctx.Done():
+			return mcp.NewToolResultText("cancelled"), nil
+		case <-time.After(5 * time.Second):
+			return mcp.NewToolResultText("finished"), nil
+		}
+	})
+
+	done := make(chan mcp.JSONRPCMessage, 1)
+	go func() {
+		done <- s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow"}}`))
+	}()
+	<-started
+	s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7}}`))
+
+	select {
+	case msg := <-done:
+		resp, ok := msg.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("response = %#v", msg)
+		}
+		result := resp.Result.(*mcp.CallToolResult)
+		if text := result.Content[0].(mcp.TextContent).Text; text != "cancelled" {
+			t.Errorf("tool returned %q, want cancelled", text)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("notifications/cancelled did not reach the tool")
+	}
+
+	inflight.Lock()
+	defer inflight.Unlock()
+	if len(inflight.cancel) != 0 {
+		t.Errorf("finished calls must be unregistered, %d left", len(inflight.cancel))
+	}
+}
+
+func TestProgressReporter_WithoutTokenIsNoop(t *testing.T) {
+	p := newProgressReporter(context.Background(), mcp.CallToolRequest{})
+	// Neither a token nor a server in the context: must not panic.
+	p.send(1, 2, "halfway", map[string]any{"stage": "x"})
+}
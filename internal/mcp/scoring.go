@@ -0,0 +1,104 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// scoringTable holds the tunable numbers behind pattern scoring and the
+// recommended_config confidence. The defaults reproduce the original
+// hand-picked constants; `trabuco patterns tune` writes keyword weights
+// learned from recorded feedback to weights.json in the patterns directory,
+// and the other fields can be edited there by hand.
+type scoringTable struct {
+	// KeywordWeights maps pattern → keyword → weight. A keyword missing
+	// from the table weighs 1.
+	KeywordWeights map[string]map[string]float64 `json:"keyword_weights,omitempty"`
+	// MatchBonus is added per matched keyword once more than one matches,
+	// rewarding patterns with more overlap.
+	MatchBonus int `json:"match_bonus"`
+	// MinRecommendScore is the top score below which no config is recommended.
+	MinRecommendScore int `json:"min_recommend_score"`
+	// HighConfidence and MediumConfidence are the score thresholds of the
+	// confidence levels; anything lower is "low".
+	HighConfidence   int `json:"high_confidence"`
+	MediumConfidence int `json:"medium_confidence"`
+	// CloseMargin is the score gap to the runner-up at or below which the
+	// confidence drops one level.
+	CloseMargin int `json:"close_margin"`
+	// SemanticWeight is the share of the semantic similarity in a pattern's
+	// score when semantic matching is on; the keyword score keeps the rest.
+	SemanticWeight float64 `json:"semantic_weight"`
+	// MinSemanticScore is the similarity (0–100) a pattern without keyword
+	// matches needs to be suggested at all.
+	MinSemanticScore int `json:"min_semantic_score"`
+}
+
+// Keyword weights learned by tuning stay within these bounds, so no single
+// keyword can dominate or vanish from scoring.
+const (
+	minKeywordWeight = 0.5
+	maxKeywordWeight = 2.0
+)
+
+func defaultScoring() scoringTable {
+	return scoringTable{
+		MatchBonus:        5,
+		MinRecommendScore: 20,
+		HighConfidence:    70,
+		MediumConfidence:  50,
+		CloseMargin:       10,
+		SemanticWeight:    0.4,
+		MinSemanticScore:  40,
+	}
+}
+
+// weight returns the weight of keyword for pattern.
+func (t scoringTable) weight(pattern, keyword string) float64 {
+	if w, ok := t.KeywordWeights[pattern][keyword]; ok {
+		return w
+	}
+	return 1
+}
+
+// weightsPath returns the file the tuned scoring table is stored in.
+func weightsPath() string {
+	return filepath.Join(patternsDir(), "weights.json")
+}
+
+// loadScoring returns the scoring table in effect: the defaults overlaid
+// with weights.json when it exists. An unreadable file falls back to the
+// defaults so a bad edit never breaks suggest_architecture.
+func loadScoring() scoringTable {
+	t, err := readScoring(weightsPath())
+	if err != nil {
+		return defaultScoring()
+	}
+	return t
+}
+
+func readScoring(path string) (scoringTable, error) {
+	t := defaultScoring()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return defaultScoring(), fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return t, nil
+}
+
+func saveScoring(t scoringTable) error {
+	path := weightsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
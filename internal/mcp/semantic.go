@@ -0,0 +1,387 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// Semantic matching is opt-in (semantic_matching in ~/.trabuco/config.yaml).
+// It embeds the requirements and every catalog pattern, and blends the
+// cosine similarity into the keyword score, so requirements phrased with
+// synonyms ("send newsletters every night") still find their pattern.
+//
+//	semantic_matching: local   # offline concept vectors, no network calls
+//	semantic_matching: auto    # provider embeddings when credentials exist, local otherwise
+const (
+	semanticLocal = "local"
+	semanticAuto  = "auto"
+)
+
+// embedder turns texts into vectors whose cosine similarity reflects how
+// close their meaning is.
+type embedder interface {
+	Name() string
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+	// similarityRange is the cosine below which texts are unrelated and
+	// the one at or above which they are as close as catalog texts get.
+	similarityRange() (floor, ceil float64)
+}
+
+// patternMatching reports how suggest_architecture scored the patterns.
+type patternMatching struct {
+	Method         string  `json:"method"` // "keyword" or "semantic"
+	Embedder       string  `json:"embedder,omitempty"`
+	SemanticWeight float64 `json:"semantic_weight,omitempty"`
+	Note           string  `json:"note,omitempty"`
+}
+
+// selectEmbedder returns the embedder the user config asks for, or nil
+// when semantic matching is off. offline rules out provider embeddings.
+func selectEmbedder(offline bool) embedder {
+	uc, err := config.LoadUserConfig()
+	if err != nil {
+		return nil
+	}
+	switch uc.SemanticMatching {
+	case semanticLocal:
+		return localEmbedder{}
+	case semanticAuto:
+		if !offline {
+			if e := apiEmbedderFromEnv(); e != nil {
+				return e
+			}
+		}
+		return localEmbedder{}
+	default:
+		return nil
+	}
+}
+
+// patternDocument is the text a pattern is embedded as.
+func patternDocument(p ArchitecturePattern) string {
+	return p.Description + ". Use cases: " + strings.Join(p.UseCases, ", ") + ". " + strings.Join(p.keywords, ", ")
+}
+
+var (
+	patternVectorsMu sync.Mutex
+	patternVectors   = map[string][][]float64{} // embedder name → one vector per catalog pattern
+)
+
+// semanticScores returns the similarity of requirements to every catalog
+// pattern on a 0–100 scale, by pattern name. Pattern vectors are embedded
+// once per embedder and reused.
+func semanticScores(ctx context.Context, e embedder, requirements string) (map[string]int, error) {
+	patternVectorsMu.Lock()
+	cached := patternVectors[e.Name()]
+	patternVectorsMu.Unlock()
+
+	texts := []string{requirements}
+	if cached == nil {
+		for _, p := range patternCatalog {
+			texts = append(texts, patternDocument(p))
+		}
+	}
+	vectors, err := e.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("%s returned %d embeddings for %d texts", e.Name(), len(vectors), len(texts))
+	}
+	if cached == nil {
+		cached = vectors[1:]
+		patternVectorsMu.Lock()
+		patternVectors[e.Name()] = cached
+		patternVectorsMu.Unlock()
+	}
+
+	floor, ceil := e.similarityRange()
+	scores := make(map[string]int, len(patternCatalog))
+	for i, p := range patternCatalog {
+		sim := cosine(vectors[0], cached[i])
+		scaled := (sim - floor) / (ceil - floor)
+		scores[p.Name] = int(math.Round(100 * math.Max(0, math.Min(1, scaled))))
+	}
+	return scores, nil
+}
+
+// rankPatterns scores the catalog against requirements. With an embedder
+// the keyword and semantic scores are blended by table.SemanticWeight, and
+// a pattern only the embedder finds still qualifies. If the embedder fails
+// the keyword scores are used and the note says why.
+func rankPatterns(ctx context.Context, requirements string, table scoringTable, e embedder) ([]scoredPattern, *patternMatching) {
+	matching := &patternMatching{Method: "keyword"}
+	if e == nil || table.SemanticWeight <= 0 {
+		return scorePatternsWith(requirements, table), matching
+	}
+	semantic, err := semanticScores(ctx, e, requirements)
+	if err != nil && e.Name() != (localEmbedder{}).Name() {
+		matching.Note = fmt.Sprintf("%s embeddings unavailable (%v); used local embeddings", e.Name(), err)
+		e = localEmbedder{}
+		semantic, err = semanticScores(ctx, e, requirements)
+	}
+	if err != nil {
+		matching.Note = fmt.Sprintf("semantic matching unavailable: %v", err)
+		return scorePatternsWith(requirements, table), matching
+	}
+	matching.Method = "semantic"
+	matching.Embedder = e.Name()
+	matching.SemanticWeight = table.SemanticWeight
+
+	w := math.Min(table.SemanticWeight, 1)
+	var results []scoredPattern
+	for _, p := range patternCatalog {
+		keyword := p.weightedScore(requirements, table)
+		sem := semantic[p.Name]
+		if keyword == 0 && sem < table.MinSemanticScore {
+			continue
+		}
+		score := int(math.Round((1-w)*float64(keyword) + w*float64(sem)))
+		if score == 0 {
+			continue
+		}
+		reasoning := fmt.Sprintf("Semantically similar to the requirements (%d%%)", sem)
+		if keyword > 0 {
+			reasoning = fmt.Sprintf("%s; semantic similarity %d%%", buildPatternReasoning(p, requirements), sem)
+		}
+		results = append(results, scoredPattern{
+			ArchitecturePattern: p,
+			Score:               score,
+			Reasoning:           reasoning,
+		})
+	}
+	return topPatterns(results), matching
+}
+
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// localEmbedder embeds offline. It is not a neural model: words are stemmed
+// and mapped to the architecture concepts of conceptLexicon, and concepts
+// and stems are hashed into a fixed-size vector. That is enough to relate
+// "newsletters every night" to scheduled background jobs without a model
+// runtime or network access.
+type localEmbedder struct{}
+
+const localDimensions = 512
+
+func (localEmbedder) Name() string { return "local" }
+
+func (localEmbedder) similarityRange() (float64, float64) { return 0.1, 0.9 }
+
+func (localEmbedder) Embed(_ context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		v := make([]float64, localDimensions)
+		for _, word := range tokenize(text) {
+			stem := stemWord(word)
+			v[featureIndex("w:"+stem)] += 0.5
+			for _, concept := range conceptIndex[stem] {
+				v[featureIndex("c:"+concept)]++
+			}
+		}
+		vectors[i] = v
+	}
+	return vectors, nil
+}
+
+func featureIndex(feature string) int {
+	h := fnv.New32a()
+	h.Write([]byte(feature))
+	return int(h.Sum32() % localDimensions)
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+}
+
+// stemWord strips common English inflections so "jobs", "scheduled" and
+// "scheduling" meet "job" and "schedule".
+func stemWord(word string) string {
+	for _, suffix := range []string{"ing", "ed", "es", "s"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			if suffix == "s" && strings.HasSuffix(word, "ss") {
+				break
+			}
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+	if len(word) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+	return word
+}
+
+// conceptLexicon groups the words requirements use for each capability a
+// pattern offers. Words are stemmed into conceptIndex at startup.
+var conceptLexicon = map[string][]string{
+	"background-jobs": {"job", "worker", "background", "queue", "schedule", "scheduler", "cron", "batch", "delay", "task",
+		"email", "mail", "newsletter", "notification", "notify", "reminder", "nightly", "night", "daily", "hourly", "weekly",
+		"periodic", "recurring", "retry", "offload", "deferred", "later"},
+	"messaging": {"event", "kafka", "rabbitmq", "rabbit", "sqs", "pubsub", "message", "broker", "topic", "stream",
+		"subscribe", "subscriber", "publish", "publisher", "consumer", "producer", "async", "asynchronous", "cqrs",
+		"sourcing", "decouple", "react", "listen", "listener", "fan"},
+	"http-api": {"rest", "api", "endpoint", "http", "crud", "web", "backend", "route", "json", "openapi", "client",
+		"mobile", "frontend", "request", "expose", "serve"},
+	"relational": {"sql", "database", "postgresql", "postgres", "mysql", "mariadb", "relational", "table", "transaction",
+		"migration", "persist", "persistence", "store", "record", "order", "inventory", "ledger", "account", "crud"},
+	"document-store": {"nosql", "mongodb", "mongo", "document", "redis", "dynamodb", "cassandra", "cache", "flexible", "schemaless",
+		"key", "value", "content", "unstructured"},
+	"stateless": {"stateless", "gateway", "proxy", "lightweight", "aggregation", "aggregate", "routing", "facade",
+		"bff", "forward", "relay", "thin", "microservice"},
+	"data-pipeline": {"headless", "etl", "pipeline", "ingest", "ingestion", "import", "export", "transform",
+		"sync", "processor", "crunch", "data", "feed", "file", "csv"},
+	"ai": {"ai", "agent", "llm", "claude", "gpt", "chatbot", "chat", "assistant", "intelligent", "natural",
+		"language", "mcp", "tool", "guardrail", "prompt", "conversation", "copilot", "a2a"},
+	"retrieval": {"rag", "retrieval", "knowledge", "vector", "embedding", "semantic", "search", "faq", "qa",
+		"question", "answer", "documentation", "docs", "pgvector", "qdrant", "grounded"},
+	"enterprise": {"enterprise", "complete", "full", "everything", "all", "complex", "platform", "kitchen", "sink"},
+}
+
+var conceptIndex = func() map[string][]string {
+	index := map[string][]string{}
+	for concept, words := range conceptLexicon {
+		for _, w := range words {
+			stem := stemWord(w)
+			if !slices.Contains(index[stem], concept) {
+				index[stem] = append(index[stem], concept)
+			}
+		}
+	}
+	return index
+}()
+
+// apiEmbedder calls an OpenAI-compatible /embeddings endpoint.
+type apiEmbedder struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+const (
+	openAIEmbeddingsURL       = "https://api.openai.com/v1"
+	openRouterEmbeddingsURL   = "https://openrouter.ai/api/v1"
+	defaultEmbeddingModel     = "text-embedding-3-small"
+	openRouterEmbeddingPrefix = "openai/"
+)
+
+// apiEmbedderFromEnv returns a provider embedder for the first embeddings
+// credential in the environment — OPENAI_API_KEY, then OPENROUTER_API_KEY —
+// or nil. Anthropic has no embeddings API, so ANTHROPIC_API_KEY is not used.
+func apiEmbedderFromEnv() *apiEmbedder {
+	if key := os.Getenv("OPENAI_API_KEY"); key != "" {
+		return newAPIEmbedder(openAIEmbeddingsURL, key, defaultEmbeddingModel)
+	}
+	if key := os.Getenv("OPENROUTER_API_KEY"); key != "" {
+		return newAPIEmbedder(openRouterEmbeddingsURL, key, openRouterEmbeddingPrefix+defaultEmbeddingModel)
+	}
+	return nil
+}
+
+func newAPIEmbedder(baseURL, apiKey, model string) *apiEmbedder {
+	return &apiEmbedder{
+		client:  &http.Client{Timeout: 15 * time.Second},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+	}
+}
+
+func (e *apiEmbedder) Name() string { return e.model }
+
+// Provider embeddings of short texts rarely fall below 0.1 even when
+// unrelated, and related ones sit around 0.5.
+func (e *apiEmbedder) similarityRange() (float64, float64) { return 0.15, 0.55 }
+
+type embeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (e *apiEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	body, err := json.Marshal(embeddingsRequest{Model: e.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var parsed embeddingsResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("unexpected response (HTTP %d)", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil && parsed.Error.Message != "" {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	vectors := make([][]float64, len(texts))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("no embedding returned for input %d", i)
+		}
+	}
+	return vectors, nil
+}
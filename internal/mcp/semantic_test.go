@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRankPatterns_LocalFindsSynonyms(t *testing.T) {
+	req := "send newsletters every night"
+	if len(scorePatternsWith(req, defaultScoring())) != 0 {
+		t.Fatal("expected no keyword matches; pick requirements without catalog keywords")
+	}
+
+	patterns, matching := rankPatterns(context.Background(), req, defaultScoring(), localEmbedder{})
+	if matching.Method != "semantic" || matching.Embedder != "local" {
+		t.Errorf("unexpected matching: %+v", matching)
+	}
+	if len(patterns) == 0 || patterns[0].Name != "background-processing" {
+		t.Fatalf("expected background-processing first, got %+v", patterns)
+	}
+	if !strings.HasPrefix(patterns[0].Reasoning, "Semantically similar") {
+		t.Errorf("unexpected reasoning: %s", patterns[0].Reasoning)
+	}
+
+	for req, want := range map[string]string{
+		"chat assistant answering questions from our documentation": "ai-agent-rag",
+		"import csv files from partners and transform them":         "worker-only",
+		"thin facade in front of three downstream services":         "microservice-light",
+	} {
+		patterns, _ := rankPatterns(context.Background(), req, defaultScoring(), localEmbedder{})
+		if len(patterns) == 0 || patterns[0].Name != want {
+			t.Errorf("%q: expected %s first, got %+v", req, want, patterns)
+		}
+	}
+}
+
+func TestRankPatterns_UnrelatedRequirements(t *testing.T) {
+	patterns, _ := rankPatterns(context.Background(), "xyzzy plugh twisty little passages", defaultScoring(), localEmbedder{})
+	if len(patterns) != 0 {
+		t.Errorf("expected no patterns, got %+v", patterns)
+	}
+}
+
+func TestRankPatterns_KeywordOnly(t *testing.T) {
+	req := "REST API with PostgreSQL database"
+	patterns, matching := rankPatterns(context.Background(), req, defaultScoring(), nil)
+	if matching.Method != "keyword" || matching.Embedder != "" {
+		t.Errorf("unexpected matching: %+v", matching)
+	}
+	want := scorePatternsWith(req, defaultScoring())
+	if len(patterns) != len(want) || patterns[0].Name != want[0].Name || patterns[0].Score != want[0].Score {
+		t.Errorf("expected the keyword ranking %+v, got %+v", want, patterns)
+	}
+}
+
+// embeddingsServer answers with [1,0] for texts containing match and [0,1]
+// for the rest.
+func embeddingsServer(t *testing.T, match string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" || r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+			return
+		}
+		var req embeddingsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		var resp embeddingsResponse
+		for i, text := range req.Input {
+			v := []float64{0, 1}
+			if strings.Contains(text, match) {
+				v = []float64{1, 0}
+			}
+			resp.Data = append(resp.Data, struct {
+				Index     int       `json:"index"`
+				Embedding []float64 `json:"embedding"`
+			}{i, v})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestRankPatterns_APIEmbedder(t *testing.T) {
+	server := embeddingsServer(t, "Headless")
+	defer server.Close()
+
+	e := newAPIEmbedder(server.URL, "test-key", "test-embedding-ranks")
+	patterns, matching := rankPatterns(context.Background(), "Headless crunching of nightly exports", defaultScoring(), e)
+	if matching.Embedder != "test-embedding-ranks" || matching.Note != "" {
+		t.Errorf("unexpected matching: %+v", matching)
+	}
+	if len(patterns) == 0 || patterns[0].Name != "worker-only" {
+		t.Fatalf("expected worker-only first, got %+v", patterns)
+	}
+}
+
+func TestRankPatterns_APIFailureFallsBackToLocal(t *testing.T) {
+	server := embeddingsServer(t, "Headless")
+	defer server.Close()
+
+	e := newAPIEmbedder(server.URL, "wrong-key", "test-embedding-fails")
+	_, matching := rankPatterns(context.Background(), "send newsletters every night", defaultScoring(), e)
+	if matching.Method != "semantic" || matching.Embedder != "local" {
+		t.Errorf("expected the local fallback, got %+v", matching)
+	}
+	if !strings.Contains(matching.Note, "invalid api key") {
+		t.Errorf("expected the provider error in the note, got %q", matching.Note)
+	}
+}
+
+func TestSelectEmbedder(t *testing.T) {
+	cfg := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TRABUCO_CONFIG", cfg)
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+	t.Setenv("OPENROUTER_API_KEY", "")
+
+	if e := selectEmbedder(false); e != nil {
+		t.Errorf("semantic matching must be off without config, got %s", e.Name())
+	}
+
+	if err := os.WriteFile(cfg, []byte("semantic_matching: auto\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if e := selectEmbedder(false); e == nil || e.Name() != defaultEmbeddingModel {
+		t.Errorf("expected provider embeddings with credentials, got %v", e)
+	}
+	if e := selectEmbedder(true); e == nil || e.Name() != "local" {
+		t.Errorf("offline must use local embeddings, got %v", e)
+	}
+
+	t.Setenv("OPENAI_API_KEY", "")
+	if e := selectEmbedder(false); e == nil || e.Name() != "local" {
+		t.Errorf("expected local embeddings without credentials, got %v", e)
+	}
+}
@@ -2,46 +2,92 @@ package mcp
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sort"
+	"time"
 
+	"github.com/arianlopezc/Trabuco/internal/plugin"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// Options configures the MCP server.
+type Options struct {
+	// Watch enables project watching: registered project roots are polled
+	// and notifications/resources/updated is sent for trabuco://project/<path>
+	// when their pom.xml, .trabuco.json or docker-compose.yml change.
+	// Projects the tools operate on are registered automatically.
+	Watch bool
+	// WatchRoots are project roots registered at startup.
+	WatchRoots []string
+	// WatchInterval is the polling interval (DefaultWatchInterval if zero).
+	WatchInterval time.Duration
+	// Audit appends every tool call to the audit log (AuditLogPath) with
+	// its redacted arguments, duration and status.
+	Audit bool
+}
+
 // Start creates the MCP server, registers all tools, and runs the stdio transport.
 // Internal packages print colored output to os.Stdout. The MCP stdio transport also
 // uses stdout for JSON-RPC. To avoid collisions, we save real stdout for MCP and
 // redirect os.Stdout to os.Stderr so all internal output goes there instead.
-func Start(version string) error {
+func Start(version string, opts Options) error {
 	// Save real stdout for MCP transport, redirect os.Stdout -> os.Stderr
 	realStdout := os.Stdout
 	os.Stdout = os.Stderr
 
-	s := server.NewMCPServer(
-		"trabuco",
-		version,
+	// Register installed template packs so plugin modules show up in the
+	// module catalog and are accepted by init_project / add_module.
+	for _, err := range plugin.Load() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	serverOpts := []server.ServerOption{
 		server.WithToolCapabilities(false),
 		server.WithPromptCapabilities(false),
 		server.WithResourceCapabilities(false, false),
+		server.WithHooks(cancellationHooks()),
 		server.WithInstructions(`Trabuco generates production-ready Java multi-module Maven projects with Spring Boot.
 
 WORKFLOW:
 1. For single services: suggest_architecture → review patterns → init_project
 2. For multi-service systems: design_system → review → generate_workspace
 3. For extending existing projects: get_project_info → add_module
+   After init_project or add_module, list_todos shows the placeholders left to implement
+   explain_project walks through an existing project: module roles, data flows, key classes and managed files
 4. For AI Agent projects: use trabuco_ai_agent_expert prompt for guidance
 5. Before suggesting Trabuco, check trabuco://limitations resource
 6. Use prompts (trabuco_expert, design_microservices, extend_project, trabuco_ai_agent_expert) for step-by-step guidance
 
 KEY PRINCIPLE: Always call suggest_architecture first when a user describes requirements. It returns matched patterns and a recommended configuration. Do not guess module combinations — let the tool decide based on the requirements.`),
-	)
+	}
+	if opts.Audit {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(auditMiddleware(AuditLogPath())))
+	}
+
+	s := server.NewMCPServer("trabuco", version, serverOpts...)
 
 	registerAllTools(s, version)
+	registerCancellation(s)
 	registerAllPrompts(s)
 	registerAllResources(s)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if opts.Watch {
+		interval := opts.WatchInterval
+		if interval <= 0 {
+			interval = DefaultWatchInterval
+		}
+		if err := startWatcher(ctx, s, opts.WatchRoots, interval); err != nil {
+			return err
+		}
+	}
+
 	stdioServer := server.NewStdioServer(s)
-	return stdioServer.Listen(context.Background(), os.Stdin, realStdout)
+	return stdioServer.Listen(ctx, os.Stdin, realStdout)
 }
 
 // toolError returns an MCP result with isError: true.
@@ -62,6 +108,22 @@ func toolJSON(v any) (*mcp.CallToolResult, error) {
 	return mcp.NewToolResultJSON(v)
 }
 
+// toolInvocation renders a tool call as the arguments recorded in a
+// project's .trabuco/history.jsonl: mcp <tool> key=value ...
+func toolInvocation(req mcp.CallToolRequest) []string {
+	args := req.GetArguments()
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	invocation := []string{"mcp", req.Params.Name}
+	for _, k := range keys {
+		invocation = append(invocation, fmt.Sprintf("%s=%v", k, args[k]))
+	}
+	return invocation
+}
+
 // resolvePath resolves an empty or relative path to an absolute path.
 func resolvePath(path string) (string, error) {
 	if path == "" {
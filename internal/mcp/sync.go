@@ -21,7 +21,7 @@ func registerSyncProject(s *server.MCPServer, version string) {
 				"WHEN TO USE: An existing Trabuco project was generated with an older CLI, and the user wants the skills, subagents, prompts, hooks, and review scaffolding to match the current CLI. "+
 				"Also use after `trabuco add <module>` to pick up new module-specific AI files (e.g., add-tool.md when AIAgent is added). "+
 				"ADDITIVE ONLY: Existing files are never modified or deleted. To refresh a file like CLAUDE.md, the user must delete it before syncing. "+
-				"JURISDICTION: Only AI-tooling files (.ai/, .claude/, .cursor/, .codex/, .agents/, .github/instructions/, .github/skills/, review-checks.sh, CLAUDE.md, AGENTS.md, and a few specific .github files) are in scope. "+
+				"JURISDICTION: Only AI-tooling files (.ai/, .claude/, .cursor/, .codex/, .agents/, .github/instructions/, .github/skills/, review-checks.sh, CLAUDE.md, AGENTS.md, GEMINI.md, CONVENTIONS.md, .rules, .aider.conf.yml, and a few specific .github files) are in scope. "+
 				"Java source, POMs, migrations, application.yml, docker-compose.yml, CI workflows (except copilot-setup-steps.yml), and all other business/infrastructure files are NEVER touched. "+
 				"USAGE: Call with apply=false first to preview the plan; if the user confirms, call again with apply=true. The tool is idempotent — running twice with apply=true is equivalent to running once.",
 		),
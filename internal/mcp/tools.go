@@ -2,7 +2,7 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,7 +14,10 @@ import (
 	"github.com/arianlopezc/Trabuco/internal/config"
 	"github.com/arianlopezc/Trabuco/internal/doctor"
 	"github.com/arianlopezc/Trabuco/internal/generator"
+	"github.com/arianlopezc/Trabuco/internal/graph"
 	"github.com/arianlopezc/Trabuco/internal/java"
+	"github.com/arianlopezc/Trabuco/internal/todos"
+	"github.com/arianlopezc/Trabuco/internal/update"
 	"github.com/arianlopezc/Trabuco/internal/utils"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -27,10 +30,15 @@ var (
 
 func registerAllTools(s *server.MCPServer, version string) {
 	registerInitProject(s, version)
+	registerValidateConfig(s)
 	registerAddModule(s, version)
 	registerSuggestArchitecture(s)
 	registerRunDoctor(s, version)
 	registerGetProjectInfo(s)
+	registerSuggestNextSteps(s, version)
+	registerExplainProject(s)
+	registerListTodos(s)
+	registerGetDependencyGraph(s)
 	registerListModules(s)
 	registerCheckDocker(s)
 	registerGetVersion(s, version)
@@ -41,6 +49,7 @@ func registerAllTools(s *server.MCPServer, version string) {
 	registerSyncProject(s, version)
 	registerMigrationTools(s, version)
 	registerAddCommandTools(s)
+	registerGenerateCommandTools(s)
 }
 
 // ---------- Project Management Tools ----------
@@ -65,28 +74,31 @@ func registerInitProject(s *server.MCPServer, version string) {
 				"API (REST), Worker (background jobs), EventConsumer (message processing). Modules have strict dependency boundaries "+
 				"enforced by Maven Enforcer and ArchUnit tests. "+
 				"Call list_modules first to see available modules with descriptions, or call suggest_architecture with a natural language "+
-				"description to get a recommended module combination.",
+				"description to get a recommended module combination. "+
+				"Call validate_config with the same parameters before generating to catch a missing broker or database and module conflicts.",
 		),
 		mcp.WithString("name",
 			mcp.Description("Project name (lowercase, hyphens allowed, e.g. 'my-platform')"),
 			mcp.Required(),
 		),
 		mcp.WithString("group_id",
-			mcp.Description("Maven group ID (e.g. 'com.company.project')"),
-			mcp.Required(),
+			mcp.Description("Maven group ID (e.g. 'com.company.project'). May be omitted when the config profile sets group_id_prefix"),
 		),
 		mcp.WithString("modules",
-			mcp.Description("Comma-separated modules: Model, SQLDatastore, NoSQLDatastore, Shared, API, Worker, Events, EventConsumer, Jobs"),
+			mcp.Description("Comma-separated modules: Model, SQLDatastore, NoSQLDatastore, Search, Shared, API, Worker, Events, EventConsumer, Jobs"),
 			mcp.Required(),
 		),
 		mcp.WithString("database",
-			mcp.Description("SQL database type: postgresql, mysql, generic (required if SQLDatastore selected)"),
+			mcp.Description("SQL database type: postgresql, mysql, mariadb, generic (required if SQLDatastore selected)"),
+		),
+		mcp.WithString("db_version",
+			mcp.Description("Pin the SQL database image version for docker-compose, CI and Testcontainers, e.g. 16 (PostgreSQL), 8.4 (MySQL) or 11.4 (MariaDB). Needs SQLDatastore with postgresql, mysql or mariadb (default: the version Trabuco ships with)"),
 		),
 		mcp.WithString("nosql_database",
-			mcp.Description("NoSQL database type: mongodb, redis (required if NoSQLDatastore selected)"),
+			mcp.Description("NoSQL database type: mongodb, redis, dynamodb, cassandra (required if NoSQLDatastore selected)"),
 		),
 		mcp.WithString("message_broker",
-			mcp.Description("Message broker: kafka, rabbitmq, sqs, pubsub (required if EventConsumer selected)"),
+			mcp.Description("Message broker: kafka, rabbitmq, sqs, pubsub, redis-streams, nats (required if EventConsumer selected)"),
 		),
 		mcp.WithString("vector_store",
 			mcp.Description("Vector RAG backend for AIAgent: pgvector, qdrant, mongodb, or none. Default: none (keyword retrieval). pgvector auto-adds SQLDatastore + forces postgresql; mongodb requires Atlas (see docs/vector-rag.md)"),
@@ -94,35 +106,258 @@ func registerInitProject(s *server.MCPServer, version string) {
 		mcp.WithString("java_version",
 			mcp.Description("Java version: 21, 25, or 26 (default: 21)"),
 		),
+		mcp.WithString("language",
+			mcp.Description("Application language: java or kotlin (default: java). Kotlin uses data classes instead of Immutables and kotlin-maven-plugin; infrastructure config stays Java"),
+		),
 		mcp.WithString("ai_agents",
-			mcp.Description("Comma-separated AI agent configs to include: claude, cursor, copilot, codex"),
+			mcp.Description("Comma-separated AI agent configs to include: claude, cursor, copilot, codex, gemini, aider, zed"),
+		),
+		mcp.WithString("ci",
+			mcp.Description("CI provider to generate: github (default: none)"),
+		),
+		mcp.WithBoolean("native",
+			mcp.Description("Add GraalVM native image support: a 'native' Maven profile, AOT reflection hints, Dockerfile.native per runtime module and a native CI job (default: false)"),
+		),
+		mcp.WithBoolean("pagination",
+			mcp.Description("Add a paginated, sorted and filtered list endpoint (GET /api/placeholders/page) with page request/response DTOs, a sort whitelist and query-by-example filtering. Needs API and SQLDatastore or MongoDB (default: false)"),
+		),
+		mcp.WithBoolean("rate_limit",
+			mcp.Description("Add per-client rate limiting to the API: a Bucket4j token bucket per authenticated principal (or client IP when anonymous), default and per-client limits under app.rate-limit in application.yml, and 429 problem responses with Retry-After. Needs API (default: false)"),
+		),
+		mcp.WithBoolean("idempotency",
+			mcp.Description("Add Idempotency-Key handling to API POST endpoints: a retry with the same key gets the stored response of the first request (Idempotent-Replayed: true), a reused key with a different body gets 422 and a concurrent retry 409. Keys live in an idempotency_keys table with a Flyway migration, or in Redis when there is no SQLDatastore; settings under app.idempotency in application.yml. Needs API and SQLDatastore or a Redis service (default: false)"),
+		),
+		mcp.WithBoolean("kafka_transactions",
+			mcp.Description("Add exactly-once Kafka processing to the EventConsumer: PlaceholderEventProcessor, a consume-process-produce listener whose output records and consumed offsets commit in one Kafka transaction, a transactional idempotent producer, read_committed consumers, and transaction ids under app.kafka.transactions in application.yml. Needs message_broker kafka and EventConsumer (default: false)"),
+		),
+		mcp.WithBoolean("perf",
+			mcp.Description("Add a k6 load test of the Placeholder endpoints under perf/ with thresholds as a performance baseline, perf/run.sh to run it against the docker-compose stack, and a manually triggered GitHub Actions workflow that uploads the results. Needs API and a datastore (default: false)"),
+		),
+		mcp.WithBoolean("devcontainer",
+			mcp.Description("Add .devcontainer/ (devcontainer.json and a Dockerfile) with the project's JDK, Maven, the Docker-in-Docker feature and forwarded ports for the selected modules, so the project opens ready to build in GitHub Codespaces or a local dev container (default: false)"),
+		),
+		mcp.WithBoolean("codeowners",
+			mcp.Description("Add a CODEOWNERS file mapping each module's directory to a placeholder team (@your-org/<module>-owners) and a boundary doc per module under docs/modules/ (what it owns, what it builds on, who uses it, the ArchUnit rules guarding it). add_module appends the entries of new modules and keeps the owners already set (default: false)"),
+		),
+		mcp.WithString("task_runner",
+			mcp.Description("Root file of developer shortcuts kept in sync with the modules when add_module runs: up/down (docker-compose), run-api, run-worker and the other runtime modules, test, fmt, verify. One of: make (Makefile), task (Taskfile.yml), none (default: make)"),
+		),
+		mcp.WithBoolean("auditing",
+			mcp.Description("Add created_by and deleted_at columns to the baseline migration, Spring Data JDBC auditing (@EnableJdbcAuditing with an AuditorAware bean) and soft deletes for the Placeholder resource. Needs SQLDatastore (default: false)"),
+		),
+		mcp.WithBoolean("read_replica",
+			mcp.Description("Add a read-only replica datasource to SQLDatastore: @Transactional(readOnly = true) work is routed to it through a LazyConnectionDataSourceProxy, each pool gets its own HikariCP settings, and on PostgreSQL docker-compose adds a streaming replica. Needs SQLDatastore with postgresql, mysql or mariadb (default: false)"),
+		),
+		mcp.WithString("static_analysis",
+			mcp.Description("Compile-time static analysis: errorprone (Error Prone plus NullAway at ERROR on main sources, with @Nullable marking values that may be null) or none. Java projects only (default: none)"),
+		),
+		mcp.WithBoolean("jpms",
+			mcp.Description("Generate a module-info.java per Maven module, derived from its imports: requires for the libraries and project modules it uses, qualified exports for only the packages other project modules use (Model exports everything). Modules using a library without a known module name stay on the classpath. Java projects only (default: false)"),
+		),
+		mcp.WithBoolean("enable_preview",
+			mcp.Description("Compile, test and run with --enable-preview to use the preview language and API features of the Java version (Java 21: string templates, scoped values, structured concurrency...; Java 24: scoped values, primitive types in patterns, flexible constructor bodies...). Java projects only, not with native (default: false)"),
+		),
+		mcp.WithBoolean("coverage_gates",
+			mcp.Description("Fail the build and CI when a module's JaCoCo line or branch coverage drops below its minimum (jacoco.minimum.* properties, Immutables and config classes excluded). Set false for prototypes to keep only the reports (default: true)"),
+		),
+		mcp.WithString("cache",
+			mcp.Description("Cache PlaceholderService lookups with Spring Cache: caffeine (in-process, per instance) or redis (shared across instances; reuses the Redis of a Redis datastore or Redis Streams broker, or adds one to docker-compose) or none. Needs Shared and SQLDatastore or NoSQLDatastore (default: none)"),
+		),
+		mcp.WithString("job_storage",
+			mcp.Description("Where the Worker's JobRunr stores jobs: postgres or mysql (the SQLDatastore database when it is that engine, otherwise a database container of its own), existing-sql (the SQLDatastore datasource) or in-memory (lost on restart and not shared with the API; for demos). Needs Worker (default: the SQL or MongoDB datastore, else a PostgreSQL container)"),
+			mcp.Enum(config.JobStoragePostgres, config.JobStorageMySQL, config.JobStorageExistingSQL, config.JobStorageInMemory),
+		),
+		mcp.WithBoolean("jobrunr_dashboard",
+			mcp.Description("Turn the Worker's JobRunr dashboard on by default, with basic auth from JOBRUNR_DASHBOARD_USERNAME/PASSWORD, and publish port 8000 from the docker-compose worker service. Needs Worker (default: false, the dashboard stays off until JOBRUNR_DASHBOARD_ENABLED=true)"),
+		),
+		mcp.WithBoolean("jobrunr_batches",
+			mcp.Description("Tune the Worker's JobRunr server for bulk workloads: worker count, poll interval, retries and succeeded/deleted job retention, each overridable by environment variable. Needs Worker (default: false)"),
+		),
+		mcp.WithString("jobrunr_carbon_area",
+			mcp.Description("Enable JobRunr carbon-aware scheduling for an ENTSO-E area code such as BE, DE or US-CA: jobs scheduled with a CarbonAware margin run in its greenest hours. Needs Worker (default: off)"),
+		),
+		mcp.WithString("kafka_consumer_group",
+			mcp.Description("Consumer group naming convention of the EventConsumer's Kafka listener; {project} stands for the project name, e.g. '{project}.orders-consumers'. Needs message_broker kafka and EventConsumer (default: {project}-consumers)"),
+		),
+		mcp.WithNumber("kafka_concurrency",
+			mcp.Description("Kafka consumer threads per EventConsumer instance, at most 64. Threads beyond the topic's partitions stay idle. Needs message_broker kafka and EventConsumer (default: 3)"),
+		),
+		mcp.WithNumber("kafka_partitions",
+			mcp.Description("Partitions of the topics the docker-compose Kafka broker creates. Needs message_broker kafka and EventConsumer (default: kafka_concurrency)"),
+		),
+		mcp.WithNumber("kafka_max_poll_records",
+			mcp.Description("Records per Kafka poll (max.poll.records); lower it when handling a record is slow. Needs message_broker kafka and EventConsumer (default: 500)"),
+		),
+		mcp.WithString("kafka_max_poll_interval",
+			mcp.Description("Longest time between Kafka polls before the consumer is evicted from the group (max.poll.interval.ms), as a duration such as 90s or 10m. Needs message_broker kafka and EventConsumer (default: 5m)"),
+		),
+		mcp.WithString("kafka_ack_mode",
+			mcp.Description("When the EventConsumer commits Kafka offsets: batch (after the records of a poll), record (after each record) or manual (PlaceholderEventListener takes an Acknowledgment and acknowledges each record). Needs message_broker kafka and EventConsumer (default: batch)"),
+			mcp.Enum(config.KafkaAckBatch, config.KafkaAckRecord, config.KafkaAckManual),
+		),
+		mcp.WithBoolean("sqs_fifo",
+			mcp.Description("Create FIFO SQS queues (<name>.fifo) with content-based deduplication. EventPublisher sends the events of one placeholder in one message group, so they are consumed in order. Needs message_broker sqs (default: false)"),
+		),
+		mcp.WithBoolean("sqs_dlq",
+			mcp.Description("Give each SQS queue a dead-letter queue (<name>-dlq) and a redrive policy in the LocalStack init scripts. Needs message_broker sqs (default: false)"),
+		),
+		mcp.WithNumber("sqs_max_receive_count",
+			mcp.Description("Receives before SQS moves a failing message to the dead-letter queue, 1 to 1000. Needs sqs_dlq (default: 5)"),
+		),
+		mcp.WithBoolean("pubsub_ordering",
+			mcp.Description("Publish Pub/Sub events with the placeholder ID as ordering key, enable message ordering on the publisher and create the emulator subscriptions with enableMessageOrdering. Needs message_broker pubsub (default: false)"),
+		),
+		mcp.WithBoolean("pubsub_exactly_once",
+			mcp.Description("Create the Pub/Sub subscriptions with exactly-once delivery; PlaceholderEventListener waits for Pub/Sub to confirm each ack. Needs message_broker pubsub (default: false)"),
+		),
+		mcp.WithString("secrets",
+			mcp.Description("Load credentials from a secrets manager: vault (Spring Cloud Vault, adds a dev-mode Vault to docker-compose), aws (AWS Secrets Manager), gcp (GCP Secret Manager), auto (aws with sqs, gcp with pubsub, vault otherwise) or none. Needs a runtime module (default: none)"),
+		),
+		mcp.WithBoolean("observability",
+			mcp.Description("Export OTLP traces by default and add a Prometheus + Grafana + Tempo stack under the 'observability' docker-compose profile, with dashboards in observability/ (default: false)"),
+		),
+		mcp.WithString("license",
+			mcp.Description("Project license: apache2, mit, or proprietary:<header-file>. Adds LICENSE, a header on every .java and .kt file, and a Spotless licenseHeader rule"),
+		),
+		mcp.WithString("image_registry",
+			mcp.Description("Registry prefix for Docker image names in the generated docs, CI and Jib configuration, e.g. ghcr.io/acme"),
+		),
+		mcp.WithString("image_builder",
+			mcp.Description("How runtime modules become container images: dockerfile (a Dockerfile per module) or jib (jib-maven-plugin in each runtime module's pom.xml, no Dockerfile or Docker daemon; image naming and tagging from IMAGE_REGISTRY and IMAGE_TAG). The CI workflow builds the images the same way (default: dockerfile)"),
+		),
+		mcp.WithString("log_format",
+			mcp.Description("Log encoding of the runtime modules outside the local profile: json (Logstash JSON), ecs (Elastic Common Schema, Spring Boot structured logging) or plain (text lines). Every format carries the correlation and trace IDs, and logging/ holds Fluent Bit parsers for it (default: json)"),
+		),
+		mcp.WithString("architecture",
+			mcp.Description("Build layout: multi-module (a Maven module per Trabuco module) or modulith (one Spring Boot application in App/ whose packages are the modules, with Spring Modulith verifying their boundaries; needs API and supports neither AIAgent, ClientSDK, Kotlin, jpms nor native) (default: multi-module)"),
+		),
+		mcp.WithString("profile",
+			mcp.Description("Defaults profile from ~/.trabuco/config.yaml (default: its default_profile). Explicit parameters win over profile values"),
+		),
+		mcp.WithString("recommendation_id",
+			mcp.Description("recommended_config.recommendation_id from suggest_architecture, when it returned one. Records locally whether the recommended modules were kept"),
 		),
 		mcp.WithString("output_dir",
 			mcp.Description("Directory to create the project in (default: current directory)"),
 		),
+		mcp.WithString("on_exists",
+			mcp.Description("What to do when the project directory already exists: fail (default), force (generate afresh; the old directory is moved to <name>.trabuco-backup-<timestamp>, returned as summary.replaced_backup) or merge (write only missing files; existing files that differ are kept and listed in summary.conflicts)"),
+			mcp.Enum(generator.OnExistsModes...),
+		),
 		mcp.WithBoolean("skip_build",
 			mcp.Description("Skip running Maven build after generation (default: true)"),
 		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("Preview without writing anything: returns the full file list, the docker-compose services, the parent POM properties and file, class and line counts (default: false)"),
+		),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		name := req.GetString("name", "")
-		groupID := req.GetString("group_id", "")
+
+		// Explicit parameters win; the config profile fills the rest
+		profile, err := config.LoadProfile(req.GetString("profile", ""))
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		defaults := profile.Defaults(name)
+		arg := func(key, fallback string) string {
+			if v := req.GetString(key, ""); v != "" {
+				return v
+			}
+			if v, ok := defaults[key]; ok {
+				return v
+			}
+			return fallback
+		}
+
+		groupID := arg("group_id", "")
 		modulesStr := req.GetString("modules", "")
-		database := req.GetString("database", "")
-		nosqlDatabase := req.GetString("nosql_database", "")
-		messageBroker := req.GetString("message_broker", "")
+		database := arg("database", "")
+		nosqlDatabase := arg("nosql_database", "")
+		messageBroker := arg("message_broker", "")
 		vectorStore := req.GetString("vector_store", "")
-		javaVersion := req.GetString("java_version", "21")
-		aiAgentsStr := req.GetString("ai_agents", "")
+		javaVersion := arg("java_version", "21")
+		language := arg("language", "")
+		aiAgentsStr := arg("ai_agents", "")
+		ciProvider := arg("ci", "")
+		license := arg("license", "")
 		outputDir := req.GetString("output_dir", "")
+		onExists := req.GetString("on_exists", generator.OnExistsFail)
 		skipBuild := req.GetBool("skip_build", true)
 
+		if err := generator.ValidateOnExists(onExists); err != nil {
+			return toolError(err.Error()), nil
+		}
+
 		// Validate vector-store value (cross-flag rules applied below
 		// after cfg construction).
 		if vsErr := config.ValidateVectorStoreFlag(vectorStore); vsErr != "" {
 			return toolError(vsErr), nil
 		}
+		if langErr := config.ValidateLanguageFlag(language); langErr != "" {
+			return toolError(langErr), nil
+		}
+		secrets := req.GetString("secrets", "")
+		if sErr := config.ValidateSecretsFlag(secrets); sErr != "" {
+			return toolError(sErr), nil
+		}
+		cache := req.GetString("cache", "")
+		if cErr := config.ValidateCacheFlag(cache); cErr != "" {
+			return toolError(cErr), nil
+		}
+		jobStorage := req.GetString("job_storage", "")
+		if jErr := config.ValidateJobStorageFlag(jobStorage); jErr != "" {
+			return toolError(jErr), nil
+		}
+		carbonArea := req.GetString("jobrunr_carbon_area", "")
+		if jErr := config.ValidateJobRunrCarbonAreaFlag(carbonArea); jErr != "" {
+			return toolError(jErr), nil
+		}
+		kafkaGroup := req.GetString("kafka_consumer_group", "")
+		kafkaConcurrency := int(req.GetFloat("kafka_concurrency", 0))
+		kafkaPartitions := int(req.GetFloat("kafka_partitions", 0))
+		kafkaPollRecords := int(req.GetFloat("kafka_max_poll_records", 0))
+		kafkaPollInterval := req.GetString("kafka_max_poll_interval", "")
+		kafkaAckMode := req.GetString("kafka_ack_mode", "")
+		sqsReceiveCount := int(req.GetFloat("sqs_max_receive_count", 0))
+		for _, kErr := range []string{
+			config.ValidateKafkaConsumerGroupFlag(kafkaGroup),
+			config.ValidateKafkaCountFlag("kafka-concurrency", kafkaConcurrency),
+			config.ValidateKafkaCountFlag("kafka-partitions", kafkaPartitions),
+			config.ValidateKafkaCountFlag("kafka-max-poll-records", kafkaPollRecords),
+			config.ValidateKafkaMaxPollIntervalFlag(kafkaPollInterval),
+			config.ValidateKafkaAckModeFlag(kafkaAckMode),
+			config.ValidateSQSMaxReceiveCountFlag(sqsReceiveCount),
+		} {
+			if kErr != "" {
+				return toolError(kErr), nil
+			}
+		}
+		dbVersion := req.GetString("db_version", "")
+		if vErr := config.ValidateDatabaseVersionFlag(dbVersion); vErr != "" {
+			return toolError(vErr), nil
+		}
+		imageBuilder := req.GetString("image_builder", config.ImageBuilderDockerfile)
+		if iErr := config.ValidateImageBuilderFlag(imageBuilder); iErr != "" {
+			return toolError(iErr), nil
+		}
+		logFormat := req.GetString("log_format", config.LogFormatJSON)
+		if lErr := config.ValidateLogFormatFlag(logFormat); lErr != "" {
+			return toolError(lErr), nil
+		}
+		architecture := req.GetString("architecture", config.ArchitectureMultiModule)
+		if aErr := config.ValidateArchitectureFlag(architecture); aErr != "" {
+			return toolError(aErr), nil
+		}
+		taskRunner := req.GetString("task_runner", config.TaskRunnerMake)
+		if tErr := config.ValidateTaskRunnerFlag(taskRunner); tErr != "" {
+			return toolError(tErr), nil
+		}
+		staticAnalysis := req.GetString("static_analysis", "")
+		if aErr := config.ValidateStaticAnalysisFlag(staticAnalysis); aErr != "" {
+			return toolError(aErr), nil
+		}
 
 		// Validate name
 		if !projectNameRegex.MatchString(name) {
@@ -134,6 +369,11 @@ func registerInitProject(s *server.MCPServer, version string) {
 			return toolError(fmt.Sprintf("Invalid group ID '%s'. Must be valid Java package format (e.g., com.company.project).", groupID)), nil
 		}
 
+		// Validate CI provider
+		if ciProvider != "" && ciProvider != "github" {
+			return toolError(fmt.Sprintf("Invalid CI provider '%s'. Valid: github", ciProvider)), nil
+		}
+
 		// Validate Java version
 		jvInt, _ := strconv.Atoi(javaVersion)
 		if !java.IsSupportedVersion(jvInt) {
@@ -174,24 +414,112 @@ func registerInitProject(s *server.MCPServer, version string) {
 		}
 
 		cfg := &config.ProjectConfig{
-			ProjectName:   name,
-			GroupID:       groupID,
-			ArtifactID:    name,
-			JavaVersion:   javaVersion,
-			Modules:       resolvedModules,
-			Database:      database,
-			NoSQLDatabase: nosqlDatabase,
-			MessageBroker: messageBroker,
-			VectorStore:   vectorStore,
-			AIAgents:      aiAgents,
+			ProjectName:       name,
+			GroupID:           groupID,
+			ArtifactID:        name,
+			JavaVersion:       javaVersion,
+			Language:          language,
+			Modules:           resolvedModules,
+			Database:          database,
+			DatabaseVersion:   dbVersion,
+			NoSQLDatabase:     nosqlDatabase,
+			MessageBroker:     messageBroker,
+			VectorStore:       vectorStore,
+			AIAgents:          aiAgents,
+			CIProvider:        ciProvider,
+			Observability:     req.GetBool("observability", false),
+			Native:            req.GetBool("native", false),
+			Pagination:        req.GetBool("pagination", false),
+			RateLimit:         req.GetBool("rate_limit", false),
+			Idempotency:       req.GetBool("idempotency", false),
+			KafkaTransactions: req.GetBool("kafka_transactions", false),
+			Perf:              req.GetBool("perf", false),
+			DevContainer:      req.GetBool("devcontainer", false),
+			CodeOwners:        req.GetBool("codeowners", false),
+			TaskRunner:        taskRunner,
+			Auditing:          req.GetBool("auditing", false),
+			ReadReplica:       req.GetBool("read_replica", false),
+			Cache:             cache,
+			JobStorage:        jobStorage,
+			JobRunrDashboard:  req.GetBool("jobrunr_dashboard", false),
+			JobRunrBatches:    req.GetBool("jobrunr_batches", false),
+			JobRunrCarbonArea: carbonArea,
+			KafkaConsumerGroup:   kafkaGroup,
+			KafkaConcurrency:     kafkaConcurrency,
+			KafkaPartitions:      kafkaPartitions,
+			KafkaMaxPollRecords:  kafkaPollRecords,
+			KafkaMaxPollInterval: kafkaPollInterval,
+			KafkaAckMode:         kafkaAckMode,
+			SQSFifo:            req.GetBool("sqs_fifo", false),
+			SQSDeadLetter:      req.GetBool("sqs_dlq", false),
+			SQSMaxReceiveCount: sqsReceiveCount,
+			PubSubOrdering:     req.GetBool("pubsub_ordering", false),
+			PubSubExactlyOnce:  req.GetBool("pubsub_exactly_once", false),
+			Secrets:           secrets,
+			ImageRegistry:     arg("image_registry", ""),
+			ImageBuilder:      imageBuilder,
+			LogFormat:         logFormat,
+			Architecture:      architecture,
+		}
+		cfg.NoCoverageGates = !req.GetBool("coverage_gates", true)
+		cfg.StaticAnalysis = staticAnalysis
+		cfg.JPMS = req.GetBool("jpms", false)
+		cfg.EnablePreview = req.GetBool("enable_preview", false)
+		if profile != nil {
+			cfg.LicenseHeader = profile.LicenseHeader
+		}
+		if license != "" {
+			cfg.License, cfg.LicenseHeader, err = config.ResolveLicense(license, name)
+			if err != nil {
+				return toolError(err.Error()), nil
+			}
 		}
 
+		// Tailor the Testcontainers setup to Podman / Colima when detected
+		cfg.ContainerRuntime = utils.CheckDocker().Runtime
+
 		// Apply vector-store cross-flag rules (auto-add SQLDatastore for
 		// pgvector, coerce nosql-database for mongodb, surface
 		// conflicts). Mutates cfg in-place.
 		if vsErr := cfg.ResolveVectorStore(); vsErr != "" {
 			return toolError(vsErr), nil
 		}
+		if sErr := cfg.ResolveSecrets(); sErr != "" {
+			return toolError(sErr), nil
+		}
+		if aErr := cfg.ResolveStaticAnalysis(); aErr != "" {
+			return toolError(aErr), nil
+		}
+		if jErr := cfg.ResolveJPMS(); jErr != "" {
+			return toolError(jErr), nil
+		}
+		if aErr := cfg.ResolveArchitecture(); aErr != "" {
+			return toolError(aErr), nil
+		}
+		if pErr := cfg.ResolvePreview(); pErr != "" {
+			return toolError(pErr), nil
+		}
+		if cErr := cfg.ResolveCache(); cErr != "" {
+			return toolError(cErr), nil
+		}
+		if vErr := cfg.ResolveDatabaseVersion(); vErr != "" {
+			return toolError(vErr), nil
+		}
+		if jErr := cfg.ResolveJobStorage(); jErr != "" {
+			return toolError(jErr), nil
+		}
+		if jErr := cfg.ResolveJobRunrFeatures(); jErr != "" {
+			return toolError(jErr), nil
+		}
+		if kErr := cfg.ResolveKafkaConsumer(); kErr != "" {
+			return toolError(kErr), nil
+		}
+		if bErr := cfg.ResolveBrokerDelivery(); bErr != "" {
+			return toolError(bErr), nil
+		}
+		if nErr := cfg.ResolveNames(); nErr != "" {
+			return toolError(nErr), nil
+		}
 
 		// Change to output dir if specified
 		if outputDir != "" {
@@ -211,14 +539,66 @@ func registerInitProject(s *server.MCPServer, version string) {
 		if err != nil {
 			return toolError(fmt.Sprintf("Failed to create generator: %v. Check that the module combination is valid (use suggest_architecture first) and the output directory is writable.", err)), nil
 		}
+		gen.SetInvocation(toolInvocation(req))
+		gen.SetOnExists(onExists)
+
+		if req.GetBool("dry_run", false) {
+			result, err := gen.DryRun()
+			if err != nil {
+				return toolError(fmt.Sprintf("Failed to preview project: %v", err)), nil
+			}
+			return toolJSON(map[string]any{
+				"status":          "dry_run",
+				"project":         result.Project,
+				"directory":       result.Directory,
+				"exists":          result.Exists,
+				"modules":         result.Modules,
+				"files":           result.Files,
+				"docker_services": result.DockerServices,
+				"pom_properties":  result.PomProperties,
+				"counts":          result.Counts,
+			})
+		}
+
+		// Report generation stages, and the Maven build as one more, to
+		// clients that sent a progress token. Cancelling the call stops
+		// generation cleanly, or stops the build keeping the project.
+		ctx, cancel := cancellable(ctx, req)
+		defer cancel()
+		progress := newProgressReporter(ctx, req)
+		steps := float64(len(generator.GenerateStages()))
+		if !skipBuild {
+			steps++
+		}
+		gen.SetContext(ctx)
+		gen.SetProgress(func(stage string, done, total int) {
+			progress.send(float64(done), steps, "generate: "+stage, nil)
+		})
 
 		if err := gen.Generate(); err != nil {
+			if ctx.Err() != nil {
+				return toolError("Generation cancelled; nothing was written. Call init_project again to start over."), nil
+			}
+			var exists *generator.ExistsError
+			if errors.As(err, &exists) {
+				return toolError(fmt.Sprintf("Directory %s already exists. Call init_project again with on_exists=merge to write only the missing files, or on_exists=force to replace it (the old directory is kept as a backup); ask the user which one first.", exists.Dir)), nil
+			}
 			return toolError(fmt.Sprintf("Failed to generate project: %v", err)), nil
 		}
 
 		var warnings []string
+		if report := gen.Report(); len(report.Conflicts) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d existing file(s) differ from what Trabuco generates and were kept as they were; see summary.conflicts", len(report.Conflicts)))
+		}
 		if cfg.ShowRedisWorkerWarning() {
 			warnings = append(warnings, "Redis support is deprecated in JobRunr 8+. Worker uses PostgreSQL for job storage.")
+		} else if cfg.WorkerUsesPostgresFallback() && cfg.HasModule(config.ModuleNoSQLDatastore) {
+			warnings = append(warnings, fmt.Sprintf("JobRunr has no %s storage provider. Worker uses PostgreSQL for job storage.", config.NoSQLDatabaseDisplayName(cfg.NoSQLDatabase)))
+		}
+		warnings = append(warnings, cfg.JobStorageWarnings()...)
+		warnings = append(warnings, cfg.KafkaConsumerWarnings()...)
+		if err := RecordInitOutcome(req.GetString("recommendation_id", ""), cfg.Modules); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Recommendation feedback not recorded: %v", err))
 		}
 
 		projectPath := name
@@ -226,13 +606,20 @@ func registerInitProject(s *server.MCPServer, version string) {
 			projectPath = filepath.Join(outputDir, name)
 		}
 		absPath, _ := filepath.Abs(projectPath)
+		watchProject(absPath)
 
 		// Run Maven build if not skipped
 		buildStatus := "skipped"
 		if !skipBuild {
-			if err := utils.RunMavenBuild(absPath); err != nil {
-				warnings = append(warnings, fmt.Sprintf("Maven build failed: %v", err))
-				buildStatus = "failed"
+			progress.send(steps-1, steps, "build: mvn clean install", map[string]any{"path": absPath, "modules": resolvedModules})
+			if err := utils.RunMavenBuildContext(ctx, absPath); err != nil {
+				if ctx.Err() != nil {
+					warnings = append(warnings, "Maven build cancelled; the generated project is complete. Run 'mvn clean install' in it to build.")
+					buildStatus = "cancelled"
+				} else {
+					warnings = append(warnings, fmt.Sprintf("Maven build failed: %v", err))
+					buildStatus = "failed"
+				}
 			} else {
 				buildStatus = "success"
 			}
@@ -249,6 +636,7 @@ func registerInitProject(s *server.MCPServer, version string) {
 			nextSteps = append(nextSteps, "Implement business logic in Shared/src/main/java/.../shared/service/")
 		}
 		nextSteps = append(nextSteps,
+			"Call suggest_next_steps with the project path for a prioritized plan that follows the project's progress",
 			"Read .ai/prompts/add-entity.md for step-by-step entity creation guide",
 			"Run 'mvn test' to verify everything compiles and tests pass",
 			"Run 'mvn spotless:apply' after making changes to auto-format code",
@@ -286,6 +674,7 @@ func registerInitProject(s *server.MCPServer, version string) {
 			)
 		}
 
+		progress.send(steps, steps, "done", nil)
 		return toolJSON(map[string]any{
 			"status":       "success",
 			"path":         absPath,
@@ -297,6 +686,7 @@ func registerInitProject(s *server.MCPServer, version string) {
 			"next_steps":   nextSteps,
 			"key_files":    keyFiles,
 			"boundaries":   boundaries,
+			"summary":      gen.Report(),
 		})
 	})
 }
@@ -307,31 +697,38 @@ func registerAddModule(s *server.MCPServer, version string) {
 			"Add a module to an existing Trabuco project. Automatically resolves dependencies, "+
 				"updates parent POM, regenerates Docker Compose and CI workflow, and creates backup before changes. "+
 				"Use get_project_info first to see which modules are already installed. "+
-				"Use dry_run=true to preview changes without applying them.",
+				"Use dry_run=true to preview changes without applying them; add diff=true to also get "+
+				"the full content of new files and unified diffs of changed files.",
 		),
 		mcp.WithString("path",
 			mcp.Description("Path to the Trabuco project root"),
 			mcp.Required(),
 		),
 		mcp.WithString("module",
-			mcp.Description("Module to add: SQLDatastore, NoSQLDatastore, Shared, API, Worker, EventConsumer"),
+			mcp.Description("Module to add: SQLDatastore, NoSQLDatastore, Search, Shared, API, Worker, Events (publisher only), EventConsumer"),
 			mcp.Required(),
 		),
 		mcp.WithString("database",
-			mcp.Description("SQL database type: postgresql, mysql, generic (for SQLDatastore)"),
+			mcp.Description("SQL database type: postgresql, mysql, mariadb, generic (for SQLDatastore)"),
 		),
 		mcp.WithString("nosql_database",
-			mcp.Description("NoSQL database type: mongodb, redis (for NoSQLDatastore)"),
+			mcp.Description("NoSQL database type: mongodb, redis, dynamodb, cassandra (for NoSQLDatastore)"),
 		),
 		mcp.WithString("message_broker",
-			mcp.Description("Message broker: kafka, rabbitmq, sqs, pubsub (for EventConsumer)"),
+			mcp.Description("Message broker: kafka, rabbitmq, sqs, pubsub, redis-streams, nats (for Events or EventConsumer; defaults to the broker an existing Events module uses)"),
 		),
 		mcp.WithBoolean("dry_run",
 			mcp.Description("Preview changes without applying them"),
 		),
+		mcp.WithBoolean("diff",
+			mcp.Description("With dry_run, include new file contents and unified diffs of changed files (default: false)"),
+		),
 		mcp.WithBoolean("skip_build",
 			mcp.Description("Skip Maven build after adding module (default: true)"),
 		),
+		mcp.WithBoolean("force_docs",
+			mcp.Description("Overwrite README.md and AI agent files instead of regenerating only their trabuco:begin/end regions, discarding user edits (default: false)"),
+		),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -341,7 +738,9 @@ func registerAddModule(s *server.MCPServer, version string) {
 		nosqlDatabase := req.GetString("nosql_database", "")
 		messageBroker := req.GetString("message_broker", "")
 		dryRun := req.GetBool("dry_run", false)
+		withDiff := req.GetBool("diff", false)
 		skipBuild := req.GetBool("skip_build", true)
+		forceDocs := req.GetBool("force_docs", false)
 
 		absPath, err := resolvePath(path)
 		if err != nil {
@@ -354,21 +753,32 @@ func registerAddModule(s *server.MCPServer, version string) {
 		}
 
 		adder := generator.NewModuleAdder(absPath, meta, version, true)
+		adder.SetInvocation(toolInvocation(req))
+		adder.SetForceDocs(forceDocs)
 
 		if dryRun {
 			result := adder.DryRun(module)
-			return toolJSON(map[string]any{
+			response := map[string]any{
 				"status":         "dry_run",
 				"module":         result.Module,
 				"dependencies":   result.Dependencies,
 				"files_created":  result.FilesCreated,
 				"files_modified": result.FilesModified,
-			})
+			}
+			if withDiff {
+				diff, err := adder.Diff(module, database, nosqlDatabase, messageBroker)
+				if err != nil {
+					return toolError(fmt.Sprintf("Failed to preview module: %v", err)), nil
+				}
+				response["diff"] = diff
+			}
+			return toolJSON(response)
 		}
 
 		if err := adder.Add(module, database, nosqlDatabase, messageBroker); err != nil {
 			return toolError(fmt.Sprintf("Failed to add module: %v", err)), nil
 		}
+		watchProject(absPath)
 
 		// Run Maven build if not skipped
 		buildStatus := "skipped"
@@ -380,15 +790,20 @@ func registerAddModule(s *server.MCPServer, version string) {
 			}
 		}
 
-		// Gather info about what was done
-		dryResult := adder.DryRun(module) // safe to call for info even after add
+		// Report what actually changed on disk (also saved as LAST_OPERATION.md)
+		report := adder.Report()
+		var filesCreated []string
+		for _, m := range report.FilesCreated {
+			filesCreated = append(filesCreated, m.Files...)
+		}
 		return toolJSON(map[string]any{
 			"status":         "success",
 			"module":         module,
-			"dependencies":   dryResult.Dependencies,
-			"files_created":  dryResult.FilesCreated,
-			"files_modified": dryResult.FilesModified,
+			"dependencies":   report.Modules[:len(report.Modules)-1],
+			"files_created":  filesCreated,
+			"files_modified": report.FilesModified,
 			"build":          buildStatus,
+			"summary":        report,
 			"next_steps": []string{
 				"Run 'mvn clean compile -DskipTests' to verify compilation",
 				"Run 'mvn spotless:apply' to format generated code",
@@ -406,12 +821,20 @@ func registerSuggestArchitecture(s *server.MCPServer) {
 				"warnings for ambiguous terms, unsupported requirement detection, and constraint rules. "+
 				"YOU (the agent) decide which modules to select based on the catalog and the user's requirements. "+
 				"Then call init_project with your chosen modules. "+
-				"Use this BEFORE init_project when the user describes what they need.",
+				"Requirements may be written in English, Spanish or Portuguese; the detected language is reported in 'language'. "+
+				"Use this BEFORE init_project when the user describes what they need. "+
+				"When recommended_config has a recommendation_id, pass it to init_project so the local feedback records whether the recommendation was kept. "+
+				"When the requirements need several patterns at once (e.g. an API with background jobs and event consumers), composed_recommendation "+
+				"merges their modules, resolves datastore conflicts, picks the database and broker, and explains which requirement drove each module. "+
+				"With semantic_matching set in ~/.trabuco/config.yaml, pattern scores blend keyword matches with embedding similarity; 'matching' reports which embedder was used.",
 		),
 		mcp.WithString("requirements",
 			mcp.Description("Natural language description of the project requirements"),
 			mcp.Required(),
 		),
+		mcp.WithBoolean("offline",
+			mcp.Description("Never call a provider embeddings API; semantic matching uses local embeddings (default: false)"),
+		),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -419,7 +842,14 @@ func registerSuggestArchitecture(s *server.MCPServer) {
 		if requirements == "" {
 			return toolError("requirements parameter is required"), nil
 		}
-		result := buildAdvisory(requirements)
+		result := buildAdvisoryWith(ctx, requirements, selectEmbedder(req.GetBool("offline", false)))
+		if result.RecommendedConfig != nil {
+			scored := requirements
+			if result.Language.Translated {
+				scored = result.Language.NormalizedRequirements
+			}
+			result.RecommendedConfig.RecommendationID = recordRecommendation(result, scored)
+		}
 		return toolJSON(result)
 	})
 }
@@ -435,6 +865,11 @@ type architectureAdvisory struct {
 	Constraints       []string          `json:"constraints"`
 	Patterns          []scoredPattern   `json:"patterns"`
 	RecommendedConfig *recommendedConfig `json:"recommended_config"`
+	// ComposedRecommendation is set when several patterns together cover
+	// the requirements better than the top one alone.
+	ComposedRecommendation *composedRecommendation `json:"composed_recommendation,omitempty"`
+	Language          *requirementsLanguage `json:"language"`
+	Matching          *patternMatching      `json:"matching"`
 }
 
 type scoredPattern struct {
@@ -451,6 +886,9 @@ type recommendedConfig struct {
 	VectorStore   string `json:"vector_store,omitempty"`
 	Confidence    string `json:"confidence"`
 	Reasoning     string `json:"reasoning"`
+	// RecommendationID is set when pattern feedback is enabled; pass it to
+	// init_project as recommendation_id.
+	RecommendationID string `json:"recommendation_id,omitempty"`
 }
 
 type advisoryModule struct {
@@ -477,6 +915,15 @@ type brokerOption struct {
 }
 
 func buildAdvisory(requirements string) *architectureAdvisory {
+	return buildAdvisoryWith(context.Background(), requirements, nil)
+}
+
+// buildAdvisoryWith is buildAdvisory with an embedder for semantic pattern
+// matching; nil scores patterns by keywords only.
+func buildAdvisoryWith(ctx context.Context, requirements string, e embedder) *architectureAdvisory {
+	// Spanish and Portuguese requirements are scored through their English
+	// keywords; the advisory reports what was detected.
+	requirements, language := normalizeRequirements(requirements)
 	lower := strings.ToLower(requirements)
 
 	// Build module catalog from registry (exclude internal modules)
@@ -500,8 +947,11 @@ func buildAdvisory(requirements string) *architectureAdvisory {
 	dbOptions := []dbOption{
 		{Value: config.DatabasePostgreSQL, Type: "sql", Description: "PostgreSQL — recommended default for SQL. Spring Data JDBC + Flyway migrations", Module: config.ModuleSQLDatastore},
 		{Value: config.DatabaseMySQL, Type: "sql", Description: "MySQL — Spring Data JDBC + Flyway migrations", Module: config.ModuleSQLDatastore},
+		{Value: config.DatabaseMariaDB, Type: "sql", Description: "MariaDB — MariaDB Connector/J, utf8mb4 server defaults, Spring Data JDBC + Flyway migrations", Module: config.ModuleSQLDatastore},
 		{Value: config.DatabaseMongoDB, Type: "nosql", Description: "MongoDB — flexible document storage with Spring Data MongoDB", Module: config.ModuleNoSQLDatastore},
 		{Value: config.DatabaseRedis, Type: "nosql", Description: "Redis — key-value storage and caching with Spring Data Redis", Module: config.ModuleNoSQLDatastore},
+		{Value: config.DatabaseDynamoDB, Type: "nosql", Description: "DynamoDB — serverless key-value storage with the AWS SDK enhanced client (dynamodb-local for development)", Module: config.ModuleNoSQLDatastore},
+		{Value: config.DatabaseCassandra, Type: "nosql", Description: "Cassandra — wide-column storage for high write throughput with Spring Data Cassandra", Module: config.ModuleNoSQLDatastore},
 	}
 
 	// Broker options
@@ -510,6 +960,8 @@ func buildAdvisory(requirements string) *architectureAdvisory {
 		{Value: config.BrokerRabbitMQ, Description: "RabbitMQ — flexible message routing with AMQP"},
 		{Value: config.BrokerSQS, Description: "AWS SQS — managed message queue (AWS-native)"},
 		{Value: config.BrokerPubSub, Description: "Google Pub/Sub — managed message queue (GCP-native)"},
+		{Value: config.BrokerRedisStreams, Description: "Redis Streams — lightweight consumer-group streams on a Redis server (shared with a Redis NoSQLDatastore)"},
+		{Value: config.BrokerNATS, Description: "NATS JetStream — lightweight single-binary messaging with durable consumers"},
 	}
 
 	// Disambiguation warnings (ensure non-nil for JSON serialization)
@@ -528,21 +980,23 @@ func buildAdvisory(requirements string) *architectureAdvisory {
 	constraints := []string{
 		"SQLDatastore and NoSQLDatastore are mutually exclusive — choose one or the other",
 		"Model is always required and automatically included",
-		"EventConsumer requires a message_broker parameter (kafka, rabbitmq, sqs, or pubsub)",
-		"SQLDatastore requires a database parameter (postgresql or mysql)",
-		"NoSQLDatastore requires a nosql_database parameter (mongodb or redis)",
+		"EventConsumer requires a message_broker parameter (kafka, rabbitmq, sqs, pubsub, redis-streams, or nats)",
+		"SQLDatastore requires a database parameter (postgresql, mysql or mariadb)",
+		"NoSQLDatastore requires a nosql_database parameter (mongodb, redis, dynamodb or cassandra)",
 		"Worker uses the SQL database for job storage — if you pick Worker, you typically also need SQLDatastore",
-		"Jobs and Events are internal modules — they are auto-included when Worker or EventConsumer is selected",
+		"Jobs and Events are internal modules — they are auto-included when Worker or EventConsumer is selected; add_module also accepts Events alone for a publish-only service",
 	}
 
 	// Score architecture patterns against requirements
-	patterns := scorePatterns(requirements)
+	table := loadScoring()
+	patterns, matching := rankPatterns(ctx, requirements, table, e)
 	if patterns == nil {
 		patterns = []scoredPattern{}
 	}
 
 	// Generate recommended config from top pattern
-	recConfig := buildRecommendedConfig(patterns)
+	recConfig := buildRecommendedConfigWith(patterns, table)
+	composed := buildComposedRecommendation(requirements, patterns, table)
 
 	return &architectureAdvisory{
 		Modules:           modules,
@@ -553,6 +1007,9 @@ func buildAdvisory(requirements string) *architectureAdvisory {
 		Constraints:       constraints,
 		Patterns:          patterns,
 		RecommendedConfig: recConfig,
+		ComposedRecommendation: composed,
+		Language:          language,
+		Matching:          matching,
 	}
 }
 
@@ -560,12 +1017,12 @@ func buildAdvisory(requirements string) *architectureAdvisory {
 // that may differ from what the user intended. These help the agent avoid misinterpreting
 // requirements, without making module selection decisions.
 func detectDisambiguations(lower string) []string {
-	brokerKeywords := []string{"kafka", "rabbitmq", "sqs", "pubsub", "pub/sub", "event-driven", "message broker", "message queue"}
+	brokerKeywords := []string{"kafka", "rabbitmq", "sqs", "pubsub", "pub/sub", "redis streams", "nats", "event-driven", "message broker", "message queue"}
 
 	var warnings []string
 
 	if containsAny(lower, "event") && !containsAny(lower, brokerKeywords...) {
-		warnings = append(warnings, "Ambiguous term 'event': In Trabuco, EventConsumer is specifically for message broker consumers (Kafka, RabbitMQ, SQS, Pub/Sub). If the user means HTTP event payloads (e.g., webhooks), they need API, not EventConsumer.")
+		warnings = append(warnings, "Ambiguous term 'event': In Trabuco, EventConsumer is specifically for message broker consumers (Kafka, RabbitMQ, SQS, Pub/Sub, Redis Streams, NATS). If the user means HTTP event payloads (e.g., webhooks), they need API, not EventConsumer.")
 	}
 
 	if containsAny(lower, "listener") && !containsAny(lower, brokerKeywords...) {
@@ -581,7 +1038,7 @@ func detectDisambiguations(lower string) []string {
 	}
 
 	if containsAny(lower, "cache", "caching") {
-		warnings = append(warnings, "Ambiguous term 'cache': NoSQLDatastore with Redis provides a persistent data store that can be used for caching. For application-level in-memory caching (e.g., Caffeine, Spring Cache), that is not generated by Trabuco — see the extending guide after generation.")
+		warnings = append(warnings, "Ambiguous term 'cache': NoSQLDatastore with Redis provides a persistent data store. For application-level caching of service lookups, pass cache=caffeine (in-process) or cache=redis (shared across instances) to init_project; it needs Shared and a datastore.")
 	}
 
 	if containsAny(lower, "batch") && !containsAny(lower, "background", "worker", "etl", "pipeline") {
@@ -671,7 +1128,9 @@ func registerRunDoctor(s *server.MCPServer, version string) {
 		mcp.WithDescription(
 			"Run health checks on a Trabuco project to detect structural issues, metadata inconsistencies, "+
 				"and configuration problems. Use fix=true to auto-fix common issues like missing metadata or "+
-				"out-of-sync module lists. Run this before add_module to validate project health.",
+				"out-of-sync module lists. Run this before add_module to validate project health. "+
+				"On large projects use summary=true (counts plus failing checks without details) or max_items "+
+				"with cursor to page through the checks.",
 		),
 		mcp.WithString("path",
 			mcp.Description("Path to the Trabuco project root"),
@@ -681,14 +1140,19 @@ func registerRunDoctor(s *server.MCPServer, version string) {
 			mcp.Description("Attempt to auto-fix issues"),
 		),
 		mcp.WithString("category",
-			mcp.Description("Run specific check category: structure, metadata, consistency"),
+			mcp.Description("Run specific check category: structure, metadata, consistency, code, environment, security, runtime. 'runtime' checks the local stack instead of the files: required docker-compose services running and healthy, their ports reachable, configured Kafka topics present — use it when the app won't start"),
 		),
+		withPaging("checks"),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		path := req.GetString("path", "")
 		fix := req.GetBool("fix", false)
 		category := req.GetString("category", "")
+		page, err := readPageRequest(req)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
 
 		absPath, err := resolvePath(path)
 		if err != nil {
@@ -702,10 +1166,7 @@ func registerRunDoctor(s *server.MCPServer, version string) {
 			if err != nil {
 				return toolError(fmt.Sprintf("Doctor failed: %v", err)), nil
 			}
-			jsonBytes, err := result.ToJSON()
-			if err != nil {
-				return toolError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
-			}
+			response := doctorResponse(result, page)
 			// Include fix results
 			fixSummary := make([]map[string]any, len(fixes))
 			for i, f := range fixes {
@@ -716,13 +1177,8 @@ func registerRunDoctor(s *server.MCPServer, version string) {
 					"error":   f.Error,
 				}
 			}
-			// Combine doctor JSON with fixes
-			var raw map[string]any
-			if err := json.Unmarshal(jsonBytes, &raw); err != nil {
-				return mcp.NewToolResultText(string(jsonBytes)), nil
-			}
-			raw["fixes"] = fixSummary
-			return toolJSON(raw)
+			response["fixes"] = fixSummary
+			return toolJSON(response)
 		}
 
 		if category != "" {
@@ -730,25 +1186,55 @@ func registerRunDoctor(s *server.MCPServer, version string) {
 			if err != nil {
 				return toolError(fmt.Sprintf("Doctor failed: %v", err)), nil
 			}
-			jsonBytes, err := result.ToJSON()
-			if err != nil {
-				return toolError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
-			}
-			return mcp.NewToolResultText(string(jsonBytes)), nil
+			return toolJSON(doctorResponse(result, page))
 		}
 
 		result, err := doc.Run()
 		if err != nil {
 			return toolError(fmt.Sprintf("Doctor failed: %v", err)), nil
 		}
-		jsonBytes, err := result.ToJSON()
-		if err != nil {
-			return toolError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
-		}
-		return mcp.NewToolResultText(string(jsonBytes)), nil
+		return toolJSON(doctorResponse(result, page))
 	})
 }
 
+// doctorResponse shapes a doctor result for run_doctor. The summary counts
+// always cover every check; page selects which checks are listed. In
+// summary mode only the failing checks are listed, without details.
+func doctorResponse(result *doctor.DoctorResult, page pageRequest) map[string]any {
+	response := map[string]any{
+		"project":        result.Project,
+		"location":       result.Location,
+		"trabucoVersion": result.TrabucoVersion,
+		"status":         result.Status,
+		"summary":        result.Summary,
+	}
+
+	if !page.Summary {
+		checks, info := paginate(result.Checks, page)
+		response["checks"] = checks
+		response["page"] = info
+		return response
+	}
+
+	failing := []map[string]any{}
+	for _, c := range result.Checks {
+		if c.Status == doctor.SeverityPass {
+			continue
+		}
+		failing = append(failing, map[string]any{
+			"id":         c.ID,
+			"name":       c.Name,
+			"status":     c.Status,
+			"message":    c.Message,
+			"canAutoFix": c.CanAutoFix,
+		})
+	}
+	checks, info := paginate(failing, page)
+	response["checks"] = checks
+	response["page"] = info
+	return response
+}
+
 func registerGetProjectInfo(s *server.MCPServer) {
 	tool := mcp.NewTool("get_project_info",
 		mcp.WithDescription("Read project metadata from a Trabuco project (.trabuco.json or inferred from POM)"),
@@ -775,6 +1261,7 @@ func registerGetProjectInfo(s *server.MCPServer) {
 				return toolError(fmt.Sprintf("Failed to read project info at '%s': %v. Verify the path points to a Trabuco project root (should contain .trabuco.json or pom.xml).", absPath, err)), nil
 			}
 		}
+		watchProject(absPath)
 
 		// Compute addable modules
 		var addable []string
@@ -821,6 +1308,111 @@ func registerGetProjectInfo(s *server.MCPServer) {
 	})
 }
 
+func registerListTodos(s *server.MCPServer) {
+	tool := mcp.NewTool("list_todos",
+		mcp.WithDescription(
+			"List the outstanding TODO and FIXME comments of a Trabuco project, grouped by module, with the "+
+				"surrounding lines as context. Each item says whether Trabuco generated it (the skeleton's "+
+				"intentional placeholders for business logic, indexed in .trabuco/todos.json) or a developer added it, "+
+				"and the response counts the generated TODOs already resolved. Use it to see what is left to implement "+
+				"after init_project or add_module. summary=true drops the context lines.",
+		),
+		mcp.WithString("path",
+			mcp.Description("Path to the Trabuco project root"),
+			mcp.Required(),
+		),
+		mcp.WithString("module",
+			mcp.Description("Only list the TODOs of this module, e.g. Worker. Use \".\" for files at the project root"),
+		),
+		mcp.WithBoolean("generated_only",
+			mcp.Description("Only list the TODOs Trabuco generated"),
+		),
+		withPaging("TODOs"),
+	)
+
+	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		page, err := readPageRequest(req)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		absPath, err := resolvePath(req.GetString("path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+		}
+
+		report, err := todos.Outstanding(absPath, req.GetString("module", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to list TODOs: %v", err)), nil
+		}
+		if req.GetBool("generated_only", false) {
+			report.GeneratedOnly()
+		}
+		if page.Summary {
+			for i := range report.Items {
+				report.Items[i].Context = nil
+			}
+		}
+		items, info := paginate(report.Items, page)
+		return toolJSON(map[string]any{
+			"total":     len(report.Items),
+			"generated": report.Generated(),
+			"resolved":  report.Resolved,
+			"indexed":   report.Indexed,
+			"by_module": report.ByModule,
+			"todos":     items,
+			"page":      info,
+		})
+	})
+}
+
+func registerGetDependencyGraph(s *server.MCPServer) {
+	tool := mcp.NewTool("get_dependency_graph",
+		mcp.WithDescription(
+			"Return the architecture of a Trabuco project as a graph of nodes and edges. Nodes are the Maven "+
+				"modules and the docker-compose services. Edges are 'compile' and 'test' dependencies between "+
+				"modules, read from the module POMs, and 'uses' edges from the module that holds a client "+
+				"(SQLDatastore, Events, Worker, ...) to the service it connects to. Use it to check which modules "+
+				"a change reaches, or where a new dependency belongs, before editing POMs or calling add_module. "+
+				"format='mermaid' or 'dot' adds the rendered diagram.",
+		),
+		mcp.WithString("path",
+			mcp.Description("Path to the Trabuco project root"),
+			mcp.Required(),
+		),
+		mcp.WithString("format",
+			mcp.Description("Also render the graph: mermaid or dot. Defaults to json only"),
+		),
+	)
+
+	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		format := req.GetString("format", graph.FormatJSON)
+		if msg := graph.ValidateFormat(format); msg != "" {
+			return toolError(strings.Replace(msg, "--format", "format", 1)), nil
+		}
+		absPath, err := resolvePath(req.GetString("path", ""))
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+		}
+
+		g, err := graph.Build(absPath)
+		if err != nil {
+			return toolError(fmt.Sprintf("Failed to build the dependency graph: %v", err)), nil
+		}
+		result := map[string]any{
+			"project": g.Project,
+			"nodes":   g.Nodes,
+			"edges":   g.Edges,
+		}
+		switch format {
+		case graph.FormatMermaid:
+			result["mermaid"] = g.Mermaid()
+		case graph.FormatDOT:
+			result["dot"] = g.DOT()
+		}
+		return toolJSON(result)
+	})
+}
+
 // ---------- Discovery Tools ----------
 
 func registerListModules(s *server.MCPServer) {
@@ -843,6 +1435,7 @@ func registerListModules(s *server.MCPServer) {
 			Internal       bool     `json:"internal"`
 			Dependencies   []string `json:"dependencies"`
 			ConflictsWith  []string `json:"conflicts_with"`
+			Plugin         string   `json:"plugin,omitempty"`
 		}
 
 		modules := make([]moduleInfo, len(config.ModuleRegistry))
@@ -857,6 +1450,7 @@ func registerListModules(s *server.MCPServer) {
 				Internal:       m.Internal,
 				Dependencies:   m.Dependencies,
 				ConflictsWith:  m.ConflictsWith,
+				Plugin:         m.Plugin,
 			}
 		}
 
@@ -866,29 +1460,67 @@ func registerListModules(s *server.MCPServer) {
 
 func registerCheckDocker(s *server.MCPServer) {
 	tool := mcp.NewTool("check_docker",
-		mcp.WithDescription("Check if Docker is installed and running (required for project generation and tests)"),
+		mcp.WithDescription("Check if Docker is installed and running (required for project generation and tests). Also reports the container runtime (docker-desktop, docker, podman, colima, remote), its socket path or remote host, and whether Testcontainers Cloud is configured."),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		status := utils.CheckDocker()
 		return toolJSON(map[string]any{
-			"installed": status.Installed,
-			"running":   status.Running,
-			"version":   status.Version,
-			"error":     status.Error,
+			"installed":   status.Installed,
+			"running":     status.Running,
+			"version":     status.Version,
+			"error":       status.Error,
+			"runtime":     status.Runtime,
+			"socket_path": status.SocketPath,
+			"host":        status.Host,
+
+			"testcontainers_cloud":   status.TestcontainersCloud,
+			"can_run_testcontainers": status.CanRunTestcontainers(),
 		})
 	})
 }
 
 func registerGetVersion(s *server.MCPServer, version string) {
 	tool := mcp.NewTool("get_version",
-		mcp.WithDescription("Get the Trabuco CLI version"),
+		mcp.WithDescription(
+			"Get the Trabuco CLI version and whether a newer release or template pack is available. "+
+				"With a project path, 'update.affected_modules' lists the project's modules that newer releases' notes mention, "+
+				"and 'update.pack_updates' covers the template packs the project uses. "+
+				"The check hits the network at most once a day and reuses the cached result otherwise.",
+		),
+		mcp.WithString("path",
+			mcp.Description("Absolute path to a Trabuco project to scope the update check to (optional)"),
+		),
+		mcp.WithBoolean("check_updates",
+			mcp.Description("Check for updates (default: true). Set false to skip any network call"),
+		),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return toolJSON(map[string]string{
+		result := map[string]any{
 			"version": version,
-		})
+		}
+		switch {
+		case !req.GetBool("check_updates", true) || update.Disabled():
+			result["update_check"] = "disabled"
+		case !update.IsRelease(version):
+			result["update_check"] = "development build"
+		default:
+			projectPath, err := resolvePath(req.GetString("path", ""))
+			if err != nil {
+				return toolError(fmt.Sprintf("Failed to resolve path: %v", err)), nil
+			}
+			modules, packs := update.Scope(projectPath)
+			status, err := update.NewChecker(version).Check(ctx, modules, packs, false)
+			if err != nil {
+				return toolError(fmt.Sprintf("Update check failed: %v", err)), nil
+			}
+			result["update"] = status
+			if notice := status.Notice(); notice != "" {
+				result["notice"] = notice
+			}
+		}
+		return toolJSON(result)
 	})
 }
 
@@ -0,0 +1,609 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/java"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// initConfigInput is a proposed init_project configuration
+type initConfigInput struct {
+	Name              string
+	GroupID           string
+	Modules           string
+	Database          string
+	DatabaseVersion   string
+	NoSQLDatabase     string
+	MessageBroker     string
+	VectorStore       string
+	JavaVersion       string
+	Language          string
+	AIAgents          string
+	CI                string
+	Secrets           string
+	StaticAnalysis    string
+	Cache             string
+	JobStorage        string
+	JobRunrCarbonArea string
+	KafkaGroup        string
+	KafkaPollInterval string
+	KafkaAckMode      string
+	KafkaConcurrency  int
+	KafkaPartitions   int
+	KafkaPollRecords  int
+	SQSReceiveCount   int
+	TaskRunner        string
+	ImageBuilder      string
+	LogFormat         string
+	Architecture      string
+	Native            bool
+	Pagination        bool
+	RateLimit         bool
+	Idempotency       bool
+	KafkaTransactions bool
+	SQSFifo           bool
+	SQSDeadLetter     bool
+	PubSubOrdering    bool
+	PubSubExactlyOnce bool
+	Perf              bool
+	DevContainer      bool
+	CodeOwners        bool
+	Auditing          bool
+	ReadReplica       bool
+	JobRunrDashboard  bool
+	JobRunrBatches    bool
+	JPMS              bool
+	EnablePreview     bool
+}
+
+// ValidationIssue is one problem found in a proposed configuration
+type ValidationIssue struct {
+	Field   string `json:"field"` // init_project parameter it concerns
+	Code    string `json:"code"`  // Stable identifier, e.g. "missing_message_broker"
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"` // What to change
+}
+
+// ConfigValidation is the validate_config result. Valid is false when there
+// are errors; warnings never block init_project.
+type ConfigValidation struct {
+	Valid    bool              `json:"valid"`
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+	Resolved *ResolvedConfig   `json:"resolved,omitempty"`
+}
+
+// ResolvedConfig is what init_project would generate from a valid
+// configuration, after dependency resolution and the cross-flag rules
+type ResolvedConfig struct {
+	Modules       []string `json:"modules"`
+	AddedModules  []string `json:"added_modules,omitempty"` // Pulled in as dependencies
+	Database      string   `json:"database,omitempty"`
+	NoSQLDatabase string   `json:"nosql_database,omitempty"`
+	MessageBroker string   `json:"message_broker,omitempty"`
+	VectorStore   string   `json:"vector_store,omitempty"`
+	Secrets       string   `json:"secrets,omitempty"`
+	Cache         string   `json:"cache,omitempty"`
+	JobStorage    string   `json:"job_storage,omitempty"` // Database JobRunr stores jobs in, when Worker is selected
+}
+
+var (
+	validDatabases      = []string{config.DatabasePostgreSQL, config.DatabaseMySQL, config.DatabaseMariaDB, "generic"}
+	validNoSQLDatabases = config.NoSQLDatabases
+	validMessageBrokers = config.MessageBrokers
+)
+
+func registerValidateConfig(s *server.MCPServer) {
+	tool := mcp.NewTool("validate_config",
+		mcp.WithDescription(
+			"Check a proposed init_project configuration without creating anything. "+
+				"Takes the same parameters as init_project and returns structured results: errors (invalid values, module conflicts, "+
+				"a database, NoSQL database or message broker missing for the modules that need it) and warnings (deprecated combinations such as "+
+				"Redis + Worker, options that will be ignored). Each issue names the parameter, a stable code and a fix. "+
+				"'resolved' shows the modules and backends init_project would use. "+
+				"Call this before init_project, and fix every error before generating.",
+		),
+		mcp.WithString("name",
+			mcp.Description("Project name (lowercase, hyphens allowed, e.g. 'my-platform')"),
+		),
+		mcp.WithString("group_id",
+			mcp.Description("Maven group ID (e.g. 'com.company.project'). May be omitted when the config profile sets group_id_prefix"),
+		),
+		mcp.WithString("modules",
+			mcp.Description("Comma-separated modules: Model, SQLDatastore, NoSQLDatastore, Search, Shared, API, Worker, Events, EventConsumer, Jobs, AIAgent, ClientSDK"),
+			mcp.Required(),
+		),
+		mcp.WithString("database",
+			mcp.Description("SQL database type: postgresql, mysql, mariadb, generic"),
+		),
+		mcp.WithString("db_version",
+			mcp.Description("Pin the SQL database image version for docker-compose, CI and Testcontainers, e.g. 16 (PostgreSQL), 8.4 (MySQL) or 11.4 (MariaDB). Needs SQLDatastore with postgresql, mysql or mariadb (default: the version Trabuco ships with)"),
+		),
+		mcp.WithString("nosql_database",
+			mcp.Description("NoSQL database type: mongodb, redis, dynamodb, cassandra"),
+		),
+		mcp.WithString("message_broker",
+			mcp.Description("Message broker: kafka, rabbitmq, sqs, pubsub, redis-streams, nats"),
+		),
+		mcp.WithString("vector_store",
+			mcp.Description("Vector RAG backend for AIAgent: pgvector, qdrant, mongodb, or none"),
+		),
+		mcp.WithString("java_version",
+			mcp.Description("Java version: 21, 25, or 26 (default: 21)"),
+		),
+		mcp.WithString("language",
+			mcp.Description("Application language: java or kotlin (default: java)"),
+		),
+		mcp.WithString("ai_agents",
+			mcp.Description("Comma-separated AI agent configs: claude, cursor, copilot, codex, gemini, aider, zed"),
+		),
+		mcp.WithString("ci",
+			mcp.Description("CI provider: github"),
+		),
+		mcp.WithString("secrets",
+			mcp.Description("Secrets manager: vault, aws, gcp, auto or none"),
+		),
+		mcp.WithString("static_analysis",
+			mcp.Description("Compile-time static analysis: errorprone or none"),
+		),
+		mcp.WithString("cache",
+			mcp.Description("Spring Cache provider for PlaceholderService: caffeine, redis or none"),
+		),
+		mcp.WithString("job_storage",
+			mcp.Description("Where the Worker's JobRunr stores jobs: postgres, mysql, existing-sql or in-memory"),
+		),
+		mcp.WithBoolean("jobrunr_dashboard",
+			mcp.Description("JobRunr dashboard on by default, with basic auth and port 8000 in docker-compose"),
+		),
+		mcp.WithBoolean("jobrunr_batches",
+			mcp.Description("JobRunr server tuned for bulk workloads"),
+		),
+		mcp.WithString("jobrunr_carbon_area",
+			mcp.Description("ENTSO-E area code for JobRunr carbon-aware scheduling, e.g. BE or US-CA"),
+		),
+		mcp.WithBoolean("native",
+			mcp.Description("GraalVM native image support"),
+		),
+		mcp.WithBoolean("pagination",
+			mcp.Description("Paginated list endpoint"),
+		),
+		mcp.WithBoolean("rate_limit",
+			mcp.Description("Per-client API rate limiting"),
+		),
+		mcp.WithBoolean("idempotency",
+			mcp.Description("Idempotency-Key handling for API POST endpoints"),
+		),
+		mcp.WithBoolean("kafka_transactions",
+			mcp.Description("Exactly-once Kafka processing in EventConsumer"),
+		),
+		mcp.WithString("kafka_consumer_group",
+			mcp.Description("Kafka consumer group naming convention, {project} for the project name"),
+		),
+		mcp.WithNumber("kafka_concurrency",
+			mcp.Description("Kafka consumer threads per EventConsumer instance"),
+		),
+		mcp.WithNumber("kafka_partitions",
+			mcp.Description("Partitions of the topics the docker-compose Kafka creates"),
+		),
+		mcp.WithNumber("kafka_max_poll_records",
+			mcp.Description("Records per Kafka poll"),
+		),
+		mcp.WithString("kafka_max_poll_interval",
+			mcp.Description("Kafka max.poll.interval.ms as a duration, e.g. 5m"),
+		),
+		mcp.WithString("kafka_ack_mode",
+			mcp.Description("When Kafka offsets are committed: batch, record or manual"),
+		),
+		mcp.WithBoolean("sqs_fifo",
+			mcp.Description("FIFO SQS queues with content-based deduplication"),
+		),
+		mcp.WithBoolean("sqs_dlq",
+			mcp.Description("A dead-letter queue and redrive policy for each SQS queue"),
+		),
+		mcp.WithNumber("sqs_max_receive_count",
+			mcp.Description("Receives before SQS moves a message to the dead-letter queue"),
+		),
+		mcp.WithBoolean("pubsub_ordering",
+			mcp.Description("Pub/Sub ordering keys and ordered subscriptions"),
+		),
+		mcp.WithBoolean("pubsub_exactly_once",
+			mcp.Description("Pub/Sub subscriptions with exactly-once delivery"),
+		),
+		mcp.WithBoolean("perf",
+			mcp.Description("k6 load test under perf/"),
+		),
+		mcp.WithBoolean("devcontainer",
+			mcp.Description("Dev container for Codespaces under .devcontainer/"),
+		),
+		mcp.WithBoolean("codeowners",
+			mcp.Description("CODEOWNERS and module boundary docs under docs/modules/"),
+		),
+		mcp.WithString("task_runner",
+			mcp.Description("Developer shortcuts file: make, task or none"),
+		),
+		mcp.WithString("image_builder",
+			mcp.Description("Container image builder: dockerfile or jib"),
+		),
+		mcp.WithString("log_format",
+			mcp.Description("Log encoding: json, ecs or plain"),
+		),
+		mcp.WithString("architecture",
+			mcp.Description("Build layout: multi-module or modulith"),
+		),
+		mcp.WithBoolean("auditing",
+			mcp.Description("Auditing columns and soft deletes"),
+		),
+		mcp.WithBoolean("read_replica",
+			mcp.Description("Read replica datasource with read-only transaction routing"),
+		),
+		mcp.WithBoolean("jpms",
+			mcp.Description("module-info.java per Maven module"),
+		),
+		mcp.WithBoolean("enable_preview",
+			mcp.Description("Compile, test and run with --enable-preview"),
+		),
+		mcp.WithString("profile",
+			mcp.Description("Defaults profile from ~/.trabuco/config.yaml, applied as init_project would"),
+		),
+	)
+
+	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := req.GetString("name", "")
+		profile, err := config.LoadProfile(req.GetString("profile", ""))
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		defaults := profile.Defaults(name)
+		arg := func(key, fallback string) string {
+			if v := req.GetString(key, ""); v != "" {
+				return v
+			}
+			if v, ok := defaults[key]; ok {
+				return v
+			}
+			return fallback
+		}
+
+		in := initConfigInput{
+			Name:              name,
+			GroupID:           arg("group_id", ""),
+			Modules:           req.GetString("modules", ""),
+			Database:          arg("database", ""),
+			DatabaseVersion:   req.GetString("db_version", ""),
+			NoSQLDatabase:     arg("nosql_database", ""),
+			MessageBroker:     arg("message_broker", ""),
+			VectorStore:       req.GetString("vector_store", ""),
+			JavaVersion:       arg("java_version", "21"),
+			Language:          arg("language", ""),
+			AIAgents:          arg("ai_agents", ""),
+			CI:                arg("ci", ""),
+			Secrets:           req.GetString("secrets", ""),
+			StaticAnalysis:    req.GetString("static_analysis", ""),
+			Cache:             req.GetString("cache", ""),
+			JobStorage:        req.GetString("job_storage", ""),
+			JobRunrCarbonArea: req.GetString("jobrunr_carbon_area", ""),
+			KafkaGroup:        req.GetString("kafka_consumer_group", ""),
+			KafkaPollInterval: req.GetString("kafka_max_poll_interval", ""),
+			KafkaAckMode:      req.GetString("kafka_ack_mode", ""),
+			KafkaConcurrency:  int(req.GetFloat("kafka_concurrency", 0)),
+			KafkaPartitions:   int(req.GetFloat("kafka_partitions", 0)),
+			KafkaPollRecords:  int(req.GetFloat("kafka_max_poll_records", 0)),
+			SQSReceiveCount:   int(req.GetFloat("sqs_max_receive_count", 0)),
+			TaskRunner:        req.GetString("task_runner", ""),
+			ImageBuilder:      req.GetString("image_builder", ""),
+			LogFormat:         req.GetString("log_format", ""),
+			Architecture:      req.GetString("architecture", ""),
+			Native:            req.GetBool("native", false),
+			Pagination:        req.GetBool("pagination", false),
+			RateLimit:         req.GetBool("rate_limit", false),
+			Idempotency:       req.GetBool("idempotency", false),
+			KafkaTransactions: req.GetBool("kafka_transactions", false),
+			SQSFifo:           req.GetBool("sqs_fifo", false),
+			SQSDeadLetter:     req.GetBool("sqs_dlq", false),
+			PubSubOrdering:    req.GetBool("pubsub_ordering", false),
+			PubSubExactlyOnce: req.GetBool("pubsub_exactly_once", false),
+			Perf:              req.GetBool("perf", false),
+			DevContainer:      req.GetBool("devcontainer", false),
+			CodeOwners:        req.GetBool("codeowners", false),
+			Auditing:          req.GetBool("auditing", false),
+			ReadReplica:       req.GetBool("read_replica", false),
+			JobRunrDashboard:  req.GetBool("jobrunr_dashboard", false),
+			JobRunrBatches:    req.GetBool("jobrunr_batches", false),
+			JPMS:              req.GetBool("jpms", false),
+			EnablePreview:     req.GetBool("enable_preview", false),
+		}
+		return toolJSON(validateInitConfig(in))
+	})
+}
+
+// validateInitConfig runs init_project's checks on a proposed configuration,
+// plus the ones that matter before generating: a backend missing for the
+// modules that need it, options the module set ignores and deprecated
+// combinations. It collects every issue instead of stopping at the first.
+func validateInitConfig(in initConfigInput) *ConfigValidation {
+	v := &ConfigValidation{Errors: []ValidationIssue{}, Warnings: []ValidationIssue{}}
+	fail := func(field, code, message, fix string) {
+		v.Errors = append(v.Errors, ValidationIssue{Field: field, Code: code, Message: message, Fix: fix})
+	}
+	warn := func(field, code, message, fix string) {
+		v.Warnings = append(v.Warnings, ValidationIssue{Field: field, Code: code, Message: message, Fix: fix})
+	}
+
+	// Project identity
+	if in.Name != "" && !projectNameRegex.MatchString(in.Name) {
+		fail("name", "invalid_name", fmt.Sprintf("Invalid project name '%s'", in.Name), "Use lowercase letters and digits, hyphen-separated (e.g. 'order-service')")
+	} else if msg := config.ValidateProjectName(in.Name); in.Name != "" && msg != "" {
+		fail("name", "reserved_name", msg, "")
+	}
+	if in.GroupID == "" {
+		fail("group_id", "missing_group_id", "group_id is required", "Pass a Maven group ID such as 'com.company.project', or set group_id_prefix in the config profile")
+	} else if !groupIDRegex.MatchString(in.GroupID) {
+		fail("group_id", "invalid_group_id", fmt.Sprintf("Invalid group ID '%s'", in.GroupID), "Use Java package format, e.g. 'com.company.project'")
+	} else if msg := config.ValidateGroupID(in.GroupID); msg != "" {
+		fail("group_id", "reserved_group_id_segment", msg, "")
+	}
+	if jv, _ := strconv.Atoi(in.JavaVersion); !java.IsSupportedVersion(jv) {
+		fail("java_version", "unsupported_java_version", fmt.Sprintf("Unsupported Java version '%s'", in.JavaVersion), "Use one of: "+java.FormatDetectedVersions(java.SupportedVersions))
+	}
+
+	// Option values
+	for _, check := range []struct{ field, msg string }{
+		{"language", config.ValidateLanguageFlag(in.Language)},
+		{"vector_store", config.ValidateVectorStoreFlag(in.VectorStore)},
+		{"secrets", config.ValidateSecretsFlag(in.Secrets)},
+		{"static_analysis", config.ValidateStaticAnalysisFlag(in.StaticAnalysis)},
+		{"cache", config.ValidateCacheFlag(in.Cache)},
+		{"job_storage", config.ValidateJobStorageFlag(in.JobStorage)},
+		{"jobrunr_carbon_area", config.ValidateJobRunrCarbonAreaFlag(in.JobRunrCarbonArea)},
+		{"kafka_consumer_group", config.ValidateKafkaConsumerGroupFlag(in.KafkaGroup)},
+		{"kafka_concurrency", config.ValidateKafkaCountFlag("kafka-concurrency", in.KafkaConcurrency)},
+		{"kafka_partitions", config.ValidateKafkaCountFlag("kafka-partitions", in.KafkaPartitions)},
+		{"kafka_max_poll_records", config.ValidateKafkaCountFlag("kafka-max-poll-records", in.KafkaPollRecords)},
+		{"kafka_max_poll_interval", config.ValidateKafkaMaxPollIntervalFlag(in.KafkaPollInterval)},
+		{"kafka_ack_mode", config.ValidateKafkaAckModeFlag(in.KafkaAckMode)},
+		{"sqs_max_receive_count", config.ValidateSQSMaxReceiveCountFlag(in.SQSReceiveCount)},
+		{"db_version", config.ValidateDatabaseVersionFlag(in.DatabaseVersion)},
+		{"task_runner", config.ValidateTaskRunnerFlag(in.TaskRunner)},
+		{"image_builder", config.ValidateImageBuilderFlag(in.ImageBuilder)},
+		{"log_format", config.ValidateLogFormatFlag(in.LogFormat)},
+		{"architecture", config.ValidateArchitectureFlag(in.Architecture)},
+	} {
+		if check.msg != "" {
+			fail(check.field, "invalid_"+check.field, check.msg, "")
+		}
+	}
+	if in.CI != "" && in.CI != "github" {
+		fail("ci", "invalid_ci", fmt.Sprintf("Invalid CI provider '%s'", in.CI), "Use 'github' or omit ci")
+	}
+	var aiAgents []string
+	if in.AIAgents != "" {
+		valid := make(map[string]bool)
+		for _, id := range config.GetAIAgentIDs() {
+			valid[id] = true
+		}
+		for _, agent := range strings.Split(in.AIAgents, ",") {
+			agent = strings.TrimSpace(strings.ToLower(agent))
+			if agent == "" {
+				continue
+			}
+			if !valid[agent] {
+				fail("ai_agents", "invalid_ai_agent", fmt.Sprintf("Invalid AI agent '%s'", agent), "Use: "+strings.Join(config.GetAIAgentIDs(), ", "))
+				continue
+			}
+			aiAgents = append(aiAgents, agent)
+		}
+	}
+
+	// Modules
+	var modules []string
+	for _, m := range strings.Split(in.Modules, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		if config.GetModule(m) == nil {
+			fail("modules", "unknown_module", fmt.Sprintf("Unknown module '%s'", m), "Call list_modules for the available modules (names are case-sensitive)")
+			continue
+		}
+		modules = append(modules, m)
+	}
+	if msg := config.ValidateModuleSelection(modules); msg != "" {
+		code := "invalid_module_selection"
+		if len(modules) > 0 {
+			code = "module_conflict"
+		}
+		fail("modules", code, msg, "")
+	}
+	resolved := config.ResolveDependencies(modules)
+	has := func(module string) bool { return hasModule(resolved, module) }
+
+	// Backends: each must be valid, given when a selected module needs it,
+	// and is ignored when no selected module uses it
+	brokerModule := ""
+	if has(config.ModuleEventConsumer) {
+		brokerModule = config.ModuleEventConsumer
+	} else if has(config.ModuleEvents) {
+		brokerModule = config.ModuleEvents
+	}
+	pgvector := in.VectorStore == config.VectorStorePgVector // Adds SQLDatastore itself
+	for _, b := range []struct {
+		field, value, label string
+		valid               []string
+		neededBy            string // Selected module that needs the backend
+		usedBy              string // Modules that use it, for the warning
+		used                bool
+	}{
+		{"database", in.Database, "SQL database", validDatabases,
+			moduleIf(has(config.ModuleSQLDatastore), config.ModuleSQLDatastore), config.ModuleSQLDatastore, has(config.ModuleSQLDatastore) || pgvector},
+		{"nosql_database", in.NoSQLDatabase, "NoSQL database", validNoSQLDatabases,
+			moduleIf(has(config.ModuleNoSQLDatastore), config.ModuleNoSQLDatastore), config.ModuleNoSQLDatastore, has(config.ModuleNoSQLDatastore)},
+		{"message_broker", in.MessageBroker, "message broker", validMessageBrokers,
+			brokerModule, config.ModuleEvents + " or " + config.ModuleEventConsumer, brokerModule != ""},
+	} {
+		options := strings.Join(b.valid, ", ")
+		switch {
+		case b.value != "" && !slices.Contains(b.valid, b.value):
+			fail(b.field, "invalid_"+b.field, fmt.Sprintf("Invalid %s '%s'", b.label, b.value), "Use one of: "+options)
+		case b.value == "" && b.neededBy != "":
+			fail(b.field, "missing_"+b.field, fmt.Sprintf("%s needs a %s", b.neededBy, b.label), "Set "+b.field+" to one of: "+options)
+		case b.value != "" && !b.used:
+			warn(b.field, "unused_"+b.field, fmt.Sprintf("%s '%s' is ignored without %s", b.label, b.value, b.usedBy), "Add "+b.usedBy+" to modules, or drop "+b.field)
+		}
+	}
+
+	cfg := &config.ProjectConfig{
+		ProjectName:       in.Name,
+		GroupID:           in.GroupID,
+		ArtifactID:        in.Name,
+		JavaVersion:       in.JavaVersion,
+		Language:          in.Language,
+		Modules:           resolved,
+		Database:          in.Database,
+		DatabaseVersion:   in.DatabaseVersion,
+		NoSQLDatabase:     in.NoSQLDatabase,
+		MessageBroker:     in.MessageBroker,
+		VectorStore:       in.VectorStore,
+		AIAgents:          aiAgents,
+		CIProvider:        in.CI,
+		Native:            in.Native,
+		Pagination:        in.Pagination,
+		RateLimit:         in.RateLimit,
+		Idempotency:       in.Idempotency,
+		KafkaTransactions: in.KafkaTransactions,
+		Perf:              in.Perf,
+		DevContainer:      in.DevContainer,
+		CodeOwners:        in.CodeOwners,
+		Auditing:          in.Auditing,
+		ReadReplica:       in.ReadReplica,
+		Secrets:           in.Secrets,
+		StaticAnalysis:    in.StaticAnalysis,
+		Cache:             in.Cache,
+		JobStorage:        in.JobStorage,
+		JobRunrDashboard:  in.JobRunrDashboard,
+		JobRunrBatches:    in.JobRunrBatches,
+		JobRunrCarbonArea: in.JobRunrCarbonArea,
+		TaskRunner:        in.TaskRunner,
+		ImageBuilder:      in.ImageBuilder,
+		LogFormat:         in.LogFormat,
+		Architecture:      in.Architecture,
+		JPMS:              in.JPMS,
+		EnablePreview:     in.EnablePreview,
+
+		KafkaConsumerGroup:   in.KafkaGroup,
+		KafkaConcurrency:     in.KafkaConcurrency,
+		KafkaPartitions:      in.KafkaPartitions,
+		KafkaMaxPollRecords:  in.KafkaPollRecords,
+		KafkaMaxPollInterval: in.KafkaPollInterval,
+		KafkaAckMode:         in.KafkaAckMode,
+
+		SQSFifo:            in.SQSFifo,
+		SQSDeadLetter:      in.SQSDeadLetter,
+		SQSMaxReceiveCount: in.SQSReceiveCount,
+		PubSubOrdering:     in.PubSubOrdering,
+		PubSubExactlyOnce:  in.PubSubExactlyOnce,
+	}
+
+	// Cross-flag rules, as init_project applies them
+	if len(v.Errors) == 0 {
+		for _, rule := range []struct {
+			field string
+			apply func() string
+		}{
+			{"vector_store", cfg.ResolveVectorStore},
+			{"secrets", cfg.ResolveSecrets},
+			{"static_analysis", cfg.ResolveStaticAnalysis},
+			{"jpms", cfg.ResolveJPMS},
+			{"architecture", cfg.ResolveArchitecture},
+			{"enable_preview", cfg.ResolvePreview},
+			{"cache", cfg.ResolveCache},
+			{"db_version", cfg.ResolveDatabaseVersion},
+			{"job_storage", cfg.ResolveJobStorage},
+			{"jobrunr", cfg.ResolveJobRunrFeatures},
+			{"kafka_listener", cfg.ResolveKafkaConsumer},
+			{"broker_delivery", cfg.ResolveBrokerDelivery},
+			{"name", cfg.ResolveNames},
+		} {
+			if msg := rule.apply(); msg != "" {
+				fail(rule.field, "conflicting_"+rule.field, msg, "")
+			}
+		}
+	}
+
+	// Deprecated and ignored combinations
+	if cfg.ShowRedisWorkerWarning() {
+		warn("nosql_database", "deprecated_redis_worker", "Redis job storage is deprecated in JobRunr 8+; Worker will store jobs in a separate PostgreSQL instance", "Use nosql_database=mongodb or SQLDatastore to keep jobs in the project's database")
+	}
+	if cfg.WorkerUsesPostgresFallback() && cfg.HasModule(config.ModuleNoSQLDatastore) && !cfg.ShowRedisWorkerWarning() {
+		warn("nosql_database", "worker_postgres_fallback", fmt.Sprintf("JobRunr has no %s storage provider; Worker will store jobs in a separate PostgreSQL instance", config.NoSQLDatabaseDisplayName(cfg.NoSQLDatabase)), "Use nosql_database=mongodb or SQLDatastore to keep jobs in the project's database")
+	}
+	for _, w := range cfg.JobStorageWarnings() {
+		warn("job_storage", "in_memory_job_storage", w, "Use job_storage=postgres or mysql to keep jobs across restarts")
+	}
+	for _, w := range cfg.KafkaConsumerWarnings() {
+		warn("kafka_partitions", "idle_kafka_consumers", w, "Raise kafka_partitions to at least kafka_concurrency")
+	}
+	if cfg.Pagination && !cfg.SupportsPagination() {
+		warn("pagination", "pagination_unsupported", "pagination needs API and SQLDatastore or NoSQLDatastore with MongoDB; it will not be generated", "")
+	}
+	if cfg.RateLimit && !cfg.HasRateLimit() {
+		warn("rate_limit", "rate_limit_unsupported", "rate_limit needs API; it will not be generated", "")
+	}
+	if cfg.Idempotency && !cfg.HasIdempotency() {
+		warn("idempotency", "idempotency_unsupported", "idempotency needs API and SQLDatastore or a Redis service; it will not be generated", "")
+	}
+	if cfg.KafkaTransactions && !cfg.HasKafkaTransactions() {
+		warn("kafka_transactions", "kafka_transactions_unsupported", "kafka_transactions needs message_broker kafka and EventConsumer; it will not be generated", "")
+	}
+	if cfg.Perf && !cfg.HasPerf() {
+		warn("perf", "perf_unsupported", "perf needs API and a datastore; it will not be generated", "")
+	}
+	if cfg.Auditing && !cfg.HasAuditing() {
+		warn("auditing", "auditing_unsupported", "auditing needs SQLDatastore; it will not be generated", "")
+	}
+	if cfg.ReadReplica && !cfg.HasReadReplica() {
+		warn("read_replica", "read_replica_unsupported", "read_replica needs SQLDatastore with postgresql, mysql or mariadb; it will not be generated", "")
+	}
+
+	v.Valid = len(v.Errors) == 0
+	if v.Valid {
+		var added []string
+		for _, m := range cfg.Modules {
+			if !hasModule(modules, m) {
+				added = append(added, m)
+			}
+		}
+		jobStorage := cfg.JobRunrStorageType()
+		if cfg.JobRunrUsesSql() {
+			jobStorage = cfg.JobRunrSqlDatabase()
+		} else if cfg.JobRunrUsesInMemory() {
+			jobStorage = config.JobStorageInMemory
+		}
+		v.Resolved = &ResolvedConfig{
+			Modules:       cfg.Modules,
+			AddedModules:  added,
+			Database:      cfg.Database,
+			NoSQLDatabase: cfg.NoSQLDatabase,
+			MessageBroker: cfg.MessageBroker,
+			VectorStore:   cfg.VectorStore,
+			Secrets:       cfg.Secrets,
+			Cache:         cfg.Cache,
+			JobStorage:    jobStorage,
+		}
+	}
+	return v
+}
+
+// moduleIf returns module when cond holds, "" otherwise
+func moduleIf(cond bool, module string) string {
+	if cond {
+		return module
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import "testing"
+
+func issueCodes(issues []ValidationIssue) map[string]string {
+	codes := make(map[string]string, len(issues))
+	for _, i := range issues {
+		codes[i.Code] = i.Field
+	}
+	return codes
+}
+
+func TestValidateInitConfig_Valid(t *testing.T) {
+	v := validateInitConfig(initConfigInput{
+		Name:          "orders",
+		GroupID:       "com.acme.orders",
+		Modules:       "SQLDatastore,API,Worker,EventConsumer",
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+		JavaVersion:   "21",
+	})
+	if !v.Valid || len(v.Errors) != 0 || len(v.Warnings) != 0 {
+		t.Fatalf("expected a clean result, got %+v", v)
+	}
+	if v.Resolved == nil || v.Resolved.JobStorage != "postgresql" || v.Resolved.MessageBroker != "kafka" {
+		t.Fatalf("unexpected resolved config: %+v", v.Resolved)
+	}
+	for _, m := range []string{"Model", "Shared", "Jobs", "Events"} {
+		if !hasModule(v.Resolved.AddedModules, m) {
+			t.Errorf("expected %s among added modules %v", m, v.Resolved.AddedModules)
+		}
+	}
+}
+
+func TestValidateInitConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    initConfigInput
+		code  string
+		field string
+	}{
+		{"missing broker", initConfigInput{Modules: "EventConsumer"}, "missing_message_broker", "message_broker"},
+		{"missing broker for publisher", initConfigInput{Modules: "Events"}, "missing_message_broker", "message_broker"},
+		{"invalid broker", initConfigInput{Modules: "EventConsumer", MessageBroker: "activemq"}, "invalid_message_broker", "message_broker"},
+		{"missing database", initConfigInput{Modules: "SQLDatastore"}, "missing_database", "database"},
+		{"missing nosql database", initConfigInput{Modules: "NoSQLDatastore"}, "missing_nosql_database", "nosql_database"},
+		{"datastore conflict", initConfigInput{Modules: "SQLDatastore,NoSQLDatastore", Database: "postgresql", NoSQLDatabase: "mongodb"}, "module_conflict", "modules"},
+		{"unknown module", initConfigInput{Modules: "API,Gateway"}, "unknown_module", "modules"},
+		{"no modules", initConfigInput{}, "invalid_module_selection", "modules"},
+		{"bad java", initConfigInput{Modules: "API", JavaVersion: "17"}, "unsupported_java_version", "java_version"},
+		{"bad name", initConfigInput{Name: "My_App", Modules: "API"}, "invalid_name", "name"},
+		{"bad agent", initConfigInput{Modules: "API", AIAgents: "claude,vim"}, "invalid_ai_agent", "ai_agents"},
+		{"pgvector with mysql", initConfigInput{Modules: "AIAgent", VectorStore: "pgvector", Database: "mysql"}, "conflicting_vector_store", "vector_store"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := tt.in
+			if in.GroupID == "" {
+				in.GroupID = "com.acme.app"
+			}
+			if in.JavaVersion == "" {
+				in.JavaVersion = "21"
+			}
+			v := validateInitConfig(in)
+			if v.Valid || v.Resolved != nil {
+				t.Errorf("expected an invalid result, got %+v", v)
+			}
+			if field, ok := issueCodes(v.Errors)[tt.code]; !ok || field != tt.field {
+				t.Errorf("expected error %s on %s, got %+v", tt.code, tt.field, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidateInitConfig_Warnings(t *testing.T) {
+	v := validateInitConfig(initConfigInput{
+		GroupID:       "com.acme.app",
+		JavaVersion:   "21",
+		Modules:       "NoSQLDatastore,Worker,API",
+		NoSQLDatabase: "redis",
+		MessageBroker: "kafka",
+		Database:      "mysql",
+		Auditing:      true,
+	})
+	if !v.Valid {
+		t.Fatalf("warnings must not invalidate the configuration: %+v", v.Errors)
+	}
+	codes := issueCodes(v.Warnings)
+	for _, code := range []string{"deprecated_redis_worker", "unused_message_broker", "unused_database", "auditing_unsupported"} {
+		if _, ok := codes[code]; !ok {
+			t.Errorf("expected warning %s, got %+v", code, v.Warnings)
+		}
+	}
+	if v.Resolved.JobStorage != "postgresql" {
+		t.Errorf("Worker with Redis should store jobs in postgresql, got %q", v.Resolved.JobStorage)
+	}
+}
+
+func TestValidateInitConfig_PgVectorNeedsNoDatabase(t *testing.T) {
+	v := validateInitConfig(initConfigInput{
+		GroupID:     "com.acme.app",
+		JavaVersion: "21",
+		Modules:     "AIAgent",
+		VectorStore: "pgvector",
+	})
+	if !v.Valid {
+		t.Fatalf("pgvector picks its own database: %+v", v.Errors)
+	}
+	if !hasModule(v.Resolved.Modules, "SQLDatastore") || v.Resolved.Database != "postgresql" {
+		t.Errorf("expected SQLDatastore with postgresql, got %+v", v.Resolved)
+	}
+}
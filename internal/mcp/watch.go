@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/doctor"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// projectResourcePrefix is the URI prefix of the per-project resource. The
+// project's absolute path follows it: trabuco://project/home/me/orders.
+const projectResourcePrefix = "trabuco://project"
+
+// DefaultWatchInterval is how often watched project roots are polled.
+const DefaultWatchInterval = 2 * time.Second
+
+// watchedFiles are the project files whose changes are reported. They are
+// the inputs get_project_info and add_module read.
+var watchedFiles = []string{"pom.xml", config.MetadataFileName, "docker-compose.yml"}
+
+// fileStamp identifies a version of a file. A zero stamp means the file does
+// not exist.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// projectWatcher polls registered project roots and reports changes to the
+// watched files. Polling keeps the server free of platform-specific file
+// notification APIs; the files are few and small.
+type projectWatcher struct {
+	mu     sync.Mutex
+	roots  map[string]map[string]fileStamp
+	notify func(uri string, changed []string)
+}
+
+// watcher is the server's project watcher, nil unless watching is enabled.
+var watcher *projectWatcher
+
+func newProjectWatcher(notify func(uri string, changed []string)) *projectWatcher {
+	return &projectWatcher{
+		roots:  make(map[string]map[string]fileStamp),
+		notify: notify,
+	}
+}
+
+// Add registers root, or takes a fresh snapshot if it is already registered.
+// Tools call it after they change a project so their own edits are not
+// reported as out-of-band changes.
+func (w *projectWatcher) Add(root string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.roots[root] = snapshot(root)
+}
+
+// Roots returns the registered project roots, sorted.
+func (w *projectWatcher) Roots() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	roots := make([]string, 0, len(w.roots))
+	for root := range w.roots {
+		roots = append(roots, root)
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// Poll compares every registered root against its last snapshot and
+// notifies once per root whose watched files changed.
+func (w *projectWatcher) Poll() {
+	type change struct {
+		root  string
+		files []string
+	}
+	var changes []change
+
+	w.mu.Lock()
+	for root, previous := range w.roots {
+		current := snapshot(root)
+		var files []string
+		for _, name := range watchedFiles {
+			if current[name] != previous[name] {
+				files = append(files, name)
+			}
+		}
+		if len(files) > 0 {
+			w.roots[root] = current
+			changes = append(changes, change{root, files})
+		}
+	}
+	w.mu.Unlock()
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].root < changes[j].root })
+	for _, c := range changes {
+		w.notify(projectResourceURI(c.root), c.files)
+	}
+}
+
+// Run polls every interval until ctx is done.
+func (w *projectWatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Poll()
+		}
+	}
+}
+
+// snapshot stamps the watched files of root.
+func snapshot(root string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(watchedFiles))
+	for _, name := range watchedFiles {
+		if info, err := os.Stat(filepath.Join(root, name)); err == nil {
+			stamps[name] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+// watchProject registers root with the watcher when watching is enabled.
+func watchProject(root string) {
+	if watcher != nil {
+		watcher.Add(root)
+	}
+}
+
+// startWatcher enables project watching: it registers roots, starts the
+// polling loop and sends notifications/resources/updated for the project
+// resource when a watched file changes.
+func startWatcher(ctx context.Context, s *server.MCPServer, roots []string, interval time.Duration) error {
+	watcher = newProjectWatcher(func(uri string, changed []string) {
+		s.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{
+			"uri":     uri,
+			"changed": changed,
+		})
+	})
+	for _, root := range roots {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return fmt.Errorf("failed to resolve watch root %q: %w", root, err)
+		}
+		watcher.Add(abs)
+	}
+	go watcher.Run(ctx, interval)
+	return nil
+}
+
+// projectResourceURI returns the URI of the project resource for root.
+func projectResourceURI(root string) string {
+	return projectResourcePrefix + filepath.ToSlash(root)
+}
+
+// registerProjectResource exposes trabuco://project/<path>: the project's
+// metadata, read the same way get_project_info reads it. This is the URI
+// that watch notifications name.
+func registerProjectResource(s *server.MCPServer) {
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			projectResourcePrefix+"{+path}",
+			"Project",
+			mcp.WithTemplateDescription("Metadata of the Trabuco project at the given absolute path. With 'trabuco mcp --watch', the server sends notifications/resources/updated for this URI when the project's pom.xml, .trabuco.json or docker-compose.yml change"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			root := strings.TrimPrefix(req.Params.URI, projectResourcePrefix)
+			if !strings.HasPrefix(root, "/") {
+				return nil, fmt.Errorf("project resource URI must contain an absolute path: %s", req.Params.URI)
+			}
+			root = filepath.FromSlash(root)
+
+			meta, err := config.LoadMetadata(root)
+			if err != nil {
+				if meta, err = doctor.GetProjectMetadata(root); err != nil {
+					return nil, fmt.Errorf("failed to read project info at '%s': %w", root, err)
+				}
+			}
+			watchProject(root)
+
+			data, err := json.MarshalIndent(map[string]any{
+				"path":     root,
+				"metadata": meta,
+			}, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      req.Params.URI,
+					MIMEType: "application/json",
+					Text:     string(data),
+				},
+			}, nil
+		},
+	)
+}
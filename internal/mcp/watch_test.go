@@ -0,0 +1,135 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type recordedChange struct {
+	uri     string
+	changed []string
+}
+
+func newRecordingWatcher() (*projectWatcher, *[]recordedChange) {
+	var changes []recordedChange
+	w := newProjectWatcher(func(uri string, changed []string) {
+		changes = append(changes, recordedChange{uri, changed})
+	})
+	return w, &changes
+}
+
+// touch rewrites path with new content and a distinct modification time, so
+// the change is visible even on file systems with coarse timestamps.
+func touch(t *testing.T, path, content string, offset time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mod := time.Now().Add(offset)
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProjectWatcher_ReportsChangedFiles(t *testing.T) {
+	root := t.TempDir()
+	touch(t, filepath.Join(root, "pom.xml"), "<project/>", -time.Hour)
+	touch(t, filepath.Join(root, ".trabuco.json"), "{}", -time.Hour)
+
+	w, changes := newRecordingWatcher()
+	w.Add(root)
+
+	w.Poll()
+	if len(*changes) != 0 {
+		t.Fatalf("expected no notification before any change, got %v", *changes)
+	}
+
+	touch(t, filepath.Join(root, "pom.xml"), "<project><modules/></project>", 0)
+	touch(t, filepath.Join(root, "docker-compose.yml"), "services: {}", 0)
+	w.Poll()
+
+	want := []recordedChange{{projectResourceURI(root), []string{"pom.xml", "docker-compose.yml"}}}
+	if !reflect.DeepEqual(*changes, want) {
+		t.Fatalf("changes = %v, want %v", *changes, want)
+	}
+
+	// The snapshot is updated, so the same change is reported only once.
+	w.Poll()
+	if len(*changes) != 1 {
+		t.Errorf("expected a single notification, got %v", *changes)
+	}
+
+	os.Remove(filepath.Join(root, ".trabuco.json"))
+	w.Poll()
+	if len(*changes) != 2 || !reflect.DeepEqual((*changes)[1].changed, []string{".trabuco.json"}) {
+		t.Errorf("expected deleting .trabuco.json to be reported, got %v", *changes)
+	}
+}
+
+func TestProjectWatcher_AddResnapshots(t *testing.T) {
+	root := t.TempDir()
+	touch(t, filepath.Join(root, "pom.xml"), "<project/>", -time.Hour)
+
+	w, changes := newRecordingWatcher()
+	w.Add(root)
+
+	// A tool changes the project and re-registers it: not an out-of-band edit.
+	touch(t, filepath.Join(root, "pom.xml"), "<project><modules/></project>", 0)
+	w.Add(root)
+	w.Poll()
+	if len(*changes) != 0 {
+		t.Errorf("expected no notification after re-registering, got %v", *changes)
+	}
+	if got := w.Roots(); !reflect.DeepEqual(got, []string{root}) {
+		t.Errorf("Roots() = %v, want [%s]", got, root)
+	}
+}
+
+func TestProjectWatcher_IgnoresOtherFiles(t *testing.T) {
+	root := t.TempDir()
+	w, changes := newRecordingWatcher()
+	w.Add(root)
+
+	touch(t, filepath.Join(root, "README.md"), "# demo", 0)
+	w.Poll()
+	if len(*changes) != 0 {
+		t.Errorf("expected README.md changes to be ignored, got %v", *changes)
+	}
+}
+
+func TestProjectResource_ReadsMetadata(t *testing.T) {
+	root := t.TempDir()
+	metadata := `{"version":"1.0.0","projectName":"demo","groupId":"com.acme.demo","artifactId":"demo","javaVersion":"21","modules":["Model","API"]}`
+	if err := os.WriteFile(filepath.Join(root, ".trabuco.json"), []byte(metadata), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := server.NewMCPServer("trabuco", "test", server.WithResourceCapabilities(false, false))
+	registerProjectResource(s)
+
+	uri := projectResourceURI(root)
+	if !strings.HasPrefix(uri, "trabuco://project/") {
+		t.Fatalf("unexpected project URI %q", uri)
+	}
+	resp := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"`+uri+`"}}`))
+	rpc, ok := resp.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("expected a JSON-RPC response, got %#v", resp)
+	}
+	result, ok := rpc.Result.(mcp.ReadResourceResult)
+	if !ok || len(result.Contents) != 1 {
+		t.Fatalf("unexpected result %#v", rpc.Result)
+	}
+	text := result.Contents[0].(mcp.TextResourceContents).Text
+	if !strings.Contains(text, `"projectName": "demo"`) || !strings.Contains(text, root) {
+		t.Errorf("project resource should contain the metadata and path, got %s", text)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/arianlopezc/Trabuco/internal/config"
@@ -48,6 +49,7 @@ func registerDesignSystem(s *server.MCPServer) {
 			mcp.Description("Natural language description of the multi-service system requirements"),
 			mcp.Required(),
 		),
+		withPaging("services"),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -55,9 +57,13 @@ func registerDesignSystem(s *server.MCPServer) {
 		if requirements == "" {
 			return toolError("requirements parameter is required"), nil
 		}
+		page, err := readPageRequest(req)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
 
 		design := buildSystemDesign(requirements)
-		return toolJSON(design)
+		return toolJSON(designResponse(design, page))
 	})
 }
 
@@ -80,6 +86,7 @@ func registerGenerateWorkspace(s *server.MCPServer, version string) {
 		mcp.WithString("group_id_prefix",
 			mcp.Description("Common group ID prefix for all services (e.g., 'com.company.platform')"),
 		),
+		withPaging("generated services in the response"),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -93,6 +100,10 @@ func registerGenerateWorkspace(s *server.MCPServer, version string) {
 		if workspaceDir == "" {
 			return toolError("workspace_dir parameter is required"), nil
 		}
+		page, err := readPageRequest(req)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
 
 		// Parse service configs
 		var services []serviceConfig
@@ -145,6 +156,17 @@ func registerGenerateWorkspace(s *server.MCPServer, version string) {
 			if validationErr := config.ValidateModuleSelection(modules); validationErr != "" {
 				return toolError(fmt.Sprintf("Service '%s': %s", svc.Name, validationErr)), nil
 			}
+			names := &config.ProjectConfig{
+				ProjectName:   svc.Name,
+				GroupID:       services[i].GroupID,
+				Modules:       config.ResolveDependencies(modules),
+				Database:      svc.Database,
+				NoSQLDatabase: svc.NoSQLDatabase,
+				MessageBroker: svc.MessageBroker,
+			}
+			if nameErr := names.ResolveNames(); nameErr != "" {
+				return toolError(fmt.Sprintf("Service '%s': %s", svc.Name, nameErr)), nil
+			}
 
 			// Check for directory conflicts
 			svcPath := filepath.Join(absWorkspace, svc.Name)
@@ -183,16 +205,20 @@ func registerGenerateWorkspace(s *server.MCPServer, version string) {
 			if err != nil {
 				return toolError(fmt.Sprintf("Service '%s': failed to create generator: %v. Check that the module combination is valid (use suggest_architecture first) and the output directory is writable.", svc.Name, err)), nil
 			}
+			gen.SetInvocation(toolInvocation(req))
 
 			if err := gen.Generate(); err != nil {
 				return toolError(fmt.Sprintf("Service '%s': generation failed: %v", svc.Name, err)), nil
 			}
 
-			generatedServices = append(generatedServices, map[string]any{
-				"name":    svc.Name,
-				"path":    outDir,
-				"modules": resolvedModules,
-			})
+			generated := map[string]any{
+				"name": svc.Name,
+				"path": outDir,
+			}
+			if !page.Summary {
+				generated["modules"] = resolvedModules
+			}
+			generatedServices = append(generatedServices, generated)
 		}
 
 		// Generate shared docker-compose.yml
@@ -202,10 +228,13 @@ func registerGenerateWorkspace(s *server.MCPServer, version string) {
 			return toolError(fmt.Sprintf("Failed to write shared docker-compose.yml: %v", err)), nil
 		}
 
+		// Paging only trims the response: every service has been generated.
+		listed, info := paginate(generatedServices, page)
 		return toolJSON(map[string]any{
 			"status":         "success",
 			"workspace":      absWorkspace,
-			"services":       generatedServices,
+			"services":       listed,
+			"page":           info,
 			"docker_compose": composePath,
 			"next_steps": []string{
 				"Review each service's AGENTS.md for coding patterns",
@@ -218,6 +247,34 @@ func registerGenerateWorkspace(s *server.MCPServer, version string) {
 	})
 }
 
+// designResponse shapes a system design for design_system. Without paging
+// arguments it is the full design; summary mode keeps each service's name,
+// pattern and modules, and drops the per-service boundaries.
+func designResponse(design *systemDesign, page pageRequest) map[string]any {
+	var services any
+	var info pageInfo
+	if page.Summary {
+		compact := make([]map[string]string, len(design.Services))
+		for i, svc := range design.Services {
+			compact[i] = map[string]string{
+				"name":    svc.Name,
+				"pattern": svc.Pattern,
+				"modules": svc.Modules,
+			}
+		}
+		services, info = paginate(compact, page)
+	} else {
+		services, info = paginate(design.Services, page)
+	}
+	return map[string]any{
+		"services":              services,
+		"page":                  info,
+		"shared_infrastructure": design.SharedInfra,
+		"communication_notes":   design.CommunicationNotes,
+		"warnings":              design.Warnings,
+	}
+}
+
 // serviceConfig is the input format for generate_workspace.
 type serviceConfig struct {
 	Name          string `json:"name"`
@@ -419,8 +476,12 @@ func buildSharedDockerCompose(services []serviceConfig) string {
 
 	needsPostgres := false
 	needsMySQL := false
+	needsMariaDB := false
 	needsMongo := false
 	needsRedis := false
+	needsDynamoDB := false
+	needsCassandra := false
+	needsOpenSearch := false
 	needsKafka := false
 	needsRabbitMQ := false
 
@@ -430,12 +491,21 @@ func buildSharedDockerCompose(services []serviceConfig) string {
 			needsPostgres = true
 		case "mysql":
 			needsMySQL = true
+		case "mariadb":
+			needsMariaDB = true
 		}
 		switch svc.NoSQLDatabase {
 		case "mongodb":
 			needsMongo = true
 		case "redis":
 			needsRedis = true
+		case "dynamodb":
+			needsDynamoDB = true
+		case "cassandra":
+			needsCassandra = true
+		}
+		if slices.Contains(strings.Split(svc.Modules, ","), config.ModuleSearch) {
+			needsOpenSearch = true
 		}
 		switch svc.MessageBroker {
 		case "kafka":
@@ -473,6 +543,20 @@ func buildSharedDockerCompose(services []serviceConfig) string {
 `)
 	}
 
+	if needsMariaDB {
+		b.WriteString(`
+  mariadb:
+    image: mariadb:11.4
+    environment:
+      MARIADB_ROOT_PASSWORD: trabuco
+    ports:
+      - "3307:3306"
+    volumes:
+      - mariadb_data:/var/lib/mysql
+    command: --character-set-server=utf8mb4 --collation-server=utf8mb4_unicode_ci
+`)
+	}
+
 	if needsMongo {
 		b.WriteString(`
   mongodb:
@@ -495,6 +579,48 @@ func buildSharedDockerCompose(services []serviceConfig) string {
 `)
 	}
 
+	// Host ports match the services' application.yml defaults.
+	if needsDynamoDB {
+		b.WriteString(`
+  dynamodb:
+    image: amazon/dynamodb-local:2.5.2
+    command: "-jar DynamoDBLocal.jar -sharedDb -inMemory"
+    ports:
+      - "8001:8000"
+`)
+	}
+
+	if needsCassandra {
+		b.WriteString(`
+  cassandra:
+    image: cassandra:5.0
+    environment:
+      CASSANDRA_DC: datacenter1
+      MAX_HEAP_SIZE: 512M
+      HEAP_NEWSIZE: 128M
+    ports:
+      - "9043:9042"
+    volumes:
+      - cassandra_data:/var/lib/cassandra
+`)
+	}
+
+	if needsOpenSearch {
+		b.WriteString(`
+  opensearch:
+    image: opensearchproject/opensearch:2.18.0
+    environment:
+      discovery.type: single-node
+      DISABLE_SECURITY_PLUGIN: "true"
+      DISABLE_INSTALL_DEMO_CONFIG: "true"
+      OPENSEARCH_JAVA_OPTS: -Xms512m -Xmx512m
+    ports:
+      - "9201:9200"
+    volumes:
+      - opensearch_data:/usr/share/opensearch/data
+`)
+	}
+
 	if needsKafka {
 		b.WriteString(`
   kafka:
@@ -532,12 +658,21 @@ func buildSharedDockerCompose(services []serviceConfig) string {
 	if needsMySQL {
 		volumes = append(volumes, "  mysql_data:")
 	}
+	if needsMariaDB {
+		volumes = append(volumes, "  mariadb_data:")
+	}
 	if needsMongo {
 		volumes = append(volumes, "  mongo_data:")
 	}
 	if needsRedis {
 		volumes = append(volumes, "  redis_data:")
 	}
+	if needsCassandra {
+		volumes = append(volumes, "  cassandra_data:")
+	}
+	if needsOpenSearch {
+		volumes = append(volumes, "  opensearch_data:")
+	}
 
 	if len(volumes) > 0 {
 		b.WriteString("\nvolumes:\n")
@@ -0,0 +1,199 @@
+// Package cache is the content-addressed store of migration LLM responses.
+// An entry's key is the SHA-256 of everything that determines the
+// response — the model, the system prompt and the user prompt, which
+// embeds the source files the specialist reads — so any change to the
+// source, the prompts or the model misses, and identical requests hit
+// regardless of repo path, run or machine. Pointing several machines at
+// one directory (a network share, a CI cache) shares the hits.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EnvDir overrides the default cache directory.
+const EnvDir = "TRABUCO_CACHE_DIR"
+
+// DefaultDir returns $TRABUCO_CACHE_DIR, or trabuco/migration under the
+// user cache directory (~/.cache on Linux, ~/Library/Caches on macOS).
+func DefaultDir() string {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		return dir
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "trabuco", "migration")
+}
+
+// Entry is one cached LLM response.
+type Entry struct {
+	Model        string    `json:"model"`
+	Content      string    `json:"content"`
+	InputTokens  int       `json:"inputTokens"`
+	OutputTokens int       `json:"outputTokens"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Cache is a directory of entries, one JSON file per key under a
+// two-character fan-out directory (ab/abcdef....json). Entries are only
+// ever replaced whole, so concurrent writers of the same key are
+// harmless.
+type Cache struct {
+	dir string
+}
+
+// Open returns the cache rooted at dir. The directory is created on the
+// first Put.
+func Open(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string { return c.dir }
+
+// Key hashes the parts of a request into a cache key. Each part is
+// length-prefixed so ("ab", "c") and ("a", "bc") differ.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%d:", len(p))
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// Get returns the entry for key. A hit refreshes the entry's
+// modification time, which Prune uses as its last use.
+func (c *Cache) Get(key string) (*Entry, bool) {
+	p := c.path(key)
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+	return &e, true
+}
+
+// Put stores e under key. The file is written beside its final name and
+// renamed into place so readers never see a partial entry.
+func (c *Cache) Put(key string, e *Entry) error {
+	p := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+// Stats describes the cache's content.
+type Stats struct {
+	Entries int   `json:"entries"`
+	Bytes   int64 `json:"bytes"`
+}
+
+type entryFile struct {
+	path    string
+	size    int64
+	lastUse time.Time
+}
+
+func (c *Cache) entries() ([]entryFile, error) {
+	var files []entryFile
+	err := filepath.WalkDir(c.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == c.dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".json") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, entryFile{path: p, size: info.Size(), lastUse: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+// Stats counts the entries and their size.
+func (c *Cache) Stats() (Stats, error) {
+	files, err := c.entries()
+	var s Stats
+	for _, f := range files {
+		s.Entries++
+		s.Bytes += f.size
+	}
+	return s, err
+}
+
+// Prune removes entries unused for longer than maxAge (0 keeps any age),
+// then the least recently used ones until the cache fits in maxBytes (0
+// means no limit). It returns what was removed.
+func (c *Cache) Prune(maxAge time.Duration, maxBytes int64) (Stats, error) {
+	files, err := c.entries()
+	if err != nil {
+		return Stats{}, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].lastUse.Before(files[j].lastUse) })
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	var removed Stats
+	cutoff := time.Now().Add(-maxAge)
+	for _, f := range files {
+		stale := maxAge > 0 && f.lastUse.Before(cutoff)
+		over := maxBytes > 0 && total > maxBytes
+		if !stale && !over {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed.Entries++
+		removed.Bytes += f.size
+		total -= f.size
+	}
+	return removed, nil
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	if Key("ab", "c") == Key("a", "bc") {
+		t.Error("parts must be length-prefixed")
+	}
+	if Key("model", "prompt") != Key("model", "prompt") {
+		t.Error("keys must be deterministic")
+	}
+}
+
+func TestGetPut(t *testing.T) {
+	c := Open(filepath.Join(t.TempDir(), "cache"))
+	key := Key("m", "system", "user")
+	if _, ok := c.Get(key); ok {
+		t.Fatal("empty cache hit")
+	}
+	if s, err := c.Stats(); err != nil || s.Entries != 0 {
+		t.Errorf("Stats of a missing dir = %+v, %v", s, err)
+	}
+	if err := c.Put(key, &Entry{Model: "m", Content: `{"phase":2}`, InputTokens: 10, OutputTokens: 5}); err != nil {
+		t.Fatal(err)
+	}
+	e, ok := c.Get(key)
+	if !ok || e.Content != `{"phase":2}` || e.InputTokens != 10 {
+		t.Errorf("Get = %+v, %v", e, ok)
+	}
+	if _, err := os.Stat(filepath.Join(c.Dir(), key[:2], key+".json")); err != nil {
+		t.Errorf("entry not fanned out by key prefix: %v", err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	c := Open(t.TempDir())
+	old := time.Now().Add(-48 * time.Hour)
+	for i, name := range []string{"oldest", "old", "recent"} {
+		key := Key(name)
+		if err := c.Put(key, &Entry{Content: "0123456789"}); err != nil {
+			t.Fatal(err)
+		}
+		if name != "recent" {
+			at := old.Add(time.Duration(i) * time.Hour)
+			if err := os.Chtimes(c.path(key), at, at); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	all, _ := c.Stats()
+
+	removed, err := c.Prune(0, all.Bytes-1)
+	if err != nil || removed.Entries != 1 {
+		t.Fatalf("size prune removed %+v, %v; want the single oldest entry", removed, err)
+	}
+	if _, ok := c.Get(Key("oldest")); ok {
+		t.Error("the least recently used entry should go first")
+	}
+
+	removed, err = c.Prune(24*time.Hour, 0)
+	if err != nil || removed.Entries != 1 {
+		t.Fatalf("age prune removed %+v, %v", removed, err)
+	}
+	if _, ok := c.Get(Key("recent")); !ok {
+		t.Error("a recent entry should survive")
+	}
+}
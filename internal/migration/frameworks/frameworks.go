@@ -0,0 +1,172 @@
+// Package frameworks knows the non-Spring source frameworks the migration
+// can convert: how to recognize them in a build file, which Spring
+// dependency replaces each of theirs, and the conversion guidance the LLM
+// specialists receive. Spring Boot sources need none of this; other
+// frameworks (Helidon, Vert.x, Ktor, ...) still block as
+// NON_SPRING_FRAMEWORK.
+package frameworks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists/prompts"
+)
+
+// Supported source frameworks. The values are what the assessor records
+// in assessment.json and state.json's sourceConfig.framework.
+const (
+	Quarkus    = "quarkus"
+	Micronaut  = "micronaut"
+	Dropwizard = "dropwizard"
+)
+
+// markers are build-file substrings identifying each framework, checked
+// in order: a Quarkus or Micronaut project can pull in a Dropwizard
+// library, never the reverse.
+var markers = []struct {
+	framework string
+	needles   []string
+}{
+	{Quarkus, []string{"<groupId>io.quarkus", "io.quarkus:", "io.quarkus.platform"}},
+	{Micronaut, []string{"<groupId>io.micronaut", "io.micronaut:", "io.micronaut.platform"}},
+	{Dropwizard, []string{"<groupId>io.dropwizard", "io.dropwizard:"}},
+}
+
+// Detect returns the supported framework the given build files (pom.xml
+// or build.gradle contents) depend on, or "" for Spring Boot and anything
+// else.
+func Detect(buildFiles ...string) string {
+	for _, m := range markers {
+		for _, content := range buildFiles {
+			for _, needle := range m.needles {
+				if strings.Contains(content, needle) {
+					return m.framework
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// Mapping is one dependency replacement: From (groupId:artifactId, or
+// groupId:* for a whole group) is dropped and To is added in the Trabuco
+// module that needs it. An empty To means the dependency is removed
+// without a replacement.
+type Mapping struct {
+	From string
+	To   string
+	Note string
+}
+
+// mappings are the dependency tables of each supported framework.
+var mappings = map[string][]Mapping{
+	Quarkus: {
+		{"io.quarkus:quarkus-resteasy-reactive-jackson", "org.springframework.boot:spring-boot-starter-web", "API"},
+		{"io.quarkus:quarkus-resteasy-jackson", "org.springframework.boot:spring-boot-starter-web", "API"},
+		{"io.quarkus:quarkus-rest-jackson", "org.springframework.boot:spring-boot-starter-web", "API"},
+		{"io.quarkus:quarkus-hibernate-validator", "org.springframework.boot:spring-boot-starter-validation", "API"},
+		{"io.quarkus:quarkus-hibernate-orm-panache", "org.springframework.boot:spring-boot-starter-data-jdbc", "SQLDatastore; entities become Immutables models"},
+		{"io.quarkus:quarkus-hibernate-orm", "org.springframework.boot:spring-boot-starter-data-jdbc", "SQLDatastore"},
+		{"io.quarkus:quarkus-jdbc-postgresql", "org.postgresql:postgresql", "SQLDatastore"},
+		{"io.quarkus:quarkus-jdbc-mysql", "com.mysql:mysql-connector-j", "SQLDatastore"},
+		{"io.quarkus:quarkus-flyway", "org.flywaydb:flyway-core", "SQLDatastore; keep the migration scripts"},
+		{"io.quarkus:quarkus-mongodb-panache", "org.springframework.boot:spring-boot-starter-data-mongodb", "NoSQLDatastore"},
+		{"io.quarkus:quarkus-redis-client", "org.springframework.boot:spring-boot-starter-data-redis", "NoSQLDatastore"},
+		{"io.quarkus:quarkus-scheduler", "org.jobrunr:jobrunr-spring-boot-3-starter", "Worker"},
+		{"io.quarkus:quarkus-quartz", "org.jobrunr:jobrunr-spring-boot-3-starter", "Worker"},
+		{"io.quarkus:quarkus-smallrye-reactive-messaging-kafka", "org.springframework.kafka:spring-kafka", "EventConsumer"},
+		{"io.quarkus:quarkus-messaging-kafka", "org.springframework.kafka:spring-kafka", "EventConsumer"},
+		{"io.quarkus:quarkus-smallrye-reactive-messaging-rabbitmq", "org.springframework.boot:spring-boot-starter-amqp", "EventConsumer"},
+		{"io.quarkus:quarkus-rest-client-reactive-jackson", "org.springframework.boot:spring-boot-starter-web", "RestClient + @HttpExchange"},
+		{"io.quarkus:quarkus-rest-client-jackson", "org.springframework.boot:spring-boot-starter-web", "RestClient + @HttpExchange"},
+		{"io.quarkus:quarkus-smallrye-health", "org.springframework.boot:spring-boot-starter-actuator", "health endpoints"},
+		{"io.quarkus:quarkus-micrometer-registry-prometheus", "io.micrometer:micrometer-registry-prometheus", ""},
+		{"io.quarkus:quarkus-smallrye-openapi", "org.springdoc:springdoc-openapi-starter-webmvc-ui", "API"},
+		{"io.quarkus:quarkus-oidc", "org.springframework.boot:spring-boot-starter-oauth2-resource-server", "API"},
+		{"io.quarkus:quarkus-junit5", "org.springframework.boot:spring-boot-starter-test", "tests"},
+		{"io.quarkus:quarkus-junit5-mockito", "org.springframework.boot:spring-boot-starter-test", "tests"},
+		{"io.quarkus:quarkus-arc", "", "CDI container; Spring's context replaces it"},
+		{"io.quarkus:quarkus-maven-plugin", "org.springframework.boot:spring-boot-maven-plugin", "build plugin"},
+		{"io.quarkus:*", "", "no Spring counterpart; review each remaining extension"},
+	},
+	Micronaut: {
+		{"io.micronaut:micronaut-http-server-netty", "org.springframework.boot:spring-boot-starter-web", "API"},
+		{"io.micronaut:micronaut-http-client", "org.springframework.boot:spring-boot-starter-web", "RestClient + @HttpExchange"},
+		{"io.micronaut:micronaut-inject", "", "DI container; Spring's context replaces it"},
+		{"io.micronaut:micronaut-inject-java", "", "annotation processor; remove"},
+		{"io.micronaut:micronaut-runtime", "", "Spring Boot's runtime replaces it"},
+		{"io.micronaut.validation:micronaut-validation", "org.springframework.boot:spring-boot-starter-validation", "API"},
+		{"io.micronaut.serde:micronaut-serde-jackson", "", "Jackson via spring-boot-starter-json"},
+		{"io.micronaut.data:micronaut-data-jdbc", "org.springframework.boot:spring-boot-starter-data-jdbc", "SQLDatastore"},
+		{"io.micronaut.data:micronaut-data-hibernate-jpa", "org.springframework.boot:spring-boot-starter-data-jdbc", "SQLDatastore"},
+		{"io.micronaut.data:micronaut-data-mongodb", "org.springframework.boot:spring-boot-starter-data-mongodb", "NoSQLDatastore"},
+		{"io.micronaut.sql:micronaut-jdbc-hikari", "", "HikariCP comes with spring-boot-starter-jdbc"},
+		{"io.micronaut.flyway:micronaut-flyway", "org.flywaydb:flyway-core", "SQLDatastore; keep the migration scripts"},
+		{"io.micronaut.redis:micronaut-redis-lettuce", "org.springframework.boot:spring-boot-starter-data-redis", "NoSQLDatastore"},
+		{"io.micronaut.kafka:micronaut-kafka", "org.springframework.kafka:spring-kafka", "EventConsumer"},
+		{"io.micronaut.rabbitmq:micronaut-rabbitmq", "org.springframework.boot:spring-boot-starter-amqp", "EventConsumer"},
+		{"io.micronaut.management:micronaut-management", "org.springframework.boot:spring-boot-starter-actuator", "health endpoints"},
+		{"io.micronaut.micrometer:micronaut-micrometer-registry-prometheus", "io.micrometer:micrometer-registry-prometheus", ""},
+		{"io.micronaut.openapi:micronaut-openapi", "org.springdoc:springdoc-openapi-starter-webmvc-ui", "API"},
+		{"io.micronaut.security:micronaut-security-jwt", "org.springframework.boot:spring-boot-starter-oauth2-resource-server", "API"},
+		{"io.micronaut.test:micronaut-test-junit5", "org.springframework.boot:spring-boot-starter-test", "tests"},
+		{"io.micronaut.maven:micronaut-maven-plugin", "org.springframework.boot:spring-boot-maven-plugin", "build plugin"},
+		{"io.micronaut:*", "", "no Spring counterpart; review each remaining module"},
+	},
+	Dropwizard: {
+		{"io.dropwizard:dropwizard-core", "org.springframework.boot:spring-boot-starter-web", "API; Jersey resources become controllers"},
+		{"io.dropwizard:dropwizard-jdbi3", "org.springframework.boot:spring-boot-starter-data-jdbc", "SQLDatastore; JDBI DAOs become repositories"},
+		{"io.dropwizard:dropwizard-hibernate", "org.springframework.boot:spring-boot-starter-data-jdbc", "SQLDatastore"},
+		{"io.dropwizard:dropwizard-db", "", "DataSourceFactory; spring.datasource.* replaces it"},
+		{"io.dropwizard:dropwizard-migrations", "org.flywaydb:flyway-core", "SQLDatastore; port the Liquibase changesets or keep Liquibase by decision"},
+		{"io.dropwizard:dropwizard-validation", "org.springframework.boot:spring-boot-starter-validation", "API"},
+		{"io.dropwizard:dropwizard-client", "org.springframework.boot:spring-boot-starter-web", "RestClient + @HttpExchange"},
+		{"io.dropwizard:dropwizard-auth", "org.springframework.boot:spring-boot-starter-oauth2-resource-server", "API; BESPOKE_AUTH_PROTOCOL decision"},
+		{"io.dropwizard:dropwizard-testing", "org.springframework.boot:spring-boot-starter-test", "tests"},
+		{"io.dropwizard:dropwizard-views-mustache", "", "server-side views are out of Trabuco's scope"},
+		{"io.dropwizard.metrics:metrics-annotation", "io.micrometer:micrometer-core", "@Timed / @Counted"},
+		{"org.jdbi:jdbi3-sqlobject", "", "replaced with Spring Data JDBC repositories"},
+		{"io.dropwizard:*", "", "no Spring counterpart; review each remaining bundle"},
+	},
+}
+
+// Mappings returns the dependency table of framework, nil for
+// unsupported ones.
+func Mappings(framework string) []Mapping {
+	return mappings[framework]
+}
+
+// Guidance returns the text appended to the LLM specialists' system
+// prompt for a source on framework: the JAX-RS and CDI conversion rules,
+// the framework's specifics and its dependency table. It is empty for
+// Spring Boot and unsupported frameworks.
+func Guidance(framework string) string {
+	var specifics string
+	switch framework {
+	case Quarkus:
+		specifics = prompts.Quarkus
+	case Micronaut:
+		specifics = prompts.Micronaut
+	case Dropwizard:
+		specifics = prompts.Dropwizard
+	default:
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(prompts.NonSpring)
+	b.WriteString("\n")
+	b.WriteString(specifics)
+	fmt.Fprintf(&b, "\n## Dependency mapping (%s → Spring Boot)\n\n", framework)
+	b.WriteString("When you write a module pom.xml, add the Spring dependency for each source dependency the module's code used. Never carry a source-framework dependency into a Trabuco module.\n\n")
+	b.WriteString("| Source dependency | Spring replacement | Where / note |\n|---|---|---|\n")
+	for _, m := range mappings[framework] {
+		to := m.To
+		if to == "" {
+			to = "(remove)"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", m.From, to, m.Note)
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package frameworks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"spring boot", []string{`<parent><groupId>org.springframework.boot</groupId></parent>`}, ""},
+		{"quarkus bom", []string{`<dependency><groupId>io.quarkus.platform</groupId><artifactId>quarkus-bom</artifactId></dependency>`}, Quarkus},
+		{"micronaut in a module", []string{"", `<groupId>io.micronaut.data</groupId>`}, Micronaut},
+		{"gradle dropwizard", []string{`implementation "io.dropwizard:dropwizard-core:4.0.7"`}, Dropwizard},
+		{"quarkus wins over a dropwizard library", []string{`<groupId>io.dropwizard.metrics</groupId>`, `<groupId>io.quarkus</groupId>`}, Quarkus},
+		{"helidon is not convertible", []string{`<groupId>io.helidon.webserver</groupId>`}, ""},
+	}
+	for _, tc := range cases {
+		if got := Detect(tc.files...); got != tc.want {
+			t.Errorf("%s: Detect = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestGuidance(t *testing.T) {
+	if got := Guidance(""); got != "" {
+		t.Errorf("Spring sources need no guidance, got %d bytes", len(got))
+	}
+	for _, fw := range []string{Quarkus, Micronaut, Dropwizard} {
+		g := Guidance(fw)
+		for _, want := range []string{"## JAX-RS → Spring MVC", "## CDI → Spring dependency injection", "## Dependency mapping (" + fw + " → Spring Boot)"} {
+			if !strings.Contains(g, want) {
+				t.Errorf("%s guidance missing %q", fw, want)
+			}
+		}
+		for _, m := range Mappings(fw) {
+			if !strings.Contains(g, "`"+m.From+"`") {
+				t.Errorf("%s guidance missing the mapping of %s", fw, m.From)
+			}
+		}
+	}
+	if !strings.Contains(Guidance(Quarkus), "PanacheEntity") || strings.Contains(Guidance(Micronaut), "PanacheEntity") {
+		t.Error("framework specifics should only reach their own framework")
+	}
+}
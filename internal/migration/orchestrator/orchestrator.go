@@ -33,6 +33,9 @@ type Orchestrator struct {
 	cliVersion string
 	registry   *specialists.Registry
 	gate       Gate
+	reviewer   Reviewer
+	autoAccept float64
+	progress   ProgressFunc
 }
 
 // Gate abstracts the user-approval surface. CLI mode supplies a terminal
@@ -155,13 +158,18 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 		return "", fmt.Errorf("no specialist registered for phase %s (this is a bug — milestone for that phase isn't shipped yet)", phase)
 	}
 
-	// Tag the pre-state so we can roll back atomically.
+	// Tag the pre-state so we can roll back atomically. A phase re-run
+	// for a later slice moves its pre-tag forward: rolling back must not
+	// discard the slices already approved.
 	preTag := vcs.PhasePreTag(phase)
-	if !vcs.TagExists(o.repoRoot, preTag) {
-		if err := vcs.CreateTag(o.repoRoot, preTag, fmt.Sprintf("trabuco migration: pre-%s", phase), false); err != nil {
+	if !vcs.TagExists(o.repoRoot, preTag) || rec.PostTag != "" {
+		if err := vcs.CreateTag(o.repoRoot, preTag, fmt.Sprintf("trabuco migration: pre-%s", phase), true); err != nil {
 			return "", fmt.Errorf("create pre-tag: %w", err)
 		}
 	}
+	// A re-run after a failure or rejection must not replay the cached
+	// LLM response that led there.
+	fresh := rec.State == types.PhaseFailed || rec.State == types.PhaseUserRejected
 	rec.State = types.PhaseInProgress
 	rec.PreTag = preTag
 	now := time.Now().UTC()
@@ -190,17 +198,28 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 		Phase:    phase,
 		State:    s,
 		UserHint: userHint,
+		Fresh:    fresh,
 	}
 	if err := writeJSON(state.PhaseInputPath(o.repoRoot, phase), in); err != nil {
 		return "", fmt.Errorf("write phase input: %w", err)
 	}
 
+	o.report(phase, StageSpecialist, rec)
 	out, err := specialist.Run(ctx, in)
+	if cerr := o.checkpoint(ctx, s, phase, rec, false); cerr != nil {
+		return "", cerr
+	}
 	if err != nil {
 		rec.State = types.PhaseFailed
 		_ = o.SaveState(s)
 		return "", fmt.Errorf("specialist %s failed: %w", specialist.Name(), err)
 	}
+	if out.Usage != nil {
+		if rec.Usage == nil {
+			rec.Usage = &types.TokenUsage{}
+		}
+		rec.Usage.Add(*out.Usage)
+	}
 	if err := writeJSON(state.PhaseOutputPath(o.repoRoot, phase), out); err != nil {
 		return "", fmt.Errorf("write phase output: %w", err)
 	}
@@ -227,9 +246,28 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 		}
 	}
 
+	// Per-file review, when on, before anything reaches the disk. The
+	// phase output is rewritten so it records what was actually written.
+	if o.reviewer != nil {
+		o.report(phase, StageReview, rec)
+		err := o.reviewFileWrites(ctx, phase, out)
+		if cerr := o.checkpoint(ctx, s, phase, rec, false); cerr != nil {
+			return "", cerr
+		}
+		if err != nil {
+			rec.State = types.PhaseFailed
+			_ = o.SaveState(s)
+			return "", fmt.Errorf("review: %w", err)
+		}
+		if err := writeJSON(state.PhaseOutputPath(o.repoRoot, phase), out); err != nil {
+			return "", fmt.Errorf("write phase output: %w", err)
+		}
+	}
+
 	// Apply file writes from each applied item. Specialists declare
 	// the changes; the orchestrator materializes them. Rollback to
 	// pre-tag if the validation funnel later fails.
+	o.report(phase, StageApply, rec)
 	if err := applyFileWrites(o.repoRoot, out); err != nil {
 		_ = vcs.ResetHard(o.repoRoot, preTag)
 		rec.State = types.PhaseFailed
@@ -237,6 +275,28 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 		return "", fmt.Errorf("apply file writes: %w (rolled back to %s)", err, preTag)
 	}
 
+	// Compile-repair loop: javac errors go back to the specialist a
+	// bounded number of times before the funnel judges the result.
+	if phase != types.PhaseActivation && hasFileWrites(out) {
+		repairs := rec.Repairs
+		o.report(phase, StageRepair, rec)
+		err := o.repairCompile(ctx, specialist, in, out, rec)
+		if cerr := o.checkpoint(ctx, s, phase, rec, true); cerr != nil {
+			return "", cerr
+		}
+		if err != nil {
+			_ = vcs.ResetHard(o.repoRoot, preTag)
+			rec.State = types.PhaseFailed
+			_ = o.SaveState(s)
+			return "", fmt.Errorf("%w (rolled back to %s)", err, preTag)
+		}
+		if rec.Repairs > repairs {
+			if err := writeJSON(state.PhaseOutputPath(o.repoRoot, phase), out); err != nil {
+				return "", fmt.Errorf("write phase output: %w", err)
+			}
+		}
+	}
+
 	// Run the validation funnel (compile + tests). ArchUnit deferred
 	// during migration phases. We skip the funnel when no item declared
 	// any file_writes — Phase 0 (assessor) produces only the assessment
@@ -251,7 +311,11 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 		if phase == types.PhaseActivation {
 			mode = validation.ModeActivation
 		}
+		o.report(phase, StageValidate, rec)
 		res = validation.Run(o.repoRoot, mode, affectedModules(o.repoRoot, out))
+		if cerr := o.checkpoint(ctx, s, phase, rec, true); cerr != nil {
+			return "", cerr
+		}
 	}
 	if !res.Passed {
 		// Auto-rollback to pre-tag, surface failure as a blocker.
@@ -268,6 +332,7 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 	}
 
 	// Present the gate.
+	o.report(phase, StageGate, rec)
 	action, editHint, err := o.gate.Present(ctx, phase, out)
 	if err != nil {
 		return "", err
@@ -288,11 +353,16 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 		rec.State = types.PhaseCompleted
 		approvedAt := time.Now().UTC()
 		rec.ApprovedAt = &approvedAt
+		if _, ok := state.PhaseCategories[phase]; ok {
+			rec.Migrated = migratedSources(out)
+			s.RecordMigrated(rec.Migrated...)
+		}
 		if err := o.SaveState(s); err != nil {
 			return "", err
 		}
 		// Write the human-readable phase report.
 		_ = writeReport(state.PhaseReportPath(o.repoRoot, phase), phase, out, &res)
+		o.report(phase, StageDone, rec)
 		return types.GateApprove, nil
 
 	case types.GateEditAndApprove:
@@ -316,6 +386,47 @@ func (o *Orchestrator) runPhaseLocked(ctx context.Context, phase types.Phase, hi
 	return "", fmt.Errorf("unknown gate action: %s", action)
 }
 
+// SetSelection restricts the module phases to a slice of the source; nil
+// lifts the restriction. Changing the selection reopens the module phases
+// already completed or skipped so they run again for the new slice;
+// sources earlier slices migrated are never migrated twice. It reports
+// whether the selection changed.
+func (o *Orchestrator) SetSelection(sel *state.Selection) (bool, error) {
+	if err := state.AcquireLock(o.repoRoot, "cli"); err != nil {
+		return false, err
+	}
+	defer state.ReleaseLock(o.repoRoot)
+
+	s, err := o.LoadState()
+	if err != nil {
+		return false, err
+	}
+	if s.Selection.Equal(sel) {
+		return false, nil
+	}
+	s.Selection = sel
+	for phase := range state.PhaseCategories {
+		rec := s.Phases[phase]
+		if rec.State == types.PhaseCompleted || rec.State == types.PhaseNotApplicable {
+			rec.State = types.PhasePending
+			rec.Reason = ""
+		}
+	}
+	return true, o.SaveState(s)
+}
+
+// migratedSources returns the source files the applied items of a phase
+// migrated, per their source_evidence.
+func migratedSources(out *specialists.Output) []string {
+	var files []string
+	for _, item := range out.Items {
+		if item.State == types.ItemApplied && item.SourceEvidence != nil && item.SourceEvidence.File != "" {
+			files = append(files, item.SourceEvidence.File)
+		}
+	}
+	return files
+}
+
 // Rollback resets to the pre-tag for the given phase and clears later
 // phases from state.json.
 func (o *Orchestrator) Rollback(toPhase types.Phase) error {
@@ -338,6 +449,7 @@ func (o *Orchestrator) Rollback(toPhase types.Phase) error {
 	// Clear all phases >= toPhase.
 	for _, p := range types.AllPhases() {
 		if int(p) >= int(toPhase) {
+			s.ForgetMigrated(s.Phases[p].Migrated...)
 			s.Phases[p] = &state.PhaseRecord{State: types.PhasePending}
 		}
 	}
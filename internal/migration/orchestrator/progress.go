@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/state"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+	"github.com/arianlopezc/Trabuco/internal/migration/vcs"
+)
+
+// Stages of a phase run, in order, as reported to the ProgressFunc.
+const (
+	StageSpecialist = "specialist"
+	StageReview     = "review"
+	StageApply      = "apply"
+	StageRepair     = "repair"
+	StageValidate   = "validate"
+	StageGate       = "gate"
+	StageDone       = "done"
+)
+
+// stagePercent is how far into a phase each stage starts. The specialist
+// call and the validation funnel dominate a phase's wall time.
+var stagePercent = map[string]float64{
+	StageSpecialist: 0,
+	StageReview:     45,
+	StageApply:      50,
+	StageRepair:     55,
+	StageValidate:   70,
+	StageGate:       95,
+	StageDone:       100,
+}
+
+// Progress is one step of a running phase.
+type Progress struct {
+	Phase   types.Phase
+	Stage   string
+	Percent float64
+	// Usage is the phase's LLM usage so far; nil before the first call.
+	Usage *types.TokenUsage
+}
+
+// ProgressFunc receives a phase's progress as it enters each stage.
+type ProgressFunc func(Progress)
+
+// SetProgress registers fn to be called as a phase enters each stage.
+// MCP tools forward the calls as progress notifications.
+func (o *Orchestrator) SetProgress(fn ProgressFunc) {
+	o.progress = fn
+}
+
+func (o *Orchestrator) report(phase types.Phase, stage string, rec *state.PhaseRecord) {
+	if o.progress == nil {
+		return
+	}
+	p := Progress{Phase: phase, Stage: stage, Percent: stagePercent[stage]}
+	if rec.Usage != nil {
+		u := *rec.Usage
+		p.Usage = &u
+	}
+	o.progress(p)
+}
+
+// checkpoint ends a phase whose context was cancelled: files written so
+// far are rolled back to the pre-tag and the phase stays in_progress,
+// which migrate resume picks up. The phase is not marked failed, so the
+// resumed run replays the LLM responses already paid for from the cache
+// instead of asking again. It returns nil when ctx is still live.
+func (o *Orchestrator) checkpoint(ctx context.Context, s *state.State, phase types.Phase, rec *state.PhaseRecord, written bool) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	if written {
+		_ = vcs.ResetHard(o.repoRoot, rec.PreTag)
+	}
+	rec.State = types.PhaseInProgress
+	if err := o.SaveState(s); err != nil {
+		return fmt.Errorf("phase %s cancelled, and saving its checkpoint failed: %w", phase, err)
+	}
+	return fmt.Errorf("phase %s cancelled; checkpointed, run migrate resume to continue: %w", phase, ctx.Err())
+}
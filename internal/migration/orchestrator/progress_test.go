@@ -0,0 +1,61 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/state"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+)
+
+func TestReport_SnapshotsUsage(t *testing.T) {
+	var got []Progress
+	o := New(t.TempDir(), "test", nil, nil)
+	o.SetProgress(func(p Progress) { got = append(got, p) })
+
+	rec := &state.PhaseRecord{}
+	o.report(types.PhaseModel, StageSpecialist, rec)
+	rec.Usage = &types.TokenUsage{CostUSD: 0.25}
+	o.report(types.PhaseModel, StageValidate, rec)
+	rec.Usage.CostUSD = 1
+
+	if len(got) != 2 {
+		t.Fatalf("got %d reports, want 2", len(got))
+	}
+	if got[0].Usage != nil || got[0].Percent != 0 {
+		t.Errorf("first report = %+v", got[0])
+	}
+	if got[1].Stage != StageValidate || got[1].Percent != stagePercent[StageValidate] || got[1].Usage.CostUSD != 0.25 {
+		t.Errorf("second report = %+v; the usage must be a copy", got[1])
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	repo := t.TempDir()
+	o := New(repo, "test", nil, nil)
+	s := state.New("test")
+	rec := s.Phases[types.PhaseModel]
+	rec.State = types.PhaseInProgress
+
+	if err := o.checkpoint(context.Background(), s, types.PhaseModel, rec, false); err != nil {
+		t.Fatalf("a live context must not checkpoint: %v", err)
+	}
+	if state.Exists(repo) {
+		t.Fatal("a live context must not save state")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := o.checkpoint(ctx, s, types.PhaseModel, rec, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	saved, err := state.Load(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := saved.Phases[types.PhaseModel].State; got != types.PhaseInProgress {
+		t.Errorf("checkpointed phase is %s, want in_progress so migrate resume picks it up", got)
+	}
+}
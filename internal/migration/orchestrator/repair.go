@@ -0,0 +1,125 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/state"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+	"github.com/arianlopezc/Trabuco/internal/migration/validation"
+)
+
+// maxRepairAttempts bounds the compile-repair loop. Each attempt is one
+// LLM call plus one Maven compile; errors left after the last attempt
+// fail the validation funnel as COMPILE_FAILED.
+const maxRepairAttempts = 3
+
+// repairCompile compiles the modules a phase wrote to and, while javac
+// reports errors, asks the specialist to fix them and applies the fixes
+// to disk and to out. Specialists that are not Repairers, builds failing
+// for reasons other than javac errors, and repairs that change nothing
+// end the loop early; the validation funnel then reports the failure.
+func (o *Orchestrator) repairCompile(ctx context.Context, sp specialists.Specialist, in *specialists.Input, out *specialists.Output, rec *state.PhaseRecord) error {
+	repairer, ok := sp.(specialists.Repairer)
+	if !ok {
+		return nil
+	}
+	for attempt := 1; attempt <= maxRepairAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		log, ok := validation.Compile(o.repoRoot, affectedModules(o.repoRoot, out))
+		if ok {
+			return nil
+		}
+		errs := validation.ParseCompileErrors(o.repoRoot, log)
+		if len(errs) == 0 {
+			return nil
+		}
+		fix, err := repairer.Repair(ctx, in, &specialists.RepairRequest{
+			Attempt: attempt,
+			Errors:  errs,
+			Files:   writtenFiles(o.repoRoot, out),
+		})
+		if err != nil {
+			return fmt.Errorf("repair attempt %d: %w", attempt, err)
+		}
+		rec.Repairs++
+		if fix.Usage != nil {
+			if rec.Usage == nil {
+				rec.Usage = &types.TokenUsage{}
+			}
+			rec.Usage.Add(*fix.Usage)
+		}
+		if !hasFileWrites(fix) {
+			return nil
+		}
+		if err := applyFileWrites(o.repoRoot, fix); err != nil {
+			return fmt.Errorf("repair attempt %d: %w", attempt, err)
+		}
+		mergeRepair(out, fix)
+	}
+	return nil
+}
+
+// writtenFiles returns the files out creates or replaces with their
+// content on disk.
+func writtenFiles(repoRoot string, out *specialists.Output) []types.FileWrite {
+	var files []types.FileWrite
+	seen := map[string]bool{}
+	for _, item := range out.Items {
+		if item.State != types.ItemApplied {
+			continue
+		}
+		for _, w := range item.FileWrites {
+			if w.Operation == types.OpDelete || seen[w.Path] {
+				continue
+			}
+			seen[w.Path] = true
+			data, err := os.ReadFile(filepath.Join(repoRoot, filepath.FromSlash(w.Path)))
+			if err != nil {
+				continue
+			}
+			files = append(files, types.FileWrite{Path: w.Path, Operation: w.Operation, Content: string(data)})
+		}
+	}
+	return files
+}
+
+// mergeRepair folds a repair's writes into the phase output so it records
+// the files as finally written: a write to a path an item already wrote
+// replaces that item's content; other writes join the output as their
+// own items.
+func mergeRepair(out *specialists.Output, fix *specialists.Output) {
+	owner := map[string]*types.FileWrite{}
+	for i := range out.Items {
+		item := &out.Items[i]
+		if item.State != types.ItemApplied {
+			continue
+		}
+		for j := range item.FileWrites {
+			owner[item.FileWrites[j].Path] = &item.FileWrites[j]
+		}
+	}
+	for _, item := range fix.Items {
+		if item.State != types.ItemApplied {
+			continue
+		}
+		var extra []types.FileWrite
+		for _, w := range item.FileWrites {
+			if prev, ok := owner[w.Path]; ok && w.Operation != types.OpDelete {
+				prev.Content = w.Content
+				continue
+			}
+			extra = append(extra, w)
+		}
+		if len(extra) > 0 {
+			item.ID = "repair-" + item.ID
+			item.FileWrites = extra
+			out.Items = append(out.Items, item)
+		}
+	}
+}
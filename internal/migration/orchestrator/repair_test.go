@@ -0,0 +1,60 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+)
+
+func TestMergeRepair(t *testing.T) {
+	out := &specialists.Output{Items: []types.OutputItem{
+		{ID: "order", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "model/src/main/java/Order.java", Operation: types.OpCreate, Content: "broken"},
+			{Path: "model/pom.xml", Operation: types.OpReplace, Content: "<project/>"},
+		}},
+		{ID: "invoice", State: types.ItemBlocked},
+	}}
+	fix := &specialists.Output{Items: []types.OutputItem{
+		{ID: "fix-order", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "model/src/main/java/Order.java", Operation: types.OpReplace, Content: "fixed"},
+		}},
+		{ID: "customer", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "model/src/main/java/Customer.java", Operation: types.OpCreate, Content: "new"},
+		}},
+		{ID: "gave-up", State: types.ItemBlocked, BlockerCode: types.BlockerCompileFailed},
+	}}
+	mergeRepair(out, fix)
+
+	if got := out.Items[0].FileWrites[0]; got.Content != "fixed" || got.Operation != types.OpCreate {
+		t.Errorf("repaired write = %+v, want the original create with the fixed content", got)
+	}
+	if len(out.Items) != 3 || out.Items[2].ID != "repair-customer" || out.Items[2].FileWrites[0].Path != "model/src/main/java/Customer.java" {
+		t.Errorf("items = %+v", out.Items)
+	}
+}
+
+func TestWrittenFiles(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, "model"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "model", "pom.xml"), []byte("on disk"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := &specialists.Output{Items: []types.OutputItem{
+		{ID: "a", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "model/pom.xml", Operation: types.OpCreate, Content: "declared"},
+			{Path: "legacy/Old.java", Operation: types.OpDelete},
+		}},
+		{ID: "b", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "model/pom.xml", Operation: types.OpReplace, Content: "declared again"},
+		}},
+	}}
+	files := writtenFiles(repo, out)
+	if len(files) != 1 || files[0].Content != "on disk" {
+		t.Errorf("files = %+v, want model/pom.xml once with its content on disk", files)
+	}
+}
@@ -0,0 +1,399 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/state"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+	"github.com/arianlopezc/Trabuco/internal/utils"
+)
+
+// ReviewDecision is the user's verdict on one file at the review stage.
+type ReviewDecision string
+
+const (
+	// ReviewAccept writes the file as the specialist produced it.
+	ReviewAccept ReviewDecision = "accept"
+	// ReviewEdit writes the content the user edited instead.
+	ReviewEdit ReviewDecision = "edit"
+	// ReviewSkip drops the write; the file stays as it was.
+	ReviewSkip ReviewDecision = "skip"
+)
+
+// FileReview is one AI-converted file presented for review before it is
+// written.
+type FileReview struct {
+	ItemID string
+	// Path is where the file will be written, relative to the repo root.
+	Path string
+	// Original is the file the diff is taken against: the current
+	// content at Path, or the item's legacy source file for new files.
+	// Empty when there is neither.
+	Original string
+	Diff     string
+	Content  string
+	Checks   []ReviewCheck
+	// Score is the fraction of Checks that passed; 0 when no check
+	// applies to the file type.
+	Score float64
+}
+
+// ReviewCheck is one outcome of the validation heuristic.
+type ReviewCheck struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+// Reviewer presents files for review. Review returns the decision and,
+// for ReviewEdit, the edited content.
+type Reviewer interface {
+	Review(ctx context.Context, phase types.Phase, fr *FileReview) (ReviewDecision, string, error)
+}
+
+// SetReviewer turns on the per-file review stage: every create and
+// replace an applied item declares goes through r before anything is
+// written. Files whose heuristic score reaches autoAccept are written
+// without review; autoAccept <= 0 reviews every file.
+func (o *Orchestrator) SetReviewer(r Reviewer, autoAccept float64) {
+	o.reviewer = r
+	o.autoAccept = autoAccept
+}
+
+// reviewFileWrites runs the review stage over out, dropping skipped
+// writes and substituting edited content. An item whose writes were all
+// skipped is retained in legacy/.
+func (o *Orchestrator) reviewFileWrites(ctx context.Context, phase types.Phase, out *specialists.Output) error {
+	idx := newImportIndex(o.repoRoot, out)
+	for i := range out.Items {
+		item := &out.Items[i]
+		if item.State != types.ItemApplied || len(item.FileWrites) == 0 {
+			continue
+		}
+		kept := item.FileWrites[:0]
+		reviewed := 0
+		for _, w := range item.FileWrites {
+			if w.Operation == types.OpDelete {
+				kept = append(kept, w)
+				continue
+			}
+			reviewed++
+			fr := o.fileReview(item, w, idx)
+			if o.autoAccept > 0 && fr.Score >= o.autoAccept {
+				kept = append(kept, w)
+				continue
+			}
+			decision, edited, err := o.reviewer.Review(ctx, phase, fr)
+			if err != nil {
+				return err
+			}
+			switch decision {
+			case ReviewAccept:
+				kept = append(kept, w)
+			case ReviewEdit:
+				w.Content = edited
+				kept = append(kept, w)
+			case ReviewSkip:
+			default:
+				return fmt.Errorf("unknown review decision %q for %s", decision, w.Path)
+			}
+		}
+		item.FileWrites = kept
+		if reviewed > 0 && len(kept) == 0 {
+			item.State = types.ItemRetainedLegacy
+			item.Reason = "every file was skipped at review"
+		}
+	}
+	return nil
+}
+
+// fileReview builds the review of one write: the diff against the
+// original and the heuristic checks.
+func (o *Orchestrator) fileReview(item *types.OutputItem, w types.FileWrite, idx *importIndex) *FileReview {
+	fr := &FileReview{ItemID: item.ID, Path: w.Path, Content: w.Content}
+	var original string
+	if data, err := os.ReadFile(filepath.Join(o.repoRoot, filepath.FromSlash(w.Path))); err == nil {
+		fr.Original, original = w.Path, string(data)
+	} else if item.SourceEvidence != nil && item.SourceEvidence.File != "" {
+		if data, err := os.ReadFile(filepath.Join(o.repoRoot, filepath.FromSlash(item.SourceEvidence.File))); err == nil {
+			fr.Original, original = item.SourceEvidence.File, string(data)
+		}
+	}
+	oldName := "/dev/null"
+	if fr.Original != "" {
+		oldName = "a/" + fr.Original
+	}
+	fr.Diff = utils.UnifiedDiff(oldName, "b/"+w.Path, original, w.Content)
+
+	fr.Checks = checkFile(w.Path, w.Content, idx)
+	if len(fr.Checks) > 0 {
+		passed := 0
+		for _, c := range fr.Checks {
+			if c.Passed {
+				passed++
+			}
+		}
+		fr.Score = float64(passed) / float64(len(fr.Checks))
+	}
+	return fr
+}
+
+// checkFile runs the heuristic checks that apply to the file type. They
+// stand in for a real compile, which only the validation funnel can run
+// once every file of the phase is on disk.
+func checkFile(p, content string, idx *importIndex) []ReviewCheck {
+	switch path.Ext(p) {
+	case ".java":
+		return []ReviewCheck{
+			checkJavaCompiles(p, content),
+			checkImportsResolve(content, idx),
+		}
+	case ".xml":
+		c := ReviewCheck{Name: "well-formed", Passed: true}
+		dec := xml.NewDecoder(strings.NewReader(content))
+		for {
+			if _, err := dec.Token(); err != nil {
+				if err != io.EOF {
+					c.Passed, c.Detail = false, err.Error()
+				}
+				break
+			}
+		}
+		return []ReviewCheck{c}
+	}
+	return nil
+}
+
+var (
+	javaPackageRe = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)\s*;`)
+	javaTypeRe    = regexp.MustCompile(`\b(class|interface|enum|record)\s+\w+`)
+	javaImportRe  = regexp.MustCompile(`(?m)^\s*import\s+(static\s+)?([\w.]+(?:\.\*)?)\s*;`)
+)
+
+// checkJavaCompiles approximates "compiles": delimiters balance outside
+// strings and comments, the file declares a type, and its package
+// matches the directory it is written to.
+func checkJavaCompiles(p, content string) ReviewCheck {
+	c := ReviewCheck{Name: "compiles"}
+	if err := balanced(content); err != nil {
+		c.Detail = err.Error()
+		return c
+	}
+	if !javaTypeRe.MatchString(stripJavaLiterals(content)) {
+		c.Detail = "no class, interface, enum or record declaration"
+		return c
+	}
+	want := state.ClassNameOf(p)
+	if i := strings.LastIndex(want, "."); i >= 0 && want != strings.ReplaceAll(strings.TrimSuffix(p, ".java"), "/", ".") {
+		m := javaPackageRe.FindStringSubmatch(content)
+		if m == nil || m[1] != want[:i] {
+			c.Detail = fmt.Sprintf("package does not match the path (want %s)", want[:i])
+			return c
+		}
+	}
+	c.Passed = true
+	return c
+}
+
+// balanced reports the first unbalanced (), [] or {} in Java source.
+func balanced(content string) error {
+	pairs := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	var stack []byte
+	src := stripJavaLiterals(content)
+	for i := 0; i < len(src); i++ {
+		switch ch := src[i]; ch {
+		case '(', '[', '{':
+			stack = append(stack, ch)
+		case ')', ']', '}':
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[ch] {
+				return fmt.Errorf("unbalanced %q at line %d", ch, strings.Count(src[:i], "\n")+1)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+	return nil
+}
+
+// stripJavaLiterals blanks comments, string, text-block and char
+// literals, keeping newlines so line numbers survive.
+func stripJavaLiterals(src string) string {
+	b := []byte(src)
+	blank := func(from, to int) {
+		for k := from; k < to && k < len(b); k++ {
+			if b[k] != '\n' {
+				b[k] = ' '
+			}
+		}
+	}
+	for i := 0; i < len(b); i++ {
+		rest := src[i:]
+		end := -1
+		switch {
+		case strings.HasPrefix(rest, "//"):
+			if end = strings.IndexByte(rest, '\n'); end < 0 {
+				end = len(rest)
+			}
+		case strings.HasPrefix(rest, "/*"):
+			if end = strings.Index(rest[2:], "*/"); end >= 0 {
+				end += 4
+			} else {
+				end = len(rest)
+			}
+		case strings.HasPrefix(rest, `"""`):
+			if end = strings.Index(rest[3:], `"""`); end >= 0 {
+				end += 6
+			} else {
+				end = len(rest)
+			}
+		case rest[0] == '"' || rest[0] == '\'':
+			end = 1
+			for end < len(rest) && rest[end] != rest[0] && rest[end] != '\n' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end++
+		}
+		if end > 0 {
+			blank(i, i+end)
+			i += end - 1
+		}
+	}
+	return string(b)
+}
+
+// checkImportsResolve reports the imports that name neither a JDK or
+// Jakarta class, a dependency declared in a pom.xml, nor a class in the
+// repo or in this phase's output.
+func checkImportsResolve(content string, idx *importIndex) ReviewCheck {
+	c := ReviewCheck{Name: "imports resolve"}
+	var missing []string
+	for _, m := range javaImportRe.FindAllStringSubmatch(content, -1) {
+		name := m[2]
+		if m[1] != "" || strings.HasSuffix(name, ".*") {
+			// Static imports and wildcards name a class or package one
+			// segment up.
+			name = name[:max(strings.LastIndex(name, "."), 0)]
+		}
+		if !idx.resolves(name) {
+			missing = append(missing, m[2])
+		}
+	}
+	if len(missing) > 0 {
+		c.Detail = "unresolved: " + strings.Join(missing, ", ")
+		return c
+	}
+	c.Passed = true
+	return c
+}
+
+// platformPrefixes are packages available without a declared dependency,
+// or through every Spring Boot starter.
+var platformPrefixes = []string{"java.", "javax.", "jakarta.", "org.slf4j."}
+
+var dependencyGroupRe = regexp.MustCompile(`(?s)<dependency>.*?<groupId>\s*([\w.-]+)\s*</groupId>`)
+
+// importIndex knows what an import can resolve to.
+type importIndex struct {
+	classes  map[string]bool
+	packages map[string]bool
+	groups   []string
+}
+
+// newImportIndex indexes the repo's Java classes and pom.xml dependency
+// groups, plus those the phase output is about to write.
+func newImportIndex(repoRoot string, out *specialists.Output) *importIndex {
+	idx := &importIndex{classes: map[string]bool{}, packages: map[string]bool{}}
+	addGroups := func(pom string) {
+		for _, m := range dependencyGroupRe.FindAllStringSubmatch(pom, -1) {
+			// Two segments: a starter's groupId (org.springframework.boot)
+			// brings in its siblings (org.springframework.web).
+			parts := strings.SplitN(m[1], ".", 3)
+			if len(parts) >= 2 {
+				idx.groups = append(idx.groups, parts[0]+"."+parts[1]+".")
+			}
+		}
+	}
+	addFile := func(rel, content string) {
+		switch {
+		case strings.HasSuffix(rel, ".java"):
+			class := state.ClassNameOf(rel)
+			idx.classes[class] = true
+			if i := strings.LastIndex(class, "."); i >= 0 {
+				idx.packages[class[:i]] = true
+			}
+		case path.Base(rel) == "pom.xml":
+			addGroups(content)
+		}
+	}
+
+	_ = filepath.WalkDir(repoRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "target", "node_modules", state.MigrationDir:
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(repoRoot, p)
+		rel = filepath.ToSlash(rel)
+		var content string
+		if d.Name() == "pom.xml" {
+			data, _ := os.ReadFile(p)
+			content = string(data)
+		}
+		addFile(rel, content)
+		return nil
+	})
+	for _, item := range out.Items {
+		if item.State != types.ItemApplied {
+			continue
+		}
+		for _, w := range item.FileWrites {
+			if w.Operation != types.OpDelete {
+				addFile(w.Path, w.Content)
+			}
+		}
+	}
+	return idx
+}
+
+// resolves reports whether an imported class or package is known.
+func (idx *importIndex) resolves(name string) bool {
+	if idx.classes[name] || idx.packages[name] {
+		return true
+	}
+	// Nested class imports (com.acme.Order.Status) resolve through the
+	// outer class.
+	if i := strings.LastIndex(name, "."); i >= 0 && idx.classes[name[:i]] {
+		return true
+	}
+	for _, prefix := range platformPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for _, group := range idx.groups {
+		if strings.HasPrefix(name, group) {
+			return true
+		}
+	}
+	return false
+}
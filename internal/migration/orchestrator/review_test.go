@@ -0,0 +1,131 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+)
+
+// scriptedReviewer answers by path and records what it was shown.
+type scriptedReviewer struct {
+	answers map[string]ReviewDecision
+	seen    map[string]*FileReview
+}
+
+func (r *scriptedReviewer) Review(ctx context.Context, phase types.Phase, fr *FileReview) (ReviewDecision, string, error) {
+	r.seen[fr.Path] = fr
+	return r.answers[fr.Path], "// edited\n", nil
+}
+
+const goodEntity = `package com.acme.model;
+
+import java.util.List;
+import com.acme.model.Customer;
+import org.immutables.value.Value;
+
+@Value.Immutable
+public interface Order {
+  List<String> lines(); // "}" in a comment
+  String NOTE = "{ not a brace";
+}
+`
+
+func TestReviewFileWrites(t *testing.T) {
+	repo := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("pom.xml", "<project><dependencies><dependency><groupId>org.immutables</groupId><artifactId>value</artifactId></dependency></dependencies></project>")
+	write("legacy/src/main/java/com/acme/domain/Order.java", "package com.acme.domain;\nclass Order {}\n")
+	write("legacy/src/main/java/com/acme/domain/Invoice.java", "package com.acme.domain;\nclass Invoice {}\n")
+
+	out := &specialists.Output{Items: []types.OutputItem{
+		{ID: "order", State: types.ItemApplied,
+			SourceEvidence: &types.SourceEvidence{File: "legacy/src/main/java/com/acme/domain/Order.java"},
+			FileWrites: []types.FileWrite{
+				{Path: "Model/src/main/java/com/acme/model/Order.java", Operation: types.OpCreate, Content: goodEntity},
+				{Path: "Model/src/main/java/com/acme/model/Customer.java", Operation: types.OpCreate, Content: "package com.acme.model;\nimport com.acme.ghost.Missing;\npublic record Customer(String id) {}\n"},
+			}},
+		{ID: "invoice", State: types.ItemApplied,
+			SourceEvidence: &types.SourceEvidence{File: "legacy/src/main/java/com/acme/domain/Invoice.java"},
+			FileWrites: []types.FileWrite{
+				{Path: "Model/src/main/java/com/acme/model/Invoice.java", Operation: types.OpCreate, Content: "package com.acme.model;\npublic class Invoice {\n"},
+				{Path: "legacy/src/main/java/com/acme/domain/Invoice.java", Operation: types.OpDelete},
+			}},
+		{ID: "pom", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "Model/pom.xml", Operation: types.OpCreate, Content: "<project></project>"},
+		}},
+	}}
+
+	r := &scriptedReviewer{
+		answers: map[string]ReviewDecision{
+			"Model/src/main/java/com/acme/model/Customer.java": ReviewEdit,
+			"Model/src/main/java/com/acme/model/Invoice.java":  ReviewSkip,
+		},
+		seen: map[string]*FileReview{},
+	}
+	o := New(repo, "test", nil, nil)
+	o.SetReviewer(r, 1)
+	if err := o.reviewFileWrites(context.Background(), types.PhaseModel, out); err != nil {
+		t.Fatal(err)
+	}
+
+	// Order.java and Model/pom.xml pass every check and are auto-accepted.
+	if _, ok := r.seen["Model/src/main/java/com/acme/model/Order.java"]; ok {
+		t.Error("a file passing every check should be auto-accepted")
+	}
+	if _, ok := r.seen["Model/pom.xml"]; ok {
+		t.Error("well-formed XML should be auto-accepted")
+	}
+
+	customer := r.seen["Model/src/main/java/com/acme/model/Customer.java"]
+	if customer == nil || customer.Score != 0.5 || !strings.Contains(customer.Checks[1].Detail, "com.acme.ghost.Missing") {
+		t.Fatalf("Customer review = %+v", customer)
+	}
+	if customer.Original != "legacy/src/main/java/com/acme/domain/Order.java" || !strings.Contains(customer.Diff, "-class Order {}") {
+		t.Errorf("a new file should be diffed against the legacy source, got %q\n%s", customer.Original, customer.Diff)
+	}
+	if got := out.Items[0].FileWrites[1].Content; got != "// edited\n" {
+		t.Errorf("edited content not substituted: %q", got)
+	}
+
+	invoice := r.seen["Model/src/main/java/com/acme/model/Invoice.java"]
+	if invoice == nil || invoice.Checks[0].Passed {
+		t.Fatalf("an unclosed class should fail the compiles check: %+v", invoice)
+	}
+	// The delete was kept, so the item still applies.
+	if out.Items[1].State != types.ItemApplied || len(out.Items[1].FileWrites) != 1 || out.Items[1].FileWrites[0].Operation != types.OpDelete {
+		t.Errorf("invoice item = %+v", out.Items[1])
+	}
+}
+
+func TestReviewFileWrites_AllSkippedRetainsLegacy(t *testing.T) {
+	out := &specialists.Output{Items: []types.OutputItem{
+		{ID: "notes", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "NOTES.md", Operation: types.OpCreate, Content: "# notes\n"},
+		}},
+	}}
+	r := &scriptedReviewer{answers: map[string]ReviewDecision{"NOTES.md": ReviewSkip}, seen: map[string]*FileReview{}}
+	o := New(t.TempDir(), "test", nil, nil)
+	o.SetReviewer(r, 0.5)
+	if err := o.reviewFileWrites(context.Background(), types.PhaseShared, out); err != nil {
+		t.Fatal(err)
+	}
+	if fr := r.seen["NOTES.md"]; fr == nil || len(fr.Checks) != 0 || fr.Score != 0 {
+		t.Errorf("files without checks are always reviewed: %+v", fr)
+	}
+	if out.Items[0].State != types.ItemRetainedLegacy || len(out.Items[0].FileWrites) != 0 {
+		t.Errorf("item = %+v", out.Items[0])
+	}
+}
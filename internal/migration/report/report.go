@@ -0,0 +1,488 @@
+// Package report builds the migration report: MIGRATION_REPORT.md at the
+// repo root and its machine-readable twin under .trabuco-migration/. Where
+// completion-report.md summarizes phase states, the migration report is
+// the hand-off document — every converted file, what was skipped and why,
+// the dependency changes, the TODOs left in migrated code, the LLM spend
+// and the manual follow-up checklist.
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/state"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+	"github.com/arianlopezc/Trabuco/internal/migration/vcs"
+	"github.com/arianlopezc/Trabuco/internal/todos"
+)
+
+// Report is the content of MIGRATION_REPORT.md, serialized as is to
+// migration-report.json.
+type Report struct {
+	GeneratedAt       time.Time          `json:"generatedAt"`
+	TrabucoCLIVersion string             `json:"trabucoCliVersion"`
+	Baseline          string             `json:"baseline,omitempty"`
+	Phases            []PhaseSummary     `json:"phases"`
+	ConvertedFiles    []ConvertedFile    `json:"convertedFiles"`
+	Skipped           []SkippedItem      `json:"skipped"`
+	Dependencies      []DependencyChange `json:"dependencies"`
+	TODOs             []todos.Item       `json:"todos"`
+	Usage             types.TokenUsage   `json:"usage"`
+	FollowUps         []FollowUp         `json:"followUps"`
+}
+
+// PhaseSummary is the outcome and LLM spend of one phase.
+type PhaseSummary struct {
+	Phase types.Phase           `json:"phase"`
+	Name  string                `json:"name"`
+	State types.PhaseStateLabel `json:"state"`
+	Usage *types.TokenUsage     `json:"usage,omitempty"`
+}
+
+// ConvertedFile is a file a phase created, replaced or deleted. A file
+// touched by several phases is listed once, with its last operation.
+type ConvertedFile struct {
+	Path      string              `json:"path"`
+	Operation types.FileOperation `json:"operation"`
+	Phase     types.Phase         `json:"phase"`
+	Item      string              `json:"item"`
+}
+
+// SkippedItem is a piece of the source that was not migrated.
+type SkippedItem struct {
+	Phase  types.Phase     `json:"phase"`
+	ID     string          `json:"id"`
+	State  types.ItemState `json:"state"`
+	Code   string          `json:"code,omitempty"`
+	File   string          `json:"file,omitempty"`
+	Reason string          `json:"reason"`
+}
+
+// DependencyChange is a Maven dependency added, removed or re-versioned
+// across the migration, compared by groupId:artifactId over every pom.xml.
+type DependencyChange struct {
+	Coordinates string `json:"coordinates"`
+	Change      string `json:"change"` // added | removed | changed
+	From        string `json:"from,omitempty"`
+	To          string `json:"to,omitempty"`
+}
+
+// FollowUp is one entry of the manual checklist. Priority 1 must be done
+// before the project ships; 3 can wait.
+type FollowUp struct {
+	Priority int    `json:"priority"`
+	Task     string `json:"task"`
+}
+
+// Build assembles the report from the migration state and phase outputs.
+// finalItems are the finalizer's own items, which are not on disk yet
+// when it calls Build; nil reads them from the phase output like the
+// others.
+func Build(repoRoot string, st *state.State, finalItems []types.OutputItem) (*Report, error) {
+	r := &Report{
+		GeneratedAt:       time.Now().UTC(),
+		TrabucoCLIVersion: st.TrabucoCLIVersion,
+		ConvertedFiles:    []ConvertedFile{},
+		Skipped:           []SkippedItem{},
+		Dependencies:      []DependencyChange{},
+		TODOs:             []todos.Item{},
+		FollowUps:         []FollowUp{},
+	}
+
+	converted := map[string]ConvertedFile{}
+	for _, p := range types.AllPhases() {
+		rec := st.Phases[p]
+		summary := PhaseSummary{Phase: p, Name: p.String(), State: types.PhasePending}
+		if rec != nil {
+			summary.State = rec.State
+			summary.Usage = rec.Usage
+			if rec.Usage != nil {
+				r.Usage.Add(*rec.Usage)
+			}
+		}
+		r.Phases = append(r.Phases, summary)
+
+		items := finalItems
+		if p != types.PhaseFinalization || finalItems == nil {
+			out, err := loadOutput(repoRoot, p)
+			if err != nil {
+				return nil, err
+			}
+			if out == nil {
+				continue
+			}
+			items = out.Items
+		}
+		for _, item := range items {
+			switch item.State {
+			case types.ItemApplied:
+				if summary.State != types.PhaseCompleted && p != types.PhaseFinalization {
+					continue
+				}
+				for _, fw := range item.FileWrites {
+					converted[fw.Path] = ConvertedFile{Path: fw.Path, Operation: fw.Operation, Phase: p, Item: item.ID}
+				}
+			case types.ItemBlocked:
+				r.Skipped = append(r.Skipped, SkippedItem{
+					Phase: p, ID: item.ID, State: item.State, Code: string(item.BlockerCode),
+					File: itemFile(item), Reason: firstLine(item.BlockerNote, item.Description),
+				})
+			case types.ItemRetainedLegacy:
+				r.Skipped = append(r.Skipped, SkippedItem{
+					Phase: p, ID: item.ID, State: item.State,
+					File: itemFile(item), Reason: firstLine(item.Reason, item.Description),
+				})
+			}
+		}
+	}
+	for _, cf := range converted {
+		r.ConvertedFiles = append(r.ConvertedFiles, cf)
+	}
+	sort.Slice(r.ConvertedFiles, func(i, j int) bool { return r.ConvertedFiles[i].Path < r.ConvertedFiles[j].Path })
+
+	var live []string
+	for _, cf := range r.ConvertedFiles {
+		if cf.Operation != types.OpDelete {
+			live = append(live, cf.Path)
+		}
+	}
+	found, err := todos.ScanFiles(repoRoot, live)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		r.TODOs = found
+	}
+
+	if sha, ok := vcs.MigrationBaseline(repoRoot); ok {
+		r.Baseline = sha
+		deps, err := dependencyChanges(repoRoot, sha)
+		if err != nil {
+			return nil, err
+		}
+		r.Dependencies = deps
+	}
+
+	r.FollowUps = followUps(r, st)
+	return r, nil
+}
+
+// Write builds the report and saves both forms. It returns the report so
+// callers can summarize it.
+func Write(repoRoot string, st *state.State, finalItems []types.OutputItem) (*Report, error) {
+	r, err := Build(repoRoot, st, finalItems)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(state.MigrationDirPath(repoRoot), 0o755); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(state.MigrationReportJSONPath(repoRoot), append(data, '\n'), 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(state.MigrationReportPath(repoRoot), []byte(r.Markdown()), 0o644); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func loadOutput(repoRoot string, p types.Phase) (*specialists.Output, error) {
+	data, err := os.ReadFile(state.PhaseOutputPath(repoRoot, p))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out specialists.Output
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(state.PhaseOutputPath(repoRoot, p)), err)
+	}
+	return &out, nil
+}
+
+// itemFile is the source file an item is about, when it names one.
+func itemFile(item types.OutputItem) string {
+	if item.SourceEvidence != nil && item.SourceEvidence.File != "" {
+		return item.SourceEvidence.File
+	}
+	if len(item.FileWrites) > 0 {
+		return item.FileWrites[0].Path
+	}
+	return ""
+}
+
+// firstLine returns the first line of the first non-empty candidate;
+// blocker notes can carry whole build logs.
+func firstLine(candidates ...string) string {
+	for _, c := range candidates {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		line, _, _ := strings.Cut(c, "\n")
+		return strings.TrimSpace(line)
+	}
+	return ""
+}
+
+// pomDependencies is the part of a pom.xml the report compares.
+type pomDependencies struct {
+	Dependencies []pomDependency `xml:"dependencies>dependency"`
+	Managed      []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+// dependencyChanges compares the dependencies declared across every
+// pom.xml at the baseline with the working tree.
+func dependencyChanges(repoRoot, baseline string) ([]DependencyChange, error) {
+	before := map[string]string{}
+	files, err := vcs.ListFilesAt(repoRoot, baseline)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if path.Base(f) != "pom.xml" {
+			continue
+		}
+		data, err := vcs.ShowFile(repoRoot, baseline, f)
+		if err != nil {
+			return nil, err
+		}
+		collectDependencies(data, before)
+	}
+
+	after := map[string]string{}
+	err = filepath.WalkDir(repoRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != repoRoot && (strings.HasPrefix(d.Name(), ".") || d.Name() == "target" || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "pom.xml" {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		collectDependencies(data, after)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []DependencyChange{}
+	for coords, from := range before {
+		to, ok := after[coords]
+		switch {
+		case !ok:
+			changes = append(changes, DependencyChange{Coordinates: coords, Change: "removed", From: from})
+		case from != to && from != "" && to != "":
+			changes = append(changes, DependencyChange{Coordinates: coords, Change: "changed", From: from, To: to})
+		}
+	}
+	for coords, to := range after {
+		if _, ok := before[coords]; !ok {
+			changes = append(changes, DependencyChange{Coordinates: coords, Change: "added", To: to})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Coordinates < changes[j].Coordinates })
+	return changes, nil
+}
+
+// collectDependencies adds the groupId:artifactId → version pairs of a
+// pom.xml to into. The project's own modules, which a multi-module build
+// depends on with ${project.groupId}, are skipped. Unparsable poms are
+// ignored: the report is best-effort and the build has its own checks.
+func collectDependencies(data []byte, into map[string]string) {
+	var pom pomDependencies
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return
+	}
+	for _, d := range append(pom.Dependencies, pom.Managed...) {
+		group := strings.TrimSpace(d.GroupID)
+		if group == "" || strings.HasPrefix(group, "${project.") {
+			continue
+		}
+		coords := group + ":" + strings.TrimSpace(d.ArtifactID)
+		if v := strings.TrimSpace(d.Version); v != "" || into[coords] == "" {
+			into[coords] = v
+		}
+	}
+}
+
+// followUps derives the manual checklist, most urgent first.
+func followUps(r *Report, st *state.State) []FollowUp {
+	var list []FollowUp
+	add := func(priority int, format string, args ...any) {
+		list = append(list, FollowUp{Priority: priority, Task: fmt.Sprintf(format, args...)})
+	}
+
+	for _, p := range r.Phases {
+		if p.State == types.PhaseFailed || p.State == types.PhaseInProgress {
+			add(1, "Phase %d (%s) is %s: resolve it and rerun `trabuco migrate resume`", int(p.Phase), p.Name, p.State)
+		}
+	}
+	for _, s := range r.Skipped {
+		if s.State != types.ItemBlocked {
+			continue
+		}
+		where := ""
+		if s.File != "" {
+			where = " in " + s.File
+		}
+		add(1, "Unblock %s%s (phase %d, %s): %s", s.ID, where, int(s.Phase), s.Code, s.Reason)
+	}
+	for _, b := range st.Blockers {
+		if b.UserChoice == "" {
+			add(1, "Choose how to resolve blocker %s in %s (phase %d)", b.Code, b.File, int(b.Phase))
+		}
+	}
+
+	for _, s := range r.Skipped {
+		if s.State == types.ItemRetainedLegacy && s.File != "" {
+			add(2, "Port %s, kept in legacy/: %s", s.File, s.Reason)
+		}
+	}
+	for _, f := range st.RetainedLegacy {
+		add(2, "Port %s, kept in legacy/ by your decision", f)
+	}
+	if n := countDependencies(r.Dependencies, "removed"); n > 0 {
+		add(2, "Check that the %d removed dependencies have working replacements (see Dependency changes)", n)
+	}
+
+	if n := len(r.TODOs); n > 0 {
+		add(3, "Resolve the %d TODO/FIXME comments left in migrated code", n)
+	}
+	if n := countDependencies(r.Dependencies, "added"); n > 0 {
+		add(3, "Review the licenses and versions of the %d added dependencies", n)
+	}
+	add(3, "Review MIGRATION_REPORT.md with the team and delete .trabuco-migration/ once the migration is merged")
+	return list
+}
+
+func countDependencies(changes []DependencyChange, kind string) int {
+	n := 0
+	for _, c := range changes {
+		if c.Change == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// Markdown renders MIGRATION_REPORT.md.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Migration Report\n\n")
+	fmt.Fprintf(&b, "Generated: %s\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Trabuco CLI: %s\n", r.TrabucoCLIVersion)
+	if r.Baseline != "" {
+		fmt.Fprintf(&b, "Baseline commit: %s\n", r.Baseline)
+	}
+	fmt.Fprintf(&b, "\n%d files converted, %d items skipped, %d dependency changes, %d TODOs, %d follow-ups.\n\n",
+		len(r.ConvertedFiles), len(r.Skipped), len(r.Dependencies), len(r.TODOs), len(r.FollowUps))
+
+	fmt.Fprintln(&b, "## Follow-up checklist")
+	fmt.Fprintln(&b)
+	for _, f := range r.FollowUps {
+		fmt.Fprintf(&b, "- [ ] **P%d** %s\n", f.Priority, f.Task)
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "## Phases and LLM usage")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "| Phase | State | Input tokens | Output tokens | Cost (USD) |")
+	fmt.Fprintln(&b, "|---|---|---:|---:|---:|")
+	for _, p := range r.Phases {
+		if p.Usage == nil {
+			fmt.Fprintf(&b, "| %d %s | %s | | | |\n", int(p.Phase), p.Name, p.State)
+			continue
+		}
+		fmt.Fprintf(&b, "| %d %s | %s | %d | %d | %.2f |\n", int(p.Phase), p.Name, p.State, p.Usage.InputTokens, p.Usage.OutputTokens, p.Usage.CostUSD)
+	}
+	fmt.Fprintf(&b, "| **Total** | | %d | %d | %.2f |\n\n", r.Usage.InputTokens, r.Usage.OutputTokens, r.Usage.CostUSD)
+	if calls := r.Usage.CacheHits + r.Usage.CacheMisses; calls > 0 {
+		fmt.Fprintf(&b, "LLM cache: %d of %d calls served from the cache (%.0f%%), saving about $%.2f.\n\n",
+			r.Usage.CacheHits, calls, 100*float64(r.Usage.CacheHits)/float64(calls), r.Usage.SavedUSD)
+	}
+
+	fmt.Fprintln(&b, "## Converted files")
+	fmt.Fprintln(&b)
+	if len(r.ConvertedFiles) == 0 {
+		fmt.Fprintln(&b, "None.")
+	}
+	for _, cf := range r.ConvertedFiles {
+		fmt.Fprintf(&b, "- `%s` — %s (phase %d, %s)\n", cf.Path, cf.Operation, int(cf.Phase), cf.Item)
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "## Skipped")
+	fmt.Fprintln(&b)
+	if len(r.Skipped) == 0 {
+		fmt.Fprintln(&b, "None.")
+	}
+	for _, s := range r.Skipped {
+		label := string(s.State)
+		if s.Code != "" {
+			label += ", " + s.Code
+		}
+		file := ""
+		if s.File != "" {
+			file = " `" + s.File + "`"
+		}
+		fmt.Fprintf(&b, "- [Phase %d] %s%s (%s): %s\n", int(s.Phase), s.ID, file, label, s.Reason)
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "## Dependency changes")
+	fmt.Fprintln(&b)
+	if len(r.Dependencies) == 0 {
+		fmt.Fprintln(&b, "None.")
+	}
+	for _, d := range r.Dependencies {
+		switch d.Change {
+		case "added":
+			fmt.Fprintf(&b, "- + `%s` %s\n", d.Coordinates, d.To)
+		case "removed":
+			fmt.Fprintf(&b, "- − `%s` %s\n", d.Coordinates, d.From)
+		default:
+			fmt.Fprintf(&b, "- ~ `%s` %s → %s\n", d.Coordinates, d.From, d.To)
+		}
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "## TODOs in migrated code")
+	fmt.Fprintln(&b)
+	if len(r.TODOs) == 0 {
+		fmt.Fprintln(&b, "None.")
+	}
+	for _, t := range r.TODOs {
+		fmt.Fprintf(&b, "- `%s:%d` %s: %s\n", t.File, t.Line, t.Kind, t.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
+	"github.com/arianlopezc/Trabuco/internal/migration/state"
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+	"github.com/arianlopezc/Trabuco/internal/migration/vcs"
+)
+
+const legacyPom = `<project>
+  <dependencies>
+    <dependency><groupId>org.springframework.boot</groupId><artifactId>spring-boot-starter-data-jpa</artifactId></dependency>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>31.1-jre</version></dependency>
+  </dependencies>
+</project>
+`
+
+const migratedPom = `<project>
+  <dependencies>
+    <dependency><groupId>org.springframework.boot</groupId><artifactId>spring-boot-starter-data-jdbc</artifactId></dependency>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>33.0-jre</version></dependency>
+    <dependency><groupId>${project.groupId}</groupId><artifactId>model</artifactId></dependency>
+  </dependencies>
+</project>
+`
+
+func TestWrite(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v (%s)", args, err, out)
+		}
+	}
+	write := func(rel, content string) {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "test")
+	git("config", "commit.gpgsign", "false")
+	write("pom.xml", legacyPom)
+	git("add", "-A")
+	git("commit", "-q", "-m", "legacy")
+	if err := vcs.CreateTag(repo, vcs.PhasePreTag(types.PhaseAssessment), "pre", false); err != nil {
+		t.Fatal(err)
+	}
+
+	write("pom.xml", migratedPom)
+	write("Model/src/main/java/Order.java", "class Order {\n  // TODO: map the legacy status column\n}\n")
+	writeOutput := func(p types.Phase, items ...types.OutputItem) {
+		data, _ := json.Marshal(specialists.Output{Phase: p, Items: items})
+		write(filepath.Join(state.MigrationDir, filepath.Base(state.PhaseOutputPath(repo, p))), string(data))
+	}
+	writeOutput(types.PhaseModel,
+		types.OutputItem{ID: "order-entity", State: types.ItemApplied, FileWrites: []types.FileWrite{
+			{Path: "Model/src/main/java/Order.java", Operation: types.OpCreate},
+		}},
+		types.OutputItem{ID: "report-view", State: types.ItemBlocked, BlockerCode: types.BlockerCompileFailed,
+			BlockerNote:    "native query uses CONNECT BY\nstack trace...",
+			SourceEvidence: &types.SourceEvidence{File: "src/main/java/ReportRepository.java"}},
+		types.OutputItem{ID: "audit-listener", State: types.ItemRetainedLegacy, Reason: "Hibernate envers listener",
+			SourceEvidence: &types.SourceEvidence{File: "src/main/java/AuditListener.java"}},
+	)
+
+	st := state.New("test")
+	st.Phases[types.PhaseAssessment].State = types.PhaseCompleted
+	st.Phases[types.PhaseAssessment].Usage = &types.TokenUsage{Model: "m", InputTokens: 1000, OutputTokens: 200, CostUSD: 0.5}
+	st.Phases[types.PhaseModel].State = types.PhaseCompleted
+	st.Phases[types.PhaseModel].Usage = &types.TokenUsage{Model: "m", InputTokens: 3000, OutputTokens: 800, CostUSD: 1.25, CacheHits: 1, CacheMisses: 1, SavedUSD: 0.4}
+
+	r, err := Write(repo, st, []types.OutputItem{{ID: "finalizer-verify", State: types.ItemApplied}})
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	if len(r.ConvertedFiles) != 1 || r.ConvertedFiles[0].Path != "Model/src/main/java/Order.java" {
+		t.Errorf("ConvertedFiles = %+v", r.ConvertedFiles)
+	}
+	if len(r.Skipped) != 2 || r.Skipped[0].Reason != "native query uses CONNECT BY" || r.Skipped[1].File != "src/main/java/AuditListener.java" {
+		t.Errorf("Skipped = %+v", r.Skipped)
+	}
+	if r.Usage.InputTokens != 4000 || r.Usage.OutputTokens != 1000 || r.Usage.CostUSD != 1.75 || r.Usage.Model != "m" {
+		t.Errorf("Usage = %+v", r.Usage)
+	}
+	if len(r.TODOs) != 1 || r.TODOs[0].Line != 2 {
+		t.Errorf("TODOs = %+v", r.TODOs)
+	}
+	changes := map[string]DependencyChange{}
+	for _, d := range r.Dependencies {
+		changes[d.Coordinates] = d
+	}
+	if len(changes) != 3 ||
+		changes["org.springframework.boot:spring-boot-starter-data-jpa"].Change != "removed" ||
+		changes["org.springframework.boot:spring-boot-starter-data-jdbc"].Change != "added" ||
+		changes["com.google.guava:guava"].To != "33.0-jre" {
+		t.Errorf("Dependencies = %+v", r.Dependencies)
+	}
+	if len(r.FollowUps) == 0 || r.FollowUps[0].Priority != 1 || !strings.Contains(r.FollowUps[0].Task, "report-view") {
+		t.Errorf("the blocked item should head the checklist: %+v", r.FollowUps)
+	}
+
+	md, err := os.ReadFile(state.MigrationReportPath(repo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## Follow-up checklist", "- [ ] **P1** Unblock report-view", "| **Total** | | 4000 | 1000 | 1.75 |", "`Model/src/main/java/Order.java:2` TODO", "LLM cache: 1 of 2 calls served from the cache (50%), saving about $0.40."} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("MIGRATION_REPORT.md missing %q:\n%s", want, md)
+		}
+	}
+	if _, err := os.Stat(state.MigrationReportJSONPath(repo)); err != nil {
+		t.Errorf("migration-report.json not written: %v", err)
+	}
+}
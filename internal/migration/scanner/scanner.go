@@ -16,6 +16,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/frameworks"
 )
 
 // Scan walks repoRoot and returns a structured snapshot suitable for
@@ -39,6 +41,9 @@ func Scan(repoRoot string) (*Snapshot, error) {
 		snap.BuildSystem = "unknown"
 	}
 
+	// Build files of every module, for framework detection.
+	buildFiles := []string{snap.RootPOM, snap.RootBuild}
+
 	// Walk for .java sources.
 	_ = filepath.WalkDir(repoRoot, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -73,6 +78,10 @@ func Scan(repoRoot string) (*Snapshot, error) {
 		// Notable file types regardless of extension.
 		base := strings.ToLower(d.Name())
 		switch {
+		case (base == "pom.xml" || base == "build.gradle" || base == "build.gradle.kts") && rel != base:
+			if data, err := os.ReadFile(path); err == nil {
+				buildFiles = append(buildFiles, string(data))
+			}
 		case base == "dockerfile" || strings.HasPrefix(base, "dockerfile."):
 			snap.Dockerfiles = append(snap.Dockerfiles, rel)
 		case base == "jenkinsfile":
@@ -128,6 +137,7 @@ func Scan(repoRoot string) (*Snapshot, error) {
 		return nil
 	})
 
+	snap.Framework = frameworks.Detect(buildFiles...)
 	return snap, nil
 }
 
@@ -215,6 +225,31 @@ func scanJavaFile(repoRoot, rel string) JavaFile {
 		jf.Signals = append(jf.Signals, "hardcoded-credential-suspect")
 	}
 
+	// Non-Spring framework signals. Imports rather than annotations:
+	// @Path, @Singleton and @Controller mean different things per
+	// framework.
+	if strings.Contains(src, "import jakarta.ws.rs") || strings.Contains(src, "import javax.ws.rs") {
+		jf.Signals = append(jf.Signals, "jaxrs-resource")
+	}
+	if strings.Contains(src, "import jakarta.enterprise") || strings.Contains(src, "import javax.enterprise") {
+		jf.Signals = append(jf.Signals, "cdi-bean")
+	}
+	if strings.Contains(src, "import io.quarkus.hibernate.orm.panache") || strings.Contains(src, "import io.quarkus.mongodb.panache") {
+		jf.Signals = append(jf.Signals, "quarkus-panache")
+	}
+	if strings.Contains(src, "import io.micronaut.http.annotation") {
+		jf.Signals = append(jf.Signals, "micronaut-http")
+	}
+	if strings.Contains(src, "import io.micronaut.data") {
+		jf.Signals = append(jf.Signals, "micronaut-data")
+	}
+	if strings.Contains(src, "import org.jdbi") {
+		jf.Signals = append(jf.Signals, "jdbi-dao")
+	}
+	if strings.Contains(src, "import io.dropwizard") {
+		jf.Signals = append(jf.Signals, "dropwizard")
+	}
+
 	// Pagination signals — Spring Data Pageable / PageRequest indicate
 	// OFFSET pagination, which Trabuco rejects in favor of keyset.
 	if strings.Contains(src, "Pageable") || strings.Contains(src, "PageRequest") {
@@ -244,6 +279,10 @@ type Snapshot struct {
 	BuildSystem  string
 	RootPOM      string
 	RootBuild    string
+	// Framework is the non-Spring source framework with a conversion set
+	// (quarkus, micronaut, dropwizard) any build file depends on; empty
+	// for Spring Boot and everything else.
+	Framework    string
 
 	JavaFiles    []JavaFile
 	KotlinFiles  []string
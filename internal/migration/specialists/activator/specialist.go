@@ -3,17 +3,20 @@
 // off, this specialist flips it on:
 //   - Adds maven-enforcer-plugin with full bannedDependencies and
 //     dependencyConvergence rules to parent POM.
-//   - Adds spotless-maven-plugin with googleJavaFormat.
+//   - Adds spotless-maven-plugin with googleJavaFormat, ratcheted to the
+//     commit the migration started from so untouched legacy files are
+//     not reformatted.
 //   - Adds Jacoco's check execution with coverage threshold.
 //   - Removes the trabuco-arch JUnit tag exclusion from Surefire so
 //     ArchUnit boundary tests run.
 //   - Configures enforcer to skip the legacy/ module if it still exists.
-//   - Runs `mvn spotless:apply` to format all code.
+//   - Runs `mvn spotless:apply` to format the files the migration changed.
 //   - Runs full `mvn verify` to confirm enforcement passes.
 package activator
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -22,6 +25,7 @@ import (
 
 	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
 	"github.com/arianlopezc/Trabuco/internal/migration/types"
+	"github.com/arianlopezc/Trabuco/internal/migration/vcs"
 )
 
 // Specialist is the Phase 12 activator.
@@ -49,10 +53,20 @@ func (s *Specialist) Run(ctx context.Context, in *specialists.Input) (*specialis
 		hasLegacy = true
 	}
 
-	upgraded := upgradeParentPOM(string(data), hasLegacy)
+	// Ratchet Spotless to the commit the migration started from. Without
+	// it, spotless:apply reformats every legacy file and buries the
+	// migration's real changes in a whitespace diff.
+	ratchetFrom, _ := vcs.MigrationBaseline(in.RepoRoot)
+
+	upgraded := upgradeParentPOM(string(data), hasLegacy, ratchetFrom)
 	if err := os.WriteFile(pomPath, []byte(upgraded), 0o644); err != nil {
 		return nil, fmt.Errorf("write upgraded pom: %w", err)
 	}
+	if ratchetFrom != "" {
+		if err := recordRatchet(in.RepoRoot, ratchetFrom); err != nil {
+			return nil, fmt.Errorf("record spotless ratchet: %w", err)
+		}
+	}
 
 	// Run spotless:apply first so spotless:check (run by mvn verify)
 	// passes. Failures here mean spotless config itself is broken.
@@ -78,7 +92,7 @@ func (s *Specialist) Run(ctx context.Context, in *specialists.Input) (*specialis
 			{
 				ID:          "activator-spotless",
 				State:       types.ItemApplied,
-				Description: "added spotless-maven-plugin and ran spotless:apply on all sources",
+				Description: spotlessNote(ratchetFrom),
 			},
 			{
 				ID:          "activator-jacoco",
@@ -123,14 +137,15 @@ func (s *Specialist) failure(code types.BlockerCode, note, log string) *speciali
 
 // upgradeParentPOM rewrites the migration-mode parent POM into the full
 // production-mode parent POM. Adds enforcer, spotless, jacoco-check
-// blocks and removes any trabuco-arch test exclusion.
+// blocks and removes any trabuco-arch test exclusion. A non-empty
+// ratchetFrom limits Spotless to files changed since that commit.
 //
 // Implementation note: 1.10.0 first iteration uses a conservative replace
 // strategy that detects the migration-mode template and substitutes the
 // production-mode template wholesale. If the user has hand-edited the
 // migration-mode pom, the rewrite preserves their <properties> and
 // <modules> blocks but rewrites <build>.
-func upgradeParentPOM(migrationModePOM string, hasLegacy bool) string {
+func upgradeParentPOM(migrationModePOM string, hasLegacy bool, ratchetFrom string) string {
 	// Find the marker comment that the skeleton-builder writes.
 	if !strings.Contains(migrationModePOM, "Trabuco migration mode") {
 		// User has already activated, or the pom was hand-written.
@@ -158,16 +173,60 @@ func upgradeParentPOM(migrationModePOM string, hasLegacy bool) string {
                             </excludeSubProjects>`
 	}
 
+	spotlessRatchet := ""
+	if ratchetFrom != "" {
+		property := "<spotless.ratchetFrom>" + ratchetFrom + "</spotless.ratchetFrom>"
+		if properties == "" {
+			properties = "<properties>\n        " + property + "\n    </properties>"
+		} else {
+			properties = strings.Replace(properties, "</properties>", "    "+property+"\n    </properties>", 1)
+		}
+		spotlessRatchet = `
+                    <ratchetFrom>${spotless.ratchetFrom}</ratchetFrom>`
+	}
+
 	body := fmt.Sprintf(productionModePOMTemplate,
 		groupID, artifactID,
 		properties,
 		modules,
 		depMgmt,
 		enforcerSkipLegacy,
+		spotlessRatchet,
 	)
 	return body
 }
 
+// recordRatchet stores the Spotless baseline in .trabuco.json so later
+// regenerations (sync, CI workflow) keep the ratchet. The file is edited
+// as a generic JSON object to preserve migration-only keys.
+func recordRatchet(repoRoot, ratchetFrom string) error {
+	path := filepath.Join(repoRoot, ".trabuco.json")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var meta map[string]any
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return err
+	}
+	meta["spotlessRatchetFrom"] = ratchetFrom
+	out, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(out, '\n'), 0o644)
+}
+
+func spotlessNote(ratchetFrom string) string {
+	if ratchetFrom == "" {
+		return "added spotless-maven-plugin and ran spotless:apply on all sources"
+	}
+	return "added spotless-maven-plugin ratcheted to " + ratchetFrom + " and ran spotless:apply on files changed since then"
+}
+
 // classifyVerifyFailure inspects mvn verify output and returns the most
 // likely BlockerCode.
 func classifyVerifyFailure(log string) types.BlockerCode {
@@ -300,7 +359,7 @@ const productionModePOMTemplate = `<?xml version="1.0" encoding="UTF-8"?>
                 <groupId>com.diffplug.spotless</groupId>
                 <artifactId>spotless-maven-plugin</artifactId>
                 <version>2.44.4</version>
-                <configuration>
+                <configuration>%s
                     <java>
                         <googleJavaFormat/>
                     </java>
@@ -0,0 +1,74 @@
+package activator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const migrationModePOM = `<project>
+    <groupId>com.acme</groupId>
+    <artifactId>shop</artifactId>
+    <!-- Trabuco migration mode: enforcement OFF. -->
+    <properties>
+        <java.version>21</java.version>
+    </properties>
+    <modules>
+        <module>model</module>
+    </modules>
+</project>
+`
+
+func TestUpgradeParentPOM_Ratchet(t *testing.T) {
+	pom := upgradeParentPOM(migrationModePOM, false, "abc123")
+
+	if !strings.Contains(pom, "<spotless.ratchetFrom>abc123</spotless.ratchetFrom>\n    </properties>") {
+		t.Errorf("ratchet property missing from <properties>:\n%s", pom)
+	}
+	if !strings.Contains(pom, "<java.version>21</java.version>") {
+		t.Error("existing properties should be preserved")
+	}
+	if !strings.Contains(pom, "<ratchetFrom>${spotless.ratchetFrom}</ratchetFrom>") {
+		t.Error("spotless configuration should ratchet from the property")
+	}
+}
+
+func TestUpgradeParentPOM_NoRatchet(t *testing.T) {
+	pom := upgradeParentPOM(migrationModePOM, false, "")
+
+	if strings.Contains(pom, "ratchetFrom") {
+		t.Errorf("no ratchet expected without a baseline:\n%s", pom)
+	}
+	if !strings.Contains(pom, "spotless-maven-plugin") {
+		t.Error("spotless plugin should still be added")
+	}
+}
+
+func TestRecordRatchet_PreservesMigrationKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".trabuco.json")
+	if err := os.WriteFile(path, []byte(`{"projectName": "shop", "migrationInProgress": true}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recordRatchet(dir, "abc123"); err != nil {
+		t.Fatalf("recordRatchet: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta map[string]any
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta["spotlessRatchetFrom"] != "abc123" {
+		t.Errorf("spotlessRatchetFrom = %v", meta["spotlessRatchetFrom"])
+	}
+	if meta["migrationInProgress"] != true {
+		t.Error("migrationInProgress should be preserved")
+	}
+}
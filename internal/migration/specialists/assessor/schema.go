@@ -19,7 +19,7 @@ type Assessment struct {
 
 	// Source-level context
 	BuildSystem       string   `json:"buildSystem"`     // maven | gradle | other
-	Framework         string   `json:"framework"`       // spring-boot-2.x | spring-boot-3.x | quarkus | micronaut | jaxrs | servlet | non-spring | mixed
+	Framework         string   `json:"framework"`       // spring-boot-2.x | spring-boot-3.x | quarkus | micronaut | dropwizard | jaxrs | servlet | non-spring | mixed
 	JavaVersion       string   `json:"javaVersion"`
 	IsMultiModule     bool     `json:"isMultiModule"`
 	ModulePaths       []string `json:"modulePaths,omitempty"`
@@ -5,9 +5,11 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/arianlopezc/Trabuco/internal/migration/frameworks"
 	"github.com/arianlopezc/Trabuco/internal/migration/scanner"
 	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
 	"github.com/arianlopezc/Trabuco/internal/migration/specialists/llm"
@@ -121,6 +123,17 @@ func (s *Specialist) Run(ctx context.Context, in *specialists.Input) (*specialis
 		return nil, fmt.Errorf("assessor produced no applied item with non-empty patch (got %d items)", len(out.Items))
 	}
 	assessment.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+	if snap.Framework != "" {
+		// Framework detection is mechanical; don't let the LLM relabel a
+		// convertible source as non-spring and block it.
+		assessment.Framework = snap.Framework
+		assessment.BlockerCodes = slices.DeleteFunc(assessment.BlockerCodes, func(code string) bool {
+			return code == string(types.BlockerNonSpringFramework)
+		})
+		if assessment.Feasibility == "red" && len(assessment.BlockerCodes) == 0 {
+			assessment.Feasibility = "yellow"
+		}
+	}
 
 	path := state.AssessmentPath(in.RepoRoot)
 	if err := Save(path, assessment); err != nil {
@@ -179,6 +192,9 @@ func (s *Specialist) buildPrompt(in *specialists.Input) (string, error) {
 
 	fmt.Fprintf(&b, "# Source repo pre-scan\n\n")
 	fmt.Fprintf(&b, "Build system: %s\n", snap.BuildSystem)
+	if snap.Framework != "" {
+		fmt.Fprintf(&b, "Source framework: %s (convertible — see the %s conversion rules below)\n", snap.Framework, snap.Framework)
+	}
 	fmt.Fprintf(&b, "Java files: %d\n", len(snap.JavaFiles))
 	fmt.Fprintf(&b, "Kotlin files: %d\n", len(snap.KotlinFiles))
 	fmt.Fprintf(&b, "Non-JVM files (sample): ")
@@ -228,6 +244,10 @@ func (s *Specialist) buildPrompt(in *specialists.Input) (string, error) {
 		fmt.Fprintf(&b, "```json\n%s\n```\n", string(buf))
 	}
 
+	if guidance := frameworks.Guidance(snap.Framework); guidance != "" {
+		fmt.Fprintf(&b, "\n# Source framework conversion rules\n\nThe later phases convert this %s code with the rules below. Classify with them in mind: JAX-RS resources and Micronaut controllers are controllers, CDI/jakarta.inject beans are services, Panache / Micronaut Data / JDBI types are entities and repositories.\n\n%s\n", snap.Framework, guidance)
+	}
+
 	b.WriteString(`
 
 # Your task
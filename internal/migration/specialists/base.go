@@ -15,6 +15,7 @@ import (
 
 	"github.com/arianlopezc/Trabuco/internal/migration/state"
 	"github.com/arianlopezc/Trabuco/internal/migration/types"
+	"github.com/arianlopezc/Trabuco/internal/migration/validation"
 )
 
 // Input is what the orchestrator hands to a specialist when invoking it.
@@ -26,6 +27,7 @@ type Input struct {
 	State        *state.State   `json:"state"`
 	UserHint     string         `json:"userHint,omitempty"`     // present when re-running after edit-and-approve
 	Aggregate    string         `json:"aggregate,omitempty"`    // for per-aggregate gate granularity
+	Fresh        bool           `json:"fresh,omitempty"`        // re-run of a failed or rejected phase: ask the LLM again, skip cached responses
 }
 
 // Output is what a specialist returns. The orchestrator validates each
@@ -36,6 +38,10 @@ type Output struct {
 	Items     []types.OutputItem    `json:"items"`
 	Summary   string                `json:"summary"`
 	Decisions []DecisionRequest     `json:"decisions,omitempty"`
+
+	// Usage is the LLM consumption of the run; nil for specialists that
+	// do not call an LLM.
+	Usage *types.TokenUsage `json:"usage,omitempty"`
 }
 
 // DecisionRequest is a question the user must answer before the phase can
@@ -64,6 +70,26 @@ type Specialist interface {
 	Run(ctx context.Context, in *Input) (*Output, error)
 }
 
+// Repairer is implemented by specialists that can fix the compile errors
+// their own output caused. After a phase's files are written, the
+// orchestrator compiles the affected modules and, while javac reports
+// errors, hands them back through Repair a bounded number of times.
+type Repairer interface {
+	// Repair returns an Output whose applied items carry the file_writes
+	// that fix the errors. Only the files being fixed need to appear.
+	Repair(ctx context.Context, in *Input, req *RepairRequest) (*Output, error)
+}
+
+// RepairRequest is one iteration of the compile-repair loop.
+type RepairRequest struct {
+	// Attempt counts from 1.
+	Attempt int
+	Errors  []validation.CompileError
+	// Files are the files the phase has written so far, with their
+	// current content.
+	Files []types.FileWrite
+}
+
 // Registry maps phases to specialists. The orchestrator dispatches via
 // this registry. Specialists register themselves on package init.
 type Registry struct {
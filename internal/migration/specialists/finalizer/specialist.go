@@ -9,6 +9,7 @@
 //     conventions).
 //   - Final `mvn verify` (with full enforcement still on from Phase 12).
 //   - Generate `.trabuco-migration/completion-report.md`.
+//   - Generate `MIGRATION_REPORT.md` and `.trabuco-migration/migration-report.json`.
 //   - If user opts and legacy/ is empty, remove it.
 package finalizer
 
@@ -21,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/arianlopezc/Trabuco/internal/migration/report"
 	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
 	"github.com/arianlopezc/Trabuco/internal/migration/state"
 	"github.com/arianlopezc/Trabuco/internal/migration/types"
@@ -115,10 +117,17 @@ func (s *Specialist) Run(ctx context.Context, in *specialists.Input) (*specialis
 		return nil, fmt.Errorf("write completion report: %w", err)
 	}
 
+	// 6. Write the migration report (the hand-off document).
+	rep, err := report.Write(in.RepoRoot, in.State, items)
+	if err != nil {
+		return nil, fmt.Errorf("write migration report: %w", err)
+	}
+
 	return &specialists.Output{
-		Phase:   types.PhaseFinalization,
-		Items:   items,
-		Summary: "Migration complete. Project passes mvn verify with full enforcement, AI tooling synced, doctor green. Completion report at .trabuco-migration/completion-report.md.",
+		Phase: types.PhaseFinalization,
+		Items: items,
+		Summary: fmt.Sprintf("Migration complete. Project passes mvn verify with full enforcement, AI tooling synced, doctor green. Completion report at .trabuco-migration/completion-report.md; MIGRATION_REPORT.md lists %d converted files and %d follow-ups.",
+			len(rep.ConvertedFiles), len(rep.FollowUps)),
 	}, nil
 }
 
@@ -15,9 +15,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/arianlopezc/Trabuco/internal/ai"
+	"github.com/arianlopezc/Trabuco/internal/migration/cache"
+	"github.com/arianlopezc/Trabuco/internal/migration/frameworks"
 	"github.com/arianlopezc/Trabuco/internal/migration/specialists"
 	"github.com/arianlopezc/Trabuco/internal/migration/state"
 	"github.com/arianlopezc/Trabuco/internal/migration/types"
@@ -69,6 +73,50 @@ func (s *Specialist) Name() string { return s.spec.Name }
 // Run implements specialists.Specialist. Builds the prompt, calls the LLM,
 // parses the JSON output, and returns it.
 func (s *Specialist) Run(ctx context.Context, in *specialists.Input) (*specialists.Output, error) {
+	if out := skipEmptySlice(in); out != nil {
+		return out, nil
+	}
+	user, err := s.buildUserPrompt(in)
+	if err != nil {
+		return nil, err
+	}
+	return s.analyze(ctx, in, s.systemPrompt(in), user, s.spec.Name)
+}
+
+// Repair implements specialists.Repairer: it sends the javac errors and
+// the files the phase wrote back to the LLM, which answers with the
+// file_writes that fix them.
+func (s *Specialist) Repair(ctx context.Context, in *specialists.Input, req *specialists.RepairRequest) (*specialists.Output, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Phase: %d (%s)\nRepair attempt: %d\n\n", int(in.Phase), in.Phase, req.Attempt)
+	b.WriteString("## Compile errors\n\n`mvn -q compile` fails on the files this phase wrote:\n\n```\n")
+	for _, e := range req.Errors {
+		b.WriteString(e.String() + "\n")
+	}
+	b.WriteString("```\n\n## Files written by this phase\n\n")
+	for _, f := range req.Files {
+		fmt.Fprintf(&b, "### %s\n```\n%s```\n\n", f.Path, withLineNumbers(f.Content))
+	}
+	if data, err := readFileBest(filepath.Join(in.RepoRoot, "pom.xml")); err == nil {
+		fmt.Fprintf(&b, "## Parent pom.xml\n\n```xml\n%s```\n\n", data)
+	}
+	return s.analyze(ctx, in, s.systemPrompt(in)+"\n\n"+repairContract, b.String(), fmt.Sprintf("%s-repair-%d", s.spec.Name, req.Attempt))
+}
+
+// systemPrompt is the phase prompt plus the source framework's guidance.
+func (s *Specialist) systemPrompt(in *specialists.Input) string {
+	system := s.spec.SystemPrompt
+	if guidance := frameworks.Guidance(in.State.SourceConfig.Framework); guidance != "" {
+		// Quarkus/Micronaut/Dropwizard sources: JAX-RS, CDI and
+		// dependency conversion rules on top of the phase prompt.
+		system += "\n\n" + guidance
+	}
+	return system
+}
+
+// analyze makes one LLM call and parses the response as an Output. The
+// raw response is kept under rawName for debugging.
+func (s *Specialist) analyze(ctx context.Context, in *specialists.Input, system, user, rawName string) (*specialists.Output, error) {
 	if s.provider == nil {
 		p, err := defaultProvider()
 		if err != nil {
@@ -77,22 +125,34 @@ func (s *Specialist) Run(ctx context.Context, in *specialists.Input) (*specialis
 		s.provider = p
 	}
 
-	user, err := s.buildUserPrompt(in)
-	if err != nil {
-		return nil, err
-	}
-
 	maxTokens := s.spec.MaxTokens
 	if maxTokens == 0 {
 		maxTokens = 8000
 	}
 
 	req := &ai.AnalysisRequest{
-		SystemPrompt: s.spec.SystemPrompt + "\n\n" + outputContract,
+		SystemPrompt: system + "\n\n" + outputContract,
 		UserPrompt:   user,
 		MaxTokens:    maxTokens,
 		Temperature:  0.2, // mostly-deterministic; prompts demand JSON
+		Model:        specialistModel,
 	}
+
+	key := cache.Key(req.Model, strconv.Itoa(req.MaxTokens), req.SystemPrompt, req.UserPrompt)
+	if responseCache != nil && !in.Fresh {
+		if e, ok := responseCache.Get(key); ok {
+			if out, err := parseOutput(e.Content, s.spec.Phase); err == nil {
+				_ = state.WriteRawLLM(in.RepoRoot, s.spec.Phase, rawName, e.Content)
+				out.Usage = &types.TokenUsage{
+					Model:     e.Model,
+					CacheHits: 1,
+					SavedUSD:  s.provider.EstimateCost(e.InputTokens, e.OutputTokens),
+				}
+				return out, nil
+			}
+		}
+	}
+
 	resp, err := s.provider.Analyze(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("LLM call: %w", err)
@@ -100,15 +160,67 @@ func (s *Specialist) Run(ctx context.Context, in *specialists.Input) (*specialis
 
 	// Persist raw LLM output for debugging. Best-effort; failure here
 	// must not mask the real result.
-	_ = state.WriteRawLLM(in.RepoRoot, s.spec.Phase, s.spec.Name, resp.Content)
+	_ = state.WriteRawLLM(in.RepoRoot, s.spec.Phase, rawName, resp.Content)
 
 	out, err := parseOutput(resp.Content, s.spec.Phase)
 	if err != nil {
 		return nil, fmt.Errorf("parse LLM output: %w (content: %s)", err, truncate(resp.Content, 1000))
 	}
+	out.Usage = &types.TokenUsage{
+		Model:        resp.Model,
+		InputTokens:  resp.InputTokens,
+		OutputTokens: resp.OutputTokens,
+		CostUSD:      s.provider.EstimateCost(resp.InputTokens, resp.OutputTokens),
+	}
+	if responseCache != nil {
+		out.Usage.CacheMisses = 1
+		// Only responses that parsed are worth replaying. Best-effort: an
+		// unwritable cache must not fail the phase.
+		_ = responseCache.Put(key, &cache.Entry{
+			Model:        resp.Model,
+			Content:      resp.Content,
+			InputTokens:  resp.InputTokens,
+			OutputTokens: resp.OutputTokens,
+			CreatedAt:    time.Now().UTC(),
+		})
+	}
 	return out, nil
 }
 
+// responseCache replays LLM responses for identical requests; nil
+// disables caching.
+var responseCache = cache.Open(cache.DefaultDir())
+
+// SetCacheDir points the specialists at another response cache
+// directory, such as one shared between machines. An empty dir disables
+// the cache.
+func SetCacheDir(dir string) {
+	if dir == "" {
+		responseCache = nil
+		return
+	}
+	responseCache = cache.Open(dir)
+}
+
+// repairContract replaces the phase task for a compile-repair call.
+const repairContract = `# Compile repair
+
+The files you wrote for this phase do not compile. The user message lists
+the javac errors and the current content of every file the phase wrote.
+Fix the errors and nothing else:
+
+- Respond with the output contract below. Emit one item with
+  state="applied" per file you change, and a file_write with the FULL new
+  content of that file. Omit files that need no change.
+- Do not add features, rename public types, or move files between
+  modules. Keep source_evidence off the items.
+- A missing import, a wrong package or an absent dependency in a module
+  pom.xml are the usual causes; when a referenced class truly does not
+  exist, write it in the module the phase owns rather than deleting the
+  reference.
+- If an error cannot be fixed without changing scope, emit an item with
+  state="blocked", blocker_code="COMPILE_FAILED" and the reason.`
+
 // buildUserPrompt is the default implementation; specialists can override
 // via Spec.BuildUserPrompt.
 func (s *Specialist) buildUserPrompt(in *specialists.Input) (string, error) {
@@ -152,6 +264,9 @@ func DefaultUserPrompt(in *specialists.Input) (string, error) {
 		// migrations grounded in real code. relevantFilePaths returns
 		// just the legacy/* paths the phase consumes.
 		paths := relevantFilePaths(in)
+		if _, ok := state.PhaseCategories[in.Phase]; ok && in.State.Slicing() {
+			fmt.Fprintf(&b, "## Selective migration\n\nThis run migrates a slice of the source (%s). Migrate ONLY the source files listed under \"Source files in scope\" below. Every other class in the assessment was migrated by an earlier slice or is left for a later one: do not migrate, move or delete it, and keep the code that references it compiling (it stays in legacy/).\n\n", in.State.Selection)
+		}
 		if len(paths) > 0 {
 			fmt.Fprintf(&b, "## Source files in scope for this phase\n\n")
 			fmt.Fprintf(&b, "Each file below is shown with line numbers. Emit source_evidence.lines ranges that fall within the actual line counts you see here. content_hash is optional — if omitted, the orchestrator validates only file+lines.\n\n")
@@ -262,26 +377,19 @@ func relevantFilePaths(in *specialists.Input) []string {
 	}
 
 	switch in.Phase {
-	case types.PhaseModel:
-		paths = append(paths, collectFileField(a, "entities")...)
+	case types.PhaseModel, types.PhaseShared, types.PhaseAPI,
+		types.PhaseWorker, types.PhaseEventConsumer:
+		paths = append(paths, selectedFiles(in.State, a, in.Phase)...)
 	case types.PhaseDatastore:
-		paths = append(paths, collectFileField(a, "repositories")...)
+		paths = append(paths, selectedFiles(in.State, a, in.Phase)...)
+		// Every entity, migrated or not: repositories map onto them.
 		paths = append(paths, collectFileField(a, "entities")...)
-	case types.PhaseShared:
-		paths = append(paths, collectFileField(a, "services")...)
-	case types.PhaseAPI:
-		paths = append(paths, collectFileField(a, "controllers")...)
-	case types.PhaseWorker:
-		paths = append(paths, collectFileField(a, "jobs")...)
-	case types.PhaseEventConsumer:
-		paths = append(paths, collectFileField(a, "listeners")...)
-		paths = append(paths, collectFileField(a, "publishers")...)
 	case types.PhaseAIAgent:
 		// AI files aren't yet a separate Assessment field; rely on the
 		// LLM reading the assessment to decide which services/controllers
 		// are AI-related.
 	case types.PhaseTests:
-		paths = collectFileField(a, "tests")
+		paths = selectedFiles(in.State, a, in.Phase)
 	case types.PhaseConfiguration:
 		if cf, ok := a["configFiles"].([]any); ok {
 			for _, p := range cf {
@@ -314,6 +422,58 @@ func relevantFilePaths(in *specialists.Input) []string {
 	return paths
 }
 
+// selectedFiles returns the files of the phase's catalogs that the
+// current slice covers and no earlier slice migrated.
+func selectedFiles(st *state.State, a map[string]any, phase types.Phase) []string {
+	var out []string
+	for _, category := range state.PhaseCategories[phase] {
+		if !st.Selection.AllowsCategory(category) {
+			continue
+		}
+		for _, f := range collectFileField(a, category) {
+			if st.Selection.Matches(f) && !st.IsMigrated(f) {
+				out = append(out, f)
+			}
+		}
+	}
+	return out
+}
+
+// skipEmptySlice short-circuits a module phase with nothing to migrate in
+// the current slice, without an LLM call. It returns nil when the phase
+// should run.
+func skipEmptySlice(in *specialists.Input) *specialists.Output {
+	if _, ok := state.PhaseCategories[in.Phase]; !ok || !in.State.Slicing() {
+		return nil
+	}
+	a, err := loadAssessmentMap(state.AssessmentPath(in.RepoRoot))
+	if err != nil || len(selectedFiles(in.State, a, in.Phase)) > 0 {
+		return nil
+	}
+	reason := fmt.Sprintf("nothing to migrate in this slice (%s)", in.State.Selection)
+	return &specialists.Output{
+		Phase:   in.Phase,
+		Items:   []types.OutputItem{{ID: in.Phase.String() + "-slice-empty", State: types.ItemNotApplicable, Reason: reason}},
+		Summary: "Skipped: " + reason + ".",
+	}
+}
+
+// RemainingSources returns the source files of every module phase's
+// catalogs that no approved phase has migrated yet, regardless of the
+// current selection. A sliced migration is done when it is empty.
+func RemainingSources(repoRoot string, st *state.State) ([]string, error) {
+	a, err := loadAssessmentMap(state.AssessmentPath(repoRoot))
+	if err != nil {
+		return nil, err
+	}
+	all := &state.State{MigratedSources: st.MigratedSources}
+	var out []string
+	for _, p := range types.AllPhases() {
+		out = append(out, selectedFiles(all, a, p)...)
+	}
+	return out, nil
+}
+
 // loadAssessmentMap reads assessment.json into a generic map so we can
 // pluck file paths without importing the assessor package (which would
 // create a circular import).
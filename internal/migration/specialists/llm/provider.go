@@ -3,6 +3,7 @@ package llm
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/arianlopezc/Trabuco/internal/ai"
 	"github.com/arianlopezc/Trabuco/internal/auth"
@@ -28,11 +29,28 @@ func defaultProvider() (ai.Provider, error) {
 		apiKey = cred.APIKey
 	}
 	return ai.NewAnthropicProvider(&ai.ProviderConfig{
-		APIKey: apiKey,
-		Model:  ai.ModelClaudeSonnet.ID, // Sonnet is the default; opus too expensive for routine specialist calls
+		APIKey:  apiKey,
+		Model:   specialistModel,
+		Timeout: int(callTimeout / time.Second),
 	})
 }
 
+// callTimeout bounds each specialist call, the SDK's retries included. A
+// specialist call that outputs tens of thousands of tokens legitimately
+// takes minutes; one that takes longer than this is stuck.
+var callTimeout = 10 * time.Minute
+
+// SetCallTimeout changes the per-call timeout. Call it before the first
+// phase runs: specialists keep the provider their first call resolved.
+// 0 leaves calls bounded only by their context.
+func SetCallTimeout(d time.Duration) {
+	callTimeout = d
+}
+
+// specialistModel is the model every specialist call uses. Sonnet is the
+// default; opus too expensive for routine specialist calls.
+var specialistModel = ai.ModelClaudeSonnet.ID
+
 func readFileBest(path string) (string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -35,3 +35,19 @@ var Deployment string
 
 //go:embed tests.md
 var Tests string
+
+// Source-framework conversion guidance, appended to every LLM
+// specialist's system prompt when the source is not Spring Boot. See
+// internal/migration/frameworks.
+
+//go:embed nonspring.md
+var NonSpring string
+
+//go:embed quarkus.md
+var Quarkus string
+
+//go:embed micronaut.md
+var Micronaut string
+
+//go:embed dropwizard.md
+var Dropwizard string
@@ -0,0 +1,161 @@
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arianlopezc/Trabuco/internal/migration/types"
+)
+
+// SelectionCategories are the assessment.json catalogs a selective
+// migration can be restricted to, each owned by one phase.
+var SelectionCategories = []string{
+	"entities", "repositories", "services", "controllers",
+	"jobs", "listeners", "publishers", "tests",
+}
+
+// PhaseCategories maps each module phase to the SelectionCategories it
+// migrates. Only these phases are sliced; the others run once.
+var PhaseCategories = map[types.Phase][]string{
+	types.PhaseModel:         {"entities"},
+	types.PhaseDatastore:     {"repositories"},
+	types.PhaseShared:        {"services"},
+	types.PhaseAPI:           {"controllers"},
+	types.PhaseWorker:        {"jobs"},
+	types.PhaseEventConsumer: {"listeners", "publishers"},
+	types.PhaseTests:         {"tests"},
+}
+
+// Selection restricts the module phases to a slice of the source so a
+// large monolith can be migrated in several passes. Classes outside the
+// slice stay in legacy/ for a later run.
+type Selection struct {
+	// Only lists the SelectionCategories to migrate; empty means all.
+	Only []string `json:"only,omitempty"`
+	// Include lists fully-qualified class name patterns, where * matches
+	// any run of characters: "com.acme.billing.*" selects the package and
+	// its subpackages, "*Controller" every controller. Empty means all.
+	Include []string `json:"include,omitempty"`
+}
+
+// ParseSelection builds a Selection from the comma-separated --only and
+// --include values. It returns nil when both are empty.
+func ParseSelection(only, include string) (*Selection, error) {
+	sel := &Selection{Only: splitList(only), Include: splitList(include)}
+	for _, c := range sel.Only {
+		if !slices.Contains(SelectionCategories, c) {
+			return nil, fmt.Errorf("unknown category %q in --only (valid: %s)", c, strings.Join(SelectionCategories, ", "))
+		}
+	}
+	if len(sel.Only) == 0 && len(sel.Include) == 0 {
+		return nil, nil
+	}
+	return sel, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" && !slices.Contains(out, part) {
+			out = append(out, part)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Equal reports whether two selections select the same slice. A nil
+// selection equals only another nil one.
+func (s *Selection) Equal(other *Selection) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	return slices.Equal(s.Only, other.Only) && slices.Equal(s.Include, other.Include)
+}
+
+// AllowsCategory reports whether the selection covers an assessment
+// category.
+func (s *Selection) AllowsCategory(category string) bool {
+	return s == nil || len(s.Only) == 0 || slices.Contains(s.Only, category)
+}
+
+// Matches reports whether a source file falls under the Include patterns.
+func (s *Selection) Matches(path string) bool {
+	if s == nil || len(s.Include) == 0 {
+		return true
+	}
+	class := ClassNameOf(path)
+	for _, pattern := range s.Include {
+		if includePattern(pattern).MatchString(class) {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the selection the way it is passed on the command line.
+func (s *Selection) String() string {
+	if s == nil {
+		return "everything"
+	}
+	var parts []string
+	if len(s.Only) > 0 {
+		parts = append(parts, "--only "+strings.Join(s.Only, ","))
+	}
+	if len(s.Include) > 0 {
+		parts = append(parts, "--include '"+strings.Join(s.Include, ",")+"'")
+	}
+	return strings.Join(parts, " ")
+}
+
+func includePattern(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	return regexp.MustCompile("^" + strings.ReplaceAll(quoted, `\*`, ".*") + "$")
+}
+
+// ClassNameOf returns the fully-qualified class name of a Java source
+// path ("legacy/src/main/java/com/acme/Order.java" → "com.acme.Order").
+// Paths outside a source root are returned with slashes turned to dots.
+func ClassNameOf(path string) string {
+	p := strings.ReplaceAll(path, "\\", "/")
+	for _, root := range []string{"src/main/java/", "src/test/java/"} {
+		if i := strings.Index(p, root); i >= 0 {
+			p = p[i+len(root):]
+			break
+		}
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(p, ".java"), "/", ".")
+}
+
+// Slicing reports whether the migration runs in slices: a selection is
+// active, or an earlier slice already migrated part of the source.
+func (s *State) Slicing() bool {
+	return s.Selection != nil || len(s.MigratedSources) > 0
+}
+
+// IsMigrated reports whether an earlier phase run migrated the source
+// file.
+func (s *State) IsMigrated(path string) bool {
+	_, found := slices.BinarySearch(s.MigratedSources, path)
+	return found
+}
+
+// RecordMigrated adds source files to MigratedSources, keeping it sorted
+// and free of duplicates.
+func (s *State) RecordMigrated(paths ...string) {
+	for _, p := range paths {
+		if i, found := slices.BinarySearch(s.MigratedSources, p); !found {
+			s.MigratedSources = slices.Insert(s.MigratedSources, i, p)
+		}
+	}
+}
+
+// ForgetMigrated removes source files from MigratedSources.
+func (s *State) ForgetMigrated(paths ...string) {
+	s.MigratedSources = slices.DeleteFunc(s.MigratedSources, func(p string) bool {
+		return slices.Contains(paths, p)
+	})
+}
@@ -0,0 +1,67 @@
+package state
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSelection(t *testing.T) {
+	sel, err := ParseSelection(" controllers,entities,controllers ", "com.acme.billing.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(sel.Only, []string{"controllers", "entities"}) || !slices.Equal(sel.Include, []string{"com.acme.billing.*"}) {
+		t.Errorf("sel = %+v", sel)
+	}
+	if got := sel.String(); got != "--only controllers,entities --include 'com.acme.billing.*'" {
+		t.Errorf("String() = %q", got)
+	}
+	if !sel.AllowsCategory("entities") || sel.AllowsCategory("jobs") {
+		t.Error("AllowsCategory should follow --only")
+	}
+
+	if sel, err := ParseSelection("", ""); sel != nil || err != nil {
+		t.Errorf("an empty selection should be nil, got %+v, %v", sel, err)
+	}
+	if _, err := ParseSelection("entities,widgets", ""); err == nil {
+		t.Error("an unknown category should be rejected")
+	}
+}
+
+func TestSelection_Matches(t *testing.T) {
+	sel := &Selection{Include: []string{"com.acme.billing.*", "*Controller"}}
+	for path, want := range map[string]bool{
+		"legacy/src/main/java/com/acme/billing/Invoice.java":     true,
+		"src/main/java/com/acme/billing/tax/TaxRule.java":        true,
+		"src/main/java/com/acme/orders/OrderController.java":     true,
+		"src/test/java/com/acme/orders/OrderServiceTest.java":    false,
+		"src/main/java/com/acme/billingreport/BillingStats.java": false,
+	} {
+		if got := sel.Matches(path); got != want {
+			t.Errorf("Matches(%s) = %v, want %v", path, got, want)
+		}
+	}
+
+	var none *Selection
+	if !none.Matches("src/main/java/Anything.java") || !none.AllowsCategory("jobs") {
+		t.Error("a nil selection should select everything")
+	}
+}
+
+func TestState_MigratedSources(t *testing.T) {
+	s := New("test")
+	if s.Slicing() {
+		t.Error("a fresh state should not be slicing")
+	}
+	s.RecordMigrated("b.java", "a.java", "b.java")
+	if !slices.Equal(s.MigratedSources, []string{"a.java", "b.java"}) {
+		t.Errorf("MigratedSources = %v", s.MigratedSources)
+	}
+	if !s.IsMigrated("a.java") || s.IsMigrated("c.java") || !s.Slicing() {
+		t.Error("IsMigrated/Slicing disagree with MigratedSources")
+	}
+	s.ForgetMigrated("a.java")
+	if !slices.Equal(s.MigratedSources, []string{"b.java"}) {
+		t.Errorf("after ForgetMigrated: %v", s.MigratedSources)
+	}
+}
@@ -38,13 +38,19 @@ type State struct {
 	Blockers          []BlockerRecord                     `json:"blockers"`
 	Decisions         []DecisionRecord                    `json:"decisions"`
 	RetainedLegacy    []string                            `json:"retainedLegacy"`
+	// Selection is the slice of the source the module phases migrate;
+	// nil migrates everything not yet migrated.
+	Selection *Selection `json:"selection,omitempty"`
+	// MigratedSources are the legacy source files approved phases have
+	// migrated, so later slices skip them.
+	MigratedSources []string `json:"migratedSources,omitempty"`
 }
 
 // SourceConfig captures what the assessor learned about the source repo.
 // Filled by Phase 0; immutable thereafter.
 type SourceConfig struct {
 	BuildSystem    string   `json:"buildSystem"`    // maven | gradle | other
-	Framework      string   `json:"framework"`      // spring-boot-2.x | spring-boot-3.x | quarkus | ...
+	Framework      string   `json:"framework"`      // spring-boot-2.x | spring-boot-3.x | quarkus | micronaut | dropwizard | ...
 	JavaVersion    string   `json:"javaVersion"`
 	Persistence    string   `json:"persistence"`    // jpa | spring-data-jdbc | mongodb | none | ...
 	Messaging      string   `json:"messaging"`      // kafka | rabbitmq | sqs | pubsub | none
@@ -74,6 +80,15 @@ type PhaseRecord struct {
 	Reason        string                `json:"reason,omitempty"`
 	SubAggregates map[string]types.PhaseStateLabel `json:"subAggregates,omitempty"`
 	RetryCount    int                   `json:"retryCount,omitempty"`
+	// Usage sums the LLM consumption of every run of the phase, retries
+	// and rejected runs included
+	Usage *types.TokenUsage `json:"usage,omitempty"`
+	// Repairs counts the compile-repair iterations across every run of
+	// the phase
+	Repairs int `json:"repairs,omitempty"`
+	// Migrated lists the source files the last approved run migrated;
+	// rolling the phase back forgets them
+	Migrated []string `json:"migrated,omitempty"`
 }
 
 // BlockerRecord is a recorded blocker with the user's resolution.
@@ -213,6 +228,19 @@ func CompletionReportPath(repoRoot string) string {
 	return filepath.Join(MigrationDirPath(repoRoot), "completion-report.md")
 }
 
+// MigrationReportPath returns the path to MIGRATION_REPORT.md. It sits at
+// the repo root, not under .trabuco-migration/, so it is reviewed and
+// committed with the migrated code.
+func MigrationReportPath(repoRoot string) string {
+	return filepath.Join(repoRoot, "MIGRATION_REPORT.md")
+}
+
+// MigrationReportJSONPath returns the path to migration-report.json, the
+// machine-readable twin of MIGRATION_REPORT.md.
+func MigrationReportJSONPath(repoRoot string) string {
+	return filepath.Join(MigrationDirPath(repoRoot), "migration-report.json")
+}
+
 // WriteRawLLM persists a specialist's raw LLM response under
 // .trabuco-migration/phase-N-{name}-raw.txt for debugging. Best-effort —
 // callers ignore errors so debug instrumentation never masks a real
@@ -100,6 +100,8 @@ func TestPathHelpers(t *testing.T) {
 		LockPath(repo):                                  filepath.Join(repo, ".trabuco-migration", "lock.json"),
 		AssessmentPath(repo):                            filepath.Join(repo, ".trabuco-migration", "assessment.json"),
 		CompletionReportPath(repo):                      filepath.Join(repo, ".trabuco-migration", "completion-report.md"),
+		MigrationReportPath(repo):                       filepath.Join(repo, "MIGRATION_REPORT.md"),
+		MigrationReportJSONPath(repo):                   filepath.Join(repo, ".trabuco-migration", "migration-report.json"),
 		PhaseInputPath(repo, types.PhaseModel):          filepath.Join(repo, ".trabuco-migration", "phase-2-input.json"),
 		PhaseOutputPath(repo, types.PhaseDeployment):    filepath.Join(repo, ".trabuco-migration", "phase-10-output.json"),
 		PhaseDiffPath(repo, types.PhaseActivation):      filepath.Join(repo, ".trabuco-migration", "phase-12-diff.patch"),
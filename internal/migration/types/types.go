@@ -146,6 +146,36 @@ type OutputItem struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+// TokenUsage is the LLM consumption of a specialist run, or the sum of
+// several runs. CostUSD is estimated from the model's list price.
+// Responses served from the migration cache cost nothing; they count as
+// CacheHits, with the price of the original call in SavedUSD.
+type TokenUsage struct {
+	Model        string  `json:"model,omitempty"`
+	InputTokens  int     `json:"inputTokens"`
+	OutputTokens int     `json:"outputTokens"`
+	CostUSD      float64 `json:"costUsd"`
+	CacheHits    int     `json:"cacheHits,omitempty"`
+	CacheMisses  int     `json:"cacheMisses,omitempty"`
+	SavedUSD     float64 `json:"savedUsd,omitempty"`
+}
+
+// Add accumulates other into u. The model is kept when both runs used the
+// same one.
+func (u *TokenUsage) Add(other TokenUsage) {
+	if u.Model == "" && u.InputTokens == 0 && u.OutputTokens == 0 && u.CacheHits == 0 {
+		u.Model = other.Model
+	} else if u.Model != other.Model {
+		u.Model = ""
+	}
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CostUSD += other.CostUSD
+	u.CacheHits += other.CacheHits
+	u.CacheMisses += other.CacheMisses
+	u.SavedUSD += other.SavedUSD
+}
+
 // FileWrite is one file-system change. The orchestrator applies these
 // after the specialist returns. Path is relative to repo root and must
 // not traverse outside the repo (orchestrator enforces).
@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Compile runs mvn -q compile on the given modules (the whole reactor when
+// empty) and returns the build log and whether it succeeded. The
+// orchestrator's compile-repair loop uses it to check a phase's files
+// before the full funnel runs.
+func Compile(repoRoot string, modules []string) (string, bool) {
+	return runMavenCompile(repoRoot, modules)
+}
+
+// CompileError is one javac error from a Maven build log.
+type CompileError struct {
+	// File is repo-relative when the file lies inside the repo.
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+func (e CompileError) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// javacErrorRe matches maven-compiler-plugin's error lines:
+// "[ERROR] /abs/Foo.java:[12,8] cannot find symbol".
+var javacErrorRe = regexp.MustCompile(`^\[ERROR\] (.+\.java):\[(\d+),(\d+)\] (.*)$`)
+
+// javacDetailPrefixes start the indented lines javac adds under an error.
+var javacDetailPrefixes = []string{"symbol:", "location:", "required:", "found:", "reason:"}
+
+// ParseCompileErrors extracts the javac errors from a Maven build log,
+// folding the symbol/location detail lines into each message. Maven
+// prints the list twice (as it happens and in the final summary); the
+// duplicates are dropped. It returns nil when the build failed for
+// another reason, such as an unresolvable dependency.
+func ParseCompileErrors(repoRoot, log string) []CompileError {
+	var errs []CompileError
+	seen := map[string]bool{}
+	var cur *CompileError
+	flush := func() {
+		if cur != nil && !seen[cur.String()] {
+			seen[cur.String()] = true
+			errs = append(errs, *cur)
+		}
+		cur = nil
+	}
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := javacErrorRe.FindStringSubmatch(line); m != nil {
+			flush()
+			file := m[1]
+			if rel, err := filepath.Rel(repoRoot, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = filepath.ToSlash(rel)
+			}
+			lineNo, _ := strconv.Atoi(m[2])
+			col, _ := strconv.Atoi(m[3])
+			cur = &CompileError{File: file, Line: lineNo, Column: col, Message: m[4]}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		detail := strings.TrimSpace(strings.TrimPrefix(line, "[ERROR]"))
+		isDetail := false
+		for _, p := range javacDetailPrefixes {
+			if strings.HasPrefix(detail, p) {
+				isDetail = true
+				break
+			}
+		}
+		if !isDetail {
+			flush()
+			continue
+		}
+		cur.Message += "\n  " + strings.Join(strings.Fields(detail), " ")
+	}
+	flush()
+	return errs
+}
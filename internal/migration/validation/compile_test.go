@@ -0,0 +1,34 @@
+package validation
+
+import "testing"
+
+const mavenLog = `[ERROR] COMPILATION ERROR :
+[ERROR] /repo/model/src/main/java/com/acme/model/Order.java:[12,8] cannot find symbol
+  symbol:   class Customer
+  location: class com.acme.model.Order
+[ERROR] /repo/model/src/main/java/com/acme/model/Order.java:[3,1] package org.immutables.value does not exist
+[ERROR] Failed to execute goal org.apache.maven.plugins:maven-compiler-plugin:3.13.0:compile (default-compile) on project model: Compilation failure: Compilation failure:
+[ERROR] /repo/model/src/main/java/com/acme/model/Order.java:[12,8] cannot find symbol
+[ERROR]   symbol:   class Customer
+[ERROR]   location: class com.acme.model.Order
+[ERROR] /repo/model/src/main/java/com/acme/model/Order.java:[3,1] package org.immutables.value does not exist
+[ERROR] -> [Help 1]
+`
+
+func TestParseCompileErrors(t *testing.T) {
+	errs := ParseCompileErrors("/repo", mavenLog)
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2 (duplicates dropped): %v", len(errs), errs)
+	}
+	want := "model/src/main/java/com/acme/model/Order.java:12:8: cannot find symbol\n  symbol: class Customer\n  location: class com.acme.model.Order"
+	if got := errs[0].String(); got != want {
+		t.Errorf("errs[0] = %q, want %q", got, want)
+	}
+	if errs[1].Line != 3 || errs[1].Message != "package org.immutables.value does not exist" {
+		t.Errorf("errs[1] = %+v", errs[1])
+	}
+
+	if errs := ParseCompileErrors("/repo", "[ERROR] Could not resolve dependencies for project com.acme:model:jar:1.0"); errs != nil {
+		t.Errorf("a dependency failure has no javac errors, got %v", errs)
+	}
+}
@@ -153,3 +153,55 @@ func HEAD(dir string) (string, error) {
 	}
 	return strings.TrimSpace(string(out)), nil
 }
+
+// ResolveCommit returns the commit SHA a ref (tag, branch or SHA) points
+// at. Annotated tags are peeled to their commit.
+func ResolveCommit(dir, ref string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", ref+"^{commit}")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse %s: %w", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ListFilesAt returns the repo-relative paths of every file tracked at ref.
+func ListFilesAt(dir, ref string) ([]string, error) {
+	cmd := exec.Command("git", "ls-tree", "-r", "--name-only", ref)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-tree %s: %w", ref, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// ShowFile returns the content of path as of ref.
+func ShowFile(dir, ref, path string) ([]byte, error) {
+	cmd := exec.Command("git", "show", ref+":"+path)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show %s:%s: %w", ref, path, err)
+	}
+	return out, nil
+}
+
+// MigrationBaseline returns the commit the migration started from: the
+// earliest phase "pre" tag that exists. ok is false when no phase has
+// been tagged yet.
+func MigrationBaseline(dir string) (sha string, ok bool) {
+	for p := types.PhaseAssessment; p <= types.PhaseFinalization; p++ {
+		tag := PhasePreTag(p)
+		if !TagExists(dir, tag) {
+			continue
+		}
+		sha, err := ResolveCommit(dir, tag)
+		if err != nil {
+			return "", false
+		}
+		return sha, true
+	}
+	return "", false
+}
@@ -157,3 +157,32 @@ func TestCommitAll_DiffPatch(t *testing.T) {
 		t.Error("DiffPatch should return non-empty patch")
 	}
 }
+
+func TestMigrationBaseline(t *testing.T) {
+	dir := gitTestRepo(t)
+	if _, ok := MigrationBaseline(dir); ok {
+		t.Fatal("MigrationBaseline should be false before any phase is tagged")
+	}
+
+	initial, _ := HEAD(dir)
+	if err := CreateTag(dir, PhasePreTag(types.PhaseSkeleton), "pre skeleton", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "next.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CommitAll(dir, "second commit"); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateTag(dir, PhasePreTag(types.PhaseModel), "pre model", false); err != nil {
+		t.Fatal(err)
+	}
+
+	sha, ok := MigrationBaseline(dir)
+	if !ok {
+		t.Fatal("MigrationBaseline should find the skeleton pre tag")
+	}
+	if sha != initial {
+		t.Errorf("baseline = %s, want the commit the migration started from %s", sha, initial)
+	}
+}
@@ -0,0 +1,181 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileName is the file every template pack must carry at its root.
+const ManifestFileName = "trabuco-plugin.yaml"
+
+// moduleNameRegex mirrors the built-in module naming convention: PascalCase,
+// letters and digits only, so the name is safe as a Maven module directory
+// and as a Java package segment once lowercased.
+var moduleNameRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// Manifest describes a template pack that contributes one module.
+//
+// Example:
+//
+//	name: AuditLog
+//	displayName: Audit Log
+//	description: Append-only audit trail for domain changes
+//	version: 1.2.0
+//	updateURL: https://example.com/auditlog/trabuco-plugin.yaml
+//	dependencies: [Model, Shared]
+//	files:
+//	  - template: templates/pom.xml.tmpl
+//	    output: AuditLog/pom.xml
+//	pom:
+//	  properties:
+//	    auditlog.version: "1.2.0"
+//	docker:
+//	  services:
+//	    audit-db:
+//	      image: postgres:16-alpine
+//	  volumes: [audit-db-data]
+type Manifest struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"displayName,omitempty"`
+	Version     string `yaml:"version,omitempty"`
+	// UpdateURL is where the pack publishes its latest trabuco-plugin.yaml;
+	// the daily update check compares its version with the installed one.
+	UpdateURL      string      `yaml:"updateURL,omitempty"`
+	Description    string      `yaml:"description"`
+	UseCase        string      `yaml:"useCase,omitempty"`
+	WhenToUse      string      `yaml:"whenToUse,omitempty"`
+	DoesNotInclude string      `yaml:"doesNotInclude,omitempty"`
+	Dependencies   []string    `yaml:"dependencies,omitempty"`
+	ConflictsWith  []string    `yaml:"conflictsWith,omitempty"`
+	Files          []FileSpec  `yaml:"files"`
+	POM            POMEdits    `yaml:"pom,omitempty"`
+	Docker         DockerEdits `yaml:"docker,omitempty"`
+}
+
+// FileSpec maps a template inside the pack to an output path inside the
+// generated project. Both the template body and the output path are rendered
+// with the project configuration, so outputs may use {{.PackagePath}} etc.
+type FileSpec struct {
+	Template   string `yaml:"template"`
+	Output     string `yaml:"output"`
+	Executable bool   `yaml:"executable,omitempty"`
+}
+
+// POMEdits lists changes applied to the parent pom.xml when the module is
+// generated or added.
+type POMEdits struct {
+	Properties           map[string]string `yaml:"properties,omitempty"`
+	DependencyManagement []ManagedDep      `yaml:"dependencyManagement,omitempty"`
+}
+
+// ManagedDep is a <dependencyManagement> entry (typically a BOM import).
+type ManagedDep struct {
+	GroupID    string `yaml:"groupId"`
+	ArtifactID string `yaml:"artifactId"`
+	Version    string `yaml:"version"`
+	Type       string `yaml:"type,omitempty"`
+	Scope      string `yaml:"scope,omitempty"`
+}
+
+// DockerEdits lists docker-compose services and named volumes the module
+// needs for local development.
+type DockerEdits struct {
+	Services map[string]map[string]interface{} `yaml:"services,omitempty"`
+	Volumes  []string                          `yaml:"volumes,omitempty"`
+}
+
+// LoadManifest reads and validates the manifest at the root of dir.
+func LoadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s not found in %s", ManifestFileName, dir)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := m.Validate(dir); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks the manifest for structural problems. When dir is non-empty
+// every referenced template must also exist inside it.
+func (m *Manifest) Validate(dir string) error {
+	if !moduleNameRegex.MatchString(m.Name) {
+		return fmt.Errorf("invalid plugin module name %q: must be PascalCase letters and digits", m.Name)
+	}
+	if m.Description == "" {
+		return fmt.Errorf("plugin %s: description is required", m.Name)
+	}
+	if len(m.Files) == 0 {
+		return fmt.Errorf("plugin %s: at least one file entry is required", m.Name)
+	}
+	for _, dep := range m.Dependencies {
+		if dep == m.Name {
+			return fmt.Errorf("plugin %s: cannot depend on itself", m.Name)
+		}
+	}
+	for i, f := range m.Files {
+		if f.Template == "" || f.Output == "" {
+			return fmt.Errorf("plugin %s: files[%d] needs both template and output", m.Name, i)
+		}
+		if filepath.IsAbs(f.Output) || escapesRoot(f.Output) {
+			return fmt.Errorf("plugin %s: output %q must stay inside the project", m.Name, f.Output)
+		}
+		if escapesRoot(f.Template) {
+			return fmt.Errorf("plugin %s: template %q must stay inside the pack", m.Name, f.Template)
+		}
+		if dir != "" {
+			if _, err := os.Stat(filepath.Join(dir, f.Template)); err != nil {
+				return fmt.Errorf("plugin %s: template %s not found", m.Name, f.Template)
+			}
+		}
+	}
+	for _, d := range m.POM.DependencyManagement {
+		if d.GroupID == "" || d.ArtifactID == "" || d.Version == "" {
+			return fmt.Errorf("plugin %s: dependencyManagement entries need groupId, artifactId and version", m.Name)
+		}
+	}
+	return nil
+}
+
+// ToModule converts the manifest into a registry entry.
+func (m *Manifest) ToModule() config.Module {
+	deps := m.Dependencies
+	if len(deps) == 0 {
+		deps = []string{config.ModuleModel}
+	}
+	useCase := m.UseCase
+	if useCase == "" {
+		useCase = m.Description
+	}
+	return config.Module{
+		Name:           m.Name,
+		DisplayName:    m.DisplayName,
+		Description:    m.Description,
+		UseCase:        useCase,
+		WhenToUse:      m.WhenToUse,
+		DoesNotInclude: m.DoesNotInclude,
+		Dependencies:   deps,
+		ConflictsWith:  m.ConflictsWith,
+		Plugin:         m.Name,
+	}
+}
+
+// escapesRoot reports whether a relative path climbs out of its root.
+func escapesRoot(rel string) bool {
+	clean := filepath.Clean(rel)
+	return clean == ".." || len(clean) > 2 && clean[:3] == ".."+string(filepath.Separator)
+}
@@ -0,0 +1,200 @@
+package plugin
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+const testManifest = `name: AuditLog
+displayName: Audit Log
+version: 1.0.0
+description: Append-only audit trail
+dependencies: [Model, Shared]
+files:
+  - template: templates/pom.xml.tmpl
+    output: AuditLog/pom.xml
+pom:
+  properties:
+    auditlog.version: "1.0.0"
+docker:
+  services:
+    audit-db:
+      image: postgres:16-alpine
+  volumes: [audit-db-data]
+`
+
+// writePack creates a minimal valid pack in a temp dir and returns its path.
+func writePack(t *testing.T, manifest string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "templates", "pom.xml.tmpl"), []byte("<artifactId>{{.ProjectName}}-audit</artifactId>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// isolate points the plugins directory at a temp dir and snapshots the
+// module registry so registrations don't leak between tests.
+func isolate(t *testing.T) {
+	t.Helper()
+	t.Setenv("TRABUCO_PLUGINS_DIR", t.TempDir())
+	saved := append([]config.Module(nil), config.ModuleRegistry...)
+	loadedMu.Lock()
+	savedLoaded := loaded
+	loaded = map[string]*Plugin{}
+	loadedMu.Unlock()
+	t.Cleanup(func() {
+		config.ModuleRegistry = saved
+		loadedMu.Lock()
+		loaded = savedLoaded
+		loadedMu.Unlock()
+	})
+}
+
+func TestManifestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Manifest
+		wantErr string
+	}{
+		{"lowercase name", Manifest{Name: "auditLog", Description: "x", Files: []FileSpec{{Template: "a", Output: "b"}}}, "invalid plugin module name"},
+		{"missing description", Manifest{Name: "AuditLog", Files: []FileSpec{{Template: "a", Output: "b"}}}, "description is required"},
+		{"no files", Manifest{Name: "AuditLog", Description: "x"}, "at least one file"},
+		{"output escapes", Manifest{Name: "AuditLog", Description: "x", Files: []FileSpec{{Template: "a", Output: "../evil"}}}, "must stay inside the project"},
+		{"self dependency", Manifest{Name: "AuditLog", Description: "x", Dependencies: []string{"AuditLog"}, Files: []FileSpec{{Template: "a", Output: "b"}}}, "depend on itself"},
+		{"valid", Manifest{Name: "AuditLog", Description: "x", Files: []FileSpec{{Template: "a", Output: "AuditLog/pom.xml"}}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate("")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestInstallFromDirectoryAndLoad(t *testing.T) {
+	isolate(t)
+	src := writePack(t, testManifest)
+
+	p, err := Install(src, false)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if p.Manifest.Name != "AuditLog" {
+		t.Errorf("expected AuditLog, got %s", p.Manifest.Name)
+	}
+
+	if _, err := Install(src, false); err == nil {
+		t.Error("expected second install without force to fail")
+	}
+	if _, err := Install(src, true); err != nil {
+		t.Errorf("forced reinstall failed: %v", err)
+	}
+
+	if errs := Load(); len(errs) > 0 {
+		t.Fatalf("Load returned errors: %v", errs)
+	}
+	m := config.GetModule("AuditLog")
+	if m == nil {
+		t.Fatal("AuditLog was not registered")
+	}
+	if m.Plugin != "AuditLog" || !config.IsPluginModule("AuditLog") {
+		t.Error("registered module should be marked as a plugin module")
+	}
+	if Get("AuditLog") == nil {
+		t.Error("Get should return the loaded pack")
+	}
+
+	// Loading twice must not duplicate the registry entry.
+	before := len(config.ModuleRegistry)
+	Load()
+	if len(config.ModuleRegistry) != before {
+		t.Error("second Load duplicated registry entries")
+	}
+}
+
+func TestInstallFromTarGz(t *testing.T) {
+	isolate(t)
+	src := writePack(t, testManifest)
+
+	archive := filepath.Join(t.TempDir(), "auditlog.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		data, _ := os.ReadFile(path)
+		hdr := &tar.Header{Name: "auditlog-pack/" + filepath.ToSlash(rel), Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+	f.Close()
+
+	p, err := Install(archive, false)
+	if err != nil {
+		t.Fatalf("Install from archive failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(p.Dir, "templates", "pom.xml.tmpl")); err != nil {
+		t.Errorf("template not extracted: %v", err)
+	}
+}
+
+func TestInstallRejectsBuiltinCollision(t *testing.T) {
+	isolate(t)
+	src := writePack(t, strings.Replace(testManifest, "name: AuditLog", "name: Worker", 1))
+	if _, err := Install(src, false); err == nil || !strings.Contains(err.Error(), "built-in") {
+		t.Fatalf("expected built-in collision error, got %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	isolate(t)
+	if _, err := Install(writePack(t, testManifest), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Remove("AuditLog"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := Remove("AuditLog"); err == nil {
+		t.Error("removing a missing plugin should fail")
+	}
+	for _, name := range []string{"..", "../AuditLog", "Audit/Log", `Audit\Log`, ""} {
+		if err := Remove(name); err == nil || !strings.Contains(err.Error(), "invalid plugin name") {
+			t.Errorf("Remove(%q) should be refused, got %v", name, err)
+		}
+	}
+}
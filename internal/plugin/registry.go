@@ -0,0 +1,271 @@
+package plugin
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// Plugin is an installed template pack.
+type Plugin struct {
+	Manifest *Manifest
+	Dir      string // Absolute path of the installed pack
+}
+
+var (
+	loadedMu sync.RWMutex
+	loaded   = map[string]*Plugin{}
+)
+
+// Dir returns the directory installed packs live in (~/.trabuco/plugins).
+// TRABUCO_PLUGINS_DIR overrides it, which tests and CI use to isolate state.
+func Dir() string {
+	if d := os.Getenv("TRABUCO_PLUGINS_DIR"); d != "" {
+		return d
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".trabuco", "plugins")
+}
+
+// List returns every installed pack, sorted by name. Packs with an invalid
+// manifest are skipped and reported in the returned error list.
+func List() ([]*Plugin, []error) {
+	root := Dir()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("failed to read plugins directory: %w", err)}
+	}
+
+	var plugins []*Plugin
+	var errs []error
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		m, err := LoadManifest(dir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", e.Name(), err))
+			continue
+		}
+		plugins = append(plugins, &Plugin{Manifest: m, Dir: dir})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Manifest.Name < plugins[j].Manifest.Name })
+	return plugins, errs
+}
+
+// Load discovers installed packs and registers their modules in
+// config.ModuleRegistry so they appear in init, add and the MCP catalog.
+// Safe to call more than once; already-registered packs are skipped.
+func Load() []error {
+	plugins, errs := List()
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+	for _, p := range plugins {
+		if _, ok := loaded[p.Manifest.Name]; ok {
+			continue
+		}
+		if err := config.RegisterModule(p.Manifest.ToModule()); err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", p.Manifest.Name, err))
+			continue
+		}
+		loaded[p.Manifest.Name] = p
+	}
+	return errs
+}
+
+// Get returns the loaded pack that contributes the named module, or nil.
+func Get(module string) *Plugin {
+	loadedMu.RLock()
+	defer loadedMu.RUnlock()
+	return loaded[module]
+}
+
+// Install copies a pack from a directory or a .tar.gz/.tgz archive into the
+// plugins directory. Existing installs are only replaced when force is set.
+func Install(src string, force bool) (*Plugin, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", src, err)
+	}
+
+	stage, err := os.MkdirTemp("", "trabuco-plugin-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stage)
+
+	switch {
+	case info.IsDir():
+		if err := copyTree(src, stage); err != nil {
+			return nil, err
+		}
+	case strings.HasSuffix(src, ".tar.gz") || strings.HasSuffix(src, ".tgz"):
+		if err := extractTarGz(src, stage); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported plugin source %s: expected a directory or .tar.gz archive", src)
+	}
+
+	// Archives commonly wrap the pack in a single top-level directory.
+	packRoot := stage
+	if _, err := os.Stat(filepath.Join(stage, ManifestFileName)); os.IsNotExist(err) {
+		entries, _ := os.ReadDir(stage)
+		if len(entries) == 1 && entries[0].IsDir() {
+			packRoot = filepath.Join(stage, entries[0].Name())
+		}
+	}
+
+	m, err := LoadManifest(packRoot)
+	if err != nil {
+		return nil, err
+	}
+	if builtin := config.GetModule(m.Name); builtin != nil && builtin.Plugin == "" {
+		return nil, fmt.Errorf("plugin module %s collides with a built-in module", m.Name)
+	}
+
+	dest := filepath.Join(Dir(), m.Name)
+	if _, err := os.Stat(dest); err == nil {
+		if !force {
+			return nil, fmt.Errorf("plugin %s is already installed (use --force to replace it)", m.Name)
+		}
+		if err := os.RemoveAll(dest); err != nil {
+			return nil, fmt.Errorf("failed to remove previous install: %w", err)
+		}
+	}
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create plugins directory: %w", err)
+	}
+	if err := copyTree(packRoot, dest); err != nil {
+		return nil, err
+	}
+
+	return &Plugin{Manifest: m, Dir: dest}, nil
+}
+
+// Remove deletes an installed pack. The name must be a valid module name,
+// so it cannot reach outside the plugins directory.
+func Remove(name string) error {
+	if !moduleNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid plugin name %q: must be PascalCase letters and digits", name)
+	}
+	dest := filepath.Join(Dir(), name)
+	if _, err := os.Stat(filepath.Join(dest, ManifestFileName)); err != nil {
+		return fmt.Errorf("plugin %s is not installed", name)
+	}
+	return os.RemoveAll(dest)
+}
+
+// ReadTemplate returns the raw content of a template shipped in the pack.
+func (p *Plugin) ReadTemplate(rel string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(p.Dir, rel))
+	if err != nil {
+		return "", fmt.Errorf("plugin %s: failed to read template %s: %w", p.Manifest.Name, rel, err)
+	}
+	return string(data), nil
+}
+
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil // skip symlinks and devices
+		}
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func extractTarGz(archive, dst string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if escapesRoot(hdr.Name) || filepath.IsAbs(hdr.Name) {
+			return fmt.Errorf("archive entry %q escapes the pack root", hdr.Name)
+		}
+		target := filepath.Join(dst, hdr.Name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
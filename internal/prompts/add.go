@@ -50,6 +50,7 @@ func RunAddPrompts(existingModules []string) (*AddPromptResult, error) {
 			Options: []string{
 				"PostgreSQL (Recommended)",
 				"MySQL",
+				"MariaDB",
 				"Generic (bring your own driver)",
 			},
 			Default: "PostgreSQL (Recommended)",
@@ -66,7 +67,7 @@ func RunAddPrompts(existingModules []string) (*AddPromptResult, error) {
 		if hasWorker {
 			yellow := color.New(color.FgYellow)
 			yellow.Println("\n⚠ Worker module exists: Redis support is deprecated in JobRunr 8+.")
-			fmt.Println("  If you select Redis, JobRunr will continue using its current storage.")
+			fmt.Println("  If you select Redis, DynamoDB or Cassandra, JobRunr will continue using its current storage.")
 			fmt.Println("  MongoDB is recommended for Worker + NoSQLDatastore.")
 			fmt.Println()
 		}
@@ -76,6 +77,8 @@ func RunAddPrompts(existingModules []string) (*AddPromptResult, error) {
 			Options: []string{
 				"MongoDB (Recommended - Document store)",
 				"Redis (Key-Value store)",
+				"DynamoDB (Serverless key-value store on AWS)",
+				"Cassandra (Wide-column store)",
 			},
 			Default: "MongoDB (Recommended - Document store)",
 		}, &result.NoSQLDatabase); err != nil {
@@ -93,6 +96,8 @@ func RunAddPrompts(existingModules []string) (*AddPromptResult, error) {
 				"RabbitMQ (Traditional message queue)",
 				"AWS SQS (Managed queue service)",
 				"GCP Pub/Sub (Google Cloud messaging)",
+				"Redis Streams (Lightweight - reuses or adds a Redis server)",
+				"NATS JetStream (Lightweight - single binary)",
 			},
 			Default: "Kafka (Recommended - High throughput, partitioned)",
 		}, &result.MessageBroker); err != nil {
@@ -133,6 +138,7 @@ func PromptDatabase() (string, error) {
 		Options: []string{
 			"PostgreSQL (Recommended)",
 			"MySQL",
+			"MariaDB",
 			"Generic (bring your own driver)",
 		},
 		Default: "PostgreSQL (Recommended)",
@@ -157,6 +163,8 @@ func PromptNoSQLDatabase(hasWorker bool) (string, error) {
 		Options: []string{
 			"MongoDB (Recommended - Document store)",
 			"Redis (Key-Value store)",
+			"DynamoDB (Serverless key-value store on AWS)",
+			"Cassandra (Wide-column store)",
 		},
 		Default: "MongoDB (Recommended - Document store)",
 	}, &database); err != nil {
@@ -175,6 +183,8 @@ func PromptMessageBroker() (string, error) {
 			"RabbitMQ (Traditional message queue)",
 			"AWS SQS (Managed queue service)",
 			"GCP Pub/Sub (Google Cloud messaging)",
+			"Redis Streams (Lightweight - reuses or adds a Redis server)",
+			"NATS JetStream (Lightweight - single binary)",
 		},
 		Default: "Kafka (Recommended - High throughput, partitioned)",
 	}, &broker); err != nil {
@@ -268,6 +278,14 @@ func buildModuleOptions(available []string, existing []string) []string {
 			if !existingSet[config.ModuleEvents] {
 				desc += " (will add " + config.ModuleEvents + ")"
 			}
+		case config.ModuleSearch:
+			if !existingSet[config.ModuleShared] {
+				desc += " (will add " + config.ModuleShared + ")"
+			}
+		case config.ModuleClientSDK:
+			if !existingSet[config.ModuleAPI] {
+				desc += " (will add " + config.ModuleAPI + ")"
+			}
 		}
 
 		options = append(options, fmt.Sprintf("%s - %s", name, desc))
@@ -333,7 +351,7 @@ func ValidateModuleCanBeAdded(module string, existingModules []string) error {
 	}
 
 	// Check if it's an internal module
-	if m.Internal {
+	if m.Internal && !m.Addable {
 		return fmt.Errorf("cannot add %s directly: it's automatically included with %s", module, getParentModule(module))
 	}
 
@@ -292,11 +292,10 @@ func TestValidateModuleCanBeAdded(t *testing.T) {
 			errorContains:   "automatically included",
 		},
 		{
-			name:            "cannot add internal module Events directly",
+			name:            "can add Events publisher directly",
 			module:          "Events",
 			existingModules: []string{"Model"},
-			wantError:       true,
-			errorContains:   "automatically included",
+			wantError:       false,
 		},
 	}
 
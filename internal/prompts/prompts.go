@@ -17,9 +17,13 @@ var projectNameRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
 // groupIDRegex validates group IDs: valid Java package format
 var groupIDRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(\.[a-z][a-z0-9]*)+$`)
 
-// RunPrompts runs the interactive prompts and returns a ProjectConfig
-func RunPrompts() (*config.ProjectConfig, error) {
+// RunPrompts runs the interactive prompts and returns a ProjectConfig.
+// A non-nil profile pre-selects its values as the prompt defaults.
+func RunPrompts(profile *config.Profile) (*config.ProjectConfig, error) {
 	cfg := &config.ProjectConfig{}
+	if profile == nil {
+		profile = &config.Profile{}
+	}
 
 	// 1. Project name
 	if err := survey.AskOne(&survey.Input{
@@ -32,6 +36,9 @@ func RunPrompts() (*config.ProjectConfig, error) {
 
 	// 2. Group ID
 	defaultGroupID := "com.company." + strings.ReplaceAll(cfg.ProjectName, "-", "")
+	if fromProfile := profile.GroupIDFor(cfg.ProjectName); fromProfile != "" {
+		defaultGroupID = fromProfile
+	}
 	if err := survey.AskOne(&survey.Input{
 		Message: "Group ID:",
 		Default: defaultGroupID,
@@ -42,18 +49,26 @@ func RunPrompts() (*config.ProjectConfig, error) {
 
 	// 3. Module selection
 	moduleOptions := config.GetModuleDisplayOptions()
+	selectableModules := config.GetSelectableModules()
+	defaultIndices := []int{0} // Model, plus any template packs from the profile
+	for i, m := range selectableModules {
+		for _, pack := range profile.TemplatePacks {
+			if m == pack {
+				defaultIndices = append(defaultIndices, i)
+			}
+		}
+	}
 	var selectedIndices []int
 	if err := survey.AskOne(&survey.MultiSelect{
 		Message: "Select modules to include:",
 		Options: moduleOptions,
-		Default: []int{0}, // Only Model selected by default
+		Default: defaultIndices,
 		Help:    "Use space to toggle, enter to confirm. At least one module is required.",
 	}, &selectedIndices, survey.WithValidator(validateModuleSelection)); err != nil {
 		return nil, err
 	}
 
 	// Convert indices to module names (use selectable modules to match display options)
-	selectableModules := config.GetSelectableModules()
 	selectedModules := make([]string, len(selectedIndices))
 	for i, idx := range selectedIndices {
 		selectedModules[i] = selectableModules[idx]
@@ -64,23 +79,38 @@ func RunPrompts() (*config.ProjectConfig, error) {
 
 	// 4. Java version with detection
 	javaDetection := java.Detect()
-	javaVersion, javaDetected, err := promptJavaVersion(javaDetection)
+	javaVersion, javaDetected, err := promptJavaVersion(javaDetection, profile.JavaVersion)
 	if err != nil {
 		return nil, err
 	}
 	cfg.JavaVersion = javaVersion
 	cfg.JavaVersionDetected = javaDetected
 
+	// 4b. Application language
+	languageOptions := []string{"Java", "Kotlin"}
+	var languageChoice string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Application language:",
+		Options: languageOptions,
+		Default: profileDefault(languageOptions, profile.Language, strings.ToLower),
+		Help:    "Kotlin generates data classes instead of Immutables and builds with kotlin-maven-plugin; infrastructure config stays Java.",
+	}, &languageChoice); err != nil {
+		return nil, err
+	}
+	cfg.Language = strings.ToLower(languageChoice)
+
 	// 5. SQL Database (only if SQLDatastore is selected)
 	if cfg.HasModule(config.ModuleSQLDatastore) {
+		options := []string{
+			"PostgreSQL (Recommended)",
+			"MySQL",
+			"MariaDB",
+			"Generic (bring your own driver)",
+		}
 		if err := survey.AskOne(&survey.Select{
 			Message: "SQL Database:",
-			Options: []string{
-				"PostgreSQL (Recommended)",
-				"MySQL",
-				"Generic (bring your own driver)",
-			},
-			Default: "PostgreSQL (Recommended)",
+			Options: options,
+			Default: profileDefault(options, profile.Database, normalizeDatabaseChoice),
 		}, &cfg.Database); err != nil {
 			return nil, err
 		}
@@ -94,28 +124,31 @@ func RunPrompts() (*config.ProjectConfig, error) {
 		if cfg.HasModule(config.ModuleWorker) {
 			yellow := color.New(color.FgYellow)
 			yellow.Println("\n⚠ Worker module note: Redis support is deprecated in JobRunr 8+.")
-			fmt.Println("  If you select Redis, JobRunr will use PostgreSQL for job storage.")
+			fmt.Println("  If you select Redis, DynamoDB or Cassandra, JobRunr will use PostgreSQL for job storage.")
 			fmt.Println("  MongoDB is recommended for Worker + NoSQLDatastore.")
 			fmt.Println()
 		}
 
+		options := []string{
+			"MongoDB (Recommended - Document store)",
+			"Redis (Key-Value store)",
+			"DynamoDB (Serverless key-value store on AWS)",
+			"Cassandra (Wide-column store)",
+		}
 		if err := survey.AskOne(&survey.Select{
 			Message: "NoSQL Database:",
-			Options: []string{
-				"MongoDB (Recommended - Document store)",
-				"Redis (Key-Value store)",
-			},
-			Default: "MongoDB (Recommended - Document store)",
+			Options: options,
+			Default: profileDefault(options, profile.NoSQLDatabase, normalizeNoSQLDatabaseChoice),
 		}, &cfg.NoSQLDatabase); err != nil {
 			return nil, err
 		}
 		// Normalize NoSQL database value
 		cfg.NoSQLDatabase = normalizeNoSQLDatabaseChoice(cfg.NoSQLDatabase)
 
-		// Additional warning if Redis was selected with Worker
-		if cfg.HasModule(config.ModuleWorker) && cfg.NoSQLDatabase == config.DatabaseRedis {
+		// Additional warning if a database JobRunr can't store jobs in was selected with Worker
+		if cfg.WorkerUsesPostgresFallback() {
 			yellow := color.New(color.FgYellow)
-			yellow.Println("\n⚠ Redis selected with Worker: JobRunr will use PostgreSQL for job storage.")
+			yellow.Printf("\n⚠ %s selected with Worker: JobRunr will use PostgreSQL for job storage.\n", config.NoSQLDatabaseDisplayName(cfg.NoSQLDatabase))
 			fmt.Println("  A separate PostgreSQL instance will be added to docker-compose.yml.")
 			fmt.Println()
 		}
@@ -123,15 +156,18 @@ func RunPrompts() (*config.ProjectConfig, error) {
 
 	// 7. Message Broker (only if EventConsumer is selected)
 	if cfg.HasModule(config.ModuleEventConsumer) {
+		options := []string{
+			"Kafka (Recommended - High throughput, partitioned)",
+			"RabbitMQ (Traditional message queue)",
+			"AWS SQS (Managed queue service)",
+			"GCP Pub/Sub (Google Cloud messaging)",
+			"Redis Streams (Lightweight - reuses or adds a Redis server)",
+			"NATS JetStream (Lightweight - single binary)",
+		}
 		if err := survey.AskOne(&survey.Select{
 			Message: "Message Broker:",
-			Options: []string{
-				"Kafka (Recommended - High throughput, partitioned)",
-				"RabbitMQ (Traditional message queue)",
-				"AWS SQS (Managed queue service)",
-				"GCP Pub/Sub (Google Cloud messaging)",
-			},
-			Default: "Kafka (Recommended - High throughput, partitioned)",
+			Options: options,
+			Default: profileDefault(options, profile.MessageBroker, normalizeMessageBrokerChoice),
 		}, &cfg.MessageBroker); err != nil {
 			return nil, err
 		}
@@ -176,18 +212,26 @@ func RunPrompts() (*config.ProjectConfig, error) {
 
 	// 8. AI coding agent context files
 	agentOptions := config.GetAIAgentDisplayOptions()
+	allAgents := config.GetAvailableAIAgents()
+	defaultAgents := []int{} // None selected by default unless the profile lists some
+	for i, a := range allAgents {
+		for _, id := range profile.AIAgents {
+			if a.ID == id {
+				defaultAgents = append(defaultAgents, i)
+			}
+		}
+	}
 	var selectedAgentIndices []int
 	if err := survey.AskOne(&survey.MultiSelect{
 		Message: "Generate AI agent context files:",
 		Options: agentOptions,
-		Default: []int{}, // None selected by default
+		Default: defaultAgents,
 		Help:    "Creates context files with project-specific commands and conventions for AI coding assistants.",
 	}, &selectedAgentIndices); err != nil {
 		return nil, err
 	}
 
 	// Convert indices to agent IDs
-	allAgents := config.GetAvailableAIAgents()
 	cfg.AIAgents = make([]string, len(selectedAgentIndices))
 	for i, idx := range selectedAgentIndices {
 		cfg.AIAgents[i] = allAgents[idx].ID
@@ -195,11 +239,21 @@ func RunPrompts() (*config.ProjectConfig, error) {
 
 	// 9. CI/CD provider
 	ciOptions := append(config.GetCIProviderDisplayOptions(), "None - Skip CI configuration")
+	defaultCI := "None - Skip CI configuration"
+	for _, p := range config.GetAvailableCIProviders() {
+		if p.ID == profile.CIProvider {
+			for _, opt := range ciOptions {
+				if strings.HasPrefix(opt, p.Name) {
+					defaultCI = opt
+				}
+			}
+		}
+	}
 	var ciChoice string
 	if err := survey.AskOne(&survey.Select{
 		Message: "CI/CD provider:",
 		Options: ciOptions,
-		Default: "None - Skip CI configuration",
+		Default: defaultCI,
 		Help:    "Generate a CI workflow for your repository.",
 	}, &ciChoice); err != nil {
 		return nil, err
@@ -215,9 +269,44 @@ func RunPrompts() (*config.ProjectConfig, error) {
 		}
 	}
 
+	// 10. Observability stack (only when there is a runtime module to instrument)
+	if len(cfg.ObservabilityTargets()) > 0 {
+		if err := survey.AskOne(&survey.Confirm{
+			Message: "Add observability stack (OTLP tracing, Prometheus, Grafana, Tempo)?",
+			Default: false,
+			Help:    "Exports traces to a local Tempo by default and adds an 'observability' docker-compose profile with dashboards under observability/.",
+		}, &cfg.Observability); err != nil {
+			return nil, err
+		}
+	}
+
+	// 11. GraalVM native image support (runtime modules only)
+	if len(cfg.RuntimeTargets()) > 0 {
+		if err := survey.AskOne(&survey.Confirm{
+			Message: "Add GraalVM native image support?",
+			Default: false,
+			Help:    "Adds a 'native' Maven profile, AOT reflection hints for Immutables/Jackson, a Dockerfile.native per runtime module and a native CI job.",
+		}, &cfg.Native); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.LicenseHeader = profile.LicenseHeader
+
 	return cfg, nil
 }
 
+// profileDefault returns the option that normalizes to want, falling back to
+// the first (recommended) option when want is empty or matches nothing.
+func profileDefault(options []string, want string, normalize func(string) string) string {
+	for _, opt := range options {
+		if want != "" && normalize(opt) == want {
+			return opt
+		}
+	}
+	return options[0]
+}
+
 // Validators
 
 func validateProjectName(val interface{}) error {
@@ -231,6 +320,9 @@ func validateProjectName(val interface{}) error {
 	if !projectNameRegex.MatchString(str) {
 		return fmt.Errorf("must be lowercase, alphanumeric, hyphens allowed (not at start/end)")
 	}
+	if msg := config.ValidateProjectName(str); msg != "" {
+		return fmt.Errorf("%s", msg)
+	}
 	return nil
 }
 
@@ -245,6 +337,9 @@ func validateGroupID(val interface{}) error {
 	if !groupIDRegex.MatchString(str) {
 		return fmt.Errorf("must be valid Java package (e.g., com.company.project)")
 	}
+	if msg := config.ValidateGroupID(str); msg != "" {
+		return fmt.Errorf("%s", msg)
+	}
 	return nil
 }
 
@@ -303,6 +398,8 @@ func normalizeDatabaseChoice(choice string) string {
 		return config.DatabasePostgreSQL
 	case strings.HasPrefix(choice, "MySQL"):
 		return config.DatabaseMySQL
+	case strings.HasPrefix(choice, "MariaDB"):
+		return config.DatabaseMariaDB
 	default:
 		return "generic"
 	}
@@ -314,6 +411,10 @@ func normalizeNoSQLDatabaseChoice(choice string) string {
 		return config.DatabaseMongoDB
 	case strings.HasPrefix(choice, "Redis"):
 		return config.DatabaseRedis
+	case strings.HasPrefix(choice, "DynamoDB"):
+		return config.DatabaseDynamoDB
+	case strings.HasPrefix(choice, "Cassandra"):
+		return config.DatabaseCassandra
 	default:
 		return config.DatabaseMongoDB
 	}
@@ -329,6 +430,10 @@ func normalizeMessageBrokerChoice(choice string) string {
 		return config.BrokerSQS
 	case strings.HasPrefix(choice, "GCP Pub/Sub"):
 		return config.BrokerPubSub
+	case strings.HasPrefix(choice, "Redis Streams"):
+		return config.BrokerRedisStreams
+	case strings.HasPrefix(choice, "NATS"):
+		return config.BrokerNATS
 	default:
 		return config.BrokerKafka
 	}
@@ -349,7 +454,8 @@ func normalizeVectorStoreChoice(choice string) string {
 
 // promptJavaVersion prompts for Java version showing only detected supported versions.
 // If no supported version is detected, returns an error asking the user to install one.
-func promptJavaVersion(detection *java.DetectionResult) (version string, detected bool, err error) {
+// A preferred version (from a config profile) is pre-selected when detected.
+func promptJavaVersion(detection *java.DetectionResult, preferred string) (version string, detected bool, err error) {
 	options := buildJavaOptions(detection)
 	if len(options) == 0 {
 		red := color.New(color.FgRed)
@@ -365,11 +471,18 @@ func promptJavaVersion(detection *java.DetectionResult) (version string, detecte
 		return "", false, fmt.Errorf("no supported Java version detected (minimum: %d)", java.MinSupportedVersion)
 	}
 
+	defaultOption := options[0]
+	for _, opt := range options {
+		if preferred != "" && strings.Split(opt, " ")[0] == preferred {
+			defaultOption = opt
+		}
+	}
+
 	var selected string
 	if err := survey.AskOne(&survey.Select{
 		Message: "Java version:",
 		Options: options,
-		Default: options[0],
+		Default: defaultOption,
 	}, &selected); err != nil {
 		return "", false, err
 	}
@@ -37,8 +37,12 @@ var allowedPrefixes = []string{
 
 // allowedExact lists top-level files (no trailing slash) that sync handles.
 var allowedExact = map[string]bool{
-	"CLAUDE.md": true,
-	"AGENTS.md": true,
+	"CLAUDE.md":       true,
+	"AGENTS.md":       true,
+	"GEMINI.md":       true,
+	"CONVENTIONS.md":  true,
+	".rules":          true,
+	".aider.conf.yml": true,
 }
 
 // managedBlockTargets lists files that sync may modify in-place via the
@@ -7,6 +7,11 @@ func TestInJurisdiction_Allowed(t *testing.T) {
 		// Top-level AI docs
 		"CLAUDE.md",
 		"AGENTS.md",
+		"GEMINI.md",
+		"CONVENTIONS.md",
+		".rules",
+		// Aider
+		".aider.conf.yml",
 		// Prompts and task guides
 		".ai/prompts/add-entity.md",
 		".ai/prompts/JAVA_CODE_QUALITY.md",
@@ -104,6 +104,9 @@ func Run(projectPath, cliVersion string, apply bool) (*Plan, error) {
 	if err != nil {
 		return nil, fmt.Errorf("initialize generator: %w", err)
 	}
+	// Render with the project's own template overrides so customised
+	// files are not reported as drift.
+	gen.UseProjectOverrides(absProject)
 
 	restoreStdout := silenceStdout()
 	genErr := gen.Generate()
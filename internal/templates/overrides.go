@@ -0,0 +1,249 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// Override scopes, in increasing order of precedence
+const (
+	OverrideScopeUser    = "user"    // ~/.trabuco/templates-overrides
+	OverrideScopeProject = "project" // <project>/.trabuco/templates
+)
+
+// ProjectOverridesDir is the project-local override directory, relative to
+// the project root.
+const ProjectOverridesDir = ".trabuco/templates"
+
+// overrideDir is a directory of templates shadowing the embedded ones.
+type overrideDir struct {
+	dir   string
+	scope string
+}
+
+// Override is a single template file that shadows an embedded template.
+type Override struct {
+	Path  string // Embedded template path it shadows, e.g. "pom/parent.xml.tmpl"
+	File  string // Absolute path of the override on disk
+	Scope string // OverrideScopeUser or OverrideScopeProject
+}
+
+// UserOverridesDir returns the user-wide override directory. It can be
+// relocated with TRABUCO_TEMPLATE_OVERRIDES_DIR.
+func UserOverridesDir() string {
+	if dir := os.Getenv("TRABUCO_TEMPLATE_OVERRIDES_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".trabuco", "templates-overrides")
+}
+
+// WithProjectOverrides makes templates under <projectRoot>/.trabuco/templates
+// take precedence over both the user overrides and the embedded templates.
+func (e *Engine) WithProjectOverrides(projectRoot string) *Engine {
+	dir := overrideDir{dir: filepath.Join(projectRoot, filepath.FromSlash(ProjectOverridesDir)), scope: OverrideScopeProject}
+	e.overrides = append([]overrideDir{dir}, e.overrides...)
+	return e
+}
+
+// Overrides returns the active overrides, one per shadowed template path.
+// When both scopes provide the same path the project one wins.
+func (e *Engine) Overrides() []Override {
+	seen := map[string]bool{}
+	var result []Override
+	for _, od := range e.overrides {
+		filepath.WalkDir(od.dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(p, ".tmpl") {
+				return nil
+			}
+			rel, err := filepath.Rel(od.dir, p)
+			if err != nil {
+				return nil
+			}
+			rel = filepath.ToSlash(rel)
+			if seen[rel] {
+				return nil
+			}
+			seen[rel] = true
+			result = append(result, Override{Path: rel, File: p, Scope: od.scope})
+			return nil
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result
+}
+
+// resolve returns the content for a template path, preferring overrides.
+// The returned override is nil when the embedded template was used.
+func (e *Engine) resolve(templatePath string) ([]byte, *Override, error) {
+	for _, od := range e.overrides {
+		file := filepath.Join(od.dir, filepath.FromSlash(templatePath))
+		content, err := os.ReadFile(file)
+		if err == nil {
+			return content, &Override{Path: templatePath, File: file, Scope: od.scope}, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("failed to read template override %s: %w", file, err)
+		}
+	}
+	content, err := fs.ReadFile(e.fs, templatePath)
+	return content, nil, err
+}
+
+// ValidateOverride checks that an override shadows an existing embedded
+// template, parses, and only references fields the generator provides:
+// those of config.ProjectConfig plus any the embedded template itself uses
+// (some templates are rendered with a wrapper that adds fields).
+func (e *Engine) ValidateOverride(o Override) error {
+	embedded, err := fs.ReadFile(e.fs, o.Path)
+	if err != nil {
+		return fmt.Errorf("%s does not shadow any built-in template", o.Path)
+	}
+	content, err := os.ReadFile(o.File)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New(o.Path).Funcs(e.funcs).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse: %w", err)
+	}
+
+	allowed := map[string]bool{}
+	if base, err := template.New(o.Path).Funcs(e.funcs).Parse(string(embedded)); err == nil {
+		for _, name := range topLevelFields(base) {
+			allowed[name] = true
+		}
+	}
+	var unknown []string
+	for _, name := range topLevelFields(tmpl) {
+		if !allowed[name] && !hasMember(reflect.TypeOf(&config.ProjectConfig{}), name) {
+			unknown = append(unknown, "."+name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("references unknown field(s) %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// checkFields verifies every top-level field reference in tmpl exists on the
+// data it is about to be rendered with. Map data is not checked.
+func checkFields(tmpl *template.Template, data interface{}) error {
+	t := reflect.TypeOf(data)
+	if t == nil {
+		return nil
+	}
+	if k := t.Kind(); k == reflect.Map || (k == reflect.Ptr && t.Elem().Kind() == reflect.Map) {
+		return nil
+	}
+	var unknown []string
+	for _, name := range topLevelFields(tmpl) {
+		if !hasMember(t, name) {
+			unknown = append(unknown, "."+name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("references unknown field(s) %s on %s", strings.Join(unknown, ", "), t)
+	}
+	return nil
+}
+
+// hasMember reports whether t (or *t) has a field or method with the name.
+func hasMember(t reflect.Type, name string) bool {
+	if _, ok := t.MethodByName(name); ok {
+		return true
+	}
+	if t.Kind() != reflect.Ptr {
+		if _, ok := reflect.PointerTo(t).MethodByName(name); ok {
+			return true
+		}
+	} else {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		_, ok := t.FieldByName(name)
+		return ok
+	}
+	return false
+}
+
+// topLevelFields returns the distinct field/method names a template reads
+// from its root data: `.Name` outside range/with blocks and `$.Name`
+// anywhere. Names under a changed dot can't be checked statically.
+func topLevelFields(tmpl *template.Template) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	var walk func(node parse.Node, atRoot bool)
+	walkPipe := func(pipe *parse.PipeNode, atRoot bool) {
+		if pipe != nil {
+			walk(pipe, atRoot)
+		}
+	}
+	walk = func(node parse.Node, atRoot bool) {
+		switch n := node.(type) {
+		case nil:
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child, atRoot)
+			}
+		case *parse.ActionNode:
+			walkPipe(n.Pipe, atRoot)
+		case *parse.PipeNode:
+			for _, cmd := range n.Cmds {
+				for _, arg := range cmd.Args {
+					walk(arg, atRoot)
+				}
+			}
+		case *parse.ChainNode:
+			walk(n.Node, atRoot)
+		case *parse.FieldNode:
+			if atRoot {
+				add(n.Ident[0])
+			}
+		case *parse.VariableNode:
+			if n.Ident[0] == "$" && len(n.Ident) > 1 {
+				add(n.Ident[1])
+			}
+		case *parse.IfNode:
+			walkPipe(n.Pipe, atRoot)
+			walk(n.List, atRoot)
+			walk(n.ElseList, atRoot)
+		case *parse.RangeNode:
+			walkPipe(n.Pipe, atRoot)
+			walk(n.List, false)
+			walk(n.ElseList, atRoot)
+		case *parse.WithNode:
+			walkPipe(n.Pipe, atRoot)
+			walk(n.List, false)
+			walk(n.ElseList, atRoot)
+		case *parse.TemplateNode:
+			walkPipe(n.Pipe, atRoot)
+		}
+	}
+	if tmpl.Tree != nil {
+		walk(tmpl.Tree.Root, true)
+	}
+	return names
+}
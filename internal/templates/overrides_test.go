@@ -0,0 +1,97 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// writeOverride writes an override template under dir at the given path.
+func writeOverride(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOverridePrecedence(t *testing.T) {
+	userDir := t.TempDir()
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", userDir)
+	project := t.TempDir()
+	cfg := &config.ProjectConfig{ProjectName: "demo"}
+
+	writeOverride(t, userDir, "docs/gitignore.tmpl", "user {{.ProjectName}}\n")
+	out, err := NewEngine().Execute("docs/gitignore.tmpl", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "user demo\n" {
+		t.Errorf("user override not applied, got %q", out)
+	}
+
+	writeOverride(t, filepath.Join(project, ProjectOverridesDir), "docs/gitignore.tmpl", "project {{.ProjectName}}\n")
+	engine := NewEngine().WithProjectOverrides(project)
+	out, err = engine.Execute("docs/gitignore.tmpl", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "project demo\n" {
+		t.Errorf("project override should win, got %q", out)
+	}
+
+	overrides := engine.Overrides()
+	if len(overrides) != 1 || overrides[0].Scope != OverrideScopeProject {
+		t.Errorf("expected a single project-scoped override, got %+v", overrides)
+	}
+}
+
+func TestOverrideUnknownFieldRejected(t *testing.T) {
+	userDir := t.TempDir()
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", userDir)
+	writeOverride(t, userDir, "docs/gitignore.tmpl", "{{.ProjectNme}}\n{{range .Modules}}{{.}}{{$.GroupId}}{{end}}\n")
+
+	engine := NewEngine()
+	_, err := engine.Execute("docs/gitignore.tmpl", &config.ProjectConfig{})
+	if err == nil {
+		t.Fatal("expected unknown field error")
+	}
+	for _, want := range []string{".ProjectNme", ".GroupId", "user override"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %q: %v", want, err)
+		}
+	}
+
+	if err := engine.ValidateOverride(engine.Overrides()[0]); err == nil {
+		t.Error("ValidateOverride should reject unknown fields")
+	}
+}
+
+func TestValidateOverride(t *testing.T) {
+	userDir := t.TempDir()
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", userDir)
+	writeOverride(t, userDir, "docs/gitignore.tmpl", "{{if .HasModule \"API\"}}{{.ProjectNamePascal}}{{end}}\n")
+	writeOverride(t, userDir, "docs/nope.tmpl", "x\n")
+	writeOverride(t, userDir, "pom/parent.xml.tmpl", "{{if .Broken}\n")
+
+	engine := NewEngine()
+	results := map[string]error{}
+	for _, o := range engine.Overrides() {
+		results[o.Path] = engine.ValidateOverride(o)
+	}
+	if err := results["docs/gitignore.tmpl"]; err != nil {
+		t.Errorf("valid override rejected: %v", err)
+	}
+	if err := results["docs/nope.tmpl"]; err == nil || !strings.Contains(err.Error(), "does not shadow") {
+		t.Errorf("expected shadow error, got %v", err)
+	}
+	if err := results["pom/parent.xml.tmpl"]; err == nil || !strings.Contains(err.Error(), "parse") {
+		t.Errorf("expected parse error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// RenderResult is the outcome of rendering one template in the sandbox
+// ('trabuco internal render')
+type RenderResult struct {
+	Path   string `json:"path"`
+	Output string `json:"-"`
+	// Missing are the root fields the template reads that the data lacks:
+	// the generator renders it with a wrapper (entity, agent or rule data)
+	// around the ProjectConfig, so it is skipped instead of failed.
+	Missing []string `json:"missing,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Skipped reports whether the template needs data the sandbox doesn't have
+func (r *RenderResult) Skipped() bool {
+	return len(r.Missing) > 0
+}
+
+// Failed reports whether the template failed to parse or execute
+func (r *RenderResult) Failed() bool {
+	return r.Error != ""
+}
+
+// WithFS makes the engine read its built-in templates from fsys instead of
+// the embedded ones, so a checkout's templates/ directory can be rendered
+// without rebuilding the binary.
+func (e *Engine) WithFS(fsys fs.FS) *Engine {
+	e.fs = fsys
+	return e
+}
+
+// MissingFields returns the root fields templatePath reads that data has
+// no field or method for, as ".Name"
+func (e *Engine) MissingFields(templatePath string, data interface{}) ([]string, error) {
+	content, _, err := e.resolve(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", templatePath, err)
+	}
+	tmpl, err := template.New(templatePath).Funcs(e.funcs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+	}
+	t := reflect.TypeOf(data)
+	var missing []string
+	for _, name := range topLevelFields(tmpl) {
+		if t == nil || !hasMember(t, name) {
+			missing = append(missing, "."+name)
+		}
+	}
+	return missing, nil
+}
+
+// Render renders one template against data for the sandbox. A template
+// reading fields data lacks is reported as skipped, not rendered.
+func (e *Engine) Render(templatePath string, data interface{}) *RenderResult {
+	result := &RenderResult{Path: templatePath}
+	missing, err := e.MissingFields(templatePath, data)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if len(missing) > 0 {
+		result.Missing = missing
+		return result
+	}
+	out, err := e.Execute(templatePath, data)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Output = out
+	return result
+}
+
+// RenderAll renders every template under dir ("." for all of them)
+// against data, in path order
+func (e *Engine) RenderAll(dir string, data interface{}) ([]*RenderResult, error) {
+	paths, err := e.ListTemplates(strings.TrimSuffix(dir, "/"))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*RenderResult, 0, len(paths))
+	for _, path := range paths {
+		results = append(results, e.Render(path, data))
+	}
+	return results, nil
+}
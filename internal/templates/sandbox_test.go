@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+func TestRenderAll(t *testing.T) {
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", t.TempDir())
+	engine := NewEngine().WithFS(fstest.MapFS{
+		"docs/ok.tmpl":      {Data: []byte("name: {{.ProjectName}}\n")},
+		"docs/entity.tmpl":  {Data: []byte("{{.EntityName}} in {{.ProjectName}}\n")},
+		"docs/broken.tmpl":  {Data: []byte("{{if .ProjectName}}\n")},
+		"docs/failing.tmpl": {Data: []byte("{{index .Modules 5}}\n")},
+		"java/other.tmpl":   {Data: []byte("{{.GroupID}}\n")},
+	})
+	cfg := &config.ProjectConfig{ProjectName: "demo", GroupID: "com.demo", Modules: []string{"Model"}}
+
+	results, err := engine.RenderAll("docs/", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byPath := map[string]*RenderResult{}
+	for _, r := range results {
+		byPath[r.Path] = r
+	}
+	if len(byPath) != 4 {
+		t.Fatalf("expected the 4 templates under docs/, got %d", len(byPath))
+	}
+
+	if r := byPath["docs/ok.tmpl"]; r.Failed() || r.Skipped() || r.Output != "name: demo\n" {
+		t.Errorf("docs/ok.tmpl: unexpected result %+v", r)
+	}
+	if r := byPath["docs/entity.tmpl"]; !r.Skipped() || r.Failed() || !slices.Equal(r.Missing, []string{".EntityName"}) {
+		t.Errorf("docs/entity.tmpl should be skipped for .EntityName, got %+v", r)
+	}
+	for _, path := range []string{"docs/broken.tmpl", "docs/failing.tmpl"} {
+		if r := byPath[path]; !r.Failed() {
+			t.Errorf("%s should fail, got %+v", path, r)
+		}
+	}
+}
+
+func TestRenderAll_Embedded(t *testing.T) {
+	t.Setenv("TRABUCO_TEMPLATE_OVERRIDES_DIR", t.TempDir())
+	cfg := &config.ProjectConfig{
+		ProjectName:   "orders",
+		GroupID:       "com.test.orders",
+		ArtifactID:    "orders",
+		JavaVersion:   "21",
+		Modules:       config.ResolveDependencies([]string{"SQLDatastore", "API", "Worker", "EventConsumer"}),
+		Database:      "postgresql",
+		MessageBroker: "kafka",
+	}
+	results, err := NewEngine().RenderAll(".", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered := 0
+	for _, r := range results {
+		if r.Failed() {
+			t.Errorf("%s: %s", r.Path, r.Error)
+		}
+		if !r.Skipped() {
+			rendered++
+		}
+	}
+	if rendered == 0 {
+		t.Error("expected templates to render against a ProjectConfig")
+	}
+}
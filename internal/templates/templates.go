@@ -9,21 +9,28 @@ import (
 
 	"github.com/arianlopezc/Trabuco/internal/config"
 	"github.com/arianlopezc/Trabuco/internal/utils"
+	"github.com/arianlopezc/Trabuco/internal/versions"
 	embeddedTemplates "github.com/arianlopezc/Trabuco/templates"
 )
 
 // Engine handles template loading and execution
 type Engine struct {
-	fs    fs.FS
-	funcs template.FuncMap
+	fs        fs.FS
+	funcs     template.FuncMap
+	overrides []overrideDir // Searched in order before the embedded FS
 }
 
-// NewEngine creates a new template engine with embedded templates
+// NewEngine creates a new template engine with embedded templates. Templates
+// in the user override directory shadow the embedded ones by path.
 func NewEngine() *Engine {
-	return &Engine{
+	e := &Engine{
 		fs:    embeddedTemplates.FS,
 		funcs: createFuncMap(),
 	}
+	if dir := UserOverridesDir(); dir != "" {
+		e.overrides = []overrideDir{{dir: dir, scope: OverrideScopeUser}}
+	}
+	return e
 }
 
 // createFuncMap returns the template functions available in all templates
@@ -58,13 +65,17 @@ func createFuncMap() template.FuncMap {
 		"inList": inList,
 		"first":  first,
 		"last":   last,
+
+		// Dependency and image versions from versions.yaml
+		"version": versions.Lookup,
+		"image":   versions.LookupImage,
 	}
 }
 
 // Execute renders a template with the given data
 func (e *Engine) Execute(templatePath string, data interface{}) (string, error) {
-	// Read template content
-	content, err := fs.ReadFile(e.fs, templatePath)
+	// Read template content, preferring an override when one exists
+	content, override, err := e.resolve(templatePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read template %s: %w", templatePath, err)
 	}
@@ -72,9 +83,20 @@ func (e *Engine) Execute(templatePath string, data interface{}) (string, error)
 	// Parse template
 	tmpl, err := template.New(templatePath).Funcs(e.funcs).Parse(string(content))
 	if err != nil {
+		if override != nil {
+			return "", fmt.Errorf("failed to parse %s override %s: %w", override.Scope, override.File, err)
+		}
 		return "", fmt.Errorf("failed to parse template %s: %w", templatePath, err)
 	}
 
+	// Overrides are user-authored: reject references to fields the data
+	// model doesn't have before rendering a half-empty file
+	if override != nil {
+		if err := checkFields(tmpl, data); err != nil {
+			return "", fmt.Errorf("%s override %s %w", override.Scope, override.File, err)
+		}
+	}
+
 	// Execute template
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
@@ -122,7 +144,7 @@ func (e *Engine) ListTemplates(dir string) ([]string, error) {
 
 // TemplateExists checks if a template file exists
 func (e *Engine) TemplateExists(templatePath string) bool {
-	_, err := fs.Stat(e.fs, templatePath)
+	_, _, err := e.resolve(templatePath)
 	return err == nil
 }
 
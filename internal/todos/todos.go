@@ -0,0 +1,346 @@
+// Package todos finds the TODO and FIXME comments of a Trabuco project and
+// keeps the index of the ones Trabuco generated in .trabuco/todos.json.
+package todos
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IndexFileName is the index of the TODOs present when the project (or a
+// module added later) was generated.
+const IndexFileName = ".trabuco/todos.json"
+
+// contextLines is the number of lines kept before and after a TODO
+const contextLines = 2
+
+// maxContinuation caps how many following comment lines join a TODO's text
+const maxContinuation = 4
+
+// scannedExtensions are the files searched for TODOs. Markdown is left
+// out: the AI prompts and docs mention TODO as a word, not as work left.
+var scannedExtensions = map[string]bool{
+	".java": true, ".kt": true, ".kts": true, ".xml": true, ".yml": true,
+	".yaml": true, ".sql": true, ".properties": true, ".sh": true,
+}
+
+// skippedDirs are never searched. Hidden directories (.git, .ai, .claude,
+// .trabuco, ...) are skipped as well.
+var skippedDirs = map[string]bool{"target": true, "build": true, "node_modules": true}
+
+var (
+	// markerPattern matches a TODO or FIXME that opens a comment
+	markerPattern = regexp.MustCompile(`^\s*(?://|#|/?\*+|<!--|--)\s*(TODO|FIXME)\b:?\s*(.*?)\s*(?:\*/|-->)?$`)
+	// commentPattern matches a comment line and captures its text
+	commentPattern = regexp.MustCompile(`^\s*(?://|#|\*|--)\s?(.*?)\s*(?:\*/)?$`)
+)
+
+// Item is one TODO or FIXME comment
+type Item struct {
+	Module    string   `json:"module"` // Top-level directory, empty for root files
+	File      string   `json:"file"`   // Project-relative, slash-separated
+	Line      int      `json:"line"`
+	Kind      string   `json:"kind"` // "TODO" or "FIXME"
+	Text      string   `json:"text"`
+	Context   []string `json:"context,omitempty"`
+	Generated bool     `json:"generated"` // Listed in the index, i.e. written by Trabuco
+}
+
+// Index is the on-disk shape of .trabuco/todos.json
+type Index struct {
+	Version   string `json:"version"` // Trabuco version that last updated the index
+	UpdatedAt string `json:"updated_at"`
+	Items     []Item `json:"items"`
+}
+
+// Report lists the outstanding TODOs of a project
+type Report struct {
+	Items    []Item         `json:"items"`
+	ByModule map[string]int `json:"by_module"`
+	Resolved int            `json:"resolved"` // Indexed TODOs no longer in the code
+	Indexed  bool           `json:"indexed"`  // Whether .trabuco/todos.json exists
+}
+
+// Scan returns every TODO and FIXME comment under root, ordered by file and
+// line
+func Scan(root string) ([]Item, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || skippedDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if scannedExtensions[filepath.Ext(path)] {
+			rel, relErr := filepath.Rel(root, path)
+			if relErr == nil {
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return ScanFiles(root, files)
+}
+
+// ScanFiles returns the TODOs of the given project-relative files. Files
+// that do not exist or are not searched are skipped.
+func ScanFiles(root string, files []string) ([]Item, error) {
+	var items []Item
+	files = append([]string(nil), files...)
+	sort.Strings(files)
+	for _, rel := range files {
+		if !scannedExtensions[filepath.Ext(rel)] {
+			continue
+		}
+		found, err := scanFile(root, rel)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+func scanFile(root, rel string) ([]Item, error) {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+
+	module := ""
+	if i := strings.Index(rel, "/"); i > 0 {
+		module = rel[:i]
+	}
+
+	var items []Item
+	for i := 0; i < len(lines); i++ {
+		m := markerPattern.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		text := []string{m[2]}
+		end := i
+		for end+1 < len(lines) && end-i < maxContinuation {
+			next := lines[end+1]
+			if markerPattern.MatchString(next) || strings.HasPrefix(strings.TrimSpace(next), "*/") {
+				break
+			}
+			c := commentPattern.FindStringSubmatch(next)
+			if c == nil || strings.TrimSpace(c[1]) == "" {
+				break
+			}
+			text = append(text, strings.TrimSpace(c[1]))
+			end++
+		}
+		items = append(items, Item{
+			Module:  module,
+			File:    rel,
+			Line:    i + 1,
+			Kind:    m[1],
+			Text:    strings.TrimSpace(strings.Join(text, " ")),
+			Context: surrounding(lines, i, end),
+		})
+		i = end
+	}
+	return items, nil
+}
+
+// surrounding returns the non-blank lines around the comment block
+// [start, end]
+func surrounding(lines []string, start, end int) []string {
+	var out []string
+	for _, l := range lines[max(0, start-contextLines):start] {
+		if strings.TrimSpace(l) != "" {
+			out = append(out, l)
+		}
+	}
+	for _, l := range lines[end+1 : min(len(lines), end+1+contextLines)] {
+		if strings.TrimSpace(l) != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// LoadIndex reads .trabuco/todos.json. A missing index yields nil.
+func LoadIndex(root string) (*Index, error) {
+	data, err := os.ReadFile(filepath.Join(root, IndexFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", IndexFileName, err)
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", IndexFileName, err)
+	}
+	return &idx, nil
+}
+
+// RecordGenerated adds the TODOs of freshly generated files to the index,
+// replacing any earlier entries for the same files. init passes every file
+// it created; add passes the files of the new module.
+func RecordGenerated(root, version string, files []string) error {
+	idx, err := LoadIndex(root)
+	if err != nil {
+		return err
+	}
+	if idx == nil {
+		idx = &Index{}
+	}
+	found, err := ScanFiles(root, files)
+	if err != nil {
+		return err
+	}
+
+	replaced := make(map[string]bool, len(files))
+	for _, f := range files {
+		replaced[f] = true
+	}
+	items := idx.Items[:0]
+	for _, it := range idx.Items {
+		if !replaced[it.File] {
+			items = append(items, it)
+		}
+	}
+	for _, it := range found {
+		it.Generated = true
+		items = append(items, it)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].File != items[j].File {
+			return items[i].File < items[j].File
+		}
+		return items[i].Line < items[j].Line
+	})
+
+	idx.Version = version
+	idx.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	idx.Items = items
+	if idx.Items == nil {
+		idx.Items = []Item{}
+	}
+	path := filepath.Join(root, IndexFileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", IndexFileName, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", IndexFileName, err)
+	}
+	return nil
+}
+
+// Outstanding scans the project and marks the TODOs Trabuco generated. An
+// indexed TODO still counts as generated after edits move it to another
+// line; one whose file or text is gone counts as resolved. module, when
+// set, keeps only the TODOs of that top-level directory ("." for root
+// files).
+func Outstanding(root, module string) (*Report, error) {
+	items, err := Scan(root)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := LoadIndex(root)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := map[string]int{}
+	if idx != nil {
+		for _, it := range idx.Items {
+			generated[key(it)]++
+		}
+	}
+	report := &Report{Items: []Item{}, ByModule: map[string]int{}, Indexed: idx != nil}
+	for _, it := range items {
+		if generated[key(it)] > 0 {
+			generated[key(it)]--
+			it.Generated = true
+		}
+		if module != "" && it.Module != strings.TrimPrefix(module, ".") {
+			continue
+		}
+		report.Items = append(report.Items, it)
+		report.ByModule[ModuleLabel(it.Module)]++
+	}
+	for k, n := range generated {
+		if module != "" && !strings.HasPrefix(k, strings.TrimPrefix(module, ".")+"\x00") {
+			continue
+		}
+		report.Resolved += n
+	}
+	return report, nil
+}
+
+// key identifies a TODO independently of its line number
+func key(it Item) string {
+	return it.Module + "\x00" + it.File + "\x00" + it.Kind + "\x00" + it.Text
+}
+
+// GeneratedOnly drops the TODOs Trabuco did not generate and recounts the
+// modules
+func (r *Report) GeneratedOnly() {
+	items := []Item{}
+	r.ByModule = map[string]int{}
+	for _, it := range r.Items {
+		if it.Generated {
+			items = append(items, it)
+			r.ByModule[ModuleLabel(it.Module)]++
+		}
+	}
+	r.Items = items
+}
+
+// Generated returns the number of listed TODOs Trabuco generated
+func (r *Report) Generated() int {
+	n := 0
+	for _, it := range r.Items {
+		if it.Generated {
+			n++
+		}
+	}
+	return n
+}
+
+// ModuleLabel names a module in per-module counts; root files are
+// "(root)"
+func ModuleLabel(module string) string {
+	if module == "" {
+		return "(root)"
+	}
+	return module
+}
@@ -0,0 +1,120 @@
+package todos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "Worker/src/main/java/Handler.java", `class Handler {
+  void run() {
+    // TODO: Replace with actual business logic, e.g.:
+    //   service.process(message);
+    log.info("done");
+  }
+
+  /**
+   * Search for {@code TODO:} markers in this file.
+   * FIXME handle retries
+   */
+}
+`)
+	writeFile(t, root, "pom.xml", "<project>\n  <!-- TODO: pin the plugin version -->\n</project>\n")
+	writeFile(t, root, "API/src/main/resources/application.yml", "server:\n  # TODO set the port\n  port: 8080\n")
+	writeFile(t, root, "docs/notes.md", "TODO: not scanned\n")
+	writeFile(t, root, "API/target/classes/Copy.java", "// TODO: build output\n")
+	writeFile(t, root, ".ai/prompts/add-job.java", "// TODO: hidden directory\n")
+
+	items, err := Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("expected 4 TODOs, got %d: %+v", len(items), items)
+	}
+
+	yml, worker, fixme, pom := items[0], items[1], items[2], items[3]
+	if yml.Module != "API" || yml.Text != "set the port" || yml.Line != 2 {
+		t.Errorf("unexpected YAML TODO %+v", yml)
+	}
+	if worker.Module != "Worker" || worker.Line != 3 || worker.Text != "Replace with actual business logic, e.g.: service.process(message);" {
+		t.Errorf("continuation lines should join the text: %+v", worker)
+	}
+	if len(worker.Context) == 0 || worker.Context[len(worker.Context)-1] != "  }" {
+		t.Errorf("expected the surrounding lines as context, got %q", worker.Context)
+	}
+	if fixme.Kind != "FIXME" || fixme.Text != "handle retries" {
+		t.Errorf("unexpected FIXME %+v", fixme)
+	}
+	if pom.Module != "" || pom.Text != "pin the plugin version" {
+		t.Errorf("unexpected root TODO %+v", pom)
+	}
+}
+
+func TestOutstanding(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "Worker/Handler.java", "// TODO: implement the handler\n// TODO: add metrics\nclass Handler {}\n")
+	writeFile(t, root, "API/Controller.java", "// TODO: validate input\nclass Controller {}\n")
+
+	report, err := Outstanding(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Indexed || report.Generated() != 0 || len(report.Items) != 3 {
+		t.Fatalf("without an index nothing is generated: %+v", report)
+	}
+
+	if err := RecordGenerated(root, "1.0.0", []string{"Worker/Handler.java", "API/Controller.java"}); err != nil {
+		t.Fatal(err)
+	}
+	// The developer resolves one TODO, moves another and adds their own.
+	writeFile(t, root, "Worker/Handler.java", "class Handler {\n  // TODO: add metrics\n  // TODO: cache lookups\n}\n")
+
+	report, err = Outstanding(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Indexed || len(report.Items) != 3 || report.Generated() != 2 || report.Resolved != 1 {
+		t.Fatalf("expected 3 outstanding, 2 generated, 1 resolved; got %+v", report)
+	}
+	if report.ByModule["Worker"] != 2 || report.ByModule["API"] != 1 {
+		t.Errorf("unexpected module counts %v", report.ByModule)
+	}
+
+	report, _ = Outstanding(root, "API")
+	if len(report.Items) != 1 || report.Resolved != 0 {
+		t.Errorf("module filter should keep API only: %+v", report)
+	}
+
+	report, _ = Outstanding(root, "Worker")
+	report.GeneratedOnly()
+	if len(report.Items) != 1 || report.Items[0].Text != "add metrics" {
+		t.Errorf("generated-only should drop the developer's TODO: %+v", report.Items)
+	}
+
+	// Adding a module indexes its files without touching the others.
+	writeFile(t, root, "EventConsumer/Listener.java", "// TODO: handle the event\n")
+	if err := RecordGenerated(root, "1.1.0", []string{"EventConsumer/Listener.java"}); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := LoadIndex(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.Version != "1.1.0" || len(idx.Items) != 4 {
+		t.Errorf("expected the index to keep the init TODOs and add one, got %+v", idx)
+	}
+}
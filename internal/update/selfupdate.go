@@ -0,0 +1,259 @@
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// ReleaseAPIURL is the GitHub API of Trabuco's releases; /latest and
+	// /tags/<tag> are read from it.
+	ReleaseAPIURL = "https://api.github.com/repos/arianlopezc/Trabuco/releases"
+
+	// ChecksumsAsset is the release asset listing the SHA-256 of every
+	// binary, in sha256sum format.
+	ChecksumsAsset = "checksums.txt"
+
+	// maxBinarySize bounds a downloaded binary.
+	maxBinarySize = 256 << 20
+)
+
+// Installer is a package manager the running binary was installed with.
+type Installer struct {
+	Name    string
+	Command []string // The command that upgrades Trabuco through it
+}
+
+// installers are recognized by a directory in the executable's path.
+var installers = []struct {
+	marker    string
+	installer Installer
+}{
+	{"/Cellar/", Installer{Name: "Homebrew", Command: []string{"brew", "upgrade", "trabuco"}}},
+	{"/homebrew/", Installer{Name: "Homebrew", Command: []string{"brew", "upgrade", "trabuco"}}},
+	{"/linuxbrew/", Installer{Name: "Homebrew", Command: []string{"brew", "upgrade", "trabuco"}}},
+	{"/scoop/", Installer{Name: "Scoop", Command: []string{"scoop", "update", "trabuco"}}},
+	{"/node_modules/", Installer{Name: "npm", Command: []string{"npm", "install", "-g", "trabuco-mcp@latest"}}},
+}
+
+// DetectInstaller returns the package manager that owns the executable at
+// exe, or nil for a binary installed by hand, install.sh or go install.
+// Replacing a managed binary in place would leave the package manager
+// believing an older version is installed.
+func DetectInstaller(exe string) *Installer {
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	path := strings.ToLower(strings.ReplaceAll(exe, `\`, "/"))
+	for _, i := range installers {
+		if strings.Contains(path, strings.ToLower(i.marker)) {
+			installer := i.installer
+			return &installer
+		}
+	}
+	return nil
+}
+
+// AssetName returns the name of the release binary for a platform, as
+// the release workflow builds it.
+func AssetName(goos, goarch string) string {
+	name := "trabuco-" + goos + "-" + goarch
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// ReleaseAssets is a release with the download URLs of its assets.
+type ReleaseAssets struct {
+	Version string
+	URL     string
+	Assets  map[string]string // asset name → download URL
+}
+
+// SelfUpdater downloads and verifies release binaries. The zero value is
+// not usable; see NewSelfUpdater.
+type SelfUpdater struct {
+	Current       string
+	ReleaseAPIURL string
+	Client        *http.Client
+}
+
+// NewSelfUpdater returns an updater for the running version against
+// GitHub releases.
+func NewSelfUpdater(current string) *SelfUpdater {
+	return &SelfUpdater{
+		Current:       current,
+		ReleaseAPIURL: ReleaseAPIURL,
+		Client:        &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Release returns the release tagged version, or the latest one when
+// version is "".
+func (u *SelfUpdater) Release(ctx context.Context, version string) (*ReleaseAssets, error) {
+	url := u.ReleaseAPIURL + "/latest"
+	if version != "" {
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		url = u.ReleaseAPIURL + "/tags/" + version
+	}
+	data, err := u.get(ctx, url, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the release: %w", err)
+	}
+	var r struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse the release: %w", err)
+	}
+	if !IsRelease(r.TagName) {
+		return nil, fmt.Errorf("release %q is not a tagged version", r.TagName)
+	}
+	release := &ReleaseAssets{Version: r.TagName, URL: r.HTMLURL, Assets: map[string]string{}}
+	for _, a := range r.Assets {
+		release.Assets[a.Name] = a.URL
+	}
+	return release, nil
+}
+
+// Newer reports whether release is newer than the running version.
+// Development builds are older than every release.
+func (u *SelfUpdater) Newer(release *ReleaseAssets) bool {
+	return !IsRelease(u.Current) || compare(release.Version, u.Current) > 0
+}
+
+// Download fetches the binary asset of release and verifies it against
+// the release's checksums.txt. A release without checksums is refused:
+// an unverified binary is never installed.
+func (u *SelfUpdater) Download(ctx context.Context, release *ReleaseAssets, asset string) ([]byte, error) {
+	binaryURL, ok := release.Assets[asset]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for this platform (%s)", release.Version, asset)
+	}
+	checksumsURL, ok := release.Assets[ChecksumsAsset]
+	if !ok {
+		return nil, fmt.Errorf("release %s publishes no %s; download it from %s instead", release.Version, ChecksumsAsset, release.URL)
+	}
+	checksums, err := u.get(ctx, checksumsURL, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+	}
+	binary, err := u.get(ctx, binaryURL, maxBinarySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset, err)
+	}
+	if err := VerifyChecksum(binary, asset, checksums); err != nil {
+		return nil, err
+	}
+	return binary, nil
+}
+
+// VerifyChecksum checks data against the SHA-256 that checksums lists
+// for asset.
+func VerifyChecksum(data []byte, asset string, checksums []byte) error {
+	var want string
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// sha256sum marks binary mode with a leading '*'
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			want = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if want == "" {
+		return fmt.Errorf("%s has no checksum for %s", ChecksumsAsset, asset)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset, want, got)
+	}
+	return nil
+}
+
+// ReplaceExecutable replaces the binary at exe with data. The new binary
+// is written next to it and renamed over it, so an interrupted update
+// leaves the old binary in place. Windows cannot overwrite a running
+// executable, so there the old one is moved aside to exe.old first.
+func ReplaceExecutable(exe string, data []byte) error {
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".trabuco-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", filepath.Dir(exe), err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp.Name(), exe); err != nil {
+			_ = os.Rename(old, exe)
+			return err
+		}
+		return nil
+	}
+	return os.Rename(tmp.Name(), exe)
+}
+
+func (u *SelfUpdater) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "trabuco/"+u.Current)
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", url, limit)
+	}
+	return data, nil
+}
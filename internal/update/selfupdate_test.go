@@ -0,0 +1,164 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newReleaseHost(t *testing.T, binary []byte, checksums string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases/latest", "/releases/tags/v1.3.0":
+			assets := fmt.Sprintf(`{"name": "trabuco-linux-amd64", "browser_download_url": "%s/download/trabuco-linux-amd64"}`, server.URL)
+			if checksums != "" {
+				assets += fmt.Sprintf(`, {"name": "checksums.txt", "browser_download_url": "%s/download/checksums.txt"}`, server.URL)
+			}
+			fmt.Fprintf(w, `{"tag_name": "v1.3.0", "html_url": "https://example.com/v1.3.0", "assets": [%s]}`, assets)
+		case "/download/trabuco-linux-amd64":
+			w.Write(binary)
+		case "/download/checksums.txt":
+			w.Write([]byte(checksums))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestSelfUpdater_Download(t *testing.T) {
+	binary := []byte("new trabuco")
+	checksums := sha256Hex([]byte("other")) + "  trabuco-darwin-arm64\n" + sha256Hex(binary) + "  trabuco-linux-amd64\n"
+	server := newReleaseHost(t, binary, checksums)
+	u := &SelfUpdater{Current: "v1.2.0", ReleaseAPIURL: server.URL + "/releases", Client: server.Client()}
+
+	release, err := u.Release(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release.Version != "v1.3.0" || !u.Newer(release) {
+		t.Fatalf("expected v1.3.0 to be newer than v1.2.0, got %+v", release)
+	}
+	if _, err := u.Release(context.Background(), "1.3.0"); err != nil {
+		t.Errorf("expected the tagged release to be found: %v", err)
+	}
+
+	data, err := u.Download(context.Background(), release, "trabuco-linux-amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(binary) {
+		t.Errorf("unexpected binary: %q", data)
+	}
+	if _, err := u.Download(context.Background(), release, "trabuco-windows-amd64.exe"); err == nil {
+		t.Error("expected an error for a platform the release has no binary for")
+	}
+}
+
+func TestSelfUpdater_DownloadRefusesUnverifiedBinary(t *testing.T) {
+	binary := []byte("tampered trabuco")
+	mismatch := newReleaseHost(t, binary, sha256Hex([]byte("new trabuco"))+"  trabuco-linux-amd64\n")
+	u := &SelfUpdater{Current: "v1.2.0", ReleaseAPIURL: mismatch.URL + "/releases", Client: mismatch.Client()}
+	release, err := u.Release(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Download(context.Background(), release, "trabuco-linux-amd64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+
+	unsigned := newReleaseHost(t, binary, "")
+	u.ReleaseAPIURL = unsigned.URL + "/releases"
+	u.Client = unsigned.Client()
+	release, err = u.Release(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Download(context.Background(), release, "trabuco-linux-amd64"); err == nil || !strings.Contains(err.Error(), ChecksumsAsset) {
+		t.Errorf("expected a release without checksums to be refused, got %v", err)
+	}
+}
+
+func TestSelfUpdater_Newer(t *testing.T) {
+	release := &ReleaseAssets{Version: "v1.3.0"}
+	for current, want := range map[string]bool{"v1.2.0": true, "v1.3.0": false, "v1.4.0": false, "dev": true} {
+		u := &SelfUpdater{Current: current}
+		if got := u.Newer(release); got != want {
+			t.Errorf("Newer with %s = %v, want %v", current, got, want)
+		}
+	}
+}
+
+func TestDetectInstaller(t *testing.T) {
+	cases := map[string]string{
+		"/opt/homebrew/Cellar/trabuco/1.2.0/bin/trabuco":                     "Homebrew",
+		"/home/linuxbrew/.linuxbrew/bin/trabuco":                             "Homebrew",
+		`C:\Users\me\scoop\apps\trabuco\current\trabuco.exe`:                 "Scoop",
+		"/usr/local/lib/node_modules/trabuco-mcp/bin/trabuco":                "npm",
+		"/usr/local/bin/trabuco":                                             "",
+		filepath.Join(os.TempDir(), "go", "bin", "trabuco"):                  "",
+		"/home/me/.local/bin/trabuco":                                        "",
+		"/Users/me/Library/Application Support/something/Cellar-ish/trabuco": "",
+	}
+	for exe, want := range cases {
+		got := ""
+		if i := DetectInstaller(exe); i != nil {
+			got = i.Name
+		}
+		if got != want {
+			t.Errorf("DetectInstaller(%s) = %q, want %q", exe, got, want)
+		}
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "trabuco")
+	if err := os.WriteFile(exe, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReplaceExecutable(exe, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Errorf("expected the binary to be replaced, got %q", data)
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("expected the new binary to be executable, got %v", info.Mode())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	if len(entries) != 1 {
+		t.Errorf("expected no leftover temporary file, got %d entries", len(entries))
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	if got := AssetName("linux", "arm64"); got != "trabuco-linux-arm64" {
+		t.Errorf("unexpected asset: %s", got)
+	}
+	if got := AssetName("windows", "amd64"); got != "trabuco-windows-amd64.exe" {
+		t.Errorf("unexpected asset: %s", got)
+	}
+}
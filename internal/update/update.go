@@ -0,0 +1,400 @@
+// Package update checks, at most once a day, whether a newer Trabuco
+// release or a newer version of an installed template pack is available.
+//
+// Results are cached in ~/.trabuco/update-check.json. A check that fails —
+// offline, rate limited, a pack host down — never surfaces an error to the
+// user: the last known result stays in use and the next attempt waits for
+// the following day.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/plugin"
+	"github.com/arianlopezc/Trabuco/internal/versions"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ReleasesURL lists Trabuco's GitHub releases, newest first.
+	ReleasesURL = "https://api.github.com/repos/arianlopezc/Trabuco/releases?per_page=20"
+
+	// CheckInterval is how long a check result is reused.
+	CheckInterval = 24 * time.Hour
+
+	// DisableEnv turns the check off when set to any non-empty value.
+	DisableEnv = "TRABUCO_NO_UPDATE_CHECK"
+)
+
+// Release is a published Trabuco release.
+type Release struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+	// Modules are the module names the release notes mention.
+	Modules []string `json:"modules,omitempty"`
+}
+
+// Pack is an installed template pack that publishes its latest manifest at
+// UpdateURL.
+type Pack struct {
+	Name      string
+	Version   string
+	UpdateURL string
+}
+
+// PackUpdate is a template pack with a newer published version.
+type PackUpdate struct {
+	Name    string `json:"name"`
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+}
+
+// Status is the outcome of a check for one project.
+type Status struct {
+	Current         string       `json:"current"`
+	Latest          string       `json:"latest,omitempty"`
+	UpdateAvailable bool         `json:"update_available"`
+	ReleaseURL      string       `json:"release_url,omitempty"`
+	AffectedModules []string     `json:"affected_modules,omitempty"`
+	Packs           []PackUpdate `json:"pack_updates,omitempty"`
+	CheckedAt       time.Time    `json:"checked_at,omitzero"`
+	// Error is the reason the last attempt failed; the other fields then
+	// come from the last successful check.
+	Error string `json:"error,omitempty"`
+}
+
+// cacheFile is the content of update-check.json.
+type cacheFile struct {
+	AttemptedAt time.Time         `json:"attempted_at"`
+	CheckedAt   time.Time         `json:"checked_at,omitzero"`
+	Error       string            `json:"error,omitempty"`
+	Releases    []Release         `json:"releases"`
+	Packs       map[string]string `json:"packs,omitempty"` // update URL → latest version
+}
+
+// Checker checks for updates. The zero value is not usable; see NewChecker.
+type Checker struct {
+	Current     string
+	ReleasesURL string
+	CachePath   string
+	// ModuleNames are the names looked for in release notes.
+	ModuleNames []string
+	Client      *http.Client
+	Now         func() time.Time
+}
+
+// NewChecker returns a checker for the running version against GitHub
+// releases, caching in ~/.trabuco/update-check.json and looking for every
+// registered module, template packs included, in release notes.
+func NewChecker(current string) *Checker {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	var names []string
+	for _, m := range config.ModuleRegistry {
+		names = append(names, m.Name)
+	}
+	return &Checker{
+		Current:     current,
+		ReleasesURL: ReleasesURL,
+		CachePath:   filepath.Join(home, ".trabuco", "update-check.json"),
+		ModuleNames: names,
+		Client:      &http.Client{Timeout: 3 * time.Second},
+		Now:         time.Now,
+	}
+}
+
+// Scope returns the modules and the template packs with an update URL that
+// matter for the project at projectPath. Outside a project every installed
+// pack is checked and no module is.
+func Scope(projectPath string) ([]string, []Pack) {
+	var modules []string
+	if meta, err := config.LoadMetadata(projectPath); err == nil {
+		modules = meta.Modules
+	}
+	installed, _ := plugin.List()
+	var packs []Pack
+	for _, p := range installed {
+		m := p.Manifest
+		if m.UpdateURL == "" || modules != nil && !slices.Contains(modules, m.Name) {
+			continue
+		}
+		packs = append(packs, Pack{Name: m.Name, Version: m.Version, UpdateURL: m.UpdateURL})
+	}
+	return modules, packs
+}
+
+// Disabled reports whether the environment opts out of update checks:
+// TRABUCO_NO_UPDATE_CHECK is set, or the process runs in CI.
+func Disabled() bool {
+	return os.Getenv(DisableEnv) != "" || os.Getenv("CI") != ""
+}
+
+// IsRelease reports whether version is a tagged release. Development
+// builds ("dev", or a version with a commit suffix) are never checked.
+func IsRelease(version string) bool {
+	return releaseRegex.MatchString(version)
+}
+
+var releaseRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+
+// Check returns the update status for a project using modules and packs.
+// The cached result is reused while younger than CheckInterval unless
+// force is set. Network failures are reported in Status.Error, never as
+// an error; the error return is for an unusable checker.
+func (c *Checker) Check(ctx context.Context, modules []string, packs []Pack, force bool) (*Status, error) {
+	if c.Current == "" {
+		return nil, fmt.Errorf("current version is unknown")
+	}
+	cache := c.readCache()
+	if force || c.Now().Sub(cache.AttemptedAt) >= CheckInterval || !c.covers(cache, packs) {
+		cache = c.refresh(ctx, cache, packs)
+		c.writeCache(cache)
+	}
+	return c.status(cache, modules, packs), nil
+}
+
+// Cached returns the status from the cache alone, without any network
+// call, or nil when nothing is cached.
+func (c *Checker) Cached(modules []string, packs []Pack) *Status {
+	cache := c.readCache()
+	if cache.AttemptedAt.IsZero() {
+		return nil
+	}
+	return c.status(cache, modules, packs)
+}
+
+// Due reports whether the cached result is older than CheckInterval.
+func (c *Checker) Due() bool {
+	return c.Now().Sub(c.readCache().AttemptedAt) >= CheckInterval
+}
+
+// covers reports whether every pack's update URL has been checked before.
+func (c *Checker) covers(cache cacheFile, packs []Pack) bool {
+	for _, p := range packs {
+		if _, ok := cache.Packs[p.UpdateURL]; !ok && p.UpdateURL != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Checker) refresh(ctx context.Context, cache cacheFile, packs []Pack) cacheFile {
+	// Record the attempt first: a check cut short by the command exiting
+	// still waits a day before the next one.
+	cache.AttemptedAt = c.Now()
+	c.writeCache(cache)
+	var errs []string
+
+	releases, err := c.fetchReleases(ctx)
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		cache.Releases = releases
+	}
+
+	if cache.Packs == nil {
+		cache.Packs = map[string]string{}
+	}
+	for _, p := range packs {
+		if p.UpdateURL == "" {
+			continue
+		}
+		latest, err := c.fetchPackVersion(ctx, p.UpdateURL)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("pack %s: %v", p.Name, err))
+			if _, ok := cache.Packs[p.UpdateURL]; !ok {
+				cache.Packs[p.UpdateURL] = "" // attempted; keeps covers from retrying before the interval
+			}
+			continue
+		}
+		cache.Packs[p.UpdateURL] = latest
+	}
+
+	cache.Error = strings.Join(errs, "; ")
+	if len(errs) == 0 {
+		cache.CheckedAt = cache.AttemptedAt
+	}
+	return cache
+}
+
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	HTMLURL    string `json:"html_url"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// fetchReleases returns every published, non-prerelease release.
+func (c *Checker) fetchReleases(ctx context.Context) ([]Release, error) {
+	var published []githubRelease
+	if err := c.getJSON(ctx, c.ReleasesURL, &published); err != nil {
+		return nil, fmt.Errorf("releases: %w", err)
+	}
+	var releases []Release
+	for _, r := range published {
+		if r.Draft || r.Prerelease || !IsRelease(r.TagName) {
+			continue
+		}
+		releases = append(releases, Release{
+			Version: r.TagName,
+			URL:     r.HTMLURL,
+			Modules: mentionedModules(r.Body, c.ModuleNames),
+		})
+	}
+	return releases, nil
+}
+
+// fetchPackVersion reads the version of the pack manifest at url.
+func (c *Checker) fetchPackVersion(ctx context.Context, url string) (string, error) {
+	data, err := c.get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	var manifest struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.Version == "" {
+		return "", fmt.Errorf("manifest has no version")
+	}
+	return manifest.Version, nil
+}
+
+func (c *Checker) getJSON(ctx context.Context, url string, v any) error {
+	data, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (c *Checker) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "trabuco/"+c.Current)
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// mentionedModules returns the names that appear as whole words in notes.
+func mentionedModules(notes string, names []string) []string {
+	var found []string
+	for _, name := range names {
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(notes) {
+			found = append(found, name)
+		}
+	}
+	return found
+}
+
+func (c *Checker) status(cache cacheFile, modules []string, packs []Pack) *Status {
+	s := &Status{Current: c.Current, CheckedAt: cache.CheckedAt, Error: cache.Error}
+	for _, r := range cache.Releases {
+		if compare(r.Version, c.Current) <= 0 {
+			continue
+		}
+		if s.Latest == "" || compare(r.Version, s.Latest) > 0 {
+			s.Latest = r.Version
+			s.ReleaseURL = r.URL
+		}
+		for _, m := range r.Modules {
+			if slices.Contains(modules, m) && !slices.Contains(s.AffectedModules, m) {
+				s.AffectedModules = append(s.AffectedModules, m)
+			}
+		}
+	}
+	s.UpdateAvailable = s.Latest != ""
+	slices.Sort(s.AffectedModules)
+
+	for _, p := range packs {
+		latest := cache.Packs[p.UpdateURL]
+		if latest == "" {
+			continue
+		}
+		if p.Version == "" || compare(latest, p.Version) > 0 {
+			s.Packs = append(s.Packs, PackUpdate{Name: p.Name, Current: p.Version, Latest: latest})
+		}
+	}
+	return s
+}
+
+func compare(a, b string) int {
+	return versions.CompareVersions(strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v"))
+}
+
+// Notice is the one-line message shown after an interactive command, or ""
+// when there is nothing to report.
+func (s *Status) Notice() string {
+	var parts []string
+	if s.UpdateAvailable {
+		msg := fmt.Sprintf("Trabuco %s is available (you have %s)", s.Latest, s.Current)
+		if len(s.AffectedModules) > 0 {
+			msg += "; its release notes mention " + strings.Join(s.AffectedModules, ", ")
+		}
+		parts = append(parts, msg)
+	}
+	for _, p := range s.Packs {
+		current := p.Current
+		if current == "" {
+			current = "unversioned"
+		}
+		parts = append(parts, fmt.Sprintf("template pack %s %s is available (installed: %s)", p.Name, p.Latest, current))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	notice := strings.Join(parts, "; ")
+	if s.ReleaseURL != "" {
+		notice += " — " + s.ReleaseURL
+	}
+	return notice
+}
+
+func (c *Checker) readCache() cacheFile {
+	var cache cacheFile
+	data, err := os.ReadFile(c.CachePath)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return cacheFile{}
+	}
+	return cache
+}
+
+// writeCache stores the cache; a failure only costs a repeated check.
+func (c *Checker) writeCache(cache cacheFile) {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.CachePath), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(c.CachePath, append(data, '\n'), 0o644)
+}
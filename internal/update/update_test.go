@@ -0,0 +1,148 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const releasesJSON = `[
+  {"tag_name": "v1.4.0-rc1", "html_url": "https://example.com/v1.4.0-rc1", "body": "Worker rewrite", "prerelease": true},
+  {"tag_name": "v1.3.0", "html_url": "https://example.com/v1.3.0", "body": "- EventConsumer: Kafka retries\n- APIGateway placeholder"},
+  {"tag_name": "v1.2.1", "html_url": "https://example.com/v1.2.1", "body": "- Fix API validation"},
+  {"tag_name": "v1.2.0", "html_url": "https://example.com/v1.2.0", "body": "- Worker: JobRunr 8"}
+]`
+
+type fakeHost struct {
+	server   *httptest.Server
+	requests atomic.Int32
+}
+
+func newFakeHost(t *testing.T) *fakeHost {
+	t.Helper()
+	h := &fakeHost{}
+	h.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.requests.Add(1)
+		switch r.URL.Path {
+		case "/releases":
+			w.Write([]byte(releasesJSON))
+		case "/packs/auditlog.yaml":
+			w.Write([]byte("name: AuditLog\nversion: 2.1.0\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(h.server.Close)
+	return h
+}
+
+func newTestChecker(t *testing.T, h *fakeHost, now *time.Time) *Checker {
+	t.Helper()
+	return &Checker{
+		Current:     "v1.2.0",
+		ReleasesURL: h.server.URL + "/releases",
+		CachePath:   filepath.Join(t.TempDir(), "update-check.json"),
+		ModuleNames: []string{"API", "Worker", "EventConsumer", "AuditLog"},
+		Client:      h.server.Client(),
+		Now:         func() time.Time { return *now },
+	}
+}
+
+func TestCheck(t *testing.T) {
+	h := newFakeHost(t)
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, h, &now)
+	packs := []Pack{{Name: "AuditLog", Version: "2.0.0", UpdateURL: h.server.URL + "/packs/auditlog.yaml"}}
+
+	s, err := c.Check(context.Background(), []string{"Model", "API", "EventConsumer", "AuditLog"}, packs, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.UpdateAvailable || s.Latest != "v1.3.0" || s.ReleaseURL != "https://example.com/v1.3.0" {
+		t.Errorf("expected v1.3.0 (pre-releases skipped), got %+v", s)
+	}
+	if strings.Join(s.AffectedModules, ",") != "API,EventConsumer" {
+		t.Errorf("expected API and EventConsumer (Worker is not used, v1.2.0 is installed), got %v", s.AffectedModules)
+	}
+	if len(s.Packs) != 1 || s.Packs[0].Latest != "2.1.0" || s.Packs[0].Current != "2.0.0" {
+		t.Errorf("expected the AuditLog pack update, got %+v", s.Packs)
+	}
+	if s.Error != "" || !s.CheckedAt.Equal(now) {
+		t.Errorf("unexpected check state: %+v", s)
+	}
+
+	notice := s.Notice()
+	for _, want := range []string{"Trabuco v1.3.0 is available (you have v1.2.0)", "mention API, EventConsumer", "template pack AuditLog 2.1.0", "https://example.com/v1.3.0"} {
+		if !strings.Contains(notice, want) {
+			t.Errorf("notice %q is missing %q", notice, want)
+		}
+	}
+
+	// Within the interval the cache answers without the network
+	before := h.requests.Load()
+	now = now.Add(time.Hour)
+	if _, err := c.Check(context.Background(), nil, packs, false); err != nil {
+		t.Fatal(err)
+	}
+	if h.requests.Load() != before {
+		t.Error("expected the cached result within the check interval")
+	}
+
+	now = now.Add(CheckInterval)
+	if _, err := c.Check(context.Background(), nil, packs, false); err != nil {
+		t.Fatal(err)
+	}
+	if h.requests.Load() == before {
+		t.Error("expected a new check after the interval")
+	}
+}
+
+func TestCheck_OfflineKeepsLastResult(t *testing.T) {
+	h := newFakeHost(t)
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, h, &now)
+	if _, err := c.Check(context.Background(), []string{"API"}, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	checkedAt := now
+
+	h.server.Close()
+	now = now.Add(2 * CheckInterval)
+	s, err := c.Check(context.Background(), []string{"API"}, nil, false)
+	if err != nil {
+		t.Fatalf("offline checks must not fail: %v", err)
+	}
+	if s.Error == "" || s.Latest != "v1.3.0" || !s.CheckedAt.Equal(checkedAt) {
+		t.Errorf("expected the last result with the failure noted, got %+v", s)
+	}
+	if c.Due() {
+		t.Error("a failed attempt must still wait for the next interval")
+	}
+}
+
+func TestCheck_UpToDate(t *testing.T) {
+	h := newFakeHost(t)
+	now := time.Now()
+	c := newTestChecker(t, h, &now)
+	c.Current = "v1.3.0"
+	s, err := c.Check(context.Background(), []string{"API"}, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.UpdateAvailable || s.Notice() != "" {
+		t.Errorf("expected no update, got %+v (%q)", s, s.Notice())
+	}
+}
+
+func TestIsRelease(t *testing.T) {
+	for v, want := range map[string]bool{"v1.2.3": true, "1.2.3": true, "dev": false, "v1.2.3-4-gabc": false, "": false} {
+		if IsRelease(v) != want {
+			t.Errorf("IsRelease(%q) = %v", v, !want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff_Equal(t *testing.T) {
+	if got := UnifiedDiff("a", "b", "x\ny\n", "x\ny\n"); got != "" {
+		t.Errorf("expected empty diff for equal input, got:\n%s", got)
+	}
+}
+
+func TestUnifiedDiff_SingleHunk(t *testing.T) {
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n"
+	neu := "1\n2\n3\n4\nfive\n6\n7\n8\n"
+	want := `--- a/f
++++ b/f
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+`
+	if got := UnifiedDiff("a/f", "b/f", old, neu); got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiff_SeparateHunks(t *testing.T) {
+	var lines []string
+	for i := 0; i < 20; i++ {
+		lines = append(lines, string(rune('a'+i)))
+	}
+	old := strings.Join(lines, "\n") + "\n"
+	changed := append([]string{}, lines...)
+	changed[1] = "B"
+	changed[18] = "S"
+	neu := strings.Join(changed, "\n") + "\n"
+
+	got := UnifiedDiff("a/f", "b/f", old, neu)
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Fatalf("expected 2 hunks, got %d:\n%s", n, got)
+	}
+	for _, want := range []string{"@@ -1,5 +1,5 @@", "@@ -16,5 +16,5 @@", "-b\n+B\n", "-s\n+S\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("diff missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestUnifiedDiff_InsertOnly(t *testing.T) {
+	got := UnifiedDiff("a/f", "b/f", "", "x\ny\n")
+	want := "--- a/f\n+++ b/f\n@@ -0,0 +1,2 @@\n+x\n+y\n"
+	if got != want {
+		t.Errorf("UnifiedDiff() =\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestUnifiedDiff_Append(t *testing.T) {
+	got := UnifiedDiff("a/f", "b/f", "a\nb\n", "a\nb\nc\n")
+	want := "--- a/f\n+++ b/f\n@@ -1,2 +1,3 @@\n a\n b\n+c\n"
+	if got != want {
+		t.Errorf("UnifiedDiff() =\n%q\nwant:\n%q", got, want)
+	}
+}
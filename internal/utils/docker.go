@@ -1,25 +1,65 @@
 package utils
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 )
 
+// Container runtimes reported by CheckDocker
+const (
+	RuntimeDockerDesktop = "docker-desktop"
+	RuntimeDocker        = "docker" // Docker Engine on Linux (or an unrecognised daemon)
+	RuntimePodman        = "podman"
+	RuntimeColima        = "colima"
+	RuntimeRemote        = "remote" // DOCKER_HOST or the active context points at a tcp:// or ssh:// daemon
+)
+
 // DockerStatus represents the status of Docker on the system
 type DockerStatus struct {
-	Installed bool
-	Running   bool
-	Version   string
-	Error     string
+	Installed  bool
+	Running    bool
+	Version    string
+	Error      string
+	Runtime    string // One of the Runtime* constants ("" when nothing is running)
+	SocketPath string // Host path of the Docker-compatible API socket, if known
+	Host       string // Remote daemon endpoint (tcp:// or ssh://) when Runtime is RuntimeRemote
+
+	// TestcontainersCloud is true when a Testcontainers Cloud token or agent
+	// is configured. Tests then run on cloud workers even without a local
+	// daemon; only docker-compose based local development needs Docker.
+	TestcontainersCloud bool
+}
+
+// CanRunTestcontainers reports whether generated integration tests can run:
+// either a daemon (local or remote) answers or Testcontainers Cloud is set up.
+func (s DockerStatus) CanRunTestcontainers() bool {
+	return s.Running || s.TestcontainersCloud
 }
 
 // CheckDocker verifies that Docker is installed and running
 func CheckDocker() DockerStatus {
+	status := checkDaemon()
+	home, _ := os.UserHomeDir()
+	status.TestcontainersCloud = testcontainersCloudConfigured(os.Getenv("TC_CLOUD_TOKEN"), filepath.Join(home, ".testcontainers.properties"))
+	return status
+}
+
+// checkDaemon probes the Docker-compatible daemon the CLI talks to.
+func checkDaemon() DockerStatus {
 	status := DockerStatus{}
+	dockerHost := os.Getenv("DOCKER_HOST")
 
 	// Check if docker command exists
 	_, err := exec.LookPath("docker")
 	if err != nil {
+		// Podman without the docker shim is still usable: it serves the
+		// Docker API and Testcontainers talks to it through the socket.
+		if _, perr := exec.LookPath("podman"); perr == nil {
+			return checkPodman()
+		}
 		status.Error = "Docker is not installed or not in PATH"
 		return status
 	}
@@ -31,10 +71,12 @@ func CheckDocker() DockerStatus {
 	if err != nil {
 		// Docker is installed but daemon is not running
 		outputStr := string(output)
-		if strings.Contains(outputStr, "Cannot connect") ||
+		if isRemoteHost(dockerHost) {
+			status.Error = "Remote Docker host " + dockerHost + " is not reachable. Check DOCKER_HOST (and DOCKER_TLS_VERIFY / DOCKER_CERT_PATH for TLS)."
+		} else if strings.Contains(outputStr, "Cannot connect") ||
 			strings.Contains(outputStr, "Is the docker daemon running") ||
 			strings.Contains(outputStr, "permission denied") {
-			status.Error = "Docker daemon is not running. Please start Docker Desktop or the Docker service."
+			status.Error = "Docker daemon is not running. Please start Docker Desktop, Colima (colima start), Podman (podman machine start) or the Docker service."
 		} else {
 			status.Error = "Docker daemon is not accessible: " + err.Error()
 		}
@@ -49,9 +91,174 @@ func CheckDocker() DockerStatus {
 		status.Version = strings.TrimSpace(string(versionOutput))
 	}
 
+	// Identify the runtime behind the docker CLI and where its socket lives
+	contextHost := dockerContextHost()
+	status.Runtime = detectRuntime(dockerHost, contextHost, string(output))
+	if status.Runtime == RuntimeRemote {
+		status.Host = dockerHost
+		if !isRemoteHost(dockerHost) {
+			status.Host = contextHost
+		}
+		return status
+	}
+	status.SocketPath = socketFromHost(dockerHost)
+	if status.SocketPath == "" {
+		status.SocketPath = socketFromHost(contextHost)
+	}
+	if status.SocketPath == "" {
+		status.SocketPath = findSocket(status.Runtime)
+	}
+
+	return status
+}
+
+// checkPodman reports a Podman installation that has no docker CLI shim.
+func checkPodman() DockerStatus {
+	status := DockerStatus{Installed: true, Runtime: RuntimePodman}
+
+	if out, err := exec.Command("podman", "info").CombinedOutput(); err != nil {
+		if runtime.GOOS == "linux" {
+			status.Error = "Podman is installed but not responding: " + strings.TrimSpace(string(out))
+		} else {
+			status.Error = "Podman machine is not running. Start it with 'podman machine start'."
+		}
+		return status
+	}
+	status.Running = true
+
+	if out, err := exec.Command("podman", "--version").Output(); err == nil {
+		status.Version = strings.TrimSpace(string(out))
+	}
+	status.SocketPath = podmanMachineSocket()
+	if status.SocketPath == "" {
+		status.SocketPath = findSocket(RuntimePodman)
+	}
 	return status
 }
 
+// dockerContextHost returns the endpoint of the active docker context
+// (Colima and Podman Desktop register their own contexts).
+func dockerContextHost() string {
+	out, err := exec.Command("docker", "context", "inspect", "--format", "{{.Endpoints.docker.Host}}").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// podmanMachineSocket asks podman for the API socket of the default machine.
+func podmanMachineSocket() string {
+	out, err := exec.Command("podman", "machine", "inspect", "--format", "{{.ConnectionInfo.PodmanSocket.Path}}").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// detectRuntime classifies the daemon from DOCKER_HOST, the active docker
+// context endpoint and the 'docker info' output, in that order of precedence.
+func detectRuntime(dockerHost, contextHost, info string) string {
+	for _, host := range []string{dockerHost, contextHost} {
+		h := strings.ToLower(host)
+		switch {
+		case strings.Contains(h, "colima") || strings.Contains(h, "/.lima/"):
+			return RuntimeColima
+		case strings.Contains(h, "podman"):
+			return RuntimePodman
+		case isRemoteHost(h):
+			return RuntimeRemote
+		}
+	}
+
+	lower := strings.ToLower(info)
+	switch {
+	case strings.Contains(lower, "podman"):
+		return RuntimePodman
+	case strings.Contains(lower, "colima"):
+		return RuntimeColima
+	case strings.Contains(lower, "docker desktop"):
+		return RuntimeDockerDesktop
+	}
+	return RuntimeDocker
+}
+
+// isRemoteHost reports whether a DOCKER_HOST value points at a daemon reached
+// over the network rather than a local socket or named pipe.
+func isRemoteHost(host string) bool {
+	h := strings.ToLower(host)
+	return strings.HasPrefix(h, "tcp://") || strings.HasPrefix(h, "ssh://")
+}
+
+// testcontainersCloudConfigured reports whether Testcontainers will use
+// Testcontainers Cloud: a TC_CLOUD_TOKEN in the environment, or a tc.host /
+// cloud token entry written by the Testcontainers Desktop agent.
+func testcontainersCloudConfigured(token, propertiesFile string) bool {
+	if strings.TrimSpace(token) != "" {
+		return true
+	}
+	data, err := os.ReadFile(propertiesFile)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "tc.host") || strings.HasPrefix(line, "cloud.token") {
+			return true
+		}
+	}
+	return false
+}
+
+// socketFromHost extracts the filesystem path from a unix:// endpoint.
+// TCP and SSH endpoints have no local socket and yield "".
+func socketFromHost(host string) string {
+	if strings.HasPrefix(host, "unix://") {
+		return strings.TrimPrefix(host, "unix://")
+	}
+	return ""
+}
+
+// candidateSockets lists the well-known socket locations for a runtime,
+// most specific first.
+func candidateSockets(rt, home, xdgRuntimeDir string) []string {
+	var paths []string
+	switch rt {
+	case RuntimeColima:
+		paths = append(paths,
+			filepath.Join(home, ".colima", "default", "docker.sock"),
+			filepath.Join(home, ".colima", "docker.sock"),
+		)
+		if matches, _ := filepath.Glob(filepath.Join(home, ".lima", "*", "sock", "docker.sock")); len(matches) > 0 {
+			paths = append(paths, matches...)
+		}
+	case RuntimePodman:
+		if xdgRuntimeDir != "" {
+			paths = append(paths, filepath.Join(xdgRuntimeDir, "podman", "podman.sock"))
+		}
+		paths = append(paths, "/run/podman/podman.sock")
+	case RuntimeDockerDesktop:
+		paths = append(paths, filepath.Join(home, ".docker", "run", "docker.sock"))
+		if runtime.GOOS == "linux" {
+			paths = append(paths, filepath.Join(home, ".docker", "desktop", "docker.sock"))
+		}
+	}
+	return append(paths, "/var/run/docker.sock")
+}
+
+// findSocket returns the first existing candidate socket for a runtime.
+func findSocket(rt string) string {
+	home, _ := os.UserHomeDir()
+	for _, p := range candidateSockets(rt, home, os.Getenv("XDG_RUNTIME_DIR")) {
+		if info, err := os.Stat(p); err == nil && info.Mode()&os.ModeSocket != 0 {
+			return p
+		}
+	}
+	return ""
+}
+
 // IsDockerReady returns true if Docker is installed and running
 func IsDockerReady() bool {
 	status := CheckDocker()
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectRuntime(t *testing.T) {
+	cases := []struct {
+		name, dockerHost, contextHost, info, want string
+	}{
+		{"docker host colima", "unix:///Users/dev/.colima/default/docker.sock", "", "", RuntimeColima},
+		{"docker host lima", "unix:///Users/dev/.lima/docker/sock/docker.sock", "", "", RuntimeColima},
+		{"docker host podman", "unix:///run/user/1000/podman/podman.sock", "", "", RuntimePodman},
+		{"context colima", "", "unix:///Users/dev/.colima/default/docker.sock", "", RuntimeColima},
+		{"env wins over context", "unix:///run/podman/podman.sock", "unix:///Users/dev/.colima/docker.sock", "", RuntimePodman},
+		{"info podman", "", "unix:///var/run/docker.sock", " Operating System: fedora\n Name: podman-machine-default\n", RuntimePodman},
+		{"info colima", "", "", " Operating System: Ubuntu 24.04 LTS\n Name: colima\n", RuntimeColima},
+		{"info docker desktop", "", "", " Operating System: Docker Desktop\n", RuntimeDockerDesktop},
+		{"plain engine", "", "unix:///var/run/docker.sock", " Operating System: Ubuntu 22.04\n", RuntimeDocker},
+		{"remote tcp", "tcp://docker.internal:2376", "", " Operating System: Ubuntu 22.04\n", RuntimeRemote},
+		{"remote ssh context", "", "ssh://core@build-host", "", RuntimeRemote},
+	}
+	for _, tc := range cases {
+		if got := detectRuntime(tc.dockerHost, tc.contextHost, tc.info); got != tc.want {
+			t.Errorf("%s: detectRuntime() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSocketFromHost(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"unix:///var/run/docker.sock", "/var/run/docker.sock"},
+		{"tcp://localhost:2375", ""},
+		{"ssh://core@localhost:50022", ""},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		if got := socketFromHost(tc.in); got != tc.want {
+			t.Errorf("socketFromHost(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestCandidateSockets(t *testing.T) {
+	home := "/home/dev"
+	colima := candidateSockets(RuntimeColima, home, "")
+	if colima[0] != filepath.Join(home, ".colima", "default", "docker.sock") {
+		t.Errorf("colima should prefer the default profile socket, got %v", colima)
+	}
+	podman := candidateSockets(RuntimePodman, home, "/run/user/1000")
+	if podman[0] != "/run/user/1000/podman/podman.sock" {
+		t.Errorf("podman should prefer the rootless socket, got %v", podman)
+	}
+	for _, rt := range []string{RuntimeColima, RuntimePodman, RuntimeDockerDesktop, RuntimeDocker} {
+		paths := candidateSockets(rt, home, "")
+		if paths[len(paths)-1] != "/var/run/docker.sock" {
+			t.Errorf("%s: /var/run/docker.sock should be the last resort, got %v", rt, paths)
+		}
+	}
+}
+
+func TestTestcontainersCloudConfigured(t *testing.T) {
+	dir := t.TempDir()
+	props := filepath.Join(dir, ".testcontainers.properties")
+
+	if !testcontainersCloudConfigured("tcc_token", props) {
+		t.Error("TC_CLOUD_TOKEN should enable Testcontainers Cloud")
+	}
+	if testcontainersCloudConfigured("", props) {
+		t.Error("missing token and properties file should not enable Testcontainers Cloud")
+	}
+
+	os.WriteFile(props, []byte("# tc.host=tcp://127.0.0.1:1234\ndocker.client.strategy=foo\n"), 0644)
+	if testcontainersCloudConfigured("", props) {
+		t.Error("commented tc.host should be ignored")
+	}
+
+	os.WriteFile(props, []byte("tc.host=tcp://127.0.0.1:53412\n"), 0644)
+	if !testcontainersCloudConfigured("", props) {
+		t.Error("tc.host written by Testcontainers Desktop should enable Testcontainers Cloud")
+	}
+}
+
+func TestCanRunTestcontainers(t *testing.T) {
+	if !(DockerStatus{Running: true}).CanRunTestcontainers() {
+		t.Error("running daemon should be enough")
+	}
+	if !(DockerStatus{TestcontainersCloud: true}).CanRunTestcontainers() {
+		t.Error("Testcontainers Cloud should be enough")
+	}
+	if (DockerStatus{Installed: true}).CanRunTestcontainers() {
+		t.Error("installed but stopped daemon should not be enough")
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -8,13 +9,21 @@ import (
 
 // RunMavenBuild executes 'mvn clean install -DskipTests' in the given directory
 func RunMavenBuild(projectDir string) error {
-	cmd := exec.Command("mvn", "clean", "install", "-DskipTests", "-q")
+	return RunMavenBuildContext(context.Background(), projectDir)
+}
+
+// RunMavenBuildContext is RunMavenBuild killing Maven once ctx is done
+func RunMavenBuildContext(ctx context.Context, projectDir string) error {
+	cmd := exec.CommandContext(ctx, "mvn", "clean", "install", "-DskipTests", "-q")
 	cmd.Dir = projectDir
 
 	// Capture output for error reporting
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if len(output) > 0 {
 			// Return last 20 lines of output
 			lines := strings.Split(string(output), "\n")
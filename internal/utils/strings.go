@@ -1,5 +1,7 @@
 package utils
 
+import "strings"
+
 // ToPascalCase converts kebab-case or snake_case to PascalCase
 func ToPascalCase(s string) string {
 	result := ""
@@ -143,6 +145,24 @@ func PluralLowerSnake(s string) string {
 	return snake + "s"
 }
 
+// SingularPascal converts a plural kebab-case or snake_case resource name
+// into a PascalCase type name, reversing the rules of PluralLowerSnake
+// (order-items → OrderItem, currencies → Currency, boxes → Box). Only the
+// last word is singularized, and singular words ending in a single s
+// ("status") lose it; callers let the user override the result.
+func SingularPascal(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies") && len(s) > 3 && !isVowel(s[len(s)-4]):
+		s = s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "sses"), strings.HasSuffix(s, "xes"), strings.HasSuffix(s, "zes"),
+		strings.HasSuffix(s, "ches"), strings.HasSuffix(s, "shes"):
+		s = s[:len(s)-2]
+	case strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss"):
+		s = s[:len(s)-1]
+	}
+	return ToPascalCase(s)
+}
+
 func isVowel(c byte) bool {
 	switch c {
 	case 'a', 'e', 'i', 'o', 'u':
@@ -44,6 +44,27 @@ func TestPluralLowerSnake(t *testing.T) {
 	}
 }
 
+func TestSingularPascal(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"orders", "Order"},
+		{"order-items", "OrderItem"},
+		{"line_items", "LineItem"},
+		{"currencies", "Currency"},
+		{"days", "Day"},
+		{"boxes", "Box"},
+		{"branches", "Branch"},
+		{"addresses", "Address"},
+		{"access", "Access"},
+		{"health", "Health"},
+	}
+	for _, tc := range cases {
+		got := SingularPascal(tc.in)
+		if got != tc.want {
+			t.Errorf("SingularPascal(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
 func TestToPascalCase(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,36 @@
+//go:build !windows
+
+package verify
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// setProcessGroup starts the command in its own process group, so that
+// stopping it also stops the JVM mvn forks, and so that Ctrl-C reaches
+// verify only, which then tears the group down itself.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// stopProcess sends SIGTERM to the command's process group, the signal
+// Spring Boot's graceful shutdown listens for, and SIGKILL when the group
+// has not exited after stopGracePeriod.
+func stopProcess(cmd *exec.Cmd, done <-chan struct{}) error {
+	pgid := -cmd.Process.Pid
+	if err := syscall.Kill(pgid, syscall.SIGTERM); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	select {
+	case <-done:
+		return nil
+	case <-time.After(stopGracePeriod):
+	}
+	if err := syscall.Kill(pgid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	<-done
+	return nil
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package verify
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// setProcessGroup starts the command in a new process group, so that
+// Ctrl-C reaches verify only, which then tears the process tree down itself.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// stopProcess kills the command's process tree, mvn and the JVM it forked;
+// Windows has no SIGTERM to deliver to a console process.
+func stopProcess(cmd *exec.Cmd, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+	if err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run(); err != nil {
+		return err
+	}
+	select {
+	case <-done:
+	case <-time.After(stopGracePeriod):
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Status is the outcome of one verification step.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	// StatusSkip marks a step that did not apply or could not run because an
+	// earlier step failed
+	StatusSkip Status = "skip"
+)
+
+// Step is the result of one verification step.
+type Step struct {
+	Name       string   `json:"name"`
+	Status     Status   `json:"status"`
+	Message    string   `json:"message,omitempty"`
+	Details    []string `json:"details,omitempty"`
+	DurationMs int64    `json:"durationMs"`
+}
+
+// Report is the structured result of a verification run.
+type Report struct {
+	Project    string `json:"project"`
+	Location   string `json:"location"`
+	Passed     bool   `json:"passed"`
+	Steps      []Step `json:"steps"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// failed reports whether any step so far failed.
+func (r *Report) failed() bool {
+	for _, s := range r.Steps {
+		if s.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// ToJSON serializes the report to JSON.
+func (r *Report) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// PrintSummary prints the report to stdout.
+func (r *Report) PrintSummary() {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	gray := color.New(color.FgHiBlack)
+	bold := color.New(color.Bold)
+	cyan := color.New(color.FgCyan)
+
+	fmt.Println()
+	bold.Println("Trabuco Project Verification")
+	fmt.Println(strings.Repeat("━", 28))
+	fmt.Println()
+	cyan.Printf("Project: ")
+	fmt.Println(r.Project)
+	cyan.Printf("Location: ")
+	fmt.Println(r.Location)
+	fmt.Println()
+
+	for _, step := range r.Steps {
+		switch step.Status {
+		case StatusPass:
+			green.Printf("  ✓ ")
+		case StatusFail:
+			red.Printf("  ✗ ")
+		default:
+			gray.Printf("  - ")
+		}
+		if step.Status == StatusSkip {
+			fmt.Println(step.Name)
+		} else {
+			fmt.Printf("%s %s\n", step.Name, gray.Sprintf("(%.1fs)", float64(step.DurationMs)/1000))
+		}
+		if step.Message != "" {
+			fmt.Printf("      %s\n", step.Message)
+		}
+		if step.Status == StatusFail {
+			for _, detail := range step.Details {
+				fmt.Printf("      %s\n", detail)
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("━", 28))
+	if r.Passed {
+		green.Printf("Status: ")
+		green.Println("PASSED")
+	} else {
+		red.Printf("Status: ")
+		red.Println("FAILED")
+	}
+	fmt.Printf("Finished in %.1fs\n", float64(r.DurationMs)/1000)
+}
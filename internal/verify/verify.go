@@ -0,0 +1,559 @@
+// Package verify proves a generated project boots (`trabuco verify`). It
+// starts the docker-compose services the modules need, launches the API
+// and optionally Worker and EventConsumer, waits for their health
+// endpoints, sends a request through PlaceholderController and tears it
+// all down again, reporting each step as passed, failed or skipped.
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+	"github.com/arianlopezc/Trabuco/internal/doctor"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout is how long a launched module gets to report healthy.
+const DefaultTimeout = 3 * time.Minute
+
+// stopGracePeriod is how long a module gets to shut down gracefully
+// before it is killed.
+const stopGracePeriod = 30 * time.Second
+
+// Options configures Run.
+type Options struct {
+	With      []string      // modules to launch besides API: Worker, EventConsumer
+	UseJars   bool          // run the built jars instead of mvn spring-boot:run
+	SkipBuild bool          // reuse the existing build
+	Timeout   time.Duration // per module; DefaultTimeout when zero
+	Progress  io.Writer     // receives a line as each step starts; nil for none
+}
+
+// app is a module verify launches.
+type app struct {
+	module  string
+	port    int
+	logPath string
+	stop    func() error
+	done    <-chan struct{} // closed when the process exits
+}
+
+type verifier struct {
+	projectPath string
+	meta        *config.ProjectMetadata
+	opts        Options
+	report      *Report
+
+	// services verify started, for teardown; downAll when nothing of the
+	// project's stack was running before
+	started []string
+	downAll bool
+
+	// Overridable in tests
+	compose func(projectPath string, args ...string) ([]byte, error)
+	running func(projectPath string) ([]string, error)
+	maven   func(projectPath string, args ...string) ([]byte, error)
+	launch  func(a *app) error
+	client  *http.Client
+}
+
+// Run verifies the project at projectPath. It returns an error when the
+// project cannot be verified at all; a failing step is recorded in the
+// report instead. Whatever was started is torn down before Run returns,
+// also when ctx is cancelled.
+func Run(ctx context.Context, projectPath string, opts Options) (*Report, error) {
+	abs, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := doctor.DetectProject(abs)
+	if err != nil {
+		return nil, err
+	}
+	v := &verifier{
+		projectPath: abs,
+		meta:        meta,
+		opts:        opts,
+		compose:     doctor.RunCompose,
+		running:     doctor.RunningComposeServices,
+		maven:       runMaven,
+		client:      &http.Client{Timeout: 5 * time.Second},
+	}
+	v.launch = v.launchProcess
+	return v.run(ctx)
+}
+
+func (v *verifier) run(ctx context.Context) (*Report, error) {
+	apps, err := v.plan()
+	if err != nil {
+		return nil, err
+	}
+	if v.opts.Timeout <= 0 {
+		v.opts.Timeout = DefaultTimeout
+	}
+	start := time.Now()
+	v.report = &Report{Project: v.meta.ProjectName, Location: v.projectPath}
+
+	ok := v.step("Start docker-compose services", v.startServices)
+	switch {
+	case v.opts.SkipBuild:
+		v.skip("Build", "Skipped with --skip-build")
+	case ok:
+		ok = v.step("Build", v.build)
+	default:
+		v.skip("Build", "Skipped after an earlier failure")
+	}
+
+	var launched []*app
+	for _, a := range apps {
+		name := "Start " + a.module
+		if !ok {
+			v.skip(name, "Skipped after an earlier failure")
+			continue
+		}
+		ok = v.step(name, func() Step { return v.startApp(ctx, a) })
+		if a.stop != nil {
+			launched = append(launched, a)
+		}
+	}
+
+	const exercise = "Request PlaceholderController"
+	switch {
+	case apps[0].module != config.ModuleAPI:
+		v.skip(exercise, "The API module is not part of this run")
+	case ok:
+		v.step(exercise, func() Step { return v.exercise(apps[0]) })
+	default:
+		v.skip(exercise, "Skipped after an earlier failure")
+	}
+
+	v.step("Tear down", func() Step { return v.teardown(launched) })
+
+	v.report.Passed = !v.report.failed()
+	v.report.DurationMs = time.Since(start).Milliseconds()
+	return v.report, nil
+}
+
+// plan returns the modules to launch, API first, with their ports.
+func (v *verifier) plan() ([]*app, error) {
+	var modules []string
+	if v.meta.HasModule(config.ModuleAPI) {
+		modules = append(modules, config.ModuleAPI)
+	}
+	for _, m := range v.opts.With {
+		m = strings.TrimSpace(m)
+		if m == config.ModuleAPI {
+			continue
+		}
+		if m != config.ModuleWorker && m != config.ModuleEventConsumer {
+			return nil, fmt.Errorf("--with accepts %s and %s, got %q", config.ModuleWorker, config.ModuleEventConsumer, m)
+		}
+		if !v.meta.HasModule(m) {
+			return nil, fmt.Errorf("the project has no %s module", m)
+		}
+		if !slices.Contains(modules, m) {
+			modules = append(modules, m)
+		}
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("the project has no API module; use --with %s or --with %s to verify those", config.ModuleWorker, config.ModuleEventConsumer)
+	}
+
+	defaults := map[string]int{}
+	for _, t := range v.meta.ToProjectConfig().RuntimeTargets() {
+		defaults[t.Module] = t.Port
+	}
+	apps := make([]*app, len(modules))
+	for i, m := range modules {
+		apps[i] = &app{module: m, port: serverPort(v.projectPath, m, defaults[m])}
+	}
+	return apps, nil
+}
+
+// step runs fn as the named step and records its result. It reports
+// whether the step did not fail.
+func (v *verifier) step(name string, fn func() Step) bool {
+	if v.opts.Progress != nil {
+		fmt.Fprintf(v.opts.Progress, "→ %s...\n", name)
+	}
+	start := time.Now()
+	s := fn()
+	s.Name = name
+	s.DurationMs = time.Since(start).Milliseconds()
+	v.report.Steps = append(v.report.Steps, s)
+	return s.Status != StatusFail
+}
+
+func (v *verifier) skip(name, message string) {
+	v.report.Steps = append(v.report.Steps, Step{Name: name, Status: StatusSkip, Message: message})
+}
+
+// startServices starts the required compose services that are not running
+// yet and waits for their healthchecks.
+func (v *verifier) startServices() Step {
+	required := doctor.RequiredRuntimeServices(v.projectPath, v.meta)
+	if len(required) == 0 {
+		return Step{Status: StatusSkip, Message: "No docker-compose services required"}
+	}
+	running, err := v.running(v.projectPath)
+	if err != nil {
+		return Step{
+			Status:  StatusFail,
+			Message: "Could not list docker-compose services",
+			Details: []string{err.Error(), "Is Docker running? See 'trabuco doctor --check=environment'"},
+		}
+	}
+	var missing []string
+	for _, s := range required {
+		if !slices.Contains(running, s) {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) == 0 {
+		return Step{Status: StatusPass, Message: "Already running, left running: " + strings.Join(required, ", ")}
+	}
+
+	v.started = missing
+	v.downAll = len(running) == 0
+	args := append([]string{"up", "-d", "--wait"}, missing...)
+	if _, err := v.compose(v.projectPath, args...); err != nil {
+		return Step{
+			Status:  StatusFail,
+			Message: "docker compose up failed",
+			Details: []string{err.Error(), "Check 'docker compose logs " + missing[0] + "'"},
+		}
+	}
+	return Step{Status: StatusPass, Message: "Started " + strings.Join(missing, ", ")}
+}
+
+// build installs the modules for spring-boot:run, or packages the jars.
+func (v *verifier) build() Step {
+	goal := "install"
+	if v.opts.UseJars {
+		goal = "package"
+	}
+	args := []string{"-B", "-q", "-DskipTests", goal}
+	out, err := v.maven(v.projectPath, args...)
+	if err != nil {
+		return Step{
+			Status:  StatusFail,
+			Message: fmt.Sprintf("mvn %s failed: %v", strings.Join(args, " "), err),
+			Details: lastLines(string(out), 20),
+		}
+	}
+	return Step{Status: StatusPass, Message: "mvn " + strings.Join(args, " ")}
+}
+
+// startApp launches a module and waits until its health endpoint answers
+// 200, the process exits, the timeout passes or ctx is cancelled.
+func (v *verifier) startApp(ctx context.Context, a *app) Step {
+	if err := v.launch(a); err != nil {
+		return Step{Status: StatusFail, Message: "Could not start " + a.module, Details: []string{err.Error()}}
+	}
+	url := fmt.Sprintf("http://localhost:%d/actuator/health", a.port)
+	timeout := time.NewTimer(v.opts.Timeout)
+	defer timeout.Stop()
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+	for {
+		if resp, err := v.client.Get(url); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return Step{Status: StatusPass, Message: "Healthy at " + url}
+			}
+		}
+		select {
+		case <-a.done:
+			return Step{Status: StatusFail, Message: a.module + " exited before it became healthy", Details: v.logTail(a)}
+		case <-timeout.C:
+			return Step{Status: StatusFail, Message: fmt.Sprintf("%s was not healthy after %s", url, v.opts.Timeout), Details: v.logTail(a)}
+		case <-ctx.Done():
+			return Step{Status: StatusFail, Message: "Interrupted"}
+		case <-tick.C:
+		}
+	}
+}
+
+// logTail returns the end of a module's log, pointing at the full log.
+func (v *verifier) logTail(a *app) []string {
+	if a.logPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(a.logPath)
+	if err != nil {
+		return nil
+	}
+	rel, _ := filepath.Rel(v.projectPath, a.logPath)
+	return append(lastLines(string(data), 20), "Full log: "+rel)
+}
+
+// sampleName is the name of the placeholder the sample request creates.
+const sampleName = "trabuco-verify"
+
+// exercise creates, reads and deletes a placeholder through the API.
+// Without a datastore the generated controller answers 501 instead.
+func (v *verifier) exercise(api *app) Step {
+	if !hasPlaceholderController(v.projectPath) {
+		return Step{Status: StatusSkip, Message: "PlaceholderController is no longer in the API module"}
+	}
+	base := fmt.Sprintf("http://localhost:%d/api/placeholders", api.port)
+	body := fmt.Sprintf(`{"name":%q,"description":"Created by trabuco verify"}`, sampleName)
+	status, resp, err := v.request(http.MethodPost, base, body)
+	if err != nil {
+		return Step{Status: StatusFail, Message: "POST /api/placeholders failed", Details: []string{err.Error()}}
+	}
+
+	if !v.meta.HasModule(config.ModuleSQLDatastore) && !v.meta.HasModule(config.ModuleNoSQLDatastore) {
+		if status != http.StatusNotImplemented {
+			return unexpected("POST", status, http.StatusNotImplemented, resp)
+		}
+		return Step{Status: StatusPass, Message: "POST /api/placeholders answered 501, as generated without a datastore"}
+	}
+	if status != http.StatusCreated {
+		return unexpected("POST", status, http.StatusCreated, resp)
+	}
+	var created struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(resp, &created); err != nil || len(created.ID) == 0 {
+		return Step{Status: StatusFail, Message: "POST /api/placeholders did not return an id", Details: []string{snippet(resp)}}
+	}
+	id := strings.Trim(string(created.ID), `"`)
+
+	status, resp, err = v.request(http.MethodGet, base+"/"+id, "")
+	if err != nil {
+		return Step{Status: StatusFail, Message: "GET /api/placeholders/" + id + " failed", Details: []string{err.Error()}}
+	}
+	if status != http.StatusOK {
+		return unexpected("GET", status, http.StatusOK, resp)
+	}
+	var read struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(resp, &read); err != nil || read.Name != sampleName {
+		return Step{Status: StatusFail, Message: "GET /api/placeholders/" + id + " did not return the created placeholder", Details: []string{snippet(resp)}}
+	}
+
+	status, resp, err = v.request(http.MethodDelete, base+"/"+id, "")
+	if err != nil {
+		return Step{Status: StatusFail, Message: "DELETE /api/placeholders/" + id + " failed", Details: []string{err.Error()}}
+	}
+	if status != http.StatusNoContent {
+		return unexpected("DELETE", status, http.StatusNoContent, resp)
+	}
+	return Step{Status: StatusPass, Message: "Created, read and deleted placeholder " + id}
+}
+
+func (v *verifier) request(method, url, body string) (int, []byte, error) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, data, err
+}
+
+func unexpected(method string, got, want int, body []byte) Step {
+	return Step{
+		Status:  StatusFail,
+		Message: fmt.Sprintf("%s /api/placeholders answered %d, expected %d", method, got, want),
+		Details: []string{snippet(body)},
+	}
+}
+
+// snippet shortens a response body for the report.
+func snippet(body []byte) string {
+	s := strings.TrimSpace(string(body))
+	if len(s) > 300 {
+		s = s[:300] + "..."
+	}
+	return s
+}
+
+// teardown stops the launched modules, last first, then the compose
+// services verify started.
+func (v *verifier) teardown(launched []*app) Step {
+	var errs, stopped []string
+	for i := len(launched) - 1; i >= 0; i-- {
+		a := launched[i]
+		if err := a.stop(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", a.module, err))
+		} else {
+			stopped = append(stopped, a.module)
+		}
+	}
+	if len(v.started) > 0 {
+		args := append([]string{"stop"}, v.started...)
+		if v.downAll {
+			args = []string{"down"}
+		}
+		if _, err := v.compose(v.projectPath, args...); err != nil {
+			errs = append(errs, "docker compose "+args[0]+": "+err.Error())
+		} else {
+			stopped = append(stopped, v.started...)
+		}
+	}
+	if len(errs) > 0 {
+		return Step{Status: StatusFail, Message: "Some processes may still be running", Details: errs}
+	}
+	if len(stopped) == 0 {
+		return Step{Status: StatusSkip, Message: "Nothing to stop"}
+	}
+	return Step{Status: StatusPass, Message: "Stopped " + strings.Join(stopped, ", ")}
+}
+
+// launchProcess starts a module with mvn spring-boot:run or from its jar,
+// logging to target/trabuco-verify/<module>.log. Auth is turned off so the
+// sample request needs no token.
+func (v *verifier) launchProcess(a *app) error {
+	if conn, err := net.DialTimeout("tcp", net.JoinHostPort("localhost", strconv.Itoa(a.port)), time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("port %d is already in use; stop whatever listens there first", a.port)
+	}
+
+	var cmd *exec.Cmd
+	if v.opts.UseJars {
+		jar, err := findJar(v.projectPath, a.module)
+		if err != nil {
+			return err
+		}
+		cmd = exec.Command("java", "-jar", jar)
+	} else {
+		cmd = exec.Command(mavenExecutable(v.projectPath), "-B", "-q", "-pl", a.module, "spring-boot:run")
+	}
+	cmd.Dir = v.projectPath
+	cmd.Env = append(os.Environ(), "TRABUCO_AUTH_ENABLED=false")
+
+	a.logPath = filepath.Join(v.projectPath, "target", "trabuco-verify", a.module+".log")
+	if err := os.MkdirAll(filepath.Dir(a.logPath), 0o755); err != nil {
+		return err
+	}
+	log, err := os.Create(a.logPath)
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = log
+	cmd.Stderr = log
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		log.Close()
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		log.Close()
+		close(done)
+	}()
+	a.done = done
+	a.stop = func() error { return stopProcess(cmd, done) }
+	return nil
+}
+
+// mavenExecutable returns the project's Maven wrapper, or mvn when the
+// project has none.
+func mavenExecutable(projectPath string) string {
+	wrapper := "mvnw"
+	if runtime.GOOS == "windows" {
+		wrapper = "mvnw.cmd"
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, wrapper)); err == nil {
+		return filepath.Join(projectPath, wrapper)
+	}
+	return "mvn"
+}
+
+func runMaven(projectPath string, args ...string) ([]byte, error) {
+	cmd := exec.Command(mavenExecutable(projectPath), args...)
+	cmd.Dir = projectPath
+	return cmd.CombinedOutput()
+}
+
+// findJar returns the executable jar the build left in module/target.
+func findJar(projectPath, module string) (string, error) {
+	jars, _ := filepath.Glob(filepath.Join(projectPath, module, "target", "*.jar"))
+	for _, jar := range jars {
+		name := filepath.Base(jar)
+		if !strings.HasSuffix(name, "-sources.jar") && !strings.HasSuffix(name, "-javadoc.jar") &&
+			!strings.HasSuffix(name, "-tests.jar") && !strings.HasSuffix(name, "-plain.jar") {
+			return jar, nil
+		}
+	}
+	return "", fmt.Errorf("no jar in %s/target; build the project or drop --skip-build", module)
+}
+
+// serverPort returns the server.port of a module's application.yml,
+// resolving ${VAR:default} from the environment like Spring does, or
+// fallback, the module's default port.
+func serverPort(projectPath, module string, fallback int) int {
+	data, err := os.ReadFile(filepath.Join(projectPath, module, "src", "main", "resources", "application.yml"))
+	if err != nil {
+		return fallback
+	}
+	var doc struct {
+		Server struct {
+			Port string `yaml:"port"`
+		} `yaml:"server"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fallback
+	}
+	if port, err := strconv.Atoi(doctor.ResolvePlaceholder(doc.Server.Port)); err == nil && port > 0 {
+		return port
+	}
+	return fallback
+}
+
+// hasPlaceholderController reports whether the API module still has the
+// generated PlaceholderController, in Java or Kotlin.
+func hasPlaceholderController(projectPath string) bool {
+	found := false
+	filepath.WalkDir(filepath.Join(projectPath, config.ModuleAPI, "src", "main"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name := d.Name(); name == "PlaceholderController.java" || name == "PlaceholderController.kt" {
+			found = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// lastLines returns the last n non-blank lines of s.
+func lastLines(s string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,226 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arianlopezc/Trabuco/internal/config"
+)
+
+// writeProject writes the files verify reads: docker-compose.yml, the
+// modules' application.yml with the given ports and PlaceholderController.
+func writeProject(t *testing.T, ports map[string]int) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"docker-compose.yml": "services:\n  postgres:\n    image: postgres:16\n    ports:\n      - \"5433:5432\"\n",
+		"API/src/main/java/com/test/verify/api/controller/PlaceholderController.java": "class PlaceholderController {}\n",
+	}
+	for module, port := range ports {
+		files[module+"/src/main/resources/application.yml"] = fmt.Sprintf("server:\n  port: ${SERVER_PORT:%d}\n", port)
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// fakeAPI serves the health endpoint and the placeholder CRUD the
+// generated API answers with.
+func fakeAPI(t *testing.T, healthy bool) (*httptest.Server, int) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /actuator/health", func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"status":"UP"}`)
+	})
+	mux.HandleFunc("POST /api/placeholders", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"42","name":"trabuco-verify"}`)
+	})
+	mux.HandleFunc("GET /api/placeholders/42", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"42","name":"trabuco-verify"}`)
+	})
+	mux.HandleFunc("DELETE /api/placeholders/42", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, server.Listener.Addr().(*net.TCPAddr).Port
+}
+
+// newTestVerifier returns a verifier whose compose, Maven and process
+// hooks only record what they were asked to do.
+func newTestVerifier(projectPath string, meta *config.ProjectMetadata, calls *[]string) *verifier {
+	return &verifier{
+		projectPath: projectPath,
+		meta:        meta,
+		opts:        Options{Timeout: 3 * time.Second},
+		compose: func(_ string, args ...string) ([]byte, error) {
+			*calls = append(*calls, "compose "+strings.Join(args, " "))
+			return nil, nil
+		},
+		running: func(string) ([]string, error) { return nil, nil },
+		maven: func(_ string, args ...string) ([]byte, error) {
+			*calls = append(*calls, "mvn "+strings.Join(args, " "))
+			return nil, nil
+		},
+		launch: func(a *app) error {
+			*calls = append(*calls, "launch "+a.module)
+			a.done = make(chan struct{})
+			a.stop = func() error {
+				*calls = append(*calls, "stop "+a.module)
+				return nil
+			}
+			return nil
+		},
+		client: &http.Client{Timeout: time.Second},
+	}
+}
+
+func stepStatuses(r *Report) string {
+	var parts []string
+	for _, s := range r.Steps {
+		parts = append(parts, s.Name+"="+string(s.Status))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestRun_Passes(t *testing.T) {
+	_, port := fakeAPI(t, true)
+	projectPath := writeProject(t, map[string]int{"API": port})
+	meta := &config.ProjectMetadata{
+		ProjectName: "verify",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    config.DatabasePostgreSQL,
+	}
+	var calls []string
+	report, err := newTestVerifier(projectPath, meta, &calls).run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Passed {
+		t.Fatalf("expected the run to pass: %s\n%+v", stepStatuses(report), report.Steps)
+	}
+	want := "compose up -d --wait postgres|mvn -B -q -DskipTests install|launch API|stop API|compose down"
+	if got := strings.Join(calls, "|"); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if msg := report.Steps[3].Message; msg != "Created, read and deleted placeholder 42" {
+		t.Errorf("unexpected sample request message %q", msg)
+	}
+}
+
+func TestRun_UnhealthyAppIsTornDown(t *testing.T) {
+	_, port := fakeAPI(t, false)
+	projectPath := writeProject(t, map[string]int{"API": port})
+	meta := &config.ProjectMetadata{
+		ProjectName: "verify",
+		Modules:     []string{"Model", "SQLDatastore", "Shared", "API"},
+		Database:    config.DatabasePostgreSQL,
+	}
+	var calls []string
+	v := newTestVerifier(projectPath, meta, &calls)
+	v.opts.Timeout = 1500 * time.Millisecond
+	v.opts.SkipBuild = true
+	v.running = func(string) ([]string, error) { return []string{"redis"}, nil }
+	report, err := v.run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Passed {
+		t.Fatal("expected the run to fail")
+	}
+	want := "Start docker-compose services=pass, Build=skip, Start API=fail, Request PlaceholderController=skip, Tear down=pass"
+	if got := stepStatuses(report); got != want {
+		t.Errorf("steps = %s, want %s", got, want)
+	}
+	// Other compose services were running, so only the started one is stopped
+	if got := strings.Join(calls, "|"); got != "compose up -d --wait postgres|launch API|stop API|compose stop postgres" {
+		t.Errorf("unexpected calls %s", got)
+	}
+}
+
+func TestRun_WithoutDatastoreExpects501(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /actuator/health", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("POST /api/placeholders", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Datastore module required", http.StatusNotImplemented)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	projectPath := writeProject(t, map[string]int{"API": server.Listener.Addr().(*net.TCPAddr).Port})
+
+	var calls []string
+	v := newTestVerifier(projectPath, &config.ProjectMetadata{ProjectName: "verify", Modules: []string{"Model", "Shared", "API"}}, &calls)
+	report, err := v.run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Passed {
+		t.Fatalf("expected the run to pass: %+v", report.Steps)
+	}
+	if report.Steps[0].Status != StatusSkip {
+		t.Errorf("no compose services are required, got %+v", report.Steps[0])
+	}
+}
+
+func TestPlan(t *testing.T) {
+	projectPath := writeProject(t, map[string]int{"Worker": 9091})
+	meta := &config.ProjectMetadata{Modules: []string{"Model", "Jobs", "Worker", "API"}}
+	var calls []string
+	v := newTestVerifier(projectPath, meta, &calls)
+
+	v.opts.With = []string{"Worker", "API", "Worker"}
+	apps, err := v.plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 || apps[0].module != "API" || apps[0].port != 8080 || apps[1].module != "Worker" || apps[1].port != 9091 {
+		t.Errorf("unexpected plan %+v %+v", apps[0], apps[len(apps)-1])
+	}
+
+	for with, want := range map[string]string{"EventConsumer": "no EventConsumer module", "AIAgent": "--with accepts"} {
+		v.opts.With = []string{with}
+		if _, err := v.plan(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("--with %s: expected %q, got %v", with, want, err)
+		}
+	}
+
+	v.meta = &config.ProjectMetadata{Modules: []string{"Model", "Jobs", "Worker"}}
+	v.opts.With = nil
+	if _, err := v.plan(); err == nil || !strings.Contains(err.Error(), "no API module") {
+		t.Errorf("expected a project without API to need --with, got %v", err)
+	}
+}
+
+func TestServerPort(t *testing.T) {
+	projectPath := writeProject(t, map[string]int{"API": 9000})
+	if got := serverPort(projectPath, "API", 8080); got != 9000 {
+		t.Errorf("expected the application.yml default, got %d", got)
+	}
+	t.Setenv("SERVER_PORT", "9100")
+	if got := serverPort(projectPath, "API", 8080); got != 9100 {
+		t.Errorf("expected SERVER_PORT to win, got %d", got)
+	}
+	if got := serverPort(projectPath, "EventConsumer", 8083); got != 8083 {
+		t.Errorf("expected the module default without application.yml, got %d", got)
+	}
+}
@@ -0,0 +1,232 @@
+package versions
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MavenCentralURL is the repository bump-versions checks by default
+const MavenCentralURL = "https://repo1.maven.org/maven2"
+
+// Update is a newer release found for a versions.yaml entry
+type Update struct {
+	Name      string `json:"name"`
+	Current   string `json:"current"`
+	Latest    string `json:"latest"`
+	Changelog string `json:"changelog,omitempty"`
+	Pinned    string `json:"pinned,omitempty"`
+}
+
+// MavenClient reads artifact versions from a Maven repository
+type MavenClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewMavenClient creates a client for the repository at baseURL, or Maven
+// Central when baseURL is empty
+func NewMavenClient(baseURL string) *MavenClient {
+	if baseURL == "" {
+		baseURL = MavenCentralURL
+	}
+	return &MavenClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// mavenMetadata is the part of maven-metadata.xml we read
+type mavenMetadata struct {
+	Versions []string `xml:"versioning>versions>version"`
+}
+
+// Versions returns every published version of an artifact
+func (c *MavenClient) Versions(ctx context.Context, group, artifact string) ([]string, error) {
+	url := fmt.Sprintf("%s/%s/%s/maven-metadata.xml", c.baseURL, strings.ReplaceAll(group, ".", "/"), artifact)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	var meta mavenMetadata
+	if err := xml.Unmarshal(body, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", url, err)
+	}
+	return meta.Versions, nil
+}
+
+// CheckUpdates looks up every maven entry of the catalog and returns the
+// entries with a newer release, in name order. Entries that could not be
+// checked are reported in the error; the other results are still returned.
+func (c *MavenClient) CheckUpdates(ctx context.Context, catalog *Catalog) ([]Update, error) {
+	var updates []Update
+	var errs []error
+	for _, name := range catalog.Names() {
+		a := catalog.Maven[name]
+		published, err := c.Versions(ctx, a.Group, a.Artifact)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if latest := Latest(a.Version, published); latest != "" {
+			updates = append(updates, Update{
+				Name:      name,
+				Current:   a.Version,
+				Latest:    latest,
+				Changelog: a.Changelog,
+				Pinned:    a.Pinned,
+			})
+		}
+	}
+	return updates, errors.Join(errs...)
+}
+
+// Latest returns the newest of published that is newer than current, or ""
+// when current is the newest. Pre-releases (alpha, beta, RC, milestones,
+// snapshots) are only considered when current carries the same qualifier,
+// so a stable version is never proposed a pre-release.
+func Latest(current string, published []string) string {
+	qualifier := qualifierOf(current)
+	latest := current
+	for _, v := range published {
+		if qualifierOf(v) != qualifier {
+			continue
+		}
+		if CompareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	if latest == current {
+		return ""
+	}
+	return latest
+}
+
+// CompareVersions compares two versions by their numeric segments, then by
+// qualifier. It returns -1, 0 or 1.
+func CompareVersions(a, b string) int {
+	an, aq := splitVersion(a)
+	bn, bq := splitVersion(b)
+	for i := 0; i < max(len(an), len(bn)); i++ {
+		var x, y int
+		if i < len(an) {
+			x = an[i]
+		}
+		if i < len(bn) {
+			y = bn[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(aq, bq)
+}
+
+// splitVersion splits "1.29.0-alpha" into [1 29 0] and "alpha"
+func splitVersion(v string) ([]int, string) {
+	end := strings.IndexFunc(v, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	})
+	if end < 0 {
+		end = len(v)
+	}
+	var nums []int
+	for _, part := range strings.Split(strings.Trim(v[:end], "."), ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		nums = append(nums, n)
+	}
+	return nums, strings.TrimLeft(v[end:], "-.")
+}
+
+// qualifierOf returns a version's qualifier kind: "alpha" for both
+// "1.0.0-alpha" and "1.0.0-alpha2", "rc" for "2.0.0-RC1", "" for a release.
+// Qualifiers that mark a release ("Final", "RELEASE", "GA", "jre") count as
+// none.
+func qualifierOf(v string) string {
+	_, q := splitVersion(v)
+	q = strings.ToLower(strings.TrimRight(q, "0123456789.-"))
+	switch q {
+	case "final", "release", "ga", "jre":
+		return ""
+	}
+	return q
+}
+
+// ApplyUpdates rewrites the version lines of the given maven entries in a
+// versions.yaml document, keeping comments, order and quoting
+func ApplyUpdates(content []byte, updates []Update) ([]byte, error) {
+	pending := make(map[string]string, len(updates))
+	for _, u := range updates {
+		pending[u.Name] = u.Latest
+	}
+
+	lines := strings.Split(string(content), "\n")
+	section, entry := "", ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			section = strings.TrimSuffix(trimmed, ":")
+			entry = ""
+		case indent == 2:
+			entry = strings.TrimSuffix(trimmed, ":")
+		case section == "maven" && strings.HasPrefix(trimmed, "version:"):
+			latest, ok := pending[entry]
+			if !ok {
+				continue
+			}
+			value := strings.TrimSpace(strings.TrimPrefix(trimmed, "version:"))
+			// Quote when the file did, or YAML would read the version as a number
+			if _, err := strconv.ParseFloat(latest, 64); err == nil || strings.HasPrefix(value, `"`) {
+				latest = strconv.Quote(latest)
+			}
+			lines[i] = line[:indent] + "version: " + latest
+			delete(pending, entry)
+		}
+	}
+
+	if len(pending) > 0 {
+		var missing []string
+		for name := range pending {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no version line for: %s", strings.Join(missing, ", "))
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
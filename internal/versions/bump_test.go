@@ -0,0 +1,126 @@
+package versions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		current   string
+		published []string
+		want      string
+	}{
+		{"3.4.2", []string{"3.4.1", "3.4.2", "3.4.3", "3.5.0-RC1", "3.5.0-M2"}, "3.4.3"},
+		{"3.4.2", []string{"3.4.0", "3.4.2"}, ""},
+		{"8.0", []string{"7.4", "8.0", "8.1"}, "8.1"},
+		{"0.53", []string{"0.53", "0.9"}, ""},
+		{"1.29.0-alpha", []string{"1.29.0-alpha", "1.30.0-alpha", "1.30.0"}, "1.30.0-alpha"},
+		{"2024.0.0", []string{"2024.0.0", "2024.0.1", "2025.0.0-M1"}, "2024.0.1"},
+		{"6.4.0", []string{"6.4.0", "6.5.0.Final"}, "6.5.0.Final"},
+	}
+	for _, tt := range tests {
+		if got := Latest(tt.current, tt.published); got != tt.want {
+			t.Errorf("Latest(%q, %v) = %q, want %q", tt.current, tt.published, got, tt.want)
+		}
+	}
+}
+
+func TestCheckUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/foo/foo-core/maven-metadata.xml":
+			w.Write([]byte(`<metadata><versioning><versions>
+				<version>1.0.0</version><version>1.2.0</version><version>2.0.0-RC1</version>
+			</versions></versioning></metadata>`))
+		case "/org/bar/bar/maven-metadata.xml":
+			w.Write([]byte(`<metadata><versioning><versions><version>3.0</version></versions></versioning></metadata>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	catalog, err := Parse([]byte(`maven:
+  foo:
+    group: org.foo
+    artifact: foo-core
+    version: 1.0.0
+    changelog: https://example.com/foo
+    pinned: held back for bar
+  bar:
+    group: org.bar
+    artifact: bar
+    version: "3.0"
+  missing:
+    group: org.missing
+    artifact: missing
+    version: 1.0.0
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updates, err := NewMavenClient(server.URL).CheckUpdates(context.Background(), catalog)
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected an error for the missing artifact, got %v", err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 update, got %+v", updates)
+	}
+	u := updates[0]
+	if u.Name != "foo" || u.Latest != "1.2.0" || u.Changelog != "https://example.com/foo" || u.Pinned == "" {
+		t.Errorf("unexpected update: %+v", u)
+	}
+}
+
+func TestApplyUpdates(t *testing.T) {
+	content := `# comment
+maven:
+  foo:
+    group: org.foo
+    artifact: foo
+    version: 1.0.0
+  bar:
+    group: org.bar
+    artifact: bar
+    version: "8.0"
+  baz:
+    group: org.baz
+    artifact: baz
+    version: 1.0.0
+images:
+  foo:
+    repository: foo
+    tag: 1.0.0
+`
+	out, err := ApplyUpdates([]byte(content), []Update{
+		{Name: "foo", Latest: "1.1.0"},
+		{Name: "bar", Latest: "8.1"},
+		{Name: "baz", Latest: "2.0"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(out)
+	for _, want := range []string{"    version: 1.1.0\n", `    version: "8.1"` + "\n", `    version: "2.0"` + "\n", "    tag: 1.0.0\n", "# comment\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+
+	c, err := Parse(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Maven["baz"].Version != "2.0" {
+		t.Errorf("baz version = %q, want 2.0", c.Maven["baz"].Version)
+	}
+
+	if _, err := ApplyUpdates([]byte(content), []Update{{Name: "nope", Latest: "1"}}); err == nil {
+		t.Error("expected an error for an unknown entry")
+	}
+}
@@ -0,0 +1,122 @@
+// Package versions holds the library, plugin and container image versions
+// generated projects use. They live in the embedded versions.yaml so the
+// templates and 'trabuco add' can't drift apart.
+package versions
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed versions.yaml
+var data []byte
+
+// FileName is the data file's path in the source tree, for tooling that
+// edits it
+const FileName = "internal/versions/versions.yaml"
+
+// Artifact is a Maven dependency or plugin version
+type Artifact struct {
+	Group     string `yaml:"group"`
+	Artifact  string `yaml:"artifact"`
+	Version   string `yaml:"version"`
+	Changelog string `yaml:"changelog"`
+	Pinned    string `yaml:"pinned,omitempty"` // Why the version must not be bumped on its own
+}
+
+// Image is a container image
+type Image struct {
+	Repository string `yaml:"repository"`
+	Tag        string `yaml:"tag"`
+}
+
+// Catalog is the parsed versions.yaml
+type Catalog struct {
+	Maven  map[string]Artifact `yaml:"maven"`
+	Images map[string]Image    `yaml:"images"`
+}
+
+var catalog = mustParse(data)
+
+// Parse reads a versions.yaml document
+func Parse(content []byte) (*Catalog, error) {
+	c := &Catalog{}
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("invalid versions file: %w", err)
+	}
+	for name, a := range c.Maven {
+		if a.Group == "" || a.Artifact == "" || a.Version == "" {
+			return nil, fmt.Errorf("maven entry %s needs group, artifact and version", name)
+		}
+	}
+	for name, img := range c.Images {
+		if img.Repository == "" || img.Tag == "" {
+			return nil, fmt.Errorf("image entry %s needs repository and tag", name)
+		}
+	}
+	return c, nil
+}
+
+func mustParse(content []byte) *Catalog {
+	c, err := Parse(content)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Get returns a Maven version by name. It panics on an unknown name: every
+// caller names an entry of the embedded file, which the tests check.
+func Get(name string) string {
+	v, err := Lookup(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Lookup returns a Maven version by name
+func Lookup(name string) (string, error) {
+	a, ok := catalog.Maven[name]
+	if !ok {
+		return "", fmt.Errorf("unknown version %q (not in versions.yaml)", name)
+	}
+	return a.Version, nil
+}
+
+// GetImage returns "repository:tag" for an image by name. Like Get, it
+// panics on an unknown name.
+func GetImage(name string) string {
+	ref, err := LookupImage(name)
+	if err != nil {
+		panic(err)
+	}
+	return ref
+}
+
+// LookupImage returns "repository:tag" for an image by name
+func LookupImage(name string) (string, error) {
+	img, ok := catalog.Images[name]
+	if !ok {
+		return "", fmt.Errorf("unknown image %q (not in versions.yaml)", name)
+	}
+	return img.Repository + ":" + img.Tag, nil
+}
+
+// Embedded returns the catalog built into the binary
+func Embedded() *Catalog {
+	return catalog
+}
+
+// Names returns the Maven entry names, sorted
+func (c *Catalog) Names() []string {
+	names := make([]string, 0, len(c.Maven))
+	for name := range c.Maven {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+package versions
+
+import (
+	"io/fs"
+	"regexp"
+	"strings"
+	"testing"
+
+	embeddedTemplates "github.com/arianlopezc/Trabuco/templates"
+)
+
+func TestEmbeddedCatalog(t *testing.T) {
+	c := Embedded()
+	if len(c.Maven) == 0 || len(c.Images) == 0 {
+		t.Fatalf("embedded catalog is empty: %d maven, %d images", len(c.Maven), len(c.Images))
+	}
+	if got := Get("spring-boot"); got == "" {
+		t.Error("spring-boot version is empty")
+	}
+	if got := GetImage("postgres"); !strings.HasPrefix(got, "postgres:") {
+		t.Errorf("GetImage(postgres) = %q", got)
+	}
+	if _, err := Lookup("no-such-library"); err == nil {
+		t.Error("Lookup of an unknown name should fail")
+	}
+}
+
+func TestParse_RequiresFields(t *testing.T) {
+	_, err := Parse([]byte("maven:\n  foo:\n    group: org.foo\n    artifact: foo\n"))
+	if err == nil || !strings.Contains(err.Error(), "foo") {
+		t.Errorf("expected an error naming the incomplete entry, got %v", err)
+	}
+}
+
+// Every {{version "x"}} and {{image "x"}} a template uses must be in the
+// catalog, or generation fails at runtime
+func TestTemplateReferencesResolve(t *testing.T) {
+	ref := regexp.MustCompile(`\{\{-?\s*(version|image)\s+"([^"]+)"`)
+	found := 0
+	err := fs.WalkDir(embeddedTemplates.FS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(embeddedTemplates.FS, path)
+		if err != nil {
+			return err
+		}
+		for _, m := range ref.FindAllStringSubmatch(string(data), -1) {
+			found++
+			lookup := Lookup
+			if m[1] == "image" {
+				lookup = LookupImage
+			}
+			if _, err := lookup(m[2]); err != nil {
+				t.Errorf("%s: %v", path, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == 0 {
+		t.Error("no version references found in templates")
+	}
+}
@@ -2,5 +2,5 @@ package templates
 
 import "embed"
 
-//go:embed all:pom all:java all:docs all:idea all:docker all:ai all:claude all:cursor all:copilot all:codex all:github all:trabuco all:skills all:maven-wrapper all:dependency-check
+//go:embed all:pom all:java all:docs all:idea all:docker all:ai all:claude all:cursor all:copilot all:codex all:aider all:devcontainer all:taskrunner all:github all:trabuco all:skills all:maven-wrapper all:dependency-check all:observability all:perf all:kotlin all:logging
 var FS embed.FS